@@ -7,8 +7,10 @@
 package api
 
 import (
+	_ "github.com/internet-measurement-network/dbos/api/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,16 +25,18 @@ const (
 
 // Agent represents a measurement agent in the system
 type Agent struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Hostname        string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
-	Alive           bool                   `protobuf:"varint,3,opt,name=alive,proto3" json:"alive,omitempty"`
-	LastSeen        int64                  `protobuf:"varint,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
-	FirstSeen       int64                  `protobuf:"varint,5,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
-	Config          map[string]string      `protobuf:"bytes,6,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	TotalHeartbeats int32                  `protobuf:"varint,7,opt,name=total_heartbeats,json=totalHeartbeats,proto3" json:"total_heartbeats,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hostname             string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Alive                bool                   `protobuf:"varint,3,opt,name=alive,proto3" json:"alive,omitempty"`
+	LastSeen             int64                  `protobuf:"varint,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	FirstSeen            int64                  `protobuf:"varint,5,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	Config               map[string]string      `protobuf:"bytes,6,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TotalHeartbeats      int32                  `protobuf:"varint,7,opt,name=total_heartbeats,json=totalHeartbeats,proto3" json:"total_heartbeats,omitempty"`
+	Labels               map[string]string      `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	MaintenanceWindowIds []string               `protobuf:"bytes,9,rep,name=maintenance_window_ids,json=maintenanceWindowIds,proto3" json:"maintenance_window_ids,omitempty"` // maintenance windows in progress covering the agent
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *Agent) Reset() {
@@ -114,6 +118,20 @@ func (x *Agent) GetTotalHeartbeats() int32 {
 	return 0
 }
 
+func (x *Agent) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Agent) GetMaintenanceWindowIds() []string {
+	if x != nil {
+		return x.MaintenanceWindowIds
+	}
+	return nil
+}
+
 // ModuleState represents the state of a module execution
 type ModuleState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -124,6 +142,8 @@ type ModuleState struct {
 	Details       map[string]string      `protobuf:"bytes,5,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	RequestId     string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Version       int64                  `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`                     // incremented by every write
+	ErrorCode     string                 `protobuf:"bytes,9,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // machine-readable code of error_message, e.g. "deadline_exceeded"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -207,16 +227,36 @@ func (x *ModuleState) GetRequestId() string {
 	return ""
 }
 
+func (x *ModuleState) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ModuleState) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
 // MeasurementResult represents a network measurement result
 type MeasurementResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	ModuleName    string                 `protobuf:"bytes,3,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
-	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // JSON-encoded result data
-	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AgentId          string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ModuleName       string                 `protobuf:"bytes,3,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Data             []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"` // JSON-encoded result data
+	Timestamp        int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Origin           string                 `protobuf:"bytes,6,opt,name=origin,proto3" json:"origin,omitempty"`                                                // "scheduled" or "local" (agent-generated, synthetic task ID)
+	Sequence         int64                  `protobuf:"varint,7,opt,name=sequence,proto3" json:"sequence,omitempty"`                                           // per-agent monotonically increasing, assigned by the server
+	AgentTimestampMs int64                  `protobuf:"varint,8,opt,name=agent_timestamp_ms,json=agentTimestampMs,proto3" json:"agent_timestamp_ms,omitempty"` // measurement time on the agent's clock; the server derives timestamp from it using the agent's clock skew
+	ClockOffsetMs    float64                `protobuf:"fixed64,9,opt,name=clock_offset_ms,json=clockOffsetMs,proto3" json:"clock_offset_ms,omitempty"`         // correction applied to agent_timestamp_ms, assigned by the server
+	CorrelationId    string                 `protobuf:"bytes,10,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`            // experiment the result belongs to; inherited from its task if unset
+	Backfilled       bool                   `protobuf:"varint,11,opt,name=backfilled,proto3" json:"backfilled,omitempty"`                                      // stored by a backfill, assigned by the server
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *MeasurementResult) Reset() {
@@ -284,23 +324,160 @@ func (x *MeasurementResult) GetTimestamp() int64 {
 	return 0
 }
 
-// Task represents a scheduled task
-type Task struct {
+func (x *MeasurementResult) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *MeasurementResult) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *MeasurementResult) GetAgentTimestampMs() int64 {
+	if x != nil {
+		return x.AgentTimestampMs
+	}
+	return 0
+}
+
+func (x *MeasurementResult) GetClockOffsetMs() float64 {
+	if x != nil {
+		return x.ClockOffsetMs
+	}
+	return 0
+}
+
+func (x *MeasurementResult) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *MeasurementResult) GetBackfilled() bool {
+	if x != nil {
+		return x.Backfilled
+	}
+	return false
+}
+
+// ClockSkew is the server's model of an agent's clock offset, fed by NTP measurements
+type ClockSkew struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	ModuleName    string                 `protobuf:"bytes,3,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
-	Payload       []byte                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"` // JSON-encoded task payload
-	ScheduledAt   int64                  `protobuf:"varint,5,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	OffsetMs      float64                `protobuf:"fixed64,2,opt,name=offset_ms,json=offsetMs,proto3" json:"offset_ms,omitempty"` // reference time minus agent time
+	DelayMs       float64                `protobuf:"fixed64,3,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+	Samples       int64                  `protobuf:"varint,4,opt,name=samples,proto3" json:"samples,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *ClockSkew) Reset() {
+	*x = ClockSkew{}
+	mi := &file_api_dbos_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClockSkew) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClockSkew) ProtoMessage() {}
+
+func (x *ClockSkew) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClockSkew.ProtoReflect.Descriptor instead.
+func (*ClockSkew) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ClockSkew) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ClockSkew) GetOffsetMs() float64 {
+	if x != nil {
+		return x.OffsetMs
+	}
+	return 0
+}
+
+func (x *ClockSkew) GetDelayMs() float64 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+func (x *ClockSkew) GetSamples() int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *ClockSkew) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Task represents a scheduled task
+type Task struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AgentId             string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ModuleName          string                 `protobuf:"bytes,3,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Payload             []byte                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"` // JSON-encoded task payload
+	ScheduledAt         int64                  `protobuf:"varint,5,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	CreatedAt           int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Status              string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Type                string                 `protobuf:"bytes,8,opt,name=type,proto3" json:"type,omitempty"`                                                                                                                            // "oneshot" (default) or "continuous"
+	IntervalSeconds     int64                  `protobuf:"varint,9,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`                                                                              // re-issue interval for continuous tasks
+	ParentId            string                 `protobuf:"bytes,10,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`                                                                                                   // continuous task that issued this instance
+	VerificationId      string                 `protobuf:"bytes,11,opt,name=verification_id,json=verificationId,proto3" json:"verification_id,omitempty"`                                                                                 // redundant measurement this task is a replica of
+	CampaignId          string                 `protobuf:"bytes,12,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`                                                                                             // campaign whose round issued this task
+	Selector            map[string]string      `protobuf:"bytes,13,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`                                         // makes a group task, run by every live agent with these labels instead of agent_id
+	AgentIds            []string               `protobuf:"bytes,14,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`                                                                                                   // agents a group task issued instances to
+	Deliveries          int64                  `protobuf:"varint,15,opt,name=deliveries,proto3" json:"deliveries,omitempty"`                                                                                                              // times delivered to its agent, counted by the streams task queue
+	CorrelationId       string                 `protobuf:"bytes,16,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`                                                                                    // experiment the task belongs to, passed on to its instances and results
+	Placement           *Placement             `protobuf:"bytes,17,opt,name=placement,proto3" json:"placement,omitempty"`                                                                                                                 // constrains which agents matching a group task's selector run it
+	LeasedAt            int64                  `protobuf:"varint,18,opt,name=leased_at,json=leasedAt,proto3" json:"leased_at,omitempty"`                                                                                                  // when its agent last leased it
+	DurationMs          float64                `protobuf:"fixed64,19,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`                                                                                           // from its lease until its agent acknowledged it
+	LeaseTimeoutSeconds int64                  `protobuf:"varint,20,opt,name=lease_timeout_seconds,json=leaseTimeoutSeconds,proto3" json:"lease_timeout_seconds,omitempty"`                                                               // how long its agent may hold a lease before it is requeued; 0 for the server's lease timeout
+	LeaseExpiresAt      int64                  `protobuf:"varint,21,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`                                                                              // when its agent's current lease ends unless extended
+	RetryPolicy         *RetryPolicy           `protobuf:"bytes,22,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`                                                                                          // spaces out the retries of the task when handed back or its lease expires
+	RetryCount          int64                  `protobuf:"varint,23,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`                                                                                            // times the task was handed back or its lease expired and it was requeued
+	BroadcastId         string                 `protobuf:"bytes,24,opt,name=broadcast_id,json=broadcastId,proto3" json:"broadcast_id,omitempty"`                                                                                          // broadcast that fanned this task out
+	FailoverSelector    map[string]string      `protobuf:"bytes,25,rep,name=failover_selector,json=failoverSelector,proto3" json:"failover_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // labels an agent must have to take over a continuous task whose agent died
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
 func (x *Task) Reset() {
 	*x = Task{}
-	mi := &file_api_dbos_proto_msgTypes[3]
+	mi := &file_api_dbos_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -312,7 +489,7 @@ func (x *Task) String() string {
 func (*Task) ProtoMessage() {}
 
 func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[3]
+	mi := &file_api_dbos_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -325,7 +502,7 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Task.ProtoReflect.Descriptor instead.
 func (*Task) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{3}
+	return file_api_dbos_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Task) GetId() string {
@@ -377,125 +554,161 @@ func (x *Task) GetStatus() string {
 	return ""
 }
 
-// Agent Management Requests
-type RegisterAgentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Agent         *Agent                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Task) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-func (x *RegisterAgentRequest) Reset() {
-	*x = RegisterAgentRequest{}
-	mi := &file_api_dbos_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Task) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
 }
 
-func (x *RegisterAgentRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Task) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
 }
 
-func (*RegisterAgentRequest) ProtoMessage() {}
+func (x *Task) GetVerificationId() string {
+	if x != nil {
+		return x.VerificationId
+	}
+	return ""
+}
 
-func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[4]
+func (x *Task) GetCampaignId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.CampaignId
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
-func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{4}
+func (x *Task) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
 }
 
-func (x *RegisterAgentRequest) GetAgent() *Agent {
+func (x *Task) GetAgentIds() []string {
 	if x != nil {
-		return x.Agent
+		return x.AgentIds
 	}
 	return nil
 }
 
-type RegisterAgentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Task) GetDeliveries() int64 {
+	if x != nil {
+		return x.Deliveries
+	}
+	return 0
 }
 
-func (x *RegisterAgentResponse) Reset() {
-	*x = RegisterAgentResponse{}
-	mi := &file_api_dbos_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Task) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
 }
 
-func (x *RegisterAgentResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Task) GetPlacement() *Placement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
 }
 
-func (*RegisterAgentResponse) ProtoMessage() {}
+func (x *Task) GetLeasedAt() int64 {
+	if x != nil {
+		return x.LeasedAt
+	}
+	return 0
+}
 
-func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[5]
+func (x *Task) GetDurationMs() float64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.DurationMs
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
-func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{5}
+func (x *Task) GetLeaseTimeoutSeconds() int64 {
+	if x != nil {
+		return x.LeaseTimeoutSeconds
+	}
+	return 0
 }
 
-func (x *RegisterAgentResponse) GetSuccess() bool {
+func (x *Task) GetLeaseExpiresAt() int64 {
 	if x != nil {
-		return x.Success
+		return x.LeaseExpiresAt
 	}
-	return false
+	return 0
 }
 
-func (x *RegisterAgentResponse) GetError() string {
+func (x *Task) GetRetryPolicy() *RetryPolicy {
 	if x != nil {
-		return x.Error
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *Task) GetRetryCount() int64 {
+	if x != nil {
+		return x.RetryCount
+	}
+	return 0
+}
+
+func (x *Task) GetBroadcastId() string {
+	if x != nil {
+		return x.BroadcastId
 	}
 	return ""
 }
 
-type GetAgentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Task) GetFailoverSelector() map[string]string {
+	if x != nil {
+		return x.FailoverSelector
+	}
+	return nil
 }
 
-func (x *GetAgentRequest) Reset() {
-	*x = GetAgentRequest{}
-	mi := &file_api_dbos_proto_msgTypes[6]
+// RetryPolicy backs off the retries of a task exponentially: the first
+// waits initial_delay_seconds, each further one multiplier times as long as
+// the last, up to max_delay_seconds, less a random part of up to jitter of
+// the delay
+type RetryPolicy struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	InitialDelaySeconds int64                  `protobuf:"varint,1,opt,name=initial_delay_seconds,json=initialDelaySeconds,proto3" json:"initial_delay_seconds,omitempty"`
+	Multiplier          float64                `protobuf:"fixed64,2,opt,name=multiplier,proto3" json:"multiplier,omitempty"`                                   // at least 1; 0 for 2
+	MaxDelaySeconds     int64                  `protobuf:"varint,3,opt,name=max_delay_seconds,json=maxDelaySeconds,proto3" json:"max_delay_seconds,omitempty"` // 0 for a day
+	Jitter              float64                `protobuf:"fixed64,4,opt,name=jitter,proto3" json:"jitter,omitempty"`                                           // fraction of each delay drawn off at random; 1 for full jitter
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_api_dbos_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAgentRequest) String() string {
+func (x *RetryPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAgentRequest) ProtoMessage() {}
+func (*RetryPolicy) ProtoMessage() {}
 
-func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[6]
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,42 +719,68 @@ func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAgentRequest.ProtoReflect.Descriptor instead.
-func (*GetAgentRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetAgentRequest) GetAgentId() string {
+func (x *RetryPolicy) GetInitialDelaySeconds() int64 {
 	if x != nil {
-		return x.AgentId
+		return x.InitialDelaySeconds
 	}
-	return ""
+	return 0
 }
 
-type GetAgentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
-	Agent         *Agent                 `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RetryPolicy) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
 }
 
-func (x *GetAgentResponse) Reset() {
-	*x = GetAgentResponse{}
-	mi := &file_api_dbos_proto_msgTypes[7]
+func (x *RetryPolicy) GetMaxDelaySeconds() int64 {
+	if x != nil {
+		return x.MaxDelaySeconds
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetJitter() float64 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
+// Placement constrains which of the live agents matching a group task's
+// selector are issued instances
+type Placement struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	MaxAgents           int32                  `protobuf:"varint,1,opt,name=max_agents,json=maxAgents,proto3" json:"max_agents,omitempty"`                                   // most agents issued instances; 0 issues to all
+	AvoidTargetPrefixV4 int32                  `protobuf:"varint,2,opt,name=avoid_target_prefix_v4,json=avoidTargetPrefixV4,proto3" json:"avoid_target_prefix_v4,omitempty"` // skip agents whose "ip" label shares this IPv4 prefix length with the target, e.g. 24
+	AvoidTargetPrefixV6 int32                  `protobuf:"varint,3,opt,name=avoid_target_prefix_v6,json=avoidTargetPrefixV6,proto3" json:"avoid_target_prefix_v6,omitempty"` // skip agents whose "ip" label shares this IPv6 prefix length with the target, e.g. 48
+	SpreadLabel         string                 `protobuf:"bytes,4,opt,name=spread_label,json=spreadLabel,proto3" json:"spread_label,omitempty"`                              // label whose distinct values the agents should cover, e.g. "asn"
+	MinSpread           int32                  `protobuf:"varint,5,opt,name=min_spread,json=minSpread,proto3" json:"min_spread,omitempty"`                                   // fewest distinct spread_label values the agents must cover
+	PreferPrevious      bool                   `protobuf:"varint,6,opt,name=prefer_previous,json=preferPrevious,proto3" json:"prefer_previous,omitempty"`                    // pick agents that measured the target with the module before first
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Placement) Reset() {
+	*x = Placement{}
+	mi := &file_api_dbos_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAgentResponse) String() string {
+func (x *Placement) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAgentResponse) ProtoMessage() {}
+func (*Placement) ProtoMessage() {}
 
-func (x *GetAgentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[7]
+func (x *Placement) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -552,53 +791,76 @@ func (x *GetAgentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAgentResponse.ProtoReflect.Descriptor instead.
-func (*GetAgentResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Placement.ProtoReflect.Descriptor instead.
+func (*Placement) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetAgentResponse) GetFound() bool {
+func (x *Placement) GetMaxAgents() int32 {
 	if x != nil {
-		return x.Found
+		return x.MaxAgents
 	}
-	return false
+	return 0
 }
 
-func (x *GetAgentResponse) GetAgent() *Agent {
+func (x *Placement) GetAvoidTargetPrefixV4() int32 {
 	if x != nil {
-		return x.Agent
+		return x.AvoidTargetPrefixV4
 	}
-	return nil
+	return 0
 }
 
-func (x *GetAgentResponse) GetError() string {
+func (x *Placement) GetAvoidTargetPrefixV6() int32 {
 	if x != nil {
-		return x.Error
+		return x.AvoidTargetPrefixV6
+	}
+	return 0
+}
+
+func (x *Placement) GetSpreadLabel() string {
+	if x != nil {
+		return x.SpreadLabel
 	}
 	return ""
 }
 
-type ListAgentsRequest struct {
+func (x *Placement) GetMinSpread() int32 {
+	if x != nil {
+		return x.MinSpread
+	}
+	return 0
+}
+
+func (x *Placement) GetPreferPrevious() bool {
+	if x != nil {
+		return x.PreferPrevious
+	}
+	return false
+}
+
+// Agent Management Requests
+type RegisterAgentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         *Agent                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAgentsRequest) Reset() {
-	*x = ListAgentsRequest{}
-	mi := &file_api_dbos_proto_msgTypes[8]
+func (x *RegisterAgentRequest) Reset() {
+	*x = RegisterAgentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAgentsRequest) String() string {
+func (x *RegisterAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAgentsRequest) ProtoMessage() {}
+func (*RegisterAgentRequest) ProtoMessage() {}
 
-func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[8]
+func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,34 +871,42 @@ func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
-func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
+func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{7}
 }
 
-type ListAgentsResponse struct {
+func (x *RegisterAgentRequest) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+type RegisterAgentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Agents        []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAgentsResponse) Reset() {
-	*x = ListAgentsResponse{}
-	mi := &file_api_dbos_proto_msgTypes[9]
+func (x *RegisterAgentResponse) Reset() {
+	*x = RegisterAgentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAgentsResponse) String() string {
+func (x *RegisterAgentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAgentsResponse) ProtoMessage() {}
+func (*RegisterAgentResponse) ProtoMessage() {}
 
-func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[9]
+func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,48 +917,58 @@ func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
-func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
+func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListAgentsResponse) GetAgents() []*Agent {
+func (x *RegisterAgentResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Agents
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *ListAgentsResponse) GetError() string {
+func (x *RegisterAgentResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-// Module State Requests
-type SetModuleStateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	State         *ModuleState           `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+func (x *RegisterAgentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type HeartbeatRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	AgentId  string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Hostname string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// lease up to this many of the agent's due tasks, as LeaseTask does, and
+	// return them with the heartbeat; at most 100, 0 leases none
+	MaxTasks      int32 `protobuf:"varint,3,opt,name=max_tasks,json=maxTasks,proto3" json:"max_tasks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetModuleStateRequest) Reset() {
-	*x = SetModuleStateRequest{}
-	mi := &file_api_dbos_proto_msgTypes[10]
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_api_dbos_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetModuleStateRequest) String() string {
+func (x *HeartbeatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetModuleStateRequest) ProtoMessage() {}
+func (*HeartbeatRequest) ProtoMessage() {}
 
-func (x *SetModuleStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[10]
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,41 +979,59 @@ func (x *SetModuleStateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetModuleStateRequest.ProtoReflect.Descriptor instead.
-func (*SetModuleStateRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *SetModuleStateRequest) GetState() *ModuleState {
+func (x *HeartbeatRequest) GetAgentId() string {
 	if x != nil {
-		return x.State
+		return x.AgentId
 	}
-	return nil
+	return ""
 }
 
-type SetModuleStateResponse struct {
+func (x *HeartbeatRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetMaxTasks() int32 {
+	if x != nil {
+		return x.MaxTasks
+	}
+	return 0
+}
+
+type HeartbeatResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Agent         *Agent                 `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Tasks         []*Task                `protobuf:"bytes,5,rep,name=tasks,proto3" json:"tasks,omitempty"` // tasks leased for max_tasks, marked running
+	Backoff       *Backoff               `protobuf:"bytes,6,opt,name=backoff,proto3" json:"backoff,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SetModuleStateResponse) Reset() {
-	*x = SetModuleStateResponse{}
-	mi := &file_api_dbos_proto_msgTypes[11]
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_api_dbos_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SetModuleStateResponse) String() string {
+func (x *HeartbeatResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetModuleStateResponse) ProtoMessage() {}
+func (*HeartbeatResponse) ProtoMessage() {}
 
-func (x *SetModuleStateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[11]
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -744,47 +1042,76 @@ func (x *SetModuleStateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetModuleStateResponse.ProtoReflect.Descriptor instead.
-func (*SetModuleStateResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *SetModuleStateResponse) GetSuccess() bool {
+func (x *HeartbeatResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *SetModuleStateResponse) GetError() string {
+func (x *HeartbeatResponse) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *HeartbeatResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type GetModuleStateRequest struct {
+func (x *HeartbeatResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *HeartbeatResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *HeartbeatResponse) GetBackoff() *Backoff {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+type GetAgentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"` // Agent fields to return, e.g. "id,labels"; empty returns all
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetModuleStateRequest) Reset() {
-	*x = GetModuleStateRequest{}
-	mi := &file_api_dbos_proto_msgTypes[12]
+func (x *GetAgentRequest) Reset() {
+	*x = GetAgentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetModuleStateRequest) String() string {
+func (x *GetAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetModuleStateRequest) ProtoMessage() {}
+func (*GetAgentRequest) ProtoMessage() {}
 
-func (x *GetModuleStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[12]
+func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -795,42 +1122,50 @@ func (x *GetModuleStateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetModuleStateRequest.ProtoReflect.Descriptor instead.
-func (*GetModuleStateRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetAgentRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetModuleStateRequest) GetRequestId() string {
+func (x *GetAgentRequest) GetAgentId() string {
 	if x != nil {
-		return x.RequestId
+		return x.AgentId
 	}
 	return ""
 }
 
-type GetModuleStateResponse struct {
+func (x *GetAgentRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type GetAgentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
-	State         *ModuleState           `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Agent         *Agent                 `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
 	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetModuleStateResponse) Reset() {
-	*x = GetModuleStateResponse{}
-	mi := &file_api_dbos_proto_msgTypes[13]
+func (x *GetAgentResponse) Reset() {
+	*x = GetAgentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetModuleStateResponse) String() string {
+func (x *GetAgentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetModuleStateResponse) ProtoMessage() {}
+func (*GetAgentResponse) ProtoMessage() {}
 
-func (x *GetModuleStateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[13]
+func (x *GetAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -841,55 +1176,66 @@ func (x *GetModuleStateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetModuleStateResponse.ProtoReflect.Descriptor instead.
-func (*GetModuleStateResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetAgentResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetModuleStateResponse) GetFound() bool {
+func (x *GetAgentResponse) GetFound() bool {
 	if x != nil {
 		return x.Found
 	}
 	return false
 }
 
-func (x *GetModuleStateResponse) GetState() *ModuleState {
+func (x *GetAgentResponse) GetAgent() *Agent {
 	if x != nil {
-		return x.State
+		return x.Agent
 	}
 	return nil
 }
 
-func (x *GetModuleStateResponse) GetError() string {
+func (x *GetAgentResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type ListModuleStatesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	ModuleName    string                 `protobuf:"bytes,2,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+func (x *GetAgentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListAgentsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Cursor   string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`                      // next_cursor of the previous page; empty for the first page
+	PageSize int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // approximate number of agents per page; 0 returns all agents
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`  // Agent fields to return; empty returns all
+	// "id", "hostname" or "last_seen", optionally followed by " desc"; ordered
+	// pages hold exactly page_size agents. Empty lists agents unordered.
+	OrderBy       string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListModuleStatesRequest) Reset() {
-	*x = ListModuleStatesRequest{}
-	mi := &file_api_dbos_proto_msgTypes[14]
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListModuleStatesRequest) String() string {
+func (x *ListAgentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListModuleStatesRequest) ProtoMessage() {}
+func (*ListAgentsRequest) ProtoMessage() {}
 
-func (x *ListModuleStatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[14]
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -900,48 +1246,64 @@ func (x *ListModuleStatesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListModuleStatesRequest.ProtoReflect.Descriptor instead.
-func (*ListModuleStatesRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ListModuleStatesRequest) GetAgentId() string {
+func (x *ListAgentsRequest) GetCursor() string {
 	if x != nil {
-		return x.AgentId
+		return x.Cursor
 	}
 	return ""
 }
 
-func (x *ListModuleStatesRequest) GetModuleName() string {
+func (x *ListAgentsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.ModuleName
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAgentsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListAgentsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
 	}
 	return ""
 }
 
-type ListModuleStatesResponse struct {
+type ListAgentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	States        []*ModuleState         `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	Agents        []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty after the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListModuleStatesResponse) Reset() {
-	*x = ListModuleStatesResponse{}
-	mi := &file_api_dbos_proto_msgTypes[15]
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListModuleStatesResponse) String() string {
+func (x *ListAgentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListModuleStatesResponse) ProtoMessage() {}
+func (*ListAgentsResponse) ProtoMessage() {}
 
-func (x *ListModuleStatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[15]
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -952,48 +1314,61 @@ func (x *ListModuleStatesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListModuleStatesResponse.ProtoReflect.Descriptor instead.
-func (*ListModuleStatesResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListModuleStatesResponse) GetStates() []*ModuleState {
+func (x *ListAgentsResponse) GetAgents() []*Agent {
 	if x != nil {
-		return x.States
+		return x.Agents
 	}
 	return nil
 }
 
-func (x *ListModuleStatesResponse) GetError() string {
+func (x *ListAgentsResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-// Measurement Result Requests
-type StoreResultRequest struct {
+func (x *ListAgentsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ListAgentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type DeleteAgentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Result        *MeasurementResult     `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoreResultRequest) Reset() {
-	*x = StoreResultRequest{}
-	mi := &file_api_dbos_proto_msgTypes[16]
+func (x *DeleteAgentRequest) Reset() {
+	*x = DeleteAgentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoreResultRequest) String() string {
+func (x *DeleteAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoreResultRequest) ProtoMessage() {}
+func (*DeleteAgentRequest) ProtoMessage() {}
 
-func (x *StoreResultRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[16]
+func (x *DeleteAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1004,41 +1379,42 @@ func (x *StoreResultRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoreResultRequest.ProtoReflect.Descriptor instead.
-func (*StoreResultRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use DeleteAgentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAgentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *StoreResultRequest) GetResult() *MeasurementResult {
+func (x *DeleteAgentRequest) GetAgentId() string {
 	if x != nil {
-		return x.Result
+		return x.AgentId
 	}
-	return nil
+	return ""
 }
 
-type StoreResultResponse struct {
+type DeleteAgentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StoreResultResponse) Reset() {
-	*x = StoreResultResponse{}
-	mi := &file_api_dbos_proto_msgTypes[17]
+func (x *DeleteAgentResponse) Reset() {
+	*x = DeleteAgentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StoreResultResponse) String() string {
+func (x *DeleteAgentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoreResultResponse) ProtoMessage() {}
+func (*DeleteAgentResponse) ProtoMessage() {}
 
-func (x *StoreResultResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[17]
+func (x *DeleteAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1049,48 +1425,54 @@ func (x *StoreResultResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoreResultResponse.ProtoReflect.Descriptor instead.
-func (*StoreResultResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use DeleteAgentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAgentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *StoreResultResponse) GetSuccess() bool {
+func (x *DeleteAgentResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *StoreResultResponse) GetError() string {
+func (x *DeleteAgentResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type GetResultRequest struct {
+func (x *DeleteAgentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type WatchAgentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Revision      string                 `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"` // resume after this revision; empty starts with a full snapshot
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetResultRequest) Reset() {
-	*x = GetResultRequest{}
-	mi := &file_api_dbos_proto_msgTypes[18]
+func (x *WatchAgentsRequest) Reset() {
+	*x = WatchAgentsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetResultRequest) String() string {
+func (x *WatchAgentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResultRequest) ProtoMessage() {}
+func (*WatchAgentsRequest) ProtoMessage() {}
 
-func (x *GetResultRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[18]
+func (x *WatchAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1101,49 +1483,43 @@ func (x *GetResultRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResultRequest.ProtoReflect.Descriptor instead.
-func (*GetResultRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *GetResultRequest) GetAgentId() string {
-	if x != nil {
-		return x.AgentId
-	}
-	return ""
+// Deprecated: Use WatchAgentsRequest.ProtoReflect.Descriptor instead.
+func (*WatchAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetResultRequest) GetRequestId() string {
+func (x *WatchAgentsRequest) GetRevision() string {
 	if x != nil {
-		return x.RequestId
+		return x.Revision
 	}
 	return ""
 }
 
-type GetResultResponse struct {
+// AgentDelta is one change to the agent inventory
+type AgentDelta struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
-	Result        *MeasurementResult     `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`         // "snapshot", "snapshot_end", "add", "update" or "remove"
+	Agent         *Agent                 `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`       // only id is set for "remove"; unset for "snapshot_end"
+	Revision      string                 `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"` // resumable revision token
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetResultResponse) Reset() {
-	*x = GetResultResponse{}
-	mi := &file_api_dbos_proto_msgTypes[19]
+func (x *AgentDelta) Reset() {
+	*x = AgentDelta{}
+	mi := &file_api_dbos_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetResultResponse) String() string {
+func (x *AgentDelta) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResultResponse) ProtoMessage() {}
+func (*AgentDelta) ProtoMessage() {}
 
-func (x *GetResultResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[19]
+func (x *AgentDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1154,54 +1530,55 @@ func (x *GetResultResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResultResponse.ProtoReflect.Descriptor instead.
-func (*GetResultResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use AgentDelta.ProtoReflect.Descriptor instead.
+func (*AgentDelta) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetResultResponse) GetFound() bool {
+func (x *AgentDelta) GetType() string {
 	if x != nil {
-		return x.Found
+		return x.Type
 	}
-	return false
+	return ""
 }
 
-func (x *GetResultResponse) GetResult() *MeasurementResult {
+func (x *AgentDelta) GetAgent() *Agent {
 	if x != nil {
-		return x.Result
+		return x.Agent
 	}
 	return nil
 }
 
-func (x *GetResultResponse) GetError() string {
+func (x *AgentDelta) GetRevision() string {
 	if x != nil {
-		return x.Error
+		return x.Revision
 	}
 	return ""
 }
 
-type ListResultsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type GetAgentGeoJSONRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Selector         map[string]string      `protobuf:"bytes,1,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // only agents with all these labels; empty for all
+	IncludeIncidents bool                   `protobuf:"varint,2,opt,name=include_incidents,json=includeIncidents,proto3" json:"include_incidents,omitempty"`                                  // add the active incidents as features at their agents
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *ListResultsRequest) Reset() {
-	*x = ListResultsRequest{}
-	mi := &file_api_dbos_proto_msgTypes[20]
+func (x *GetAgentGeoJSONRequest) Reset() {
+	*x = GetAgentGeoJSONRequest{}
+	mi := &file_api_dbos_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListResultsRequest) String() string {
+func (x *GetAgentGeoJSONRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListResultsRequest) ProtoMessage() {}
+func (*GetAgentGeoJSONRequest) ProtoMessage() {}
 
-func (x *ListResultsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[20]
+func (x *GetAgentGeoJSONRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1212,41 +1589,50 @@ func (x *ListResultsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListResultsRequest.ProtoReflect.Descriptor instead.
-func (*ListResultsRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetAgentGeoJSONRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentGeoJSONRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ListResultsRequest) GetAgentId() string {
+func (x *GetAgentGeoJSONRequest) GetSelector() map[string]string {
 	if x != nil {
-		return x.AgentId
+		return x.Selector
 	}
-	return ""
+	return nil
 }
 
-type ListResultsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Results       []*MeasurementResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetAgentGeoJSONRequest) GetIncludeIncidents() bool {
+	if x != nil {
+		return x.IncludeIncidents
+	}
+	return false
 }
 
-func (x *ListResultsResponse) Reset() {
-	*x = ListResultsResponse{}
-	mi := &file_api_dbos_proto_msgTypes[21]
+type GetAgentGeoJSONResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Geojson         []byte                 `protobuf:"bytes,1,opt,name=geojson,proto3" json:"geojson,omitempty"`                                         // RFC 7946 FeatureCollection of the located agents
+	UnlocatedAgents int32                  `protobuf:"varint,2,opt,name=unlocated_agents,json=unlocatedAgents,proto3" json:"unlocated_agents,omitempty"` // matching agents left out for lacking valid lat and lon labels
+	Error           string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode       string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetAgentGeoJSONResponse) Reset() {
+	*x = GetAgentGeoJSONResponse{}
+	mi := &file_api_dbos_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListResultsResponse) String() string {
+func (x *GetAgentGeoJSONResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListResultsResponse) ProtoMessage() {}
+func (*GetAgentGeoJSONResponse) ProtoMessage() {}
 
-func (x *ListResultsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[21]
+func (x *GetAgentGeoJSONResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1257,48 +1643,64 @@ func (x *ListResultsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListResultsResponse.ProtoReflect.Descriptor instead.
-func (*ListResultsResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetAgentGeoJSONResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentGeoJSONResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListResultsResponse) GetResults() []*MeasurementResult {
+func (x *GetAgentGeoJSONResponse) GetGeojson() []byte {
 	if x != nil {
-		return x.Results
+		return x.Geojson
 	}
 	return nil
 }
 
-func (x *ListResultsResponse) GetError() string {
+func (x *GetAgentGeoJSONResponse) GetUnlocatedAgents() int32 {
+	if x != nil {
+		return x.UnlocatedAgents
+	}
+	return 0
+}
+
+func (x *GetAgentGeoJSONResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-// Task Scheduling Requests
-type ScheduleTaskRequest struct {
+func (x *GetAgentGeoJSONResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Provisioning Requests
+type CreateBootstrapTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // applied to every agent enrolled with the token
+	Config        map[string]string      `protobuf:"bytes,2,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // initial config handed to the enrolled agent
+	TtlSeconds    int64                  `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`                                                // defaults to 24 hours
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ScheduleTaskRequest) Reset() {
-	*x = ScheduleTaskRequest{}
-	mi := &file_api_dbos_proto_msgTypes[22]
+func (x *CreateBootstrapTokenRequest) Reset() {
+	*x = CreateBootstrapTokenRequest{}
+	mi := &file_api_dbos_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScheduleTaskRequest) String() string {
+func (x *CreateBootstrapTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScheduleTaskRequest) ProtoMessage() {}
+func (*CreateBootstrapTokenRequest) ProtoMessage() {}
 
-func (x *ScheduleTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[22]
+func (x *CreateBootstrapTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1309,41 +1711,57 @@ func (x *ScheduleTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScheduleTaskRequest.ProtoReflect.Descriptor instead.
-func (*ScheduleTaskRequest) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use CreateBootstrapTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateBootstrapTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ScheduleTaskRequest) GetTask() *Task {
+func (x *CreateBootstrapTokenRequest) GetLabels() map[string]string {
 	if x != nil {
-		return x.Task
+		return x.Labels
 	}
 	return nil
 }
 
-type ScheduleTaskResponse struct {
+func (x *CreateBootstrapTokenRequest) GetConfig() map[string]string {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *CreateBootstrapTokenRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type CreateBootstrapTokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // shown once; only its hash is stored
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ScheduleTaskResponse) Reset() {
-	*x = ScheduleTaskResponse{}
-	mi := &file_api_dbos_proto_msgTypes[23]
+func (x *CreateBootstrapTokenResponse) Reset() {
+	*x = CreateBootstrapTokenResponse{}
+	mi := &file_api_dbos_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScheduleTaskResponse) String() string {
+func (x *CreateBootstrapTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScheduleTaskResponse) ProtoMessage() {}
+func (*CreateBootstrapTokenResponse) ProtoMessage() {}
 
-func (x *ScheduleTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_dbos_proto_msgTypes[23]
+func (x *CreateBootstrapTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1354,46 +1772,106 @@ func (x *ScheduleTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScheduleTaskResponse.ProtoReflect.Descriptor instead.
-func (*ScheduleTaskResponse) Descriptor() ([]byte, []int) {
-	return file_api_dbos_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use CreateBootstrapTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateBootstrapTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ScheduleTaskResponse) GetSuccess() bool {
+func (x *CreateBootstrapTokenResponse) GetToken() string {
 	if x != nil {
-		return x.Success
+		return x.Token
 	}
-	return false
+	return ""
 }
 
-func (x *ScheduleTaskResponse) GetError() string {
+func (x *CreateBootstrapTokenResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *CreateBootstrapTokenResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-type GetTaskRequest struct {
+func (x *CreateBootstrapTokenResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type CreateAgentTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskRequest) Reset() {
-	*x = GetTaskRequest{}
+func (x *CreateAgentTokenRequest) Reset() {
+	*x = CreateAgentTokenRequest{}
+	mi := &file_api_dbos_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAgentTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAgentTokenRequest) ProtoMessage() {}
+
+func (x *CreateAgentTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAgentTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateAgentTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateAgentTokenRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type CreateAgentTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // shown once; only its hash is stored
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAgentTokenResponse) Reset() {
+	*x = CreateAgentTokenResponse{}
 	mi := &file_api_dbos_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskRequest) String() string {
+func (x *CreateAgentTokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskRequest) ProtoMessage() {}
+func (*CreateAgentTokenResponse) ProtoMessage() {}
 
-func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateAgentTokenResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_dbos_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1405,41 +1883,54 @@ func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
-func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateAgentTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateAgentTokenResponse) Descriptor() ([]byte, []int) {
 	return file_api_dbos_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *GetTaskRequest) GetTaskId() string {
+func (x *CreateAgentTokenResponse) GetToken() string {
 	if x != nil {
-		return x.TaskId
+		return x.Token
 	}
 	return ""
 }
 
-type GetTaskResponse struct {
+func (x *CreateAgentTokenResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateAgentTokenResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type CreateAPITokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
-	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // "operator" or "read_only"; agent tokens are issued by CreateAgentToken
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // who or what the token is for, shown in logs and errors
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTaskResponse) Reset() {
-	*x = GetTaskResponse{}
+func (x *CreateAPITokenRequest) Reset() {
+	*x = CreateAPITokenRequest{}
 	mi := &file_api_dbos_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTaskResponse) String() string {
+func (x *CreateAPITokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTaskResponse) ProtoMessage() {}
+func (*CreateAPITokenRequest) ProtoMessage() {}
 
-func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateAPITokenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_dbos_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1451,53 +1942,48 @@ func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
-func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateAPITokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPITokenRequest) Descriptor() ([]byte, []int) {
 	return file_api_dbos_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *GetTaskResponse) GetFound() bool {
-	if x != nil {
-		return x.Found
-	}
-	return false
-}
-
-func (x *GetTaskResponse) GetTask() *Task {
+func (x *CreateAPITokenRequest) GetRole() string {
 	if x != nil {
-		return x.Task
+		return x.Role
 	}
-	return nil
+	return ""
 }
 
-func (x *GetTaskResponse) GetError() string {
+func (x *CreateAPITokenRequest) GetName() string {
 	if x != nil {
-		return x.Error
+		return x.Name
 	}
 	return ""
 }
 
-type ListDueTasksRequest struct {
+type CreateAPITokenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // shown once; only its hash is stored
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDueTasksRequest) Reset() {
-	*x = ListDueTasksRequest{}
+func (x *CreateAPITokenResponse) Reset() {
+	*x = CreateAPITokenResponse{}
 	mi := &file_api_dbos_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDueTasksRequest) String() string {
+func (x *CreateAPITokenResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDueTasksRequest) ProtoMessage() {}
+func (*CreateAPITokenResponse) ProtoMessage() {}
 
-func (x *ListDueTasksRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateAPITokenResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_dbos_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1509,40 +1995,55 @@ func (x *ListDueTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDueTasksRequest.ProtoReflect.Descriptor instead.
-func (*ListDueTasksRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateAPITokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPITokenResponse) Descriptor() ([]byte, []int) {
 	return file_api_dbos_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *ListDueTasksRequest) GetTimestamp() int64 {
+func (x *CreateAPITokenResponse) GetToken() string {
 	if x != nil {
-		return x.Timestamp
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
-type ListDueTasksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreateAPITokenResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
 }
 
-func (x *ListDueTasksResponse) Reset() {
-	*x = ListDueTasksResponse{}
+func (x *CreateAPITokenResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type EnrollAgentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BootstrapToken string                 `protobuf:"bytes,1,opt,name=bootstrap_token,json=bootstrapToken,proto3" json:"bootstrap_token,omitempty"`
+	AgentId        string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional; minted by the server when empty
+	Hostname       string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EnrollAgentRequest) Reset() {
+	*x = EnrollAgentRequest{}
 	mi := &file_api_dbos_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDueTasksResponse) String() string {
+func (x *EnrollAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDueTasksResponse) ProtoMessage() {}
+func (*EnrollAgentRequest) ProtoMessage() {}
 
-func (x *ListDueTasksResponse) ProtoReflect() protoreflect.Message {
+func (x *EnrollAgentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_dbos_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1554,157 +2055,16985 @@ func (x *ListDueTasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDueTasksResponse.ProtoReflect.Descriptor instead.
-func (*ListDueTasksResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EnrollAgentRequest.ProtoReflect.Descriptor instead.
+func (*EnrollAgentRequest) Descriptor() ([]byte, []int) {
 	return file_api_dbos_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *ListDueTasksResponse) GetTasks() []*Task {
+func (x *EnrollAgentRequest) GetBootstrapToken() string {
 	if x != nil {
-		return x.Tasks
+		return x.BootstrapToken
+	}
+	return ""
+}
+
+func (x *EnrollAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *EnrollAgentRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type EnrollAgentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Agent         *Agent                 `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
+	AgentToken    string                 `protobuf:"bytes,3,opt,name=agent_token,json=agentToken,proto3" json:"agent_token,omitempty"` // permanent credential for the enrolled agent
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollAgentResponse) Reset() {
+	*x = EnrollAgentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollAgentResponse) ProtoMessage() {}
+
+func (x *EnrollAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollAgentResponse.ProtoReflect.Descriptor instead.
+func (*EnrollAgentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EnrollAgentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EnrollAgentResponse) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
 	}
 	return nil
 }
 
-func (x *ListDueTasksResponse) GetError() string {
+func (x *EnrollAgentResponse) GetAgentToken() string {
+	if x != nil {
+		return x.AgentToken
+	}
+	return ""
+}
+
+func (x *EnrollAgentResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-var File_api_dbos_proto protoreflect.FileDescriptor
+func (x *EnrollAgentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
 
-const file_api_dbos_proto_rawDesc = "" +
-	"\n" +
-	"\x0eapi/dbos.proto\x12\x04dbos\"\x9c\x02\n" +
-	"\x05Agent\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
-	"\bhostname\x18\x02 \x01(\tR\bhostname\x12\x14\n" +
-	"\x05alive\x18\x03 \x01(\bR\x05alive\x12\x1b\n" +
-	"\tlast_seen\x18\x04 \x01(\x03R\blastSeen\x12\x1d\n" +
-	"\n" +
-	"first_seen\x18\x05 \x01(\x03R\tfirstSeen\x12/\n" +
-	"\x06config\x18\x06 \x03(\v2\x17.dbos.Agent.ConfigEntryR\x06config\x12)\n" +
-	"\x10total_heartbeats\x18\a \x01(\x05R\x0ftotalHeartbeats\x1a9\n" +
-	"\vConfigEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb7\x02\n" +
-	"\vModuleState\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1f\n" +
-	"\vmodule_name\x18\x02 \x01(\tR\n" +
-	"moduleName\x12\x14\n" +
-	"\x05state\x18\x03 \x01(\tR\x05state\x12#\n" +
-	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x128\n" +
-	"\adetails\x18\x05 \x03(\v2\x1e.dbos.ModuleState.DetailsEntryR\adetails\x12\x1c\n" +
-	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\x12\x1d\n" +
-	"\n" +
-	"request_id\x18\a \x01(\tR\trequestId\x1a:\n" +
-	"\fDetailsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x91\x01\n" +
-	"\x11MeasurementResult\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1f\n" +
-	"\vmodule_name\x18\x03 \x01(\tR\n" +
-	"moduleName\x12\x12\n" +
-	"\x04data\x18\x04 \x01(\fR\x04data\x12\x1c\n" +
-	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"\xc6\x01\n" +
-	"\x04Task\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1f\n" +
-	"\vmodule_name\x18\x03 \x01(\tR\n" +
-	"moduleName\x12\x18\n" +
-	"\apayload\x18\x04 \x01(\fR\apayload\x12!\n" +
-	"\fscheduled_at\x18\x05 \x01(\x03R\vscheduledAt\x12\x1d\n" +
-	"\n" +
+// Artifact is an agent or module binary listed in the signed software manifest
+type Artifact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`     // "agent" or "module"
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`       // where agents download the binary
+	Sha256        string                 `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"` // hex-encoded digest agents check downloads against
+	Size          int64                  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_api_dbos_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Artifact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Artifact) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Artifact) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Artifact) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Artifact) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Artifact) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type PublishArtifactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Artifact      *Artifact              `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"` // replaces the artifact of the same name and version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishArtifactRequest) Reset() {
+	*x = PublishArtifactRequest{}
+	mi := &file_api_dbos_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishArtifactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishArtifactRequest) ProtoMessage() {}
+
+func (x *PublishArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishArtifactRequest.ProtoReflect.Descriptor instead.
+func (*PublishArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PublishArtifactRequest) GetArtifact() *Artifact {
+	if x != nil {
+		return x.Artifact
+	}
+	return nil
+}
+
+type PublishArtifactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishArtifactResponse) Reset() {
+	*x = PublishArtifactResponse{}
+	mi := &file_api_dbos_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishArtifactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishArtifactResponse) ProtoMessage() {}
+
+func (x *PublishArtifactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishArtifactResponse.ProtoReflect.Descriptor instead.
+func (*PublishArtifactResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PublishArtifactResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PublishArtifactResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PublishArtifactResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type UnpublishArtifactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpublishArtifactRequest) Reset() {
+	*x = UnpublishArtifactRequest{}
+	mi := &file_api_dbos_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpublishArtifactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishArtifactRequest) ProtoMessage() {}
+
+func (x *UnpublishArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishArtifactRequest.ProtoReflect.Descriptor instead.
+func (*UnpublishArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UnpublishArtifactRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UnpublishArtifactRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type UnpublishArtifactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpublishArtifactResponse) Reset() {
+	*x = UnpublishArtifactResponse{}
+	mi := &file_api_dbos_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpublishArtifactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishArtifactResponse) ProtoMessage() {}
+
+func (x *UnpublishArtifactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishArtifactResponse.ProtoReflect.Descriptor instead.
+func (*UnpublishArtifactResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UnpublishArtifactResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UnpublishArtifactResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *UnpublishArtifactResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type GetManifestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetManifestRequest) Reset() {
+	*x = GetManifestRequest{}
+	mi := &file_api_dbos_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManifestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManifestRequest) ProtoMessage() {}
+
+func (x *GetManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManifestRequest.ProtoReflect.Descriptor instead.
+func (*GetManifestRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{34}
+}
+
+type GetManifestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Manifest      []byte                 `protobuf:"bytes,1,opt,name=manifest,proto3" json:"manifest,omitempty"`        // JSON manifest of the published artifacts, exactly as signed
+	Signature     []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`      // Ed25519 signature of manifest
+	KeyId         string                 `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"` // first 8 bytes of the SHA-256 of the signing public key, hex-encoded
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetManifestResponse) Reset() {
+	*x = GetManifestResponse{}
+	mi := &file_api_dbos_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetManifestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManifestResponse) ProtoMessage() {}
+
+func (x *GetManifestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManifestResponse.ProtoReflect.Descriptor instead.
+func (*GetManifestResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetManifestResponse) GetManifest() []byte {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+func (x *GetManifestResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *GetManifestResponse) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *GetManifestResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetManifestResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Config Rollout Requests
+type AgentConfigVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Config        map[string]string      `protobuf:"bytes,3,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RolloutId     string                 `protobuf:"bytes,4,opt,name=rollout_id,json=rolloutId,proto3" json:"rollout_id,omitempty"`
+	AppliedAt     int64                  `protobuf:"varint,5,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentConfigVersion) Reset() {
+	*x = AgentConfigVersion{}
+	mi := &file_api_dbos_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentConfigVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentConfigVersion) ProtoMessage() {}
+
+func (x *AgentConfigVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentConfigVersion.ProtoReflect.Descriptor instead.
+func (*AgentConfigVersion) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AgentConfigVersion) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentConfigVersion) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AgentConfigVersion) GetConfig() map[string]string {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *AgentConfigVersion) GetRolloutId() string {
+	if x != nil {
+		return x.RolloutId
+	}
+	return ""
+}
+
+func (x *AgentConfigVersion) GetAppliedAt() int64 {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return 0
+}
+
+type ConfigRollout struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Config               map[string]string      `protobuf:"bytes,2,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`     // keys merged into each target's config
+	Selector             map[string]string      `protobuf:"bytes,3,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // target agents by label; empty targets all agents
+	Stages               []int32                `protobuf:"varint,4,rep,packed,name=stages,proto3" json:"stages,omitempty"`                                                                       // cumulative cohort percentages, e.g. [5, 25, 100]
+	CurrentStage         int32                  `protobuf:"varint,5,opt,name=current_stage,json=currentStage,proto3" json:"current_stage,omitempty"`
+	MaxErrorRate         float64                `protobuf:"fixed64,6,opt,name=max_error_rate,json=maxErrorRate,proto3" json:"max_error_rate,omitempty"` // abort and roll back if a cohort's error rate exceeds this
+	StageDurationSeconds int64                  `protobuf:"varint,7,opt,name=stage_duration_seconds,json=stageDurationSeconds,proto3" json:"stage_duration_seconds,omitempty"`
+	StageStartedAt       int64                  `protobuf:"varint,8,opt,name=stage_started_at,json=stageStartedAt,proto3" json:"stage_started_at,omitempty"`
+	Status               string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"` // "in_progress", "completed", "aborted" or "rolled_back"
+	UpdatedAgents        []string               `protobuf:"bytes,10,rep,name=updated_agents,json=updatedAgents,proto3" json:"updated_agents,omitempty"`
+	Reason               string                 `protobuf:"bytes,11,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt            int64                  `protobuf:"varint,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ConfigRollout) Reset() {
+	*x = ConfigRollout{}
+	mi := &file_api_dbos_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigRollout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigRollout) ProtoMessage() {}
+
+func (x *ConfigRollout) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigRollout.ProtoReflect.Descriptor instead.
+func (*ConfigRollout) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ConfigRollout) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConfigRollout) GetConfig() map[string]string {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ConfigRollout) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *ConfigRollout) GetStages() []int32 {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *ConfigRollout) GetCurrentStage() int32 {
+	if x != nil {
+		return x.CurrentStage
+	}
+	return 0
+}
+
+func (x *ConfigRollout) GetMaxErrorRate() float64 {
+	if x != nil {
+		return x.MaxErrorRate
+	}
+	return 0
+}
+
+func (x *ConfigRollout) GetStageDurationSeconds() int64 {
+	if x != nil {
+		return x.StageDurationSeconds
+	}
+	return 0
+}
+
+func (x *ConfigRollout) GetStageStartedAt() int64 {
+	if x != nil {
+		return x.StageStartedAt
+	}
+	return 0
+}
+
+func (x *ConfigRollout) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ConfigRollout) GetUpdatedAgents() []string {
+	if x != nil {
+		return x.UpdatedAgents
+	}
+	return nil
+}
+
+func (x *ConfigRollout) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ConfigRollout) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type StartConfigRolloutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rollout       *ConfigRollout         `protobuf:"bytes,1,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartConfigRolloutRequest) Reset() {
+	*x = StartConfigRolloutRequest{}
+	mi := &file_api_dbos_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartConfigRolloutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartConfigRolloutRequest) ProtoMessage() {}
+
+func (x *StartConfigRolloutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartConfigRolloutRequest.ProtoReflect.Descriptor instead.
+func (*StartConfigRolloutRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StartConfigRolloutRequest) GetRollout() *ConfigRollout {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+type StartConfigRolloutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Rollout       *ConfigRollout         `protobuf:"bytes,2,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Violations    []*ConfigViolation     `protobuf:"bytes,5,rep,name=violations,proto3" json:"violations,omitempty"` // why the config was rejected by the targets' schemas
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartConfigRolloutResponse) Reset() {
+	*x = StartConfigRolloutResponse{}
+	mi := &file_api_dbos_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartConfigRolloutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartConfigRolloutResponse) ProtoMessage() {}
+
+func (x *StartConfigRolloutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartConfigRolloutResponse.ProtoReflect.Descriptor instead.
+func (*StartConfigRolloutResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StartConfigRolloutResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartConfigRolloutResponse) GetRollout() *ConfigRollout {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+func (x *StartConfigRolloutResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StartConfigRolloutResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *StartConfigRolloutResponse) GetViolations() []*ConfigViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+type GetConfigRolloutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RolloutId     string                 `protobuf:"bytes,1,opt,name=rollout_id,json=rolloutId,proto3" json:"rollout_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRolloutRequest) Reset() {
+	*x = GetConfigRolloutRequest{}
+	mi := &file_api_dbos_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRolloutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRolloutRequest) ProtoMessage() {}
+
+func (x *GetConfigRolloutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRolloutRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRolloutRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetConfigRolloutRequest) GetRolloutId() string {
+	if x != nil {
+		return x.RolloutId
+	}
+	return ""
+}
+
+type GetConfigRolloutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Rollout       *ConfigRollout         `protobuf:"bytes,2,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigRolloutResponse) Reset() {
+	*x = GetConfigRolloutResponse{}
+	mi := &file_api_dbos_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigRolloutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigRolloutResponse) ProtoMessage() {}
+
+func (x *GetConfigRolloutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRolloutResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigRolloutResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetConfigRolloutResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetConfigRolloutResponse) GetRollout() *ConfigRollout {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+func (x *GetConfigRolloutResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetConfigRolloutResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type RollbackConfigRolloutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RolloutId     string                 `protobuf:"bytes,1,opt,name=rollout_id,json=rolloutId,proto3" json:"rollout_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackConfigRolloutRequest) Reset() {
+	*x = RollbackConfigRolloutRequest{}
+	mi := &file_api_dbos_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackConfigRolloutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackConfigRolloutRequest) ProtoMessage() {}
+
+func (x *RollbackConfigRolloutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackConfigRolloutRequest.ProtoReflect.Descriptor instead.
+func (*RollbackConfigRolloutRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RollbackConfigRolloutRequest) GetRolloutId() string {
+	if x != nil {
+		return x.RolloutId
+	}
+	return ""
+}
+
+type RollbackConfigRolloutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Rollout       *ConfigRollout         `protobuf:"bytes,2,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackConfigRolloutResponse) Reset() {
+	*x = RollbackConfigRolloutResponse{}
+	mi := &file_api_dbos_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackConfigRolloutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackConfigRolloutResponse) ProtoMessage() {}
+
+func (x *RollbackConfigRolloutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackConfigRolloutResponse.ProtoReflect.Descriptor instead.
+func (*RollbackConfigRolloutResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RollbackConfigRolloutResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RollbackConfigRolloutResponse) GetRollout() *ConfigRollout {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+func (x *RollbackConfigRolloutResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RollbackConfigRolloutResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type GetAgentConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentConfigRequest) Reset() {
+	*x = GetAgentConfigRequest{}
+	mi := &file_api_dbos_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentConfigRequest) ProtoMessage() {}
+
+func (x *GetAgentConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentConfigRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetAgentConfigRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type GetAgentConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Config        *AgentConfigVersion    `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentConfigResponse) Reset() {
+	*x = GetAgentConfigResponse{}
+	mi := &file_api_dbos_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentConfigResponse) ProtoMessage() {}
+
+func (x *GetAgentConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentConfigResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetAgentConfigResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetAgentConfigResponse) GetConfig() *AgentConfigVersion {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *GetAgentConfigResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetAgentConfigResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// ConfigKeySchema constrains the value of one config key
+type ConfigKeySchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`          // "string" (default), "int", "float", "bool", "duration" (e.g. "30s") or "enum"
+	Required      bool                   `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"` // the key must be set in the agent's resulting config
+	Min           *float64               `protobuf:"fixed64,3,opt,name=min,proto3,oneof" json:"min,omitempty"`    // bounds of "int", "float" and "duration" values, durations in seconds
+	Max           *float64               `protobuf:"fixed64,4,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	Values        []string               `protobuf:"bytes,5,rep,name=values,proto3" json:"values,omitempty"`   // allowed values of "enum" keys
+	Pattern       string                 `protobuf:"bytes,6,opt,name=pattern,proto3" json:"pattern,omitempty"` // regular expression "string" values must match in full
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigKeySchema) Reset() {
+	*x = ConfigKeySchema{}
+	mi := &file_api_dbos_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigKeySchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigKeySchema) ProtoMessage() {}
+
+func (x *ConfigKeySchema) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigKeySchema.ProtoReflect.Descriptor instead.
+func (*ConfigKeySchema) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ConfigKeySchema) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ConfigKeySchema) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *ConfigKeySchema) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *ConfigKeySchema) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *ConfigKeySchema) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ConfigKeySchema) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+// ConfigSchema describes the config accepted by agents of one version
+type ConfigSchema struct {
+	state            protoimpl.MessageState      `protogen:"open.v1"`
+	AgentVersion     string                      `protobuf:"bytes,1,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"` // agents' "agent_version" label; empty for the default schema of versions without one
+	Keys             map[string]*ConfigKeySchema `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	AllowUnknownKeys bool                        `protobuf:"varint,3,opt,name=allow_unknown_keys,json=allowUnknownKeys,proto3" json:"allow_unknown_keys,omitempty"` // accept keys the schema does not list
+	UpdatedAt        int64                       `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConfigSchema) Reset() {
+	*x = ConfigSchema{}
+	mi := &file_api_dbos_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigSchema) ProtoMessage() {}
+
+func (x *ConfigSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigSchema.ProtoReflect.Descriptor instead.
+func (*ConfigSchema) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ConfigSchema) GetAgentVersion() string {
+	if x != nil {
+		return x.AgentVersion
+	}
+	return ""
+}
+
+func (x *ConfigSchema) GetKeys() map[string]*ConfigKeySchema {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *ConfigSchema) GetAllowUnknownKeys() bool {
+	if x != nil {
+		return x.AllowUnknownKeys
+	}
+	return false
+}
+
+func (x *ConfigSchema) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// ConfigViolation is a way a config breaks the schema of an agent version
+type ConfigViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentVersion  string                 `protobuf:"bytes,1,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	AgentIds      []string               `protobuf:"bytes,4,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"` // the agents whose resulting config has the violation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigViolation) Reset() {
+	*x = ConfigViolation{}
+	mi := &file_api_dbos_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigViolation) ProtoMessage() {}
+
+func (x *ConfigViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigViolation.ProtoReflect.Descriptor instead.
+func (*ConfigViolation) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ConfigViolation) GetAgentVersion() string {
+	if x != nil {
+		return x.AgentVersion
+	}
+	return ""
+}
+
+func (x *ConfigViolation) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigViolation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ConfigViolation) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+type SetConfigSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *ConfigSchema          `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConfigSchemaRequest) Reset() {
+	*x = SetConfigSchemaRequest{}
+	mi := &file_api_dbos_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConfigSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConfigSchemaRequest) ProtoMessage() {}
+
+func (x *SetConfigSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConfigSchemaRequest.ProtoReflect.Descriptor instead.
+func (*SetConfigSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SetConfigSchemaRequest) GetSchema() *ConfigSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type SetConfigSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Schema        *ConfigSchema          `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConfigSchemaResponse) Reset() {
+	*x = SetConfigSchemaResponse{}
+	mi := &file_api_dbos_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConfigSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConfigSchemaResponse) ProtoMessage() {}
+
+func (x *SetConfigSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConfigSchemaResponse.ProtoReflect.Descriptor instead.
+func (*SetConfigSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SetConfigSchemaResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetConfigSchemaResponse) GetSchema() *ConfigSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *SetConfigSchemaResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SetConfigSchemaResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListConfigSchemasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigSchemasRequest) Reset() {
+	*x = ListConfigSchemasRequest{}
+	mi := &file_api_dbos_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigSchemasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigSchemasRequest) ProtoMessage() {}
+
+func (x *ListConfigSchemasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigSchemasRequest.ProtoReflect.Descriptor instead.
+func (*ListConfigSchemasRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{51}
+}
+
+type ListConfigSchemasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schemas       []*ConfigSchema        `protobuf:"bytes,1,rep,name=schemas,proto3" json:"schemas,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigSchemasResponse) Reset() {
+	*x = ListConfigSchemasResponse{}
+	mi := &file_api_dbos_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigSchemasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigSchemasResponse) ProtoMessage() {}
+
+func (x *ListConfigSchemasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigSchemasResponse.ProtoReflect.Descriptor instead.
+func (*ListConfigSchemasResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListConfigSchemasResponse) GetSchemas() []*ConfigSchema {
+	if x != nil {
+		return x.Schemas
+	}
+	return nil
+}
+
+func (x *ListConfigSchemasResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListConfigSchemasResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type DeleteConfigSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentVersion  string                 `protobuf:"bytes,1,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConfigSchemaRequest) Reset() {
+	*x = DeleteConfigSchemaRequest{}
+	mi := &file_api_dbos_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConfigSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConfigSchemaRequest) ProtoMessage() {}
+
+func (x *DeleteConfigSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConfigSchemaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteConfigSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DeleteConfigSchemaRequest) GetAgentVersion() string {
+	if x != nil {
+		return x.AgentVersion
+	}
+	return ""
+}
+
+type DeleteConfigSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConfigSchemaResponse) Reset() {
+	*x = DeleteConfigSchemaResponse{}
+	mi := &file_api_dbos_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConfigSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConfigSchemaResponse) ProtoMessage() {}
+
+func (x *DeleteConfigSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConfigSchemaResponse.ProtoReflect.Descriptor instead.
+func (*DeleteConfigSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeleteConfigSchemaResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteConfigSchemaResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeleteConfigSchemaResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// ValidateConfigRequest checks config changes against the schemas of the
+// agents they would be pushed to, without pushing them
+type ValidateConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        map[string]string      `protobuf:"bytes,1,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`     // keys merged into each target's config, as by a rollout
+	Selector      map[string]string      `protobuf:"bytes,2,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // target agents by label; empty targets all agents
+	AgentId       string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                                                              // target only this agent instead
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateConfigRequest) Reset() {
+	*x = ValidateConfigRequest{}
+	mi := &file_api_dbos_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateConfigRequest) ProtoMessage() {}
+
+func (x *ValidateConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateConfigRequest.ProtoReflect.Descriptor instead.
+func (*ValidateConfigRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ValidateConfigRequest) GetConfig() map[string]string {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *ValidateConfigRequest) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *ValidateConfigRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type ValidateConfigResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Valid               bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Violations          []*ConfigViolation     `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	AgentsChecked       int32                  `protobuf:"varint,3,opt,name=agents_checked,json=agentsChecked,proto3" json:"agents_checked,omitempty"`
+	AgentsWithoutSchema int32                  `protobuf:"varint,4,opt,name=agents_without_schema,json=agentsWithoutSchema,proto3" json:"agents_without_schema,omitempty"` // targets whose version has no schema and no default applies
+	Error               string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode           string                 `protobuf:"bytes,6,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ValidateConfigResponse) Reset() {
+	*x = ValidateConfigResponse{}
+	mi := &file_api_dbos_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateConfigResponse) ProtoMessage() {}
+
+func (x *ValidateConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateConfigResponse.ProtoReflect.Descriptor instead.
+func (*ValidateConfigResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ValidateConfigResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateConfigResponse) GetViolations() []*ConfigViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *ValidateConfigResponse) GetAgentsChecked() int32 {
+	if x != nil {
+		return x.AgentsChecked
+	}
+	return 0
+}
+
+func (x *ValidateConfigResponse) GetAgentsWithoutSchema() int32 {
+	if x != nil {
+		return x.AgentsWithoutSchema
+	}
+	return 0
+}
+
+func (x *ValidateConfigResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ValidateConfigResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Module State Requests
+type SetModuleStateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	State *ModuleState           `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// If set, the write only applies if the stored state is at this version;
+	// 0 requires that no state is stored yet
+	ExpectedVersion *int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetModuleStateRequest) Reset() {
+	*x = SetModuleStateRequest{}
+	mi := &file_api_dbos_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetModuleStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetModuleStateRequest) ProtoMessage() {}
+
+func (x *SetModuleStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetModuleStateRequest.ProtoReflect.Descriptor instead.
+func (*SetModuleStateRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SetModuleStateRequest) GetState() *ModuleState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *SetModuleStateRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type SetModuleStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`   // version of the state after the write, or the current one on conflict
+	Conflict      bool                   `protobuf:"varint,4,opt,name=conflict,proto3" json:"conflict,omitempty"` // the expected version did not match
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetModuleStateResponse) Reset() {
+	*x = SetModuleStateResponse{}
+	mi := &file_api_dbos_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetModuleStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetModuleStateResponse) ProtoMessage() {}
+
+func (x *SetModuleStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetModuleStateResponse.ProtoReflect.Descriptor instead.
+func (*SetModuleStateResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SetModuleStateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetModuleStateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SetModuleStateResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *SetModuleStateResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SetModuleStateResponse) GetConflict() bool {
+	if x != nil {
+		return x.Conflict
+	}
+	return false
+}
+
+type GetModuleStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetModuleStateRequest) Reset() {
+	*x = GetModuleStateRequest{}
+	mi := &file_api_dbos_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetModuleStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetModuleStateRequest) ProtoMessage() {}
+
+func (x *GetModuleStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetModuleStateRequest.ProtoReflect.Descriptor instead.
+func (*GetModuleStateRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetModuleStateRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type GetModuleStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	State         *ModuleState           `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetModuleStateResponse) Reset() {
+	*x = GetModuleStateResponse{}
+	mi := &file_api_dbos_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetModuleStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetModuleStateResponse) ProtoMessage() {}
+
+func (x *GetModuleStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetModuleStateResponse.ProtoReflect.Descriptor instead.
+func (*GetModuleStateResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetModuleStateResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetModuleStateResponse) GetState() *ModuleState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *GetModuleStateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetModuleStateResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListModuleStatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ModuleName    string                 `protobuf:"bytes,2,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModuleStatesRequest) Reset() {
+	*x = ListModuleStatesRequest{}
+	mi := &file_api_dbos_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModuleStatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModuleStatesRequest) ProtoMessage() {}
+
+func (x *ListModuleStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModuleStatesRequest.ProtoReflect.Descriptor instead.
+func (*ListModuleStatesRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListModuleStatesRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ListModuleStatesRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+// WatchModuleStatesRequest streams module states as they are written
+type WatchModuleStatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`          // empty watches all agents
+	ModuleName    string                 `protobuf:"bytes,2,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"` // empty watches all modules
+	States        []string               `protobuf:"bytes,3,rep,name=states,proto3" json:"states,omitempty"`                           // only states in this list, e.g. "completed" and "error"; empty sends all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchModuleStatesRequest) Reset() {
+	*x = WatchModuleStatesRequest{}
+	mi := &file_api_dbos_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchModuleStatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchModuleStatesRequest) ProtoMessage() {}
+
+func (x *WatchModuleStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchModuleStatesRequest.ProtoReflect.Descriptor instead.
+func (*WatchModuleStatesRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *WatchModuleStatesRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *WatchModuleStatesRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *WatchModuleStatesRequest) GetStates() []string {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type ListModuleStatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	States        []*ModuleState         `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModuleStatesResponse) Reset() {
+	*x = ListModuleStatesResponse{}
+	mi := &file_api_dbos_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModuleStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModuleStatesResponse) ProtoMessage() {}
+
+func (x *ListModuleStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModuleStatesResponse.ProtoReflect.Descriptor instead.
+func (*ListModuleStatesResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListModuleStatesResponse) GetStates() []*ModuleState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListModuleStatesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListModuleStatesResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Measurement Result Requests
+type StoreResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *MeasurementResult     `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreResultRequest) Reset() {
+	*x = StoreResultRequest{}
+	mi := &file_api_dbos_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreResultRequest) ProtoMessage() {}
+
+func (x *StoreResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreResultRequest.ProtoReflect.Descriptor instead.
+func (*StoreResultRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *StoreResultRequest) GetResult() *MeasurementResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type StoreResultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ResultId      string                 `protobuf:"bytes,3,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"` // ID the result is stored under, minted if the result had none
+	Backoff       *Backoff               `protobuf:"bytes,5,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreResultResponse) Reset() {
+	*x = StoreResultResponse{}
+	mi := &file_api_dbos_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreResultResponse) ProtoMessage() {}
+
+func (x *StoreResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreResultResponse.ProtoReflect.Descriptor instead.
+func (*StoreResultResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *StoreResultResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StoreResultResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StoreResultResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *StoreResultResponse) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+func (x *StoreResultResponse) GetBackoff() *Backoff {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+// Backoff asks a client to slow down while the server is under load. It is
+// a hint: the request it answers was still served.
+type Backoff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RetryAfterMs  int64                  `protobuf:"varint,1,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"` // wait at least this long before the next such request
+	MaxBatchSize  int32                  `protobuf:"varint,2,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"` // send at most this many results per batch; 0 if not a batch
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                    // "redis_latency" or "inflight_requests"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Backoff) Reset() {
+	*x = Backoff{}
+	mi := &file_api_dbos_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Backoff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backoff) ProtoMessage() {}
+
+func (x *Backoff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backoff.ProtoReflect.Descriptor instead.
+func (*Backoff) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *Backoff) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
+func (x *Backoff) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *Backoff) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// StoreResultsRequest stores a batch of results in one call
+type StoreResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*MeasurementResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`    // at most 1000
+	Backfill      bool                   `protobuf:"varint,2,opt,name=backfill,proto3" json:"backfill,omitempty"` // late historical results: of any past measurement time, and kept out of real-time views, alerting and analyses
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreResultsRequest) Reset() {
+	*x = StoreResultsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreResultsRequest) ProtoMessage() {}
+
+func (x *StoreResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreResultsRequest.ProtoReflect.Descriptor instead.
+func (*StoreResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *StoreResultsRequest) GetResults() []*MeasurementResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *StoreResultsRequest) GetBackfill() bool {
+	if x != nil {
+		return x.Backfill
+	}
+	return false
+}
+
+// ResultStoreStatus is the outcome of storing one result of a batch
+type ResultStoreStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResultId      string                 `protobuf:"bytes,1,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"` // minted if the result had none
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                     // "stored", "duplicate" (a re-delivery of a stored result, stored again) or "error"
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResultStoreStatus) Reset() {
+	*x = ResultStoreStatus{}
+	mi := &file_api_dbos_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResultStoreStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultStoreStatus) ProtoMessage() {}
+
+func (x *ResultStoreStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultStoreStatus.ProtoReflect.Descriptor instead.
+func (*ResultStoreStatus) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ResultStoreStatus) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+func (x *ResultStoreStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ResultStoreStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ResultStoreStatus) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type StoreResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ResultStoreStatus   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // in the order of the request's results
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Backoff       *Backoff               `protobuf:"bytes,4,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreResultsResponse) Reset() {
+	*x = StoreResultsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreResultsResponse) ProtoMessage() {}
+
+func (x *StoreResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreResultsResponse.ProtoReflect.Descriptor instead.
+func (*StoreResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *StoreResultsResponse) GetResults() []*ResultStoreStatus {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *StoreResultsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StoreResultsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *StoreResultsResponse) GetBackoff() *Backoff {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+// StreamResultsResponse summarizes a StreamResults stream once the client
+// closes it
+type StreamResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stored        int64                  `protobuf:"varint,1,opt,name=stored,proto3" json:"stored,omitempty"`
+	Rejected      []*RejectedResult      `protobuf:"bytes,2,rep,name=rejected,proto3" json:"rejected,omitempty"` // in stream order
+	Backoff       *Backoff               `protobuf:"bytes,3,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamResultsResponse) Reset() {
+	*x = StreamResultsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamResultsResponse) ProtoMessage() {}
+
+func (x *StreamResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamResultsResponse.ProtoReflect.Descriptor instead.
+func (*StreamResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *StreamResultsResponse) GetStored() int64 {
+	if x != nil {
+		return x.Stored
+	}
+	return 0
+}
+
+func (x *StreamResultsResponse) GetRejected() []*RejectedResult {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+func (x *StreamResultsResponse) GetBackoff() *Backoff {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+// RejectedResult is a result of a StreamResults stream that was not stored
+type RejectedResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int64                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // position in the stream, from 0
+	ResultId      string                 `protobuf:"bytes,2,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectedResult) Reset() {
+	*x = RejectedResult{}
+	mi := &file_api_dbos_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectedResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedResult) ProtoMessage() {}
+
+func (x *RejectedResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedResult.ProtoReflect.Descriptor instead.
+func (*RejectedResult) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *RejectedResult) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RejectedResult) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+func (x *RejectedResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RejectedResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type GetResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"` // MeasurementResult fields to return, e.g. without "data"; empty returns all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResultRequest) Reset() {
+	*x = GetResultRequest{}
+	mi := &file_api_dbos_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResultRequest) ProtoMessage() {}
+
+func (x *GetResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResultRequest.ProtoReflect.Descriptor instead.
+func (*GetResultRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetResultRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *GetResultRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *GetResultRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type GetResultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Result        *MeasurementResult     `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResultResponse) Reset() {
+	*x = GetResultResponse{}
+	mi := &file_api_dbos_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResultResponse) ProtoMessage() {}
+
+func (x *GetResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResultResponse.ProtoReflect.Descriptor instead.
+func (*GetResultResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetResultResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetResultResponse) GetResult() *MeasurementResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetResultResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetResultResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                      // results per page; 0 for 100, at most 1000
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`                                     // next_cursor of the previous page; empty for the first page
+	FromTimestamp int64                  `protobuf:"varint,4,opt,name=from_timestamp,json=fromTimestamp,proto3" json:"from_timestamp,omitempty"` // unix seconds, inclusive; 0 for no bound
+	ToTimestamp   int64                  `protobuf:"varint,5,opt,name=to_timestamp,json=toTimestamp,proto3" json:"to_timestamp,omitempty"`       // unix seconds, exclusive; 0 for no bound
+	ModuleName    string                 `protobuf:"bytes,6,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"` // MeasurementResult fields to return; empty returns all
+	OrderBy       string                 `protobuf:"bytes,8,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`    // "timestamp" (default) or "sequence", optionally followed by " desc"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResultsRequest) Reset() {
+	*x = ListResultsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResultsRequest) ProtoMessage() {}
+
+func (x *ListResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResultsRequest.ProtoReflect.Descriptor instead.
+func (*ListResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListResultsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ListResultsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListResultsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListResultsRequest) GetFromTimestamp() int64 {
+	if x != nil {
+		return x.FromTimestamp
+	}
+	return 0
+}
+
+func (x *ListResultsRequest) GetToTimestamp() int64 {
+	if x != nil {
+		return x.ToTimestamp
+	}
+	return 0
+}
+
+func (x *ListResultsRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *ListResultsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListResultsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+type ListResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*MeasurementResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty after the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResultsResponse) Reset() {
+	*x = ListResultsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResultsResponse) ProtoMessage() {}
+
+func (x *ListResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResultsResponse.ProtoReflect.Descriptor instead.
+func (*ListResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ListResultsResponse) GetResults() []*MeasurementResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ListResultsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListResultsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ListResultsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// GetCorrelatedResultsRequest pages through the results of every agent and
+// module sharing a correlation ID, in measurement time order
+type GetCorrelatedResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // results per page; 0 for 100, at most 1000
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor of the previous page; empty for the first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCorrelatedResultsRequest) Reset() {
+	*x = GetCorrelatedResultsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCorrelatedResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCorrelatedResultsRequest) ProtoMessage() {}
+
+func (x *GetCorrelatedResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCorrelatedResultsRequest.ProtoReflect.Descriptor instead.
+func (*GetCorrelatedResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetCorrelatedResultsRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *GetCorrelatedResultsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetCorrelatedResultsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetCorrelatedResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*MeasurementResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty after the last page
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCorrelatedResultsResponse) Reset() {
+	*x = GetCorrelatedResultsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCorrelatedResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCorrelatedResultsResponse) ProtoMessage() {}
+
+func (x *GetCorrelatedResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCorrelatedResultsResponse.ProtoReflect.Descriptor instead.
+func (*GetCorrelatedResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetCorrelatedResultsResponse) GetResults() []*MeasurementResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *GetCorrelatedResultsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetCorrelatedResultsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetCorrelatedResultsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// CountResultsRequest counts an agent's results matching the same filter as
+// ListResultsRequest, without transferring them
+type CountResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	FromTimestamp int64                  `protobuf:"varint,2,opt,name=from_timestamp,json=fromTimestamp,proto3" json:"from_timestamp,omitempty"` // unix seconds, inclusive; 0 for no bound
+	ToTimestamp   int64                  `protobuf:"varint,3,opt,name=to_timestamp,json=toTimestamp,proto3" json:"to_timestamp,omitempty"`       // unix seconds, exclusive; 0 for no bound
+	ModuleName    string                 `protobuf:"bytes,4,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountResultsRequest) Reset() {
+	*x = CountResultsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResultsRequest) ProtoMessage() {}
+
+func (x *CountResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResultsRequest.ProtoReflect.Descriptor instead.
+func (*CountResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *CountResultsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CountResultsRequest) GetFromTimestamp() int64 {
+	if x != nil {
+		return x.FromTimestamp
+	}
+	return 0
+}
+
+func (x *CountResultsRequest) GetToTimestamp() int64 {
+	if x != nil {
+		return x.ToTimestamp
+	}
+	return 0
+}
+
+func (x *CountResultsRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+type CountResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountResultsResponse) Reset() {
+	*x = CountResultsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResultsResponse) ProtoMessage() {}
+
+func (x *CountResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResultsResponse.ProtoReflect.Descriptor instead.
+func (*CountResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *CountResultsResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CountResultsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CountResultsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// HasResultRequest checks that a result is stored, without transferring it
+type HasResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HasResultRequest) Reset() {
+	*x = HasResultRequest{}
+	mi := &file_api_dbos_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HasResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasResultRequest) ProtoMessage() {}
+
+func (x *HasResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasResultRequest.ProtoReflect.Descriptor instead.
+func (*HasResultRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *HasResultRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *HasResultRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type HasResultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HasResultResponse) Reset() {
+	*x = HasResultResponse{}
+	mi := &file_api_dbos_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HasResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasResultResponse) ProtoMessage() {}
+
+func (x *HasResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasResultResponse.ProtoReflect.Descriptor instead.
+func (*HasResultResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *HasResultResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *HasResultResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *HasResultResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// ExportResultsRequest selects results to export as an Apache Arrow IPC stream
+type ExportResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentIds      []string               `protobuf:"bytes,1,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"` // empty exports every agent's results
+	ModuleName    string                 `protobuf:"bytes,2,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Fields        []string               `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"` // dotted JSON paths into result data, exported as float64 columns named "data.<path>"
+	From          int64                  `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`    // unix seconds, inclusive; 0 for no bound
+	To            int64                  `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`        // unix seconds, exclusive; 0 for no bound
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportResultsRequest) Reset() {
+	*x = ExportResultsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResultsRequest) ProtoMessage() {}
+
+func (x *ExportResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportResultsRequest.ProtoReflect.Descriptor instead.
+func (*ExportResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ExportResultsRequest) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *ExportResultsRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *ExportResultsRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ExportResultsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ExportResultsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// ExportResultsChunk is a piece of an Arrow IPC stream; concatenated, the
+// chunks form the stream
+type ExportResultsChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportResultsChunk) Reset() {
+	*x = ExportResultsChunk{}
+	mi := &file_api_dbos_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportResultsChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResultsChunk) ProtoMessage() {}
+
+func (x *ExportResultsChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportResultsChunk.ProtoReflect.Descriptor instead.
+func (*ExportResultsChunk) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ExportResultsChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ProfileResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentIds      []string               `protobuf:"bytes,1,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"` // empty profiles every agent's results
+	ModuleName    string                 `protobuf:"bytes,2,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	From          int64                  `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`   // unix seconds, inclusive; 0 for no bound
+	To            int64                  `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`       // unix seconds, exclusive; 0 for no bound
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"` // results profiled at most; default 10000, at most 100000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileResultsRequest) Reset() {
+	*x = ProfileResultsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileResultsRequest) ProtoMessage() {}
+
+func (x *ProfileResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileResultsRequest.ProtoReflect.Descriptor instead.
+func (*ProfileResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ProfileResultsRequest) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *ProfileResultsRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *ProfileResultsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ProfileResultsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ProfileResultsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// FieldProfile describes the values at one path of the profiled results'
+// data
+type FieldProfile struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Path                  string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                                                              // dotted JSON path; "[]" stands for every element of an array, e.g. "hops[].rtt"
+	Results               int64                  `protobuf:"varint,2,opt,name=results,proto3" json:"results,omitempty"`                                                                       // results the path occurs in
+	Coverage              float64                `protobuf:"fixed64,3,opt,name=coverage,proto3" json:"coverage,omitempty"`                                                                    // fraction of the profiled results the path occurs in
+	Values                int64                  `protobuf:"varint,4,opt,name=values,proto3" json:"values,omitempty"`                                                                         // values at the path; more than results under arrays
+	Types                 map[string]int64       `protobuf:"bytes,5,rep,name=types,proto3" json:"types,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // values per JSON type: "null", "boolean", "number", "string", "object" or "array"
+	NullRate              float64                `protobuf:"fixed64,6,opt,name=null_rate,json=nullRate,proto3" json:"null_rate,omitempty"`                                                    // fraction of the values that are null
+	Min                   float64                `protobuf:"fixed64,7,opt,name=min,proto3" json:"min,omitempty"`                                                                              // of the numbers
+	Max                   float64                `protobuf:"fixed64,8,opt,name=max,proto3" json:"max,omitempty"`
+	Mean                  float64                `protobuf:"fixed64,9,opt,name=mean,proto3" json:"mean,omitempty"`
+	MinLength             int32                  `protobuf:"varint,10,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"` // of the strings, in characters
+	MaxLength             int32                  `protobuf:"varint,11,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	DistinctStrings       int32                  `protobuf:"varint,12,opt,name=distinct_strings,json=distinctStrings,proto3" json:"distinct_strings,omitempty"`                     // counted up to 1000
+	DistinctStringsCapped bool                   `protobuf:"varint,13,opt,name=distinct_strings_capped,json=distinctStringsCapped,proto3" json:"distinct_strings_capped,omitempty"` // there were more than 1000
+	Examples              []string               `protobuf:"bytes,14,rep,name=examples,proto3" json:"examples,omitempty"`                                                           // up to 3 distinct non-null scalar values, JSON-encoded
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *FieldProfile) Reset() {
+	*x = FieldProfile{}
+	mi := &file_api_dbos_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldProfile) ProtoMessage() {}
+
+func (x *FieldProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldProfile.ProtoReflect.Descriptor instead.
+func (*FieldProfile) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *FieldProfile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FieldProfile) GetResults() int64 {
+	if x != nil {
+		return x.Results
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetCoverage() float64 {
+	if x != nil {
+		return x.Coverage
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetValues() int64 {
+	if x != nil {
+		return x.Values
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetTypes() map[string]int64 {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *FieldProfile) GetNullRate() float64 {
+	if x != nil {
+		return x.NullRate
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetMinLength() int32 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetDistinctStrings() int32 {
+	if x != nil {
+		return x.DistinctStrings
+	}
+	return 0
+}
+
+func (x *FieldProfile) GetDistinctStringsCapped() bool {
+	if x != nil {
+		return x.DistinctStringsCapped
+	}
+	return false
+}
+
+func (x *FieldProfile) GetExamples() []string {
+	if x != nil {
+		return x.Examples
+	}
+	return nil
+}
+
+type ProfileResultsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Results         int64                  `protobuf:"varint,1,opt,name=results,proto3" json:"results,omitempty"`                                        // results profiled
+	InvalidResults  int64                  `protobuf:"varint,2,opt,name=invalid_results,json=invalidResults,proto3" json:"invalid_results,omitempty"`    // results left out as their data is not JSON
+	Truncated       bool                   `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`                                    // more results matched than limit
+	Fields          []*FieldProfile        `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`                                           // in path order
+	FieldsTruncated bool                   `protobuf:"varint,5,opt,name=fields_truncated,json=fieldsTruncated,proto3" json:"fields_truncated,omitempty"` // paths beyond the first 1000 seen were left out
+	Error           string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode       string                 `protobuf:"bytes,7,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProfileResultsResponse) Reset() {
+	*x = ProfileResultsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileResultsResponse) ProtoMessage() {}
+
+func (x *ProfileResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileResultsResponse.ProtoReflect.Descriptor instead.
+func (*ProfileResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ProfileResultsResponse) GetResults() int64 {
+	if x != nil {
+		return x.Results
+	}
+	return 0
+}
+
+func (x *ProfileResultsResponse) GetInvalidResults() int64 {
+	if x != nil {
+		return x.InvalidResults
+	}
+	return 0
+}
+
+func (x *ProfileResultsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *ProfileResultsResponse) GetFields() []*FieldProfile {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ProfileResultsResponse) GetFieldsTruncated() bool {
+	if x != nil {
+		return x.FieldsTruncated
+	}
+	return false
+}
+
+func (x *ProfileResultsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProfileResultsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type GetClockSkewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClockSkewRequest) Reset() {
+	*x = GetClockSkewRequest{}
+	mi := &file_api_dbos_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClockSkewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClockSkewRequest) ProtoMessage() {}
+
+func (x *GetClockSkewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClockSkewRequest.ProtoReflect.Descriptor instead.
+func (*GetClockSkewRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetClockSkewRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type GetClockSkewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Skew          *ClockSkew             `protobuf:"bytes,2,opt,name=skew,proto3" json:"skew,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetClockSkewResponse) Reset() {
+	*x = GetClockSkewResponse{}
+	mi := &file_api_dbos_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetClockSkewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetClockSkewResponse) ProtoMessage() {}
+
+func (x *GetClockSkewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetClockSkewResponse.ProtoReflect.Descriptor instead.
+func (*GetClockSkewResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *GetClockSkewResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetClockSkewResponse) GetSkew() *ClockSkew {
+	if x != nil {
+		return x.Skew
+	}
+	return nil
+}
+
+func (x *GetClockSkewResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetClockSkewResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Alert is a notable condition detected in a measurement result
+type Alert struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	AgentId       string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ResultId      string                 `protobuf:"bytes,4,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`
+	ModuleName    string                 `protobuf:"bytes,5,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Target        string                 `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,8,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	mi := &file_api_dbos_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *Alert) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Alert) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Alert) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *Alert) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+func (x *Alert) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *Alert) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Alert) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Alert) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *Alert) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListAlertsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // empty lists alerts of all agents
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                      // empty lists alerts of all types
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                   // 0 returns all retained alerts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertsRequest) Reset() {
+	*x = ListAlertsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertsRequest) ProtoMessage() {}
+
+func (x *ListAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertsRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ListAlertsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ListAlertsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListAlertsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAlertsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alerts        []*Alert               `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertsResponse) Reset() {
+	*x = ListAlertsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertsResponse) ProtoMessage() {}
+
+func (x *ListAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertsResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *ListAlertsResponse) GetAlerts() []*Alert {
+	if x != nil {
+		return x.Alerts
+	}
+	return nil
+}
+
+func (x *ListAlertsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListAlertsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Incident is an ongoing condition detected across measurement results
+type Incident struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Key            string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "open", "acknowledged" or "resolved"
+	Region         string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	Target         string                 `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	Reason         string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	AgentIds       []string               `protobuf:"bytes,8,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	ResultIds      []string               `protobuf:"bytes,9,rep,name=result_ids,json=resultIds,proto3" json:"result_ids,omitempty"` // latest first
+	Evidence       map[string]string      `protobuf:"bytes,10,rep,name=evidence,proto3" json:"evidence,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Occurrences    int64                  `protobuf:"varint,11,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
+	FirstSeen      int64                  `protobuf:"varint,12,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen       int64                  `protobuf:"varint,13,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	ResolvedAt     int64                  `protobuf:"varint,14,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	EventIds       []string               `protobuf:"bytes,15,rep,name=event_ids,json=eventIds,proto3" json:"event_ids,omitempty"` // linked external events, latest first
+	Title          string                 `protobuf:"bytes,16,opt,name=title,proto3" json:"title,omitempty"`
+	Targets        []string               `protobuf:"bytes,17,rep,name=targets,proto3" json:"targets,omitempty"`                   // further affected targets
+	AlertIds       []string               `protobuf:"bytes,18,rep,name=alert_ids,json=alertIds,proto3" json:"alert_ids,omitempty"` // linked alerts, latest first
+	Comments       []*IncidentComment     `protobuf:"bytes,19,rep,name=comments,proto3" json:"comments,omitempty"`                 // oldest first
+	AcknowledgedBy string                 `protobuf:"bytes,20,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`
+	AcknowledgedAt int64                  `protobuf:"varint,21,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	ResolvedBy     string                 `protobuf:"bytes,22,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Incident) Reset() {
+	*x = Incident{}
+	mi := &file_api_dbos_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Incident) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Incident) ProtoMessage() {}
+
+func (x *Incident) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Incident.ProtoReflect.Descriptor instead.
+func (*Incident) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *Incident) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Incident) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Incident) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Incident) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Incident) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Incident) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Incident) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Incident) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *Incident) GetResultIds() []string {
+	if x != nil {
+		return x.ResultIds
+	}
+	return nil
+}
+
+func (x *Incident) GetEvidence() map[string]string {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *Incident) GetOccurrences() int64 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return 0
+}
+
+func (x *Incident) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *Incident) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *Incident) GetResolvedAt() int64 {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return 0
+}
+
+func (x *Incident) GetEventIds() []string {
+	if x != nil {
+		return x.EventIds
+	}
+	return nil
+}
+
+func (x *Incident) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Incident) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *Incident) GetAlertIds() []string {
+	if x != nil {
+		return x.AlertIds
+	}
+	return nil
+}
+
+func (x *Incident) GetComments() []*IncidentComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *Incident) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
+	}
+	return ""
+}
+
+func (x *Incident) GetAcknowledgedAt() int64 {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return 0
+}
+
+func (x *Incident) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+// IncidentComment is a note left on an incident
+type IncidentComment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncidentComment) Reset() {
+	*x = IncidentComment{}
+	mi := &file_api_dbos_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncidentComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncidentComment) ProtoMessage() {}
+
+func (x *IncidentComment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncidentComment.ProtoReflect.Descriptor instead.
+func (*IncidentComment) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *IncidentComment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IncidentComment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *IncidentComment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *IncidentComment) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// RoutingEvent is a BGP update affecting a watched prefix
+type RoutingEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type              string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // "withdrawal" or "hijack"
+	Prefix            string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	WatchedPrefix     string                 `protobuf:"bytes,4,opt,name=watched_prefix,json=watchedPrefix,proto3" json:"watched_prefix,omitempty"`
+	OriginAsn         int64                  `protobuf:"varint,5,opt,name=origin_asn,json=originAsn,proto3" json:"origin_asn,omitempty"`
+	ExpectedOriginAsn int64                  `protobuf:"varint,6,opt,name=expected_origin_asn,json=expectedOriginAsn,proto3" json:"expected_origin_asn,omitempty"`
+	Path              string                 `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	Peer              string                 `protobuf:"bytes,8,opt,name=peer,proto3" json:"peer,omitempty"`
+	PeerAsn           string                 `protobuf:"bytes,9,opt,name=peer_asn,json=peerAsn,proto3" json:"peer_asn,omitempty"`
+	Collector         string                 `protobuf:"bytes,10,opt,name=collector,proto3" json:"collector,omitempty"`
+	Timestamp         int64                  `protobuf:"varint,11,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RoutingEvent) Reset() {
+	*x = RoutingEvent{}
+	mi := &file_api_dbos_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutingEvent) ProtoMessage() {}
+
+func (x *RoutingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutingEvent.ProtoReflect.Descriptor instead.
+func (*RoutingEvent) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *RoutingEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RoutingEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RoutingEvent) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *RoutingEvent) GetWatchedPrefix() string {
+	if x != nil {
+		return x.WatchedPrefix
+	}
+	return ""
+}
+
+func (x *RoutingEvent) GetOriginAsn() int64 {
+	if x != nil {
+		return x.OriginAsn
+	}
+	return 0
+}
+
+func (x *RoutingEvent) GetExpectedOriginAsn() int64 {
+	if x != nil {
+		return x.ExpectedOriginAsn
+	}
+	return 0
+}
+
+func (x *RoutingEvent) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RoutingEvent) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *RoutingEvent) GetPeerAsn() string {
+	if x != nil {
+		return x.PeerAsn
+	}
+	return ""
+}
+
+func (x *RoutingEvent) GetCollector() string {
+	if x != nil {
+		return x.Collector
+	}
+	return ""
+}
+
+func (x *RoutingEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type ListRoutingEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WatchedPrefix string                 `protobuf:"bytes,1,opt,name=watched_prefix,json=watchedPrefix,proto3" json:"watched_prefix,omitempty"` // empty lists events of all watched prefixes
+	Since         int64                  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`                                     // unix time; 0 lists all retained events
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                     // 0 returns all matching events
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoutingEventsRequest) Reset() {
+	*x = ListRoutingEventsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoutingEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoutingEventsRequest) ProtoMessage() {}
+
+func (x *ListRoutingEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoutingEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoutingEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ListRoutingEventsRequest) GetWatchedPrefix() string {
+	if x != nil {
+		return x.WatchedPrefix
+	}
+	return ""
+}
+
+func (x *ListRoutingEventsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ListRoutingEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRoutingEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*RoutingEvent        `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // newest first
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoutingEventsResponse) Reset() {
+	*x = ListRoutingEventsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoutingEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoutingEventsResponse) ProtoMessage() {}
+
+func (x *ListRoutingEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoutingEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoutingEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *ListRoutingEventsResponse) GetEvents() []*RoutingEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListRoutingEventsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListRoutingEventsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type GetIncidentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIncidentRequest) Reset() {
+	*x = GetIncidentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIncidentRequest) ProtoMessage() {}
+
+func (x *GetIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIncidentRequest.ProtoReflect.Descriptor instead.
+func (*GetIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *GetIncidentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetIncidentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Incident      *Incident              `protobuf:"bytes,2,opt,name=incident,proto3" json:"incident,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIncidentResponse) Reset() {
+	*x = GetIncidentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIncidentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIncidentResponse) ProtoMessage() {}
+
+func (x *GetIncidentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIncidentResponse.ProtoReflect.Descriptor instead.
+func (*GetIncidentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *GetIncidentResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetIncidentResponse) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *GetIncidentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetIncidentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListIncidentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`     // empty lists incidents of all types
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "open", "acknowledged", "resolved", "active" (open or acknowledged) or empty for all
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"` // empty lists incidents of all regions
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`  // 0 returns all incidents
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncidentsRequest) Reset() {
+	*x = ListIncidentsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncidentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncidentsRequest) ProtoMessage() {}
+
+func (x *ListIncidentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncidentsRequest.ProtoReflect.Descriptor instead.
+func (*ListIncidentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ListIncidentsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListIncidentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListIncidentsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ListIncidentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListIncidentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Incidents     []*Incident            `protobuf:"bytes,1,rep,name=incidents,proto3" json:"incidents,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncidentsResponse) Reset() {
+	*x = ListIncidentsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncidentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncidentsResponse) ProtoMessage() {}
+
+func (x *ListIncidentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncidentsResponse.ProtoReflect.Descriptor instead.
+func (*ListIncidentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ListIncidentsResponse) GetIncidents() []*Incident {
+	if x != nil {
+		return x.Incidents
+	}
+	return nil
+}
+
+func (x *ListIncidentsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListIncidentsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type CreateIncidentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // defaults to "manual"
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	Targets       []string               `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	AgentIds      []string               `protobuf:"bytes,7,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	ResultIds     []string               `protobuf:"bytes,8,rep,name=result_ids,json=resultIds,proto3" json:"result_ids,omitempty"`
+	AlertIds      []string               `protobuf:"bytes,9,rep,name=alert_ids,json=alertIds,proto3" json:"alert_ids,omitempty"`
+	Author        string                 `protobuf:"bytes,10,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIncidentRequest) Reset() {
+	*x = CreateIncidentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIncidentRequest) ProtoMessage() {}
+
+func (x *CreateIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIncidentRequest.ProtoReflect.Descriptor instead.
+func (*CreateIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *CreateIncidentRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateIncidentRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateIncidentRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *CreateIncidentRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *CreateIncidentRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *CreateIncidentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateIncidentRequest) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *CreateIncidentRequest) GetResultIds() []string {
+	if x != nil {
+		return x.ResultIds
+	}
+	return nil
+}
+
+func (x *CreateIncidentRequest) GetAlertIds() []string {
+	if x != nil {
+		return x.AlertIds
+	}
+	return nil
+}
+
+func (x *CreateIncidentRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+type CreateIncidentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Incident      *Incident              `protobuf:"bytes,2,opt,name=incident,proto3" json:"incident,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIncidentResponse) Reset() {
+	*x = CreateIncidentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIncidentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIncidentResponse) ProtoMessage() {}
+
+func (x *CreateIncidentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIncidentResponse.ProtoReflect.Descriptor instead.
+func (*CreateIncidentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *CreateIncidentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateIncidentResponse) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *CreateIncidentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateIncidentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// UpdateIncidentRequest changes the title and reason, if set, and links
+// further agents, targets, results and alerts
+type UpdateIncidentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	AddAgentIds   []string               `protobuf:"bytes,4,rep,name=add_agent_ids,json=addAgentIds,proto3" json:"add_agent_ids,omitempty"`
+	AddTargets    []string               `protobuf:"bytes,5,rep,name=add_targets,json=addTargets,proto3" json:"add_targets,omitempty"`
+	AddResultIds  []string               `protobuf:"bytes,6,rep,name=add_result_ids,json=addResultIds,proto3" json:"add_result_ids,omitempty"`
+	AddAlertIds   []string               `protobuf:"bytes,7,rep,name=add_alert_ids,json=addAlertIds,proto3" json:"add_alert_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateIncidentRequest) Reset() {
+	*x = UpdateIncidentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIncidentRequest) ProtoMessage() {}
+
+func (x *UpdateIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIncidentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *UpdateIncidentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateIncidentRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateIncidentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateIncidentRequest) GetAddAgentIds() []string {
+	if x != nil {
+		return x.AddAgentIds
+	}
+	return nil
+}
+
+func (x *UpdateIncidentRequest) GetAddTargets() []string {
+	if x != nil {
+		return x.AddTargets
+	}
+	return nil
+}
+
+func (x *UpdateIncidentRequest) GetAddResultIds() []string {
+	if x != nil {
+		return x.AddResultIds
+	}
+	return nil
+}
+
+func (x *UpdateIncidentRequest) GetAddAlertIds() []string {
+	if x != nil {
+		return x.AddAlertIds
+	}
+	return nil
+}
+
+type UpdateIncidentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Incident      *Incident              `protobuf:"bytes,2,opt,name=incident,proto3" json:"incident,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateIncidentResponse) Reset() {
+	*x = UpdateIncidentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIncidentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIncidentResponse) ProtoMessage() {}
+
+func (x *UpdateIncidentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIncidentResponse.ProtoReflect.Descriptor instead.
+func (*UpdateIncidentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *UpdateIncidentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateIncidentResponse) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *UpdateIncidentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *UpdateIncidentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type AcknowledgeIncidentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeIncidentRequest) Reset() {
+	*x = AcknowledgeIncidentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeIncidentRequest) ProtoMessage() {}
+
+func (x *AcknowledgeIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeIncidentRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *AcknowledgeIncidentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AcknowledgeIncidentRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+type AcknowledgeIncidentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Incident      *Incident              `protobuf:"bytes,2,opt,name=incident,proto3" json:"incident,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeIncidentResponse) Reset() {
+	*x = AcknowledgeIncidentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeIncidentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeIncidentResponse) ProtoMessage() {}
+
+func (x *AcknowledgeIncidentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeIncidentResponse.ProtoReflect.Descriptor instead.
+func (*AcknowledgeIncidentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *AcknowledgeIncidentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AcknowledgeIncidentResponse) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *AcknowledgeIncidentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AcknowledgeIncidentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ResolveIncidentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"` // optional; added as a comment
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveIncidentRequest) Reset() {
+	*x = ResolveIncidentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveIncidentRequest) ProtoMessage() {}
+
+func (x *ResolveIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveIncidentRequest.ProtoReflect.Descriptor instead.
+func (*ResolveIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *ResolveIncidentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResolveIncidentRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ResolveIncidentRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ResolveIncidentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Incident      *Incident              `protobuf:"bytes,2,opt,name=incident,proto3" json:"incident,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveIncidentResponse) Reset() {
+	*x = ResolveIncidentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveIncidentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveIncidentResponse) ProtoMessage() {}
+
+func (x *ResolveIncidentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveIncidentResponse.ProtoReflect.Descriptor instead.
+func (*ResolveIncidentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *ResolveIncidentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResolveIncidentResponse) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *ResolveIncidentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ResolveIncidentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type AddIncidentCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddIncidentCommentRequest) Reset() {
+	*x = AddIncidentCommentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddIncidentCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddIncidentCommentRequest) ProtoMessage() {}
+
+func (x *AddIncidentCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddIncidentCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddIncidentCommentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *AddIncidentCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AddIncidentCommentRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AddIncidentCommentRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+type AddIncidentCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Incident      *Incident              `protobuf:"bytes,2,opt,name=incident,proto3" json:"incident,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddIncidentCommentResponse) Reset() {
+	*x = AddIncidentCommentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddIncidentCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddIncidentCommentResponse) ProtoMessage() {}
+
+func (x *AddIncidentCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddIncidentCommentResponse.ProtoReflect.Descriptor instead.
+func (*AddIncidentCommentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *AddIncidentCommentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddIncidentCommentResponse) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *AddIncidentCommentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AddIncidentCommentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type DeleteIncidentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIncidentRequest) Reset() {
+	*x = DeleteIncidentRequest{}
+	mi := &file_api_dbos_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIncidentRequest) ProtoMessage() {}
+
+func (x *DeleteIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIncidentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *DeleteIncidentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteIncidentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIncidentResponse) Reset() {
+	*x = DeleteIncidentResponse{}
+	mi := &file_api_dbos_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIncidentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIncidentResponse) ProtoMessage() {}
+
+func (x *DeleteIncidentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIncidentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteIncidentResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *DeleteIncidentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteIncidentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeleteIncidentResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type WatchIncidentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      string                 `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"` // resume after this revision; empty starts with new events
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchIncidentsRequest) Reset() {
+	*x = WatchIncidentsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchIncidentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchIncidentsRequest) ProtoMessage() {}
+
+func (x *WatchIncidentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchIncidentsRequest.ProtoReflect.Descriptor instead.
+func (*WatchIncidentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *WatchIncidentsRequest) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+// IncidentEvent is one change to an incident. Occurrences of an active
+// incident are not events.
+type IncidentEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`         // "opened", "updated", "acknowledged", "commented", "resolved" or "deleted"
+	Incident      *Incident              `protobuf:"bytes,2,opt,name=incident,proto3" json:"incident,omitempty"` // only id is set for "deleted"
+	Revision      string                 `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"` // resumable revision token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncidentEvent) Reset() {
+	*x = IncidentEvent{}
+	mi := &file_api_dbos_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncidentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncidentEvent) ProtoMessage() {}
+
+func (x *IncidentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncidentEvent.ProtoReflect.Descriptor instead.
+func (*IncidentEvent) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *IncidentEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *IncidentEvent) GetIncident() *Incident {
+	if x != nil {
+		return x.Incident
+	}
+	return nil
+}
+
+func (x *IncidentEvent) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+type GetIngestGapsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	FromSequence  int64                  `protobuf:"varint,2,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"` // defaults to 1
+	ToSequence    int64                  `protobuf:"varint,3,opt,name=to_sequence,json=toSequence,proto3" json:"to_sequence,omitempty"`       // defaults to the last assigned sequence
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIngestGapsRequest) Reset() {
+	*x = GetIngestGapsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIngestGapsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIngestGapsRequest) ProtoMessage() {}
+
+func (x *GetIngestGapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIngestGapsRequest.ProtoReflect.Descriptor instead.
+func (*GetIngestGapsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *GetIngestGapsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *GetIngestGapsRequest) GetFromSequence() int64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
+
+func (x *GetIngestGapsRequest) GetToSequence() int64 {
+	if x != nil {
+		return x.ToSequence
+	}
+	return 0
+}
+
+// SequenceGap is an inclusive range of sequence numbers with no stored result
+type SequenceGap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromSequence  int64                  `protobuf:"varint,1,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+	ToSequence    int64                  `protobuf:"varint,2,opt,name=to_sequence,json=toSequence,proto3" json:"to_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SequenceGap) Reset() {
+	*x = SequenceGap{}
+	mi := &file_api_dbos_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SequenceGap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SequenceGap) ProtoMessage() {}
+
+func (x *SequenceGap) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SequenceGap.ProtoReflect.Descriptor instead.
+func (*SequenceGap) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *SequenceGap) GetFromSequence() int64 {
+	if x != nil {
+		return x.FromSequence
+	}
+	return 0
+}
+
+func (x *SequenceGap) GetToSequence() int64 {
+	if x != nil {
+		return x.ToSequence
+	}
+	return 0
+}
+
+type GetIngestGapsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gaps          []*SequenceGap         `protobuf:"bytes,1,rep,name=gaps,proto3" json:"gaps,omitempty"`
+	LastSequence  int64                  `protobuf:"varint,2,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIngestGapsResponse) Reset() {
+	*x = GetIngestGapsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIngestGapsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIngestGapsResponse) ProtoMessage() {}
+
+func (x *GetIngestGapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIngestGapsResponse.ProtoReflect.Descriptor instead.
+func (*GetIngestGapsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *GetIngestGapsResponse) GetGaps() []*SequenceGap {
+	if x != nil {
+		return x.Gaps
+	}
+	return nil
+}
+
+func (x *GetIngestGapsResponse) GetLastSequence() int64 {
+	if x != nil {
+		return x.LastSequence
+	}
+	return 0
+}
+
+func (x *GetIngestGapsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetIngestGapsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Task Scheduling Requests
+type ScheduleTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Makes retries safe: scheduling again with the same key, or the same
+	// task ID if there is none, schedules nothing within the dedup window
+	DedupKey      string `protobuf:"bytes,2,opt,name=dedup_key,json=dedupKey,proto3" json:"dedup_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleTaskRequest) Reset() {
+	*x = ScheduleTaskRequest{}
+	mi := &file_api_dbos_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleTaskRequest) ProtoMessage() {}
+
+func (x *ScheduleTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleTaskRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *ScheduleTaskRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *ScheduleTaskRequest) GetDedupKey() string {
+	if x != nil {
+		return x.DedupKey
+	}
+	return ""
+}
+
+type ScheduleTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	TaskId        string                 `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`                       // ID of the scheduled task, minted if the task had none
+	AlreadyExists bool                   `protobuf:"varint,5,opt,name=already_exists,json=alreadyExists,proto3" json:"already_exists,omitempty"` // the task was scheduled before; task_id is its ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleTaskResponse) Reset() {
+	*x = ScheduleTaskResponse{}
+	mi := &file_api_dbos_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleTaskResponse) ProtoMessage() {}
+
+func (x *ScheduleTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleTaskResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *ScheduleTaskResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScheduleTaskResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduleTaskResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ScheduleTaskResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ScheduleTaskResponse) GetAlreadyExists() bool {
+	if x != nil {
+		return x.AlreadyExists
+	}
+	return false
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_api_dbos_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *GetTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type GetTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Progress      *TaskProgress          `protobuf:"bytes,5,opt,name=progress,proto3" json:"progress,omitempty"` // the progress last reported, if any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	mi := &file_api_dbos_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *GetTaskResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *GetTaskResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *GetTaskResponse) GetProgress() *TaskProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+type CancelTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTaskRequest) Reset() {
+	*x = CancelTaskRequest{}
+	mi := &file_api_dbos_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTaskRequest) ProtoMessage() {}
+
+func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTaskRequest.ProtoReflect.Descriptor instead.
+func (*CancelTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *CancelTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type CancelTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTaskResponse) Reset() {
+	*x = CancelTaskResponse{}
+	mi := &file_api_dbos_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTaskResponse) ProtoMessage() {}
+
+func (x *CancelTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTaskResponse.ProtoReflect.Descriptor instead.
+func (*CancelTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *CancelTaskResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelTaskResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CancelTaskResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type StreamTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTasksRequest) Reset() {
+	*x = StreamTasksRequest{}
+	mi := &file_api_dbos_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTasksRequest) ProtoMessage() {}
+
+func (x *StreamTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTasksRequest.ProtoReflect.Descriptor instead.
+func (*StreamTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *StreamTasksRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type LeaseTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	WaitSeconds   int64                  `protobuf:"varint,2,opt,name=wait_seconds,json=waitSeconds,proto3" json:"wait_seconds,omitempty"` // long-poll for up to this long if no task is due
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseTaskRequest) Reset() {
+	*x = LeaseTaskRequest{}
+	mi := &file_api_dbos_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseTaskRequest) ProtoMessage() {}
+
+func (x *LeaseTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseTaskRequest.ProtoReflect.Descriptor instead.
+func (*LeaseTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *LeaseTaskRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *LeaseTaskRequest) GetWaitSeconds() int64 {
+	if x != nil {
+		return x.WaitSeconds
+	}
+	return 0
+}
+
+type LeaseTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Backoff       *Backoff               `protobuf:"bytes,5,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseTaskResponse) Reset() {
+	*x = LeaseTaskResponse{}
+	mi := &file_api_dbos_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseTaskResponse) ProtoMessage() {}
+
+func (x *LeaseTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseTaskResponse.ProtoReflect.Descriptor instead.
+func (*LeaseTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *LeaseTaskResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *LeaseTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *LeaseTaskResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *LeaseTaskResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *LeaseTaskResponse) GetBackoff() *Backoff {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+// AckTasksRequest reports tasks an agent leased as completed
+type AckTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	TaskIds       []string               `protobuf:"bytes,2,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"` // at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckTasksRequest) Reset() {
+	*x = AckTasksRequest{}
+	mi := &file_api_dbos_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckTasksRequest) ProtoMessage() {}
+
+func (x *AckTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckTasksRequest.ProtoReflect.Descriptor instead.
+func (*AckTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *AckTasksRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AckTasksRequest) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+// TaskAck is the outcome of acknowledging one task of a batch
+type TaskAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskAck) Reset() {
+	*x = TaskAck{}
+	mi := &file_api_dbos_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskAck) ProtoMessage() {}
+
+func (x *TaskAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskAck.ProtoReflect.Descriptor instead.
+func (*TaskAck) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *TaskAck) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskAck) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TaskAck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TaskAck) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type AckTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*TaskAck             `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // in the order of task_ids
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckTasksResponse) Reset() {
+	*x = AckTasksResponse{}
+	mi := &file_api_dbos_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckTasksResponse) ProtoMessage() {}
+
+func (x *AckTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckTasksResponse.ProtoReflect.Descriptor instead.
+func (*AckTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *AckTasksResponse) GetResults() []*TaskAck {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *AckTasksResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AckTasksResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// NackTasksRequest hands back tasks an agent leased but could not complete
+type NackTasksRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AgentId           string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	TaskIds           []string               `protobuf:"bytes,2,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`                                  // at most 1000
+	Requeue           bool                   `protobuf:"varint,3,opt,name=requeue,proto3" json:"requeue,omitempty"`                                                // schedule the tasks again instead of failing them
+	RetryDelaySeconds int64                  `protobuf:"varint,4,opt,name=retry_delay_seconds,json=retryDelaySeconds,proto3" json:"retry_delay_seconds,omitempty"` // least delay before requeued tasks are due again; tasks with a retry policy may wait longer
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *NackTasksRequest) Reset() {
+	*x = NackTasksRequest{}
+	mi := &file_api_dbos_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NackTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackTasksRequest) ProtoMessage() {}
+
+func (x *NackTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackTasksRequest.ProtoReflect.Descriptor instead.
+func (*NackTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *NackTasksRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *NackTasksRequest) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+func (x *NackTasksRequest) GetRequeue() bool {
+	if x != nil {
+		return x.Requeue
+	}
+	return false
+}
+
+func (x *NackTasksRequest) GetRetryDelaySeconds() int64 {
+	if x != nil {
+		return x.RetryDelaySeconds
+	}
+	return 0
+}
+
+type NackTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*TaskAck             `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // in the order of task_ids
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NackTasksResponse) Reset() {
+	*x = NackTasksResponse{}
+	mi := &file_api_dbos_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NackTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackTasksResponse) ProtoMessage() {}
+
+func (x *NackTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackTasksResponse.ProtoReflect.Descriptor instead.
+func (*NackTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *NackTasksResponse) GetResults() []*TaskAck {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *NackTasksResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *NackTasksResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// ExtendTaskVisibilityRequest renews the lease an agent holds on a running task
+type ExtendTaskVisibilityRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AgentId         string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	TaskId          string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // the lease ends this long from now
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExtendTaskVisibilityRequest) Reset() {
+	*x = ExtendTaskVisibilityRequest{}
+	mi := &file_api_dbos_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendTaskVisibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendTaskVisibilityRequest) ProtoMessage() {}
+
+func (x *ExtendTaskVisibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendTaskVisibilityRequest.ProtoReflect.Descriptor instead.
+func (*ExtendTaskVisibilityRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *ExtendTaskVisibilityRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ExtendTaskVisibilityRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ExtendTaskVisibilityRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type ExtendTaskVisibilityResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	LeaseExpiresAt int64                  `protobuf:"varint,2,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`
+	Error          string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode      string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExtendTaskVisibilityResponse) Reset() {
+	*x = ExtendTaskVisibilityResponse{}
+	mi := &file_api_dbos_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtendTaskVisibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtendTaskVisibilityResponse) ProtoMessage() {}
+
+func (x *ExtendTaskVisibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtendTaskVisibilityResponse.ProtoReflect.Descriptor instead.
+func (*ExtendTaskVisibilityResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *ExtendTaskVisibilityResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExtendTaskVisibilityResponse) GetLeaseExpiresAt() int64 {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return 0
+}
+
+func (x *ExtendTaskVisibilityResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExtendTaskVisibilityResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// TaskProgress is how far an agent got with a running task, as it last
+// reported
+type TaskProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Percent       float64                `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"` // 0 to 100
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"` // JSON-encoded partial results, if any
+	ReportedAt    int64                  `protobuf:"varint,6,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskProgress) Reset() {
+	*x = TaskProgress{}
+	mi := &file_api_dbos_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskProgress) ProtoMessage() {}
+
+func (x *TaskProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskProgress.ProtoReflect.Descriptor instead.
+func (*TaskProgress) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *TaskProgress) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *TaskProgress) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TaskProgress) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TaskProgress) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TaskProgress) GetReportedAt() int64 {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return 0
+}
+
+// ReportTaskProgressRequest reports how far the agent got with a task it
+// holds a lease on, replacing the progress it reported before
+type ReportTaskProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Percent       float64                `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Data          []byte                 `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"` // JSON-encoded partial results
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportTaskProgressRequest) Reset() {
+	*x = ReportTaskProgressRequest{}
+	mi := &file_api_dbos_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportTaskProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportTaskProgressRequest) ProtoMessage() {}
+
+func (x *ReportTaskProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportTaskProgressRequest.ProtoReflect.Descriptor instead.
+func (*ReportTaskProgressRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *ReportTaskProgressRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ReportTaskProgressRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ReportTaskProgressRequest) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *ReportTaskProgressRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReportTaskProgressRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ReportTaskProgressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportTaskProgressResponse) Reset() {
+	*x = ReportTaskProgressResponse{}
+	mi := &file_api_dbos_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportTaskProgressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportTaskProgressResponse) ProtoMessage() {}
+
+func (x *ReportTaskProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportTaskProgressResponse.ProtoReflect.Descriptor instead.
+func (*ReportTaskProgressResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *ReportTaskProgressResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReportTaskProgressResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ReportTaskProgressResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Verification is one logical measurement run redundantly on independent agents
+type Verification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ModuleName    string                 `protobuf:"bytes,2,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Replicas      int32                  `protobuf:"varint,4,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	CompareField  string                 `protobuf:"bytes,5,opt,name=compare_field,json=compareField,proto3" json:"compare_field,omitempty"` // JSON path compared across replica results
+	Tolerance     float64                `protobuf:"fixed64,6,opt,name=tolerance,proto3" json:"tolerance,omitempty"`                         // max spread of numeric values; non-numeric values must match exactly
+	AgentIds      []string               `protobuf:"bytes,7,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	TaskIds       []string               `protobuf:"bytes,8,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`
+	Values        map[string]string      `protobuf:"bytes,9,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // agent ID -> compared value reported
+	Status        string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`                                                                          // "pending", "verified" or "disagreed"
+	CreatedAt     int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Verification) Reset() {
+	*x = Verification{}
+	mi := &file_api_dbos_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Verification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Verification) ProtoMessage() {}
+
+func (x *Verification) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Verification.ProtoReflect.Descriptor instead.
+func (*Verification) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *Verification) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Verification) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *Verification) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Verification) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *Verification) GetCompareField() string {
+	if x != nil {
+		return x.CompareField
+	}
+	return ""
+}
+
+func (x *Verification) GetTolerance() float64 {
+	if x != nil {
+		return x.Tolerance
+	}
+	return 0
+}
+
+func (x *Verification) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *Verification) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+func (x *Verification) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *Verification) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Verification) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ScheduleVerifiedTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verification  *Verification          `protobuf:"bytes,1,opt,name=verification,proto3" json:"verification,omitempty"`
+	Selector      map[string]string      `protobuf:"bytes,2,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // candidate agents by label; empty considers all live agents
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleVerifiedTaskRequest) Reset() {
+	*x = ScheduleVerifiedTaskRequest{}
+	mi := &file_api_dbos_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleVerifiedTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleVerifiedTaskRequest) ProtoMessage() {}
+
+func (x *ScheduleVerifiedTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleVerifiedTaskRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleVerifiedTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *ScheduleVerifiedTaskRequest) GetVerification() *Verification {
+	if x != nil {
+		return x.Verification
+	}
+	return nil
+}
+
+func (x *ScheduleVerifiedTaskRequest) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+type ScheduleVerifiedTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Verification  *Verification          `protobuf:"bytes,2,opt,name=verification,proto3" json:"verification,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleVerifiedTaskResponse) Reset() {
+	*x = ScheduleVerifiedTaskResponse{}
+	mi := &file_api_dbos_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleVerifiedTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleVerifiedTaskResponse) ProtoMessage() {}
+
+func (x *ScheduleVerifiedTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleVerifiedTaskResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleVerifiedTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *ScheduleVerifiedTaskResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScheduleVerifiedTaskResponse) GetVerification() *Verification {
+	if x != nil {
+		return x.Verification
+	}
+	return nil
+}
+
+func (x *ScheduleVerifiedTaskResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ScheduleVerifiedTaskResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type GetVerificationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VerificationId string                 `protobuf:"bytes,1,opt,name=verification_id,json=verificationId,proto3" json:"verification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetVerificationRequest) Reset() {
+	*x = GetVerificationRequest{}
+	mi := &file_api_dbos_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVerificationRequest) ProtoMessage() {}
+
+func (x *GetVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVerificationRequest.ProtoReflect.Descriptor instead.
+func (*GetVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *GetVerificationRequest) GetVerificationId() string {
+	if x != nil {
+		return x.VerificationId
+	}
+	return ""
+}
+
+type GetVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Verification  *Verification          `protobuf:"bytes,2,opt,name=verification,proto3" json:"verification,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVerificationResponse) Reset() {
+	*x = GetVerificationResponse{}
+	mi := &file_api_dbos_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVerificationResponse) ProtoMessage() {}
+
+func (x *GetVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVerificationResponse.ProtoReflect.Descriptor instead.
+func (*GetVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *GetVerificationResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetVerificationResponse) GetVerification() *Verification {
+	if x != nil {
+		return x.Verification
+	}
+	return nil
+}
+
+func (x *GetVerificationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetVerificationResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Broadcast is one task definition fanned out to every live agent, or every
+// live agent matching a selector, when it was broadcast
+type Broadcast struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ModuleName    string                 `protobuf:"bytes,2,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Selector      map[string]string      `protobuf:"bytes,4,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // agents by label; empty for all
+	AgentIds      []string               `protobuf:"bytes,5,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`                                                           // agents the task was fanned out to
+	TaskIds       []string               `protobuf:"bytes,6,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`                                                              // in the order of agent_ids
+	ScheduledAt   int64                  `protobuf:"varint,7,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CorrelationId string                 `protobuf:"bytes,9,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Broadcast) Reset() {
+	*x = Broadcast{}
+	mi := &file_api_dbos_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Broadcast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Broadcast) ProtoMessage() {}
+
+func (x *Broadcast) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Broadcast.ProtoReflect.Descriptor instead.
+func (*Broadcast) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *Broadcast) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Broadcast) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *Broadcast) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Broadcast) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *Broadcast) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *Broadcast) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+func (x *Broadcast) GetScheduledAt() int64 {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return 0
+}
+
+func (x *Broadcast) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Broadcast) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+// BroadcastTaskRequest fans a task out to the live agents matching a
+// selector. The task's module_name, payload, scheduled_at, correlation_id,
+// lease_timeout_seconds and retry_policy are given to each agent's task;
+// its agent_id and selector must be empty and it must be one-shot.
+type BroadcastTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BroadcastId   string                 `protobuf:"bytes,1,opt,name=broadcast_id,json=broadcastId,proto3" json:"broadcast_id,omitempty"` // minted if empty
+	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Selector      map[string]string      `protobuf:"bytes,3,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // agents by label; empty for all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastTaskRequest) Reset() {
+	*x = BroadcastTaskRequest{}
+	mi := &file_api_dbos_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastTaskRequest) ProtoMessage() {}
+
+func (x *BroadcastTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastTaskRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *BroadcastTaskRequest) GetBroadcastId() string {
+	if x != nil {
+		return x.BroadcastId
+	}
+	return ""
+}
+
+func (x *BroadcastTaskRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *BroadcastTaskRequest) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+type BroadcastTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Broadcast     *Broadcast             `protobuf:"bytes,2,opt,name=broadcast,proto3" json:"broadcast,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastTaskResponse) Reset() {
+	*x = BroadcastTaskResponse{}
+	mi := &file_api_dbos_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastTaskResponse) ProtoMessage() {}
+
+func (x *BroadcastTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastTaskResponse.ProtoReflect.Descriptor instead.
+func (*BroadcastTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *BroadcastTaskResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BroadcastTaskResponse) GetBroadcast() *Broadcast {
+	if x != nil {
+		return x.Broadcast
+	}
+	return nil
+}
+
+func (x *BroadcastTaskResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BroadcastTaskResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type GetBroadcastStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BroadcastId   string                 `protobuf:"bytes,1,opt,name=broadcast_id,json=broadcastId,proto3" json:"broadcast_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBroadcastStatusRequest) Reset() {
+	*x = GetBroadcastStatusRequest{}
+	mi := &file_api_dbos_proto_msgTypes[145]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBroadcastStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBroadcastStatusRequest) ProtoMessage() {}
+
+func (x *GetBroadcastStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[145]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBroadcastStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBroadcastStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{145}
+}
+
+func (x *GetBroadcastStatusRequest) GetBroadcastId() string {
+	if x != nil {
+		return x.BroadcastId
+	}
+	return ""
+}
+
+// BroadcastAgentStatus is the progress of one agent's task of a broadcast
+type BroadcastAgentStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // "pending", "running", "completed", "failed", "cancelled" or "unknown" if the task was deleted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastAgentStatus) Reset() {
+	*x = BroadcastAgentStatus{}
+	mi := &file_api_dbos_proto_msgTypes[146]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastAgentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastAgentStatus) ProtoMessage() {}
+
+func (x *BroadcastAgentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[146]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastAgentStatus.ProtoReflect.Descriptor instead.
+func (*BroadcastAgentStatus) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *BroadcastAgentStatus) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *BroadcastAgentStatus) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *BroadcastAgentStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetBroadcastStatusResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Found         bool                    `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Broadcast     *Broadcast              `protobuf:"bytes,2,opt,name=broadcast,proto3" json:"broadcast,omitempty"`
+	Agents        []*BroadcastAgentStatus `protobuf:"bytes,3,rep,name=agents,proto3" json:"agents,omitempty"` // in the order of the broadcast's agent_ids
+	Pending       int32                   `protobuf:"varint,4,opt,name=pending,proto3" json:"pending,omitempty"`
+	Running       int32                   `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
+	Completed     int32                   `protobuf:"varint,6,opt,name=completed,proto3" json:"completed,omitempty"`
+	Failed        int32                   `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"`
+	Cancelled     int32                   `protobuf:"varint,8,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	Done          bool                    `protobuf:"varint,9,opt,name=done,proto3" json:"done,omitempty"` // every agent's task has finished
+	Error         string                  `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                  `protobuf:"bytes,11,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBroadcastStatusResponse) Reset() {
+	*x = GetBroadcastStatusResponse{}
+	mi := &file_api_dbos_proto_msgTypes[147]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBroadcastStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBroadcastStatusResponse) ProtoMessage() {}
+
+func (x *GetBroadcastStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[147]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBroadcastStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBroadcastStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *GetBroadcastStatusResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetBroadcastStatusResponse) GetBroadcast() *Broadcast {
+	if x != nil {
+		return x.Broadcast
+	}
+	return nil
+}
+
+func (x *GetBroadcastStatusResponse) GetAgents() []*BroadcastAgentStatus {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *GetBroadcastStatusResponse) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *GetBroadcastStatusResponse) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *GetBroadcastStatusResponse) GetCompleted() int32 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *GetBroadcastStatusResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *GetBroadcastStatusResponse) GetCancelled() int32 {
+	if x != nil {
+		return x.Cancelled
+	}
+	return 0
+}
+
+func (x *GetBroadcastStatusResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *GetBroadcastStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetBroadcastStatusResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// View is a materialized view over results, maintained at ingest
+type View struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`                               // "latest" (most recent result per agent and key) or "daily" (per-day aggregates)
+	ModuleName    string                 `protobuf:"bytes,3,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"` // only results of this module feed the view; empty for all
+	KeyField      string                 `protobuf:"bytes,4,opt,name=key_field,json=keyField,proto3" json:"key_field,omitempty"`       // JSON path of the row key in result data, e.g. "target"
+	ValueField    string                 `protobuf:"bytes,5,opt,name=value_field,json=valueField,proto3" json:"value_field,omitempty"` // JSON path of the numeric value aggregated by daily views
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *View) Reset() {
+	*x = View{}
+	mi := &file_api_dbos_proto_msgTypes[148]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *View) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*View) ProtoMessage() {}
+
+func (x *View) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[148]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use View.ProtoReflect.Descriptor instead.
+func (*View) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{148}
+}
+
+func (x *View) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *View) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *View) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *View) GetKeyField() string {
+	if x != nil {
+		return x.KeyField
+	}
+	return ""
+}
+
+func (x *View) GetValueField() string {
+	if x != nil {
+		return x.ValueField
+	}
+	return ""
+}
+
+func (x *View) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ViewRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Day           string                 `protobuf:"bytes,3,opt,name=day,proto3" json:"day,omitempty"`
+	ResultId      string                 `protobuf:"bytes,4,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Data          []byte                 `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	Count         int64                  `protobuf:"varint,7,opt,name=count,proto3" json:"count,omitempty"`
+	Sum           float64                `protobuf:"fixed64,8,opt,name=sum,proto3" json:"sum,omitempty"`
+	Min           float64                `protobuf:"fixed64,9,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,10,opt,name=max,proto3" json:"max,omitempty"`
+	Avg           float64                `protobuf:"fixed64,11,opt,name=avg,proto3" json:"avg,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewRow) Reset() {
+	*x = ViewRow{}
+	mi := &file_api_dbos_proto_msgTypes[149]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewRow) ProtoMessage() {}
+
+func (x *ViewRow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[149]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewRow.ProtoReflect.Descriptor instead.
+func (*ViewRow) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{149}
+}
+
+func (x *ViewRow) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ViewRow) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ViewRow) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *ViewRow) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+func (x *ViewRow) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ViewRow) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ViewRow) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ViewRow) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *ViewRow) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *ViewRow) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *ViewRow) GetAvg() float64 {
+	if x != nil {
+		return x.Avg
+	}
+	return 0
+}
+
+type CreateViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	View          *View                  `protobuf:"bytes,1,opt,name=view,proto3" json:"view,omitempty"`
+	Backfill      bool                   `protobuf:"varint,2,opt,name=backfill,proto3" json:"backfill,omitempty"` // fold already stored results into the new view
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateViewRequest) Reset() {
+	*x = CreateViewRequest{}
+	mi := &file_api_dbos_proto_msgTypes[150]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateViewRequest) ProtoMessage() {}
+
+func (x *CreateViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[150]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateViewRequest.ProtoReflect.Descriptor instead.
+func (*CreateViewRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{150}
+}
+
+func (x *CreateViewRequest) GetView() *View {
+	if x != nil {
+		return x.View
+	}
+	return nil
+}
+
+func (x *CreateViewRequest) GetBackfill() bool {
+	if x != nil {
+		return x.Backfill
+	}
+	return false
+}
+
+type CreateViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateViewResponse) Reset() {
+	*x = CreateViewResponse{}
+	mi := &file_api_dbos_proto_msgTypes[151]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateViewResponse) ProtoMessage() {}
+
+func (x *CreateViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[151]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateViewResponse.ProtoReflect.Descriptor instead.
+func (*CreateViewResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{151}
+}
+
+func (x *CreateViewResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateViewResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateViewResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListViewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViewsRequest) Reset() {
+	*x = ListViewsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[152]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViewsRequest) ProtoMessage() {}
+
+func (x *ListViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[152]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViewsRequest.ProtoReflect.Descriptor instead.
+func (*ListViewsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{152}
+}
+
+type ListViewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Views         []*View                `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViewsResponse) Reset() {
+	*x = ListViewsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[153]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViewsResponse) ProtoMessage() {}
+
+func (x *ListViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[153]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViewsResponse.ProtoReflect.Descriptor instead.
+func (*ListViewsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{153}
+}
+
+func (x *ListViewsResponse) GetViews() []*View {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+func (x *ListViewsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListViewsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type DeleteViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteViewRequest) Reset() {
+	*x = DeleteViewRequest{}
+	mi := &file_api_dbos_proto_msgTypes[154]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteViewRequest) ProtoMessage() {}
+
+func (x *DeleteViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[154]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteViewRequest.ProtoReflect.Descriptor instead.
+func (*DeleteViewRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{154}
+}
+
+func (x *DeleteViewRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteViewResponse) Reset() {
+	*x = DeleteViewResponse{}
+	mi := &file_api_dbos_proto_msgTypes[155]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteViewResponse) ProtoMessage() {}
+
+func (x *DeleteViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[155]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteViewResponse.ProtoReflect.Descriptor instead.
+func (*DeleteViewResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{155}
+}
+
+func (x *DeleteViewResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteViewResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeleteViewResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type QueryViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional filter
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`                        // optional filter
+	Day           string                 `protobuf:"bytes,4,opt,name=day,proto3" json:"day,omitempty"`                        // daily views only, "YYYY-MM-DD" (UTC); defaults to today
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryViewRequest) Reset() {
+	*x = QueryViewRequest{}
+	mi := &file_api_dbos_proto_msgTypes[156]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryViewRequest) ProtoMessage() {}
+
+func (x *QueryViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[156]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryViewRequest.ProtoReflect.Descriptor instead.
+func (*QueryViewRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{156}
+}
+
+func (x *QueryViewRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueryViewRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *QueryViewRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *QueryViewRequest) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+type QueryViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*ViewRow             `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryViewResponse) Reset() {
+	*x = QueryViewResponse{}
+	mi := &file_api_dbos_proto_msgTypes[157]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryViewResponse) ProtoMessage() {}
+
+func (x *QueryViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[157]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryViewResponse.ProtoReflect.Descriptor instead.
+func (*QueryViewResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{157}
+}
+
+func (x *QueryViewResponse) GetRows() []*ViewRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *QueryViewResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *QueryViewResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// ExtractionRule extracts a field of a module's results into an indexed
+// column at ingest, so results can be filtered on it without scanning payloads
+type ExtractionRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleName    string                 `protobuf:"bytes,1,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Column        string                 `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"` // letters, digits and underscores, e.g. "latency_ms"
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`     // JSON path of the value in result data, e.g. "rtt.avg"
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`     // "number" or "string"
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExtractionRule) Reset() {
+	*x = ExtractionRule{}
+	mi := &file_api_dbos_proto_msgTypes[158]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtractionRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtractionRule) ProtoMessage() {}
+
+func (x *ExtractionRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[158]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtractionRule.ProtoReflect.Descriptor instead.
+func (*ExtractionRule) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{158}
+}
+
+func (x *ExtractionRule) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *ExtractionRule) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *ExtractionRule) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExtractionRule) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ExtractionRule) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type CreateExtractionRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *ExtractionRule        `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Backfill      bool                   `protobuf:"varint,2,opt,name=backfill,proto3" json:"backfill,omitempty"` // index already stored results of the module
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExtractionRuleRequest) Reset() {
+	*x = CreateExtractionRuleRequest{}
+	mi := &file_api_dbos_proto_msgTypes[159]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExtractionRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExtractionRuleRequest) ProtoMessage() {}
+
+func (x *CreateExtractionRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[159]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExtractionRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateExtractionRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{159}
+}
+
+func (x *CreateExtractionRuleRequest) GetRule() *ExtractionRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *CreateExtractionRuleRequest) GetBackfill() bool {
+	if x != nil {
+		return x.Backfill
+	}
+	return false
+}
+
+type CreateExtractionRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExtractionRuleResponse) Reset() {
+	*x = CreateExtractionRuleResponse{}
+	mi := &file_api_dbos_proto_msgTypes[160]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExtractionRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExtractionRuleResponse) ProtoMessage() {}
+
+func (x *CreateExtractionRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[160]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExtractionRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateExtractionRuleResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{160}
+}
+
+func (x *CreateExtractionRuleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateExtractionRuleResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateExtractionRuleResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListExtractionRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleName    string                 `protobuf:"bytes,1,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"` // optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExtractionRulesRequest) Reset() {
+	*x = ListExtractionRulesRequest{}
+	mi := &file_api_dbos_proto_msgTypes[161]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExtractionRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtractionRulesRequest) ProtoMessage() {}
+
+func (x *ListExtractionRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[161]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtractionRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListExtractionRulesRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{161}
+}
+
+func (x *ListExtractionRulesRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+type ListExtractionRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*ExtractionRule      `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExtractionRulesResponse) Reset() {
+	*x = ListExtractionRulesResponse{}
+	mi := &file_api_dbos_proto_msgTypes[162]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExtractionRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExtractionRulesResponse) ProtoMessage() {}
+
+func (x *ListExtractionRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[162]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExtractionRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListExtractionRulesResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{162}
+}
+
+func (x *ListExtractionRulesResponse) GetRules() []*ExtractionRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *ListExtractionRulesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListExtractionRulesResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type DeleteExtractionRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleName    string                 `protobuf:"bytes,1,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Column        string                 `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteExtractionRuleRequest) Reset() {
+	*x = DeleteExtractionRuleRequest{}
+	mi := &file_api_dbos_proto_msgTypes[163]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteExtractionRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteExtractionRuleRequest) ProtoMessage() {}
+
+func (x *DeleteExtractionRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[163]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteExtractionRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteExtractionRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{163}
+}
+
+func (x *DeleteExtractionRuleRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *DeleteExtractionRuleRequest) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+type DeleteExtractionRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteExtractionRuleResponse) Reset() {
+	*x = DeleteExtractionRuleResponse{}
+	mi := &file_api_dbos_proto_msgTypes[164]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteExtractionRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteExtractionRuleResponse) ProtoMessage() {}
+
+func (x *DeleteExtractionRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[164]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteExtractionRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteExtractionRuleResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{164}
+}
+
+func (x *DeleteExtractionRuleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteExtractionRuleResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeleteExtractionRuleResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// ColumnFilter compares an extracted column with a value, e.g. latency_ms > 100
+type ColumnFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Column        string                 `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Op            string                 `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"` // "=", "<", "<=", ">" or ">="; string columns support "=" only
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColumnFilter) Reset() {
+	*x = ColumnFilter{}
+	mi := &file_api_dbos_proto_msgTypes[165]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColumnFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnFilter) ProtoMessage() {}
+
+func (x *ColumnFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[165]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnFilter.ProtoReflect.Descriptor instead.
+func (*ColumnFilter) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{165}
+}
+
+func (x *ColumnFilter) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *ColumnFilter) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ColumnFilter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type QueryResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleName    string                 `protobuf:"bytes,1,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Filters       []*ColumnFilter        `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`                // all must match; the first is scanned, so put the most selective first
+	AgentId       string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // optional filter
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                   // default 100, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResultsRequest) Reset() {
+	*x = QueryResultsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[166]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResultsRequest) ProtoMessage() {}
+
+func (x *QueryResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[166]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResultsRequest.ProtoReflect.Descriptor instead.
+func (*QueryResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{166}
+}
+
+func (x *QueryResultsRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *QueryResultsRequest) GetFilters() []*ColumnFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *QueryResultsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *QueryResultsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type QueryResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*MeasurementResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // ordered by the first filter's column
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResultsResponse) Reset() {
+	*x = QueryResultsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[167]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResultsResponse) ProtoMessage() {}
+
+func (x *QueryResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[167]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResultsResponse.ProtoReflect.Descriptor instead.
+func (*QueryResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{167}
+}
+
+func (x *QueryResultsResponse) GetResults() []*MeasurementResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *QueryResultsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *QueryResultsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// SavedQuery is a named query over results or agents, referenced by
+// dashboards and alert rules instead of repeating its filters
+type SavedQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Target        string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`                                                                           // "results" or "agents"
+	ModuleName    string                 `protobuf:"bytes,4,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`                                                 // results: the module whose extracted columns are filtered
+	Filters       []*ColumnFilter        `protobuf:"bytes,5,rep,name=filters,proto3" json:"filters,omitempty"`                                                                         // results: all must match, as in QueryResults
+	AgentId       string                 `protobuf:"bytes,6,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                                                          // results: optional filter
+	Labels        map[string]string      `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // agents: labels the agents must carry
+	AliveOnly     bool                   `protobuf:"varint,8,opt,name=alive_only,json=aliveOnly,proto3" json:"alive_only,omitempty"`                                                   // agents: only live agents
+	Aggregation   *Aggregation           `protobuf:"bytes,9,opt,name=aggregation,proto3" json:"aggregation,omitempty"`                                                                 // optional; the query then executes to a single value
+	Limit         int32                  `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`                                                                           // rows returned without aggregation; default 100, at most 1000
+	CreatedAt     int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedQuery) Reset() {
+	*x = SavedQuery{}
+	mi := &file_api_dbos_proto_msgTypes[168]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedQuery) ProtoMessage() {}
+
+func (x *SavedQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[168]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedQuery.ProtoReflect.Descriptor instead.
+func (*SavedQuery) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{168}
+}
+
+func (x *SavedQuery) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedQuery) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SavedQuery) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SavedQuery) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *SavedQuery) GetFilters() []*ColumnFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *SavedQuery) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *SavedQuery) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SavedQuery) GetAliveOnly() bool {
+	if x != nil {
+		return x.AliveOnly
+	}
+	return false
+}
+
+func (x *SavedQuery) GetAggregation() *Aggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return nil
+}
+
+func (x *SavedQuery) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SavedQuery) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *SavedQuery) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Aggregation reduces the rows of a saved query to a single value
+type Aggregation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Function      string                 `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"` // "count", "sum", "avg", "min" or "max"; agents support "count" only
+	Column        string                 `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`     // results: the number column aggregated, unused by "count"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Aggregation) Reset() {
+	*x = Aggregation{}
+	mi := &file_api_dbos_proto_msgTypes[169]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Aggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aggregation) ProtoMessage() {}
+
+func (x *Aggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[169]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aggregation.ProtoReflect.Descriptor instead.
+func (*Aggregation) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{169}
+}
+
+func (x *Aggregation) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *Aggregation) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+type CreateSavedQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         *SavedQuery            `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSavedQueryRequest) Reset() {
+	*x = CreateSavedQueryRequest{}
+	mi := &file_api_dbos_proto_msgTypes[170]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSavedQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSavedQueryRequest) ProtoMessage() {}
+
+func (x *CreateSavedQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[170]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSavedQueryRequest.ProtoReflect.Descriptor instead.
+func (*CreateSavedQueryRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{170}
+}
+
+func (x *CreateSavedQueryRequest) GetQuery() *SavedQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type CreateSavedQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSavedQueryResponse) Reset() {
+	*x = CreateSavedQueryResponse{}
+	mi := &file_api_dbos_proto_msgTypes[171]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSavedQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSavedQueryResponse) ProtoMessage() {}
+
+func (x *CreateSavedQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[171]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSavedQueryResponse.ProtoReflect.Descriptor instead.
+func (*CreateSavedQueryResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{171}
+}
+
+func (x *CreateSavedQueryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateSavedQueryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateSavedQueryResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type GetSavedQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSavedQueryRequest) Reset() {
+	*x = GetSavedQueryRequest{}
+	mi := &file_api_dbos_proto_msgTypes[172]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSavedQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedQueryRequest) ProtoMessage() {}
+
+func (x *GetSavedQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[172]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSavedQueryRequest.ProtoReflect.Descriptor instead.
+func (*GetSavedQueryRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{172}
+}
+
+func (x *GetSavedQueryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetSavedQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Query         *SavedQuery            `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSavedQueryResponse) Reset() {
+	*x = GetSavedQueryResponse{}
+	mi := &file_api_dbos_proto_msgTypes[173]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSavedQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSavedQueryResponse) ProtoMessage() {}
+
+func (x *GetSavedQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[173]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSavedQueryResponse.ProtoReflect.Descriptor instead.
+func (*GetSavedQueryResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{173}
+}
+
+func (x *GetSavedQueryResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetSavedQueryResponse) GetQuery() *SavedQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *GetSavedQueryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetSavedQueryResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListSavedQueriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedQueriesRequest) Reset() {
+	*x = ListSavedQueriesRequest{}
+	mi := &file_api_dbos_proto_msgTypes[174]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedQueriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedQueriesRequest) ProtoMessage() {}
+
+func (x *ListSavedQueriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[174]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedQueriesRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedQueriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{174}
+}
+
+type ListSavedQueriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queries       []*SavedQuery          `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedQueriesResponse) Reset() {
+	*x = ListSavedQueriesResponse{}
+	mi := &file_api_dbos_proto_msgTypes[175]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedQueriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedQueriesResponse) ProtoMessage() {}
+
+func (x *ListSavedQueriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[175]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedQueriesResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedQueriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{175}
+}
+
+func (x *ListSavedQueriesResponse) GetQueries() []*SavedQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *ListSavedQueriesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListSavedQueriesResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type UpdateSavedQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         *SavedQuery            `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"` // replaces the query of the same name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSavedQueryRequest) Reset() {
+	*x = UpdateSavedQueryRequest{}
+	mi := &file_api_dbos_proto_msgTypes[176]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSavedQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSavedQueryRequest) ProtoMessage() {}
+
+func (x *UpdateSavedQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[176]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSavedQueryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSavedQueryRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{176}
+}
+
+func (x *UpdateSavedQueryRequest) GetQuery() *SavedQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type UpdateSavedQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSavedQueryResponse) Reset() {
+	*x = UpdateSavedQueryResponse{}
+	mi := &file_api_dbos_proto_msgTypes[177]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSavedQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSavedQueryResponse) ProtoMessage() {}
+
+func (x *UpdateSavedQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[177]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSavedQueryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSavedQueryResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{177}
+}
+
+func (x *UpdateSavedQueryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateSavedQueryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *UpdateSavedQueryResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type DeleteSavedQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedQueryRequest) Reset() {
+	*x = DeleteSavedQueryRequest{}
+	mi := &file_api_dbos_proto_msgTypes[178]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedQueryRequest) ProtoMessage() {}
+
+func (x *DeleteSavedQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[178]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedQueryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSavedQueryRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{178}
+}
+
+func (x *DeleteSavedQueryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteSavedQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSavedQueryResponse) Reset() {
+	*x = DeleteSavedQueryResponse{}
+	mi := &file_api_dbos_proto_msgTypes[179]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSavedQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSavedQueryResponse) ProtoMessage() {}
+
+func (x *DeleteSavedQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[179]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSavedQueryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSavedQueryResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{179}
+}
+
+func (x *DeleteSavedQueryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteSavedQueryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeleteSavedQueryResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ExecuteSavedQueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteSavedQueryRequest) Reset() {
+	*x = ExecuteSavedQueryRequest{}
+	mi := &file_api_dbos_proto_msgTypes[180]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteSavedQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteSavedQueryRequest) ProtoMessage() {}
+
+func (x *ExecuteSavedQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[180]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteSavedQueryRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteSavedQueryRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{180}
+}
+
+func (x *ExecuteSavedQueryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ExecuteSavedQueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*MeasurementResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // results queries without aggregation
+	Agents        []*Agent               `protobuf:"bytes,2,rep,name=agents,proto3" json:"agents,omitempty"`   // agents queries without aggregation
+	Aggregated    bool                   `protobuf:"varint,3,opt,name=aggregated,proto3" json:"aggregated,omitempty"`
+	Value         float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`        // the aggregate; 0 if no rows had a value
+	Count         int64                  `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`         // rows aggregated
+	Truncated     bool                   `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"` // the aggregate covers only the first rows scanned
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,8,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteSavedQueryResponse) Reset() {
+	*x = ExecuteSavedQueryResponse{}
+	mi := &file_api_dbos_proto_msgTypes[181]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteSavedQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteSavedQueryResponse) ProtoMessage() {}
+
+func (x *ExecuteSavedQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[181]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteSavedQueryResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteSavedQueryResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{181}
+}
+
+func (x *ExecuteSavedQueryResponse) GetResults() []*MeasurementResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ExecuteSavedQueryResponse) GetAgents() []*Agent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *ExecuteSavedQueryResponse) GetAggregated() bool {
+	if x != nil {
+		return x.Aggregated
+	}
+	return false
+}
+
+func (x *ExecuteSavedQueryResponse) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ExecuteSavedQueryResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ExecuteSavedQueryResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *ExecuteSavedQueryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExecuteSavedQueryResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// AlertRule is a condition over rollup metrics, e.g. "avg(ping_rtt) > 150"
+// over today's rows of a daily view or "slow_probes >= 10" over the aggregate
+// of a saved query. A series fires once the condition has held for
+// for_seconds, raising an alert and an incident.
+type AlertRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Expr          string                 `protobuf:"bytes,2,opt,name=expr,proto3" json:"expr,omitempty"`
+	ForSeconds    int64                  `protobuf:"varint,3,opt,name=for_seconds,json=forSeconds,proto3" json:"for_seconds,omitempty"`
+	Severity      string                 `protobuf:"bytes,4,opt,name=severity,proto3" json:"severity,omitempty"` // "info", "warning" or "critical"
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Series        []*AlertSeries         `protobuf:"bytes,7,rep,name=series,proto3" json:"series,omitempty"` // series the condition currently holds for
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertRule) Reset() {
+	*x = AlertRule{}
+	mi := &file_api_dbos_proto_msgTypes[182]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule) ProtoMessage() {}
+
+func (x *AlertRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[182]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule.ProtoReflect.Descriptor instead.
+func (*AlertRule) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{182}
+}
+
+func (x *AlertRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AlertRule) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *AlertRule) GetForSeconds() int64 {
+	if x != nil {
+		return x.ForSeconds
+	}
+	return 0
+}
+
+func (x *AlertRule) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *AlertRule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AlertRule) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *AlertRule) GetSeries() []*AlertSeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
+// AlertSeries is a series an alert rule's condition holds for
+type AlertSeries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        string                 `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // empty for saved query rules
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`                        // the view row key; empty for saved query rules
+	Value         float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	ActiveSince   int64                  `protobuf:"varint,5,opt,name=active_since,json=activeSince,proto3" json:"active_since,omitempty"`
+	Firing        bool                   `protobuf:"varint,6,opt,name=firing,proto3" json:"firing,omitempty"` // false while pending
+	FiredAt       int64                  `protobuf:"varint,7,opt,name=fired_at,json=firedAt,proto3" json:"fired_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertSeries) Reset() {
+	*x = AlertSeries{}
+	mi := &file_api_dbos_proto_msgTypes[183]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertSeries) ProtoMessage() {}
+
+func (x *AlertSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[183]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertSeries.ProtoReflect.Descriptor instead.
+func (*AlertSeries) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{183}
+}
+
+func (x *AlertSeries) GetSeries() string {
+	if x != nil {
+		return x.Series
+	}
+	return ""
+}
+
+func (x *AlertSeries) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AlertSeries) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AlertSeries) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *AlertSeries) GetActiveSince() int64 {
+	if x != nil {
+		return x.ActiveSince
+	}
+	return 0
+}
+
+func (x *AlertSeries) GetFiring() bool {
+	if x != nil {
+		return x.Firing
+	}
+	return false
+}
+
+func (x *AlertSeries) GetFiredAt() int64 {
+	if x != nil {
+		return x.FiredAt
+	}
+	return 0
+}
+
+type CreateAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *AlertRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertRuleRequest) Reset() {
+	*x = CreateAlertRuleRequest{}
+	mi := &file_api_dbos_proto_msgTypes[184]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRuleRequest) ProtoMessage() {}
+
+func (x *CreateAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[184]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{184}
+}
+
+func (x *CreateAlertRuleRequest) GetRule() *AlertRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type CreateAlertRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertRuleResponse) Reset() {
+	*x = CreateAlertRuleResponse{}
+	mi := &file_api_dbos_proto_msgTypes[185]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRuleResponse) ProtoMessage() {}
+
+func (x *CreateAlertRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[185]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateAlertRuleResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{185}
+}
+
+func (x *CreateAlertRuleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateAlertRuleResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateAlertRuleResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListAlertRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesRequest) Reset() {
+	*x = ListAlertRulesRequest{}
+	mi := &file_api_dbos_proto_msgTypes[186]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesRequest) ProtoMessage() {}
+
+func (x *ListAlertRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[186]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{186}
+}
+
+type ListAlertRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*AlertRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesResponse) Reset() {
+	*x = ListAlertRulesResponse{}
+	mi := &file_api_dbos_proto_msgTypes[187]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesResponse) ProtoMessage() {}
+
+func (x *ListAlertRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[187]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{187}
+}
+
+func (x *ListAlertRulesResponse) GetRules() []*AlertRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *ListAlertRulesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListAlertRulesResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type DeleteAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAlertRuleRequest) Reset() {
+	*x = DeleteAlertRuleRequest{}
+	mi := &file_api_dbos_proto_msgTypes[188]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAlertRuleRequest) ProtoMessage() {}
+
+func (x *DeleteAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[188]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{188}
+}
+
+func (x *DeleteAlertRuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteAlertRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAlertRuleResponse) Reset() {
+	*x = DeleteAlertRuleResponse{}
+	mi := &file_api_dbos_proto_msgTypes[189]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAlertRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAlertRuleResponse) ProtoMessage() {}
+
+func (x *DeleteAlertRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[189]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAlertRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAlertRuleResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{189}
+}
+
+func (x *DeleteAlertRuleResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteAlertRuleResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeleteAlertRuleResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// MaintenanceWindow suppresses alerts about the agents matching its selector
+// and tenant, if either is set, and about the targets it lists
+type MaintenanceWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Selector      map[string]string      `protobuf:"bytes,4,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // agent group by label
+	Tenant        string                 `protobuf:"bytes,5,opt,name=tenant,proto3" json:"tenant,omitempty"`                                                                               // agents labelled tenant=<tenant>
+	Targets       []string               `protobuf:"bytes,6,rep,name=targets,proto3" json:"targets,omitempty"`
+	StartsAt      int64                  `protobuf:"varint,7,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        int64                  `protobuf:"varint,8,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	PauseTasks    bool                   `protobuf:"varint,9,opt,name=pause_tasks,json=pauseTasks,proto3" json:"pause_tasks,omitempty"` // stop re-issuing continuous tasks of covered agents and targets
+	CreatedBy     string                 `protobuf:"bytes,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Active        bool                   `protobuf:"varint,12,opt,name=active,proto3" json:"active,omitempty"` // in progress now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceWindow) Reset() {
+	*x = MaintenanceWindow{}
+	mi := &file_api_dbos_proto_msgTypes[190]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceWindow) ProtoMessage() {}
+
+func (x *MaintenanceWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[190]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceWindow.ProtoReflect.Descriptor instead.
+func (*MaintenanceWindow) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{190}
+}
+
+func (x *MaintenanceWindow) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *MaintenanceWindow) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *MaintenanceWindow) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+func (x *MaintenanceWindow) GetPauseTasks() bool {
+	if x != nil {
+		return x.PauseTasks
+	}
+	return false
+}
+
+func (x *MaintenanceWindow) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *MaintenanceWindow) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *MaintenanceWindow) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type CreateMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMaintenanceWindowRequest) Reset() {
+	*x = CreateMaintenanceWindowRequest{}
+	mi := &file_api_dbos_proto_msgTypes[191]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *CreateMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[191]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMaintenanceWindowRequest.ProtoReflect.Descriptor instead.
+func (*CreateMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{191}
+}
+
+func (x *CreateMaintenanceWindowRequest) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type CreateMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMaintenanceWindowResponse) Reset() {
+	*x = CreateMaintenanceWindowResponse{}
+	mi := &file_api_dbos_proto_msgTypes[192]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *CreateMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[192]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMaintenanceWindowResponse.ProtoReflect.Descriptor instead.
+func (*CreateMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{192}
+}
+
+func (x *CreateMaintenanceWindowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateMaintenanceWindowResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateMaintenanceWindowResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *CreateMaintenanceWindowResponse) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type GetMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMaintenanceWindowRequest) Reset() {
+	*x = GetMaintenanceWindowRequest{}
+	mi := &file_api_dbos_proto_msgTypes[193]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *GetMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[193]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaintenanceWindowRequest.ProtoReflect.Descriptor instead.
+func (*GetMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{193}
+}
+
+func (x *GetMaintenanceWindowRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMaintenanceWindowResponse) Reset() {
+	*x = GetMaintenanceWindowResponse{}
+	mi := &file_api_dbos_proto_msgTypes[194]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *GetMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[194]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMaintenanceWindowResponse.ProtoReflect.Descriptor instead.
+func (*GetMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{194}
+}
+
+func (x *GetMaintenanceWindowResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetMaintenanceWindowResponse) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *GetMaintenanceWindowResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetMaintenanceWindowResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListMaintenanceWindowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActiveOnly    bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"` // only windows in progress now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceWindowsRequest) Reset() {
+	*x = ListMaintenanceWindowsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[195]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceWindowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceWindowsRequest) ProtoMessage() {}
+
+func (x *ListMaintenanceWindowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[195]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceWindowsRequest.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceWindowsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{195}
+}
+
+func (x *ListMaintenanceWindowsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+type ListMaintenanceWindowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Windows       []*MaintenanceWindow   `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceWindowsResponse) Reset() {
+	*x = ListMaintenanceWindowsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[196]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceWindowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceWindowsResponse) ProtoMessage() {}
+
+func (x *ListMaintenanceWindowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[196]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceWindowsResponse.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceWindowsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{196}
+}
+
+func (x *ListMaintenanceWindowsResponse) GetWindows() []*MaintenanceWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+func (x *ListMaintenanceWindowsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListMaintenanceWindowsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// UpdateMaintenanceWindowRequest replaces a window's definition
+type UpdateMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMaintenanceWindowRequest) Reset() {
+	*x = UpdateMaintenanceWindowRequest{}
+	mi := &file_api_dbos_proto_msgTypes[197]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *UpdateMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[197]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMaintenanceWindowRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{197}
+}
+
+func (x *UpdateMaintenanceWindowRequest) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type UpdateMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Window        *MaintenanceWindow     `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMaintenanceWindowResponse) Reset() {
+	*x = UpdateMaintenanceWindowResponse{}
+	mi := &file_api_dbos_proto_msgTypes[198]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *UpdateMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[198]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMaintenanceWindowResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{198}
+}
+
+func (x *UpdateMaintenanceWindowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateMaintenanceWindowResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *UpdateMaintenanceWindowResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *UpdateMaintenanceWindowResponse) GetWindow() *MaintenanceWindow {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type DeleteMaintenanceWindowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMaintenanceWindowRequest) Reset() {
+	*x = DeleteMaintenanceWindowRequest{}
+	mi := &file_api_dbos_proto_msgTypes[199]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMaintenanceWindowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMaintenanceWindowRequest) ProtoMessage() {}
+
+func (x *DeleteMaintenanceWindowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[199]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMaintenanceWindowRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMaintenanceWindowRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{199}
+}
+
+func (x *DeleteMaintenanceWindowRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteMaintenanceWindowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMaintenanceWindowResponse) Reset() {
+	*x = DeleteMaintenanceWindowResponse{}
+	mi := &file_api_dbos_proto_msgTypes[200]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMaintenanceWindowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMaintenanceWindowResponse) ProtoMessage() {}
+
+func (x *DeleteMaintenanceWindowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[200]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMaintenanceWindowResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMaintenanceWindowResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{200}
+}
+
+func (x *DeleteMaintenanceWindowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteMaintenanceWindowResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeleteMaintenanceWindowResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// TrendPoint summarizes the distribution of a metric over one day (or a whole range)
+type TrendPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"` // "YYYY-MM-DD" (UTC); empty for a range summary
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Min           float64                `protobuf:"fixed64,3,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,4,opt,name=max,proto3" json:"max,omitempty"`
+	Mean          float64                `protobuf:"fixed64,5,opt,name=mean,proto3" json:"mean,omitempty"`
+	Quantiles     []float64              `protobuf:"fixed64,6,rep,packed,name=quantiles,proto3" json:"quantiles,omitempty"` // values at the requested quantiles, in order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendPoint) Reset() {
+	*x = TrendPoint{}
+	mi := &file_api_dbos_proto_msgTypes[201]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendPoint) ProtoMessage() {}
+
+func (x *TrendPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[201]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendPoint.ProtoReflect.Descriptor instead.
+func (*TrendPoint) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{201}
+}
+
+func (x *TrendPoint) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *TrendPoint) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *TrendPoint) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *TrendPoint) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *TrendPoint) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *TrendPoint) GetQuantiles() []float64 {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+type GetTrendsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metric        string                 `protobuf:"bytes,1,opt,name=metric,proto3" json:"metric,omitempty"` // name of a configured metric field
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Target        string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	FromDay       string                 `protobuf:"bytes,4,opt,name=from_day,json=fromDay,proto3" json:"from_day,omitempty"` // "YYYY-MM-DD" (UTC); defaults to 30 days before to_day
+	ToDay         string                 `protobuf:"bytes,5,opt,name=to_day,json=toDay,proto3" json:"to_day,omitempty"`       // "YYYY-MM-DD" (UTC); defaults to today
+	Quantiles     []float64              `protobuf:"fixed64,6,rep,packed,name=quantiles,proto3" json:"quantiles,omitempty"`   // defaults to 0.5, 0.9, 0.99
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendsRequest) Reset() {
+	*x = GetTrendsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[202]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendsRequest) ProtoMessage() {}
+
+func (x *GetTrendsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[202]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendsRequest.ProtoReflect.Descriptor instead.
+func (*GetTrendsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{202}
+}
+
+func (x *GetTrendsRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *GetTrendsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *GetTrendsRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *GetTrendsRequest) GetFromDay() string {
+	if x != nil {
+		return x.FromDay
+	}
+	return ""
+}
+
+func (x *GetTrendsRequest) GetToDay() string {
+	if x != nil {
+		return x.ToDay
+	}
+	return ""
+}
+
+func (x *GetTrendsRequest) GetQuantiles() []float64 {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+type GetTrendsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*TrendPoint          `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	Summary       *TrendPoint            `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"` // the whole range merged
+	Quantiles     []float64              `protobuf:"fixed64,3,rep,packed,name=quantiles,proto3" json:"quantiles,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendsResponse) Reset() {
+	*x = GetTrendsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[203]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendsResponse) ProtoMessage() {}
+
+func (x *GetTrendsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[203]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendsResponse.ProtoReflect.Descriptor instead.
+func (*GetTrendsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{203}
+}
+
+func (x *GetTrendsResponse) GetPoints() []*TrendPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *GetTrendsResponse) GetSummary() *TrendPoint {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *GetTrendsResponse) GetQuantiles() []float64 {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+func (x *GetTrendsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetTrendsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListTasksRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AgentId        string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`     // empty lists the tasks of all agents
+	OrderBy        string                 `protobuf:"bytes,2,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`     // "scheduled_at" (default) or "created_at", optionally followed by " desc"
+	PageSize       int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 0 for 100, at most 1000
+	Cursor         string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`                      // next_cursor of the previous page; empty for the first page
+	ModuleName     string                 `protobuf:"bytes,5,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                  // "pending", "running", "completed", "failed" or "cancelled"
+	CreatedSince   int64                  `protobuf:"varint,7,opt,name=created_since,json=createdSince,proto3" json:"created_since,omitempty"` // unix seconds, inclusive; 0 for no bound
+	CreatedUntil   int64                  `protobuf:"varint,8,opt,name=created_until,json=createdUntil,proto3" json:"created_until,omitempty"` // unix seconds, exclusive; 0 for no bound
+	ScheduledSince int64                  `protobuf:"varint,9,opt,name=scheduled_since,json=scheduledSince,proto3" json:"scheduled_since,omitempty"`
+	ScheduledUntil int64                  `protobuf:"varint,10,opt,name=scheduled_until,json=scheduledUntil,proto3" json:"scheduled_until,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_api_dbos_proto_msgTypes[204]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[204]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{204}
+}
+
+func (x *ListTasksRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetCreatedSince() int64 {
+	if x != nil {
+		return x.CreatedSince
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetCreatedUntil() int64 {
+	if x != nil {
+		return x.CreatedUntil
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetScheduledSince() int64 {
+	if x != nil {
+		return x.ScheduledSince
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetScheduledUntil() int64 {
+	if x != nil {
+		return x.ScheduledUntil
+	}
+	return 0
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty after the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_api_dbos_proto_msgTypes[205]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[205]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{205}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListTasksResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ListTasksResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// ListDueTasksRequest lists the tasks due at a time that wait in the
+// queues of the agents. Agents take turns: the list holds each agent's
+// earliest due task, then each one's second, and so on.
+type ListDueTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // only this agent's queue; empty lists every agent's
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                   // 0 for all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDueTasksRequest) Reset() {
+	*x = ListDueTasksRequest{}
+	mi := &file_api_dbos_proto_msgTypes[206]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDueTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDueTasksRequest) ProtoMessage() {}
+
+func (x *ListDueTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[206]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDueTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListDueTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{206}
+}
+
+func (x *ListDueTasksRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ListDueTasksRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ListDueTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDueTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"` // earliest first within each turn
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDueTasksResponse) Reset() {
+	*x = ListDueTasksResponse{}
+	mi := &file_api_dbos_proto_msgTypes[207]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDueTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDueTasksResponse) ProtoMessage() {}
+
+func (x *ListDueTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[207]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDueTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListDueTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{207}
+}
+
+func (x *ListDueTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListDueTasksResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListDueTasksResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// ListPendingTasks lists the tasks delivered to an agent through the
+// streams task queue that were not settled yet, oldest delivery first
+type ListPendingTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingTasksRequest) Reset() {
+	*x = ListPendingTasksRequest{}
+	mi := &file_api_dbos_proto_msgTypes[208]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingTasksRequest) ProtoMessage() {}
+
+func (x *ListPendingTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[208]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{208}
+}
+
+func (x *ListPendingTasksRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type PendingTask struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	EntryId       string                 `protobuf:"bytes,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"` // stream entry the task was delivered as
+	Consumer      string                 `protobuf:"bytes,3,opt,name=consumer,proto3" json:"consumer,omitempty"`              // the agent, "dbos:expired" or "dbos:waiting"
+	IdleMs        int64                  `protobuf:"varint,4,opt,name=idle_ms,json=idleMs,proto3" json:"idle_ms,omitempty"`   // since the entry was last delivered or claimed
+	Deliveries    int64                  `protobuf:"varint,5,opt,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingTask) Reset() {
+	*x = PendingTask{}
+	mi := &file_api_dbos_proto_msgTypes[209]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingTask) ProtoMessage() {}
+
+func (x *PendingTask) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[209]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingTask.ProtoReflect.Descriptor instead.
+func (*PendingTask) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{209}
+}
+
+func (x *PendingTask) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *PendingTask) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *PendingTask) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *PendingTask) GetIdleMs() int64 {
+	if x != nil {
+		return x.IdleMs
+	}
+	return 0
+}
+
+func (x *PendingTask) GetDeliveries() int64 {
+	if x != nil {
+		return x.Deliveries
+	}
+	return 0
+}
+
+type ListPendingTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*PendingTask         `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingTasksResponse) Reset() {
+	*x = ListPendingTasksResponse{}
+	mi := &file_api_dbos_proto_msgTypes[210]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingTasksResponse) ProtoMessage() {}
+
+func (x *ListPendingTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[210]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{210}
+}
+
+func (x *ListPendingTasksResponse) GetTasks() []*PendingTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListPendingTasksResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListPendingTasksResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Campaign measures a set of targets with one module from the agents it
+// lists and those matching its selector, every interval. Each round
+// schedules a task per live agent and target, with the target set at
+// target_field of payload; their results are correlated under the campaign.
+type Campaign struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ModuleName      string                 `protobuf:"bytes,4,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Payload         []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"` // JSON object each task's payload is built from
+	Targets         []string               `protobuf:"bytes,6,rep,name=targets,proto3" json:"targets,omitempty"`
+	TargetField     string                 `protobuf:"bytes,7,opt,name=target_field,json=targetField,proto3" json:"target_field,omitempty"` // payload field set to the target; "host" by default
+	AgentIds        []string               `protobuf:"bytes,8,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	Selector        map[string]string      `protobuf:"bytes,9,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // agent group by label
+	IntervalSeconds int64                  `protobuf:"varint,10,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`                                    // 0 runs a single round
+	StartsAt        int64                  `protobuf:"varint,11,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`                                                         // 0 starts now
+	EndsAt          int64                  `protobuf:"varint,12,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`                                                               // 0 runs until stopped
+	CreatedBy       string                 `protobuf:"bytes,13,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt       int64                  `protobuf:"varint,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Status          string                 `protobuf:"bytes,15,opt,name=status,proto3" json:"status,omitempty"` // "running", "completed" or "stopped"
+	StoppedAt       int64                  `protobuf:"varint,16,opt,name=stopped_at,json=stoppedAt,proto3" json:"stopped_at,omitempty"`
+	NextRunAt       int64                  `protobuf:"varint,17,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"` // 0 when no round is due
+	Rounds          int64                  `protobuf:"varint,18,opt,name=rounds,proto3" json:"rounds,omitempty"`                          // rounds run so far
+	TasksIssued     int64                  `protobuf:"varint,19,opt,name=tasks_issued,json=tasksIssued,proto3" json:"tasks_issued,omitempty"`
+	ResultsReceived int64                  `protobuf:"varint,20,opt,name=results_received,json=resultsReceived,proto3" json:"results_received,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Campaign) Reset() {
+	*x = Campaign{}
+	mi := &file_api_dbos_proto_msgTypes[211]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Campaign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Campaign) ProtoMessage() {}
+
+func (x *Campaign) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[211]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Campaign.ProtoReflect.Descriptor instead.
+func (*Campaign) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{211}
+}
+
+func (x *Campaign) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Campaign) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Campaign) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Campaign) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *Campaign) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Campaign) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *Campaign) GetTargetField() string {
+	if x != nil {
+		return x.TargetField
+	}
+	return ""
+}
+
+func (x *Campaign) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *Campaign) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *Campaign) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *Campaign) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *Campaign) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+func (x *Campaign) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Campaign) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Campaign) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Campaign) GetStoppedAt() int64 {
+	if x != nil {
+		return x.StoppedAt
+	}
+	return 0
+}
+
+func (x *Campaign) GetNextRunAt() int64 {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return 0
+}
+
+func (x *Campaign) GetRounds() int64 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *Campaign) GetTasksIssued() int64 {
+	if x != nil {
+		return x.TasksIssued
+	}
+	return 0
+}
+
+func (x *Campaign) GetResultsReceived() int64 {
+	if x != nil {
+		return x.ResultsReceived
+	}
+	return 0
+}
+
+type CreateCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCampaignRequest) Reset() {
+	*x = CreateCampaignRequest{}
+	mi := &file_api_dbos_proto_msgTypes[212]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignRequest) ProtoMessage() {}
+
+func (x *CreateCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[212]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignRequest.ProtoReflect.Descriptor instead.
+func (*CreateCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{212}
+}
+
+func (x *CreateCampaignRequest) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+type CreateCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Campaign      *Campaign              `protobuf:"bytes,3,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCampaignResponse) Reset() {
+	*x = CreateCampaignResponse{}
+	mi := &file_api_dbos_proto_msgTypes[213]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignResponse) ProtoMessage() {}
+
+func (x *CreateCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[213]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignResponse.ProtoReflect.Descriptor instead.
+func (*CreateCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{213}
+}
+
+func (x *CreateCampaignResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateCampaignResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateCampaignResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *CreateCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+type GetCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignRequest) Reset() {
+	*x = GetCampaignRequest{}
+	mi := &file_api_dbos_proto_msgTypes[214]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignRequest) ProtoMessage() {}
+
+func (x *GetCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[214]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignRequest.ProtoReflect.Descriptor instead.
+func (*GetCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{214}
+}
+
+func (x *GetCampaignRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Campaign      *Campaign              `protobuf:"bytes,2,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCampaignResponse) Reset() {
+	*x = GetCampaignResponse{}
+	mi := &file_api_dbos_proto_msgTypes[215]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCampaignResponse) ProtoMessage() {}
+
+func (x *GetCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[215]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCampaignResponse.ProtoReflect.Descriptor instead.
+func (*GetCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{215}
+}
+
+func (x *GetCampaignResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *GetCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *GetCampaignResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetCampaignResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type ListCampaignsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // only campaigns with this status; empty for all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignsRequest) Reset() {
+	*x = ListCampaignsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[216]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignsRequest) ProtoMessage() {}
+
+func (x *ListCampaignsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[216]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignsRequest.ProtoReflect.Descriptor instead.
+func (*ListCampaignsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{216}
+}
+
+func (x *ListCampaignsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListCampaignsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaigns     []*Campaign            `protobuf:"bytes,1,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignsResponse) Reset() {
+	*x = ListCampaignsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[217]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignsResponse) ProtoMessage() {}
+
+func (x *ListCampaignsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[217]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignsResponse.ProtoReflect.Descriptor instead.
+func (*ListCampaignsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{217}
+}
+
+func (x *ListCampaignsResponse) GetCampaigns() []*Campaign {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+func (x *ListCampaignsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListCampaignsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// StopCampaignRequest stops a campaign from running further rounds
+type StopCampaignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopCampaignRequest) Reset() {
+	*x = StopCampaignRequest{}
+	mi := &file_api_dbos_proto_msgTypes[218]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopCampaignRequest) ProtoMessage() {}
+
+func (x *StopCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[218]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopCampaignRequest.ProtoReflect.Descriptor instead.
+func (*StopCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{218}
+}
+
+func (x *StopCampaignRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type StopCampaignResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Campaign      *Campaign              `protobuf:"bytes,3,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopCampaignResponse) Reset() {
+	*x = StopCampaignResponse{}
+	mi := &file_api_dbos_proto_msgTypes[219]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopCampaignResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopCampaignResponse) ProtoMessage() {}
+
+func (x *StopCampaignResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[219]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopCampaignResponse.ProtoReflect.Descriptor instead.
+func (*StopCampaignResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{219}
+}
+
+func (x *StopCampaignResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StopCampaignResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StopCampaignResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *StopCampaignResponse) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+type ListCampaignResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                      // results per page; 0 for 100, at most 1000
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`                     // next_cursor of the previous page; empty for the first page
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"` // MeasurementResult fields to return; empty returns all
+	OrderBy       string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`    // "timestamp" (default), optionally followed by " desc"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignResultsRequest) Reset() {
+	*x = ListCampaignResultsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[220]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignResultsRequest) ProtoMessage() {}
+
+func (x *ListCampaignResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[220]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignResultsRequest.ProtoReflect.Descriptor instead.
+func (*ListCampaignResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{220}
+}
+
+func (x *ListCampaignResultsRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *ListCampaignResultsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCampaignResultsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListCampaignResultsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListCampaignResultsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+type ListCampaignResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*MeasurementResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty after the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCampaignResultsResponse) Reset() {
+	*x = ListCampaignResultsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[221]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCampaignResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCampaignResultsResponse) ProtoMessage() {}
+
+func (x *ListCampaignResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[221]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCampaignResultsResponse.ProtoReflect.Descriptor instead.
+func (*ListCampaignResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{221}
+}
+
+func (x *ListCampaignResultsResponse) GetResults() []*MeasurementResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ListCampaignResultsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListCampaignResultsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ListCampaignResultsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// ApplySpecRequest applies a declarative spec of target lists, campaigns
+// and recurring tasks, in YAML or JSON. What the spec names is created, or
+// updated if it exists.
+type ApplySpecRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spec          []byte                 `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // report the changes without making them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplySpecRequest) Reset() {
+	*x = ApplySpecRequest{}
+	mi := &file_api_dbos_proto_msgTypes[222]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplySpecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplySpecRequest) ProtoMessage() {}
+
+func (x *ApplySpecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[222]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplySpecRequest.ProtoReflect.Descriptor instead.
+func (*ApplySpecRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{222}
+}
+
+func (x *ApplySpecRequest) GetSpec() []byte {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *ApplySpecRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// SpecChange is what applying a spec did, or would do, to one thing it
+// defines
+type SpecChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // "target_list", "campaign" or "task"
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"` // "created", "updated" or "unchanged"
+	Fields        []string               `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"` // the fields an update changes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpecChange) Reset() {
+	*x = SpecChange{}
+	mi := &file_api_dbos_proto_msgTypes[223]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpecChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpecChange) ProtoMessage() {}
+
+func (x *SpecChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[223]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpecChange.ProtoReflect.Descriptor instead.
+func (*SpecChange) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{223}
+}
+
+func (x *SpecChange) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SpecChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SpecChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *SpecChange) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type ApplySpecResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Changes       []*SpecChange          `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"` // target lists, then campaigns, then tasks, in spec order
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplySpecResponse) Reset() {
+	*x = ApplySpecResponse{}
+	mi := &file_api_dbos_proto_msgTypes[224]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplySpecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplySpecResponse) ProtoMessage() {}
+
+func (x *ApplySpecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[224]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplySpecResponse.ProtoReflect.Descriptor instead.
+func (*ApplySpecResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{224}
+}
+
+func (x *ApplySpecResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ApplySpecResponse) GetChanges() []*SpecChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ApplySpecResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ApplySpecResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// GetDriftRequest reports how the campaigns and recurring tasks specs
+// manage differ from the definitions ApplySpec last applied
+type GetDriftRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriftRequest) Reset() {
+	*x = GetDriftRequest{}
+	mi := &file_api_dbos_proto_msgTypes[225]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriftRequest) ProtoMessage() {}
+
+func (x *GetDriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[225]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriftRequest.ProtoReflect.Descriptor instead.
+func (*GetDriftRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{225}
+}
+
+// Drift is a difference between a campaign or recurring task a spec manages
+// and its definition
+type Drift struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // "campaign" or "task"
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                            // "missing", "stopped", "cancelled", "not_scheduled" or "changed"
+	Fields        []string               `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`                            // the fields that differ, if "changed"
+	DetectedAt    int64                  `protobuf:"varint,5,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"` // when drift detection first found it; 0 if it has not yet
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Drift) Reset() {
+	*x = Drift{}
+	mi := &file_api_dbos_proto_msgTypes[226]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Drift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Drift) ProtoMessage() {}
+
+func (x *Drift) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[226]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Drift.ProtoReflect.Descriptor instead.
+func (*Drift) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{226}
+}
+
+func (x *Drift) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Drift) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Drift) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Drift) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Drift) GetDetectedAt() int64 {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return 0
+}
+
+type GetDriftResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drifts        []*Drift               `protobuf:"bytes,1,rep,name=drifts,proto3" json:"drifts,omitempty"` // campaigns, then tasks, by name
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriftResponse) Reset() {
+	*x = GetDriftResponse{}
+	mi := &file_api_dbos_proto_msgTypes[227]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriftResponse) ProtoMessage() {}
+
+func (x *GetDriftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[227]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriftResponse.ProtoReflect.Descriptor instead.
+func (*GetDriftResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{227}
+}
+
+func (x *GetDriftResponse) GetDrifts() []*Drift {
+	if x != nil {
+		return x.Drifts
+	}
+	return nil
+}
+
+func (x *GetDriftResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetDriftResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// PlanCapacityRequest estimates the load a proposed campaign would put on
+// the live agents it would run on, from their recent task executions,
+// without creating it
+type PlanCapacityRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Campaign          *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3" json:"campaign,omitempty"`                                               // validated as by CreateCampaign; id and progress are ignored
+	AgentBudget       *CapacityBudget        `protobuf:"bytes,2,opt,name=agent_budget,json=agentBudget,proto3" json:"agent_budget,omitempty"`                      // limits each agent is checked against
+	GroupLabel        string                 `protobuf:"bytes,3,opt,name=group_label,json=groupLabel,proto3" json:"group_label,omitempty"`                         // also sums the load per value of this agent label, e.g. "region"
+	GroupBudget       *CapacityBudget        `protobuf:"bytes,4,opt,name=group_budget,json=groupBudget,proto3" json:"group_budget,omitempty"`                      // limits each group is checked against
+	HorizonSeconds    int64                  `protobuf:"varint,5,opt,name=horizon_seconds,json=horizonSeconds,proto3" json:"horizon_seconds,omitempty"`            // span of the queue depth estimate; until the campaign ends, at most 7 days, by default
+	DefaultDurationMs int64                  `protobuf:"varint,6,opt,name=default_duration_ms,json=defaultDurationMs,proto3" json:"default_duration_ms,omitempty"` // duration assumed for a module no agent ran recently; 0 for 1000
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PlanCapacityRequest) Reset() {
+	*x = PlanCapacityRequest{}
+	mi := &file_api_dbos_proto_msgTypes[228]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanCapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanCapacityRequest) ProtoMessage() {}
+
+func (x *PlanCapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[228]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanCapacityRequest.ProtoReflect.Descriptor instead.
+func (*PlanCapacityRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{228}
+}
+
+func (x *PlanCapacityRequest) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *PlanCapacityRequest) GetAgentBudget() *CapacityBudget {
+	if x != nil {
+		return x.AgentBudget
+	}
+	return nil
+}
+
+func (x *PlanCapacityRequest) GetGroupLabel() string {
+	if x != nil {
+		return x.GroupLabel
+	}
+	return ""
+}
+
+func (x *PlanCapacityRequest) GetGroupBudget() *CapacityBudget {
+	if x != nil {
+		return x.GroupBudget
+	}
+	return nil
+}
+
+func (x *PlanCapacityRequest) GetHorizonSeconds() int64 {
+	if x != nil {
+		return x.HorizonSeconds
+	}
+	return 0
+}
+
+func (x *PlanCapacityRequest) GetDefaultDurationMs() int64 {
+	if x != nil {
+		return x.DefaultDurationMs
+	}
+	return 0
+}
+
+// CapacityBudget is the load an agent or group should stay within; 0 leaves
+// a limit unset
+type CapacityBudget struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MaxUtilization    float64                `protobuf:"fixed64,1,opt,name=max_utilization,json=maxUtilization,proto3" json:"max_utilization,omitempty"`              // fraction of the time between rounds spent running tasks, e.g. 0.5
+	MaxQueueDepth     int64                  `protobuf:"varint,2,opt,name=max_queue_depth,json=maxQueueDepth,proto3" json:"max_queue_depth,omitempty"`                // most tasks queued or running at once
+	MaxBytesPerSecond float64                `protobuf:"fixed64,3,opt,name=max_bytes_per_second,json=maxBytesPerSecond,proto3" json:"max_bytes_per_second,omitempty"` // result bytes stored per second
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CapacityBudget) Reset() {
+	*x = CapacityBudget{}
+	mi := &file_api_dbos_proto_msgTypes[229]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapacityBudget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacityBudget) ProtoMessage() {}
+
+func (x *CapacityBudget) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[229]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapacityBudget.ProtoReflect.Descriptor instead.
+func (*CapacityBudget) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{229}
+}
+
+func (x *CapacityBudget) GetMaxUtilization() float64 {
+	if x != nil {
+		return x.MaxUtilization
+	}
+	return 0
+}
+
+func (x *CapacityBudget) GetMaxQueueDepth() int64 {
+	if x != nil {
+		return x.MaxQueueDepth
+	}
+	return 0
+}
+
+func (x *CapacityBudget) GetMaxBytesPerSecond() float64 {
+	if x != nil {
+		return x.MaxBytesPerSecond
+	}
+	return 0
+}
+
+// AgentCapacity is the estimated load of one agent
+type AgentCapacity struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AgentId        string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Group          string                 `protobuf:"bytes,2,opt,name=group,proto3" json:"group,omitempty"` // value of the group_label label
+	TasksPerRound  int64                  `protobuf:"varint,3,opt,name=tasks_per_round,json=tasksPerRound,proto3" json:"tasks_per_round,omitempty"`
+	TasksPerHour   float64                `protobuf:"fixed64,4,opt,name=tasks_per_hour,json=tasksPerHour,proto3" json:"tasks_per_hour,omitempty"` // 0 for a campaign of one round
+	MeanDurationMs float64                `protobuf:"fixed64,5,opt,name=mean_duration_ms,json=meanDurationMs,proto3" json:"mean_duration_ms,omitempty"`
+	P95DurationMs  float64                `protobuf:"fixed64,6,opt,name=p95_duration_ms,json=p95DurationMs,proto3" json:"p95_duration_ms,omitempty"`
+	DurationSource string                 `protobuf:"bytes,7,opt,name=duration_source,json=durationSource,proto3" json:"duration_source,omitempty"` // "agent", "module" (all agents) or "default"
+	Samples        int64                  `protobuf:"varint,8,opt,name=samples,proto3" json:"samples,omitempty"`                                    // executions the durations are taken from
+	RoundSeconds   float64                `protobuf:"fixed64,9,opt,name=round_seconds,json=roundSeconds,proto3" json:"round_seconds,omitempty"`     // time to run one round's tasks
+	Utilization    float64                `protobuf:"fixed64,10,opt,name=utilization,proto3" json:"utilization,omitempty"`                          // round_seconds over the interval; above 1 the queue grows every round
+	PeakQueueDepth int64                  `protobuf:"varint,11,opt,name=peak_queue_depth,json=peakQueueDepth,proto3" json:"peak_queue_depth,omitempty"`
+	BytesPerSecond float64                `protobuf:"fixed64,12,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	Exceeded       []string               `protobuf:"bytes,13,rep,name=exceeded,proto3" json:"exceeded,omitempty"` // agent_budget limits exceeded: "utilization", "queue_depth" or "bandwidth"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AgentCapacity) Reset() {
+	*x = AgentCapacity{}
+	mi := &file_api_dbos_proto_msgTypes[230]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentCapacity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentCapacity) ProtoMessage() {}
+
+func (x *AgentCapacity) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[230]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentCapacity.ProtoReflect.Descriptor instead.
+func (*AgentCapacity) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{230}
+}
+
+func (x *AgentCapacity) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentCapacity) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *AgentCapacity) GetTasksPerRound() int64 {
+	if x != nil {
+		return x.TasksPerRound
+	}
+	return 0
+}
+
+func (x *AgentCapacity) GetTasksPerHour() float64 {
+	if x != nil {
+		return x.TasksPerHour
+	}
+	return 0
+}
+
+func (x *AgentCapacity) GetMeanDurationMs() float64 {
+	if x != nil {
+		return x.MeanDurationMs
+	}
+	return 0
+}
+
+func (x *AgentCapacity) GetP95DurationMs() float64 {
+	if x != nil {
+		return x.P95DurationMs
+	}
+	return 0
+}
+
+func (x *AgentCapacity) GetDurationSource() string {
+	if x != nil {
+		return x.DurationSource
+	}
+	return ""
+}
+
+func (x *AgentCapacity) GetSamples() int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *AgentCapacity) GetRoundSeconds() float64 {
+	if x != nil {
+		return x.RoundSeconds
+	}
+	return 0
+}
+
+func (x *AgentCapacity) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+func (x *AgentCapacity) GetPeakQueueDepth() int64 {
+	if x != nil {
+		return x.PeakQueueDepth
+	}
+	return 0
+}
+
+func (x *AgentCapacity) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *AgentCapacity) GetExceeded() []string {
+	if x != nil {
+		return x.Exceeded
+	}
+	return nil
+}
+
+// GroupCapacity is the estimated load of the agents sharing a group label value
+type GroupCapacity struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Group          string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Agents         int32                  `protobuf:"varint,2,opt,name=agents,proto3" json:"agents,omitempty"`
+	TasksPerHour   float64                `protobuf:"fixed64,3,opt,name=tasks_per_hour,json=tasksPerHour,proto3" json:"tasks_per_hour,omitempty"`
+	Utilization    float64                `protobuf:"fixed64,4,opt,name=utilization,proto3" json:"utilization,omitempty"`                              // mean of its agents'
+	PeakQueueDepth int64                  `protobuf:"varint,5,opt,name=peak_queue_depth,json=peakQueueDepth,proto3" json:"peak_queue_depth,omitempty"` // of its agents together
+	BytesPerSecond float64                `protobuf:"fixed64,6,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	Exceeded       []string               `protobuf:"bytes,7,rep,name=exceeded,proto3" json:"exceeded,omitempty"` // group_budget limits exceeded
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GroupCapacity) Reset() {
+	*x = GroupCapacity{}
+	mi := &file_api_dbos_proto_msgTypes[231]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupCapacity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupCapacity) ProtoMessage() {}
+
+func (x *GroupCapacity) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[231]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupCapacity.ProtoReflect.Descriptor instead.
+func (*GroupCapacity) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{231}
+}
+
+func (x *GroupCapacity) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GroupCapacity) GetAgents() int32 {
+	if x != nil {
+		return x.Agents
+	}
+	return 0
+}
+
+func (x *GroupCapacity) GetTasksPerHour() float64 {
+	if x != nil {
+		return x.TasksPerHour
+	}
+	return 0
+}
+
+func (x *GroupCapacity) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+func (x *GroupCapacity) GetPeakQueueDepth() int64 {
+	if x != nil {
+		return x.PeakQueueDepth
+	}
+	return 0
+}
+
+func (x *GroupCapacity) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *GroupCapacity) GetExceeded() []string {
+	if x != nil {
+		return x.Exceeded
+	}
+	return nil
+}
+
+// QueueDepthPoint is the number of tasks queued or running at a time
+type QueueDepthPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Depth         int64                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueDepthPoint) Reset() {
+	*x = QueueDepthPoint{}
+	mi := &file_api_dbos_proto_msgTypes[232]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueDepthPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueDepthPoint) ProtoMessage() {}
+
+func (x *QueueDepthPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[232]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueDepthPoint.ProtoReflect.Descriptor instead.
+func (*QueueDepthPoint) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{232}
+}
+
+func (x *QueueDepthPoint) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *QueueDepthPoint) GetDepth() int64 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type PlanCapacityResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Success             bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error               string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode           string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Agents              []*AgentCapacity       `protobuf:"bytes,4,rep,name=agents,proto3" json:"agents,omitempty"`
+	Groups              []*GroupCapacity       `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`
+	QueueDepth          []*QueueDepthPoint     `protobuf:"bytes,6,rep,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"` // across all agents as each round is issued, at most 500 points
+	TasksPerHour        float64                `protobuf:"fixed64,7,opt,name=tasks_per_hour,json=tasksPerHour,proto3" json:"tasks_per_hour,omitempty"`
+	BytesPerSecond      float64                `protobuf:"fixed64,8,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	AgentsOverBudget    int32                  `protobuf:"varint,9,opt,name=agents_over_budget,json=agentsOverBudget,proto3" json:"agents_over_budget,omitempty"`
+	GroupsOverBudget    int32                  `protobuf:"varint,10,opt,name=groups_over_budget,json=groupsOverBudget,proto3" json:"groups_over_budget,omitempty"`
+	UnavailableAgentIds []string               `protobuf:"bytes,11,rep,name=unavailable_agent_ids,json=unavailableAgentIds,proto3" json:"unavailable_agent_ids,omitempty"` // listed agents that are not live, so would not run it
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PlanCapacityResponse) Reset() {
+	*x = PlanCapacityResponse{}
+	mi := &file_api_dbos_proto_msgTypes[233]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanCapacityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanCapacityResponse) ProtoMessage() {}
+
+func (x *PlanCapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[233]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanCapacityResponse.ProtoReflect.Descriptor instead.
+func (*PlanCapacityResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{233}
+}
+
+func (x *PlanCapacityResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PlanCapacityResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PlanCapacityResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *PlanCapacityResponse) GetAgents() []*AgentCapacity {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *PlanCapacityResponse) GetGroups() []*GroupCapacity {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *PlanCapacityResponse) GetQueueDepth() []*QueueDepthPoint {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return nil
+}
+
+func (x *PlanCapacityResponse) GetTasksPerHour() float64 {
+	if x != nil {
+		return x.TasksPerHour
+	}
+	return 0
+}
+
+func (x *PlanCapacityResponse) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *PlanCapacityResponse) GetAgentsOverBudget() int32 {
+	if x != nil {
+		return x.AgentsOverBudget
+	}
+	return 0
+}
+
+func (x *PlanCapacityResponse) GetGroupsOverBudget() int32 {
+	if x != nil {
+		return x.GroupsOverBudget
+	}
+	return 0
+}
+
+func (x *PlanCapacityResponse) GetUnavailableAgentIds() []string {
+	if x != nil {
+		return x.UnavailableAgentIds
+	}
+	return nil
+}
+
+// GetExecutionStatsRequest retrieves how long agents took to run a module's
+// tasks, from their lease until their result was stored or they were
+// acknowledged
+type GetExecutionStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModuleName    string                 `protobuf:"bytes,1,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // only this agent's statistics; all agents that ran the module in the last 30 days if empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutionStatsRequest) Reset() {
+	*x = GetExecutionStatsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[234]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionStatsRequest) ProtoMessage() {}
+
+func (x *GetExecutionStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[234]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutionStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{234}
+}
+
+func (x *GetExecutionStatsRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *GetExecutionStatsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+// DurationBucket counts the executions longer than the previous bucket's
+// bound and at most as long as its own
+type DurationBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpperBoundMs  float64                `protobuf:"fixed64,1,opt,name=upper_bound_ms,json=upperBoundMs,proto3" json:"upper_bound_ms,omitempty"` // 0 for the last bucket, which has no bound
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DurationBucket) Reset() {
+	*x = DurationBucket{}
+	mi := &file_api_dbos_proto_msgTypes[235]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DurationBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DurationBucket) ProtoMessage() {}
+
+func (x *DurationBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[235]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DurationBucket.ProtoReflect.Descriptor instead.
+func (*DurationBucket) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{235}
+}
+
+func (x *DurationBucket) GetUpperBoundMs() float64 {
+	if x != nil {
+		return x.UpperBoundMs
+	}
+	return 0
+}
+
+func (x *DurationBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// ExecutionDurationStats describes the execution durations of a module by
+// one agent, or by all agents
+type ExecutionDurationStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AgentId        string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // empty for all agents
+	Samples        int64                  `protobuf:"varint,2,opt,name=samples,proto3" json:"samples,omitempty"`               // recent executions the mean and percentiles are taken from
+	MeanDurationMs float64                `protobuf:"fixed64,3,opt,name=mean_duration_ms,json=meanDurationMs,proto3" json:"mean_duration_ms,omitempty"`
+	P50DurationMs  float64                `protobuf:"fixed64,4,opt,name=p50_duration_ms,json=p50DurationMs,proto3" json:"p50_duration_ms,omitempty"`
+	P95DurationMs  float64                `protobuf:"fixed64,5,opt,name=p95_duration_ms,json=p95DurationMs,proto3" json:"p95_duration_ms,omitempty"`
+	P99DurationMs  float64                `protobuf:"fixed64,6,opt,name=p99_duration_ms,json=p99DurationMs,proto3" json:"p99_duration_ms,omitempty"`
+	Histogram      []*DurationBucket      `protobuf:"bytes,7,rep,name=histogram,proto3" json:"histogram,omitempty"` // all executions of the last 30 days
+	Count          int64                  `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`        // executions in the histogram
+	SumDurationMs  float64                `protobuf:"fixed64,9,opt,name=sum_duration_ms,json=sumDurationMs,proto3" json:"sum_duration_ms,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExecutionDurationStats) Reset() {
+	*x = ExecutionDurationStats{}
+	mi := &file_api_dbos_proto_msgTypes[236]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionDurationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionDurationStats) ProtoMessage() {}
+
+func (x *ExecutionDurationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[236]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionDurationStats.ProtoReflect.Descriptor instead.
+func (*ExecutionDurationStats) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{236}
+}
+
+func (x *ExecutionDurationStats) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ExecutionDurationStats) GetSamples() int64 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *ExecutionDurationStats) GetMeanDurationMs() float64 {
+	if x != nil {
+		return x.MeanDurationMs
+	}
+	return 0
+}
+
+func (x *ExecutionDurationStats) GetP50DurationMs() float64 {
+	if x != nil {
+		return x.P50DurationMs
+	}
+	return 0
+}
+
+func (x *ExecutionDurationStats) GetP95DurationMs() float64 {
+	if x != nil {
+		return x.P95DurationMs
+	}
+	return 0
+}
+
+func (x *ExecutionDurationStats) GetP99DurationMs() float64 {
+	if x != nil {
+		return x.P99DurationMs
+	}
+	return 0
+}
+
+func (x *ExecutionDurationStats) GetHistogram() []*DurationBucket {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (x *ExecutionDurationStats) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ExecutionDurationStats) GetSumDurationMs() float64 {
+	if x != nil {
+		return x.SumDurationMs
+	}
+	return 0
+}
+
+type GetExecutionStatsResponse struct {
+	state           protoimpl.MessageState    `protogen:"open.v1"`
+	Success         bool                      `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error           string                    `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode       string                    `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Module          *ExecutionDurationStats   `protobuf:"bytes,4,opt,name=module,proto3" json:"module,omitempty"` // across all agents
+	Agents          []*ExecutionDurationStats `protobuf:"bytes,5,rep,name=agents,proto3" json:"agents,omitempty"`
+	HungThresholdMs float64                   `protobuf:"fixed64,6,opt,name=hung_threshold_ms,json=hungThresholdMs,proto3" json:"hung_threshold_ms,omitempty"` // running time past which a task of the module is hung; 0 if none
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetExecutionStatsResponse) Reset() {
+	*x = GetExecutionStatsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[237]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionStatsResponse) ProtoMessage() {}
+
+func (x *GetExecutionStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[237]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetExecutionStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{237}
+}
+
+func (x *GetExecutionStatsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetExecutionStatsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetExecutionStatsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *GetExecutionStatsResponse) GetModule() *ExecutionDurationStats {
+	if x != nil {
+		return x.Module
+	}
+	return nil
+}
+
+func (x *GetExecutionStatsResponse) GetAgents() []*ExecutionDurationStats {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *GetExecutionStatsResponse) GetHungThresholdMs() float64 {
+	if x != nil {
+		return x.HungThresholdMs
+	}
+	return 0
+}
+
+// GetUsageRequest retrieves the RPCs made by agents and API keys over a
+// trailing window, for spotting clients that overload the shared servers
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrincipalKind string                 `protobuf:"bytes,1,opt,name=principal_kind,json=principalKind,proto3" json:"principal_kind,omitempty"`  // empty for all kinds
+	Principal     string                 `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`                               // only this agent ID or key name; empty for all
+	WindowSeconds int64                  `protobuf:"varint,3,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"` // 300 by default; rounded up to whole minutes
+	ByMethod      bool                   `protobuf:"varint,4,opt,name=by_method,json=byMethod,proto3" json:"by_method,omitempty"`                // one entry per principal and RPC rather than per principal
+	OrderBy       string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`                    // descending; "calls" by default
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`                                      // 100 by default, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_api_dbos_proto_msgTypes[238]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[238]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{238}
+}
+
+func (x *GetUsageRequest) GetPrincipalKind() string {
+	if x != nil {
+		return x.PrincipalKind
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *GetUsageRequest) GetByMethod() bool {
+	if x != nil {
+		return x.ByMethod
+	}
+	return false
+}
+
+func (x *GetUsageRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *GetUsageRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// UsageEntry counts the RPCs of an agent or API key over the window
+type UsageEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrincipalKind string                 `protobuf:"bytes,1,opt,name=principal_kind,json=principalKind,proto3" json:"principal_kind,omitempty"` // "agent", "key" or "anonymous"
+	Principal     string                 `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`                              // agent ID, or name or role of the key
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`                                    // full RPC name, with by_method only
+	Calls         int64                  `protobuf:"varint,4,opt,name=calls,proto3" json:"calls,omitempty"`
+	Errors        int64                  `protobuf:"varint,5,opt,name=errors,proto3" json:"errors,omitempty"`                         // calls failing or answered with an error
+	ErrorRate     float64                `protobuf:"fixed64,6,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"` // errors per call
+	BytesIn       int64                  `protobuf:"varint,7,opt,name=bytes_in,json=bytesIn,proto3" json:"bytes_in,omitempty"`        // request messages, as encoded
+	BytesOut      int64                  `protobuf:"varint,8,opt,name=bytes_out,json=bytesOut,proto3" json:"bytes_out,omitempty"`     // response messages, as encoded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsageEntry) Reset() {
+	*x = UsageEntry{}
+	mi := &file_api_dbos_proto_msgTypes[239]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsageEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageEntry) ProtoMessage() {}
+
+func (x *UsageEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[239]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageEntry.ProtoReflect.Descriptor instead.
+func (*UsageEntry) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{239}
+}
+
+func (x *UsageEntry) GetPrincipalKind() string {
+	if x != nil {
+		return x.PrincipalKind
+	}
+	return ""
+}
+
+func (x *UsageEntry) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *UsageEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *UsageEntry) GetCalls() int64 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *UsageEntry) GetErrors() int64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *UsageEntry) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *UsageEntry) GetBytesIn() int64 {
+	if x != nil {
+		return x.BytesIn
+	}
+	return 0
+}
+
+func (x *UsageEntry) GetBytesOut() int64 {
+	if x != nil {
+		return x.BytesOut
+	}
+	return 0
+}
+
+type GetUsageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*UsageEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	WindowSeconds int64                  `protobuf:"varint,2,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"` // the window counted
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_api_dbos_proto_msgTypes[240]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[240]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{240}
+}
+
+func (x *GetUsageResponse) GetEntries() []*UsageEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetUsageResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// Event is an entry of the log of mutations made through the API or by the
+// server's own workers, such as an agent registering, a task being scheduled
+// or a module changing state
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                      // e.g. "agent_registered", "task_scheduled", "module_state_changed"
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`                    // the caller's token identity, e.g. "agent probe-1"; "system" for the server's workers
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`                // resource name of what was mutated, e.g. "agents/probe-1" or "tasks/{task}"
+	AgentId       string                 `protobuf:"bytes,5,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // the agent concerned, if any
+	Severity      string                 `protobuf:"bytes,6,opt,name=severity,proto3" json:"severity,omitempty"`              // "info", "warning" or "error"
+	CorrelationId string                 `protobuf:"bytes,7,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,9,opt,name=payload,proto3" json:"payload,omitempty"`    // JSON details of the mutation
+	Sequence      string                 `protobuf:"bytes,10,opt,name=sequence,proto3" json:"sequence,omitempty"` // position in the log, to resume StreamEvents after
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_api_dbos_proto_msgTypes[241]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[241]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{241}
+}
+
+func (x *Event) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Event) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Event) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *Event) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Event) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *Event) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Event) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetSequence() string {
+	if x != nil {
+		return x.Sequence
+	}
+	return ""
+}
+
+type GetEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []string               `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`                    // empty matches events of all types
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // empty matches events of all agents
+	Since         int64                  `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`                   // unix seconds, inclusive; 0 for no lower bound
+	Until         int64                  `protobuf:"varint,4,opt,name=until,proto3" json:"until,omitempty"`                   // unix seconds, exclusive; 0 for no upper bound
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                   // 100 by default, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventsRequest) Reset() {
+	*x = GetEventsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[242]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsRequest) ProtoMessage() {}
+
+func (x *GetEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[242]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{242}
+}
+
+func (x *GetEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *GetEventsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *GetEventsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *GetEventsRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *GetEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // newest first
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventsResponse) Reset() {
+	*x = GetEventsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[243]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventsResponse) ProtoMessage() {}
+
+func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[243]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{243}
+}
+
+func (x *GetEventsResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetEventsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type StreamEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AfterSequence string                 `protobuf:"bytes,1,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"` // resume after this sequence; empty streams only new events, "0" the whole log
+	Types         []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`                                      // empty matches events of all types
+	AgentId       string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                   // empty matches events of all agents
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[244]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[244]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{244}
+}
+
+func (x *StreamEventsRequest) GetAfterSequence() string {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return ""
+}
+
+func (x *StreamEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *StreamEventsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+// StateEvent is an entry of the event-sourced log of agent and task
+// mutations, holding the state of one agent or task after a mutation
+type StateEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                               // e.g. "agent_heartbeat", "task_leased", "task_acked"
+	EntityType    string                 `protobuf:"bytes,3,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"` // "agent" or "task"
+	EntityId      string                 `protobuf:"bytes,4,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Agent         *Agent                 `protobuf:"bytes,5,opt,name=agent,proto3" json:"agent,omitempty"`                             // the agent after the mutation, unset once deleted
+	Task          *Task                  `protobuf:"bytes,6,opt,name=task,proto3" json:"task,omitempty"`                               // the task after the mutation
+	NextRunAt     int64                  `protobuf:"varint,7,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"` // when a continuous or group task is next issued
+	Timestamp     int64                  `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateEvent) Reset() {
+	*x = StateEvent{}
+	mi := &file_api_dbos_proto_msgTypes[245]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateEvent) ProtoMessage() {}
+
+func (x *StateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[245]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateEvent.ProtoReflect.Descriptor instead.
+func (*StateEvent) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{245}
+}
+
+func (x *StateEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StateEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StateEvent) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *StateEvent) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *StateEvent) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *StateEvent) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *StateEvent) GetNextRunAt() int64 {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return 0
+}
+
+func (x *StateEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type ListStateEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EntityType    string                 `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"` // with entity_id, only the history of one agent or task
+	EntityId      string                 `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor of the previous page
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`  // 100 by default, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStateEventsRequest) Reset() {
+	*x = ListStateEventsRequest{}
+	mi := &file_api_dbos_proto_msgTypes[246]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStateEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStateEventsRequest) ProtoMessage() {}
+
+func (x *ListStateEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[246]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStateEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListStateEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{246}
+}
+
+func (x *ListStateEventsRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListStateEventsRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *ListStateEventsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListStateEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListStateEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*StateEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty after the last page
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStateEventsResponse) Reset() {
+	*x = ListStateEventsResponse{}
+	mi := &file_api_dbos_proto_msgTypes[247]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStateEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStateEventsResponse) ProtoMessage() {}
+
+func (x *ListStateEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[247]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStateEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListStateEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{247}
+}
+
+func (x *ListStateEventsResponse) GetEvents() []*StateEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListStateEventsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListStateEventsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListStateEventsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type RebuildStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // only report the agents and tasks differing from the log
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildStateRequest) Reset() {
+	*x = RebuildStateRequest{}
+	mi := &file_api_dbos_proto_msgTypes[248]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildStateRequest) ProtoMessage() {}
+
+func (x *RebuildStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[248]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildStateRequest.ProtoReflect.Descriptor instead.
+func (*RebuildStateRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{248}
+}
+
+func (x *RebuildStateRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type RebuildStateResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	EventsReplayed   int64                  `protobuf:"varint,2,opt,name=events_replayed,json=eventsReplayed,proto3" json:"events_replayed,omitempty"`
+	AgentsRebuilt    int32                  `protobuf:"varint,3,opt,name=agents_rebuilt,json=agentsRebuilt,proto3" json:"agents_rebuilt,omitempty"`
+	TasksRebuilt     int32                  `protobuf:"varint,4,opt,name=tasks_rebuilt,json=tasksRebuilt,proto3" json:"tasks_rebuilt,omitempty"`
+	DivergedAgentIds []string               `protobuf:"bytes,5,rep,name=diverged_agent_ids,json=divergedAgentIds,proto3" json:"diverged_agent_ids,omitempty"`
+	DivergedTaskIds  []string               `protobuf:"bytes,6,rep,name=diverged_task_ids,json=divergedTaskIds,proto3" json:"diverged_task_ids,omitempty"`
+	Error            string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	ErrorCode        string                 `protobuf:"bytes,8,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RebuildStateResponse) Reset() {
+	*x = RebuildStateResponse{}
+	mi := &file_api_dbos_proto_msgTypes[249]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildStateResponse) ProtoMessage() {}
+
+func (x *RebuildStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[249]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildStateResponse.ProtoReflect.Descriptor instead.
+func (*RebuildStateResponse) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{249}
+}
+
+func (x *RebuildStateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RebuildStateResponse) GetEventsReplayed() int64 {
+	if x != nil {
+		return x.EventsReplayed
+	}
+	return 0
+}
+
+func (x *RebuildStateResponse) GetAgentsRebuilt() int32 {
+	if x != nil {
+		return x.AgentsRebuilt
+	}
+	return 0
+}
+
+func (x *RebuildStateResponse) GetTasksRebuilt() int32 {
+	if x != nil {
+		return x.TasksRebuilt
+	}
+	return 0
+}
+
+func (x *RebuildStateResponse) GetDivergedAgentIds() []string {
+	if x != nil {
+		return x.DivergedAgentIds
+	}
+	return nil
+}
+
+func (x *RebuildStateResponse) GetDivergedTaskIds() []string {
+	if x != nil {
+		return x.DivergedTaskIds
+	}
+	return nil
+}
+
+func (x *RebuildStateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RebuildStateResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+// ExportSnapshotRequest selects the results of a snapshot export, which
+// also holds every agent and task
+type ExportSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResultsSince  int64                  `protobuf:"varint,1,opt,name=results_since,json=resultsSince,proto3" json:"results_since,omitempty"` // unix seconds; results measured earlier are left out, 0 for the last 24 hours
+	ModuleName    string                 `protobuf:"bytes,2,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`        // only results of one module
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSnapshotRequest) Reset() {
+	*x = ExportSnapshotRequest{}
+	mi := &file_api_dbos_proto_msgTypes[250]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSnapshotRequest) ProtoMessage() {}
+
+func (x *ExportSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[250]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*ExportSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{250}
+}
+
+func (x *ExportSnapshotRequest) GetResultsSince() int64 {
+	if x != nil {
+		return x.ResultsSince
+	}
+	return 0
+}
+
+func (x *ExportSnapshotRequest) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+// SnapshotMarker is the point in time a snapshot export captures
+type SnapshotMarker struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TakenAt         int64                  `protobuf:"varint,1,opt,name=taken_at,json=takenAt,proto3" json:"taken_at,omitempty"`
+	StateEventId    string                 `protobuf:"bytes,2,opt,name=state_event_id,json=stateEventId,proto3" json:"state_event_id,omitempty"`                                                                                   // the last state event the snapshot reflects, empty if the log was empty
+	ResultSequences map[string]int64       `protobuf:"bytes,3,rep,name=result_sequences,json=resultSequences,proto3" json:"result_sequences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // per agent, the last result sequence the snapshot includes
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SnapshotMarker) Reset() {
+	*x = SnapshotMarker{}
+	mi := &file_api_dbos_proto_msgTypes[251]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotMarker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotMarker) ProtoMessage() {}
+
+func (x *SnapshotMarker) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[251]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotMarker.ProtoReflect.Descriptor instead.
+func (*SnapshotMarker) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{251}
+}
+
+func (x *SnapshotMarker) GetTakenAt() int64 {
+	if x != nil {
+		return x.TakenAt
+	}
+	return 0
+}
+
+func (x *SnapshotMarker) GetStateEventId() string {
+	if x != nil {
+		return x.StateEventId
+	}
+	return ""
+}
+
+func (x *SnapshotMarker) GetResultSequences() map[string]int64 {
+	if x != nil {
+		return x.ResultSequences
+	}
+	return nil
+}
+
+// SnapshotRecord is a message of a snapshot export, setting one field: the
+// marker first, then agents, tasks and results
+type SnapshotRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Marker        *SnapshotMarker        `protobuf:"bytes,1,opt,name=marker,proto3" json:"marker,omitempty"`
+	Agent         *Agent                 `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
+	Task          *Task                  `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	Result        *MeasurementResult     `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotRecord) Reset() {
+	*x = SnapshotRecord{}
+	mi := &file_api_dbos_proto_msgTypes[252]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRecord) ProtoMessage() {}
+
+func (x *SnapshotRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_api_dbos_proto_msgTypes[252]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRecord.ProtoReflect.Descriptor instead.
+func (*SnapshotRecord) Descriptor() ([]byte, []int) {
+	return file_api_dbos_proto_rawDescGZIP(), []int{252}
+}
+
+func (x *SnapshotRecord) GetMarker() *SnapshotMarker {
+	if x != nil {
+		return x.Marker
+	}
+	return nil
+}
+
+func (x *SnapshotRecord) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *SnapshotRecord) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *SnapshotRecord) GetResult() *MeasurementResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+var File_api_dbos_proto protoreflect.FileDescriptor
+
+const file_api_dbos_proto_rawDesc = "" +
+	"\n" +
+	"\x0eapi/dbos.proto\x12\x04dbos\x1a\x1bapi/validate/validate.proto\x1a google/protobuf/field_mask.proto\"\xdb\x03\n" +
+	"\x05Agent\x12\x18\n" +
+	"\x02id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\x02id\x12!\n" +
+	"\bhostname\x18\x02 \x01(\tB\x05\x98\xb5\x18\xff\x01R\bhostname\x12\x14\n" +
+	"\x05alive\x18\x03 \x01(\bR\x05alive\x12!\n" +
+	"\tlast_seen\x18\x04 \x01(\x03B\x04\xa8\xb5\x18\x01R\blastSeen\x12#\n" +
+	"\n" +
+	"first_seen\x18\x05 \x01(\x03B\x04\xa8\xb5\x18\x01R\tfirstSeen\x12/\n" +
+	"\x06config\x18\x06 \x03(\v2\x17.dbos.Agent.ConfigEntryR\x06config\x12)\n" +
+	"\x10total_heartbeats\x18\a \x01(\x05R\x0ftotalHeartbeats\x12/\n" +
+	"\x06labels\x18\b \x03(\v2\x17.dbos.Agent.LabelsEntryR\x06labels\x124\n" +
+	"\x16maintenance_window_ids\x18\t \x03(\tR\x14maintenanceWindowIds\x1a9\n" +
+	"\vConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x80\x03\n" +
+	"\vModuleState\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\aagentId\x12\x1f\n" +
+	"\vmodule_name\x18\x02 \x01(\tR\n" +
+	"moduleName\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x128\n" +
+	"\adetails\x18\x05 \x03(\v2\x1e.dbos.ModuleState.DetailsEntryR\adetails\x12\"\n" +
+	"\ttimestamp\x18\x06 \x01(\x03B\x04\xa8\xb5\x18\x01R\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\t \x01(\tR\terrorCode\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf8\x02\n" +
+	"\x11MeasurementResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\bagent_id\x18\x02 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\aagentId\x12\x1f\n" +
+	"\vmodule_name\x18\x03 \x01(\tR\n" +
+	"moduleName\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\"\n" +
+	"\ttimestamp\x18\x05 \x01(\x03B\x04\xa8\xb5\x18\x01R\ttimestamp\x12\x16\n" +
+	"\x06origin\x18\x06 \x01(\tR\x06origin\x12\x1a\n" +
+	"\bsequence\x18\a \x01(\x03R\bsequence\x12,\n" +
+	"\x12agent_timestamp_ms\x18\b \x01(\x03R\x10agentTimestampMs\x12&\n" +
+	"\x0fclock_offset_ms\x18\t \x01(\x01R\rclockOffsetMs\x12+\n" +
+	"\x0ecorrelation_id\x18\n" +
+	" \x01(\tB\x04\x90\xb5\x18\x01R\rcorrelationId\x12\x1e\n" +
+	"\n" +
+	"backfilled\x18\v \x01(\bR\n" +
+	"backfilled\"\x97\x01\n" +
+	"\tClockSkew\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
+	"\toffset_ms\x18\x02 \x01(\x01R\boffsetMs\x12\x19\n" +
+	"\bdelay_ms\x18\x03 \x01(\x01R\adelayMs\x12\x18\n" +
+	"\asamples\x18\x04 \x01(\x03R\asamples\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\"\xe7\b\n" +
+	"\x04Task\x12\x14\n" +
+	"\x02id\x18\x01 \x01(\tB\x04\x90\xb5\x18\x01R\x02id\x12\x1f\n" +
+	"\bagent_id\x18\x02 \x01(\tB\x04\x90\xb5\x18\x01R\aagentId\x12\x1f\n" +
+	"\vmodule_name\x18\x03 \x01(\tR\n" +
+	"moduleName\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\fR\apayload\x12'\n" +
+	"\fscheduled_at\x18\x05 \x01(\x03B\x04\xa8\xb5\x18\x01R\vscheduledAt\x12\x1d\n" +
+	"\n" +
 	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x16\n" +
-	"\x06status\x18\a \x01(\tR\x06status\"9\n" +
-	"\x14RegisterAgentRequest\x12!\n" +
-	"\x05agent\x18\x01 \x01(\v2\v.dbos.AgentR\x05agent\"G\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12-\n" +
+	"\x04type\x18\b \x01(\tB\x19\xb2\xb5\x18\aoneshot\xb2\xb5\x18\n" +
+	"continuousR\x04type\x12)\n" +
+	"\x10interval_seconds\x18\t \x01(\x03R\x0fintervalSeconds\x12\x1b\n" +
+	"\tparent_id\x18\n" +
+	" \x01(\tR\bparentId\x12'\n" +
+	"\x0fverification_id\x18\v \x01(\tR\x0everificationId\x12\x1f\n" +
+	"\vcampaign_id\x18\f \x01(\tR\n" +
+	"campaignId\x124\n" +
+	"\bselector\x18\r \x03(\v2\x18.dbos.Task.SelectorEntryR\bselector\x12\x1b\n" +
+	"\tagent_ids\x18\x0e \x03(\tR\bagentIds\x12\x1e\n" +
+	"\n" +
+	"deliveries\x18\x0f \x01(\x03R\n" +
+	"deliveries\x12+\n" +
+	"\x0ecorrelation_id\x18\x10 \x01(\tB\x04\x90\xb5\x18\x01R\rcorrelationId\x12-\n" +
+	"\tplacement\x18\x11 \x01(\v2\x0f.dbos.PlacementR\tplacement\x12\x1b\n" +
+	"\tleased_at\x18\x12 \x01(\x03R\bleasedAt\x12\x1f\n" +
+	"\vduration_ms\x18\x13 \x01(\x01R\n" +
+	"durationMs\x12J\n" +
+	"\x15lease_timeout_seconds\x18\x14 \x01(\x03B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00\xc1\xb5\x18\x00\x00\x00\x00\x00\x18\xf5@R\x13leaseTimeoutSeconds\x12(\n" +
+	"\x10lease_expires_at\x18\x15 \x01(\x03R\x0eleaseExpiresAt\x124\n" +
+	"\fretry_policy\x18\x16 \x01(\v2\x11.dbos.RetryPolicyR\vretryPolicy\x12\x1f\n" +
+	"\vretry_count\x18\x17 \x01(\x03R\n" +
+	"retryCount\x12!\n" +
+	"\fbroadcast_id\x18\x18 \x01(\tR\vbroadcastId\x12M\n" +
+	"\x11failover_selector\x18\x19 \x03(\v2 .dbos.Task.FailoverSelectorEntryR\x10failoverSelector\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1aC\n" +
+	"\x15FailoverSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x02\n" +
+	"\vRetryPolicy\x12J\n" +
+	"\x15initial_delay_seconds\x18\x01 \x01(\x03B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00\xc1\xb5\x18\x00\x00\x00\x00\x00\x18\xf5@R\x13initialDelaySeconds\x126\n" +
+	"\n" +
+	"multiplier\x18\x02 \x01(\x01B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00\xc1\xb5\x18\x00\x00\x00\x00\x00\x00Y@R\n" +
+	"multiplier\x12B\n" +
+	"\x11max_delay_seconds\x18\x03 \x01(\x03B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00\xc1\xb5\x18\x00\x00\x00\x00\x00\x18\xf5@R\x0fmaxDelaySeconds\x12.\n" +
+	"\x06jitter\x18\x04 \x01(\x01B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00\xc1\xb5\x18\x00\x00\x00\x00\x00\x00\xf0?R\x06jitter\"\xff\x01\n" +
+	"\tPlacement\x12\x1d\n" +
+	"\n" +
+	"max_agents\x18\x01 \x01(\x05R\tmaxAgents\x123\n" +
+	"\x16avoid_target_prefix_v4\x18\x02 \x01(\x05R\x13avoidTargetPrefixV4\x123\n" +
+	"\x16avoid_target_prefix_v6\x18\x03 \x01(\x05R\x13avoidTargetPrefixV6\x12!\n" +
+	"\fspread_label\x18\x04 \x01(\tR\vspreadLabel\x12\x1d\n" +
+	"\n" +
+	"min_spread\x18\x05 \x01(\x05R\tminSpread\x12'\n" +
+	"\x0fprefer_previous\x18\x06 \x01(\bR\x0epreferPrevious\"?\n" +
+	"\x14RegisterAgentRequest\x12'\n" +
+	"\x05agent\x18\x01 \x01(\v2\v.dbos.AgentB\x04\x88\xb5\x18\x01R\x05agent\"f\n" +
 	"\x15RegisterAgentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\",\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x8f\x01\n" +
+	"\x10HeartbeatRequest\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\aagentId\x12!\n" +
+	"\bhostname\x18\x02 \x01(\tB\x05\x98\xb5\x18\xff\x01R\bhostname\x123\n" +
+	"\tmax_tasks\x18\x03 \x01(\x05B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00\xc1\xb5\x18\x00\x00\x00\x00\x00\x00Y@R\bmaxTasks\"\xd0\x01\n" +
+	"\x11HeartbeatResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12!\n" +
+	"\x05agent\x18\x02 \x01(\v2\v.dbos.AgentR\x05agent\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12 \n" +
+	"\x05tasks\x18\x05 \x03(\v2\n" +
+	".dbos.TaskR\x05tasks\x12'\n" +
+	"\abackoff\x18\x06 \x01(\v2\r.dbos.BackoffR\abackoff\"e\n" +
 	"\x0fGetAgentRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\"a\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x127\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\x80\x01\n" +
 	"\x10GetAgentResponse\x12\x14\n" +
 	"\x05found\x18\x01 \x01(\bR\x05found\x12!\n" +
 	"\x05agent\x18\x02 \x01(\v2\v.dbos.AgentR\x05agent\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"\x13\n" +
-	"\x11ListAgentsRequest\"O\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\x9c\x01\n" +
+	"\x11ListAgentsRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x127\n" +
+	"\tread_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\"\x8f\x01\n" +
 	"\x12ListAgentsResponse\x12#\n" +
 	"\x06agents\x18\x01 \x03(\v2\v.dbos.AgentR\x06agents\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"@\n" +
-	"\x15SetModuleStateRequest\x12'\n" +
-	"\x05state\x18\x01 \x01(\v2\x11.dbos.ModuleStateR\x05state\"H\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"/\n" +
+	"\x12DeleteAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"d\n" +
+	"\x13DeleteAgentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"0\n" +
+	"\x12WatchAgentsRequest\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\tR\brevision\"_\n" +
+	"\n" +
+	"AgentDelta\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12!\n" +
+	"\x05agent\x18\x02 \x01(\v2\v.dbos.AgentR\x05agent\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\tR\brevision\"\xca\x01\n" +
+	"\x16GetAgentGeoJSONRequest\x12F\n" +
+	"\bselector\x18\x01 \x03(\v2*.dbos.GetAgentGeoJSONRequest.SelectorEntryR\bselector\x12+\n" +
+	"\x11include_incidents\x18\x02 \x01(\bR\x10includeIncidents\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x93\x01\n" +
+	"\x17GetAgentGeoJSONResponse\x12\x18\n" +
+	"\ageojson\x18\x01 \x01(\fR\ageojson\x12)\n" +
+	"\x10unlocated_agents\x18\x02 \x01(\x05R\x0funlocatedAgents\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\xc2\x02\n" +
+	"\x1bCreateBootstrapTokenRequest\x12E\n" +
+	"\x06labels\x18\x01 \x03(\v2-.dbos.CreateBootstrapTokenRequest.LabelsEntryR\x06labels\x12E\n" +
+	"\x06config\x18\x02 \x03(\v2-.dbos.CreateBootstrapTokenRequest.ConfigEntryR\x06config\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSeconds\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x88\x01\n" +
+	"\x1cCreateBootstrapTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\">\n" +
+	"\x17CreateAgentTokenRequest\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\aagentId\"e\n" +
+	"\x18CreateAgentTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"d\n" +
+	"\x15CreateAPITokenRequest\x121\n" +
+	"\x04role\x18\x01 \x01(\tB\x1d\x88\xb5\x18\x01\xb2\xb5\x18\boperator\xb2\xb5\x18\tread_onlyR\x04role\x12\x18\n" +
+	"\x04name\x18\x02 \x01(\tB\x04\x90\xb5\x18\x01R\x04name\"c\n" +
+	"\x16CreateAPITokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x81\x01\n" +
+	"\x12EnrollAgentRequest\x12'\n" +
+	"\x0fbootstrap_token\x18\x01 \x01(\tR\x0ebootstrapToken\x12\x1f\n" +
+	"\bagent_id\x18\x02 \x01(\tB\x04\x90\xb5\x18\x01R\aagentId\x12!\n" +
+	"\bhostname\x18\x03 \x01(\tB\x05\x98\xb5\x18\xff\x01R\bhostname\"\xa8\x01\n" +
+	"\x13EnrollAgentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12!\n" +
+	"\x05agent\x18\x02 \x01(\v2\v.dbos.AgentR\x05agent\x12\x1f\n" +
+	"\vagent_token\x18\x03 \x01(\tR\n" +
+	"agentToken\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\tR\terrorCode\"\x8a\x01\n" +
+	"\bArtifact\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\x12\x12\n" +
+	"\x04size\x18\x06 \x01(\x03R\x04size\"J\n" +
+	"\x16PublishArtifactRequest\x120\n" +
+	"\bartifact\x18\x01 \x01(\v2\x0e.dbos.ArtifactB\x04\x88\xb5\x18\x01R\bartifact\"h\n" +
+	"\x17PublishArtifactResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"H\n" +
+	"\x18UnpublishArtifactRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"j\n" +
+	"\x19UnpublishArtifactResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x14\n" +
+	"\x12GetManifestRequest\"\x9b\x01\n" +
+	"\x13GetManifestResponse\x12\x1a\n" +
+	"\bmanifest\x18\x01 \x01(\fR\bmanifest\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\fR\tsignature\x12\x15\n" +
+	"\x06key_id\x18\x03 \x01(\tR\x05keyId\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\tR\terrorCode\"\x80\x02\n" +
+	"\x12AgentConfigVersion\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12<\n" +
+	"\x06config\x18\x03 \x03(\v2$.dbos.AgentConfigVersion.ConfigEntryR\x06config\x12\x1d\n" +
+	"\n" +
+	"rollout_id\x18\x04 \x01(\tR\trolloutId\x12\x1d\n" +
+	"\n" +
+	"applied_at\x18\x05 \x01(\x03R\tappliedAt\x1a9\n" +
+	"\vConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xce\x04\n" +
+	"\rConfigRollout\x12\x14\n" +
+	"\x02id\x18\x01 \x01(\tB\x04\x90\xb5\x18\x01R\x02id\x127\n" +
+	"\x06config\x18\x02 \x03(\v2\x1f.dbos.ConfigRollout.ConfigEntryR\x06config\x12=\n" +
+	"\bselector\x18\x03 \x03(\v2!.dbos.ConfigRollout.SelectorEntryR\bselector\x12\x16\n" +
+	"\x06stages\x18\x04 \x03(\x05R\x06stages\x12#\n" +
+	"\rcurrent_stage\x18\x05 \x01(\x05R\fcurrentStage\x12$\n" +
+	"\x0emax_error_rate\x18\x06 \x01(\x01R\fmaxErrorRate\x124\n" +
+	"\x16stage_duration_seconds\x18\a \x01(\x03R\x14stageDurationSeconds\x12(\n" +
+	"\x10stage_started_at\x18\b \x01(\x03R\x0estageStartedAt\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12%\n" +
+	"\x0eupdated_agents\x18\n" +
+	" \x03(\tR\rupdatedAgents\x12\x16\n" +
+	"\x06reason\x18\v \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\x03R\tcreatedAt\x1a9\n" +
+	"\vConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"J\n" +
+	"\x19StartConfigRolloutRequest\x12-\n" +
+	"\arollout\x18\x01 \x01(\v2\x13.dbos.ConfigRolloutR\arollout\"\xd1\x01\n" +
+	"\x1aStartConfigRolloutResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12-\n" +
+	"\arollout\x18\x02 \x01(\v2\x13.dbos.ConfigRolloutR\arollout\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x125\n" +
+	"\n" +
+	"violations\x18\x05 \x03(\v2\x15.dbos.ConfigViolationR\n" +
+	"violations\"8\n" +
+	"\x17GetConfigRolloutRequest\x12\x1d\n" +
+	"\n" +
+	"rollout_id\x18\x01 \x01(\tR\trolloutId\"\x94\x01\n" +
+	"\x18GetConfigRolloutResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12-\n" +
+	"\arollout\x18\x02 \x01(\v2\x13.dbos.ConfigRolloutR\arollout\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"=\n" +
+	"\x1cRollbackConfigRolloutRequest\x12\x1d\n" +
+	"\n" +
+	"rollout_id\x18\x01 \x01(\tR\trolloutId\"\x9d\x01\n" +
+	"\x1dRollbackConfigRolloutResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12-\n" +
+	"\arollout\x18\x02 \x01(\v2\x13.dbos.ConfigRolloutR\arollout\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"2\n" +
+	"\x15GetAgentConfigRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\x95\x01\n" +
+	"\x16GetAgentConfigResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x120\n" +
+	"\x06config\x18\x02 \x01(\v2\x18.dbos.AgentConfigVersionR\x06config\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\xb1\x01\n" +
+	"\x0fConfigKeySchema\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1a\n" +
+	"\brequired\x18\x02 \x01(\bR\brequired\x12\x15\n" +
+	"\x03min\x18\x03 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x04 \x01(\x01H\x01R\x03max\x88\x01\x01\x12\x16\n" +
+	"\x06values\x18\x05 \x03(\tR\x06values\x12\x18\n" +
+	"\apattern\x18\x06 \x01(\tR\apatternB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"\x82\x02\n" +
+	"\fConfigSchema\x12#\n" +
+	"\ragent_version\x18\x01 \x01(\tR\fagentVersion\x120\n" +
+	"\x04keys\x18\x02 \x03(\v2\x1c.dbos.ConfigSchema.KeysEntryR\x04keys\x12,\n" +
+	"\x12allow_unknown_keys\x18\x03 \x01(\bR\x10allowUnknownKeys\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\x1aN\n" +
+	"\tKeysEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.dbos.ConfigKeySchemaR\x05value:\x028\x01\"}\n" +
+	"\x0fConfigViolation\x12#\n" +
+	"\ragent_version\x18\x01 \x01(\tR\fagentVersion\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1b\n" +
+	"\tagent_ids\x18\x04 \x03(\tR\bagentIds\"J\n" +
+	"\x16SetConfigSchemaRequest\x120\n" +
+	"\x06schema\x18\x01 \x01(\v2\x12.dbos.ConfigSchemaB\x04\x88\xb5\x18\x01R\x06schema\"\x94\x01\n" +
+	"\x17SetConfigSchemaResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
+	"\x06schema\x18\x02 \x01(\v2\x12.dbos.ConfigSchemaR\x06schema\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\x1a\n" +
+	"\x18ListConfigSchemasRequest\"~\n" +
+	"\x19ListConfigSchemasResponse\x12,\n" +
+	"\aschemas\x18\x01 \x03(\v2\x12.dbos.ConfigSchemaR\aschemas\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"@\n" +
+	"\x19DeleteConfigSchemaRequest\x12#\n" +
+	"\ragent_version\x18\x01 \x01(\tR\fagentVersion\"k\n" +
+	"\x1aDeleteConfigSchemaResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\xb2\x02\n" +
+	"\x15ValidateConfigRequest\x12?\n" +
+	"\x06config\x18\x01 \x03(\v2'.dbos.ValidateConfigRequest.ConfigEntryR\x06config\x12E\n" +
+	"\bselector\x18\x02 \x03(\v2).dbos.ValidateConfigRequest.SelectorEntryR\bselector\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x1a9\n" +
+	"\vConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf5\x01\n" +
+	"\x16ValidateConfigResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x125\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2\x15.dbos.ConfigViolationR\n" +
+	"violations\x12%\n" +
+	"\x0eagents_checked\x18\x03 \x01(\x05R\ragentsChecked\x122\n" +
+	"\x15agents_without_schema\x18\x04 \x01(\x05R\x13agentsWithoutSchema\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x06 \x01(\tR\terrorCode\"\x8b\x01\n" +
+	"\x15SetModuleStateRequest\x12-\n" +
+	"\x05state\x18\x01 \x01(\v2\x11.dbos.ModuleStateB\x04\x88\xb5\x18\x01R\x05state\x12.\n" +
+	"\x10expected_version\x18\x02 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\x9d\x01\n" +
 	"\x16SetModuleStateResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"6\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\tR\terrorCode\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12\x1a\n" +
+	"\bconflict\x18\x04 \x01(\bR\bconflict\"6\n" +
 	"\x15GetModuleStateRequest\x12\x1d\n" +
 	"\n" +
-	"request_id\x18\x01 \x01(\tR\trequestId\"m\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\"\x8c\x01\n" +
 	"\x16GetModuleStateResponse\x12\x14\n" +
 	"\x05found\x18\x01 \x01(\bR\x05found\x12'\n" +
 	"\x05state\x18\x02 \x01(\v2\x11.dbos.ModuleStateR\x05state\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"U\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"U\n" +
 	"\x17ListModuleStatesRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1f\n" +
 	"\vmodule_name\x18\x02 \x01(\tR\n" +
-	"moduleName\"[\n" +
+	"moduleName\"n\n" +
+	"\x18WatchModuleStatesRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1f\n" +
+	"\vmodule_name\x18\x02 \x01(\tR\n" +
+	"moduleName\x12\x16\n" +
+	"\x06states\x18\x03 \x03(\tR\x06states\"z\n" +
 	"\x18ListModuleStatesResponse\x12)\n" +
 	"\x06states\x18\x01 \x03(\v2\x11.dbos.ModuleStateR\x06states\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"E\n" +
-	"\x12StoreResultRequest\x12/\n" +
-	"\x06result\x18\x01 \x01(\v2\x17.dbos.MeasurementResultR\x06result\"E\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"O\n" +
+	"\x12StoreResultRequest\x129\n" +
+	"\x06result\x18\x01 \x01(\v2\x17.dbos.MeasurementResultB\b\x88\xb5\x18\x01ȵ\x18\x01R\x06result\"\xaa\x01\n" +
 	"\x13StoreResultResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"L\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12\x1b\n" +
+	"\tresult_id\x18\x03 \x01(\tR\bresultId\x12'\n" +
+	"\abackoff\x18\x05 \x01(\v2\r.dbos.BackoffR\abackoff\"m\n" +
+	"\aBackoff\x12$\n" +
+	"\x0eretry_after_ms\x18\x01 \x01(\x03R\fretryAfterMs\x12$\n" +
+	"\x0emax_batch_size\x18\x02 \x01(\x05R\fmaxBatchSize\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"o\n" +
+	"\x13StoreResultsRequest\x12<\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.dbos.MeasurementResultB\t\xa0\xb5\x18\xe8\aȵ\x18\x01R\aresults\x12\x1a\n" +
+	"\bbackfill\x18\x02 \x01(\bR\bbackfill\"}\n" +
+	"\x11ResultStoreStatus\x12\x1b\n" +
+	"\tresult_id\x18\x01 \x01(\tR\bresultId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\xa7\x01\n" +
+	"\x14StoreResultsResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.dbos.ResultStoreStatusR\aresults\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\x12'\n" +
+	"\abackoff\x18\x04 \x01(\v2\r.dbos.BackoffR\abackoff\"\x8a\x01\n" +
+	"\x15StreamResultsResponse\x12\x16\n" +
+	"\x06stored\x18\x01 \x01(\x03R\x06stored\x120\n" +
+	"\brejected\x18\x02 \x03(\v2\x14.dbos.RejectedResultR\brejected\x12'\n" +
+	"\abackoff\x18\x03 \x01(\v2\r.dbos.BackoffR\abackoff\"x\n" +
+	"\x0eRejectedResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1b\n" +
+	"\tresult_id\x18\x02 \x01(\tR\bresultId\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\x85\x01\n" +
 	"\x10GetResultRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
 	"\n" +
-	"request_id\x18\x02 \x01(\tR\trequestId\"p\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x127\n" +
+	"\tread_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\x8f\x01\n" +
 	"\x11GetResultResponse\x12\x14\n" +
 	"\x05found\x18\x01 \x01(\bR\x05found\x12/\n" +
 	"\x06result\x18\x02 \x01(\v2\x17.dbos.MeasurementResultR\x06result\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"/\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\x9c\x02\n" +
 	"\x12ListResultsRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\"^\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12%\n" +
+	"\x0efrom_timestamp\x18\x04 \x01(\x03R\rfromTimestamp\x12!\n" +
+	"\fto_timestamp\x18\x05 \x01(\x03R\vtoTimestamp\x12\x1f\n" +
+	"\vmodule_name\x18\x06 \x01(\tR\n" +
+	"moduleName\x127\n" +
+	"\tread_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x19\n" +
+	"\border_by\x18\b \x01(\tR\aorderBy\"\x9e\x01\n" +
 	"\x13ListResultsResponse\x121\n" +
 	"\aresults\x18\x01 \x03(\v2\x17.dbos.MeasurementResultR\aresults\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"5\n" +
-	"\x13ScheduleTaskRequest\x12\x1e\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"|\n" +
+	"\x1bGetCorrelatedResultsRequest\x12/\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\rcorrelationId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"\xa7\x01\n" +
+	"\x1cGetCorrelatedResultsResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.dbos.MeasurementResultR\aresults\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\x9b\x01\n" +
+	"\x13CountResultsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12%\n" +
+	"\x0efrom_timestamp\x18\x02 \x01(\x03R\rfromTimestamp\x12!\n" +
+	"\fto_timestamp\x18\x03 \x01(\x03R\vtoTimestamp\x12\x1f\n" +
+	"\vmodule_name\x18\x04 \x01(\tR\n" +
+	"moduleName\"a\n" +
+	"\x14CountResultsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"L\n" +
+	"\x10HasResultRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"^\n" +
+	"\x11HasResultResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x90\x01\n" +
+	"\x14ExportResultsRequest\x12\x1b\n" +
+	"\tagent_ids\x18\x01 \x03(\tR\bagentIds\x12\x1f\n" +
+	"\vmodule_name\x18\x02 \x01(\tR\n" +
+	"moduleName\x12\x16\n" +
+	"\x06fields\x18\x03 \x03(\tR\x06fields\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\x03R\x02to\"(\n" +
+	"\x12ExportResultsChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x8f\x01\n" +
+	"\x15ProfileResultsRequest\x12\x1b\n" +
+	"\tagent_ids\x18\x01 \x03(\tR\bagentIds\x12\x1f\n" +
+	"\vmodule_name\x18\x02 \x01(\tR\n" +
+	"moduleName\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\x03R\x02to\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\xf1\x03\n" +
+	"\fFieldProfile\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x18\n" +
+	"\aresults\x18\x02 \x01(\x03R\aresults\x12\x1a\n" +
+	"\bcoverage\x18\x03 \x01(\x01R\bcoverage\x12\x16\n" +
+	"\x06values\x18\x04 \x01(\x03R\x06values\x123\n" +
+	"\x05types\x18\x05 \x03(\v2\x1d.dbos.FieldProfile.TypesEntryR\x05types\x12\x1b\n" +
+	"\tnull_rate\x18\x06 \x01(\x01R\bnullRate\x12\x10\n" +
+	"\x03min\x18\a \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\b \x01(\x01R\x03max\x12\x12\n" +
+	"\x04mean\x18\t \x01(\x01R\x04mean\x12\x1d\n" +
+	"\n" +
+	"min_length\x18\n" +
+	" \x01(\x05R\tminLength\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\v \x01(\x05R\tmaxLength\x12)\n" +
+	"\x10distinct_strings\x18\f \x01(\x05R\x0fdistinctStrings\x126\n" +
+	"\x17distinct_strings_capped\x18\r \x01(\bR\x15distinctStringsCapped\x12\x1a\n" +
+	"\bexamples\x18\x0e \x03(\tR\bexamples\x1a8\n" +
+	"\n" +
+	"TypesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x85\x02\n" +
+	"\x16ProfileResultsResponse\x12\x18\n" +
+	"\aresults\x18\x01 \x01(\x03R\aresults\x12'\n" +
+	"\x0finvalid_results\x18\x02 \x01(\x03R\x0einvalidResults\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x12*\n" +
+	"\x06fields\x18\x04 \x03(\v2\x12.dbos.FieldProfileR\x06fields\x12)\n" +
+	"\x10fields_truncated\x18\x05 \x01(\bR\x0ffieldsTruncated\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\a \x01(\tR\terrorCode\"0\n" +
+	"\x13GetClockSkewRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\x86\x01\n" +
+	"\x14GetClockSkewResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12#\n" +
+	"\x04skew\x18\x02 \x01(\v2\x0f.dbos.ClockSkewR\x04skew\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\xc3\x02\n" +
+	"\x05Alert\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tresult_id\x18\x04 \x01(\tR\bresultId\x12\x1f\n" +
+	"\vmodule_name\x18\x05 \x01(\tR\n" +
+	"moduleName\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\tR\x06target\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x122\n" +
+	"\adetails\x18\b \x03(\v2\x18.dbos.Alert.DetailsEntryR\adetails\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"X\n" +
+	"\x11ListAlertsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"n\n" +
+	"\x12ListAlertsResponse\x12#\n" +
+	"\x06alerts\x18\x01 \x03(\v2\v.dbos.AlertR\x06alerts\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\xe2\x05\n" +
+	"\bIncident\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\tR\x06target\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x1b\n" +
+	"\tagent_ids\x18\b \x03(\tR\bagentIds\x12\x1d\n" +
+	"\n" +
+	"result_ids\x18\t \x03(\tR\tresultIds\x128\n" +
+	"\bevidence\x18\n" +
+	" \x03(\v2\x1c.dbos.Incident.EvidenceEntryR\bevidence\x12 \n" +
+	"\voccurrences\x18\v \x01(\x03R\voccurrences\x12\x1d\n" +
+	"\n" +
+	"first_seen\x18\f \x01(\x03R\tfirstSeen\x12\x1b\n" +
+	"\tlast_seen\x18\r \x01(\x03R\blastSeen\x12\x1f\n" +
+	"\vresolved_at\x18\x0e \x01(\x03R\n" +
+	"resolvedAt\x12\x1b\n" +
+	"\tevent_ids\x18\x0f \x03(\tR\beventIds\x12\x14\n" +
+	"\x05title\x18\x10 \x01(\tR\x05title\x12\x18\n" +
+	"\atargets\x18\x11 \x03(\tR\atargets\x12\x1b\n" +
+	"\talert_ids\x18\x12 \x03(\tR\balertIds\x121\n" +
+	"\bcomments\x18\x13 \x03(\v2\x15.dbos.IncidentCommentR\bcomments\x12'\n" +
+	"\x0facknowledged_by\x18\x14 \x01(\tR\x0eacknowledgedBy\x12'\n" +
+	"\x0facknowledged_at\x18\x15 \x01(\x03R\x0eacknowledgedAt\x12\x1f\n" +
+	"\vresolved_by\x18\x16 \x01(\tR\n" +
+	"resolvedBy\x1a;\n" +
+	"\rEvidenceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"l\n" +
+	"\x0fIncidentComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"\xbf\x02\n" +
+	"\fRoutingEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12%\n" +
+	"\x0ewatched_prefix\x18\x04 \x01(\tR\rwatchedPrefix\x12\x1d\n" +
+	"\n" +
+	"origin_asn\x18\x05 \x01(\x03R\toriginAsn\x12.\n" +
+	"\x13expected_origin_asn\x18\x06 \x01(\x03R\x11expectedOriginAsn\x12\x12\n" +
+	"\x04path\x18\a \x01(\tR\x04path\x12\x12\n" +
+	"\x04peer\x18\b \x01(\tR\x04peer\x12\x19\n" +
+	"\bpeer_asn\x18\t \x01(\tR\apeerAsn\x12\x1c\n" +
+	"\tcollector\x18\n" +
+	" \x01(\tR\tcollector\x12\x1c\n" +
+	"\ttimestamp\x18\v \x01(\x03R\ttimestamp\"m\n" +
+	"\x18ListRoutingEventsRequest\x12%\n" +
+	"\x0ewatched_prefix\x18\x01 \x01(\tR\rwatchedPrefix\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"|\n" +
+	"\x19ListRoutingEventsResponse\x12*\n" +
+	"\x06events\x18\x01 \x03(\v2\x12.dbos.RoutingEventR\x06events\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"$\n" +
+	"\x12GetIncidentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8c\x01\n" +
+	"\x13GetIncidentResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12*\n" +
+	"\bincident\x18\x02 \x01(\v2\x0e.dbos.IncidentR\bincident\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"p\n" +
+	"\x14ListIncidentsRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"z\n" +
+	"\x15ListIncidentsResponse\x12,\n" +
+	"\tincidents\x18\x01 \x03(\v2\x0e.dbos.IncidentR\tincidents\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x9a\x02\n" +
+	"\x15CreateIncidentRequest\x12\x1a\n" +
+	"\x05title\x18\x01 \x01(\tB\x04\x88\xb5\x18\x01R\x05title\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\x12\x18\n" +
+	"\atargets\x18\x05 \x03(\tR\atargets\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x1b\n" +
+	"\tagent_ids\x18\a \x03(\tR\bagentIds\x12\x1d\n" +
+	"\n" +
+	"result_ids\x18\b \x03(\tR\tresultIds\x12\x1b\n" +
+	"\talert_ids\x18\t \x03(\tR\balertIds\x12\x16\n" +
+	"\x06author\x18\n" +
+	" \x01(\tR\x06author\"\x93\x01\n" +
+	"\x16CreateIncidentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
+	"\bincident\x18\x02 \x01(\v2\x0e.dbos.IncidentR\bincident\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\xe4\x01\n" +
+	"\x15UpdateIncidentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\"\n" +
+	"\radd_agent_ids\x18\x04 \x03(\tR\vaddAgentIds\x12\x1f\n" +
+	"\vadd_targets\x18\x05 \x03(\tR\n" +
+	"addTargets\x12$\n" +
+	"\x0eadd_result_ids\x18\x06 \x03(\tR\faddResultIds\x12\"\n" +
+	"\radd_alert_ids\x18\a \x03(\tR\vaddAlertIds\"\x93\x01\n" +
+	"\x16UpdateIncidentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
+	"\bincident\x18\x02 \x01(\v2\x0e.dbos.IncidentR\bincident\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"D\n" +
+	"\x1aAcknowledgeIncidentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\"\x98\x01\n" +
+	"\x1bAcknowledgeIncidentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
+	"\bincident\x18\x02 \x01(\v2\x0e.dbos.IncidentR\bincident\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"Z\n" +
+	"\x16ResolveIncidentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"\x94\x01\n" +
+	"\x17ResolveIncidentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
+	"\bincident\x18\x02 \x01(\v2\x0e.dbos.IncidentR\bincident\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"]\n" +
+	"\x19AddIncidentCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x18\n" +
+	"\x04body\x18\x03 \x01(\tB\x04\x88\xb5\x18\x01R\x04body\"\x97\x01\n" +
+	"\x1aAddIncidentCommentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
+	"\bincident\x18\x02 \x01(\v2\x0e.dbos.IncidentR\bincident\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"'\n" +
+	"\x15DeleteIncidentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"g\n" +
+	"\x16DeleteIncidentResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"3\n" +
+	"\x15WatchIncidentsRequest\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\tR\brevision\"k\n" +
+	"\rIncidentEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12*\n" +
+	"\bincident\x18\x02 \x01(\v2\x0e.dbos.IncidentR\bincident\x12\x1a\n" +
+	"\brevision\x18\x03 \x01(\tR\brevision\"w\n" +
+	"\x14GetIngestGapsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12#\n" +
+	"\rfrom_sequence\x18\x02 \x01(\x03R\ffromSequence\x12\x1f\n" +
+	"\vto_sequence\x18\x03 \x01(\x03R\n" +
+	"toSequence\"S\n" +
+	"\vSequenceGap\x12#\n" +
+	"\rfrom_sequence\x18\x01 \x01(\x03R\ffromSequence\x12\x1f\n" +
+	"\vto_sequence\x18\x02 \x01(\x03R\n" +
+	"toSequence\"\x98\x01\n" +
+	"\x15GetIngestGapsResponse\x12%\n" +
+	"\x04gaps\x18\x01 \x03(\v2\x11.dbos.SequenceGapR\x04gaps\x12#\n" +
+	"\rlast_sequence\x18\x02 \x01(\x03R\flastSequence\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"_\n" +
+	"\x13ScheduleTaskRequest\x12$\n" +
 	"\x04task\x18\x01 \x01(\v2\n" +
-	".dbos.TaskR\x04task\"F\n" +
+	".dbos.TaskB\x04\x88\xb5\x18\x01R\x04task\x12\"\n" +
+	"\tdedup_key\x18\x02 \x01(\tB\x05\x98\xb5\x18\x80\x02R\bdedupKey\"\xa5\x01\n" +
 	"\x14ScheduleTaskResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\")\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\tR\x06taskId\x12%\n" +
+	"\x0ealready_exists\x18\x05 \x01(\bR\ralreadyExists\")\n" +
 	"\x0eGetTaskRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\"]\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"\xac\x01\n" +
 	"\x0fGetTaskResponse\x12\x14\n" +
 	"\x05found\x18\x01 \x01(\bR\x05found\x12\x1e\n" +
 	"\x04task\x18\x02 \x01(\v2\n" +
 	".dbos.TaskR\x04task\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"3\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12.\n" +
+	"\bprogress\x18\x05 \x01(\v2\x12.dbos.TaskProgressR\bprogress\",\n" +
+	"\x11CancelTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"c\n" +
+	"\x12CancelTaskResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"9\n" +
+	"\x12StreamTasksRequest\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\aagentId\"Z\n" +
+	"\x10LeaseTaskRequest\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\aagentId\x12!\n" +
+	"\fwait_seconds\x18\x02 \x01(\x03R\vwaitSeconds\"\xa7\x01\n" +
+	"\x11LeaseTaskResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x1e\n" +
+	"\x04task\x18\x02 \x01(\v2\n" +
+	".dbos.TaskR\x04task\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12'\n" +
+	"\abackoff\x18\x05 \x01(\v2\r.dbos.BackoffR\abackoff\"G\n" +
+	"\x0fAckTasksRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x19\n" +
+	"\btask_ids\x18\x02 \x03(\tR\ataskIds\"q\n" +
+	"\aTaskAck\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"p\n" +
+	"\x10AckTasksResponse\x12'\n" +
+	"\aresults\x18\x01 \x03(\v2\r.dbos.TaskAckR\aresults\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x9f\x01\n" +
+	"\x10NackTasksRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x19\n" +
+	"\btask_ids\x18\x02 \x03(\tR\ataskIds\x12\x18\n" +
+	"\arequeue\x18\x03 \x01(\bR\arequeue\x12;\n" +
+	"\x13retry_delay_seconds\x18\x04 \x01(\x03B\v\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00R\x11retryDelaySeconds\"q\n" +
+	"\x11NackTasksResponse\x12'\n" +
+	"\aresults\x18\x01 \x03(\v2\r.dbos.TaskAckR\aresults\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\xa4\x01\n" +
+	"\x1bExtendTaskVisibilityRequest\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\aagentId\x12\x1d\n" +
+	"\atask_id\x18\x02 \x01(\tB\x04\x88\xb5\x18\x01R\x06taskId\x12A\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x03B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\xf0?\xc1\xb5\x18\x00\x00\x00\x00\x00\x18\xf5@R\x0fdurationSeconds\"\x97\x01\n" +
+	"\x1cExtendTaskVisibilityResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12(\n" +
+	"\x10lease_expires_at\x18\x02 \x01(\x03R\x0eleaseExpiresAt\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\xab\x01\n" +
+	"\fTaskProgress\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x18\n" +
+	"\apercent\x18\x03 \x01(\x01R\apercent\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x12\n" +
+	"\x04data\x18\x05 \x01(\fR\x04data\x12\x1f\n" +
+	"\vreported_at\x18\x06 \x01(\x03R\n" +
+	"reportedAt\"\xce\x01\n" +
+	"\x19ReportTaskProgressRequest\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\aagentId\x12\x1d\n" +
+	"\atask_id\x18\x02 \x01(\tB\x04\x88\xb5\x18\x01R\x06taskId\x120\n" +
+	"\apercent\x18\x03 \x01(\x01B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00\xc1\xb5\x18\x00\x00\x00\x00\x00\x00Y@R\apercent\x12\x1f\n" +
+	"\amessage\x18\x04 \x01(\tB\x05\x98\xb5\x18\x80\bR\amessage\x12\x1a\n" +
+	"\x04data\x18\x05 \x01(\fB\x06\x98\xb5\x18\x80\x80\x04R\x04data\"k\n" +
+	"\x1aReportTaskProgressResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\xad\x03\n" +
+	"\fVerification\x12\x14\n" +
+	"\x02id\x18\x01 \x01(\tB\x04\x90\xb5\x18\x01R\x02id\x12\x1f\n" +
+	"\vmodule_name\x18\x02 \x01(\tR\n" +
+	"moduleName\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x12\x1a\n" +
+	"\breplicas\x18\x04 \x01(\x05R\breplicas\x12#\n" +
+	"\rcompare_field\x18\x05 \x01(\tR\fcompareField\x12)\n" +
+	"\ttolerance\x18\x06 \x01(\x01B\v\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00R\ttolerance\x12\x1b\n" +
+	"\tagent_ids\x18\a \x03(\tR\bagentIds\x12\x19\n" +
+	"\btask_ids\x18\b \x03(\tR\ataskIds\x126\n" +
+	"\x06values\x18\t \x03(\v2\x1e.dbos.Verification.ValuesEntryR\x06values\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xdf\x01\n" +
+	"\x1bScheduleVerifiedTaskRequest\x126\n" +
+	"\fverification\x18\x01 \x01(\v2\x12.dbos.VerificationR\fverification\x12K\n" +
+	"\bselector\x18\x02 \x03(\v2/.dbos.ScheduleVerifiedTaskRequest.SelectorEntryR\bselector\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa5\x01\n" +
+	"\x1cScheduleVerifiedTaskResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x126\n" +
+	"\fverification\x18\x02 \x01(\v2\x12.dbos.VerificationR\fverification\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"A\n" +
+	"\x16GetVerificationRequest\x12'\n" +
+	"\x0fverification_id\x18\x01 \x01(\tR\x0everificationId\"\x9c\x01\n" +
+	"\x17GetVerificationResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x126\n" +
+	"\fverification\x18\x02 \x01(\v2\x12.dbos.VerificationR\fverification\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\xf5\x02\n" +
+	"\tBroadcast\x12\x14\n" +
+	"\x02id\x18\x01 \x01(\tB\x04\x90\xb5\x18\x01R\x02id\x12\x1f\n" +
+	"\vmodule_name\x18\x02 \x01(\tR\n" +
+	"moduleName\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x129\n" +
+	"\bselector\x18\x04 \x03(\v2\x1d.dbos.Broadcast.SelectorEntryR\bselector\x12\x1b\n" +
+	"\tagent_ids\x18\x05 \x03(\tR\bagentIds\x12\x19\n" +
+	"\btask_ids\x18\x06 \x03(\tR\ataskIds\x12!\n" +
+	"\fscheduled_at\x18\a \x01(\x03R\vscheduledAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\x12%\n" +
+	"\x0ecorrelation_id\x18\t \x01(\tR\rcorrelationId\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe8\x01\n" +
+	"\x14BroadcastTaskRequest\x12'\n" +
+	"\fbroadcast_id\x18\x01 \x01(\tB\x04\x90\xb5\x18\x01R\vbroadcastId\x12$\n" +
+	"\x04task\x18\x02 \x01(\v2\n" +
+	".dbos.TaskB\x04\x88\xb5\x18\x01R\x04task\x12D\n" +
+	"\bselector\x18\x03 \x03(\v2(.dbos.BroadcastTaskRequest.SelectorEntryR\bselector\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x95\x01\n" +
+	"\x15BroadcastTaskResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12-\n" +
+	"\tbroadcast\x18\x02 \x01(\v2\x0f.dbos.BroadcastR\tbroadcast\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"D\n" +
+	"\x19GetBroadcastStatusRequest\x12'\n" +
+	"\fbroadcast_id\x18\x01 \x01(\tB\x04\x88\xb5\x18\x01R\vbroadcastId\"b\n" +
+	"\x14BroadcastAgentStatus\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\xe6\x02\n" +
+	"\x1aGetBroadcastStatusResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12-\n" +
+	"\tbroadcast\x18\x02 \x01(\v2\x0f.dbos.BroadcastR\tbroadcast\x122\n" +
+	"\x06agents\x18\x03 \x03(\v2\x1a.dbos.BroadcastAgentStatusR\x06agents\x12\x18\n" +
+	"\apending\x18\x04 \x01(\x05R\apending\x12\x18\n" +
+	"\arunning\x18\x05 \x01(\x05R\arunning\x12\x1c\n" +
+	"\tcompleted\x18\x06 \x01(\x05R\tcompleted\x12\x16\n" +
+	"\x06failed\x18\a \x01(\x05R\x06failed\x12\x1c\n" +
+	"\tcancelled\x18\b \x01(\x05R\tcancelled\x12\x12\n" +
+	"\x04done\x18\t \x01(\bR\x04done\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\v \x01(\tR\terrorCode\"\xb2\x01\n" +
+	"\x04View\x12\x18\n" +
+	"\x04name\x18\x01 \x01(\tB\x04\x88\xb5\x18\x01R\x04name\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1f\n" +
+	"\vmodule_name\x18\x03 \x01(\tR\n" +
+	"moduleName\x12\x1b\n" +
+	"\tkey_field\x18\x04 \x01(\tR\bkeyField\x12\x1f\n" +
+	"\vvalue_field\x18\x05 \x01(\tR\n" +
+	"valueField\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\"\xf5\x01\n" +
+	"\aViewRow\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\tR\x03day\x12\x1b\n" +
+	"\tresult_id\x18\x04 \x01(\tR\bresultId\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x12\n" +
+	"\x04data\x18\x06 \x01(\fR\x04data\x12\x14\n" +
+	"\x05count\x18\a \x01(\x03R\x05count\x12\x10\n" +
+	"\x03sum\x18\b \x01(\x01R\x03sum\x12\x10\n" +
+	"\x03min\x18\t \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\n" +
+	" \x01(\x01R\x03max\x12\x10\n" +
+	"\x03avg\x18\v \x01(\x01R\x03avg\"U\n" +
+	"\x11CreateViewRequest\x12$\n" +
+	"\x04view\x18\x01 \x01(\v2\n" +
+	".dbos.ViewB\x04\x88\xb5\x18\x01R\x04view\x12\x1a\n" +
+	"\bbackfill\x18\x02 \x01(\bR\bbackfill\"c\n" +
+	"\x12CreateViewResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x12\n" +
+	"\x10ListViewsRequest\"j\n" +
+	"\x11ListViewsResponse\x12 \n" +
+	"\x05views\x18\x01 \x03(\v2\n" +
+	".dbos.ViewR\x05views\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"'\n" +
+	"\x11DeleteViewRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"c\n" +
+	"\x12DeleteViewResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"e\n" +
+	"\x10QueryViewRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x10\n" +
+	"\x03day\x18\x04 \x01(\tR\x03day\"k\n" +
+	"\x11QueryViewResponse\x12!\n" +
+	"\x04rows\x18\x01 \x03(\v2\r.dbos.ViewRowR\x04rows\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x90\x01\n" +
+	"\x0eExtractionRule\x12\x1f\n" +
+	"\vmodule_name\x18\x01 \x01(\tR\n" +
+	"moduleName\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\tR\x06column\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"i\n" +
+	"\x1bCreateExtractionRuleRequest\x12.\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.dbos.ExtractionRuleB\x04\x88\xb5\x18\x01R\x04rule\x12\x1a\n" +
+	"\bbackfill\x18\x02 \x01(\bR\bbackfill\"m\n" +
+	"\x1cCreateExtractionRuleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"=\n" +
+	"\x1aListExtractionRulesRequest\x12\x1f\n" +
+	"\vmodule_name\x18\x01 \x01(\tR\n" +
+	"moduleName\"~\n" +
+	"\x1bListExtractionRulesResponse\x12*\n" +
+	"\x05rules\x18\x01 \x03(\v2\x14.dbos.ExtractionRuleR\x05rules\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"V\n" +
+	"\x1bDeleteExtractionRuleRequest\x12\x1f\n" +
+	"\vmodule_name\x18\x01 \x01(\tR\n" +
+	"moduleName\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\tR\x06column\"m\n" +
+	"\x1cDeleteExtractionRuleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"L\n" +
+	"\fColumnFilter\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\tR\x06column\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"\x95\x01\n" +
+	"\x13QueryResultsRequest\x12\x1f\n" +
+	"\vmodule_name\x18\x01 \x01(\tR\n" +
+	"moduleName\x12,\n" +
+	"\afilters\x18\x02 \x03(\v2\x12.dbos.ColumnFilterR\afilters\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"~\n" +
+	"\x14QueryResultsResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.dbos.MeasurementResultR\aresults\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\xdd\x03\n" +
+	"\n" +
+	"SavedQuery\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x1f\n" +
+	"\vmodule_name\x18\x04 \x01(\tR\n" +
+	"moduleName\x12,\n" +
+	"\afilters\x18\x05 \x03(\v2\x12.dbos.ColumnFilterR\afilters\x12\x19\n" +
+	"\bagent_id\x18\x06 \x01(\tR\aagentId\x124\n" +
+	"\x06labels\x18\a \x03(\v2\x1c.dbos.SavedQuery.LabelsEntryR\x06labels\x12\x1d\n" +
+	"\n" +
+	"alive_only\x18\b \x01(\bR\taliveOnly\x123\n" +
+	"\vaggregation\x18\t \x01(\v2\x11.dbos.AggregationR\vaggregation\x12\x14\n" +
+	"\x05limit\x18\n" +
+	" \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\x03R\tupdatedAt\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
+	"\vAggregation\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\tR\bfunction\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\tR\x06column\"G\n" +
+	"\x17CreateSavedQueryRequest\x12,\n" +
+	"\x05query\x18\x01 \x01(\v2\x10.dbos.SavedQueryB\x04\x88\xb5\x18\x01R\x05query\"i\n" +
+	"\x18CreateSavedQueryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"*\n" +
+	"\x14GetSavedQueryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x8a\x01\n" +
+	"\x15GetSavedQueryResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12&\n" +
+	"\x05query\x18\x02 \x01(\v2\x10.dbos.SavedQueryR\x05query\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\x19\n" +
+	"\x17ListSavedQueriesRequest\"{\n" +
+	"\x18ListSavedQueriesResponse\x12*\n" +
+	"\aqueries\x18\x01 \x03(\v2\x10.dbos.SavedQueryR\aqueries\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"G\n" +
+	"\x17UpdateSavedQueryRequest\x12,\n" +
+	"\x05query\x18\x01 \x01(\v2\x10.dbos.SavedQueryB\x04\x88\xb5\x18\x01R\x05query\"i\n" +
+	"\x18UpdateSavedQueryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"-\n" +
+	"\x17DeleteSavedQueryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"i\n" +
+	"\x18DeleteSavedQueryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\".\n" +
+	"\x18ExecuteSavedQueryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x92\x02\n" +
+	"\x19ExecuteSavedQueryResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.dbos.MeasurementResultR\aresults\x12#\n" +
+	"\x06agents\x18\x02 \x03(\v2\v.dbos.AgentR\x06agents\x12\x1e\n" +
+	"\n" +
+	"aggregated\x18\x03 \x01(\bR\n" +
+	"aggregated\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x12\x14\n" +
+	"\x05count\x18\x05 \x01(\x03R\x05count\x12\x1c\n" +
+	"\ttruncated\x18\x06 \x01(\bR\ttruncated\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\b \x01(\tR\terrorCode\"\xdc\x01\n" +
+	"\tAlertRule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04expr\x18\x02 \x01(\tR\x04expr\x12\x1f\n" +
+	"\vfor_seconds\x18\x03 \x01(\x03R\n" +
+	"forSeconds\x12\x1a\n" +
+	"\bseverity\x18\x04 \x01(\tR\bseverity\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12)\n" +
+	"\x06series\x18\a \x03(\v2\x11.dbos.AlertSeriesR\x06series\"\xbe\x01\n" +
+	"\vAlertSeries\x12\x16\n" +
+	"\x06series\x18\x01 \x01(\tR\x06series\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x12!\n" +
+	"\factive_since\x18\x05 \x01(\x03R\vactiveSince\x12\x16\n" +
+	"\x06firing\x18\x06 \x01(\bR\x06firing\x12\x19\n" +
+	"\bfired_at\x18\a \x01(\x03R\afiredAt\"C\n" +
+	"\x16CreateAlertRuleRequest\x12)\n" +
+	"\x04rule\x18\x01 \x01(\v2\x0f.dbos.AlertRuleB\x04\x88\xb5\x18\x01R\x04rule\"h\n" +
+	"\x17CreateAlertRuleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x17\n" +
+	"\x15ListAlertRulesRequest\"t\n" +
+	"\x16ListAlertRulesResponse\x12%\n" +
+	"\x05rules\x18\x01 \x03(\v2\x0f.dbos.AlertRuleR\x05rules\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\",\n" +
+	"\x16DeleteAlertRuleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"h\n" +
+	"\x17DeleteAlertRuleResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\xb8\x03\n" +
+	"\x11MaintenanceWindow\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12A\n" +
+	"\bselector\x18\x04 \x03(\v2%.dbos.MaintenanceWindow.SelectorEntryR\bselector\x12\x16\n" +
+	"\x06tenant\x18\x05 \x01(\tR\x06tenant\x12\x18\n" +
+	"\atargets\x18\x06 \x03(\tR\atargets\x12\x1b\n" +
+	"\tstarts_at\x18\a \x01(\x03R\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\b \x01(\x03R\x06endsAt\x12\x1f\n" +
+	"\vpause_tasks\x18\t \x01(\bR\n" +
+	"pauseTasks\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\n" +
+	" \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x16\n" +
+	"\x06active\x18\f \x01(\bR\x06active\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"W\n" +
+	"\x1eCreateMaintenanceWindowRequest\x125\n" +
+	"\x06window\x18\x01 \x01(\v2\x17.dbos.MaintenanceWindowB\x04\x88\xb5\x18\x01R\x06window\"\xa1\x01\n" +
+	"\x1fCreateMaintenanceWindowResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12/\n" +
+	"\x06window\x18\x03 \x01(\v2\x17.dbos.MaintenanceWindowR\x06window\"-\n" +
+	"\x1bGetMaintenanceWindowRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9a\x01\n" +
+	"\x1cGetMaintenanceWindowResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12/\n" +
+	"\x06window\x18\x02 \x01(\v2\x17.dbos.MaintenanceWindowR\x06window\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"@\n" +
+	"\x1dListMaintenanceWindowsRequest\x12\x1f\n" +
+	"\vactive_only\x18\x01 \x01(\bR\n" +
+	"activeOnly\"\x88\x01\n" +
+	"\x1eListMaintenanceWindowsResponse\x121\n" +
+	"\awindows\x18\x01 \x03(\v2\x17.dbos.MaintenanceWindowR\awindows\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"W\n" +
+	"\x1eUpdateMaintenanceWindowRequest\x125\n" +
+	"\x06window\x18\x01 \x01(\v2\x17.dbos.MaintenanceWindowB\x04\x88\xb5\x18\x01R\x06window\"\xa1\x01\n" +
+	"\x1fUpdateMaintenanceWindowResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12/\n" +
+	"\x06window\x18\x03 \x01(\v2\x17.dbos.MaintenanceWindowR\x06window\"0\n" +
+	"\x1eDeleteMaintenanceWindowRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"p\n" +
+	"\x1fDeleteMaintenanceWindowResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\x8a\x01\n" +
+	"\n" +
+	"TrendPoint\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x10\n" +
+	"\x03min\x18\x03 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x04 \x01(\x01R\x03max\x12\x12\n" +
+	"\x04mean\x18\x05 \x01(\x01R\x04mean\x12\x1c\n" +
+	"\tquantiles\x18\x06 \x03(\x01R\tquantiles\"\xad\x01\n" +
+	"\x10GetTrendsRequest\x12\x16\n" +
+	"\x06metric\x18\x01 \x01(\tR\x06metric\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x19\n" +
+	"\bfrom_day\x18\x04 \x01(\tR\afromDay\x12\x15\n" +
+	"\x06to_day\x18\x05 \x01(\tR\x05toDay\x12\x1c\n" +
+	"\tquantiles\x18\x06 \x03(\x01R\tquantiles\"\xbc\x01\n" +
+	"\x11GetTrendsResponse\x12(\n" +
+	"\x06points\x18\x01 \x03(\v2\x10.dbos.TrendPointR\x06points\x12*\n" +
+	"\asummary\x18\x02 \x01(\v2\x10.dbos.TrendPointR\asummary\x12\x1c\n" +
+	"\tquantiles\x18\x03 \x03(\x01R\tquantiles\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\tR\terrorCode\"\xd2\x02\n" +
+	"\x10ListTasksRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x19\n" +
+	"\border_by\x18\x02 \x01(\tR\aorderBy\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x1f\n" +
+	"\vmodule_name\x18\x05 \x01(\tR\n" +
+	"moduleName\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12#\n" +
+	"\rcreated_since\x18\a \x01(\x03R\fcreatedSince\x12#\n" +
+	"\rcreated_until\x18\b \x01(\x03R\fcreatedUntil\x12'\n" +
+	"\x0fscheduled_since\x18\t \x01(\x03R\x0escheduledSince\x12'\n" +
+	"\x0fscheduled_until\x18\n" +
+	" \x01(\x03R\x0escheduledUntil\"\x8b\x01\n" +
+	"\x11ListTasksResponse\x12 \n" +
+	"\x05tasks\x18\x01 \x03(\v2\n" +
+	".dbos.TaskR\x05tasks\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"w\n" +
 	"\x13ListDueTasksRequest\x12\x1c\n" +
-	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\"N\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x1f\n" +
+	"\bagent_id\x18\x02 \x01(\tB\x04\x90\xb5\x18\x01R\aagentId\x12!\n" +
+	"\x05limit\x18\x03 \x01(\x05B\v\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00R\x05limit\"m\n" +
 	"\x14ListDueTasksResponse\x12 \n" +
 	"\x05tasks\x18\x01 \x03(\v2\n" +
 	".dbos.TaskR\x05tasks\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error2\xc5\x06\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"4\n" +
+	"\x17ListPendingTasksRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\x96\x01\n" +
+	"\vPendingTask\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x19\n" +
+	"\bentry_id\x18\x02 \x01(\tR\aentryId\x12\x1a\n" +
+	"\bconsumer\x18\x03 \x01(\tR\bconsumer\x12\x17\n" +
+	"\aidle_ms\x18\x04 \x01(\x03R\x06idleMs\x12\x1e\n" +
+	"\n" +
+	"deliveries\x18\x05 \x01(\x03R\n" +
+	"deliveries\"x\n" +
+	"\x18ListPendingTasksResponse\x12'\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x11.dbos.PendingTaskR\x05tasks\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\xb8\x05\n" +
+	"\bCampaign\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vmodule_name\x18\x04 \x01(\tR\n" +
+	"moduleName\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\fR\apayload\x12\x18\n" +
+	"\atargets\x18\x06 \x03(\tR\atargets\x12!\n" +
+	"\ftarget_field\x18\a \x01(\tR\vtargetField\x12\x1b\n" +
+	"\tagent_ids\x18\b \x03(\tR\bagentIds\x128\n" +
+	"\bselector\x18\t \x03(\v2\x1c.dbos.Campaign.SelectorEntryR\bselector\x12)\n" +
+	"\x10interval_seconds\x18\n" +
+	" \x01(\x03R\x0fintervalSeconds\x12\x1b\n" +
+	"\tstarts_at\x18\v \x01(\x03R\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\f \x01(\x03R\x06endsAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\r \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\x03R\tcreatedAt\x12\x16\n" +
+	"\x06status\x18\x0f \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"stopped_at\x18\x10 \x01(\x03R\tstoppedAt\x12\x1e\n" +
+	"\vnext_run_at\x18\x11 \x01(\x03R\tnextRunAt\x12\x16\n" +
+	"\x06rounds\x18\x12 \x01(\x03R\x06rounds\x12!\n" +
+	"\ftasks_issued\x18\x13 \x01(\x03R\vtasksIssued\x12)\n" +
+	"\x10results_received\x18\x14 \x01(\x03R\x0fresultsReceived\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"I\n" +
+	"\x15CreateCampaignRequest\x120\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x0e.dbos.CampaignB\x04\x88\xb5\x18\x01R\bcampaign\"\x93\x01\n" +
+	"\x16CreateCampaignResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12*\n" +
+	"\bcampaign\x18\x03 \x01(\v2\x0e.dbos.CampaignR\bcampaign\"$\n" +
+	"\x12GetCampaignRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8c\x01\n" +
+	"\x13GetCampaignResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12*\n" +
+	"\bcampaign\x18\x02 \x01(\v2\x0e.dbos.CampaignR\bcampaign\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\".\n" +
+	"\x14ListCampaignsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"z\n" +
+	"\x15ListCampaignsResponse\x12,\n" +
+	"\tcampaigns\x18\x01 \x03(\v2\x0e.dbos.CampaignR\tcampaigns\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"%\n" +
+	"\x13StopCampaignRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x91\x01\n" +
+	"\x14StopCampaignResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12*\n" +
+	"\bcampaign\x18\x03 \x01(\v2\x0e.dbos.CampaignR\bcampaign\"\xbf\x01\n" +
+	"\x1aListCampaignResultsRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x127\n" +
+	"\tread_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x19\n" +
+	"\border_by\x18\x05 \x01(\tR\aorderBy\"\xa6\x01\n" +
+	"\x1bListCampaignResultsResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.dbos.MeasurementResultR\aresults\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"K\n" +
+	"\x10ApplySpecRequest\x12\x1e\n" +
+	"\x04spec\x18\x01 \x01(\fB\n" +
+	"\x88\xb5\x18\x01\x98\xb5\x18\x80\x80@R\x04spec\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"d\n" +
+	"\n" +
+	"SpecChange\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\"\x8e\x01\n" +
+	"\x11ApplySpecResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12*\n" +
+	"\achanges\x18\x02 \x03(\v2\x10.dbos.SpecChangeR\achanges\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\x11\n" +
+	"\x0fGetDriftRequest\"\x80\x01\n" +
+	"\x05Drift\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\x12\x1f\n" +
+	"\vdetected_at\x18\x05 \x01(\x03R\n" +
+	"detectedAt\"l\n" +
+	"\x10GetDriftResponse\x12#\n" +
+	"\x06drifts\x18\x01 \x03(\v2\v.dbos.DriftR\x06drifts\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"\xad\x02\n" +
+	"\x13PlanCapacityRequest\x12*\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x0e.dbos.CampaignR\bcampaign\x127\n" +
+	"\fagent_budget\x18\x02 \x01(\v2\x14.dbos.CapacityBudgetR\vagentBudget\x12\x1f\n" +
+	"\vgroup_label\x18\x03 \x01(\tR\n" +
+	"groupLabel\x127\n" +
+	"\fgroup_budget\x18\x04 \x01(\v2\x14.dbos.CapacityBudgetR\vgroupBudget\x12'\n" +
+	"\x0fhorizon_seconds\x18\x05 \x01(\x03R\x0ehorizonSeconds\x12.\n" +
+	"\x13default_duration_ms\x18\x06 \x01(\x03R\x11defaultDurationMs\"\x92\x01\n" +
+	"\x0eCapacityBudget\x12'\n" +
+	"\x0fmax_utilization\x18\x01 \x01(\x01R\x0emaxUtilization\x12&\n" +
+	"\x0fmax_queue_depth\x18\x02 \x01(\x03R\rmaxQueueDepth\x12/\n" +
+	"\x14max_bytes_per_second\x18\x03 \x01(\x01R\x11maxBytesPerSecond\"\xda\x03\n" +
+	"\rAgentCapacity\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05group\x18\x02 \x01(\tR\x05group\x12&\n" +
+	"\x0ftasks_per_round\x18\x03 \x01(\x03R\rtasksPerRound\x12$\n" +
+	"\x0etasks_per_hour\x18\x04 \x01(\x01R\ftasksPerHour\x12(\n" +
+	"\x10mean_duration_ms\x18\x05 \x01(\x01R\x0emeanDurationMs\x12&\n" +
+	"\x0fp95_duration_ms\x18\x06 \x01(\x01R\rp95DurationMs\x12'\n" +
+	"\x0fduration_source\x18\a \x01(\tR\x0edurationSource\x12\x18\n" +
+	"\asamples\x18\b \x01(\x03R\asamples\x12#\n" +
+	"\rround_seconds\x18\t \x01(\x01R\froundSeconds\x12 \n" +
+	"\vutilization\x18\n" +
+	" \x01(\x01R\vutilization\x12(\n" +
+	"\x10peak_queue_depth\x18\v \x01(\x03R\x0epeakQueueDepth\x12(\n" +
+	"\x10bytes_per_second\x18\f \x01(\x01R\x0ebytesPerSecond\x12\x1a\n" +
+	"\bexceeded\x18\r \x03(\tR\bexceeded\"\xf5\x01\n" +
+	"\rGroupCapacity\x12\x14\n" +
+	"\x05group\x18\x01 \x01(\tR\x05group\x12\x16\n" +
+	"\x06agents\x18\x02 \x01(\x05R\x06agents\x12$\n" +
+	"\x0etasks_per_hour\x18\x03 \x01(\x01R\ftasksPerHour\x12 \n" +
+	"\vutilization\x18\x04 \x01(\x01R\vutilization\x12(\n" +
+	"\x10peak_queue_depth\x18\x05 \x01(\x03R\x0epeakQueueDepth\x12(\n" +
+	"\x10bytes_per_second\x18\x06 \x01(\x01R\x0ebytesPerSecond\x12\x1a\n" +
+	"\bexceeded\x18\a \x03(\tR\bexceeded\"E\n" +
+	"\x0fQueueDepthPoint\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x03R\x05depth\"\xd7\x03\n" +
+	"\x14PlanCapacityResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\x12+\n" +
+	"\x06agents\x18\x04 \x03(\v2\x13.dbos.AgentCapacityR\x06agents\x12+\n" +
+	"\x06groups\x18\x05 \x03(\v2\x13.dbos.GroupCapacityR\x06groups\x126\n" +
+	"\vqueue_depth\x18\x06 \x03(\v2\x15.dbos.QueueDepthPointR\n" +
+	"queueDepth\x12$\n" +
+	"\x0etasks_per_hour\x18\a \x01(\x01R\ftasksPerHour\x12(\n" +
+	"\x10bytes_per_second\x18\b \x01(\x01R\x0ebytesPerSecond\x12,\n" +
+	"\x12agents_over_budget\x18\t \x01(\x05R\x10agentsOverBudget\x12,\n" +
+	"\x12groups_over_budget\x18\n" +
+	" \x01(\x05R\x10groupsOverBudget\x122\n" +
+	"\x15unavailable_agent_ids\x18\v \x03(\tR\x13unavailableAgentIds\"V\n" +
+	"\x18GetExecutionStatsRequest\x12\x1f\n" +
+	"\vmodule_name\x18\x01 \x01(\tR\n" +
+	"moduleName\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\"L\n" +
+	"\x0eDurationBucket\x12$\n" +
+	"\x0eupper_bound_ms\x18\x01 \x01(\x01R\fupperBoundMs\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xe1\x02\n" +
+	"\x16ExecutionDurationStats\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x18\n" +
+	"\asamples\x18\x02 \x01(\x03R\asamples\x12(\n" +
+	"\x10mean_duration_ms\x18\x03 \x01(\x01R\x0emeanDurationMs\x12&\n" +
+	"\x0fp50_duration_ms\x18\x04 \x01(\x01R\rp50DurationMs\x12&\n" +
+	"\x0fp95_duration_ms\x18\x05 \x01(\x01R\rp95DurationMs\x12&\n" +
+	"\x0fp99_duration_ms\x18\x06 \x01(\x01R\rp99DurationMs\x122\n" +
+	"\thistogram\x18\a \x03(\v2\x14.dbos.DurationBucketR\thistogram\x12\x14\n" +
+	"\x05count\x18\b \x01(\x03R\x05count\x12&\n" +
+	"\x0fsum_duration_ms\x18\t \x01(\x01R\rsumDurationMs\"\x82\x02\n" +
+	"\x19GetExecutionStatsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\x124\n" +
+	"\x06module\x18\x04 \x01(\v2\x1c.dbos.ExecutionDurationStatsR\x06module\x124\n" +
+	"\x06agents\x18\x05 \x03(\v2\x1c.dbos.ExecutionDurationStatsR\x06agents\x12*\n" +
+	"\x11hung_threshold_ms\x18\x06 \x01(\x01R\x0fhungThresholdMs\"\xdd\x02\n" +
+	"\x0fGetUsageRequest\x12D\n" +
+	"\x0eprincipal_kind\x18\x01 \x01(\tB\x1d\xb2\xb5\x18\x05agent\xb2\xb5\x18\x03key\xb2\xb5\x18\tanonymousR\rprincipalKind\x12#\n" +
+	"\tprincipal\x18\x02 \x01(\tB\x05\x98\xb5\x18\x80\x02R\tprincipal\x12=\n" +
+	"\x0ewindow_seconds\x18\x03 \x01(\x03B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00\xc1\xb5\x18\x00\x00\x00\x00\x00\x18\xf5@R\rwindowSeconds\x12\x1b\n" +
+	"\tby_method\x18\x04 \x01(\bR\bbyMethod\x12U\n" +
+	"\border_by\x18\x05 \x01(\tB:\xb2\xb5\x18\x05calls\xb2\xb5\x18\x06errors\xb2\xb5\x18\n" +
+	"error_rate\xb2\xb5\x18\bbytes_in\xb2\xb5\x18\tbytes_outR\aorderBy\x12,\n" +
+	"\x05limit\x18\x06 \x01(\x05B\x16\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00\xc1\xb5\x18\x00\x00\x00\x00\x00@\x8f@R\x05limit\"\xee\x01\n" +
+	"\n" +
+	"UsageEntry\x12%\n" +
+	"\x0eprincipal_kind\x18\x01 \x01(\tR\rprincipalKind\x12\x1c\n" +
+	"\tprincipal\x18\x02 \x01(\tR\tprincipal\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x14\n" +
+	"\x05calls\x18\x04 \x01(\x03R\x05calls\x12\x16\n" +
+	"\x06errors\x18\x05 \x01(\x03R\x06errors\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\x06 \x01(\x01R\terrorRate\x12\x19\n" +
+	"\bbytes_in\x18\a \x01(\x03R\abytesIn\x12\x1b\n" +
+	"\tbytes_out\x18\b \x01(\x03R\bbytesOut\"\x9a\x01\n" +
+	"\x10GetUsageResponse\x12*\n" +
+	"\aentries\x18\x01 \x03(\v2\x10.dbos.UsageEntryR\aentries\x12%\n" +
+	"\x0ewindow_seconds\x18\x02 \x01(\x03R\rwindowSeconds\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"\x8d\x02\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12\x19\n" +
+	"\bagent_id\x18\x05 \x01(\tR\aagentId\x12\x1a\n" +
+	"\bseverity\x18\x06 \x01(\tR\bseverity\x12%\n" +
+	"\x0ecorrelation_id\x18\a \x01(\tR\rcorrelationId\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x03R\ttimestamp\x12\x18\n" +
+	"\apayload\x18\t \x01(\fR\apayload\x12\x1a\n" +
+	"\bsequence\x18\n" +
+	" \x01(\tR\bsequence\"\x85\x01\n" +
+	"\x10GetEventsRequest\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05until\x18\x04 \x01(\x03R\x05until\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"m\n" +
+	"\x11GetEventsResponse\x12#\n" +
+	"\x06events\x18\x01 \x03(\v2\v.dbos.EventR\x06events\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\"m\n" +
+	"\x13StreamEventsRequest\x12%\n" +
+	"\x0eafter_sequence\x18\x01 \x01(\tR\rafterSequence\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\"\xef\x01\n" +
+	"\n" +
+	"StateEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1f\n" +
+	"\ventity_type\x18\x03 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x04 \x01(\tR\bentityId\x12!\n" +
+	"\x05agent\x18\x05 \x01(\v2\v.dbos.AgentR\x05agent\x12\x1e\n" +
+	"\x04task\x18\x06 \x01(\v2\n" +
+	".dbos.TaskR\x04task\x12\x1e\n" +
+	"\vnext_run_at\x18\a \x01(\x03R\tnextRunAt\x12\x1c\n" +
+	"\ttimestamp\x18\b \x01(\x03R\ttimestamp\"\x84\x01\n" +
+	"\x16ListStateEventsRequest\x12\x1f\n" +
+	"\ventity_type\x18\x01 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\tR\bentityId\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x99\x01\n" +
+	"\x17ListStateEventsResponse\x12(\n" +
+	"\x06events\x18\x01 \x03(\v2\x10.dbos.StateEventR\x06events\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\".\n" +
+	"\x13RebuildStateRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"\xb4\x02\n" +
+	"\x14RebuildStateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12'\n" +
+	"\x0fevents_replayed\x18\x02 \x01(\x03R\x0eeventsReplayed\x12%\n" +
+	"\x0eagents_rebuilt\x18\x03 \x01(\x05R\ragentsRebuilt\x12#\n" +
+	"\rtasks_rebuilt\x18\x04 \x01(\x05R\ftasksRebuilt\x12,\n" +
+	"\x12diverged_agent_ids\x18\x05 \x03(\tR\x10divergedAgentIds\x12*\n" +
+	"\x11diverged_task_ids\x18\x06 \x03(\tR\x0fdivergedTaskIds\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\b \x01(\tR\terrorCode\"]\n" +
+	"\x15ExportSnapshotRequest\x12#\n" +
+	"\rresults_since\x18\x01 \x01(\x03R\fresultsSince\x12\x1f\n" +
+	"\vmodule_name\x18\x02 \x01(\tR\n" +
+	"moduleName\"\xeb\x01\n" +
+	"\x0eSnapshotMarker\x12\x19\n" +
+	"\btaken_at\x18\x01 \x01(\x03R\atakenAt\x12$\n" +
+	"\x0estate_event_id\x18\x02 \x01(\tR\fstateEventId\x12T\n" +
+	"\x10result_sequences\x18\x03 \x03(\v2).dbos.SnapshotMarker.ResultSequencesEntryR\x0fresultSequences\x1aB\n" +
+	"\x14ResultSequencesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xb2\x01\n" +
+	"\x0eSnapshotRecord\x12,\n" +
+	"\x06marker\x18\x01 \x01(\v2\x14.dbos.SnapshotMarkerR\x06marker\x12!\n" +
+	"\x05agent\x18\x02 \x01(\v2\v.dbos.AgentR\x05agent\x12\x1e\n" +
+	"\x04task\x18\x03 \x01(\v2\n" +
+	".dbos.TaskR\x04task\x12/\n" +
+	"\x06result\x18\x04 \x01(\v2\x17.dbos.MeasurementResultR\x06result2\xc6=\n" +
 	"\x04DBOS\x12H\n" +
-	"\rRegisterAgent\x12\x1a.dbos.RegisterAgentRequest\x1a\x1b.dbos.RegisterAgentResponse\x129\n" +
+	"\rRegisterAgent\x12\x1a.dbos.RegisterAgentRequest\x1a\x1b.dbos.RegisterAgentResponse\x12<\n" +
+	"\tHeartbeat\x12\x16.dbos.HeartbeatRequest\x1a\x17.dbos.HeartbeatResponse\x129\n" +
 	"\bGetAgent\x12\x15.dbos.GetAgentRequest\x1a\x16.dbos.GetAgentResponse\x12?\n" +
 	"\n" +
-	"ListAgents\x12\x17.dbos.ListAgentsRequest\x1a\x18.dbos.ListAgentsResponse\x12K\n" +
+	"ListAgents\x12\x17.dbos.ListAgentsRequest\x1a\x18.dbos.ListAgentsResponse\x12B\n" +
+	"\vDeleteAgent\x12\x18.dbos.DeleteAgentRequest\x1a\x19.dbos.DeleteAgentResponse\x12;\n" +
+	"\vWatchAgents\x12\x18.dbos.WatchAgentsRequest\x1a\x10.dbos.AgentDelta0\x01\x12N\n" +
+	"\x0fGetAgentGeoJSON\x12\x1c.dbos.GetAgentGeoJSONRequest\x1a\x1d.dbos.GetAgentGeoJSONResponse\x12]\n" +
+	"\x14CreateBootstrapToken\x12!.dbos.CreateBootstrapTokenRequest\x1a\".dbos.CreateBootstrapTokenResponse\x12B\n" +
+	"\vEnrollAgent\x12\x18.dbos.EnrollAgentRequest\x1a\x19.dbos.EnrollAgentResponse\x12Q\n" +
+	"\x10CreateAgentToken\x12\x1d.dbos.CreateAgentTokenRequest\x1a\x1e.dbos.CreateAgentTokenResponse\x12K\n" +
+	"\x0eCreateAPIToken\x12\x1b.dbos.CreateAPITokenRequest\x1a\x1c.dbos.CreateAPITokenResponse\x12N\n" +
+	"\x0fPublishArtifact\x12\x1c.dbos.PublishArtifactRequest\x1a\x1d.dbos.PublishArtifactResponse\x12T\n" +
+	"\x11UnpublishArtifact\x12\x1e.dbos.UnpublishArtifactRequest\x1a\x1f.dbos.UnpublishArtifactResponse\x12B\n" +
+	"\vGetManifest\x12\x18.dbos.GetManifestRequest\x1a\x19.dbos.GetManifestResponse\x12W\n" +
+	"\x12StartConfigRollout\x12\x1f.dbos.StartConfigRolloutRequest\x1a .dbos.StartConfigRolloutResponse\x12Q\n" +
+	"\x10GetConfigRollout\x12\x1d.dbos.GetConfigRolloutRequest\x1a\x1e.dbos.GetConfigRolloutResponse\x12`\n" +
+	"\x15RollbackConfigRollout\x12\".dbos.RollbackConfigRolloutRequest\x1a#.dbos.RollbackConfigRolloutResponse\x12K\n" +
+	"\x0eGetAgentConfig\x12\x1b.dbos.GetAgentConfigRequest\x1a\x1c.dbos.GetAgentConfigResponse\x12N\n" +
+	"\x0fSetConfigSchema\x12\x1c.dbos.SetConfigSchemaRequest\x1a\x1d.dbos.SetConfigSchemaResponse\x12T\n" +
+	"\x11ListConfigSchemas\x12\x1e.dbos.ListConfigSchemasRequest\x1a\x1f.dbos.ListConfigSchemasResponse\x12W\n" +
+	"\x12DeleteConfigSchema\x12\x1f.dbos.DeleteConfigSchemaRequest\x1a .dbos.DeleteConfigSchemaResponse\x12K\n" +
+	"\x0eValidateConfig\x12\x1b.dbos.ValidateConfigRequest\x1a\x1c.dbos.ValidateConfigResponse\x12K\n" +
 	"\x0eSetModuleState\x12\x1b.dbos.SetModuleStateRequest\x1a\x1c.dbos.SetModuleStateResponse\x12K\n" +
 	"\x0eGetModuleState\x12\x1b.dbos.GetModuleStateRequest\x1a\x1c.dbos.GetModuleStateResponse\x12Q\n" +
-	"\x10ListModuleStates\x12\x1d.dbos.ListModuleStatesRequest\x1a\x1e.dbos.ListModuleStatesResponse\x12B\n" +
-	"\vStoreResult\x12\x18.dbos.StoreResultRequest\x1a\x19.dbos.StoreResultResponse\x12<\n" +
+	"\x10ListModuleStates\x12\x1d.dbos.ListModuleStatesRequest\x1a\x1e.dbos.ListModuleStatesResponse\x12H\n" +
+	"\x11WatchModuleStates\x12\x1e.dbos.WatchModuleStatesRequest\x1a\x11.dbos.ModuleState0\x01\x12B\n" +
+	"\vStoreResult\x12\x18.dbos.StoreResultRequest\x1a\x19.dbos.StoreResultResponse\x12E\n" +
+	"\fStoreResults\x12\x19.dbos.StoreResultsRequest\x1a\x1a.dbos.StoreResultsResponse\x12H\n" +
+	"\rStreamResults\x12\x18.dbos.StoreResultRequest\x1a\x1b.dbos.StreamResultsResponse(\x01\x12<\n" +
 	"\tGetResult\x12\x16.dbos.GetResultRequest\x1a\x17.dbos.GetResultResponse\x12B\n" +
-	"\vListResults\x12\x18.dbos.ListResultsRequest\x1a\x19.dbos.ListResultsResponse\x12E\n" +
+	"\vListResults\x12\x18.dbos.ListResultsRequest\x1a\x19.dbos.ListResultsResponse\x12]\n" +
+	"\x14GetCorrelatedResults\x12!.dbos.GetCorrelatedResultsRequest\x1a\".dbos.GetCorrelatedResultsResponse\x12E\n" +
+	"\fCountResults\x12\x19.dbos.CountResultsRequest\x1a\x1a.dbos.CountResultsResponse\x12<\n" +
+	"\tHasResult\x12\x16.dbos.HasResultRequest\x1a\x17.dbos.HasResultResponse\x12G\n" +
+	"\rExportResults\x12\x1a.dbos.ExportResultsRequest\x1a\x18.dbos.ExportResultsChunk0\x01\x12K\n" +
+	"\x0eProfileResults\x12\x1b.dbos.ProfileResultsRequest\x1a\x1c.dbos.ProfileResultsResponse\x12H\n" +
+	"\rGetIngestGaps\x12\x1a.dbos.GetIngestGapsRequest\x1a\x1b.dbos.GetIngestGapsResponse\x12E\n" +
+	"\fGetClockSkew\x12\x19.dbos.GetClockSkewRequest\x1a\x1a.dbos.GetClockSkewResponse\x12?\n" +
+	"\n" +
+	"ListAlerts\x12\x17.dbos.ListAlertsRequest\x1a\x18.dbos.ListAlertsResponse\x12B\n" +
+	"\vGetIncident\x12\x18.dbos.GetIncidentRequest\x1a\x19.dbos.GetIncidentResponse\x12H\n" +
+	"\rListIncidents\x12\x1a.dbos.ListIncidentsRequest\x1a\x1b.dbos.ListIncidentsResponse\x12K\n" +
+	"\x0eCreateIncident\x12\x1b.dbos.CreateIncidentRequest\x1a\x1c.dbos.CreateIncidentResponse\x12K\n" +
+	"\x0eUpdateIncident\x12\x1b.dbos.UpdateIncidentRequest\x1a\x1c.dbos.UpdateIncidentResponse\x12Z\n" +
+	"\x13AcknowledgeIncident\x12 .dbos.AcknowledgeIncidentRequest\x1a!.dbos.AcknowledgeIncidentResponse\x12N\n" +
+	"\x0fResolveIncident\x12\x1c.dbos.ResolveIncidentRequest\x1a\x1d.dbos.ResolveIncidentResponse\x12W\n" +
+	"\x12AddIncidentComment\x12\x1f.dbos.AddIncidentCommentRequest\x1a .dbos.AddIncidentCommentResponse\x12K\n" +
+	"\x0eDeleteIncident\x12\x1b.dbos.DeleteIncidentRequest\x1a\x1c.dbos.DeleteIncidentResponse\x12D\n" +
+	"\x0eWatchIncidents\x12\x1b.dbos.WatchIncidentsRequest\x1a\x13.dbos.IncidentEvent0\x01\x12T\n" +
+	"\x11ListRoutingEvents\x12\x1e.dbos.ListRoutingEventsRequest\x1a\x1f.dbos.ListRoutingEventsResponse\x12E\n" +
 	"\fScheduleTask\x12\x19.dbos.ScheduleTaskRequest\x1a\x1a.dbos.ScheduleTaskResponse\x126\n" +
-	"\aGetTask\x12\x14.dbos.GetTaskRequest\x1a\x15.dbos.GetTaskResponse\x12E\n" +
-	"\fListDueTasks\x12\x19.dbos.ListDueTasksRequest\x1a\x1a.dbos.ListDueTasksResponseB\aZ\x05./apib\x06proto3"
+	"\aGetTask\x12\x14.dbos.GetTaskRequest\x1a\x15.dbos.GetTaskResponse\x12<\n" +
+	"\tListTasks\x12\x16.dbos.ListTasksRequest\x1a\x17.dbos.ListTasksResponse\x12E\n" +
+	"\fListDueTasks\x12\x19.dbos.ListDueTasksRequest\x1a\x1a.dbos.ListDueTasksResponse\x12Q\n" +
+	"\x10ListPendingTasks\x12\x1d.dbos.ListPendingTasksRequest\x1a\x1e.dbos.ListPendingTasksResponse\x12?\n" +
+	"\n" +
+	"CancelTask\x12\x17.dbos.CancelTaskRequest\x1a\x18.dbos.CancelTaskResponse\x12<\n" +
+	"\tLeaseTask\x12\x16.dbos.LeaseTaskRequest\x1a\x17.dbos.LeaseTaskResponse\x129\n" +
+	"\bAckTasks\x12\x15.dbos.AckTasksRequest\x1a\x16.dbos.AckTasksResponse\x12<\n" +
+	"\tNackTasks\x12\x16.dbos.NackTasksRequest\x1a\x17.dbos.NackTasksResponse\x12]\n" +
+	"\x14ExtendTaskVisibility\x12!.dbos.ExtendTaskVisibilityRequest\x1a\".dbos.ExtendTaskVisibilityResponse\x12W\n" +
+	"\x12ReportTaskProgress\x12\x1f.dbos.ReportTaskProgressRequest\x1a .dbos.ReportTaskProgressResponse\x125\n" +
+	"\vStreamTasks\x12\x18.dbos.StreamTasksRequest\x1a\n" +
+	".dbos.Task0\x01\x12]\n" +
+	"\x14ScheduleVerifiedTask\x12!.dbos.ScheduleVerifiedTaskRequest\x1a\".dbos.ScheduleVerifiedTaskResponse\x12N\n" +
+	"\x0fGetVerification\x12\x1c.dbos.GetVerificationRequest\x1a\x1d.dbos.GetVerificationResponse\x12H\n" +
+	"\rBroadcastTask\x12\x1a.dbos.BroadcastTaskRequest\x1a\x1b.dbos.BroadcastTaskResponse\x12W\n" +
+	"\x12GetBroadcastStatus\x12\x1f.dbos.GetBroadcastStatusRequest\x1a .dbos.GetBroadcastStatusResponse\x12?\n" +
+	"\n" +
+	"CreateView\x12\x17.dbos.CreateViewRequest\x1a\x18.dbos.CreateViewResponse\x12<\n" +
+	"\tListViews\x12\x16.dbos.ListViewsRequest\x1a\x17.dbos.ListViewsResponse\x12?\n" +
+	"\n" +
+	"DeleteView\x12\x17.dbos.DeleteViewRequest\x1a\x18.dbos.DeleteViewResponse\x12<\n" +
+	"\tQueryView\x12\x16.dbos.QueryViewRequest\x1a\x17.dbos.QueryViewResponse\x12]\n" +
+	"\x14CreateExtractionRule\x12!.dbos.CreateExtractionRuleRequest\x1a\".dbos.CreateExtractionRuleResponse\x12Z\n" +
+	"\x13ListExtractionRules\x12 .dbos.ListExtractionRulesRequest\x1a!.dbos.ListExtractionRulesResponse\x12]\n" +
+	"\x14DeleteExtractionRule\x12!.dbos.DeleteExtractionRuleRequest\x1a\".dbos.DeleteExtractionRuleResponse\x12E\n" +
+	"\fQueryResults\x12\x19.dbos.QueryResultsRequest\x1a\x1a.dbos.QueryResultsResponse\x12Q\n" +
+	"\x10CreateSavedQuery\x12\x1d.dbos.CreateSavedQueryRequest\x1a\x1e.dbos.CreateSavedQueryResponse\x12H\n" +
+	"\rGetSavedQuery\x12\x1a.dbos.GetSavedQueryRequest\x1a\x1b.dbos.GetSavedQueryResponse\x12Q\n" +
+	"\x10ListSavedQueries\x12\x1d.dbos.ListSavedQueriesRequest\x1a\x1e.dbos.ListSavedQueriesResponse\x12Q\n" +
+	"\x10UpdateSavedQuery\x12\x1d.dbos.UpdateSavedQueryRequest\x1a\x1e.dbos.UpdateSavedQueryResponse\x12Q\n" +
+	"\x10DeleteSavedQuery\x12\x1d.dbos.DeleteSavedQueryRequest\x1a\x1e.dbos.DeleteSavedQueryResponse\x12T\n" +
+	"\x11ExecuteSavedQuery\x12\x1e.dbos.ExecuteSavedQueryRequest\x1a\x1f.dbos.ExecuteSavedQueryResponse\x12N\n" +
+	"\x0fCreateAlertRule\x12\x1c.dbos.CreateAlertRuleRequest\x1a\x1d.dbos.CreateAlertRuleResponse\x12K\n" +
+	"\x0eListAlertRules\x12\x1b.dbos.ListAlertRulesRequest\x1a\x1c.dbos.ListAlertRulesResponse\x12N\n" +
+	"\x0fDeleteAlertRule\x12\x1c.dbos.DeleteAlertRuleRequest\x1a\x1d.dbos.DeleteAlertRuleResponse\x12f\n" +
+	"\x17CreateMaintenanceWindow\x12$.dbos.CreateMaintenanceWindowRequest\x1a%.dbos.CreateMaintenanceWindowResponse\x12]\n" +
+	"\x14GetMaintenanceWindow\x12!.dbos.GetMaintenanceWindowRequest\x1a\".dbos.GetMaintenanceWindowResponse\x12c\n" +
+	"\x16ListMaintenanceWindows\x12#.dbos.ListMaintenanceWindowsRequest\x1a$.dbos.ListMaintenanceWindowsResponse\x12f\n" +
+	"\x17UpdateMaintenanceWindow\x12$.dbos.UpdateMaintenanceWindowRequest\x1a%.dbos.UpdateMaintenanceWindowResponse\x12f\n" +
+	"\x17DeleteMaintenanceWindow\x12$.dbos.DeleteMaintenanceWindowRequest\x1a%.dbos.DeleteMaintenanceWindowResponse\x12<\n" +
+	"\tGetTrends\x12\x16.dbos.GetTrendsRequest\x1a\x17.dbos.GetTrendsResponse\x12K\n" +
+	"\x0eCreateCampaign\x12\x1b.dbos.CreateCampaignRequest\x1a\x1c.dbos.CreateCampaignResponse\x12B\n" +
+	"\vGetCampaign\x12\x18.dbos.GetCampaignRequest\x1a\x19.dbos.GetCampaignResponse\x12H\n" +
+	"\rListCampaigns\x12\x1a.dbos.ListCampaignsRequest\x1a\x1b.dbos.ListCampaignsResponse\x12E\n" +
+	"\fStopCampaign\x12\x19.dbos.StopCampaignRequest\x1a\x1a.dbos.StopCampaignResponse\x12Z\n" +
+	"\x13ListCampaignResults\x12 .dbos.ListCampaignResultsRequest\x1a!.dbos.ListCampaignResultsResponse\x12<\n" +
+	"\tApplySpec\x12\x16.dbos.ApplySpecRequest\x1a\x17.dbos.ApplySpecResponse\x129\n" +
+	"\bGetDrift\x12\x15.dbos.GetDriftRequest\x1a\x16.dbos.GetDriftResponse\x12E\n" +
+	"\fPlanCapacity\x12\x19.dbos.PlanCapacityRequest\x1a\x1a.dbos.PlanCapacityResponse\x12T\n" +
+	"\x11GetExecutionStats\x12\x1e.dbos.GetExecutionStatsRequest\x1a\x1f.dbos.GetExecutionStatsResponse\x129\n" +
+	"\bGetUsage\x12\x15.dbos.GetUsageRequest\x1a\x16.dbos.GetUsageResponse\x12<\n" +
+	"\tGetEvents\x12\x16.dbos.GetEventsRequest\x1a\x17.dbos.GetEventsResponse\x128\n" +
+	"\fStreamEvents\x12\x19.dbos.StreamEventsRequest\x1a\v.dbos.Event0\x01\x12N\n" +
+	"\x0fListStateEvents\x12\x1c.dbos.ListStateEventsRequest\x1a\x1d.dbos.ListStateEventsResponse\x12E\n" +
+	"\fRebuildState\x12\x19.dbos.RebuildStateRequest\x1a\x1a.dbos.RebuildStateResponse\x12E\n" +
+	"\x0eExportSnapshot\x12\x1b.dbos.ExportSnapshotRequest\x1a\x14.dbos.SnapshotRecord0\x01B\aZ\x05./apib\x06proto3"
 
 var (
 	file_api_dbos_proto_rawDescOnce sync.Once
@@ -1718,83 +19047,648 @@ func file_api_dbos_proto_rawDescGZIP() []byte {
 	return file_api_dbos_proto_rawDescData
 }
 
-var file_api_dbos_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_api_dbos_proto_msgTypes = make([]protoimpl.MessageInfo, 278)
 var file_api_dbos_proto_goTypes = []any{
-	(*Agent)(nil),                    // 0: dbos.Agent
-	(*ModuleState)(nil),              // 1: dbos.ModuleState
-	(*MeasurementResult)(nil),        // 2: dbos.MeasurementResult
-	(*Task)(nil),                     // 3: dbos.Task
-	(*RegisterAgentRequest)(nil),     // 4: dbos.RegisterAgentRequest
-	(*RegisterAgentResponse)(nil),    // 5: dbos.RegisterAgentResponse
-	(*GetAgentRequest)(nil),          // 6: dbos.GetAgentRequest
-	(*GetAgentResponse)(nil),         // 7: dbos.GetAgentResponse
-	(*ListAgentsRequest)(nil),        // 8: dbos.ListAgentsRequest
-	(*ListAgentsResponse)(nil),       // 9: dbos.ListAgentsResponse
-	(*SetModuleStateRequest)(nil),    // 10: dbos.SetModuleStateRequest
-	(*SetModuleStateResponse)(nil),   // 11: dbos.SetModuleStateResponse
-	(*GetModuleStateRequest)(nil),    // 12: dbos.GetModuleStateRequest
-	(*GetModuleStateResponse)(nil),   // 13: dbos.GetModuleStateResponse
-	(*ListModuleStatesRequest)(nil),  // 14: dbos.ListModuleStatesRequest
-	(*ListModuleStatesResponse)(nil), // 15: dbos.ListModuleStatesResponse
-	(*StoreResultRequest)(nil),       // 16: dbos.StoreResultRequest
-	(*StoreResultResponse)(nil),      // 17: dbos.StoreResultResponse
-	(*GetResultRequest)(nil),         // 18: dbos.GetResultRequest
-	(*GetResultResponse)(nil),        // 19: dbos.GetResultResponse
-	(*ListResultsRequest)(nil),       // 20: dbos.ListResultsRequest
-	(*ListResultsResponse)(nil),      // 21: dbos.ListResultsResponse
-	(*ScheduleTaskRequest)(nil),      // 22: dbos.ScheduleTaskRequest
-	(*ScheduleTaskResponse)(nil),     // 23: dbos.ScheduleTaskResponse
-	(*GetTaskRequest)(nil),           // 24: dbos.GetTaskRequest
-	(*GetTaskResponse)(nil),          // 25: dbos.GetTaskResponse
-	(*ListDueTasksRequest)(nil),      // 26: dbos.ListDueTasksRequest
-	(*ListDueTasksResponse)(nil),     // 27: dbos.ListDueTasksResponse
-	nil,                              // 28: dbos.Agent.ConfigEntry
-	nil,                              // 29: dbos.ModuleState.DetailsEntry
+	(*Agent)(nil),                           // 0: dbos.Agent
+	(*ModuleState)(nil),                     // 1: dbos.ModuleState
+	(*MeasurementResult)(nil),               // 2: dbos.MeasurementResult
+	(*ClockSkew)(nil),                       // 3: dbos.ClockSkew
+	(*Task)(nil),                            // 4: dbos.Task
+	(*RetryPolicy)(nil),                     // 5: dbos.RetryPolicy
+	(*Placement)(nil),                       // 6: dbos.Placement
+	(*RegisterAgentRequest)(nil),            // 7: dbos.RegisterAgentRequest
+	(*RegisterAgentResponse)(nil),           // 8: dbos.RegisterAgentResponse
+	(*HeartbeatRequest)(nil),                // 9: dbos.HeartbeatRequest
+	(*HeartbeatResponse)(nil),               // 10: dbos.HeartbeatResponse
+	(*GetAgentRequest)(nil),                 // 11: dbos.GetAgentRequest
+	(*GetAgentResponse)(nil),                // 12: dbos.GetAgentResponse
+	(*ListAgentsRequest)(nil),               // 13: dbos.ListAgentsRequest
+	(*ListAgentsResponse)(nil),              // 14: dbos.ListAgentsResponse
+	(*DeleteAgentRequest)(nil),              // 15: dbos.DeleteAgentRequest
+	(*DeleteAgentResponse)(nil),             // 16: dbos.DeleteAgentResponse
+	(*WatchAgentsRequest)(nil),              // 17: dbos.WatchAgentsRequest
+	(*AgentDelta)(nil),                      // 18: dbos.AgentDelta
+	(*GetAgentGeoJSONRequest)(nil),          // 19: dbos.GetAgentGeoJSONRequest
+	(*GetAgentGeoJSONResponse)(nil),         // 20: dbos.GetAgentGeoJSONResponse
+	(*CreateBootstrapTokenRequest)(nil),     // 21: dbos.CreateBootstrapTokenRequest
+	(*CreateBootstrapTokenResponse)(nil),    // 22: dbos.CreateBootstrapTokenResponse
+	(*CreateAgentTokenRequest)(nil),         // 23: dbos.CreateAgentTokenRequest
+	(*CreateAgentTokenResponse)(nil),        // 24: dbos.CreateAgentTokenResponse
+	(*CreateAPITokenRequest)(nil),           // 25: dbos.CreateAPITokenRequest
+	(*CreateAPITokenResponse)(nil),          // 26: dbos.CreateAPITokenResponse
+	(*EnrollAgentRequest)(nil),              // 27: dbos.EnrollAgentRequest
+	(*EnrollAgentResponse)(nil),             // 28: dbos.EnrollAgentResponse
+	(*Artifact)(nil),                        // 29: dbos.Artifact
+	(*PublishArtifactRequest)(nil),          // 30: dbos.PublishArtifactRequest
+	(*PublishArtifactResponse)(nil),         // 31: dbos.PublishArtifactResponse
+	(*UnpublishArtifactRequest)(nil),        // 32: dbos.UnpublishArtifactRequest
+	(*UnpublishArtifactResponse)(nil),       // 33: dbos.UnpublishArtifactResponse
+	(*GetManifestRequest)(nil),              // 34: dbos.GetManifestRequest
+	(*GetManifestResponse)(nil),             // 35: dbos.GetManifestResponse
+	(*AgentConfigVersion)(nil),              // 36: dbos.AgentConfigVersion
+	(*ConfigRollout)(nil),                   // 37: dbos.ConfigRollout
+	(*StartConfigRolloutRequest)(nil),       // 38: dbos.StartConfigRolloutRequest
+	(*StartConfigRolloutResponse)(nil),      // 39: dbos.StartConfigRolloutResponse
+	(*GetConfigRolloutRequest)(nil),         // 40: dbos.GetConfigRolloutRequest
+	(*GetConfigRolloutResponse)(nil),        // 41: dbos.GetConfigRolloutResponse
+	(*RollbackConfigRolloutRequest)(nil),    // 42: dbos.RollbackConfigRolloutRequest
+	(*RollbackConfigRolloutResponse)(nil),   // 43: dbos.RollbackConfigRolloutResponse
+	(*GetAgentConfigRequest)(nil),           // 44: dbos.GetAgentConfigRequest
+	(*GetAgentConfigResponse)(nil),          // 45: dbos.GetAgentConfigResponse
+	(*ConfigKeySchema)(nil),                 // 46: dbos.ConfigKeySchema
+	(*ConfigSchema)(nil),                    // 47: dbos.ConfigSchema
+	(*ConfigViolation)(nil),                 // 48: dbos.ConfigViolation
+	(*SetConfigSchemaRequest)(nil),          // 49: dbos.SetConfigSchemaRequest
+	(*SetConfigSchemaResponse)(nil),         // 50: dbos.SetConfigSchemaResponse
+	(*ListConfigSchemasRequest)(nil),        // 51: dbos.ListConfigSchemasRequest
+	(*ListConfigSchemasResponse)(nil),       // 52: dbos.ListConfigSchemasResponse
+	(*DeleteConfigSchemaRequest)(nil),       // 53: dbos.DeleteConfigSchemaRequest
+	(*DeleteConfigSchemaResponse)(nil),      // 54: dbos.DeleteConfigSchemaResponse
+	(*ValidateConfigRequest)(nil),           // 55: dbos.ValidateConfigRequest
+	(*ValidateConfigResponse)(nil),          // 56: dbos.ValidateConfigResponse
+	(*SetModuleStateRequest)(nil),           // 57: dbos.SetModuleStateRequest
+	(*SetModuleStateResponse)(nil),          // 58: dbos.SetModuleStateResponse
+	(*GetModuleStateRequest)(nil),           // 59: dbos.GetModuleStateRequest
+	(*GetModuleStateResponse)(nil),          // 60: dbos.GetModuleStateResponse
+	(*ListModuleStatesRequest)(nil),         // 61: dbos.ListModuleStatesRequest
+	(*WatchModuleStatesRequest)(nil),        // 62: dbos.WatchModuleStatesRequest
+	(*ListModuleStatesResponse)(nil),        // 63: dbos.ListModuleStatesResponse
+	(*StoreResultRequest)(nil),              // 64: dbos.StoreResultRequest
+	(*StoreResultResponse)(nil),             // 65: dbos.StoreResultResponse
+	(*Backoff)(nil),                         // 66: dbos.Backoff
+	(*StoreResultsRequest)(nil),             // 67: dbos.StoreResultsRequest
+	(*ResultStoreStatus)(nil),               // 68: dbos.ResultStoreStatus
+	(*StoreResultsResponse)(nil),            // 69: dbos.StoreResultsResponse
+	(*StreamResultsResponse)(nil),           // 70: dbos.StreamResultsResponse
+	(*RejectedResult)(nil),                  // 71: dbos.RejectedResult
+	(*GetResultRequest)(nil),                // 72: dbos.GetResultRequest
+	(*GetResultResponse)(nil),               // 73: dbos.GetResultResponse
+	(*ListResultsRequest)(nil),              // 74: dbos.ListResultsRequest
+	(*ListResultsResponse)(nil),             // 75: dbos.ListResultsResponse
+	(*GetCorrelatedResultsRequest)(nil),     // 76: dbos.GetCorrelatedResultsRequest
+	(*GetCorrelatedResultsResponse)(nil),    // 77: dbos.GetCorrelatedResultsResponse
+	(*CountResultsRequest)(nil),             // 78: dbos.CountResultsRequest
+	(*CountResultsResponse)(nil),            // 79: dbos.CountResultsResponse
+	(*HasResultRequest)(nil),                // 80: dbos.HasResultRequest
+	(*HasResultResponse)(nil),               // 81: dbos.HasResultResponse
+	(*ExportResultsRequest)(nil),            // 82: dbos.ExportResultsRequest
+	(*ExportResultsChunk)(nil),              // 83: dbos.ExportResultsChunk
+	(*ProfileResultsRequest)(nil),           // 84: dbos.ProfileResultsRequest
+	(*FieldProfile)(nil),                    // 85: dbos.FieldProfile
+	(*ProfileResultsResponse)(nil),          // 86: dbos.ProfileResultsResponse
+	(*GetClockSkewRequest)(nil),             // 87: dbos.GetClockSkewRequest
+	(*GetClockSkewResponse)(nil),            // 88: dbos.GetClockSkewResponse
+	(*Alert)(nil),                           // 89: dbos.Alert
+	(*ListAlertsRequest)(nil),               // 90: dbos.ListAlertsRequest
+	(*ListAlertsResponse)(nil),              // 91: dbos.ListAlertsResponse
+	(*Incident)(nil),                        // 92: dbos.Incident
+	(*IncidentComment)(nil),                 // 93: dbos.IncidentComment
+	(*RoutingEvent)(nil),                    // 94: dbos.RoutingEvent
+	(*ListRoutingEventsRequest)(nil),        // 95: dbos.ListRoutingEventsRequest
+	(*ListRoutingEventsResponse)(nil),       // 96: dbos.ListRoutingEventsResponse
+	(*GetIncidentRequest)(nil),              // 97: dbos.GetIncidentRequest
+	(*GetIncidentResponse)(nil),             // 98: dbos.GetIncidentResponse
+	(*ListIncidentsRequest)(nil),            // 99: dbos.ListIncidentsRequest
+	(*ListIncidentsResponse)(nil),           // 100: dbos.ListIncidentsResponse
+	(*CreateIncidentRequest)(nil),           // 101: dbos.CreateIncidentRequest
+	(*CreateIncidentResponse)(nil),          // 102: dbos.CreateIncidentResponse
+	(*UpdateIncidentRequest)(nil),           // 103: dbos.UpdateIncidentRequest
+	(*UpdateIncidentResponse)(nil),          // 104: dbos.UpdateIncidentResponse
+	(*AcknowledgeIncidentRequest)(nil),      // 105: dbos.AcknowledgeIncidentRequest
+	(*AcknowledgeIncidentResponse)(nil),     // 106: dbos.AcknowledgeIncidentResponse
+	(*ResolveIncidentRequest)(nil),          // 107: dbos.ResolveIncidentRequest
+	(*ResolveIncidentResponse)(nil),         // 108: dbos.ResolveIncidentResponse
+	(*AddIncidentCommentRequest)(nil),       // 109: dbos.AddIncidentCommentRequest
+	(*AddIncidentCommentResponse)(nil),      // 110: dbos.AddIncidentCommentResponse
+	(*DeleteIncidentRequest)(nil),           // 111: dbos.DeleteIncidentRequest
+	(*DeleteIncidentResponse)(nil),          // 112: dbos.DeleteIncidentResponse
+	(*WatchIncidentsRequest)(nil),           // 113: dbos.WatchIncidentsRequest
+	(*IncidentEvent)(nil),                   // 114: dbos.IncidentEvent
+	(*GetIngestGapsRequest)(nil),            // 115: dbos.GetIngestGapsRequest
+	(*SequenceGap)(nil),                     // 116: dbos.SequenceGap
+	(*GetIngestGapsResponse)(nil),           // 117: dbos.GetIngestGapsResponse
+	(*ScheduleTaskRequest)(nil),             // 118: dbos.ScheduleTaskRequest
+	(*ScheduleTaskResponse)(nil),            // 119: dbos.ScheduleTaskResponse
+	(*GetTaskRequest)(nil),                  // 120: dbos.GetTaskRequest
+	(*GetTaskResponse)(nil),                 // 121: dbos.GetTaskResponse
+	(*CancelTaskRequest)(nil),               // 122: dbos.CancelTaskRequest
+	(*CancelTaskResponse)(nil),              // 123: dbos.CancelTaskResponse
+	(*StreamTasksRequest)(nil),              // 124: dbos.StreamTasksRequest
+	(*LeaseTaskRequest)(nil),                // 125: dbos.LeaseTaskRequest
+	(*LeaseTaskResponse)(nil),               // 126: dbos.LeaseTaskResponse
+	(*AckTasksRequest)(nil),                 // 127: dbos.AckTasksRequest
+	(*TaskAck)(nil),                         // 128: dbos.TaskAck
+	(*AckTasksResponse)(nil),                // 129: dbos.AckTasksResponse
+	(*NackTasksRequest)(nil),                // 130: dbos.NackTasksRequest
+	(*NackTasksResponse)(nil),               // 131: dbos.NackTasksResponse
+	(*ExtendTaskVisibilityRequest)(nil),     // 132: dbos.ExtendTaskVisibilityRequest
+	(*ExtendTaskVisibilityResponse)(nil),    // 133: dbos.ExtendTaskVisibilityResponse
+	(*TaskProgress)(nil),                    // 134: dbos.TaskProgress
+	(*ReportTaskProgressRequest)(nil),       // 135: dbos.ReportTaskProgressRequest
+	(*ReportTaskProgressResponse)(nil),      // 136: dbos.ReportTaskProgressResponse
+	(*Verification)(nil),                    // 137: dbos.Verification
+	(*ScheduleVerifiedTaskRequest)(nil),     // 138: dbos.ScheduleVerifiedTaskRequest
+	(*ScheduleVerifiedTaskResponse)(nil),    // 139: dbos.ScheduleVerifiedTaskResponse
+	(*GetVerificationRequest)(nil),          // 140: dbos.GetVerificationRequest
+	(*GetVerificationResponse)(nil),         // 141: dbos.GetVerificationResponse
+	(*Broadcast)(nil),                       // 142: dbos.Broadcast
+	(*BroadcastTaskRequest)(nil),            // 143: dbos.BroadcastTaskRequest
+	(*BroadcastTaskResponse)(nil),           // 144: dbos.BroadcastTaskResponse
+	(*GetBroadcastStatusRequest)(nil),       // 145: dbos.GetBroadcastStatusRequest
+	(*BroadcastAgentStatus)(nil),            // 146: dbos.BroadcastAgentStatus
+	(*GetBroadcastStatusResponse)(nil),      // 147: dbos.GetBroadcastStatusResponse
+	(*View)(nil),                            // 148: dbos.View
+	(*ViewRow)(nil),                         // 149: dbos.ViewRow
+	(*CreateViewRequest)(nil),               // 150: dbos.CreateViewRequest
+	(*CreateViewResponse)(nil),              // 151: dbos.CreateViewResponse
+	(*ListViewsRequest)(nil),                // 152: dbos.ListViewsRequest
+	(*ListViewsResponse)(nil),               // 153: dbos.ListViewsResponse
+	(*DeleteViewRequest)(nil),               // 154: dbos.DeleteViewRequest
+	(*DeleteViewResponse)(nil),              // 155: dbos.DeleteViewResponse
+	(*QueryViewRequest)(nil),                // 156: dbos.QueryViewRequest
+	(*QueryViewResponse)(nil),               // 157: dbos.QueryViewResponse
+	(*ExtractionRule)(nil),                  // 158: dbos.ExtractionRule
+	(*CreateExtractionRuleRequest)(nil),     // 159: dbos.CreateExtractionRuleRequest
+	(*CreateExtractionRuleResponse)(nil),    // 160: dbos.CreateExtractionRuleResponse
+	(*ListExtractionRulesRequest)(nil),      // 161: dbos.ListExtractionRulesRequest
+	(*ListExtractionRulesResponse)(nil),     // 162: dbos.ListExtractionRulesResponse
+	(*DeleteExtractionRuleRequest)(nil),     // 163: dbos.DeleteExtractionRuleRequest
+	(*DeleteExtractionRuleResponse)(nil),    // 164: dbos.DeleteExtractionRuleResponse
+	(*ColumnFilter)(nil),                    // 165: dbos.ColumnFilter
+	(*QueryResultsRequest)(nil),             // 166: dbos.QueryResultsRequest
+	(*QueryResultsResponse)(nil),            // 167: dbos.QueryResultsResponse
+	(*SavedQuery)(nil),                      // 168: dbos.SavedQuery
+	(*Aggregation)(nil),                     // 169: dbos.Aggregation
+	(*CreateSavedQueryRequest)(nil),         // 170: dbos.CreateSavedQueryRequest
+	(*CreateSavedQueryResponse)(nil),        // 171: dbos.CreateSavedQueryResponse
+	(*GetSavedQueryRequest)(nil),            // 172: dbos.GetSavedQueryRequest
+	(*GetSavedQueryResponse)(nil),           // 173: dbos.GetSavedQueryResponse
+	(*ListSavedQueriesRequest)(nil),         // 174: dbos.ListSavedQueriesRequest
+	(*ListSavedQueriesResponse)(nil),        // 175: dbos.ListSavedQueriesResponse
+	(*UpdateSavedQueryRequest)(nil),         // 176: dbos.UpdateSavedQueryRequest
+	(*UpdateSavedQueryResponse)(nil),        // 177: dbos.UpdateSavedQueryResponse
+	(*DeleteSavedQueryRequest)(nil),         // 178: dbos.DeleteSavedQueryRequest
+	(*DeleteSavedQueryResponse)(nil),        // 179: dbos.DeleteSavedQueryResponse
+	(*ExecuteSavedQueryRequest)(nil),        // 180: dbos.ExecuteSavedQueryRequest
+	(*ExecuteSavedQueryResponse)(nil),       // 181: dbos.ExecuteSavedQueryResponse
+	(*AlertRule)(nil),                       // 182: dbos.AlertRule
+	(*AlertSeries)(nil),                     // 183: dbos.AlertSeries
+	(*CreateAlertRuleRequest)(nil),          // 184: dbos.CreateAlertRuleRequest
+	(*CreateAlertRuleResponse)(nil),         // 185: dbos.CreateAlertRuleResponse
+	(*ListAlertRulesRequest)(nil),           // 186: dbos.ListAlertRulesRequest
+	(*ListAlertRulesResponse)(nil),          // 187: dbos.ListAlertRulesResponse
+	(*DeleteAlertRuleRequest)(nil),          // 188: dbos.DeleteAlertRuleRequest
+	(*DeleteAlertRuleResponse)(nil),         // 189: dbos.DeleteAlertRuleResponse
+	(*MaintenanceWindow)(nil),               // 190: dbos.MaintenanceWindow
+	(*CreateMaintenanceWindowRequest)(nil),  // 191: dbos.CreateMaintenanceWindowRequest
+	(*CreateMaintenanceWindowResponse)(nil), // 192: dbos.CreateMaintenanceWindowResponse
+	(*GetMaintenanceWindowRequest)(nil),     // 193: dbos.GetMaintenanceWindowRequest
+	(*GetMaintenanceWindowResponse)(nil),    // 194: dbos.GetMaintenanceWindowResponse
+	(*ListMaintenanceWindowsRequest)(nil),   // 195: dbos.ListMaintenanceWindowsRequest
+	(*ListMaintenanceWindowsResponse)(nil),  // 196: dbos.ListMaintenanceWindowsResponse
+	(*UpdateMaintenanceWindowRequest)(nil),  // 197: dbos.UpdateMaintenanceWindowRequest
+	(*UpdateMaintenanceWindowResponse)(nil), // 198: dbos.UpdateMaintenanceWindowResponse
+	(*DeleteMaintenanceWindowRequest)(nil),  // 199: dbos.DeleteMaintenanceWindowRequest
+	(*DeleteMaintenanceWindowResponse)(nil), // 200: dbos.DeleteMaintenanceWindowResponse
+	(*TrendPoint)(nil),                      // 201: dbos.TrendPoint
+	(*GetTrendsRequest)(nil),                // 202: dbos.GetTrendsRequest
+	(*GetTrendsResponse)(nil),               // 203: dbos.GetTrendsResponse
+	(*ListTasksRequest)(nil),                // 204: dbos.ListTasksRequest
+	(*ListTasksResponse)(nil),               // 205: dbos.ListTasksResponse
+	(*ListDueTasksRequest)(nil),             // 206: dbos.ListDueTasksRequest
+	(*ListDueTasksResponse)(nil),            // 207: dbos.ListDueTasksResponse
+	(*ListPendingTasksRequest)(nil),         // 208: dbos.ListPendingTasksRequest
+	(*PendingTask)(nil),                     // 209: dbos.PendingTask
+	(*ListPendingTasksResponse)(nil),        // 210: dbos.ListPendingTasksResponse
+	(*Campaign)(nil),                        // 211: dbos.Campaign
+	(*CreateCampaignRequest)(nil),           // 212: dbos.CreateCampaignRequest
+	(*CreateCampaignResponse)(nil),          // 213: dbos.CreateCampaignResponse
+	(*GetCampaignRequest)(nil),              // 214: dbos.GetCampaignRequest
+	(*GetCampaignResponse)(nil),             // 215: dbos.GetCampaignResponse
+	(*ListCampaignsRequest)(nil),            // 216: dbos.ListCampaignsRequest
+	(*ListCampaignsResponse)(nil),           // 217: dbos.ListCampaignsResponse
+	(*StopCampaignRequest)(nil),             // 218: dbos.StopCampaignRequest
+	(*StopCampaignResponse)(nil),            // 219: dbos.StopCampaignResponse
+	(*ListCampaignResultsRequest)(nil),      // 220: dbos.ListCampaignResultsRequest
+	(*ListCampaignResultsResponse)(nil),     // 221: dbos.ListCampaignResultsResponse
+	(*ApplySpecRequest)(nil),                // 222: dbos.ApplySpecRequest
+	(*SpecChange)(nil),                      // 223: dbos.SpecChange
+	(*ApplySpecResponse)(nil),               // 224: dbos.ApplySpecResponse
+	(*GetDriftRequest)(nil),                 // 225: dbos.GetDriftRequest
+	(*Drift)(nil),                           // 226: dbos.Drift
+	(*GetDriftResponse)(nil),                // 227: dbos.GetDriftResponse
+	(*PlanCapacityRequest)(nil),             // 228: dbos.PlanCapacityRequest
+	(*CapacityBudget)(nil),                  // 229: dbos.CapacityBudget
+	(*AgentCapacity)(nil),                   // 230: dbos.AgentCapacity
+	(*GroupCapacity)(nil),                   // 231: dbos.GroupCapacity
+	(*QueueDepthPoint)(nil),                 // 232: dbos.QueueDepthPoint
+	(*PlanCapacityResponse)(nil),            // 233: dbos.PlanCapacityResponse
+	(*GetExecutionStatsRequest)(nil),        // 234: dbos.GetExecutionStatsRequest
+	(*DurationBucket)(nil),                  // 235: dbos.DurationBucket
+	(*ExecutionDurationStats)(nil),          // 236: dbos.ExecutionDurationStats
+	(*GetExecutionStatsResponse)(nil),       // 237: dbos.GetExecutionStatsResponse
+	(*GetUsageRequest)(nil),                 // 238: dbos.GetUsageRequest
+	(*UsageEntry)(nil),                      // 239: dbos.UsageEntry
+	(*GetUsageResponse)(nil),                // 240: dbos.GetUsageResponse
+	(*Event)(nil),                           // 241: dbos.Event
+	(*GetEventsRequest)(nil),                // 242: dbos.GetEventsRequest
+	(*GetEventsResponse)(nil),               // 243: dbos.GetEventsResponse
+	(*StreamEventsRequest)(nil),             // 244: dbos.StreamEventsRequest
+	(*StateEvent)(nil),                      // 245: dbos.StateEvent
+	(*ListStateEventsRequest)(nil),          // 246: dbos.ListStateEventsRequest
+	(*ListStateEventsResponse)(nil),         // 247: dbos.ListStateEventsResponse
+	(*RebuildStateRequest)(nil),             // 248: dbos.RebuildStateRequest
+	(*RebuildStateResponse)(nil),            // 249: dbos.RebuildStateResponse
+	(*ExportSnapshotRequest)(nil),           // 250: dbos.ExportSnapshotRequest
+	(*SnapshotMarker)(nil),                  // 251: dbos.SnapshotMarker
+	(*SnapshotRecord)(nil),                  // 252: dbos.SnapshotRecord
+	nil,                                     // 253: dbos.Agent.ConfigEntry
+	nil,                                     // 254: dbos.Agent.LabelsEntry
+	nil,                                     // 255: dbos.ModuleState.DetailsEntry
+	nil,                                     // 256: dbos.Task.SelectorEntry
+	nil,                                     // 257: dbos.Task.FailoverSelectorEntry
+	nil,                                     // 258: dbos.GetAgentGeoJSONRequest.SelectorEntry
+	nil,                                     // 259: dbos.CreateBootstrapTokenRequest.LabelsEntry
+	nil,                                     // 260: dbos.CreateBootstrapTokenRequest.ConfigEntry
+	nil,                                     // 261: dbos.AgentConfigVersion.ConfigEntry
+	nil,                                     // 262: dbos.ConfigRollout.ConfigEntry
+	nil,                                     // 263: dbos.ConfigRollout.SelectorEntry
+	nil,                                     // 264: dbos.ConfigSchema.KeysEntry
+	nil,                                     // 265: dbos.ValidateConfigRequest.ConfigEntry
+	nil,                                     // 266: dbos.ValidateConfigRequest.SelectorEntry
+	nil,                                     // 267: dbos.FieldProfile.TypesEntry
+	nil,                                     // 268: dbos.Alert.DetailsEntry
+	nil,                                     // 269: dbos.Incident.EvidenceEntry
+	nil,                                     // 270: dbos.Verification.ValuesEntry
+	nil,                                     // 271: dbos.ScheduleVerifiedTaskRequest.SelectorEntry
+	nil,                                     // 272: dbos.Broadcast.SelectorEntry
+	nil,                                     // 273: dbos.BroadcastTaskRequest.SelectorEntry
+	nil,                                     // 274: dbos.SavedQuery.LabelsEntry
+	nil,                                     // 275: dbos.MaintenanceWindow.SelectorEntry
+	nil,                                     // 276: dbos.Campaign.SelectorEntry
+	nil,                                     // 277: dbos.SnapshotMarker.ResultSequencesEntry
+	(*fieldmaskpb.FieldMask)(nil),           // 278: google.protobuf.FieldMask
 }
 var file_api_dbos_proto_depIdxs = []int32{
-	28, // 0: dbos.Agent.config:type_name -> dbos.Agent.ConfigEntry
-	29, // 1: dbos.ModuleState.details:type_name -> dbos.ModuleState.DetailsEntry
-	0,  // 2: dbos.RegisterAgentRequest.agent:type_name -> dbos.Agent
-	0,  // 3: dbos.GetAgentResponse.agent:type_name -> dbos.Agent
-	0,  // 4: dbos.ListAgentsResponse.agents:type_name -> dbos.Agent
-	1,  // 5: dbos.SetModuleStateRequest.state:type_name -> dbos.ModuleState
-	1,  // 6: dbos.GetModuleStateResponse.state:type_name -> dbos.ModuleState
-	1,  // 7: dbos.ListModuleStatesResponse.states:type_name -> dbos.ModuleState
-	2,  // 8: dbos.StoreResultRequest.result:type_name -> dbos.MeasurementResult
-	2,  // 9: dbos.GetResultResponse.result:type_name -> dbos.MeasurementResult
-	2,  // 10: dbos.ListResultsResponse.results:type_name -> dbos.MeasurementResult
-	3,  // 11: dbos.ScheduleTaskRequest.task:type_name -> dbos.Task
-	3,  // 12: dbos.GetTaskResponse.task:type_name -> dbos.Task
-	3,  // 13: dbos.ListDueTasksResponse.tasks:type_name -> dbos.Task
-	4,  // 14: dbos.DBOS.RegisterAgent:input_type -> dbos.RegisterAgentRequest
-	6,  // 15: dbos.DBOS.GetAgent:input_type -> dbos.GetAgentRequest
-	8,  // 16: dbos.DBOS.ListAgents:input_type -> dbos.ListAgentsRequest
-	10, // 17: dbos.DBOS.SetModuleState:input_type -> dbos.SetModuleStateRequest
-	12, // 18: dbos.DBOS.GetModuleState:input_type -> dbos.GetModuleStateRequest
-	14, // 19: dbos.DBOS.ListModuleStates:input_type -> dbos.ListModuleStatesRequest
-	16, // 20: dbos.DBOS.StoreResult:input_type -> dbos.StoreResultRequest
-	18, // 21: dbos.DBOS.GetResult:input_type -> dbos.GetResultRequest
-	20, // 22: dbos.DBOS.ListResults:input_type -> dbos.ListResultsRequest
-	22, // 23: dbos.DBOS.ScheduleTask:input_type -> dbos.ScheduleTaskRequest
-	24, // 24: dbos.DBOS.GetTask:input_type -> dbos.GetTaskRequest
-	26, // 25: dbos.DBOS.ListDueTasks:input_type -> dbos.ListDueTasksRequest
-	5,  // 26: dbos.DBOS.RegisterAgent:output_type -> dbos.RegisterAgentResponse
-	7,  // 27: dbos.DBOS.GetAgent:output_type -> dbos.GetAgentResponse
-	9,  // 28: dbos.DBOS.ListAgents:output_type -> dbos.ListAgentsResponse
-	11, // 29: dbos.DBOS.SetModuleState:output_type -> dbos.SetModuleStateResponse
-	13, // 30: dbos.DBOS.GetModuleState:output_type -> dbos.GetModuleStateResponse
-	15, // 31: dbos.DBOS.ListModuleStates:output_type -> dbos.ListModuleStatesResponse
-	17, // 32: dbos.DBOS.StoreResult:output_type -> dbos.StoreResultResponse
-	19, // 33: dbos.DBOS.GetResult:output_type -> dbos.GetResultResponse
-	21, // 34: dbos.DBOS.ListResults:output_type -> dbos.ListResultsResponse
-	23, // 35: dbos.DBOS.ScheduleTask:output_type -> dbos.ScheduleTaskResponse
-	25, // 36: dbos.DBOS.GetTask:output_type -> dbos.GetTaskResponse
-	27, // 37: dbos.DBOS.ListDueTasks:output_type -> dbos.ListDueTasksResponse
-	26, // [26:38] is the sub-list for method output_type
-	14, // [14:26] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	253, // 0: dbos.Agent.config:type_name -> dbos.Agent.ConfigEntry
+	254, // 1: dbos.Agent.labels:type_name -> dbos.Agent.LabelsEntry
+	255, // 2: dbos.ModuleState.details:type_name -> dbos.ModuleState.DetailsEntry
+	256, // 3: dbos.Task.selector:type_name -> dbos.Task.SelectorEntry
+	6,   // 4: dbos.Task.placement:type_name -> dbos.Placement
+	5,   // 5: dbos.Task.retry_policy:type_name -> dbos.RetryPolicy
+	257, // 6: dbos.Task.failover_selector:type_name -> dbos.Task.FailoverSelectorEntry
+	0,   // 7: dbos.RegisterAgentRequest.agent:type_name -> dbos.Agent
+	0,   // 8: dbos.HeartbeatResponse.agent:type_name -> dbos.Agent
+	4,   // 9: dbos.HeartbeatResponse.tasks:type_name -> dbos.Task
+	66,  // 10: dbos.HeartbeatResponse.backoff:type_name -> dbos.Backoff
+	278, // 11: dbos.GetAgentRequest.read_mask:type_name -> google.protobuf.FieldMask
+	0,   // 12: dbos.GetAgentResponse.agent:type_name -> dbos.Agent
+	278, // 13: dbos.ListAgentsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	0,   // 14: dbos.ListAgentsResponse.agents:type_name -> dbos.Agent
+	0,   // 15: dbos.AgentDelta.agent:type_name -> dbos.Agent
+	258, // 16: dbos.GetAgentGeoJSONRequest.selector:type_name -> dbos.GetAgentGeoJSONRequest.SelectorEntry
+	259, // 17: dbos.CreateBootstrapTokenRequest.labels:type_name -> dbos.CreateBootstrapTokenRequest.LabelsEntry
+	260, // 18: dbos.CreateBootstrapTokenRequest.config:type_name -> dbos.CreateBootstrapTokenRequest.ConfigEntry
+	0,   // 19: dbos.EnrollAgentResponse.agent:type_name -> dbos.Agent
+	29,  // 20: dbos.PublishArtifactRequest.artifact:type_name -> dbos.Artifact
+	261, // 21: dbos.AgentConfigVersion.config:type_name -> dbos.AgentConfigVersion.ConfigEntry
+	262, // 22: dbos.ConfigRollout.config:type_name -> dbos.ConfigRollout.ConfigEntry
+	263, // 23: dbos.ConfigRollout.selector:type_name -> dbos.ConfigRollout.SelectorEntry
+	37,  // 24: dbos.StartConfigRolloutRequest.rollout:type_name -> dbos.ConfigRollout
+	37,  // 25: dbos.StartConfigRolloutResponse.rollout:type_name -> dbos.ConfigRollout
+	48,  // 26: dbos.StartConfigRolloutResponse.violations:type_name -> dbos.ConfigViolation
+	37,  // 27: dbos.GetConfigRolloutResponse.rollout:type_name -> dbos.ConfigRollout
+	37,  // 28: dbos.RollbackConfigRolloutResponse.rollout:type_name -> dbos.ConfigRollout
+	36,  // 29: dbos.GetAgentConfigResponse.config:type_name -> dbos.AgentConfigVersion
+	264, // 30: dbos.ConfigSchema.keys:type_name -> dbos.ConfigSchema.KeysEntry
+	47,  // 31: dbos.SetConfigSchemaRequest.schema:type_name -> dbos.ConfigSchema
+	47,  // 32: dbos.SetConfigSchemaResponse.schema:type_name -> dbos.ConfigSchema
+	47,  // 33: dbos.ListConfigSchemasResponse.schemas:type_name -> dbos.ConfigSchema
+	265, // 34: dbos.ValidateConfigRequest.config:type_name -> dbos.ValidateConfigRequest.ConfigEntry
+	266, // 35: dbos.ValidateConfigRequest.selector:type_name -> dbos.ValidateConfigRequest.SelectorEntry
+	48,  // 36: dbos.ValidateConfigResponse.violations:type_name -> dbos.ConfigViolation
+	1,   // 37: dbos.SetModuleStateRequest.state:type_name -> dbos.ModuleState
+	1,   // 38: dbos.GetModuleStateResponse.state:type_name -> dbos.ModuleState
+	1,   // 39: dbos.ListModuleStatesResponse.states:type_name -> dbos.ModuleState
+	2,   // 40: dbos.StoreResultRequest.result:type_name -> dbos.MeasurementResult
+	66,  // 41: dbos.StoreResultResponse.backoff:type_name -> dbos.Backoff
+	2,   // 42: dbos.StoreResultsRequest.results:type_name -> dbos.MeasurementResult
+	68,  // 43: dbos.StoreResultsResponse.results:type_name -> dbos.ResultStoreStatus
+	66,  // 44: dbos.StoreResultsResponse.backoff:type_name -> dbos.Backoff
+	71,  // 45: dbos.StreamResultsResponse.rejected:type_name -> dbos.RejectedResult
+	66,  // 46: dbos.StreamResultsResponse.backoff:type_name -> dbos.Backoff
+	278, // 47: dbos.GetResultRequest.read_mask:type_name -> google.protobuf.FieldMask
+	2,   // 48: dbos.GetResultResponse.result:type_name -> dbos.MeasurementResult
+	278, // 49: dbos.ListResultsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	2,   // 50: dbos.ListResultsResponse.results:type_name -> dbos.MeasurementResult
+	2,   // 51: dbos.GetCorrelatedResultsResponse.results:type_name -> dbos.MeasurementResult
+	267, // 52: dbos.FieldProfile.types:type_name -> dbos.FieldProfile.TypesEntry
+	85,  // 53: dbos.ProfileResultsResponse.fields:type_name -> dbos.FieldProfile
+	3,   // 54: dbos.GetClockSkewResponse.skew:type_name -> dbos.ClockSkew
+	268, // 55: dbos.Alert.details:type_name -> dbos.Alert.DetailsEntry
+	89,  // 56: dbos.ListAlertsResponse.alerts:type_name -> dbos.Alert
+	269, // 57: dbos.Incident.evidence:type_name -> dbos.Incident.EvidenceEntry
+	93,  // 58: dbos.Incident.comments:type_name -> dbos.IncidentComment
+	94,  // 59: dbos.ListRoutingEventsResponse.events:type_name -> dbos.RoutingEvent
+	92,  // 60: dbos.GetIncidentResponse.incident:type_name -> dbos.Incident
+	92,  // 61: dbos.ListIncidentsResponse.incidents:type_name -> dbos.Incident
+	92,  // 62: dbos.CreateIncidentResponse.incident:type_name -> dbos.Incident
+	92,  // 63: dbos.UpdateIncidentResponse.incident:type_name -> dbos.Incident
+	92,  // 64: dbos.AcknowledgeIncidentResponse.incident:type_name -> dbos.Incident
+	92,  // 65: dbos.ResolveIncidentResponse.incident:type_name -> dbos.Incident
+	92,  // 66: dbos.AddIncidentCommentResponse.incident:type_name -> dbos.Incident
+	92,  // 67: dbos.IncidentEvent.incident:type_name -> dbos.Incident
+	116, // 68: dbos.GetIngestGapsResponse.gaps:type_name -> dbos.SequenceGap
+	4,   // 69: dbos.ScheduleTaskRequest.task:type_name -> dbos.Task
+	4,   // 70: dbos.GetTaskResponse.task:type_name -> dbos.Task
+	134, // 71: dbos.GetTaskResponse.progress:type_name -> dbos.TaskProgress
+	4,   // 72: dbos.LeaseTaskResponse.task:type_name -> dbos.Task
+	66,  // 73: dbos.LeaseTaskResponse.backoff:type_name -> dbos.Backoff
+	128, // 74: dbos.AckTasksResponse.results:type_name -> dbos.TaskAck
+	128, // 75: dbos.NackTasksResponse.results:type_name -> dbos.TaskAck
+	270, // 76: dbos.Verification.values:type_name -> dbos.Verification.ValuesEntry
+	137, // 77: dbos.ScheduleVerifiedTaskRequest.verification:type_name -> dbos.Verification
+	271, // 78: dbos.ScheduleVerifiedTaskRequest.selector:type_name -> dbos.ScheduleVerifiedTaskRequest.SelectorEntry
+	137, // 79: dbos.ScheduleVerifiedTaskResponse.verification:type_name -> dbos.Verification
+	137, // 80: dbos.GetVerificationResponse.verification:type_name -> dbos.Verification
+	272, // 81: dbos.Broadcast.selector:type_name -> dbos.Broadcast.SelectorEntry
+	4,   // 82: dbos.BroadcastTaskRequest.task:type_name -> dbos.Task
+	273, // 83: dbos.BroadcastTaskRequest.selector:type_name -> dbos.BroadcastTaskRequest.SelectorEntry
+	142, // 84: dbos.BroadcastTaskResponse.broadcast:type_name -> dbos.Broadcast
+	142, // 85: dbos.GetBroadcastStatusResponse.broadcast:type_name -> dbos.Broadcast
+	146, // 86: dbos.GetBroadcastStatusResponse.agents:type_name -> dbos.BroadcastAgentStatus
+	148, // 87: dbos.CreateViewRequest.view:type_name -> dbos.View
+	148, // 88: dbos.ListViewsResponse.views:type_name -> dbos.View
+	149, // 89: dbos.QueryViewResponse.rows:type_name -> dbos.ViewRow
+	158, // 90: dbos.CreateExtractionRuleRequest.rule:type_name -> dbos.ExtractionRule
+	158, // 91: dbos.ListExtractionRulesResponse.rules:type_name -> dbos.ExtractionRule
+	165, // 92: dbos.QueryResultsRequest.filters:type_name -> dbos.ColumnFilter
+	2,   // 93: dbos.QueryResultsResponse.results:type_name -> dbos.MeasurementResult
+	165, // 94: dbos.SavedQuery.filters:type_name -> dbos.ColumnFilter
+	274, // 95: dbos.SavedQuery.labels:type_name -> dbos.SavedQuery.LabelsEntry
+	169, // 96: dbos.SavedQuery.aggregation:type_name -> dbos.Aggregation
+	168, // 97: dbos.CreateSavedQueryRequest.query:type_name -> dbos.SavedQuery
+	168, // 98: dbos.GetSavedQueryResponse.query:type_name -> dbos.SavedQuery
+	168, // 99: dbos.ListSavedQueriesResponse.queries:type_name -> dbos.SavedQuery
+	168, // 100: dbos.UpdateSavedQueryRequest.query:type_name -> dbos.SavedQuery
+	2,   // 101: dbos.ExecuteSavedQueryResponse.results:type_name -> dbos.MeasurementResult
+	0,   // 102: dbos.ExecuteSavedQueryResponse.agents:type_name -> dbos.Agent
+	183, // 103: dbos.AlertRule.series:type_name -> dbos.AlertSeries
+	182, // 104: dbos.CreateAlertRuleRequest.rule:type_name -> dbos.AlertRule
+	182, // 105: dbos.ListAlertRulesResponse.rules:type_name -> dbos.AlertRule
+	275, // 106: dbos.MaintenanceWindow.selector:type_name -> dbos.MaintenanceWindow.SelectorEntry
+	190, // 107: dbos.CreateMaintenanceWindowRequest.window:type_name -> dbos.MaintenanceWindow
+	190, // 108: dbos.CreateMaintenanceWindowResponse.window:type_name -> dbos.MaintenanceWindow
+	190, // 109: dbos.GetMaintenanceWindowResponse.window:type_name -> dbos.MaintenanceWindow
+	190, // 110: dbos.ListMaintenanceWindowsResponse.windows:type_name -> dbos.MaintenanceWindow
+	190, // 111: dbos.UpdateMaintenanceWindowRequest.window:type_name -> dbos.MaintenanceWindow
+	190, // 112: dbos.UpdateMaintenanceWindowResponse.window:type_name -> dbos.MaintenanceWindow
+	201, // 113: dbos.GetTrendsResponse.points:type_name -> dbos.TrendPoint
+	201, // 114: dbos.GetTrendsResponse.summary:type_name -> dbos.TrendPoint
+	4,   // 115: dbos.ListTasksResponse.tasks:type_name -> dbos.Task
+	4,   // 116: dbos.ListDueTasksResponse.tasks:type_name -> dbos.Task
+	209, // 117: dbos.ListPendingTasksResponse.tasks:type_name -> dbos.PendingTask
+	276, // 118: dbos.Campaign.selector:type_name -> dbos.Campaign.SelectorEntry
+	211, // 119: dbos.CreateCampaignRequest.campaign:type_name -> dbos.Campaign
+	211, // 120: dbos.CreateCampaignResponse.campaign:type_name -> dbos.Campaign
+	211, // 121: dbos.GetCampaignResponse.campaign:type_name -> dbos.Campaign
+	211, // 122: dbos.ListCampaignsResponse.campaigns:type_name -> dbos.Campaign
+	211, // 123: dbos.StopCampaignResponse.campaign:type_name -> dbos.Campaign
+	278, // 124: dbos.ListCampaignResultsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	2,   // 125: dbos.ListCampaignResultsResponse.results:type_name -> dbos.MeasurementResult
+	223, // 126: dbos.ApplySpecResponse.changes:type_name -> dbos.SpecChange
+	226, // 127: dbos.GetDriftResponse.drifts:type_name -> dbos.Drift
+	211, // 128: dbos.PlanCapacityRequest.campaign:type_name -> dbos.Campaign
+	229, // 129: dbos.PlanCapacityRequest.agent_budget:type_name -> dbos.CapacityBudget
+	229, // 130: dbos.PlanCapacityRequest.group_budget:type_name -> dbos.CapacityBudget
+	230, // 131: dbos.PlanCapacityResponse.agents:type_name -> dbos.AgentCapacity
+	231, // 132: dbos.PlanCapacityResponse.groups:type_name -> dbos.GroupCapacity
+	232, // 133: dbos.PlanCapacityResponse.queue_depth:type_name -> dbos.QueueDepthPoint
+	235, // 134: dbos.ExecutionDurationStats.histogram:type_name -> dbos.DurationBucket
+	236, // 135: dbos.GetExecutionStatsResponse.module:type_name -> dbos.ExecutionDurationStats
+	236, // 136: dbos.GetExecutionStatsResponse.agents:type_name -> dbos.ExecutionDurationStats
+	239, // 137: dbos.GetUsageResponse.entries:type_name -> dbos.UsageEntry
+	241, // 138: dbos.GetEventsResponse.events:type_name -> dbos.Event
+	0,   // 139: dbos.StateEvent.agent:type_name -> dbos.Agent
+	4,   // 140: dbos.StateEvent.task:type_name -> dbos.Task
+	245, // 141: dbos.ListStateEventsResponse.events:type_name -> dbos.StateEvent
+	277, // 142: dbos.SnapshotMarker.result_sequences:type_name -> dbos.SnapshotMarker.ResultSequencesEntry
+	251, // 143: dbos.SnapshotRecord.marker:type_name -> dbos.SnapshotMarker
+	0,   // 144: dbos.SnapshotRecord.agent:type_name -> dbos.Agent
+	4,   // 145: dbos.SnapshotRecord.task:type_name -> dbos.Task
+	2,   // 146: dbos.SnapshotRecord.result:type_name -> dbos.MeasurementResult
+	46,  // 147: dbos.ConfigSchema.KeysEntry.value:type_name -> dbos.ConfigKeySchema
+	7,   // 148: dbos.DBOS.RegisterAgent:input_type -> dbos.RegisterAgentRequest
+	9,   // 149: dbos.DBOS.Heartbeat:input_type -> dbos.HeartbeatRequest
+	11,  // 150: dbos.DBOS.GetAgent:input_type -> dbos.GetAgentRequest
+	13,  // 151: dbos.DBOS.ListAgents:input_type -> dbos.ListAgentsRequest
+	15,  // 152: dbos.DBOS.DeleteAgent:input_type -> dbos.DeleteAgentRequest
+	17,  // 153: dbos.DBOS.WatchAgents:input_type -> dbos.WatchAgentsRequest
+	19,  // 154: dbos.DBOS.GetAgentGeoJSON:input_type -> dbos.GetAgentGeoJSONRequest
+	21,  // 155: dbos.DBOS.CreateBootstrapToken:input_type -> dbos.CreateBootstrapTokenRequest
+	27,  // 156: dbos.DBOS.EnrollAgent:input_type -> dbos.EnrollAgentRequest
+	23,  // 157: dbos.DBOS.CreateAgentToken:input_type -> dbos.CreateAgentTokenRequest
+	25,  // 158: dbos.DBOS.CreateAPIToken:input_type -> dbos.CreateAPITokenRequest
+	30,  // 159: dbos.DBOS.PublishArtifact:input_type -> dbos.PublishArtifactRequest
+	32,  // 160: dbos.DBOS.UnpublishArtifact:input_type -> dbos.UnpublishArtifactRequest
+	34,  // 161: dbos.DBOS.GetManifest:input_type -> dbos.GetManifestRequest
+	38,  // 162: dbos.DBOS.StartConfigRollout:input_type -> dbos.StartConfigRolloutRequest
+	40,  // 163: dbos.DBOS.GetConfigRollout:input_type -> dbos.GetConfigRolloutRequest
+	42,  // 164: dbos.DBOS.RollbackConfigRollout:input_type -> dbos.RollbackConfigRolloutRequest
+	44,  // 165: dbos.DBOS.GetAgentConfig:input_type -> dbos.GetAgentConfigRequest
+	49,  // 166: dbos.DBOS.SetConfigSchema:input_type -> dbos.SetConfigSchemaRequest
+	51,  // 167: dbos.DBOS.ListConfigSchemas:input_type -> dbos.ListConfigSchemasRequest
+	53,  // 168: dbos.DBOS.DeleteConfigSchema:input_type -> dbos.DeleteConfigSchemaRequest
+	55,  // 169: dbos.DBOS.ValidateConfig:input_type -> dbos.ValidateConfigRequest
+	57,  // 170: dbos.DBOS.SetModuleState:input_type -> dbos.SetModuleStateRequest
+	59,  // 171: dbos.DBOS.GetModuleState:input_type -> dbos.GetModuleStateRequest
+	61,  // 172: dbos.DBOS.ListModuleStates:input_type -> dbos.ListModuleStatesRequest
+	62,  // 173: dbos.DBOS.WatchModuleStates:input_type -> dbos.WatchModuleStatesRequest
+	64,  // 174: dbos.DBOS.StoreResult:input_type -> dbos.StoreResultRequest
+	67,  // 175: dbos.DBOS.StoreResults:input_type -> dbos.StoreResultsRequest
+	64,  // 176: dbos.DBOS.StreamResults:input_type -> dbos.StoreResultRequest
+	72,  // 177: dbos.DBOS.GetResult:input_type -> dbos.GetResultRequest
+	74,  // 178: dbos.DBOS.ListResults:input_type -> dbos.ListResultsRequest
+	76,  // 179: dbos.DBOS.GetCorrelatedResults:input_type -> dbos.GetCorrelatedResultsRequest
+	78,  // 180: dbos.DBOS.CountResults:input_type -> dbos.CountResultsRequest
+	80,  // 181: dbos.DBOS.HasResult:input_type -> dbos.HasResultRequest
+	82,  // 182: dbos.DBOS.ExportResults:input_type -> dbos.ExportResultsRequest
+	84,  // 183: dbos.DBOS.ProfileResults:input_type -> dbos.ProfileResultsRequest
+	115, // 184: dbos.DBOS.GetIngestGaps:input_type -> dbos.GetIngestGapsRequest
+	87,  // 185: dbos.DBOS.GetClockSkew:input_type -> dbos.GetClockSkewRequest
+	90,  // 186: dbos.DBOS.ListAlerts:input_type -> dbos.ListAlertsRequest
+	97,  // 187: dbos.DBOS.GetIncident:input_type -> dbos.GetIncidentRequest
+	99,  // 188: dbos.DBOS.ListIncidents:input_type -> dbos.ListIncidentsRequest
+	101, // 189: dbos.DBOS.CreateIncident:input_type -> dbos.CreateIncidentRequest
+	103, // 190: dbos.DBOS.UpdateIncident:input_type -> dbos.UpdateIncidentRequest
+	105, // 191: dbos.DBOS.AcknowledgeIncident:input_type -> dbos.AcknowledgeIncidentRequest
+	107, // 192: dbos.DBOS.ResolveIncident:input_type -> dbos.ResolveIncidentRequest
+	109, // 193: dbos.DBOS.AddIncidentComment:input_type -> dbos.AddIncidentCommentRequest
+	111, // 194: dbos.DBOS.DeleteIncident:input_type -> dbos.DeleteIncidentRequest
+	113, // 195: dbos.DBOS.WatchIncidents:input_type -> dbos.WatchIncidentsRequest
+	95,  // 196: dbos.DBOS.ListRoutingEvents:input_type -> dbos.ListRoutingEventsRequest
+	118, // 197: dbos.DBOS.ScheduleTask:input_type -> dbos.ScheduleTaskRequest
+	120, // 198: dbos.DBOS.GetTask:input_type -> dbos.GetTaskRequest
+	204, // 199: dbos.DBOS.ListTasks:input_type -> dbos.ListTasksRequest
+	206, // 200: dbos.DBOS.ListDueTasks:input_type -> dbos.ListDueTasksRequest
+	208, // 201: dbos.DBOS.ListPendingTasks:input_type -> dbos.ListPendingTasksRequest
+	122, // 202: dbos.DBOS.CancelTask:input_type -> dbos.CancelTaskRequest
+	125, // 203: dbos.DBOS.LeaseTask:input_type -> dbos.LeaseTaskRequest
+	127, // 204: dbos.DBOS.AckTasks:input_type -> dbos.AckTasksRequest
+	130, // 205: dbos.DBOS.NackTasks:input_type -> dbos.NackTasksRequest
+	132, // 206: dbos.DBOS.ExtendTaskVisibility:input_type -> dbos.ExtendTaskVisibilityRequest
+	135, // 207: dbos.DBOS.ReportTaskProgress:input_type -> dbos.ReportTaskProgressRequest
+	124, // 208: dbos.DBOS.StreamTasks:input_type -> dbos.StreamTasksRequest
+	138, // 209: dbos.DBOS.ScheduleVerifiedTask:input_type -> dbos.ScheduleVerifiedTaskRequest
+	140, // 210: dbos.DBOS.GetVerification:input_type -> dbos.GetVerificationRequest
+	143, // 211: dbos.DBOS.BroadcastTask:input_type -> dbos.BroadcastTaskRequest
+	145, // 212: dbos.DBOS.GetBroadcastStatus:input_type -> dbos.GetBroadcastStatusRequest
+	150, // 213: dbos.DBOS.CreateView:input_type -> dbos.CreateViewRequest
+	152, // 214: dbos.DBOS.ListViews:input_type -> dbos.ListViewsRequest
+	154, // 215: dbos.DBOS.DeleteView:input_type -> dbos.DeleteViewRequest
+	156, // 216: dbos.DBOS.QueryView:input_type -> dbos.QueryViewRequest
+	159, // 217: dbos.DBOS.CreateExtractionRule:input_type -> dbos.CreateExtractionRuleRequest
+	161, // 218: dbos.DBOS.ListExtractionRules:input_type -> dbos.ListExtractionRulesRequest
+	163, // 219: dbos.DBOS.DeleteExtractionRule:input_type -> dbos.DeleteExtractionRuleRequest
+	166, // 220: dbos.DBOS.QueryResults:input_type -> dbos.QueryResultsRequest
+	170, // 221: dbos.DBOS.CreateSavedQuery:input_type -> dbos.CreateSavedQueryRequest
+	172, // 222: dbos.DBOS.GetSavedQuery:input_type -> dbos.GetSavedQueryRequest
+	174, // 223: dbos.DBOS.ListSavedQueries:input_type -> dbos.ListSavedQueriesRequest
+	176, // 224: dbos.DBOS.UpdateSavedQuery:input_type -> dbos.UpdateSavedQueryRequest
+	178, // 225: dbos.DBOS.DeleteSavedQuery:input_type -> dbos.DeleteSavedQueryRequest
+	180, // 226: dbos.DBOS.ExecuteSavedQuery:input_type -> dbos.ExecuteSavedQueryRequest
+	184, // 227: dbos.DBOS.CreateAlertRule:input_type -> dbos.CreateAlertRuleRequest
+	186, // 228: dbos.DBOS.ListAlertRules:input_type -> dbos.ListAlertRulesRequest
+	188, // 229: dbos.DBOS.DeleteAlertRule:input_type -> dbos.DeleteAlertRuleRequest
+	191, // 230: dbos.DBOS.CreateMaintenanceWindow:input_type -> dbos.CreateMaintenanceWindowRequest
+	193, // 231: dbos.DBOS.GetMaintenanceWindow:input_type -> dbos.GetMaintenanceWindowRequest
+	195, // 232: dbos.DBOS.ListMaintenanceWindows:input_type -> dbos.ListMaintenanceWindowsRequest
+	197, // 233: dbos.DBOS.UpdateMaintenanceWindow:input_type -> dbos.UpdateMaintenanceWindowRequest
+	199, // 234: dbos.DBOS.DeleteMaintenanceWindow:input_type -> dbos.DeleteMaintenanceWindowRequest
+	202, // 235: dbos.DBOS.GetTrends:input_type -> dbos.GetTrendsRequest
+	212, // 236: dbos.DBOS.CreateCampaign:input_type -> dbos.CreateCampaignRequest
+	214, // 237: dbos.DBOS.GetCampaign:input_type -> dbos.GetCampaignRequest
+	216, // 238: dbos.DBOS.ListCampaigns:input_type -> dbos.ListCampaignsRequest
+	218, // 239: dbos.DBOS.StopCampaign:input_type -> dbos.StopCampaignRequest
+	220, // 240: dbos.DBOS.ListCampaignResults:input_type -> dbos.ListCampaignResultsRequest
+	222, // 241: dbos.DBOS.ApplySpec:input_type -> dbos.ApplySpecRequest
+	225, // 242: dbos.DBOS.GetDrift:input_type -> dbos.GetDriftRequest
+	228, // 243: dbos.DBOS.PlanCapacity:input_type -> dbos.PlanCapacityRequest
+	234, // 244: dbos.DBOS.GetExecutionStats:input_type -> dbos.GetExecutionStatsRequest
+	238, // 245: dbos.DBOS.GetUsage:input_type -> dbos.GetUsageRequest
+	242, // 246: dbos.DBOS.GetEvents:input_type -> dbos.GetEventsRequest
+	244, // 247: dbos.DBOS.StreamEvents:input_type -> dbos.StreamEventsRequest
+	246, // 248: dbos.DBOS.ListStateEvents:input_type -> dbos.ListStateEventsRequest
+	248, // 249: dbos.DBOS.RebuildState:input_type -> dbos.RebuildStateRequest
+	250, // 250: dbos.DBOS.ExportSnapshot:input_type -> dbos.ExportSnapshotRequest
+	8,   // 251: dbos.DBOS.RegisterAgent:output_type -> dbos.RegisterAgentResponse
+	10,  // 252: dbos.DBOS.Heartbeat:output_type -> dbos.HeartbeatResponse
+	12,  // 253: dbos.DBOS.GetAgent:output_type -> dbos.GetAgentResponse
+	14,  // 254: dbos.DBOS.ListAgents:output_type -> dbos.ListAgentsResponse
+	16,  // 255: dbos.DBOS.DeleteAgent:output_type -> dbos.DeleteAgentResponse
+	18,  // 256: dbos.DBOS.WatchAgents:output_type -> dbos.AgentDelta
+	20,  // 257: dbos.DBOS.GetAgentGeoJSON:output_type -> dbos.GetAgentGeoJSONResponse
+	22,  // 258: dbos.DBOS.CreateBootstrapToken:output_type -> dbos.CreateBootstrapTokenResponse
+	28,  // 259: dbos.DBOS.EnrollAgent:output_type -> dbos.EnrollAgentResponse
+	24,  // 260: dbos.DBOS.CreateAgentToken:output_type -> dbos.CreateAgentTokenResponse
+	26,  // 261: dbos.DBOS.CreateAPIToken:output_type -> dbos.CreateAPITokenResponse
+	31,  // 262: dbos.DBOS.PublishArtifact:output_type -> dbos.PublishArtifactResponse
+	33,  // 263: dbos.DBOS.UnpublishArtifact:output_type -> dbos.UnpublishArtifactResponse
+	35,  // 264: dbos.DBOS.GetManifest:output_type -> dbos.GetManifestResponse
+	39,  // 265: dbos.DBOS.StartConfigRollout:output_type -> dbos.StartConfigRolloutResponse
+	41,  // 266: dbos.DBOS.GetConfigRollout:output_type -> dbos.GetConfigRolloutResponse
+	43,  // 267: dbos.DBOS.RollbackConfigRollout:output_type -> dbos.RollbackConfigRolloutResponse
+	45,  // 268: dbos.DBOS.GetAgentConfig:output_type -> dbos.GetAgentConfigResponse
+	50,  // 269: dbos.DBOS.SetConfigSchema:output_type -> dbos.SetConfigSchemaResponse
+	52,  // 270: dbos.DBOS.ListConfigSchemas:output_type -> dbos.ListConfigSchemasResponse
+	54,  // 271: dbos.DBOS.DeleteConfigSchema:output_type -> dbos.DeleteConfigSchemaResponse
+	56,  // 272: dbos.DBOS.ValidateConfig:output_type -> dbos.ValidateConfigResponse
+	58,  // 273: dbos.DBOS.SetModuleState:output_type -> dbos.SetModuleStateResponse
+	60,  // 274: dbos.DBOS.GetModuleState:output_type -> dbos.GetModuleStateResponse
+	63,  // 275: dbos.DBOS.ListModuleStates:output_type -> dbos.ListModuleStatesResponse
+	1,   // 276: dbos.DBOS.WatchModuleStates:output_type -> dbos.ModuleState
+	65,  // 277: dbos.DBOS.StoreResult:output_type -> dbos.StoreResultResponse
+	69,  // 278: dbos.DBOS.StoreResults:output_type -> dbos.StoreResultsResponse
+	70,  // 279: dbos.DBOS.StreamResults:output_type -> dbos.StreamResultsResponse
+	73,  // 280: dbos.DBOS.GetResult:output_type -> dbos.GetResultResponse
+	75,  // 281: dbos.DBOS.ListResults:output_type -> dbos.ListResultsResponse
+	77,  // 282: dbos.DBOS.GetCorrelatedResults:output_type -> dbos.GetCorrelatedResultsResponse
+	79,  // 283: dbos.DBOS.CountResults:output_type -> dbos.CountResultsResponse
+	81,  // 284: dbos.DBOS.HasResult:output_type -> dbos.HasResultResponse
+	83,  // 285: dbos.DBOS.ExportResults:output_type -> dbos.ExportResultsChunk
+	86,  // 286: dbos.DBOS.ProfileResults:output_type -> dbos.ProfileResultsResponse
+	117, // 287: dbos.DBOS.GetIngestGaps:output_type -> dbos.GetIngestGapsResponse
+	88,  // 288: dbos.DBOS.GetClockSkew:output_type -> dbos.GetClockSkewResponse
+	91,  // 289: dbos.DBOS.ListAlerts:output_type -> dbos.ListAlertsResponse
+	98,  // 290: dbos.DBOS.GetIncident:output_type -> dbos.GetIncidentResponse
+	100, // 291: dbos.DBOS.ListIncidents:output_type -> dbos.ListIncidentsResponse
+	102, // 292: dbos.DBOS.CreateIncident:output_type -> dbos.CreateIncidentResponse
+	104, // 293: dbos.DBOS.UpdateIncident:output_type -> dbos.UpdateIncidentResponse
+	106, // 294: dbos.DBOS.AcknowledgeIncident:output_type -> dbos.AcknowledgeIncidentResponse
+	108, // 295: dbos.DBOS.ResolveIncident:output_type -> dbos.ResolveIncidentResponse
+	110, // 296: dbos.DBOS.AddIncidentComment:output_type -> dbos.AddIncidentCommentResponse
+	112, // 297: dbos.DBOS.DeleteIncident:output_type -> dbos.DeleteIncidentResponse
+	114, // 298: dbos.DBOS.WatchIncidents:output_type -> dbos.IncidentEvent
+	96,  // 299: dbos.DBOS.ListRoutingEvents:output_type -> dbos.ListRoutingEventsResponse
+	119, // 300: dbos.DBOS.ScheduleTask:output_type -> dbos.ScheduleTaskResponse
+	121, // 301: dbos.DBOS.GetTask:output_type -> dbos.GetTaskResponse
+	205, // 302: dbos.DBOS.ListTasks:output_type -> dbos.ListTasksResponse
+	207, // 303: dbos.DBOS.ListDueTasks:output_type -> dbos.ListDueTasksResponse
+	210, // 304: dbos.DBOS.ListPendingTasks:output_type -> dbos.ListPendingTasksResponse
+	123, // 305: dbos.DBOS.CancelTask:output_type -> dbos.CancelTaskResponse
+	126, // 306: dbos.DBOS.LeaseTask:output_type -> dbos.LeaseTaskResponse
+	129, // 307: dbos.DBOS.AckTasks:output_type -> dbos.AckTasksResponse
+	131, // 308: dbos.DBOS.NackTasks:output_type -> dbos.NackTasksResponse
+	133, // 309: dbos.DBOS.ExtendTaskVisibility:output_type -> dbos.ExtendTaskVisibilityResponse
+	136, // 310: dbos.DBOS.ReportTaskProgress:output_type -> dbos.ReportTaskProgressResponse
+	4,   // 311: dbos.DBOS.StreamTasks:output_type -> dbos.Task
+	139, // 312: dbos.DBOS.ScheduleVerifiedTask:output_type -> dbos.ScheduleVerifiedTaskResponse
+	141, // 313: dbos.DBOS.GetVerification:output_type -> dbos.GetVerificationResponse
+	144, // 314: dbos.DBOS.BroadcastTask:output_type -> dbos.BroadcastTaskResponse
+	147, // 315: dbos.DBOS.GetBroadcastStatus:output_type -> dbos.GetBroadcastStatusResponse
+	151, // 316: dbos.DBOS.CreateView:output_type -> dbos.CreateViewResponse
+	153, // 317: dbos.DBOS.ListViews:output_type -> dbos.ListViewsResponse
+	155, // 318: dbos.DBOS.DeleteView:output_type -> dbos.DeleteViewResponse
+	157, // 319: dbos.DBOS.QueryView:output_type -> dbos.QueryViewResponse
+	160, // 320: dbos.DBOS.CreateExtractionRule:output_type -> dbos.CreateExtractionRuleResponse
+	162, // 321: dbos.DBOS.ListExtractionRules:output_type -> dbos.ListExtractionRulesResponse
+	164, // 322: dbos.DBOS.DeleteExtractionRule:output_type -> dbos.DeleteExtractionRuleResponse
+	167, // 323: dbos.DBOS.QueryResults:output_type -> dbos.QueryResultsResponse
+	171, // 324: dbos.DBOS.CreateSavedQuery:output_type -> dbos.CreateSavedQueryResponse
+	173, // 325: dbos.DBOS.GetSavedQuery:output_type -> dbos.GetSavedQueryResponse
+	175, // 326: dbos.DBOS.ListSavedQueries:output_type -> dbos.ListSavedQueriesResponse
+	177, // 327: dbos.DBOS.UpdateSavedQuery:output_type -> dbos.UpdateSavedQueryResponse
+	179, // 328: dbos.DBOS.DeleteSavedQuery:output_type -> dbos.DeleteSavedQueryResponse
+	181, // 329: dbos.DBOS.ExecuteSavedQuery:output_type -> dbos.ExecuteSavedQueryResponse
+	185, // 330: dbos.DBOS.CreateAlertRule:output_type -> dbos.CreateAlertRuleResponse
+	187, // 331: dbos.DBOS.ListAlertRules:output_type -> dbos.ListAlertRulesResponse
+	189, // 332: dbos.DBOS.DeleteAlertRule:output_type -> dbos.DeleteAlertRuleResponse
+	192, // 333: dbos.DBOS.CreateMaintenanceWindow:output_type -> dbos.CreateMaintenanceWindowResponse
+	194, // 334: dbos.DBOS.GetMaintenanceWindow:output_type -> dbos.GetMaintenanceWindowResponse
+	196, // 335: dbos.DBOS.ListMaintenanceWindows:output_type -> dbos.ListMaintenanceWindowsResponse
+	198, // 336: dbos.DBOS.UpdateMaintenanceWindow:output_type -> dbos.UpdateMaintenanceWindowResponse
+	200, // 337: dbos.DBOS.DeleteMaintenanceWindow:output_type -> dbos.DeleteMaintenanceWindowResponse
+	203, // 338: dbos.DBOS.GetTrends:output_type -> dbos.GetTrendsResponse
+	213, // 339: dbos.DBOS.CreateCampaign:output_type -> dbos.CreateCampaignResponse
+	215, // 340: dbos.DBOS.GetCampaign:output_type -> dbos.GetCampaignResponse
+	217, // 341: dbos.DBOS.ListCampaigns:output_type -> dbos.ListCampaignsResponse
+	219, // 342: dbos.DBOS.StopCampaign:output_type -> dbos.StopCampaignResponse
+	221, // 343: dbos.DBOS.ListCampaignResults:output_type -> dbos.ListCampaignResultsResponse
+	224, // 344: dbos.DBOS.ApplySpec:output_type -> dbos.ApplySpecResponse
+	227, // 345: dbos.DBOS.GetDrift:output_type -> dbos.GetDriftResponse
+	233, // 346: dbos.DBOS.PlanCapacity:output_type -> dbos.PlanCapacityResponse
+	237, // 347: dbos.DBOS.GetExecutionStats:output_type -> dbos.GetExecutionStatsResponse
+	240, // 348: dbos.DBOS.GetUsage:output_type -> dbos.GetUsageResponse
+	243, // 349: dbos.DBOS.GetEvents:output_type -> dbos.GetEventsResponse
+	241, // 350: dbos.DBOS.StreamEvents:output_type -> dbos.Event
+	247, // 351: dbos.DBOS.ListStateEvents:output_type -> dbos.ListStateEventsResponse
+	249, // 352: dbos.DBOS.RebuildState:output_type -> dbos.RebuildStateResponse
+	252, // 353: dbos.DBOS.ExportSnapshot:output_type -> dbos.SnapshotRecord
+	251, // [251:354] is the sub-list for method output_type
+	148, // [148:251] is the sub-list for method input_type
+	148, // [148:148] is the sub-list for extension type_name
+	148, // [148:148] is the sub-list for extension extendee
+	0,   // [0:148] is the sub-list for field type_name
 }
 
 func init() { file_api_dbos_proto_init() }
@@ -1802,13 +19696,15 @@ func file_api_dbos_proto_init() {
 	if File_api_dbos_proto != nil {
 		return
 	}
+	file_api_dbos_proto_msgTypes[46].OneofWrappers = []any{}
+	file_api_dbos_proto_msgTypes[57].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_dbos_proto_rawDesc), len(file_api_dbos_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   30,
+			NumMessages:   278,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,18 +19,109 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DBOS_RegisterAgent_FullMethodName    = "/dbos.DBOS/RegisterAgent"
-	DBOS_GetAgent_FullMethodName         = "/dbos.DBOS/GetAgent"
-	DBOS_ListAgents_FullMethodName       = "/dbos.DBOS/ListAgents"
-	DBOS_SetModuleState_FullMethodName   = "/dbos.DBOS/SetModuleState"
-	DBOS_GetModuleState_FullMethodName   = "/dbos.DBOS/GetModuleState"
-	DBOS_ListModuleStates_FullMethodName = "/dbos.DBOS/ListModuleStates"
-	DBOS_StoreResult_FullMethodName      = "/dbos.DBOS/StoreResult"
-	DBOS_GetResult_FullMethodName        = "/dbos.DBOS/GetResult"
-	DBOS_ListResults_FullMethodName      = "/dbos.DBOS/ListResults"
-	DBOS_ScheduleTask_FullMethodName     = "/dbos.DBOS/ScheduleTask"
-	DBOS_GetTask_FullMethodName          = "/dbos.DBOS/GetTask"
-	DBOS_ListDueTasks_FullMethodName     = "/dbos.DBOS/ListDueTasks"
+	DBOS_RegisterAgent_FullMethodName           = "/dbos.DBOS/RegisterAgent"
+	DBOS_Heartbeat_FullMethodName               = "/dbos.DBOS/Heartbeat"
+	DBOS_GetAgent_FullMethodName                = "/dbos.DBOS/GetAgent"
+	DBOS_ListAgents_FullMethodName              = "/dbos.DBOS/ListAgents"
+	DBOS_DeleteAgent_FullMethodName             = "/dbos.DBOS/DeleteAgent"
+	DBOS_WatchAgents_FullMethodName             = "/dbos.DBOS/WatchAgents"
+	DBOS_GetAgentGeoJSON_FullMethodName         = "/dbos.DBOS/GetAgentGeoJSON"
+	DBOS_CreateBootstrapToken_FullMethodName    = "/dbos.DBOS/CreateBootstrapToken"
+	DBOS_EnrollAgent_FullMethodName             = "/dbos.DBOS/EnrollAgent"
+	DBOS_CreateAgentToken_FullMethodName        = "/dbos.DBOS/CreateAgentToken"
+	DBOS_CreateAPIToken_FullMethodName          = "/dbos.DBOS/CreateAPIToken"
+	DBOS_PublishArtifact_FullMethodName         = "/dbos.DBOS/PublishArtifact"
+	DBOS_UnpublishArtifact_FullMethodName       = "/dbos.DBOS/UnpublishArtifact"
+	DBOS_GetManifest_FullMethodName             = "/dbos.DBOS/GetManifest"
+	DBOS_StartConfigRollout_FullMethodName      = "/dbos.DBOS/StartConfigRollout"
+	DBOS_GetConfigRollout_FullMethodName        = "/dbos.DBOS/GetConfigRollout"
+	DBOS_RollbackConfigRollout_FullMethodName   = "/dbos.DBOS/RollbackConfigRollout"
+	DBOS_GetAgentConfig_FullMethodName          = "/dbos.DBOS/GetAgentConfig"
+	DBOS_SetConfigSchema_FullMethodName         = "/dbos.DBOS/SetConfigSchema"
+	DBOS_ListConfigSchemas_FullMethodName       = "/dbos.DBOS/ListConfigSchemas"
+	DBOS_DeleteConfigSchema_FullMethodName      = "/dbos.DBOS/DeleteConfigSchema"
+	DBOS_ValidateConfig_FullMethodName          = "/dbos.DBOS/ValidateConfig"
+	DBOS_SetModuleState_FullMethodName          = "/dbos.DBOS/SetModuleState"
+	DBOS_GetModuleState_FullMethodName          = "/dbos.DBOS/GetModuleState"
+	DBOS_ListModuleStates_FullMethodName        = "/dbos.DBOS/ListModuleStates"
+	DBOS_WatchModuleStates_FullMethodName       = "/dbos.DBOS/WatchModuleStates"
+	DBOS_StoreResult_FullMethodName             = "/dbos.DBOS/StoreResult"
+	DBOS_StoreResults_FullMethodName            = "/dbos.DBOS/StoreResults"
+	DBOS_StreamResults_FullMethodName           = "/dbos.DBOS/StreamResults"
+	DBOS_GetResult_FullMethodName               = "/dbos.DBOS/GetResult"
+	DBOS_ListResults_FullMethodName             = "/dbos.DBOS/ListResults"
+	DBOS_GetCorrelatedResults_FullMethodName    = "/dbos.DBOS/GetCorrelatedResults"
+	DBOS_CountResults_FullMethodName            = "/dbos.DBOS/CountResults"
+	DBOS_HasResult_FullMethodName               = "/dbos.DBOS/HasResult"
+	DBOS_ExportResults_FullMethodName           = "/dbos.DBOS/ExportResults"
+	DBOS_ProfileResults_FullMethodName          = "/dbos.DBOS/ProfileResults"
+	DBOS_GetIngestGaps_FullMethodName           = "/dbos.DBOS/GetIngestGaps"
+	DBOS_GetClockSkew_FullMethodName            = "/dbos.DBOS/GetClockSkew"
+	DBOS_ListAlerts_FullMethodName              = "/dbos.DBOS/ListAlerts"
+	DBOS_GetIncident_FullMethodName             = "/dbos.DBOS/GetIncident"
+	DBOS_ListIncidents_FullMethodName           = "/dbos.DBOS/ListIncidents"
+	DBOS_CreateIncident_FullMethodName          = "/dbos.DBOS/CreateIncident"
+	DBOS_UpdateIncident_FullMethodName          = "/dbos.DBOS/UpdateIncident"
+	DBOS_AcknowledgeIncident_FullMethodName     = "/dbos.DBOS/AcknowledgeIncident"
+	DBOS_ResolveIncident_FullMethodName         = "/dbos.DBOS/ResolveIncident"
+	DBOS_AddIncidentComment_FullMethodName      = "/dbos.DBOS/AddIncidentComment"
+	DBOS_DeleteIncident_FullMethodName          = "/dbos.DBOS/DeleteIncident"
+	DBOS_WatchIncidents_FullMethodName          = "/dbos.DBOS/WatchIncidents"
+	DBOS_ListRoutingEvents_FullMethodName       = "/dbos.DBOS/ListRoutingEvents"
+	DBOS_ScheduleTask_FullMethodName            = "/dbos.DBOS/ScheduleTask"
+	DBOS_GetTask_FullMethodName                 = "/dbos.DBOS/GetTask"
+	DBOS_ListTasks_FullMethodName               = "/dbos.DBOS/ListTasks"
+	DBOS_ListDueTasks_FullMethodName            = "/dbos.DBOS/ListDueTasks"
+	DBOS_ListPendingTasks_FullMethodName        = "/dbos.DBOS/ListPendingTasks"
+	DBOS_CancelTask_FullMethodName              = "/dbos.DBOS/CancelTask"
+	DBOS_LeaseTask_FullMethodName               = "/dbos.DBOS/LeaseTask"
+	DBOS_AckTasks_FullMethodName                = "/dbos.DBOS/AckTasks"
+	DBOS_NackTasks_FullMethodName               = "/dbos.DBOS/NackTasks"
+	DBOS_ExtendTaskVisibility_FullMethodName    = "/dbos.DBOS/ExtendTaskVisibility"
+	DBOS_ReportTaskProgress_FullMethodName      = "/dbos.DBOS/ReportTaskProgress"
+	DBOS_StreamTasks_FullMethodName             = "/dbos.DBOS/StreamTasks"
+	DBOS_ScheduleVerifiedTask_FullMethodName    = "/dbos.DBOS/ScheduleVerifiedTask"
+	DBOS_GetVerification_FullMethodName         = "/dbos.DBOS/GetVerification"
+	DBOS_BroadcastTask_FullMethodName           = "/dbos.DBOS/BroadcastTask"
+	DBOS_GetBroadcastStatus_FullMethodName      = "/dbos.DBOS/GetBroadcastStatus"
+	DBOS_CreateView_FullMethodName              = "/dbos.DBOS/CreateView"
+	DBOS_ListViews_FullMethodName               = "/dbos.DBOS/ListViews"
+	DBOS_DeleteView_FullMethodName              = "/dbos.DBOS/DeleteView"
+	DBOS_QueryView_FullMethodName               = "/dbos.DBOS/QueryView"
+	DBOS_CreateExtractionRule_FullMethodName    = "/dbos.DBOS/CreateExtractionRule"
+	DBOS_ListExtractionRules_FullMethodName     = "/dbos.DBOS/ListExtractionRules"
+	DBOS_DeleteExtractionRule_FullMethodName    = "/dbos.DBOS/DeleteExtractionRule"
+	DBOS_QueryResults_FullMethodName            = "/dbos.DBOS/QueryResults"
+	DBOS_CreateSavedQuery_FullMethodName        = "/dbos.DBOS/CreateSavedQuery"
+	DBOS_GetSavedQuery_FullMethodName           = "/dbos.DBOS/GetSavedQuery"
+	DBOS_ListSavedQueries_FullMethodName        = "/dbos.DBOS/ListSavedQueries"
+	DBOS_UpdateSavedQuery_FullMethodName        = "/dbos.DBOS/UpdateSavedQuery"
+	DBOS_DeleteSavedQuery_FullMethodName        = "/dbos.DBOS/DeleteSavedQuery"
+	DBOS_ExecuteSavedQuery_FullMethodName       = "/dbos.DBOS/ExecuteSavedQuery"
+	DBOS_CreateAlertRule_FullMethodName         = "/dbos.DBOS/CreateAlertRule"
+	DBOS_ListAlertRules_FullMethodName          = "/dbos.DBOS/ListAlertRules"
+	DBOS_DeleteAlertRule_FullMethodName         = "/dbos.DBOS/DeleteAlertRule"
+	DBOS_CreateMaintenanceWindow_FullMethodName = "/dbos.DBOS/CreateMaintenanceWindow"
+	DBOS_GetMaintenanceWindow_FullMethodName    = "/dbos.DBOS/GetMaintenanceWindow"
+	DBOS_ListMaintenanceWindows_FullMethodName  = "/dbos.DBOS/ListMaintenanceWindows"
+	DBOS_UpdateMaintenanceWindow_FullMethodName = "/dbos.DBOS/UpdateMaintenanceWindow"
+	DBOS_DeleteMaintenanceWindow_FullMethodName = "/dbos.DBOS/DeleteMaintenanceWindow"
+	DBOS_GetTrends_FullMethodName               = "/dbos.DBOS/GetTrends"
+	DBOS_CreateCampaign_FullMethodName          = "/dbos.DBOS/CreateCampaign"
+	DBOS_GetCampaign_FullMethodName             = "/dbos.DBOS/GetCampaign"
+	DBOS_ListCampaigns_FullMethodName           = "/dbos.DBOS/ListCampaigns"
+	DBOS_StopCampaign_FullMethodName            = "/dbos.DBOS/StopCampaign"
+	DBOS_ListCampaignResults_FullMethodName     = "/dbos.DBOS/ListCampaignResults"
+	DBOS_ApplySpec_FullMethodName               = "/dbos.DBOS/ApplySpec"
+	DBOS_GetDrift_FullMethodName                = "/dbos.DBOS/GetDrift"
+	DBOS_PlanCapacity_FullMethodName            = "/dbos.DBOS/PlanCapacity"
+	DBOS_GetExecutionStats_FullMethodName       = "/dbos.DBOS/GetExecutionStats"
+	DBOS_GetUsage_FullMethodName                = "/dbos.DBOS/GetUsage"
+	DBOS_GetEvents_FullMethodName               = "/dbos.DBOS/GetEvents"
+	DBOS_StreamEvents_FullMethodName            = "/dbos.DBOS/StreamEvents"
+	DBOS_ListStateEvents_FullMethodName         = "/dbos.DBOS/ListStateEvents"
+	DBOS_RebuildState_FullMethodName            = "/dbos.DBOS/RebuildState"
+	DBOS_ExportSnapshot_FullMethodName          = "/dbos.DBOS/ExportSnapshot"
 )
 
 // DBOSClient is the client API for DBOS service.
@@ -41,20 +132,118 @@ const (
 type DBOSClient interface {
 	// Agent Management
 	RegisterAgent(ctx context.Context, in *RegisterAgentRequest, opts ...grpc.CallOption) (*RegisterAgentResponse, error)
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
 	GetAgent(ctx context.Context, in *GetAgentRequest, opts ...grpc.CallOption) (*GetAgentResponse, error)
 	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	DeleteAgent(ctx context.Context, in *DeleteAgentRequest, opts ...grpc.CallOption) (*DeleteAgentResponse, error)
+	WatchAgents(ctx context.Context, in *WatchAgentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentDelta], error)
+	GetAgentGeoJSON(ctx context.Context, in *GetAgentGeoJSONRequest, opts ...grpc.CallOption) (*GetAgentGeoJSONResponse, error)
+	// Provisioning
+	CreateBootstrapToken(ctx context.Context, in *CreateBootstrapTokenRequest, opts ...grpc.CallOption) (*CreateBootstrapTokenResponse, error)
+	EnrollAgent(ctx context.Context, in *EnrollAgentRequest, opts ...grpc.CallOption) (*EnrollAgentResponse, error)
+	CreateAgentToken(ctx context.Context, in *CreateAgentTokenRequest, opts ...grpc.CallOption) (*CreateAgentTokenResponse, error)
+	CreateAPIToken(ctx context.Context, in *CreateAPITokenRequest, opts ...grpc.CallOption) (*CreateAPITokenResponse, error)
+	// Software Distribution
+	PublishArtifact(ctx context.Context, in *PublishArtifactRequest, opts ...grpc.CallOption) (*PublishArtifactResponse, error)
+	UnpublishArtifact(ctx context.Context, in *UnpublishArtifactRequest, opts ...grpc.CallOption) (*UnpublishArtifactResponse, error)
+	GetManifest(ctx context.Context, in *GetManifestRequest, opts ...grpc.CallOption) (*GetManifestResponse, error)
+	// Config Rollouts
+	StartConfigRollout(ctx context.Context, in *StartConfigRolloutRequest, opts ...grpc.CallOption) (*StartConfigRolloutResponse, error)
+	GetConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...grpc.CallOption) (*GetConfigRolloutResponse, error)
+	RollbackConfigRollout(ctx context.Context, in *RollbackConfigRolloutRequest, opts ...grpc.CallOption) (*RollbackConfigRolloutResponse, error)
+	GetAgentConfig(ctx context.Context, in *GetAgentConfigRequest, opts ...grpc.CallOption) (*GetAgentConfigResponse, error)
+	SetConfigSchema(ctx context.Context, in *SetConfigSchemaRequest, opts ...grpc.CallOption) (*SetConfigSchemaResponse, error)
+	ListConfigSchemas(ctx context.Context, in *ListConfigSchemasRequest, opts ...grpc.CallOption) (*ListConfigSchemasResponse, error)
+	DeleteConfigSchema(ctx context.Context, in *DeleteConfigSchemaRequest, opts ...grpc.CallOption) (*DeleteConfigSchemaResponse, error)
+	ValidateConfig(ctx context.Context, in *ValidateConfigRequest, opts ...grpc.CallOption) (*ValidateConfigResponse, error)
 	// Module State Management
 	SetModuleState(ctx context.Context, in *SetModuleStateRequest, opts ...grpc.CallOption) (*SetModuleStateResponse, error)
 	GetModuleState(ctx context.Context, in *GetModuleStateRequest, opts ...grpc.CallOption) (*GetModuleStateResponse, error)
 	ListModuleStates(ctx context.Context, in *ListModuleStatesRequest, opts ...grpc.CallOption) (*ListModuleStatesResponse, error)
+	WatchModuleStates(ctx context.Context, in *WatchModuleStatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ModuleState], error)
 	// Measurement Results
 	StoreResult(ctx context.Context, in *StoreResultRequest, opts ...grpc.CallOption) (*StoreResultResponse, error)
+	StoreResults(ctx context.Context, in *StoreResultsRequest, opts ...grpc.CallOption) (*StoreResultsResponse, error)
+	StreamResults(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StoreResultRequest, StreamResultsResponse], error)
 	GetResult(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*GetResultResponse, error)
 	ListResults(ctx context.Context, in *ListResultsRequest, opts ...grpc.CallOption) (*ListResultsResponse, error)
+	GetCorrelatedResults(ctx context.Context, in *GetCorrelatedResultsRequest, opts ...grpc.CallOption) (*GetCorrelatedResultsResponse, error)
+	CountResults(ctx context.Context, in *CountResultsRequest, opts ...grpc.CallOption) (*CountResultsResponse, error)
+	HasResult(ctx context.Context, in *HasResultRequest, opts ...grpc.CallOption) (*HasResultResponse, error)
+	ExportResults(ctx context.Context, in *ExportResultsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportResultsChunk], error)
+	ProfileResults(ctx context.Context, in *ProfileResultsRequest, opts ...grpc.CallOption) (*ProfileResultsResponse, error)
+	GetIngestGaps(ctx context.Context, in *GetIngestGapsRequest, opts ...grpc.CallOption) (*GetIngestGapsResponse, error)
+	GetClockSkew(ctx context.Context, in *GetClockSkewRequest, opts ...grpc.CallOption) (*GetClockSkewResponse, error)
+	ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error)
+	GetIncident(ctx context.Context, in *GetIncidentRequest, opts ...grpc.CallOption) (*GetIncidentResponse, error)
+	ListIncidents(ctx context.Context, in *ListIncidentsRequest, opts ...grpc.CallOption) (*ListIncidentsResponse, error)
+	CreateIncident(ctx context.Context, in *CreateIncidentRequest, opts ...grpc.CallOption) (*CreateIncidentResponse, error)
+	UpdateIncident(ctx context.Context, in *UpdateIncidentRequest, opts ...grpc.CallOption) (*UpdateIncidentResponse, error)
+	AcknowledgeIncident(ctx context.Context, in *AcknowledgeIncidentRequest, opts ...grpc.CallOption) (*AcknowledgeIncidentResponse, error)
+	ResolveIncident(ctx context.Context, in *ResolveIncidentRequest, opts ...grpc.CallOption) (*ResolveIncidentResponse, error)
+	AddIncidentComment(ctx context.Context, in *AddIncidentCommentRequest, opts ...grpc.CallOption) (*AddIncidentCommentResponse, error)
+	DeleteIncident(ctx context.Context, in *DeleteIncidentRequest, opts ...grpc.CallOption) (*DeleteIncidentResponse, error)
+	WatchIncidents(ctx context.Context, in *WatchIncidentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[IncidentEvent], error)
+	ListRoutingEvents(ctx context.Context, in *ListRoutingEventsRequest, opts ...grpc.CallOption) (*ListRoutingEventsResponse, error)
 	// Task Scheduling
 	ScheduleTask(ctx context.Context, in *ScheduleTaskRequest, opts ...grpc.CallOption) (*ScheduleTaskResponse, error)
 	GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error)
+	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
 	ListDueTasks(ctx context.Context, in *ListDueTasksRequest, opts ...grpc.CallOption) (*ListDueTasksResponse, error)
+	ListPendingTasks(ctx context.Context, in *ListPendingTasksRequest, opts ...grpc.CallOption) (*ListPendingTasksResponse, error)
+	CancelTask(ctx context.Context, in *CancelTaskRequest, opts ...grpc.CallOption) (*CancelTaskResponse, error)
+	LeaseTask(ctx context.Context, in *LeaseTaskRequest, opts ...grpc.CallOption) (*LeaseTaskResponse, error)
+	AckTasks(ctx context.Context, in *AckTasksRequest, opts ...grpc.CallOption) (*AckTasksResponse, error)
+	NackTasks(ctx context.Context, in *NackTasksRequest, opts ...grpc.CallOption) (*NackTasksResponse, error)
+	ExtendTaskVisibility(ctx context.Context, in *ExtendTaskVisibilityRequest, opts ...grpc.CallOption) (*ExtendTaskVisibilityResponse, error)
+	ReportTaskProgress(ctx context.Context, in *ReportTaskProgressRequest, opts ...grpc.CallOption) (*ReportTaskProgressResponse, error)
+	StreamTasks(ctx context.Context, in *StreamTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Task], error)
+	ScheduleVerifiedTask(ctx context.Context, in *ScheduleVerifiedTaskRequest, opts ...grpc.CallOption) (*ScheduleVerifiedTaskResponse, error)
+	GetVerification(ctx context.Context, in *GetVerificationRequest, opts ...grpc.CallOption) (*GetVerificationResponse, error)
+	BroadcastTask(ctx context.Context, in *BroadcastTaskRequest, opts ...grpc.CallOption) (*BroadcastTaskResponse, error)
+	GetBroadcastStatus(ctx context.Context, in *GetBroadcastStatusRequest, opts ...grpc.CallOption) (*GetBroadcastStatusResponse, error)
+	CreateView(ctx context.Context, in *CreateViewRequest, opts ...grpc.CallOption) (*CreateViewResponse, error)
+	ListViews(ctx context.Context, in *ListViewsRequest, opts ...grpc.CallOption) (*ListViewsResponse, error)
+	DeleteView(ctx context.Context, in *DeleteViewRequest, opts ...grpc.CallOption) (*DeleteViewResponse, error)
+	QueryView(ctx context.Context, in *QueryViewRequest, opts ...grpc.CallOption) (*QueryViewResponse, error)
+	CreateExtractionRule(ctx context.Context, in *CreateExtractionRuleRequest, opts ...grpc.CallOption) (*CreateExtractionRuleResponse, error)
+	ListExtractionRules(ctx context.Context, in *ListExtractionRulesRequest, opts ...grpc.CallOption) (*ListExtractionRulesResponse, error)
+	DeleteExtractionRule(ctx context.Context, in *DeleteExtractionRuleRequest, opts ...grpc.CallOption) (*DeleteExtractionRuleResponse, error)
+	QueryResults(ctx context.Context, in *QueryResultsRequest, opts ...grpc.CallOption) (*QueryResultsResponse, error)
+	CreateSavedQuery(ctx context.Context, in *CreateSavedQueryRequest, opts ...grpc.CallOption) (*CreateSavedQueryResponse, error)
+	GetSavedQuery(ctx context.Context, in *GetSavedQueryRequest, opts ...grpc.CallOption) (*GetSavedQueryResponse, error)
+	ListSavedQueries(ctx context.Context, in *ListSavedQueriesRequest, opts ...grpc.CallOption) (*ListSavedQueriesResponse, error)
+	UpdateSavedQuery(ctx context.Context, in *UpdateSavedQueryRequest, opts ...grpc.CallOption) (*UpdateSavedQueryResponse, error)
+	DeleteSavedQuery(ctx context.Context, in *DeleteSavedQueryRequest, opts ...grpc.CallOption) (*DeleteSavedQueryResponse, error)
+	ExecuteSavedQuery(ctx context.Context, in *ExecuteSavedQueryRequest, opts ...grpc.CallOption) (*ExecuteSavedQueryResponse, error)
+	CreateAlertRule(ctx context.Context, in *CreateAlertRuleRequest, opts ...grpc.CallOption) (*CreateAlertRuleResponse, error)
+	ListAlertRules(ctx context.Context, in *ListAlertRulesRequest, opts ...grpc.CallOption) (*ListAlertRulesResponse, error)
+	DeleteAlertRule(ctx context.Context, in *DeleteAlertRuleRequest, opts ...grpc.CallOption) (*DeleteAlertRuleResponse, error)
+	CreateMaintenanceWindow(ctx context.Context, in *CreateMaintenanceWindowRequest, opts ...grpc.CallOption) (*CreateMaintenanceWindowResponse, error)
+	GetMaintenanceWindow(ctx context.Context, in *GetMaintenanceWindowRequest, opts ...grpc.CallOption) (*GetMaintenanceWindowResponse, error)
+	ListMaintenanceWindows(ctx context.Context, in *ListMaintenanceWindowsRequest, opts ...grpc.CallOption) (*ListMaintenanceWindowsResponse, error)
+	UpdateMaintenanceWindow(ctx context.Context, in *UpdateMaintenanceWindowRequest, opts ...grpc.CallOption) (*UpdateMaintenanceWindowResponse, error)
+	DeleteMaintenanceWindow(ctx context.Context, in *DeleteMaintenanceWindowRequest, opts ...grpc.CallOption) (*DeleteMaintenanceWindowResponse, error)
+	GetTrends(ctx context.Context, in *GetTrendsRequest, opts ...grpc.CallOption) (*GetTrendsResponse, error)
+	// Measurement Campaigns
+	CreateCampaign(ctx context.Context, in *CreateCampaignRequest, opts ...grpc.CallOption) (*CreateCampaignResponse, error)
+	GetCampaign(ctx context.Context, in *GetCampaignRequest, opts ...grpc.CallOption) (*GetCampaignResponse, error)
+	ListCampaigns(ctx context.Context, in *ListCampaignsRequest, opts ...grpc.CallOption) (*ListCampaignsResponse, error)
+	StopCampaign(ctx context.Context, in *StopCampaignRequest, opts ...grpc.CallOption) (*StopCampaignResponse, error)
+	ListCampaignResults(ctx context.Context, in *ListCampaignResultsRequest, opts ...grpc.CallOption) (*ListCampaignResultsResponse, error)
+	ApplySpec(ctx context.Context, in *ApplySpecRequest, opts ...grpc.CallOption) (*ApplySpecResponse, error)
+	GetDrift(ctx context.Context, in *GetDriftRequest, opts ...grpc.CallOption) (*GetDriftResponse, error)
+	PlanCapacity(ctx context.Context, in *PlanCapacityRequest, opts ...grpc.CallOption) (*PlanCapacityResponse, error)
+	GetExecutionStats(ctx context.Context, in *GetExecutionStatsRequest, opts ...grpc.CallOption) (*GetExecutionStatsResponse, error)
+	// API Usage
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
+	// Event Log
+	GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error)
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// Event Sourcing
+	ListStateEvents(ctx context.Context, in *ListStateEventsRequest, opts ...grpc.CallOption) (*ListStateEventsResponse, error)
+	RebuildState(ctx context.Context, in *RebuildStateRequest, opts ...grpc.CallOption) (*RebuildStateResponse, error)
+	ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SnapshotRecord], error)
 }
 
 type dBOSClient struct {
@@ -75,6 +264,16 @@ func (c *dBOSClient) RegisterAgent(ctx context.Context, in *RegisterAgentRequest
 	return out, nil
 }
 
+func (c *dBOSClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, DBOS_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBOSClient) GetAgent(ctx context.Context, in *GetAgentRequest, opts ...grpc.CallOption) (*GetAgentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetAgentResponse)
@@ -95,6 +294,195 @@ func (c *dBOSClient) ListAgents(ctx context.Context, in *ListAgentsRequest, opts
 	return out, nil
 }
 
+func (c *dBOSClient) DeleteAgent(ctx context.Context, in *DeleteAgentRequest, opts ...grpc.CallOption) (*DeleteAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAgentResponse)
+	err := c.cc.Invoke(ctx, DBOS_DeleteAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) WatchAgents(ctx context.Context, in *WatchAgentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AgentDelta], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DBOS_ServiceDesc.Streams[0], DBOS_WatchAgents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAgentsRequest, AgentDelta]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_WatchAgentsClient = grpc.ServerStreamingClient[AgentDelta]
+
+func (c *dBOSClient) GetAgentGeoJSON(ctx context.Context, in *GetAgentGeoJSONRequest, opts ...grpc.CallOption) (*GetAgentGeoJSONResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAgentGeoJSONResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetAgentGeoJSON_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CreateBootstrapToken(ctx context.Context, in *CreateBootstrapTokenRequest, opts ...grpc.CallOption) (*CreateBootstrapTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBootstrapTokenResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateBootstrapToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) EnrollAgent(ctx context.Context, in *EnrollAgentRequest, opts ...grpc.CallOption) (*EnrollAgentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollAgentResponse)
+	err := c.cc.Invoke(ctx, DBOS_EnrollAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CreateAgentToken(ctx context.Context, in *CreateAgentTokenRequest, opts ...grpc.CallOption) (*CreateAgentTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAgentTokenResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateAgentToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CreateAPIToken(ctx context.Context, in *CreateAPITokenRequest, opts ...grpc.CallOption) (*CreateAPITokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAPITokenResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateAPIToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) PublishArtifact(ctx context.Context, in *PublishArtifactRequest, opts ...grpc.CallOption) (*PublishArtifactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishArtifactResponse)
+	err := c.cc.Invoke(ctx, DBOS_PublishArtifact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) UnpublishArtifact(ctx context.Context, in *UnpublishArtifactRequest, opts ...grpc.CallOption) (*UnpublishArtifactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnpublishArtifactResponse)
+	err := c.cc.Invoke(ctx, DBOS_UnpublishArtifact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetManifest(ctx context.Context, in *GetManifestRequest, opts ...grpc.CallOption) (*GetManifestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetManifestResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetManifest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) StartConfigRollout(ctx context.Context, in *StartConfigRolloutRequest, opts ...grpc.CallOption) (*StartConfigRolloutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartConfigRolloutResponse)
+	err := c.cc.Invoke(ctx, DBOS_StartConfigRollout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetConfigRollout(ctx context.Context, in *GetConfigRolloutRequest, opts ...grpc.CallOption) (*GetConfigRolloutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigRolloutResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetConfigRollout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) RollbackConfigRollout(ctx context.Context, in *RollbackConfigRolloutRequest, opts ...grpc.CallOption) (*RollbackConfigRolloutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackConfigRolloutResponse)
+	err := c.cc.Invoke(ctx, DBOS_RollbackConfigRollout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetAgentConfig(ctx context.Context, in *GetAgentConfigRequest, opts ...grpc.CallOption) (*GetAgentConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAgentConfigResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetAgentConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) SetConfigSchema(ctx context.Context, in *SetConfigSchemaRequest, opts ...grpc.CallOption) (*SetConfigSchemaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetConfigSchemaResponse)
+	err := c.cc.Invoke(ctx, DBOS_SetConfigSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListConfigSchemas(ctx context.Context, in *ListConfigSchemasRequest, opts ...grpc.CallOption) (*ListConfigSchemasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConfigSchemasResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListConfigSchemas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) DeleteConfigSchema(ctx context.Context, in *DeleteConfigSchemaRequest, opts ...grpc.CallOption) (*DeleteConfigSchemaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteConfigSchemaResponse)
+	err := c.cc.Invoke(ctx, DBOS_DeleteConfigSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ValidateConfig(ctx context.Context, in *ValidateConfigRequest, opts ...grpc.CallOption) (*ValidateConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateConfigResponse)
+	err := c.cc.Invoke(ctx, DBOS_ValidateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBOSClient) SetModuleState(ctx context.Context, in *SetModuleStateRequest, opts ...grpc.CallOption) (*SetModuleStateResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SetModuleStateResponse)
@@ -125,6 +513,25 @@ func (c *dBOSClient) ListModuleStates(ctx context.Context, in *ListModuleStatesR
 	return out, nil
 }
 
+func (c *dBOSClient) WatchModuleStates(ctx context.Context, in *WatchModuleStatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ModuleState], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DBOS_ServiceDesc.Streams[1], DBOS_WatchModuleStates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchModuleStatesRequest, ModuleState]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_WatchModuleStatesClient = grpc.ServerStreamingClient[ModuleState]
+
 func (c *dBOSClient) StoreResult(ctx context.Context, in *StoreResultRequest, opts ...grpc.CallOption) (*StoreResultResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StoreResultResponse)
@@ -135,6 +542,29 @@ func (c *dBOSClient) StoreResult(ctx context.Context, in *StoreResultRequest, op
 	return out, nil
 }
 
+func (c *dBOSClient) StoreResults(ctx context.Context, in *StoreResultsRequest, opts ...grpc.CallOption) (*StoreResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreResultsResponse)
+	err := c.cc.Invoke(ctx, DBOS_StoreResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) StreamResults(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StoreResultRequest, StreamResultsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DBOS_ServiceDesc.Streams[2], DBOS_StreamResults_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StoreResultRequest, StreamResultsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_StreamResultsClient = grpc.ClientStreamingClient[StoreResultRequest, StreamResultsResponse]
+
 func (c *dBOSClient) GetResult(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*GetResultResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetResultResponse)
@@ -155,341 +585,3025 @@ func (c *dBOSClient) ListResults(ctx context.Context, in *ListResultsRequest, op
 	return out, nil
 }
 
-func (c *dBOSClient) ScheduleTask(ctx context.Context, in *ScheduleTaskRequest, opts ...grpc.CallOption) (*ScheduleTaskResponse, error) {
+func (c *dBOSClient) GetCorrelatedResults(ctx context.Context, in *GetCorrelatedResultsRequest, opts ...grpc.CallOption) (*GetCorrelatedResultsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ScheduleTaskResponse)
-	err := c.cc.Invoke(ctx, DBOS_ScheduleTask_FullMethodName, in, out, cOpts...)
+	out := new(GetCorrelatedResultsResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetCorrelatedResults_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dBOSClient) GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error) {
+func (c *dBOSClient) CountResults(ctx context.Context, in *CountResultsRequest, opts ...grpc.CallOption) (*CountResultsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetTaskResponse)
-	err := c.cc.Invoke(ctx, DBOS_GetTask_FullMethodName, in, out, cOpts...)
+	out := new(CountResultsResponse)
+	err := c.cc.Invoke(ctx, DBOS_CountResults_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dBOSClient) ListDueTasks(ctx context.Context, in *ListDueTasksRequest, opts ...grpc.CallOption) (*ListDueTasksResponse, error) {
+func (c *dBOSClient) HasResult(ctx context.Context, in *HasResultRequest, opts ...grpc.CallOption) (*HasResultResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListDueTasksResponse)
-	err := c.cc.Invoke(ctx, DBOS_ListDueTasks_FullMethodName, in, out, cOpts...)
+	out := new(HasResultResponse)
+	err := c.cc.Invoke(ctx, DBOS_HasResult_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// DBOSServer is the server API for DBOS service.
-// All implementations must embed UnimplementedDBOSServer
-// for forward compatibility.
-//
-// DBOS Service Definition
-type DBOSServer interface {
-	// Agent Management
-	RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error)
-	GetAgent(context.Context, *GetAgentRequest) (*GetAgentResponse, error)
-	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
-	// Module State Management
-	SetModuleState(context.Context, *SetModuleStateRequest) (*SetModuleStateResponse, error)
-	GetModuleState(context.Context, *GetModuleStateRequest) (*GetModuleStateResponse, error)
-	ListModuleStates(context.Context, *ListModuleStatesRequest) (*ListModuleStatesResponse, error)
-	// Measurement Results
-	StoreResult(context.Context, *StoreResultRequest) (*StoreResultResponse, error)
-	GetResult(context.Context, *GetResultRequest) (*GetResultResponse, error)
-	ListResults(context.Context, *ListResultsRequest) (*ListResultsResponse, error)
-	// Task Scheduling
-	ScheduleTask(context.Context, *ScheduleTaskRequest) (*ScheduleTaskResponse, error)
-	GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error)
-	ListDueTasks(context.Context, *ListDueTasksRequest) (*ListDueTasksResponse, error)
-	mustEmbedUnimplementedDBOSServer()
+func (c *dBOSClient) ExportResults(ctx context.Context, in *ExportResultsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportResultsChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DBOS_ServiceDesc.Streams[3], DBOS_ExportResults_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportResultsRequest, ExportResultsChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-// UnimplementedDBOSServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedDBOSServer struct{}
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_ExportResultsClient = grpc.ServerStreamingClient[ExportResultsChunk]
 
-func (UnimplementedDBOSServer) RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RegisterAgent not implemented")
+func (c *dBOSClient) ProfileResults(ctx context.Context, in *ProfileResultsRequest, opts ...grpc.CallOption) (*ProfileResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProfileResultsResponse)
+	err := c.cc.Invoke(ctx, DBOS_ProfileResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBOSServer) GetAgent(context.Context, *GetAgentRequest) (*GetAgentResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAgent not implemented")
+
+func (c *dBOSClient) GetIngestGaps(ctx context.Context, in *GetIngestGapsRequest, opts ...grpc.CallOption) (*GetIngestGapsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetIngestGapsResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetIngestGaps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBOSServer) ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
+
+func (c *dBOSClient) GetClockSkew(ctx context.Context, in *GetClockSkewRequest, opts ...grpc.CallOption) (*GetClockSkewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetClockSkewResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetClockSkew_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBOSServer) SetModuleState(context.Context, *SetModuleStateRequest) (*SetModuleStateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetModuleState not implemented")
+
+func (c *dBOSClient) ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertsResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBOSServer) GetModuleState(context.Context, *GetModuleStateRequest) (*GetModuleStateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetModuleState not implemented")
+
+func (c *dBOSClient) GetIncident(ctx context.Context, in *GetIncidentRequest, opts ...grpc.CallOption) (*GetIncidentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetIncidentResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBOSServer) ListModuleStates(context.Context, *ListModuleStatesRequest) (*ListModuleStatesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListModuleStates not implemented")
+
+func (c *dBOSClient) ListIncidents(ctx context.Context, in *ListIncidentsRequest, opts ...grpc.CallOption) (*ListIncidentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncidentsResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListIncidents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBOSServer) StoreResult(context.Context, *StoreResultRequest) (*StoreResultResponse, error) {
+
+func (c *dBOSClient) CreateIncident(ctx context.Context, in *CreateIncidentRequest, opts ...grpc.CallOption) (*CreateIncidentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateIncidentResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) UpdateIncident(ctx context.Context, in *UpdateIncidentRequest, opts ...grpc.CallOption) (*UpdateIncidentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateIncidentResponse)
+	err := c.cc.Invoke(ctx, DBOS_UpdateIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) AcknowledgeIncident(ctx context.Context, in *AcknowledgeIncidentRequest, opts ...grpc.CallOption) (*AcknowledgeIncidentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcknowledgeIncidentResponse)
+	err := c.cc.Invoke(ctx, DBOS_AcknowledgeIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ResolveIncident(ctx context.Context, in *ResolveIncidentRequest, opts ...grpc.CallOption) (*ResolveIncidentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveIncidentResponse)
+	err := c.cc.Invoke(ctx, DBOS_ResolveIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) AddIncidentComment(ctx context.Context, in *AddIncidentCommentRequest, opts ...grpc.CallOption) (*AddIncidentCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddIncidentCommentResponse)
+	err := c.cc.Invoke(ctx, DBOS_AddIncidentComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) DeleteIncident(ctx context.Context, in *DeleteIncidentRequest, opts ...grpc.CallOption) (*DeleteIncidentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteIncidentResponse)
+	err := c.cc.Invoke(ctx, DBOS_DeleteIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) WatchIncidents(ctx context.Context, in *WatchIncidentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[IncidentEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DBOS_ServiceDesc.Streams[4], DBOS_WatchIncidents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchIncidentsRequest, IncidentEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_WatchIncidentsClient = grpc.ServerStreamingClient[IncidentEvent]
+
+func (c *dBOSClient) ListRoutingEvents(ctx context.Context, in *ListRoutingEventsRequest, opts ...grpc.CallOption) (*ListRoutingEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoutingEventsResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListRoutingEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ScheduleTask(ctx context.Context, in *ScheduleTaskRequest, opts ...grpc.CallOption) (*ScheduleTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleTaskResponse)
+	err := c.cc.Invoke(ctx, DBOS_ScheduleTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListDueTasks(ctx context.Context, in *ListDueTasksRequest, opts ...grpc.CallOption) (*ListDueTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDueTasksResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListDueTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListPendingTasks(ctx context.Context, in *ListPendingTasksRequest, opts ...grpc.CallOption) (*ListPendingTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingTasksResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListPendingTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CancelTask(ctx context.Context, in *CancelTaskRequest, opts ...grpc.CallOption) (*CancelTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelTaskResponse)
+	err := c.cc.Invoke(ctx, DBOS_CancelTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) LeaseTask(ctx context.Context, in *LeaseTaskRequest, opts ...grpc.CallOption) (*LeaseTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseTaskResponse)
+	err := c.cc.Invoke(ctx, DBOS_LeaseTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) AckTasks(ctx context.Context, in *AckTasksRequest, opts ...grpc.CallOption) (*AckTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AckTasksResponse)
+	err := c.cc.Invoke(ctx, DBOS_AckTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) NackTasks(ctx context.Context, in *NackTasksRequest, opts ...grpc.CallOption) (*NackTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NackTasksResponse)
+	err := c.cc.Invoke(ctx, DBOS_NackTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ExtendTaskVisibility(ctx context.Context, in *ExtendTaskVisibilityRequest, opts ...grpc.CallOption) (*ExtendTaskVisibilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtendTaskVisibilityResponse)
+	err := c.cc.Invoke(ctx, DBOS_ExtendTaskVisibility_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ReportTaskProgress(ctx context.Context, in *ReportTaskProgressRequest, opts ...grpc.CallOption) (*ReportTaskProgressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportTaskProgressResponse)
+	err := c.cc.Invoke(ctx, DBOS_ReportTaskProgress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) StreamTasks(ctx context.Context, in *StreamTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Task], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DBOS_ServiceDesc.Streams[5], DBOS_StreamTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTasksRequest, Task]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_StreamTasksClient = grpc.ServerStreamingClient[Task]
+
+func (c *dBOSClient) ScheduleVerifiedTask(ctx context.Context, in *ScheduleVerifiedTaskRequest, opts ...grpc.CallOption) (*ScheduleVerifiedTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleVerifiedTaskResponse)
+	err := c.cc.Invoke(ctx, DBOS_ScheduleVerifiedTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetVerification(ctx context.Context, in *GetVerificationRequest, opts ...grpc.CallOption) (*GetVerificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVerificationResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) BroadcastTask(ctx context.Context, in *BroadcastTaskRequest, opts ...grpc.CallOption) (*BroadcastTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastTaskResponse)
+	err := c.cc.Invoke(ctx, DBOS_BroadcastTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetBroadcastStatus(ctx context.Context, in *GetBroadcastStatusRequest, opts ...grpc.CallOption) (*GetBroadcastStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBroadcastStatusResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetBroadcastStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CreateView(ctx context.Context, in *CreateViewRequest, opts ...grpc.CallOption) (*CreateViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateViewResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListViews(ctx context.Context, in *ListViewsRequest, opts ...grpc.CallOption) (*ListViewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListViewsResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListViews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) DeleteView(ctx context.Context, in *DeleteViewRequest, opts ...grpc.CallOption) (*DeleteViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteViewResponse)
+	err := c.cc.Invoke(ctx, DBOS_DeleteView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) QueryView(ctx context.Context, in *QueryViewRequest, opts ...grpc.CallOption) (*QueryViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryViewResponse)
+	err := c.cc.Invoke(ctx, DBOS_QueryView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CreateExtractionRule(ctx context.Context, in *CreateExtractionRuleRequest, opts ...grpc.CallOption) (*CreateExtractionRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateExtractionRuleResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateExtractionRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListExtractionRules(ctx context.Context, in *ListExtractionRulesRequest, opts ...grpc.CallOption) (*ListExtractionRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExtractionRulesResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListExtractionRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) DeleteExtractionRule(ctx context.Context, in *DeleteExtractionRuleRequest, opts ...grpc.CallOption) (*DeleteExtractionRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteExtractionRuleResponse)
+	err := c.cc.Invoke(ctx, DBOS_DeleteExtractionRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) QueryResults(ctx context.Context, in *QueryResultsRequest, opts ...grpc.CallOption) (*QueryResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryResultsResponse)
+	err := c.cc.Invoke(ctx, DBOS_QueryResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CreateSavedQuery(ctx context.Context, in *CreateSavedQueryRequest, opts ...grpc.CallOption) (*CreateSavedQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateSavedQueryResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateSavedQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetSavedQuery(ctx context.Context, in *GetSavedQueryRequest, opts ...grpc.CallOption) (*GetSavedQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSavedQueryResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetSavedQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListSavedQueries(ctx context.Context, in *ListSavedQueriesRequest, opts ...grpc.CallOption) (*ListSavedQueriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedQueriesResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListSavedQueries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) UpdateSavedQuery(ctx context.Context, in *UpdateSavedQueryRequest, opts ...grpc.CallOption) (*UpdateSavedQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSavedQueryResponse)
+	err := c.cc.Invoke(ctx, DBOS_UpdateSavedQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) DeleteSavedQuery(ctx context.Context, in *DeleteSavedQueryRequest, opts ...grpc.CallOption) (*DeleteSavedQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSavedQueryResponse)
+	err := c.cc.Invoke(ctx, DBOS_DeleteSavedQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ExecuteSavedQuery(ctx context.Context, in *ExecuteSavedQueryRequest, opts ...grpc.CallOption) (*ExecuteSavedQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteSavedQueryResponse)
+	err := c.cc.Invoke(ctx, DBOS_ExecuteSavedQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CreateAlertRule(ctx context.Context, in *CreateAlertRuleRequest, opts ...grpc.CallOption) (*CreateAlertRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAlertRuleResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateAlertRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListAlertRules(ctx context.Context, in *ListAlertRulesRequest, opts ...grpc.CallOption) (*ListAlertRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAlertRulesResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListAlertRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) DeleteAlertRule(ctx context.Context, in *DeleteAlertRuleRequest, opts ...grpc.CallOption) (*DeleteAlertRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAlertRuleResponse)
+	err := c.cc.Invoke(ctx, DBOS_DeleteAlertRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CreateMaintenanceWindow(ctx context.Context, in *CreateMaintenanceWindowRequest, opts ...grpc.CallOption) (*CreateMaintenanceWindowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateMaintenanceWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetMaintenanceWindow(ctx context.Context, in *GetMaintenanceWindowRequest, opts ...grpc.CallOption) (*GetMaintenanceWindowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetMaintenanceWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListMaintenanceWindows(ctx context.Context, in *ListMaintenanceWindowsRequest, opts ...grpc.CallOption) (*ListMaintenanceWindowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMaintenanceWindowsResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListMaintenanceWindows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) UpdateMaintenanceWindow(ctx context.Context, in *UpdateMaintenanceWindowRequest, opts ...grpc.CallOption) (*UpdateMaintenanceWindowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, DBOS_UpdateMaintenanceWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) DeleteMaintenanceWindow(ctx context.Context, in *DeleteMaintenanceWindowRequest, opts ...grpc.CallOption) (*DeleteMaintenanceWindowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMaintenanceWindowResponse)
+	err := c.cc.Invoke(ctx, DBOS_DeleteMaintenanceWindow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetTrends(ctx context.Context, in *GetTrendsRequest, opts ...grpc.CallOption) (*GetTrendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrendsResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetTrends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) CreateCampaign(ctx context.Context, in *CreateCampaignRequest, opts ...grpc.CallOption) (*CreateCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCampaignResponse)
+	err := c.cc.Invoke(ctx, DBOS_CreateCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetCampaign(ctx context.Context, in *GetCampaignRequest, opts ...grpc.CallOption) (*GetCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCampaignResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListCampaigns(ctx context.Context, in *ListCampaignsRequest, opts ...grpc.CallOption) (*ListCampaignsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCampaignsResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListCampaigns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) StopCampaign(ctx context.Context, in *StopCampaignRequest, opts ...grpc.CallOption) (*StopCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopCampaignResponse)
+	err := c.cc.Invoke(ctx, DBOS_StopCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ListCampaignResults(ctx context.Context, in *ListCampaignResultsRequest, opts ...grpc.CallOption) (*ListCampaignResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCampaignResultsResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListCampaignResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ApplySpec(ctx context.Context, in *ApplySpecRequest, opts ...grpc.CallOption) (*ApplySpecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplySpecResponse)
+	err := c.cc.Invoke(ctx, DBOS_ApplySpec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetDrift(ctx context.Context, in *GetDriftRequest, opts ...grpc.CallOption) (*GetDriftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriftResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetDrift_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) PlanCapacity(ctx context.Context, in *PlanCapacityRequest, opts ...grpc.CallOption) (*PlanCapacityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanCapacityResponse)
+	err := c.cc.Invoke(ctx, DBOS_PlanCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetExecutionStats(ctx context.Context, in *GetExecutionStatsRequest, opts ...grpc.CallOption) (*GetExecutionStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExecutionStatsResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetExecutionStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) GetEvents(ctx context.Context, in *GetEventsRequest, opts ...grpc.CallOption) (*GetEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEventsResponse)
+	err := c.cc.Invoke(ctx, DBOS_GetEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DBOS_ServiceDesc.Streams[6], DBOS_StreamEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_StreamEventsClient = grpc.ServerStreamingClient[Event]
+
+func (c *dBOSClient) ListStateEvents(ctx context.Context, in *ListStateEventsRequest, opts ...grpc.CallOption) (*ListStateEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStateEventsResponse)
+	err := c.cc.Invoke(ctx, DBOS_ListStateEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) RebuildState(ctx context.Context, in *RebuildStateRequest, opts ...grpc.CallOption) (*RebuildStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebuildStateResponse)
+	err := c.cc.Invoke(ctx, DBOS_RebuildState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBOSClient) ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SnapshotRecord], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DBOS_ServiceDesc.Streams[7], DBOS_ExportSnapshot_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportSnapshotRequest, SnapshotRecord]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_ExportSnapshotClient = grpc.ServerStreamingClient[SnapshotRecord]
+
+// DBOSServer is the server API for DBOS service.
+// All implementations must embed UnimplementedDBOSServer
+// for forward compatibility.
+//
+// DBOS Service Definition
+type DBOSServer interface {
+	// Agent Management
+	RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error)
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	GetAgent(context.Context, *GetAgentRequest) (*GetAgentResponse, error)
+	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	DeleteAgent(context.Context, *DeleteAgentRequest) (*DeleteAgentResponse, error)
+	WatchAgents(*WatchAgentsRequest, grpc.ServerStreamingServer[AgentDelta]) error
+	GetAgentGeoJSON(context.Context, *GetAgentGeoJSONRequest) (*GetAgentGeoJSONResponse, error)
+	// Provisioning
+	CreateBootstrapToken(context.Context, *CreateBootstrapTokenRequest) (*CreateBootstrapTokenResponse, error)
+	EnrollAgent(context.Context, *EnrollAgentRequest) (*EnrollAgentResponse, error)
+	CreateAgentToken(context.Context, *CreateAgentTokenRequest) (*CreateAgentTokenResponse, error)
+	CreateAPIToken(context.Context, *CreateAPITokenRequest) (*CreateAPITokenResponse, error)
+	// Software Distribution
+	PublishArtifact(context.Context, *PublishArtifactRequest) (*PublishArtifactResponse, error)
+	UnpublishArtifact(context.Context, *UnpublishArtifactRequest) (*UnpublishArtifactResponse, error)
+	GetManifest(context.Context, *GetManifestRequest) (*GetManifestResponse, error)
+	// Config Rollouts
+	StartConfigRollout(context.Context, *StartConfigRolloutRequest) (*StartConfigRolloutResponse, error)
+	GetConfigRollout(context.Context, *GetConfigRolloutRequest) (*GetConfigRolloutResponse, error)
+	RollbackConfigRollout(context.Context, *RollbackConfigRolloutRequest) (*RollbackConfigRolloutResponse, error)
+	GetAgentConfig(context.Context, *GetAgentConfigRequest) (*GetAgentConfigResponse, error)
+	SetConfigSchema(context.Context, *SetConfigSchemaRequest) (*SetConfigSchemaResponse, error)
+	ListConfigSchemas(context.Context, *ListConfigSchemasRequest) (*ListConfigSchemasResponse, error)
+	DeleteConfigSchema(context.Context, *DeleteConfigSchemaRequest) (*DeleteConfigSchemaResponse, error)
+	ValidateConfig(context.Context, *ValidateConfigRequest) (*ValidateConfigResponse, error)
+	// Module State Management
+	SetModuleState(context.Context, *SetModuleStateRequest) (*SetModuleStateResponse, error)
+	GetModuleState(context.Context, *GetModuleStateRequest) (*GetModuleStateResponse, error)
+	ListModuleStates(context.Context, *ListModuleStatesRequest) (*ListModuleStatesResponse, error)
+	WatchModuleStates(*WatchModuleStatesRequest, grpc.ServerStreamingServer[ModuleState]) error
+	// Measurement Results
+	StoreResult(context.Context, *StoreResultRequest) (*StoreResultResponse, error)
+	StoreResults(context.Context, *StoreResultsRequest) (*StoreResultsResponse, error)
+	StreamResults(grpc.ClientStreamingServer[StoreResultRequest, StreamResultsResponse]) error
+	GetResult(context.Context, *GetResultRequest) (*GetResultResponse, error)
+	ListResults(context.Context, *ListResultsRequest) (*ListResultsResponse, error)
+	GetCorrelatedResults(context.Context, *GetCorrelatedResultsRequest) (*GetCorrelatedResultsResponse, error)
+	CountResults(context.Context, *CountResultsRequest) (*CountResultsResponse, error)
+	HasResult(context.Context, *HasResultRequest) (*HasResultResponse, error)
+	ExportResults(*ExportResultsRequest, grpc.ServerStreamingServer[ExportResultsChunk]) error
+	ProfileResults(context.Context, *ProfileResultsRequest) (*ProfileResultsResponse, error)
+	GetIngestGaps(context.Context, *GetIngestGapsRequest) (*GetIngestGapsResponse, error)
+	GetClockSkew(context.Context, *GetClockSkewRequest) (*GetClockSkewResponse, error)
+	ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error)
+	GetIncident(context.Context, *GetIncidentRequest) (*GetIncidentResponse, error)
+	ListIncidents(context.Context, *ListIncidentsRequest) (*ListIncidentsResponse, error)
+	CreateIncident(context.Context, *CreateIncidentRequest) (*CreateIncidentResponse, error)
+	UpdateIncident(context.Context, *UpdateIncidentRequest) (*UpdateIncidentResponse, error)
+	AcknowledgeIncident(context.Context, *AcknowledgeIncidentRequest) (*AcknowledgeIncidentResponse, error)
+	ResolveIncident(context.Context, *ResolveIncidentRequest) (*ResolveIncidentResponse, error)
+	AddIncidentComment(context.Context, *AddIncidentCommentRequest) (*AddIncidentCommentResponse, error)
+	DeleteIncident(context.Context, *DeleteIncidentRequest) (*DeleteIncidentResponse, error)
+	WatchIncidents(*WatchIncidentsRequest, grpc.ServerStreamingServer[IncidentEvent]) error
+	ListRoutingEvents(context.Context, *ListRoutingEventsRequest) (*ListRoutingEventsResponse, error)
+	// Task Scheduling
+	ScheduleTask(context.Context, *ScheduleTaskRequest) (*ScheduleTaskResponse, error)
+	GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error)
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	ListDueTasks(context.Context, *ListDueTasksRequest) (*ListDueTasksResponse, error)
+	ListPendingTasks(context.Context, *ListPendingTasksRequest) (*ListPendingTasksResponse, error)
+	CancelTask(context.Context, *CancelTaskRequest) (*CancelTaskResponse, error)
+	LeaseTask(context.Context, *LeaseTaskRequest) (*LeaseTaskResponse, error)
+	AckTasks(context.Context, *AckTasksRequest) (*AckTasksResponse, error)
+	NackTasks(context.Context, *NackTasksRequest) (*NackTasksResponse, error)
+	ExtendTaskVisibility(context.Context, *ExtendTaskVisibilityRequest) (*ExtendTaskVisibilityResponse, error)
+	ReportTaskProgress(context.Context, *ReportTaskProgressRequest) (*ReportTaskProgressResponse, error)
+	StreamTasks(*StreamTasksRequest, grpc.ServerStreamingServer[Task]) error
+	ScheduleVerifiedTask(context.Context, *ScheduleVerifiedTaskRequest) (*ScheduleVerifiedTaskResponse, error)
+	GetVerification(context.Context, *GetVerificationRequest) (*GetVerificationResponse, error)
+	BroadcastTask(context.Context, *BroadcastTaskRequest) (*BroadcastTaskResponse, error)
+	GetBroadcastStatus(context.Context, *GetBroadcastStatusRequest) (*GetBroadcastStatusResponse, error)
+	CreateView(context.Context, *CreateViewRequest) (*CreateViewResponse, error)
+	ListViews(context.Context, *ListViewsRequest) (*ListViewsResponse, error)
+	DeleteView(context.Context, *DeleteViewRequest) (*DeleteViewResponse, error)
+	QueryView(context.Context, *QueryViewRequest) (*QueryViewResponse, error)
+	CreateExtractionRule(context.Context, *CreateExtractionRuleRequest) (*CreateExtractionRuleResponse, error)
+	ListExtractionRules(context.Context, *ListExtractionRulesRequest) (*ListExtractionRulesResponse, error)
+	DeleteExtractionRule(context.Context, *DeleteExtractionRuleRequest) (*DeleteExtractionRuleResponse, error)
+	QueryResults(context.Context, *QueryResultsRequest) (*QueryResultsResponse, error)
+	CreateSavedQuery(context.Context, *CreateSavedQueryRequest) (*CreateSavedQueryResponse, error)
+	GetSavedQuery(context.Context, *GetSavedQueryRequest) (*GetSavedQueryResponse, error)
+	ListSavedQueries(context.Context, *ListSavedQueriesRequest) (*ListSavedQueriesResponse, error)
+	UpdateSavedQuery(context.Context, *UpdateSavedQueryRequest) (*UpdateSavedQueryResponse, error)
+	DeleteSavedQuery(context.Context, *DeleteSavedQueryRequest) (*DeleteSavedQueryResponse, error)
+	ExecuteSavedQuery(context.Context, *ExecuteSavedQueryRequest) (*ExecuteSavedQueryResponse, error)
+	CreateAlertRule(context.Context, *CreateAlertRuleRequest) (*CreateAlertRuleResponse, error)
+	ListAlertRules(context.Context, *ListAlertRulesRequest) (*ListAlertRulesResponse, error)
+	DeleteAlertRule(context.Context, *DeleteAlertRuleRequest) (*DeleteAlertRuleResponse, error)
+	CreateMaintenanceWindow(context.Context, *CreateMaintenanceWindowRequest) (*CreateMaintenanceWindowResponse, error)
+	GetMaintenanceWindow(context.Context, *GetMaintenanceWindowRequest) (*GetMaintenanceWindowResponse, error)
+	ListMaintenanceWindows(context.Context, *ListMaintenanceWindowsRequest) (*ListMaintenanceWindowsResponse, error)
+	UpdateMaintenanceWindow(context.Context, *UpdateMaintenanceWindowRequest) (*UpdateMaintenanceWindowResponse, error)
+	DeleteMaintenanceWindow(context.Context, *DeleteMaintenanceWindowRequest) (*DeleteMaintenanceWindowResponse, error)
+	GetTrends(context.Context, *GetTrendsRequest) (*GetTrendsResponse, error)
+	// Measurement Campaigns
+	CreateCampaign(context.Context, *CreateCampaignRequest) (*CreateCampaignResponse, error)
+	GetCampaign(context.Context, *GetCampaignRequest) (*GetCampaignResponse, error)
+	ListCampaigns(context.Context, *ListCampaignsRequest) (*ListCampaignsResponse, error)
+	StopCampaign(context.Context, *StopCampaignRequest) (*StopCampaignResponse, error)
+	ListCampaignResults(context.Context, *ListCampaignResultsRequest) (*ListCampaignResultsResponse, error)
+	ApplySpec(context.Context, *ApplySpecRequest) (*ApplySpecResponse, error)
+	GetDrift(context.Context, *GetDriftRequest) (*GetDriftResponse, error)
+	PlanCapacity(context.Context, *PlanCapacityRequest) (*PlanCapacityResponse, error)
+	GetExecutionStats(context.Context, *GetExecutionStatsRequest) (*GetExecutionStatsResponse, error)
+	// API Usage
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	// Event Log
+	GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error)
+	StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error
+	// Event Sourcing
+	ListStateEvents(context.Context, *ListStateEventsRequest) (*ListStateEventsResponse, error)
+	RebuildState(context.Context, *RebuildStateRequest) (*RebuildStateResponse, error)
+	ExportSnapshot(*ExportSnapshotRequest, grpc.ServerStreamingServer[SnapshotRecord]) error
+	mustEmbedUnimplementedDBOSServer()
+}
+
+// UnimplementedDBOSServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDBOSServer struct{}
+
+func (UnimplementedDBOSServer) RegisterAgent(context.Context, *RegisterAgentRequest) (*RegisterAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterAgent not implemented")
+}
+func (UnimplementedDBOSServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedDBOSServer) GetAgent(context.Context, *GetAgentRequest) (*GetAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgent not implemented")
+}
+func (UnimplementedDBOSServer) ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAgents not implemented")
+}
+func (UnimplementedDBOSServer) DeleteAgent(context.Context, *DeleteAgentRequest) (*DeleteAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAgent not implemented")
+}
+func (UnimplementedDBOSServer) WatchAgents(*WatchAgentsRequest, grpc.ServerStreamingServer[AgentDelta]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAgents not implemented")
+}
+func (UnimplementedDBOSServer) GetAgentGeoJSON(context.Context, *GetAgentGeoJSONRequest) (*GetAgentGeoJSONResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentGeoJSON not implemented")
+}
+func (UnimplementedDBOSServer) CreateBootstrapToken(context.Context, *CreateBootstrapTokenRequest) (*CreateBootstrapTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBootstrapToken not implemented")
+}
+func (UnimplementedDBOSServer) EnrollAgent(context.Context, *EnrollAgentRequest) (*EnrollAgentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollAgent not implemented")
+}
+func (UnimplementedDBOSServer) CreateAgentToken(context.Context, *CreateAgentTokenRequest) (*CreateAgentTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAgentToken not implemented")
+}
+func (UnimplementedDBOSServer) CreateAPIToken(context.Context, *CreateAPITokenRequest) (*CreateAPITokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIToken not implemented")
+}
+func (UnimplementedDBOSServer) PublishArtifact(context.Context, *PublishArtifactRequest) (*PublishArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishArtifact not implemented")
+}
+func (UnimplementedDBOSServer) UnpublishArtifact(context.Context, *UnpublishArtifactRequest) (*UnpublishArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpublishArtifact not implemented")
+}
+func (UnimplementedDBOSServer) GetManifest(context.Context, *GetManifestRequest) (*GetManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetManifest not implemented")
+}
+func (UnimplementedDBOSServer) StartConfigRollout(context.Context, *StartConfigRolloutRequest) (*StartConfigRolloutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartConfigRollout not implemented")
+}
+func (UnimplementedDBOSServer) GetConfigRollout(context.Context, *GetConfigRolloutRequest) (*GetConfigRolloutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigRollout not implemented")
+}
+func (UnimplementedDBOSServer) RollbackConfigRollout(context.Context, *RollbackConfigRolloutRequest) (*RollbackConfigRolloutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackConfigRollout not implemented")
+}
+func (UnimplementedDBOSServer) GetAgentConfig(context.Context, *GetAgentConfigRequest) (*GetAgentConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgentConfig not implemented")
+}
+func (UnimplementedDBOSServer) SetConfigSchema(context.Context, *SetConfigSchemaRequest) (*SetConfigSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConfigSchema not implemented")
+}
+func (UnimplementedDBOSServer) ListConfigSchemas(context.Context, *ListConfigSchemasRequest) (*ListConfigSchemasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConfigSchemas not implemented")
+}
+func (UnimplementedDBOSServer) DeleteConfigSchema(context.Context, *DeleteConfigSchemaRequest) (*DeleteConfigSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteConfigSchema not implemented")
+}
+func (UnimplementedDBOSServer) ValidateConfig(context.Context, *ValidateConfigRequest) (*ValidateConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateConfig not implemented")
+}
+func (UnimplementedDBOSServer) SetModuleState(context.Context, *SetModuleStateRequest) (*SetModuleStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetModuleState not implemented")
+}
+func (UnimplementedDBOSServer) GetModuleState(context.Context, *GetModuleStateRequest) (*GetModuleStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetModuleState not implemented")
+}
+func (UnimplementedDBOSServer) ListModuleStates(context.Context, *ListModuleStatesRequest) (*ListModuleStatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModuleStates not implemented")
+}
+func (UnimplementedDBOSServer) WatchModuleStates(*WatchModuleStatesRequest, grpc.ServerStreamingServer[ModuleState]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchModuleStates not implemented")
+}
+func (UnimplementedDBOSServer) StoreResult(context.Context, *StoreResultRequest) (*StoreResultResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StoreResult not implemented")
 }
-func (UnimplementedDBOSServer) GetResult(context.Context, *GetResultRequest) (*GetResultResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetResult not implemented")
+func (UnimplementedDBOSServer) StoreResults(context.Context, *StoreResultsRequest) (*StoreResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreResults not implemented")
+}
+func (UnimplementedDBOSServer) StreamResults(grpc.ClientStreamingServer[StoreResultRequest, StreamResultsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamResults not implemented")
+}
+func (UnimplementedDBOSServer) GetResult(context.Context, *GetResultRequest) (*GetResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResult not implemented")
+}
+func (UnimplementedDBOSServer) ListResults(context.Context, *ListResultsRequest) (*ListResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResults not implemented")
+}
+func (UnimplementedDBOSServer) GetCorrelatedResults(context.Context, *GetCorrelatedResultsRequest) (*GetCorrelatedResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCorrelatedResults not implemented")
+}
+func (UnimplementedDBOSServer) CountResults(context.Context, *CountResultsRequest) (*CountResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountResults not implemented")
+}
+func (UnimplementedDBOSServer) HasResult(context.Context, *HasResultRequest) (*HasResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasResult not implemented")
+}
+func (UnimplementedDBOSServer) ExportResults(*ExportResultsRequest, grpc.ServerStreamingServer[ExportResultsChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportResults not implemented")
+}
+func (UnimplementedDBOSServer) ProfileResults(context.Context, *ProfileResultsRequest) (*ProfileResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProfileResults not implemented")
+}
+func (UnimplementedDBOSServer) GetIngestGaps(context.Context, *GetIngestGapsRequest) (*GetIngestGapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIngestGaps not implemented")
+}
+func (UnimplementedDBOSServer) GetClockSkew(context.Context, *GetClockSkewRequest) (*GetClockSkewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClockSkew not implemented")
+}
+func (UnimplementedDBOSServer) ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlerts not implemented")
+}
+func (UnimplementedDBOSServer) GetIncident(context.Context, *GetIncidentRequest) (*GetIncidentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIncident not implemented")
+}
+func (UnimplementedDBOSServer) ListIncidents(context.Context, *ListIncidentsRequest) (*ListIncidentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncidents not implemented")
+}
+func (UnimplementedDBOSServer) CreateIncident(context.Context, *CreateIncidentRequest) (*CreateIncidentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateIncident not implemented")
+}
+func (UnimplementedDBOSServer) UpdateIncident(context.Context, *UpdateIncidentRequest) (*UpdateIncidentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateIncident not implemented")
+}
+func (UnimplementedDBOSServer) AcknowledgeIncident(context.Context, *AcknowledgeIncidentRequest) (*AcknowledgeIncidentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeIncident not implemented")
+}
+func (UnimplementedDBOSServer) ResolveIncident(context.Context, *ResolveIncidentRequest) (*ResolveIncidentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveIncident not implemented")
+}
+func (UnimplementedDBOSServer) AddIncidentComment(context.Context, *AddIncidentCommentRequest) (*AddIncidentCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddIncidentComment not implemented")
+}
+func (UnimplementedDBOSServer) DeleteIncident(context.Context, *DeleteIncidentRequest) (*DeleteIncidentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteIncident not implemented")
+}
+func (UnimplementedDBOSServer) WatchIncidents(*WatchIncidentsRequest, grpc.ServerStreamingServer[IncidentEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchIncidents not implemented")
+}
+func (UnimplementedDBOSServer) ListRoutingEvents(context.Context, *ListRoutingEventsRequest) (*ListRoutingEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoutingEvents not implemented")
+}
+func (UnimplementedDBOSServer) ScheduleTask(context.Context, *ScheduleTaskRequest) (*ScheduleTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleTask not implemented")
+}
+func (UnimplementedDBOSServer) GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+}
+func (UnimplementedDBOSServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedDBOSServer) ListDueTasks(context.Context, *ListDueTasksRequest) (*ListDueTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDueTasks not implemented")
+}
+func (UnimplementedDBOSServer) ListPendingTasks(context.Context, *ListPendingTasksRequest) (*ListPendingTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingTasks not implemented")
+}
+func (UnimplementedDBOSServer) CancelTask(context.Context, *CancelTaskRequest) (*CancelTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTask not implemented")
+}
+func (UnimplementedDBOSServer) LeaseTask(context.Context, *LeaseTaskRequest) (*LeaseTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaseTask not implemented")
+}
+func (UnimplementedDBOSServer) AckTasks(context.Context, *AckTasksRequest) (*AckTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AckTasks not implemented")
+}
+func (UnimplementedDBOSServer) NackTasks(context.Context, *NackTasksRequest) (*NackTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NackTasks not implemented")
+}
+func (UnimplementedDBOSServer) ExtendTaskVisibility(context.Context, *ExtendTaskVisibilityRequest) (*ExtendTaskVisibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExtendTaskVisibility not implemented")
+}
+func (UnimplementedDBOSServer) ReportTaskProgress(context.Context, *ReportTaskProgressRequest) (*ReportTaskProgressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportTaskProgress not implemented")
+}
+func (UnimplementedDBOSServer) StreamTasks(*StreamTasksRequest, grpc.ServerStreamingServer[Task]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTasks not implemented")
+}
+func (UnimplementedDBOSServer) ScheduleVerifiedTask(context.Context, *ScheduleVerifiedTaskRequest) (*ScheduleVerifiedTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleVerifiedTask not implemented")
+}
+func (UnimplementedDBOSServer) GetVerification(context.Context, *GetVerificationRequest) (*GetVerificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVerification not implemented")
+}
+func (UnimplementedDBOSServer) BroadcastTask(context.Context, *BroadcastTaskRequest) (*BroadcastTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BroadcastTask not implemented")
+}
+func (UnimplementedDBOSServer) GetBroadcastStatus(context.Context, *GetBroadcastStatusRequest) (*GetBroadcastStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBroadcastStatus not implemented")
+}
+func (UnimplementedDBOSServer) CreateView(context.Context, *CreateViewRequest) (*CreateViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateView not implemented")
+}
+func (UnimplementedDBOSServer) ListViews(context.Context, *ListViewsRequest) (*ListViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListViews not implemented")
+}
+func (UnimplementedDBOSServer) DeleteView(context.Context, *DeleteViewRequest) (*DeleteViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteView not implemented")
+}
+func (UnimplementedDBOSServer) QueryView(context.Context, *QueryViewRequest) (*QueryViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryView not implemented")
+}
+func (UnimplementedDBOSServer) CreateExtractionRule(context.Context, *CreateExtractionRuleRequest) (*CreateExtractionRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExtractionRule not implemented")
+}
+func (UnimplementedDBOSServer) ListExtractionRules(context.Context, *ListExtractionRulesRequest) (*ListExtractionRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExtractionRules not implemented")
+}
+func (UnimplementedDBOSServer) DeleteExtractionRule(context.Context, *DeleteExtractionRuleRequest) (*DeleteExtractionRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteExtractionRule not implemented")
+}
+func (UnimplementedDBOSServer) QueryResults(context.Context, *QueryResultsRequest) (*QueryResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryResults not implemented")
+}
+func (UnimplementedDBOSServer) CreateSavedQuery(context.Context, *CreateSavedQueryRequest) (*CreateSavedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSavedQuery not implemented")
+}
+func (UnimplementedDBOSServer) GetSavedQuery(context.Context, *GetSavedQueryRequest) (*GetSavedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSavedQuery not implemented")
+}
+func (UnimplementedDBOSServer) ListSavedQueries(context.Context, *ListSavedQueriesRequest) (*ListSavedQueriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavedQueries not implemented")
+}
+func (UnimplementedDBOSServer) UpdateSavedQuery(context.Context, *UpdateSavedQueryRequest) (*UpdateSavedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSavedQuery not implemented")
+}
+func (UnimplementedDBOSServer) DeleteSavedQuery(context.Context, *DeleteSavedQueryRequest) (*DeleteSavedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSavedQuery not implemented")
+}
+func (UnimplementedDBOSServer) ExecuteSavedQuery(context.Context, *ExecuteSavedQueryRequest) (*ExecuteSavedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteSavedQuery not implemented")
+}
+func (UnimplementedDBOSServer) CreateAlertRule(context.Context, *CreateAlertRuleRequest) (*CreateAlertRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAlertRule not implemented")
+}
+func (UnimplementedDBOSServer) ListAlertRules(context.Context, *ListAlertRulesRequest) (*ListAlertRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlertRules not implemented")
+}
+func (UnimplementedDBOSServer) DeleteAlertRule(context.Context, *DeleteAlertRuleRequest) (*DeleteAlertRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAlertRule not implemented")
+}
+func (UnimplementedDBOSServer) CreateMaintenanceWindow(context.Context, *CreateMaintenanceWindowRequest) (*CreateMaintenanceWindowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMaintenanceWindow not implemented")
+}
+func (UnimplementedDBOSServer) GetMaintenanceWindow(context.Context, *GetMaintenanceWindowRequest) (*GetMaintenanceWindowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMaintenanceWindow not implemented")
+}
+func (UnimplementedDBOSServer) ListMaintenanceWindows(context.Context, *ListMaintenanceWindowsRequest) (*ListMaintenanceWindowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMaintenanceWindows not implemented")
+}
+func (UnimplementedDBOSServer) UpdateMaintenanceWindow(context.Context, *UpdateMaintenanceWindowRequest) (*UpdateMaintenanceWindowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMaintenanceWindow not implemented")
+}
+func (UnimplementedDBOSServer) DeleteMaintenanceWindow(context.Context, *DeleteMaintenanceWindowRequest) (*DeleteMaintenanceWindowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMaintenanceWindow not implemented")
+}
+func (UnimplementedDBOSServer) GetTrends(context.Context, *GetTrendsRequest) (*GetTrendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrends not implemented")
+}
+func (UnimplementedDBOSServer) CreateCampaign(context.Context, *CreateCampaignRequest) (*CreateCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCampaign not implemented")
+}
+func (UnimplementedDBOSServer) GetCampaign(context.Context, *GetCampaignRequest) (*GetCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCampaign not implemented")
+}
+func (UnimplementedDBOSServer) ListCampaigns(context.Context, *ListCampaignsRequest) (*ListCampaignsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCampaigns not implemented")
+}
+func (UnimplementedDBOSServer) StopCampaign(context.Context, *StopCampaignRequest) (*StopCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopCampaign not implemented")
+}
+func (UnimplementedDBOSServer) ListCampaignResults(context.Context, *ListCampaignResultsRequest) (*ListCampaignResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCampaignResults not implemented")
+}
+func (UnimplementedDBOSServer) ApplySpec(context.Context, *ApplySpecRequest) (*ApplySpecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplySpec not implemented")
+}
+func (UnimplementedDBOSServer) GetDrift(context.Context, *GetDriftRequest) (*GetDriftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDrift not implemented")
+}
+func (UnimplementedDBOSServer) PlanCapacity(context.Context, *PlanCapacityRequest) (*PlanCapacityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanCapacity not implemented")
+}
+func (UnimplementedDBOSServer) GetExecutionStats(context.Context, *GetExecutionStatsRequest) (*GetExecutionStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExecutionStats not implemented")
+}
+func (UnimplementedDBOSServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedDBOSServer) GetEvents(context.Context, *GetEventsRequest) (*GetEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvents not implemented")
+}
+func (UnimplementedDBOSServer) StreamEvents(*StreamEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedDBOSServer) ListStateEvents(context.Context, *ListStateEventsRequest) (*ListStateEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStateEvents not implemented")
+}
+func (UnimplementedDBOSServer) RebuildState(context.Context, *RebuildStateRequest) (*RebuildStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildState not implemented")
+}
+func (UnimplementedDBOSServer) ExportSnapshot(*ExportSnapshotRequest, grpc.ServerStreamingServer[SnapshotRecord]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportSnapshot not implemented")
+}
+func (UnimplementedDBOSServer) mustEmbedUnimplementedDBOSServer() {}
+func (UnimplementedDBOSServer) testEmbeddedByValue()              {}
+
+// UnsafeDBOSServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DBOSServer will
+// result in compilation errors.
+type UnsafeDBOSServer interface {
+	mustEmbedUnimplementedDBOSServer()
+}
+
+func RegisterDBOSServer(s grpc.ServiceRegistrar, srv DBOSServer) {
+	// If the following call pancis, it indicates UnimplementedDBOSServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DBOS_ServiceDesc, srv)
+}
+
+func _DBOS_RegisterAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).RegisterAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_RegisterAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).RegisterAgent(ctx, req.(*RegisterAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetAgent(ctx, req.(*GetAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListAgents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListAgents(ctx, req.(*ListAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_DeleteAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).DeleteAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_DeleteAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).DeleteAgent(ctx, req.(*DeleteAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_WatchAgents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAgentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DBOSServer).WatchAgents(m, &grpc.GenericServerStream[WatchAgentsRequest, AgentDelta]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_WatchAgentsServer = grpc.ServerStreamingServer[AgentDelta]
+
+func _DBOS_GetAgentGeoJSON_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentGeoJSONRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetAgentGeoJSON(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetAgentGeoJSON_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetAgentGeoJSON(ctx, req.(*GetAgentGeoJSONRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CreateBootstrapToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBootstrapTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateBootstrapToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateBootstrapToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateBootstrapToken(ctx, req.(*CreateBootstrapTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_EnrollAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).EnrollAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_EnrollAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).EnrollAgent(ctx, req.(*EnrollAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CreateAgentToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAgentTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateAgentToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateAgentToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateAgentToken(ctx, req.(*CreateAgentTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CreateAPIToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPITokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateAPIToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateAPIToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateAPIToken(ctx, req.(*CreateAPITokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_PublishArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).PublishArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_PublishArtifact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).PublishArtifact(ctx, req.(*PublishArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_UnpublishArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).UnpublishArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_UnpublishArtifact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).UnpublishArtifact(ctx, req.(*UnpublishArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetManifest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetManifest(ctx, req.(*GetManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_StartConfigRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartConfigRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).StartConfigRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_StartConfigRollout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).StartConfigRollout(ctx, req.(*StartConfigRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetConfigRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetConfigRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetConfigRollout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetConfigRollout(ctx, req.(*GetConfigRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_RollbackConfigRollout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackConfigRolloutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).RollbackConfigRollout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_RollbackConfigRollout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).RollbackConfigRollout(ctx, req.(*RollbackConfigRolloutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetAgentConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetAgentConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetAgentConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetAgentConfig(ctx, req.(*GetAgentConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_SetConfigSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).SetConfigSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_SetConfigSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).SetConfigSchema(ctx, req.(*SetConfigSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListConfigSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConfigSchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListConfigSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListConfigSchemas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListConfigSchemas(ctx, req.(*ListConfigSchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_DeleteConfigSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteConfigSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).DeleteConfigSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_DeleteConfigSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).DeleteConfigSchema(ctx, req.(*DeleteConfigSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ValidateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ValidateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ValidateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ValidateConfig(ctx, req.(*ValidateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_SetModuleState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetModuleStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).SetModuleState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_SetModuleState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).SetModuleState(ctx, req.(*SetModuleStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetModuleState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetModuleStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetModuleState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetModuleState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetModuleState(ctx, req.(*GetModuleStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListModuleStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModuleStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListModuleStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListModuleStates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListModuleStates(ctx, req.(*ListModuleStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_WatchModuleStates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchModuleStatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DBOSServer).WatchModuleStates(m, &grpc.GenericServerStream[WatchModuleStatesRequest, ModuleState]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_WatchModuleStatesServer = grpc.ServerStreamingServer[ModuleState]
+
+func _DBOS_StoreResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).StoreResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_StoreResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).StoreResult(ctx, req.(*StoreResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_StoreResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).StoreResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_StoreResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).StoreResults(ctx, req.(*StoreResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_StreamResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DBOSServer).StreamResults(&grpc.GenericServerStream[StoreResultRequest, StreamResultsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_StreamResultsServer = grpc.ClientStreamingServer[StoreResultRequest, StreamResultsResponse]
+
+func _DBOS_GetResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetResult(ctx, req.(*GetResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListResults(ctx, req.(*ListResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetCorrelatedResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCorrelatedResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetCorrelatedResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetCorrelatedResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetCorrelatedResults(ctx, req.(*GetCorrelatedResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CountResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CountResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CountResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CountResults(ctx, req.(*CountResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_HasResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).HasResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_HasResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).HasResult(ctx, req.(*HasResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ExportResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DBOSServer).ExportResults(m, &grpc.GenericServerStream[ExportResultsRequest, ExportResultsChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_ExportResultsServer = grpc.ServerStreamingServer[ExportResultsChunk]
+
+func _DBOS_ProfileResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfileResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ProfileResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ProfileResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ProfileResults(ctx, req.(*ProfileResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetIngestGaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIngestGapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetIngestGaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetIngestGaps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetIngestGaps(ctx, req.(*GetIngestGapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetClockSkew_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClockSkewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetClockSkew(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetClockSkew_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetClockSkew(ctx, req.(*GetClockSkewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListAlerts(ctx, req.(*ListAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetIncident(ctx, req.(*GetIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListIncidents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncidentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListIncidents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListIncidents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListIncidents(ctx, req.(*ListIncidentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CreateIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateIncident(ctx, req.(*CreateIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_UpdateIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).UpdateIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_UpdateIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).UpdateIncident(ctx, req.(*UpdateIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_AcknowledgeIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).AcknowledgeIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_AcknowledgeIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).AcknowledgeIncident(ctx, req.(*AcknowledgeIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ResolveIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ResolveIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ResolveIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ResolveIncident(ctx, req.(*ResolveIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_AddIncidentComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddIncidentCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).AddIncidentComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_AddIncidentComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).AddIncidentComment(ctx, req.(*AddIncidentCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_DeleteIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).DeleteIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_DeleteIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).DeleteIncident(ctx, req.(*DeleteIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_WatchIncidents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchIncidentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DBOSServer).WatchIncidents(m, &grpc.GenericServerStream[WatchIncidentsRequest, IncidentEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_WatchIncidentsServer = grpc.ServerStreamingServer[IncidentEvent]
+
+func _DBOS_ListRoutingEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoutingEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListRoutingEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListRoutingEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListRoutingEvents(ctx, req.(*ListRoutingEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ScheduleTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ScheduleTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ScheduleTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ScheduleTask(ctx, req.(*ScheduleTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetTask(ctx, req.(*GetTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListDueTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDueTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListDueTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListDueTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListDueTasks(ctx, req.(*ListDueTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListPendingTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListPendingTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListPendingTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListPendingTasks(ctx, req.(*ListPendingTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CancelTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CancelTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CancelTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CancelTask(ctx, req.(*CancelTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_LeaseTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).LeaseTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_LeaseTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).LeaseTask(ctx, req.(*LeaseTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_AckTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).AckTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_AckTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).AckTasks(ctx, req.(*AckTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_NackTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NackTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).NackTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_NackTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).NackTasks(ctx, req.(*NackTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ExtendTaskVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtendTaskVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ExtendTaskVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ExtendTaskVisibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ExtendTaskVisibility(ctx, req.(*ExtendTaskVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ReportTaskProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportTaskProgressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ReportTaskProgress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ReportTaskProgress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ReportTaskProgress(ctx, req.(*ReportTaskProgressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_StreamTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTasksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DBOSServer).StreamTasks(m, &grpc.GenericServerStream[StreamTasksRequest, Task]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_StreamTasksServer = grpc.ServerStreamingServer[Task]
+
+func _DBOS_ScheduleVerifiedTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleVerifiedTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ScheduleVerifiedTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ScheduleVerifiedTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ScheduleVerifiedTask(ctx, req.(*ScheduleVerifiedTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetVerification(ctx, req.(*GetVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_BroadcastTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).BroadcastTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_BroadcastTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).BroadcastTask(ctx, req.(*BroadcastTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetBroadcastStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBroadcastStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetBroadcastStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetBroadcastStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetBroadcastStatus(ctx, req.(*GetBroadcastStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CreateView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateView(ctx, req.(*CreateViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListViewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListViews(ctx, req.(*ListViewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_DeleteView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).DeleteView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_DeleteView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).DeleteView(ctx, req.(*DeleteViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_QueryView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).QueryView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_QueryView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).QueryView(ctx, req.(*QueryViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CreateExtractionRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExtractionRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateExtractionRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateExtractionRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateExtractionRule(ctx, req.(*CreateExtractionRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListExtractionRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExtractionRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListExtractionRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListExtractionRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListExtractionRules(ctx, req.(*ListExtractionRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_DeleteExtractionRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExtractionRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).DeleteExtractionRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_DeleteExtractionRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).DeleteExtractionRule(ctx, req.(*DeleteExtractionRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBOSServer) ListResults(context.Context, *ListResultsRequest) (*ListResultsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListResults not implemented")
+
+func _DBOS_QueryResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).QueryResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_QueryResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).QueryResults(ctx, req.(*QueryResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBOSServer) ScheduleTask(context.Context, *ScheduleTaskRequest) (*ScheduleTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ScheduleTask not implemented")
+
+func _DBOS_CreateSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateSavedQuery(ctx, req.(*CreateSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBOSServer) GetTask(context.Context, *GetTaskRequest) (*GetTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+
+func _DBOS_GetSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetSavedQuery(ctx, req.(*GetSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBOSServer) ListDueTasks(context.Context, *ListDueTasksRequest) (*ListDueTasksResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListDueTasks not implemented")
+
+func _DBOS_ListSavedQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListSavedQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListSavedQueries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListSavedQueries(ctx, req.(*ListSavedQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBOSServer) mustEmbedUnimplementedDBOSServer() {}
-func (UnimplementedDBOSServer) testEmbeddedByValue()              {}
 
-// UnsafeDBOSServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to DBOSServer will
-// result in compilation errors.
-type UnsafeDBOSServer interface {
-	mustEmbedUnimplementedDBOSServer()
+func _DBOS_UpdateSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).UpdateSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_UpdateSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).UpdateSavedQuery(ctx, req.(*UpdateSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDBOSServer(s grpc.ServiceRegistrar, srv DBOSServer) {
-	// If the following call pancis, it indicates UnimplementedDBOSServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _DBOS_DeleteSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).DeleteSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_DeleteSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).DeleteSavedQuery(ctx, req.(*DeleteSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ExecuteSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ExecuteSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ExecuteSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ExecuteSavedQuery(ctx, req.(*ExecuteSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CreateAlertRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAlertRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateAlertRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateAlertRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateAlertRule(ctx, req.(*CreateAlertRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListAlertRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListAlertRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListAlertRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListAlertRules(ctx, req.(*ListAlertRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_DeleteAlertRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAlertRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).DeleteAlertRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_DeleteAlertRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).DeleteAlertRule(ctx, req.(*DeleteAlertRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CreateMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateMaintenanceWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateMaintenanceWindow(ctx, req.(*CreateMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetMaintenanceWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetMaintenanceWindow(ctx, req.(*GetMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_ListMaintenanceWindows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMaintenanceWindowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).ListMaintenanceWindows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_ListMaintenanceWindows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).ListMaintenanceWindows(ctx, req.(*ListMaintenanceWindowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_UpdateMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).UpdateMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_UpdateMaintenanceWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).UpdateMaintenanceWindow(ctx, req.(*UpdateMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_DeleteMaintenanceWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMaintenanceWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).DeleteMaintenanceWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_DeleteMaintenanceWindow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).DeleteMaintenanceWindow(ctx, req.(*DeleteMaintenanceWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_GetTrends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).GetTrends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_GetTrends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).GetTrends(ctx, req.(*GetTrendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBOS_CreateCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBOSServer).CreateCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBOS_CreateCampaign_FullMethodName,
 	}
-	s.RegisterService(&DBOS_ServiceDesc, srv)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBOSServer).CreateCampaign(ctx, req.(*CreateCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_RegisterAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterAgentRequest)
+func _DBOS_GetCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCampaignRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).RegisterAgent(ctx, in)
+		return srv.(DBOSServer).GetCampaign(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_RegisterAgent_FullMethodName,
+		FullMethod: DBOS_GetCampaign_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).RegisterAgent(ctx, req.(*RegisterAgentRequest))
+		return srv.(DBOSServer).GetCampaign(ctx, req.(*GetCampaignRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_GetAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAgentRequest)
+func _DBOS_ListCampaigns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCampaignsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).GetAgent(ctx, in)
+		return srv.(DBOSServer).ListCampaigns(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_GetAgent_FullMethodName,
+		FullMethod: DBOS_ListCampaigns_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).GetAgent(ctx, req.(*GetAgentRequest))
+		return srv.(DBOSServer).ListCampaigns(ctx, req.(*ListCampaignsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListAgentsRequest)
+func _DBOS_StopCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopCampaignRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).ListAgents(ctx, in)
+		return srv.(DBOSServer).StopCampaign(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_ListAgents_FullMethodName,
+		FullMethod: DBOS_StopCampaign_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).ListAgents(ctx, req.(*ListAgentsRequest))
+		return srv.(DBOSServer).StopCampaign(ctx, req.(*StopCampaignRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_SetModuleState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetModuleStateRequest)
+func _DBOS_ListCampaignResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCampaignResultsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).SetModuleState(ctx, in)
+		return srv.(DBOSServer).ListCampaignResults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_SetModuleState_FullMethodName,
+		FullMethod: DBOS_ListCampaignResults_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).SetModuleState(ctx, req.(*SetModuleStateRequest))
+		return srv.(DBOSServer).ListCampaignResults(ctx, req.(*ListCampaignResultsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_GetModuleState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetModuleStateRequest)
+func _DBOS_ApplySpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplySpecRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).GetModuleState(ctx, in)
+		return srv.(DBOSServer).ApplySpec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_GetModuleState_FullMethodName,
+		FullMethod: DBOS_ApplySpec_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).GetModuleState(ctx, req.(*GetModuleStateRequest))
+		return srv.(DBOSServer).ApplySpec(ctx, req.(*ApplySpecRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_ListModuleStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListModuleStatesRequest)
+func _DBOS_GetDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriftRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).ListModuleStates(ctx, in)
+		return srv.(DBOSServer).GetDrift(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_ListModuleStates_FullMethodName,
+		FullMethod: DBOS_GetDrift_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).ListModuleStates(ctx, req.(*ListModuleStatesRequest))
+		return srv.(DBOSServer).GetDrift(ctx, req.(*GetDriftRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_StoreResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StoreResultRequest)
+func _DBOS_PlanCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanCapacityRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).StoreResult(ctx, in)
+		return srv.(DBOSServer).PlanCapacity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_StoreResult_FullMethodName,
+		FullMethod: DBOS_PlanCapacity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).StoreResult(ctx, req.(*StoreResultRequest))
+		return srv.(DBOSServer).PlanCapacity(ctx, req.(*PlanCapacityRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_GetResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetResultRequest)
+func _DBOS_GetExecutionStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).GetResult(ctx, in)
+		return srv.(DBOSServer).GetExecutionStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_GetResult_FullMethodName,
+		FullMethod: DBOS_GetExecutionStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).GetResult(ctx, req.(*GetResultRequest))
+		return srv.(DBOSServer).GetExecutionStats(ctx, req.(*GetExecutionStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_ListResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListResultsRequest)
+func _DBOS_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).ListResults(ctx, in)
+		return srv.(DBOSServer).GetUsage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_ListResults_FullMethodName,
+		FullMethod: DBOS_GetUsage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).ListResults(ctx, req.(*ListResultsRequest))
+		return srv.(DBOSServer).GetUsage(ctx, req.(*GetUsageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_ScheduleTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ScheduleTaskRequest)
+func _DBOS_GetEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).ScheduleTask(ctx, in)
+		return srv.(DBOSServer).GetEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_ScheduleTask_FullMethodName,
+		FullMethod: DBOS_GetEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).ScheduleTask(ctx, req.(*ScheduleTaskRequest))
+		return srv.(DBOSServer).GetEvents(ctx, req.(*GetEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTaskRequest)
+func _DBOS_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DBOSServer).StreamEvents(m, &grpc.GenericServerStream[StreamEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_StreamEventsServer = grpc.ServerStreamingServer[Event]
+
+func _DBOS_ListStateEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStateEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).GetTask(ctx, in)
+		return srv.(DBOSServer).ListStateEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_GetTask_FullMethodName,
+		FullMethod: DBOS_ListStateEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).GetTask(ctx, req.(*GetTaskRequest))
+		return srv.(DBOSServer).ListStateEvents(ctx, req.(*ListStateEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBOS_ListDueTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListDueTasksRequest)
+func _DBOS_RebuildState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildStateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBOSServer).ListDueTasks(ctx, in)
+		return srv.(DBOSServer).RebuildState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBOS_ListDueTasks_FullMethodName,
+		FullMethod: DBOS_RebuildState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBOSServer).ListDueTasks(ctx, req.(*ListDueTasksRequest))
+		return srv.(DBOSServer).RebuildState(ctx, req.(*RebuildStateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DBOS_ExportSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DBOSServer).ExportSnapshot(m, &grpc.GenericServerStream[ExportSnapshotRequest, SnapshotRecord]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBOS_ExportSnapshotServer = grpc.ServerStreamingServer[SnapshotRecord]
+
 // DBOS_ServiceDesc is the grpc.ServiceDesc for DBOS service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -501,6 +3615,10 @@ var DBOS_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterAgent",
 			Handler:    _DBOS_RegisterAgent_Handler,
 		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _DBOS_Heartbeat_Handler,
+		},
 		{
 			MethodName: "GetAgent",
 			Handler:    _DBOS_GetAgent_Handler,
@@ -509,6 +3627,74 @@ var DBOS_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListAgents",
 			Handler:    _DBOS_ListAgents_Handler,
 		},
+		{
+			MethodName: "DeleteAgent",
+			Handler:    _DBOS_DeleteAgent_Handler,
+		},
+		{
+			MethodName: "GetAgentGeoJSON",
+			Handler:    _DBOS_GetAgentGeoJSON_Handler,
+		},
+		{
+			MethodName: "CreateBootstrapToken",
+			Handler:    _DBOS_CreateBootstrapToken_Handler,
+		},
+		{
+			MethodName: "EnrollAgent",
+			Handler:    _DBOS_EnrollAgent_Handler,
+		},
+		{
+			MethodName: "CreateAgentToken",
+			Handler:    _DBOS_CreateAgentToken_Handler,
+		},
+		{
+			MethodName: "CreateAPIToken",
+			Handler:    _DBOS_CreateAPIToken_Handler,
+		},
+		{
+			MethodName: "PublishArtifact",
+			Handler:    _DBOS_PublishArtifact_Handler,
+		},
+		{
+			MethodName: "UnpublishArtifact",
+			Handler:    _DBOS_UnpublishArtifact_Handler,
+		},
+		{
+			MethodName: "GetManifest",
+			Handler:    _DBOS_GetManifest_Handler,
+		},
+		{
+			MethodName: "StartConfigRollout",
+			Handler:    _DBOS_StartConfigRollout_Handler,
+		},
+		{
+			MethodName: "GetConfigRollout",
+			Handler:    _DBOS_GetConfigRollout_Handler,
+		},
+		{
+			MethodName: "RollbackConfigRollout",
+			Handler:    _DBOS_RollbackConfigRollout_Handler,
+		},
+		{
+			MethodName: "GetAgentConfig",
+			Handler:    _DBOS_GetAgentConfig_Handler,
+		},
+		{
+			MethodName: "SetConfigSchema",
+			Handler:    _DBOS_SetConfigSchema_Handler,
+		},
+		{
+			MethodName: "ListConfigSchemas",
+			Handler:    _DBOS_ListConfigSchemas_Handler,
+		},
+		{
+			MethodName: "DeleteConfigSchema",
+			Handler:    _DBOS_DeleteConfigSchema_Handler,
+		},
+		{
+			MethodName: "ValidateConfig",
+			Handler:    _DBOS_ValidateConfig_Handler,
+		},
 		{
 			MethodName: "SetModuleState",
 			Handler:    _DBOS_SetModuleState_Handler,
@@ -525,6 +3711,10 @@ var DBOS_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StoreResult",
 			Handler:    _DBOS_StoreResult_Handler,
 		},
+		{
+			MethodName: "StoreResults",
+			Handler:    _DBOS_StoreResults_Handler,
+		},
 		{
 			MethodName: "GetResult",
 			Handler:    _DBOS_GetResult_Handler,
@@ -533,6 +3723,70 @@ var DBOS_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListResults",
 			Handler:    _DBOS_ListResults_Handler,
 		},
+		{
+			MethodName: "GetCorrelatedResults",
+			Handler:    _DBOS_GetCorrelatedResults_Handler,
+		},
+		{
+			MethodName: "CountResults",
+			Handler:    _DBOS_CountResults_Handler,
+		},
+		{
+			MethodName: "HasResult",
+			Handler:    _DBOS_HasResult_Handler,
+		},
+		{
+			MethodName: "ProfileResults",
+			Handler:    _DBOS_ProfileResults_Handler,
+		},
+		{
+			MethodName: "GetIngestGaps",
+			Handler:    _DBOS_GetIngestGaps_Handler,
+		},
+		{
+			MethodName: "GetClockSkew",
+			Handler:    _DBOS_GetClockSkew_Handler,
+		},
+		{
+			MethodName: "ListAlerts",
+			Handler:    _DBOS_ListAlerts_Handler,
+		},
+		{
+			MethodName: "GetIncident",
+			Handler:    _DBOS_GetIncident_Handler,
+		},
+		{
+			MethodName: "ListIncidents",
+			Handler:    _DBOS_ListIncidents_Handler,
+		},
+		{
+			MethodName: "CreateIncident",
+			Handler:    _DBOS_CreateIncident_Handler,
+		},
+		{
+			MethodName: "UpdateIncident",
+			Handler:    _DBOS_UpdateIncident_Handler,
+		},
+		{
+			MethodName: "AcknowledgeIncident",
+			Handler:    _DBOS_AcknowledgeIncident_Handler,
+		},
+		{
+			MethodName: "ResolveIncident",
+			Handler:    _DBOS_ResolveIncident_Handler,
+		},
+		{
+			MethodName: "AddIncidentComment",
+			Handler:    _DBOS_AddIncidentComment_Handler,
+		},
+		{
+			MethodName: "DeleteIncident",
+			Handler:    _DBOS_DeleteIncident_Handler,
+		},
+		{
+			MethodName: "ListRoutingEvents",
+			Handler:    _DBOS_ListRoutingEvents_Handler,
+		},
 		{
 			MethodName: "ScheduleTask",
 			Handler:    _DBOS_ScheduleTask_Handler,
@@ -541,11 +3795,244 @@ var DBOS_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTask",
 			Handler:    _DBOS_GetTask_Handler,
 		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _DBOS_ListTasks_Handler,
+		},
 		{
 			MethodName: "ListDueTasks",
 			Handler:    _DBOS_ListDueTasks_Handler,
 		},
+		{
+			MethodName: "ListPendingTasks",
+			Handler:    _DBOS_ListPendingTasks_Handler,
+		},
+		{
+			MethodName: "CancelTask",
+			Handler:    _DBOS_CancelTask_Handler,
+		},
+		{
+			MethodName: "LeaseTask",
+			Handler:    _DBOS_LeaseTask_Handler,
+		},
+		{
+			MethodName: "AckTasks",
+			Handler:    _DBOS_AckTasks_Handler,
+		},
+		{
+			MethodName: "NackTasks",
+			Handler:    _DBOS_NackTasks_Handler,
+		},
+		{
+			MethodName: "ExtendTaskVisibility",
+			Handler:    _DBOS_ExtendTaskVisibility_Handler,
+		},
+		{
+			MethodName: "ReportTaskProgress",
+			Handler:    _DBOS_ReportTaskProgress_Handler,
+		},
+		{
+			MethodName: "ScheduleVerifiedTask",
+			Handler:    _DBOS_ScheduleVerifiedTask_Handler,
+		},
+		{
+			MethodName: "GetVerification",
+			Handler:    _DBOS_GetVerification_Handler,
+		},
+		{
+			MethodName: "BroadcastTask",
+			Handler:    _DBOS_BroadcastTask_Handler,
+		},
+		{
+			MethodName: "GetBroadcastStatus",
+			Handler:    _DBOS_GetBroadcastStatus_Handler,
+		},
+		{
+			MethodName: "CreateView",
+			Handler:    _DBOS_CreateView_Handler,
+		},
+		{
+			MethodName: "ListViews",
+			Handler:    _DBOS_ListViews_Handler,
+		},
+		{
+			MethodName: "DeleteView",
+			Handler:    _DBOS_DeleteView_Handler,
+		},
+		{
+			MethodName: "QueryView",
+			Handler:    _DBOS_QueryView_Handler,
+		},
+		{
+			MethodName: "CreateExtractionRule",
+			Handler:    _DBOS_CreateExtractionRule_Handler,
+		},
+		{
+			MethodName: "ListExtractionRules",
+			Handler:    _DBOS_ListExtractionRules_Handler,
+		},
+		{
+			MethodName: "DeleteExtractionRule",
+			Handler:    _DBOS_DeleteExtractionRule_Handler,
+		},
+		{
+			MethodName: "QueryResults",
+			Handler:    _DBOS_QueryResults_Handler,
+		},
+		{
+			MethodName: "CreateSavedQuery",
+			Handler:    _DBOS_CreateSavedQuery_Handler,
+		},
+		{
+			MethodName: "GetSavedQuery",
+			Handler:    _DBOS_GetSavedQuery_Handler,
+		},
+		{
+			MethodName: "ListSavedQueries",
+			Handler:    _DBOS_ListSavedQueries_Handler,
+		},
+		{
+			MethodName: "UpdateSavedQuery",
+			Handler:    _DBOS_UpdateSavedQuery_Handler,
+		},
+		{
+			MethodName: "DeleteSavedQuery",
+			Handler:    _DBOS_DeleteSavedQuery_Handler,
+		},
+		{
+			MethodName: "ExecuteSavedQuery",
+			Handler:    _DBOS_ExecuteSavedQuery_Handler,
+		},
+		{
+			MethodName: "CreateAlertRule",
+			Handler:    _DBOS_CreateAlertRule_Handler,
+		},
+		{
+			MethodName: "ListAlertRules",
+			Handler:    _DBOS_ListAlertRules_Handler,
+		},
+		{
+			MethodName: "DeleteAlertRule",
+			Handler:    _DBOS_DeleteAlertRule_Handler,
+		},
+		{
+			MethodName: "CreateMaintenanceWindow",
+			Handler:    _DBOS_CreateMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "GetMaintenanceWindow",
+			Handler:    _DBOS_GetMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "ListMaintenanceWindows",
+			Handler:    _DBOS_ListMaintenanceWindows_Handler,
+		},
+		{
+			MethodName: "UpdateMaintenanceWindow",
+			Handler:    _DBOS_UpdateMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "DeleteMaintenanceWindow",
+			Handler:    _DBOS_DeleteMaintenanceWindow_Handler,
+		},
+		{
+			MethodName: "GetTrends",
+			Handler:    _DBOS_GetTrends_Handler,
+		},
+		{
+			MethodName: "CreateCampaign",
+			Handler:    _DBOS_CreateCampaign_Handler,
+		},
+		{
+			MethodName: "GetCampaign",
+			Handler:    _DBOS_GetCampaign_Handler,
+		},
+		{
+			MethodName: "ListCampaigns",
+			Handler:    _DBOS_ListCampaigns_Handler,
+		},
+		{
+			MethodName: "StopCampaign",
+			Handler:    _DBOS_StopCampaign_Handler,
+		},
+		{
+			MethodName: "ListCampaignResults",
+			Handler:    _DBOS_ListCampaignResults_Handler,
+		},
+		{
+			MethodName: "ApplySpec",
+			Handler:    _DBOS_ApplySpec_Handler,
+		},
+		{
+			MethodName: "GetDrift",
+			Handler:    _DBOS_GetDrift_Handler,
+		},
+		{
+			MethodName: "PlanCapacity",
+			Handler:    _DBOS_PlanCapacity_Handler,
+		},
+		{
+			MethodName: "GetExecutionStats",
+			Handler:    _DBOS_GetExecutionStats_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _DBOS_GetUsage_Handler,
+		},
+		{
+			MethodName: "GetEvents",
+			Handler:    _DBOS_GetEvents_Handler,
+		},
+		{
+			MethodName: "ListStateEvents",
+			Handler:    _DBOS_ListStateEvents_Handler,
+		},
+		{
+			MethodName: "RebuildState",
+			Handler:    _DBOS_RebuildState_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAgents",
+			Handler:       _DBOS_WatchAgents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchModuleStates",
+			Handler:       _DBOS_WatchModuleStates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamResults",
+			Handler:       _DBOS_StreamResults_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportResults",
+			Handler:       _DBOS_ExportResults_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchIncidents",
+			Handler:       _DBOS_WatchIncidents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTasks",
+			Handler:       _DBOS_StreamTasks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _DBOS_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportSnapshot",
+			Handler:       _DBOS_ExportSnapshot_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/dbos.proto",
 }
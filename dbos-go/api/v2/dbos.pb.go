@@ -0,0 +1,1688 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: api/v2/dbos.proto
+
+// Package dbos.v2 is the resource-oriented DBOS API. Resources have names
+// ("agents/{agent}", "tasks/{task}", "agents/{agent}/results/{result}"),
+// list methods page with page_size and page_token, updates take a field
+// mask, and failures are reported as gRPC status codes rather than in
+// response fields. The v1 API (package dbos) stays served alongside it.
+
+package apiv2
+
+import (
+	_ "github.com/internet-measurement-network/dbos/api/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// State is the lifecycle state of a task
+type Task_State int32
+
+const (
+	Task_STATE_UNSPECIFIED Task_State = 0
+	Task_PENDING           Task_State = 1
+	Task_RUNNING           Task_State = 2
+	Task_COMPLETED         Task_State = 3
+	Task_FAILED            Task_State = 4
+	Task_CANCELLED         Task_State = 5
+)
+
+// Enum value maps for Task_State.
+var (
+	Task_State_name = map[int32]string{
+		0: "STATE_UNSPECIFIED",
+		1: "PENDING",
+		2: "RUNNING",
+		3: "COMPLETED",
+		4: "FAILED",
+		5: "CANCELLED",
+	}
+	Task_State_value = map[string]int32{
+		"STATE_UNSPECIFIED": 0,
+		"PENDING":           1,
+		"RUNNING":           2,
+		"COMPLETED":         3,
+		"FAILED":            4,
+		"CANCELLED":         5,
+	}
+)
+
+func (x Task_State) Enum() *Task_State {
+	p := new(Task_State)
+	*p = x
+	return p
+}
+
+func (x Task_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Task_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v2_dbos_proto_enumTypes[0].Descriptor()
+}
+
+func (Task_State) Type() protoreflect.EnumType {
+	return &file_api_v2_dbos_proto_enumTypes[0]
+}
+
+func (x Task_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Task_State.Descriptor instead.
+func (Task_State) EnumDescriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// Type tells whether a task runs once or is re-issued periodically
+type Task_Type int32
+
+const (
+	Task_TYPE_UNSPECIFIED Task_Type = 0 // treated as ONE_SHOT
+	Task_ONE_SHOT         Task_Type = 1
+	Task_CONTINUOUS       Task_Type = 2
+)
+
+// Enum value maps for Task_Type.
+var (
+	Task_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "ONE_SHOT",
+		2: "CONTINUOUS",
+	}
+	Task_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"ONE_SHOT":         1,
+		"CONTINUOUS":       2,
+	}
+)
+
+func (x Task_Type) Enum() *Task_Type {
+	p := new(Task_Type)
+	*p = x
+	return p
+}
+
+func (x Task_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Task_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v2_dbos_proto_enumTypes[1].Descriptor()
+}
+
+func (Task_Type) Type() protoreflect.EnumType {
+	return &file_api_v2_dbos_proto_enumTypes[1]
+}
+
+func (x Task_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Task_Type.Descriptor instead.
+func (Task_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{1, 1}
+}
+
+// Origin tells how the measurement came about
+type Result_Origin int32
+
+const (
+	Result_ORIGIN_UNSPECIFIED Result_Origin = 0
+	Result_SCHEDULED          Result_Origin = 1 // answers a DBOS task
+	Result_LOCAL              Result_Origin = 2 // scheduled by the agent itself
+)
+
+// Enum value maps for Result_Origin.
+var (
+	Result_Origin_name = map[int32]string{
+		0: "ORIGIN_UNSPECIFIED",
+		1: "SCHEDULED",
+		2: "LOCAL",
+	}
+	Result_Origin_value = map[string]int32{
+		"ORIGIN_UNSPECIFIED": 0,
+		"SCHEDULED":          1,
+		"LOCAL":              2,
+	}
+)
+
+func (x Result_Origin) Enum() *Result_Origin {
+	p := new(Result_Origin)
+	*p = x
+	return p
+}
+
+func (x Result_Origin) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Result_Origin) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v2_dbos_proto_enumTypes[2].Descriptor()
+}
+
+func (Result_Origin) Type() protoreflect.EnumType {
+	return &file_api_v2_dbos_proto_enumTypes[2]
+}
+
+func (x Result_Origin) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Result_Origin.Descriptor instead.
+func (Result_Origin) EnumDescriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{2, 0}
+}
+
+// Agent is a measurement agent
+type Agent struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Name                 string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // "agents/{agent}"
+	Hostname             string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Alive                bool                   `protobuf:"varint,3,opt,name=alive,proto3" json:"alive,omitempty"`                                       // output only
+	LastSeenTime         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen_time,json=lastSeenTime,proto3" json:"last_seen_time,omitempty"`    // output only
+	FirstSeenTime        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=first_seen_time,json=firstSeenTime,proto3" json:"first_seen_time,omitempty"` // output only
+	Config               map[string]string      `protobuf:"bytes,6,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	HeartbeatCount       int32                  `protobuf:"varint,7,opt,name=heartbeat_count,json=heartbeatCount,proto3" json:"heartbeat_count,omitempty"` // output only
+	Labels               map[string]string      `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	MaintenanceWindowIds []string               `protobuf:"bytes,9,rep,name=maintenance_window_ids,json=maintenanceWindowIds,proto3" json:"maintenance_window_ids,omitempty"` // output only; maintenance windows in progress covering the agent
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Agent) Reset() {
+	*x = Agent{}
+	mi := &file_api_v2_dbos_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Agent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Agent) ProtoMessage() {}
+
+func (x *Agent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Agent.ProtoReflect.Descriptor instead.
+func (*Agent) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Agent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Agent) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Agent) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+func (x *Agent) GetLastSeenTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenTime
+	}
+	return nil
+}
+
+func (x *Agent) GetFirstSeenTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeenTime
+	}
+	return nil
+}
+
+func (x *Agent) GetConfig() map[string]string {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Agent) GetHeartbeatCount() int32 {
+	if x != nil {
+		return x.HeartbeatCount
+	}
+	return 0
+}
+
+func (x *Agent) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Agent) GetMaintenanceWindowIds() []string {
+	if x != nil {
+		return x.MaintenanceWindowIds
+	}
+	return nil
+}
+
+// Task is a measurement an agent is asked to run
+type Task struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // "tasks/{task}"
+	Agent          string                 `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"` // "agents/{agent}"; empty for a group task
+	ModuleName     string                 `protobuf:"bytes,3,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Payload        []byte                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`                               // JSON-encoded task payload
+	ScheduleTime   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=schedule_time,json=scheduleTime,proto3" json:"schedule_time,omitempty"` // unset runs the task now
+	CreateTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`       // output only
+	State          Task_State             `protobuf:"varint,7,opt,name=state,proto3,enum=dbos.v2.Task_State" json:"state,omitempty"`          // output only
+	Type           Task_Type              `protobuf:"varint,8,opt,name=type,proto3,enum=dbos.v2.Task_Type" json:"type,omitempty"`
+	Interval       *durationpb.Duration   `protobuf:"bytes,9,opt,name=interval,proto3" json:"interval,omitempty"`                                                                            // re-issue interval of continuous tasks
+	Parent         string                 `protobuf:"bytes,10,opt,name=parent,proto3" json:"parent,omitempty"`                                                                               // output only; "tasks/{task}" of the continuous task that issued this instance
+	VerificationId string                 `protobuf:"bytes,11,opt,name=verification_id,json=verificationId,proto3" json:"verification_id,omitempty"`                                         // output only; redundant measurement this task is a replica of
+	CampaignId     string                 `protobuf:"bytes,12,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`                                                     // output only; campaign whose round issued this task
+	Selector       map[string]string      `protobuf:"bytes,13,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // makes a group task, run by every live agent with these labels; agent is then left empty
+	Agents         []string               `protobuf:"bytes,14,rep,name=agents,proto3" json:"agents,omitempty"`                                                                               // output only; "agents/{agent}" a group task issued instances to
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_api_v2_dbos_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Task) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Task) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+func (x *Task) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *Task) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Task) GetScheduleTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduleTime
+	}
+	return nil
+}
+
+func (x *Task) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Task) GetState() Task_State {
+	if x != nil {
+		return x.State
+	}
+	return Task_STATE_UNSPECIFIED
+}
+
+func (x *Task) GetType() Task_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Task_TYPE_UNSPECIFIED
+}
+
+func (x *Task) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Task) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *Task) GetVerificationId() string {
+	if x != nil {
+		return x.VerificationId
+	}
+	return ""
+}
+
+func (x *Task) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *Task) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *Task) GetAgents() []string {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+// Result is the outcome of a measurement
+type Result struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // "agents/{agent}/results/{result}"; the result ID is the task ID, or starts with "local-"
+	ModuleName    string                 `protobuf:"bytes,2,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                                            // JSON-encoded result data
+	MeasureTime   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=measure_time,json=measureTime,proto3" json:"measure_time,omitempty"`           // derived from agent_time if that is set; unset on create means now
+	Origin        Result_Origin          `protobuf:"varint,5,opt,name=origin,proto3,enum=dbos.v2.Result_Origin" json:"origin,omitempty"`            // output only
+	Sequence      int64                  `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`                                   // output only; per-agent, monotonically increasing
+	AgentTime     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=agent_time,json=agentTime,proto3" json:"agent_time,omitempty"`                 // input only; measurement time on the agent's clock, corrected for its clock skew
+	ClockOffsetMs float64                `protobuf:"fixed64,8,opt,name=clock_offset_ms,json=clockOffsetMs,proto3" json:"clock_offset_ms,omitempty"` // output only; correction applied to agent_time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	mi := &file_api_v2_dbos_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Result) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Result) GetModuleName() string {
+	if x != nil {
+		return x.ModuleName
+	}
+	return ""
+}
+
+func (x *Result) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Result) GetMeasureTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MeasureTime
+	}
+	return nil
+}
+
+func (x *Result) GetOrigin() Result_Origin {
+	if x != nil {
+		return x.Origin
+	}
+	return Result_ORIGIN_UNSPECIFIED
+}
+
+func (x *Result) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Result) GetAgentTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AgentTime
+	}
+	return nil
+}
+
+func (x *Result) GetClockOffsetMs() float64 {
+	if x != nil {
+		return x.ClockOffsetMs
+	}
+	return 0
+}
+
+type GetAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"` // Agent fields to return, e.g. "name,labels"; empty returns all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentRequest) Reset() {
+	*x = GetAgentRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentRequest) ProtoMessage() {}
+
+func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAgentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetAgentRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type ListAgentsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // approximate; 0 for 100, at most 1000
+	PageToken string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	ReadMask  *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`    // Agent fields to return; empty returns all
+	// "name", "hostname" or "last_seen_time", optionally followed by " desc";
+	// ordered pages hold exactly page_size agents. Empty lists agents unordered.
+	OrderBy       string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAgentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAgentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListAgentsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+type ListAgentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agents        []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_api_v2_dbos_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsResponse) ProtoMessage() {}
+
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListAgentsResponse) GetAgents() []*Agent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *ListAgentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CreateAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Agent         *Agent                 `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAgentRequest) Reset() {
+	*x = CreateAgentRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAgentRequest) ProtoMessage() {}
+
+func (x *CreateAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAgentRequest.ProtoReflect.Descriptor instead.
+func (*CreateAgentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CreateAgentRequest) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+type UpdateAgentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Agent *Agent                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	// Fields to update: "hostname", "labels", "config", or a single key as
+	// "labels.<key>" or "config.<key>" (removed if absent from agent). Empty
+	// updates the fields set in agent; "*" replaces all of them.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAgentRequest) Reset() {
+	*x = UpdateAgentRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAgentRequest) ProtoMessage() {}
+
+func (x *UpdateAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAgentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAgentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateAgentRequest) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *UpdateAgentRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAgentRequest) Reset() {
+	*x = DeleteAgentRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAgentRequest) ProtoMessage() {}
+
+func (x *DeleteAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAgentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAgentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteAgentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // registers the agent if unknown
+	Hostname      string                 `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HeartbeatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+type CreateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // empty generates one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateTaskRequest) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CancelTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTaskRequest) Reset() {
+	*x = CancelTaskRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTaskRequest) ProtoMessage() {}
+
+func (x *CancelTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTaskRequest.ProtoReflect.Descriptor instead.
+func (*CancelTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Parent        string                 `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"` // "agents/{agent}"
+	Result        *Result                `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	ResultId      string                 `protobuf:"bytes,3,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"` // the task ID, or "local-..." for measurements the agent scheduled itself
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateResultRequest) Reset() {
+	*x = CreateResultRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResultRequest) ProtoMessage() {}
+
+func (x *CreateResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResultRequest.ProtoReflect.Descriptor instead.
+func (*CreateResultRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateResultRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *CreateResultRequest) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CreateResultRequest) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+type GetResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"` // Result fields to return, e.g. without "data"; empty returns all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResultRequest) Reset() {
+	*x = GetResultRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResultRequest) ProtoMessage() {}
+
+func (x *GetResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResultRequest.ProtoReflect.Descriptor instead.
+func (*GetResultRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetResultRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetResultRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type ListResultsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Parent    string                 `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`                        // "agents/{agent}"
+	PageSize  int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 0 for 100, at most 1000
+	PageToken string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page
+	// Conjunction of restrictions joined by AND: module_name = "ping_module",
+	// or measure_time compared with >=, >, < or <= to an RFC 3339 time,
+	// e.g. measure_time >= "2024-01-01T00:00:00Z" AND measure_time < "2024-01-02T00:00:00Z"
+	Filter        string                 `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"` // Result fields to return; empty returns all
+	OrderBy       string                 `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`    // "measure_time" (default) or "sequence", optionally followed by " desc"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResultsRequest) Reset() {
+	*x = ListResultsRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResultsRequest) ProtoMessage() {}
+
+func (x *ListResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResultsRequest.ProtoReflect.Descriptor instead.
+func (*ListResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListResultsRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListResultsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListResultsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListResultsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListResultsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListResultsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+type ListResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*Result              `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResultsResponse) Reset() {
+	*x = ListResultsResponse{}
+	mi := &file_api_v2_dbos_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResultsResponse) ProtoMessage() {}
+
+func (x *ListResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResultsResponse.ProtoReflect.Descriptor instead.
+func (*ListResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListResultsResponse) GetResults() []*Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ListResultsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// CountResultsRequest counts the results ListResultsRequest would list,
+// without transferring them
+type CountResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Parent        string                 `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"` // "agents/{agent}"
+	Filter        string                 `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"` // as in ListResultsRequest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountResultsRequest) Reset() {
+	*x = CountResultsRequest{}
+	mi := &file_api_v2_dbos_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResultsRequest) ProtoMessage() {}
+
+func (x *CountResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResultsRequest.ProtoReflect.Descriptor instead.
+func (*CountResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CountResultsRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *CountResultsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+type CountResultsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountResultsResponse) Reset() {
+	*x = CountResultsResponse{}
+	mi := &file_api_v2_dbos_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountResultsResponse) ProtoMessage() {}
+
+func (x *CountResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v2_dbos_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountResultsResponse.ProtoReflect.Descriptor instead.
+func (*CountResultsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v2_dbos_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CountResultsResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_api_v2_dbos_proto protoreflect.FileDescriptor
+
+const file_api_v2_dbos_proto_rawDesc = "" +
+	"\n" +
+	"\x11api/v2/dbos.proto\x12\adbos.v2\x1a\x1bapi/validate/validate.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x97\x04\n" +
+	"\x05Agent\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\bhostname\x18\x02 \x01(\tB\x05\x98\xb5\x18\xff\x01R\bhostname\x12\x14\n" +
+	"\x05alive\x18\x03 \x01(\bR\x05alive\x12@\n" +
+	"\x0elast_seen_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\flastSeenTime\x12B\n" +
+	"\x0ffirst_seen_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rfirstSeenTime\x122\n" +
+	"\x06config\x18\x06 \x03(\v2\x1a.dbos.v2.Agent.ConfigEntryR\x06config\x12'\n" +
+	"\x0fheartbeat_count\x18\a \x01(\x05R\x0eheartbeatCount\x122\n" +
+	"\x06labels\x18\b \x03(\v2\x1a.dbos.v2.Agent.LabelsEntryR\x06labels\x124\n" +
+	"\x16maintenance_window_ids\x18\t \x03(\tR\x14maintenanceWindowIds\x1a9\n" +
+	"\vConfigEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x89\x06\n" +
+	"\x04Task\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05agent\x18\x02 \x01(\tR\x05agent\x12%\n" +
+	"\vmodule_name\x18\x03 \x01(\tB\x04\x88\xb5\x18\x01R\n" +
+	"moduleName\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\fR\apayload\x12?\n" +
+	"\rschedule_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fscheduleTime\x12;\n" +
+	"\vcreate_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12)\n" +
+	"\x05state\x18\a \x01(\x0e2\x13.dbos.v2.Task.StateR\x05state\x12&\n" +
+	"\x04type\x18\b \x01(\x0e2\x12.dbos.v2.Task.TypeR\x04type\x125\n" +
+	"\binterval\x18\t \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x16\n" +
+	"\x06parent\x18\n" +
+	" \x01(\tR\x06parent\x12'\n" +
+	"\x0fverification_id\x18\v \x01(\tR\x0everificationId\x12\x1f\n" +
+	"\vcampaign_id\x18\f \x01(\tR\n" +
+	"campaignId\x127\n" +
+	"\bselector\x18\r \x03(\v2\x1b.dbos.v2.Task.SelectorEntryR\bselector\x12\x16\n" +
+	"\x06agents\x18\x0e \x03(\tR\x06agents\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"b\n" +
+	"\x05State\x12\x15\n" +
+	"\x11STATE_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aPENDING\x10\x01\x12\v\n" +
+	"\aRUNNING\x10\x02\x12\r\n" +
+	"\tCOMPLETED\x10\x03\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x04\x12\r\n" +
+	"\tCANCELLED\x10\x05\":\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bONE_SHOT\x10\x01\x12\x0e\n" +
+	"\n" +
+	"CONTINUOUS\x10\x02\"\xfb\x02\n" +
+	"\x06Result\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vmodule_name\x18\x02 \x01(\tR\n" +
+	"moduleName\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12=\n" +
+	"\fmeasure_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vmeasureTime\x12.\n" +
+	"\x06origin\x18\x05 \x01(\x0e2\x16.dbos.v2.Result.OriginR\x06origin\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x03R\bsequence\x129\n" +
+	"\n" +
+	"agent_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tagentTime\x12&\n" +
+	"\x0fclock_offset_ms\x18\b \x01(\x01R\rclockOffsetMs\":\n" +
+	"\x06Origin\x12\x16\n" +
+	"\x12ORIGIN_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tSCHEDULED\x10\x01\x12\t\n" +
+	"\x05LOCAL\x10\x02\"^\n" +
+	"\x0fGetAgentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x127\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\xb0\x01\n" +
+	"\x11ListAgentsRequest\x12(\n" +
+	"\tpage_size\x18\x01 \x01(\x05B\v\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x127\n" +
+	"\tread_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\"d\n" +
+	"\x12ListAgentsResponse\x12&\n" +
+	"\x06agents\x18\x01 \x03(\v2\x0e.dbos.v2.AgentR\x06agents\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"e\n" +
+	"\x12CreateAgentRequest\x12#\n" +
+	"\bagent_id\x18\x01 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\aagentId\x12*\n" +
+	"\x05agent\x18\x02 \x01(\v2\x0e.dbos.v2.AgentB\x04\x88\xb5\x18\x01R\x05agent\"}\n" +
+	"\x12UpdateAgentRequest\x12*\n" +
+	"\x05agent\x18\x01 \x01(\v2\x0e.dbos.v2.AgentB\x04\x88\xb5\x18\x01R\x05agent\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"(\n" +
+	"\x12DeleteAgentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"I\n" +
+	"\x10HeartbeatRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\bhostname\x18\x02 \x01(\tB\x05\x98\xb5\x18\xff\x01R\bhostname\"[\n" +
+	"\x11CreateTaskRequest\x12'\n" +
+	"\x04task\x18\x01 \x01(\v2\r.dbos.v2.TaskB\x04\x88\xb5\x18\x01R\x04task\x12\x1d\n" +
+	"\atask_id\x18\x02 \x01(\tB\x04\x90\xb5\x18\x01R\x06taskId\"$\n" +
+	"\x0eGetTaskRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"'\n" +
+	"\x11CancelTaskRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x83\x01\n" +
+	"\x13CreateResultRequest\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\x12-\n" +
+	"\x06result\x18\x02 \x01(\v2\x0f.dbos.v2.ResultB\x04\x88\xb5\x18\x01R\x06result\x12%\n" +
+	"\tresult_id\x18\x03 \x01(\tB\b\x88\xb5\x18\x01\x90\xb5\x18\x01R\bresultId\"_\n" +
+	"\x10GetResultRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x127\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\xe1\x01\n" +
+	"\x12ListResultsRequest\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\x12(\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\v\xb9\xb5\x18\x00\x00\x00\x00\x00\x00\x00\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06filter\x18\x04 \x01(\tR\x06filter\x127\n" +
+	"\tread_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\x12\x19\n" +
+	"\border_by\x18\x06 \x01(\tR\aorderBy\"h\n" +
+	"\x13ListResultsResponse\x12)\n" +
+	"\aresults\x18\x01 \x03(\v2\x0f.dbos.v2.ResultR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"E\n" +
+	"\x13CountResultsRequest\x12\x16\n" +
+	"\x06parent\x18\x01 \x01(\tR\x06parent\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\",\n" +
+	"\x14CountResultsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count2\xab\x06\n" +
+	"\x04DBOS\x124\n" +
+	"\bGetAgent\x12\x18.dbos.v2.GetAgentRequest\x1a\x0e.dbos.v2.Agent\x12E\n" +
+	"\n" +
+	"ListAgents\x12\x1a.dbos.v2.ListAgentsRequest\x1a\x1b.dbos.v2.ListAgentsResponse\x12:\n" +
+	"\vCreateAgent\x12\x1b.dbos.v2.CreateAgentRequest\x1a\x0e.dbos.v2.Agent\x12:\n" +
+	"\vUpdateAgent\x12\x1b.dbos.v2.UpdateAgentRequest\x1a\x0e.dbos.v2.Agent\x12B\n" +
+	"\vDeleteAgent\x12\x1b.dbos.v2.DeleteAgentRequest\x1a\x16.google.protobuf.Empty\x126\n" +
+	"\tHeartbeat\x12\x19.dbos.v2.HeartbeatRequest\x1a\x0e.dbos.v2.Agent\x127\n" +
+	"\n" +
+	"CreateTask\x12\x1a.dbos.v2.CreateTaskRequest\x1a\r.dbos.v2.Task\x121\n" +
+	"\aGetTask\x12\x17.dbos.v2.GetTaskRequest\x1a\r.dbos.v2.Task\x127\n" +
+	"\n" +
+	"CancelTask\x12\x1a.dbos.v2.CancelTaskRequest\x1a\r.dbos.v2.Task\x12=\n" +
+	"\fCreateResult\x12\x1c.dbos.v2.CreateResultRequest\x1a\x0f.dbos.v2.Result\x127\n" +
+	"\tGetResult\x12\x19.dbos.v2.GetResultRequest\x1a\x0f.dbos.v2.Result\x12H\n" +
+	"\vListResults\x12\x1b.dbos.v2.ListResultsRequest\x1a\x1c.dbos.v2.ListResultsResponse\x12K\n" +
+	"\fCountResults\x12\x1c.dbos.v2.CountResultsRequest\x1a\x1d.dbos.v2.CountResultsResponseB\x10Z\x0e./api/v2;apiv2b\x06proto3"
+
+var (
+	file_api_v2_dbos_proto_rawDescOnce sync.Once
+	file_api_v2_dbos_proto_rawDescData []byte
+)
+
+func file_api_v2_dbos_proto_rawDescGZIP() []byte {
+	file_api_v2_dbos_proto_rawDescOnce.Do(func() {
+		file_api_v2_dbos_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v2_dbos_proto_rawDesc), len(file_api_v2_dbos_proto_rawDesc)))
+	})
+	return file_api_v2_dbos_proto_rawDescData
+}
+
+var file_api_v2_dbos_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_v2_dbos_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_api_v2_dbos_proto_goTypes = []any{
+	(Task_State)(0),               // 0: dbos.v2.Task.State
+	(Task_Type)(0),                // 1: dbos.v2.Task.Type
+	(Result_Origin)(0),            // 2: dbos.v2.Result.Origin
+	(*Agent)(nil),                 // 3: dbos.v2.Agent
+	(*Task)(nil),                  // 4: dbos.v2.Task
+	(*Result)(nil),                // 5: dbos.v2.Result
+	(*GetAgentRequest)(nil),       // 6: dbos.v2.GetAgentRequest
+	(*ListAgentsRequest)(nil),     // 7: dbos.v2.ListAgentsRequest
+	(*ListAgentsResponse)(nil),    // 8: dbos.v2.ListAgentsResponse
+	(*CreateAgentRequest)(nil),    // 9: dbos.v2.CreateAgentRequest
+	(*UpdateAgentRequest)(nil),    // 10: dbos.v2.UpdateAgentRequest
+	(*DeleteAgentRequest)(nil),    // 11: dbos.v2.DeleteAgentRequest
+	(*HeartbeatRequest)(nil),      // 12: dbos.v2.HeartbeatRequest
+	(*CreateTaskRequest)(nil),     // 13: dbos.v2.CreateTaskRequest
+	(*GetTaskRequest)(nil),        // 14: dbos.v2.GetTaskRequest
+	(*CancelTaskRequest)(nil),     // 15: dbos.v2.CancelTaskRequest
+	(*CreateResultRequest)(nil),   // 16: dbos.v2.CreateResultRequest
+	(*GetResultRequest)(nil),      // 17: dbos.v2.GetResultRequest
+	(*ListResultsRequest)(nil),    // 18: dbos.v2.ListResultsRequest
+	(*ListResultsResponse)(nil),   // 19: dbos.v2.ListResultsResponse
+	(*CountResultsRequest)(nil),   // 20: dbos.v2.CountResultsRequest
+	(*CountResultsResponse)(nil),  // 21: dbos.v2.CountResultsResponse
+	nil,                           // 22: dbos.v2.Agent.ConfigEntry
+	nil,                           // 23: dbos.v2.Agent.LabelsEntry
+	nil,                           // 24: dbos.v2.Task.SelectorEntry
+	(*timestamppb.Timestamp)(nil), // 25: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 26: google.protobuf.Duration
+	(*fieldmaskpb.FieldMask)(nil), // 27: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),         // 28: google.protobuf.Empty
+}
+var file_api_v2_dbos_proto_depIdxs = []int32{
+	25, // 0: dbos.v2.Agent.last_seen_time:type_name -> google.protobuf.Timestamp
+	25, // 1: dbos.v2.Agent.first_seen_time:type_name -> google.protobuf.Timestamp
+	22, // 2: dbos.v2.Agent.config:type_name -> dbos.v2.Agent.ConfigEntry
+	23, // 3: dbos.v2.Agent.labels:type_name -> dbos.v2.Agent.LabelsEntry
+	25, // 4: dbos.v2.Task.schedule_time:type_name -> google.protobuf.Timestamp
+	25, // 5: dbos.v2.Task.create_time:type_name -> google.protobuf.Timestamp
+	0,  // 6: dbos.v2.Task.state:type_name -> dbos.v2.Task.State
+	1,  // 7: dbos.v2.Task.type:type_name -> dbos.v2.Task.Type
+	26, // 8: dbos.v2.Task.interval:type_name -> google.protobuf.Duration
+	24, // 9: dbos.v2.Task.selector:type_name -> dbos.v2.Task.SelectorEntry
+	25, // 10: dbos.v2.Result.measure_time:type_name -> google.protobuf.Timestamp
+	2,  // 11: dbos.v2.Result.origin:type_name -> dbos.v2.Result.Origin
+	25, // 12: dbos.v2.Result.agent_time:type_name -> google.protobuf.Timestamp
+	27, // 13: dbos.v2.GetAgentRequest.read_mask:type_name -> google.protobuf.FieldMask
+	27, // 14: dbos.v2.ListAgentsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	3,  // 15: dbos.v2.ListAgentsResponse.agents:type_name -> dbos.v2.Agent
+	3,  // 16: dbos.v2.CreateAgentRequest.agent:type_name -> dbos.v2.Agent
+	3,  // 17: dbos.v2.UpdateAgentRequest.agent:type_name -> dbos.v2.Agent
+	27, // 18: dbos.v2.UpdateAgentRequest.update_mask:type_name -> google.protobuf.FieldMask
+	4,  // 19: dbos.v2.CreateTaskRequest.task:type_name -> dbos.v2.Task
+	5,  // 20: dbos.v2.CreateResultRequest.result:type_name -> dbos.v2.Result
+	27, // 21: dbos.v2.GetResultRequest.read_mask:type_name -> google.protobuf.FieldMask
+	27, // 22: dbos.v2.ListResultsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	5,  // 23: dbos.v2.ListResultsResponse.results:type_name -> dbos.v2.Result
+	6,  // 24: dbos.v2.DBOS.GetAgent:input_type -> dbos.v2.GetAgentRequest
+	7,  // 25: dbos.v2.DBOS.ListAgents:input_type -> dbos.v2.ListAgentsRequest
+	9,  // 26: dbos.v2.DBOS.CreateAgent:input_type -> dbos.v2.CreateAgentRequest
+	10, // 27: dbos.v2.DBOS.UpdateAgent:input_type -> dbos.v2.UpdateAgentRequest
+	11, // 28: dbos.v2.DBOS.DeleteAgent:input_type -> dbos.v2.DeleteAgentRequest
+	12, // 29: dbos.v2.DBOS.Heartbeat:input_type -> dbos.v2.HeartbeatRequest
+	13, // 30: dbos.v2.DBOS.CreateTask:input_type -> dbos.v2.CreateTaskRequest
+	14, // 31: dbos.v2.DBOS.GetTask:input_type -> dbos.v2.GetTaskRequest
+	15, // 32: dbos.v2.DBOS.CancelTask:input_type -> dbos.v2.CancelTaskRequest
+	16, // 33: dbos.v2.DBOS.CreateResult:input_type -> dbos.v2.CreateResultRequest
+	17, // 34: dbos.v2.DBOS.GetResult:input_type -> dbos.v2.GetResultRequest
+	18, // 35: dbos.v2.DBOS.ListResults:input_type -> dbos.v2.ListResultsRequest
+	20, // 36: dbos.v2.DBOS.CountResults:input_type -> dbos.v2.CountResultsRequest
+	3,  // 37: dbos.v2.DBOS.GetAgent:output_type -> dbos.v2.Agent
+	8,  // 38: dbos.v2.DBOS.ListAgents:output_type -> dbos.v2.ListAgentsResponse
+	3,  // 39: dbos.v2.DBOS.CreateAgent:output_type -> dbos.v2.Agent
+	3,  // 40: dbos.v2.DBOS.UpdateAgent:output_type -> dbos.v2.Agent
+	28, // 41: dbos.v2.DBOS.DeleteAgent:output_type -> google.protobuf.Empty
+	3,  // 42: dbos.v2.DBOS.Heartbeat:output_type -> dbos.v2.Agent
+	4,  // 43: dbos.v2.DBOS.CreateTask:output_type -> dbos.v2.Task
+	4,  // 44: dbos.v2.DBOS.GetTask:output_type -> dbos.v2.Task
+	4,  // 45: dbos.v2.DBOS.CancelTask:output_type -> dbos.v2.Task
+	5,  // 46: dbos.v2.DBOS.CreateResult:output_type -> dbos.v2.Result
+	5,  // 47: dbos.v2.DBOS.GetResult:output_type -> dbos.v2.Result
+	19, // 48: dbos.v2.DBOS.ListResults:output_type -> dbos.v2.ListResultsResponse
+	21, // 49: dbos.v2.DBOS.CountResults:output_type -> dbos.v2.CountResultsResponse
+	37, // [37:50] is the sub-list for method output_type
+	24, // [24:37] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_api_v2_dbos_proto_init() }
+func file_api_v2_dbos_proto_init() {
+	if File_api_v2_dbos_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v2_dbos_proto_rawDesc), len(file_api_v2_dbos_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v2_dbos_proto_goTypes,
+		DependencyIndexes: file_api_v2_dbos_proto_depIdxs,
+		EnumInfos:         file_api_v2_dbos_proto_enumTypes,
+		MessageInfos:      file_api_v2_dbos_proto_msgTypes,
+	}.Build()
+	File_api_v2_dbos_proto = out.File
+	file_api_v2_dbos_proto_goTypes = nil
+	file_api_v2_dbos_proto_depIdxs = nil
+}
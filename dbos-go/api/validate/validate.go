@@ -0,0 +1,207 @@
+package validate
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxUnixTime is the last second of year 9999
+const maxUnixTime = 253402300799
+
+// rules are the annotations of a field
+type rules struct {
+	required bool
+	id       bool
+	maxLen   uint32
+	maxItems uint32
+	unixTime bool
+	in       []string
+	min, max *float64
+	skip     bool
+}
+
+// fieldRules caches the rules of fields by descriptor
+var fieldRules sync.Map // protoreflect.FieldDescriptor -> *rules
+
+// rulesOf returns the rules a field is annotated with
+func rulesOf(fd protoreflect.FieldDescriptor) *rules {
+	if r, ok := fieldRules.Load(fd); ok {
+		return r.(*rules)
+	}
+	r := &rules{}
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts != nil {
+		r.required = proto.GetExtension(opts, E_Required).(bool)
+		r.id = proto.GetExtension(opts, E_Id).(bool)
+		r.maxLen = proto.GetExtension(opts, E_MaxLen).(uint32)
+		r.maxItems = proto.GetExtension(opts, E_MaxItems).(uint32)
+		r.unixTime = proto.GetExtension(opts, E_UnixTime).(bool)
+		r.in = proto.GetExtension(opts, E_In).([]string)
+		if proto.HasExtension(opts, E_Min) {
+			min := proto.GetExtension(opts, E_Min).(float64)
+			r.min = &min
+		}
+		if proto.HasExtension(opts, E_Max) {
+			max := proto.GetExtension(opts, E_Max).(float64)
+			r.max = &max
+		}
+		r.skip = proto.GetExtension(opts, E_Skip).(bool)
+	}
+	fieldRules.Store(fd, r)
+	return r
+}
+
+// Message checks a message against the rules of its fields, recursing into
+// the messages it holds. The error is InvalidArgument and names the first
+// field breaking a rule by its path, e.g. "task.agent_id".
+func Message(m proto.Message) error {
+	return Field("", m)
+}
+
+// Field is Message for a message found at path in the request, which
+// prefixes the field paths errors name
+func Field(path string, m proto.Message) error {
+	return message(path, m.ProtoReflect())
+}
+
+func message(path string, m protoreflect.Message) error {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if err := field(join(path, string(fd.Name())), m, fd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func field(path string, m protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	r := rulesOf(fd)
+	if !m.Has(fd) {
+		if r.required {
+			return dberrors.New(dberrors.InvalidArgument, "%s is required", path)
+		}
+		return nil
+	}
+
+	v := m.Get(fd)
+	switch {
+	case fd.IsList():
+		list := v.List()
+		if r.maxItems > 0 && uint32(list.Len()) > r.maxItems {
+			return dberrors.New(dberrors.InvalidArgument, "%s has %d entries, more than %d", path, list.Len(), r.maxItems)
+		}
+		for i := 0; i < list.Len(); i++ {
+			if err := value(fmt.Sprintf("%s[%d]", path, i), fd, r, list.Get(i)); err != nil {
+				return err
+			}
+		}
+	case fd.IsMap():
+		entries := v.Map()
+		if r.maxItems > 0 && uint32(entries.Len()) > r.maxItems {
+			return dberrors.New(dberrors.InvalidArgument, "%s has %d entries, more than %d", path, entries.Len(), r.maxItems)
+		}
+		var err error
+		entries.Range(func(key protoreflect.MapKey, v protoreflect.Value) bool {
+			err = value(fmt.Sprintf("%s[%v]", path, key.Interface()), fd.MapValue(), r, v)
+			return err == nil
+		})
+		return err
+	default:
+		return value(path, fd, r, v)
+	}
+	return nil
+}
+
+// value checks a single value of a field: the field's value, or an entry
+// of a repeated or map field
+func value(path string, fd protoreflect.FieldDescriptor, r *rules, v protoreflect.Value) error {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		s := v.String()
+		if r.maxLen > 0 && uint32(len(s)) > r.maxLen {
+			return dberrors.New(dberrors.InvalidArgument, "%s is %d bytes long, longer than %d", path, len(s), r.maxLen)
+		}
+		if s == "" {
+			return nil
+		}
+		if r.id {
+			if err := ids.Validate(s); err != nil {
+				return dberrors.New(dberrors.InvalidArgument, "%s: %v", path, err)
+			}
+		}
+		if len(r.in) > 0 && !slices.Contains(r.in, s) {
+			return dberrors.New(dberrors.InvalidArgument, "%s must be one of %q, not %q", path, r.in, s)
+		}
+
+	case protoreflect.BytesKind:
+		if r.maxLen > 0 && uint32(len(v.Bytes())) > r.maxLen {
+			return dberrors.New(dberrors.InvalidArgument, "%s is %d bytes long, longer than %d", path, len(v.Bytes()), r.maxLen)
+		}
+
+	case protoreflect.EnumKind:
+		if fd.Enum().Values().ByNumber(v.Enum()) == nil {
+			return dberrors.New(dberrors.InvalidArgument, "%s: %d is not a %s value", path, v.Enum(), fd.Enum().Name())
+		}
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n := v.Int()
+		if r.unixTime && (n < 0 || n > maxUnixTime) {
+			return dberrors.New(dberrors.InvalidArgument, "%s: %d is not a Unix time from 1970 to 9999", path, n)
+		}
+		return bounds(path, r, float64(n))
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return bounds(path, r, float64(v.Uint()))
+
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return bounds(path, r, v.Float())
+
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if r.skip {
+			return nil
+		}
+		switch m := v.Message().Interface().(type) {
+		case *timestamppb.Timestamp:
+			if err := m.CheckValid(); err != nil {
+				return dberrors.New(dberrors.InvalidArgument, "%s: %v", path, err)
+			}
+			return nil
+		case *durationpb.Duration:
+			if err := m.CheckValid(); err != nil {
+				return dberrors.New(dberrors.InvalidArgument, "%s: %v", path, err)
+			}
+			return nil
+		}
+		return message(path, v.Message())
+	}
+	return nil
+}
+
+// bounds checks a number against a field's min and max
+func bounds(path string, r *rules, n float64) error {
+	if r.min != nil && n < *r.min {
+		return dberrors.New(dberrors.InvalidArgument, "%s must be at least %v", path, *r.min)
+	}
+	if r.max != nil && n > *r.max {
+		return dberrors.New(dberrors.InvalidArgument, "%s must be at most %v", path, *r.max)
+	}
+	return nil
+}
+
+// join appends a field name to a path
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
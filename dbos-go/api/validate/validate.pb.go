@@ -0,0 +1,202 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.31.1
+// source: api/validate/validate.proto
+
+// Field rules of the DBOS APIs, checked on every request before its handler
+// runs (see validate.Message). Besides the annotated rules, every request is
+// checked for enum fields holding undefined values and for timestamps and
+// durations out of range.
+
+package validate
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_api_validate_validate_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50001,
+		Name:          "dbos.validate.required",
+		Tag:           "varint,50001,opt,name=required",
+		Filename:      "api/validate/validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50002,
+		Name:          "dbos.validate.id",
+		Tag:           "varint,50002,opt,name=id",
+		Filename:      "api/validate/validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*uint32)(nil),
+		Field:         50003,
+		Name:          "dbos.validate.max_len",
+		Tag:           "varint,50003,opt,name=max_len",
+		Filename:      "api/validate/validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*uint32)(nil),
+		Field:         50004,
+		Name:          "dbos.validate.max_items",
+		Tag:           "varint,50004,opt,name=max_items",
+		Filename:      "api/validate/validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50005,
+		Name:          "dbos.validate.unix_time",
+		Tag:           "varint,50005,opt,name=unix_time",
+		Filename:      "api/validate/validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         50006,
+		Name:          "dbos.validate.in",
+		Tag:           "bytes,50006,rep,name=in",
+		Filename:      "api/validate/validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*float64)(nil),
+		Field:         50007,
+		Name:          "dbos.validate.min",
+		Tag:           "fixed64,50007,opt,name=min",
+		Filename:      "api/validate/validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*float64)(nil),
+		Field:         50008,
+		Name:          "dbos.validate.max",
+		Tag:           "fixed64,50008,opt,name=max",
+		Filename:      "api/validate/validate.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50009,
+		Name:          "dbos.validate.skip",
+		Tag:           "varint,50009,opt,name=skip",
+		Filename:      "api/validate/validate.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// required fails an empty string or bytes, an unset message and an empty
+	// repeated or map field
+	//
+	// optional bool required = 50001;
+	E_Required = &file_api_validate_validate_proto_extTypes[0]
+	// id checks that a set string is a client-supplied ID: 1 to 128 bytes of
+	// letters, digits and "-_.:@[]"
+	//
+	// optional bool id = 50002;
+	E_Id = &file_api_validate_validate_proto_extTypes[1]
+	// max_len caps the bytes of a string or bytes field
+	//
+	// optional uint32 max_len = 50003;
+	E_MaxLen = &file_api_validate_validate_proto_extTypes[2]
+	// max_items caps the entries of a repeated or map field
+	//
+	// optional uint32 max_items = 50004;
+	E_MaxItems = &file_api_validate_validate_proto_extTypes[3]
+	// unix_time checks that an integer is Unix seconds from 1970 to 9999
+	//
+	// optional bool unix_time = 50005;
+	E_UnixTime = &file_api_validate_validate_proto_extTypes[4]
+	// in lists the values a set string may take
+	//
+	// repeated string in = 50006;
+	E_In = &file_api_validate_validate_proto_extTypes[5]
+	// min and max bound a number
+	//
+	// optional double min = 50007;
+	E_Min = &file_api_validate_validate_proto_extTypes[6]
+	// optional double max = 50008;
+	E_Max = &file_api_validate_validate_proto_extTypes[7]
+	// skip leaves the messages of a field unchecked, for handlers that check
+	// them one by one to reject them individually
+	//
+	// optional bool skip = 50009;
+	E_Skip = &file_api_validate_validate_proto_extTypes[8]
+)
+
+var File_api_validate_validate_proto protoreflect.FileDescriptor
+
+const file_api_validate_validate_proto_rawDesc = "" +
+	"\n" +
+	"\x1bapi/validate/validate.proto\x12\rdbos.validate\x1a google/protobuf/descriptor.proto:;\n" +
+	"\brequired\x12\x1d.google.protobuf.FieldOptions\x18ц\x03 \x01(\bR\brequired:/\n" +
+	"\x02id\x12\x1d.google.protobuf.FieldOptions\x18҆\x03 \x01(\bR\x02id:8\n" +
+	"\amax_len\x12\x1d.google.protobuf.FieldOptions\x18ӆ\x03 \x01(\rR\x06maxLen:<\n" +
+	"\tmax_items\x12\x1d.google.protobuf.FieldOptions\x18Ԇ\x03 \x01(\rR\bmaxItems:<\n" +
+	"\tunix_time\x12\x1d.google.protobuf.FieldOptions\x18Ն\x03 \x01(\bR\bunixTime:/\n" +
+	"\x02in\x12\x1d.google.protobuf.FieldOptions\x18ֆ\x03 \x03(\tR\x02in:1\n" +
+	"\x03min\x12\x1d.google.protobuf.FieldOptions\x18׆\x03 \x01(\x01R\x03min:1\n" +
+	"\x03max\x12\x1d.google.protobuf.FieldOptions\x18؆\x03 \x01(\x01R\x03max:3\n" +
+	"\x04skip\x12\x1d.google.protobuf.FieldOptions\x18ن\x03 \x01(\bR\x04skipB;Z9github.com/internet-measurement-network/dbos/api/validateb\x06proto3"
+
+var file_api_validate_validate_proto_goTypes = []any{
+	(*descriptorpb.FieldOptions)(nil), // 0: google.protobuf.FieldOptions
+}
+var file_api_validate_validate_proto_depIdxs = []int32{
+	0, // 0: dbos.validate.required:extendee -> google.protobuf.FieldOptions
+	0, // 1: dbos.validate.id:extendee -> google.protobuf.FieldOptions
+	0, // 2: dbos.validate.max_len:extendee -> google.protobuf.FieldOptions
+	0, // 3: dbos.validate.max_items:extendee -> google.protobuf.FieldOptions
+	0, // 4: dbos.validate.unix_time:extendee -> google.protobuf.FieldOptions
+	0, // 5: dbos.validate.in:extendee -> google.protobuf.FieldOptions
+	0, // 6: dbos.validate.min:extendee -> google.protobuf.FieldOptions
+	0, // 7: dbos.validate.max:extendee -> google.protobuf.FieldOptions
+	0, // 8: dbos.validate.skip:extendee -> google.protobuf.FieldOptions
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	0, // [0:9] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_api_validate_validate_proto_init() }
+func file_api_validate_validate_proto_init() {
+	if File_api_validate_validate_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_validate_validate_proto_rawDesc), len(file_api_validate_validate_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 9,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_validate_validate_proto_goTypes,
+		DependencyIndexes: file_api_validate_validate_proto_depIdxs,
+		ExtensionInfos:    file_api_validate_validate_proto_extTypes,
+	}.Build()
+	File_api_validate_validate_proto = out.File
+	file_api_validate_validate_proto_goTypes = nil
+	file_api_validate_validate_proto_depIdxs = nil
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/agent"
+	"github.com/internet-measurement-network/dbos/internal/agent/httpprobe"
+	"github.com/internet-measurement-network/dbos/internal/agent/ping"
+	"github.com/internet-measurement-network/dbos/internal/agent/traceroute"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/apitoken"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"github.com/internet-measurement-network/dbos/pkg/manifest"
+	"github.com/internet-measurement-network/dbos/pkg/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// version is reported as the agent's agent_version label; set at build
+// time with -ldflags "-X main.version=..."
+var version = "dev"
+
+func main() {
+	// Get configuration from environment variables
+	dbosAddr := os.Getenv("DBOS_ADDR")
+	if dbosAddr == "" {
+		dbosAddr = "localhost:50051"
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		log.Fatalf("Failed to get hostname: %v", err)
+	}
+	cfg := agent.Config{
+		AgentID:  os.Getenv("AGENT_ID"),
+		Hostname: hostname,
+		Labels:   map[string]string{models.AgentVersionLabel: version},
+	}
+	if cfg.AgentID == "" {
+		cfg.AgentID = hostname
+	}
+	if err := ids.Validate(cfg.AgentID); err != nil {
+		log.Fatalf("Invalid agent ID: %v", err)
+	}
+
+	if v := os.Getenv("AGENT_LABELS"); v != "" {
+		for _, entry := range strings.Split(v, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || key == "" {
+				log.Fatalf("Invalid AGENT_LABELS entry %q, expected key=value", entry)
+			}
+			cfg.Labels[key] = value
+		}
+	}
+
+	if v := os.Getenv("AGENT_HEARTBEAT_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid AGENT_HEARTBEAT_SECONDS %q", v)
+		}
+		cfg.HeartbeatInterval = time.Duration(n) * time.Second
+	}
+
+	cfg.Concurrency = 4
+	if v := os.Getenv("AGENT_CONCURRENCY"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid AGENT_CONCURRENCY %q", v)
+		}
+		cfg.Concurrency = n
+	}
+
+	if v := os.Getenv("AGENT_TASK_MODE"); v != "" {
+		if v != agent.TaskModeStream && v != agent.TaskModePoll {
+			log.Fatalf("Invalid AGENT_TASK_MODE %q", v)
+		}
+		cfg.TaskMode = v
+	}
+	if v := os.Getenv("AGENT_POLL_WAIT_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid AGENT_POLL_WAIT_SECONDS %q", v)
+		}
+		cfg.PollWait = time.Duration(n) * time.Second
+	}
+
+	if path := os.Getenv("MANIFEST_PUBLIC_KEY_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read MANIFEST_PUBLIC_KEY_FILE: %v", err)
+		}
+		cfg.ManifestKey, err = manifest.ParsePublicKey(data)
+		if err != nil {
+			log.Fatalf("Invalid MANIFEST_PUBLIC_KEY_FILE %s: %v", path, err)
+		}
+	}
+
+	cfg.ModuleDir = os.Getenv("AGENT_MODULE_DIR")
+	var modules []string
+	if v := os.Getenv("AGENT_MODULES"); v != "" {
+		if cfg.ManifestKey == nil {
+			log.Fatalf("AGENT_MODULES requires MANIFEST_PUBLIC_KEY_FILE to verify the modules")
+		}
+		for _, entry := range strings.Split(v, ",") {
+			modules = append(modules, strings.TrimSpace(entry))
+		}
+	}
+	updateArtifact := os.Getenv("AGENT_UPDATE_ARTIFACT")
+	if updateArtifact != "" && cfg.ManifestKey == nil {
+		log.Fatalf("AGENT_UPDATE_ARTIFACT requires MANIFEST_PUBLIC_KEY_FILE to verify updates")
+	}
+
+	if dir := os.Getenv("AGENT_SPOOL_DIR"); dir != "" {
+		limit := 10000
+		if v := os.Getenv("AGENT_SPOOL_LIMIT"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n <= 0 {
+				log.Fatalf("Invalid AGENT_SPOOL_LIMIT %q", v)
+			}
+			limit = n
+		}
+		cfg.Spool, err = agent.OpenSpool(dir, limit)
+		if err != nil {
+			log.Fatalf("Failed to open AGENT_SPOOL_DIR %s: %v", dir, err)
+		}
+		if n := cfg.Spool.Len(); n > 0 {
+			log.Printf("Replaying %d calls spooled in %s", n, dir)
+		}
+	}
+
+	creds := insecure.NewCredentials()
+	tlsConfig, err := tlsconfig.ClientFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid DBOS TLS configuration: %v", err)
+	}
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	token := os.Getenv("DBOS_TOKEN")
+	if bootstrapToken := os.Getenv("DBOS_BOOTSTRAP_TOKEN"); token == "" && bootstrapToken != "" {
+		tokenFile := os.Getenv("AGENT_TOKEN_FILE")
+		if tokenFile == "" {
+			tokenFile = "agentd.token"
+		}
+		token, err = enrollOnce(ctx, dbosAddr, creds, tokenFile, bootstrapToken, cfg.AgentID, hostname)
+		if err != nil {
+			log.Fatalf("Failed to enroll with DBOS at %s: %v", dbosAddr, err)
+		}
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(apitoken.New(token)))
+	}
+	conn, err := grpc.NewClient(dbosAddr, opts...)
+	if err != nil {
+		log.Fatalf("Failed to connect to DBOS at %s: %v", dbosAddr, err)
+	}
+	defer conn.Close()
+
+	a := agent.New(api.NewDBOSClient(conn), cfg)
+	a.Register(ping.New())
+	a.Register(traceroute.New())
+	a.Register(httpprobe.New())
+
+	// DBOS may not be up yet when the daemon starts; keep trying
+	for delay := time.Second; ; delay = min(delay*2, time.Minute) {
+		err := a.RegisterSelf(ctx)
+		if err == nil {
+			break
+		}
+		log.Printf("Failed to register agent %s, retrying in %s: %v", cfg.AgentID, delay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+
+	if updateArtifact != "" {
+		updateSelf(ctx, a, updateArtifact)
+	}
+	for _, module := range modules {
+		name, moduleVersion, _ := strings.Cut(module, "@")
+		if err := a.InstallModule(ctx, name, moduleVersion); err != nil {
+			log.Fatalf("Failed to install module %s: %v", module, err)
+		}
+		log.Printf("Installed module %s", module)
+	}
+	if len(modules) > 0 {
+		// Advertise the installed modules in the agent's modules label
+		if err := a.RegisterSelf(ctx); err != nil {
+			log.Printf("Failed to list the installed modules on agent %s: %v", cfg.AgentID, err)
+		}
+	}
+
+	log.Printf("agentd %s: agent %s running tasks from DBOS at %s (%s mode)", version, cfg.AgentID, dbosAddr, cfg.TaskMode)
+	a.Run(ctx)
+}
+
+// updateSelf replaces the daemon's binary with the latest version of the
+// agent artifact name in the signed manifest and restarts into it, unless
+// it runs that version already. A failed update is logged and the daemon
+// carries on with its current version.
+func updateSelf(ctx context.Context, a *agent.Agent, name string) {
+	path, err := os.Executable()
+	if err == nil {
+		path, err = filepath.EvalSymlinks(path)
+	}
+	if err != nil {
+		log.Printf("Not updating: locating the agentd binary: %v", err)
+		return
+	}
+	installed, err := a.Update(ctx, name, version, path)
+	if err != nil {
+		log.Printf("Not updating to the latest %s: %v", name, err)
+		return
+	}
+	if installed == "" {
+		return
+	}
+	log.Printf("Updated agentd %s to %s, restarting", version, installed)
+	if err := syscall.Exec(path, os.Args, os.Environ()); err != nil {
+		log.Fatalf("Failed to restart updated agentd: %v", err)
+	}
+}
+
+// enrollOnce returns the agent token saved in tokenFile, or redeems the
+// bootstrap token for one and saves it there, so a restarted daemon does
+// not redeem the already used bootstrap token again
+func enrollOnce(ctx context.Context, dbosAddr string, creds credentials.TransportCredentials, tokenFile, bootstrapToken, agentID, hostname string) (string, error) {
+	data, err := os.ReadFile(tokenFile)
+	if err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	// EnrollAgent is the one call made without a token
+	conn, err := grpc.NewClient(dbosAddr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	enrollCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	_, token, err := agent.Enroll(enrollCtx, api.NewDBOSClient(conn), bootstrapToken, agentID, hostname)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(tokenFile, []byte(token+"\n"), 0o600); err != nil {
+		return "", err
+	}
+	log.Printf("Enrolled agent %s, agent token saved to %s", agentID, tokenFile)
+	return token, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/pkg/client"
+)
+
+const usage = `Usage: dbosctl <command> [flags]
+
+Commands:
+  apply -f <file> [-dry-run]   apply a spec of target lists, campaigns and recurring tasks
+`
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "apply":
+		apply(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// apply applies a spec read from a file, or standard input if it is -, and
+// prints the change made to each thing it defines
+func apply(args []string) {
+	flags := flag.NewFlagSet("apply", flag.ExitOnError)
+	file := flags.String("f", "", "spec file to apply, or - for standard input")
+	dryRun := flags.Bool("dry-run", false, "only report the changes applying the spec would make")
+	flags.Parse(args)
+	if *file == "" {
+		log.Fatal("apply needs a spec file: dbosctl apply -f <file>")
+	}
+
+	var spec []byte
+	var err error
+	if *file == "-" {
+		spec, err = io.ReadAll(os.Stdin)
+	} else {
+		spec, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *file, err)
+	}
+
+	// Get configuration from environment variables
+	clientConfig, err := client.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	dbos, err := client.New(clientConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to DBOS at %s: %v", clientConfig.Addr, err)
+	}
+	defer dbos.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	resp, err := dbos.ApplySpec(ctx, &api.ApplySpecRequest{Spec: spec, DryRun: *dryRun})
+	if err != nil {
+		log.Fatalf("Failed to apply %s: %v", *file, err)
+	}
+	for _, change := range resp.Changes {
+		line := fmt.Sprintf("%s/%s %s", change.Kind, change.Name, change.Action)
+		if len(change.Fields) > 0 {
+			line += " (" + strings.Join(change.Fields, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+	if !resp.Success {
+		dbos.Close()
+		log.Fatalf("Failed to apply %s: %s", *file, resp.Error)
+	}
+	if *dryRun {
+		fmt.Println("Dry run: no changes were made")
+	}
+}
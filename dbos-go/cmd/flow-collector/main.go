@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/flow"
+	"github.com/internet-measurement-network/dbos/pkg/client"
+)
+
+func main() {
+	// Get configuration from environment variables
+	clientConfig, err := client.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	netflowAddr, ok := os.LookupEnv("NETFLOW_ADDR")
+	if !ok {
+		netflowAddr = ":2055"
+	}
+	sflowAddr, ok := os.LookupEnv("SFLOW_ADDR")
+	if !ok {
+		sflowAddr = ":6343"
+	}
+
+	interval := time.Minute
+	if v := os.Getenv("FLOW_INTERVAL_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid FLOW_INTERVAL_SECONDS %q", v)
+		}
+		interval = time.Duration(n) * time.Second
+	}
+
+	topN := 10
+	if v := os.Getenv("FLOW_TOP_N"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid FLOW_TOP_N %q", v)
+		}
+		topN = n
+	}
+
+	dbos, err := client.New(clientConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to DBOS at %s: %v", clientConfig.Addr, err)
+	}
+	defer dbos.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	collector := flow.NewCollector(dbos, interval, topN)
+	if err := collector.Run(ctx, netflowAddr, sflowAddr); err != nil {
+		log.Fatalf("Flow collector failed: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/internet-measurement-network/dbos/internal/gnmi"
+	"github.com/internet-measurement-network/dbos/pkg/client"
+)
+
+func main() {
+	// Get configuration from environment variables
+	clientConfig, err := client.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	configPath := os.Getenv("GNMI_CONFIG")
+	if configPath == "" {
+		configPath = "gnmi.json"
+	}
+
+	cfg, err := gnmi.LoadConfig(configPath)
+	if err != nil {
+		log.Fatalf("Failed to load %s: %v", configPath, err)
+	}
+
+	dbos, err := client.New(clientConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to DBOS at %s: %v", clientConfig.Addr, err)
+	}
+	defer dbos.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Streaming telemetry from %d devices into DBOS at %s", len(cfg.Devices), clientConfig.Addr)
+	gnmi.NewAdapter(dbos).Run(ctx, cfg.Devices)
+}
@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/internet-measurement-network/dbos/internal/server"
 )
@@ -19,11 +25,362 @@ func main() {
 		port = "50051"
 	}
 
+	cfg := server.DefaultConfig(redisAddr)
+
+	if v := os.Getenv("REDIS_NAMESPACE"); v != "" {
+		// Keys are scanned by pattern, so the namespace must match itself
+		if strings.ContainsAny(v, "*?[]\\") {
+			log.Fatalf("Invalid REDIS_NAMESPACE %q", v)
+		}
+		cfg.RedisNamespace = v
+	}
+
+	if v := os.Getenv("RESULT_DEDUP_MIN_BYTES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("Invalid RESULT_DEDUP_MIN_BYTES %q: %v", v, err)
+		}
+		cfg.DedupMinBytes = n
+	}
+
+	if v := os.Getenv("AGENT_LIVENESS_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid AGENT_LIVENESS_SECONDS %q", v)
+		}
+		cfg.AgentLivenessWindow = time.Duration(n) * time.Second
+	}
+
+	cfg.HTTPPort = os.Getenv("HTTP_PORT")
+	cfg.GraphQLPort = os.Getenv("GRAPHQL_PORT")
+	cfg.StatusPort = os.Getenv("STATUS_PORT")
+	cfg.UIPort = os.Getenv("UI_PORT")
+
+	cfg.TLSCertFile = os.Getenv("TLS_CERT_FILE")
+	cfg.TLSKeyFile = os.Getenv("TLS_KEY_FILE")
+	cfg.TLSClientCAFile = os.Getenv("TLS_CLIENT_CA_FILE")
+	if v := os.Getenv("TLS_OPERATOR_CNS"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.TLSOperatorNames = append(cfg.TLSOperatorNames, name)
+			}
+		}
+	}
+
+	cfg.ManifestSigningKeyFile = os.Getenv("MANIFEST_SIGNING_KEY_FILE")
+
+	cfg.TokenAuth = os.Getenv("TOKEN_AUTH") == "true"
+	if v := os.Getenv("ADMIN_TOKENS"); v != "" {
+		for _, token := range strings.Split(v, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				cfg.AdminTokens = append(cfg.AdminTokens, token)
+			}
+		}
+	}
+
+	if v := os.Getenv("METRIC_FIELDS"); v != "" {
+		fields, err := server.ParseMetricFields(v)
+		if err != nil {
+			log.Fatalf("Invalid METRIC_FIELDS %q: %v", v, err)
+		}
+		cfg.MetricFields = fields
+	}
+	cfg.RemoteWriteURL = os.Getenv("REMOTE_WRITE_URL")
+	cfg.TrendsEnabled = os.Getenv("TRENDS_ENABLED") == "true"
+	cfg.InfluxURL = os.Getenv("INFLUX_URL")
+	cfg.InfluxToken = os.Getenv("INFLUX_TOKEN")
+	cfg.ClickHouseURL = os.Getenv("CLICKHOUSE_URL")
+	if v := os.Getenv("CLICKHOUSE_DATABASE"); v != "" {
+		cfg.ClickHouseDatabase = v
+	}
+	cfg.ClickHouseUser = os.Getenv("CLICKHOUSE_USER")
+	cfg.ClickHousePassword = os.Getenv("CLICKHOUSE_PASSWORD")
+	if v, ok := os.LookupEnv("CLICKHOUSE_TABLE_PREFIX"); ok {
+		cfg.ClickHouseTablePrefix = v
+	}
+	if v := os.Getenv("CLICKHOUSE_BATCH_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid CLICKHOUSE_BATCH_SIZE %q", v)
+		}
+		cfg.ClickHouseBatchSize = n
+	}
+	if v := os.Getenv("CLICKHOUSE_INTERVAL_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid CLICKHOUSE_INTERVAL_SECONDS %q", v)
+		}
+		cfg.ClickHouseInterval = time.Duration(n) * time.Second
+	}
+	if v := os.Getenv("BUS_DRIVER"); v != "" {
+		if v != server.BusKafka && v != server.BusNATS {
+			log.Fatalf("Invalid BUS_DRIVER %q", v)
+		}
+		cfg.BusDriver = v
+	}
+	if v := os.Getenv("BUS_SERVERS"); v != "" {
+		for _, addr := range strings.Split(v, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				cfg.BusServers = append(cfg.BusServers, addr)
+			}
+		}
+	}
+	if cfg.BusDriver != "" && len(cfg.BusServers) == 0 {
+		log.Fatal("BUS_SERVERS is required when BUS_DRIVER is set")
+	}
+	cfg.BusUser = os.Getenv("BUS_USER")
+	cfg.BusPassword = os.Getenv("BUS_PASSWORD")
+	cfg.BusToken = os.Getenv("BUS_TOKEN")
+	cfg.BusJetStream = os.Getenv("BUS_NATS_JETSTREAM") == "true"
+	if v := os.Getenv("BUS_RESULTS_TOPIC"); v != "" {
+		cfg.BusResultsTopic = v
+	}
+	if v := os.Getenv("BUS_EVENTS_TOPIC"); v != "" {
+		cfg.BusEventsTopic = v
+	}
+	if v := os.Getenv("BUS_BATCH_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid BUS_BATCH_SIZE %q", v)
+		}
+		cfg.BusBatchSize = n
+	}
+	if v := os.Getenv("BUS_OUTBOX_MAX_LEN"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid BUS_OUTBOX_MAX_LEN %q", v)
+		}
+		cfg.BusOutboxMaxLen = n
+	}
+	cfg.CTLookupURL = os.Getenv("CT_LOOKUP_URL")
+	cfg.AlertWebhookURL = os.Getenv("ALERT_WEBHOOK_URL")
+
+	if v := os.Getenv("SIMULATED_AGENTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid SIMULATED_AGENTS %q", v)
+		}
+		cfg.SimulatedAgents = n
+	}
+	if v := os.Getenv("SIMULATED_AGENT_INTERVAL_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid SIMULATED_AGENT_INTERVAL_SECONDS %q", v)
+		}
+		cfg.SimulatedAgentInterval = time.Duration(n) * time.Second
+	}
+
+	if v := os.Getenv("ROUTING_PREFIXES"); v != "" {
+		prefixes, err := server.ParseWatchedPrefixes(v)
+		if err != nil {
+			log.Fatalf("Invalid ROUTING_PREFIXES %q: %v", v, err)
+		}
+		cfg.RoutingPrefixes = prefixes
+	}
+	if v := os.Getenv("RIS_LIVE_URL"); v != "" {
+		cfg.RISLiveURL = v
+	}
+
+	cfg.UpstreamAddr = os.Getenv("UPSTREAM_ADDR")
+	cfg.UpstreamTLSCAFile = os.Getenv("UPSTREAM_TLS_CA_FILE")
+	cfg.UpstreamTLSCertFile = os.Getenv("UPSTREAM_TLS_CERT_FILE")
+	cfg.UpstreamTLSKeyFile = os.Getenv("UPSTREAM_TLS_KEY_FILE")
+	cfg.UpstreamToken = os.Getenv("UPSTREAM_TOKEN")
+	if v := os.Getenv("RELAY_BUFFER_LIMIT"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid RELAY_BUFFER_LIMIT %q", v)
+		}
+		cfg.RelayBufferLimit = n
+	}
+
+	if v := os.Getenv("SHUTDOWN_TIMEOUT_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid SHUTDOWN_TIMEOUT_SECONDS %q", v)
+		}
+		cfg.ShutdownTimeout = time.Duration(n) * time.Second
+	}
+
+	if v := os.Getenv("TASK_LEASE_TIMEOUT_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid TASK_LEASE_TIMEOUT_SECONDS %q", v)
+		}
+		cfg.TaskLeaseTimeout = time.Duration(n) * time.Second
+	}
+	if v := os.Getenv("TASK_REQUEUE_INTERVAL_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid TASK_REQUEUE_INTERVAL_SECONDS %q", v)
+		}
+		cfg.TaskRequeueInterval = time.Duration(n) * time.Second
+	}
+	if v := os.Getenv("TASK_HUNG_FACTOR"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 {
+			log.Fatalf("Invalid TASK_HUNG_FACTOR %q", v)
+		}
+		cfg.TaskHungFactor = f
+	}
+	if v := os.Getenv("TASK_HUNG_MIN_AGE_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid TASK_HUNG_MIN_AGE_SECONDS %q", v)
+		}
+		cfg.TaskHungMinAge = time.Duration(n) * time.Second
+	}
+	if v := os.Getenv("TASK_DEDUP_WINDOW_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid TASK_DEDUP_WINDOW_SECONDS %q", v)
+		}
+		cfg.TaskDedupWindow = time.Duration(n) * time.Second
+	}
+	if v := os.Getenv("TASK_QUEUE"); v != "" {
+		if v != server.TaskQueueZSet && v != server.TaskQueueStreams {
+			log.Fatalf("Invalid TASK_QUEUE %q", v)
+		}
+		cfg.TaskQueue = v
+	}
+
+	if v := os.Getenv("STREAM_RESULTS_BATCH_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid STREAM_RESULTS_BATCH_SIZE %q", v)
+		}
+		cfg.StreamResultsBatchSize = n
+	}
+	if v := os.Getenv("STREAM_RESULTS_FLUSH_INTERVAL_MS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid STREAM_RESULTS_FLUSH_INTERVAL_MS %q", v)
+		}
+		cfg.StreamResultsFlushInterval = time.Duration(n) * time.Millisecond
+	}
+
+	cfg.EventSourcing = os.Getenv("EVENT_SOURCING") == "true"
+
+	if v := os.Getenv("RESULT_RETENTION_HOURS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid RESULT_RETENTION_HOURS %q", v)
+		}
+		cfg.ResultRetention = time.Duration(n) * time.Hour
+	}
+	if v := os.Getenv("RESULT_RETENTION_MODULES"); v != "" {
+		retentions, err := server.ParseModuleRetentions(v)
+		if err != nil {
+			log.Fatalf("Invalid RESULT_RETENTION_MODULES %q: %v", v, err)
+		}
+		cfg.ModuleResultRetention = retentions
+	}
+	if v := os.Getenv("RESULT_MAX_AGE_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid RESULT_MAX_AGE_SECONDS %q", v)
+		}
+		cfg.ResultMaxAge = time.Duration(n) * time.Second
+	}
+	if v := os.Getenv("RESULT_RETENTION_INTERVAL_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid RESULT_RETENTION_INTERVAL_SECONDS %q", v)
+		}
+		cfg.ResultRetentionInterval = time.Duration(n) * time.Second
+	}
+	cfg.ResultArchiveURL = os.Getenv("RESULT_ARCHIVE_URL")
+	cfg.ResultArchiveToken = os.Getenv("RESULT_ARCHIVE_TOKEN")
+	cfg.ResultArchiveAccessKey = os.Getenv("RESULT_ARCHIVE_ACCESS_KEY")
+	cfg.ResultArchiveSecretKey = os.Getenv("RESULT_ARCHIVE_SECRET_KEY")
+	if v := os.Getenv("RESULT_ARCHIVE_REGION"); v != "" {
+		cfg.ResultArchiveRegion = v
+	}
+	if v := os.Getenv("RESULT_ARCHIVE_FORMAT"); v != "" {
+		if v != server.ResultArchiveNDJSON && v != server.ResultArchiveParquet {
+			log.Fatalf("Invalid RESULT_ARCHIVE_FORMAT %q", v)
+		}
+		cfg.ResultArchiveFormat = v
+	}
+	if v := os.Getenv("RESULT_ARCHIVE_AFTER_HOURS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid RESULT_ARCHIVE_AFTER_HOURS %q", v)
+		}
+		cfg.ResultArchiveAfter = time.Duration(n) * time.Hour
+	}
+
+	if v := os.Getenv("EVENT_LOG_MAX_EVENTS"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid EVENT_LOG_MAX_EVENTS %q", v)
+		}
+		cfg.EventLogMaxEvents = n
+	}
+	if v := os.Getenv("EVENT_LOG_RETENTION_HOURS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid EVENT_LOG_RETENTION_HOURS %q", v)
+		}
+		cfg.EventLogRetention = time.Duration(n) * time.Hour
+	}
+	if v := os.Getenv("EVENT_LOG_TRIM_INTERVAL_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			log.Fatalf("Invalid EVENT_LOG_TRIM_INTERVAL_SECONDS %q", v)
+		}
+		cfg.EventLogTrimInterval = time.Duration(n) * time.Second
+	}
+
+	if v := os.Getenv("BACKPRESSURE_REDIS_LATENCY_MS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid BACKPRESSURE_REDIS_LATENCY_MS %q", v)
+		}
+		cfg.BackpressureRedisLatency = time.Duration(n) * time.Millisecond
+	}
+	if v := os.Getenv("BACKPRESSURE_INFLIGHT_REQUESTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid BACKPRESSURE_INFLIGHT_REQUESTS %q", v)
+		}
+		cfg.BackpressureInflightRequests = n
+	}
+
+	if v := os.Getenv("USAGE_FLUSH_INTERVAL_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid USAGE_FLUSH_INTERVAL_SECONDS %q", v)
+		}
+		cfg.UsageFlushInterval = time.Duration(n) * time.Second
+	}
+	if v := os.Getenv("QUOTAS"); v != "" {
+		quotas, err := server.ParseQuotas(v)
+		if err != nil {
+			log.Fatalf("Invalid QUOTAS %q: %v", v, err)
+		}
+		if cfg.UsageFlushInterval == 0 {
+			log.Fatal("QUOTAS require usage accounting, disabled by USAGE_FLUSH_INTERVAL_SECONDS=0")
+		}
+		cfg.Quotas = quotas
+	}
+	if v := os.Getenv("DRIFT_CHECK_INTERVAL_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid DRIFT_CHECK_INTERVAL_SECONDS %q", v)
+		}
+		cfg.DriftCheckInterval = time.Duration(n) * time.Second
+	}
+	cfg.DriftRepair = os.Getenv("DRIFT_REPAIR") == "true"
+
 	// Create and start the server
-	srv := server.NewServer(redisAddr)
+	srv := server.NewServerWithConfig(cfg)
 
 	log.Printf("Starting DBOS server on port %s with Redis at %s", port, redisAddr)
-	if err := srv.Start(port); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := srv.Run(ctx, port); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
@@ -6,15 +6,22 @@ toolchain go1.24.5
 
 require (
 	github.com/go-redis/redis/v8 v8.11.5
+	github.com/golang/snappy v1.0.0
+	github.com/google/flatbuffers v25.2.10+incompatible
+	github.com/gorilla/websocket v1.5.3
+	github.com/graph-gophers/graphql-go v1.5.0
+	github.com/openconfig/gnmi v0.0.0-20180912164834-33a1865c3029
+	golang.org/x/net v0.46.1-0.20251013234738-63d1a5100f82
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251022142026-3a174f9686a8
 	google.golang.org/grpc v1.77.0
 	google.golang.org/protobuf v1.36.10
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
-	golang.org/x/net v0.46.1-0.20251013234738-63d1a5100f82 // indirect
+	github.com/golang/protobuf v1.5.4 // indirect
 	golang.org/x/sys v0.37.0 // indirect
 	golang.org/x/text v0.30.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20251022142026-3a174f9686a8 // indirect
 )
@@ -0,0 +1,560 @@
+// Package agent is a measurement agent runtime: it receives an agent's
+// tasks from DBOS, runs each with the module it names and stores the
+// outcome back as a result, reporting module states along the way.
+package agent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/manifest"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// minBackoff and maxBackoff bound the delay before reopening the task
+	// stream, or retrying a spooled call
+	minBackoff = 5 * time.Second
+	maxBackoff = time.Minute
+)
+
+// Module is a measurement an agent can run. Run receives a task's
+// JSON-encoded payload and returns the result data, which must be JSON
+// encodable; ctx is cancelled when the agent stops.
+type Module interface {
+	Name() string
+	Run(ctx context.Context, payload []byte) (interface{}, error)
+}
+
+// Config configures an agent runtime
+type Config struct {
+	AgentID           string
+	Hostname          string
+	HeartbeatInterval time.Duration
+	// Concurrency is how many tasks run at once
+	Concurrency int
+	// ManifestKey verifies the signed software manifest FetchArtifact
+	// downloads binaries by; nil disables FetchArtifact
+	ManifestKey ed25519.PublicKey
+	// ManifestMaxAge is how long after it was issued a manifest is
+	// accepted, manifest.DefaultMaxAge if zero
+	ManifestMaxAge time.Duration
+	// ModuleDir is the directory InstallModule installs module binaries in
+	ModuleDir string
+	// Labels are set on the agent's registration when it starts, over the
+	// labels it already has
+	Labels map[string]string
+	// TaskMode is how tasks are received, TaskModeStream (the default) or
+	// TaskModePoll
+	TaskMode string
+	// PollWait is how long each LeaseTask call waits for a due task in
+	// TaskModePoll
+	PollWait time.Duration
+	// Spool buffers the results and module states DBOS cannot take while
+	// unreachable, replaying them once it is; nil drops them
+	Spool *Spool
+}
+
+// Task modes of Config.TaskMode
+const (
+	// TaskModeStream receives tasks pushed over StreamTasks
+	TaskModeStream = "stream"
+	// TaskModePoll long-polls LeaseTask, for networks that cut long-lived
+	// streams
+	TaskModePoll = "poll"
+)
+
+// Agent runs the tasks DBOS hands to one agent with its registered modules
+type Agent struct {
+	dbos    api.DBOSClient
+	config  Config
+	modules map[string]Module
+
+	// manifestIssuedAt is when the last manifest accepted was issued; an
+	// earlier one is rejected as replayed
+	manifestMu       sync.Mutex
+	manifestIssuedAt time.Time
+}
+
+// New creates a new agent runtime talking to DBOS through a client
+func New(dbos api.DBOSClient, cfg Config) *Agent {
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = 15 * time.Second
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.TaskMode == "" {
+		cfg.TaskMode = TaskModeStream
+	}
+	if cfg.PollWait <= 0 {
+		cfg.PollWait = 30 * time.Second
+	}
+	if cfg.ManifestMaxAge <= 0 {
+		cfg.ManifestMaxAge = manifest.DefaultMaxAge
+	}
+	if cfg.ModuleDir == "" {
+		cfg.ModuleDir = "modules"
+	}
+	return &Agent{
+		dbos:    dbos,
+		config:  cfg,
+		modules: make(map[string]Module),
+	}
+}
+
+// Register adds a module, replacing any registered under the same name
+func (a *Agent) Register(module Module) {
+	a.modules[module.Name()] = module
+}
+
+// RegisterSelf registers the agent through a heartbeat, which registers it if
+// unknown, and sets Config.Labels on its registration if any differ, along
+// with the modules label listing the modules registered, by which the
+// server fails continuous tasks over to it
+func (a *Agent) RegisterSelf(ctx context.Context) error {
+	resp, err := a.dbos.Heartbeat(ctx, &api.HeartbeatRequest{
+		AgentId:  a.config.AgentID,
+		Hostname: a.config.Hostname,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error)
+	}
+
+	registered := resp.Agent
+	if registered == nil {
+		registered = &api.Agent{Id: a.config.AgentID, Hostname: a.config.Hostname, Alive: true}
+	}
+	changed := false
+	labels := maps.Clone(a.config.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[models.AgentModulesLabel] = strings.Join(slices.Sorted(maps.Keys(a.modules)), ",")
+	for key, value := range labels {
+		if registered.Labels[key] != value {
+			if registered.Labels == nil {
+				registered.Labels = make(map[string]string)
+			}
+			registered.Labels[key] = value
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	registerResp, err := a.dbos.RegisterAgent(ctx, &api.RegisterAgentRequest{Agent: registered})
+	if err != nil {
+		return err
+	}
+	if !registerResp.Success {
+		return dberrors.New(dberrors.Code(registerResp.ErrorCode), "%s", registerResp.Error)
+	}
+	return nil
+}
+
+// Run heartbeats and runs tasks until ctx is done, reopening the task
+// stream, or resuming polling, with backoff when it fails. Tasks still
+// running are waited for.
+func (a *Agent) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		a.runHeartbeats(ctx)
+	}()
+	if a.config.Spool != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.replaySpool(ctx)
+		}()
+	}
+
+	slots := make(chan struct{}, a.config.Concurrency)
+	receive := a.streamTasks
+	if a.config.TaskMode == TaskModePoll {
+		receive = a.pollTasks
+	}
+	backoff := minBackoff
+	for {
+		start := time.Now()
+		err := receive(ctx, slots, &wg)
+		if ctx.Err() != nil {
+			break
+		}
+		log.Printf("Agent %s: receiving tasks failed: %v", a.config.AgentID, err)
+
+		// A stream that ran for a while was healthy; retry promptly
+		if time.Since(start) > maxBackoff {
+			backoff = minBackoff
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+
+	wg.Wait()
+}
+
+// runHeartbeats reports liveness, which also registers the agent if unknown
+func (a *Agent) runHeartbeats(ctx context.Context) {
+	ticker := time.NewTicker(a.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := a.dbos.Heartbeat(ctx, &api.HeartbeatRequest{
+			AgentId:  a.config.AgentID,
+			Hostname: a.config.Hostname,
+		})
+		if err == nil && !resp.Success {
+			err = fmt.Errorf("%s", resp.Error)
+		}
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Agent %s: heartbeat: %v", a.config.AgentID, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// streamTasks receives the agent's tasks as they become due and runs each
+// once a slot is free, until the stream fails
+func (a *Agent) streamTasks(ctx context.Context, slots chan struct{}, wg *sync.WaitGroup) error {
+	stream, err := a.dbos.StreamTasks(ctx, &api.StreamTasksRequest{AgentId: a.config.AgentID})
+	if err != nil {
+		return err
+	}
+
+	for {
+		task, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case slots <- struct{}{}:
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			a.runTask(ctx, task)
+		}()
+	}
+}
+
+// pollTasks long-polls LeaseTask for the agent's due tasks and runs each
+// once a slot is free, until a lease fails. A slot is taken before leasing,
+// so no task is leased that cannot start right away.
+func (a *Agent) pollTasks(ctx context.Context, slots chan struct{}, wg *sync.WaitGroup) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case slots <- struct{}{}:
+		}
+
+		resp, err := a.dbos.LeaseTask(ctx, &api.LeaseTaskRequest{
+			AgentId:     a.config.AgentID,
+			WaitSeconds: int64(a.config.PollWait / time.Second),
+		})
+		if err == nil && resp.Error != "" {
+			err = dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error)
+		}
+		if err != nil {
+			<-slots
+			return err
+		}
+		if !resp.Found {
+			<-slots
+			if resp.Backoff != nil && resp.Backoff.RetryAfterMs > 0 {
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Duration(resp.Backoff.RetryAfterMs) * time.Millisecond):
+				}
+			}
+			continue
+		}
+
+		task := resp.Task
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			a.runTask(ctx, task)
+		}()
+	}
+}
+
+// runTask runs a task with its module and stores the result, reporting the
+// task's module state as running, then completed or error. If DBOS asks
+// the agent to back off, the task's slot is held for the time asked, so no
+// further task starts in the meantime.
+func (a *Agent) runTask(ctx context.Context, task *api.Task) {
+	module, ok := a.modules[task.ModuleName]
+	if !ok {
+		a.reportState(ctx, task, models.ModuleStateError, dberrors.New(dberrors.Unimplemented, "unknown module %s", task.ModuleName))
+		return
+	}
+
+	a.reportState(ctx, task, models.ModuleStateRunning, nil)
+	leaseCtx, stopLease := context.WithCancel(ctx)
+	go a.keepLease(leaseCtx, task)
+	start := time.Now()
+	data, err := module.Run(ctx, task.Payload)
+	stopLease()
+	if err != nil {
+		a.reportState(ctx, task, models.ModuleStateError, err)
+		return
+	}
+	backoff, err := a.storeResult(ctx, task, start, data)
+	if err != nil {
+		log.Printf("Agent %s: storing result of task %s: %v", a.config.AgentID, task.Id, err)
+		a.reportState(ctx, task, models.ModuleStateError, fmt.Errorf("storing result: %w", err))
+		return
+	}
+	a.reportState(ctx, task, models.ModuleStateCompleted, nil)
+
+	if backoff != nil && backoff.RetryAfterMs > 0 {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Duration(backoff.RetryAfterMs) * time.Millisecond):
+		}
+	}
+}
+
+// keepLease extends the lease of a running task, by as long as it was
+// leased for, each time half of what is left of it has passed, until ctx is
+// done. Measurements running longer than their lease are thus not requeued
+// under them.
+func (a *Agent) keepLease(ctx context.Context, task *api.Task) {
+	if task.LeaseExpiresAt == 0 || task.LeasedAt == 0 {
+		return
+	}
+	length := time.Duration(task.LeaseExpiresAt-task.LeasedAt) * time.Second
+	if length <= 0 {
+		return
+	}
+
+	expiresAt := time.Unix(task.LeaseExpiresAt, 0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(max(time.Until(expiresAt)/2, time.Second)):
+		}
+
+		resp, err := a.dbos.ExtendTaskVisibility(ctx, &api.ExtendTaskVisibilityRequest{
+			AgentId:         a.config.AgentID,
+			TaskId:          task.Id,
+			DurationSeconds: int64(length / time.Second),
+		})
+		if err == nil && resp.Error != "" {
+			err = dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error)
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Agent %s: extending lease of task %s: %v", a.config.AgentID, task.Id, err)
+			}
+			return
+		}
+		expiresAt = time.Unix(resp.LeaseExpiresAt, 0)
+	}
+}
+
+// storeResult stores a module's result data under the task's ID, returning
+// the backoff hint DBOS answered with, if any
+func (a *Agent) storeResult(ctx context.Context, task *api.Task, start time.Time, data interface{}) (*api.Backoff, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.deliver(ctx, &api.StoreResultRequest{
+		Result: &api.MeasurementResult{
+			Id:               task.Id,
+			AgentId:          a.config.AgentID,
+			ModuleName:       task.ModuleName,
+			Data:             payload,
+			Timestamp:        start.Unix(),
+			AgentTimestampMs: start.UnixMilli(),
+			CorrelationId:    task.CorrelationId,
+		},
+	})
+}
+
+// reportState records the module state of a task and the error it failed
+// with, if any, logging failures
+func (a *Agent) reportState(ctx context.Context, task *api.Task, state models.ModuleStateEnum, taskErr error) {
+	apiState := &api.ModuleState{
+		AgentId:    a.config.AgentID,
+		ModuleName: task.ModuleName,
+		State:      string(state),
+		Timestamp:  time.Now().Unix(),
+		RequestId:  task.Id,
+	}
+	if taskErr != nil {
+		apiState.ErrorMessage = taskErr.Error()
+		apiState.ErrorCode = string(dberrors.CodeOf(taskErr))
+	}
+	_, err := a.deliver(ctx, &api.SetModuleStateRequest{
+		State: apiState,
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Printf("Agent %s: reporting %s state of task %s: %v", a.config.AgentID, state, task.Id, err)
+	}
+}
+
+// call makes a StoreResult or SetModuleState call, returning the backoff
+// hint of a stored result and the error the call or its response reported
+func (a *Agent) call(ctx context.Context, req proto.Message) (*api.Backoff, error) {
+	switch req := req.(type) {
+	case *api.StoreResultRequest:
+		resp, err := a.dbos.StoreResult(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error)
+		}
+		return resp.Backoff, nil
+	case *api.SetModuleStateRequest:
+		resp, err := a.dbos.SetModuleState(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error)
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported call %T", req)
+}
+
+// backfill stores a result as a late historical one
+func (a *Agent) backfill(ctx context.Context, req *api.StoreResultRequest) error {
+	resp, err := a.dbos.StoreResults(ctx, &api.StoreResultsRequest{
+		Results:  []*api.MeasurementResult{req.Result},
+		Backfill: true,
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error)
+	}
+	for _, outcome := range resp.Results {
+		if outcome.Status == "error" {
+			return dberrors.New(dberrors.Code(outcome.ErrorCode), "%s", outcome.Error)
+		}
+	}
+	return nil
+}
+
+// deliver makes a call, or buffers it in the spool if DBOS is unreachable.
+// While calls are buffered, later ones are buffered behind them without
+// being tried, so DBOS receives them in the order they were made.
+func (a *Agent) deliver(ctx context.Context, req proto.Message) (*api.Backoff, error) {
+	spool := a.config.Spool
+	if spool == nil {
+		return a.call(ctx, req)
+	}
+	if spool.Len() == 0 {
+		backoff, err := a.call(ctx, req)
+		if err == nil || !transient(err) || ctx.Err() != nil {
+			return backoff, err
+		}
+	}
+	return nil, spool.add(req)
+}
+
+// replaySpool replays the buffered calls oldest first, until ctx is done.
+// While DBOS is unreachable the oldest call is retried with backoff; a
+// result DBOS rejects as invalid, such as for being too old, is stored
+// once more as a backfill, and a call rejected for another reason is
+// dropped, as it would be rejected again. Backoff hints of DBOS are waited out, so a large spool does not
+// swamp it.
+func (a *Agent) replaySpool(ctx context.Context) {
+	spool := a.config.Spool
+	backoff := minBackoff
+	for {
+		name, req, err := spool.oldest()
+		if err == nil && req == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-spool.wake:
+				continue
+			}
+		}
+		var hint *api.Backoff
+		if err == nil {
+			hint, err = a.call(ctx, req)
+			if result, ok := req.(*api.StoreResultRequest); ok && dberrors.CodeOf(err) == dberrors.InvalidArgument {
+				err = a.backfill(ctx, result)
+			}
+			if err != nil && !transient(err) {
+				log.Printf("Agent %s: dropping spooled call %s DBOS rejected: %v", a.config.AgentID, name, err)
+				err = nil
+			}
+			if err == nil {
+				err = spool.remove(name)
+			}
+		}
+		if err == nil {
+			backoff = minBackoff
+			if hint != nil && hint.RetryAfterMs > 0 {
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Duration(hint.RetryAfterMs) * time.Millisecond):
+				}
+			}
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Agent %s: replaying spooled calls, %d left: %v", a.config.AgentID, spool.Len(), err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// transient tells whether a call failed because DBOS could not take it for
+// now: it, its storage or a relay's upstream was unreachable, or a relay's
+// buffer was full
+func transient(err error) bool {
+	switch dberrors.CodeOf(err) {
+	case dberrors.Unavailable, dberrors.StorageUnavailable, dberrors.DeadlineExceeded, dberrors.QuotaExceeded:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/manifest"
+)
+
+// FetchArtifact downloads an agent or module binary of a kind listed in the
+// signed software manifest, an empty version meaning the latest published,
+// and returns its manifest entry and bytes. The manifest's signature and
+// age are verified with Config.ManifestKey and the download against the
+// digest it lists, so the returned bytes are safe to run; without a key
+// nothing is fetched.
+func (a *Agent) FetchArtifact(ctx context.Context, kind models.ArtifactKindEnum, name, version string) (*manifest.Artifact, []byte, error) {
+	m, err := a.fetchManifest(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	artifact, ok := m.Find(name, version)
+	if !ok {
+		return nil, nil, fmt.Errorf("artifact %s version %q is not in the manifest", name, version)
+	}
+	if artifact.Kind != string(kind) {
+		return nil, nil, fmt.Errorf("artifact %s %s is of kind %s, not %s", name, artifact.Version, artifact.Kind, kind)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, artifact.URL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	download, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer download.Body.Close()
+	if download.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("downloading %s: %s", artifact.URL, download.Status)
+	}
+
+	// A listed size bounds the download; the digest check catches the rest
+	body := io.Reader(download.Body)
+	if artifact.Size > 0 {
+		body = io.LimitReader(download.Body, artifact.Size+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := manifest.VerifyArtifact(artifact, data); err != nil {
+		return nil, nil, err
+	}
+	return artifact, data, nil
+}
+
+// fetchManifest gets the software manifest and verifies it, rejecting one
+// issued before the last manifest accepted
+func (a *Agent) fetchManifest(ctx context.Context) (*manifest.Manifest, error) {
+	if a.config.ManifestKey == nil {
+		return nil, fmt.Errorf("no manifest key configured, refusing to fetch unverifiable code")
+	}
+
+	resp, err := a.dbos.GetManifest(ctx, &api.GetManifestRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("getting manifest: %s", resp.Error)
+	}
+
+	a.manifestMu.Lock()
+	defer a.manifestMu.Unlock()
+	m, err := manifest.Verify(a.config.ManifestKey, &manifest.Signed{
+		Manifest:  resp.Manifest,
+		Signature: resp.Signature,
+		KeyID:     resp.KeyId,
+	}, time.Now(), a.config.ManifestMaxAge, a.manifestIssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	a.manifestIssuedAt = m.IssuedAt
+	return m, nil
+}
+
+// InstallModule downloads a module binary published in the manifest into
+// Config.ModuleDir and registers it under its name, an empty version
+// meaning the latest published. The binary is run for each task with the
+// task's payload on its standard input, and must write its result as JSON
+// to its standard output. Modules are installed before Run.
+func (a *Agent) InstallModule(ctx context.Context, name, version string) error {
+	artifact, data, err := a.FetchArtifact(ctx, models.ArtifactKindModule, name, version)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(a.config.ModuleDir, name+"-"+artifact.Version)
+	if err := writeExecutable(path, data); err != nil {
+		return err
+	}
+	a.Register(&execModule{artifact: *artifact, path: path})
+	return nil
+}
+
+// Update replaces the running binary at path with the latest version of
+// the agent artifact name published in the manifest, unless that is the
+// current version. It returns the version installed, or "" if the agent
+// was up to date; the caller restarts the agent to run it.
+func (a *Agent) Update(ctx context.Context, name, current, path string) (string, error) {
+	m, err := a.fetchManifest(ctx)
+	if err != nil {
+		return "", err
+	}
+	if latest, ok := m.Find(name, ""); !ok || latest.Version == current {
+		return "", nil
+	}
+
+	artifact, data, err := a.FetchArtifact(ctx, models.ArtifactKindAgent, name, "")
+	if err != nil {
+		return "", err
+	}
+	if err := writeExecutable(path, data); err != nil {
+		return "", err
+	}
+	return artifact.Version, nil
+}
+
+// writeExecutable writes a verified binary to path through a temporary
+// file renamed over it, so that no partial binary is ever run
+func writeExecutable(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o755); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// execModule is a module run as an installed binary
+type execModule struct {
+	artifact manifest.Artifact
+	path     string
+}
+
+func (m *execModule) Name() string {
+	return m.artifact.Name
+}
+
+// Run checks the installed binary against its manifest digest again, so a
+// binary changed on disk since it was installed is not run, then runs it
+// with the payload on its standard input
+func (m *execModule) Run(ctx context.Context, payload []byte) (interface{}, error) {
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return nil, err
+	}
+	if err := manifest.VerifyArtifact(&m.artifact, data); err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, m.path)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s %s: %w: %s", m.artifact.Name, m.artifact.Version, err, msg)
+		}
+		return nil, fmt.Errorf("%s %s: %w", m.artifact.Name, m.artifact.Version, err)
+	}
+	if !json.Valid(stdout.Bytes()) {
+		return nil, fmt.Errorf("%s %s did not write a JSON result", m.artifact.Name, m.artifact.Version)
+	}
+	return json.RawMessage(stdout.Bytes()), nil
+}
@@ -0,0 +1,25 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/internet-measurement-network/dbos/api"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// Enroll redeems a bootstrap token for an agent, returning the agent's ID,
+// minted by DBOS if agentID is empty, and its permanent agent token
+func Enroll(ctx context.Context, dbos api.DBOSClient, bootstrapToken, agentID, hostname string) (string, string, error) {
+	resp, err := dbos.EnrollAgent(ctx, &api.EnrollAgentRequest{
+		BootstrapToken: bootstrapToken,
+		AgentId:        agentID,
+		Hostname:       hostname,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if !resp.Success {
+		return "", "", dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error)
+	}
+	return resp.Agent.GetId(), resp.AgentToken, nil
+}
@@ -0,0 +1,382 @@
+// Package httpprobe is an agent module fetching a URL over HTTP or HTTPS,
+// timing each phase of every request along its redirect chain.
+package httpprobe
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ModuleName is the module HTTP tasks name and results are stored under
+const ModuleName = "http_module"
+
+const (
+	defaultMaxRedirects = 10
+	maxMaxRedirects     = 30
+	defaultTimeout      = 30 * time.Second
+	defaultMaxBodyBytes = 10 << 20
+	maxMaxBodyBytes     = 100 << 20
+	userAgent           = "dbos-agent/" + ModuleName
+
+	// titleScanBytes is how much of an HTML body is searched for its title
+	titleScanBytes = 64 << 10
+)
+
+// titlePattern matches an HTML document's title
+var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// Query is the payload of an HTTP task
+type Query struct {
+	URL string `json:"url"`
+	// Method is "GET" (the default) or "HEAD"
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// NoRedirects stores a redirect response instead of following it
+	NoRedirects bool `json:"no_redirects,omitempty"`
+	// MaxRedirects is how many redirects are followed before failing
+	MaxRedirects int `json:"max_redirects,omitempty"`
+	// Timeout is in seconds, for the whole fetch including redirects
+	Timeout float64 `json:"timeout,omitempty"`
+	// MaxBodyBytes is how much of the body is read and hashed
+	MaxBodyBytes int64 `json:"max_body_bytes,omitempty"`
+	// Insecure skips verifying the server's certificate
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// Timings are the phases of one request in milliseconds. DNS, Connect and
+// TLS are phase durations, 0 when the phase did not happen; TTFB is from
+// sending the request to the first response byte and Total to the end of
+// the body.
+type Timings struct {
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	TLS     float64 `json:"tls"`
+	TTFB    float64 `json:"ttfb"`
+	Total   float64 `json:"total"`
+}
+
+// Redirect is a redirect response on the way to the final one
+type Redirect struct {
+	URL        string  `json:"url"`
+	Address    string  `json:"address,omitempty"`
+	StatusCode int     `json:"status_code"`
+	Location   string  `json:"location"`
+	Timings    Timings `json:"timings"`
+}
+
+// TLS describes the connection the final response was received on
+type TLS struct {
+	Version     string `json:"version"`
+	CipherSuite string `json:"cipher_suite"`
+	ServerName  string `json:"server_name"`
+	// Subject, Issuer and NotAfter describe the server's leaf certificate
+	Subject  string    `json:"subject,omitempty"`
+	Issuer   string    `json:"issuer,omitempty"`
+	NotAfter time.Time `json:"not_after"`
+}
+
+// Result is the data of an HTTP result. The response fields and Timings are
+// those of the final request; Redirects lists the responses before it in
+// order and Duration is the whole fetch in milliseconds. The body is
+// described by its hash and length, and is absent for HEAD requests.
+type Result struct {
+	URL        string            `json:"url"`
+	FinalURL   string            `json:"final_url"`
+	Method     string            `json:"method"`
+	Address    string            `json:"address,omitempty"`
+	Proto      string            `json:"proto"`
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers"`
+	Redirects  []*Redirect       `json:"redirects"`
+	Timings    Timings           `json:"timings"`
+	Duration   float64           `json:"duration"`
+	TLS        *TLS              `json:"tls,omitempty"`
+
+	BodySHA256    string `json:"body_sha256,omitempty"`
+	BodyLength    int64  `json:"body_length"`
+	BodyTruncated bool   `json:"body_truncated,omitempty"`
+	Title         string `json:"title,omitempty"`
+}
+
+// Module runs HTTP tasks
+type Module struct{}
+
+// New creates a new HTTP module
+func New() *Module {
+	return &Module{}
+}
+
+// Name returns the module name
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+// Run fetches the URL a query names, following redirects one request at a
+// time so that each is timed on a fresh connection
+func (m *Module) Run(ctx context.Context, payload []byte) (interface{}, error) {
+	var query Query
+	if err := json.Unmarshal(payload, &query); err != nil {
+		return nil, fmt.Errorf("invalid HTTP query: %w", err)
+	}
+	if query.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	target, err := url.Parse(query.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return nil, fmt.Errorf("url scheme must be http or https")
+	}
+	query.Method = strings.ToUpper(query.Method)
+	switch query.Method {
+	case "":
+		query.Method = http.MethodGet
+	case http.MethodGet, http.MethodHead:
+	default:
+		return nil, fmt.Errorf("unsupported method %q", query.Method)
+	}
+	if query.MaxRedirects <= 0 {
+		query.MaxRedirects = defaultMaxRedirects
+	}
+	if query.MaxRedirects > maxMaxRedirects {
+		return nil, fmt.Errorf("max_redirects must be at most %d", maxMaxRedirects)
+	}
+	if query.MaxBodyBytes <= 0 {
+		query.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	if query.MaxBodyBytes > maxMaxBodyBytes {
+		return nil, fmt.Errorf("max_body_bytes must be at most %d", maxMaxBodyBytes)
+	}
+	timeout := defaultTimeout
+	if query.Timeout > 0 {
+		timeout = time.Duration(query.Timeout * float64(time.Second))
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Proxies would measure the path to the proxy, so connect directly
+	transport := &http.Transport{
+		DisableKeepAlives: true,
+		ForceAttemptHTTP2: true,
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: query.Insecure},
+	}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	result := &Result{
+		URL:       query.URL,
+		Method:    query.Method,
+		Redirects: []*Redirect{},
+	}
+	start := time.Now()
+	for {
+		f, err := fetch(ctx, client, &query, target)
+		if err != nil {
+			return nil, err
+		}
+		location := f.redirect()
+		if query.NoRedirects || location == nil {
+			if err := f.finish(&query, result); err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		f.resp.Body.Close()
+		f.timings.Total = millis(time.Since(f.start))
+		if len(result.Redirects) == query.MaxRedirects {
+			return nil, fmt.Errorf("stopped after %d redirects", query.MaxRedirects)
+		}
+		if location.Scheme != "http" && location.Scheme != "https" {
+			return nil, fmt.Errorf("redirect to unsupported url %s", location)
+		}
+		result.Redirects = append(result.Redirects, &Redirect{
+			URL:        target.String(),
+			Address:    f.address,
+			StatusCode: f.resp.StatusCode,
+			Location:   location.String(),
+			Timings:    f.timings,
+		})
+		target = location
+	}
+	result.Duration = millis(time.Since(start))
+
+	return result, nil
+}
+
+// fetched is a response whose headers have arrived, with the timings of
+// the request so far
+type fetched struct {
+	resp    *http.Response
+	start   time.Time
+	address string
+	timings Timings
+}
+
+// fetch sends one request for target and receives its response headers,
+// tracing the phases of the request
+func fetch(ctx context.Context, client *http.Client, query *Query, target *url.URL) (*fetched, error) {
+	f := &fetched{}
+	var dnsStart, connectStart, tlsStart time.Time
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			f.timings.DNS = millis(time.Since(dnsStart))
+		},
+		// With several addresses connections may be attempted in
+		// parallel; the one used finishes last
+		ConnectStart: func(string, string) {
+			if connectStart.IsZero() {
+				connectStart = time.Now()
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				f.timings.Connect = millis(time.Since(connectStart))
+			}
+		},
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			f.timings.TLS = millis(time.Since(tlsStart))
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			f.address = info.Conn.RemoteAddr().String()
+		},
+		GotFirstResponseByte: func() {
+			f.timings.TTFB = millis(time.Since(f.start))
+		},
+	}
+
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), query.Method, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	for name, value := range query.Headers {
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	f.start = time.Now()
+	f.resp, err = client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// redirect returns where a redirect response points, or nil if the
+// response is not a redirect
+func (f *fetched) redirect() *url.URL {
+	switch f.resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return nil
+	}
+	location, err := f.resp.Location()
+	if err != nil {
+		return nil
+	}
+	return location
+}
+
+// finish reads the body of the final response and fills in the result
+func (f *fetched) finish(query *Query, result *Result) error {
+	defer f.resp.Body.Close()
+
+	resp := f.resp
+	result.FinalURL = resp.Request.URL.String()
+	result.Address = f.address
+	result.Proto = resp.Proto
+	result.StatusCode = resp.StatusCode
+	result.Headers = make(map[string]string, len(resp.Header))
+	for name, values := range resp.Header {
+		result.Headers[name] = strings.Join(values, ", ")
+	}
+	if state := resp.TLS; state != nil {
+		result.TLS = &TLS{
+			Version:     tls.VersionName(state.Version),
+			CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+			ServerName:  state.ServerName,
+		}
+		if len(state.PeerCertificates) > 0 {
+			leaf := state.PeerCertificates[0]
+			result.TLS.Subject = leaf.Subject.String()
+			result.TLS.Issuer = leaf.Issuer.String()
+			result.TLS.NotAfter = leaf.NotAfter
+		}
+	}
+
+	if query.Method != http.MethodHead {
+		hash := sha256.New()
+		head := &prefixWriter{limit: titleScanBytes}
+		n, err := io.Copy(io.MultiWriter(hash, head), io.LimitReader(resp.Body, query.MaxBodyBytes))
+		if err != nil {
+			return fmt.Errorf("reading body: %w", err)
+		}
+		// A byte past the limit means the body was cut short
+		if n == query.MaxBodyBytes {
+			var b [1]byte
+			more, _ := resp.Body.Read(b[:])
+			result.BodyTruncated = more > 0
+		}
+		result.BodySHA256 = hex.EncodeToString(hash.Sum(nil))
+		result.BodyLength = n
+		if strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "html") {
+			result.Title = htmlTitle(head.buf)
+		}
+	}
+	f.timings.Total = millis(time.Since(f.start))
+	result.Timings = f.timings
+	return nil
+}
+
+// prefixWriter keeps the first limit bytes written to it
+type prefixWriter struct {
+	buf   []byte
+	limit int
+}
+
+// Write keeps what fits of p, never failing
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	if room := w.limit - len(w.buf); room > 0 {
+		w.buf = append(w.buf, p[:min(room, len(p))]...)
+	}
+	return len(p), nil
+}
+
+// htmlTitle returns the title of an HTML document with whitespace collapsed,
+// or "" if it has none
+func htmlTitle(body []byte) string {
+	match := titlePattern.FindSubmatch(body)
+	if match == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(string(match[1])), " ")
+}
+
+// millis converts a duration to milliseconds at microsecond precision
+func millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,352 @@
+// Package ping is an agent module measuring round-trip times to a host with
+// ICMP echo requests, or with UDP probes where ICMP sockets are not allowed.
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// ModuleName is the module ping tasks name and results are stored under
+const ModuleName = "ping_module"
+
+const (
+	// ProtocolICMP probes with ICMP echo requests
+	ProtocolICMP = "icmp"
+	// ProtocolUDP probes with UDP datagrams to a closed port, timing the
+	// port unreachable error (or a reply) they draw
+	ProtocolUDP = "udp"
+
+	defaultCount    = 3
+	defaultPort     = 33434
+	defaultInterval = time.Second
+	defaultTimeout  = 5 * time.Second
+	maxCount        = 100
+)
+
+// Query is the payload of a ping task
+type Query struct {
+	Host  string `json:"host"`
+	Count int    `json:"count,omitempty"`
+	// Port is the UDP probe destination port
+	Port int `json:"port,omitempty"`
+	// Protocol is "icmp", "udp", or empty for ICMP falling back to UDP
+	Protocol string `json:"protocol,omitempty"`
+	// Interval and Timeout are in seconds, between probes and per probe
+	Interval float64 `json:"interval,omitempty"`
+	Timeout  float64 `json:"timeout,omitempty"`
+}
+
+// Result is the data of a ping result. RTTs are in milliseconds and hold
+// one entry per answered probe; the summaries are 0 when none was.
+type Result struct {
+	Host            string    `json:"host"`
+	Address         string    `json:"address"`
+	Protocol        string    `json:"protocol"`
+	RTTs            []float64 `json:"rtts"`
+	PacketsSent     int       `json:"packets_sent"`
+	PacketsReceived int       `json:"packets_received"`
+	PacketLoss      float64   `json:"packet_loss"` // fraction of probes unanswered
+	RTTMin          float64   `json:"rtt_min"`
+	RTTAvg          float64   `json:"rtt_avg"`
+	RTTMax          float64   `json:"rtt_max"`
+}
+
+// Module runs ping tasks
+type Module struct{}
+
+// New creates a new ping module
+func New() *Module {
+	return &Module{}
+}
+
+// Name returns the module name
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+// Run pings the host a query names
+func (m *Module) Run(ctx context.Context, payload []byte) (interface{}, error) {
+	var query Query
+	if err := json.Unmarshal(payload, &query); err != nil {
+		return nil, fmt.Errorf("invalid ping query: %w", err)
+	}
+	if query.Host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if query.Count <= 0 {
+		query.Count = defaultCount
+	}
+	if query.Count > maxCount {
+		return nil, fmt.Errorf("count must be at most %d", maxCount)
+	}
+	if query.Port <= 0 {
+		query.Port = defaultPort
+	}
+	interval := seconds(query.Interval, defaultInterval)
+	timeout := seconds(query.Timeout, defaultTimeout)
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", query.Host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no address for %s", query.Host)
+	}
+	addr := addrs[0].Unmap()
+
+	var p prober
+	switch query.Protocol {
+	case ProtocolICMP:
+		p, err = newICMPProber(addr)
+	case ProtocolUDP:
+		p = newUDPProber(addr, query.Port)
+	case "":
+		if p, err = newICMPProber(addr); err != nil {
+			p, err = newUDPProber(addr, query.Port), nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", query.Protocol)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+
+	result := &Result{
+		Host:     query.Host,
+		Address:  addr.String(),
+		Protocol: p.Protocol(),
+		RTTs:     []float64{},
+	}
+	for seq := 0; seq < query.Count; seq++ {
+		if seq > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		result.PacketsSent++
+		rtt, ok, err := p.Probe(ctx, seq, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result.PacketsReceived++
+			result.RTTs = append(result.RTTs, float64(rtt.Microseconds())/1000)
+		}
+	}
+	result.Summarize()
+
+	return result, nil
+}
+
+// Summarize derives the packet loss and RTT summaries from the probe counts
+// and RTTs
+func (r *Result) Summarize() {
+	r.PacketLoss = float64(r.PacketsSent-r.PacketsReceived) / float64(r.PacketsSent)
+	if len(r.RTTs) == 0 {
+		return
+	}
+
+	r.RTTMin, r.RTTMax = math.Inf(1), math.Inf(-1)
+	var sum float64
+	for _, rtt := range r.RTTs {
+		r.RTTMin = math.Min(r.RTTMin, rtt)
+		r.RTTMax = math.Max(r.RTTMax, rtt)
+		sum += rtt
+	}
+	r.RTTAvg = sum / float64(len(r.RTTs))
+}
+
+// seconds converts a duration in seconds, using def if it is not positive
+func seconds(s float64, def time.Duration) time.Duration {
+	if s <= 0 {
+		return def
+	}
+	return time.Duration(s * float64(time.Second))
+}
+
+// prober sends probes to one address. Probe reports the round-trip time of
+// probe seq and whether it was answered within timeout; errors are failures
+// to probe at all.
+type prober interface {
+	Protocol() string
+	Probe(ctx context.Context, seq int, timeout time.Duration) (time.Duration, bool, error)
+	Close() error
+}
+
+// icmpProber sends ICMP echo requests over an unprivileged ICMP socket, or a
+// raw one when running privileged
+type icmpProber struct {
+	conn      *icmp.PacketConn
+	dst       net.Addr
+	echoType  icmp.Type
+	replyType icmp.Type
+	proto     int
+	id        int
+}
+
+// newICMPProber opens an ICMP socket for the address family of addr
+func newICMPProber(addr netip.Addr) (*icmpProber, error) {
+	p := &icmpProber{
+		echoType:  ipv4.ICMPTypeEcho,
+		replyType: ipv4.ICMPTypeEchoReply,
+		proto:     1,
+		id:        int(time.Now().UnixNano() & 0xffff),
+	}
+	dgram, raw, listen := "udp4", "ip4:icmp", "0.0.0.0"
+	if addr.Is6() {
+		p.echoType, p.replyType, p.proto = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply, 58
+		dgram, raw, listen = "udp6", "ip6:ipv6-icmp", "::"
+	}
+
+	conn, err := icmp.ListenPacket(dgram, listen)
+	if err == nil {
+		p.conn, p.dst = conn, &net.UDPAddr{IP: addr.AsSlice(), Zone: addr.Zone()}
+		return p, nil
+	}
+	conn, rawErr := icmp.ListenPacket(raw, listen)
+	if rawErr != nil {
+		return nil, fmt.Errorf("opening ICMP socket: %w", err)
+	}
+	p.conn, p.dst = conn, &net.IPAddr{IP: addr.AsSlice(), Zone: addr.Zone()}
+	return p, nil
+}
+
+// Protocol returns "icmp"
+func (p *icmpProber) Protocol() string {
+	return ProtocolICMP
+}
+
+// Probe sends one echo request and waits for its reply. Unprivileged
+// sockets rewrite the identifier, so replies are matched on sequence number
+// and payload.
+func (p *icmpProber) Probe(ctx context.Context, seq int, timeout time.Duration) (time.Duration, bool, error) {
+	body := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+	msg, err := (&icmp.Message{
+		Type: p.echoType,
+		Body: &icmp.Echo{ID: p.id, Seq: seq, Data: body},
+	}).Marshal(nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := p.conn.SetReadDeadline(deadline); err != nil {
+		return 0, false, err
+	}
+
+	start := time.Now()
+	if _, err := p.conn.WriteTo(msg, p.dst); err != nil {
+		return 0, false, err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := p.conn.ReadFrom(buf)
+		if err != nil {
+			if isTimeout(err) {
+				return 0, false, nil
+			}
+			return 0, false, err
+		}
+		rtt := time.Since(start)
+
+		reply, err := icmp.ParseMessage(p.proto, buf[:n])
+		if err != nil || reply.Type != p.replyType {
+			continue
+		}
+		echo, ok := reply.Body.(*icmp.Echo)
+		if !ok || echo.Seq != seq || string(echo.Data) != string(body) {
+			continue
+		}
+		return rtt, true, nil
+	}
+}
+
+// Close closes the ICMP socket
+func (p *icmpProber) Close() error {
+	return p.conn.Close()
+}
+
+// udpProber sends UDP datagrams, each from a fresh socket so that a late
+// port unreachable error is not taken for the answer to the next probe
+type udpProber struct {
+	dst string
+}
+
+// newUDPProber creates a prober for a UDP port of addr
+func newUDPProber(addr netip.Addr, port int) *udpProber {
+	return &udpProber{
+		dst: netip.AddrPortFrom(addr, uint16(port)).String(),
+	}
+}
+
+// Protocol returns "udp"
+func (p *udpProber) Protocol() string {
+	return ProtocolUDP
+}
+
+// Probe sends one datagram and waits for a reply or for the connection
+// refused error that an ICMP port unreachable message raises
+func (p *udpProber) Probe(ctx context.Context, seq int, timeout time.Duration) (time.Duration, bool, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", p.dst)
+	if err != nil {
+		return 0, false, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return 0, false, err
+	}
+
+	start := time.Now()
+	if _, err := conn.Write([]byte(fmt.Sprintf("dbos-ping %d", seq))); err != nil {
+		return 0, false, err
+	}
+
+	buf := make([]byte, 1500)
+	_, err = conn.Read(buf)
+	rtt := time.Since(start)
+	switch {
+	case err == nil, errors.Is(err, syscall.ECONNREFUSED):
+		return rtt, true, nil
+	case isTimeout(err):
+		return 0, false, nil
+	}
+	return 0, false, err
+}
+
+// Close does nothing; sockets are closed after every probe
+func (p *udpProber) Close() error {
+	return nil
+}
+
+// isTimeout reports whether err is a read deadline expiring
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,171 @@
+package agent
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/internet-measurement-network/dbos/api"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// Extensions of spooled call files, naming the request they hold
+const (
+	spoolResultExt = ".result"
+	spoolStateExt  = ".state"
+	spoolTempExt   = ".tmp"
+)
+
+// Spool is a directory buffering the StoreResult and SetModuleState calls
+// an agent could not make while DBOS was unreachable, to be replayed once
+// it is reachable again. Each call is a file holding its request, named by
+// a sequence number so the calls are replayed in the order they were made,
+// also after the agent restarts. Replaying is safe as both calls are
+// idempotent: a result is stored under its ID, a module state under its
+// request ID.
+type Spool struct {
+	dir   string
+	limit int
+
+	mu    sync.Mutex
+	next  uint64
+	count int
+	// wake is signalled when a call is added
+	wake chan struct{}
+}
+
+// OpenSpool opens the spool in dir, creating the directory if needed. The
+// spool holds up to limit calls; further calls are refused until replayed
+// ones make room.
+func OpenSpool(dir string, limit int) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Spool{dir: dir, limit: limit, wake: make(chan struct{}, 1)}
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		switch ext {
+		case spoolTempExt:
+			// A call the agent stopped while writing was never spooled
+			os.Remove(filepath.Join(dir, name))
+			continue
+		case spoolResultExt, spoolStateExt:
+		default:
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 10, 64)
+		if err != nil {
+			continue
+		}
+		s.next = max(s.next, seq+1)
+		s.count++
+	}
+	return s, nil
+}
+
+// Len returns the number of calls buffered
+func (s *Spool) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+// add buffers a StoreResult or SetModuleState request after those already
+// buffered
+func (s *Spool) add(req proto.Message) error {
+	var ext string
+	switch req.(type) {
+	case *api.StoreResultRequest:
+		ext = spoolResultExt
+	case *api.SetModuleStateRequest:
+		ext = spoolStateExt
+	default:
+		return fmt.Errorf("cannot spool %T", req)
+	}
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.count >= s.limit {
+		return dberrors.New(dberrors.QuotaExceeded, "spool %s is full with %d calls", s.dir, s.count)
+	}
+
+	// Written aside and renamed, so a replay never reads a partial call
+	path := filepath.Join(s.dir, fmt.Sprintf("%020d%s", s.next, ext))
+	if err := os.WriteFile(path+spoolTempExt, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(path+spoolTempExt, path); err != nil {
+		os.Remove(path + spoolTempExt)
+		return err
+	}
+	s.next++
+	s.count++
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// oldest returns the earliest buffered call and the name of its file, or a
+// nil request if none is buffered. Calls that cannot be decoded are dropped.
+func (s *Spool) oldest() (string, proto.Message, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		var req proto.Message
+		switch filepath.Ext(name) {
+		case spoolResultExt:
+			req = &api.StoreResultRequest{}
+		case spoolStateExt:
+			req = &api.SetModuleStateRequest{}
+		default:
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return "", nil, err
+		}
+		if err := proto.Unmarshal(data, req); err != nil {
+			// A corrupt call can never be replayed
+			log.Printf("Dropping corrupt spooled call %s: %v", name, err)
+			if err := s.remove(name); err != nil {
+				return "", nil, err
+			}
+			continue
+		}
+		return name, req, nil
+	}
+	return "", nil, nil
+}
+
+// remove drops a buffered call once replayed
+func (s *Spool) remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(filepath.Join(s.dir, name)); err != nil {
+		return err
+	}
+	s.count--
+	return nil
+}
@@ -0,0 +1,676 @@
+// Package traceroute is an agent module tracing the path to a host hop by
+// hop with ICMP, UDP or TCP probes of increasing TTL.
+package traceroute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// ModuleName is the module traceroute tasks name and results are stored under
+const ModuleName = "traceroute_module"
+
+const (
+	// ProtocolICMP probes with ICMP echo requests
+	ProtocolICMP = "icmp"
+	// ProtocolUDP probes with UDP datagrams to high, usually closed ports
+	ProtocolUDP = "udp"
+	// ProtocolTCP probes with TCP connection attempts, which pass firewalls
+	// that drop ICMP and UDP
+	ProtocolTCP = "tcp"
+
+	defaultMaxHops = 30
+	maxMaxHops     = 64
+	defaultQueries = 3
+	maxQueries     = 10
+	defaultUDPPort = 33434
+	defaultTCPPort = 80
+	defaultTimeout = 2 * time.Second
+
+	// asnTimeout bounds the AS number lookups of a trace
+	asnTimeout = 5 * time.Second
+)
+
+// Query is the payload of a traceroute task
+type Query struct {
+	Host string `json:"host"`
+	// Protocol is "icmp" (the default), "udp" or "tcp"
+	Protocol string `json:"protocol,omitempty"`
+	// Port is the first UDP destination port, incremented per probe, or the
+	// TCP destination port
+	Port int `json:"port,omitempty"`
+	// MaxHops is the highest TTL probed
+	MaxHops int `json:"max_hops,omitempty"`
+	// Queries is how many probes are sent per hop
+	Queries int `json:"queries,omitempty"`
+	// Timeout is in seconds, per probe
+	Timeout float64 `json:"timeout,omitempty"`
+	// SkipASN skips looking up the AS numbers of hops
+	SkipASN bool `json:"skip_asn,omitempty"`
+}
+
+// Hop is one TTL of a trace. RTTs are in milliseconds and hold one entry per
+// answered probe; the summaries are 0 when none was.
+type Hop struct {
+	TTL int `json:"ttl"`
+	// Address is the router that answered first, "" if none did
+	Address string `json:"address,omitempty"`
+	// Addresses lists every router that answered when they differ, e.g. on
+	// load-balanced paths
+	Addresses      []string  `json:"addresses,omitempty"`
+	ASN            int64     `json:"asn,omitempty"`
+	RTTs           []float64 `json:"rtts"`
+	ProbesSent     int       `json:"probes_sent"`
+	ProbesReceived int       `json:"probes_received"`
+	Loss           float64   `json:"loss"` // fraction of probes unanswered
+	RTTMin         float64   `json:"rtt_min"`
+	RTTAvg         float64   `json:"rtt_avg"`
+	RTTMax         float64   `json:"rtt_max"`
+}
+
+// Result is the data of a traceroute result. Reached reports whether the
+// destination answered; tracing stops at the hop where it did or where a
+// router reported it unreachable.
+type Result struct {
+	Host     string `json:"host"`
+	Address  string `json:"address"`
+	Protocol string `json:"protocol"`
+	Port     int    `json:"port,omitempty"`
+	Reached  bool   `json:"reached"`
+	Hops     []*Hop `json:"hops"`
+}
+
+// Module runs traceroute tasks
+type Module struct{}
+
+// New creates a new traceroute module
+func New() *Module {
+	return &Module{}
+}
+
+// Name returns the module name
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+// Run traces the path to the host a query names. Replies are received on a
+// raw ICMP socket, so the agent needs to run as root or with CAP_NET_RAW.
+func (m *Module) Run(ctx context.Context, payload []byte) (interface{}, error) {
+	var query Query
+	if err := json.Unmarshal(payload, &query); err != nil {
+		return nil, fmt.Errorf("invalid traceroute query: %w", err)
+	}
+	if query.Host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if query.Protocol == "" {
+		query.Protocol = ProtocolICMP
+	}
+	switch query.Protocol {
+	case ProtocolICMP:
+		query.Port = 0
+	case ProtocolUDP:
+		if query.Port <= 0 {
+			query.Port = defaultUDPPort
+		}
+	case ProtocolTCP:
+		if query.Port <= 0 {
+			query.Port = defaultTCPPort
+		}
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", query.Protocol)
+	}
+	if query.Port > math.MaxUint16 {
+		return nil, fmt.Errorf("invalid port %d", query.Port)
+	}
+	if query.MaxHops <= 0 {
+		query.MaxHops = defaultMaxHops
+	}
+	if query.MaxHops > maxMaxHops {
+		return nil, fmt.Errorf("max_hops must be at most %d", maxMaxHops)
+	}
+	if query.Queries <= 0 {
+		query.Queries = defaultQueries
+	}
+	if query.Queries > maxQueries {
+		return nil, fmt.Errorf("queries must be at most %d", maxQueries)
+	}
+	timeout := defaultTimeout
+	if query.Timeout > 0 {
+		timeout = time.Duration(query.Timeout * float64(time.Second))
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", query.Host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no address for %s", query.Host)
+	}
+	addr := addrs[0].Unmap()
+
+	t, err := newTracer(addr, query.Protocol, query.Port)
+	if err != nil {
+		return nil, err
+	}
+	defer t.Close()
+
+	result := &Result{
+		Host:     query.Host,
+		Address:  addr.String(),
+		Protocol: query.Protocol,
+		Port:     query.Port,
+		Hops:     []*Hop{},
+	}
+	for ttl := 1; ttl <= query.MaxHops; ttl++ {
+		hop := &Hop{TTL: ttl, RTTs: []float64{}}
+		stop := false
+		for i := 0; i < query.Queries; i++ {
+			hop.ProbesSent++
+			answer, err := t.Probe(ctx, ttl, timeout)
+			if err != nil {
+				return nil, err
+			}
+			if answer == nil {
+				continue
+			}
+
+			hop.ProbesReceived++
+			hop.RTTs = append(hop.RTTs, float64(answer.rtt.Microseconds())/1000)
+			hop.addAddress(answer.from.String())
+			switch answer.kind {
+			case answerDestination:
+				result.Reached, stop = true, true
+			case answerUnreachable:
+				stop = true
+			}
+		}
+		hop.summarize()
+		result.Hops = append(result.Hops, hop)
+		if stop {
+			break
+		}
+	}
+
+	if !query.SkipASN {
+		lookupCtx, cancel := context.WithTimeout(ctx, asnTimeout)
+		resolveASNs(lookupCtx, result.Hops)
+		cancel()
+	}
+
+	return result, nil
+}
+
+// addAddress records a router that answered a probe of the hop
+func (h *Hop) addAddress(address string) {
+	if h.Address == "" {
+		h.Address = address
+		return
+	}
+	if len(h.Addresses) == 0 {
+		if address == h.Address {
+			return
+		}
+		h.Addresses = []string{h.Address}
+	}
+	for _, known := range h.Addresses {
+		if known == address {
+			return
+		}
+	}
+	h.Addresses = append(h.Addresses, address)
+}
+
+// summarize derives the loss and RTT summaries from the probe counts and RTTs
+func (h *Hop) summarize() {
+	h.Loss = float64(h.ProbesSent-h.ProbesReceived) / float64(h.ProbesSent)
+	if len(h.RTTs) == 0 {
+		return
+	}
+
+	h.RTTMin, h.RTTMax = math.Inf(1), math.Inf(-1)
+	var sum float64
+	for _, rtt := range h.RTTs {
+		h.RTTMin = math.Min(h.RTTMin, rtt)
+		h.RTTMax = math.Max(h.RTTMax, rtt)
+		sum += rtt
+	}
+	h.RTTAvg = sum / float64(len(h.RTTs))
+}
+
+// answerKind tells what answered a probe
+type answerKind int
+
+const (
+	// answerTransit is a router reporting the probe's TTL expired
+	answerTransit answerKind = iota
+	// answerDestination is the destination itself
+	answerDestination
+	// answerUnreachable is a router reporting the destination unreachable
+	answerUnreachable
+)
+
+// answer is the answer to a probe
+type answer struct {
+	from netip.Addr
+	rtt  time.Duration
+	kind answerKind
+}
+
+// reply is an ICMP message received in answer to the probe identified by key
+type reply struct {
+	key  int
+	from netip.Addr
+	kind answerKind
+	at   time.Time
+}
+
+// tracer sends probes towards one address and receives the ICMP messages
+// they draw on a raw socket. Probes are identified by a key the quoted probe
+// header carries: the echo sequence number, the UDP destination port or
+// the TCP source port.
+type tracer struct {
+	dst      netip.Addr
+	protocol string
+	port     int
+
+	conn      *icmp.PacketConn
+	icmpProto int
+	udp       *net.UDPConn
+	id        int
+	next      int
+
+	replies chan reply
+	done    chan struct{}
+}
+
+// newTracer opens the raw ICMP socket, and the UDP socket for UDP probes,
+// and starts receiving replies
+func newTracer(dst netip.Addr, protocol string, port int) (*tracer, error) {
+	t := &tracer{
+		dst:       dst,
+		protocol:  protocol,
+		port:      port,
+		icmpProto: 1,
+		id:        int(time.Now().UnixNano() & 0xffff),
+		replies:   make(chan reply, 16),
+		done:      make(chan struct{}),
+	}
+	raw, listen := "ip4:icmp", "0.0.0.0"
+	if dst.Is6() {
+		t.icmpProto = 58
+		raw, listen = "ip6:ipv6-icmp", "::"
+	}
+
+	conn, err := icmp.ListenPacket(raw, listen)
+	if err != nil {
+		return nil, fmt.Errorf("opening raw ICMP socket (traceroute needs root or CAP_NET_RAW): %w", err)
+	}
+	t.conn = conn
+	if protocol == ProtocolUDP {
+		network := "udp4"
+		if dst.Is6() {
+			network = "udp6"
+		}
+		if t.udp, err = net.ListenUDP(network, nil); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	go t.receive()
+	return t, nil
+}
+
+// Close closes the tracer's sockets, which ends receiving
+func (t *tracer) Close() error {
+	close(t.done)
+	if t.udp != nil {
+		t.udp.Close()
+	}
+	return t.conn.Close()
+}
+
+// receive reads ICMP messages until the socket is closed, passing on those
+// answering a probe of the tracer
+func (t *tracer) receive() {
+	buf := make([]byte, 1500)
+	for {
+		n, peer, err := t.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		at := time.Now()
+		from, ok := peerAddr(peer)
+		if !ok {
+			continue
+		}
+		key, kind, ok := t.match(buf[:n], from)
+		if !ok {
+			continue
+		}
+
+		select {
+		case t.replies <- reply{key: key, from: from, kind: kind, at: at}:
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// match parses an ICMP message, reporting the key of the probe it answers
+// and what answered it
+func (t *tracer) match(b []byte, from netip.Addr) (int, answerKind, bool) {
+	msg, err := icmp.ParseMessage(t.icmpProto, b)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	var quoted []byte
+	var kind answerKind
+	switch msg.Type {
+	case ipv4.ICMPTypeEchoReply, ipv6.ICMPTypeEchoReply:
+		echo, ok := msg.Body.(*icmp.Echo)
+		if !ok || t.protocol != ProtocolICMP || echo.ID != t.id || from != t.dst {
+			return 0, 0, false
+		}
+		return echo.Seq, answerDestination, true
+	case ipv4.ICMPTypeTimeExceeded, ipv6.ICMPTypeTimeExceeded:
+		body, ok := msg.Body.(*icmp.TimeExceeded)
+		if !ok {
+			return 0, 0, false
+		}
+		quoted, kind = body.Data, answerTransit
+	case ipv4.ICMPTypeDestinationUnreachable, ipv6.ICMPTypeDestinationUnreachable:
+		body, ok := msg.Body.(*icmp.DstUnreach)
+		if !ok {
+			return 0, 0, false
+		}
+		// A closed port of the destination is how UDP probes arrive
+		quoted, kind = body.Data, answerUnreachable
+		if from == t.dst {
+			kind = answerDestination
+		}
+	default:
+		return 0, 0, false
+	}
+
+	proto, dst, header, ok := parseQuoted(quoted, t.dst.Is6())
+	if !ok || dst != t.dst {
+		return 0, 0, false
+	}
+	switch {
+	case t.protocol == ProtocolICMP && proto == t.icmpProto:
+		if int(header[4])<<8|int(header[5]) != t.id {
+			return 0, 0, false
+		}
+		return int(header[6])<<8 | int(header[7]), kind, true
+	case t.protocol == ProtocolUDP && proto == syscall.IPPROTO_UDP:
+		return int(header[2])<<8 | int(header[3]), kind, true
+	case t.protocol == ProtocolTCP && proto == syscall.IPPROTO_TCP:
+		return int(header[0])<<8 | int(header[1]), kind, true
+	}
+	return 0, 0, false
+}
+
+// parseQuoted parses the probe an ICMP error quotes, returning its protocol,
+// destination and the first 8 bytes of its transport header
+func parseQuoted(b []byte, is6 bool) (int, netip.Addr, []byte, bool) {
+	if is6 {
+		if len(b) < ipv6.HeaderLen+8 {
+			return 0, netip.Addr{}, nil, false
+		}
+		return int(b[6]), netip.AddrFrom16([16]byte(b[24:40])), b[ipv6.HeaderLen:], true
+	}
+	if len(b) < ipv4.HeaderLen {
+		return 0, netip.Addr{}, nil, false
+	}
+	headerLen := int(b[0]&0x0f) * 4
+	if headerLen < ipv4.HeaderLen || len(b) < headerLen+8 {
+		return 0, netip.Addr{}, nil, false
+	}
+	return int(b[9]), netip.AddrFrom4([4]byte(b[16:20])), b[headerLen:], true
+}
+
+// Probe sends one probe with a TTL and waits for its answer, returning nil
+// if none arrives within timeout
+func (t *tracer) Probe(ctx context.Context, ttl int, timeout time.Duration) (*answer, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var key int
+	var connected chan error
+	start := time.Now()
+	switch t.protocol {
+	case ProtocolICMP:
+		key = t.nextKey(0)
+		if err := t.sendEcho(ttl, key); err != nil {
+			return nil, err
+		}
+	case ProtocolUDP:
+		key = t.nextKey(t.port)
+		if err := t.sendDatagram(ttl, key); err != nil {
+			return nil, err
+		}
+	case ProtocolTCP:
+		var err error
+		if key, connected, err = t.connect(probeCtx, ttl); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		select {
+		case <-probeCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, nil
+		case r := <-t.replies:
+			if r.key != key || r.at.Before(start) {
+				continue
+			}
+			return &answer{from: r.from, rtt: r.at.Sub(start), kind: r.kind}, nil
+		case err := <-connected:
+			// An accepted or refused connection means the destination answered
+			if err == nil || errors.Is(err, syscall.ECONNREFUSED) {
+				return &answer{from: t.dst, rtt: time.Since(start), kind: answerDestination}, nil
+			}
+			connected = nil
+		}
+	}
+}
+
+// nextKey returns the key of the next ICMP or UDP probe, counting up from base
+func (t *tracer) nextKey(base int) int {
+	key := (base + t.next) & 0xffff
+	t.next++
+	return key
+}
+
+// sendEcho sends an echo request with a TTL on the raw socket
+func (t *tracer) sendEcho(ttl, seq int) error {
+	echoType := icmp.Type(ipv4.ICMPTypeEcho)
+	dst := &net.IPAddr{IP: t.dst.AsSlice(), Zone: t.dst.Zone()}
+	if t.dst.Is6() {
+		echoType = ipv6.ICMPTypeEchoRequest
+		if err := t.conn.IPv6PacketConn().SetHopLimit(ttl); err != nil {
+			return err
+		}
+	} else if err := t.conn.IPv4PacketConn().SetTTL(ttl); err != nil {
+		return err
+	}
+
+	msg, err := (&icmp.Message{
+		Type: echoType,
+		Body: &icmp.Echo{ID: t.id, Seq: seq, Data: []byte("dbos-traceroute")},
+	}).Marshal(nil)
+	if err != nil {
+		return err
+	}
+	_, err = t.conn.WriteTo(msg, dst)
+	return err
+}
+
+// sendDatagram sends a UDP datagram with a TTL to a port of the destination
+func (t *tracer) sendDatagram(ttl, port int) error {
+	if t.dst.Is6() {
+		if err := ipv6.NewConn(t.udp).SetHopLimit(ttl); err != nil {
+			return err
+		}
+	} else if err := ipv4.NewConn(t.udp).SetTTL(ttl); err != nil {
+		return err
+	}
+
+	_, err := t.udp.WriteToUDPAddrPort([]byte("dbos-traceroute"), netip.AddrPortFrom(t.dst, uint16(port)))
+	return err
+}
+
+// connect starts a TCP connection attempt with a TTL, returning the local
+// port identifying it and a channel receiving the attempt's outcome. The
+// attempt is abandoned when ctx is done.
+func (t *tracer) connect(ctx context.Context, ttl int) (int, chan error, error) {
+	ports := make(chan int, 1)
+	connected := make(chan error, 1)
+	dialer := net.Dialer{
+		Control: func(network, address string, c syscall.RawConn) error {
+			var err error
+			var port int
+			if ctrlErr := c.Control(func(fd uintptr) {
+				port, err = bindWithTTL(int(fd), t.dst.Is6(), ttl)
+			}); ctrlErr != nil {
+				return ctrlErr
+			}
+			if err != nil {
+				return err
+			}
+			ports <- port
+			return nil
+		},
+	}
+
+	go func() {
+		conn, err := dialer.DialContext(ctx, "tcp", netip.AddrPortFrom(t.dst, uint16(t.port)).String())
+		if err == nil {
+			conn.Close()
+		}
+		connected <- err
+	}()
+
+	select {
+	case port := <-ports:
+		return port, connected, nil
+	case err := <-connected:
+		return 0, nil, err
+	}
+}
+
+// bindWithTTL sets the TTL of a socket and binds it to an ephemeral port,
+// returning the port
+func bindWithTTL(fd int, is6 bool, ttl int) (int, error) {
+	if is6 {
+		if err := syscall.SetsockoptInt(fd, syscall.IPPROTO_IPV6, syscall.IPV6_UNICAST_HOPS, ttl); err != nil {
+			return 0, err
+		}
+		if err := syscall.Bind(fd, &syscall.SockaddrInet6{}); err != nil {
+			return 0, err
+		}
+	} else {
+		if err := syscall.SetsockoptInt(fd, syscall.IPPROTO_IP, syscall.IP_TTL, ttl); err != nil {
+			return 0, err
+		}
+		if err := syscall.Bind(fd, &syscall.SockaddrInet4{}); err != nil {
+			return 0, err
+		}
+	}
+
+	sa, err := syscall.Getsockname(fd)
+	if err != nil {
+		return 0, err
+	}
+	switch sa := sa.(type) {
+	case *syscall.SockaddrInet4:
+		return sa.Port, nil
+	case *syscall.SockaddrInet6:
+		return sa.Port, nil
+	}
+	return 0, fmt.Errorf("unexpected socket address %T", sa)
+}
+
+// peerAddr returns the address of a raw socket peer
+func peerAddr(peer net.Addr) (netip.Addr, bool) {
+	ipAddr, ok := peer.(*net.IPAddr)
+	if !ok {
+		return netip.Addr{}, false
+	}
+	addr, ok := netip.AddrFromSlice(ipAddr.IP)
+	return addr.Unmap(), ok
+}
+
+// resolveASNs looks up the AS number announcing each hop's address. Hops
+// whose address is private or cannot be looked up are left without one.
+func resolveASNs(ctx context.Context, hops []*Hop) {
+	cache := make(map[string]int64)
+	for _, hop := range hops {
+		if hop.Address == "" {
+			continue
+		}
+		asn, ok := cache[hop.Address]
+		if !ok {
+			asn = lookupASN(ctx, hop.Address)
+			cache[hop.Address] = asn
+		}
+		hop.ASN = asn
+	}
+}
+
+// lookupASN looks up the origin AS of an address in Team Cymru's IP to ASN
+// DNS zone, returning 0 if it is unknown
+func lookupASN(ctx context.Context, address string) int64 {
+	addr, err := netip.ParseAddr(address)
+	if err != nil || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return 0
+	}
+
+	var name string
+	if addr.Is4() {
+		b := addr.As4()
+		name = fmt.Sprintf("%d.%d.%d.%d.origin.asn.cymru.com", b[3], b[2], b[1], b[0])
+	} else {
+		b := addr.As16()
+		nibbles := make([]string, 0, 32)
+		for i := len(b) - 1; i >= 0; i-- {
+			nibbles = append(nibbles, strconv.FormatUint(uint64(b[i]&0x0f), 16), strconv.FormatUint(uint64(b[i]>>4), 16))
+		}
+		name = strings.Join(nibbles, ".") + ".origin6.asn.cymru.com"
+	}
+
+	records, err := net.DefaultResolver.LookupTXT(ctx, name)
+	if err != nil || len(records) == 0 {
+		return 0
+	}
+	// Records read "15169 | 8.8.8.0/24 | US | arin | 2014-03-14", listing
+	// every origin of a multi-origin prefix first
+	origins := strings.Fields(strings.SplitN(records[0], "|", 2)[0])
+	if len(origins) == 0 {
+		return 0
+	}
+	asn, err := strconv.ParseInt(origins[0], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return asn
+}
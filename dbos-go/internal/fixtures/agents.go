@@ -0,0 +1,64 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/agent/ping"
+	"github.com/internet-measurement-network/dbos/internal/agent/traceroute"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// AgentID returns the ID of the i-th fixture agent
+func AgentID(i int) string {
+	return fmt.Sprintf("agent-%03d", i+1)
+}
+
+// Agent generates the i-th fixture agent: alive, labelled with its region,
+// country and agent version, first seen at Epoch and last seen a day later
+func Agent(i int) *models.Agent {
+	region := RegionOf(i)
+	id := AgentID(i)
+	return &models.Agent{
+		ID:              id,
+		Hostname:        id + ".probes.example",
+		Alive:           true,
+		FirstSeen:       Epoch,
+		LastSeen:        Epoch.Add(24 * time.Hour),
+		TotalHeartbeats: 5760,
+		Config:          map[string]string{"heartbeat_seconds": "15"},
+		Labels: map[string]string{
+			"region":                 region.Name,
+			"country":                region.Country,
+			models.AgentVersionLabel: "1.4.0",
+		},
+	}
+}
+
+// PingTask generates a ping task of an agent for a host, scheduled at ts
+func PingTask(id, agentID, host string, ts time.Time) *models.Task {
+	return task(id, agentID, ping.ModuleName, &ping.Query{Host: host, Count: 5}, ts)
+}
+
+// TracerouteTask generates a traceroute task of an agent for a host,
+// scheduled at ts
+func TracerouteTask(id, agentID, host string, ts time.Time) *models.Task {
+	return task(id, agentID, traceroute.ModuleName, &traceroute.Query{Host: host, MaxHops: 30, Queries: 3}, ts)
+}
+
+// DNSTask generates a DNS task of an agent for a name, scheduled at ts
+func DNSTask(id, agentID, name string, ts time.Time) *models.Task {
+	return task(id, agentID, DNSModuleName, map[string]string{"query": name, "type": "A"}, ts)
+}
+
+// DNSModuleName is the module DNS results are stored under
+const DNSModuleName = "dns_module"
+
+// task generates a pending one-shot task created at ts
+func task(id, agentID, moduleName string, query interface{}, ts time.Time) *models.Task {
+	payload, _ := json.Marshal(query)
+	t := models.NewTask(id, agentID, moduleName, payload, ts)
+	t.CreatedAt = ts
+	return t
+}
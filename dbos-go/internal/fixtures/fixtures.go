@@ -0,0 +1,86 @@
+// Package fixtures generates realistic sample data: agents, tasks and ping,
+// traceroute and DNS results shaped like those real agents report. A
+// Generator is deterministic for a seed, so the same seed always yields the
+// same data; the golden files under golden/ are the serialized forms of
+// the data of GoldenSeed, regenerated with "go generate".
+//
+// The server's simulated agents measure with a Generator, so demo and load
+// data and fixtures agree.
+package fixtures
+
+import (
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// Epoch is the time fixtures are dated from, so they are stable
+var Epoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Region is a region agents are spread over, with the latency it adds on
+// top of a target's base RTT and where it is
+type Region struct {
+	Name     string
+	Country  string
+	Delay    float64 // ms
+	Lat, Lon float64
+}
+
+// Regions are the regions fixture agents are spread over
+var Regions = []Region{
+	{"eu-west", "IE", 0, 53.35, -6.26},
+	{"us-east", "US", 35, 39.04, -77.49},
+	{"ap-south", "IN", 120, 19.08, 72.88},
+	{"sa-east", "BR", 90, -23.55, -46.63},
+}
+
+// Targets are the hosts fixture measurements are made of
+var Targets = []string{"example.com", "example.net", "example.org"}
+
+// Generator generates sample data from a random source
+type Generator struct {
+	rng *rand.Rand
+}
+
+// New creates a generator whose data depends only on seed
+func New(seed uint64) *Generator {
+	return &Generator{
+		rng: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+	}
+}
+
+// Duration returns a random duration in [0, d)
+func (g *Generator) Duration(d time.Duration) time.Duration {
+	return time.Duration(g.rng.Int64N(int64(d)))
+}
+
+// RegionOf returns the region the i-th agent is in
+func RegionOf(i int) Region {
+	return Regions[i%len(Regions)]
+}
+
+// Address returns the i-th documentation address of a name: stable for the
+// name, in 203.0.113.0/24 or 2001:db8::/32
+func Address(name string, ipv6 bool, i int) netip.Addr {
+	n := byte(Hash(name)%250) + byte(i) + 1
+	if ipv6 {
+		return netip.AddrFrom16([16]byte{0x20, 0x01, 0x0d, 0xb8, 15: n})
+	}
+	return netip.AddrFrom4([4]byte{203, 0, 113, n})
+}
+
+// Hash hashes a name into stable generation parameters
+func Hash(name string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(name)))
+	return h.Sum32()
+}
+
+// roundMillis rounds a duration in milliseconds to microseconds, the
+// precision real agents report
+func roundMillis(ms float64) float64 {
+	return math.Round(ms*1000) / 1000
+}
@@ -0,0 +1,27 @@
+// Command gen writes the golden files of package fixtures from GoldenData
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/internet-measurement-network/dbos/internal/fixtures"
+)
+
+func main() {
+	data, err := fixtures.GoldenData()
+	if err != nil {
+		log.Fatalf("Generating golden data: %v", err)
+	}
+	for name, value := range data {
+		encoded, err := fixtures.EncodeGolden(value)
+		if err != nil {
+			log.Fatalf("Encoding %s: %v", name, err)
+		}
+		path := filepath.Join("golden", name+".json")
+		if err := os.WriteFile(path, encoded, 0o644); err != nil {
+			log.Fatalf("Writing %s: %v", path, err)
+		}
+	}
+}
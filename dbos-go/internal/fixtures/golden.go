@@ -0,0 +1,60 @@
+package fixtures
+
+//go:generate go run ./gen
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/agent/ping"
+)
+
+// GoldenSeed is the seed the golden data is generated from
+const GoldenSeed = 1
+
+//go:embed golden/*.json
+var golden embed.FS
+
+// Golden returns the serialized form of the golden data of a name, e.g.
+// "agent" or "ping_result"
+func Golden(name string) ([]byte, error) {
+	return golden.ReadFile("golden/" + name + ".json")
+}
+
+// EncodeGolden serializes a value of the golden data as its golden file
+// holds it: indented JSON ending in a newline
+func EncodeGolden(value interface{}) ([]byte, error) {
+	encoded, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(encoded, '\n'), nil
+}
+
+// GoldenData generates the golden data by name from GoldenSeed. Each value
+// is serialized with EncodeGolden into golden/<name>.json.
+func GoldenData() (map[string]interface{}, error) {
+	g := New(GoldenSeed)
+	agent := Agent(0)
+	host := Targets[0]
+	delay := RegionOf(0).Delay
+	ts := Epoch.Add(time.Hour)
+
+	pingResult := g.Ping(host, 5, delay)
+	result, err := Result("task-0001", agent.ID, ping.ModuleName, pingResult, ts, 1)
+	if err != nil {
+		return nil, fmt.Errorf("measurement_result: %w", err)
+	}
+	return map[string]interface{}{
+		"agent":              agent,
+		"ping_task":          PingTask("task-0001", agent.ID, host, ts),
+		"traceroute_task":    TracerouteTask("task-0002", agent.ID, host, ts),
+		"dns_task":           DNSTask("task-0003", agent.ID, "www."+host, ts),
+		"ping_result":        pingResult,
+		"traceroute_result":  g.Traceroute(host, delay),
+		"dns_result":         g.DNS("www."+host, "A", delay),
+		"measurement_result": result,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package fixtures
+
+import (
+	"bytes"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+// TestGolden regenerates the golden data and compares it with the golden
+// files, which go generate rewrites when the generators or models change
+func TestGolden(t *testing.T) {
+	data, err := GoldenData()
+	if err != nil {
+		t.Fatalf("GoldenData: %v", err)
+	}
+	for name, value := range data {
+		want, err := EncodeGolden(value)
+		if err != nil {
+			t.Fatalf("encoding %s: %v", name, err)
+		}
+		got, err := Golden(name)
+		if err != nil {
+			t.Errorf("%s has no golden file; run go generate ./internal/fixtures", name)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("golden/%s.json is stale; run go generate ./internal/fixtures\nhave:\n%s\nwant:\n%s", name, got, want)
+		}
+	}
+
+	files, err := fs.Glob(golden, "golden/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimPrefix(file, "golden/"), ".json")
+		if _, ok := data[name]; !ok {
+			t.Errorf("%s is not generated by GoldenData", file)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/agent/ping"
+	"github.com/internet-measurement-network/dbos/internal/agent/traceroute"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+const (
+	// LossRate is the fraction of generated probes lost
+	LossRate = 0.02
+
+	// spikeRate is the fraction of generated probes whose RTT spikes
+	spikeRate = 0.01
+)
+
+// BaseRTT is the RTT to a host from a region adding delay, before jitter
+func BaseRTT(host string, delay float64) float64 {
+	return 5 + float64(Hash(host)%80) + delay
+}
+
+// Ping generates the result of pinging a host with count probes from a
+// region adding delay. RTTs jitter around BaseRTT, occasionally spike and
+// a LossRate of probes is lost.
+func (g *Generator) Ping(host string, count int, delay float64) *ping.Result {
+	base := BaseRTT(host, delay)
+	result := &ping.Result{
+		Host:        host,
+		Address:     Address(host, false, 0).String(),
+		Protocol:    ping.ProtocolICMP,
+		RTTs:        []float64{},
+		PacketsSent: count,
+	}
+	for i := 0; i < count; i++ {
+		if g.rng.Float64() < LossRate {
+			continue
+		}
+		rtt := base * (1 + g.rng.NormFloat64()*0.05)
+		if g.rng.Float64() < spikeRate {
+			rtt *= 3
+		}
+		result.PacketsReceived++
+		result.RTTs = append(result.RTTs, roundMillis(max(rtt, 0.1)))
+	}
+	result.Summarize()
+	return result
+}
+
+// Traceroute generates the ICMP trace of a host from a region adding
+// delay: a private first hop, transit routers in 198.51.100.0/24 with
+// documentation AS numbers, and the host answering at the last hop
+func (g *Generator) Traceroute(host string, delay float64) *traceroute.Result {
+	const queries = 3
+	result := &traceroute.Result{
+		Host:     host,
+		Address:  Address(host, false, 0).String(),
+		Protocol: traceroute.ProtocolICMP,
+		Reached:  true,
+	}
+
+	hops := 4 + int(Hash(host)%8)
+	base := BaseRTT(host, delay)
+	for ttl := 1; ttl <= hops; ttl++ {
+		hop := &traceroute.Hop{TTL: ttl, RTTs: []float64{}, ProbesSent: queries}
+		switch ttl {
+		case 1:
+			hop.Address = "192.168.1.1"
+		case hops:
+			hop.Address = result.Address
+			hop.ASN = 64496 + int64(Hash(host)%16)
+		default:
+			n := byte(Hash(fmt.Sprintf("%s/%d", host, ttl))%250) + 1
+			hop.Address = netip.AddrFrom4([4]byte{198, 51, 100, n}).String()
+			hop.ASN = 64496 + int64(n%16)
+		}
+
+		// RTTs grow with the hops to the host's base RTT
+		hopRTT := 1 + (base-1)*float64(ttl-1)/float64(hops-1)
+		for i := 0; i < queries; i++ {
+			if ttl > 1 && ttl < hops && g.rng.Float64() < LossRate {
+				continue
+			}
+			rtt := hopRTT * (1 + g.rng.NormFloat64()*0.05)
+			hop.RTTs = append(hop.RTTs, roundMillis(max(rtt, 0.1)))
+		}
+		hop.ProbesReceived = len(hop.RTTs)
+		summarizeHop(hop)
+		result.Hops = append(result.Hops, hop)
+	}
+	return result
+}
+
+// summarizeHop derives a hop's loss and RTT summaries
+func summarizeHop(hop *traceroute.Hop) {
+	hop.Loss = float64(hop.ProbesSent-hop.ProbesReceived) / float64(hop.ProbesSent)
+	if len(hop.RTTs) == 0 {
+		return
+	}
+	hop.RTTMin, hop.RTTMax = math.Inf(1), math.Inf(-1)
+	var sum float64
+	for _, rtt := range hop.RTTs {
+		hop.RTTMin = math.Min(hop.RTTMin, rtt)
+		hop.RTTMax = math.Max(hop.RTTMax, rtt)
+		sum += rtt
+	}
+	hop.RTTAvg = roundMillis(sum / float64(len(hop.RTTs)))
+}
+
+// DNS generates the result of resolving a name from a region adding delay.
+// Answers depend only on the name and type, so every region agrees.
+func (g *Generator) DNS(name, qtype string, delay float64) map[string]interface{} {
+	qtype = strings.ToUpper(qtype)
+	if qtype == "" {
+		qtype = "A"
+	}
+	answers := []string{}
+	if qtype == "A" || qtype == "AAAA" {
+		for i := 0; i < 2; i++ {
+			answers = append(answers, Address(name, qtype == "AAAA", i).String())
+		}
+	}
+	return map[string]interface{}{
+		"query":    name,
+		"type":     qtype,
+		"rcode":    "NOERROR",
+		"answers":  answers,
+		"resolver": "simulated",
+		"rtt":      roundMillis(2 + delay/10 + g.rng.Float64()*3),
+	}
+}
+
+// Result wraps result data into a stored result of an agent, measured at
+// ts and numbered seq
+func Result(id, agentID, moduleName string, data interface{}, ts time.Time, seq int64) (*models.MeasurementResult, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MeasurementResult{
+		ID:         id,
+		AgentID:    agentID,
+		ModuleName: moduleName,
+		Data:       payload,
+		Timestamp:  ts,
+		Origin:     string(models.ResultOriginScheduled),
+		Sequence:   seq,
+	}, nil
+}
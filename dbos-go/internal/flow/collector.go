@@ -0,0 +1,153 @@
+package flow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/ingest"
+)
+
+// ModuleName is the module flow summaries are stored under
+const ModuleName = "flow_summary"
+
+// maxDatagramSize bounds a NetFlow or sFlow datagram
+const maxDatagramSize = 65535
+
+// Collector receives NetFlow and sFlow exports and stores a traffic summary
+// per exporter and interval as a result of agent "flow-<exporter>"
+type Collector struct {
+	dbos      api.DBOSClient
+	interval  time.Duration
+	templates *Templates
+
+	mu         sync.Mutex
+	aggregator *Aggregator
+}
+
+// NewCollector creates a new collector summarizing every interval, keeping
+// the topN talkers and destination ports
+func NewCollector(dbos api.DBOSClient, interval time.Duration, topN int) *Collector {
+	return &Collector{
+		dbos:       dbos,
+		interval:   interval,
+		templates:  NewTemplates(),
+		aggregator: NewAggregator(topN, time.Now()),
+	}
+}
+
+// AgentID returns the ID of the agent an exporter's summaries are stored under
+func AgentID(exporter string) string {
+	return "flow-" + strings.ReplaceAll(exporter, ":", "-")
+}
+
+// Run listens for NetFlow on netflowAddr and sFlow on sflowAddr (either may
+// be empty to disable it) and stores summaries until ctx is done
+func (c *Collector) Run(ctx context.Context, netflowAddr, sflowAddr string) error {
+	var conns []net.PacketConn
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	for _, l := range []struct {
+		addr, source string
+	}{{netflowAddr, "netflow"}, {sflowAddr, "sflow"}} {
+		if l.addr == "" {
+			continue
+		}
+		conn, err := net.ListenPacket("udp", l.addr)
+		if err != nil {
+			return err
+		}
+		conns = append(conns, conn)
+		log.Printf("Listening for %s on %s", l.source, l.addr)
+		go c.receive(conn, l.source)
+	}
+	if len(conns) == 0 {
+		return fmt.Errorf("no listen address configured")
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			c.flush(ctx, now)
+		}
+	}
+}
+
+// receive parses datagrams from conn until it is closed
+func (c *Collector) receive(conn net.PacketConn, source string) {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		from, _ := netip.ParseAddrPort(addr.String())
+
+		exporter := from.Addr().Unmap().String()
+		var records []Record
+		if source == "sflow" {
+			var agent netip.Addr
+			agent, records, err = ParseSFlow(buf[:n])
+			// sFlow names the exporting agent, which may differ from the sender
+			if agent.IsValid() && !agent.IsUnspecified() {
+				exporter = agent.String()
+			}
+		} else {
+			records, err = ParseNetFlow(buf[:n], exporter, c.templates)
+		}
+		if err != nil {
+			log.Printf("Flow collector: %s from %s: %v", source, exporter, err)
+		}
+		if len(records) == 0 {
+			continue
+		}
+
+		c.mu.Lock()
+		c.aggregator.Add(exporter, source, records)
+		c.mu.Unlock()
+	}
+}
+
+// flush stores the summaries of the interval ending at now
+func (c *Collector) flush(ctx context.Context, now time.Time) {
+	c.mu.Lock()
+	summaries := c.aggregator.Flush(now)
+	c.mu.Unlock()
+
+	for _, summary := range summaries {
+		if err := c.store(ctx, summary); err != nil {
+			log.Printf("Flow collector: storing summary for %s: %v", summary.Exporter, err)
+		}
+	}
+}
+
+// store registers the exporter's agent and stores its summary
+func (c *Collector) store(ctx context.Context, summary *Summary) error {
+	agentID := AgentID(summary.Exporter)
+	labels := map[string]string{"kind": "flow-exporter"}
+	if err := ingest.MarkAlive(ctx, c.dbos, agentID, summary.Exporter, labels); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("flow-%s-%d", strings.ReplaceAll(summary.Exporter, ":", "-"), summary.IntervalEnd.Unix())
+	return ingest.StoreLocalResult(ctx, c.dbos, agentID, ModuleName, name, summary.IntervalEnd, data)
+}
@@ -0,0 +1,246 @@
+package flow
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync"
+)
+
+// NetFlow v9 field types used in summaries
+const (
+	fieldInBytes  = 1
+	fieldInPkts   = 2
+	fieldProtocol = 4
+	fieldSrcPort  = 7
+	fieldSrcIPv4  = 8
+	fieldDstPort  = 11
+	fieldDstIPv4  = 12
+	fieldOutBytes = 23
+	fieldOutPkts  = 24
+	fieldSrcIPv6  = 27
+	fieldDstIPv6  = 28
+)
+
+// NetFlow packet layout
+const (
+	v5HeaderLen    = 24
+	v5RecordLen    = 48
+	v9HeaderLen    = 20
+	v9TemplateSet  = 0
+	v9MinDataSetID = 256
+)
+
+// errShortPacket reports a truncated export packet
+var errShortPacket = errors.New("packet too short")
+
+// templateField is one field of a NetFlow v9 template
+type templateField struct {
+	typ    uint16
+	length int
+}
+
+// templateKey identifies a template: IDs are scoped to an exporter and its source ID
+type templateKey struct {
+	exporter   string
+	sourceID   uint32
+	templateID uint16
+}
+
+// Templates caches the NetFlow v9 templates exporters have announced
+type Templates struct {
+	mu        sync.Mutex
+	templates map[templateKey][]templateField
+}
+
+// NewTemplates creates an empty template cache
+func NewTemplates() *Templates {
+	return &Templates{
+		templates: make(map[templateKey][]templateField),
+	}
+}
+
+// ParseNetFlow parses a NetFlow v5 or v9 export packet from exporter. Data
+// records of v9 templates that have not been announced yet are skipped.
+func ParseNetFlow(data []byte, exporter string, templates *Templates) ([]Record, error) {
+	if len(data) < 2 {
+		return nil, errShortPacket
+	}
+
+	switch version := binary.BigEndian.Uint16(data); version {
+	case 5:
+		return parseNetFlowV5(data)
+	case 9:
+		return parseNetFlowV9(data, exporter, templates)
+	default:
+		return nil, fmt.Errorf("unsupported NetFlow version %d", version)
+	}
+}
+
+// parseNetFlowV5 parses the fixed-format v5 records, scaling them by the
+// sampling interval announced in the header
+func parseNetFlowV5(data []byte) ([]Record, error) {
+	if len(data) < v5HeaderLen {
+		return nil, errShortPacket
+	}
+	count := int(binary.BigEndian.Uint16(data[2:]))
+	if len(data) < v5HeaderLen+count*v5RecordLen {
+		return nil, errShortPacket
+	}
+
+	// The low 14 bits hold the sampling interval; zero means unsampled
+	rate := uint64(binary.BigEndian.Uint16(data[22:]) & 0x3fff)
+	if rate == 0 {
+		rate = 1
+	}
+
+	records := make([]Record, 0, count)
+	for i := 0; i < count; i++ {
+		r := data[v5HeaderLen+i*v5RecordLen:]
+		records = append(records, Record{
+			Src:      netip.AddrFrom4([4]byte(r[0:4])),
+			Dst:      netip.AddrFrom4([4]byte(r[4:8])),
+			Packets:  uint64(binary.BigEndian.Uint32(r[16:])) * rate,
+			Bytes:    uint64(binary.BigEndian.Uint32(r[20:])) * rate,
+			SrcPort:  binary.BigEndian.Uint16(r[32:]),
+			DstPort:  binary.BigEndian.Uint16(r[34:]),
+			Protocol: r[38],
+		})
+	}
+	return records, nil
+}
+
+// parseNetFlowV9 learns templates from template flowsets and decodes data
+// flowsets with them
+func parseNetFlowV9(data []byte, exporter string, templates *Templates) ([]Record, error) {
+	if len(data) < v9HeaderLen {
+		return nil, errShortPacket
+	}
+	sourceID := binary.BigEndian.Uint32(data[16:])
+
+	var records []Record
+	rest := data[v9HeaderLen:]
+	for len(rest) >= 4 {
+		setID := binary.BigEndian.Uint16(rest)
+		setLen := int(binary.BigEndian.Uint16(rest[2:]))
+		if setLen < 4 || setLen > len(rest) {
+			return records, errShortPacket
+		}
+		body := rest[4:setLen]
+		rest = rest[setLen:]
+
+		switch {
+		case setID == v9TemplateSet:
+			if err := templates.learn(body, exporter, sourceID); err != nil {
+				return records, err
+			}
+		case setID >= v9MinDataSetID:
+			fields, ok := templates.get(templateKey{exporter, sourceID, setID})
+			if !ok {
+				continue
+			}
+			records = append(records, decodeV9Records(body, fields)...)
+		}
+		// Options templates (set ID 1) and their data carry no flows
+	}
+
+	return records, nil
+}
+
+// learn records every template in a template flowset
+func (t *Templates) learn(body []byte, exporter string, sourceID uint32) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for len(body) >= 4 {
+		templateID := binary.BigEndian.Uint16(body)
+		fieldCount := int(binary.BigEndian.Uint16(body[2:]))
+		body = body[4:]
+		if len(body) < fieldCount*4 {
+			return errShortPacket
+		}
+
+		fields := make([]templateField, fieldCount)
+		for i := range fields {
+			fields[i] = templateField{
+				typ:    binary.BigEndian.Uint16(body[i*4:]),
+				length: int(binary.BigEndian.Uint16(body[i*4+2:])),
+			}
+		}
+		body = body[fieldCount*4:]
+
+		t.templates[templateKey{exporter, sourceID, templateID}] = fields
+	}
+	return nil
+}
+
+// get returns a learned template
+func (t *Templates) get(key templateKey) ([]templateField, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fields, ok := t.templates[key]
+	return fields, ok
+}
+
+// decodeV9Records decodes the records of a data flowset; trailing padding
+// shorter than a record is ignored
+func decodeV9Records(body []byte, fields []templateField) []Record {
+	recordLen := 0
+	for _, f := range fields {
+		recordLen += f.length
+	}
+	if recordLen == 0 {
+		return nil
+	}
+
+	var records []Record
+	for len(body) >= recordLen {
+		var r Record
+		var outBytes, outPkts uint64
+		offset := 0
+		for _, f := range fields {
+			v := body[offset : offset+f.length]
+			offset += f.length
+
+			switch f.typ {
+			case fieldInBytes:
+				r.Bytes = readUint(v)
+			case fieldInPkts:
+				r.Packets = readUint(v)
+			case fieldOutBytes:
+				outBytes = readUint(v)
+			case fieldOutPkts:
+				outPkts = readUint(v)
+			case fieldProtocol:
+				r.Protocol = uint8(readUint(v))
+			case fieldSrcPort:
+				r.SrcPort = uint16(readUint(v))
+			case fieldDstPort:
+				r.DstPort = uint16(readUint(v))
+			case fieldSrcIPv4, fieldSrcIPv6:
+				r.Src, _ = netip.AddrFromSlice(v)
+			case fieldDstIPv4, fieldDstIPv6:
+				r.Dst, _ = netip.AddrFromSlice(v)
+			}
+		}
+		body = body[recordLen:]
+
+		// Egress-only exporters report OUT_ counters instead
+		if r.Bytes == 0 && r.Packets == 0 {
+			r.Bytes, r.Packets = outBytes, outPkts
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// readUint reads a big-endian unsigned integer of up to 8 bytes
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
@@ -0,0 +1,45 @@
+// Package flow collects NetFlow and sFlow exports and summarizes them per
+// interval, giving active measurements traffic context.
+package flow
+
+import (
+	"net/netip"
+)
+
+// Record is one flow (NetFlow) or one sampled packet scaled by its sampling
+// rate (sFlow)
+type Record struct {
+	Src      netip.Addr
+	Dst      netip.Addr
+	SrcPort  uint16
+	DstPort  uint16
+	Protocol uint8
+	Bytes    uint64
+	Packets  uint64
+}
+
+// protocolName returns the name of common IP protocols
+func protocolName(protocol uint8) string {
+	switch protocol {
+	case 1:
+		return "icmp"
+	case 6:
+		return "tcp"
+	case 17:
+		return "udp"
+	case 47:
+		return "gre"
+	case 50:
+		return "esp"
+	case 58:
+		return "icmpv6"
+	case 132:
+		return "sctp"
+	}
+	return "other"
+}
+
+// hasPorts reports whether a protocol carries ports
+func hasPorts(protocol uint8) bool {
+	return protocol == 6 || protocol == 17 || protocol == 132
+}
@@ -0,0 +1,185 @@
+package flow
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+)
+
+// sFlow v5 sample and record formats (enterprise 0)
+const (
+	sflowFlowSample         = 1
+	sflowExpandedFlowSample = 3
+	sflowRawPacketHeader    = 1
+	headerProtocolEthernet  = 1
+	headerProtocolIPv4      = 11
+	headerProtocolIPv6      = 12
+	etherTypeIPv4           = 0x0800
+	etherTypeIPv6           = 0x86dd
+	etherTypeVLAN           = 0x8100
+	etherTypeQinQ           = 0x88a8
+)
+
+// ParseSFlow parses an sFlow v5 datagram, returning its agent address and a
+// record per sampled packet header, scaled by the sampling rate. Counter
+// samples and other record types are skipped.
+func ParseSFlow(data []byte) (netip.Addr, []Record, error) {
+	r := reader{data: data}
+	if version := r.uint32(); version != 5 {
+		return netip.Addr{}, nil, fmt.Errorf("unsupported sFlow version %d", version)
+	}
+
+	var agent netip.Addr
+	switch addrType := r.uint32(); addrType {
+	case 1:
+		agent, _ = netip.AddrFromSlice(r.bytes(4))
+	case 2:
+		agent, _ = netip.AddrFromSlice(r.bytes(16))
+	default:
+		return netip.Addr{}, nil, fmt.Errorf("unsupported sFlow agent address type %d", addrType)
+	}
+	r.skip(12) // sub-agent ID, sequence number, uptime
+	numSamples := r.uint32()
+
+	var records []Record
+	for i := uint32(0); i < numSamples && r.err == nil; i++ {
+		format := r.uint32()
+		sample := reader{data: r.bytes(int(r.uint32()))}
+		if r.err != nil {
+			break
+		}
+
+		switch format {
+		case sflowFlowSample:
+			sample.skip(8) // sequence number, source ID
+		case sflowExpandedFlowSample:
+			sample.skip(12) // sequence number, source ID type and index
+		default:
+			continue
+		}
+		rate := uint64(sample.uint32())
+		if rate == 0 {
+			rate = 1
+		}
+		if format == sflowFlowSample {
+			sample.skip(16) // sample pool, drops, input, output
+		} else {
+			sample.skip(24) // sample pool, drops, input and output format and value
+		}
+
+		numRecords := sample.uint32()
+		for j := uint32(0); j < numRecords && sample.err == nil; j++ {
+			recordFormat := sample.uint32()
+			record := reader{data: sample.bytes(int(sample.uint32()))}
+			if sample.err != nil || recordFormat != sflowRawPacketHeader {
+				continue
+			}
+
+			headerProtocol := record.uint32()
+			frameLength := uint64(record.uint32())
+			record.skip(4) // bytes stripped
+			header := record.bytes(int(record.uint32()))
+			if record.err != nil {
+				continue
+			}
+
+			flow, ok := parsePacketHeader(headerProtocol, header)
+			if !ok {
+				continue
+			}
+			flow.Bytes = frameLength * rate
+			flow.Packets = rate
+			records = append(records, flow)
+		}
+	}
+
+	if r.err != nil {
+		return agent, records, r.err
+	}
+	return agent, records, nil
+}
+
+// parsePacketHeader extracts addresses, protocol and ports from a sampled header
+func parsePacketHeader(headerProtocol uint32, header []byte) (Record, bool) {
+	var etherType uint16
+	switch headerProtocol {
+	case headerProtocolEthernet:
+		if len(header) < 14 {
+			return Record{}, false
+		}
+		etherType = binary.BigEndian.Uint16(header[12:])
+		header = header[14:]
+		for (etherType == etherTypeVLAN || etherType == etherTypeQinQ) && len(header) >= 4 {
+			etherType = binary.BigEndian.Uint16(header[2:])
+			header = header[4:]
+		}
+	case headerProtocolIPv4:
+		etherType = etherTypeIPv4
+	case headerProtocolIPv6:
+		etherType = etherTypeIPv6
+	default:
+		return Record{}, false
+	}
+
+	var flow Record
+	var transport []byte
+	switch etherType {
+	case etherTypeIPv4:
+		if len(header) < 20 {
+			return Record{}, false
+		}
+		ihl := int(header[0]&0x0f) * 4
+		flow.Protocol = header[9]
+		flow.Src = netip.AddrFrom4([4]byte(header[12:16]))
+		flow.Dst = netip.AddrFrom4([4]byte(header[16:20]))
+		if ihl >= 20 && len(header) >= ihl {
+			transport = header[ihl:]
+		}
+	case etherTypeIPv6:
+		if len(header) < 40 {
+			return Record{}, false
+		}
+		flow.Protocol = header[6]
+		flow.Src = netip.AddrFrom16([16]byte(header[8:24]))
+		flow.Dst = netip.AddrFrom16([16]byte(header[24:40]))
+		transport = header[40:]
+	default:
+		return Record{}, false
+	}
+
+	if hasPorts(flow.Protocol) && len(transport) >= 4 {
+		flow.SrcPort = binary.BigEndian.Uint16(transport)
+		flow.DstPort = binary.BigEndian.Uint16(transport[2:])
+	}
+	return flow, true
+}
+
+// reader reads sFlow's XDR encoding: big-endian words, opaque data padded to
+// four bytes. The first out-of-bounds read sets err; later reads return zeros.
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) uint32() uint32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *reader) bytes(n int) []byte {
+	padded := (n + 3) &^ 3
+	if r.err != nil || n < 0 || padded > len(r.data) {
+		r.err = errShortPacket
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[padded:]
+	return b
+}
+
+func (r *reader) skip(n int) {
+	r.bytes(n)
+}
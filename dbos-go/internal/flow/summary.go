@@ -0,0 +1,158 @@
+package flow
+
+import (
+	"sort"
+	"time"
+)
+
+// Summary aggregates the traffic an exporter reported over one interval
+type Summary struct {
+	Exporter      string                     `json:"exporter"`
+	Sources       []string                   `json:"sources"`
+	IntervalStart time.Time                  `json:"interval_start"`
+	IntervalEnd   time.Time                  `json:"interval_end"`
+	Bytes         uint64                     `json:"bytes"`
+	Packets       uint64                     `json:"packets"`
+	Flows         uint64                     `json:"flows"`
+	Protocols     map[string]*TrafficCounter `json:"protocols"`
+	TopTalkers    []*Talker                  `json:"top_talkers"`
+	TopDstPorts   []*PortTraffic             `json:"top_dst_ports"`
+}
+
+// TrafficCounter counts bytes and packets
+type TrafficCounter struct {
+	Bytes   uint64 `json:"bytes"`
+	Packets uint64 `json:"packets"`
+}
+
+// Talker is the traffic between a source and a destination
+type Talker struct {
+	Src string `json:"src"`
+	Dst string `json:"dst"`
+	TrafficCounter
+}
+
+// PortTraffic is the traffic to a destination port
+type PortTraffic struct {
+	Port     uint16 `json:"port"`
+	Protocol string `json:"protocol"`
+	TrafficCounter
+}
+
+// aggregate accumulates one exporter's records for the current interval
+type aggregate struct {
+	sources map[string]bool
+	summary *Summary
+	talkers map[[2]string]*Talker
+	ports   map[[2]uint16]*PortTraffic
+}
+
+// Aggregator accumulates records per exporter until flushed. It is not safe
+// for concurrent use.
+type Aggregator struct {
+	topN  int
+	start time.Time
+	byExp map[string]*aggregate
+}
+
+// NewAggregator creates an aggregator keeping the topN talkers and ports per exporter
+func NewAggregator(topN int, start time.Time) *Aggregator {
+	return &Aggregator{
+		topN:  topN,
+		start: start,
+		byExp: make(map[string]*aggregate),
+	}
+}
+
+// Add accumulates records an exporter sent over source ("netflow" or "sflow")
+func (a *Aggregator) Add(exporter, source string, records []Record) {
+	agg, ok := a.byExp[exporter]
+	if !ok {
+		agg = &aggregate{
+			sources: make(map[string]bool),
+			summary: &Summary{Exporter: exporter, Protocols: make(map[string]*TrafficCounter)},
+			talkers: make(map[[2]string]*Talker),
+			ports:   make(map[[2]uint16]*PortTraffic),
+		}
+		a.byExp[exporter] = agg
+	}
+	agg.sources[source] = true
+
+	s := agg.summary
+	for _, r := range records {
+		s.Bytes += r.Bytes
+		s.Packets += r.Packets
+		s.Flows++
+
+		name := protocolName(r.Protocol)
+		proto, ok := s.Protocols[name]
+		if !ok {
+			proto = &TrafficCounter{}
+			s.Protocols[name] = proto
+		}
+		proto.Bytes += r.Bytes
+		proto.Packets += r.Packets
+
+		talkerKey := [2]string{r.Src.String(), r.Dst.String()}
+		talker, ok := agg.talkers[talkerKey]
+		if !ok {
+			talker = &Talker{Src: talkerKey[0], Dst: talkerKey[1]}
+			agg.talkers[talkerKey] = talker
+		}
+		talker.Bytes += r.Bytes
+		talker.Packets += r.Packets
+
+		// Port 0 means the export carried no port
+		if hasPorts(r.Protocol) && r.DstPort != 0 {
+			portKey := [2]uint16{r.DstPort, uint16(r.Protocol)}
+			port, ok := agg.ports[portKey]
+			if !ok {
+				port = &PortTraffic{Port: r.DstPort, Protocol: name}
+				agg.ports[portKey] = port
+			}
+			port.Bytes += r.Bytes
+			port.Packets += r.Packets
+		}
+	}
+}
+
+// Flush returns a summary per exporter for the interval ending at end and
+// starts a new interval
+func (a *Aggregator) Flush(end time.Time) []*Summary {
+	summaries := make([]*Summary, 0, len(a.byExp))
+	for _, agg := range a.byExp {
+		s := agg.summary
+		s.IntervalStart = a.start
+		s.IntervalEnd = end
+		for source := range agg.sources {
+			s.Sources = append(s.Sources, source)
+		}
+		sort.Strings(s.Sources)
+
+		for _, talker := range agg.talkers {
+			s.TopTalkers = append(s.TopTalkers, talker)
+		}
+		sort.Slice(s.TopTalkers, func(i, j int) bool {
+			return s.TopTalkers[i].Bytes > s.TopTalkers[j].Bytes
+		})
+		if len(s.TopTalkers) > a.topN {
+			s.TopTalkers = s.TopTalkers[:a.topN]
+		}
+
+		for _, port := range agg.ports {
+			s.TopDstPorts = append(s.TopDstPorts, port)
+		}
+		sort.Slice(s.TopDstPorts, func(i, j int) bool {
+			return s.TopDstPorts[i].Bytes > s.TopDstPorts[j].Bytes
+		})
+		if len(s.TopDstPorts) > a.topN {
+			s.TopDstPorts = s.TopDstPorts[:a.topN]
+		}
+
+		summaries = append(summaries, s)
+	}
+
+	a.start = end
+	a.byExp = make(map[string]*aggregate)
+	return summaries
+}
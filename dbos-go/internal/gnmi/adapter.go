@@ -0,0 +1,208 @@
+package gnmi
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/ingest"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// ModuleName is the module telemetry results are stored under
+	ModuleName = "gnmi"
+
+	// livenessInterval is how often a streaming device's agent is marked alive
+	livenessInterval = 15 * time.Second
+
+	// minBackoff and maxBackoff bound the delay before resubscribing to a device
+	minBackoff = 5 * time.Second
+	maxBackoff = time.Minute
+)
+
+// Adapter streams telemetry from devices into DBOS
+type Adapter struct {
+	dbos api.DBOSClient
+}
+
+// NewAdapter creates a new adapter storing results through a DBOS client
+func NewAdapter(dbos api.DBOSClient) *Adapter {
+	return &Adapter{
+		dbos: dbos,
+	}
+}
+
+// Run subscribes to every device until ctx is done, resubscribing with
+// backoff when a device's stream fails
+func (a *Adapter) Run(ctx context.Context, devices []Device) {
+	var wg sync.WaitGroup
+	for _, device := range devices {
+		wg.Add(1)
+		go func(device Device) {
+			defer wg.Done()
+			a.runDevice(ctx, &device)
+		}(device)
+	}
+	wg.Wait()
+}
+
+// runDevice keeps a subscription to one device open until ctx is done
+func (a *Adapter) runDevice(ctx context.Context, device *Device) {
+	backoff := minBackoff
+	for {
+		start := time.Now()
+		err := a.subscribe(ctx, device)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("gNMI %s: subscription ended: %v", device.Name, err)
+
+		// A stream that ran for a while was healthy; retry promptly
+		if time.Since(start) > maxBackoff {
+			backoff = minBackoff
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// subscribe opens a streaming SAMPLE subscription and stores every
+// notification until the stream fails
+func (a *Adapter) subscribe(ctx context.Context, device *Device) error {
+	creds := insecure.NewCredentials()
+	if !device.Insecure {
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: device.SkipVerify})
+	}
+	conn, err := grpc.NewClient(device.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if device.Username != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", device.Username, "password", device.Password)
+	}
+
+	stream, err := gpb.NewGNMIClient(conn).Subscribe(ctx)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make([]*gpb.Subscription, 0, len(device.Paths))
+	for _, p := range device.Paths {
+		path, err := ParsePath(p)
+		if err != nil {
+			return err
+		}
+		subscriptions = append(subscriptions, &gpb.Subscription{
+			Path:           path,
+			Mode:           gpb.SubscriptionMode_SAMPLE,
+			SampleInterval: uint64(device.SampleInterval().Nanoseconds()),
+		})
+	}
+	err = stream.Send(&gpb.SubscribeRequest{
+		Request: &gpb.SubscribeRequest_Subscribe{
+			Subscribe: &gpb.SubscriptionList{
+				Mode:         gpb.SubscriptionList_STREAM,
+				Encoding:     gpb.Encoding_JSON_IETF,
+				Subscription: subscriptions,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := a.markAlive(ctx, device); err != nil {
+		return err
+	}
+	lastAlive := time.Now()
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		switch r := resp.GetResponse().(type) {
+		case *gpb.SubscribeResponse_Update:
+			if err := a.storeNotification(ctx, device, r.Update); err != nil {
+				log.Printf("gNMI %s: storing notification: %v", device.Name, err)
+			}
+		case *gpb.SubscribeResponse_Error:
+			return fmt.Errorf("device error: %s", r.Error.GetMessage())
+		}
+
+		if time.Since(lastAlive) >= livenessInterval {
+			if err := a.markAlive(ctx, device); err != nil {
+				log.Printf("gNMI %s: marking agent alive: %v", device.Name, err)
+			}
+			lastAlive = time.Now()
+		}
+	}
+}
+
+// markAlive registers the device agent, or marks it alive and seen now
+func (a *Adapter) markAlive(ctx context.Context, device *Device) error {
+	labels := map[string]string{}
+	for key, value := range device.Labels {
+		labels[key] = value
+	}
+	labels["kind"] = "device"
+	return ingest.MarkAlive(ctx, a.dbos, device.AgentID(), device.Address, labels)
+}
+
+// storeNotification normalizes a notification into a measurement result:
+// one JSON object mapping each updated leaf path to its value
+func (a *Adapter) storeNotification(ctx context.Context, device *Device, n *gpb.Notification) error {
+	values := make(map[string]interface{}, len(n.GetUpdate()))
+	for _, update := range n.GetUpdate() {
+		value, err := decodeValue(update.GetVal())
+		if err != nil {
+			log.Printf("gNMI %s: %s: %v", device.Name, FormatPath(n.GetPrefix(), update.GetPath()), err)
+			continue
+		}
+		values[FormatPath(n.GetPrefix(), update.GetPath())] = value
+	}
+	deleted := make([]string, 0, len(n.GetDelete()))
+	for _, path := range n.GetDelete() {
+		deleted = append(deleted, FormatPath(n.GetPrefix(), path))
+	}
+	if len(values) == 0 && len(deleted) == 0 {
+		return nil
+	}
+
+	ts := time.Unix(0, n.GetTimestamp())
+	if n.GetTimestamp() == 0 {
+		ts = time.Now()
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"device":    device.Name,
+		"address":   device.Address,
+		"timestamp": ts.UnixNano(),
+		"values":    values,
+		"deleted":   deleted,
+	})
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("gnmi-%s-%d", device.Name, ts.UnixNano())
+	return ingest.StoreLocalResult(ctx, a.dbos, device.AgentID(), ModuleName, name, ts, data)
+}
@@ -0,0 +1,86 @@
+// Package gnmi subscribes to gNMI streaming telemetry from network devices
+// and stores the selected paths as measurement results of device agents.
+package gnmi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Config lists the devices the adapter subscribes to
+type Config struct {
+	Devices []Device `json:"devices"`
+}
+
+// Device is a router or switch streaming telemetry over gNMI
+type Device struct {
+	// Name identifies the device; results are stored under agent "device-<name>"
+	Name string `json:"name"`
+	// Address is the host:port of the device's gNMI server
+	Address string `json:"address"`
+	// Username and Password are sent as gNMI call metadata, if set
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Insecure disables TLS; SkipVerify keeps TLS but does not verify the certificate
+	Insecure   bool `json:"insecure,omitempty"`
+	SkipVerify bool `json:"skip_verify,omitempty"`
+	// Paths are the telemetry paths to subscribe to, e.g.
+	// "/interfaces/interface[name=Ethernet1]/state/counters"
+	Paths []string `json:"paths"`
+	// SampleIntervalSeconds is how often the device samples the paths
+	SampleIntervalSeconds int `json:"sample_interval_seconds,omitempty"`
+	// Labels are attached to the device agent
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// defaultSampleInterval is used for devices without a sample interval
+const defaultSampleInterval = 30 * time.Second
+
+// AgentID returns the ID of the agent the device's results are stored under
+func (d *Device) AgentID() string {
+	return "device-" + d.Name
+}
+
+// SampleInterval returns how often the device samples its paths
+func (d *Device) SampleInterval() time.Duration {
+	if d.SampleIntervalSeconds <= 0 {
+		return defaultSampleInterval
+	}
+	return time.Duration(d.SampleIntervalSeconds) * time.Second
+}
+
+// LoadConfig reads an adapter configuration from a JSON file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, device := range cfg.Devices {
+		if device.Name == "" || device.Address == "" {
+			return nil, fmt.Errorf("every device needs a name and an address")
+		}
+		if seen[device.Name] {
+			return nil, fmt.Errorf("duplicate device %q", device.Name)
+		}
+		seen[device.Name] = true
+		if len(device.Paths) == 0 {
+			return nil, fmt.Errorf("device %q subscribes to no paths", device.Name)
+		}
+		for _, p := range device.Paths {
+			if _, err := ParsePath(p); err != nil {
+				return nil, fmt.Errorf("device %q: %v", device.Name, err)
+			}
+		}
+	}
+
+	return &cfg, nil
+}
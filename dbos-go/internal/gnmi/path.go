@@ -0,0 +1,154 @@
+package gnmi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// ParsePath parses a path such as "/interfaces/interface[name=eth0]/state"
+func ParsePath(path string) (*gpb.Path, error) {
+	path = strings.TrimPrefix(path, "/")
+	result := &gpb.Path{}
+	if path == "" {
+		return result, nil
+	}
+
+	for _, part := range splitPath(path) {
+		name := part
+		keys := make(map[string]string)
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			name = part[:open]
+			rest := part[open:]
+			for rest != "" {
+				end := strings.IndexByte(rest, ']')
+				if rest[0] != '[' || end < 0 {
+					return nil, fmt.Errorf("invalid path element %q", part)
+				}
+				key, value, ok := strings.Cut(rest[1:end], "=")
+				if !ok || key == "" {
+					return nil, fmt.Errorf("invalid key in path element %q", part)
+				}
+				keys[key] = value
+				rest = rest[end+1:]
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("empty path element in %q", path)
+		}
+
+		elem := &gpb.PathElem{Name: name}
+		if len(keys) > 0 {
+			elem.Key = keys
+		}
+		result.Elem = append(result.Elem, elem)
+	}
+
+	return result, nil
+}
+
+// splitPath splits a path on slashes outside of key brackets
+func splitPath(path string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range path {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '/':
+			if depth == 0 {
+				parts = append(parts, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, path[start:])
+}
+
+// FormatPath renders the concatenation of prefix and path, with element
+// keys sorted so the same leaf always has the same name
+func FormatPath(prefix, path *gpb.Path) string {
+	var b strings.Builder
+	for _, p := range []*gpb.Path{prefix, path} {
+		if p == nil {
+			continue
+		}
+		for _, elem := range p.GetElem() {
+			b.WriteByte('/')
+			b.WriteString(elem.GetName())
+
+			keys := make([]string, 0, len(elem.GetKey()))
+			for key := range elem.GetKey() {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				fmt.Fprintf(&b, "[%s=%s]", key, elem.GetKey()[key])
+			}
+		}
+		// Pre-0.4 devices send path elements as plain strings
+		for _, elem := range p.GetElement() {
+			b.WriteByte('/')
+			b.WriteString(elem)
+		}
+	}
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
+
+// decodeValue converts a gNMI typed value into a JSON-compatible value
+func decodeValue(v *gpb.TypedValue) (interface{}, error) {
+	switch val := v.GetValue().(type) {
+	case *gpb.TypedValue_StringVal:
+		return val.StringVal, nil
+	case *gpb.TypedValue_AsciiVal:
+		return val.AsciiVal, nil
+	case *gpb.TypedValue_IntVal:
+		return val.IntVal, nil
+	case *gpb.TypedValue_UintVal:
+		return val.UintVal, nil
+	case *gpb.TypedValue_BoolVal:
+		return val.BoolVal, nil
+	case *gpb.TypedValue_BytesVal:
+		return val.BytesVal, nil
+	case *gpb.TypedValue_FloatVal:
+		return float64(val.FloatVal), nil
+	case *gpb.TypedValue_DecimalVal:
+		return float64(val.DecimalVal.GetDigits()) / math.Pow10(int(val.DecimalVal.GetPrecision())), nil
+	case *gpb.TypedValue_LeaflistVal:
+		list := make([]interface{}, 0, len(val.LeaflistVal.GetElement()))
+		for _, elem := range val.LeaflistVal.GetElement() {
+			decoded, err := decodeValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, decoded)
+		}
+		return list, nil
+	case *gpb.TypedValue_JsonVal:
+		return decodeJSON(val.JsonVal)
+	case *gpb.TypedValue_JsonIetfVal:
+		return decodeJSON(val.JsonIetfVal)
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", val)
+	}
+}
+
+// decodeJSON decodes a JSON-encoded value
+func decodeJSON(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
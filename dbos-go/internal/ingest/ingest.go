@@ -0,0 +1,69 @@
+// Package ingest holds helpers for adapters that feed externally collected
+// data (device telemetry, flow summaries) into DBOS as results of
+// synthetic agents.
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// MarkAlive registers a synthetic agent, or marks it alive and seen now,
+// keeping its config and merging labels into its existing ones
+func MarkAlive(ctx context.Context, dbos api.DBOSClient, agentID, hostname string, labels map[string]string) error {
+	now := time.Now().Unix()
+	agent := &api.Agent{
+		Id:        agentID,
+		FirstSeen: now,
+		Config:    map[string]string{},
+		Labels:    map[string]string{},
+	}
+	if resp, err := dbos.GetAgent(ctx, &api.GetAgentRequest{AgentId: agentID}); err == nil && resp.Found {
+		agent = resp.Agent
+		if agent.Labels == nil {
+			agent.Labels = map[string]string{}
+		}
+	}
+
+	agent.Hostname = hostname
+	agent.Alive = true
+	agent.LastSeen = now
+	agent.TotalHeartbeats++
+	for key, value := range labels {
+		agent.Labels[key] = value
+	}
+
+	resp, err := dbos.RegisterAgent(ctx, &api.RegisterAgentRequest{Agent: agent})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
+
+// StoreLocalResult stores data as a locally generated result of a synthetic
+// agent; name must make the result ID unique for the agent
+func StoreLocalResult(ctx context.Context, dbos api.DBOSClient, agentID, moduleName, name string, ts time.Time, data []byte) error {
+	resp, err := dbos.StoreResult(ctx, &api.StoreResultRequest{
+		Result: &api.MeasurementResult{
+			Id:         models.LocalTaskIDPrefix + name,
+			AgentId:    agentID,
+			ModuleName: moduleName,
+			Data:       data,
+			Timestamp:  ts.Unix(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	return nil
+}
@@ -1,9 +1,23 @@
 package models
 
 import (
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// AgentLatitudeLabel and AgentLongitudeLabel are the labels locating an
+// agent, in decimal degrees
+const (
+	AgentLatitudeLabel  = "lat"
+	AgentLongitudeLabel = "lon"
+)
+
+// AgentModulesLabel is the label listing the modules an agent runs,
+// comma-separated
+const AgentModulesLabel = "modules"
+
 // Agent represents a measurement agent in the system
 type Agent struct {
 	ID              string            `json:"id"`
@@ -13,6 +27,7 @@ type Agent struct {
 	FirstSeen       time.Time         `json:"first_seen"`
 	Config          map[string]string `json:"config"`
 	TotalHeartbeats int32             `json:"total_heartbeats"`
+	Labels          map[string]string `json:"labels"`
 }
 
 // NewAgent creates a new agent instance
@@ -24,5 +39,42 @@ func NewAgent(id, hostname string) *Agent {
 		LastSeen:  time.Now(),
 		FirstSeen: time.Now(),
 		Config:    make(map[string]string),
+		Labels:    make(map[string]string),
+	}
+}
+
+// RunsModule reports whether an agent lists a module in its modules label
+func (a *Agent) RunsModule(module string) bool {
+	return slices.Contains(strings.Split(a.Labels[AgentModulesLabel], ","), module)
+}
+
+// Location returns the coordinates of an agent's lat and lon labels, and
+// false unless both are set and in range
+func (a *Agent) Location() (lat, lon float64, ok bool) {
+	lat, err := strconv.ParseFloat(a.Labels[AgentLatitudeLabel], 64)
+	if err != nil || !(lat >= -90 && lat <= 90) {
+		return 0, 0, false
+	}
+	lon, err = strconv.ParseFloat(a.Labels[AgentLongitudeLabel], 64)
+	if err != nil || !(lon >= -180 && lon <= 180) {
+		return 0, 0, false
 	}
+	return lat, lon, true
+}
+
+// AgentChange represents one change to the agent inventory
+type AgentChange struct {
+	Revision string `json:"revision"`
+	Type     string `json:"type"`
+	AgentID  string `json:"agent_id"`
+	Agent    *Agent `json:"agent,omitempty"`
 }
+
+// AgentChangeEnum defines the possible agent inventory changes
+type AgentChangeEnum string
+
+const (
+	AgentChangeAdd    AgentChangeEnum = "add"
+	AgentChangeUpdate AgentChangeEnum = "update"
+	AgentChangeRemove AgentChangeEnum = "remove"
+)
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Alert is a notable condition detected while processing measurement results
+type Alert struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	AgentID    string            `json:"agent_id"`
+	ResultID   string            `json:"result_id"`
+	ModuleName string            `json:"module_name"`
+	Target     string            `json:"target"`
+	Reason     string            `json:"reason"`
+	Details    map[string]string `json:"details,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// AlertTypeEnum classifies alerts
+type AlertTypeEnum string
+
+const (
+	// AlertTypeTLSInterception flags a certificate that is unlogged in CT or
+	// does not match its host, as a man-in-the-middle would present
+	AlertTypeTLSInterception AlertTypeEnum = "tls_interception"
+
+	// AlertTypeRule flags a series for which an alert rule fired
+	AlertTypeRule AlertTypeEnum = "alert_rule"
+)
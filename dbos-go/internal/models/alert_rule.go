@@ -0,0 +1,100 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// AlertRule is a declarative condition over rollup metrics. Every series
+// the expression holds for is pending until it has held for the rule's
+// duration, then fires.
+type AlertRule struct {
+	Name        string    `json:"name"`
+	Expr        string    `json:"expr"`
+	ForSeconds  int64     `json:"for_seconds"`
+	Severity    string    `json:"severity"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// For returns how long the expression must hold before the rule fires
+func (r *AlertRule) For() time.Duration {
+	return time.Duration(r.ForSeconds) * time.Second
+}
+
+// AlertSeverityEnum defines the severities of alert rules
+type AlertSeverityEnum string
+
+const (
+	AlertSeverityInfo     AlertSeverityEnum = "info"
+	AlertSeverityWarning  AlertSeverityEnum = "warning"
+	AlertSeverityCritical AlertSeverityEnum = "critical"
+)
+
+// AlertExpr is a parsed alert rule expression. It compares either a
+// function of today's rows of a daily view, one series per agent and key,
+// e.g. "avg(ping_rtt) > 150", or the aggregate of a saved query, a single
+// series, e.g. "slow_probes >= 10".
+type AlertExpr struct {
+	Function  AggregationFunctionEnum // empty for a saved query
+	View      string
+	Query     string
+	Op        string
+	Threshold float64
+}
+
+// alertExprPattern matches fn(view) op threshold and query op threshold
+var alertExprPattern = regexp.MustCompile(`^\s*(?:(count|sum|avg|min|max)\(\s*([A-Za-z0-9_.:-]+)\s*\)|([A-Za-z0-9_.:-]+))\s*(>=|<=|==|!=|>|<)\s*(\S+)\s*$`)
+
+// ParseAlertExpr parses an alert rule expression
+func ParseAlertExpr(expr string) (*AlertExpr, error) {
+	m := alertExprPattern.FindStringSubmatch(expr)
+	if m == nil {
+		return nil, fmt.Errorf("invalid alert expression %q: want fn(view) op number or query op number", expr)
+	}
+
+	threshold, err := strconv.ParseFloat(m[5], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert expression %q: threshold %q is not a number", expr, m[5])
+	}
+	return &AlertExpr{
+		Function:  AggregationFunctionEnum(m[1]),
+		View:      m[2],
+		Query:     m[3],
+		Op:        m[4],
+		Threshold: threshold,
+	}, nil
+}
+
+// Holds reports whether the expression holds for a value
+func (e *AlertExpr) Holds(value float64) bool {
+	switch e.Op {
+	case ">":
+		return value > e.Threshold
+	case ">=":
+		return value >= e.Threshold
+	case "<":
+		return value < e.Threshold
+	case "<=":
+		return value <= e.Threshold
+	case "==":
+		return value == e.Threshold
+	case "!=":
+		return value != e.Threshold
+	}
+	return false
+}
+
+// AlertSeriesState tracks one series of an alert rule while its expression
+// holds. It is removed once the expression no longer holds.
+type AlertSeriesState struct {
+	Series      string    `json:"series"`
+	AgentID     string    `json:"agent_id,omitempty"`
+	Key         string    `json:"key,omitempty"`
+	Value       float64   `json:"value"`
+	ActiveSince time.Time `json:"active_since"`
+	Firing      bool      `json:"firing"`
+	FiredAt     time.Time `json:"fired_at,omitempty"`
+}
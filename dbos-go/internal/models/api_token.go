@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// RoleEnum defines what the holder of an API token may do
+type RoleEnum string
+
+const (
+	// RoleAgent may only call the agent RPCs, for its own agent
+	RoleAgent RoleEnum = "agent"
+
+	// RoleReadOnly may query agents, tasks, results and everything derived
+	// from them
+	RoleReadOnly RoleEnum = "read_only"
+
+	// RoleOperator may also schedule tasks and manage campaigns, incidents,
+	// rules and the other configuration of measurements
+	RoleOperator RoleEnum = "operator"
+
+	// RoleAdmin may call anything, including issuing tokens
+	RoleAdmin RoleEnum = "admin"
+)
+
+// APIToken holds the claims of an API token issued to a user or service
+type APIToken struct {
+	Role      string    `json:"role"`
+	Name      string    `json:"name,omitempty"`
+	AgentID   string    `json:"agent_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/hex"
+	"time"
+
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// Artifact is an agent or module binary distributed to agents through the
+// signed software manifest. The binary itself is hosted at URL; DBOS only
+// vouches for its digest.
+type Artifact struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	URL     string `json:"url"`
+	// SHA256 is the hex-encoded digest of the binary
+	SHA256      string    `json:"sha256"`
+	Size        int64     `json:"size"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// ArtifactKindEnum is what an artifact is
+type ArtifactKindEnum string
+
+const (
+	ArtifactKindAgent  ArtifactKindEnum = "agent"
+	ArtifactKindModule ArtifactKindEnum = "module"
+)
+
+// Check validates an artifact before it is published
+func (a *Artifact) Check() error {
+	if a.Name == "" || a.Version == "" {
+		return dberrors.New(dberrors.InvalidArgument, "artifact name and version are required")
+	}
+	switch ArtifactKindEnum(a.Kind) {
+	case ArtifactKindAgent, ArtifactKindModule:
+	default:
+		return dberrors.New(dberrors.InvalidArgument, "artifact kind must be %q or %q, got %q", ArtifactKindAgent, ArtifactKindModule, a.Kind)
+	}
+	if a.URL == "" {
+		return dberrors.New(dberrors.InvalidArgument, "artifact url is required")
+	}
+	if digest, err := hex.DecodeString(a.SHA256); err != nil || len(digest) != 32 {
+		return dberrors.New(dberrors.InvalidArgument, "artifact sha256 must be 64 hex digits")
+	}
+	return nil
+}
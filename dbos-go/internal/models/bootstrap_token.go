@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// BootstrapToken is a one-time token new probes use to enroll themselves
+type BootstrapToken struct {
+	Labels    map[string]string `json:"labels"`
+	Config    map[string]string `json:"config"`
+	CreatedAt time.Time         `json:"created_at"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// NewBootstrapToken creates a new bootstrap token valid for ttl
+func NewBootstrapToken(labels, config map[string]string, ttl time.Duration) *BootstrapToken {
+	now := time.Now()
+	return &BootstrapToken{
+		Labels:    labels,
+		Config:    config,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
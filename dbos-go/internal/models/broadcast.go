@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Broadcast is one task definition fanned out to every live agent, or every
+// live agent matching a selector, when it was broadcast. Each agent is
+// given its own task, whose progress is tracked under the broadcast.
+type Broadcast struct {
+	ID         string `json:"id"`
+	ModuleName string `json:"module_name"`
+	Payload    []byte `json:"payload"`
+	// Selector picked the agents by label; empty picked all
+	Selector map[string]string `json:"selector,omitempty"`
+	// AgentIDs are the agents the task was fanned out to, and TaskIDs
+	// their tasks
+	AgentIDs      []string  `json:"agent_ids"`
+	TaskIDs       []string  `json:"task_ids"`
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	CorrelationID string    `json:"correlation_id,omitempty"`
+}
+
+// TaskID returns the ID of the broadcast's task for an agent
+func (b *Broadcast) TaskID(agentID string) string {
+	return b.ID + "-" + agentID
+}
+
+// BroadcastStatus is the progress of a broadcast: the status of each
+// agent's task, in the order of the broadcast's agents
+type BroadcastStatus struct {
+	Broadcast *Broadcast
+	Agents    []BroadcastAgentStatus
+}
+
+// BroadcastAgentStatus is the status of one agent's task of a broadcast, a
+// task status or BroadcastAgentUnknown
+type BroadcastAgentStatus struct {
+	AgentID string
+	TaskID  string
+	Status  string
+}
+
+// BroadcastAgentUnknown is the status of an agent's task of a broadcast
+// that was deleted
+const BroadcastAgentUnknown = "unknown"
+
+// Count returns the number of agents whose task has a status
+func (s *BroadcastStatus) Count(status TaskStatusEnum) int {
+	n := 0
+	for _, agent := range s.Agents {
+		if agent.Status == string(status) {
+			n++
+		}
+	}
+	return n
+}
+
+// Done reports whether every agent's task has finished
+func (s *BroadcastStatus) Done() bool {
+	for _, agent := range s.Agents {
+		switch TaskStatusEnum(agent.Status) {
+		case TaskStatusCompleted, TaskStatusFailed, TaskStatusCancelled:
+		default:
+			if agent.Status != BroadcastAgentUnknown {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultCampaignTargetField is the payload field targets are set at
+	// unless a campaign names another
+	DefaultCampaignTargetField = "host"
+
+	// MaxCampaignTargets caps the targets of one campaign
+	MaxCampaignTargets = 1000
+)
+
+// Campaign is a named measurement of a set of targets with one module from
+// a set of agents, repeated every interval. Each round schedules a task per
+// live agent and target; their results are correlated under the campaign.
+type Campaign struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	ModuleName  string `json:"module_name"`
+	// Payload is a JSON object each task's payload is built from, with the
+	// task's target set at TargetField
+	Payload     []byte   `json:"payload,omitempty"`
+	Targets     []string `json:"targets"`
+	TargetField string   `json:"target_field"`
+	// AgentIDs and the agents matching Selector measure the campaign
+	AgentIDs        []string          `json:"agent_ids,omitempty"`
+	Selector        map[string]string `json:"selector,omitempty"`
+	IntervalSeconds int64             `json:"interval_seconds,omitempty"` // 0 runs one round
+	StartsAt        time.Time         `json:"starts_at"`
+	EndsAt          time.Time         `json:"ends_at,omitempty"` // zero runs until stopped
+	CreatedBy       string            `json:"created_by,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	StoppedAt       time.Time         `json:"stopped_at,omitempty"`
+
+	// The progress of a campaign is kept apart from its definition
+	Status          string    `json:"-"`
+	NextRunAt       time.Time `json:"-"` // zero when no round is due
+	Rounds          int64     `json:"-"`
+	TasksIssued     int64     `json:"-"`
+	ResultsReceived int64     `json:"-"`
+}
+
+// Validate checks that the campaign names a module, targets and agents, that
+// its payload is a JSON object and that its schedule is valid
+func (c *Campaign) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("campaign name is required")
+	}
+	if c.ModuleName == "" {
+		return fmt.Errorf("campaign module name is required")
+	}
+	if len(c.Targets) == 0 {
+		return fmt.Errorf("campaign needs at least one target")
+	}
+	if len(c.Targets) > MaxCampaignTargets {
+		return fmt.Errorf("campaign has %d targets, at most %d are allowed", len(c.Targets), MaxCampaignTargets)
+	}
+	if len(c.AgentIDs) == 0 && len(c.Selector) == 0 {
+		return fmt.Errorf("campaign needs agent IDs or a selector")
+	}
+	if len(c.Payload) > 0 {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(c.Payload, &fields); err != nil {
+			return fmt.Errorf("campaign payload must be a JSON object: %w", err)
+		}
+	}
+	if c.IntervalSeconds < 0 {
+		return fmt.Errorf("campaign interval must not be negative")
+	}
+	if !c.EndsAt.IsZero() && !c.EndsAt.After(c.StartsAt) {
+		return fmt.Errorf("campaign must end after it starts")
+	}
+	return nil
+}
+
+// Interval returns the time between rounds, 0 for a campaign of one round
+func (c *Campaign) Interval() time.Duration {
+	return time.Duration(c.IntervalSeconds) * time.Second
+}
+
+// NextRunAfter returns when the round after the one due at due is, skipping
+// rounds missed up to now. It reports false if there is no further round.
+func (c *Campaign) NextRunAfter(due, now time.Time) (time.Time, bool) {
+	interval := c.Interval()
+	if interval <= 0 {
+		return time.Time{}, false
+	}
+	next := due.Add(interval)
+	if !next.After(now) {
+		next = next.Add(interval * (now.Sub(next)/interval + 1))
+	}
+	if !c.EndsAt.IsZero() && !next.Before(c.EndsAt) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// MatchesAgent reports whether an agent is listed by or matches the
+// selector of the campaign
+func (c *Campaign) MatchesAgent(agent *Agent) bool {
+	if containsID(c.AgentIDs, agent.ID) {
+		return true
+	}
+	return len(c.Selector) > 0 && MatchesLabels(c.Selector, agent.Labels)
+}
+
+// TaskPayload returns the payload of the task measuring a target
+func (c *Campaign) TaskPayload(target string) ([]byte, error) {
+	fields := make(map[string]interface{})
+	if len(c.Payload) > 0 {
+		if err := json.Unmarshal(c.Payload, &fields); err != nil {
+			return nil, err
+		}
+	}
+	fields[c.TargetField] = target
+	return json.Marshal(fields)
+}
+
+// RoundTaskID returns the ID of the k-th task of a round
+func (c *Campaign) RoundTaskID(round int64, k int) string {
+	return fmt.Sprintf("%s-%d-%d", c.ID, round, k)
+}
+
+// CampaignStatusEnum defines the possible statuses for a campaign
+type CampaignStatusEnum string
+
+const (
+	CampaignStatusRunning   CampaignStatusEnum = "running"
+	CampaignStatusCompleted CampaignStatusEnum = "completed"
+	CampaignStatusStopped   CampaignStatusEnum = "stopped"
+)
@@ -0,0 +1,35 @@
+package models
+
+import "fmt"
+
+// CapacityBudget is the load an agent or a group of agents should stay
+// within; zero leaves a limit unset
+type CapacityBudget struct {
+	MaxUtilization    float64
+	MaxQueueDepth     int64
+	MaxBytesPerSecond float64
+}
+
+// Validate checks that the budget's limits are not negative
+func (b CapacityBudget) Validate() error {
+	if b.MaxUtilization < 0 || b.MaxQueueDepth < 0 || b.MaxBytesPerSecond < 0 {
+		return fmt.Errorf("budget limits must not be negative")
+	}
+	return nil
+}
+
+// Exceeded returns the limits of the budget a load exceeds, as
+// "utilization", "queue_depth" and "bandwidth"
+func (b CapacityBudget) Exceeded(utilization float64, queueDepth int64, bytesPerSecond float64) []string {
+	var exceeded []string
+	if b.MaxUtilization > 0 && utilization > b.MaxUtilization {
+		exceeded = append(exceeded, "utilization")
+	}
+	if b.MaxQueueDepth > 0 && queueDepth > b.MaxQueueDepth {
+		exceeded = append(exceeded, "queue_depth")
+	}
+	if b.MaxBytesPerSecond > 0 && bytesPerSecond > b.MaxBytesPerSecond {
+		exceeded = append(exceeded, "bandwidth")
+	}
+	return exceeded
+}
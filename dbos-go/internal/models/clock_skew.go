@@ -0,0 +1,61 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// ClockSkewSmoothing is the weight of a new offset measurement in the
+	// smoothed offset of an agent's clock
+	ClockSkewSmoothing = 0.25
+
+	// ClockSkewMaxAge is how long a clock offset is trusted without a new
+	// measurement; older models are neither applied nor smoothed into
+	ClockSkewMaxAge = 6 * time.Hour
+)
+
+// ClockSkew models the offset of an agent's clock from reference time, as
+// measured by the agent against NTP servers
+type ClockSkew struct {
+	AgentID   string    `json:"agent_id"`
+	OffsetMs  float64   `json:"offset_ms"` // reference time minus agent time
+	DelayMs   float64   `json:"delay_ms"`
+	Samples   int64     `json:"samples"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewClockSkew creates an empty clock skew model for an agent
+func NewClockSkew(agentID string) *ClockSkew {
+	return &ClockSkew{
+		AgentID: agentID,
+	}
+}
+
+// Observe folds an offset measurement into the model; the first measurement,
+// or one following a stale model, replaces the offset outright
+func (c *ClockSkew) Observe(offsetMs, delayMs float64, at time.Time) {
+	if math.IsNaN(offsetMs) || math.IsInf(offsetMs, 0) {
+		return
+	}
+
+	if c.Samples == 0 || !c.Fresh(at) {
+		c.OffsetMs = offsetMs
+		c.DelayMs = delayMs
+	} else {
+		c.OffsetMs += ClockSkewSmoothing * (offsetMs - c.OffsetMs)
+		c.DelayMs += ClockSkewSmoothing * (delayMs - c.DelayMs)
+	}
+	c.Samples++
+	c.UpdatedAt = at
+}
+
+// Fresh reports whether the model was updated recently enough to be applied
+func (c *ClockSkew) Fresh(now time.Time) bool {
+	return c.Samples > 0 && now.Sub(c.UpdatedAt) <= ClockSkewMaxAge
+}
+
+// Normalize converts a time read from the agent's clock to reference time
+func (c *ClockSkew) Normalize(agentTime time.Time) time.Time {
+	return agentTime.Add(time.Duration(c.OffsetMs * float64(time.Millisecond)))
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+)
+
+// AgentConfigVersion is one version of the desired config of an agent
+type AgentConfigVersion struct {
+	AgentID   string            `json:"agent_id"`
+	Version   int64             `json:"version"`
+	Config    map[string]string `json:"config"`
+	RolloutID string            `json:"rollout_id"`
+	AppliedAt time.Time         `json:"applied_at"`
+}
+
+// ConfigRollout represents a staged, health-gated config change across agents
+type ConfigRollout struct {
+	ID                   string            `json:"id"`
+	Config               map[string]string `json:"config"`
+	Selector             map[string]string `json:"selector"`
+	Stages               []int32           `json:"stages"`
+	CurrentStage         int32             `json:"current_stage"`
+	MaxErrorRate         float64           `json:"max_error_rate"`
+	StageDurationSeconds int64             `json:"stage_duration_seconds"`
+	StageStartedAt       time.Time         `json:"stage_started_at"`
+	Status               string            `json:"status"`
+	UpdatedAgents        []string          `json:"updated_agents"`
+	Reason               string            `json:"reason"`
+	CreatedAt            time.Time         `json:"created_at"`
+}
+
+// NewConfigRollout creates a new config rollout instance
+func NewConfigRollout(id string, config map[string]string, stages []int32) *ConfigRollout {
+	now := time.Now()
+	return &ConfigRollout{
+		ID:             id,
+		Config:         config,
+		Selector:       make(map[string]string),
+		Stages:         stages,
+		StageStartedAt: now,
+		Status:         string(RolloutStatusInProgress),
+		CreatedAt:      now,
+	}
+}
+
+// StageDuration returns how long each stage must stay healthy before the next one starts
+func (r *ConfigRollout) StageDuration() time.Duration {
+	return time.Duration(r.StageDurationSeconds) * time.Second
+}
+
+// CohortSize returns how many of total targets the given stage covers
+func (r *ConfigRollout) CohortSize(stage int32, total int) int {
+	if stage < 0 || int(stage) >= len(r.Stages) {
+		return 0
+	}
+	n := (int(r.Stages[stage])*total + 99) / 100
+	if n > total {
+		n = total
+	}
+	return n
+}
+
+// RolloutStatusEnum defines the possible statuses for a config rollout
+type RolloutStatusEnum string
+
+const (
+	RolloutStatusInProgress RolloutStatusEnum = "in_progress"
+	RolloutStatusCompleted  RolloutStatusEnum = "completed"
+	RolloutStatusAborted    RolloutStatusEnum = "aborted"
+	RolloutStatusRolledBack RolloutStatusEnum = "rolled_back"
+)
+
+// MatchesLabels reports whether labels contain every key/value pair of selector
+func MatchesLabels(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
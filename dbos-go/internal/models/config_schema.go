@@ -0,0 +1,182 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// AgentVersionLabel is the label carrying the version of an agent's
+// software, which selects the config schema its config is validated with
+const AgentVersionLabel = "agent_version"
+
+// ConfigSchema describes the config accepted by agents of one version. The
+// schema with an empty AgentVersion is the default, applying to agents
+// whose version has no schema of its own.
+type ConfigSchema struct {
+	AgentVersion     string                     `json:"agent_version"`
+	Keys             map[string]ConfigKeySchema `json:"keys"`
+	AllowUnknownKeys bool                       `json:"allow_unknown_keys"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
+}
+
+// ConfigKeySchema constrains the value of one config key
+type ConfigKeySchema struct {
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+	// Min and Max bound int, float and duration values, durations in seconds
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// Values are the allowed values of enum keys
+	Values []string `json:"values,omitempty"`
+	// Pattern is a regular expression string values must match in full
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// ConfigKeyTypeEnum defines the value types of config keys
+type ConfigKeyTypeEnum string
+
+const (
+	ConfigKeyString   ConfigKeyTypeEnum = "string"
+	ConfigKeyInt      ConfigKeyTypeEnum = "int"
+	ConfigKeyFloat    ConfigKeyTypeEnum = "float"
+	ConfigKeyBool     ConfigKeyTypeEnum = "bool"
+	ConfigKeyDuration ConfigKeyTypeEnum = "duration"
+	ConfigKeyEnum     ConfigKeyTypeEnum = "enum"
+)
+
+// ConfigViolation is a way a config breaks the schema of an agent version
+type ConfigViolation struct {
+	AgentVersion string
+	Key          string
+	Reason       string
+	// AgentIDs are the agents whose resulting config has the violation
+	AgentIDs []string
+}
+
+func (v *ConfigViolation) String() string {
+	return fmt.Sprintf("key %s: %s", v.Key, v.Reason)
+}
+
+// Check validates the schema itself, defaulting key types to string
+func (s *ConfigSchema) Check() error {
+	for key, keySchema := range s.Keys {
+		if key == "" {
+			return dberrors.New(dberrors.InvalidArgument, "config schema keys must not be empty")
+		}
+		switch ConfigKeyTypeEnum(keySchema.Type) {
+		case "":
+			keySchema.Type = string(ConfigKeyString)
+		case ConfigKeyString, ConfigKeyInt, ConfigKeyFloat, ConfigKeyBool, ConfigKeyDuration:
+		case ConfigKeyEnum:
+			if len(keySchema.Values) == 0 {
+				return dberrors.New(dberrors.InvalidArgument, "enum key %s needs at least one value", key)
+			}
+		default:
+			return dberrors.New(dberrors.InvalidArgument, "key %s has unknown type %q", key, keySchema.Type)
+		}
+		if keySchema.Min != nil && keySchema.Max != nil && *keySchema.Min > *keySchema.Max {
+			return dberrors.New(dberrors.InvalidArgument, "key %s has min above max", key)
+		}
+		if keySchema.Pattern != "" {
+			if _, err := regexp.Compile(keySchema.Pattern); err != nil {
+				return dberrors.New(dberrors.InvalidArgument, "key %s has an invalid pattern: %v", key, err)
+			}
+		}
+		s.Keys[key] = keySchema
+	}
+	return nil
+}
+
+// Validate checks a config against the schema, returning the reason each
+// offending key is rejected by key
+func (s *ConfigSchema) Validate(config map[string]string) map[string]string {
+	reasons := make(map[string]string)
+	for key, keySchema := range s.Keys {
+		value, ok := config[key]
+		if !ok {
+			if keySchema.Required {
+				reasons[key] = "required key is missing"
+			}
+			continue
+		}
+		if reason := keySchema.validate(value); reason != "" {
+			reasons[key] = reason
+		}
+	}
+	if !s.AllowUnknownKeys {
+		for key := range config {
+			if _, ok := s.Keys[key]; !ok {
+				reasons[key] = "unknown key"
+			}
+		}
+	}
+	return reasons
+}
+
+// validate checks one value, returning why it is rejected or empty
+func (k ConfigKeySchema) validate(value string) string {
+	var number float64
+	switch ConfigKeyTypeEnum(k.Type) {
+	case ConfigKeyInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Sprintf("%q is not an integer", value)
+		}
+		number = float64(n)
+	case ConfigKeyFloat:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Sprintf("%q is not a number", value)
+		}
+		number = f
+	case ConfigKeyDuration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Sprintf("%q is not a duration", value)
+		}
+		number = d.Seconds()
+	case ConfigKeyBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Sprintf("%q is not a boolean", value)
+		}
+		return ""
+	case ConfigKeyEnum:
+		for _, allowed := range k.Values {
+			if value == allowed {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of %s", value, strings.Join(k.Values, ", "))
+	default:
+		if k.Pattern != "" {
+			if re, err := regexp.Compile("^(?:" + k.Pattern + ")$"); err == nil && !re.MatchString(value) {
+				return fmt.Sprintf("%q does not match %s", value, k.Pattern)
+			}
+		}
+		return ""
+	}
+
+	if k.Min != nil && number < *k.Min {
+		return fmt.Sprintf("%s is below the minimum %g", value, *k.Min)
+	}
+	if k.Max != nil && number > *k.Max {
+		return fmt.Sprintf("%s is above the maximum %g", value, *k.Max)
+	}
+	return ""
+}
+
+// SortConfigViolations orders violations by agent version and key
+func SortConfigViolations(violations []*ConfigViolation) {
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].AgentVersion != violations[j].AgentVersion {
+			return violations[i].AgentVersion < violations[j].AgentVersion
+		}
+		return violations[i].Key < violations[j].Key
+	})
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DNSObservation is the latest answer to a DNS query seen from one region
+type DNSObservation struct {
+	Region     string    `json:"region"`
+	AgentID    string    `json:"agent_id"`
+	ResultID   string    `json:"result_id"`
+	Rcode      string    `json:"rcode"`
+	Answers    []string  `json:"answers"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// DNSExpectation is the answer set a DNS query is expected to resolve to,
+// as last reported by an agent asking the name's authoritative servers
+type DNSExpectation struct {
+	Answers   []string  `json:"answers"`
+	AgentID   string    `json:"agent_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NormalizeDNSAnswers lower-cases answers, strips trailing dots from names
+// and sorts them, so answer sets compare regardless of formatting
+func NormalizeDNSAnswers(answers []string) []string {
+	normalized := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		answer = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(answer)), ".")
+		if answer != "" {
+			normalized = append(normalized, answer)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// AnswersOverlap reports whether two answer sets share at least one answer
+func AnswersOverlap(a, b []string) bool {
+	seen := make(map[string]bool, len(a))
+	for _, answer := range a {
+		seen[answer] = true
+	}
+	for _, answer := range b {
+		if seen[answer] {
+			return true
+		}
+	}
+	return false
+}
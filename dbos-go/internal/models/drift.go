@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Drift is a difference between a campaign or recurring task a spec manages
+// and the definition ApplySpec last applied for it
+type Drift struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	Reason string   `json:"reason"`
+	Fields []string `json:"fields,omitempty"`
+	// DetectedAt is when drift detection first found the drift
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// Key identifies a drift across detections
+func (d *Drift) Key() string {
+	return d.Kind + "/" + d.Name + "/" + d.Reason
+}
+
+// DriftReasonEnum defines how what a spec manages can drift
+type DriftReasonEnum string
+
+const (
+	// DriftMissing is a campaign or task that no longer exists
+	DriftMissing DriftReasonEnum = "missing"
+	// DriftStopped is a campaign stopped before its end
+	DriftStopped DriftReasonEnum = "stopped"
+	// DriftCancelled is a recurring task that was cancelled
+	DriftCancelled DriftReasonEnum = "cancelled"
+	// DriftNotScheduled is a campaign or recurring task with no next run
+	// although it should recur
+	DriftNotScheduled DriftReasonEnum = "not_scheduled"
+	// DriftChanged is a definition differing from the spec's
+	DriftChanged DriftReasonEnum = "changed"
+)
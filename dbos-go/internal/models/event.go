@@ -0,0 +1,159 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event is an entry of the log of mutations made through the API or by
+// the server's own workers
+type Event struct {
+	ID string `json:"id"`
+	// Sequence is the event's position in the log, assigned when appended
+	Sequence string `json:"-"`
+	Type     string `json:"type"`
+	// Actor is who caused the mutation: the identity of the caller's token,
+	// EventActorSystem for the server's workers, or empty if unknown
+	Actor string `json:"actor,omitempty"`
+	// Subject is the resource name of what was mutated, e.g.
+	// "agents/probe-1" or "tasks/task-01h..."
+	Subject       string          `json:"subject"`
+	AgentID       string          `json:"agent_id,omitempty"`
+	Severity      string          `json:"severity"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Timestamp     time.Time       `json:"timestamp"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+// EventFilter selects events of the event log; zero fields match all
+type EventFilter struct {
+	Types   []string
+	AgentID string
+	Since   time.Time
+	Until   time.Time
+}
+
+// Matches reports whether an event passes the filter
+func (f EventFilter) Matches(event *Event) bool {
+	if len(f.Types) > 0 {
+		found := false
+		for _, eventType := range f.Types {
+			if event.Type == eventType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.AgentID != "" && event.AgentID != f.AgentID {
+		return false
+	}
+	if !f.Since.IsZero() && event.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !event.Timestamp.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// EventActorSystem is the actor of mutations made by the server itself,
+// such as the liveness sweeper marking agents dead
+const EventActorSystem = "system"
+
+// EventTypeEnum defines the mutations recorded in the event log
+type EventTypeEnum string
+
+const (
+	EventAgentRegistered    EventTypeEnum = "agent_registered"
+	EventAgentEnrolled      EventTypeEnum = "agent_enrolled"
+	EventAgentUpdated       EventTypeEnum = "agent_updated"
+	EventAgentMarkedDead    EventTypeEnum = "agent_marked_dead"
+	EventAgentDeleted       EventTypeEnum = "agent_deleted"
+	EventTaskScheduled      EventTypeEnum = "task_scheduled"
+	EventTaskCancelled      EventTypeEnum = "task_cancelled"
+	EventTaskFailedOver     EventTypeEnum = "task_failed_over"
+	EventTaskUnassigned     EventTypeEnum = "task_unassigned"
+	EventTaskLeased         EventTypeEnum = "task_leased"
+	EventTaskAcked          EventTypeEnum = "task_acked"
+	EventTaskNacked         EventTypeEnum = "task_nacked"
+	EventTaskProgress       EventTypeEnum = "task_progress"
+	EventTaskRequeued       EventTypeEnum = "task_requeued"
+	EventTaskCompleted      EventTypeEnum = "task_completed"
+	EventTaskFailed         EventTypeEnum = "task_failed"
+	EventModuleStateChanged EventTypeEnum = "module_state_changed"
+	EventQuotaWarning       EventTypeEnum = "quota_warning"
+	EventQuotaExceeded      EventTypeEnum = "quota_exceeded"
+	EventDriftDetected      EventTypeEnum = "drift_detected"
+	EventDriftRepaired      EventTypeEnum = "drift_repaired"
+	EventDataMigrated       EventTypeEnum = "data_migrated"
+)
+
+// TaskFailover is the payload of the events of a continuous task failing
+// over from its dead agent, to ToAgentID or, if no live agent runs its
+// module and matches its failover selector, to none
+type TaskFailover struct {
+	TaskID      string `json:"task_id"`
+	ModuleName  string `json:"module_name"`
+	FromAgentID string `json:"from_agent_id"`
+	ToAgentID   string `json:"to_agent_id,omitempty"`
+}
+
+// TaskLeaseEndReasonEnum defines why the server ended the lease of a task
+// its agent did not settle
+type TaskLeaseEndReasonEnum string
+
+const (
+	TaskLeaseExpired     TaskLeaseEndReasonEnum = "expired"
+	TaskLeaseHung        TaskLeaseEndReasonEnum = "hung"
+	TaskLeaseUndelivered TaskLeaseEndReasonEnum = "undelivered"
+)
+
+// TaskTransition is the payload of the events of a task changing state:
+// leased by AgentID until LeaseExpiresAt, if its lease ends, or settled by
+// its agent, or by the server for Reason, leaving it as Status, due again
+// at ScheduledAt if pending
+type TaskTransition struct {
+	TaskID         string                 `json:"task_id"`
+	ModuleName     string                 `json:"module_name"`
+	AgentID        string                 `json:"agent_id"`
+	Reason         TaskLeaseEndReasonEnum `json:"reason,omitempty"`
+	Status         string                 `json:"status"`
+	RetryCount     int64                  `json:"retry_count"`
+	ScheduledAt    time.Time              `json:"scheduled_at"`
+	LeaseExpiresAt *time.Time             `json:"lease_expires_at,omitempty"`
+}
+
+// TaskTransitionOf describes the current state of a task as the payload of
+// an event of its transition
+func TaskTransitionOf(task *Task, reason TaskLeaseEndReasonEnum) *TaskTransition {
+	return &TaskTransition{
+		TaskID:         task.ID,
+		ModuleName:     task.ModuleName,
+		AgentID:        task.AgentID,
+		Reason:         reason,
+		Status:         task.Status,
+		RetryCount:     task.RetryCount,
+		ScheduledAt:    task.ScheduledAt,
+		LeaseExpiresAt: task.LeaseExpiresAt,
+	}
+}
+
+// DataMigration is the payload of the events of the server moving data
+// stored by earlier versions into its current layout at startup
+type DataMigration struct {
+	Migration string `json:"migration"`
+	AgentID   string `json:"agent_id,omitempty"`
+	Moved     int64  `json:"moved"`
+}
+
+// EventSeverityEnum grades events
+type EventSeverityEnum string
+
+const (
+	EventSeverityInfo    EventSeverityEnum = "info"
+	EventSeverityWarning EventSeverityEnum = "warning"
+	EventSeverityError   EventSeverityEnum = "error"
+)
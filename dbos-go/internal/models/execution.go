@@ -0,0 +1,114 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// MaxAgentExecutions is how many of an agent's latest executions of a
+	// module are kept for its statistics
+	MaxAgentExecutions = 100
+
+	// MaxModuleExecutions is how many of the latest executions of a module
+	// across all agents are kept for its statistics
+	MaxModuleExecutions = 1000
+)
+
+// ExecutionBucketsMs are the upper bounds, in milliseconds, of the buckets
+// of execution duration histograms; a last bucket holds longer executions
+var ExecutionBucketsMs = []float64{
+	10, 25, 50, 100, 250, 500,
+	1000, 2500, 5000, 10000, 30000, 60000,
+	120000, 300000, 600000, 1800000, 3600000,
+}
+
+// Execution records how long an agent took to run a leased task, from its
+// lease until its result was stored or it was acknowledged, whichever came
+// first, and how large the result was
+type Execution struct {
+	DurationMs  float64   `json:"duration_ms"`
+	ResultBytes int64     `json:"result_bytes"`
+	At          time.Time `json:"at"`
+	// Acked marks an execution ended by the task's acknowledgement, which
+	// carries no result size
+	Acked bool `json:"acked,omitempty"`
+}
+
+// ExecutionStats summarizes a set of executions
+type ExecutionStats struct {
+	Samples         int
+	MeanDurationMs  float64
+	P50DurationMs   float64
+	P95DurationMs   float64
+	P99DurationMs   float64
+	MeanResultBytes float64
+}
+
+// SummarizeExecutions returns the statistics of a set of executions. The
+// mean result size is that of the executions ended by their result.
+func SummarizeExecutions(executions []*Execution) ExecutionStats {
+	stats := ExecutionStats{Samples: len(executions)}
+	if len(executions) == 0 {
+		return stats
+	}
+
+	durations := make([]float64, len(executions))
+	var totalMs, totalBytes float64
+	results := 0
+	for i, execution := range executions {
+		durations[i] = execution.DurationMs
+		totalMs += execution.DurationMs
+		if !execution.Acked {
+			totalBytes += float64(execution.ResultBytes)
+			results++
+		}
+	}
+	sort.Float64s(durations)
+	percentile := func(p float64) float64 {
+		return durations[int(math.Ceil(p*float64(len(durations))))-1]
+	}
+	stats.MeanDurationMs = totalMs / float64(len(executions))
+	stats.P50DurationMs = percentile(0.50)
+	stats.P95DurationMs = percentile(0.95)
+	stats.P99DurationMs = percentile(0.99)
+	if results > 0 {
+		stats.MeanResultBytes = totalBytes / float64(results)
+	}
+	return stats
+}
+
+// ExecutionHistogram counts executions by duration over all time
+type ExecutionHistogram struct {
+	// Counts holds the executions of each bucket of ExecutionBucketsMs,
+	// then those longer than the last bound
+	Counts []int64
+	Count  int64
+	SumMs  float64
+}
+
+// ExecutionBucket returns the histogram field counting executions as long
+// as durationMs: the upper bound of its bucket, or "+Inf"
+func ExecutionBucket(durationMs float64) string {
+	for _, bound := range ExecutionBucketsMs {
+		if durationMs <= bound {
+			return strconv.FormatFloat(bound, 'f', -1, 64)
+		}
+	}
+	return "+Inf"
+}
+
+// ParseExecutionHistogram decodes a histogram from the counts of its bucket
+// fields and its "count" and "sum_ms" fields
+func ParseExecutionHistogram(fields map[string]string) ExecutionHistogram {
+	histogram := ExecutionHistogram{Counts: make([]int64, len(ExecutionBucketsMs)+1)}
+	for i, bound := range ExecutionBucketsMs {
+		histogram.Counts[i], _ = strconv.ParseInt(fields[strconv.FormatFloat(bound, 'f', -1, 64)], 10, 64)
+	}
+	histogram.Counts[len(ExecutionBucketsMs)], _ = strconv.ParseInt(fields["+Inf"], 10, 64)
+	histogram.Count, _ = strconv.ParseInt(fields["count"], 10, 64)
+	histogram.SumMs, _ = strconv.ParseFloat(fields["sum_ms"], 64)
+	return histogram
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// ExtractionRule extracts a field of a module's results into an indexed
+// column at ingest, so results can be filtered on it without scanning payloads
+type ExtractionRule struct {
+	ModuleName string    `json:"module_name"`
+	Column     string    `json:"column"`
+	Path       string    `json:"path"`
+	Type       string    `json:"type"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewExtractionRule creates a new extraction rule
+func NewExtractionRule(moduleName, column, path, columnType string) *ExtractionRule {
+	return &ExtractionRule{
+		ModuleName: moduleName,
+		Column:     column,
+		Path:       path,
+		Type:       columnType,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// ColumnFilter compares an extracted column with a value
+type ColumnFilter struct {
+	Column string `json:"column"`
+	Op     string `json:"op"`
+	Value  string `json:"value"`
+}
+
+// ColumnTypeEnum defines the types of extracted columns
+type ColumnTypeEnum string
+
+const (
+	// ColumnTypeNumber columns hold numbers and support range filters
+	ColumnTypeNumber ColumnTypeEnum = "number"
+	// ColumnTypeString columns hold strings and support equality filters;
+	// non-string values are indexed as their JSON encoding
+	ColumnTypeString ColumnTypeEnum = "string"
+)
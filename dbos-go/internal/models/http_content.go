@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// HTTPObservation is the latest response to a URL seen from one region
+type HTTPObservation struct {
+	Region     string    `json:"region"`
+	AgentID    string    `json:"agent_id"`
+	ResultID   string    `json:"result_id"`
+	StatusCode int       `json:"status_code"`
+	BodySHA256 string    `json:"body_sha256"`
+	BodyLength int64     `json:"body_length"`
+	Title      string    `json:"title,omitempty"`
+	ObservedAt time.Time `json:"observed_at"`
+}
@@ -0,0 +1,198 @@
+package models
+
+import "time"
+
+// IncidentTypeEnum classifies incidents
+type IncidentTypeEnum string
+
+const (
+	// IncidentTypeDNSManipulation flags a region whose DNS answers diverge
+	// from the authoritative ones or from every other region
+	IncidentTypeDNSManipulation IncidentTypeEnum = "dns_manipulation"
+
+	// IncidentTypeHTTPContentTampering flags a region served different
+	// content for a URL than the other regions agree on, such as a block
+	// page or injected content
+	IncidentTypeHTTPContentTampering IncidentTypeEnum = "http_content_tampering"
+
+	// IncidentTypeRoutingAnomaly links BGP withdrawals or hijacks of a
+	// watched prefix with concurrent probe anomalies towards it
+	IncidentTypeRoutingAnomaly IncidentTypeEnum = "routing_anomaly"
+
+	// IncidentTypeAlertRule tracks a series for which an alert rule is firing
+	IncidentTypeAlertRule IncidentTypeEnum = "alert_rule"
+
+	// IncidentTypeManual is an incident declared by an operator
+	IncidentTypeManual IncidentTypeEnum = "manual"
+)
+
+// IncidentStatusEnum is the lifecycle state of an incident
+type IncidentStatusEnum string
+
+const (
+	IncidentStatusOpen         IncidentStatusEnum = "open"
+	IncidentStatusAcknowledged IncidentStatusEnum = "acknowledged"
+	IncidentStatusResolved     IncidentStatusEnum = "resolved"
+)
+
+// IncidentStatusActive filters incidents that are open or acknowledged
+const IncidentStatusActive = "active"
+
+// maxIncidentResults bounds how many of the latest result and event IDs an incident keeps
+const maxIncidentResults = 20
+
+// Incident is an ongoing condition detected across measurement results or
+// declared by an operator. An incident stays active, accumulating
+// occurrences, until a result shows the condition has cleared or it is
+// resolved by hand; acknowledging it records that someone is on it.
+type Incident struct {
+	ID             string             `json:"id"`
+	Type           string             `json:"type"`
+	Key            string             `json:"key"` // identifies the condition; one active incident per key
+	Status         IncidentStatusEnum `json:"status"`
+	Title          string             `json:"title,omitempty"`
+	Region         string             `json:"region"`
+	Target         string             `json:"target"`
+	Targets        []string           `json:"targets,omitempty"` // further affected targets
+	Reason         string             `json:"reason"`
+	AgentIDs       []string           `json:"agent_ids"`
+	ResultIDs      []string           `json:"result_ids"`          // latest first
+	EventIDs       []string           `json:"event_ids,omitempty"` // linked external events, latest first
+	AlertIDs       []string           `json:"alert_ids,omitempty"` // linked alerts, latest first
+	Evidence       map[string]string  `json:"evidence,omitempty"`
+	Comments       []IncidentComment  `json:"comments,omitempty"` // oldest first
+	Occurrences    int64              `json:"occurrences"`
+	FirstSeen      time.Time          `json:"first_seen"`
+	LastSeen       time.Time          `json:"last_seen"`
+	AcknowledgedBy string             `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt time.Time          `json:"acknowledged_at,omitempty"`
+	ResolvedBy     string             `json:"resolved_by,omitempty"`
+	ResolvedAt     time.Time          `json:"resolved_at,omitempty"`
+}
+
+// IncidentComment is a note left on an incident
+type IncidentComment struct {
+	ID        string    `json:"id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Active reports whether an incident is open or acknowledged
+func (i *Incident) Active() bool {
+	return i.Status != IncidentStatusResolved
+}
+
+// Recur records another occurrence of an open incident, taking over the
+// latest reason and evidence. Occurrences not seen in a result, such as
+// alert rule evaluations, have no result ID and may have no agent ID.
+func (i *Incident) Recur(agentID, resultID, reason string, evidence map[string]string, at time.Time) {
+	i.AddAgents(agentID)
+	if resultID != "" {
+		i.ResultIDs = append([]string{resultID}, i.ResultIDs...)
+		if len(i.ResultIDs) > maxIncidentResults {
+			i.ResultIDs = i.ResultIDs[:maxIncidentResults]
+		}
+	}
+	i.Reason = reason
+	i.Evidence = evidence
+	i.Occurrences++
+	i.LastSeen = at
+}
+
+// LinkEvents links external events, such as BGP updates, to an incident
+func (i *Incident) LinkEvents(ids ...string) {
+	i.EventIDs = linkIDs(i.EventIDs, ids)
+}
+
+// LinkAlerts links alerts, such as the anomalies that led to an incident
+func (i *Incident) LinkAlerts(ids ...string) {
+	i.AlertIDs = linkIDs(i.AlertIDs, ids)
+}
+
+// LinkResults links results showing the condition without counting them as
+// occurrences
+func (i *Incident) LinkResults(ids ...string) {
+	i.ResultIDs = linkIDs(i.ResultIDs, ids)
+}
+
+// AddAgents records further affected agents
+func (i *Incident) AddAgents(ids ...string) {
+	i.AgentIDs = addIDs(i.AgentIDs, ids)
+}
+
+// AddTargets records further affected targets
+func (i *Incident) AddTargets(targets ...string) {
+	for _, target := range targets {
+		if target != i.Target {
+			i.Targets = addIDs(i.Targets, []string{target})
+		}
+	}
+}
+
+// Acknowledge records who is handling an active incident
+func (i *Incident) Acknowledge(by string, at time.Time) {
+	i.Status = IncidentStatusAcknowledged
+	i.AcknowledgedBy = by
+	i.AcknowledgedAt = at
+}
+
+// linkIDs prepends the IDs not yet in linked, keeping the latest
+// maxIncidentResults
+func linkIDs(linked, ids []string) []string {
+	for _, id := range ids {
+		if id != "" && !containsID(linked, id) {
+			linked = append([]string{id}, linked...)
+		}
+	}
+	if len(linked) > maxIncidentResults {
+		linked = linked[:maxIncidentResults]
+	}
+	return linked
+}
+
+// addIDs appends the IDs not yet in list
+func addIDs(list, ids []string) []string {
+	for _, id := range ids {
+		if id != "" && !containsID(list, id) {
+			list = append(list, id)
+		}
+	}
+	return list
+}
+
+func containsID(list []string, id string) bool {
+	for _, existing := range list {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve closes an incident
+func (i *Incident) Resolve(at time.Time) {
+	i.Status = IncidentStatusResolved
+	i.ResolvedAt = at
+}
+
+// IncidentEventTypeEnum classifies changes to incidents
+type IncidentEventTypeEnum string
+
+const (
+	IncidentEventOpened       IncidentEventTypeEnum = "opened"
+	IncidentEventUpdated      IncidentEventTypeEnum = "updated"
+	IncidentEventAcknowledged IncidentEventTypeEnum = "acknowledged"
+	IncidentEventCommented    IncidentEventTypeEnum = "commented"
+	IncidentEventResolved     IncidentEventTypeEnum = "resolved"
+	IncidentEventDeleted      IncidentEventTypeEnum = "deleted"
+)
+
+// IncidentEvent is one entry of the incident event log. Occurrences of an
+// active incident are not logged.
+type IncidentEvent struct {
+	Revision   string
+	Type       string
+	IncidentID string
+	Incident   *Incident // nil for deletions
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// TenantLabel is the agent label naming the tenant an agent belongs to
+const TenantLabel = "tenant"
+
+// MaintenanceWindow is a scheduled period during which alerts about the
+// agents or targets it covers are suppressed. It covers the agents matching
+// its selector and tenant, if either is set, and the targets it lists.
+type MaintenanceWindow struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Selector    map[string]string `json:"selector,omitempty"`
+	Tenant      string            `json:"tenant,omitempty"`
+	Targets     []string          `json:"targets,omitempty"`
+	StartsAt    time.Time         `json:"starts_at"`
+	EndsAt      time.Time         `json:"ends_at"`
+	// PauseTasks stops continuous tasks of covered agents or targets from
+	// being re-issued during the window
+	PauseTasks bool      `json:"pause_tasks"`
+	CreatedBy  string    `json:"created_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Validate checks that the window has a name, a scope and a valid period
+func (w *MaintenanceWindow) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("maintenance window name is required")
+	}
+	if len(w.Selector) == 0 && w.Tenant == "" && len(w.Targets) == 0 {
+		return fmt.Errorf("maintenance window needs a selector, tenant or targets")
+	}
+	if w.StartsAt.IsZero() || w.EndsAt.IsZero() {
+		return fmt.Errorf("maintenance window start and end are required")
+	}
+	if !w.EndsAt.After(w.StartsAt) {
+		return fmt.Errorf("maintenance window must end after it starts")
+	}
+	return nil
+}
+
+// ActiveAt reports whether the window is in progress at t
+func (w *MaintenanceWindow) ActiveAt(t time.Time) bool {
+	return !t.Before(w.StartsAt) && t.Before(w.EndsAt)
+}
+
+// CoversAgent reports whether the window's selector and tenant match an
+// agent; a window without either covers no agent
+func (w *MaintenanceWindow) CoversAgent(agent *Agent) bool {
+	if len(w.Selector) == 0 && w.Tenant == "" {
+		return false
+	}
+	if w.Tenant != "" && agent.Labels[TenantLabel] != w.Tenant {
+		return false
+	}
+	return MatchesLabels(w.Selector, agent.Labels)
+}
+
+// CoversTarget reports whether the window lists a target
+func (w *MaintenanceWindow) CoversTarget(target string) bool {
+	return target != "" && containsID(w.Targets, target)
+}
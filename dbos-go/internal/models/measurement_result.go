@@ -1,9 +1,14 @@
 package models
 
 import (
+	"strings"
 	"time"
 )
 
+// LocalTaskIDPrefix marks synthetic task IDs minted by agents for
+// measurements they scheduled themselves rather than received from DBOS
+const LocalTaskIDPrefix = "local-"
+
 // MeasurementResult represents a network measurement result
 type MeasurementResult struct {
 	ID         string    `json:"id"`
@@ -11,6 +16,41 @@ type MeasurementResult struct {
 	ModuleName string    `json:"module_name"`
 	Data       []byte    `json:"data"` // JSON-encoded result data
 	Timestamp  time.Time `json:"timestamp"`
+	Origin     string    `json:"origin"`
+	Sequence   int64     `json:"sequence"`
+	BlobRefs   []string  `json:"blob_refs,omitempty"` // deduplicated fragments referenced by Data
+
+	// ClockOffsetMs is the correction applied to the agent's clock when
+	// deriving Timestamp from an agent-reported time
+	ClockOffsetMs float64 `json:"clock_offset_ms,omitempty"`
+
+	// CorrelationID groups the result with other measurements of one
+	// experiment, such as a ping, traceroute and DNS lookup of one target
+	CorrelationID string `json:"correlation_id,omitempty"`
+
+	// Backfilled marks a late historical result stored by a backfill,
+	// which real-time views and analyses leave out
+	Backfilled bool `json:"backfilled,omitempty"`
+}
+
+// SequenceGap is an inclusive range of result sequence numbers with no stored result
+type SequenceGap struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// ResultRange selects an agent's results by measurement time and module.
+// From is inclusive and To exclusive; zero times and an empty module do not
+// restrict the range.
+type ResultRange struct {
+	From       time.Time
+	To         time.Time
+	ModuleName string
+}
+
+// Contains reports whether a measurement time is within the range
+func (r ResultRange) Contains(t time.Time) bool {
+	return (r.From.IsZero() || !t.Before(r.From)) && (r.To.IsZero() || t.Before(r.To))
 }
 
 // NewMeasurementResult creates a new measurement result instance
@@ -21,5 +61,38 @@ func NewMeasurementResult(id, agentID, moduleName string, data []byte) *Measurem
 		ModuleName: moduleName,
 		Data:       data,
 		Timestamp:  time.Now(),
+		Origin:     string(ResultOriginFor(id)),
 	}
 }
+
+// IsLocalTaskID reports whether id is a synthetic, agent-generated task ID
+func IsLocalTaskID(id string) bool {
+	return strings.HasPrefix(id, LocalTaskIDPrefix)
+}
+
+// ResultOriginFor returns the origin implied by a result's task ID
+func ResultOriginFor(id string) ResultOriginEnum {
+	if IsLocalTaskID(id) {
+		return ResultOriginLocal
+	}
+	return ResultOriginScheduled
+}
+
+// ResultOriginEnum defines where the measurement behind a result was scheduled
+type ResultOriginEnum string
+
+const (
+	ResultOriginScheduled ResultOriginEnum = "scheduled"
+	ResultOriginLocal     ResultOriginEnum = "local"
+)
+
+// ResultStoreStatusEnum defines the outcome of storing one result of a batch
+type ResultStoreStatusEnum string
+
+const (
+	ResultStoreStatusStored ResultStoreStatusEnum = "stored"
+	// ResultStoreStatusDuplicate is a re-delivery of a stored result, stored
+	// again under its sequence number
+	ResultStoreStatusDuplicate ResultStoreStatusEnum = "duplicate"
+	ResultStoreStatusError     ResultStoreStatusEnum = "error"
+)
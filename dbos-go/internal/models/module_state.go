@@ -10,9 +10,11 @@ type ModuleState struct {
 	ModuleName   string            `json:"module_name"`
 	State        string            `json:"state"`
 	ErrorMessage string            `json:"error_message"`
+	ErrorCode    string            `json:"error_code,omitempty"`
 	Details      map[string]string `json:"details"`
 	Timestamp    time.Time         `json:"timestamp"`
 	RequestID    string            `json:"request_id"`
+	Version      int64             `json:"version"` // incremented by every write
 }
 
 // NewModuleState creates a new module state instance
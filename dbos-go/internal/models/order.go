@@ -0,0 +1,33 @@
+package models
+
+// Order is the order a list is returned in: by one field, ascending unless
+// Desc is set. The zero Order is a list's default order.
+type Order struct {
+	Field string
+	Desc  bool
+}
+
+// Fields agents can be ordered by
+const (
+	AgentOrderID       = "id"
+	AgentOrderHostname = "hostname"
+	AgentOrderLastSeen = "last_seen"
+)
+
+// Fields an agent's results can be ordered by; the default is measurement time
+const (
+	ResultOrderTimestamp = "timestamp"
+	ResultOrderSequence  = "sequence"
+)
+
+// Fields an agent's tasks can be ordered by; the default is scheduled time
+const (
+	TaskOrderScheduledAt = "scheduled_at"
+	TaskOrderCreatedAt   = "created_at"
+)
+
+// AgentOrderFields lists the fields agents can be ordered by
+var AgentOrderFields = []string{AgentOrderID, AgentOrderHostname, AgentOrderLastSeen}
+
+// TaskOrderFields lists the fields tasks can be ordered by
+var TaskOrderFields = []string{TaskOrderScheduledAt, TaskOrderCreatedAt}
@@ -0,0 +1,22 @@
+package models
+
+// OutboxEntry is a message waiting in the outbox to be published to a
+// message bus
+type OutboxEntry struct {
+	ID   string
+	Kind string
+	// Key orders the messages sharing it, such as an agent's results
+	Key string
+	// Tag fills in the topic of the message: a result's module or an
+	// event's entity type
+	Tag  string
+	Data []byte
+}
+
+// OutboxKindEnum defines the kinds of messages published from the outbox
+type OutboxKindEnum string
+
+const (
+	OutboxKindResult OutboxKindEnum = "result"
+	OutboxKindEvent  OutboxKindEnum = "event"
+)
@@ -0,0 +1,52 @@
+package models
+
+import "fmt"
+
+// AgentIPLabel is the agent label holding the agent's public address, as
+// placement rules compare it with task targets
+const AgentIPLabel = "ip"
+
+// Placement constrains which of the live agents matching a group task's
+// selector are issued instances
+type Placement struct {
+	// MaxAgents caps the agents issued instances, zero issuing to all
+	MaxAgents int `json:"max_agents,omitempty"`
+
+	// AvoidTargetPrefixV4 and AvoidTargetPrefixV6 skip agents whose address
+	// shares a prefix of this length with the task's target, so a target
+	// is not measured from inside its own network
+	AvoidTargetPrefixV4 int `json:"avoid_target_prefix_v4,omitempty"`
+	AvoidTargetPrefixV6 int `json:"avoid_target_prefix_v6,omitempty"`
+
+	// SpreadLabel and MinSpread require the agents issued instances to
+	// carry at least MinSpread distinct values of a label, such as asn
+	SpreadLabel string `json:"spread_label,omitempty"`
+	MinSpread   int    `json:"min_spread,omitempty"`
+
+	// PreferPrevious picks agents that measured the task's target with its
+	// module before first
+	PreferPrevious bool `json:"prefer_previous,omitempty"`
+}
+
+// Validate checks that the placement's limits are consistent
+func (p *Placement) Validate() error {
+	if p.MaxAgents < 0 {
+		return fmt.Errorf("placement max_agents must not be negative")
+	}
+	if p.AvoidTargetPrefixV4 < 0 || p.AvoidTargetPrefixV4 > 32 {
+		return fmt.Errorf("placement avoid_target_prefix_v4 must be between 0 and 32")
+	}
+	if p.AvoidTargetPrefixV6 < 0 || p.AvoidTargetPrefixV6 > 128 {
+		return fmt.Errorf("placement avoid_target_prefix_v6 must be between 0 and 128")
+	}
+	if p.MinSpread < 0 {
+		return fmt.Errorf("placement min_spread must not be negative")
+	}
+	if p.MinSpread > 0 && p.SpreadLabel == "" {
+		return fmt.Errorf("placement min_spread requires a spread_label")
+	}
+	if p.MaxAgents > 0 && p.MinSpread > p.MaxAgents {
+		return fmt.Errorf("placement min_spread %d exceeds max_agents %d", p.MinSpread, p.MaxAgents)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Quotas of the API usage of an agent or API key
+const (
+	// QuotaStorage is the bytes of results an agent or key stores per day
+	QuotaStorage = "storage"
+	// QuotaTaskRate is the tasks an agent or key schedules per hour
+	QuotaTaskRate = "task_rate"
+	// QuotaBandwidth is the bytes of request and response messages an
+	// agent or key exchanges per minute
+	QuotaBandwidth = "bandwidth"
+)
+
+// QuotaWindows are the windows quotas are counted over, starting at
+// multiples of their length since the Unix epoch
+var QuotaWindows = map[string]time.Duration{
+	QuotaStorage:   24 * time.Hour,
+	QuotaTaskRate:  time.Hour,
+	QuotaBandwidth: time.Minute,
+}
+
+// DefaultQuotaSoftRatio is the share of a quota's limit its usage is
+// warned about at if no soft limit is given
+const DefaultQuotaSoftRatio = 0.8
+
+// Levels of quota notifications
+const (
+	QuotaLevelWarning  = "warning"
+	QuotaLevelExceeded = "exceeded"
+)
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// RelayedRequest is an agent request a relay buffered while its upstream
+// server was unreachable, forwarded once the upstream is back
+type RelayedRequest struct {
+	Method     string    `json:"method"`
+	Request    []byte    `json:"request"`
+	BufferedAt time.Time `json:"buffered_at"`
+}
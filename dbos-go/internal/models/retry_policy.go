@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// DefaultRetryMultiplier is how much each retry delay grows over the
+	// last when a retry policy leaves it unset
+	DefaultRetryMultiplier = 2
+
+	// MaxRetryDelay caps retry delays when a retry policy leaves it to
+	MaxRetryDelay = 24 * time.Hour
+)
+
+// RetryPolicy backs off the retries of a task handed back by its agent or
+// whose lease expired. The first retry waits InitialDelaySeconds and each
+// further one Multiplier times as long as the last, up to MaxDelaySeconds;
+// Jitter draws a random part of up to that fraction off each delay, so the
+// retries of tasks failing together spread out.
+type RetryPolicy struct {
+	InitialDelaySeconds int64   `json:"initial_delay_seconds,omitempty"`
+	Multiplier          float64 `json:"multiplier,omitempty"`
+	MaxDelaySeconds     int64   `json:"max_delay_seconds,omitempty"`
+	Jitter              float64 `json:"jitter,omitempty"`
+}
+
+// Validate checks that the policy's delays are consistent
+func (p *RetryPolicy) Validate() error {
+	if p.InitialDelaySeconds < 0 || p.MaxDelaySeconds < 0 {
+		return fmt.Errorf("retry_policy delays must not be negative")
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return fmt.Errorf("retry_policy multiplier must be at least 1")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("retry_policy jitter must be between 0 and 1")
+	}
+	if p.MaxDelaySeconds > 0 && p.MaxDelaySeconds < p.InitialDelaySeconds {
+		return fmt.Errorf("retry_policy max_delay_seconds %d is below initial_delay_seconds %d", p.MaxDelaySeconds, p.InitialDelaySeconds)
+	}
+	return nil
+}
+
+// Delay returns the wait before retry n, counted from 1, with the jitter
+// drawn by random, a number in [0, 1)
+func (p *RetryPolicy) Delay(n int64, random float64) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = DefaultRetryMultiplier
+	}
+	maxDelay := MaxRetryDelay
+	if p.MaxDelaySeconds > 0 {
+		maxDelay = time.Duration(p.MaxDelaySeconds) * time.Second
+	}
+
+	// Computed in floating point, so that many retries do not overflow
+	seconds := float64(p.InitialDelaySeconds) * math.Pow(multiplier, float64(max(n, 1)-1))
+	seconds = math.Min(seconds, maxDelay.Seconds())
+	seconds -= seconds * p.Jitter * random
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// Retry counts a retry of the task and returns when it is due again: at
+// earliest, or later if its retry policy backs off further from now
+func (t *Task) Retry(earliest, now time.Time) time.Time {
+	t.RetryCount++
+	if t.RetryPolicy == nil {
+		return earliest
+	}
+	if due := now.Add(t.RetryPolicy.Delay(t.RetryCount, rand.Float64())); due.After(earliest) {
+		return due
+	}
+	return earliest
+}
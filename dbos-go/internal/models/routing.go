@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// RoutingEventTypeEnum classifies routing events
+type RoutingEventTypeEnum string
+
+const (
+	// RoutingEventWithdrawal is a watched prefix, or a more-specific of it,
+	// being withdrawn by a route collector peer
+	RoutingEventWithdrawal RoutingEventTypeEnum = "withdrawal"
+	// RoutingEventHijack is a watched prefix, or a more-specific of it,
+	// being announced from an origin other than the expected one
+	RoutingEventHijack RoutingEventTypeEnum = "hijack"
+)
+
+// RoutingEvent is a BGP update affecting a watched prefix
+type RoutingEvent struct {
+	ID                string    `json:"id"`
+	Type              string    `json:"type"`
+	Prefix            string    `json:"prefix"`
+	WatchedPrefix     string    `json:"watched_prefix"`
+	OriginASN         int64     `json:"origin_asn,omitempty"`
+	ExpectedOriginASN int64     `json:"expected_origin_asn,omitempty"`
+	Path              string    `json:"path,omitempty"`
+	Peer              string    `json:"peer"`
+	PeerASN           string    `json:"peer_asn"`
+	Collector         string    `json:"collector"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+// ProbeAnomalyKindEnum classifies probe anomalies
+type ProbeAnomalyKindEnum string
+
+const (
+	// ProbeAnomalyUnreachable is a probe receiving no replies at all
+	ProbeAnomalyUnreachable ProbeAnomalyKindEnum = "unreachable"
+	// ProbeAnomalyLatency is a probe's RTT far above its baseline
+	ProbeAnomalyLatency ProbeAnomalyKindEnum = "latency"
+)
+
+// ProbeAnomaly is a measurement of an address in a watched prefix showing
+// lost reachability or a latency spike
+type ProbeAnomaly struct {
+	Kind        string    `json:"kind"`
+	AgentID     string    `json:"agent_id"`
+	ResultID    string    `json:"result_id"`
+	Address     string    `json:"address"`
+	AvgRTT      float64   `json:"avg_rtt,omitempty"`
+	BaselineRTT float64   `json:"baseline_rtt,omitempty"`
+	ObservedAt  time.Time `json:"observed_at"`
+}
+
+// RTTBaselineSmoothing is the weight of a new sample in an RTT baseline
+const RTTBaselineSmoothing = 0.1
+
+// RTTBaseline is the smoothed RTT from one agent to one address
+type RTTBaseline struct {
+	AvgRTT  float64 `json:"avg_rtt"`
+	Samples int64   `json:"samples"`
+}
+
+// Observe folds an RTT sample into the baseline
+func (b *RTTBaseline) Observe(rtt float64) {
+	if b.Samples == 0 {
+		b.AvgRTT = rtt
+	} else {
+		b.AvgRTT += RTTBaselineSmoothing * (rtt - b.AvgRTT)
+	}
+	b.Samples++
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// SavedQuery is a named query over results or agents, referenced by
+// dashboards and alert rules instead of repeating its filters
+type SavedQuery struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Target      string            `json:"target"`
+	ModuleName  string            `json:"module_name,omitempty"`
+	Filters     []ColumnFilter    `json:"filters,omitempty"`
+	AgentID     string            `json:"agent_id,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	AliveOnly   bool              `json:"alive_only,omitempty"`
+	Aggregation *Aggregation      `json:"aggregation,omitempty"`
+	Limit       int               `json:"limit,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// Aggregation reduces the rows of a saved query to a single value
+type Aggregation struct {
+	Function string `json:"function"`
+	Column   string `json:"column,omitempty"`
+}
+
+// SavedQueryTargetEnum defines what a saved query selects
+type SavedQueryTargetEnum string
+
+const (
+	SavedQueryTargetResults SavedQueryTargetEnum = "results"
+	SavedQueryTargetAgents  SavedQueryTargetEnum = "agents"
+)
+
+// AggregationFunctionEnum defines the aggregation functions
+type AggregationFunctionEnum string
+
+const (
+	AggregationCount AggregationFunctionEnum = "count"
+	AggregationSum   AggregationFunctionEnum = "sum"
+	AggregationAvg   AggregationFunctionEnum = "avg"
+	AggregationMin   AggregationFunctionEnum = "min"
+	AggregationMax   AggregationFunctionEnum = "max"
+)
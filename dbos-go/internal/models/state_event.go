@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// StateEvent is an entry of the event-sourced log of agent and task
+// mutations, holding the state of one agent or task after a mutation
+type StateEvent struct {
+	ID         string `json:"-"`
+	Type       string `json:"type"`
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	// Agent is the agent after the mutation, nil if it was deleted
+	Agent *Agent `json:"agent,omitempty"`
+	// Task is the task after the mutation
+	Task *Task `json:"task,omitempty"`
+	// NextRunAt is when a continuous or group task is next issued, for
+	// events rescheduling one
+	NextRunAt time.Time `json:"next_run_at,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// StateEntityEnum defines the kinds of entities whose state is event-sourced
+type StateEntityEnum string
+
+const (
+	StateEntityAgent StateEntityEnum = "agent"
+	StateEntityTask  StateEntityEnum = "task"
+)
+
+// StateEventEnum defines the mutations recorded in the state event log
+type StateEventEnum string
+
+const (
+	StateEventAgentRegistered StateEventEnum = "agent_registered"
+	StateEventAgentHeartbeat  StateEventEnum = "agent_heartbeat"
+	StateEventAgentMarkedDead StateEventEnum = "agent_marked_dead"
+	StateEventAgentDeleted    StateEventEnum = "agent_deleted"
+	StateEventTaskScheduled   StateEventEnum = "task_scheduled"
+	StateEventTaskUpdated     StateEventEnum = "task_updated"
+	StateEventTaskCancelled   StateEventEnum = "task_cancelled"
+	StateEventTaskRescheduled StateEventEnum = "task_rescheduled"
+	StateEventTaskFinished    StateEventEnum = "task_finished"
+	StateEventTaskLeased      StateEventEnum = "task_leased"
+	StateEventTaskAcked       StateEventEnum = "task_acked"
+	StateEventTaskNacked      StateEventEnum = "task_nacked"
+	StateEventTaskExpired     StateEventEnum = "task_lease_expired"
+	StateEventTaskExtended    StateEventEnum = "task_lease_extended"
+)
+
+// StateRebuild reports a rebuild of agent and task state from the state
+// event log
+type StateRebuild struct {
+	EventsReplayed int64
+	AgentsRebuilt  int
+	TasksRebuilt   int
+	// DivergedAgentIDs and DivergedTaskIDs are the agents and tasks whose
+	// stored state differed from the replayed one
+	DivergedAgentIDs []string
+	DivergedTaskIDs  []string
+}
+
+// SnapshotMarker is the point in time a snapshot export captures: the state
+// event log up to StateEventID and, per agent, the results up to a sequence
+type SnapshotMarker struct {
+	TakenAt time.Time
+	// StateEventID is the last event of the state event log at the marker,
+	// empty if the log was empty
+	StateEventID    string
+	ResultSequences map[string]int64
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// TargetList is a named list of targets, defined by applying a spec, whose
+// targets campaigns of later specs can measure by naming it
+type TargetList struct {
+	Name      string    `json:"name"`
+	Targets   []string  `json:"targets"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
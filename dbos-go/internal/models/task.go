@@ -1,18 +1,95 @@
 package models
 
 import (
+	"fmt"
+	"slices"
 	"time"
 )
 
 // Task represents a scheduled task
 type Task struct {
-	ID          string    `json:"id"`
-	AgentID     string    `json:"agent_id"`
-	ModuleName  string    `json:"module_name"`
-	Payload     []byte    `json:"payload"` // JSON-encoded task payload
-	ScheduledAt time.Time `json:"scheduled_at"`
-	CreatedAt   time.Time `json:"created_at"`
-	Status      string    `json:"status"`
+	ID              string    `json:"id"`
+	AgentID         string    `json:"agent_id"`
+	ModuleName      string    `json:"module_name"`
+	Payload         []byte    `json:"payload"` // JSON-encoded task payload
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	Status          string    `json:"status"`
+	Type            string    `json:"type"`
+	IntervalSeconds int64     `json:"interval_seconds"`
+	ParentID        string    `json:"parent_id,omitempty"`
+	VerificationID  string    `json:"verification_id,omitempty"`
+	CampaignID      string    `json:"campaign_id,omitempty"`
+	// Selector makes the task a group task, run by every live agent whose
+	// labels match it instead of by AgentID
+	Selector map[string]string `json:"selector,omitempty"`
+	// AgentIDs are the agents a group task issued instances to
+	AgentIDs []string `json:"agent_ids,omitempty"`
+	// Deliveries counts how often the task was delivered to its agent, as
+	// tracked by the streams task queue
+	Deliveries int64 `json:"deliveries,omitempty"`
+	// CorrelationID groups the task with other measurements of one
+	// experiment; its instances and results inherit it
+	CorrelationID string `json:"correlation_id,omitempty"`
+	// Placement constrains which agents matching a group task's selector
+	// are issued instances
+	Placement *Placement `json:"placement,omitempty"`
+	// LeasedAt is when the task was last leased by its agent, from which
+	// its execution time is measured once its result is stored or it is
+	// acknowledged
+	LeasedAt *time.Time `json:"leased_at,omitempty"`
+	// DurationMs is how long the task ran, from its lease until its agent
+	// acknowledged it
+	DurationMs float64 `json:"duration_ms,omitempty"`
+	// LeaseTimeoutSeconds overrides the server's lease timeout for the
+	// task, for measurements known to run long
+	LeaseTimeoutSeconds int64 `json:"lease_timeout_seconds,omitempty"`
+	// LeaseExpiresAt is when the current lease of the task ends unless its
+	// agent extends it
+	LeaseExpiresAt *time.Time `json:"lease_expires_at,omitempty"`
+	// LeaseExtendedAt is when its agent last extended the current lease
+	LeaseExtendedAt *time.Time `json:"lease_extended_at,omitempty"`
+	// RetryPolicy spaces out the retries of the task; without one a retry
+	// is due as soon as it is requeued
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+	// RetryCount counts how often the task was handed back by its agent or
+	// its lease expired, and it was requeued
+	RetryCount int64 `json:"retry_count,omitempty"`
+	// BroadcastID is the broadcast that fanned the task out
+	BroadcastID string `json:"broadcast_id,omitempty"`
+	// FailoverSelector holds the labels an agent must have to take over
+	// the continuous task when its agent dies
+	FailoverSelector map[string]string `json:"failover_selector,omitempty"`
+}
+
+// TimeOf returns the time an optional time such as Task.LeasedAt points to,
+// or the zero time if it is unset
+func TimeOf(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// OptionalTime returns t as an optional time, unset if t is zero
+func OptionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// PendingTask is a task delivered to an agent through the streams task
+// queue and not yet settled
+type PendingTask struct {
+	TaskID  string
+	EntryID string
+	// Consumer holds the delivery: the agent, or the expired or waiting
+	// consumer of the queue
+	Consumer string
+	// Idle is how long ago the entry was last delivered or claimed
+	Idle       time.Duration
+	Deliveries int64
 }
 
 // NewTask creates a new task instance
@@ -25,7 +102,43 @@ func NewTask(id, agentID, moduleName string, payload []byte, scheduledAt time.Ti
 		ScheduledAt: scheduledAt,
 		CreatedAt:   time.Now(),
 		Status:      "pending",
+		Type:        string(TaskTypeOneShot),
+	}
+}
+
+// IsContinuous reports whether the task is re-issued on an interval until cancelled
+func (t *Task) IsContinuous() bool {
+	return t.Type == string(TaskTypeContinuous)
+}
+
+// IsGroup reports whether the task targets the agents matching a selector
+// rather than one agent
+func (t *Task) IsGroup() bool {
+	return len(t.Selector) > 0
+}
+
+// GroupInstanceID returns the ID of the instance of a group task issued to
+// an agent. Continuous group tasks issue an instance per agent each time
+// they are re-issued, at issuedAt.
+func (t *Task) GroupInstanceID(agentID string, issuedAt time.Time) string {
+	if t.IsContinuous() {
+		return fmt.Sprintf("%s-%s-%d", t.ID, agentID, issuedAt.Unix())
 	}
+	return fmt.Sprintf("%s-%s", t.ID, agentID)
+}
+
+// IsFinished reports whether the task has completed, failed or been cancelled
+func (t *Task) IsFinished() bool {
+	switch TaskStatusEnum(t.Status) {
+	case TaskStatusCompleted, TaskStatusFailed, TaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Interval returns the re-issue interval of a continuous task
+func (t *Task) Interval() time.Duration {
+	return time.Duration(t.IntervalSeconds) * time.Second
 }
 
 // TaskStatusEnum defines the possible statuses for a task
@@ -36,4 +149,66 @@ const (
 	TaskStatusRunning   TaskStatusEnum = "running"
 	TaskStatusCompleted TaskStatusEnum = "completed"
 	TaskStatusFailed    TaskStatusEnum = "failed"
+	TaskStatusCancelled TaskStatusEnum = "cancelled"
+)
+
+// TaskStatuses are the statuses tasks can have
+var TaskStatuses = []TaskStatusEnum{TaskStatusPending, TaskStatusRunning, TaskStatusCompleted, TaskStatusFailed, TaskStatusCancelled}
+
+// IsTaskStatus reports whether a status is one tasks can have
+func IsTaskStatus(status string) bool {
+	return slices.Contains(TaskStatuses, TaskStatusEnum(status))
+}
+
+// TaskFilter selects tasks to list; zero fields match all. Times are
+// matched from Since up to, excluding, Until.
+type TaskFilter struct {
+	AgentID        string
+	ModuleName     string
+	Status         string
+	CreatedSince   time.Time
+	CreatedUntil   time.Time
+	ScheduledSince time.Time
+	ScheduledUntil time.Time
+}
+
+// Matches reports whether a task passes the filter
+func (f TaskFilter) Matches(task *Task) bool {
+	if f.AgentID != "" && task.AgentID != f.AgentID {
+		return false
+	}
+	if f.ModuleName != "" && task.ModuleName != f.ModuleName {
+		return false
+	}
+	if f.Status != "" && task.Status != f.Status {
+		return false
+	}
+	return inTimeRange(task.CreatedAt, f.CreatedSince, f.CreatedUntil) &&
+		inTimeRange(task.ScheduledAt, f.ScheduledSince, f.ScheduledUntil)
+}
+
+// TimeRange returns the bounds of the filter on a field of
+// TaskOrderFields
+func (f TaskFilter) TimeRange(field string) (since, until time.Time) {
+	if field == TaskOrderCreatedAt {
+		return f.CreatedSince, f.CreatedUntil
+	}
+	return f.ScheduledSince, f.ScheduledUntil
+}
+
+// inTimeRange reports whether t is in [since, until), either bound zero
+// leaving that side open
+func inTimeRange(t, since, until time.Time) bool {
+	if !since.IsZero() && t.Before(since) {
+		return false
+	}
+	return until.IsZero() || t.Before(until)
+}
+
+// TaskTypeEnum defines the possible types for a task
+type TaskTypeEnum string
+
+const (
+	TaskTypeOneShot    TaskTypeEnum = "oneshot"
+	TaskTypeContinuous TaskTypeEnum = "continuous"
 )
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TaskProgress is how far an agent got with a running task, as it last
+// reported. Data holds the JSON-encoded partial results of the task, such
+// as the hops of a traceroute found so far.
+type TaskProgress struct {
+	TaskID     string          `json:"task_id"`
+	AgentID    string          `json:"agent_id"`
+	Percent    float64         `json:"percent"`
+	Message    string          `json:"message,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	ReportedAt time.Time       `json:"reported_at"`
+}
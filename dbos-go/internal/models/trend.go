@@ -0,0 +1,22 @@
+package models
+
+import (
+	"github.com/internet-measurement-network/dbos/pkg/tdigest"
+)
+
+// TrendDay is the distribution of one metric for an agent and target over a
+// UTC day (formatted with ViewDayFormat)
+type TrendDay struct {
+	Day    string
+	Digest *tdigest.TDigest
+}
+
+// TrendSeries identifies the values of one metric measured by an agent against a target
+type TrendSeries struct {
+	Metric  string
+	AgentID string
+	Target  string
+}
+
+// DefaultTrendQuantiles are reported when a trend query names none
+var DefaultTrendQuantiles = []float64{0.5, 0.9, 0.99}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// UsageRetention is how long per-minute API usage counts are kept, which
+// bounds the window usage can be queried over
+const UsageRetention = 24 * time.Hour
+
+// Kinds of principals API usage is accounted to
+const (
+	UsagePrincipalAgent     = "agent"
+	UsagePrincipalKey       = "key"
+	UsagePrincipalAnonymous = "anonymous"
+)
+
+// UsageKey identifies what API usage is counted for: the RPCs of one method
+// made by an agent or API key
+type UsageKey struct {
+	Kind      string
+	Principal string
+	Method    string
+}
+
+// Usage counts RPCs and the bytes of their messages
+type Usage struct {
+	Calls    int64
+	Errors   int64
+	BytesIn  int64
+	BytesOut int64
+}
+
+// Add adds the counts of other
+func (u *Usage) Add(other Usage) {
+	u.Calls += other.Calls
+	u.Errors += other.Errors
+	u.BytesIn += other.BytesIn
+	u.BytesOut += other.BytesOut
+}
+
+// ErrorRate returns the share of calls that failed
+func (u *Usage) ErrorRate() float64 {
+	if u.Calls == 0 {
+		return 0
+	}
+	return float64(u.Errors) / float64(u.Calls)
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Verification represents one logical measurement scheduled redundantly on
+// independent agents; it is verified only when their results agree
+type Verification struct {
+	ID           string            `json:"id"`
+	ModuleName   string            `json:"module_name"`
+	Payload      []byte            `json:"payload"`
+	Replicas     int32             `json:"replicas"`
+	CompareField string            `json:"compare_field"`
+	Tolerance    float64           `json:"tolerance"`
+	AgentIDs     []string          `json:"agent_ids"`
+	TaskIDs      []string          `json:"task_ids"`
+	Values       map[string]string `json:"values"`
+	Status       string            `json:"status"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// NewVerification creates a new verification instance
+func NewVerification(id, moduleName string, payload []byte, replicas int32, compareField string, tolerance float64) *Verification {
+	return &Verification{
+		ID:           id,
+		ModuleName:   moduleName,
+		Payload:      payload,
+		Replicas:     replicas,
+		CompareField: compareField,
+		Tolerance:    tolerance,
+		Values:       make(map[string]string),
+		Status:       string(VerificationStatusPending),
+		CreatedAt:    time.Now(),
+	}
+}
+
+// ReplicaTaskID returns the ID of the task for the i-th replica
+func (v *Verification) ReplicaTaskID(i int) string {
+	return fmt.Sprintf("%s-%d", v.ID, i)
+}
+
+// Evaluate updates the status once every replica has reported: numeric values
+// agree if their spread is within the tolerance, other values must be equal
+func (v *Verification) Evaluate() {
+	if len(v.Values) < int(v.Replicas) {
+		v.Status = string(VerificationStatusPending)
+		return
+	}
+
+	numeric := true
+	lo, hi := math.Inf(1), math.Inf(-1)
+	var first string
+	equal := true
+	for _, raw := range v.Values {
+		if first == "" {
+			first = raw
+		} else if raw != first {
+			equal = false
+		}
+
+		var f float64
+		if err := json.Unmarshal([]byte(raw), &f); err != nil {
+			numeric = false
+			continue
+		}
+		lo = math.Min(lo, f)
+		hi = math.Max(hi, f)
+	}
+
+	agree := equal
+	if numeric {
+		agree = hi-lo <= v.Tolerance
+	}
+	if agree {
+		v.Status = string(VerificationStatusVerified)
+	} else {
+		v.Status = string(VerificationStatusDisagreed)
+	}
+}
+
+// VerificationStatusEnum defines the possible statuses for a verification
+type VerificationStatusEnum string
+
+const (
+	VerificationStatusPending   VerificationStatusEnum = "pending"
+	VerificationStatusVerified  VerificationStatusEnum = "verified"
+	VerificationStatusDisagreed VerificationStatusEnum = "disagreed"
+)
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// View defines a materialized view over measurement results, maintained
+// incrementally as results are ingested
+type View struct {
+	Name       string    `json:"name"`
+	Kind       string    `json:"kind"`
+	ModuleName string    `json:"module_name,omitempty"`
+	KeyField   string    `json:"key_field,omitempty"`
+	ValueField string    `json:"value_field,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewView creates a new view definition
+func NewView(name, kind, moduleName, keyField, valueField string) *View {
+	return &View{
+		Name:       name,
+		Kind:       kind,
+		ModuleName: moduleName,
+		KeyField:   keyField,
+		ValueField: valueField,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Matches reports whether a result feeds this view
+func (v *View) Matches(result *MeasurementResult) bool {
+	return v.ModuleName == "" || v.ModuleName == result.ModuleName
+}
+
+// ViewRow is one row of a materialized view, keyed by agent and the value of
+// the view's key field. Latest views fill the result fields, daily views the
+// aggregate fields.
+type ViewRow struct {
+	AgentID   string    `json:"agent_id"`
+	Key       string    `json:"key"`
+	Day       string    `json:"day,omitempty"`
+	ResultID  string    `json:"result_id,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	Data      []byte    `json:"data,omitempty"`
+	Count     int64     `json:"count,omitempty"`
+	Sum       float64   `json:"sum,omitempty"`
+	Min       float64   `json:"min,omitempty"`
+	Max       float64   `json:"max,omitempty"`
+}
+
+// Avg returns the mean of the aggregated values
+func (r *ViewRow) Avg() float64 {
+	if r.Count == 0 {
+		return 0
+	}
+	return r.Sum / float64(r.Count)
+}
+
+// ViewDayFormat is the layout of the day a daily view row aggregates (UTC)
+const ViewDayFormat = "2006-01-02"
+
+// ViewKindEnum defines the kinds of materialized view
+type ViewKindEnum string
+
+const (
+	// ViewKindLatest keeps the most recent result per agent and key
+	ViewKindLatest ViewKindEnum = "latest"
+	// ViewKindDaily aggregates a numeric field per day, agent and key
+	ViewKindDaily ViewKindEnum = "daily"
+)
@@ -0,0 +1,434 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// alertSample is the value of one series of an alert rule's expression
+type alertSample struct {
+	agentID string
+	key     string
+	value   float64
+}
+
+// alertNotification is the webhook payload sent when a series of an alert
+// rule fires or resolves
+type alertNotification struct {
+	Status      string    `json:"status"` // "firing" or "resolved"
+	Rule        string    `json:"rule"`
+	Expr        string    `json:"expr"`
+	Severity    string    `json:"severity"`
+	Description string    `json:"description,omitempty"`
+	Series      string    `json:"series"`
+	AgentID     string    `json:"agent_id,omitempty"`
+	Key         string    `json:"key,omitempty"`
+	Value       float64   `json:"value"`
+	ActiveSince time.Time `json:"active_since"`
+	IncidentID  string    `json:"incident_id,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// CreateAlertRule stores a new alert rule over a daily view or an
+// aggregating saved query
+func (s *Server) CreateAlertRule(ctx context.Context, req *api.CreateAlertRuleRequest) (*api.CreateAlertRuleResponse, error) {
+	rule := &models.AlertRule{
+		Name:        req.Rule.Name,
+		Expr:        req.Rule.Expr,
+		ForSeconds:  req.Rule.ForSeconds,
+		Severity:    req.Rule.Severity,
+		Description: req.Rule.Description,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.checkAlertExprSource(ctx, rule.Expr); err != nil {
+		return &api.CreateAlertRuleResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	if err := s.alertRuleStore.CreateRule(ctx, rule); err != nil {
+		return &api.CreateAlertRuleResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.CreateAlertRuleResponse{
+		Success: true,
+	}, nil
+}
+
+// ListAlertRules retrieves the alert rules with the series they hold for
+func (s *Server) ListAlertRules(ctx context.Context, req *api.ListAlertRulesRequest) (*api.ListAlertRulesResponse, error) {
+	rules, err := s.alertRuleStore.ListRules(ctx)
+	if err != nil {
+		return &api.ListAlertRulesResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiRules := make([]*api.AlertRule, len(rules))
+	for i, rule := range rules {
+		states, err := s.alertRuleStore.ListSeries(ctx, rule.Name)
+		if err != nil {
+			return &api.ListAlertRulesResponse{
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+		apiRules[i] = alertRuleToAPI(rule, states)
+	}
+
+	return &api.ListAlertRulesResponse{
+		Rules: apiRules,
+	}, nil
+}
+
+// DeleteAlertRule removes an alert rule, resolving the incidents of its
+// firing series
+func (s *Server) DeleteAlertRule(ctx context.Context, req *api.DeleteAlertRuleRequest) (*api.DeleteAlertRuleResponse, error) {
+	states, err := s.alertRuleStore.ListSeries(ctx, req.Name)
+	if err == nil {
+		err = s.alertRuleStore.DeleteRule(ctx, req.Name)
+	}
+	if err != nil {
+		return &api.DeleteAlertRuleResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	for _, state := range states {
+		if !state.Firing {
+			continue
+		}
+		if err := s.resolveIncident(ctx, alertIncidentKey(req.Name, state.Series)); err != nil {
+			log.Printf("Alert rule %s: resolving %s: %v", req.Name, state.Series, err)
+		}
+	}
+
+	return &api.DeleteAlertRuleResponse{
+		Success: true,
+	}, nil
+}
+
+// checkAlertExprSource checks that an expression's daily view or
+// aggregating saved query exists
+func (s *Server) checkAlertExprSource(ctx context.Context, expr string) error {
+	parsed, err := models.ParseAlertExpr(expr)
+	if err != nil {
+		return err
+	}
+
+	if parsed.View != "" {
+		view, err := s.viewStore.GetView(ctx, parsed.View)
+		if err != nil {
+			return err
+		}
+		if models.ViewKindEnum(view.Kind) != models.ViewKindDaily {
+			return dberrors.New(dberrors.InvalidArgument, "view %s is not a daily view", parsed.View)
+		}
+		return nil
+	}
+
+	query, err := s.savedQueryStore.GetSavedQuery(ctx, parsed.Query)
+	if err != nil {
+		return err
+	}
+	if query.Aggregation == nil {
+		return dberrors.New(dberrors.InvalidArgument, "saved query %s has no aggregation", parsed.Query)
+	}
+	return nil
+}
+
+// runAlertEvaluator evaluates every alert rule each interval
+func (s *Server) runAlertEvaluator(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			rules, err := s.alertRuleStore.ListRules(ctx)
+			if err != nil {
+				log.Printf("Alert rules: %v", err)
+				continue
+			}
+			for _, rule := range rules {
+				if err := s.evaluateAlertRule(ctx, rule, now); err != nil {
+					log.Printf("Alert rule %s: %v", rule.Name, err)
+				}
+			}
+		}
+	}
+}
+
+// evaluateAlertRule advances the series of a rule: series the expression
+// newly holds for become pending, pending series that have held for the
+// rule's duration fire, and series it no longer holds for are dropped,
+// resolving them if they fired
+func (s *Server) evaluateAlertRule(ctx context.Context, rule *models.AlertRule, now time.Time) error {
+	expr, err := models.ParseAlertExpr(rule.Expr)
+	if err != nil {
+		return err
+	}
+	samples, err := s.alertSamples(ctx, expr, now)
+	if err != nil {
+		return err
+	}
+	states, err := s.alertRuleStore.ListSeries(ctx, rule.Name)
+	if err != nil {
+		return err
+	}
+
+	active := make(map[string]*models.AlertSeriesState, len(states))
+	for _, state := range states {
+		active[state.Series] = state
+	}
+
+	for series, sample := range samples {
+		if !expr.Holds(sample.value) {
+			continue
+		}
+
+		state := active[series]
+		if state == nil {
+			state = &models.AlertSeriesState{
+				Series:      series,
+				AgentID:     sample.agentID,
+				Key:         sample.key,
+				ActiveSince: now,
+			}
+		}
+		delete(active, series)
+		state.Value = sample.value
+
+		// A series covered by maintenance stays pending until the window ends
+		fired := false
+		if !state.Firing && now.Sub(state.ActiveSince) >= rule.For() &&
+			s.alertSuppressed(ctx, state.AgentID, alertRuleTarget(state), now) == nil {
+			state.Firing, state.FiredAt = true, now
+			fired = true
+		}
+		if state.Firing {
+			if err := s.raiseAlertRuleIncident(ctx, rule, state, fired); err != nil {
+				return err
+			}
+		}
+		if err := s.alertRuleStore.SaveSeries(ctx, rule.Name, state); err != nil {
+			return err
+		}
+	}
+
+	// Series left over no longer hold, or have no rollup any more
+	for series, state := range active {
+		if sample, ok := samples[series]; ok {
+			state.Value = sample.value
+		}
+		if state.Firing {
+			if err := s.resolveAlertRuleIncident(ctx, rule, state, now); err != nil {
+				return err
+			}
+		}
+		if err := s.alertRuleStore.DeleteSeries(ctx, rule.Name, series); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// alertSamples evaluates the metric of an expression: one sample per agent
+// and key of today's rows of a daily view, or the single aggregate of a
+// saved query
+func (s *Server) alertSamples(ctx context.Context, expr *models.AlertExpr, now time.Time) (map[string]alertSample, error) {
+	samples := make(map[string]alertSample)
+
+	if expr.View != "" {
+		rows, err := s.viewStore.QueryView(ctx, expr.View, "", "", now.UTC().Format(models.ViewDayFormat))
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			var value float64
+			switch expr.Function {
+			case models.AggregationCount:
+				value = float64(row.Count)
+			case models.AggregationSum:
+				value = row.Sum
+			case models.AggregationAvg:
+				value = row.Avg()
+			case models.AggregationMin:
+				value = row.Min
+			case models.AggregationMax:
+				value = row.Max
+			}
+			samples[row.AgentID+"|"+row.Key] = alertSample{agentID: row.AgentID, key: row.Key, value: value}
+		}
+		return samples, nil
+	}
+
+	resp, _ := s.ExecuteSavedQuery(ctx, &api.ExecuteSavedQueryRequest{Name: expr.Query})
+	if resp.Error != "" {
+		return nil, fmt.Errorf("saved query %s: %s", expr.Query, resp.Error)
+	}
+	if !resp.Aggregated {
+		return nil, fmt.Errorf("saved query %s has no aggregation", expr.Query)
+	}
+	samples[expr.Query] = alertSample{value: resp.Value}
+	return samples, nil
+}
+
+// raiseAlertRuleIncident records an evaluation of a firing series in its
+// incident. When the series has just fired it also raises an alert and
+// sends a notification.
+func (s *Server) raiseAlertRuleIncident(ctx context.Context, rule *models.AlertRule, state *models.AlertSeriesState, fired bool) error {
+	key := alertIncidentKey(rule.Name, state.Series)
+	target := alertRuleTarget(state)
+	var region string
+	if state.AgentID != "" {
+		region = s.agentRegionLabel(ctx, state.AgentID)
+	}
+
+	value := strconv.FormatFloat(state.Value, 'g', -1, 64)
+	reason := fmt.Sprintf("%s: %s with value %s", rule.Name, rule.Expr, value)
+	evidence := map[string]string{
+		"rule":     rule.Name,
+		"expr":     rule.Expr,
+		"severity": rule.Severity,
+		"value":    value,
+	}
+	if err := s.raiseIncident(ctx, models.IncidentTypeAlertRule, key, region, target, state.AgentID, "", reason, evidence); err != nil {
+		return err
+	}
+	if !fired {
+		return nil
+	}
+
+	incident, err := s.incidentStore.GetOpenIncident(ctx, key)
+	if err != nil {
+		return err
+	}
+	alert := &models.Alert{
+		Type:    string(models.AlertTypeRule),
+		AgentID: state.AgentID,
+		Target:  target,
+		Reason:  reason,
+		Details: evidence,
+	}
+	if incident != nil {
+		alert.Details["incident_id"] = incident.ID
+	}
+	if err := s.raiseAlert(ctx, alert); err != nil {
+		return err
+	}
+	if incident != nil {
+		incident.LinkAlerts(alert.ID)
+		if err := s.incidentStore.SaveIncident(ctx, incident); err != nil {
+			return err
+		}
+	}
+
+	s.notifyAlertRule(ctx, "firing", rule, state, alert.Details["incident_id"], state.FiredAt)
+	return nil
+}
+
+// resolveAlertRuleIncident resolves the incident of a series that stopped
+// firing and sends a notification
+func (s *Server) resolveAlertRuleIncident(ctx context.Context, rule *models.AlertRule, state *models.AlertSeriesState, now time.Time) error {
+	incident, err := s.incidentStore.ResolveIncident(ctx, alertIncidentKey(rule.Name, state.Series), now)
+	if err != nil {
+		return err
+	}
+
+	var incidentID string
+	if incident != nil {
+		incidentID = incident.ID
+		log.Printf("Incident %s resolved after %d occurrences", incident.ID, incident.Occurrences)
+		s.emitIncidentEvent(ctx, models.IncidentEventResolved, incident)
+	}
+	s.notifyAlertRule(ctx, "resolved", rule, state, incidentID, now)
+	return nil
+}
+
+// notifyAlertRule posts a firing or resolved notification to the alert
+// webhook, if one is configured
+func (s *Server) notifyAlertRule(ctx context.Context, status string, rule *models.AlertRule, state *models.AlertSeriesState, incidentID string, at time.Time) {
+	if s.alertWebhook == nil {
+		return
+	}
+
+	err := s.alertWebhook.Post(ctx, &alertNotification{
+		Status:      status,
+		Rule:        rule.Name,
+		Expr:        rule.Expr,
+		Severity:    rule.Severity,
+		Description: rule.Description,
+		Series:      state.Series,
+		AgentID:     state.AgentID,
+		Key:         state.Key,
+		Value:       state.Value,
+		ActiveSince: state.ActiveSince,
+		IncidentID:  incidentID,
+		At:          at,
+	})
+	if err != nil {
+		log.Printf("Alert rule %s: notifying %s of %s: %v", rule.Name, status, state.Series, err)
+	}
+}
+
+// alertIncidentKey identifies the incident of a series of an alert rule
+func alertIncidentKey(rule, series string) string {
+	return "alert_rule:" + rule + ":" + series
+}
+
+// alertRuleTarget returns the target of a series: its view row key, or the
+// series itself for saved query rules
+func alertRuleTarget(state *models.AlertSeriesState) string {
+	if state.Key != "" {
+		return state.Key
+	}
+	return state.Series
+}
+
+// alertRuleToAPI converts an alert rule and its active series to their API
+// representation
+func alertRuleToAPI(rule *models.AlertRule, states []*models.AlertSeriesState) *api.AlertRule {
+	r := &api.AlertRule{
+		Name:        rule.Name,
+		Expr:        rule.Expr,
+		ForSeconds:  rule.ForSeconds,
+		Severity:    rule.Severity,
+		Description: rule.Description,
+		CreatedAt:   rule.CreatedAt.Unix(),
+	}
+	for _, state := range states {
+		var firedAt int64
+		if state.Firing {
+			firedAt = state.FiredAt.Unix()
+		}
+		r.Series = append(r.Series, &api.AlertSeries{
+			Series:      state.Series,
+			AgentId:     state.AgentID,
+			Key:         state.Key,
+			Value:       state.Value,
+			ActiveSince: state.ActiveSince.Unix(),
+			Firing:      state.Firing,
+			FiredAt:     firedAt,
+		})
+	}
+	return r
+}
@@ -0,0 +1,689 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	apiv2 "github.com/internet-measurement-network/dbos/api/v2"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultV2PageSize is the page size of v2 list methods when none is given
+	defaultV2PageSize = 100
+	// maxV2PageSize caps the page size of v2 list methods
+	maxV2PageSize = 1000
+)
+
+// v2Server serves the v2 API next to the v1 one, over the same stores, so
+// clients of either version see the same agents, tasks and results while
+// they migrate. Writes with side effects beyond storage go through the v1
+// handlers, so ingest hooks and validation are shared.
+type v2Server struct {
+	apiv2.UnimplementedDBOSServer
+	s *Server
+}
+
+// GetAgent retrieves an agent
+func (v *v2Server) GetAgent(ctx context.Context, req *apiv2.GetAgentRequest) (*apiv2.Agent, error) {
+	agentID, err := parseAgentName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := newV2ReadMask(&apiv2.Agent{}, req.ReadMask)
+	if err != nil {
+		return nil, err
+	}
+	agent, err := v.s.agentStore.GetAgent(ctx, agentID)
+	if err != nil {
+		return nil, storeStatus(err, "agent %s", agentID)
+	}
+
+	windows, _ := v.s.maintenanceStore.ListActiveWindows(ctx, time.Now())
+	v2Agent := agentToV2(agent, windows)
+	mask.apply(v2Agent)
+	return v2Agent, nil
+}
+
+// ListAgents retrieves a page of agents
+func (v *v2Server) ListAgents(ctx context.Context, req *apiv2.ListAgentsRequest) (*apiv2.ListAgentsResponse, error) {
+	pageSize := v2PageSize(req.PageSize)
+	cursor, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := newV2ReadMask(&apiv2.Agent{}, req.ReadMask)
+	if err != nil {
+		return nil, err
+	}
+	order, err := parseOrderBy(req.OrderBy, v2AgentOrderBy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var agents []*models.Agent
+	var nextCursor string
+	if order.Field != "" {
+		agents, nextCursor, err = v.s.agentStore.ListAgentsOrdered(ctx, order, cursor, pageSize)
+	} else {
+		agents, nextCursor, err = v.s.agentStore.ListAgentsPage(ctx, cursor, pageSize)
+	}
+	if err != nil {
+		return nil, storeStatus(err, "agents")
+	}
+	windows, err := v.s.maintenanceStore.ListActiveWindows(ctx, time.Now())
+	if err != nil {
+		return nil, storeStatus(err, "maintenance windows")
+	}
+
+	v2Agents := make([]*apiv2.Agent, len(agents))
+	for i, agent := range agents {
+		v2Agents[i] = agentToV2(agent, windows)
+		mask.apply(v2Agents[i])
+	}
+	return &apiv2.ListAgentsResponse{
+		Agents:        v2Agents,
+		NextPageToken: encodePageToken(nextCursor),
+	}, nil
+}
+
+// CreateAgent registers a new agent
+func (v *v2Server) CreateAgent(ctx context.Context, req *apiv2.CreateAgentRequest) (*apiv2.Agent, error) {
+	if _, err := v.s.agentStore.GetAgent(ctx, req.AgentId); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "agent %s already exists", req.AgentId)
+	} else if !redis.IsNotFound(err) {
+		return nil, storeStatus(err, "agent %s", req.AgentId)
+	}
+
+	agent := models.NewAgent(req.AgentId, req.Agent.Hostname)
+	if req.Agent.Labels != nil {
+		agent.Labels = req.Agent.Labels
+	}
+	if req.Agent.Config != nil {
+		agent.Config = req.Agent.Config
+	}
+	if err := v.s.agentStore.RegisterAgent(ctx, agent); err != nil {
+		return nil, storeStatus(err, "agent %s", agent.ID)
+	}
+	v.s.recordAgentEvent(ctx, models.EventAgentRegistered, models.EventSeverityInfo, agent.ID, agent)
+	return v.GetAgent(ctx, &apiv2.GetAgentRequest{Name: agentName(agent.ID)})
+}
+
+// UpdateAgent updates the fields of an agent its update mask names
+func (v *v2Server) UpdateAgent(ctx context.Context, req *apiv2.UpdateAgentRequest) (*apiv2.Agent, error) {
+	agentID, err := parseAgentName(req.Agent.Name)
+	if err != nil {
+		return nil, err
+	}
+	agent, err := v.s.agentStore.GetAgent(ctx, agentID)
+	if err != nil {
+		return nil, storeStatus(err, "agent %s", agentID)
+	}
+
+	config, version := maps.Clone(agent.Config), agent.Labels[models.AgentVersionLabel]
+	if err := applyAgentMask(agent, req.Agent, req.UpdateMask.GetPaths()); err != nil {
+		return nil, err
+	}
+	if !maps.Equal(config, agent.Config) || version != agent.Labels[models.AgentVersionLabel] {
+		if err := v.s.validateAgentConfig(ctx, agent); err != nil {
+			return nil, storeStatus(err, "agent %s", agentID)
+		}
+	}
+	if err := v.s.agentStore.RegisterAgent(ctx, agent); err != nil {
+		return nil, storeStatus(err, "agent %s", agentID)
+	}
+	v.s.recordAgentEvent(ctx, models.EventAgentUpdated, models.EventSeverityInfo, agentID, agent)
+	return v.GetAgent(ctx, &apiv2.GetAgentRequest{Name: req.Agent.Name})
+}
+
+// DeleteAgent removes an agent
+func (v *v2Server) DeleteAgent(ctx context.Context, req *apiv2.DeleteAgentRequest) (*emptypb.Empty, error) {
+	agentID, err := parseAgentName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := v.s.agentStore.GetAgent(ctx, agentID); err != nil {
+		return nil, storeStatus(err, "agent %s", agentID)
+	}
+	if err := v.s.agentStore.DeleteAgent(ctx, agentID); err != nil {
+		return nil, storeStatus(err, "agent %s", agentID)
+	}
+	v.s.recordAgentEvent(ctx, models.EventAgentDeleted, models.EventSeverityInfo, agentID, nil)
+	return &emptypb.Empty{}, nil
+}
+
+// Heartbeat marks an agent alive, registering it if unknown
+func (v *v2Server) Heartbeat(ctx context.Context, req *apiv2.HeartbeatRequest) (*apiv2.Agent, error) {
+	agentID, err := parseAgentName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	agent, err := v.s.agentStore.RecordHeartbeat(ctx, agentID, req.Hostname, time.Now())
+	if err != nil {
+		return nil, storeStatus(err, "agent %s", agentID)
+	}
+
+	windows, _ := v.s.maintenanceStore.ListActiveWindows(ctx, time.Now())
+	return agentToV2(agent, windows), nil
+}
+
+// CreateTask schedules a task through the v1 ScheduleTask handler
+func (v *v2Server) CreateTask(ctx context.Context, req *apiv2.CreateTaskRequest) (*apiv2.Task, error) {
+	var agentID string
+	var err error
+	if len(req.Task.Selector) == 0 || req.Task.Agent != "" {
+		if agentID, err = parseAgentName(req.Task.Agent); err != nil {
+			return nil, err
+		}
+	}
+	if agentID != "" && len(req.Task.Selector) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "a task targets either an agent or a selector, not both")
+	}
+	continuous := req.Task.Type == apiv2.Task_CONTINUOUS
+	if continuous && req.Task.Interval.AsDuration() < time.Second {
+		return nil, status.Error(codes.InvalidArgument, "continuous tasks require an interval of at least a second")
+	}
+
+	taskID := req.TaskId
+	if taskID == "" {
+		taskID = ids.New("task-")
+	}
+	if _, err := v.s.taskStore.GetTask(ctx, taskID); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "task %s already exists", taskID)
+	} else if !redis.IsNotFound(err) {
+		return nil, storeStatus(err, "task %s", taskID)
+	}
+
+	now := time.Now()
+	scheduledAt := now
+	if req.Task.ScheduleTime != nil {
+		scheduledAt = req.Task.ScheduleTime.AsTime()
+	}
+	task := &api.Task{
+		Id:          taskID,
+		AgentId:     agentID,
+		ModuleName:  req.Task.ModuleName,
+		Payload:     req.Task.Payload,
+		ScheduledAt: scheduledAt.Unix(),
+		CreatedAt:   now.Unix(),
+		Status:      string(models.TaskStatusPending),
+		Type:        string(models.TaskTypeOneShot),
+		Selector:    req.Task.Selector,
+	}
+	if continuous {
+		task.Type = string(models.TaskTypeContinuous)
+		task.IntervalSeconds = int64(req.Task.Interval.AsDuration() / time.Second)
+	}
+	resp, _ := v.s.ScheduleTask(ctx, &api.ScheduleTaskRequest{Task: task})
+	if !resp.Success {
+		return nil, dberrors.Status(dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error))
+	}
+	if resp.AlreadyExists {
+		return nil, status.Errorf(codes.AlreadyExists, "task %s already exists", taskID)
+	}
+	return v.GetTask(ctx, &apiv2.GetTaskRequest{Name: taskName(taskID)})
+}
+
+// GetTask retrieves a task
+func (v *v2Server) GetTask(ctx context.Context, req *apiv2.GetTaskRequest) (*apiv2.Task, error) {
+	taskID, err := parseTaskName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	task, err := v.s.taskStore.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, storeStatus(err, "task %s", taskID)
+	}
+	return taskToV2(task), nil
+}
+
+// CancelTask cancels a task, returning it in its cancelled state
+func (v *v2Server) CancelTask(ctx context.Context, req *apiv2.CancelTaskRequest) (*apiv2.Task, error) {
+	taskID, err := parseTaskName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.s.taskStore.CancelTask(ctx, taskID); err != nil {
+		return nil, storeStatus(err, "task %s", taskID)
+	}
+	v.s.recordTaskCancelled(ctx, taskID)
+	return v.GetTask(ctx, &apiv2.GetTaskRequest{Name: req.Name})
+}
+
+// CreateResult stores a result through the v1 StoreResult handler, so it is
+// analyzed like any other
+func (v *v2Server) CreateResult(ctx context.Context, req *apiv2.CreateResultRequest) (*apiv2.Result, error) {
+	agentID, err := parseAgentName(req.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.MeasurementResult{
+		Id:         req.ResultId,
+		AgentId:    agentID,
+		ModuleName: req.Result.ModuleName,
+		Data:       req.Result.Data,
+		Timestamp:  time.Now().Unix(),
+	}
+	if req.Result.MeasureTime != nil {
+		result.Timestamp = req.Result.MeasureTime.AsTime().Unix()
+	}
+	if req.Result.AgentTime != nil {
+		result.AgentTimestampMs = req.Result.AgentTime.AsTime().UnixMilli()
+	}
+	if models.IsLocalTaskID(result.Id) {
+		local := &models.MeasurementResult{ID: result.Id, AgentID: agentID, ModuleName: result.ModuleName}
+		if err := v.s.validateLocalResult(ctx, local); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
+	resp, _ := v.s.StoreResult(ctx, &api.StoreResultRequest{Result: result})
+	if !resp.Success {
+		return nil, dberrors.Status(dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error))
+	}
+	return v.GetResult(ctx, &apiv2.GetResultRequest{Name: resultName(agentID, result.Id)})
+}
+
+// GetResult retrieves a result
+func (v *v2Server) GetResult(ctx context.Context, req *apiv2.GetResultRequest) (*apiv2.Result, error) {
+	agentID, resultID, err := parseResultName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := newV2ReadMask(&apiv2.Result{}, req.ReadMask)
+	if err != nil {
+		return nil, err
+	}
+	result, err := v.s.getResult(ctx, agentID, resultID)
+	if err != nil {
+		return nil, storeStatus(err, "result %s of agent %s", resultID, agentID)
+	}
+
+	v2Result := resultToV2(result)
+	mask.apply(v2Result)
+	return v2Result, nil
+}
+
+// ListResults retrieves a page of an agent's results in measurement time
+// order, restricted by the request's filter
+func (v *v2Server) ListResults(ctx context.Context, req *apiv2.ListResultsRequest) (*apiv2.ListResultsResponse, error) {
+	agentID, err := parseAgentName(req.Parent)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := v2PageSize(req.PageSize)
+	cursor, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	rng, err := parseResultFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := newV2ReadMask(&apiv2.Result{}, req.ReadMask)
+	if err != nil {
+		return nil, err
+	}
+	order, err := parseOrderBy(req.OrderBy, v2ResultOrderBy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results, nextCursor, err := v.s.resultStore.ListResultsPage(ctx, agentID, rng, order, cursor, pageSize)
+	if err != nil {
+		return nil, storeStatus(err, "results of agent %s", agentID)
+	}
+
+	v2Results := make([]*apiv2.Result, len(results))
+	for i, result := range results {
+		v2Results[i] = resultToV2(result)
+		mask.apply(v2Results[i])
+	}
+	return &apiv2.ListResultsResponse{
+		Results:       v2Results,
+		NextPageToken: encodePageToken(nextCursor),
+	}, nil
+}
+
+// CountResults counts the results of an agent matching a filter
+func (v *v2Server) CountResults(ctx context.Context, req *apiv2.CountResultsRequest) (*apiv2.CountResultsResponse, error) {
+	agentID, err := parseAgentName(req.Parent)
+	if err != nil {
+		return nil, err
+	}
+	rng, err := parseResultFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := v.s.resultStore.CountResults(ctx, agentID, rng)
+	if err != nil {
+		return nil, storeStatus(err, "results of agent %s", agentID)
+	}
+	return &apiv2.CountResultsResponse{Count: count}, nil
+}
+
+// storeStatus converts a store error into a status with the gRPC code of
+// its error code, naming the resource it concerns
+func storeStatus(err error, format string, args ...interface{}) error {
+	what := fmt.Sprintf(format, args...)
+	code := dberrors.CodeOf(err)
+	if code == dberrors.NotFound {
+		return dberrors.Status(dberrors.New(code, "%s not found", what))
+	}
+	return dberrors.Status(dberrors.New(code, "%s: %v", what, err))
+}
+
+// newV2ReadMask is newReadMask reporting an invalid mask as InvalidArgument
+func newV2ReadMask(returned proto.Message, mask *fieldmaskpb.FieldMask) (readMask, error) {
+	m, err := newReadMask(returned, mask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return m, nil
+}
+
+// v2PageSize returns the page size a v2 list request asks for
+func v2PageSize(size int32) int {
+	switch {
+	case size == 0:
+		return defaultV2PageSize
+	case size > maxV2PageSize:
+		return maxV2PageSize
+	}
+	return int(size)
+}
+
+// encodePageToken wraps a v1 store cursor into an opaque page token
+func encodePageToken(cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+// decodePageToken unwraps the store cursor of a page token
+func decodePageToken(token string) (string, error) {
+	cursor, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid page_token %q", token)
+	}
+	return string(cursor), nil
+}
+
+// agentName returns the resource name of an agent
+func agentName(agentID string) string {
+	return "agents/" + agentID
+}
+
+// taskName returns the resource name of a task
+func taskName(taskID string) string {
+	return "tasks/" + taskID
+}
+
+// resultName returns the resource name of an agent's result
+func resultName(agentID, resultID string) string {
+	return agentName(agentID) + "/results/" + resultID
+}
+
+// parseName returns the IDs in a resource name of the given collections
+func parseName(name string, collections ...string) ([]string, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 2*len(collections) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, want %s", name, namePattern(collections))
+	}
+	ids := make([]string, len(collections))
+	for i, collection := range collections {
+		if parts[2*i] != collection || parts[2*i+1] == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid resource name %q, want %s", name, namePattern(collections))
+		}
+		ids[i] = parts[2*i+1]
+	}
+	return ids, nil
+}
+
+// namePattern describes the resource names of the given collections
+func namePattern(collections []string) string {
+	segments := make([]string, len(collections))
+	for i, collection := range collections {
+		segments[i] = fmt.Sprintf("%s/{%s}", collection, strings.TrimSuffix(collection, "s"))
+	}
+	return strings.Join(segments, "/")
+}
+
+// parseAgentName returns the agent ID of an "agents/{agent}" name
+func parseAgentName(name string) (string, error) {
+	ids, err := parseName(name, "agents")
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// parseTaskName returns the task ID of a "tasks/{task}" name
+func parseTaskName(name string) (string, error) {
+	ids, err := parseName(name, "tasks")
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// parseResultName returns the agent and result IDs of an
+// "agents/{agent}/results/{result}" name
+func parseResultName(name string) (string, string, error) {
+	ids, err := parseName(name, "agents", "results")
+	if err != nil {
+		return "", "", err
+	}
+	return ids[0], ids[1], nil
+}
+
+// applyAgentMask copies the fields of update a field mask names onto an
+// agent. An empty mask names the fields update sets; "*" names them all.
+func applyAgentMask(agent *models.Agent, update *apiv2.Agent, paths []string) error {
+	if len(paths) == 0 {
+		if update.Hostname != "" {
+			paths = append(paths, "hostname")
+		}
+		if update.Labels != nil {
+			paths = append(paths, "labels")
+		}
+		if update.Config != nil {
+			paths = append(paths, "config")
+		}
+	}
+
+	for _, path := range paths {
+		field, key, keyed := strings.Cut(path, ".")
+		switch {
+		case path == "*":
+			agent.Hostname = update.Hostname
+			agent.Labels = copyOrEmpty(update.Labels)
+			agent.Config = copyOrEmpty(update.Config)
+		case path == "hostname":
+			agent.Hostname = update.Hostname
+		case path == "labels":
+			agent.Labels = copyOrEmpty(update.Labels)
+		case path == "config":
+			agent.Config = copyOrEmpty(update.Config)
+		case keyed && key != "" && field == "labels":
+			agent.Labels = updateMapKey(agent.Labels, update.Labels, key)
+		case keyed && key != "" && field == "config":
+			agent.Config = updateMapKey(agent.Config, update.Config, key)
+		default:
+			return status.Errorf(codes.InvalidArgument, "update_mask path %q is not an updatable agent field", path)
+		}
+	}
+	return nil
+}
+
+// copyOrEmpty copies a map, returning an empty one for nil
+func copyOrEmpty(m map[string]string) map[string]string {
+	copied := make(map[string]string, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+// updateMapKey sets key of dst to its value in src, or removes it if src
+// does not have it
+func updateMapKey(dst, src map[string]string, key string) map[string]string {
+	if dst == nil {
+		dst = make(map[string]string)
+	}
+	if value, ok := src[key]; ok {
+		dst[key] = value
+	} else {
+		delete(dst, key)
+	}
+	return dst
+}
+
+// resultFilterTerm matches one restriction of a ListResults filter
+var resultFilterTerm = regexp.MustCompile(`^(\w+)\s*(>=|<=|=|>|<)\s*(?:"([^"]*)"|(\S+))$`)
+
+// parseResultFilter parses a ListResults filter into a result range.
+// Measurement times are compared at second precision.
+func parseResultFilter(filter string) (models.ResultRange, error) {
+	var rng models.ResultRange
+	if strings.TrimSpace(filter) == "" {
+		return rng, nil
+	}
+
+	for _, term := range strings.Split(filter, " AND ") {
+		match := resultFilterTerm.FindStringSubmatch(strings.TrimSpace(term))
+		if match == nil {
+			return rng, status.Errorf(codes.InvalidArgument, "invalid filter term %q", term)
+		}
+		field, op, value := match[1], match[2], match[3]+match[4]
+
+		switch field {
+		case "module_name":
+			if op != "=" {
+				return rng, status.Errorf(codes.InvalidArgument, "module_name only supports =")
+			}
+			rng.ModuleName = value
+		case "measure_time":
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return rng, status.Errorf(codes.InvalidArgument, "invalid measure_time %q, want RFC 3339", value)
+			}
+			t = t.Truncate(time.Second)
+			switch op {
+			case ">=":
+				rng.From = t
+			case ">":
+				rng.From = t.Add(time.Second)
+			case "<":
+				rng.To = t
+			case "<=":
+				rng.To = t.Add(time.Second)
+			default:
+				return rng, status.Errorf(codes.InvalidArgument, "measure_time does not support %s", op)
+			}
+		default:
+			return rng, status.Errorf(codes.InvalidArgument, "cannot filter on %q", field)
+		}
+	}
+	return rng, nil
+}
+
+// v2TaskStates maps task statuses to v2 task states
+var v2TaskStates = map[string]apiv2.Task_State{
+	string(models.TaskStatusPending):   apiv2.Task_PENDING,
+	string(models.TaskStatusRunning):   apiv2.Task_RUNNING,
+	string(models.TaskStatusCompleted): apiv2.Task_COMPLETED,
+	string(models.TaskStatusFailed):    apiv2.Task_FAILED,
+	string(models.TaskStatusCancelled): apiv2.Task_CANCELLED,
+}
+
+// timeToV2 converts a time into a timestamp, the zero time into none
+func timeToV2(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() || t.Unix() == 0 {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// agentToV2 converts a model agent into a v2 one, listing the windows
+// covering it
+func agentToV2(agent *models.Agent, windows []*models.MaintenanceWindow) *apiv2.Agent {
+	return &apiv2.Agent{
+		Name:                 agentName(agent.ID),
+		Hostname:             agent.Hostname,
+		Alive:                agent.Alive,
+		LastSeenTime:         timeToV2(agent.LastSeen),
+		FirstSeenTime:        timeToV2(agent.FirstSeen),
+		Config:               agent.Config,
+		HeartbeatCount:       agent.TotalHeartbeats,
+		Labels:               agent.Labels,
+		MaintenanceWindowIds: agentMaintenanceWindowIDs(windows, agent),
+	}
+}
+
+// taskToV2 converts a model task into a v2 one
+func taskToV2(task *models.Task) *apiv2.Task {
+	v2Task := &apiv2.Task{
+		Name:           taskName(task.ID),
+		Agent:          agentName(task.AgentID),
+		ModuleName:     task.ModuleName,
+		Payload:        task.Payload,
+		ScheduleTime:   timeToV2(task.ScheduledAt),
+		CreateTime:     timeToV2(task.CreatedAt),
+		State:          v2TaskStates[task.Status],
+		Type:           apiv2.Task_ONE_SHOT,
+		VerificationId: task.VerificationID,
+		CampaignId:     task.CampaignID,
+		Selector:       task.Selector,
+	}
+	if task.AgentID == "" {
+		v2Task.Agent = ""
+	}
+	for _, agentID := range task.AgentIDs {
+		v2Task.Agents = append(v2Task.Agents, agentName(agentID))
+	}
+	if task.IsContinuous() {
+		v2Task.Type = apiv2.Task_CONTINUOUS
+		v2Task.Interval = durationpb.New(time.Duration(task.IntervalSeconds) * time.Second)
+	}
+	if task.ParentID != "" {
+		v2Task.Parent = taskName(task.ParentID)
+	}
+	return v2Task
+}
+
+// resultToV2 converts a model result into a v2 one
+func resultToV2(result *models.MeasurementResult) *apiv2.Result {
+	origin := apiv2.Result_SCHEDULED
+	if result.Origin == string(models.ResultOriginLocal) {
+		origin = apiv2.Result_LOCAL
+	}
+	return &apiv2.Result{
+		Name:          resultName(result.AgentID, result.ID),
+		ModuleName:    result.ModuleName,
+		Data:          result.Data,
+		MeasureTime:   timeToV2(result.Timestamp),
+		Origin:        origin,
+		Sequence:      result.Sequence,
+		ClockOffsetMs: result.ClockOffsetMs,
+	}
+}
@@ -0,0 +1,423 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/internal/spec"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// Kinds of the things a spec defines
+const (
+	specKindTargetList = "target_list"
+	specKindCampaign   = "campaign"
+	specKindTask       = "task"
+)
+
+// Actions applying a spec takes on what it defines
+const (
+	specActionCreated   = "created"
+	specActionUpdated   = "updated"
+	specActionUnchanged = "unchanged"
+)
+
+// specStep is the change applying a spec makes to one thing it defines,
+// made by apply unless the change is none. manage, if set, then records
+// the definition applied for drift detection.
+type specStep struct {
+	change *api.SpecChange
+	apply  func(ctx context.Context) error
+	manage func(ctx context.Context) error
+}
+
+// ApplySpec applies a declarative spec of target lists, campaigns and
+// recurring tasks: what it names is created, or updated to match it if it
+// exists, so an edited spec can be applied again. The changes are all
+// planned, and the spec rejected if any is invalid, before any is made.
+func (s *Server) ApplySpec(ctx context.Context, req *api.ApplySpecRequest) (*api.ApplySpecResponse, error) {
+	parsed, err := spec.Parse(req.Spec)
+	if err != nil {
+		return &api.ApplySpecResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+
+	steps, err := s.planSpec(ctx, parsed, time.Now())
+	if err != nil {
+		return &api.ApplySpecResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	changes := make([]*api.SpecChange, len(steps))
+	for i, step := range steps {
+		changes[i] = step.change
+	}
+	if req.DryRun {
+		return &api.ApplySpecResponse{
+			Success: true,
+			Changes: changes,
+		}, nil
+	}
+
+	for i, step := range steps {
+		if step.change.Action != specActionUnchanged {
+			if err := step.apply(ctx); err != nil {
+				// What was applied before stays; applying the spec again
+				// finishes the rest
+				return &api.ApplySpecResponse{
+					Success:   false,
+					Changes:   changes[:i],
+					Error:     err.Error(),
+					ErrorCode: errorCode(err),
+				}, nil
+			}
+			log.Printf("Spec applied: %s %s %s", step.change.Kind, step.change.Name, step.change.Action)
+		}
+		if step.manage != nil {
+			if err := step.manage(ctx); err != nil {
+				return &api.ApplySpecResponse{
+					Success:   false,
+					Changes:   changes[:i+1],
+					Error:     err.Error(),
+					ErrorCode: errorCode(err),
+				}, nil
+			}
+		}
+	}
+
+	return &api.ApplySpecResponse{
+		Success: true,
+		Changes: changes,
+	}, nil
+}
+
+// planSpec plans the changes applying a spec makes: target lists first,
+// whose targets the spec's campaigns measure, then campaigns, then tasks
+func (s *Server) planSpec(ctx context.Context, parsed *spec.Spec, now time.Time) ([]specStep, error) {
+	var steps []specStep
+	lists := make(map[string][]string)
+	for _, list := range parsed.TargetLists {
+		step, err := s.planTargetList(ctx, list, now)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+		lists[list.Name] = list.Targets
+	}
+	for _, campaign := range parsed.Campaigns {
+		step, err := s.planCampaign(ctx, campaign, lists, now)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	for _, task := range parsed.Tasks {
+		step, err := s.planTask(ctx, task, now)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// planTargetList plans storing a target list
+func (s *Server) planTargetList(ctx context.Context, definition spec.TargetList, now time.Time) (specStep, error) {
+	existing, err := s.targetListStore.GetTargetList(ctx, definition.Name)
+	if err != nil {
+		return specStep{}, err
+	}
+
+	list := &models.TargetList{
+		Name:      definition.Name,
+		Targets:   definition.Targets,
+		UpdatedAt: now,
+	}
+	var change *api.SpecChange
+	switch {
+	case existing == nil:
+		change = newSpecChange(specKindTargetList, list.Name, specActionCreated, nil)
+	case !slices.Equal(existing.Targets, list.Targets):
+		change = newSpecChange(specKindTargetList, list.Name, specActionUpdated, []string{"targets"})
+	default:
+		change = newSpecChange(specKindTargetList, list.Name, specActionUnchanged, nil)
+	}
+	return specStep{
+		change: change,
+		apply: func(ctx context.Context) error {
+			return s.targetListStore.SaveTargetList(ctx, list)
+		},
+	}, nil
+}
+
+// planCampaign plans creating a campaign, whose ID is its name, or updating
+// its definition. An updated campaign keeps its schedule and progress, and
+// is not restarted if it was stopped or has completed.
+func (s *Server) planCampaign(ctx context.Context, definition spec.Campaign, lists map[string][]string, now time.Time) (specStep, error) {
+	targets := slices.Clone(definition.Targets)
+	if definition.TargetList != "" {
+		listTargets, ok := lists[definition.TargetList]
+		if !ok {
+			list, err := s.targetListStore.GetTargetList(ctx, definition.TargetList)
+			if err != nil {
+				return specStep{}, err
+			}
+			if list == nil {
+				return specStep{}, dberrors.New(dberrors.NotFound, "campaign %s: target list %s not found", definition.Name, definition.TargetList)
+			}
+			listTargets = list.Targets
+		}
+		targets = append(targets, listTargets...)
+	}
+	payload, err := spec.EncodePayload(definition.Payload)
+	if err != nil {
+		return specStep{}, dberrors.New(dberrors.InvalidArgument, "campaign %s: %v", definition.Name, err)
+	}
+
+	desired := &models.Campaign{
+		ID:              definition.Name,
+		Name:            definition.Name,
+		Description:     definition.Description,
+		ModuleName:      definition.Module,
+		Payload:         payload,
+		Targets:         targets,
+		TargetField:     definition.TargetField,
+		AgentIDs:        definition.AgentIDs,
+		Selector:        definition.Selector,
+		IntervalSeconds: definition.IntervalSeconds,
+		StartsAt:        definition.StartsAt,
+		EndsAt:          definition.EndsAt,
+		CreatedAt:       now,
+	}
+	if desired.TargetField == "" {
+		desired.TargetField = models.DefaultCampaignTargetField
+	}
+	if desired.StartsAt.IsZero() {
+		desired.StartsAt = now
+	}
+
+	manage := func(ctx context.Context) error {
+		return s.specStore.SaveManagedCampaign(ctx, desired)
+	}
+
+	existing, err := s.campaignStore.GetCampaign(ctx, desired.ID)
+	if dberrors.CodeOf(err) == dberrors.NotFound {
+		if err := desired.Validate(); err != nil {
+			return specStep{}, dberrors.New(dberrors.InvalidArgument, "campaign %s: %v", desired.ID, err)
+		}
+		return specStep{
+			change: newSpecChange(specKindCampaign, desired.ID, specActionCreated, nil),
+			apply: func(ctx context.Context) error {
+				return s.campaignStore.CreateCampaign(ctx, desired)
+			},
+			manage: manage,
+		}, nil
+	}
+	if err != nil {
+		return specStep{}, err
+	}
+
+	fields := campaignChanges(existing, desired)
+	if len(fields) == 0 {
+		return specStep{
+			change: newSpecChange(specKindCampaign, desired.ID, specActionUnchanged, nil),
+			manage: manage,
+		}, nil
+	}
+	updated := withCampaignDefinition(existing, desired)
+	if err := updated.Validate(); err != nil {
+		return specStep{}, dberrors.New(dberrors.InvalidArgument, "campaign %s: %v", desired.ID, err)
+	}
+	return specStep{
+		change: newSpecChange(specKindCampaign, desired.ID, specActionUpdated, fields),
+		apply: func(ctx context.Context) error {
+			return s.campaignStore.UpdateCampaign(ctx, updated)
+		},
+		manage: manage,
+	}, nil
+}
+
+// withCampaignDefinition returns a copy of a campaign with the definition
+// of the desired one, keeping its schedule and progress
+func withCampaignDefinition(existing, desired *models.Campaign) *models.Campaign {
+	updated := *existing
+	updated.Description = desired.Description
+	updated.ModuleName = desired.ModuleName
+	updated.Payload = desired.Payload
+	updated.Targets = desired.Targets
+	updated.TargetField = desired.TargetField
+	updated.AgentIDs = desired.AgentIDs
+	updated.Selector = desired.Selector
+	updated.IntervalSeconds = desired.IntervalSeconds
+	updated.EndsAt = desired.EndsAt
+	return &updated
+}
+
+// campaignChanges lists the fields of a campaign's definition that differ
+// from the desired one's
+func campaignChanges(existing, desired *models.Campaign) []string {
+	var fields []string
+	diff := func(name string, changed bool) {
+		if changed {
+			fields = append(fields, name)
+		}
+	}
+	diff("description", existing.Description != desired.Description)
+	diff("module_name", existing.ModuleName != desired.ModuleName)
+	diff("payload", !jsonEqual(existing.Payload, desired.Payload))
+	diff("targets", !slices.Equal(existing.Targets, desired.Targets))
+	diff("target_field", existing.TargetField != desired.TargetField)
+	diff("agent_ids", !slices.Equal(existing.AgentIDs, desired.AgentIDs))
+	diff("selector", !maps.Equal(existing.Selector, desired.Selector))
+	diff("interval_seconds", existing.IntervalSeconds != desired.IntervalSeconds)
+	diff("ends_at", !existing.EndsAt.Equal(desired.EndsAt))
+	return fields
+}
+
+// planTask plans scheduling a recurring task, a continuous task whose ID is
+// its name, or updating its definition, which its instances issued from
+// then on run. A task changing between an agent and a selector is
+// cancelled and scheduled anew, as is a task that was cancelled.
+func (s *Server) planTask(ctx context.Context, definition spec.Task, now time.Time) (specStep, error) {
+	payload, err := spec.EncodePayload(definition.Payload)
+	if err != nil {
+		return specStep{}, dberrors.New(dberrors.InvalidArgument, "task %s: %v", definition.Name, err)
+	}
+	scheduledAt := definition.StartsAt
+	if scheduledAt.IsZero() {
+		scheduledAt = now
+	}
+	desired := models.NewTask(definition.Name, definition.AgentID, definition.Module, payload, scheduledAt)
+	desired.Type = string(models.TaskTypeContinuous)
+	desired.IntervalSeconds = definition.IntervalSeconds
+	desired.Selector = definition.Selector
+
+	schedule := func(ctx context.Context) error {
+		return s.scheduleRecurringTask(ctx, desired)
+	}
+	manage := func(ctx context.Context) error {
+		return s.specStore.SaveManagedTask(ctx, desired)
+	}
+
+	existing, err := s.taskStore.GetTask(ctx, desired.ID)
+	if dberrors.CodeOf(err) == dberrors.NotFound {
+		return specStep{
+			change: newSpecChange(specKindTask, desired.ID, specActionCreated, nil),
+			apply:  schedule,
+			manage: manage,
+		}, nil
+	}
+	if err != nil {
+		return specStep{}, err
+	}
+	if !existing.IsContinuous() {
+		return specStep{}, dberrors.New(dberrors.FailedPrecondition, "task %s exists and is not a recurring task", desired.ID)
+	}
+	if existing.Status == string(models.TaskStatusCancelled) {
+		return specStep{
+			change: newSpecChange(specKindTask, desired.ID, specActionCreated, nil),
+			apply:  schedule,
+			manage: manage,
+		}, nil
+	}
+
+	fields := taskChanges(existing, desired)
+	if len(fields) == 0 {
+		return specStep{
+			change: newSpecChange(specKindTask, desired.ID, specActionUnchanged, nil),
+			manage: manage,
+		}, nil
+	}
+	return specStep{
+		change: newSpecChange(specKindTask, desired.ID, specActionUpdated, fields),
+		apply: func(ctx context.Context) error {
+			return s.replaceRecurringTask(ctx, existing, desired)
+		},
+		manage: manage,
+	}, nil
+}
+
+// scheduleRecurringTask schedules a recurring task, replacing a cancelled
+// one of the same ID
+func (s *Server) scheduleRecurringTask(ctx context.Context, task *models.Task) error {
+	if err := s.taskStore.ScheduleTask(ctx, task); err != nil {
+		return err
+	}
+	s.recordTaskEvent(ctx, models.EventTaskScheduled, task)
+	return nil
+}
+
+// replaceRecurringTask gives a recurring task the definition of the desired
+// one. A task changing between an agent and a selector is cancelled and
+// the desired one scheduled in its place; otherwise the instances it
+// issues from then on run the new definition.
+func (s *Server) replaceRecurringTask(ctx context.Context, existing, desired *models.Task) error {
+	if existing.IsGroup() != desired.IsGroup() {
+		if err := s.taskStore.CancelTask(ctx, existing.ID); err != nil {
+			return err
+		}
+		s.recordTaskCancelled(ctx, existing.ID)
+		return s.scheduleRecurringTask(ctx, desired)
+	}
+
+	updated := *existing
+	updated.ModuleName = desired.ModuleName
+	updated.Payload = desired.Payload
+	updated.AgentID = desired.AgentID
+	updated.Selector = desired.Selector
+	updated.IntervalSeconds = desired.IntervalSeconds
+	return s.taskStore.UpdateTask(ctx, &updated)
+}
+
+// taskChanges lists the fields of a recurring task's definition that differ
+// from the desired one's
+func taskChanges(existing, desired *models.Task) []string {
+	var fields []string
+	diff := func(name string, changed bool) {
+		if changed {
+			fields = append(fields, name)
+		}
+	}
+	diff("module_name", existing.ModuleName != desired.ModuleName)
+	diff("payload", !jsonEqual(existing.Payload, desired.Payload))
+	diff("agent_id", existing.AgentID != desired.AgentID)
+	diff("selector", !maps.Equal(existing.Selector, desired.Selector))
+	diff("interval_seconds", existing.IntervalSeconds != desired.IntervalSeconds)
+	return fields
+}
+
+// jsonEqual reports whether two JSON documents hold the same value, however
+// they are formatted; empty ones are equal
+func jsonEqual(a, b []byte) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return string(a) == string(b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// newSpecChange returns the change applying a spec makes to one thing
+func newSpecChange(kind, name, action string, fields []string) *api.SpecChange {
+	return &api.SpecChange{
+		Kind:   kind,
+		Name:   name,
+		Action: action,
+		Fields: fields,
+	}
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/parquet"
+)
+
+// archiveFields are the columns of results archived as Parquet
+var archiveFields = []parquet.Field{
+	{Name: "id", Type: parquet.String},
+	{Name: "agent_id", Type: parquet.String},
+	{Name: "module_name", Type: parquet.String},
+	{Name: "data", Type: parquet.JSON},
+	{Name: "timestamp", Type: parquet.Timestamp},
+	{Name: "origin", Type: parquet.String},
+	{Name: "sequence", Type: parquet.Int64},
+	{Name: "clock_offset_ms", Type: parquet.Float64},
+	{Name: "correlation_id", Type: parquet.String},
+}
+
+// archiveResults uploads results of an agent, oldest first, to the archive
+// as one object, named after the agent and the range of measurement times
+// and IDs it holds, and returns its name
+func (s *Server) archiveResults(ctx context.Context, agentID string, results []*models.MeasurementResult) (string, error) {
+	var data []byte
+	var ext, contentType string
+	var err error
+	if s.config.ResultArchiveFormat == ResultArchiveParquet {
+		data, err = encodeParquetResults(results)
+		ext, contentType = ".parquet", parquet.ContentType
+	} else {
+		data, err = encodeNDJSONResults(results)
+		ext, contentType = ".jsonl.gz", "application/gzip"
+	}
+	if err != nil {
+		return "", err
+	}
+
+	first, last := results[0], results[len(results)-1]
+	name := fmt.Sprintf("results/%s/%d-%s_%d-%s%s", agentID,
+		first.Timestamp.Unix(), first.ID, last.Timestamp.Unix(), last.ID, ext)
+	if err := s.archive.Put(ctx, name, contentType, data); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// encodeNDJSONResults encodes results as gzipped JSON lines
+func encodeNDJSONResults(results []*models.MeasurementResult) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(zw)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeParquetResults encodes results as a Parquet file of archiveFields
+func encodeParquetResults(results []*models.MeasurementResult) ([]byte, error) {
+	var buf bytes.Buffer
+	w := parquet.NewWriter(&buf, archiveFields)
+	for _, result := range results {
+		err := w.Append(result.ID, result.AgentID, result.ModuleName, string(result.Data),
+			result.Timestamp, result.Origin, result.Sequence, result.ClockOffsetMs, result.CorrelationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// getResult retrieves a result from Redis or, if it was moved to the
+// archive, from its archived object
+func (s *Server) getResult(ctx context.Context, agentID, requestID string) (*models.MeasurementResult, error) {
+	result, err := s.resultStore.GetResult(ctx, agentID, requestID)
+	if s.archive == nil || dberrors.CodeOf(err) != dberrors.NotFound {
+		return result, err
+	}
+
+	object, lookupErr := s.archiveStore.GetLocation(ctx, agentID, requestID)
+	if dberrors.CodeOf(lookupErr) == dberrors.NotFound {
+		return nil, err
+	}
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+	data, err := s.archive.Get(ctx, object)
+	if err != nil {
+		return nil, fmt.Errorf("fetching archived object %s: %w", object, err)
+	}
+
+	var results []*models.MeasurementResult
+	if bytes.HasPrefix(data, []byte("PAR1")) {
+		results, err = decodeParquetResults(data)
+	} else {
+		results, err = decodeNDJSONResults(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding archived object %s: %w", object, err)
+	}
+	for _, result := range results {
+		if result.AgentID == agentID && result.ID == requestID {
+			return result, nil
+		}
+	}
+	return nil, dberrors.New(dberrors.NotFound, "result %s not found in archived object %s", requestID, object)
+}
+
+// decodeNDJSONResults decodes gzipped JSON lines of results
+func decodeNDJSONResults(data []byte) ([]*models.MeasurementResult, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(zr)
+	var results []*models.MeasurementResult
+	for {
+		var result models.MeasurementResult
+		if err := decoder.Decode(&result); err == io.EOF {
+			return results, nil
+		} else if err != nil {
+			return nil, err
+		}
+		results = append(results, &result)
+	}
+}
+
+// decodeParquetResults decodes a Parquet file of results, matching columns
+// of archiveFields by name
+func decodeParquetResults(data []byte) ([]*models.MeasurementResult, error) {
+	fields, rows, err := parquet.Read(data)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*models.MeasurementResult, len(rows))
+	for i, row := range rows {
+		result := &models.MeasurementResult{}
+		for j, field := range fields {
+			switch v := row[j].(type) {
+			case string:
+				switch field.Name {
+				case "id":
+					result.ID = v
+				case "agent_id":
+					result.AgentID = v
+				case "module_name":
+					result.ModuleName = v
+				case "data":
+					result.Data = []byte(v)
+				case "origin":
+					result.Origin = v
+				case "correlation_id":
+					result.CorrelationID = v
+				}
+			case time.Time:
+				if field.Name == "timestamp" {
+					result.Timestamp = v
+				}
+			case int64:
+				if field.Name == "sequence" {
+					result.Sequence = v
+				}
+			case float64:
+				if field.Name == "clock_offset_ms" {
+					result.ClockOffsetMs = v
+				}
+			}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"google.golang.org/grpc"
+)
+
+// Under load the server asks agents to back off: lease and ingest
+// responses carry a Backoff hint, growing with how far load is over its
+// thresholds. Load is the Redis round trip, probed every loadProbeInterval,
+// and the number of RPCs in flight. Leasing new work and batch ingest back
+// off as soon as load crosses a threshold; results of single finished
+// measurements only once it is twice over, so work already done drains
+// first.
+const (
+	// loadProbeInterval is how often the Redis round trip is measured
+	loadProbeInterval = time.Second
+
+	// loadProbeTimeout bounds a probe; a failed probe counts as this slow
+	loadProbeTimeout = 5 * time.Second
+
+	// backoffBase is the retry-after of load at its threshold
+	backoffBase = time.Second
+
+	// maxBackoff caps the retry-after of a hint
+	maxBackoff = time.Minute
+)
+
+// Reasons of backoff hints
+const (
+	backoffRedisLatency     = "redis_latency"
+	backoffInflightRequests = "inflight_requests"
+)
+
+// backoffPriority is how long work keeps being served without a hint
+type backoffPriority int
+
+const (
+	// backoffLow is work that can wait: leasing tasks and batch ingest
+	backoffLow backoffPriority = iota
+	// backoffHigh is the result of a finished measurement
+	backoffHigh
+)
+
+// untrackedMethods are RPCs not counted as in flight besides subscription
+// streams, as they mostly wait for tasks rather than load the server
+var untrackedMethods = map[string]bool{
+	api.DBOS_LeaseTask_FullMethodName: true,
+}
+
+// loadMonitor tracks the load backoff hints are derived from
+type loadMonitor struct {
+	inflight     atomic.Int64
+	redisLatency atomic.Int64 // of the last probe, in nanoseconds
+}
+
+// runLoadProbe measures the storage backend's round trip until ctx is done
+func (s *Server) runLoadProbe(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, loadProbeTimeout)
+		start := time.Now()
+		err := s.backend.Ping(probeCtx)
+		latency := time.Since(start)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			latency = loadProbeTimeout
+		}
+		s.load.redisLatency.Store(int64(latency))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// unaryLoadInterceptor counts the unary RPCs in flight
+func (s *Server) unaryLoadInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if untrackedMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	s.load.inflight.Add(1)
+	defer s.load.inflight.Add(-1)
+	return handler(ctx, req)
+}
+
+// streamLoadInterceptor counts the streaming RPCs in flight, except
+// subscription streams
+func (s *Server) streamLoadInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if subscriptionStreams[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	s.load.inflight.Add(1)
+	defer s.load.inflight.Add(-1)
+	return handler(srv, ss)
+}
+
+// backpressureEnabled reports whether any load threshold is set
+func (s *Server) backpressureEnabled() bool {
+	return s.config.BackpressureRedisLatency > 0 || s.config.BackpressureInflightRequests > 0
+}
+
+// backoff returns the hint for a response to work of a priority, or nil if
+// load does not call for slowing it down. batch is the most results the
+// request may hold, zero if it is not a batch.
+func (s *Server) backoff(priority backoffPriority, batch int) *api.Backoff {
+	ratio, reason := s.loadRatio()
+	threshold := 1.0
+	if priority == backoffHigh {
+		threshold = 2
+	}
+	if ratio < threshold {
+		return nil
+	}
+
+	retryAfter := min(time.Duration(ratio*float64(backoffBase)), maxBackoff)
+	hint := &api.Backoff{
+		RetryAfterMs: retryAfter.Milliseconds(),
+		Reason:       reason,
+	}
+	if batch > 0 {
+		hint.MaxBatchSize = int32(max(1, int(float64(batch)/ratio)))
+	}
+	return hint
+}
+
+// loadRatio returns how far load is over its thresholds, 1 being at a
+// threshold, and the reason of the threshold it is furthest over
+func (s *Server) loadRatio() (float64, string) {
+	var ratio float64
+	var reason string
+	if threshold := s.config.BackpressureRedisLatency; threshold > 0 {
+		ratio = float64(s.load.redisLatency.Load()) / float64(threshold)
+		reason = backoffRedisLatency
+	}
+	if threshold := s.config.BackpressureInflightRequests; threshold > 0 {
+		if r := float64(s.load.inflight.Load()) / float64(threshold); r > ratio {
+			ratio, reason = r, backoffInflightRequests
+		}
+	}
+	return ratio, reason
+}
+
+// waitBackoff waits out a backoff hint, returning early once ctx is done
+func waitBackoff(ctx context.Context, hint *api.Backoff) {
+	if hint == nil || hint.RetryAfterMs <= 0 {
+		return
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Duration(hint.RetryAfterMs) * time.Millisecond):
+	}
+}
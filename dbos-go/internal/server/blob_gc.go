@@ -0,0 +1,29 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// runBlobGC periodically deletes payload fragments no result references any more
+func (s *Server) runBlobGC(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.blobStore.GC(ctx)
+			if err != nil {
+				log.Printf("Blob GC: %v", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("Blob GC: deleted %d unreferenced fragments", deleted)
+			}
+		}
+	}
+}
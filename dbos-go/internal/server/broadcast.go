@@ -0,0 +1,266 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+)
+
+// BroadcastTask fans a task out to every live agent matching a selector, or
+// every live agent if it is empty, giving each its own task. The agents are
+// those live when the task is broadcast; agents registering later are not
+// given it.
+func (s *Server) BroadcastTask(ctx context.Context, req *api.BroadcastTaskRequest) (*api.BroadcastTaskResponse, error) {
+	definition := taskFromAPI(req.Task)
+	if definition.ModuleName == "" {
+		return &api.BroadcastTaskResponse{
+			Success:   false,
+			Error:     "a broadcast task requires a module name",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+	if definition.AgentID != "" || definition.IsGroup() {
+		return &api.BroadcastTaskResponse{
+			Success:   false,
+			Error:     "a broadcast task is given to the agents of the broadcast's selector, not an agent_id or selector of its own",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+	if definition.IsContinuous() || definition.Placement != nil {
+		return &api.BroadcastTaskResponse{
+			Success:   false,
+			Error:     "a broadcast task must be one-shot and without a placement",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+	if definition.RetryPolicy != nil {
+		if err := definition.RetryPolicy.Validate(); err != nil {
+			return &api.BroadcastTaskResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: string(dberrors.InvalidArgument),
+			}, nil
+		}
+	}
+
+	id := req.BroadcastId
+	if id == "" {
+		id = ids.New("broadcast-")
+	}
+	if _, err := s.broadcastStore.GetBroadcast(ctx, id); err == nil {
+		return &api.BroadcastTaskResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("broadcast %s already exists", id),
+			ErrorCode: string(dberrors.AlreadyExists),
+		}, nil
+	}
+
+	now := time.Now()
+	agentIDs, err := s.liveAgentsMatching(ctx, req.Selector, now)
+	if err != nil {
+		return &api.BroadcastTaskResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	scheduledAt := now
+	if req.Task.ScheduledAt > 0 {
+		scheduledAt = definition.ScheduledAt
+	}
+	broadcast := &models.Broadcast{
+		ID:            id,
+		ModuleName:    definition.ModuleName,
+		Payload:       definition.Payload,
+		Selector:      req.Selector,
+		AgentIDs:      agentIDs,
+		ScheduledAt:   scheduledAt,
+		CreatedAt:     now,
+		CorrelationID: definition.CorrelationID,
+	}
+	for _, agentID := range agentIDs {
+		broadcast.TaskIDs = append(broadcast.TaskIDs, broadcast.TaskID(agentID))
+	}
+	if err := s.broadcastStore.SaveBroadcast(ctx, broadcast); err != nil {
+		return &api.BroadcastTaskResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	for i, agentID := range agentIDs {
+		task := models.NewTask(broadcast.TaskIDs[i], agentID, broadcast.ModuleName, broadcast.Payload, scheduledAt)
+		task.BroadcastID = id
+		task.CorrelationID = definition.CorrelationID
+		task.LeaseTimeoutSeconds = definition.LeaseTimeoutSeconds
+		task.RetryPolicy = definition.RetryPolicy
+		if err := s.taskStore.ScheduleTask(ctx, task); err != nil {
+			return &api.BroadcastTaskResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+		s.recordTaskEvent(ctx, models.EventTaskScheduled, task)
+	}
+
+	return &api.BroadcastTaskResponse{
+		Success:   true,
+		Broadcast: broadcastToAPI(broadcast),
+	}, nil
+}
+
+// GetBroadcastStatus reports the progress of a broadcast: the status of
+// each agent's task and how many agents are at each status
+func (s *Server) GetBroadcastStatus(ctx context.Context, req *api.GetBroadcastStatusRequest) (*api.GetBroadcastStatusResponse, error) {
+	status, err := s.broadcastStatus(ctx, req.BroadcastId)
+	if err != nil {
+		return &api.GetBroadcastStatusResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	agents := make([]*api.BroadcastAgentStatus, len(status.Agents))
+	for i, agent := range status.Agents {
+		agents[i] = &api.BroadcastAgentStatus{
+			AgentId: agent.AgentID,
+			TaskId:  agent.TaskID,
+			Status:  agent.Status,
+		}
+	}
+	return &api.GetBroadcastStatusResponse{
+		Found:     true,
+		Broadcast: broadcastToAPI(status.Broadcast),
+		Agents:    agents,
+		Pending:   int32(status.Count(models.TaskStatusPending)),
+		Running:   int32(status.Count(models.TaskStatusRunning)),
+		Completed: int32(status.Count(models.TaskStatusCompleted)),
+		Failed:    int32(status.Count(models.TaskStatusFailed)),
+		Cancelled: int32(status.Count(models.TaskStatusCancelled)),
+		Done:      status.Done(),
+	}, nil
+}
+
+// broadcastStatus gathers the status of each agent's task of a broadcast.
+// An outcome the agent reported through a result or module state stands
+// over its task's status, which is kept for agents yet to report one.
+func (s *Server) broadcastStatus(ctx context.Context, broadcastID string) (*models.BroadcastStatus, error) {
+	broadcast, err := s.broadcastStore.GetBroadcast(ctx, broadcastID)
+	if err != nil {
+		return nil, err
+	}
+	reported, err := s.broadcastStore.AgentStatuses(ctx, broadcastID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.taskStore.GetTasks(ctx, broadcast.TaskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.BroadcastStatus{Broadcast: broadcast}
+	for i, agentID := range broadcast.AgentIDs {
+		agent := models.BroadcastAgentStatus{
+			AgentID: agentID,
+			TaskID:  broadcast.TaskIDs[i],
+			Status:  models.BroadcastAgentUnknown,
+		}
+		if outcome, ok := reported[agentID]; ok {
+			agent.Status = outcome
+		} else if tasks[i] != nil {
+			agent.Status = tasks[i].Status
+		}
+		status.Agents = append(status.Agents, agent)
+	}
+	return status, nil
+}
+
+// liveAgentsMatching returns the IDs of the live agents matching selector,
+// in ID order, failing if there are none
+func (s *Server) liveAgentsMatching(ctx context.Context, selector map[string]string, now time.Time) ([]string, error) {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var agentIDs []string
+	for _, agent := range agents {
+		if !agent.Alive || now.Sub(agent.LastSeen) > s.config.AgentLivenessWindow {
+			continue
+		}
+		if !models.MatchesLabels(selector, agent.Labels) {
+			continue
+		}
+		agentIDs = append(agentIDs, agent.ID)
+	}
+	if len(agentIDs) == 0 {
+		return nil, dberrors.New(dberrors.FailedPrecondition, "no live agents match the selector")
+	}
+	sort.Strings(agentIDs)
+	return agentIDs, nil
+}
+
+// recordBroadcastResult marks an agent's task of a broadcast completed once
+// its result is stored
+func (s *Server) recordBroadcastResult(ctx context.Context, result *models.MeasurementResult) {
+	if result.Origin != string(models.ResultOriginScheduled) {
+		return
+	}
+	task, err := s.taskStore.GetTask(ctx, result.ID)
+	if err != nil || task.BroadcastID == "" {
+		return
+	}
+	if err := s.broadcastStore.RecordAgentStatus(ctx, task.BroadcastID, task.AgentID, models.TaskStatusCompleted); err != nil {
+		log.Printf("Broadcast %s: %v", task.BroadcastID, err)
+	}
+}
+
+// recordBroadcastState records the outcome a module state reports for an
+// agent's task of a broadcast: completed, or failed on an error
+func (s *Server) recordBroadcastState(ctx context.Context, state *models.ModuleState) {
+	var outcome models.TaskStatusEnum
+	switch models.ModuleStateEnum(state.State) {
+	case models.ModuleStateCompleted:
+		outcome = models.TaskStatusCompleted
+	case models.ModuleStateError, models.ModuleStateFailed:
+		outcome = models.TaskStatusFailed
+	default:
+		return
+	}
+	if state.RequestID == "" {
+		return
+	}
+	task, err := s.taskStore.GetTask(ctx, state.RequestID)
+	if err != nil || task.BroadcastID == "" {
+		return
+	}
+	if err := s.broadcastStore.RecordAgentStatus(ctx, task.BroadcastID, task.AgentID, outcome); err != nil {
+		log.Printf("Broadcast %s: %v", task.BroadcastID, err)
+	}
+}
+
+// broadcastToAPI converts a model broadcast into an API broadcast
+func broadcastToAPI(b *models.Broadcast) *api.Broadcast {
+	return &api.Broadcast{
+		Id:            b.ID,
+		ModuleName:    b.ModuleName,
+		Payload:       b.Payload,
+		Selector:      b.Selector,
+		AgentIds:      b.AgentIDs,
+		TaskIds:       b.TaskIDs,
+		ScheduledAt:   b.ScheduledAt.Unix(),
+		CreatedAt:     b.CreatedAt.Unix(),
+		CorrelationId: b.CorrelationID,
+	}
+}
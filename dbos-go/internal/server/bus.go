@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/kafka"
+	"github.com/internet-measurement-network/dbos/pkg/nats"
+)
+
+const (
+	// busReadBlock is how long the publisher waits for new messages before
+	// checking for stalled ones again
+	busReadBlock = time.Second
+
+	// busClaimIdle is how long a message may wait unacknowledged by the
+	// publisher that read it before another takes it over, as when that
+	// publisher's server stopped
+	busClaimIdle = time.Minute
+
+	// busPublishTimeout bounds the publish of one batch
+	busPublishTimeout = 30 * time.Second
+
+	// busRetryDelay is the wait before retrying a failed batch, doubling
+	// with each further failure up to busMaxRetryDelay
+	busRetryDelay    = time.Second
+	busMaxRetryDelay = time.Minute
+)
+
+// busMessage is a message published to a message bus
+type busMessage struct {
+	Topic string
+	Key   string
+	Value []byte
+}
+
+// busPublisher publishes messages to a message bus, returning once the bus
+// accepted them all
+type busPublisher interface {
+	Publish(ctx context.Context, messages []busMessage) error
+	Close() error
+}
+
+// newBusPublisher creates the publisher of the configured bus driver, or
+// nil if publishing is disabled
+func newBusPublisher(cfg Config) busPublisher {
+	switch cfg.BusDriver {
+	case BusKafka:
+		return kafkaBus{kafka.NewProducer(cfg.BusServers, cfg.BusUser, cfg.BusPassword)}
+	case BusNATS:
+		return natsBus{nats.NewPublisher(cfg.BusServers, nats.Options{
+			User:      cfg.BusUser,
+			Password:  cfg.BusPassword,
+			Token:     cfg.BusToken,
+			JetStream: cfg.BusJetStream,
+		})}
+	}
+	return nil
+}
+
+// kafkaBus publishes to Kafka topics, partitioned by message key
+type kafkaBus struct {
+	*kafka.Producer
+}
+
+func (b kafkaBus) Publish(ctx context.Context, messages []busMessage) error {
+	records := make([]kafka.Message, len(messages))
+	for i, m := range messages {
+		records[i] = kafka.Message{Topic: m.Topic, Key: []byte(m.Key), Value: m.Value}
+	}
+	return b.Produce(ctx, records)
+}
+
+// natsBus publishes to NATS subjects; NATS messages have no key
+type natsBus struct {
+	*nats.Publisher
+}
+
+func (b natsBus) Publish(ctx context.Context, messages []busMessage) error {
+	msgs := make([]nats.Message, len(messages))
+	for i, m := range messages {
+		msgs[i] = nats.Message{Subject: m.Topic, Data: m.Value}
+	}
+	return b.Publisher.Publish(ctx, msgs)
+}
+
+// runBusPublisher publishes the outbox to the message bus until ctx is
+// done, then closes the bus connection. Servers sharing a Redis share the
+// outbox, each publishing the messages it reads.
+func (s *Server) runBusPublisher(ctx context.Context) {
+	defer s.bus.Close()
+
+	consumer, err := os.Hostname()
+	if err != nil || consumer == "" {
+		consumer = "dbos"
+	}
+	delay := busRetryDelay
+	for {
+		err := s.outboxStore.CreateGroup(ctx)
+		if err == nil {
+			err = s.publishOutbox(ctx, consumer)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			delay = busRetryDelay
+			continue
+		}
+
+		log.Printf("Bus publisher: %v, retrying in %s", err, delay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, busMaxRetryDelay)
+	}
+}
+
+// publishOutbox publishes one batch of messages and acknowledges them: the
+// ones this publisher read before without acknowledging them, which
+// include those taken over from stalled publishers, or else new ones. A
+// failed batch stays pending and is published again on the next call.
+func (s *Server) publishOutbox(ctx context.Context, consumer string) error {
+	count := int64(s.config.BusBatchSize)
+	if err := s.outboxStore.Claim(ctx, consumer, busClaimIdle, count); err != nil {
+		return err
+	}
+	entries, err := s.outboxStore.Read(ctx, consumer, true, count, 0)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		entries, err = s.outboxStore.Read(ctx, consumer, false, count, busReadBlock)
+		if err != nil {
+			return err
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(entries))
+	messages := make([]busMessage, 0, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+		// Messages trimmed from the outbox before they were published read
+		// back empty and are only acknowledged
+		topic, ok := s.busTopic(entry)
+		if !ok {
+			continue
+		}
+		messages = append(messages, busMessage{Topic: topic, Key: entry.Key, Value: entry.Data})
+	}
+	if len(messages) > 0 {
+		publishCtx, cancel := context.WithTimeout(ctx, busPublishTimeout)
+		err := s.bus.Publish(publishCtx, messages)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return s.outboxStore.Ack(ctx, ids)
+}
+
+// busTopic returns the topic an outbox message is published to
+func (s *Server) busTopic(entry *models.OutboxEntry) (string, bool) {
+	switch models.OutboxKindEnum(entry.Kind) {
+	case models.OutboxKindResult:
+		return strings.ReplaceAll(s.config.BusResultsTopic, "{module}", entry.Tag), true
+	case models.OutboxKindEvent:
+		return strings.ReplaceAll(s.config.BusEventsTopic, "{entity_type}", entry.Tag), true
+	}
+	return "", false
+}
@@ -0,0 +1,331 @@
+package server
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+)
+
+// campaignSchedulerInterval is how often due campaign rounds are issued
+const campaignSchedulerInterval = 5 * time.Second
+
+// campaignResultOrderBy are the fields of ListCampaignResults' order_by
+var campaignResultOrderBy = map[string]string{
+	"timestamp": models.ResultOrderTimestamp,
+}
+
+// CreateCampaign creates a measurement campaign, running its first round at
+// its start
+func (s *Server) CreateCampaign(ctx context.Context, req *api.CreateCampaignRequest) (*api.CreateCampaignResponse, error) {
+	now := time.Now()
+	campaign := campaignFromAPI(req.Campaign)
+	campaign.ID = ids.New("campaign-")
+	campaign.CreatedAt = now
+	if campaign.TargetField == "" {
+		campaign.TargetField = models.DefaultCampaignTargetField
+	}
+	if campaign.StartsAt.IsZero() {
+		campaign.StartsAt = now
+	}
+	if err := s.campaignStore.CreateCampaign(ctx, campaign); err != nil {
+		return &api.CreateCampaignResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	log.Printf("Campaign %s (%s) created by %s: %s on %d targets from %s", campaign.ID, campaign.Name, authorOrUnknown(campaign.CreatedBy),
+		campaign.ModuleName, len(campaign.Targets), campaign.StartsAt.UTC().Format(time.RFC3339))
+	campaign, err := s.campaignStore.GetCampaign(ctx, campaign.ID)
+	if err != nil {
+		return &api.CreateCampaignResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	return &api.CreateCampaignResponse{
+		Success:  true,
+		Campaign: campaignToAPI(campaign),
+	}, nil
+}
+
+// GetCampaign retrieves a campaign and its progress by ID
+func (s *Server) GetCampaign(ctx context.Context, req *api.GetCampaignRequest) (*api.GetCampaignResponse, error) {
+	campaign, err := s.campaignStore.GetCampaign(ctx, req.Id)
+	if err != nil {
+		return &api.GetCampaignResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.GetCampaignResponse{
+		Found:    true,
+		Campaign: campaignToAPI(campaign),
+	}, nil
+}
+
+// ListCampaigns retrieves campaigns newest first, optionally only those
+// with a status
+func (s *Server) ListCampaigns(ctx context.Context, req *api.ListCampaignsRequest) (*api.ListCampaignsResponse, error) {
+	campaigns, err := s.campaignStore.ListCampaigns(ctx)
+	if err != nil {
+		return &api.ListCampaignsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiCampaigns := make([]*api.Campaign, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		if req.Status != "" && campaign.Status != req.Status {
+			continue
+		}
+		apiCampaigns = append(apiCampaigns, campaignToAPI(campaign))
+	}
+
+	return &api.ListCampaignsResponse{
+		Campaigns: apiCampaigns,
+	}, nil
+}
+
+// StopCampaign stops a running campaign; tasks it already issued still run
+// and their results are still correlated with it
+func (s *Server) StopCampaign(ctx context.Context, req *api.StopCampaignRequest) (*api.StopCampaignResponse, error) {
+	campaign, err := s.campaignStore.StopCampaign(ctx, req.Id, time.Now())
+	if err != nil {
+		return &api.StopCampaignResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	log.Printf("Campaign %s (%s) stopped after %d rounds", campaign.ID, campaign.Name, campaign.Rounds)
+	return &api.StopCampaignResponse{
+		Success:  true,
+		Campaign: campaignToAPI(campaign),
+	}, nil
+}
+
+// ListCampaignResults retrieves a page of the results correlated with a
+// campaign in measurement time order
+func (s *Server) ListCampaignResults(ctx context.Context, req *api.ListCampaignResultsRequest) (*api.ListCampaignResultsResponse, error) {
+	mask, err := newReadMask(&api.MeasurementResult{}, req.ReadMask)
+	if err != nil {
+		return &api.ListCampaignResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	order, err := parseOrderBy(req.OrderBy, campaignResultOrderBy)
+	if err != nil {
+		return &api.ListCampaignResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	if _, err := s.campaignStore.GetCampaign(ctx, req.CampaignId); err != nil {
+		return &api.ListCampaignResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultResultPageSize
+	}
+	if limit > maxResultPageSize {
+		limit = maxResultPageSize
+	}
+
+	refs, nextCursor, err := s.campaignStore.ListResults(ctx, req.CampaignId, order.Desc, req.Cursor, limit)
+	if err != nil {
+		return &api.ListCampaignResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiResults := make([]*api.MeasurementResult, 0, len(refs))
+	for _, ref := range refs {
+		result, err := s.resultStore.GetResult(ctx, ref.AgentID, ref.RequestID)
+		if err != nil {
+			continue
+		}
+		apiResult := resultToAPI(result)
+		mask.apply(apiResult)
+		apiResults = append(apiResults, apiResult)
+	}
+
+	return &api.ListCampaignResultsResponse{
+		Results:    apiResults,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// runCampaignScheduler periodically issues the due rounds of campaigns until
+// ctx is done
+func (s *Server) runCampaignScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.issueCampaignRounds(ctx, now); err != nil {
+				log.Printf("Campaign scheduler: %v", err)
+			}
+		}
+	}
+}
+
+// issueCampaignRounds runs the due round of every campaign: a task per live
+// agent of the campaign and target. Rounds missed while no server ran are
+// skipped, and tasks paused by a maintenance window are not issued.
+func (s *Server) issueCampaignRounds(ctx context.Context, now time.Time) error {
+	campaigns, err := s.campaignStore.ListDueCampaigns(ctx, now)
+	if err != nil {
+		return err
+	}
+	if len(campaigns) == 0 {
+		return nil
+	}
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].ID < agents[j].ID
+	})
+	windows, err := s.maintenanceStore.ListActiveWindows(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range campaigns {
+		round, err := s.campaignStore.ClaimRound(ctx, campaign, now)
+		if err != nil {
+			return err
+		}
+		if round == 0 {
+			continue
+		}
+
+		issued, paused := 0, 0
+		k := 0
+		for _, agent := range agents {
+			if !agent.Alive || now.Sub(agent.LastSeen) > s.config.AgentLivenessWindow || !campaign.MatchesAgent(agent) {
+				continue
+			}
+			for _, target := range campaign.Targets {
+				payload, err := campaign.TaskPayload(target)
+				if err != nil {
+					return err
+				}
+				task := models.NewTask(campaign.RoundTaskID(round, k), agent.ID, campaign.ModuleName, payload, now)
+				task.CampaignID = campaign.ID
+				k++
+
+				if s.taskPaused(ctx, windows, task) != nil {
+					paused++
+					continue
+				}
+				if err := s.taskStore.ScheduleTask(ctx, task); err != nil {
+					return err
+				}
+				issued++
+			}
+		}
+
+		if err := s.campaignStore.AddTasksIssued(ctx, campaign.ID, issued); err != nil {
+			return err
+		}
+		log.Printf("Campaign %s: round %d issued %d tasks, %d paused by maintenance windows", campaign.ID, round, issued, paused)
+	}
+
+	return nil
+}
+
+// recordCampaignResult correlates a result with the campaign whose round
+// issued its task, if any
+func (s *Server) recordCampaignResult(ctx context.Context, result *models.MeasurementResult) {
+	if result.Origin != string(models.ResultOriginScheduled) {
+		return
+	}
+	task, err := s.taskStore.GetTask(ctx, result.ID)
+	if err != nil || task.CampaignID == "" {
+		return
+	}
+
+	if err := s.campaignStore.RecordResult(ctx, task.CampaignID, result); err != nil {
+		log.Printf("Campaign %s: %v", task.CampaignID, err)
+	}
+}
+
+// campaignFromAPI converts an API campaign into a model one
+func campaignFromAPI(c *api.Campaign) *models.Campaign {
+	return &models.Campaign{
+		ID:              c.Id,
+		Name:            c.Name,
+		Description:     c.Description,
+		ModuleName:      c.ModuleName,
+		Payload:         c.Payload,
+		Targets:         c.Targets,
+		TargetField:     c.TargetField,
+		AgentIDs:        c.AgentIds,
+		Selector:        c.Selector,
+		IntervalSeconds: c.IntervalSeconds,
+		StartsAt:        timeFromUnix(c.StartsAt),
+		EndsAt:          timeFromUnix(c.EndsAt),
+		CreatedBy:       c.CreatedBy,
+	}
+}
+
+// campaignToAPI converts a model campaign into an API one
+func campaignToAPI(c *models.Campaign) *api.Campaign {
+	var endsAt, stoppedAt, nextRunAt int64
+	if !c.EndsAt.IsZero() {
+		endsAt = c.EndsAt.Unix()
+	}
+	if !c.StoppedAt.IsZero() {
+		stoppedAt = c.StoppedAt.Unix()
+	}
+	if !c.NextRunAt.IsZero() {
+		nextRunAt = c.NextRunAt.Unix()
+	}
+
+	return &api.Campaign{
+		Id:              c.ID,
+		Name:            c.Name,
+		Description:     c.Description,
+		ModuleName:      c.ModuleName,
+		Payload:         c.Payload,
+		Targets:         c.Targets,
+		TargetField:     c.TargetField,
+		AgentIds:        c.AgentIDs,
+		Selector:        c.Selector,
+		IntervalSeconds: c.IntervalSeconds,
+		StartsAt:        c.StartsAt.Unix(),
+		EndsAt:          endsAt,
+		CreatedBy:       c.CreatedBy,
+		CreatedAt:       c.CreatedAt.Unix(),
+		Status:          c.Status,
+		StoppedAt:       stoppedAt,
+		NextRunAt:       nextRunAt,
+		Rounds:          c.Rounds,
+		TasksIssued:     c.TasksIssued,
+		ResultsReceived: c.ResultsReceived,
+	}
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+const (
+	// defaultCapacityHorizon is the span of the queue depth estimate of a
+	// campaign that runs until stopped
+	defaultCapacityHorizon = 24 * time.Hour
+
+	// maxCapacityHorizon caps the span of the queue depth estimate
+	maxCapacityHorizon = 7 * 24 * time.Hour
+
+	// defaultTaskDurationMs is the duration assumed for tasks of a module no
+	// agent ran recently, unless the request names another
+	defaultTaskDurationMs = 1000
+
+	// minAgentExecutions is how many recent executions an agent needs for
+	// its own durations to be used instead of its module's
+	minAgentExecutions = 5
+
+	// maxQueueDepthPoints caps the points of the queue depth estimate
+	maxQueueDepthPoints = 500
+)
+
+// PlanCapacity estimates the load a proposed campaign would put on the
+// agents that would run it and checks it against budgets
+func (s *Server) PlanCapacity(ctx context.Context, req *api.PlanCapacityRequest) (*api.PlanCapacityResponse, error) {
+	plan, err := s.planCapacity(ctx, req, time.Now())
+	if err != nil {
+		return &api.PlanCapacityResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	plan.Success = true
+	return plan, nil
+}
+
+// planCapacity estimates a campaign's load. Each agent is taken to run its
+// tasks one at a time, each for the mean duration of its recent executions
+// of the module, or of the module's across all agents if it ran fewer than
+// minAgentExecutions, so its queue grows by a round's work every round and
+// drains at the rate the agent runs tasks.
+func (s *Server) planCapacity(ctx context.Context, req *api.PlanCapacityRequest, now time.Time) (*api.PlanCapacityResponse, error) {
+	if req.Campaign == nil {
+		return nil, dberrors.New(dberrors.InvalidArgument, "campaign is required")
+	}
+	campaign := campaignFromAPI(req.Campaign)
+	if campaign.TargetField == "" {
+		campaign.TargetField = models.DefaultCampaignTargetField
+	}
+	if campaign.StartsAt.IsZero() {
+		campaign.StartsAt = now
+	}
+	if err := campaign.Validate(); err != nil {
+		return nil, dberrors.Wrap(dberrors.InvalidArgument, err)
+	}
+	agentBudget, groupBudget := capacityBudgetFromAPI(req.AgentBudget), capacityBudgetFromAPI(req.GroupBudget)
+	if err := agentBudget.Validate(); err != nil {
+		return nil, dberrors.Wrap(dberrors.InvalidArgument, err)
+	}
+	if err := groupBudget.Validate(); err != nil {
+		return nil, dberrors.Wrap(dberrors.InvalidArgument, err)
+	}
+	if req.HorizonSeconds < 0 || req.DefaultDurationMs < 0 {
+		return nil, dberrors.New(dberrors.InvalidArgument, "horizon and default duration must not be negative")
+	}
+	defaultDurationMs := float64(req.DefaultDurationMs)
+	if defaultDurationMs == 0 {
+		defaultDurationMs = defaultTaskDurationMs
+	}
+
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].ID < agents[j].ID
+	})
+	var running []*models.Agent
+	live := make(map[string]bool)
+	for _, agent := range agents {
+		if !agent.Alive || now.Sub(agent.LastSeen) > s.config.AgentLivenessWindow {
+			continue
+		}
+		live[agent.ID] = true
+		if campaign.MatchesAgent(agent) {
+			running = append(running, agent)
+		}
+	}
+	var unavailable []string
+	for _, agentID := range campaign.AgentIDs {
+		if !live[agentID] {
+			unavailable = append(unavailable, agentID)
+		}
+	}
+
+	agentIDs := make([]string, len(running))
+	for i, agent := range running {
+		agentIDs[i] = agent.ID
+	}
+	agentStats, err := s.executionStore.AgentStats(ctx, agentIDs, campaign.ModuleName)
+	if err != nil {
+		return nil, err
+	}
+	moduleStats, err := s.executionStore.ModuleStats(ctx, campaign.ModuleName)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := campaign.Interval().Seconds()
+	rounds, times := capacityRounds(campaign, time.Duration(req.HorizonSeconds)*time.Second)
+	points := samplePoints(rounds, maxQueueDepthPoints)
+
+	plan := &api.PlanCapacityResponse{
+		QueueDepth:          make([]*api.QueueDepthPoint, len(points)),
+		UnavailableAgentIds: unavailable,
+	}
+	for i, k := range points {
+		plan.QueueDepth[i] = &api.QueueDepthPoint{Timestamp: times(k).Unix()}
+	}
+
+	groups := make(map[string]*api.GroupCapacity)
+	tasks := int64(len(campaign.Targets))
+	for _, agent := range running {
+		stats, source := agentStats[agent.ID], "agent"
+		if stats.Samples < minAgentExecutions {
+			stats, source = moduleStats, "module"
+		}
+		if stats.Samples == 0 {
+			stats, source = models.ExecutionStats{MeanDurationMs: defaultDurationMs, P95DurationMs: defaultDurationMs}, "default"
+		}
+		// A task takes at least a millisecond, so queues always drain
+		durationSeconds := max(stats.MeanDurationMs, 1) / 1000
+
+		capacity := &api.AgentCapacity{
+			AgentId:        agent.ID,
+			TasksPerRound:  tasks,
+			MeanDurationMs: stats.MeanDurationMs,
+			P95DurationMs:  stats.P95DurationMs,
+			DurationSource: source,
+			Samples:        int64(stats.Samples),
+			RoundSeconds:   float64(tasks) * durationSeconds,
+		}
+		if req.GroupLabel != "" {
+			capacity.Group = agent.Labels[req.GroupLabel]
+		}
+		roundBytes := float64(tasks) * stats.MeanResultBytes
+		if interval > 0 {
+			capacity.TasksPerHour = float64(tasks) * 3600 / interval
+			capacity.Utilization = capacity.RoundSeconds / interval
+			capacity.BytesPerSecond = roundBytes / interval
+		} else if capacity.RoundSeconds > 0 {
+			capacity.BytesPerSecond = roundBytes / capacity.RoundSeconds
+		}
+		for i, k := range points {
+			plan.QueueDepth[i].Depth += queueDepth(tasks, durationSeconds, interval, k)
+		}
+		// Queues only grow from round to round, so they peak at the last
+		capacity.PeakQueueDepth = queueDepth(tasks, durationSeconds, interval, rounds-1)
+		capacity.Exceeded = agentBudget.Exceeded(capacity.Utilization, capacity.PeakQueueDepth, capacity.BytesPerSecond)
+		if len(capacity.Exceeded) > 0 {
+			plan.AgentsOverBudget++
+		}
+		plan.Agents = append(plan.Agents, capacity)
+		plan.TasksPerHour += capacity.TasksPerHour
+		plan.BytesPerSecond += capacity.BytesPerSecond
+
+		if req.GroupLabel == "" {
+			continue
+		}
+		group, ok := groups[capacity.Group]
+		if !ok {
+			group = &api.GroupCapacity{Group: capacity.Group}
+			groups[capacity.Group] = group
+		}
+		group.Agents++
+		group.TasksPerHour += capacity.TasksPerHour
+		group.Utilization += capacity.Utilization
+		group.PeakQueueDepth += capacity.PeakQueueDepth
+		group.BytesPerSecond += capacity.BytesPerSecond
+	}
+
+	for _, group := range groups {
+		group.Utilization /= float64(group.Agents)
+		group.Exceeded = groupBudget.Exceeded(group.Utilization, group.PeakQueueDepth, group.BytesPerSecond)
+		if len(group.Exceeded) > 0 {
+			plan.GroupsOverBudget++
+		}
+		plan.Groups = append(plan.Groups, group)
+	}
+	sort.Slice(plan.Groups, func(i, j int) bool {
+		return plan.Groups[i].Group < plan.Groups[j].Group
+	})
+	return plan, nil
+}
+
+// capacityRounds returns how many rounds of a campaign fall within the
+// horizon from its start, at least one, and the time of the k-th. The
+// horizon defaults to the campaign's span and is capped at
+// maxCapacityHorizon.
+func capacityRounds(campaign *models.Campaign, horizon time.Duration) (int64, func(k int64) time.Time) {
+	at := func(k int64) time.Time {
+		return campaign.StartsAt.Add(time.Duration(k) * campaign.Interval())
+	}
+	if campaign.Interval() <= 0 {
+		return 1, at
+	}
+
+	if horizon == 0 {
+		horizon = defaultCapacityHorizon
+		if !campaign.EndsAt.IsZero() {
+			horizon = campaign.EndsAt.Sub(campaign.StartsAt)
+		}
+	}
+	horizon = min(horizon, maxCapacityHorizon)
+	if !campaign.EndsAt.IsZero() {
+		horizon = min(horizon, campaign.EndsAt.Sub(campaign.StartsAt))
+	}
+	rounds := int64((horizon + campaign.Interval() - 1) / campaign.Interval())
+	return max(rounds, 1), at
+}
+
+// samplePoints returns up to count of the rounds 0 to rounds-1, evenly
+// spread and including the first and last
+func samplePoints(rounds int64, count int) []int64 {
+	if rounds <= int64(count) {
+		points := make([]int64, rounds)
+		for k := range points {
+			points[k] = int64(k)
+		}
+		return points
+	}
+	points := make([]int64, count)
+	for i := range points {
+		points[i] = int64(i) * (rounds - 1) / int64(count-1)
+	}
+	return points
+}
+
+// queueDepth returns how many tasks an agent has queued or running as the
+// k-th round adds tasks taking durationSeconds each, rounds interval
+// seconds apart. The queue empties between rounds unless a round takes
+// longer than the interval, when each round leaves the excess behind.
+func queueDepth(tasks int64, durationSeconds, interval float64, k int64) int64 {
+	excess := max(0, float64(tasks)*durationSeconds-interval)
+	if interval <= 0 {
+		excess = 0
+	}
+	return tasks + int64(math.Ceil(float64(k)*excess/durationSeconds-1e-9))
+}
+
+// capacityBudgetFromAPI converts an API capacity budget into a model one
+func capacityBudgetFromAPI(b *api.CapacityBudget) models.CapacityBudget {
+	if b == nil {
+		return models.CapacityBudget{}
+	}
+	return models.CapacityBudget{
+		MaxUtilization:    b.MaxUtilization,
+		MaxQueueDepth:     b.MaxQueueDepth,
+		MaxBytesPerSecond: b.MaxBytesPerSecond,
+	}
+}
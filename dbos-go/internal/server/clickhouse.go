@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/clickhouse"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+)
+
+const (
+	// clickHouseSink names the ClickHouse exporter's cursors
+	clickHouseSink = "clickhouse"
+
+	// clickHouseLagMetric is the metric sample reporting, per agent, how
+	// many results were stored but not yet exported when a pass started
+	clickHouseLagMetric = "dbos_clickhouse_export_lag"
+
+	// clickHouseMaxAttempts is how often a batch is inserted before the
+	// pass gives up; the next pass starts over from the same results
+	clickHouseMaxAttempts = 5
+
+	// clickHouseRetryDelay is the wait before the first retry of a batch,
+	// doubling with each further one
+	clickHouseRetryDelay = time.Second
+
+	// maxClickHouseBatches caps the batches of one agent's results a pass
+	// exports, so a backlog does not starve other agents
+	maxClickHouseBatches = 100
+)
+
+// clickHouseColumns are the columns of every module's table
+var clickHouseColumns = []string{
+	"id String",
+	"agent_id String",
+	"module_name LowCardinality(String)",
+	"timestamp DateTime64(3, 'UTC')",
+	"origin LowCardinality(String)",
+	"sequence Int64",
+	"clock_offset_ms Float64",
+	"correlation_id String",
+	"data String",
+}
+
+// clickHouseColumnTypes maps extraction rule column types to ClickHouse
+// column types
+var clickHouseColumnTypes = map[models.ColumnTypeEnum]string{
+	models.ColumnTypeNumber: "Nullable(Float64)",
+	models.ColumnTypeString: "Nullable(String)",
+}
+
+// runClickHouseExport periodically exports newly stored results to
+// ClickHouse until ctx is done
+func (s *Server) runClickHouseExport(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// tables holds the columns of each module's table known to exist
+	tables := make(map[string]map[string]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.exportToClickHouse(ctx, now, tables); err != nil {
+				log.Printf("ClickHouse export: %v", err)
+			}
+		}
+	}
+}
+
+// exportToClickHouse inserts every agent's results stored since the last
+// pass, in sequence order, advancing the agent's cursor after each batch
+func (s *Server) exportToClickHouse(ctx context.Context, now time.Time, tables map[string]map[string]bool) error {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return err
+	}
+	cursors, err := s.exportStore.GetCursors(ctx, clickHouseSink)
+	if err != nil {
+		return err
+	}
+
+	samples := make([]metricSample, 0, len(agents))
+	for _, agent := range agents {
+		last, err := s.resultStore.LastResultSequence(ctx, agent.ID)
+		if err != nil {
+			return err
+		}
+		samples = append(samples, metricSample{
+			Name:      clickHouseLagMetric,
+			AgentID:   agent.ID,
+			Value:     float64(max(0, last-cursors[agent.ID])),
+			Timestamp: now,
+		})
+	}
+	s.exportSamples(samples)
+
+	for i, agent := range agents {
+		after := cursors[agent.ID]
+		if samples[i].Value == 0 {
+			continue
+		}
+		for batch := 0; batch < maxClickHouseBatches; batch++ {
+			results, err := s.resultStore.ListResultsAfter(ctx, agent.ID, after, s.config.ClickHouseBatchSize)
+			if err != nil {
+				return err
+			}
+			if len(results) == 0 {
+				break
+			}
+			if err := s.insertClickHouse(ctx, results, tables); err != nil {
+				return fmt.Errorf("results of agent %s after sequence %d: %w", agent.ID, after, err)
+			}
+			after = results[len(results)-1].Sequence
+			if err := s.exportStore.SetCursor(ctx, clickHouseSink, agent.ID, after); err != nil {
+				return err
+			}
+			if len(results) < s.config.ClickHouseBatchSize {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// insertClickHouse inserts results into their modules' tables, retrying a
+// failed insert with growing delays
+func (s *Server) insertClickHouse(ctx context.Context, results []*models.MeasurementResult, tables map[string]map[string]bool) error {
+	var modules []string
+	byModule := make(map[string][]*models.MeasurementResult)
+	for _, result := range results {
+		if _, ok := byModule[result.ModuleName]; !ok {
+			modules = append(modules, result.ModuleName)
+		}
+		byModule[result.ModuleName] = append(byModule[result.ModuleName], result)
+	}
+
+	for _, module := range modules {
+		rules, err := s.indexStore.ListRules(ctx, module)
+		if err != nil {
+			return err
+		}
+
+		delay := clickHouseRetryDelay
+		for attempt := 1; ; attempt++ {
+			err = s.ensureClickHouseTable(ctx, module, rules, tables)
+			if err == nil {
+				err = s.clickHouse.Insert(ctx, s.clickHouseTable(module), clickHouseRows(byModule[module], rules))
+			}
+			if err == nil {
+				break
+			}
+			if attempt == clickHouseMaxAttempts {
+				return err
+			}
+			log.Printf("ClickHouse export: inserting %d results of module %s failed (attempt %d), retrying in %s: %v",
+				len(byModule[module]), module, attempt, delay, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+	}
+	return nil
+}
+
+// clickHouseTable returns the table of a module's results, its name reduced
+// to letters, digits and underscores
+func (s *Server) clickHouseTable(module string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, module)
+	return s.config.ClickHouseTablePrefix + name
+}
+
+// ensureClickHouseTable creates a module's table and adds the columns of its
+// extraction rules it lacks
+func (s *Server) ensureClickHouseTable(ctx context.Context, module string, rules []*models.ExtractionRule, tables map[string]map[string]bool) error {
+	table := clickhouse.QuoteIdentifier(s.clickHouseTable(module))
+	columns, ok := tables[module]
+	if !ok {
+		// ReplacingMergeTree collapses results exported twice, e.g. after a
+		// retried batch, once parts merge. Rule columns are added below, as
+		// the table may predate some rules.
+		statement := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s) ENGINE = ReplacingMergeTree ORDER BY (agent_id, timestamp, id)",
+			table, strings.Join(clickHouseColumns, ", "))
+		if err := s.clickHouse.Exec(ctx, statement); err != nil {
+			return err
+		}
+		columns = make(map[string]bool)
+		tables[module] = columns
+	}
+
+	for _, rule := range rules {
+		typ, ok := clickHouseColumnTypes[models.ColumnTypeEnum(rule.Type)]
+		if !ok || columns[rule.Column] || isClickHouseBaseColumn(rule.Column) {
+			continue
+		}
+		statement := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s", table, clickhouse.QuoteIdentifier(rule.Column), typ)
+		if err := s.clickHouse.Exec(ctx, statement); err != nil {
+			return err
+		}
+		columns[rule.Column] = true
+	}
+	return nil
+}
+
+// isClickHouseBaseColumn reports whether a column is one of every table's,
+// which extraction rule columns cannot replace
+func isClickHouseBaseColumn(column string) bool {
+	for _, def := range clickHouseColumns {
+		if strings.HasPrefix(def, column+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// clickHouseRows converts results to rows of their module's table,
+// extracting the column of each of its extraction rules from their data
+func clickHouseRows(results []*models.MeasurementResult, rules []*models.ExtractionRule) []map[string]interface{} {
+	rows := make([]map[string]interface{}, len(results))
+	for i, result := range results {
+		row := map[string]interface{}{
+			"id":              result.ID,
+			"agent_id":        result.AgentID,
+			"module_name":     result.ModuleName,
+			"timestamp":       result.Timestamp.UTC().Format("2006-01-02 15:04:05.000"),
+			"origin":          result.Origin,
+			"sequence":        result.Sequence,
+			"clock_offset_ms": result.ClockOffsetMs,
+			"correlation_id":  result.CorrelationID,
+			"data":            string(result.Data),
+		}
+		for _, rule := range rules {
+			if isClickHouseBaseColumn(rule.Column) {
+				continue
+			}
+			value, found := jsonpath.Lookup(result.Data, rule.Path)
+			if !found || value == nil {
+				continue
+			}
+			switch models.ColumnTypeEnum(rule.Type) {
+			case models.ColumnTypeNumber:
+				if number, ok := jsonpath.ToFloat(value); ok {
+					row[rule.Column] = number
+				}
+			case models.ColumnTypeString:
+				if str, ok := value.(string); ok {
+					row[rule.Column] = str
+				} else if data, err := json.Marshal(value); err == nil {
+					row[rule.Column] = string(data)
+				}
+			}
+		}
+		rows[i] = row
+	}
+	return rows
+}
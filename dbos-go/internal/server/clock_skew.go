@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+)
+
+// clockModuleName is the module whose results measure an agent's clock offset
+const clockModuleName = "ntp_module"
+
+// normalizeTimestamp derives a result's timestamp from the time the agent
+// reported on its own clock, corrected by the agent's clock skew model
+func (s *Server) normalizeTimestamp(ctx context.Context, result *models.MeasurementResult, agentTimestampMs int64) {
+	if agentTimestampMs == 0 {
+		return
+	}
+
+	agentTime := time.UnixMilli(agentTimestampMs)
+	result.Timestamp = agentTime
+
+	skew, err := s.clockSkewStore.GetClockSkew(ctx, result.AgentID)
+	if err != nil || !skew.Fresh(time.Now()) {
+		return
+	}
+	result.Timestamp = skew.Normalize(agentTime)
+	result.ClockOffsetMs = skew.OffsetMs
+}
+
+// recordClockOffset feeds the offset measured by a clock module result into
+// the agent's clock skew model
+func (s *Server) recordClockOffset(ctx context.Context, result *models.MeasurementResult) {
+	if result.ModuleName != clockModuleName {
+		return
+	}
+
+	offset, ok := jsonpath.LookupFloat(result.Data, "offset_ms")
+	if !ok {
+		return
+	}
+	delay, _ := jsonpath.LookupFloat(result.Data, "delay_ms")
+
+	if _, err := s.clockSkewStore.RecordOffset(ctx, result.AgentID, offset, delay, time.Now()); err != nil {
+		log.Printf("Clock skew: recording offset for %s: %v", result.AgentID, err)
+	}
+}
+
+// GetClockSkew retrieves the clock skew model of an agent
+func (s *Server) GetClockSkew(ctx context.Context, req *api.GetClockSkewRequest) (*api.GetClockSkewResponse, error) {
+	skew, err := s.clockSkewStore.GetClockSkew(ctx, req.AgentId)
+	if err != nil {
+		return &api.GetClockSkewResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.GetClockSkewResponse{
+		Found: true,
+		Skew: &api.ClockSkew{
+			AgentId:   skew.AgentID,
+			OffsetMs:  skew.OffsetMs,
+			DelayMs:   skew.DelayMs,
+			Samples:   skew.Samples,
+			UpdatedAt: skew.UpdatedAt.Unix(),
+		},
+	}, nil
+}
@@ -0,0 +1,550 @@
+package server
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/rislive"
+)
+
+// Config holds the tunable settings of a DBOS server
+type Config struct {
+	// RedisAddr is the address of the Redis instance backing all stores
+	RedisAddr string
+
+	// RedisNamespace prefixes every Redis key and channel with
+	// "<namespace>:", so instances sharing one Redis do not collide; empty
+	// leaves keys unprefixed
+	RedisNamespace string
+
+	// DedupMinBytes enables deduplication of result payload fragments of at
+	// least this many bytes; zero disables deduplication
+	DedupMinBytes int
+
+	// BlobGCInterval is how often unreferenced payload fragments are deleted
+	BlobGCInterval time.Duration
+
+	// AgentLivenessWindow is how long an agent may go without a heartbeat
+	// before it is marked dead and its continuous tasks fail over
+	AgentLivenessWindow time.Duration
+
+	// HTTPPort enables the HTTP/1.1 JSON ingest fallback on this port; empty disables it
+	HTTPPort string
+
+	// GraphQLPort enables the GraphQL query endpoint on this port; empty disables it
+	GraphQLPort string
+
+	// StatusPort enables the unauthenticated public status page endpoint on
+	// this port; empty disables it
+	StatusPort string
+
+	// UIPort enables the operator web UI on this port; empty disables it
+	UIPort string
+
+	// TLSCertFile and TLSKeyFile enable TLS on the gRPC and HTTP ingest
+	// listeners with this PEM certificate and key; empty disables TLS
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// TLSClientCAFile requires clients to present a certificate signed by a
+	// CA in this PEM file. Agent-scoped writes are then only accepted for the
+	// agent named by the certificate CN.
+	TLSClientCAFile string
+
+	// TLSOperatorNames are client certificate CNs allowed to act for any agent
+	TLSOperatorNames []string
+
+	// TokenAuth requires an API token on every call but EnrollAgent: an
+	// agent token, which only allows the agent RPCs for its own agent, or
+	// one of AdminTokens, which allow everything
+	TokenAuth   bool
+	AdminTokens []string
+
+	// ManifestSigningKeyFile is the PEM PKCS #8 Ed25519 key the software
+	// manifest is signed with; empty disables GetManifest
+	ManifestSigningKeyFile string
+
+	// MetricFields selects the numeric result fields exported as time series
+	MetricFields []MetricField
+
+	// RemoteWriteURL enables pushing metric fields to a Prometheus
+	// remote-write endpoint; empty disables it
+	RemoteWriteURL string
+
+	// RemoteWriteInterval is how often buffered samples are pushed
+	RemoteWriteInterval time.Duration
+
+	// TrendsEnabled records metric fields into long-term per-day digests
+	TrendsEnabled bool
+
+	// TrendRollupInterval is how often finished days are compacted into digests
+	TrendRollupInterval time.Duration
+
+	// InfluxURL enables writing metric fields to this InfluxDB write URL as
+	// line protocol; empty disables it
+	InfluxURL string
+
+	// InfluxToken is the InfluxDB API token, if the write URL requires one
+	InfluxToken string
+
+	// InfluxInterval is how often buffered points are written to InfluxDB
+	InfluxInterval time.Duration
+
+	// ClickHouseURL enables exporting stored results to ClickHouse through
+	// its HTTP interface at this URL; empty disables it
+	ClickHouseURL string
+
+	// ClickHouseDatabase is the database results are exported into
+	ClickHouseDatabase string
+
+	// ClickHouseUser and ClickHousePassword authenticate to ClickHouse
+	ClickHouseUser     string
+	ClickHousePassword string
+
+	// ClickHouseTablePrefix is prepended to module names to name the table
+	// of each module's results
+	ClickHouseTablePrefix string
+
+	// ClickHouseBatchSize is the most results inserted in one request
+	ClickHouseBatchSize int
+
+	// ClickHouseInterval is how often newly stored results are exported
+	ClickHouseInterval time.Duration
+
+	// BusDriver enables publishing every stored result and logged state
+	// event to a message bus: BusKafka or BusNATS; empty disables it
+	BusDriver string
+
+	// BusServers are the Kafka brokers or NATS servers published to
+	BusServers []string
+
+	// BusUser and BusPassword authenticate to the bus, with SASL/PLAIN on
+	// Kafka; BusToken authenticates to NATS instead
+	BusUser     string
+	BusPassword string
+	BusToken    string
+
+	// BusJetStream waits for NATS JetStream to acknowledge storing each
+	// message instead of only for the server to receive it
+	BusJetStream bool
+
+	// BusResultsTopic is the topic, or NATS subject, results are published
+	// to; "{module}" in it is replaced by the result's module
+	BusResultsTopic string
+
+	// BusEventsTopic is the topic, or NATS subject, state events are
+	// published to; "{entity_type}" in it is replaced by the event's entity
+	// type
+	BusEventsTopic string
+
+	// BusBatchSize is the most messages published at once
+	BusBatchSize int
+
+	// BusOutboxMaxLen caps about how many messages wait to be published;
+	// beyond it the oldest are dropped
+	BusOutboxMaxLen int64
+
+	// CTLookupURL enables checking TLS module certificates against Certificate
+	// Transparency logs through this crt.sh-style search URL; empty disables it
+	CTLookupURL string
+
+	// RoutingPrefixes enables ingesting BGP updates from RIS Live for these
+	// prefixes and correlating them with probe anomalies; empty disables it
+	RoutingPrefixes []WatchedPrefix
+
+	// RISLiveURL is the RIS Live websocket URL BGP updates are streamed from
+	RISLiveURL string
+
+	// RoutingCorrelationWindow is how far apart a routing event and a probe
+	// anomaly may be to be linked in one incident
+	RoutingCorrelationWindow time.Duration
+
+	// AlertEvaluationInterval is how often alert rules are evaluated
+	AlertEvaluationInterval time.Duration
+
+	// AlertWebhookURL receives a JSON notification whenever a series of an
+	// alert rule fires or resolves; empty disables notifications
+	AlertWebhookURL string
+
+	// SimulatedAgents is how many in-process simulated agents generate ping
+	// and DNS results; 0 disables simulation
+	SimulatedAgents int
+
+	// SimulatedAgentInterval is how often each simulated agent measures
+	SimulatedAgentInterval time.Duration
+
+	// UpstreamAddr enables relay mode: agent RPCs are forwarded to the DBOS
+	// server at this address instead of being served locally, and results
+	// and module states are buffered in Redis while it is unreachable
+	UpstreamAddr string
+
+	// UpstreamTLSCAFile enables TLS to the upstream server, verifying it
+	// against the CAs in this PEM file
+	UpstreamTLSCAFile string
+
+	// UpstreamTLSCertFile and UpstreamTLSKeyFile are the client certificate
+	// the relay presents upstream; its CN must be an upstream operator name
+	UpstreamTLSCertFile string
+	UpstreamTLSKeyFile  string
+
+	// UpstreamToken is the API token a relay sends upstream; it must be an
+	// upstream admin token
+	UpstreamToken string
+
+	// RelayBufferLimit is how many requests a relay buffers at most while
+	// its upstream is unreachable; further ones are rejected
+	RelayBufferLimit int
+
+	// ShutdownTimeout is how long a shutting down server waits for the RPCs
+	// and HTTP requests in flight before cancelling them
+	ShutdownTimeout time.Duration
+
+	// TaskLeaseTimeout is how long an agent may hold a task lease without
+	// settling it before the task is requeued; with zero, only leases of
+	// tasks setting their own lease timeout, or extended, expire
+	TaskLeaseTimeout time.Duration
+
+	// TaskRequeueInterval is how often expired task leases are looked for
+	TaskRequeueInterval time.Duration
+
+	// TaskHungFactor ends the lease of a task running for longer than this
+	// multiple of its module's 99th percentile execution time before the
+	// lease timeout, as a hung task; zero disables it. It applies to the
+	// zset task queue and to modules with enough recorded executions.
+	TaskHungFactor float64
+
+	// TaskHungMinAge is the shortest time a task runs before it is hung
+	TaskHungMinAge time.Duration
+
+	// TaskDedupWindow is how long ScheduleTask remembers a dedup key, or a
+	// task ID given by the caller, to skip scheduling the task again
+	TaskDedupWindow time.Duration
+
+	// TaskQueue selects how leased tasks are tracked: TaskQueueZSet, the
+	// default, keeps them in a sorted set, and TaskQueueStreams delivers
+	// them through Redis Streams, counting their deliveries
+	TaskQueue string
+
+	// StreamResultsBatchSize is how many results of a StreamResults stream
+	// are stored at once at most
+	StreamResultsBatchSize int
+
+	// StreamResultsFlushInterval is how long results of a StreamResults
+	// stream wait at most for their batch to fill before it is stored
+	StreamResultsFlushInterval time.Duration
+
+	// EventSourcing records every agent and task mutation in an append-only
+	// log from which their state can be rebuilt
+	EventSourcing bool
+
+	// ResultRetention is how long results are kept, by measurement time;
+	// zero keeps them forever
+	ResultRetention time.Duration
+
+	// ModuleResultRetention overrides ResultRetention for the results of
+	// some modules; zero keeps a module's results forever
+	ModuleResultRetention map[string]time.Duration
+
+	// ResultMaxAge rejects results measured longer ago than this, unless
+	// stored by a backfill; zero accepts results of any age
+	ResultMaxAge time.Duration
+
+	// ResultRetentionInterval is how often expired results are deleted
+	ResultRetentionInterval time.Duration
+
+	// ResultArchiveURL enables archiving expired results before they are
+	// deleted, uploading them with PUT requests below this URL
+	ResultArchiveURL string
+
+	// ResultArchiveToken is sent as a bearer token to ResultArchiveURL
+	ResultArchiveToken string
+
+	// ResultArchiveAccessKey and ResultArchiveSecretKey sign requests to
+	// ResultArchiveURL for an S3-compatible bucket instead of a token
+	ResultArchiveAccessKey string
+	ResultArchiveSecretKey string
+
+	// ResultArchiveRegion is the region of the S3 bucket
+	ResultArchiveRegion string
+
+	// ResultArchiveFormat is the format of archived objects:
+	// ResultArchiveNDJSON, the default, or ResultArchiveParquet
+	ResultArchiveFormat string
+
+	// ResultArchiveAfter moves results older than this, by measurement
+	// time, from Redis to the archive, where GetResult still finds them;
+	// zero archives results only as they expire
+	ResultArchiveAfter time.Duration
+
+	// EventLogMaxEvents is how many of the newest events the event log
+	// keeps; zero keeps any number
+	EventLogMaxEvents int64
+
+	// EventLogRetention is how long events are kept in the event log;
+	// zero keeps them forever
+	EventLogRetention time.Duration
+
+	// EventLogTrimInterval is how often events beyond EventLogMaxEvents
+	// or EventLogRetention are trimmed from the event log, archived first
+	// if ResultArchiveURL is set
+	EventLogTrimInterval time.Duration
+
+	// BackpressureRedisLatency is the Redis round trip above which lease and
+	// ingest responses ask agents to back off; zero disables it
+	BackpressureRedisLatency time.Duration
+
+	// BackpressureInflightRequests is the number of RPCs in flight above
+	// which lease and ingest responses ask agents to back off; zero
+	// disables it
+	BackpressureInflightRequests int
+
+	// UsageFlushInterval is how often the RPCs counted per agent and API
+	// key are stored for GetUsage and exported as metrics; zero disables
+	// usage accounting
+	UsageFlushInterval time.Duration
+
+	// Quotas limit the API usage of each agent and API key; they require
+	// usage accounting
+	Quotas []Quota
+
+	// DriftCheckInterval is how often the campaigns and recurring tasks
+	// specs manage are compared with their definitions; zero disables
+	// drift detection
+	DriftCheckInterval time.Duration
+
+	// DriftRepair restores what specs manage to their definitions when
+	// drift is detected, rather than only reporting it
+	DriftRepair bool
+}
+
+// Task queues of Config.TaskQueue
+const (
+	TaskQueueZSet    = "zset"
+	TaskQueueStreams = "streams"
+)
+
+// Message bus drivers of Config.BusDriver
+const (
+	BusKafka = "kafka"
+	BusNATS  = "nats"
+)
+
+// Archive formats of Config.ResultArchiveFormat
+const (
+	// ResultArchiveNDJSON archives results as gzipped JSON lines
+	ResultArchiveNDJSON = "ndjson"
+	// ResultArchiveParquet archives results as Snappy-compressed Parquet
+	ResultArchiveParquet = "parquet"
+)
+
+// ParseModuleRetentions parses a comma-separated list of module=hours
+// entries, e.g. "ping_module=168,traceroute_module=720"; zero hours keeps a
+// module's results forever
+func ParseModuleRetentions(spec string) (map[string]time.Duration, error) {
+	retentions := make(map[string]time.Duration)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		module, hoursSpec, ok := strings.Cut(entry, "=")
+		if !ok || module == "" {
+			return nil, fmt.Errorf("module retention %q: expected module=hours", entry)
+		}
+		hours, err := strconv.Atoi(hoursSpec)
+		if err != nil || hours < 0 {
+			return nil, fmt.Errorf("module retention %q: invalid hours", entry)
+		}
+		retentions[module] = time.Duration(hours) * time.Hour
+	}
+	return retentions, nil
+}
+
+// Quota limits one kind of API usage of each agent and API key over the
+// quota's window, one of models.QuotaWindows
+type Quota struct {
+	// Name is the quota, e.g. models.QuotaStorage
+	Name string
+	// Limit rejects the calls counted by the quota once an agent or key
+	// has used this much in the window; zero only warns
+	Limit int64
+	// SoftLimit is the usage an agent or key is warned about at, before
+	// its calls are rejected; zero never warns
+	SoftLimit int64
+}
+
+// ParseQuotas parses a comma-separated list of name=limit[:soft_limit]
+// entries, e.g. "storage=1073741824,task_rate=1000:500"; without a soft
+// limit, it is 80% of the limit. A limit of zero only warns at the soft
+// limit.
+func ParseQuotas(spec string) ([]Quota, error) {
+	var quotas []Quota
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, limits, ok := strings.Cut(entry, "=")
+		if _, known := models.QuotaWindows[name]; !ok || !known {
+			return nil, fmt.Errorf("quota %q: expected storage, task_rate or bandwidth=limit", entry)
+		}
+		limitSpec, softSpec, hasSoft := strings.Cut(limits, ":")
+		limit, err := strconv.ParseInt(limitSpec, 10, 64)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("quota %q: invalid limit", entry)
+		}
+		quota := Quota{Name: name, Limit: limit, SoftLimit: int64(float64(limit) * models.DefaultQuotaSoftRatio)}
+		if hasSoft {
+			quota.SoftLimit, err = strconv.ParseInt(softSpec, 10, 64)
+			if err != nil || quota.SoftLimit < 0 || (limit > 0 && quota.SoftLimit > limit) {
+				return nil, fmt.Errorf("quota %q: invalid soft limit", entry)
+			}
+		}
+		if quota.Limit == 0 && quota.SoftLimit == 0 {
+			return nil, fmt.Errorf("quota %q: neither a limit nor a soft limit", entry)
+		}
+		quotas = append(quotas, quota)
+	}
+	return quotas, nil
+}
+
+// WatchedPrefix is a prefix whose BGP updates are ingested
+type WatchedPrefix struct {
+	Prefix netip.Prefix
+	// OriginASN is the expected origin; announcements from any other origin
+	// are hijacks. Zero disables hijack detection for the prefix.
+	OriginASN int64
+}
+
+// Covers reports whether p is the watched prefix or a more-specific of it
+func (w WatchedPrefix) Covers(p netip.Prefix) bool {
+	return p.Bits() >= w.Prefix.Bits() && w.Prefix.Contains(p.Addr())
+}
+
+// ParseWatchedPrefixes parses a comma-separated list of prefix[=origin_asn]
+// entries, e.g. "193.0.0.0/21=3333,2001:67c:2e8::/48"
+func ParseWatchedPrefixes(spec string) ([]WatchedPrefix, error) {
+	var prefixes []WatchedPrefix
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefixSpec, originSpec, hasOrigin := strings.Cut(entry, "=")
+		prefix, err := netip.ParsePrefix(prefixSpec)
+		if err != nil {
+			return nil, fmt.Errorf("watched prefix %q: %v", entry, err)
+		}
+		watched := WatchedPrefix{Prefix: prefix.Masked()}
+		if hasOrigin {
+			watched.OriginASN, err = strconv.ParseInt(strings.TrimPrefix(strings.ToUpper(originSpec), "AS"), 10, 64)
+			if err != nil || watched.OriginASN <= 0 {
+				return nil, fmt.Errorf("watched prefix %q: invalid origin ASN", entry)
+			}
+		}
+		prefixes = append(prefixes, watched)
+	}
+	return prefixes, nil
+}
+
+// MetricField maps a numeric field of a module's results to a time series
+type MetricField struct {
+	// Module is the module whose results carry the field
+	Module string
+	// Name is the exported metric name
+	Name string
+	// Path is the dotted JSON path of the value in result data
+	Path string
+	// TargetPath is the JSON path of the measured target, exported as the
+	// "target" label; empty omits the label
+	TargetPath string
+}
+
+// ParseMetricFields parses a comma-separated list of
+// module:name=path[@target_path] entries, e.g.
+// "ping:packets_received=packets_received@address"
+func ParseMetricFields(spec string) ([]MetricField, error) {
+	var fields []MetricField
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		module, rest, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("metric field %q: missing module", entry)
+		}
+		name, path, ok := strings.Cut(rest, "=")
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("metric field %q: expected name=path", entry)
+		}
+		path, targetPath, _ := strings.Cut(path, "@")
+
+		fields = append(fields, MetricField{
+			Module:     module,
+			Name:       name,
+			Path:       path,
+			TargetPath: targetPath,
+		})
+	}
+	return fields, nil
+}
+
+// DefaultConfig returns the default configuration for a Redis address
+func DefaultConfig(redisAddr string) Config {
+	return Config{
+		RedisAddr:           redisAddr,
+		BlobGCInterval:      10 * time.Minute,
+		AgentLivenessWindow: 30 * time.Second,
+		RemoteWriteInterval: 15 * time.Second,
+		InfluxInterval:      10 * time.Second,
+		TrendRollupInterval: time.Hour,
+
+		ClickHouseDatabase:    "default",
+		ClickHouseTablePrefix: "results_",
+		ClickHouseBatchSize:   1000,
+		ClickHouseInterval:    5 * time.Second,
+
+		BusResultsTopic: "dbos.results",
+		BusEventsTopic:  "dbos.events",
+		BusBatchSize:    100,
+		BusOutboxMaxLen: 1000000,
+
+		AlertEvaluationInterval: 30 * time.Second,
+		SimulatedAgentInterval:  30 * time.Second,
+
+		RISLiveURL:               rislive.DefaultURL,
+		RoutingCorrelationWindow: 15 * time.Minute,
+
+		RelayBufferLimit: 100000,
+		ShutdownTimeout:  30 * time.Second,
+
+		TaskLeaseTimeout:    10 * time.Minute,
+		TaskRequeueInterval: 30 * time.Second,
+		TaskHungMinAge:      time.Minute,
+		TaskDedupWindow:     24 * time.Hour,
+		TaskQueue:           TaskQueueZSet,
+
+		StreamResultsBatchSize:     500,
+		StreamResultsFlushInterval: time.Second,
+
+		ResultRetentionInterval: 10 * time.Minute,
+		ResultArchiveRegion:     "us-east-1",
+		ResultArchiveFormat:     ResultArchiveNDJSON,
+
+		EventLogMaxEvents:    1000000,
+		EventLogTrimInterval: time.Minute,
+
+		UsageFlushInterval: 10 * time.Second,
+
+		DriftCheckInterval: time.Minute,
+	}
+}
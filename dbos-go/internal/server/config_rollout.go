@@ -0,0 +1,309 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+)
+
+const (
+	// configRolloutInterval is how often in-progress rollouts are health-checked
+	configRolloutInterval = 10 * time.Second
+
+	// defaultStageDuration is how long a rollout stage runs before it is evaluated
+	defaultStageDuration = 10 * time.Minute
+)
+
+// StartConfigRollout starts a staged config rollout by applying the config to
+// the first (canary) cohort of target agents
+func (s *Server) StartConfigRollout(ctx context.Context, req *api.StartConfigRolloutRequest) (*api.StartConfigRolloutResponse, error) {
+	if req.Rollout == nil || len(req.Rollout.Config) == 0 {
+		return &api.StartConfigRolloutResponse{
+			Success:   false,
+			Error:     "rollout must change at least one config key",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+	if err := validateRolloutStages(req.Rollout.Stages); err != nil {
+		return &api.StartConfigRolloutResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	id := req.Rollout.Id
+	if id == "" {
+		id = ids.New("rollout-")
+	}
+	if _, err := s.configStore.GetRollout(ctx, id); err == nil {
+		return &api.StartConfigRolloutResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("rollout %s already exists", id),
+			ErrorCode: string(dberrors.AlreadyExists),
+		}, nil
+	}
+
+	rollout := models.NewConfigRollout(id, req.Rollout.Config, req.Rollout.Stages)
+	if req.Rollout.Selector != nil {
+		rollout.Selector = req.Rollout.Selector
+	}
+	rollout.MaxErrorRate = req.Rollout.MaxErrorRate
+	rollout.StageDurationSeconds = req.Rollout.StageDurationSeconds
+	if rollout.StageDurationSeconds <= 0 {
+		rollout.StageDurationSeconds = int64(defaultStageDuration / time.Second)
+	}
+
+	// Every target is validated up front, so a config its schema rejects is
+	// never pushed to the canary cohort either
+	targets, err := s.configTargets(ctx, rollout.Selector)
+	if err != nil {
+		return &api.StartConfigRolloutResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	validation, err := s.validateConfigChanges(ctx, targets, rollout.Config)
+	if err == nil {
+		err = validation.err()
+	}
+	if err != nil {
+		resp := &api.StartConfigRolloutResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}
+		if validation != nil {
+			resp.Violations = configViolationsToAPI(validation.violations)
+		}
+		return resp, nil
+	}
+
+	if err := s.applyRolloutStage(ctx, rollout, time.Now()); err != nil {
+		return &api.StartConfigRolloutResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.StartConfigRolloutResponse{
+		Success: true,
+		Rollout: rolloutToAPI(rollout),
+	}, nil
+}
+
+// GetConfigRollout retrieves a config rollout by ID
+func (s *Server) GetConfigRollout(ctx context.Context, req *api.GetConfigRolloutRequest) (*api.GetConfigRolloutResponse, error) {
+	rollout, err := s.configStore.GetRollout(ctx, req.RolloutId)
+	if err != nil {
+		return &api.GetConfigRolloutResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.GetConfigRolloutResponse{
+		Found:   true,
+		Rollout: rolloutToAPI(rollout),
+	}, nil
+}
+
+// RollbackConfigRollout restores the prior config on every agent the rollout updated
+func (s *Server) RollbackConfigRollout(ctx context.Context, req *api.RollbackConfigRolloutRequest) (*api.RollbackConfigRolloutResponse, error) {
+	rollout, err := s.configStore.GetRollout(ctx, req.RolloutId)
+	if err != nil {
+		return &api.RollbackConfigRolloutResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	if err := s.revertRollout(ctx, rollout, models.RolloutStatusRolledBack, "rolled back by operator"); err != nil {
+		return &api.RollbackConfigRolloutResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.RollbackConfigRolloutResponse{
+		Success: true,
+		Rollout: rolloutToAPI(rollout),
+	}, nil
+}
+
+// GetAgentConfig retrieves the current desired config version of an agent
+func (s *Server) GetAgentConfig(ctx context.Context, req *api.GetAgentConfigRequest) (*api.GetAgentConfigResponse, error) {
+	version, err := s.configStore.GetAgentConfig(ctx, req.AgentId)
+	if err != nil {
+		return &api.GetAgentConfigResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.GetAgentConfigResponse{
+		Found: true,
+		Config: &api.AgentConfigVersion{
+			AgentId:   version.AgentID,
+			Version:   version.Version,
+			Config:    version.Config,
+			RolloutId: version.RolloutID,
+			AppliedAt: version.AppliedAt.Unix(),
+		},
+	}, nil
+}
+
+// runConfigRollouts periodically advances or aborts in-progress rollouts until ctx is done
+func (s *Server) runConfigRollouts(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			rollouts, err := s.configStore.ListActiveRollouts(ctx)
+			if err != nil {
+				log.Printf("Config rollouts: %v", err)
+				continue
+			}
+			for _, rollout := range rollouts {
+				if err := s.evaluateRollout(ctx, rollout, now); err != nil {
+					log.Printf("Config rollout %s: %v", rollout.ID, err)
+				}
+			}
+		}
+	}
+}
+
+// evaluateRollout gates a rollout whose current stage has run its course:
+// an unhealthy cohort aborts and rolls back, a healthy one progresses
+func (s *Server) evaluateRollout(ctx context.Context, rollout *models.ConfigRollout, now time.Time) error {
+	if now.Sub(rollout.StageStartedAt) < rollout.StageDuration() {
+		return nil
+	}
+
+	rate, reported, err := s.configStore.ErrorRate(ctx, rollout.UpdatedAgents, rollout.StageStartedAt)
+	if err != nil {
+		return err
+	}
+	if reported > 0 && rate > rollout.MaxErrorRate {
+		reason := fmt.Sprintf("stage %d error rate %.3f exceeded %.3f", rollout.CurrentStage, rate, rollout.MaxErrorRate)
+		log.Printf("Config rollout %s: aborting, %s", rollout.ID, reason)
+		return s.revertRollout(ctx, rollout, models.RolloutStatusAborted, reason)
+	}
+
+	if int(rollout.CurrentStage) >= len(rollout.Stages)-1 {
+		rollout.Status = string(models.RolloutStatusCompleted)
+		return s.configStore.SaveRollout(ctx, rollout)
+	}
+
+	rollout.CurrentStage++
+	return s.applyRolloutStage(ctx, rollout, now)
+}
+
+// applyRolloutStage applies the rollout's config to the agents newly covered
+// by its current stage. Agents whose schema rejects the resulting config,
+// such as agents upgraded since the rollout started, are skipped.
+func (s *Server) applyRolloutStage(ctx context.Context, rollout *models.ConfigRollout, now time.Time) error {
+	targets, err := s.configTargets(ctx, rollout.Selector)
+	if err != nil {
+		return err
+	}
+
+	updated := make(map[string]bool, len(rollout.UpdatedAgents))
+	for _, agentID := range rollout.UpdatedAgents {
+		updated[agentID] = true
+	}
+
+	var cohort []*models.Agent
+	for _, agent := range targets[:rollout.CohortSize(rollout.CurrentStage, len(targets))] {
+		if !updated[agent.ID] {
+			cohort = append(cohort, agent)
+		}
+	}
+	validation, err := s.validateConfigChanges(ctx, cohort, rollout.Config)
+	if err != nil {
+		return err
+	}
+
+	for _, agent := range cohort {
+		if validation.invalid[agent.ID] {
+			log.Printf("Config rollout %s: agent %s rejects the config by its schema, skipped", rollout.ID, agent.ID)
+			continue
+		}
+		if _, err := s.configStore.ApplyConfig(ctx, agent.ID, rollout.Config, rollout.ID, now); err != nil {
+			return err
+		}
+		rollout.UpdatedAgents = append(rollout.UpdatedAgents, agent.ID)
+	}
+
+	rollout.StageStartedAt = now
+	return s.configStore.SaveRollout(ctx, rollout)
+}
+
+// revertRollout restores the prior config on every updated agent and ends the rollout
+func (s *Server) revertRollout(ctx context.Context, rollout *models.ConfigRollout, status models.RolloutStatusEnum, reason string) error {
+	for _, agentID := range rollout.UpdatedAgents {
+		reverted, err := s.configStore.RevertConfig(ctx, agentID, rollout.ID)
+		if err != nil {
+			return err
+		}
+		if !reverted {
+			log.Printf("Config rollout %s: agent %s has a newer config, not reverted", rollout.ID, agentID)
+		}
+	}
+
+	rollout.Status = string(status)
+	rollout.Reason = reason
+	return s.configStore.SaveRollout(ctx, rollout)
+}
+
+// validateRolloutStages checks stages are ascending percentages ending at 100
+func validateRolloutStages(stages []int32) error {
+	if len(stages) == 0 {
+		return dberrors.New(dberrors.InvalidArgument, "rollout needs at least one stage")
+	}
+	var prev int32
+	for _, pct := range stages {
+		if pct <= prev || pct > 100 {
+			return dberrors.New(dberrors.InvalidArgument, "rollout stages must be ascending percentages between 1 and 100")
+		}
+		prev = pct
+	}
+	if prev != 100 {
+		return dberrors.New(dberrors.InvalidArgument, "the last rollout stage must cover 100%% of agents")
+	}
+	return nil
+}
+
+// rolloutToAPI converts a model config rollout into an API config rollout
+func rolloutToAPI(rollout *models.ConfigRollout) *api.ConfigRollout {
+	return &api.ConfigRollout{
+		Id:                   rollout.ID,
+		Config:               rollout.Config,
+		Selector:             rollout.Selector,
+		Stages:               rollout.Stages,
+		CurrentStage:         rollout.CurrentStage,
+		MaxErrorRate:         rollout.MaxErrorRate,
+		StageDurationSeconds: rollout.StageDurationSeconds,
+		StageStartedAt:       rollout.StageStartedAt.Unix(),
+		Status:               rollout.Status,
+		UpdatedAgents:        rollout.UpdatedAgents,
+		Reason:               rollout.Reason,
+		CreatedAt:            rollout.CreatedAt.Unix(),
+	}
+}
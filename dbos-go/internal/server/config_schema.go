@@ -0,0 +1,288 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// SetConfigSchema stores the config schema of an agent version, replacing
+// any it had
+func (s *Server) SetConfigSchema(ctx context.Context, req *api.SetConfigSchemaRequest) (*api.SetConfigSchemaResponse, error) {
+	schema := configSchemaFromAPI(req.Schema)
+	schema.UpdatedAt = time.Now()
+	if err := s.configStore.SetSchema(ctx, schema); err != nil {
+		return &api.SetConfigSchemaResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.SetConfigSchemaResponse{
+		Success: true,
+		Schema:  configSchemaToAPI(schema),
+	}, nil
+}
+
+// ListConfigSchemas retrieves the config schemas of all agent versions
+func (s *Server) ListConfigSchemas(ctx context.Context, req *api.ListConfigSchemasRequest) (*api.ListConfigSchemasResponse, error) {
+	schemas, err := s.configStore.ListSchemas(ctx)
+	if err != nil {
+		return &api.ListConfigSchemasResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiSchemas := make([]*api.ConfigSchema, len(schemas))
+	for i, schema := range schemas {
+		apiSchemas[i] = configSchemaToAPI(schema)
+	}
+	return &api.ListConfigSchemasResponse{
+		Schemas: apiSchemas,
+	}, nil
+}
+
+// DeleteConfigSchema removes the config schema of an agent version
+func (s *Server) DeleteConfigSchema(ctx context.Context, req *api.DeleteConfigSchemaRequest) (*api.DeleteConfigSchemaResponse, error) {
+	if err := s.configStore.DeleteSchema(ctx, req.AgentVersion); err != nil {
+		return &api.DeleteConfigSchemaResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.DeleteConfigSchemaResponse{
+		Success: true,
+	}, nil
+}
+
+// ValidateConfig checks config changes against the schemas of the agents
+// they would be pushed to, without pushing them
+func (s *Server) ValidateConfig(ctx context.Context, req *api.ValidateConfigRequest) (*api.ValidateConfigResponse, error) {
+	var agents []*models.Agent
+	if req.AgentId != "" {
+		agent, err := s.agentStore.GetAgent(ctx, req.AgentId)
+		if err != nil {
+			return &api.ValidateConfigResponse{
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+		agents = []*models.Agent{agent}
+	} else {
+		var err error
+		agents, err = s.configTargets(ctx, req.Selector)
+		if err != nil {
+			return &api.ValidateConfigResponse{
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+	}
+
+	validation, err := s.validateConfigChanges(ctx, agents, req.Config)
+	if err != nil {
+		return &api.ValidateConfigResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.ValidateConfigResponse{
+		Valid:               len(validation.violations) == 0,
+		Violations:          configViolationsToAPI(validation.violations),
+		AgentsChecked:       int32(len(agents)),
+		AgentsWithoutSchema: int32(validation.withoutSchema),
+	}, nil
+}
+
+// configValidation is the outcome of validating config changes for agents
+type configValidation struct {
+	violations []*models.ConfigViolation
+	// invalid are the IDs of the agents whose resulting config is rejected
+	invalid       map[string]bool
+	withoutSchema int
+}
+
+// configTargets lists the agents matching a label selector, ordered by ID
+func (s *Server) configTargets(ctx context.Context, selector map[string]string) ([]*models.Agent, error) {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []*models.Agent
+	for _, agent := range agents {
+		if models.MatchesLabels(selector, agent.Labels) {
+			targets = append(targets, agent)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].ID < targets[j].ID })
+	return targets, nil
+}
+
+// validateConfigChanges merges changes into the desired config of each
+// agent and validates the result with the schema of the agent's version,
+// or the default schema. Agents without either are accepted.
+func (s *Server) validateConfigChanges(ctx context.Context, agents []*models.Agent, changes map[string]string) (*configValidation, error) {
+	schemas, err := s.configSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	validation := &configValidation{invalid: make(map[string]bool)}
+	byReason := make(map[string]*models.ConfigViolation)
+	for _, agent := range agents {
+		schema := agentConfigSchema(schemas, agent)
+		if schema == nil {
+			validation.withoutSchema++
+			continue
+		}
+
+		config, _, err := s.configStore.MergedConfig(ctx, agent.ID, changes)
+		if err != nil {
+			return nil, err
+		}
+		for key, reason := range schema.Validate(config) {
+			id := strings.Join([]string{schema.AgentVersion, key, reason}, "\x00")
+			violation, ok := byReason[id]
+			if !ok {
+				violation = &models.ConfigViolation{AgentVersion: schema.AgentVersion, Key: key, Reason: reason}
+				byReason[id] = violation
+				validation.violations = append(validation.violations, violation)
+			}
+			violation.AgentIDs = append(violation.AgentIDs, agent.ID)
+			validation.invalid[agent.ID] = true
+		}
+	}
+	models.SortConfigViolations(validation.violations)
+	return validation, nil
+}
+
+// validateAgentConfig validates an agent's whole config with the schema of
+// its version, or the default schema, returning an InvalidArgument error
+// naming the first rejected key
+func (s *Server) validateAgentConfig(ctx context.Context, agent *models.Agent) error {
+	schemas, err := s.configSchemas(ctx)
+	if err != nil {
+		return err
+	}
+	schema := agentConfigSchema(schemas, agent)
+	if schema == nil {
+		return nil
+	}
+
+	reasons := schema.Validate(agent.Config)
+	keys := make([]string, 0, len(reasons))
+	for key := range reasons {
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	sort.Strings(keys)
+	return dberrors.New(dberrors.InvalidArgument, "config rejected by the schema of agent version %q: key %s: %s",
+		schema.AgentVersion, keys[0], reasons[keys[0]])
+}
+
+// configSchemas retrieves the config schemas by agent version
+func (s *Server) configSchemas(ctx context.Context) (map[string]*models.ConfigSchema, error) {
+	schemaList, err := s.configStore.ListSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*models.ConfigSchema, len(schemaList))
+	for _, schema := range schemaList {
+		schemas[schema.AgentVersion] = schema
+	}
+	return schemas, nil
+}
+
+// agentConfigSchema picks the schema of an agent's version, or the default
+// schema, nil if there is neither
+func agentConfigSchema(schemas map[string]*models.ConfigSchema, agent *models.Agent) *models.ConfigSchema {
+	if schema, ok := schemas[agent.Labels[models.AgentVersionLabel]]; ok {
+		return schema
+	}
+	return schemas[""]
+}
+
+// err summarizes the violations as an InvalidArgument error, nil if none
+func (v *configValidation) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	first := v.violations[0]
+	msg := fmt.Sprintf("config rejected by the schema of %d agents: %s", len(v.invalid), first)
+	if len(v.violations) > 1 {
+		msg += fmt.Sprintf(" (and %d more violations)", len(v.violations)-1)
+	}
+	return dberrors.New(dberrors.InvalidArgument, "%s", msg)
+}
+
+// configSchemaFromAPI converts an API config schema into a model one
+func configSchemaFromAPI(s *api.ConfigSchema) *models.ConfigSchema {
+	schema := &models.ConfigSchema{
+		AgentVersion:     s.AgentVersion,
+		Keys:             make(map[string]models.ConfigKeySchema, len(s.Keys)),
+		AllowUnknownKeys: s.AllowUnknownKeys,
+	}
+	for key, k := range s.Keys {
+		if k == nil {
+			k = &api.ConfigKeySchema{}
+		}
+		schema.Keys[key] = models.ConfigKeySchema{
+			Type:     k.Type,
+			Required: k.Required,
+			Min:      k.Min,
+			Max:      k.Max,
+			Values:   k.Values,
+			Pattern:  k.Pattern,
+		}
+	}
+	return schema
+}
+
+// configSchemaToAPI converts a model config schema into an API one
+func configSchemaToAPI(schema *models.ConfigSchema) *api.ConfigSchema {
+	keys := make(map[string]*api.ConfigKeySchema, len(schema.Keys))
+	for key, k := range schema.Keys {
+		keys[key] = &api.ConfigKeySchema{
+			Type:     k.Type,
+			Required: k.Required,
+			Min:      k.Min,
+			Max:      k.Max,
+			Values:   k.Values,
+			Pattern:  k.Pattern,
+		}
+	}
+	return &api.ConfigSchema{
+		AgentVersion:     schema.AgentVersion,
+		Keys:             keys,
+		AllowUnknownKeys: schema.AllowUnknownKeys,
+		UpdatedAt:        schema.UpdatedAt.Unix(),
+	}
+}
+
+// configViolationsToAPI converts model config violations into API ones
+func configViolationsToAPI(violations []*models.ConfigViolation) []*api.ConfigViolation {
+	apiViolations := make([]*api.ConfigViolation, len(violations))
+	for i, v := range violations {
+		apiViolations[i] = &api.ConfigViolation{
+			AgentVersion: v.AgentVersion,
+			Key:          v.Key,
+			Reason:       v.Reason,
+			AgentIds:     v.AgentIDs,
+		}
+	}
+	return apiViolations
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// continuousSchedulerInterval is how often due continuous tasks are re-issued
+const continuousSchedulerInterval = 5 * time.Second
+
+// runContinuousScheduler periodically re-issues due continuous tasks until ctx is done
+func (s *Server) runContinuousScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.issueContinuousTasks(ctx, now); err != nil {
+				log.Printf("Continuous scheduler: %v", err)
+			}
+		}
+	}
+}
+
+// issueContinuousTasks schedules one instance of every due continuous task,
+// failing the assignment over to another live agent running its module and
+// matching its failover selector if the assignee is dead.
+// Tasks paused by a maintenance window skip their instance. Due group tasks
+// issue instances to the agents of their group.
+func (s *Server) issueContinuousTasks(ctx context.Context, now time.Time) error {
+	tasks, err := s.taskStore.ListDueContinuousTasks(ctx, now)
+	if err != nil {
+		return err
+	}
+	windows, err := s.maintenanceStore.ListActiveWindows(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if task.Status == string(models.TaskStatusCancelled) {
+			continue
+		}
+		if task.IsGroup() {
+			if err := s.issueGroupTask(ctx, windows, task, now); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if window := s.taskPaused(ctx, windows, task); window != nil {
+			log.Printf("Continuous task %s: paused by maintenance window %s (%s)", task.ID, window.ID, window.Name)
+			if err := s.taskStore.RescheduleContinuousTask(ctx, task, now.Add(task.Interval())); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !s.agentIsLive(ctx, task.AgentID, now) {
+			issued, err := s.failOver(ctx, task, now)
+			if err != nil || !issued {
+				return err
+			}
+		}
+
+		instance := models.NewTask(fmt.Sprintf("%s-%d", task.ID, now.Unix()), task.AgentID, task.ModuleName, task.Payload, now)
+		instance.ParentID = task.ID
+		instance.CorrelationID = task.CorrelationID
+		instance.LeaseTimeoutSeconds = task.LeaseTimeoutSeconds
+		instance.RetryPolicy = task.RetryPolicy
+		if err := s.taskStore.ScheduleTask(ctx, instance); err != nil {
+			return err
+		}
+
+		if err := s.taskStore.RescheduleContinuousTask(ctx, task, now.Add(task.Interval())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// agentIsLive reports whether an agent is alive and was seen recently
+func (s *Server) agentIsLive(ctx context.Context, agentID string, now time.Time) bool {
+	agent, err := s.agentStore.GetAgent(ctx, agentID)
+	if err != nil {
+		return false
+	}
+	return agent.Alive && now.Sub(agent.LastSeen) <= s.config.AgentLivenessWindow
+}
+
+// failOver assigns a continuous task whose agent is not live to another
+// live agent matching it, reporting whether the task can be issued. With
+// no such agent the task is left unassigned until the next interval.
+func (s *Server) failOver(ctx context.Context, task *models.Task, now time.Time) (bool, error) {
+	agentID, err := s.pickFailoverAgent(ctx, task, now)
+	if err != nil {
+		return false, err
+	}
+	failover := &models.TaskFailover{
+		TaskID:      task.ID,
+		ModuleName:  task.ModuleName,
+		FromAgentID: task.AgentID,
+		ToAgentID:   agentID,
+	}
+
+	if agentID == "" {
+		if task.AgentID != "" {
+			log.Printf("Continuous task %s: no live agent runs %s and matches its failover selector, unassigned from %s", task.ID, task.ModuleName, task.AgentID)
+			// The event is filed under the agent that left the task
+			assigned := *task
+			task.AgentID = ""
+			if err := s.taskStore.UpdateTask(ctx, task); err != nil {
+				return false, err
+			}
+			s.recordTaskChange(ctx, models.EventTaskUnassigned, models.EventSeverityWarning, &assigned, failover)
+		}
+		return false, s.taskStore.RescheduleContinuousTask(ctx, task, now.Add(task.Interval()))
+	}
+
+	log.Printf("Continuous task %s: failing over from %s to %s", task.ID, task.AgentID, agentID)
+	task.AgentID = agentID
+	if err := s.taskStore.UpdateTask(ctx, task); err != nil {
+		return false, err
+	}
+	s.recordTaskChange(ctx, models.EventTaskFailedOver, models.EventSeverityWarning, task, failover)
+	return true, nil
+}
+
+// pickFailoverAgent selects the most recently seen live agent that runs a
+// task's module and matches its failover selector, other than its own
+// agent, or returns "" if there is none
+func (s *Server) pickFailoverAgent(ctx context.Context, task *models.Task, now time.Time) (string, error) {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var best *models.Agent
+	for _, agent := range agents {
+		if agent.ID == task.AgentID || !agent.Alive || now.Sub(agent.LastSeen) > s.config.AgentLivenessWindow {
+			continue
+		}
+		if !agent.RunsModule(task.ModuleName) || !models.MatchesLabels(task.FailoverSelector, agent.Labels) {
+			continue
+		}
+		if best == nil || agent.LastSeen.After(best.LastSeen) {
+			best = agent
+		}
+	}
+	if best == nil {
+		return "", nil
+	}
+	return best.ID, nil
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/ct"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+)
+
+// tlsModuleName is the module whose results carry observed TLS certificates
+const tlsModuleName = "tls_module"
+
+const (
+	// ctCheckTimeout bounds the CT lookups of one result
+	ctCheckTimeout = 30 * time.Second
+	// ctLoggedTTL is how long a certificate found in CT logs is trusted to stay there
+	ctLoggedTTL = 30 * 24 * time.Hour
+	// ctUnloggedTTL is how long a miss is cached; logs lag issuance, so it is rechecked
+	ctUnloggedTTL = time.Hour
+)
+
+// checkCertificates cross-checks the leaf certificate observed by a TLS
+// module result against its host name and CT logs, raising an interception
+// alert if it is unlogged or does not match the host
+func (s *Server) checkCertificates(result *models.MeasurementResult) {
+	ctx, cancel := context.WithTimeout(context.Background(), ctCheckTimeout)
+	defer cancel()
+
+	chain, ok := jsonpath.Lookup(result.Data, "certificates")
+	if !ok {
+		return
+	}
+	certs, ok := chain.([]interface{})
+	if !ok || len(certs) == 0 {
+		return
+	}
+	encoded, ok := certs[0].(string)
+	if !ok {
+		return
+	}
+	leaf, err := ct.ParseCertificate(encoded)
+	if err != nil {
+		log.Printf("CT check: result %s: %v", result.ID, err)
+		return
+	}
+
+	var host string
+	if v, ok := jsonpath.Lookup(result.Data, "host"); ok {
+		host, _ = v.(string)
+	}
+
+	var reasons []string
+	if host != "" && leaf.VerifyHostname(host) != nil {
+		reasons = append(reasons, "hostname_mismatch")
+	}
+
+	logged, err := s.certificateLogged(ctx, leaf)
+	if err != nil {
+		// Without a verdict nothing is flagged rather than alerting on outages
+		log.Printf("CT check: result %s: %v", result.ID, err)
+	} else if !logged {
+		reasons = append(reasons, "unlogged")
+	}
+
+	if len(reasons) == 0 {
+		return
+	}
+
+	fingerprint := ct.Fingerprint(leaf)
+	alert := &models.Alert{
+		Type:       string(models.AlertTypeTLSInterception),
+		AgentID:    result.AgentID,
+		ResultID:   result.ID,
+		ModuleName: result.ModuleName,
+		Target:     host,
+		Reason:     strings.Join(reasons, ","),
+		Details: map[string]string{
+			"fingerprint_sha256": hex.EncodeToString(fingerprint[:]),
+			"subject":            leaf.Subject.String(),
+			"issuer":             leaf.Issuer.String(),
+			"not_before":         leaf.NotBefore.UTC().Format(time.RFC3339),
+		},
+	}
+	if err := s.raiseAlert(ctx, alert); err != nil {
+		log.Printf("CT check: recording alert for result %s: %v", result.ID, err)
+	}
+}
+
+// certificateLogged reports whether a certificate is in CT logs. Embedded
+// SCTs are taken as proof without verifying their signatures; otherwise the
+// CT search service is consulted and its verdict cached.
+func (s *Server) certificateLogged(ctx context.Context, cert *x509.Certificate) (bool, error) {
+	if ct.HasEmbeddedSCTs(cert) {
+		return true, nil
+	}
+
+	fingerprint := ct.Fingerprint(cert)
+	key := hex.EncodeToString(fingerprint[:])
+	if logged, found, err := s.alertStore.CTVerdict(ctx, key); err == nil && found {
+		return logged, nil
+	}
+
+	logged, err := s.ct.Logged(ctx, fingerprint)
+	if err != nil {
+		return false, err
+	}
+
+	ttl := ctUnloggedTTL
+	if logged {
+		ttl = ctLoggedTTL
+	}
+	if err := s.alertStore.SaveCTVerdict(ctx, key, logged, ttl); err != nil {
+		log.Printf("CT check: caching verdict for %s: %v", key, err)
+	}
+	return logged, nil
+}
+
+// raiseAlert assigns an alert an ID and creation time, then logs and stores
+// it, unless a maintenance window in progress covers its agent or target
+func (s *Server) raiseAlert(ctx context.Context, alert *models.Alert) error {
+	if window := s.alertSuppressed(ctx, alert.AgentID, alert.Target, time.Now()); window != nil {
+		log.Printf("Alert %s on agent %s for %s suppressed by maintenance window %s (%s)", alert.Type, alert.AgentID, alert.Target, window.ID, window.Name)
+		return nil
+	}
+
+	alert.ID = ids.New("alert-")
+	alert.CreatedAt = time.Now()
+
+	log.Printf("Alert %s: %s on agent %s for %s (%s)", alert.ID, alert.Type, alert.AgentID, alert.Target, alert.Reason)
+	return s.alertStore.RecordAlert(ctx, alert)
+}
+
+// ListAlerts retrieves recent alerts, newest first
+func (s *Server) ListAlerts(ctx context.Context, req *api.ListAlertsRequest) (*api.ListAlertsResponse, error) {
+	alerts, err := s.alertStore.ListAlerts(ctx, req.AgentId, req.Type, int(req.Limit))
+	if err != nil {
+		return &api.ListAlertsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiAlerts := make([]*api.Alert, len(alerts))
+	for i, alert := range alerts {
+		apiAlerts[i] = &api.Alert{
+			Id:         alert.ID,
+			Type:       alert.Type,
+			AgentId:    alert.AgentID,
+			ResultId:   alert.ResultID,
+			ModuleName: alert.ModuleName,
+			Target:     alert.Target,
+			Reason:     alert.Reason,
+			Details:    alert.Details,
+			CreatedAt:  alert.CreatedAt.Unix(),
+		}
+	}
+
+	return &api.ListAlertsResponse{
+		Alerts: apiAlerts,
+	}, nil
+}
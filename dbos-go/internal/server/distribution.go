@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/manifest"
+)
+
+// loadManifestKey reads the key the software manifest is signed with, if
+// one is configured
+func (s *Server) loadManifestKey() error {
+	if s.config.ManifestSigningKeyFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.config.ManifestSigningKeyFile)
+	if err != nil {
+		return fmt.Errorf("reading manifest signing key: %w", err)
+	}
+	key, err := manifest.ParsePrivateKey(data)
+	if err != nil {
+		return fmt.Errorf("parsing manifest signing key %s: %w", s.config.ManifestSigningKeyFile, err)
+	}
+	s.manifestKey = key
+	log.Printf("Signing software manifests with key %s", manifest.KeyID(key.Public().(ed25519.PublicKey)))
+	return nil
+}
+
+// PublishArtifact lists an agent or module binary in the software manifest
+func (s *Server) PublishArtifact(ctx context.Context, req *api.PublishArtifactRequest) (*api.PublishArtifactResponse, error) {
+	artifact := artifactFromAPI(req.Artifact)
+	artifact.PublishedAt = time.Now()
+	if err := s.artifactStore.PublishArtifact(ctx, artifact); err != nil {
+		return &api.PublishArtifactResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	log.Printf("Artifact %s version %s published with SHA-256 %s", artifact.Name, artifact.Version, artifact.SHA256)
+	return &api.PublishArtifactResponse{
+		Success: true,
+	}, nil
+}
+
+// UnpublishArtifact removes an artifact version from the software manifest
+func (s *Server) UnpublishArtifact(ctx context.Context, req *api.UnpublishArtifactRequest) (*api.UnpublishArtifactResponse, error) {
+	if err := s.artifactStore.UnpublishArtifact(ctx, req.Name, req.Version); err != nil {
+		return &api.UnpublishArtifactResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	return &api.UnpublishArtifactResponse{
+		Success: true,
+	}, nil
+}
+
+// GetManifest returns the software manifest of the published artifacts,
+// signed with the server's key. Without a signing key it fails rather than
+// hand out an unsigned manifest.
+func (s *Server) GetManifest(ctx context.Context, req *api.GetManifestRequest) (*api.GetManifestResponse, error) {
+	if s.manifestKey == nil {
+		return &api.GetManifestResponse{
+			Error:     "no manifest signing key is configured",
+			ErrorCode: string(dberrors.FailedPrecondition),
+		}, nil
+	}
+
+	artifacts, err := s.artifactStore.ListArtifacts(ctx)
+	if err != nil {
+		return &api.GetManifestResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	m := &manifest.Manifest{
+		Artifacts: make([]manifest.Artifact, len(artifacts)),
+		IssuedAt:  time.Now().UTC(),
+	}
+	for i, artifact := range artifacts {
+		m.Artifacts[i] = manifest.Artifact{
+			Name:    artifact.Name,
+			Version: artifact.Version,
+			Kind:    artifact.Kind,
+			URL:     artifact.URL,
+			SHA256:  artifact.SHA256,
+			Size:    artifact.Size,
+		}
+	}
+	signed, err := manifest.Sign(s.manifestKey, m)
+	if err != nil {
+		return &api.GetManifestResponse{
+			Error:     err.Error(),
+			ErrorCode: string(dberrors.Internal),
+		}, nil
+	}
+
+	return &api.GetManifestResponse{
+		Manifest:  signed.Manifest,
+		Signature: signed.Signature,
+		KeyId:     signed.KeyID,
+	}, nil
+}
+
+// artifactFromAPI converts an API artifact into a model artifact
+func artifactFromAPI(a *api.Artifact) *models.Artifact {
+	return &models.Artifact{
+		Name:    a.Name,
+		Version: a.Version,
+		Kind:    a.Kind,
+		URL:     a.Url,
+		SHA256:  a.Sha256,
+		Size:    a.Size,
+	}
+}
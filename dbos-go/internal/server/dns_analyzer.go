@@ -0,0 +1,224 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+)
+
+// dnsModuleName is the module whose results carry DNS answers
+const dnsModuleName = "dns_module"
+
+const (
+	// dnsPeerWindow is how recent another region's answer must be to be compared against
+	dnsPeerWindow = 24 * time.Hour
+	// dnsMinPeers is how many other regions must agree before a region
+	// disagreeing with all of them is flagged
+	dnsMinPeers = 2
+)
+
+// analyzeDNS compares the DNS answer of a result against the authoritative
+// answers and the answers seen from other regions, opening a DNS
+// manipulation incident for the agent's region when it diverges and
+// resolving it once the region's answers are consistent again
+func (s *Server) analyzeDNS(ctx context.Context, result *models.MeasurementResult) {
+	if result.ModuleName != dnsModuleName {
+		return
+	}
+
+	name, _ := lookupString(result.Data, "query")
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if name == "" {
+		return
+	}
+	qtype, _ := lookupString(result.Data, "type")
+	qtype = strings.ToUpper(qtype)
+	if qtype == "" {
+		qtype = "A"
+	}
+	rcode, _ := lookupString(result.Data, "rcode")
+	rcode = strings.ToUpper(rcode)
+	if rcode == "" {
+		rcode = "NOERROR"
+	}
+	var answers []string
+	if v, ok := jsonpath.Lookup(result.Data, "answers"); ok {
+		list, _ := v.([]interface{})
+		for _, answer := range list {
+			if answer, ok := answer.(string); ok {
+				answers = append(answers, answer)
+			}
+		}
+	}
+	answers = models.NormalizeDNSAnswers(answers)
+	query := qtype + ":" + name
+	now := time.Now()
+
+	// Answers from the authoritative servers set the expectation rather than
+	// being a vantage point's view
+	if v, ok := jsonpath.Lookup(result.Data, "authoritative"); ok && v == true {
+		if rcode != "NOERROR" || len(answers) == 0 {
+			return
+		}
+		expectation := &models.DNSExpectation{
+			Answers:   answers,
+			AgentID:   result.AgentID,
+			UpdatedAt: now,
+		}
+		if err := s.dnsStore.SetExpectation(ctx, query, expectation); err != nil {
+			log.Printf("DNS analyzer: storing expectation for %s: %v", query, err)
+		}
+		return
+	}
+
+	region := s.agentRegion(ctx, result.AgentID)
+	observation := &models.DNSObservation{
+		Region:     region,
+		AgentID:    result.AgentID,
+		ResultID:   result.ID,
+		Rcode:      rcode,
+		Answers:    answers,
+		ObservedAt: now,
+	}
+	if err := s.dnsStore.RecordObservation(ctx, query, observation); err != nil {
+		log.Printf("DNS analyzer: recording %s from %s: %v", query, region, err)
+		return
+	}
+
+	reasons, evidence, err := s.dnsDivergence(ctx, query, observation)
+	if err != nil {
+		log.Printf("DNS analyzer: %s from %s: %v", query, region, err)
+		return
+	}
+
+	key := fmt.Sprintf("%s:%s:%s", models.IncidentTypeDNSManipulation, query, region)
+	if len(reasons) == 0 {
+		err = s.resolveIncident(ctx, key)
+	} else {
+		err = s.raiseIncident(ctx, models.IncidentTypeDNSManipulation, key, region, query, result.AgentID, result.ID, strings.Join(reasons, ","), evidence)
+	}
+	if err != nil {
+		log.Printf("DNS analyzer: incident %s: %v", key, err)
+	}
+}
+
+// dnsDivergence explains how an observation diverges from the expected
+// answers of its query or, without an expectation, from the answers every
+// other recently reporting region agrees on
+func (s *Server) dnsDivergence(ctx context.Context, query string, observation *models.DNSObservation) ([]string, map[string]string, error) {
+	var reasons []string
+	evidence := map[string]string{
+		"rcode":   observation.Rcode,
+		"answers": strings.Join(observation.Answers, " "),
+	}
+	answered := observation.Rcode == "NOERROR" && len(observation.Answers) > 0
+
+	var expected []string
+	if expectation, err := s.dnsStore.GetExpectation(ctx, query); err == nil {
+		expected = expectation.Answers
+		evidence["expected"] = strings.Join(expected, " ")
+	}
+
+	// Censors commonly answer with addresses that cannot be the real server
+	for _, answer := range observation.Answers {
+		addr, err := netip.ParseAddr(answer)
+		if err != nil || models.AnswersOverlap([]string{answer}, expected) {
+			continue
+		}
+		if addr.IsPrivate() || addr.IsLoopback() || addr.IsUnspecified() || addr.IsLinkLocalUnicast() || addr.IsMulticast() {
+			reasons = append(reasons, "bogon_answer")
+			break
+		}
+	}
+
+	if len(expected) > 0 {
+		if !answered {
+			reasons = append(reasons, "unexpected_rcode")
+		} else if !models.AnswersOverlap(observation.Answers, expected) {
+			reasons = append(reasons, "unexpected_answers")
+		}
+		return reasons, evidence, nil
+	}
+
+	observations, err := s.dnsStore.ListObservations(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	var peers []*models.DNSObservation
+	for _, peer := range observations {
+		if peer.Region == observation.Region || peer.Rcode != "NOERROR" || len(peer.Answers) == 0 {
+			continue
+		}
+		if observation.ObservedAt.Sub(peer.ObservedAt) > dnsPeerWindow {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+	if len(peers) < dnsMinPeers || !dnsPeersAgree(peers) {
+		// Without a consensus, e.g. for geo-balanced names, regions differing
+		// is expected
+		return reasons, evidence, nil
+	}
+
+	evidence["peer_regions"] = fmt.Sprint(len(peers))
+	diverges := !answered
+	if answered {
+		diverges = true
+		for _, peer := range peers {
+			if models.AnswersOverlap(observation.Answers, peer.Answers) {
+				diverges = false
+				break
+			}
+		}
+	}
+	if diverges {
+		reasons = append(reasons, "divergent_from_peers")
+	}
+	return reasons, evidence, nil
+}
+
+// dnsPeersAgree reports whether every pair of observations shares an answer
+func dnsPeersAgree(peers []*models.DNSObservation) bool {
+	for i := range peers {
+		for j := i + 1; j < len(peers); j++ {
+			if !models.AnswersOverlap(peers[i].Answers, peers[j].Answers) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// agentRegion returns the region label of an agent, or its ID if it has none,
+// so every unlabeled agent counts as its own vantage point
+func (s *Server) agentRegion(ctx context.Context, agentID string) string {
+	if region := s.agentRegionLabel(ctx, agentID); region != "" {
+		return region
+	}
+	return agentID
+}
+
+// agentRegionLabel returns the region label of an agent, or "" if it has none
+func (s *Server) agentRegionLabel(ctx context.Context, agentID string) string {
+	agent, err := s.agentStore.GetAgent(ctx, agentID)
+	if err != nil {
+		return ""
+	}
+	return agent.Labels["region"]
+}
+
+// lookupString returns the string at a JSON path of result data
+func lookupString(data []byte, path string) (string, bool) {
+	v, ok := jsonpath.Lookup(data, path)
+	if !ok {
+		return "", false
+	}
+	str, ok := v.(string)
+	return str, ok
+}
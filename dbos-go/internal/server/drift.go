@@ -0,0 +1,302 @@
+package server
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// driftFinding is drift found in a campaign or recurring task a spec
+// manages, with how to repair it
+type driftFinding struct {
+	drift  *models.Drift
+	repair func(ctx context.Context, now time.Time) error
+}
+
+// runDriftDetector periodically detects, and repairs if enabled, drift in
+// what specs manage until ctx is done
+func (s *Server) runDriftDetector(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.reconcileDrift(ctx, now); err != nil {
+				log.Printf("Drift detection: %v", err)
+			}
+		}
+	}
+}
+
+// reconcileDrift detects drift, recording an event the first time each
+// drift is found, and with DriftRepair repairs it. The drift left is kept
+// for GetDrift.
+func (s *Server) reconcileDrift(ctx context.Context, now time.Time) error {
+	findings, err := s.detectDrift(ctx, now)
+	if err != nil {
+		return err
+	}
+	reported, err := s.specStore.GetDrift(ctx)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]*models.Drift, 0, len(findings))
+	for _, finding := range findings {
+		drift := finding.drift
+		if previous, ok := reported[drift.Key()]; ok {
+			drift.DetectedAt = previous.DetectedAt
+		} else {
+			drift.DetectedAt = now
+			log.Printf("Drift: %s %s is %s", drift.Kind, drift.Name, drift.Reason)
+			s.recordDriftEvent(ctx, models.EventDriftDetected, models.EventSeverityWarning, drift)
+		}
+
+		if !s.config.DriftRepair {
+			remaining = append(remaining, drift)
+			continue
+		}
+		if err := finding.repair(ctx, now); err != nil {
+			log.Printf("Drift: repairing %s %s: %v", drift.Kind, drift.Name, err)
+			remaining = append(remaining, drift)
+			continue
+		}
+		log.Printf("Drift: repaired %s %s, which was %s", drift.Kind, drift.Name, drift.Reason)
+		s.recordDriftEvent(ctx, models.EventDriftRepaired, models.EventSeverityInfo, drift)
+	}
+	return s.specStore.SaveDrift(ctx, remaining)
+}
+
+// detectDrift compares the campaigns and recurring tasks specs manage with
+// the definitions ApplySpec last applied for them
+func (s *Server) detectDrift(ctx context.Context, now time.Time) ([]driftFinding, error) {
+	campaigns, err := s.specStore.ListManagedCampaigns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.specStore.ListManagedTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []driftFinding
+	for _, want := range campaigns {
+		found, err := s.campaignDrift(ctx, want, now)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+	for _, want := range tasks {
+		found, err := s.taskDrift(ctx, want)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+	return findings, nil
+}
+
+// campaignDrift finds how a managed campaign drifted: it is missing, was
+// stopped, ran out of rounds although it runs until stopped, or its
+// definition changed. A campaign past its end is left alone.
+func (s *Server) campaignDrift(ctx context.Context, want *models.Campaign, now time.Time) ([]driftFinding, error) {
+	if !want.EndsAt.IsZero() && !want.EndsAt.After(now) {
+		return nil, nil
+	}
+
+	campaign, err := s.campaignStore.GetCampaign(ctx, want.ID)
+	if dberrors.CodeOf(err) == dberrors.NotFound {
+		return []driftFinding{{
+			drift: newDrift(specKindCampaign, want.ID, models.DriftMissing, nil),
+			repair: func(ctx context.Context, now time.Time) error {
+				recreated := *want
+				recreated.CreatedAt = now
+				if recreated.StartsAt.Before(now) {
+					recreated.StartsAt = now
+				}
+				return s.campaignStore.CreateCampaign(ctx, &recreated)
+			},
+		}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []driftFinding
+	resume := func(ctx context.Context, now time.Time) error {
+		return s.campaignStore.ResumeCampaign(ctx, want.ID, now)
+	}
+	switch models.CampaignStatusEnum(campaign.Status) {
+	case models.CampaignStatusStopped:
+		findings = append(findings, driftFinding{
+			drift:  newDrift(specKindCampaign, want.ID, models.DriftStopped, nil),
+			repair: resume,
+		})
+	case models.CampaignStatusCompleted:
+		// Campaigns of one round, or with an end, complete on their own
+		if want.IntervalSeconds > 0 && want.EndsAt.IsZero() {
+			findings = append(findings, driftFinding{
+				drift:  newDrift(specKindCampaign, want.ID, models.DriftNotScheduled, nil),
+				repair: resume,
+			})
+		}
+	}
+	if fields := campaignChanges(campaign, want); len(fields) > 0 {
+		findings = append(findings, driftFinding{
+			drift: newDrift(specKindCampaign, want.ID, models.DriftChanged, fields),
+			repair: func(ctx context.Context, now time.Time) error {
+				current, err := s.campaignStore.GetCampaign(ctx, want.ID)
+				if err != nil {
+					return err
+				}
+				return s.campaignStore.UpdateCampaign(ctx, withCampaignDefinition(current, want))
+			},
+		})
+	}
+	return findings, nil
+}
+
+// taskDrift finds how a managed recurring task drifted: it is missing, was
+// cancelled, is no longer re-issued or its definition changed. An agent
+// taken over by failover is not drift, and is kept by a repair.
+func (s *Server) taskDrift(ctx context.Context, want *models.Task) ([]driftFinding, error) {
+	reschedule := func(ctx context.Context, now time.Time) error {
+		task := *want
+		task.Status = string(models.TaskStatusPending)
+		task.ScheduledAt = now
+		task.CreatedAt = now
+		return s.scheduleRecurringTask(ctx, &task)
+	}
+
+	task, err := s.taskStore.GetTask(ctx, want.ID)
+	if dberrors.CodeOf(err) == dberrors.NotFound {
+		return []driftFinding{{
+			drift:  newDrift(specKindTask, want.ID, models.DriftMissing, nil),
+			repair: reschedule,
+		}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Replaced by a task that is not recurring, which ApplySpec refuses to
+	// manage until it is gone
+	if !task.IsContinuous() {
+		return nil, nil
+	}
+	if task.Status == string(models.TaskStatusCancelled) {
+		return []driftFinding{{
+			drift:  newDrift(specKindTask, want.ID, models.DriftCancelled, nil),
+			repair: reschedule,
+		}}, nil
+	}
+
+	var findings []driftFinding
+	scheduled, err := s.taskStore.ContinuousTaskScheduled(ctx, want.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !scheduled {
+		findings = append(findings, driftFinding{
+			drift: newDrift(specKindTask, want.ID, models.DriftNotScheduled, nil),
+			repair: func(ctx context.Context, now time.Time) error {
+				return s.taskStore.RescheduleContinuousTask(ctx, task, now)
+			},
+		})
+	}
+	fields := slices.DeleteFunc(taskChanges(task, want), func(field string) bool {
+		return field == "agent_id"
+	})
+	if len(fields) > 0 {
+		findings = append(findings, driftFinding{
+			drift: newDrift(specKindTask, want.ID, models.DriftChanged, fields),
+			repair: func(ctx context.Context, now time.Time) error {
+				current, err := s.taskStore.GetTask(ctx, want.ID)
+				if err != nil {
+					return err
+				}
+				desired := *want
+				if !current.IsGroup() && !desired.IsGroup() {
+					desired.AgentID = current.AgentID
+				}
+				return s.replaceRecurringTask(ctx, current, &desired)
+			},
+		})
+	}
+	return findings, nil
+}
+
+// GetDrift reports how the campaigns and recurring tasks specs manage
+// differ from the definitions ApplySpec last applied, as found now
+func (s *Server) GetDrift(ctx context.Context, req *api.GetDriftRequest) (*api.GetDriftResponse, error) {
+	findings, err := s.detectDrift(ctx, time.Now())
+	if err != nil {
+		return &api.GetDriftResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	reported, err := s.specStore.GetDrift(ctx)
+	if err != nil {
+		return &api.GetDriftResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	drifts := make([]*api.Drift, len(findings))
+	for i, finding := range findings {
+		if previous, ok := reported[finding.drift.Key()]; ok {
+			finding.drift.DetectedAt = previous.DetectedAt
+		}
+		drifts[i] = driftToAPI(finding.drift)
+	}
+	return &api.GetDriftResponse{
+		Drifts: drifts,
+	}, nil
+}
+
+// recordDriftEvent appends an event for drift found or repaired by the
+// server
+func (s *Server) recordDriftEvent(ctx context.Context, eventType models.EventTypeEnum, severity models.EventSeverityEnum, drift *models.Drift) {
+	subject := taskName(drift.Name)
+	if drift.Kind == specKindCampaign {
+		subject = "campaigns/" + drift.Name
+	}
+	s.recordEvent(ctx, eventType, severity, &models.Event{
+		Actor:   models.EventActorSystem,
+		Subject: subject,
+	}, drift)
+}
+
+// newDrift returns drift found in a campaign or task
+func newDrift(kind, name string, reason models.DriftReasonEnum, fields []string) *models.Drift {
+	return &models.Drift{
+		Kind:   kind,
+		Name:   name,
+		Reason: string(reason),
+		Fields: fields,
+	}
+}
+
+// driftToAPI converts model drift into API drift
+func driftToAPI(d *models.Drift) *api.Drift {
+	drift := &api.Drift{
+		Kind:   d.Kind,
+		Name:   d.Name,
+		Reason: d.Reason,
+		Fields: d.Fields,
+	}
+	if !d.DetectedAt.IsZero() {
+		drift.DetectedAt = d.DetectedAt.Unix()
+	}
+	return drift
+}
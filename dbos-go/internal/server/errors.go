@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// errorCode returns the machine-readable code of an error, as v1 responses
+// report it in error_code next to the message in error
+func errorCode(err error) string {
+	return string(dberrors.CodeOf(err))
+}
+
+// unaryErrorInterceptor converts the errors RPCs fail with into gRPC
+// statuses carrying their machine-readable code, so v2, streaming and
+// interceptor errors are classified like the error_code of v1 responses
+func unaryErrorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	return resp, dberrors.Status(err)
+}
+
+// streamErrorInterceptor is unaryErrorInterceptor for streaming RPCs
+func streamErrorInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return dberrors.Status(handler(srv, ss))
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// eventLogRetentionEnabled reports whether events are ever trimmed from
+// the event log
+func (s *Server) eventLogRetentionEnabled() bool {
+	return s.config.EventLogMaxEvents > 0 || s.config.EventLogRetention > 0
+}
+
+// runEventLogRetention periodically trims the event log until ctx is done
+func (s *Server) runEventLogRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			trimmed, err := s.trimEventLog(ctx, now)
+			if err != nil {
+				log.Printf("Event log retention: %v", err)
+			}
+			if trimmed > 0 {
+				log.Printf("Event log retention: trimmed %d events", trimmed)
+			}
+		}
+	}
+}
+
+// trimEventLog removes the oldest events beyond EventLogMaxEvents and those
+// older than EventLogRetention, archiving them first if an archive is
+// configured, and returns how many were removed. A sweep trims at most
+// maxRetentionPages pages; the next one continues.
+func (s *Server) trimEventLog(ctx context.Context, now time.Time) (int, error) {
+	var excess int64
+	if s.config.EventLogMaxEvents > 0 {
+		length, err := s.eventLog.Length(ctx)
+		if err != nil {
+			return 0, err
+		}
+		excess = length - s.config.EventLogMaxEvents
+	}
+	var cutoff time.Time
+	if s.config.EventLogRetention > 0 {
+		cutoff = now.Add(-s.config.EventLogRetention)
+	}
+
+	trimmed := 0
+	for page := 0; page < maxRetentionPages; page++ {
+		events, err := s.eventLog.OldestEvents(ctx, retentionPageSize)
+		if err != nil {
+			return trimmed, err
+		}
+
+		// The log is in append order, so the events to trim are a prefix
+		n := 0
+		for n < len(events) && (int64(n) < excess || (!cutoff.IsZero() && events[n].Timestamp.Before(cutoff))) {
+			n++
+		}
+		if n == 0 {
+			return trimmed, nil
+		}
+
+		expired := events[:n]
+		if s.archive != nil {
+			// The events are kept until they could be archived
+			if _, err := s.archiveEvents(ctx, expired); err != nil {
+				return trimmed, fmt.Errorf("archiving events: %w", err)
+			}
+		}
+		if err := s.eventLog.TrimEvents(ctx, expired[n-1].Sequence); err != nil {
+			return trimmed, err
+		}
+		trimmed += n
+		excess -= int64(n)
+
+		if n < len(events) {
+			return trimmed, nil
+		}
+	}
+	return trimmed, nil
+}
+
+// archivedEvent is an event as archived, with its sequence
+type archivedEvent struct {
+	Sequence string `json:"sequence"`
+	*models.Event
+}
+
+// archiveEvents uploads events of the event log, oldest first, to the
+// archive as one object of gzipped JSON lines, named after the range of
+// times and sequences it holds, and returns its name
+func (s *Server) archiveEvents(ctx context.Context, events []*models.Event) (string, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(zw)
+	for _, event := range events {
+		if err := encoder.Encode(archivedEvent{Sequence: event.Sequence, Event: event}); err != nil {
+			return "", err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+
+	first, last := events[0], events[len(events)-1]
+	name := fmt.Sprintf("events/%d-%s_%d-%s.jsonl.gz",
+		first.Timestamp.Unix(), first.Sequence, last.Timestamp.Unix(), last.Sequence)
+	if err := s.archive.Put(ctx, name, "application/gzip", buf.Bytes()); err != nil {
+		return "", err
+	}
+	return name, nil
+}
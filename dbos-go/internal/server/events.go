@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Page sizes of GetEvents
+const (
+	defaultEventPageSize = 100
+	maxEventPageSize     = 1000
+)
+
+// streamEventsBlock is how long a single event log read of StreamEvents
+// waits for new events
+const streamEventsBlock = 5 * time.Second
+
+// GetEvents retrieves events of the event log newest first, optionally only
+// those of some types, of one agent or within a time range
+func (s *Server) GetEvents(ctx context.Context, req *api.GetEventsRequest) (*api.GetEventsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultEventPageSize
+	}
+	if limit > maxEventPageSize {
+		limit = maxEventPageSize
+	}
+
+	filter := models.EventFilter{
+		Types:   req.Types,
+		AgentID: req.AgentId,
+	}
+	if req.Since > 0 {
+		filter.Since = time.Unix(req.Since, 0)
+	}
+	if req.Until > 0 {
+		filter.Until = time.Unix(req.Until, 0)
+	}
+
+	events, err := s.eventLog.ListEvents(ctx, filter, limit)
+	if err != nil {
+		return &api.GetEventsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiEvents := make([]*api.Event, len(events))
+	for i, event := range events {
+		apiEvents[i] = eventToAPI(event)
+	}
+	return &api.GetEventsResponse{
+		Events: apiEvents,
+	}, nil
+}
+
+// StreamEvents streams the events appended to the event log in order,
+// optionally only those of some types or of one agent. Each event carries
+// its sequence, after which a consumer that reconnects resumes.
+func (s *Server) StreamEvents(req *api.StreamEventsRequest, stream api.DBOS_StreamEventsServer) error {
+	ctx := stream.Context()
+	filter := models.EventFilter{
+		Types:   req.Types,
+		AgentID: req.AgentId,
+	}
+
+	sequence := req.AfterSequence
+	switch sequence {
+	case "":
+		latest, err := s.eventLog.LatestSequence(ctx)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "reading event sequence: %v", err)
+		}
+		sequence = latest
+	case "0", "0-0":
+		sequence = "0-0"
+	default:
+		available, err := s.eventLog.SequenceAvailable(ctx, sequence)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "checking event sequence: %v", err)
+		}
+		if !available {
+			return status.Errorf(codes.OutOfRange, "sequence %s has been trimmed from the event log; stream again from \"0\" or without a sequence", sequence)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		events, last, err := s.eventLog.ReadEvents(ctx, sequence, streamEventsBlock)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return status.Errorf(codes.Unavailable, "reading events: %v", err)
+		}
+
+		for _, event := range events {
+			if !filter.Matches(event) {
+				continue
+			}
+			if err := stream.Send(eventToAPI(event)); err != nil {
+				return err
+			}
+		}
+		if last != "" {
+			sequence = last
+		}
+	}
+}
+
+// recordEvent appends an event for a mutation that was made to the event
+// log, its actor taken from the caller's token unless set. The mutation
+// stands even if the event cannot be recorded, so failures are only logged.
+func (s *Server) recordEvent(ctx context.Context, eventType models.EventTypeEnum, severity models.EventSeverityEnum, event *models.Event, payload any) {
+	event.ID = ids.New("event-")
+	event.Type = string(eventType)
+	event.Severity = string(severity)
+	event.Timestamp = time.Now()
+	if event.Actor == "" {
+		if identity, ok := ctx.Value(tokenIdentityKey{}).(*tokenIdentity); ok {
+			event.Actor = identity.String()
+		}
+	}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			log.Printf("Failed to encode %s event of %s: %v", eventType, event.Subject, err)
+			return
+		}
+		event.Payload = data
+	}
+	if err := s.eventLog.AppendEvent(ctx, event); err != nil {
+		log.Printf("Failed to record %s event of %s: %v", eventType, event.Subject, err)
+	}
+}
+
+// recordAgentEvent appends an event for a mutation of an agent
+func (s *Server) recordAgentEvent(ctx context.Context, eventType models.EventTypeEnum, severity models.EventSeverityEnum, agentID string, payload any) {
+	s.recordEvent(ctx, eventType, severity, &models.Event{
+		Subject: agentName(agentID),
+		AgentID: agentID,
+	}, payload)
+}
+
+// recordTaskEvent appends an event for a mutation of a task, carrying the
+// task as it is after the mutation
+func (s *Server) recordTaskEvent(ctx context.Context, eventType models.EventTypeEnum, task *models.Task) {
+	s.recordTaskChange(ctx, eventType, models.EventSeverityInfo, task, task)
+}
+
+// recordTaskChange appends an event for a mutation of a task, carrying a
+// payload describing it
+func (s *Server) recordTaskChange(ctx context.Context, eventType models.EventTypeEnum, severity models.EventSeverityEnum, task *models.Task, payload any) {
+	s.recordEvent(ctx, eventType, severity, &models.Event{
+		Subject:       taskName(task.ID),
+		AgentID:       task.AgentID,
+		CorrelationID: task.CorrelationID,
+	}, payload)
+}
+
+// recordTaskCancelled appends an event for a cancelled task, as it is after
+// the cancellation
+func (s *Server) recordTaskCancelled(ctx context.Context, taskID string) {
+	task, err := s.taskStore.GetTask(ctx, taskID)
+	if err != nil {
+		log.Printf("Failed to record %s event of task %s: %v", models.EventTaskCancelled, taskID, err)
+		return
+	}
+	s.recordTaskEvent(ctx, models.EventTaskCancelled, task)
+}
+
+// recordTaskTransition appends an event for a task changing state, as it
+// is after the change
+func (s *Server) recordTaskTransition(ctx context.Context, eventType models.EventTypeEnum, severity models.EventSeverityEnum, task *models.Task, reason models.TaskLeaseEndReasonEnum) {
+	s.recordTaskChange(ctx, eventType, severity, task, models.TaskTransitionOf(task, reason))
+}
+
+// recordSettledTasks appends an event for each task settled without an
+// error in errs, as it is after settling, and returns those tasks
+func (s *Server) recordSettledTasks(ctx context.Context, eventType models.EventTypeEnum, severity models.EventSeverityEnum, reason models.TaskLeaseEndReasonEnum, taskIDs []string, errs []error) []*models.Task {
+	settledIDs := make([]string, 0, len(taskIDs))
+	for i, taskID := range taskIDs {
+		if errs[i] == nil {
+			settledIDs = append(settledIDs, taskID)
+		}
+	}
+	if len(settledIDs) == 0 {
+		return nil
+	}
+	tasks, err := s.taskStore.GetTasks(ctx, settledIDs)
+	if err != nil {
+		log.Printf("Failed to record %s events of %d tasks: %v", eventType, len(settledIDs), err)
+		return nil
+	}
+
+	settled := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+		s.recordTaskTransition(ctx, eventType, severity, task, reason)
+		settled = append(settled, task)
+	}
+	return settled
+}
+
+// recordMigration appends an event for data moved by a migration at
+// startup, of an agent or of all tasks
+func (s *Server) recordMigration(ctx context.Context, migration *models.DataMigration) {
+	subject := "tasks"
+	if migration.AgentID != "" {
+		subject = agentName(migration.AgentID)
+	}
+	s.recordEvent(ctx, models.EventDataMigrated, models.EventSeverityInfo, &models.Event{
+		Actor:   models.EventActorSystem,
+		Subject: subject,
+		AgentID: migration.AgentID,
+	}, migration)
+}
+
+// recordModuleStateEvent appends an event for a module state written by an
+// agent, graded by whether the module failed
+func (s *Server) recordModuleStateEvent(ctx context.Context, state *models.ModuleState) {
+	severity := models.EventSeverityInfo
+	switch models.ModuleStateEnum(state.State) {
+	case models.ModuleStateError, models.ModuleStateFailed:
+		severity = models.EventSeverityError
+	}
+	s.recordEvent(ctx, models.EventModuleStateChanged, severity, &models.Event{
+		Subject: agentName(state.AgentID) + "/modules/" + state.ModuleName,
+		AgentID: state.AgentID,
+	}, state)
+}
+
+// eventToAPI converts a model event into an API event
+func eventToAPI(e *models.Event) *api.Event {
+	return &api.Event{
+		Id:            e.ID,
+		Type:          e.Type,
+		Actor:         e.Actor,
+		Subject:       e.Subject,
+		AgentId:       e.AgentID,
+		Severity:      e.Severity,
+		CorrelationId: e.CorrelationID,
+		Timestamp:     e.Timestamp.Unix(),
+		Payload:       e.Payload,
+		Sequence:      e.Sequence,
+	}
+}
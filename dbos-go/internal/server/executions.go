@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+const (
+	// taskDurationMetric is the metric sample reporting how long an agent
+	// ran a task, from its lease until its result or acknowledgement
+	taskDurationMetric = "dbos_task_duration_ms"
+
+	// minHungTaskExecutions is how many recent executions a module needs
+	// for its tasks to be judged hung
+	minHungTaskExecutions = 20
+)
+
+// GetExecutionStats reports how long agents took to run a module's tasks
+func (s *Server) GetExecutionStats(ctx context.Context, req *api.GetExecutionStatsRequest) (*api.GetExecutionStatsResponse, error) {
+	stats, err := s.getExecutionStats(ctx, req)
+	if err != nil {
+		return &api.GetExecutionStatsResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	stats.Success = true
+	return stats, nil
+}
+
+// getExecutionStats collects the execution statistics of a module across
+// all agents and of the requested agent, or of every agent that ran it
+func (s *Server) getExecutionStats(ctx context.Context, req *api.GetExecutionStatsRequest) (*api.GetExecutionStatsResponse, error) {
+	if req.ModuleName == "" {
+		return nil, dberrors.New(dberrors.InvalidArgument, "module name is required")
+	}
+	agentIDs := []string{req.AgentId}
+	if req.AgentId == "" {
+		var err error
+		agentIDs, err = s.executionStore.ListAgents(ctx, req.ModuleName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	moduleStats, err := s.executionStore.ModuleStats(ctx, req.ModuleName)
+	if err != nil {
+		return nil, err
+	}
+	agentStats, err := s.executionStore.AgentStats(ctx, agentIDs, req.ModuleName)
+	if err != nil {
+		return nil, err
+	}
+	moduleHistogram, agentHistograms, err := s.executionStore.Histograms(ctx, req.ModuleName, agentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &api.GetExecutionStatsResponse{
+		Module: executionStatsToAPI("", moduleStats, moduleHistogram),
+		Agents: make([]*api.ExecutionDurationStats, len(agentIDs)),
+	}
+	for i, agentID := range agentIDs {
+		resp.Agents[i] = executionStatsToAPI(agentID, agentStats[agentID], agentHistograms[agentID])
+	}
+	if threshold, ok := s.hungTaskThreshold(moduleStats); ok {
+		resp.HungThresholdMs = float64(threshold) / float64(time.Millisecond)
+	}
+	return resp, nil
+}
+
+// hungTaskThreshold returns how long a task of a module with these recent
+// executions runs before it is hung, if hung task detection is enabled and
+// the module ran often enough
+func (s *Server) hungTaskThreshold(stats models.ExecutionStats) (time.Duration, bool) {
+	if !s.hungTaskDetection() || stats.Samples < minHungTaskExecutions {
+		return 0, false
+	}
+	threshold := time.Duration(s.config.TaskHungFactor * stats.P99DurationMs * float64(time.Millisecond))
+	return max(threshold, s.config.TaskHungMinAge), true
+}
+
+// recordResultExecution records how long the agent of a scheduled result
+// took to run its task, from its lease until the result was stored. Tasks
+// that were never leased, such as those listed with ListDueTasks, have no
+// execution time.
+func (s *Server) recordResultExecution(ctx context.Context, result *models.MeasurementResult) {
+	if result.Origin != string(models.ResultOriginScheduled) {
+		return
+	}
+	task, err := s.taskStore.GetTask(ctx, result.ID)
+	if err != nil || task.AgentID != result.AgentID {
+		return
+	}
+	s.recordExecution(ctx, task, &models.Execution{ResultBytes: int64(len(result.Data))})
+}
+
+// recordAckedExecutions records how long an agent took to run the tasks it
+// acknowledged, from their lease until the acknowledgement
+func (s *Server) recordAckedExecutions(ctx context.Context, tasks []*models.Task) {
+	for _, task := range tasks {
+		s.recordExecution(ctx, task, &models.Execution{Acked: true})
+	}
+}
+
+// recordExecution records the execution of a task under its current lease
+// as ending now, unless it ended before, for capacity planning, the
+// execution statistics and hung task detection
+func (s *Server) recordExecution(ctx context.Context, task *models.Task, execution *models.Execution) {
+	now := time.Now()
+	leasedAt := models.TimeOf(task.LeasedAt)
+	if leasedAt.IsZero() || now.Before(leasedAt) {
+		return
+	}
+	execution.DurationMs = float64(now.Sub(leasedAt)) / float64(time.Millisecond)
+	execution.At = now
+
+	recorded, err := s.executionStore.RecordExecution(ctx, task.AgentID, task.ModuleName, task.ID, leasedAt, execution)
+	if err != nil {
+		log.Printf("Task %s: recording execution: %v", task.ID, err)
+		return
+	}
+	if recorded {
+		s.exportSamples([]metricSample{{
+			Name:      taskDurationMetric,
+			AgentID:   task.AgentID,
+			Module:    task.ModuleName,
+			Value:     execution.DurationMs,
+			Timestamp: now,
+		}})
+	}
+}
+
+// executionStatsToAPI converts execution statistics and a histogram into
+// their API form
+func executionStatsToAPI(agentID string, stats models.ExecutionStats, histogram models.ExecutionHistogram) *api.ExecutionDurationStats {
+	buckets := make([]*api.DurationBucket, len(histogram.Counts))
+	for i, count := range histogram.Counts {
+		buckets[i] = &api.DurationBucket{Count: count}
+		if i < len(models.ExecutionBucketsMs) {
+			buckets[i].UpperBoundMs = models.ExecutionBucketsMs[i]
+		}
+	}
+	return &api.ExecutionDurationStats{
+		AgentId:        agentID,
+		Samples:        int64(stats.Samples),
+		MeanDurationMs: stats.MeanDurationMs,
+		P50DurationMs:  stats.P50DurationMs,
+		P95DurationMs:  stats.P95DurationMs,
+		P99DurationMs:  stats.P99DurationMs,
+		Histogram:      buckets,
+		Count:          histogram.Count,
+		SumDurationMs:  histogram.SumMs,
+	}
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/arrowipc"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// exportPageSize is how many results are read from the store at a time
+	exportPageSize = 1000
+	// exportBatchRows is how many rows go into one Arrow record batch
+	exportBatchRows = 16384
+	// exportChunkBytes bounds the size of an ExportResults stream message
+	exportChunkBytes = 1 << 20
+)
+
+// exportColumns are the result metadata columns of an export, before the
+// extracted fields
+var exportColumns = []arrowipc.Field{
+	{Name: "id", Type: arrowipc.String},
+	{Name: "agent_id", Type: arrowipc.String},
+	{Name: "module_name", Type: arrowipc.String},
+	{Name: "timestamp", Type: arrowipc.Timestamp},
+	{Name: "origin", Type: arrowipc.String},
+	{Name: "sequence", Type: arrowipc.Int64},
+	{Name: "clock_offset_ms", Type: arrowipc.Float64},
+	{Name: "size_bytes", Type: arrowipc.Int64},
+}
+
+// ExportResults streams result metadata and numeric fields extracted from
+// result data as an Apache Arrow IPC stream, split across messages
+func (s *Server) ExportResults(req *api.ExportResultsRequest, stream api.DBOS_ExportResultsServer) error {
+	w := bufio.NewWriterSize(&chunkWriter{stream: stream}, exportChunkBytes)
+	if err := s.exportResults(stream.Context(), req, w); err != nil {
+		return status.Errorf(codes.Unavailable, "exporting results: %v", err)
+	}
+	return w.Flush()
+}
+
+// exportResults writes the results req selects to w as an Arrow IPC stream,
+// per agent in sequence order
+func (s *Server) exportResults(ctx context.Context, req *api.ExportResultsRequest, w io.Writer) error {
+	agentIDs := req.AgentIds
+	if len(agentIDs) == 0 {
+		agents, err := s.agentStore.ListAgents(ctx)
+		if err != nil {
+			return err
+		}
+		for _, agent := range agents {
+			agentIDs = append(agentIDs, agent.ID)
+		}
+	}
+
+	fields := append([]arrowipc.Field{}, exportColumns...)
+	for _, path := range req.Fields {
+		fields = append(fields, arrowipc.Field{Name: "data." + path, Type: arrowipc.Float64})
+	}
+	writer := arrowipc.NewWriter(w, fields)
+
+	var from, to time.Time
+	if req.From > 0 {
+		from = time.Unix(req.From, 0)
+	}
+	if req.To > 0 {
+		to = time.Unix(req.To, 0)
+	}
+
+	row := make([]interface{}, len(fields))
+	for _, agentID := range agentIDs {
+		var after int64
+		for {
+			results, err := s.resultStore.ListResultsAfter(ctx, agentID, after, exportPageSize)
+			if err != nil {
+				return err
+			}
+			if len(results) == 0 {
+				break
+			}
+			for _, result := range results {
+				after = result.Sequence
+				if !exportSelects(req, result, from, to) {
+					continue
+				}
+
+				row[0], row[1], row[2] = result.ID, result.AgentID, result.ModuleName
+				row[3], row[4], row[5] = result.Timestamp, result.Origin, result.Sequence
+				row[6], row[7] = result.ClockOffsetMs, int64(len(result.Data))
+				for i, path := range req.Fields {
+					row[len(exportColumns)+i] = nil
+					if v, ok := jsonpath.LookupFloat(result.Data, path); ok {
+						row[len(exportColumns)+i] = v
+					}
+				}
+				if err := writer.Append(row...); err != nil {
+					return err
+				}
+				if writer.Len() >= exportBatchRows {
+					if err := writer.Flush(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return writer.Close()
+}
+
+// exportSelects reports whether an export includes a result
+func exportSelects(req *api.ExportResultsRequest, result *models.MeasurementResult, from, to time.Time) bool {
+	if req.ModuleName != "" && result.ModuleName != req.ModuleName {
+		return false
+	}
+	if !from.IsZero() && result.Timestamp.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !result.Timestamp.Before(to) {
+		return false
+	}
+	return true
+}
+
+// chunkWriter sends writes as ExportResults stream messages of at most
+// exportChunkBytes
+type chunkWriter struct {
+	stream api.DBOS_ExportResultsServer
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	for sent := 0; sent < len(p); sent += exportChunkBytes {
+		chunk := p[sent:min(len(p), sent+exportChunkBytes)]
+		if err := c.stream.Send(&api.ExportResultsChunk{Data: chunk}); err != nil {
+			return sent, err
+		}
+	}
+	return len(p), nil
+}
+
+// handleHTTPExportResults serves GET /v1/results/export, taking the
+// ExportResultsRequest fields as query parameters (agent_id and field may be
+// repeated) and answering with the Arrow IPC stream
+func (s *Server) handleHTTPExportResults(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &api.ExportResultsRequest{
+		AgentIds:   query["agent_id"],
+		ModuleName: query.Get("module_name"),
+		Fields:     query["field"],
+	}
+	for name, bound := range map[string]*int64{"from": &req.From, "to": &req.To} {
+		if v := query.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				writeHTTPError(w, dberrors.New(dberrors.InvalidArgument, "invalid %s: %v", name, err))
+				return
+			}
+			*bound = n
+		}
+	}
+
+	s.meterHTTP(w, r, api.DBOS_ExportResults_FullMethodName, req, func(w http.ResponseWriter) {
+		out := &responseStarter{w: w}
+		bw := bufio.NewWriterSize(out, exportChunkBytes)
+		if err := s.exportResults(r.Context(), req, bw); err != nil {
+			if !out.started {
+				writeHTTPError(w, err)
+				return
+			}
+			// Once the stream started the status cannot change; a stream
+			// without its end marker tells readers the export is incomplete
+			log.Printf("HTTP export: %v", err)
+			return
+		}
+		bw.Flush()
+	})
+}
+
+// responseStarter sets the Arrow content type on the first write to a
+// response, so errors before it can still be answered with an error status
+type responseStarter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (r *responseStarter) Write(p []byte) (int, error) {
+	if !r.started {
+		r.w.Header().Set("Content-Type", arrowipc.ContentType)
+		r.started = true
+	}
+	return r.w.Write(p)
+}
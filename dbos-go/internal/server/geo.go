@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// Colors of map features by health, as simplestyle marker-color values
+const (
+	geoColorHealthy  = "#1f8a4c"
+	geoColorDegraded = "#b7791f"
+	geoColorDown     = "#c23a32"
+)
+
+// geoFeatureCollection is a GeoJSON FeatureCollection (RFC 7946)
+type geoFeatureCollection struct {
+	Type     string       `json:"type"`
+	Features []geoFeature `json:"features"`
+}
+
+// geoFeature is a GeoJSON Feature
+type geoFeature struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id"`
+	Geometry   geoGeometry    `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// geoGeometry is a Point or MultiPoint geometry; positions are longitude
+// first
+type geoGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// GetAgentGeoJSON returns the agents matching a selector as GeoJSON for map
+// views, each a Point at its lat and lon labels. Their properties carry
+// the agent's freshness and health with a marker-color to draw it in;
+// with include_incidents, the active incidents are added as MultiPoints
+// at the agents they concern.
+func (s *Server) GetAgentGeoJSON(ctx context.Context, req *api.GetAgentGeoJSONRequest) (*api.GetAgentGeoJSONResponse, error) {
+	collection, unlocated, err := s.agentGeoJSON(ctx, req.Selector, req.IncludeIncidents, time.Now())
+	if err != nil {
+		return &api.GetAgentGeoJSONResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	data, err := json.Marshal(collection)
+	if err != nil {
+		return &api.GetAgentGeoJSONResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.GetAgentGeoJSONResponse{
+		Geojson:         data,
+		UnlocatedAgents: int32(unlocated),
+	}, nil
+}
+
+// agentGeoJSON builds the feature collection of GetAgentGeoJSON, returning
+// the number of matching agents left out for lacking a location
+func (s *Server) agentGeoJSON(ctx context.Context, selector map[string]string, includeIncidents bool, now time.Time) (*geoFeatureCollection, int, error) {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].ID < agents[j].ID })
+
+	var incidents []*models.Incident
+	if includeIncidents {
+		incidents, err = s.incidentStore.ListIncidents(ctx, "", models.IncidentStatusActive, "", 0)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Incidents concern the agents they name, or else their region's
+	incidentsOf := make(map[string][]string)
+	for _, incident := range incidents {
+		for _, agentID := range incident.AgentIDs {
+			incidentsOf[agentID] = append(incidentsOf[agentID], incident.ID)
+		}
+	}
+
+	collection := &geoFeatureCollection{Type: "FeatureCollection", Features: []geoFeature{}}
+	positions := make(map[string][]float64)
+	regions := make(map[string][]string)
+	unlocated := 0
+	for _, agent := range agents {
+		if !models.MatchesLabels(selector, agent.Labels) {
+			continue
+		}
+		lat, lon, ok := agent.Location()
+		if !ok {
+			unlocated++
+			continue
+		}
+		position := []float64{lon, lat}
+		positions[agent.ID] = position
+		region := agent.Labels["region"]
+		if region != "" {
+			regions[region] = append(regions[region], agent.ID)
+		}
+
+		properties := s.agentGeoProperties(agent, now)
+		if includeIncidents {
+			concerning := incidentsOf[agent.ID]
+			for _, incident := range incidents {
+				if len(incident.AgentIDs) == 0 && region != "" && incident.Region == region {
+					concerning = append(concerning, incident.ID)
+				}
+			}
+			properties["incidents"] = len(concerning)
+			if len(concerning) > 0 && properties["health"] == "healthy" {
+				properties["health"] = "degraded"
+				properties["marker-color"] = geoColorDegraded
+			}
+		}
+		collection.Features = append(collection.Features, geoFeature{
+			Type:       "Feature",
+			ID:         "agents/" + agent.ID,
+			Geometry:   geoGeometry{Type: "Point", Coordinates: position},
+			Properties: properties,
+		})
+	}
+
+	for _, incident := range incidents {
+		agentIDs := incident.AgentIDs
+		if len(agentIDs) == 0 {
+			agentIDs = regions[incident.Region]
+		}
+		var located []string
+		var points [][]float64
+		for _, agentID := range agentIDs {
+			if position, ok := positions[agentID]; ok {
+				located = append(located, agentID)
+				points = append(points, position)
+			}
+		}
+		// Incidents of agents off the map have nowhere to be drawn
+		if len(points) == 0 {
+			continue
+		}
+
+		color := geoColorDown
+		if incident.Status == models.IncidentStatusAcknowledged {
+			color = geoColorDegraded
+		}
+		collection.Features = append(collection.Features, geoFeature{
+			Type:     "Feature",
+			ID:       "incidents/" + incident.ID,
+			Geometry: geoGeometry{Type: "MultiPoint", Coordinates: points},
+			Properties: map[string]any{
+				"kind":         "incident",
+				"id":           incident.ID,
+				"type":         incident.Type,
+				"status":       incident.Status,
+				"title":        incident.Title,
+				"region":       incident.Region,
+				"target":       incident.Target,
+				"agent_ids":    located,
+				"occurrences":  incident.Occurrences,
+				"first_seen":   incident.FirstSeen.UTC().Format(time.RFC3339),
+				"last_seen":    incident.LastSeen.UTC().Format(time.RFC3339),
+				"marker-color": color,
+			},
+		})
+	}
+
+	return collection, unlocated, nil
+}
+
+// agentGeoProperties describes an agent on a map. Its freshness is
+// "fresh" while its last heartbeat is within half the liveness window,
+// "stale" past that and "dead" once it is marked dead; its health is
+// "healthy" when fresh, "degraded" when stale and "down" when dead.
+func (s *Server) agentGeoProperties(agent *models.Agent, now time.Time) map[string]any {
+	age := now.Sub(agent.LastSeen)
+	freshness, health, color := "fresh", "healthy", geoColorHealthy
+	switch {
+	case !agent.Alive:
+		freshness, health, color = "dead", "down", geoColorDown
+	case age > s.config.AgentLivenessWindow/2:
+		freshness, health, color = "stale", "degraded", geoColorDegraded
+	}
+
+	labels := agent.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return map[string]any{
+		"kind":              "agent",
+		"id":                agent.ID,
+		"hostname":          agent.Hostname,
+		"region":            agent.Labels["region"],
+		"labels":            labels,
+		"alive":             agent.Alive,
+		"last_seen":         agent.LastSeen.UTC().Format(time.RFC3339),
+		"last_seen_seconds": max(int64(age/time.Second), 0),
+		"freshness":         freshness,
+		"health":            health,
+		"marker-color":      color,
+	}
+}
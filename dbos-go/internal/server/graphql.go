@@ -0,0 +1,438 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// graphQLSchema exposes agents, tasks and result metadata for dashboards.
+// Result payloads are deliberately not exposed; fetch them with GetResult.
+const graphQLSchema = `
+schema {
+	query: Query
+}
+
+type Query {
+	agent(id: ID!): Agent
+	agents(alive: Boolean): [Agent!]!
+	task(id: ID!): Task
+	dueTasks(before: String, agentId: ID, limit: Int): [Task!]!
+	result(agentId: ID!, id: ID!): Result
+	verification(id: ID!): Verification
+}
+
+type Label {
+	key: String!
+	value: String!
+}
+
+type Agent {
+	id: ID!
+	hostname: String!
+	alive: Boolean!
+	lastSeen: String!
+	firstSeen: String!
+	totalHeartbeats: Int!
+	labels: [Label!]!
+	config: [Label!]!
+	maintenanceWindowIds: [ID!]!
+	moduleStates(moduleName: String!): [ModuleState!]!
+	results(moduleName: String, limit: Int = 50): [Result!]!
+}
+
+type ModuleState {
+	requestId: ID!
+	moduleName: String!
+	state: String!
+	errorMessage: String!
+	errorCode: String!
+	timestamp: String!
+}
+
+type Task {
+	id: ID!
+	agent: Agent
+	moduleName: String!
+	scheduledAt: String!
+	createdAt: String!
+	status: String!
+	type: String!
+	intervalSeconds: Int!
+	parent: Task
+	verification: Verification
+}
+
+type Result {
+	id: ID!
+	agent: Agent
+	moduleName: String!
+	timestamp: String!
+	origin: String!
+	sequence: Float!
+	size: Int!
+	task: Task
+}
+
+type Verification {
+	id: ID!
+	moduleName: String!
+	replicas: Int!
+	compareField: String!
+	tolerance: Float!
+	status: String!
+	createdAt: String!
+	tasks: [Task!]!
+}
+`
+
+// newGraphQLHandler returns the GraphQL query endpoint. With token
+// authentication it requires a token allowed to read.
+func (s *Server) newGraphQLHandler() http.Handler {
+	schema := graphql.MustParseSchema(graphQLSchema, &queryResolver{s: s}, graphql.UseFieldResolvers())
+	mux := http.NewServeMux()
+	mux.Handle("POST /graphql", &relay.Handler{Schema: schema})
+	if s.config.TokenAuth {
+		return withHTTPClientIdentity(s.withHTTPReadTokenAuth(mux))
+	}
+	return withHTTPClientIdentity(mux)
+}
+
+// startGraphQL serves the GraphQL endpoint on port, over TLS if the gRPC
+// server uses TLS
+func (s *Server) startGraphQL(ctx context.Context, port string) {
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		log.Printf("GraphQL endpoint not started: %v", err)
+		return
+	}
+	httpServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           s.newGraphQLHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         tlsConfig,
+	}
+
+	log.Printf("Starting GraphQL endpoint on port %s", port)
+	serve := httpServer.ListenAndServe
+	if tlsConfig != nil {
+		serve = func() error {
+			return httpServer.ListenAndServeTLS("", "")
+		}
+	}
+	if err := s.serveHTTP(ctx, httpServer, serve); err != nil {
+		log.Printf("GraphQL endpoint stopped: %v", err)
+	}
+}
+
+// formatTime renders a timestamp for GraphQL responses
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// labelsOf converts a string map into sorted GraphQL labels
+func labelsOf(m map[string]string) []*labelResolver {
+	labels := make([]*labelResolver, 0, len(m))
+	for key, value := range m {
+		labels = append(labels, &labelResolver{Key: key, Value: value})
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Key < labels[j].Key
+	})
+	return labels
+}
+
+// queryResolver resolves the root Query type
+type queryResolver struct {
+	s *Server
+}
+
+func (q *queryResolver) Agent(ctx context.Context, args struct{ ID graphql.ID }) *agentResolver {
+	agent, err := q.s.agentStore.GetAgent(ctx, string(args.ID))
+	if err != nil {
+		return nil
+	}
+	return &agentResolver{s: q.s, agent: agent}
+}
+
+func (q *queryResolver) Agents(ctx context.Context, args struct{ Alive *bool }) ([]*agentResolver, error) {
+	agents, err := q.s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(agents, func(i, j int) bool {
+		return agents[i].ID < agents[j].ID
+	})
+
+	resolvers := make([]*agentResolver, 0, len(agents))
+	for _, agent := range agents {
+		if args.Alive != nil && agent.Alive != *args.Alive {
+			continue
+		}
+		resolvers = append(resolvers, &agentResolver{s: q.s, agent: agent})
+	}
+	return resolvers, nil
+}
+
+func (q *queryResolver) Task(ctx context.Context, args struct{ ID graphql.ID }) *taskResolver {
+	return q.s.resolveTask(ctx, string(args.ID))
+}
+
+func (q *queryResolver) DueTasks(ctx context.Context, args struct {
+	Before  *string
+	AgentID *graphql.ID
+	Limit   *int32
+}) ([]*taskResolver, error) {
+	before := time.Now()
+	if args.Before != nil {
+		t, err := time.Parse(time.RFC3339, *args.Before)
+		if err != nil {
+			return nil, err
+		}
+		before = t
+	}
+
+	agentID := ""
+	if args.AgentID != nil {
+		agentID = string(*args.AgentID)
+	}
+	limit := 0
+	if args.Limit != nil {
+		limit = max(int(*args.Limit), 0)
+	}
+
+	tasks, err := q.s.taskStore.ListDueTasks(ctx, agentID, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*taskResolver, len(tasks))
+	for i, task := range tasks {
+		resolvers[i] = &taskResolver{s: q.s, task: task}
+	}
+	return resolvers, nil
+}
+
+func (q *queryResolver) Result(ctx context.Context, args struct {
+	AgentID graphql.ID
+	ID      graphql.ID
+}) *resultResolver {
+	result, err := q.s.getResult(ctx, string(args.AgentID), string(args.ID))
+	if err != nil {
+		return nil
+	}
+	return &resultResolver{s: q.s, result: result}
+}
+
+func (q *queryResolver) Verification(ctx context.Context, args struct{ ID graphql.ID }) *verificationResolver {
+	return q.s.resolveVerification(ctx, string(args.ID))
+}
+
+// resolveTask looks up a task, returning nil if it does not exist
+func (s *Server) resolveTask(ctx context.Context, taskID string) *taskResolver {
+	if taskID == "" {
+		return nil
+	}
+	task, err := s.taskStore.GetTask(ctx, taskID)
+	if err != nil {
+		return nil
+	}
+	return &taskResolver{s: s, task: task}
+}
+
+// resolveAgent looks up an agent, returning nil if it does not exist
+func (s *Server) resolveAgent(ctx context.Context, agentID string) *agentResolver {
+	agent, err := s.agentStore.GetAgent(ctx, agentID)
+	if err != nil {
+		return nil
+	}
+	return &agentResolver{s: s, agent: agent}
+}
+
+// resolveVerification looks up a verification, returning nil if it does not exist
+func (s *Server) resolveVerification(ctx context.Context, verificationID string) *verificationResolver {
+	if verificationID == "" {
+		return nil
+	}
+	verification, err := s.verificationStore.GetVerification(ctx, verificationID)
+	if err != nil {
+		return nil
+	}
+	return &verificationResolver{s: s, verification: verification}
+}
+
+// labelResolver resolves the Label type
+type labelResolver struct {
+	Key   string
+	Value string
+}
+
+// agentResolver resolves the Agent type
+type agentResolver struct {
+	s     *Server
+	agent *models.Agent
+}
+
+func (r *agentResolver) ID() graphql.ID           { return graphql.ID(r.agent.ID) }
+func (r *agentResolver) Hostname() string         { return r.agent.Hostname }
+func (r *agentResolver) Alive() bool              { return r.agent.Alive }
+func (r *agentResolver) LastSeen() string         { return formatTime(r.agent.LastSeen) }
+func (r *agentResolver) FirstSeen() string        { return formatTime(r.agent.FirstSeen) }
+func (r *agentResolver) TotalHeartbeats() int32   { return r.agent.TotalHeartbeats }
+func (r *agentResolver) Labels() []*labelResolver { return labelsOf(r.agent.Labels) }
+func (r *agentResolver) Config() []*labelResolver { return labelsOf(r.agent.Config) }
+
+// MaintenanceWindowIDs returns the maintenance windows in progress covering the agent
+func (r *agentResolver) MaintenanceWindowIDs(ctx context.Context) ([]graphql.ID, error) {
+	windows, err := r.s.maintenanceStore.ListActiveWindows(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []graphql.ID{}
+	for _, id := range agentMaintenanceWindowIDs(windows, r.agent) {
+		ids = append(ids, graphql.ID(id))
+	}
+	return ids, nil
+}
+
+func (r *agentResolver) ModuleStates(ctx context.Context, args struct{ ModuleName string }) ([]*moduleStateResolver, error) {
+	states, err := r.s.moduleStateStore.ListModuleStates(ctx, r.agent.ID, args.ModuleName)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Timestamp.After(states[j].Timestamp)
+	})
+
+	resolvers := make([]*moduleStateResolver, len(states))
+	for i, state := range states {
+		resolvers[i] = &moduleStateResolver{state: state}
+	}
+	return resolvers, nil
+}
+
+// Results returns the agent's most recent results, newest first
+func (r *agentResolver) Results(ctx context.Context, args struct {
+	ModuleName *string
+	Limit      int32
+}) ([]*resultResolver, error) {
+	results, err := r.s.resultStore.ListResults(ctx, r.agent.ID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Sequence > results[j].Sequence
+	})
+
+	resolvers := make([]*resultResolver, 0, len(results))
+	for _, result := range results {
+		if args.ModuleName != nil && result.ModuleName != *args.ModuleName {
+			continue
+		}
+		if args.Limit > 0 && len(resolvers) >= int(args.Limit) {
+			break
+		}
+		resolvers = append(resolvers, &resultResolver{s: r.s, result: result})
+	}
+	return resolvers, nil
+}
+
+// moduleStateResolver resolves the ModuleState type
+type moduleStateResolver struct {
+	state *models.ModuleState
+}
+
+func (r *moduleStateResolver) RequestID() graphql.ID { return graphql.ID(r.state.RequestID) }
+func (r *moduleStateResolver) ModuleName() string    { return r.state.ModuleName }
+func (r *moduleStateResolver) State() string         { return r.state.State }
+func (r *moduleStateResolver) ErrorMessage() string  { return r.state.ErrorMessage }
+func (r *moduleStateResolver) ErrorCode() string     { return r.state.ErrorCode }
+func (r *moduleStateResolver) Timestamp() string     { return formatTime(r.state.Timestamp) }
+
+// taskResolver resolves the Task type
+type taskResolver struct {
+	s    *Server
+	task *models.Task
+}
+
+func (r *taskResolver) ID() graphql.ID         { return graphql.ID(r.task.ID) }
+func (r *taskResolver) ModuleName() string     { return r.task.ModuleName }
+func (r *taskResolver) ScheduledAt() string    { return formatTime(r.task.ScheduledAt) }
+func (r *taskResolver) CreatedAt() string      { return formatTime(r.task.CreatedAt) }
+func (r *taskResolver) Status() string         { return r.task.Status }
+func (r *taskResolver) IntervalSeconds() int32 { return int32(r.task.IntervalSeconds) }
+
+func (r *taskResolver) Type() string {
+	if r.task.Type == "" {
+		return string(models.TaskTypeOneShot)
+	}
+	return r.task.Type
+}
+
+func (r *taskResolver) Agent(ctx context.Context) *agentResolver {
+	return r.s.resolveAgent(ctx, r.task.AgentID)
+}
+
+func (r *taskResolver) Parent(ctx context.Context) *taskResolver {
+	return r.s.resolveTask(ctx, r.task.ParentID)
+}
+
+func (r *taskResolver) Verification(ctx context.Context) *verificationResolver {
+	return r.s.resolveVerification(ctx, r.task.VerificationID)
+}
+
+// resultResolver resolves the Result type
+type resultResolver struct {
+	s      *Server
+	result *models.MeasurementResult
+}
+
+func (r *resultResolver) ID() graphql.ID     { return graphql.ID(r.result.ID) }
+func (r *resultResolver) ModuleName() string { return r.result.ModuleName }
+func (r *resultResolver) Timestamp() string  { return formatTime(r.result.Timestamp) }
+func (r *resultResolver) Origin() string     { return r.result.Origin }
+func (r *resultResolver) Sequence() float64  { return float64(r.result.Sequence) }
+func (r *resultResolver) Size() int32        { return int32(len(r.result.Data)) }
+
+func (r *resultResolver) Agent(ctx context.Context) *agentResolver {
+	return r.s.resolveAgent(ctx, r.result.AgentID)
+}
+
+// Task returns the task that produced a scheduled result
+func (r *resultResolver) Task(ctx context.Context) *taskResolver {
+	if r.result.Origin == string(models.ResultOriginLocal) {
+		return nil
+	}
+	return r.s.resolveTask(ctx, r.result.ID)
+}
+
+// verificationResolver resolves the Verification type
+type verificationResolver struct {
+	s            *Server
+	verification *models.Verification
+}
+
+func (r *verificationResolver) ID() graphql.ID       { return graphql.ID(r.verification.ID) }
+func (r *verificationResolver) ModuleName() string   { return r.verification.ModuleName }
+func (r *verificationResolver) Replicas() int32      { return r.verification.Replicas }
+func (r *verificationResolver) CompareField() string { return r.verification.CompareField }
+func (r *verificationResolver) Tolerance() float64   { return r.verification.Tolerance }
+func (r *verificationResolver) Status() string       { return r.verification.Status }
+func (r *verificationResolver) CreatedAt() string    { return formatTime(r.verification.CreatedAt) }
+
+func (r *verificationResolver) Tasks(ctx context.Context) []*taskResolver {
+	resolvers := make([]*taskResolver, 0, len(r.verification.TaskIDs))
+	for _, taskID := range r.verification.TaskIDs {
+		if task := r.s.resolveTask(ctx, taskID); task != nil {
+			resolvers = append(resolvers, task)
+		}
+	}
+	return resolvers
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// issueGroupTask issues the instances of a due group task to the live
+// agents matching its selector. A continuous group task issues an instance
+// to each of them every interval, so agents joining or leaving the group
+// gain or lose it from the next instance on. A one-shot group task issues
+// one instance per agent and is re-checked every scheduler pass until each
+// instance has a result or has finished: agents joining the group meanwhile
+// are issued an instance, and instances of agents that left it are
+// cancelled unless they are being run by a live agent. A task whose
+// placement cannot be met issues no instances until it can; a one-shot task
+// keeps the instances it issued meanwhile.
+func (s *Server) issueGroupTask(ctx context.Context, windows []*models.MaintenanceWindow, task *models.Task, now time.Time) error {
+	agents, placed, err := s.groupAgents(ctx, task, now)
+	if err != nil {
+		return err
+	}
+
+	if task.IsContinuous() {
+		if !placed {
+			log.Printf("Group task %s: placement not met by the matching agents, skipping this interval", task.ID)
+		}
+		issued := make([]string, 0, len(agents))
+		for _, agentID := range agents {
+			instance := groupInstance(task, agentID, now)
+			if window := s.taskPaused(ctx, windows, instance); window != nil {
+				log.Printf("Group task %s: instance for %s paused by maintenance window %s (%s)", task.ID, agentID, window.ID, window.Name)
+				continue
+			}
+			if err := s.taskStore.ScheduleTask(ctx, instance); err != nil {
+				return err
+			}
+			issued = append(issued, agentID)
+		}
+		task.AgentIDs = issued
+		if err := s.taskStore.UpdateTask(ctx, task); err != nil {
+			return err
+		}
+		return s.taskStore.RescheduleContinuousTask(ctx, task, now.Add(task.Interval()))
+	}
+
+	members := make(map[string]bool, len(agents))
+	for _, agentID := range agents {
+		members[agentID] = true
+	}
+	if !placed {
+		log.Printf("Group task %s: placement not met by the matching agents, waiting for more to join", task.ID)
+		for _, agentID := range task.AgentIDs {
+			members[agentID] = true
+		}
+	}
+
+	open := false
+	for _, agentID := range task.AgentIDs {
+		instance, err := s.taskStore.GetTask(ctx, task.GroupInstanceID(agentID, now))
+		if err != nil || instance.IsFinished() {
+			continue
+		}
+		if stored, err := s.resultStore.HasResult(ctx, agentID, instance.ID); err != nil {
+			return err
+		} else if stored {
+			continue
+		}
+		// An agent that left the group keeps an instance it is running,
+		// unless it left by dying
+		if !members[agentID] && (instance.Status == string(models.TaskStatusPending) || !s.agentIsLive(ctx, agentID, now)) {
+			log.Printf("Group task %s: agent %s left the group, cancelling its instance", task.ID, agentID)
+			if err := s.taskStore.CancelTask(ctx, instance.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		open = true
+	}
+
+	for _, agentID := range agents {
+		if slices.Contains(task.AgentIDs, agentID) {
+			continue
+		}
+		instance := groupInstance(task, agentID, now)
+		if window := s.taskPaused(ctx, windows, instance); window != nil {
+			// Issued once the window ends, if the task is still open
+			open = true
+			continue
+		}
+		if err := s.taskStore.ScheduleTask(ctx, instance); err != nil {
+			return err
+		}
+		task.AgentIDs = append(task.AgentIDs, agentID)
+		open = true
+	}
+
+	// A group task no agent has matched yet waits for one to join
+	if open || len(task.AgentIDs) == 0 {
+		if len(task.AgentIDs) > 0 {
+			task.Status = string(models.TaskStatusRunning)
+		}
+		if err := s.taskStore.UpdateTask(ctx, task); err != nil {
+			return err
+		}
+		return s.taskStore.RescheduleContinuousTask(ctx, task, now.Add(continuousSchedulerInterval))
+	}
+
+	task.Status = string(models.TaskStatusCompleted)
+	if err := s.taskStore.FinishGroupTask(ctx, task); err != nil {
+		return err
+	}
+	s.recordTaskTransition(ctx, models.EventTaskCompleted, models.EventSeverityInfo, task, "")
+	return nil
+}
+
+// groupAgents returns the IDs of the live agents matching a group task's
+// selector, in ID order, picked by its placement if it has one. It reports
+// false if the placement cannot be met.
+func (s *Server) groupAgents(ctx context.Context, task *models.Task, now time.Time) ([]string, bool, error) {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var matching []*models.Agent
+	for _, agent := range agents {
+		if !agent.Alive || now.Sub(agent.LastSeen) > s.config.AgentLivenessWindow {
+			continue
+		}
+		if models.MatchesLabels(task.Selector, agent.Labels) {
+			matching = append(matching, agent)
+		}
+	}
+	if task.Placement != nil {
+		ids, placed := s.placeAgents(ctx, task, matching)
+		return ids, placed, nil
+	}
+
+	ids := make([]string, len(matching))
+	for i, agent := range matching {
+		ids[i] = agent.ID
+	}
+	sort.Strings(ids)
+	return ids, true, nil
+}
+
+// groupInstance returns the instance of a group task for an agent
+func groupInstance(task *models.Task, agentID string, now time.Time) *models.Task {
+	instance := models.NewTask(task.GroupInstanceID(agentID, now), agentID, task.ModuleName, task.Payload, now)
+	instance.ParentID = task.ID
+	instance.CorrelationID = task.CorrelationID
+	instance.LeaseTimeoutSeconds = task.LeaseTimeoutSeconds
+	instance.RetryPolicy = task.RetryPolicy
+	return instance
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+)
+
+// httpModuleName is the module whose results carry fetched HTTP responses
+const httpModuleName = "http_module"
+
+const (
+	// httpPeerWindow is how recent another region's response must be to be compared against
+	httpPeerWindow = 24 * time.Hour
+	// httpMinPeers is how many other regions must be served the same body
+	// before a region served a different one is flagged
+	httpMinPeers = 2
+)
+
+// analyzeHTTPContent compares the response body of a result with the body
+// the other regions are served for the same URL, opening an HTTP content
+// tampering incident for the agent's region when it was served something
+// else, and resolving it once the region is served the common body again
+func (s *Server) analyzeHTTPContent(ctx context.Context, result *models.MeasurementResult) {
+	if result.ModuleName != httpModuleName {
+		return
+	}
+
+	url, _ := lookupString(result.Data, "url")
+	if url == "" {
+		return
+	}
+	observation := &models.HTTPObservation{
+		Region:     s.agentRegion(ctx, result.AgentID),
+		AgentID:    result.AgentID,
+		ResultID:   result.ID,
+		ObservedAt: time.Now(),
+	}
+	if status, ok := jsonpath.LookupFloat(result.Data, "status_code"); ok {
+		observation.StatusCode = int(status)
+	}
+	observation.Title, _ = lookupString(result.Data, "title")
+
+	// Agents may report the body or only its hash and length
+	body, hasBody := lookupString(result.Data, "body")
+	if hash, ok := lookupString(result.Data, "body_sha256"); ok {
+		observation.BodySHA256 = strings.ToLower(hash)
+	} else if hasBody {
+		sum := sha256.Sum256([]byte(body))
+		observation.BodySHA256 = hex.EncodeToString(sum[:])
+	} else {
+		return
+	}
+	if length, ok := jsonpath.LookupFloat(result.Data, "body_length"); ok {
+		observation.BodyLength = int64(length)
+	} else {
+		observation.BodyLength = int64(len(body))
+	}
+
+	// Read before recording, so the region's previous response is still there
+	observations, err := s.httpContentStore.ListObservations(ctx, url)
+	if err != nil {
+		log.Printf("HTTP analyzer: %s from %s: %v", url, observation.Region, err)
+		return
+	}
+	if err := s.httpContentStore.RecordObservation(ctx, url, observation); err != nil {
+		log.Printf("HTTP analyzer: recording %s from %s: %v", url, observation.Region, err)
+		return
+	}
+
+	reason, evidence := httpDivergence(observation, observations)
+
+	key := fmt.Sprintf("%s:%s:%s", models.IncidentTypeHTTPContentTampering, url, observation.Region)
+	if reason == "" {
+		err = s.resolveIncident(ctx, key)
+	} else {
+		err = s.raiseIncident(ctx, models.IncidentTypeHTTPContentTampering, key, observation.Region, url, result.AgentID, result.ID, reason, evidence)
+	}
+	if err != nil {
+		log.Printf("HTTP analyzer: incident %s: %v", key, err)
+	}
+}
+
+// httpDivergence classifies how a response differs from the body most other
+// recently reporting regions were served. A different status or a body less
+// than half as long is taken for a block page, a longer body for injected
+// content. It returns an empty reason if the response is not suspect.
+func httpDivergence(observation *models.HTTPObservation, observations []*models.HTTPObservation) (string, map[string]string) {
+	evidence := map[string]string{
+		"status_code": fmt.Sprint(observation.StatusCode),
+		"body_sha256": observation.BodySHA256,
+		"body_length": fmt.Sprint(observation.BodyLength),
+	}
+	if observation.Title != "" {
+		evidence["title"] = observation.Title
+	}
+
+	var peers []*models.HTTPObservation
+	for _, peer := range observations {
+		if peer.Region == observation.Region {
+			if peer.BodySHA256 != observation.BodySHA256 {
+				evidence["previous_sha256"] = peer.BodySHA256
+			}
+			continue
+		}
+		if observation.ObservedAt.Sub(peer.ObservedAt) > httpPeerWindow {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+
+	// Unavailable For Legal Reasons is a block page wherever it is served
+	legalBlock := observation.StatusCode == http.StatusUnavailableForLegalReasons
+
+	// The common body is the one served to a majority of the other regions
+	counts := make(map[string]int)
+	var common *models.HTTPObservation
+	for _, peer := range peers {
+		counts[peer.BodySHA256]++
+		if common == nil || counts[peer.BodySHA256] > counts[common.BodySHA256] {
+			common = peer
+		}
+	}
+	if common == nil || counts[common.BodySHA256] < httpMinPeers || counts[common.BodySHA256]*2 <= len(peers) {
+		// Without a common body, e.g. for pages rendered per request,
+		// regions differing is expected
+		if legalBlock {
+			return "block_page", evidence
+		}
+		return "", evidence
+	}
+	if observation.BodySHA256 == common.BodySHA256 && !legalBlock {
+		return "", evidence
+	}
+
+	evidence["expected_status_code"] = fmt.Sprint(common.StatusCode)
+	evidence["expected_sha256"] = common.BodySHA256
+	evidence["expected_length"] = fmt.Sprint(common.BodyLength)
+	evidence["peer_regions"] = fmt.Sprint(len(peers))
+
+	switch {
+	case legalBlock, observation.StatusCode != common.StatusCode, observation.BodyLength*2 < common.BodyLength:
+		return "block_page", evidence
+	case observation.BodyLength > common.BodyLength:
+		return "injected_content", evidence
+	default:
+		return "modified_content", evidence
+	}
+}
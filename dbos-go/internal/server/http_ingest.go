@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/api/validate"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxIngestBodyBytes bounds the size of an HTTP ingest request body
+const maxIngestBodyBytes = 8 << 20
+
+// newIngestHandler returns the HTTP/1.1 JSON fallback for probes that cannot
+// use gRPC. Requests are decoded into the gRPC request messages and served by
+// the same handlers, so validation is shared with the gRPC path.
+func (s *Server) newIngestHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/results", s.handleHTTPStoreResult)
+	mux.HandleFunc("POST /v1/heartbeat", s.handleHTTPHeartbeat)
+	mux.HandleFunc("POST /v1/tasks/lease", s.handleHTTPLeaseTask)
+	mux.HandleFunc("GET /v1/results/export", s.handleHTTPExportResults)
+	mux.HandleFunc("GET /v1/results/profile", s.handleHTTPProfileResults)
+	mux.HandleFunc("GET /v1/queries/{name}", s.handleHTTPExecuteSavedQuery)
+	if s.config.TokenAuth {
+		return withHTTPClientIdentity(s.withHTTPTokenAuth(mux))
+	}
+	return withHTTPClientIdentity(mux)
+}
+
+// startHTTPIngest serves the HTTP ingest endpoint on port, over TLS if the
+// gRPC server uses TLS
+func (s *Server) startHTTPIngest(ctx context.Context, port string) {
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		log.Printf("HTTP ingest endpoint not started: %v", err)
+		return
+	}
+	httpServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           s.newIngestHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         tlsConfig,
+	}
+
+	log.Printf("Starting HTTP ingest endpoint on port %s", port)
+	serve := httpServer.ListenAndServe
+	if tlsConfig != nil {
+		serve = func() error {
+			return httpServer.ListenAndServeTLS("", "")
+		}
+	}
+	if err := s.serveHTTP(ctx, httpServer, serve); err != nil {
+		log.Printf("HTTP ingest endpoint stopped: %v", err)
+	}
+}
+
+// handleHTTPStoreResult serves POST /v1/results with a StoreResultRequest body
+func (s *Server) handleHTTPStoreResult(w http.ResponseWriter, r *http.Request) {
+	req := &api.StoreResultRequest{}
+	if !decodeProtoBody(w, r, req) {
+		return
+	}
+	if !s.authorizeHTTPRequest(w, r, req) {
+		return
+	}
+
+	s.meterHTTP(w, r, api.DBOS_StoreResult_FullMethodName, req, func(w http.ResponseWriter) {
+		resp, _ := s.StoreResult(r.Context(), req)
+		writeProtoJSON(w, resp)
+	})
+}
+
+// handleHTTPHeartbeat serves POST /v1/heartbeat with a HeartbeatRequest body
+func (s *Server) handleHTTPHeartbeat(w http.ResponseWriter, r *http.Request) {
+	req := &api.HeartbeatRequest{}
+	if !decodeProtoBody(w, r, req) {
+		return
+	}
+	if !s.authorizeHTTPRequest(w, r, req) {
+		return
+	}
+
+	s.meterHTTP(w, r, api.DBOS_Heartbeat_FullMethodName, req, func(w http.ResponseWriter) {
+		resp, _ := s.Heartbeat(r.Context(), req)
+		writeProtoJSON(w, resp)
+	})
+}
+
+// handleHTTPLeaseTask serves POST /v1/tasks/lease with a LeaseTaskRequest body,
+// long-polling for up to wait_seconds
+func (s *Server) handleHTTPLeaseTask(w http.ResponseWriter, r *http.Request) {
+	req := &api.LeaseTaskRequest{}
+	if !decodeProtoBody(w, r, req) {
+		return
+	}
+	if !s.authorizeHTTPRequest(w, r, req) {
+		return
+	}
+
+	s.meterHTTP(w, r, api.DBOS_LeaseTask_FullMethodName, req, func(w http.ResponseWriter) {
+		resp, _ := s.LeaseTask(r.Context(), req)
+		writeProtoJSON(w, resp)
+	})
+}
+
+// decodeProtoBody decodes a JSON request body into msg and validates it as
+// gRPC requests are, answering 400 on failure
+func decodeProtoBody(w http.ResponseWriter, r *http.Request, msg proto.Message) bool {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxIngestBodyBytes))
+	if err != nil {
+		writeHTTPError(w, dberrors.Wrap(dberrors.InvalidArgument, err))
+		return false
+	}
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		writeHTTPError(w, dberrors.Wrap(dberrors.InvalidArgument, err))
+		return false
+	}
+	if err := validate.Message(msg); err != nil {
+		writeHTTPError(w, err)
+		return false
+	}
+	return true
+}
+
+// writeProtoJSON writes msg as JSON using the proto field names, with the
+// HTTP status of its error_code if it has one
+func writeProtoJSON(w http.ResponseWriter, msg proto.Message) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if field := msg.ProtoReflect().Descriptor().Fields().ByName("error_code"); field != nil {
+		if code := msg.ProtoReflect().Get(field).String(); code != "" {
+			w.WriteHeader(dberrors.HTTPStatus(dberrors.Code(code)))
+		}
+	}
+	w.Write(data)
+}
+
+// writeHTTPError answers with the HTTP status of an error's code and a JSON
+// body holding its message and code, as v1 responses report them
+func writeHTTPError(w http.ResponseWriter, err error) {
+	code := dberrors.CodeOf(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(dberrors.HTTPStatus(code))
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":      status.Convert(err).Message(),
+		"error_code": string(code),
+	})
+}
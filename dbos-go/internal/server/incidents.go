@@ -0,0 +1,368 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+)
+
+// raiseIncident records an occurrence of the condition identified by key,
+// seen in a result and linked to any events, opening an incident for it
+// unless one is already open
+func (s *Server) raiseIncident(ctx context.Context, incidentType models.IncidentTypeEnum, key, region, target, agentID, resultID, reason string, evidence map[string]string, eventIDs ...string) error {
+	now := time.Now()
+	incident, err := s.incidentStore.GetOpenIncident(ctx, key)
+	if err != nil {
+		return err
+	}
+	opened := incident == nil
+	if opened {
+		incident = &models.Incident{
+			ID:        ids.New("incident-"),
+			Type:      string(incidentType),
+			Key:       key,
+			Status:    models.IncidentStatusOpen,
+			Region:    region,
+			Target:    target,
+			FirstSeen: now,
+		}
+		where := target
+		if region != "" {
+			where += " in " + region
+		}
+		log.Printf("Incident %s opened: %s for %s (%s)", incident.ID, incidentType, where, reason)
+	}
+
+	incident.Recur(agentID, resultID, reason, evidence, now)
+	incident.LinkEvents(eventIDs...)
+	if err := s.incidentStore.SaveIncident(ctx, incident); err != nil {
+		return err
+	}
+	if opened {
+		s.emitIncidentEvent(ctx, models.IncidentEventOpened, incident)
+	}
+	return nil
+}
+
+// linkIncidentEvents links events to the open incident for key, reporting
+// whether there was one
+func (s *Server) linkIncidentEvents(ctx context.Context, key string, eventIDs ...string) (bool, error) {
+	incident, err := s.incidentStore.GetOpenIncident(ctx, key)
+	if err != nil || incident == nil {
+		return false, err
+	}
+
+	incident.LinkEvents(eventIDs...)
+	return true, s.incidentStore.SaveIncident(ctx, incident)
+}
+
+// resolveIncident resolves the open incident for key, if any
+func (s *Server) resolveIncident(ctx context.Context, key string) error {
+	incident, err := s.incidentStore.ResolveIncident(ctx, key, time.Now())
+	if err != nil {
+		return err
+	}
+	if incident != nil {
+		log.Printf("Incident %s resolved after %d occurrences", incident.ID, incident.Occurrences)
+		s.emitIncidentEvent(ctx, models.IncidentEventResolved, incident)
+	}
+	return nil
+}
+
+// GetIncident retrieves an incident by ID
+func (s *Server) GetIncident(ctx context.Context, req *api.GetIncidentRequest) (*api.GetIncidentResponse, error) {
+	incident, err := s.incidentStore.GetIncident(ctx, req.Id)
+	if err != nil {
+		return &api.GetIncidentResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.GetIncidentResponse{
+		Found:    true,
+		Incident: incidentToAPI(incident),
+	}, nil
+}
+
+// ListIncidents retrieves incidents, most recently seen first
+func (s *Server) ListIncidents(ctx context.Context, req *api.ListIncidentsRequest) (*api.ListIncidentsResponse, error) {
+	incidents, err := s.incidentStore.ListIncidents(ctx, req.Type, req.Status, req.Region, int(req.Limit))
+	if err != nil {
+		return &api.ListIncidentsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiIncidents := make([]*api.Incident, len(incidents))
+	for i, incident := range incidents {
+		apiIncidents[i] = incidentToAPI(incident)
+	}
+
+	return &api.ListIncidentsResponse{
+		Incidents: apiIncidents,
+	}, nil
+}
+
+// CreateIncident opens an incident declared by an operator
+func (s *Server) CreateIncident(ctx context.Context, req *api.CreateIncidentRequest) (*api.CreateIncidentResponse, error) {
+	incidentType := req.Type
+	if incidentType == "" {
+		incidentType = string(models.IncidentTypeManual)
+	}
+
+	id := ids.New("incident-")
+	now := time.Now()
+	incident := &models.Incident{
+		ID:          id,
+		Type:        incidentType,
+		Key:         "manual:" + id,
+		Status:      models.IncidentStatusOpen,
+		Title:       req.Title,
+		Region:      req.Region,
+		Target:      req.Target,
+		Reason:      req.Reason,
+		Occurrences: 1,
+		FirstSeen:   now,
+		LastSeen:    now,
+	}
+	incident.AddTargets(req.Targets...)
+	incident.AddAgents(req.AgentIds...)
+	incident.LinkResults(req.ResultIds...)
+	incident.LinkAlerts(req.AlertIds...)
+
+	if err := s.incidentStore.SaveIncident(ctx, incident); err != nil {
+		return &api.CreateIncidentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	log.Printf("Incident %s opened by %s: %s", incident.ID, authorOrUnknown(req.Author), incident.Title)
+	s.emitIncidentEvent(ctx, models.IncidentEventOpened, incident)
+
+	return &api.CreateIncidentResponse{
+		Success:  true,
+		Incident: incidentToAPI(incident),
+	}, nil
+}
+
+// UpdateIncident changes an incident's title and reason and links further
+// agents, targets, results and alerts
+func (s *Server) UpdateIncident(ctx context.Context, req *api.UpdateIncidentRequest) (*api.UpdateIncidentResponse, error) {
+	incident, err := s.updateIncident(ctx, req.Id, models.IncidentEventUpdated, func(incident *models.Incident) error {
+		if req.Title != "" {
+			incident.Title = req.Title
+		}
+		if req.Reason != "" {
+			incident.Reason = req.Reason
+		}
+		incident.AddAgents(req.AddAgentIds...)
+		incident.AddTargets(req.AddTargets...)
+		incident.LinkResults(req.AddResultIds...)
+		incident.LinkAlerts(req.AddAlertIds...)
+		return nil
+	})
+	if err != nil {
+		return &api.UpdateIncidentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.UpdateIncidentResponse{
+		Success:  true,
+		Incident: incidentToAPI(incident),
+	}, nil
+}
+
+// AcknowledgeIncident records who is handling an active incident
+func (s *Server) AcknowledgeIncident(ctx context.Context, req *api.AcknowledgeIncidentRequest) (*api.AcknowledgeIncidentResponse, error) {
+	incident, err := s.updateIncident(ctx, req.Id, models.IncidentEventAcknowledged, func(incident *models.Incident) error {
+		if !incident.Active() {
+			return dberrors.New(dberrors.InvalidTransition, "incident %s is resolved", incident.ID)
+		}
+		incident.Acknowledge(req.Author, time.Now())
+		return nil
+	})
+	if err != nil {
+		return &api.AcknowledgeIncidentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.AcknowledgeIncidentResponse{
+		Success:  true,
+		Incident: incidentToAPI(incident),
+	}, nil
+}
+
+// ResolveIncident resolves an active incident by hand, optionally leaving a
+// comment. An automatically detected condition that persists opens a new
+// incident.
+func (s *Server) ResolveIncident(ctx context.Context, req *api.ResolveIncidentRequest) (*api.ResolveIncidentResponse, error) {
+	incident, err := s.updateIncident(ctx, req.Id, models.IncidentEventResolved, func(incident *models.Incident) error {
+		if !incident.Active() {
+			return dberrors.New(dberrors.InvalidTransition, "incident %s is already resolved", incident.ID)
+		}
+		now := time.Now()
+		if req.Comment != "" {
+			addIncidentComment(incident, req.Author, req.Comment, now)
+		}
+		incident.Resolve(now)
+		incident.ResolvedBy = req.Author
+		return nil
+	})
+	if err != nil {
+		return &api.ResolveIncidentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	log.Printf("Incident %s resolved by %s", incident.ID, authorOrUnknown(req.Author))
+
+	return &api.ResolveIncidentResponse{
+		Success:  true,
+		Incident: incidentToAPI(incident),
+	}, nil
+}
+
+// AddIncidentComment leaves a comment on an incident
+func (s *Server) AddIncidentComment(ctx context.Context, req *api.AddIncidentCommentRequest) (*api.AddIncidentCommentResponse, error) {
+	incident, err := s.updateIncident(ctx, req.Id, models.IncidentEventCommented, func(incident *models.Incident) error {
+		addIncidentComment(incident, req.Author, req.Body, time.Now())
+		return nil
+	})
+	if err != nil {
+		return &api.AddIncidentCommentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.AddIncidentCommentResponse{
+		Success:  true,
+		Incident: incidentToAPI(incident),
+	}, nil
+}
+
+// DeleteIncident removes an incident, such as one opened by mistake
+func (s *Server) DeleteIncident(ctx context.Context, req *api.DeleteIncidentRequest) (*api.DeleteIncidentResponse, error) {
+	incident, err := s.incidentStore.GetIncident(ctx, req.Id)
+	if err == nil {
+		err = s.incidentStore.DeleteIncident(ctx, incident)
+	}
+	if err != nil {
+		return &api.DeleteIncidentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	s.emitIncidentEvent(ctx, models.IncidentEventDeleted, incident)
+
+	return &api.DeleteIncidentResponse{
+		Success: true,
+	}, nil
+}
+
+// updateIncident applies change to an incident, then saves it and records
+// the change as an event of eventType
+func (s *Server) updateIncident(ctx context.Context, id string, eventType models.IncidentEventTypeEnum, change func(*models.Incident) error) (*models.Incident, error) {
+	incident, err := s.incidentStore.GetIncident(ctx, id)
+	if err != nil {
+		return nil, dberrors.New(dberrors.NotFound, "incident %s not found", id)
+	}
+	if err := change(incident); err != nil {
+		return nil, err
+	}
+	if err := s.incidentStore.SaveIncident(ctx, incident); err != nil {
+		return nil, err
+	}
+
+	s.emitIncidentEvent(ctx, eventType, incident)
+	return incident, nil
+}
+
+// addIncidentComment appends a comment to an incident
+func addIncidentComment(incident *models.Incident, author, body string, at time.Time) {
+	incident.Comments = append(incident.Comments, models.IncidentComment{
+		ID:        ids.New("comment-"),
+		Author:    author,
+		Body:      body,
+		CreatedAt: at,
+	})
+}
+
+// emitIncidentEvent records a change to an incident in the incident event log
+func (s *Server) emitIncidentEvent(ctx context.Context, eventType models.IncidentEventTypeEnum, incident *models.Incident) {
+	if _, err := s.incidentStore.AppendEvent(ctx, eventType, incident); err != nil {
+		log.Printf("Incident %s: recording %s event: %v", incident.ID, eventType, err)
+	}
+}
+
+// authorOrUnknown names the author of a change in logs
+func authorOrUnknown(author string) string {
+	if author == "" {
+		return "unknown"
+	}
+	return author
+}
+
+// incidentToAPI converts an incident model to its API representation
+func incidentToAPI(incident *models.Incident) *api.Incident {
+	var acknowledgedAt, resolvedAt int64
+	if !incident.AcknowledgedAt.IsZero() {
+		acknowledgedAt = incident.AcknowledgedAt.Unix()
+	}
+	if !incident.ResolvedAt.IsZero() {
+		resolvedAt = incident.ResolvedAt.Unix()
+	}
+
+	comments := make([]*api.IncidentComment, len(incident.Comments))
+	for i, comment := range incident.Comments {
+		comments[i] = &api.IncidentComment{
+			Id:        comment.ID,
+			Author:    comment.Author,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt.Unix(),
+		}
+	}
+
+	return &api.Incident{
+		Id:             incident.ID,
+		Type:           incident.Type,
+		Key:            incident.Key,
+		Status:         string(incident.Status),
+		Region:         incident.Region,
+		Target:         incident.Target,
+		Reason:         incident.Reason,
+		AgentIds:       incident.AgentIDs,
+		ResultIds:      incident.ResultIDs,
+		EventIds:       incident.EventIDs,
+		Evidence:       incident.Evidence,
+		Occurrences:    incident.Occurrences,
+		FirstSeen:      incident.FirstSeen.Unix(),
+		LastSeen:       incident.LastSeen.Unix(),
+		ResolvedAt:     resolvedAt,
+		Title:          incident.Title,
+		Targets:        incident.Targets,
+		AlertIds:       incident.AlertIDs,
+		Comments:       comments,
+		AcknowledgedBy: incident.AcknowledgedBy,
+		AcknowledgedAt: acknowledgedAt,
+		ResolvedBy:     incident.ResolvedBy,
+	}
+}
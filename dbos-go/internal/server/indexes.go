@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+const (
+	// defaultQueryResultsLimit and maxQueryResultsLimit bound QueryResults responses
+	defaultQueryResultsLimit = 100
+	maxQueryResultsLimit     = 1000
+)
+
+// CreateExtractionRule declares a column extracted from a module's results
+// at ingest, optionally backfilling it from already stored results
+func (s *Server) CreateExtractionRule(ctx context.Context, req *api.CreateExtractionRuleRequest) (*api.CreateExtractionRuleResponse, error) {
+	rule := models.NewExtractionRule(req.Rule.ModuleName, req.Rule.Column, req.Rule.Path, req.Rule.Type)
+	if err := s.indexStore.CreateRule(ctx, rule); err != nil {
+		return &api.CreateExtractionRuleResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	if req.Backfill {
+		if err := s.backfillExtractionRule(ctx, rule); err != nil {
+			return &api.CreateExtractionRuleResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+	}
+
+	return &api.CreateExtractionRuleResponse{
+		Success: true,
+	}, nil
+}
+
+// ListExtractionRules retrieves the extraction rules
+func (s *Server) ListExtractionRules(ctx context.Context, req *api.ListExtractionRulesRequest) (*api.ListExtractionRulesResponse, error) {
+	rules, err := s.indexStore.ListRules(ctx, req.ModuleName)
+	if err != nil {
+		return &api.ListExtractionRulesResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiRules := make([]*api.ExtractionRule, len(rules))
+	for i, rule := range rules {
+		apiRules[i] = &api.ExtractionRule{
+			ModuleName: rule.ModuleName,
+			Column:     rule.Column,
+			Path:       rule.Path,
+			Type:       rule.Type,
+			CreatedAt:  rule.CreatedAt.Unix(),
+		}
+	}
+
+	return &api.ListExtractionRulesResponse{
+		Rules: apiRules,
+	}, nil
+}
+
+// DeleteExtractionRule removes an extraction rule and its index
+func (s *Server) DeleteExtractionRule(ctx context.Context, req *api.DeleteExtractionRuleRequest) (*api.DeleteExtractionRuleResponse, error) {
+	err := s.indexStore.DeleteRule(ctx, req.ModuleName, req.Column)
+	if err != nil {
+		return &api.DeleteExtractionRuleResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.DeleteExtractionRuleResponse{
+		Success: true,
+	}, nil
+}
+
+// QueryResults finds a module's results by the values of extracted columns
+func (s *Server) QueryResults(ctx context.Context, req *api.QueryResultsRequest) (*api.QueryResultsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultQueryResultsLimit
+	}
+	limit = min(limit, maxQueryResultsLimit)
+
+	filters := make([]models.ColumnFilter, len(req.Filters))
+	for i, filter := range req.Filters {
+		filters[i] = models.ColumnFilter{
+			Column: filter.Column,
+			Op:     filter.Op,
+			Value:  filter.Value,
+		}
+	}
+
+	refs, err := s.indexStore.QueryResults(ctx, req.ModuleName, filters, req.AgentId, limit)
+	if err != nil {
+		return &api.QueryResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiResults := make([]*api.MeasurementResult, 0, len(refs))
+	for _, ref := range refs {
+		result, err := s.resultStore.GetResult(ctx, ref.AgentID, ref.RequestID)
+		if err != nil {
+			continue
+		}
+		apiResults = append(apiResults, resultToAPI(result))
+	}
+
+	return &api.QueryResultsResponse{
+		Results: apiResults,
+	}, nil
+}
+
+// backfillExtractionRule indexes the stored results of every known agent
+// under a new column
+func (s *Server) backfillExtractionRule(ctx context.Context, rule *models.ExtractionRule) error {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, agent := range agents {
+		results, err := s.resultStore.ListResults(ctx, agent.ID)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			if err := s.indexStore.ApplyRule(ctx, rule, result); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+const (
+	// maxLeaseWait caps how long a LeaseTask call may long-poll
+	maxLeaseWait = 30 * time.Second
+
+	// leasePollInterval is how often a long-polling LeaseTask re-checks for due tasks
+	leasePollInterval = 500 * time.Millisecond
+
+	// maxTaskAckBatch caps the tasks of one AckTasks or NackTasks call
+	maxTaskAckBatch = 1000
+)
+
+// LeaseTask hands the agent its next due task, marking it running. If none is
+// due it waits up to wait_seconds for one to become due. A task handed out
+// under load comes with a backoff hint.
+func (s *Server) LeaseTask(ctx context.Context, req *api.LeaseTaskRequest) (*api.LeaseTaskResponse, error) {
+	wait := time.Duration(req.WaitSeconds) * time.Second
+	if wait > maxLeaseWait {
+		wait = maxLeaseWait
+	}
+	deadline := time.Now().Add(wait)
+
+	for {
+		task, err := s.leaseTask(ctx, req.AgentId)
+		if err != nil {
+			return &api.LeaseTaskResponse{
+				Found:     false,
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+		if task != nil {
+			return &api.LeaseTaskResponse{
+				Found:   true,
+				Task:    taskToAPI(task),
+				Backoff: s.backoff(backoffLow, 0),
+			}, nil
+		}
+
+		if time.Now().Add(leasePollInterval).After(deadline) {
+			return &api.LeaseTaskResponse{
+				Found: false,
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return &api.LeaseTaskResponse{
+				Found:     false,
+				Error:     ctx.Err().Error(),
+				ErrorCode: errorCode(ctx.Err()),
+			}, nil
+		case <-time.After(leasePollInterval):
+		}
+	}
+}
+
+// leaseTask leases the agent's next due task, if any, recording the lease
+// in the event log
+func (s *Server) leaseTask(ctx context.Context, agentID string) (*models.Task, error) {
+	task, err := s.taskStore.LeaseTask(ctx, agentID, time.Now())
+	if err == nil && task != nil {
+		s.recordTaskTransition(ctx, models.EventTaskLeased, models.EventSeverityInfo, task, "")
+	}
+	return task, err
+}
+
+// AckTasks marks a batch of tasks the agent leased as completed, releasing
+// their leases and recording how long they ran and an event per task, and
+// reports the outcome per task
+func (s *Server) AckTasks(ctx context.Context, req *api.AckTasksRequest) (*api.AckTasksResponse, error) {
+	if len(req.TaskIds) > maxTaskAckBatch {
+		return &api.AckTasksResponse{
+			Error:     fmt.Sprintf("at most %d tasks can be acknowledged at once", maxTaskAckBatch),
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+
+	errs, err := s.taskStore.AckTasks(ctx, req.AgentId, req.TaskIds)
+	if err != nil {
+		return &api.AckTasksResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	acked := s.recordSettledTasks(ctx, models.EventTaskAcked, models.EventSeverityInfo, "", req.TaskIds, errs)
+	s.recordAckedExecutions(ctx, acked)
+
+	return &api.AckTasksResponse{
+		Results: taskAcks(req.TaskIds, errs),
+	}, nil
+}
+
+// NackTasks hands back a batch of tasks the agent leased but could not
+// complete, requeueing them as retries after retry_delay_seconds, or later
+// as their retry policies back off, or failing them, recording an event per
+// task, and reports the outcome per task
+func (s *Server) NackTasks(ctx context.Context, req *api.NackTasksRequest) (*api.NackTasksResponse, error) {
+	if len(req.TaskIds) > maxTaskAckBatch {
+		return &api.NackTasksResponse{
+			Error:     fmt.Sprintf("at most %d tasks can be handed back at once", maxTaskAckBatch),
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+
+	var requeueAt time.Time
+	if req.Requeue {
+		requeueAt = time.Now().Add(time.Duration(req.RetryDelaySeconds) * time.Second)
+	}
+	errs, err := s.taskStore.NackTasks(ctx, req.AgentId, req.TaskIds, requeueAt, true)
+	if err != nil {
+		return &api.NackTasksResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	s.recordSettledTasks(ctx, models.EventTaskNacked, models.EventSeverityWarning, "", req.TaskIds, errs)
+
+	return &api.NackTasksResponse{
+		Results: taskAcks(req.TaskIds, errs),
+	}, nil
+}
+
+// ExtendTaskVisibility renews the lease the agent holds on a running task
+// so that it ends duration_seconds from now, keeping a long measurement from
+// being requeued while it still runs
+func (s *Server) ExtendTaskVisibility(ctx context.Context, req *api.ExtendTaskVisibilityRequest) (*api.ExtendTaskVisibilityResponse, error) {
+	now := time.Now()
+	task, err := s.taskStore.ExtendLease(ctx, req.AgentId, req.TaskId, now.Add(time.Duration(req.DurationSeconds)*time.Second), now)
+	if err != nil {
+		return &api.ExtendTaskVisibilityResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.ExtendTaskVisibilityResponse{
+		Success:        true,
+		LeaseExpiresAt: models.TimeOf(task.LeaseExpiresAt).Unix(),
+	}, nil
+}
+
+// taskAcks converts the per-task errors of a batch into its outcomes
+func taskAcks(taskIDs []string, errs []error) []*api.TaskAck {
+	acks := make([]*api.TaskAck, len(taskIDs))
+	for i, taskID := range taskIDs {
+		acks[i] = &api.TaskAck{
+			TaskId:  taskID,
+			Success: errs[i] == nil,
+		}
+		if errs[i] != nil {
+			acks[i].Error = errs[i].Error()
+			acks[i].ErrorCode = errorCode(errs[i])
+		}
+	}
+	return acks
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+const (
+	// livenessSweepInterval is how often agents are checked for missed heartbeats
+	livenessSweepInterval = 5 * time.Second
+)
+
+// Heartbeat records that an agent is alive, registering it if unknown, and
+// leases up to max_tasks of its due tasks so that small probes need not poll
+// with LeaseTask as well. A task handed out under load comes with a backoff
+// hint.
+func (s *Server) Heartbeat(ctx context.Context, req *api.HeartbeatRequest) (*api.HeartbeatResponse, error) {
+	agent, err := s.agentStore.RecordHeartbeat(ctx, req.AgentId, req.Hostname, time.Now())
+	if err != nil {
+		return &api.HeartbeatResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	resp := &api.HeartbeatResponse{
+		Success: true,
+		Agent:   agentToAPI(agent),
+	}
+	for len(resp.Tasks) < int(req.MaxTasks) {
+		task, err := s.leaseTask(ctx, req.AgentId)
+		if err != nil {
+			// The heartbeat was recorded and the tasks leased so far must
+			// reach the agent; the others are leased by a later heartbeat
+			log.Printf("Heartbeat of agent %s: leasing tasks: %v", req.AgentId, err)
+			break
+		}
+		if task == nil {
+			break
+		}
+		resp.Tasks = append(resp.Tasks, taskToAPI(task))
+	}
+	if len(resp.Tasks) > 0 {
+		resp.Backoff = s.backoff(backoffLow, 0)
+	}
+	return resp, nil
+}
+
+// runLivenessSweeper periodically marks agents dead once their heartbeats
+// stop arriving, until ctx is done
+func (s *Server) runLivenessSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.sweepDeadAgents(ctx, now); err != nil {
+				log.Printf("Liveness sweeper: %v", err)
+			}
+		}
+	}
+}
+
+// sweepDeadAgents marks every alive agent not seen within the liveness
+// window as dead
+func (s *Server) sweepDeadAgents(ctx context.Context, now time.Time) error {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return err
+	}
+
+	cutoff := now.Add(-s.config.AgentLivenessWindow)
+	for _, agent := range agents {
+		if !agent.Alive || !agent.LastSeen.Before(cutoff) {
+			continue
+		}
+		// Re-checked by the store so a heartbeat racing the sweep wins
+		marked, err := s.agentStore.MarkDead(ctx, agent.ID, cutoff)
+		if err != nil {
+			log.Printf("Liveness sweeper: agent %s: %v", agent.ID, err)
+			continue
+		}
+		if marked {
+			log.Printf("Agent %s missed heartbeats since %s, marked dead", agent.ID, agent.LastSeen.Format(time.RFC3339))
+			s.recordEvent(ctx, models.EventAgentMarkedDead, models.EventSeverityWarning, &models.Event{
+				Actor:   models.EventActorSystem,
+				Subject: agentName(agent.ID),
+				AgentID: agent.ID,
+			}, map[string]time.Time{"last_seen": agent.LastSeen})
+		}
+	}
+	return nil
+}
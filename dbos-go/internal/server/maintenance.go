@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+)
+
+// taskTargetPaths are the task payload fields naming a task's target, in
+// order of preference
+var taskTargetPaths = []string{"target", "host"}
+
+// CreateMaintenanceWindow schedules a new maintenance window
+func (s *Server) CreateMaintenanceWindow(ctx context.Context, req *api.CreateMaintenanceWindowRequest) (*api.CreateMaintenanceWindowResponse, error) {
+	window := maintenanceWindowFromAPI(req.Window)
+	window.ID = ids.New("maintenance-")
+	window.CreatedAt = time.Now()
+	if err := s.maintenanceStore.SaveWindow(ctx, window); err != nil {
+		return &api.CreateMaintenanceWindowResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	log.Printf("Maintenance window %s (%s) scheduled by %s from %s to %s", window.ID, window.Name, authorOrUnknown(window.CreatedBy),
+		window.StartsAt.UTC().Format(time.RFC3339), window.EndsAt.UTC().Format(time.RFC3339))
+	return &api.CreateMaintenanceWindowResponse{
+		Success: true,
+		Window:  maintenanceWindowToAPI(window, time.Now()),
+	}, nil
+}
+
+// GetMaintenanceWindow retrieves a maintenance window by ID
+func (s *Server) GetMaintenanceWindow(ctx context.Context, req *api.GetMaintenanceWindowRequest) (*api.GetMaintenanceWindowResponse, error) {
+	window, err := s.maintenanceStore.GetWindow(ctx, req.Id)
+	if err != nil {
+		return &api.GetMaintenanceWindowResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.GetMaintenanceWindowResponse{
+		Found:  true,
+		Window: maintenanceWindowToAPI(window, time.Now()),
+	}, nil
+}
+
+// ListMaintenanceWindows retrieves maintenance windows ordered by start,
+// optionally only those in progress
+func (s *Server) ListMaintenanceWindows(ctx context.Context, req *api.ListMaintenanceWindowsRequest) (*api.ListMaintenanceWindowsResponse, error) {
+	now := time.Now()
+	var windows []*models.MaintenanceWindow
+	var err error
+	if req.ActiveOnly {
+		windows, err = s.maintenanceStore.ListActiveWindows(ctx, now)
+	} else {
+		windows, err = s.maintenanceStore.ListWindows(ctx)
+	}
+	if err != nil {
+		return &api.ListMaintenanceWindowsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiWindows := make([]*api.MaintenanceWindow, len(windows))
+	for i, window := range windows {
+		apiWindows[i] = maintenanceWindowToAPI(window, now)
+	}
+
+	return &api.ListMaintenanceWindowsResponse{
+		Windows: apiWindows,
+	}, nil
+}
+
+// UpdateMaintenanceWindow replaces the definition of a maintenance window,
+// e.g. to extend or end it early
+func (s *Server) UpdateMaintenanceWindow(ctx context.Context, req *api.UpdateMaintenanceWindowRequest) (*api.UpdateMaintenanceWindowResponse, error) {
+	existing, err := s.maintenanceStore.GetWindow(ctx, req.Window.Id)
+	if err != nil {
+		return &api.UpdateMaintenanceWindowResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	window := maintenanceWindowFromAPI(req.Window)
+	window.ID = existing.ID
+	window.CreatedBy = existing.CreatedBy
+	window.CreatedAt = existing.CreatedAt
+	if err := s.maintenanceStore.SaveWindow(ctx, window); err != nil {
+		return &api.UpdateMaintenanceWindowResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.UpdateMaintenanceWindowResponse{
+		Success: true,
+		Window:  maintenanceWindowToAPI(window, time.Now()),
+	}, nil
+}
+
+// DeleteMaintenanceWindow removes a maintenance window, ending it if it is
+// in progress
+func (s *Server) DeleteMaintenanceWindow(ctx context.Context, req *api.DeleteMaintenanceWindowRequest) (*api.DeleteMaintenanceWindowResponse, error) {
+	if err := s.maintenanceStore.DeleteWindow(ctx, req.Id); err != nil {
+		return &api.DeleteMaintenanceWindowResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.DeleteMaintenanceWindowResponse{
+		Success: true,
+	}, nil
+}
+
+// maintenanceWindowCovering returns the first of windows covering an agent
+// or a target, or nil. The agent is only looked up if a window is scoped
+// to agents.
+func (s *Server) maintenanceWindowCovering(ctx context.Context, windows []*models.MaintenanceWindow, agentID, target string) *models.MaintenanceWindow {
+	var agent *models.Agent
+	lookedUp := false
+	for _, window := range windows {
+		if window.CoversTarget(target) {
+			return window
+		}
+		if agentID == "" || (len(window.Selector) == 0 && window.Tenant == "") {
+			continue
+		}
+		if !lookedUp {
+			agent, _ = s.agentStore.GetAgent(ctx, agentID)
+			lookedUp = true
+		}
+		if agent != nil && window.CoversAgent(agent) {
+			return window
+		}
+	}
+	return nil
+}
+
+// alertSuppressed reports the maintenance window in progress that covers an
+// alert's agent or target, or nil. Alerts are not suppressed if the windows
+// cannot be read.
+func (s *Server) alertSuppressed(ctx context.Context, agentID, target string, now time.Time) *models.MaintenanceWindow {
+	windows, err := s.maintenanceStore.ListActiveWindows(ctx, now)
+	if err != nil {
+		log.Printf("Maintenance windows: %v", err)
+		return nil
+	}
+	return s.maintenanceWindowCovering(ctx, windows, agentID, target)
+}
+
+// taskPaused reports the window of windows that pauses a continuous task,
+// or nil
+func (s *Server) taskPaused(ctx context.Context, windows []*models.MaintenanceWindow, task *models.Task) *models.MaintenanceWindow {
+	pausing := make([]*models.MaintenanceWindow, 0, len(windows))
+	for _, window := range windows {
+		if window.PauseTasks {
+			pausing = append(pausing, window)
+		}
+	}
+	if len(pausing) == 0 {
+		return nil
+	}
+	return s.maintenanceWindowCovering(ctx, pausing, task.AgentID, taskTarget(task.Payload))
+}
+
+// taskTarget returns the target a task payload names, or ""
+func taskTarget(payload []byte) string {
+	for _, path := range taskTargetPaths {
+		if raw, ok := jsonpath.Lookup(payload, path); ok {
+			if target := labelValue(raw); target != "" {
+				return target
+			}
+		}
+	}
+	return ""
+}
+
+// agentMaintenanceWindowIDs returns the IDs of the windows covering an agent
+func agentMaintenanceWindowIDs(windows []*models.MaintenanceWindow, agent *models.Agent) []string {
+	var ids []string
+	for _, window := range windows {
+		if window.CoversAgent(agent) {
+			ids = append(ids, window.ID)
+		}
+	}
+	return ids
+}
+
+// maintenanceWindowFromAPI converts an API maintenance window into a model one
+func maintenanceWindowFromAPI(w *api.MaintenanceWindow) *models.MaintenanceWindow {
+	return &models.MaintenanceWindow{
+		ID:          w.Id,
+		Name:        w.Name,
+		Description: w.Description,
+		Selector:    w.Selector,
+		Tenant:      w.Tenant,
+		Targets:     w.Targets,
+		StartsAt:    timeFromUnix(w.StartsAt),
+		EndsAt:      timeFromUnix(w.EndsAt),
+		PauseTasks:  w.PauseTasks,
+		CreatedBy:   w.CreatedBy,
+	}
+}
+
+// timeFromUnix converts unix seconds into a time, 0 into the zero time
+func timeFromUnix(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// maintenanceWindowToAPI converts a model maintenance window into an API one
+func maintenanceWindowToAPI(w *models.MaintenanceWindow, now time.Time) *api.MaintenanceWindow {
+	return &api.MaintenanceWindow{
+		Id:          w.ID,
+		Name:        w.Name,
+		Description: w.Description,
+		Selector:    w.Selector,
+		Tenant:      w.Tenant,
+		Targets:     w.Targets,
+		StartsAt:    w.StartsAt.Unix(),
+		EndsAt:      w.EndsAt.Unix(),
+		PauseTasks:  w.PauseTasks,
+		CreatedBy:   w.CreatedBy,
+		CreatedAt:   w.CreatedAt.Unix(),
+		Active:      w.ActiveAt(now),
+	}
+}
@@ -0,0 +1,257 @@
+package server
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/influx"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+	"github.com/internet-measurement-network/dbos/pkg/remotewrite"
+)
+
+// maxBufferedSamples bounds the samples a sink holds between pushes;
+// samples arriving while the buffer is full are dropped
+const maxBufferedSamples = 100000
+
+// metricSample is one numeric value extracted from a result, or counted by
+// the server itself
+type metricSample struct {
+	Name    string
+	AgentID string
+	Module  string
+	Target  string
+	// Labels are further labels of samples the server counts, such as the
+	// principal of API usage
+	Labels    map[string]string
+	Value     float64
+	Timestamp time.Time
+}
+
+// extractMetrics returns the values of every configured metric field found in a result
+func extractMetrics(fields []MetricField, result *models.MeasurementResult) []metricSample {
+	var samples []metricSample
+	for _, field := range fields {
+		if field.Module != result.ModuleName {
+			continue
+		}
+
+		value, ok := jsonpath.LookupFloat(result.Data, field.Path)
+		if !ok {
+			continue
+		}
+
+		target := ""
+		if field.TargetPath != "" {
+			if raw, ok := jsonpath.Lookup(result.Data, field.TargetPath); ok {
+				target = labelValue(raw)
+			}
+		}
+
+		samples = append(samples, metricSample{
+			Name:      field.Name,
+			AgentID:   result.AgentID,
+			Module:    result.ModuleName,
+			Target:    target,
+			Value:     value,
+			Timestamp: result.Timestamp,
+		})
+	}
+	return samples
+}
+
+// labelValue renders a decoded JSON string or number as a label value
+func labelValue(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	if f, ok := jsonpath.ToFloat(value); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return ""
+}
+
+// sampleBuffer accumulates samples between pushes to a sink
+type sampleBuffer struct {
+	name    string
+	mu      sync.Mutex
+	samples []metricSample
+}
+
+// add buffers samples, dropping them if the buffer is full
+func (b *sampleBuffer) add(samples []metricSample) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.samples)+len(samples) > maxBufferedSamples {
+		log.Printf("%s: buffer full, dropping %d samples", b.name, len(samples))
+		return
+	}
+	b.samples = append(b.samples, samples...)
+}
+
+// drain returns and clears the buffered samples
+func (b *sampleBuffer) drain() []metricSample {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	samples := b.samples
+	b.samples = nil
+	return samples
+}
+
+// exportMetrics buffers a result's metric fields for the configured sinks
+func (s *Server) exportMetrics(result *models.MeasurementResult) {
+	if s.remoteWrite == nil && s.influx == nil {
+		return
+	}
+
+	s.exportSamples(extractMetrics(s.config.MetricFields, result))
+}
+
+// exportSamples buffers samples for the configured sinks
+func (s *Server) exportSamples(samples []metricSample) {
+	if len(samples) == 0 {
+		return
+	}
+	if s.remoteWrite != nil {
+		s.remoteWriteBuffer.add(samples)
+	}
+	if s.influx != nil {
+		s.influxBuffer.add(samples)
+	}
+}
+
+// runMetricSink periodically pushes the samples buffered for a sink with
+// write until ctx is done, then pushes what is left. Samples of a failed
+// push are dropped.
+func (s *Server) runMetricSink(ctx context.Context, interval time.Duration, buf *sampleBuffer, write func(context.Context, []metricSample) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), metricSinkFlushTimeout)
+			defer cancel()
+			flushMetricSink(flushCtx, buf, write)
+			return
+		case <-ticker.C:
+			flushMetricSink(ctx, buf, write)
+		}
+	}
+}
+
+// flushMetricSink pushes the samples buffered for a sink with write
+func flushMetricSink(ctx context.Context, buf *sampleBuffer, write func(context.Context, []metricSample) error) {
+	samples := buf.drain()
+	if len(samples) == 0 {
+		return
+	}
+	if err := write(ctx, samples); err != nil {
+		log.Printf("%s: dropping %d samples: %v", buf.name, len(samples), err)
+	}
+}
+
+// writeRemoteWrite pushes samples to the Prometheus remote-write endpoint
+func (s *Server) writeRemoteWrite(ctx context.Context, samples []metricSample) error {
+	return s.remoteWrite.Write(ctx, toTimeSeries(samples))
+}
+
+// writeInflux pushes samples to InfluxDB
+func (s *Server) writeInflux(ctx context.Context, samples []metricSample) error {
+	return s.influx.Write(ctx, toInfluxPoints(samples))
+}
+
+// toTimeSeries groups samples into series labelled by metric name, agent,
+// module, target and their further labels
+func toTimeSeries(samples []metricSample) []remotewrite.TimeSeries {
+	index := make(map[[5]string]int)
+	var series []remotewrite.TimeSeries
+	for _, sample := range samples {
+		key := [5]string{sample.Name, sample.AgentID, sample.Module, sample.Target, labelsKey(sample.Labels)}
+		i, ok := index[key]
+		if !ok {
+			labels := []remotewrite.Label{
+				{Name: "__name__", Value: sample.Name},
+				{Name: "agent", Value: sample.AgentID},
+				{Name: "module", Value: sample.Module},
+			}
+			if sample.Target != "" {
+				labels = append(labels, remotewrite.Label{Name: "target", Value: sample.Target})
+			}
+			for _, name := range sortedLabelNames(sample.Labels) {
+				labels = append(labels, remotewrite.Label{Name: name, Value: sample.Labels[name]})
+			}
+			i = len(series)
+			index[key] = i
+			series = append(series, remotewrite.TimeSeries{Labels: labels})
+		}
+		series[i].Samples = append(series[i].Samples, remotewrite.Sample{
+			Value:     sample.Value,
+			Timestamp: sample.Timestamp.UnixMilli(),
+		})
+	}
+
+	return series
+}
+
+// toInfluxPoints groups samples into one point per module, agent, target,
+// further labels and timestamp, with each metric as a field
+func toInfluxPoints(samples []metricSample) []influx.Point {
+	type pointKey struct {
+		module, agentID, target, labels string
+		ts                              int64
+	}
+
+	index := make(map[pointKey]int)
+	var points []influx.Point
+	for _, sample := range samples {
+		key := pointKey{sample.Module, sample.AgentID, sample.Target, labelsKey(sample.Labels), sample.Timestamp.UnixNano()}
+		i, ok := index[key]
+		if !ok {
+			tags := map[string]string{"agent": sample.AgentID, "target": sample.Target}
+			for name, value := range sample.Labels {
+				tags[name] = value
+			}
+			i = len(points)
+			index[key] = i
+			points = append(points, influx.Point{
+				Measurement: sample.Module,
+				Tags:        tags,
+				Fields:      make(map[string]float64),
+				Time:        sample.Timestamp,
+			})
+		}
+		points[i].Fields[sample.Name] = sample.Value
+	}
+
+	return points
+}
+
+// sortedLabelNames returns the names of further labels in order
+func sortedLabelNames(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// labelsKey renders further labels as a key of the series they belong to
+func labelsKey(labels map[string]string) string {
+	var key strings.Builder
+	for _, name := range sortedLabelNames(labels) {
+		key.WriteString(name)
+		key.WriteByte(0)
+		key.WriteString(labels[name])
+		key.WriteByte(0)
+	}
+	return key.String()
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// migrate moves the data earlier versions stored into the backend's current
+// layout, for backends that need it, recording each migration that moved
+// any in the event log
+func (s *Server) migrate(ctx context.Context) {
+	if s.migrator == nil {
+		return
+	}
+	err := s.migrator.Migrate(ctx, func(migration *models.DataMigration) {
+		s.recordMigration(ctx, migration)
+	})
+	if err != nil {
+		log.Printf("Migrating stored data: %v", err)
+	}
+}
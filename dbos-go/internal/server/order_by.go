@@ -0,0 +1,65 @@
+package server
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// Fields of list requests' order_by, mapped to the store fields they order by
+var (
+	agentOrderBy = map[string]string{
+		"id":        models.AgentOrderID,
+		"hostname":  models.AgentOrderHostname,
+		"last_seen": models.AgentOrderLastSeen,
+	}
+	resultOrderBy = map[string]string{
+		"timestamp": models.ResultOrderTimestamp,
+		"sequence":  models.ResultOrderSequence,
+	}
+	taskOrderBy = map[string]string{
+		"scheduled_at": models.TaskOrderScheduledAt,
+		"created_at":   models.TaskOrderCreatedAt,
+	}
+	v2AgentOrderBy = map[string]string{
+		"name":           models.AgentOrderID,
+		"hostname":       models.AgentOrderHostname,
+		"last_seen_time": models.AgentOrderLastSeen,
+	}
+	v2ResultOrderBy = map[string]string{
+		"measure_time": models.ResultOrderTimestamp,
+		"sequence":     models.ResultOrderSequence,
+	}
+)
+
+// parseOrderBy parses an order_by naming one of fields, optionally followed
+// by "asc" or "desc", e.g. "last_seen desc". An empty order_by is the zero
+// Order, a list's default order.
+func parseOrderBy(orderBy string, fields map[string]string) (models.Order, error) {
+	words := strings.Fields(orderBy)
+	if len(words) == 0 {
+		return models.Order{}, nil
+	}
+
+	field, ok := fields[words[0]]
+	if !ok {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return models.Order{}, dberrors.New(dberrors.InvalidArgument, "cannot order by %q, only by one of %s", words[0], strings.Join(names, ", "))
+	}
+	order := models.Order{Field: field}
+	switch {
+	case len(words) == 1:
+	case len(words) == 2 && words[1] == "asc":
+	case len(words) == 2 && words[1] == "desc":
+		order.Desc = true
+	default:
+		return models.Order{}, dberrors.New(dberrors.InvalidArgument, "invalid order_by %q, expected a field optionally followed by asc or desc", orderBy)
+	}
+	return order, nil
+}
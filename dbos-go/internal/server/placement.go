@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/netip"
+	"slices"
+	"sort"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// validatePlacement checks a task's placement: only group tasks have one,
+// and rules comparing agents with the target need the payload to name it,
+// as an address to avoid its network
+func validatePlacement(task *models.Task) error {
+	p := task.Placement
+	if !task.IsGroup() {
+		return dberrors.New(dberrors.InvalidArgument, "placement requires a selector")
+	}
+	if err := p.Validate(); err != nil {
+		return dberrors.Wrap(dberrors.InvalidArgument, err)
+	}
+
+	target := taskTarget(task.Payload)
+	if (p.AvoidTargetPrefixV4 > 0 || p.AvoidTargetPrefixV6 > 0 || p.PreferPrevious) && target == "" {
+		return dberrors.New(dberrors.InvalidArgument, "placement by target requires the payload to name a target or host")
+	}
+	if p.AvoidTargetPrefixV4 > 0 || p.AvoidTargetPrefixV6 > 0 {
+		if _, err := netip.ParseAddr(target); err != nil {
+			return dberrors.New(dberrors.InvalidArgument, "placement avoiding the target's network requires an IP address target, not %q", target)
+		}
+	}
+	return nil
+}
+
+// placeAgents picks the agents of a group task's matching agents to issue
+// instances to under its placement, in ID order. Agents in the target's
+// network are skipped; the rest are ranked with agents already issued an
+// instance first, so a one-shot task keeps its picks, then with agents that
+// measured the target before if preferred, then by ID. Up to MaxAgents are
+// picked, one per spread label value first. It reports false if the picks
+// do not cover MinSpread label values.
+func (s *Server) placeAgents(ctx context.Context, task *models.Task, agents []*models.Agent) ([]string, bool) {
+	p := task.Placement
+	target := taskTarget(task.Payload)
+
+	if addr, err := netip.ParseAddr(target); err == nil && (p.AvoidTargetPrefixV4 > 0 || p.AvoidTargetPrefixV6 > 0) {
+		agents = slices.DeleteFunc(slices.Clone(agents), func(agent *models.Agent) bool {
+			return inTargetNetwork(p, addr, agent)
+		})
+	}
+
+	rank := make(map[string]int, len(agents))
+	if p.PreferPrevious {
+		measured, err := s.targetHistory.ListAgents(ctx, task.ModuleName, target)
+		if err != nil {
+			log.Printf("Group task %s: target history: %v", task.ID, err)
+		}
+		for _, agentID := range measured {
+			rank[agentID] = 1
+		}
+	}
+	for _, agentID := range task.AgentIDs {
+		rank[agentID] = 2
+	}
+	ranked := slices.Clone(agents)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if rank[ranked[i].ID] != rank[ranked[j].ID] {
+			return rank[ranked[i].ID] > rank[ranked[j].ID]
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+
+	picked := ranked
+	if p.MaxAgents > 0 && len(ranked) > p.MaxAgents {
+		picked = make([]*models.Agent, 0, p.MaxAgents)
+		chosen := make(map[string]bool, p.MaxAgents)
+		if p.SpreadLabel != "" {
+			covered := make(map[string]bool)
+			for _, agent := range ranked {
+				value := agent.Labels[p.SpreadLabel]
+				if len(picked) == p.MaxAgents || value == "" || covered[value] {
+					continue
+				}
+				covered[value] = true
+				chosen[agent.ID] = true
+				picked = append(picked, agent)
+			}
+		}
+		for _, agent := range ranked {
+			if len(picked) == p.MaxAgents {
+				break
+			}
+			if !chosen[agent.ID] {
+				picked = append(picked, agent)
+			}
+		}
+	}
+
+	if p.MinSpread > 0 {
+		values := make(map[string]bool)
+		for _, agent := range picked {
+			if value := agent.Labels[p.SpreadLabel]; value != "" {
+				values[value] = true
+			}
+		}
+		if len(values) < p.MinSpread {
+			return nil, false
+		}
+	}
+
+	ids := make([]string, len(picked))
+	for i, agent := range picked {
+		ids[i] = agent.ID
+	}
+	sort.Strings(ids)
+	return ids, true
+}
+
+// inTargetNetwork reports whether an agent's address shares the placement's
+// prefix of its family with the target. Agents without an address label
+// are not known to be inside it.
+func inTargetNetwork(p *models.Placement, target netip.Addr, agent *models.Agent) bool {
+	addr, err := netip.ParseAddr(agent.Labels[models.AgentIPLabel])
+	if err != nil {
+		return false
+	}
+	target, addr = target.Unmap(), addr.Unmap()
+	if target.Is4() != addr.Is4() {
+		return false
+	}
+	bits := p.AvoidTargetPrefixV6
+	if target.Is4() {
+		bits = p.AvoidTargetPrefixV4
+	}
+	if bits <= 0 {
+		return false
+	}
+	prefix, err := target.Prefix(bits)
+	return err == nil && prefix.Contains(addr)
+}
+
+// recordTargetMeasured records the agent of a scheduled result as having
+// measured its task's target, for placements preferring such agents
+func (s *Server) recordTargetMeasured(ctx context.Context, result *models.MeasurementResult) {
+	if result.Origin != string(models.ResultOriginScheduled) {
+		return
+	}
+	task, err := s.taskStore.GetTask(ctx, result.ID)
+	if err != nil {
+		return
+	}
+	target := taskTarget(task.Payload)
+	if target == "" {
+		return
+	}
+
+	if err := s.targetHistory.RecordMeasured(ctx, task.ModuleName, target, result.AgentID, result.Timestamp); err != nil {
+		log.Printf("Target history: %v", err)
+	}
+}
+
+// placementFromAPI converts an API placement into a model one
+func placementFromAPI(p *api.Placement) *models.Placement {
+	if p == nil {
+		return nil
+	}
+	return &models.Placement{
+		MaxAgents:           int(p.MaxAgents),
+		AvoidTargetPrefixV4: int(p.AvoidTargetPrefixV4),
+		AvoidTargetPrefixV6: int(p.AvoidTargetPrefixV6),
+		SpreadLabel:         p.SpreadLabel,
+		MinSpread:           int(p.MinSpread),
+		PreferPrevious:      p.PreferPrevious,
+	}
+}
+
+// placementToAPI converts a model placement into an API one
+func placementToAPI(p *models.Placement) *api.Placement {
+	if p == nil {
+		return nil
+	}
+	return &api.Placement{
+		MaxAgents:           int32(p.MaxAgents),
+		AvoidTargetPrefixV4: int32(p.AvoidTargetPrefixV4),
+		AvoidTargetPrefixV6: int32(p.AvoidTargetPrefixV6),
+		SpreadLabel:         p.SpreadLabel,
+		MinSpread:           int32(p.MinSpread),
+		PreferPrevious:      p.PreferPrevious,
+	}
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/jsonprofile"
+)
+
+const (
+	// defaultProfileLimit is how many results ProfileResults profiles when
+	// no limit is given
+	defaultProfileLimit = 10000
+	// maxProfileLimit caps the results ProfileResults profiles
+	maxProfileLimit = 100000
+	// maxProfileFields caps the paths ProfileResults reports
+	maxProfileFields = 1000
+	// profilePageSize is how many results are read from the store at a time
+	profilePageSize = 1000
+)
+
+// ProfileResults scans the results a filter selects, each agent's newest
+// first, and reports the fields of their data: how many results each path
+// occurs in, the JSON types of its values, their null rate and their
+// ranges
+func (s *Server) ProfileResults(ctx context.Context, req *api.ProfileResultsRequest) (*api.ProfileResultsResponse, error) {
+	limit := int64(req.Limit)
+	if limit <= 0 {
+		limit = defaultProfileLimit
+	}
+	if limit > maxProfileLimit {
+		err := dberrors.New(dberrors.InvalidArgument, "limit must be at most %d", maxProfileLimit)
+		return &api.ProfileResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	agentIDs := req.AgentIds
+	if len(agentIDs) == 0 {
+		agents, err := s.agentStore.ListAgents(ctx)
+		if err != nil {
+			return &api.ProfileResultsResponse{
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+		for _, agent := range agents {
+			agentIDs = append(agentIDs, agent.ID)
+		}
+	}
+
+	rng := models.ResultRange{ModuleName: req.ModuleName}
+	if req.From > 0 {
+		rng.From = time.Unix(req.From, 0)
+	}
+	if req.To > 0 {
+		rng.To = time.Unix(req.To, 0)
+	}
+	order := models.Order{Field: models.ResultOrderTimestamp, Desc: true}
+
+	profiler := jsonprofile.New(maxProfileFields)
+	scanned, truncated := int64(0), false
+	for _, agentID := range agentIDs {
+		cursor := ""
+		for !truncated {
+			results, next, err := s.resultStore.ListResultsPage(ctx, agentID, rng, order, cursor, profilePageSize)
+			if err != nil {
+				return &api.ProfileResultsResponse{
+					Error:     err.Error(),
+					ErrorCode: errorCode(err),
+				}, nil
+			}
+			for _, result := range results {
+				if scanned == limit {
+					truncated = true
+					break
+				}
+				profiler.Add(result.Data)
+				scanned++
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+	}
+
+	resp := &api.ProfileResultsResponse{
+		Results:         profiler.Documents(),
+		InvalidResults:  profiler.Invalid(),
+		Truncated:       truncated,
+		FieldsTruncated: profiler.Truncated(),
+	}
+	for _, f := range profiler.Fields() {
+		resp.Fields = append(resp.Fields, fieldProfileToAPI(f, profiler.Documents()))
+	}
+	return resp, nil
+}
+
+// fieldProfileToAPI converts a field profile over a number of results into
+// its API form
+func fieldProfileToAPI(f *jsonprofile.Field, results int64) *api.FieldProfile {
+	return &api.FieldProfile{
+		Path:                  f.Path,
+		Results:               f.Documents,
+		Coverage:              float64(f.Documents) / float64(results),
+		Values:                f.Values,
+		Types:                 f.Types,
+		NullRate:              f.NullRate(),
+		Min:                   f.Min,
+		Max:                   f.Max,
+		Mean:                  f.Mean(),
+		MinLength:             int32(f.MinLength),
+		MaxLength:             int32(f.MaxLength),
+		DistinctStrings:       int32(f.Distinct),
+		DistinctStringsCapped: f.DistinctCapped,
+		Examples:              f.Examples,
+	}
+}
+
+// handleHTTPProfileResults serves GET /v1/results/profile, taking the
+// ProfileResultsRequest fields as query parameters (agent_id may be
+// repeated)
+func (s *Server) handleHTTPProfileResults(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &api.ProfileResultsRequest{
+		AgentIds:   query["agent_id"],
+		ModuleName: query.Get("module_name"),
+	}
+	for name, bound := range map[string]*int64{"from": &req.From, "to": &req.To} {
+		if v := query.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				writeHTTPError(w, dberrors.New(dberrors.InvalidArgument, "invalid %s: %v", name, err))
+				return
+			}
+			*bound = n
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			writeHTTPError(w, dberrors.New(dberrors.InvalidArgument, "invalid limit: %v", err))
+			return
+		}
+		req.Limit = int32(n)
+	}
+
+	s.meterHTTP(w, r, api.DBOS_ProfileResults_FullMethodName, req, func(w http.ResponseWriter) {
+		resp, _ := s.ProfileResults(r.Context(), req)
+		writeProtoJSON(w, resp)
+	})
+}
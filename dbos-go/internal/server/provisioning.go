@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+)
+
+// defaultBootstrapTokenTTL is how long a bootstrap token stays valid by default
+const defaultBootstrapTokenTTL = 24 * time.Hour
+
+// CreateBootstrapToken creates a one-time token a new probe can enroll with
+func (s *Server) CreateBootstrapToken(ctx context.Context, req *api.CreateBootstrapTokenRequest) (*api.CreateBootstrapTokenResponse, error) {
+	ttl := time.Duration(req.TtlSeconds) * time.Second
+	if ttl <= 0 {
+		ttl = defaultBootstrapTokenTTL
+	}
+
+	token := models.NewBootstrapToken(req.Labels, req.Config, ttl)
+	secret, err := s.credentialStore.CreateBootstrapToken(ctx, token)
+	if err != nil {
+		return &api.CreateBootstrapTokenResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.CreateBootstrapTokenResponse{
+		Token:     secret,
+		ExpiresAt: token.ExpiresAt.Unix(),
+	}, nil
+}
+
+// CreateAgentToken issues an API token for an agent that did not enroll,
+// such as one provisioned before token authentication was enabled
+func (s *Server) CreateAgentToken(ctx context.Context, req *api.CreateAgentTokenRequest) (*api.CreateAgentTokenResponse, error) {
+	token, err := s.credentialStore.IssueAgentToken(ctx, req.AgentId)
+	if err != nil {
+		return &api.CreateAgentTokenResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	log.Printf("API token issued for agent %s", req.AgentId)
+	return &api.CreateAgentTokenResponse{
+		Token: token,
+	}, nil
+}
+
+// CreateAPIToken issues an API token holding a role for a user or service:
+// operator or read-only, as admin tokens are configured and agent tokens
+// are bound to an agent
+func (s *Server) CreateAPIToken(ctx context.Context, req *api.CreateAPITokenRequest) (*api.CreateAPITokenResponse, error) {
+	token, err := s.credentialStore.IssueAPIToken(ctx, &models.APIToken{
+		Role:      req.Role,
+		Name:      req.Name,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return &api.CreateAPITokenResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	log.Printf("API token issued with role %s for %s", req.Role, authorOrUnknown(req.Name))
+	return &api.CreateAPITokenResponse{
+		Token: token,
+	}, nil
+}
+
+// EnrollAgent redeems a bootstrap token: the agent is registered with the
+// token's labels and initial config and receives its permanent credential
+func (s *Server) EnrollAgent(ctx context.Context, req *api.EnrollAgentRequest) (*api.EnrollAgentResponse, error) {
+	token, err := s.credentialStore.ConsumeBootstrapToken(ctx, req.BootstrapToken)
+	if err != nil {
+		return &api.EnrollAgentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	agentID := req.AgentId
+	if agentID == "" {
+		agentID = ids.New("agent-")
+	}
+
+	agent := models.NewAgent(agentID, req.Hostname)
+	for k, v := range token.Labels {
+		agent.Labels[k] = v
+	}
+	for k, v := range token.Config {
+		agent.Config[k] = v
+	}
+
+	if err := s.agentStore.RegisterAgent(ctx, agent); err != nil {
+		return &api.EnrollAgentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	s.recordAgentEvent(ctx, models.EventAgentEnrolled, models.EventSeverityInfo, agent.ID, agent)
+
+	agentToken, err := s.credentialStore.IssueAgentToken(ctx, agent.ID)
+	if err != nil {
+		return &api.EnrollAgentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.EnrollAgentResponse{
+		Success:    true,
+		Agent:      agentToAPI(agent),
+		AgentToken: agentToken,
+	}, nil
+}
@@ -0,0 +1,279 @@
+package server
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	apiv2 "github.com/internet-measurement-network/dbos/api/v2"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// storageQuotaMethods are the RPCs storing results, whose request bytes
+// the storage quota counts
+var storageQuotaMethods = map[string]bool{
+	api.DBOS_StoreResult_FullMethodName:    true,
+	api.DBOS_StoreResults_FullMethodName:   true,
+	api.DBOS_StreamResults_FullMethodName:  true,
+	apiv2.DBOS_CreateResult_FullMethodName: true,
+}
+
+// taskRateQuotaMethods are the RPCs scheduling a task, whose successful
+// calls the task rate quota counts
+var taskRateQuotaMethods = map[string]bool{
+	api.DBOS_ScheduleTask_FullMethodName:         true,
+	api.DBOS_ScheduleVerifiedTask_FullMethodName: true,
+	api.DBOS_BroadcastTask_FullMethodName:        true,
+	apiv2.DBOS_CreateTask_FullMethodName:         true,
+}
+
+// quotaUnits describe the usage quotas limit, for messages
+var quotaUnits = map[string]string{
+	models.QuotaStorage:   "bytes of results per day",
+	models.QuotaTaskRate:  "tasks per hour",
+	models.QuotaBandwidth: "bytes per minute",
+}
+
+// quotaNotification is the payload of quota events, and the body of the
+// notifications of quotas posted to the alert webhook
+type quotaNotification struct {
+	Status        string    `json:"status"` // "warning" or "exceeded"
+	Quota         string    `json:"quota"`
+	PrincipalKind string    `json:"principal_kind"`
+	Principal     string    `json:"principal"`
+	Used          int64     `json:"used"`
+	SoftLimit     int64     `json:"soft_limit,omitempty"`
+	Limit         int64     `json:"limit,omitempty"`
+	WindowStart   time.Time `json:"window_start"`
+	WindowEnd     time.Time `json:"window_end"`
+	At            time.Time `json:"at"`
+}
+
+// quotaCount identifies the usage of a quota by a principal in a window
+type quotaCount struct {
+	quota       string
+	windowStart int64
+	key         models.UsageKey
+}
+
+// quotaTracker holds the quota usage of each principal: that of the
+// current windows stored by all servers as of the last flush, and that
+// counted here since
+type quotaTracker struct {
+	mu      sync.Mutex
+	stored  map[quotaCount]int64
+	pending map[quotaCount]int64
+}
+
+// add counts usage of a quota by a principal
+func (t *quotaTracker) add(count quotaCount, n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pending == nil {
+		t.pending = make(map[quotaCount]int64)
+	}
+	t.pending[count] += n
+}
+
+// used returns the usage of a quota by a principal
+func (t *quotaTracker) used(count quotaCount) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stored[count] + t.pending[count]
+}
+
+// drain returns and clears the usage counted since the last flush
+func (t *quotaTracker) drain() map[quotaCount]int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pending := t.pending
+	t.pending = nil
+	return pending
+}
+
+// refresh replaces the stored usage of a quota with that of its current
+// window
+func (t *quotaTracker) refresh(quota string, windowStart int64, usage map[models.UsageKey]int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for count := range t.stored {
+		if count.quota == quota {
+			delete(t.stored, count)
+		}
+	}
+	if t.stored == nil {
+		t.stored = make(map[quotaCount]int64)
+	}
+	for key, n := range usage {
+		t.stored[quotaCount{quota: quota, windowStart: windowStart, key: key}] = n
+	}
+}
+
+// quotaWindowStart returns when the window of a quota containing now began
+func quotaWindowStart(quota string, now time.Time) int64 {
+	seconds := int64(models.QuotaWindows[quota] / time.Second)
+	return now.Unix() / seconds * seconds
+}
+
+// quotaAmount returns how much of a quota an RPC of method used
+func quotaAmount(quota, method string, usage models.Usage) int64 {
+	switch quota {
+	case models.QuotaStorage:
+		if storageQuotaMethods[method] {
+			return usage.BytesIn
+		}
+	case models.QuotaTaskRate:
+		if taskRateQuotaMethods[method] {
+			return usage.Calls - usage.Errors
+		}
+	case models.QuotaBandwidth:
+		return usage.BytesIn + usage.BytesOut
+	}
+	return 0
+}
+
+// quotaCounts reports whether a quota counts, and so rejects, RPCs of method
+func quotaCounts(quota, method string) bool {
+	switch quota {
+	case models.QuotaStorage:
+		return storageQuotaMethods[method]
+	case models.QuotaTaskRate:
+		return taskRateQuotaMethods[method]
+	}
+	return true
+}
+
+// quotaExempt reports whether a call is exempt from quotas: anonymous calls
+// have no one to hold to them, and admins must be able to step in
+func quotaExempt(ctx context.Context, kind string) bool {
+	if kind == models.UsagePrincipalAnonymous {
+		return true
+	}
+	identity, ok := ctx.Value(tokenIdentityKey{}).(*tokenIdentity)
+	return ok && identity.role == models.RoleAdmin
+}
+
+// checkQuotas rejects a call whose principal has used up a quota counting
+// it in the quota's current window
+func (s *Server) checkQuotas(ctx context.Context, key models.UsageKey, now time.Time) error {
+	if len(s.config.Quotas) == 0 || quotaExempt(ctx, key.Kind) {
+		return nil
+	}
+	principal := models.UsageKey{Kind: key.Kind, Principal: key.Principal}
+	for _, quota := range s.config.Quotas {
+		if quota.Limit == 0 || !quotaCounts(quota.Name, key.Method) {
+			continue
+		}
+		used := s.quotas.used(quotaCount{quota: quota.Name, windowStart: quotaWindowStart(quota.Name, now), key: principal})
+		if used >= quota.Limit {
+			return dberrors.New(dberrors.QuotaExceeded, "%s %s has used up its %s quota of %d %s", key.Kind, key.Principal, quota.Name, quota.Limit, quotaUnits[quota.Name])
+		}
+	}
+	return nil
+}
+
+// countQuotas counts the usage of a call against the quotas of its
+// principal
+func (s *Server) countQuotas(ctx context.Context, key models.UsageKey, usage models.Usage, now time.Time) {
+	if len(s.config.Quotas) == 0 || quotaExempt(ctx, key.Kind) {
+		return
+	}
+	principal := models.UsageKey{Kind: key.Kind, Principal: key.Principal}
+	for _, quota := range s.config.Quotas {
+		if n := quotaAmount(quota.Name, key.Method, usage); n > 0 {
+			s.quotas.add(quotaCount{quota: quota.Name, windowStart: quotaWindowStart(quota.Name, now), key: principal}, n)
+		}
+	}
+}
+
+// flushQuotas adds the quota usage counted since the last flush to the
+// usage store, then reloads the usage of the current windows by all
+// servers and notifies principals reaching a soft limit or a limit
+func (s *Server) flushQuotas(ctx context.Context, now time.Time) {
+	if len(s.config.Quotas) == 0 {
+		return
+	}
+
+	type window struct {
+		quota string
+		start int64
+	}
+	windows := make(map[window]map[models.UsageKey]int64)
+	for count, n := range s.quotas.drain() {
+		w := window{count.quota, count.windowStart}
+		if windows[w] == nil {
+			windows[w] = make(map[models.UsageKey]int64)
+		}
+		windows[w][count.key] += n
+	}
+	for w, usage := range windows {
+		if err := s.usageStore.RecordQuotaUsage(ctx, w.quota, time.Unix(w.start, 0), models.QuotaWindows[w.quota], usage); err != nil {
+			log.Printf("Dropping %s quota usage of %d principals: %v", w.quota, len(usage), err)
+		}
+	}
+
+	for _, quota := range s.config.Quotas {
+		start := quotaWindowStart(quota.Name, now)
+		usage, err := s.usageStore.GetQuotaUsage(ctx, quota.Name, time.Unix(start, 0))
+		if err != nil {
+			log.Printf("Loading %s quota usage: %v", quota.Name, err)
+			continue
+		}
+		s.quotas.refresh(quota.Name, start, usage)
+		for key, used := range usage {
+			if quota.SoftLimit > 0 && used >= quota.SoftLimit {
+				s.notifyQuota(ctx, quota, models.QuotaLevelWarning, key, used, start, now)
+			}
+			if quota.Limit > 0 && used >= quota.Limit {
+				s.notifyQuota(ctx, quota, models.QuotaLevelExceeded, key, used, start, now)
+			}
+		}
+	}
+}
+
+// notifyQuota records an event and posts a notification to the alert
+// webhook once per window for a principal reaching a level of a quota:
+// its soft limit for a warning, its limit once calls are rejected
+func (s *Server) notifyQuota(ctx context.Context, quota Quota, level string, key models.UsageKey, used, windowStart int64, now time.Time) {
+	window := models.QuotaWindows[quota.Name]
+	first, err := s.usageStore.MarkQuotaNotified(ctx, quota.Name, time.Unix(windowStart, 0), window, level, key)
+	if err != nil {
+		log.Printf("Quota %s of %s %s: %v", quota.Name, key.Kind, key.Principal, err)
+		return
+	}
+	if !first {
+		return
+	}
+
+	notification := &quotaNotification{
+		Status:        level,
+		Quota:         quota.Name,
+		PrincipalKind: key.Kind,
+		Principal:     key.Principal,
+		Used:          used,
+		SoftLimit:     quota.SoftLimit,
+		Limit:         quota.Limit,
+		WindowStart:   time.Unix(windowStart, 0).UTC(),
+		WindowEnd:     time.Unix(windowStart, 0).Add(window).UTC(),
+		At:            now.UTC(),
+	}
+	event := &models.Event{Actor: models.EventActorSystem, Subject: "keys/" + key.Principal}
+	if key.Kind == models.UsagePrincipalAgent {
+		event.Subject = agentName(key.Principal)
+		event.AgentID = key.Principal
+	}
+	eventType, severity := models.EventQuotaWarning, models.EventSeverityWarning
+	if level == models.QuotaLevelExceeded {
+		eventType, severity = models.EventQuotaExceeded, models.EventSeverityError
+	}
+	s.recordEvent(ctx, eventType, severity, event, notification)
+
+	if s.alertWebhook != nil {
+		if err := s.alertWebhook.Post(ctx, notification); err != nil {
+			log.Printf("Quota %s of %s %s: notifying %s: %v", quota.Name, key.Kind, key.Principal, level, err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// readMask is the set of top-level fields a Get or List request asks for; a
+// nil readMask returns every field
+type readMask map[protoreflect.Name]bool
+
+// newReadMask checks that every path of a field mask names a top-level
+// field of the returned message type
+func newReadMask(returned proto.Message, mask *fieldmaskpb.FieldMask) (readMask, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, nil
+	}
+
+	desc := returned.ProtoReflect().Descriptor()
+	m := make(readMask, len(mask.Paths))
+	for _, path := range mask.Paths {
+		name := protoreflect.Name(path)
+		if !name.IsValid() || desc.Fields().ByName(name) == nil {
+			return nil, dberrors.New(dberrors.InvalidArgument, "read_mask path %q is not a field of %s", path, desc.Name())
+		}
+		m[name] = true
+	}
+	return m, nil
+}
+
+// apply clears the fields of msg the mask does not name
+func (m readMask) apply(msg proto.Message) {
+	if m == nil {
+		return
+	}
+
+	r := msg.ProtoReflect()
+	r.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !m[fd.Name()] {
+			r.Clear(fd)
+		}
+		return true
+	})
+}
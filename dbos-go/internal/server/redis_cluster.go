@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+	"log"
+)
+
+// clusterChecker is implemented by storage backends on Redis
+type clusterChecker interface {
+	ClusterEnabled(ctx context.Context) (bool, error)
+	CrossSlotOperations() []string
+}
+
+// checkRedisCluster warns at startup when the Redis backend runs in cluster
+// mode, which DBOS does not support yet, listing the scripts and
+// transactions the cluster would refuse for spanning hash slots
+func (s *Server) checkRedisCluster(ctx context.Context) {
+	if s.cluster == nil {
+		return
+	}
+	enabled, err := s.cluster.ClusterEnabled(ctx)
+	if err != nil {
+		log.Printf("Checking Redis cluster mode: %v", err)
+		return
+	}
+	if !enabled {
+		return
+	}
+
+	problems := s.cluster.CrossSlotOperations()
+	log.Printf("Redis runs in cluster mode, which is not supported; %d operations are not cluster compatible", len(problems))
+	for _, problem := range problems {
+		log.Printf("Redis cluster: %s", problem)
+	}
+}
@@ -0,0 +1,408 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/internal/store"
+	"github.com/internet-measurement-network/dbos/pkg/apitoken"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"github.com/internet-measurement-network/dbos/pkg/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// relayReplayInterval is how often a relay forwards the requests it buffered
+// while its upstream was unreachable
+const relayReplayInterval = 5 * time.Second
+
+// relayUpstreamTimeout bounds forwarding a request the relay can buffer, so
+// agents are answered from the buffer rather than kept waiting on an
+// unreachable upstream
+const relayUpstreamTimeout = 10 * time.Second
+
+// Methods of the requests a relay buffers
+const (
+	relayMethodStoreResult    = "StoreResult"
+	relayMethodSetModuleState = "SetModuleState"
+)
+
+// relayServer serves the agent RPCs of the DBOS service by forwarding them
+// to an upstream server. Results and module states are buffered while the
+// upstream is unreachable and forwarded in order once it is back; the other
+// agent RPCs fail with the upstream's error. All other RPCs are
+// unimplemented.
+type relayServer struct {
+	api.UnimplementedDBOSServer
+	s        *Server
+	upstream api.DBOSClient
+}
+
+// startRelay registers the relay of agent RPCs to the upstream server and
+// starts replaying buffered requests, returning the upstream connection
+func (s *Server) startRelay(grpcServer *grpc.Server, workers *workerGroup) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if s.config.UpstreamTLSCAFile != "" || s.config.UpstreamTLSCertFile != "" {
+		cfg, err := tlsconfig.Client(s.config.UpstreamTLSCertFile, s.config.UpstreamTLSKeyFile, s.config.UpstreamTLSCAFile)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if s.config.UpstreamToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(apitoken.New(s.config.UpstreamToken)))
+	}
+	conn, err := grpc.NewClient(s.config.UpstreamAddr, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &relayServer{s: s, upstream: api.NewDBOSClient(conn)}
+	api.RegisterDBOSServer(grpcServer, r)
+	workers.Go(func(ctx context.Context) {
+		r.runReplay(ctx, relayReplayInterval)
+	})
+
+	log.Printf("Relaying agent RPCs to upstream DBOS at %s", s.config.UpstreamAddr)
+	return conn, nil
+}
+
+// RegisterAgent forwards an agent registration upstream
+func (r *relayServer) RegisterAgent(ctx context.Context, req *api.RegisterAgentRequest) (*api.RegisterAgentResponse, error) {
+	return r.upstream.RegisterAgent(ctx, req)
+}
+
+// Heartbeat forwards a heartbeat upstream; heartbeats are not buffered, as
+// a late one would misreport the agent's liveness
+func (r *relayServer) Heartbeat(ctx context.Context, req *api.HeartbeatRequest) (*api.HeartbeatResponse, error) {
+	return r.upstream.Heartbeat(ctx, req)
+}
+
+// EnrollAgent forwards an agent enrollment upstream
+func (r *relayServer) EnrollAgent(ctx context.Context, req *api.EnrollAgentRequest) (*api.EnrollAgentResponse, error) {
+	return r.upstream.EnrollAgent(ctx, req)
+}
+
+// GetAgentConfig forwards an agent's configuration lookup upstream
+func (r *relayServer) GetAgentConfig(ctx context.Context, req *api.GetAgentConfigRequest) (*api.GetAgentConfigResponse, error) {
+	return r.upstream.GetAgentConfig(ctx, req)
+}
+
+// LeaseTask forwards a task lease upstream
+func (r *relayServer) LeaseTask(ctx context.Context, req *api.LeaseTaskRequest) (*api.LeaseTaskResponse, error) {
+	return r.upstream.LeaseTask(ctx, req)
+}
+
+// AckTasks forwards task acknowledgements upstream
+func (r *relayServer) AckTasks(ctx context.Context, req *api.AckTasksRequest) (*api.AckTasksResponse, error) {
+	return r.upstream.AckTasks(ctx, req)
+}
+
+// NackTasks forwards negative task acknowledgements upstream
+func (r *relayServer) NackTasks(ctx context.Context, req *api.NackTasksRequest) (*api.NackTasksResponse, error) {
+	return r.upstream.NackTasks(ctx, req)
+}
+
+// ExtendTaskVisibility forwards a task lease renewal upstream
+func (r *relayServer) ExtendTaskVisibility(ctx context.Context, req *api.ExtendTaskVisibilityRequest) (*api.ExtendTaskVisibilityResponse, error) {
+	return r.upstream.ExtendTaskVisibility(ctx, req)
+}
+
+// ReportTaskProgress forwards a task progress report upstream
+func (r *relayServer) ReportTaskProgress(ctx context.Context, req *api.ReportTaskProgressRequest) (*api.ReportTaskProgressResponse, error) {
+	return r.upstream.ReportTaskProgress(ctx, req)
+}
+
+// StreamTasks relays an agent's task stream from upstream until either side
+// ends it
+func (r *relayServer) StreamTasks(req *api.StreamTasksRequest, stream api.DBOS_StreamTasksServer) error {
+	upstream, err := r.upstream.StreamTasks(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		task, err := upstream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(task); err != nil {
+			return err
+		}
+	}
+}
+
+// StoreResult forwards a result upstream, buffering it while the upstream
+// is unreachable. A result without an ID is given one here, so the ID
+// returned for a buffered result is the one it is stored under.
+func (r *relayServer) StoreResult(ctx context.Context, req *api.StoreResultRequest) (*api.StoreResultResponse, error) {
+	if req.Result != nil && req.Result.Id == "" {
+		req.Result.Id = models.LocalTaskIDPrefix + ids.ULID()
+	}
+
+	var resp *api.StoreResultResponse
+	buffered, err := r.forward(ctx, relayMethodStoreResult, req, func(ctx context.Context) (err error) {
+		resp, err = r.upstream.StoreResult(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if buffered {
+		return &api.StoreResultResponse{
+			Success:  true,
+			ResultId: req.Result.GetId(),
+		}, nil
+	}
+	return resp, nil
+}
+
+// StoreResults forwards a batch of results upstream one at a time as
+// StoreResult does, so each is buffered while the upstream is unreachable.
+// The relay cannot tell re-deliveries apart, so none is reported as a
+// duplicate. The last backoff hint the upstream gave is passed on. A
+// backfill is forwarded upstream whole and not buffered, as buffered
+// results are replayed as StoreResult calls.
+func (r *relayServer) StoreResults(ctx context.Context, req *api.StoreResultsRequest) (*api.StoreResultsResponse, error) {
+	if req.Backfill {
+		return r.upstream.StoreResults(ctx, req)
+	}
+	statuses := make([]*api.ResultStoreStatus, len(req.Results))
+	var backoff *api.Backoff
+	for i, result := range req.Results {
+		resp, err := r.StoreResult(ctx, &api.StoreResultRequest{Result: result})
+		if err == nil && resp.Backoff != nil {
+			backoff = resp.Backoff
+		}
+		switch {
+		case err != nil:
+			statuses[i] = &api.ResultStoreStatus{
+				ResultId:  result.GetId(),
+				Status:    string(models.ResultStoreStatusError),
+				Error:     status.Convert(err).Message(),
+				ErrorCode: errorCode(err),
+			}
+		case !resp.Success:
+			statuses[i] = &api.ResultStoreStatus{
+				ResultId:  result.GetId(),
+				Status:    string(models.ResultStoreStatusError),
+				Error:     resp.Error,
+				ErrorCode: resp.ErrorCode,
+			}
+		default:
+			statuses[i] = &api.ResultStoreStatus{
+				ResultId: resp.ResultId,
+				Status:   string(models.ResultStoreStatusStored),
+			}
+		}
+	}
+	return &api.StoreResultsResponse{
+		Results: statuses,
+		Backoff: backoff,
+	}, nil
+}
+
+// StreamResults forwards the results of a stream upstream one at a time as
+// StoreResult does, so each is buffered while the upstream is unreachable.
+// The last backoff hint the upstream gave is passed on.
+func (r *relayServer) StreamResults(stream api.DBOS_StreamResultsServer) error {
+	resp := &api.StreamResultsResponse{}
+	for index := int64(0); ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+
+		stored, err := r.StoreResult(stream.Context(), req)
+		if err == nil && stored.Backoff != nil {
+			resp.Backoff = stored.Backoff
+		}
+		switch {
+		case err != nil:
+			resp.Rejected = append(resp.Rejected, &api.RejectedResult{
+				Index:     index,
+				ResultId:  req.GetResult().GetId(),
+				Error:     status.Convert(err).Message(),
+				ErrorCode: errorCode(err),
+			})
+		case !stored.Success:
+			resp.Rejected = append(resp.Rejected, &api.RejectedResult{
+				Index:     index,
+				ResultId:  req.GetResult().GetId(),
+				Error:     stored.Error,
+				ErrorCode: stored.ErrorCode,
+			})
+		default:
+			resp.Stored++
+		}
+	}
+}
+
+// SetModuleState forwards a module state upstream, buffering it while the
+// upstream is unreachable
+func (r *relayServer) SetModuleState(ctx context.Context, req *api.SetModuleStateRequest) (*api.SetModuleStateResponse, error) {
+	var resp *api.SetModuleStateResponse
+	buffered, err := r.forward(ctx, relayMethodSetModuleState, req, func(ctx context.Context) (err error) {
+		resp, err = r.upstream.SetModuleState(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if buffered {
+		return &api.SetModuleStateResponse{
+			Success: true,
+		}, nil
+	}
+	return resp, nil
+}
+
+// forward forwards a request with send, or buffers it if the upstream is
+// unreachable or earlier requests are still buffered, which keeps requests
+// in order. It reports whether the request was buffered.
+func (r *relayServer) forward(ctx context.Context, method string, req proto.Message, send func(context.Context) error) (bool, error) {
+	pending, err := r.s.relayStore.Pending(ctx)
+	if err != nil {
+		return false, status.Errorf(codes.Unavailable, "relay buffer: %v", err)
+	}
+	if pending == 0 {
+		sendCtx, cancel := context.WithTimeout(ctx, relayUpstreamTimeout)
+		err := send(sendCtx)
+		cancel()
+		if !upstreamUnavailable(err) {
+			return false, err
+		}
+	}
+
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return false, status.Error(codes.Internal, err.Error())
+	}
+	err = r.s.relayStore.Buffer(ctx, &models.RelayedRequest{
+		Method:     method,
+		Request:    data,
+		BufferedAt: time.Now(),
+	})
+	if errors.Is(err, store.ErrRelayBufferFull) {
+		return false, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		return false, status.Errorf(codes.Unavailable, "relay buffer: %v", err)
+	}
+	return true, nil
+}
+
+// runReplay periodically forwards buffered requests until ctx is done
+func (r *relayServer) runReplay(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.replay(ctx); err != nil {
+				log.Printf("Relay replay: %v", err)
+			}
+		}
+	}
+}
+
+// replay forwards buffered requests oldest first until the buffer is empty
+// or the upstream is unreachable. A request the upstream rejects is dropped,
+// as forwarding it again would fail again. Backoff hints of the upstream
+// are waited out, so a large buffer does not swamp it.
+func (r *relayServer) replay(ctx context.Context) error {
+	forwarded, dropped := 0, 0
+	defer func() {
+		if forwarded+dropped > 0 {
+			log.Printf("Relay replay: forwarded %d buffered requests, dropped %d rejected upstream", forwarded, dropped)
+		}
+	}()
+
+	for {
+		req, err := r.s.relayStore.Next(ctx)
+		if err != nil || req == nil {
+			return err
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, relayUpstreamTimeout)
+		backoff, err := r.send(sendCtx, req)
+		cancel()
+		if upstreamUnavailable(err) {
+			return nil
+		}
+		if err != nil {
+			log.Printf("Relay replay: upstream rejected %s request buffered at %s: %v", req.Method, req.BufferedAt.UTC().Format(time.RFC3339), err)
+			dropped++
+		} else {
+			forwarded++
+		}
+
+		if err := r.s.relayStore.Done(ctx); err != nil {
+			return err
+		}
+		waitBackoff(ctx, backoff)
+	}
+}
+
+// send forwards a buffered request upstream, returning the backoff hint the
+// upstream answered with and the error it failed or was rejected with
+func (r *relayServer) send(ctx context.Context, req *models.RelayedRequest) (*api.Backoff, error) {
+	switch req.Method {
+	case relayMethodStoreResult:
+		var m api.StoreResultRequest
+		if err := proto.Unmarshal(req.Request, &m); err != nil {
+			return nil, err
+		}
+		resp, err := r.upstream.StoreResult(ctx, &m)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return resp.Backoff, dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error)
+		}
+		return resp.Backoff, nil
+	case relayMethodSetModuleState:
+		var m api.SetModuleStateRequest
+		if err := proto.Unmarshal(req.Request, &m); err != nil {
+			return nil, err
+		}
+		resp, err := r.upstream.SetModuleState(ctx, &m)
+		if err == nil && !resp.Success {
+			err = dberrors.New(dberrors.Code(resp.ErrorCode), "%s", resp.Error)
+		}
+		return nil, err
+	}
+	return nil, fmt.Errorf("unknown method %s", req.Method)
+}
+
+// upstreamUnavailable reports whether an upstream call failed because the
+// upstream could not be reached in time
+func upstreamUnavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
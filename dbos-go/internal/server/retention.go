@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+const (
+	// retentionPageSize is how many results are read and deleted at a time
+	retentionPageSize = 1000
+
+	// maxRetentionPages caps the pages of one agent's results a sweep
+	// reads; the next sweep continues where it stopped
+	maxRetentionPages = 100
+)
+
+// retentionEnabled reports whether any results expire
+func (s *Server) retentionEnabled() bool {
+	if s.config.ResultRetention > 0 {
+		return true
+	}
+	for _, retention := range s.config.ModuleResultRetention {
+		if retention > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// tieringEnabled reports whether results are moved to the archive before
+// they expire
+func (s *Server) tieringEnabled() bool {
+	return s.archive != nil && s.config.ResultArchiveAfter > 0
+}
+
+// resultRetention returns how long a module's results are kept, zero
+// meaning forever
+func (s *Server) resultRetention(moduleName string) time.Duration {
+	if retention, ok := s.config.ModuleResultRetention[moduleName]; ok {
+		return retention
+	}
+	return s.config.ResultRetention
+}
+
+// shortestRetention returns the shortest time any results are kept
+func (s *Server) shortestRetention() time.Duration {
+	shortest := s.config.ResultRetention
+	for _, retention := range s.config.ModuleResultRetention {
+		if retention > 0 && (shortest == 0 || retention < shortest) {
+			shortest = retention
+		}
+	}
+	return shortest
+}
+
+// runResultRetention periodically deletes expired results and moves old
+// ones to the archive until ctx is done
+func (s *Server) runResultRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// cursors are where each agent's sweep stopped, if it did not get
+	// through the agent's expired results
+	cursors := make(map[string]string)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.expireResults(ctx, now, cursors); err != nil {
+				log.Printf("Result retention: %v", err)
+			}
+		}
+	}
+}
+
+// expireResults deletes every agent's results measured before their
+// module's retention, archiving them first if an archive is configured, and
+// moves results measured before ResultArchiveAfter to the archive
+func (s *Server) expireResults(ctx context.Context, now time.Time, cursors map[string]string) error {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Results older than the shortest retention may be expired; which are
+	// depends on their module
+	cutoff := s.shortestRetention()
+	if s.tieringEnabled() && (cutoff == 0 || s.config.ResultArchiveAfter < cutoff) {
+		cutoff = s.config.ResultArchiveAfter
+	}
+	rng := models.ResultRange{To: now.Add(-cutoff)}
+	order := models.Order{Field: models.ResultOrderTimestamp}
+	deleted, archived := 0, 0
+	defer func() {
+		if deleted > 0 || archived > 0 {
+			log.Printf("Result retention: deleted %d expired results, moved %d results to the archive", deleted, archived)
+		}
+	}()
+
+	for _, agent := range agents {
+		cursor := cursors[agent.ID]
+		for page := 0; page < maxRetentionPages; page++ {
+			results, next, err := s.resultStore.ListResultsPage(ctx, agent.ID, rng, order, cursor, retentionPageSize)
+			if err != nil {
+				return err
+			}
+
+			// moved are the expired and archived results, oldest first;
+			// kept are the request IDs of the archived ones, which remain
+			// available from the archive
+			var moved []*models.MeasurementResult
+			var kept []string
+			for _, result := range results {
+				retention := s.resultRetention(result.ModuleName)
+				switch {
+				case retention > 0 && result.Timestamp.Before(now.Add(-retention)):
+					moved = append(moved, result)
+				case s.tieringEnabled() && result.Timestamp.Before(now.Add(-s.config.ResultArchiveAfter)):
+					moved = append(moved, result)
+					kept = append(kept, result.ID)
+				}
+			}
+			if len(moved) > 0 {
+				if s.archive != nil {
+					// The results are kept until they could be archived
+					object, err := s.archiveResults(ctx, agent.ID, moved)
+					if err == nil {
+						err = s.archiveStore.RecordArchived(ctx, agent.ID, object, kept)
+					}
+					if err != nil {
+						cursors[agent.ID] = cursor
+						return fmt.Errorf("archiving results of agent %s: %w", agent.ID, err)
+					}
+				}
+				if err := s.resultStore.DeleteResults(ctx, moved); err != nil {
+					cursors[agent.ID] = cursor
+					return err
+				}
+				deleted += len(moved) - len(kept)
+				archived += len(kept)
+			}
+
+			cursor = next
+			if cursor == "" {
+				break
+			}
+		}
+		if cursor == "" {
+			delete(cursors, agent.ID)
+		} else {
+			cursors[agent.ID] = cursor
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// retryPolicyFromAPI converts an API retry policy into a model one
+func retryPolicyFromAPI(p *api.RetryPolicy) *models.RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	return &models.RetryPolicy{
+		InitialDelaySeconds: p.InitialDelaySeconds,
+		Multiplier:          p.Multiplier,
+		MaxDelaySeconds:     p.MaxDelaySeconds,
+		Jitter:              p.Jitter,
+	}
+}
+
+// retryPolicyToAPI converts a model retry policy into an API one
+func retryPolicyToAPI(p *models.RetryPolicy) *api.RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	return &api.RetryPolicy{
+		InitialDelaySeconds: p.InitialDelaySeconds,
+		Multiplier:          p.Multiplier,
+		MaxDelaySeconds:     p.MaxDelaySeconds,
+		Jitter:              p.Jitter,
+	}
+}
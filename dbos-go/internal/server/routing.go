@@ -0,0 +1,341 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/netip"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+	"github.com/internet-measurement-network/dbos/pkg/rislive"
+)
+
+// pingModuleName is the module whose results are checked for probe anomalies
+const pingModuleName = "ping_module"
+
+const (
+	// risLiveMinBackoff and risLiveMaxBackoff bound the delay before
+	// reconnecting to RIS Live after the feed failed
+	risLiveMinBackoff = 5 * time.Second
+	risLiveMaxBackoff = 5 * time.Minute
+
+	// A probe is a latency anomaly once its average RTT exceeds its baseline
+	// by both latencyAnomalyFactor and latencyAnomalyMinMs, and the baseline
+	// has at least rttBaselineMinSamples samples
+	latencyAnomalyFactor  = 2.0
+	latencyAnomalyMinMs   = 20.0
+	rttBaselineMinSamples = 5
+)
+
+// runRISLive streams BGP updates for the watched prefixes from RIS Live,
+// reconnecting with backoff, until ctx is done
+func (s *Server) runRISLive(ctx context.Context) {
+	client := rislive.NewClient(s.config.RISLiveURL)
+	prefixes := make([]string, len(s.config.RoutingPrefixes))
+	for i, watched := range s.config.RoutingPrefixes {
+		prefixes[i] = watched.Prefix.String()
+	}
+
+	backoff := risLiveMinBackoff
+	for {
+		connected := time.Now()
+		err := client.Stream(ctx, prefixes, func(update *rislive.Update) {
+			s.handleRoutingUpdate(ctx, update)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		// A connection that held for a while starts the backoff over
+		if time.Since(connected) > risLiveMaxBackoff {
+			backoff = risLiveMinBackoff
+		}
+		log.Printf("RIS Live: %v; reconnecting in %s", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, risLiveMaxBackoff)
+	}
+}
+
+// handleRoutingUpdate records the withdrawals and hijacks of watched
+// prefixes in a BGP update and correlates them with probe anomalies
+func (s *Server) handleRoutingUpdate(ctx context.Context, update *rislive.Update) {
+	for _, prefixSpec := range update.Withdrawals {
+		prefix, err := netip.ParsePrefix(prefixSpec)
+		if err != nil {
+			continue
+		}
+		for _, watched := range s.config.RoutingPrefixes {
+			if watched.Covers(prefix) {
+				s.recordRoutingEvent(ctx, watched, newRoutingEvent(models.RoutingEventWithdrawal, prefix, watched, update))
+			}
+		}
+	}
+
+	// An origin hidden in an AS set cannot be told apart from the expected one
+	origin := update.OriginASN()
+	if origin == 0 {
+		return
+	}
+	for _, announcement := range update.Announcements {
+		for _, prefixSpec := range announcement.Prefixes {
+			prefix, err := netip.ParsePrefix(prefixSpec)
+			if err != nil {
+				continue
+			}
+			for _, watched := range s.config.RoutingPrefixes {
+				if watched.OriginASN != 0 && origin != watched.OriginASN && watched.Covers(prefix) {
+					event := newRoutingEvent(models.RoutingEventHijack, prefix, watched, update)
+					event.OriginASN = origin
+					s.recordRoutingEvent(ctx, watched, event)
+				}
+			}
+		}
+	}
+}
+
+// newRoutingEvent creates a routing event for a prefix of a BGP update
+func newRoutingEvent(eventType models.RoutingEventTypeEnum, prefix netip.Prefix, watched WatchedPrefix, update *rislive.Update) *models.RoutingEvent {
+	return &models.RoutingEvent{
+		Type:              string(eventType),
+		Prefix:            prefix.String(),
+		WatchedPrefix:     watched.Prefix.String(),
+		ExpectedOriginASN: watched.OriginASN,
+		Path:              update.PathString(),
+		Peer:              update.Peer,
+		PeerASN:           update.PeerASN,
+		Collector:         update.Host,
+		Timestamp:         update.Time(),
+	}
+}
+
+// recordRoutingEvent stores a routing event and links it to an open routing
+// incident of its prefix, or opens one if probes towards the prefix showed
+// anomalies within the correlation window
+func (s *Server) recordRoutingEvent(ctx context.Context, watched WatchedPrefix, event *models.RoutingEvent) {
+	if err := s.routingStore.RecordEvent(ctx, event); err != nil {
+		log.Printf("Routing: recording %s of %s: %v", event.Type, event.Prefix, err)
+		return
+	}
+
+	key := routingIncidentKey(watched)
+	linked, err := s.linkIncidentEvents(ctx, key, event.ID)
+	if err != nil || linked {
+		if err != nil {
+			log.Printf("Routing: incident %s: %v", key, err)
+		}
+		return
+	}
+
+	window := s.config.RoutingCorrelationWindow
+	anomalies, err := s.routingStore.ListAnomalies(ctx, event.WatchedPrefix, event.Timestamp.Add(-window), time.Now())
+	if err != nil {
+		log.Printf("Routing: anomalies of %s: %v", event.WatchedPrefix, err)
+		return
+	}
+	if len(anomalies) == 0 {
+		return
+	}
+
+	anomaly := anomalies[len(anomalies)-1]
+	if err := s.raiseRoutingIncident(ctx, watched, event, anomaly, event.ID); err != nil {
+		log.Printf("Routing: incident %s: %v", key, err)
+	}
+}
+
+// analyzeRoutingProbe checks a ping result towards a watched prefix for lost
+// reachability or a latency spike. An anomaly within the correlation window
+// of a routing event of the prefix opens or extends its routing incident; a
+// healthy result from an agent that saw the incident resolves it.
+func (s *Server) analyzeRoutingProbe(ctx context.Context, result *models.MeasurementResult) {
+	if len(s.config.RoutingPrefixes) == 0 || result.ModuleName != pingModuleName {
+		return
+	}
+
+	addressSpec, _ := lookupString(result.Data, "address")
+	address, err := netip.ParseAddr(addressSpec)
+	if err != nil {
+		return
+	}
+	var watched WatchedPrefix
+	found := false
+	for _, candidate := range s.config.RoutingPrefixes {
+		if candidate.Prefix.Contains(address) {
+			watched, found = candidate, true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+
+	anomaly, err := s.detectProbeAnomaly(ctx, result, address.String())
+	if err != nil {
+		log.Printf("Routing: probe %s: %v", result.ID, err)
+		return
+	}
+
+	key := routingIncidentKey(watched)
+	if anomaly == nil {
+		incident, err := s.incidentStore.GetOpenIncident(ctx, key)
+		if err == nil && incident != nil && containsString(incident.AgentIDs, result.AgentID) {
+			err = s.resolveIncident(ctx, key)
+		}
+		if err != nil {
+			log.Printf("Routing: incident %s: %v", key, err)
+		}
+		return
+	}
+
+	if err := s.routingStore.RecordAnomaly(ctx, watched.Prefix.String(), anomaly); err != nil {
+		log.Printf("Routing: recording anomaly of %s: %v", result.ID, err)
+		return
+	}
+
+	events, err := s.routingStore.ListEvents(ctx, anomaly.ObservedAt.Add(-s.config.RoutingCorrelationWindow), watched.Prefix.String(), 0)
+	if err != nil {
+		log.Printf("Routing: events of %s: %v", watched.Prefix, err)
+		return
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	eventIDs := make([]string, len(events))
+	for i, event := range events {
+		eventIDs[len(events)-1-i] = event.ID
+	}
+	if err := s.raiseRoutingIncident(ctx, watched, events[0], anomaly, eventIDs...); err != nil {
+		log.Printf("Routing: incident %s: %v", key, err)
+	}
+}
+
+// detectProbeAnomaly returns the anomaly a ping result shows, or nil if it
+// is healthy, folding healthy RTTs into the agent's baseline to the address
+func (s *Server) detectProbeAnomaly(ctx context.Context, result *models.MeasurementResult, address string) (*models.ProbeAnomaly, error) {
+	anomaly := &models.ProbeAnomaly{
+		AgentID:    result.AgentID,
+		ResultID:   result.ID,
+		Address:    address,
+		ObservedAt: time.Now(),
+	}
+
+	sent, _ := jsonpath.LookupFloat(result.Data, "packets_sent")
+	received, _ := jsonpath.LookupFloat(result.Data, "packets_received")
+	if sent > 0 && received == 0 {
+		anomaly.Kind = string(models.ProbeAnomalyUnreachable)
+		return anomaly, nil
+	}
+
+	var total float64
+	var count int
+	if v, ok := jsonpath.Lookup(result.Data, "rtts"); ok {
+		rtts, _ := v.([]interface{})
+		for _, rtt := range rtts {
+			if rtt, ok := jsonpath.ToFloat(rtt); ok {
+				total += rtt
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	avg := total / float64(count)
+
+	baseline, err := s.routingStore.GetRTTBaseline(ctx, result.AgentID, address)
+	if err != nil {
+		return nil, err
+	}
+	if baseline.Samples >= rttBaselineMinSamples && avg > latencyAnomalyFactor*baseline.AvgRTT && avg-baseline.AvgRTT >= latencyAnomalyMinMs {
+		// Spikes are kept out of the baseline so a long one stays anomalous
+		anomaly.Kind = string(models.ProbeAnomalyLatency)
+		anomaly.AvgRTT = avg
+		anomaly.BaselineRTT = baseline.AvgRTT
+		return anomaly, nil
+	}
+
+	baseline.Observe(avg)
+	return nil, s.routingStore.SaveRTTBaseline(ctx, result.AgentID, address, baseline)
+}
+
+// raiseRoutingIncident records a probe anomaly coinciding with a routing
+// event in the routing incident of a watched prefix, linking eventIDs
+func (s *Server) raiseRoutingIncident(ctx context.Context, watched WatchedPrefix, event *models.RoutingEvent, anomaly *models.ProbeAnomaly, eventIDs ...string) error {
+	evidence := map[string]string{
+		"event_type": event.Type,
+		"prefix":     event.Prefix,
+		"path":       event.Path,
+		"collector":  event.Collector,
+		"anomaly":    anomaly.Kind,
+		"address":    anomaly.Address,
+	}
+	if event.OriginASN != 0 {
+		evidence["origin_asn"] = fmt.Sprint(event.OriginASN)
+		evidence["expected_origin_asn"] = fmt.Sprint(event.ExpectedOriginASN)
+	}
+	if anomaly.Kind == string(models.ProbeAnomalyLatency) {
+		evidence["avg_rtt"] = fmt.Sprintf("%.1f", anomaly.AvgRTT)
+		evidence["baseline_rtt"] = fmt.Sprintf("%.1f", anomaly.BaselineRTT)
+	}
+
+	reason := event.Type + "," + anomaly.Kind
+	return s.raiseIncident(ctx, models.IncidentTypeRoutingAnomaly, routingIncidentKey(watched), "", watched.Prefix.String(), anomaly.AgentID, anomaly.ResultID, reason, evidence, eventIDs...)
+}
+
+// routingIncidentKey identifies the routing incident of a watched prefix
+func routingIncidentKey(watched WatchedPrefix) string {
+	return fmt.Sprintf("%s:%s", models.IncidentTypeRoutingAnomaly, watched.Prefix)
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ListRoutingEvents retrieves recent routing events of watched prefixes
+func (s *Server) ListRoutingEvents(ctx context.Context, req *api.ListRoutingEventsRequest) (*api.ListRoutingEventsResponse, error) {
+	var since time.Time
+	if req.Since > 0 {
+		since = time.Unix(req.Since, 0)
+	}
+	events, err := s.routingStore.ListEvents(ctx, since, req.WatchedPrefix, int(req.Limit))
+	if err != nil {
+		return &api.ListRoutingEventsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiEvents := make([]*api.RoutingEvent, len(events))
+	for i, event := range events {
+		apiEvents[i] = &api.RoutingEvent{
+			Id:                event.ID,
+			Type:              event.Type,
+			Prefix:            event.Prefix,
+			WatchedPrefix:     event.WatchedPrefix,
+			OriginAsn:         event.OriginASN,
+			ExpectedOriginAsn: event.ExpectedOriginASN,
+			Path:              event.Path,
+			Peer:              event.Peer,
+			PeerAsn:           event.PeerASN,
+			Collector:         event.Collector,
+			Timestamp:         event.Timestamp.Unix(),
+		}
+	}
+
+	return &api.ListRoutingEventsResponse{
+		Events: apiEvents,
+	}, nil
+}
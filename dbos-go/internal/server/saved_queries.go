@@ -0,0 +1,318 @@
+package server
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// savedQueryMaxAggregateRows bounds how many results an aggregation scans
+const savedQueryMaxAggregateRows = 100000
+
+// CreateSavedQuery stores a new saved query
+func (s *Server) CreateSavedQuery(ctx context.Context, req *api.CreateSavedQueryRequest) (*api.CreateSavedQueryResponse, error) {
+	if err := s.savedQueryStore.CreateSavedQuery(ctx, savedQueryFromAPI(req.Query)); err != nil {
+		return &api.CreateSavedQueryResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.CreateSavedQueryResponse{
+		Success: true,
+	}, nil
+}
+
+// GetSavedQuery retrieves a saved query by name
+func (s *Server) GetSavedQuery(ctx context.Context, req *api.GetSavedQueryRequest) (*api.GetSavedQueryResponse, error) {
+	query, err := s.savedQueryStore.GetSavedQuery(ctx, req.Name)
+	if err != nil {
+		return &api.GetSavedQueryResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.GetSavedQueryResponse{
+		Found: true,
+		Query: savedQueryToAPI(query),
+	}, nil
+}
+
+// ListSavedQueries retrieves all saved queries
+func (s *Server) ListSavedQueries(ctx context.Context, req *api.ListSavedQueriesRequest) (*api.ListSavedQueriesResponse, error) {
+	queries, err := s.savedQueryStore.ListSavedQueries(ctx)
+	if err != nil {
+		return &api.ListSavedQueriesResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiQueries := make([]*api.SavedQuery, len(queries))
+	for i, query := range queries {
+		apiQueries[i] = savedQueryToAPI(query)
+	}
+
+	return &api.ListSavedQueriesResponse{
+		Queries: apiQueries,
+	}, nil
+}
+
+// UpdateSavedQuery replaces an existing saved query
+func (s *Server) UpdateSavedQuery(ctx context.Context, req *api.UpdateSavedQueryRequest) (*api.UpdateSavedQueryResponse, error) {
+	if err := s.savedQueryStore.UpdateSavedQuery(ctx, savedQueryFromAPI(req.Query)); err != nil {
+		return &api.UpdateSavedQueryResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.UpdateSavedQueryResponse{
+		Success: true,
+	}, nil
+}
+
+// DeleteSavedQuery removes a saved query
+func (s *Server) DeleteSavedQuery(ctx context.Context, req *api.DeleteSavedQueryRequest) (*api.DeleteSavedQueryResponse, error) {
+	if err := s.savedQueryStore.DeleteSavedQuery(ctx, req.Name); err != nil {
+		return &api.DeleteSavedQueryResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.DeleteSavedQueryResponse{
+		Success: true,
+	}, nil
+}
+
+// ExecuteSavedQuery runs a saved query, returning its rows or its aggregate
+func (s *Server) ExecuteSavedQuery(ctx context.Context, req *api.ExecuteSavedQueryRequest) (*api.ExecuteSavedQueryResponse, error) {
+	query, err := s.savedQueryStore.GetSavedQuery(ctx, req.Name)
+	if err != nil {
+		return &api.ExecuteSavedQueryResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	var resp *api.ExecuteSavedQueryResponse
+	if query.Target == string(models.SavedQueryTargetAgents) {
+		resp, err = s.executeAgentsQuery(ctx, query)
+	} else {
+		resp, err = s.executeResultsQuery(ctx, query)
+	}
+	if err != nil {
+		return &api.ExecuteSavedQueryResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	return resp, nil
+}
+
+// executeResultsQuery runs a saved query over the extracted columns of results
+func (s *Server) executeResultsQuery(ctx context.Context, query *models.SavedQuery) (*api.ExecuteSavedQueryResponse, error) {
+	if query.Aggregation == nil {
+		refs, err := s.indexStore.QueryResults(ctx, query.ModuleName, query.Filters, query.AgentID, savedQueryLimit(query))
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &api.ExecuteSavedQueryResponse{}
+		for _, ref := range refs {
+			result, err := s.resultStore.GetResult(ctx, ref.AgentID, ref.RequestID)
+			if err != nil {
+				continue
+			}
+			resp.Results = append(resp.Results, resultToAPI(result))
+		}
+		return resp, nil
+	}
+
+	refs, err := s.indexStore.QueryResults(ctx, query.ModuleName, query.Filters, query.AgentID, savedQueryMaxAggregateRows)
+	if err != nil {
+		return nil, err
+	}
+	resp := &api.ExecuteSavedQueryResponse{
+		Aggregated: true,
+		Truncated:  len(refs) >= savedQueryMaxAggregateRows,
+	}
+
+	if query.Aggregation.Function == string(models.AggregationCount) {
+		resp.Count = int64(len(refs))
+		resp.Value = float64(len(refs))
+		return resp, nil
+	}
+
+	values, err := s.indexStore.NumberValues(ctx, query.ModuleName, query.Aggregation.Column, refs)
+	if err != nil {
+		return nil, err
+	}
+	numbers := make([]float64, 0, len(values))
+	for _, value := range values {
+		numbers = append(numbers, value)
+	}
+	resp.Count = int64(len(numbers))
+	resp.Value = aggregate(models.AggregationFunctionEnum(query.Aggregation.Function), numbers)
+	return resp, nil
+}
+
+// executeAgentsQuery runs a saved query over the agent inventory
+func (s *Server) executeAgentsQuery(ctx context.Context, query *models.SavedQuery) (*api.ExecuteSavedQueryResponse, error) {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var matched []*models.Agent
+	for _, agent := range agents {
+		if query.AliveOnly && (!agent.Alive || now.Sub(agent.LastSeen) > s.config.AgentLivenessWindow) {
+			continue
+		}
+		if !hasLabels(agent, query.Labels) {
+			continue
+		}
+		matched = append(matched, agent)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].ID < matched[j].ID
+	})
+
+	if query.Aggregation != nil {
+		return &api.ExecuteSavedQueryResponse{
+			Aggregated: true,
+			Count:      int64(len(matched)),
+			Value:      float64(len(matched)),
+		}, nil
+	}
+
+	resp := &api.ExecuteSavedQueryResponse{}
+	for _, agent := range matched[:min(len(matched), savedQueryLimit(query))] {
+		resp.Agents = append(resp.Agents, agentToAPI(agent))
+	}
+	return resp, nil
+}
+
+// hasLabels reports whether an agent carries all of labels
+func hasLabels(agent *models.Agent, labels map[string]string) bool {
+	for key, value := range labels {
+		if agent.Labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// aggregate reduces values with an aggregation function; it returns 0 for no values
+func aggregate(function models.AggregationFunctionEnum, values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		sum += value
+		lowest = math.Min(lowest, value)
+		highest = math.Max(highest, value)
+	}
+
+	switch function {
+	case models.AggregationSum:
+		return sum
+	case models.AggregationAvg:
+		return sum / float64(len(values))
+	case models.AggregationMin:
+		return lowest
+	case models.AggregationMax:
+		return highest
+	}
+	return float64(len(values))
+}
+
+// savedQueryLimit returns how many rows a saved query returns
+func savedQueryLimit(query *models.SavedQuery) int {
+	if query.Limit <= 0 {
+		return defaultQueryResultsLimit
+	}
+	return min(query.Limit, maxQueryResultsLimit)
+}
+
+// handleHTTPExecuteSavedQuery serves GET /v1/queries/{name}, same as ExecuteSavedQuery
+func (s *Server) handleHTTPExecuteSavedQuery(w http.ResponseWriter, r *http.Request) {
+	req := &api.ExecuteSavedQueryRequest{Name: r.PathValue("name")}
+	s.meterHTTP(w, r, api.DBOS_ExecuteSavedQuery_FullMethodName, req, func(w http.ResponseWriter) {
+		resp, _ := s.ExecuteSavedQuery(r.Context(), req)
+		writeProtoJSON(w, resp)
+	})
+}
+
+// savedQueryFromAPI converts an API saved query into a model saved query
+func savedQueryFromAPI(q *api.SavedQuery) *models.SavedQuery {
+	query := &models.SavedQuery{
+		Name:        q.Name,
+		Description: q.Description,
+		Target:      q.Target,
+		ModuleName:  q.ModuleName,
+		AgentID:     q.AgentId,
+		Labels:      q.Labels,
+		AliveOnly:   q.AliveOnly,
+		Limit:       int(q.Limit),
+	}
+	for _, filter := range q.Filters {
+		query.Filters = append(query.Filters, models.ColumnFilter{
+			Column: filter.Column,
+			Op:     filter.Op,
+			Value:  filter.Value,
+		})
+	}
+	if q.Aggregation != nil {
+		query.Aggregation = &models.Aggregation{
+			Function: q.Aggregation.Function,
+			Column:   q.Aggregation.Column,
+		}
+	}
+	return query
+}
+
+// savedQueryToAPI converts a model saved query into an API saved query
+func savedQueryToAPI(query *models.SavedQuery) *api.SavedQuery {
+	q := &api.SavedQuery{
+		Name:        query.Name,
+		Description: query.Description,
+		Target:      query.Target,
+		ModuleName:  query.ModuleName,
+		AgentId:     query.AgentID,
+		Labels:      query.Labels,
+		AliveOnly:   query.AliveOnly,
+		Limit:       int32(query.Limit),
+		CreatedAt:   query.CreatedAt.Unix(),
+		UpdatedAt:   query.UpdatedAt.Unix(),
+	}
+	for _, filter := range query.Filters {
+		q.Filters = append(q.Filters, &api.ColumnFilter{
+			Column: filter.Column,
+			Op:     filter.Op,
+			Value:  filter.Value,
+		})
+	}
+	if query.Aggregation != nil {
+		q.Aggregation = &api.Aggregation{
+			Function: query.Aggregation.Function,
+			Column:   query.Aggregation.Column,
+		}
+	}
+	return q
+}
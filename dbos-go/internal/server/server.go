@@ -2,55 +2,433 @@ package server
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"net"
 	"time"
 
 	"github.com/internet-measurement-network/dbos/api"
+	apiv2 "github.com/internet-measurement-network/dbos/api/v2"
+	"github.com/internet-measurement-network/dbos/api/validate"
 	"github.com/internet-measurement-network/dbos/internal/models"
 	"github.com/internet-measurement-network/dbos/internal/store"
+	"github.com/internet-measurement-network/dbos/pkg/clickhouse"
+	"github.com/internet-measurement-network/dbos/pkg/ct"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"github.com/internet-measurement-network/dbos/pkg/influx"
+	"github.com/internet-measurement-network/dbos/pkg/objectstore"
 	"github.com/internet-measurement-network/dbos/pkg/redis"
+	"github.com/internet-measurement-network/dbos/pkg/remotewrite"
+	"github.com/internet-measurement-network/dbos/pkg/webhook"
 	"google.golang.org/grpc"
 )
 
 // Server implements the DBOS gRPC service
 type Server struct {
 	api.UnimplementedDBOSServer
-	agentStore       *store.AgentStore
-	moduleStateStore *store.ModuleStateStore
-	resultStore      *store.ResultStore
-	taskStore        *store.TaskStore
+	config            Config
+	agentStore        store.Agents
+	moduleStateStore  store.ModuleStates
+	resultStore       store.Results
+	taskStore         store.Tasks
+	events            store.Events
+	eventLog          *store.EventLogStore
+	blobStore         *store.BlobStore
+	credentialStore   *store.CredentialStore
+	configStore       *store.ConfigStore
+	verificationStore *store.VerificationStore
+	broadcastStore    *store.BroadcastStore
+	taskProgressStore *store.TaskProgressStore
+	targetListStore   *store.TargetListStore
+	specStore         *store.SpecStore
+	viewStore         *store.ViewStore
+	indexStore        *store.IndexStore
+	savedQueryStore   *store.SavedQueryStore
+	alertRuleStore    *store.AlertRuleStore
+	maintenanceStore  *store.MaintenanceStore
+	trendStore        *store.TrendStore
+	clockSkewStore    *store.ClockSkewStore
+	alertStore        *store.AlertStore
+	incidentStore     *store.IncidentStore
+	dnsStore          *store.DNSStore
+	httpContentStore  *store.HTTPContentStore
+	routingStore      *store.RoutingStore
+	statusStore       *store.StatusStore
+	usageStore        *store.UsageStore
+	campaignStore     *store.CampaignStore
+	relayStore        *store.RelayStore
+	archiveStore      *store.ArchiveStore
+	artifactStore     *store.ArtifactStore
+	exportStore       *store.ExportStore
+	targetHistory     *store.TargetHistoryStore
+	executionStore    *store.ExecutionStore
+	stateEvents       *store.EventSourcedStore
+	outboxStore       *store.OutboxStore
+	backend           store.Store
+	migrator          store.Migrator
+	cluster           clusterChecker
+	draining          chan struct{}
+	ct                *ct.Client
+	remoteWrite       *remotewrite.Client
+	remoteWriteBuffer sampleBuffer
+	influx            *influx.Client
+	influxBuffer      sampleBuffer
+	clickHouse        *clickhouse.Client
+	alertWebhook      *webhook.Client
+	archive           *objectstore.Client
+	manifestKey       ed25519.PrivateKey
+	bus               busPublisher
+	load              loadMonitor
+	usage             usageMeter
+	quotas            quotaTracker
 }
 
-// NewServer creates a new DBOS server
+// NewServer creates a new DBOS server with the default configuration
 func NewServer(redisAddr string) *Server {
+	return NewServerWithConfig(DefaultConfig(redisAddr))
+}
+
+// NewServerWithConfig creates a new DBOS server backed by Redis
+func NewServerWithConfig(cfg Config) *Server {
 	// Create Redis client
-	redisClient := redis.NewClient(redisAddr)
+	redisClient := redis.NewNamespacedClient(cfg.RedisAddr, cfg.RedisNamespace)
+
+	backend := store.NewRedisStore(redisClient)
+	backend.SetTaskStreams(cfg.TaskQueue == TaskQueueStreams)
+	backend.SetTaskLeaseTimeout(cfg.TaskLeaseTimeout)
+	s := newServer(cfg, backend, redisClient)
+	s.migrator = store.NewRedisMigrator(redisClient)
+
+	// Payload deduplication, view maintenance and column indexing hook into
+	// Redis result storage
+	backend.SetViewStore(s.viewStore)
+	backend.SetIndexStore(s.indexStore)
+	if s.outboxStore != nil {
+		backend.SetOutbox(s.outboxStore)
+	}
+	if cfg.DedupMinBytes > 0 {
+		s.blobStore = store.NewBlobStore(redisClient, cfg.DedupMinBytes)
+		backend.SetBlobStore(s.blobStore)
+	}
+
+	return s
+}
+
+// NewServerWithStore creates a new DBOS server keeping agents, module states,
+// results, tasks and events in backend, which it closes once it stops. The
+// stores of auxiliary features, such as the event log and alerts, are kept
+// on aux, which the caller closes. Backends that implement store.Migrator
+// are migrated at startup.
+func NewServerWithStore(cfg Config, backend store.Store, aux *redis.Client) *Server {
+	s := newServer(cfg, backend, aux)
+	if migrator, ok := backend.(store.Migrator); ok {
+		s.migrator = migrator
+	}
+	return s
+}
+
+// newServer creates a server on backend, with auxiliary stores on redisClient
+func newServer(cfg Config, backend store.Store, redisClient *redis.Client) *Server {
+	cluster, _ := backend.(clusterChecker)
+
+	var remoteWrite *remotewrite.Client
+	if cfg.RemoteWriteURL != "" {
+		remoteWrite = remotewrite.NewClient(cfg.RemoteWriteURL)
+	}
+
+	var influxClient *influx.Client
+	if cfg.InfluxURL != "" {
+		influxClient = influx.NewClient(cfg.InfluxURL, cfg.InfluxToken)
+	}
+
+	var clickHouseClient *clickhouse.Client
+	if cfg.ClickHouseURL != "" {
+		clickHouseClient = clickhouse.NewClient(cfg.ClickHouseURL, cfg.ClickHouseDatabase, cfg.ClickHouseUser, cfg.ClickHousePassword)
+	}
+
+	var ctClient *ct.Client
+	if cfg.CTLookupURL != "" {
+		ctClient = ct.NewClient(cfg.CTLookupURL)
+	}
 
-	// Create stores
-	agentStore := store.NewAgentStore(redisClient)
-	moduleStateStore := store.NewModuleStateStore(redisClient)
-	resultStore := store.NewResultStore(redisClient)
-	taskStore := store.NewTaskStore(redisClient)
+	var alertWebhook *webhook.Client
+	if cfg.AlertWebhookURL != "" {
+		alertWebhook = webhook.NewClient(cfg.AlertWebhookURL)
+	}
+
+	var archive *objectstore.Client
+	if cfg.ResultArchiveURL != "" && cfg.ResultArchiveAccessKey != "" {
+		archive = objectstore.NewS3Client(cfg.ResultArchiveURL, objectstore.Credentials{
+			AccessKey: cfg.ResultArchiveAccessKey,
+			SecretKey: cfg.ResultArchiveSecretKey,
+			Region:    cfg.ResultArchiveRegion,
+		})
+	} else if cfg.ResultArchiveURL != "" {
+		archive = objectstore.NewClient(cfg.ResultArchiveURL, cfg.ResultArchiveToken)
+	}
+
+	// Results are published from the outbox when stored in Redis, and
+	// state events when logged
+	var outboxStore *store.OutboxStore
+	if cfg.BusDriver != "" {
+		outboxStore = store.NewOutboxStore(redisClient, cfg.BusOutboxMaxLen)
+	}
+
+	var stateEvents *store.EventSourcedStore
+	if cfg.EventSourcing {
+		stateEvents = store.NewEventSourcedStore(backend, redisClient)
+		if outboxStore != nil {
+			stateEvents.SetOutbox(outboxStore)
+		}
+		backend = stateEvents
+	}
 
 	return &Server{
-		agentStore:       agentStore,
-		moduleStateStore: moduleStateStore,
-		resultStore:      resultStore,
-		taskStore:        taskStore,
+		config:            cfg,
+		agentStore:        backend.Agents(),
+		moduleStateStore:  backend.ModuleStates(),
+		resultStore:       backend.Results(),
+		taskStore:         backend.Tasks(),
+		events:            backend.Events(),
+		eventLog:          store.NewEventLogStore(redisClient),
+		credentialStore:   store.NewCredentialStore(redisClient),
+		configStore:       store.NewConfigStore(redisClient),
+		verificationStore: store.NewVerificationStore(redisClient),
+		broadcastStore:    store.NewBroadcastStore(redisClient),
+		taskProgressStore: store.NewTaskProgressStore(redisClient),
+		targetListStore:   store.NewTargetListStore(redisClient),
+		specStore:         store.NewSpecStore(redisClient),
+		viewStore:         store.NewViewStore(redisClient),
+		indexStore:        store.NewIndexStore(redisClient),
+		savedQueryStore:   store.NewSavedQueryStore(redisClient),
+		alertRuleStore:    store.NewAlertRuleStore(redisClient),
+		maintenanceStore:  store.NewMaintenanceStore(redisClient),
+		trendStore:        store.NewTrendStore(redisClient),
+		clockSkewStore:    store.NewClockSkewStore(redisClient),
+		alertStore:        store.NewAlertStore(redisClient),
+		incidentStore:     store.NewIncidentStore(redisClient),
+		dnsStore:          store.NewDNSStore(redisClient),
+		httpContentStore:  store.NewHTTPContentStore(redisClient),
+		routingStore:      store.NewRoutingStore(redisClient),
+		statusStore:       store.NewStatusStore(redisClient),
+		usageStore:        store.NewUsageStore(redisClient),
+		campaignStore:     store.NewCampaignStore(redisClient),
+		relayStore:        store.NewRelayStore(redisClient, cfg.RelayBufferLimit),
+		archiveStore:      store.NewArchiveStore(redisClient),
+		artifactStore:     store.NewArtifactStore(redisClient),
+		exportStore:       store.NewExportStore(redisClient),
+		targetHistory:     store.NewTargetHistoryStore(redisClient),
+		executionStore:    store.NewExecutionStore(redisClient),
+		stateEvents:       stateEvents,
+		outboxStore:       outboxStore,
+		backend:           backend,
+		cluster:           cluster,
+		draining:          make(chan struct{}),
+		ct:                ctClient,
+		remoteWrite:       remoteWrite,
+		remoteWriteBuffer: sampleBuffer{name: "Remote write"},
+		influx:            influxClient,
+		influxBuffer:      sampleBuffer{name: "Influx sink"},
+		clickHouse:        clickHouseClient,
+		alertWebhook:      alertWebhook,
+		archive:           archive,
+		bus:               newBusPublisher(cfg),
 	}
 }
 
-// Start starts the gRPC server
+// Start starts the gRPC server and serves until it fails
 func (s *Server) Start(port string) error {
+	return s.Run(context.Background(), port)
+}
+
+// Run starts the gRPC server and serves until ctx is done, then shuts down
+// gracefully
+func (s *Server) Run(ctx context.Context, port string) error {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
+	tlsOpts, err := s.grpcServerOptions()
+	if err != nil {
+		return err
+	}
+	if err := s.loadManifestKey(); err != nil {
+		return err
+	}
+	// Errors are classified outermost, after every other interceptor
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryErrorInterceptor),
+		grpc.ChainStreamInterceptor(streamErrorInterceptor),
+	}
+	opts = append(opts, tlsOpts...)
+	opts = append(opts, grpc.ChainStreamInterceptor(s.drainStreamInterceptor))
+	if s.backpressureEnabled() && s.config.UpstreamAddr == "" {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(s.unaryLoadInterceptor),
+			grpc.ChainStreamInterceptor(s.streamLoadInterceptor),
+		)
+	}
+	if s.config.TokenAuth && s.config.UpstreamAddr == "" {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(s.unaryTokenInterceptor),
+			grpc.ChainStreamInterceptor(s.streamTokenInterceptor),
+		)
+	}
+	// Usage is accounted to the caller once authenticated, counting the
+	// requests validation rejects
+	if s.usageEnabled() && s.config.UpstreamAddr == "" {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(s.unaryUsageInterceptor),
+			grpc.ChainStreamInterceptor(s.streamUsageInterceptor),
+		)
+	}
+	// Requests are validated once authorized; relays validate too, sparing
+	// the upstream requests it would reject
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryValidationInterceptor),
+		grpc.ChainStreamInterceptor(streamValidationInterceptor),
+	)
+	grpcServer := grpc.NewServer(opts...)
+
+	workers := newWorkerGroup()
+	if s.config.UpstreamAddr != "" {
+		upstream, err := s.startRelay(grpcServer, workers)
+		if err != nil {
+			return err
+		}
+		defer upstream.Close()
+	} else {
+		s.startServices(ctx, grpcServer, workers)
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- grpcServer.Serve(lis)
+	}()
+	select {
+	case err := <-served:
+		workers.Stop()
+		s.backend.Close()
+		return err
+	case <-ctx.Done():
+	}
+
+	s.shutdown(grpcServer, workers)
+	return nil
+}
+
+// startServices registers the DBOS services and starts the background
+// workers and HTTP endpoints; the endpoints shut down once ctx is done
+func (s *Server) startServices(ctx context.Context, grpcServer *grpc.Server, workers *workerGroup) {
 	api.RegisterDBOSServer(grpcServer, s)
+	apiv2.RegisterDBOSServer(grpcServer, &v2Server{s: s})
+	s.checkRedisCluster(ctx)
+	s.migrate(ctx)
 
-	return grpcServer.Serve(lis)
+	workers.Go(func(ctx context.Context) {
+		s.runContinuousScheduler(ctx, continuousSchedulerInterval)
+	})
+	workers.Go(func(ctx context.Context) {
+		s.runCampaignScheduler(ctx, campaignSchedulerInterval)
+	})
+	workers.Go(func(ctx context.Context) {
+		s.runConfigRollouts(ctx, configRolloutInterval)
+	})
+	workers.Go(func(ctx context.Context) {
+		s.runLivenessSweeper(ctx, livenessSweepInterval)
+	})
+	workers.Go(func(ctx context.Context) {
+		s.runAlertEvaluator(ctx, s.config.AlertEvaluationInterval)
+	})
+	// Tasks may set lease timeouts of their own without a default one
+	workers.Go(func(ctx context.Context) {
+		s.runTaskRequeuer(ctx, s.config.TaskRequeueInterval)
+	})
+	if s.retentionEnabled() || s.tieringEnabled() {
+		workers.Go(func(ctx context.Context) {
+			s.runResultRetention(ctx, s.config.ResultRetentionInterval)
+		})
+	}
+	if s.eventLogRetentionEnabled() {
+		workers.Go(func(ctx context.Context) {
+			s.runEventLogRetention(ctx, s.config.EventLogTrimInterval)
+		})
+	}
+	if s.config.BackpressureRedisLatency > 0 {
+		workers.Go(func(ctx context.Context) {
+			s.runLoadProbe(ctx, loadProbeInterval)
+		})
+	}
+	if s.usageEnabled() {
+		workers.Go(func(ctx context.Context) {
+			s.runUsageFlusher(ctx, s.config.UsageFlushInterval)
+		})
+	}
+	if s.blobStore != nil {
+		workers.Go(func(ctx context.Context) {
+			s.runBlobGC(ctx, s.config.BlobGCInterval)
+		})
+	}
+	if s.config.DriftCheckInterval > 0 {
+		workers.Go(func(ctx context.Context) {
+			s.runDriftDetector(ctx, s.config.DriftCheckInterval)
+		})
+	}
+	if s.config.HTTPPort != "" {
+		workers.Go(func(context.Context) {
+			s.startHTTPIngest(ctx, s.config.HTTPPort)
+		})
+	}
+	if s.remoteWrite != nil {
+		workers.Go(func(ctx context.Context) {
+			s.runMetricSink(ctx, s.config.RemoteWriteInterval, &s.remoteWriteBuffer, s.writeRemoteWrite)
+		})
+	}
+	if s.influx != nil {
+		workers.Go(func(ctx context.Context) {
+			s.runMetricSink(ctx, s.config.InfluxInterval, &s.influxBuffer, s.writeInflux)
+		})
+	}
+	if s.clickHouse != nil {
+		workers.Go(func(ctx context.Context) {
+			s.runClickHouseExport(ctx, s.config.ClickHouseInterval)
+		})
+	}
+	if s.bus != nil {
+		workers.Go(func(ctx context.Context) {
+			s.runBusPublisher(ctx)
+		})
+	}
+	if s.config.TrendsEnabled {
+		workers.Go(func(ctx context.Context) {
+			s.runTrendRollup(ctx, s.config.TrendRollupInterval)
+		})
+	}
+	if s.config.GraphQLPort != "" {
+		workers.Go(func(context.Context) {
+			s.startGraphQL(ctx, s.config.GraphQLPort)
+		})
+	}
+	if s.config.StatusPort != "" {
+		workers.Go(func(context.Context) {
+			s.startStatusPage(ctx, s.config.StatusPort)
+		})
+	}
+	if s.config.UIPort != "" {
+		workers.Go(func(context.Context) {
+			s.startWebUI(ctx, s.config.UIPort)
+		})
+	}
+	if len(s.config.RoutingPrefixes) > 0 {
+		workers.Go(s.runRISLive)
+	}
+	if s.config.SimulatedAgents > 0 {
+		workers.Go(func(ctx context.Context) {
+			s.runSimulatedAgents(ctx, s.config.SimulatedAgents, s.config.SimulatedAgentInterval)
+		})
+	}
 }
 
 // RegisterAgent registers a new agent
@@ -63,15 +441,18 @@ func (s *Server) RegisterAgent(ctx context.Context, req *api.RegisterAgentReques
 		FirstSeen:       time.Unix(req.Agent.FirstSeen, 0),
 		Config:          req.Agent.Config,
 		TotalHeartbeats: req.Agent.TotalHeartbeats,
+		Labels:          req.Agent.Labels,
 	}
 
 	err := s.agentStore.RegisterAgent(ctx, agent)
 	if err != nil {
 		return &api.RegisterAgentResponse{
-			Success: false,
-			Error:   err.Error(),
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
+	s.recordAgentEvent(ctx, models.EventAgentRegistered, models.EventSeverityInfo, agent.ID, agent)
 
 	return &api.RegisterAgentResponse{
 		Success: true,
@@ -80,77 +461,153 @@ func (s *Server) RegisterAgent(ctx context.Context, req *api.RegisterAgentReques
 
 // GetAgent retrieves an agent by ID
 func (s *Server) GetAgent(ctx context.Context, req *api.GetAgentRequest) (*api.GetAgentResponse, error) {
+	mask, err := newReadMask(&api.Agent{}, req.ReadMask)
+	if err != nil {
+		return &api.GetAgentResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
 	agent, err := s.agentStore.GetAgent(ctx, req.AgentId)
 	if err != nil {
 		return &api.GetAgentResponse{
-			Found: false,
-			Error: err.Error(),
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
 
+	apiAgent := agentToAPI(agent)
+	if windows, err := s.maintenanceStore.ListActiveWindows(ctx, time.Now()); err == nil {
+		apiAgent.MaintenanceWindowIds = agentMaintenanceWindowIDs(windows, agent)
+	}
+	mask.apply(apiAgent)
+
 	return &api.GetAgentResponse{
 		Found: true,
-		Agent: &api.Agent{
-			Id:              agent.ID,
-			Hostname:        agent.Hostname,
-			Alive:           agent.Alive,
-			LastSeen:        agent.LastSeen.Unix(),
-			FirstSeen:       agent.FirstSeen.Unix(),
-			Config:          agent.Config,
-			TotalHeartbeats: agent.TotalHeartbeats,
-		},
+		Agent: apiAgent,
 	}, nil
 }
 
-// ListAgents retrieves all agents
+// ListAgents retrieves all agents, or a page of them if a page size is
+// given, in an order if one is given
 func (s *Server) ListAgents(ctx context.Context, req *api.ListAgentsRequest) (*api.ListAgentsResponse, error) {
-	agents, err := s.agentStore.ListAgents(ctx)
+	mask, err := newReadMask(&api.Agent{}, req.ReadMask)
 	if err != nil {
 		return &api.ListAgentsResponse{
-			Error: err.Error(),
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	order, err := parseOrderBy(req.OrderBy, agentOrderBy)
+	if err != nil {
+		return &api.ListAgentsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	var agents []*models.Agent
+	var nextCursor string
+	if order.Field != "" {
+		agents, nextCursor, err = s.agentStore.ListAgentsOrdered(ctx, order, req.Cursor, int(req.PageSize))
+	} else if req.PageSize > 0 {
+		agents, nextCursor, err = s.agentStore.ListAgentsPage(ctx, req.Cursor, int(req.PageSize))
+	} else {
+		agents, err = s.agentStore.ListAgents(ctx)
+	}
+	if err != nil {
+		return &api.ListAgentsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	// Agents under maintenance are flagged, so fleet views can tell them apart
+	windows, err := s.maintenanceStore.ListActiveWindows(ctx, time.Now())
+	if err != nil {
+		return &api.ListAgentsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
 
 	apiAgents := make([]*api.Agent, len(agents))
 	for i, agent := range agents {
-		apiAgents[i] = &api.Agent{
-			Id:              agent.ID,
-			Hostname:        agent.Hostname,
-			Alive:           agent.Alive,
-			LastSeen:        agent.LastSeen.Unix(),
-			FirstSeen:       agent.FirstSeen.Unix(),
-			Config:          agent.Config,
-			TotalHeartbeats: agent.TotalHeartbeats,
-		}
+		apiAgents[i] = agentToAPI(agent)
+		apiAgents[i].MaintenanceWindowIds = agentMaintenanceWindowIDs(windows, agent)
+		mask.apply(apiAgents[i])
 	}
 
 	return &api.ListAgentsResponse{
-		Agents: apiAgents,
+		Agents:     apiAgents,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// DeleteAgent removes an agent from the inventory
+func (s *Server) DeleteAgent(ctx context.Context, req *api.DeleteAgentRequest) (*api.DeleteAgentResponse, error) {
+	err := s.agentStore.DeleteAgent(ctx, req.AgentId)
+	if err != nil {
+		return &api.DeleteAgentResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	s.recordAgentEvent(ctx, models.EventAgentDeleted, models.EventSeverityInfo, req.AgentId, nil)
+
+	return &api.DeleteAgentResponse{
+		Success: true,
 	}, nil
 }
 
 // SetModuleState sets a module state
 func (s *Server) SetModuleState(ctx context.Context, req *api.SetModuleStateRequest) (*api.SetModuleStateResponse, error) {
+	if req.State.RequestId != "" {
+		if err := validateRequestID("state.request_id", req.State.RequestId); err != nil {
+			return &api.SetModuleStateResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+	}
+
 	state := &models.ModuleState{
 		AgentID:      req.State.AgentId,
 		ModuleName:   req.State.ModuleName,
 		State:        req.State.State,
 		ErrorMessage: req.State.ErrorMessage,
+		ErrorCode:    req.State.ErrorCode,
 		Details:      req.State.Details,
 		Timestamp:    time.Unix(req.State.Timestamp, 0),
 		RequestID:    req.State.RequestId,
 	}
 
-	err := s.moduleStateStore.SetModuleState(ctx, state)
+	var err error
+	if req.ExpectedVersion != nil {
+		err = s.moduleStateStore.SetModuleStateWithVersion(ctx, state, *req.ExpectedVersion)
+	} else {
+		err = s.moduleStateStore.SetModuleState(ctx, state)
+	}
 	if err != nil {
 		return &api.SetModuleStateResponse{
-			Success: false,
-			Error:   err.Error(),
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+			Version:   state.Version,
+			Conflict:  errors.Is(err, store.ErrVersionConflict),
 		}, nil
 	}
+	s.recordModuleStateEvent(ctx, state)
+	s.recordBroadcastState(ctx, state)
 
 	return &api.SetModuleStateResponse{
 		Success: true,
+		Version: state.Version,
 	}, nil
 }
 
@@ -159,22 +616,15 @@ func (s *Server) GetModuleState(ctx context.Context, req *api.GetModuleStateRequ
 	state, err := s.moduleStateStore.GetModuleState(ctx, req.RequestId)
 	if err != nil {
 		return &api.GetModuleStateResponse{
-			Found: false,
-			Error: err.Error(),
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
 
 	return &api.GetModuleStateResponse{
 		Found: true,
-		State: &api.ModuleState{
-			AgentId:      state.AgentID,
-			ModuleName:   state.ModuleName,
-			State:        state.State,
-			ErrorMessage: state.ErrorMessage,
-			Details:      state.Details,
-			Timestamp:    state.Timestamp.Unix(),
-			RequestId:    state.RequestID,
-		},
+		State: moduleStateToAPI(state),
 	}, nil
 }
 
@@ -183,21 +633,14 @@ func (s *Server) ListModuleStates(ctx context.Context, req *api.ListModuleStates
 	states, err := s.moduleStateStore.ListModuleStates(ctx, req.AgentId, req.ModuleName)
 	if err != nil {
 		return &api.ListModuleStatesResponse{
-			Error: err.Error(),
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
 
 	apiStates := make([]*api.ModuleState, len(states))
 	for i, state := range states {
-		apiStates[i] = &api.ModuleState{
-			AgentId:      state.AgentID,
-			ModuleName:   state.ModuleName,
-			State:        state.State,
-			ErrorMessage: state.ErrorMessage,
-			Details:      state.Details,
-			Timestamp:    state.Timestamp.Unix(),
-			RequestId:    state.RequestID,
-		}
+		apiStates[i] = moduleStateToAPI(state)
 	}
 
 	return &api.ListModuleStatesResponse{
@@ -207,146 +650,777 @@ func (s *Server) ListModuleStates(ctx context.Context, req *api.ListModuleStates
 
 // StoreResult stores a measurement result
 func (s *Server) StoreResult(ctx context.Context, req *api.StoreResultRequest) (*api.StoreResultResponse, error) {
-	result := &models.MeasurementResult{
-		ID:         req.Result.Id,
-		AgentID:    req.Result.AgentId,
-		ModuleName: req.Result.ModuleName,
-		Data:       req.Result.Data,
-		Timestamp:  time.Unix(req.Result.Timestamp, 0),
+	result, err := s.resultFromAPI(ctx, req.Result, false)
+	if err != nil {
+		return &api.StoreResultResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
 	}
 
-	err := s.resultStore.StoreResult(ctx, result)
+	duplicate, err := s.resultStore.StoreResult(ctx, result)
 	if err != nil {
 		return &api.StoreResultResponse{
-			Success: false,
-			Error:   err.Error(),
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
+	s.analyzeResult(ctx, result, duplicate)
 
 	return &api.StoreResultResponse{
-		Success: true,
+		Success:  true,
+		ResultId: result.ID,
+		Backoff:  s.backoff(backoffHigh, 0),
+	}, nil
+}
+
+// maxStoreResultsBatch is the most results one StoreResults call stores,
+// the max_items of StoreResultsRequest.results
+const maxStoreResultsBatch = 1000
+
+// StoreResults stores a batch of results, validating and analyzing each as
+// StoreResult does, and reports the outcome per result. The batch is read
+// and written in a few pipelined round trips, however many results it
+// holds. A backfill stores late historical results, as resultFromAPI and
+// analyzeResult describe.
+func (s *Server) StoreResults(ctx context.Context, req *api.StoreResultsRequest) (*api.StoreResultsResponse, error) {
+	statuses := make([]*api.ResultStoreStatus, len(req.Results))
+	results := make([]*models.MeasurementResult, 0, len(req.Results))
+	valid := make([]int, 0, len(req.Results))
+	for i, apiResult := range req.Results {
+		if apiResult == nil {
+			statuses[i] = resultStoreError("", dberrors.New(dberrors.InvalidArgument, "result is required"))
+			continue
+		}
+		result, err := s.resultFromAPI(ctx, apiResult, req.Backfill)
+		if err != nil {
+			statuses[i] = resultStoreError(apiResult.Id, err)
+			continue
+		}
+		results = append(results, result)
+		valid = append(valid, i)
+	}
+
+	outcomes, err := s.resultStore.StoreResults(ctx, results)
+	if err != nil {
+		return &api.StoreResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	for j, i := range valid {
+		result := results[j]
+		if outcomes[j].Err != nil {
+			statuses[i] = resultStoreError(result.ID, outcomes[j].Err)
+			continue
+		}
+		status := models.ResultStoreStatusStored
+		if outcomes[j].Duplicate {
+			status = models.ResultStoreStatusDuplicate
+		}
+		statuses[i] = &api.ResultStoreStatus{
+			ResultId: result.ID,
+			Status:   string(status),
+		}
+		s.analyzeResult(ctx, result, outcomes[j].Duplicate)
+	}
+
+	return &api.StoreResultsResponse{
+		Results: statuses,
+		Backoff: s.backoff(backoffLow, maxStoreResultsBatch),
 	}, nil
 }
 
+// resultStoreError returns the outcome of a result of a batch that was not
+// stored
+func resultStoreError(resultID string, err error) *api.ResultStoreStatus {
+	return &api.ResultStoreStatus{
+		ResultId:  resultID,
+		Status:    string(models.ResultStoreStatusError),
+		Error:     err.Error(),
+		ErrorCode: errorCode(err),
+	}
+}
+
+// resultFromAPI validates a result to store and converts it to its model,
+// giving a result without an ID a sortable local one. Results are validated
+// here rather than with their request, so a batch or stream rejects them
+// one by one. A backfilled result must carry its measurement time, which
+// may be of any age but not in the future.
+func (s *Server) resultFromAPI(ctx context.Context, apiResult *api.MeasurementResult, backfill bool) (*models.MeasurementResult, error) {
+	if apiResult.Id == "" {
+		apiResult.Id = models.LocalTaskIDPrefix + ids.ULID()
+	}
+	if err := validate.Field("result", apiResult); err != nil {
+		return nil, err
+	}
+	if err := validateRequestID("result.id", apiResult.Id); err != nil {
+		return nil, err
+	}
+
+	result := &models.MeasurementResult{
+		ID:            apiResult.Id,
+		AgentID:       apiResult.AgentId,
+		ModuleName:    apiResult.ModuleName,
+		Data:          apiResult.Data,
+		Timestamp:     time.Unix(apiResult.Timestamp, 0),
+		Origin:        string(models.ResultOriginFor(apiResult.Id)),
+		CorrelationID: apiResult.CorrelationId,
+		Backfilled:    backfill,
+	}
+	s.normalizeTimestamp(ctx, result, apiResult.AgentTimestampMs)
+	if backfill && apiResult.Timestamp == 0 && apiResult.AgentTimestampMs == 0 {
+		return nil, dberrors.New(dberrors.InvalidArgument, "backfilled result %s has no measurement time", result.ID)
+	}
+	if err := s.checkResultAge(result, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// A scheduled result belongs to its task's experiment unless the agent
+	// says otherwise
+	if result.CorrelationID == "" && result.Origin == string(models.ResultOriginScheduled) {
+		if task, err := s.taskStore.GetTask(ctx, result.ID); err == nil {
+			result.CorrelationID = task.CorrelationID
+		}
+	}
+
+	// Locally generated measurements carry no DBOS task, so attribute them
+	// only to a known agent and a named module
+	if result.Origin == string(models.ResultOriginLocal) {
+		if err := s.validateLocalResult(ctx, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// maxBackfillClockSkew is how far in the future a backfilled result may be
+// measured, allowing for agent clocks running ahead
+const maxBackfillClockSkew = 5 * time.Minute
+
+// checkResultAge rejects results measured longer than ResultMaxAge ago,
+// which only a backfill stores, and backfilled results measured in the
+// future
+func (s *Server) checkResultAge(result *models.MeasurementResult, now time.Time) error {
+	age := now.Sub(result.Timestamp)
+	if result.Backfilled {
+		if -age > maxBackfillClockSkew {
+			return dberrors.New(dberrors.InvalidArgument, "backfilled result %s is measured in the future, at %s", result.ID, result.Timestamp.UTC().Format(time.RFC3339))
+		}
+		return nil
+	}
+	// Results without a measurement time are not told apart by age
+	if s.config.ResultMaxAge > 0 && result.Timestamp.Unix() > 0 && age > s.config.ResultMaxAge {
+		return dberrors.New(dberrors.InvalidArgument, "result %s was measured %s ago, more than %s; store late results with backfill", result.ID, age.Truncate(time.Second), s.config.ResultMaxAge)
+	}
+	return nil
+}
+
+// analyzeResult feeds a stored result to the verifications, campaigns,
+// metric sinks and analyses it concerns. Backfilled results only settle
+// their task's verification and campaign and record their target: they are
+// history, so neither execution times, metrics, trends and clock models
+// nor anomaly detection and alerting take them in. Neither do duplicates,
+// re-deliveries of a stored result such as client retries, which were
+// taken in when first stored.
+func (s *Server) analyzeResult(ctx context.Context, result *models.MeasurementResult, duplicate bool) {
+	s.recordVerificationResult(ctx, result)
+	s.recordCampaignResult(ctx, result)
+	s.recordBroadcastResult(ctx, result)
+	s.recordTargetMeasured(ctx, result)
+	if result.Backfilled || duplicate {
+		return
+	}
+	s.recordResultExecution(ctx, result)
+	s.exportMetrics(result)
+	s.recordTrends(ctx, result)
+	s.recordClockOffset(ctx, result)
+	s.analyzeDNS(ctx, result)
+	s.analyzeHTTPContent(ctx, result)
+	s.analyzeRoutingProbe(ctx, result)
+	s.recordStatusProbe(ctx, result)
+	if s.ct != nil && result.ModuleName == tlsModuleName {
+		// CT lookups are slow network calls, so they do not hold up ingest
+		go s.checkCertificates(result)
+	}
+}
+
 // GetResult retrieves a measurement result by agent ID and request ID
 func (s *Server) GetResult(ctx context.Context, req *api.GetResultRequest) (*api.GetResultResponse, error) {
-	result, err := s.resultStore.GetResult(ctx, req.AgentId, req.RequestId)
+	mask, err := newReadMask(&api.MeasurementResult{}, req.ReadMask)
 	if err != nil {
 		return &api.GetResultResponse{
-			Found: false,
-			Error: err.Error(),
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
 
+	result, err := s.getResult(ctx, req.AgentId, req.RequestId)
+	if err != nil {
+		return &api.GetResultResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiResult := resultToAPI(result)
+	mask.apply(apiResult)
+
 	return &api.GetResultResponse{
-		Found: true,
-		Result: &api.MeasurementResult{
-			Id:         result.ID,
-			AgentId:    result.AgentID,
-			ModuleName: result.ModuleName,
-			Data:       result.Data,
-			Timestamp:  result.Timestamp.Unix(),
-		},
+		Found:  true,
+		Result: apiResult,
 	}, nil
 }
 
-// ListResults retrieves all results for an agent
+const (
+	// defaultResultPageSize is the ListResults page size when none is given
+	defaultResultPageSize = 100
+	// maxResultPageSize caps the ListResults page size
+	maxResultPageSize = 1000
+)
+
+// ListResults retrieves a page of an agent's results in measurement time
+// or sequence order, optionally within a time range and for one module
 func (s *Server) ListResults(ctx context.Context, req *api.ListResultsRequest) (*api.ListResultsResponse, error) {
-	results, err := s.resultStore.ListResults(ctx, req.AgentId)
+	mask, err := newReadMask(&api.MeasurementResult{}, req.ReadMask)
+	if err != nil {
+		return &api.ListResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	order, err := parseOrderBy(req.OrderBy, resultOrderBy)
+	if err != nil {
+		return &api.ListResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultResultPageSize
+	}
+	if limit > maxResultPageSize {
+		limit = maxResultPageSize
+	}
+
+	rng := models.ResultRange{ModuleName: req.ModuleName}
+	if req.FromTimestamp > 0 {
+		rng.From = time.Unix(req.FromTimestamp, 0)
+	}
+	if req.ToTimestamp > 0 {
+		rng.To = time.Unix(req.ToTimestamp, 0)
+	}
+
+	results, nextCursor, err := s.resultStore.ListResultsPage(ctx, req.AgentId, rng, order, req.Cursor, limit)
 	if err != nil {
 		return &api.ListResultsResponse{
-			Error: err.Error(),
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
 
 	apiResults := make([]*api.MeasurementResult, len(results))
 	for i, result := range results {
-		apiResults[i] = &api.MeasurementResult{
-			Id:         result.ID,
-			AgentId:    result.AgentID,
-			ModuleName: result.ModuleName,
-			Data:       result.Data,
-			Timestamp:  result.Timestamp.Unix(),
-		}
+		apiResults[i] = resultToAPI(result)
+		mask.apply(apiResults[i])
 	}
 
 	return &api.ListResultsResponse{
-		Results: apiResults,
+		Results:    apiResults,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// GetCorrelatedResults pages through the results of every agent and module
+// sharing a correlation ID, in measurement time order
+func (s *Server) GetCorrelatedResults(ctx context.Context, req *api.GetCorrelatedResultsRequest) (*api.GetCorrelatedResultsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultResultPageSize
+	}
+	if limit > maxResultPageSize {
+		limit = maxResultPageSize
+	}
+
+	results, nextCursor, err := s.resultStore.ListCorrelatedResults(ctx, req.CorrelationId, req.Cursor, limit)
+	if err != nil {
+		return &api.GetCorrelatedResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiResults := make([]*api.MeasurementResult, len(results))
+	for i, result := range results {
+		apiResults[i] = resultToAPI(result)
+	}
+	return &api.GetCorrelatedResultsResponse{
+		Results:    apiResults,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// CountResults counts an agent's results, optionally within a time range
+// and for one module
+func (s *Server) CountResults(ctx context.Context, req *api.CountResultsRequest) (*api.CountResultsResponse, error) {
+	rng := models.ResultRange{ModuleName: req.ModuleName}
+	if req.FromTimestamp > 0 {
+		rng.From = time.Unix(req.FromTimestamp, 0)
+	}
+	if req.ToTimestamp > 0 {
+		rng.To = time.Unix(req.ToTimestamp, 0)
+	}
+
+	count, err := s.resultStore.CountResults(ctx, req.AgentId, rng)
+	if err != nil {
+		return &api.CountResultsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.CountResultsResponse{
+		Count: count,
+	}, nil
+}
+
+// HasResult reports whether a result is stored
+func (s *Server) HasResult(ctx context.Context, req *api.HasResultRequest) (*api.HasResultResponse, error) {
+	found, err := s.resultStore.HasResult(ctx, req.AgentId, req.RequestId)
+	if err != nil {
+		return &api.HasResultResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.HasResultResponse{
+		Found: found,
+	}, nil
+}
+
+// GetIngestGaps reports sequence ranges for which an agent has no stored result
+func (s *Server) GetIngestGaps(ctx context.Context, req *api.GetIngestGapsRequest) (*api.GetIngestGapsResponse, error) {
+	gaps, last, err := s.resultStore.GetIngestGaps(ctx, req.AgentId, req.FromSequence, req.ToSequence)
+	if err != nil {
+		return &api.GetIngestGapsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiGaps := make([]*api.SequenceGap, len(gaps))
+	for i, gap := range gaps {
+		apiGaps[i] = &api.SequenceGap{
+			FromSequence: gap.From,
+			ToSequence:   gap.To,
+		}
+	}
+
+	return &api.GetIngestGapsResponse{
+		Gaps:         apiGaps,
+		LastSequence: last,
 	}, nil
 }
 
+// validateRequestID checks the ID of the request a result or module state
+// answers: a local request's ID is minted by the client, while a scheduled
+// one's is its task's, which may be derived from client IDs
+func validateRequestID(field, id string) error {
+	check := ids.ValidateDerived
+	if models.IsLocalTaskID(id) {
+		check = ids.Validate
+	}
+	if err := check(id); err != nil {
+		return dberrors.New(dberrors.InvalidArgument, "%s: %v", field, err)
+	}
+	return nil
+}
+
+// validateLocalResult checks that a locally generated result can be attributed
+func (s *Server) validateLocalResult(ctx context.Context, result *models.MeasurementResult) error {
+	if result.ModuleName == "" {
+		return dberrors.New(dberrors.InvalidArgument, "local result %s must name the module that produced it", result.ID)
+	}
+	if _, err := s.agentStore.GetAgent(ctx, result.AgentID); err != nil {
+		return dberrors.New(dberrors.FailedPrecondition, "local result %s from unknown agent %s", result.ID, result.AgentID)
+	}
+	return nil
+}
+
 // ScheduleTask schedules a task
 func (s *Server) ScheduleTask(ctx context.Context, req *api.ScheduleTaskRequest) (*api.ScheduleTaskResponse, error) {
-	task := &models.Task{
-		ID:          req.Task.Id,
-		AgentID:     req.Task.AgentId,
-		ModuleName:  req.Task.ModuleName,
-		Payload:     req.Task.Payload,
-		ScheduledAt: time.Unix(req.Task.ScheduledAt, 0),
-		CreatedAt:   time.Unix(req.Task.CreatedAt, 0),
-		Status:      req.Task.Status,
+	task := taskFromAPI(req.Task)
+	if task.ID == "" {
+		task.ID = ids.New("task-")
+	}
+	if task.Type == "" {
+		task.Type = string(models.TaskTypeOneShot)
+	}
+	if task.IsContinuous() && task.IntervalSeconds <= 0 {
+		return &api.ScheduleTaskResponse{
+			Success:   false,
+			Error:     "continuous tasks require a positive interval_seconds",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+	if task.IsGroup() && task.AgentID != "" {
+		return &api.ScheduleTaskResponse{
+			Success:   false,
+			Error:     "a task targets either an agent_id or a selector, not both",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+	if task.Placement != nil {
+		if err := validatePlacement(task); err != nil {
+			return &api.ScheduleTaskResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+	}
+	if task.RetryPolicy != nil {
+		if err := task.RetryPolicy.Validate(); err != nil {
+			return &api.ScheduleTaskResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: string(dberrors.InvalidArgument),
+			}, nil
+		}
+	}
+
+	// A retried call finds the key the first one claimed and schedules
+	// nothing; the key is released if the task cannot be scheduled
+	dedupKey := taskDedupKey(req.DedupKey, req.Task.Id)
+	if dedupKey != "" {
+		existingID, claimed, err := s.taskStore.ClaimDedupKey(ctx, dedupKey, task.ID, s.config.TaskDedupWindow)
+		if err != nil {
+			return &api.ScheduleTaskResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+		if !claimed {
+			return &api.ScheduleTaskResponse{
+				Success:       true,
+				TaskId:        existingID,
+				AlreadyExists: true,
+			}, nil
+		}
+	}
+	// A task given its ID may have been scheduled before the ID's claim, or
+	// after it expired
+	if req.Task.Id != "" {
+		if _, err := s.taskStore.GetTask(ctx, task.ID); err == nil {
+			return &api.ScheduleTaskResponse{
+				Success:       true,
+				TaskId:        task.ID,
+				AlreadyExists: true,
+			}, nil
+		} else if !redis.IsNotFound(err) {
+			s.releaseTaskDedupKey(ctx, dedupKey, task.ID)
+			return &api.ScheduleTaskResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
 	}
 
 	err := s.taskStore.ScheduleTask(ctx, task)
 	if err != nil {
+		s.releaseTaskDedupKey(ctx, dedupKey, task.ID)
 		return &api.ScheduleTaskResponse{
-			Success: false,
-			Error:   err.Error(),
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
+	s.recordTaskEvent(ctx, models.EventTaskScheduled, task)
 
 	return &api.ScheduleTaskResponse{
 		Success: true,
+		TaskId:  task.ID,
 	}, nil
 }
 
-// GetTask retrieves a task by ID
+// GetTask retrieves a task by ID, with the progress its agent last
+// reported
 func (s *Server) GetTask(ctx context.Context, req *api.GetTaskRequest) (*api.GetTaskResponse, error) {
 	task, err := s.taskStore.GetTask(ctx, req.TaskId)
 	if err != nil {
 		return &api.GetTaskResponse{
-			Found: false,
-			Error: err.Error(),
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
 
-	return &api.GetTaskResponse{
+	resp := &api.GetTaskResponse{
 		Found: true,
-		Task: &api.Task{
-			Id:          task.ID,
-			AgentId:     task.AgentID,
-			ModuleName:  task.ModuleName,
-			Payload:     task.Payload,
-			ScheduledAt: task.ScheduledAt.Unix(),
-			CreatedAt:   task.CreatedAt.Unix(),
-			Status:      task.Status,
-		},
+		Task:  taskToAPI(task),
+	}
+	progress, err := s.taskProgressStore.GetProgress(ctx, task.ID)
+	if err == nil {
+		resp.Progress = taskProgressToAPI(progress)
+	} else if !redis.IsNotFound(err) {
+		return &api.GetTaskResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	return resp, nil
+}
+
+const (
+	// defaultTaskPageSize is the ListTasks page size when none is given
+	defaultTaskPageSize = 100
+	// maxTaskPageSize caps the ListTasks page size
+	maxTaskPageSize = 1000
+)
+
+// ListTasks retrieves a page of the tasks of an agent, or of all agents,
+// in scheduled or creation time order, optionally only those of a module
+// or status or in a range of either time
+func (s *Server) ListTasks(ctx context.Context, req *api.ListTasksRequest) (*api.ListTasksResponse, error) {
+	order, err := parseOrderBy(req.OrderBy, taskOrderBy)
+	if err != nil {
+		return &api.ListTasksResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	filter, err := taskFilterFromAPI(req)
+	if err != nil {
+		return &api.ListTasksResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultTaskPageSize
+	}
+	if pageSize > maxTaskPageSize {
+		pageSize = maxTaskPageSize
+	}
+
+	tasks, nextCursor, err := s.taskStore.ListTasksPage(ctx, filter, order, req.Cursor, pageSize)
+	if err != nil {
+		return &api.ListTasksResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiTasks := make([]*api.Task, len(tasks))
+	for i, task := range tasks {
+		apiTasks[i] = taskToAPI(task)
+	}
+
+	return &api.ListTasksResponse{
+		Tasks:      apiTasks,
+		NextCursor: nextCursor,
 	}, nil
 }
 
-// ListDueTasks retrieves all due tasks
+// taskFilterFromAPI converts the filters of a ListTasks request into a task
+// filter, rejecting an unknown status or an empty time range
+func taskFilterFromAPI(req *api.ListTasksRequest) (models.TaskFilter, error) {
+	if req.Status != "" && !models.IsTaskStatus(req.Status) {
+		return models.TaskFilter{}, dberrors.New(dberrors.InvalidArgument, "unknown task status %q", req.Status)
+	}
+	if req.CreatedSince > 0 && req.CreatedUntil > 0 && req.CreatedSince >= req.CreatedUntil {
+		return models.TaskFilter{}, dberrors.New(dberrors.InvalidArgument, "created_since must be before created_until")
+	}
+	if req.ScheduledSince > 0 && req.ScheduledUntil > 0 && req.ScheduledSince >= req.ScheduledUntil {
+		return models.TaskFilter{}, dberrors.New(dberrors.InvalidArgument, "scheduled_since must be before scheduled_until")
+	}
+
+	filter := models.TaskFilter{
+		AgentID:    req.AgentId,
+		ModuleName: req.ModuleName,
+		Status:     req.Status,
+	}
+	if req.CreatedSince > 0 {
+		filter.CreatedSince = time.Unix(req.CreatedSince, 0)
+	}
+	if req.CreatedUntil > 0 {
+		filter.CreatedUntil = time.Unix(req.CreatedUntil, 0)
+	}
+	if req.ScheduledSince > 0 {
+		filter.ScheduledSince = time.Unix(req.ScheduledSince, 0)
+	}
+	if req.ScheduledUntil > 0 {
+		filter.ScheduledUntil = time.Unix(req.ScheduledUntil, 0)
+	}
+	return filter, nil
+}
+
+// ListDueTasks retrieves the tasks due at a time, of one agent or of all
+// agents taking turns
 func (s *Server) ListDueTasks(ctx context.Context, req *api.ListDueTasksRequest) (*api.ListDueTasksResponse, error) {
-	tasks, err := s.taskStore.ListDueTasks(ctx, time.Unix(req.Timestamp, 0))
+	tasks, err := s.taskStore.ListDueTasks(ctx, req.AgentId, time.Unix(req.Timestamp, 0), int(req.Limit))
 	if err != nil {
 		return &api.ListDueTasksResponse{
-			Error: err.Error(),
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
 		}, nil
 	}
 
 	apiTasks := make([]*api.Task, len(tasks))
 	for i, task := range tasks {
-		apiTasks[i] = &api.Task{
-			Id:          task.ID,
-			AgentId:     task.AgentID,
-			ModuleName:  task.ModuleName,
-			Payload:     task.Payload,
-			ScheduledAt: task.ScheduledAt.Unix(),
-			CreatedAt:   task.CreatedAt.Unix(),
-			Status:      task.Status,
-		}
+		apiTasks[i] = taskToAPI(task)
 	}
 
 	return &api.ListDueTasksResponse{
 		Tasks: apiTasks,
 	}, nil
 }
+
+// ListPendingTasks retrieves the tasks delivered to an agent and not yet
+// settled, as tracked by the streams task queue
+func (s *Server) ListPendingTasks(ctx context.Context, req *api.ListPendingTasksRequest) (*api.ListPendingTasksResponse, error) {
+	tasks, err := s.taskStore.ListPendingTasks(ctx, req.AgentId)
+	if err != nil {
+		return &api.ListPendingTasksResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiTasks := make([]*api.PendingTask, len(tasks))
+	for i, task := range tasks {
+		apiTasks[i] = &api.PendingTask{
+			TaskId:     task.TaskID,
+			EntryId:    task.EntryID,
+			Consumer:   task.Consumer,
+			IdleMs:     task.Idle.Milliseconds(),
+			Deliveries: task.Deliveries,
+		}
+	}
+
+	return &api.ListPendingTasksResponse{
+		Tasks: apiTasks,
+	}, nil
+}
+
+// CancelTask cancels a task; continuous tasks stop being re-issued
+func (s *Server) CancelTask(ctx context.Context, req *api.CancelTaskRequest) (*api.CancelTaskResponse, error) {
+	err := s.taskStore.CancelTask(ctx, req.TaskId)
+	if err != nil {
+		return &api.CancelTaskResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	s.recordTaskCancelled(ctx, req.TaskId)
+
+	return &api.CancelTaskResponse{
+		Success: true,
+	}, nil
+}
+
+// agentToAPI converts a model agent into an API agent
+func agentToAPI(agent *models.Agent) *api.Agent {
+	return &api.Agent{
+		Id:              agent.ID,
+		Hostname:        agent.Hostname,
+		Alive:           agent.Alive,
+		LastSeen:        agent.LastSeen.Unix(),
+		FirstSeen:       agent.FirstSeen.Unix(),
+		Config:          agent.Config,
+		TotalHeartbeats: agent.TotalHeartbeats,
+		Labels:          agent.Labels,
+	}
+}
+
+// resultToAPI converts a model result into an API result
+func resultToAPI(result *models.MeasurementResult) *api.MeasurementResult {
+	return &api.MeasurementResult{
+		Id:            result.ID,
+		AgentId:       result.AgentID,
+		ModuleName:    result.ModuleName,
+		Data:          result.Data,
+		Timestamp:     result.Timestamp.Unix(),
+		Origin:        result.Origin,
+		Sequence:      result.Sequence,
+		ClockOffsetMs: result.ClockOffsetMs,
+		CorrelationId: result.CorrelationID,
+		Backfilled:    result.Backfilled,
+	}
+}
+
+// taskFromAPI converts an API task into a model task
+func taskFromAPI(t *api.Task) *models.Task {
+	return &models.Task{
+		ID:                  t.Id,
+		AgentID:             t.AgentId,
+		ModuleName:          t.ModuleName,
+		Payload:             t.Payload,
+		ScheduledAt:         time.Unix(t.ScheduledAt, 0),
+		CreatedAt:           time.Unix(t.CreatedAt, 0),
+		Status:              t.Status,
+		Type:                t.Type,
+		IntervalSeconds:     t.IntervalSeconds,
+		ParentID:            t.ParentId,
+		VerificationID:      t.VerificationId,
+		CampaignID:          t.CampaignId,
+		Selector:            t.Selector,
+		AgentIDs:            t.AgentIds,
+		CorrelationID:       t.CorrelationId,
+		Placement:           placementFromAPI(t.Placement),
+		LeaseTimeoutSeconds: t.LeaseTimeoutSeconds,
+		RetryPolicy:         retryPolicyFromAPI(t.RetryPolicy),
+		FailoverSelector:    t.FailoverSelector,
+	}
+}
+
+// taskToAPI converts a model task into an API task
+func taskToAPI(task *models.Task) *api.Task {
+	var leasedAt, leaseExpiresAt int64
+	if t := models.TimeOf(task.LeasedAt); !t.IsZero() {
+		leasedAt = t.Unix()
+	}
+	if t := models.TimeOf(task.LeaseExpiresAt); !t.IsZero() && task.Status == string(models.TaskStatusRunning) {
+		leaseExpiresAt = t.Unix()
+	}
+	return &api.Task{
+		Id:                  task.ID,
+		AgentId:             task.AgentID,
+		ModuleName:          task.ModuleName,
+		Payload:             task.Payload,
+		ScheduledAt:         task.ScheduledAt.Unix(),
+		CreatedAt:           task.CreatedAt.Unix(),
+		Status:              task.Status,
+		Type:                task.Type,
+		IntervalSeconds:     task.IntervalSeconds,
+		ParentId:            task.ParentID,
+		VerificationId:      task.VerificationID,
+		CampaignId:          task.CampaignID,
+		Selector:            task.Selector,
+		AgentIds:            task.AgentIDs,
+		Deliveries:          task.Deliveries,
+		CorrelationId:       task.CorrelationID,
+		Placement:           placementToAPI(task.Placement),
+		LeasedAt:            leasedAt,
+		DurationMs:          task.DurationMs,
+		LeaseTimeoutSeconds: task.LeaseTimeoutSeconds,
+		LeaseExpiresAt:      leaseExpiresAt,
+		RetryPolicy:         retryPolicyToAPI(task.RetryPolicy),
+		RetryCount:          task.RetryCount,
+		BroadcastId:         task.BroadcastID,
+		FailoverSelector:    task.FailoverSelector,
+	}
+}
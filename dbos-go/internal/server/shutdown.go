@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"google.golang.org/grpc"
+)
+
+// metricSinkFlushTimeout bounds the final push of a metric sink on shutdown
+const metricSinkFlushTimeout = 10 * time.Second
+
+// subscriptionStreams are the streaming RPCs that run until the client
+// leaves; they end as soon as the server starts draining, so clients
+// reconnect elsewhere rather than hold up the shutdown
+var subscriptionStreams = map[string]bool{
+	api.DBOS_StreamTasks_FullMethodName:       true,
+	api.DBOS_WatchAgents_FullMethodName:       true,
+	api.DBOS_WatchIncidents_FullMethodName:    true,
+	api.DBOS_WatchModuleStates_FullMethodName: true,
+}
+
+// workerGroup runs background workers until they are stopped
+type workerGroup struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newWorkerGroup creates an empty worker group
+func newWorkerGroup() *workerGroup {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &workerGroup{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Go runs a worker with a context cancelled when the group is stopped
+func (g *workerGroup) Go(run func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		run(g.ctx)
+	}()
+}
+
+// Stop cancels the workers and waits for them to return
+func (g *workerGroup) Stop() {
+	g.cancel()
+	g.wg.Wait()
+}
+
+// shutdown drains the gRPC server once Run's context is done: it stops
+// accepting connections, ends subscription streams, and waits up to the
+// shutdown timeout for the RPCs in flight before cancelling them. The
+// background workers are then stopped, which flushes the metric sinks, and
+// the Redis connections are closed.
+func (s *Server) shutdown(grpcServer *grpc.Server, workers *workerGroup) {
+	log.Printf("Shutting down: draining RPCs for up to %s", s.config.ShutdownTimeout)
+	close(s.draining)
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(s.config.ShutdownTimeout):
+		log.Printf("Shutdown timeout reached, cancelling the RPCs still in flight")
+		grpcServer.Stop()
+		<-stopped
+	}
+
+	workers.Stop()
+	if err := s.backend.Close(); err != nil {
+		log.Printf("Closing the storage backend: %v", err)
+	}
+	log.Printf("Shutdown complete")
+}
+
+// drainStreamInterceptor ends subscription streams when the server starts
+// draining by cancelling their context
+func (s *Server) drainStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !subscriptionStreams[info.FullMethod] {
+		return handler(srv, ss)
+	}
+
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.draining:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return handler(srv, &drainingStream{ServerStream: ss, ctx: ctx})
+}
+
+// drainingStream is a server stream whose context is cancelled on draining
+type drainingStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *drainingStream) Context() context.Context {
+	return s.ctx
+}
+
+// serveHTTP runs serve for an HTTP endpoint until ctx is done, then shuts
+// the endpoint down, waiting up to the shutdown timeout for the requests in
+// flight
+func (s *Server) serveHTTP(ctx context.Context, httpServer *http.Server, serve func() error) error {
+	shutDown := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		defer close(shutDown)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	})
+
+	err := serve()
+	if stop() {
+		// The endpoint failed before ctx was done
+		return err
+	}
+	<-shutDown
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
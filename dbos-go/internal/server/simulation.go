@@ -0,0 +1,260 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/agent/ping"
+	"github.com/internet-measurement-network/dbos/internal/agent/traceroute"
+	"github.com/internet-measurement-network/dbos/internal/fixtures"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+const (
+	// simulatedHeartbeatInterval is how often simulated agents heartbeat,
+	// well within the liveness window
+	simulatedHeartbeatInterval = 10 * time.Second
+
+	// simulatedTaskPollInterval is how often simulated agents lease due tasks
+	simulatedTaskPollInterval = time.Second
+
+	// simulatedPingCount is how many probes a simulated ping sends
+	simulatedPingCount = 5
+)
+
+// simulatedDNSQueries are what simulated agents resolve on their own every
+// interval, besides pinging fixtures.Targets
+var simulatedDNSQueries = []string{"www.example.com", "example.net"}
+
+// simulatedAgent is an in-process agent producing plausible measurements
+type simulatedAgent struct {
+	id       string
+	hostname string
+	region   string
+	delay    float64
+	lat, lon float64
+	gen      *fixtures.Generator
+}
+
+// newSimulatedAgent creates the i-th simulated agent
+func newSimulatedAgent(i int) *simulatedAgent {
+	region := fixtures.RegionOf(i)
+	id := fmt.Sprintf("sim-%03d", i+1)
+	return &simulatedAgent{
+		id:       id,
+		hostname: id + ".simulated",
+		region:   region.Name,
+		delay:    region.Delay,
+		lat:      region.Lat,
+		lon:      region.Lon,
+		gen:      fixtures.New(uint64(time.Now().UnixNano()) + uint64(i)),
+	}
+}
+
+// runSimulatedAgents runs count simulated agents that heartbeat, measure
+// ping and DNS every interval and answer their ping and DNS tasks, so the
+// server can be demonstrated and integrated against without real probes. It
+// returns once ctx is done and all of them stopped.
+func (s *Server) runSimulatedAgents(ctx context.Context, count int, interval time.Duration) {
+	log.Printf("Simulating %d agents measuring every %s", count, interval)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runSimulatedAgent(ctx, newSimulatedAgent(i), interval)
+		}()
+	}
+	wg.Wait()
+}
+
+// runSimulatedAgent runs one simulated agent until ctx is done. Agents start
+// measuring at staggered offsets so their results do not arrive in bursts.
+func (s *Server) runSimulatedAgent(ctx context.Context, agent *simulatedAgent, interval time.Duration) {
+	if err := s.registerSimulatedAgent(ctx, agent); err != nil {
+		log.Printf("Simulated agent %s: registering: %v", agent.id, err)
+		return
+	}
+
+	heartbeats := time.NewTicker(simulatedHeartbeatInterval)
+	defer heartbeats.Stop()
+	tasks := time.NewTicker(simulatedTaskPollInterval)
+	defer tasks.Stop()
+
+	measure := time.NewTimer(agent.gen.Duration(interval))
+	defer measure.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-heartbeats.C:
+			if _, err := s.agentStore.RecordHeartbeat(ctx, agent.id, agent.hostname, now); err != nil {
+				log.Printf("Simulated agent %s: heartbeat: %v", agent.id, err)
+			}
+		case <-tasks.C:
+			s.runSimulatedTasks(ctx, agent)
+		case now := <-measure.C:
+			s.storeSimulatedMeasurements(ctx, agent, now)
+			measure.Reset(interval)
+		}
+	}
+}
+
+// registerSimulatedAgent registers a simulated agent as alive, labelled with
+// its region, its region's location and as simulated
+func (s *Server) registerSimulatedAgent(ctx context.Context, agent *simulatedAgent) error {
+	now := time.Now()
+	registered, err := s.agentStore.GetAgent(ctx, agent.id)
+	if err != nil {
+		registered = models.NewAgent(agent.id, agent.hostname)
+		registered.FirstSeen = now
+	}
+	if registered.Labels == nil {
+		registered.Labels = make(map[string]string)
+	}
+	registered.Labels["region"] = agent.region
+	registered.Labels[models.AgentLatitudeLabel] = strconv.FormatFloat(agent.lat, 'f', -1, 64)
+	registered.Labels[models.AgentLongitudeLabel] = strconv.FormatFloat(agent.lon, 'f', -1, 64)
+	registered.Labels["kind"] = "simulated"
+	registered.Alive = true
+	registered.LastSeen = now
+	registered.TotalHeartbeats++
+	return s.agentStore.RegisterAgent(ctx, registered)
+}
+
+// storeSimulatedMeasurements stores a ping result for every simulated ping
+// target and a DNS result for every simulated query as local results
+func (s *Server) storeSimulatedMeasurements(ctx context.Context, agent *simulatedAgent, now time.Time) {
+	for _, host := range fixtures.Targets {
+		name := fmt.Sprintf("sim-ping-%s-%d", host, now.UnixNano())
+		s.storeSimulatedResult(ctx, agent, models.LocalTaskIDPrefix+name, pingModuleName, now, agent.gen.Ping(host, simulatedPingCount, agent.delay))
+	}
+	for _, query := range simulatedDNSQueries {
+		name := fmt.Sprintf("sim-dns-%s-%d", query, now.UnixNano())
+		s.storeSimulatedResult(ctx, agent, models.LocalTaskIDPrefix+name, dnsModuleName, now, agent.gen.DNS(query, "A", agent.delay))
+	}
+}
+
+// runSimulatedTasks answers the due ping, traceroute and DNS tasks of a
+// simulated agent with simulated results; tasks of other modules fail
+func (s *Server) runSimulatedTasks(ctx context.Context, agent *simulatedAgent) {
+	for {
+		task, err := s.leaseTask(ctx, agent.id)
+		if err != nil {
+			log.Printf("Simulated agent %s: leasing task: %v", agent.id, err)
+			return
+		}
+		if task == nil {
+			return
+		}
+
+		s.setSimulatedModuleState(ctx, agent, task, models.ModuleStateRunning, nil)
+		data, err := agent.run(task)
+		if err != nil {
+			s.setSimulatedModuleState(ctx, agent, task, models.ModuleStateError, err)
+			continue
+		}
+		if !s.storeSimulatedResult(ctx, agent, task.ID, task.ModuleName, time.Now(), data) {
+			s.setSimulatedModuleState(ctx, agent, task, models.ModuleStateError, dberrors.New(dberrors.Internal, "storing result failed"))
+			continue
+		}
+		s.setSimulatedModuleState(ctx, agent, task, models.ModuleStateCompleted, nil)
+	}
+}
+
+// storeSimulatedResult stores simulated result data through StoreResult, so
+// it is analyzed like any agent's, reporting whether it was stored
+func (s *Server) storeSimulatedResult(ctx context.Context, agent *simulatedAgent, id, moduleName string, ts time.Time, data interface{}) bool {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Simulated agent %s: encoding result %s: %v", agent.id, id, err)
+		return false
+	}
+
+	resp, _ := s.StoreResult(ctx, &api.StoreResultRequest{
+		Result: &api.MeasurementResult{
+			Id:               id,
+			AgentId:          agent.id,
+			ModuleName:       moduleName,
+			Data:             payload,
+			Timestamp:        ts.Unix(),
+			AgentTimestampMs: ts.UnixMilli(),
+		},
+	})
+	if !resp.Success {
+		log.Printf("Simulated agent %s: storing result %s: %s", agent.id, id, resp.Error)
+		return false
+	}
+	return true
+}
+
+// setSimulatedModuleState records the module state of a simulated agent's
+// task and the error it failed with, if any
+func (s *Server) setSimulatedModuleState(ctx context.Context, agent *simulatedAgent, task *models.Task, state models.ModuleStateEnum, taskErr error) {
+	apiState := &api.ModuleState{
+		AgentId:    agent.id,
+		ModuleName: task.ModuleName,
+		State:      string(state),
+		Timestamp:  time.Now().Unix(),
+		RequestId:  task.ID,
+	}
+	if taskErr != nil {
+		apiState.ErrorMessage = taskErr.Error()
+		apiState.ErrorCode = errorCode(taskErr)
+	}
+	resp, _ := s.SetModuleState(ctx, &api.SetModuleStateRequest{
+		State: apiState,
+	})
+	if !resp.Success {
+		log.Printf("Simulated agent %s: reporting %s state of task %s: %s", agent.id, state, task.ID, resp.Error)
+	}
+}
+
+// run simulates the module a task names on its payload
+func (a *simulatedAgent) run(task *models.Task) (interface{}, error) {
+	switch task.ModuleName {
+	case pingModuleName:
+		var query ping.Query
+		if err := json.Unmarshal(task.Payload, &query); err != nil {
+			return nil, fmt.Errorf("invalid ping query: %w", err)
+		}
+		if query.Host == "" {
+			return nil, fmt.Errorf("host is required")
+		}
+		count := query.Count
+		if count <= 0 {
+			count = simulatedPingCount
+		}
+		return a.gen.Ping(query.Host, min(count, 100), a.delay), nil
+	case traceroute.ModuleName:
+		var query traceroute.Query
+		if err := json.Unmarshal(task.Payload, &query); err != nil {
+			return nil, fmt.Errorf("invalid traceroute query: %w", err)
+		}
+		if query.Host == "" {
+			return nil, fmt.Errorf("host is required")
+		}
+		return a.gen.Traceroute(query.Host, a.delay), nil
+	case dnsModuleName:
+		var query struct {
+			Query string `json:"query"`
+			Type  string `json:"type"`
+		}
+		if err := json.Unmarshal(task.Payload, &query); err != nil {
+			return nil, fmt.Errorf("invalid DNS query: %w", err)
+		}
+		if query.Query == "" {
+			return nil, fmt.Errorf("query is required")
+		}
+		return a.gen.DNS(query.Query, query.Type, a.delay), nil
+	}
+	return nil, fmt.Errorf("unknown module %s", task.ModuleName)
+}
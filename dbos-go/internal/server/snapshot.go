@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// snapshotResultWindow is how far back a snapshot export's results go by
+// default
+const snapshotResultWindow = 24 * time.Hour
+
+// ExportSnapshot streams a consistent point-in-time snapshot of agents,
+// tasks and recent results. A marker captures the end of the state event log
+// and each agent's last result sequence at once; agents and tasks are
+// exported as of their last event at the marker, and results stored since
+// are left out, so ingest can continue during the export.
+func (s *Server) ExportSnapshot(req *api.ExportSnapshotRequest, stream api.DBOS_ExportSnapshotServer) error {
+	if s.stateEvents == nil {
+		return status.Error(codes.FailedPrecondition, "snapshot exports need event sourcing")
+	}
+	if err := s.exportSnapshot(stream.Context(), req, stream.Send); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Unavailable, "exporting snapshot: %v", err)
+	}
+	return nil
+}
+
+// exportSnapshot captures a snapshot marker and sends the snapshot it marks.
+// An agent or task without history in the state event log, such as one
+// stored before event sourcing was enabled, is exported as stored if it was
+// created before the marker.
+func (s *Server) exportSnapshot(ctx context.Context, req *api.ExportSnapshotRequest, send func(*api.SnapshotRecord) error) error {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return err
+	}
+	agentIDs := make([]string, len(agents))
+	for i, agent := range agents {
+		agentIDs[i] = agent.ID
+	}
+	marker, err := s.stateEvents.Marker(ctx, agentIDs)
+	if err != nil {
+		return err
+	}
+	if err := send(&api.SnapshotRecord{Marker: snapshotMarkerToAPI(marker)}); err != nil {
+		return err
+	}
+
+	// Agents listed before the marker include those deleted since, and
+	// agents listed after it those registered just before it
+	agents, err = s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return err
+	}
+	stored := make(map[string]*models.Agent, len(agents))
+	for _, agent := range agents {
+		if _, ok := marker.ResultSequences[agent.ID]; !ok {
+			agentIDs = append(agentIDs, agent.ID)
+		}
+		stored[agent.ID] = agent
+	}
+	sort.Strings(agentIDs)
+	events, histories, err := s.stateEvents.StatesAt(ctx, string(models.StateEntityAgent), agentIDs, marker.StateEventID)
+	if err != nil {
+		return err
+	}
+	for i, agentID := range agentIDs {
+		agent := stored[agentID]
+		switch {
+		case events[i] != nil:
+			agent = events[i].Agent
+		case histories[i] || agent == nil || !agent.FirstSeen.Before(marker.TakenAt):
+			agent = nil
+		}
+		if agent == nil {
+			continue
+		}
+		if err := send(&api.SnapshotRecord{Agent: agentToAPI(agent)}); err != nil {
+			return err
+		}
+	}
+
+	cursor := ""
+	for {
+		tasks, next, err := s.taskStore.ListTasksPage(ctx, models.TaskFilter{}, models.Order{Field: models.TaskOrderCreatedAt}, cursor, exportPageSize)
+		if err != nil {
+			return err
+		}
+		taskIDs := make([]string, len(tasks))
+		for i, task := range tasks {
+			taskIDs[i] = task.ID
+		}
+		events, histories, err := s.stateEvents.StatesAt(ctx, string(models.StateEntityTask), taskIDs, marker.StateEventID)
+		if err != nil {
+			return err
+		}
+		for i, task := range tasks {
+			switch {
+			case events[i] != nil:
+				task = events[i].Task
+			case histories[i] || !task.CreatedAt.Before(marker.TakenAt):
+				task = nil
+			}
+			if task == nil {
+				continue
+			}
+			if err := send(&api.SnapshotRecord{Task: taskToAPI(task)}); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	since := time.Now().Add(-snapshotResultWindow)
+	if req.ResultsSince > 0 {
+		since = time.Unix(req.ResultsSince, 0)
+	}
+	for _, agentID := range agentIDs {
+		mark := marker.ResultSequences[agentID]
+		var after int64
+		for after < mark {
+			results, err := s.resultStore.ListResultsAfter(ctx, agentID, after, exportPageSize)
+			if err != nil {
+				return err
+			}
+			if len(results) == 0 {
+				break
+			}
+			for _, result := range results {
+				after = result.Sequence
+				if result.Sequence > mark {
+					break
+				}
+				if result.Timestamp.Before(since) || (req.ModuleName != "" && result.ModuleName != req.ModuleName) {
+					continue
+				}
+				if err := send(&api.SnapshotRecord{Result: resultToAPI(result)}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// snapshotMarkerToAPI converts a model snapshot marker into an API one
+func snapshotMarkerToAPI(m *models.SnapshotMarker) *api.SnapshotMarker {
+	return &api.SnapshotMarker{
+		TakenAt:         m.TakenAt.Unix(),
+		StateEventId:    m.StateEventID,
+		ResultSequences: m.ResultSequences,
+	}
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// Page sizes of ListStateEvents
+const (
+	defaultStateEventPageSize = 100
+	maxStateEventPageSize     = 1000
+)
+
+// errEventSourcingDisabled is reported by the event sourcing RPCs when the
+// server does not record state events
+const errEventSourcingDisabled = "event sourcing is not enabled"
+
+// ListStateEvents retrieves a page of the state event log oldest first,
+// optionally only the history of one agent or task
+func (s *Server) ListStateEvents(ctx context.Context, req *api.ListStateEventsRequest) (*api.ListStateEventsResponse, error) {
+	if s.stateEvents == nil {
+		return &api.ListStateEventsResponse{
+			Error:     errEventSourcingDisabled,
+			ErrorCode: string(dberrors.FailedPrecondition),
+		}, nil
+	}
+	if (req.EntityType == "") != (req.EntityId == "") {
+		return &api.ListStateEventsResponse{
+			Error:     "entity_type and entity_id must be set together",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+	switch models.StateEntityEnum(req.EntityType) {
+	case "", models.StateEntityAgent, models.StateEntityTask:
+	default:
+		return &api.ListStateEventsResponse{
+			Error:     "unknown entity_type " + req.EntityType,
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultStateEventPageSize
+	}
+	if limit > maxStateEventPageSize {
+		limit = maxStateEventPageSize
+	}
+
+	events, nextCursor, err := s.stateEvents.ListEvents(ctx, req.EntityType, req.EntityId, req.Cursor, limit)
+	if err != nil {
+		return &api.ListStateEventsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiEvents := make([]*api.StateEvent, len(events))
+	for i, event := range events {
+		apiEvents[i] = stateEventToAPI(event)
+	}
+	return &api.ListStateEventsResponse{
+		Events:     apiEvents,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// RebuildState replays the state event log to rebuild agents and tasks,
+// reporting those whose stored state differed from the log
+func (s *Server) RebuildState(ctx context.Context, req *api.RebuildStateRequest) (*api.RebuildStateResponse, error) {
+	if s.stateEvents == nil {
+		return &api.RebuildStateResponse{
+			Success:   false,
+			Error:     errEventSourcingDisabled,
+			ErrorCode: string(dberrors.FailedPrecondition),
+		}, nil
+	}
+
+	rebuild, err := s.stateEvents.Rebuild(ctx, req.DryRun)
+	if err != nil {
+		return &api.RebuildStateResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	if !req.DryRun {
+		log.Printf("State rebuilt from %d events: %d agents (%d diverged), %d tasks (%d diverged)", rebuild.EventsReplayed,
+			rebuild.AgentsRebuilt, len(rebuild.DivergedAgentIDs), rebuild.TasksRebuilt, len(rebuild.DivergedTaskIDs))
+	}
+	return &api.RebuildStateResponse{
+		Success:          true,
+		EventsReplayed:   rebuild.EventsReplayed,
+		AgentsRebuilt:    int32(rebuild.AgentsRebuilt),
+		TasksRebuilt:     int32(rebuild.TasksRebuilt),
+		DivergedAgentIds: rebuild.DivergedAgentIDs,
+		DivergedTaskIds:  rebuild.DivergedTaskIDs,
+	}, nil
+}
+
+// stateEventToAPI converts a model state event into an API one
+func stateEventToAPI(e *models.StateEvent) *api.StateEvent {
+	event := &api.StateEvent{
+		Id:         e.ID,
+		Type:       e.Type,
+		EntityType: e.EntityType,
+		EntityId:   e.EntityID,
+		Timestamp:  e.Timestamp.Unix(),
+	}
+	if e.Agent != nil {
+		event.Agent = agentToAPI(e.Agent)
+	}
+	if e.Task != nil {
+		event.Task = taskToAPI(e.Task)
+	}
+	if !e.NextRunAt.IsZero() {
+		event.NextRunAt = e.NextRunAt.Unix()
+	}
+	return event
+}
@@ -0,0 +1,300 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/internal/store"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+)
+
+const (
+	// statusCacheTTL is how long a generated status page is served, bounding
+	// the load clients can put on Redis regardless of their number
+	statusCacheTTL = 10 * time.Second
+
+	// statusRequestsPerMinute and statusBurst rate limit each client address
+	statusRequestsPerMinute = 30
+	statusBurst             = 10
+
+	// statusMinRegionAgents is how many live agents a region needs to be
+	// shown, so no region's figures are one agent's results
+	statusMinRegionAgents = 2
+
+	// statusMaxIncidents bounds how many open incidents are listed
+	statusMaxIncidents = 50
+)
+
+// statusPage is the public status page document
+type statusPage struct {
+	GeneratedAt string           `json:"generated_at"`
+	Regions     []statusRegion   `json:"regions"`
+	Incidents   []statusIncident `json:"incidents"`
+}
+
+// statusRegion is the aggregate health of one region; percentages are null
+// without probes
+type statusRegion struct {
+	Region             string   `json:"region"`
+	ReachabilityPct2h  *float64 `json:"reachability_pct_2h"`
+	Probes2h           int64    `json:"probes_2h"`
+	ReachabilityPct24h *float64 `json:"reachability_pct_24h"`
+	Probes24h          int64    `json:"probes_24h"`
+}
+
+// statusIncident is an open incident stripped of agents, results and evidence
+type statusIncident struct {
+	Type     string `json:"type"`
+	Region   string `json:"region,omitempty"`
+	Target   string `json:"target"`
+	Since    string `json:"since"`
+	LastSeen string `json:"last_seen"`
+}
+
+// statusHandler serves the cached, rate-limited public status page
+type statusHandler struct {
+	s       *Server
+	limiter *rateLimiter
+
+	mu       sync.Mutex
+	cached   []byte
+	cachedAt time.Time
+}
+
+// newStatusHandler returns the public status page endpoint. It needs no
+// authentication, so it only exposes aggregates and is rate limited per
+// client address.
+func (s *Server) newStatusHandler() http.Handler {
+	h := &statusHandler{
+		s:       s,
+		limiter: newRateLimiter(statusRequestsPerMinute/60.0, statusBurst),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /v1/status", h)
+	return mux
+}
+
+// startStatusPage serves the public status page endpoint on port
+func (s *Server) startStatusPage(ctx context.Context, port string) {
+	httpServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           s.newStatusHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	log.Printf("Starting public status page endpoint on port %s", port)
+	if err := s.serveHTTP(ctx, httpServer, httpServer.ListenAndServe); err != nil {
+		log.Printf("Public status page endpoint stopped: %v", err)
+	}
+}
+
+// ServeHTTP serves GET /v1/status
+func (h *statusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The direct peer is used, as forwarding headers can be forged
+	client, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		client = r.RemoteAddr
+	}
+	if !h.limiter.Allow(client, time.Now()) {
+		w.Header().Set("Retry-After", strconv.Itoa(60/statusRequestsPerMinute))
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	data, err := h.page(r.Context())
+	if err != nil {
+		log.Printf("Public status page: %v", err)
+		http.Error(w, "status unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(statusCacheTTL/time.Second)))
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Write(data)
+}
+
+// page returns the encoded status page, regenerating it once the cached one expired
+func (h *statusHandler) page(ctx context.Context) ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if h.cached != nil && now.Sub(h.cachedAt) < statusCacheTTL {
+		return h.cached, nil
+	}
+
+	page, err := h.s.buildStatusPage(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(page)
+	if err != nil {
+		return nil, err
+	}
+	h.cached, h.cachedAt = data, now
+	return data, nil
+}
+
+// buildStatusPage aggregates the reachability of every region with enough
+// live agents, and lists the open incidents
+func (s *Server) buildStatusPage(ctx context.Context, now time.Time) (*statusPage, error) {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	liveAgents := make(map[string]int)
+	for _, agent := range agents {
+		if !agent.Alive || now.Sub(agent.LastSeen) > s.config.AgentLivenessWindow {
+			continue
+		}
+		if region := agent.Labels["region"]; region != "" {
+			liveAgents[region]++
+		}
+	}
+
+	recent, err := s.statusStore.GetReachability(ctx, now.Add(-time.Hour), now)
+	if err != nil {
+		return nil, err
+	}
+	daily, err := s.statusStore.GetReachability(ctx, now.Add(-23*time.Hour), now)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &statusPage{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Regions:     []statusRegion{},
+		Incidents:   []statusIncident{},
+	}
+	public := make(map[string]bool)
+	for region, count := range liveAgents {
+		if count < statusMinRegionAgents {
+			continue
+		}
+		public[region] = true
+		entry := statusRegion{Region: region}
+		entry.ReachabilityPct2h, entry.Probes2h = reachabilityPct(recent[region])
+		entry.ReachabilityPct24h, entry.Probes24h = reachabilityPct(daily[region])
+		page.Regions = append(page.Regions, entry)
+	}
+	sort.Slice(page.Regions, func(i, j int) bool {
+		return page.Regions[i].Region < page.Regions[j].Region
+	})
+
+	incidents, err := s.incidentStore.ListIncidents(ctx, "", models.IncidentStatusActive, "", statusMaxIncidents)
+	if err != nil {
+		return nil, err
+	}
+	for _, incident := range incidents {
+		entry := statusIncident{
+			Type:     incident.Type,
+			Target:   incident.Target,
+			Since:    incident.FirstSeen.UTC().Format(time.RFC3339),
+			LastSeen: incident.LastSeen.UTC().Format(time.RFC3339),
+		}
+		// Regions too small to show, or unlabeled agents standing in for
+		// one, would identify individual agents
+		if public[incident.Region] {
+			entry.Region = incident.Region
+		}
+		page.Incidents = append(page.Incidents, entry)
+	}
+
+	return page, nil
+}
+
+// reachabilityPct returns the percentage of probes that reached their
+// target, rounded to two decimals
+func reachabilityPct(r *store.Reachability) (*float64, int64) {
+	if r == nil || r.Probes == 0 {
+		return nil, 0
+	}
+	pct := math.Round(float64(r.Reachable)/float64(r.Probes)*10000) / 100
+	return &pct, r.Probes
+}
+
+// recordStatusProbe counts a ping result towards its region's reachability
+func (s *Server) recordStatusProbe(ctx context.Context, result *models.MeasurementResult) {
+	if result.ModuleName != pingModuleName {
+		return
+	}
+	sent, _ := jsonpath.LookupFloat(result.Data, "packets_sent")
+	if sent <= 0 {
+		return
+	}
+	region := s.agentRegionLabel(ctx, result.AgentID)
+	if region == "" {
+		return
+	}
+
+	received, _ := jsonpath.LookupFloat(result.Data, "packets_received")
+	if err := s.statusStore.RecordProbe(ctx, region, received > 0, time.Now()); err != nil {
+		log.Printf("Public status page: recording probe %s: %v", result.ID, err)
+	}
+}
+
+// rateLimiter is a per-client token bucket rate limiter
+type rateLimiter struct {
+	rate  float64 // tokens per second
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastPrune time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a limiter allowing each client rate requests per
+// second, and bursts of up to burst requests
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Allow takes a token from a client's bucket, reporting whether one was left
+func (l *rateLimiter) Allow(client string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Buckets idle long enough to have refilled are equivalent to new ones
+	if now.Sub(l.lastPrune) > time.Minute {
+		refill := time.Duration(l.burst / l.rate * float64(time.Second))
+		for key, bucket := range l.buckets {
+			if now.Sub(bucket.last) > refill {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = bucket
+	}
+	bucket.tokens = min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// StreamResults stores the results a client streams, validating each as
+// StoreResult does. Results are stored in batches of up to
+// StreamResultsBatchSize, written in a few pipelined round trips, and a
+// batch waits at most StreamResultsFlushInterval to fill. Once the client
+// closes the stream, the last batch is stored and the results that were
+// not are reported. If the stream fails, the results of the batch being
+// filled are not stored.
+func (s *Server) StreamResults(stream api.DBOS_StreamResultsServer) error {
+	ctx := stream.Context()
+
+	received := make(chan *api.StoreResultRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case received <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	b := &resultBatch{s: s, resp: &api.StreamResultsResponse{}}
+	// The flush timer runs from the first result of each batch
+	flush := time.NewTimer(s.config.StreamResultsFlushInterval)
+	flush.Stop()
+	defer flush.Stop()
+	for {
+		select {
+		case req := <-received:
+			b.add(ctx, req)
+			if len(b.results) >= s.config.StreamResultsBatchSize {
+				if err := b.flush(ctx); err != nil {
+					return err
+				}
+			} else if len(b.results) == 1 {
+				flush.Reset(s.config.StreamResultsFlushInterval)
+			}
+		case <-flush.C:
+			if err := b.flush(ctx); err != nil {
+				return err
+			}
+		case err := <-recvErr:
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			if err := b.flush(ctx); err != nil {
+				return err
+			}
+			slices.SortFunc(b.resp.Rejected, func(x, y *api.RejectedResult) int {
+				return cmp.Compare(x.Index, y.Index)
+			})
+			b.resp.Backoff = s.backoff(backoffLow, s.config.StreamResultsBatchSize)
+			return stream.SendAndClose(b.resp)
+		}
+	}
+}
+
+// resultBatch collects the results of a StreamResults stream until they
+// are stored
+type resultBatch struct {
+	s       *Server
+	resp    *api.StreamResultsResponse
+	next    int64 // stream position of the next result received
+	results []*models.MeasurementResult
+	indexes []int64 // stream positions of results
+}
+
+// add validates a received result and adds it to the batch, or records it
+// as rejected
+func (b *resultBatch) add(ctx context.Context, req *api.StoreResultRequest) {
+	index := b.next
+	b.next++
+	if req.Result == nil {
+		b.reject(index, "", dberrors.New(dberrors.InvalidArgument, "result is required"))
+		return
+	}
+	result, err := b.s.resultFromAPI(ctx, req.Result, false)
+	if err != nil {
+		b.reject(index, req.Result.Id, err)
+		return
+	}
+	b.results = append(b.results, result)
+	b.indexes = append(b.indexes, index)
+}
+
+// flush stores the batch and analyzes its stored results, failing only if
+// the batch could not be stored at all
+func (b *resultBatch) flush(ctx context.Context) error {
+	if len(b.results) == 0 {
+		return nil
+	}
+	outcomes, err := b.s.resultStore.StoreResults(ctx, b.results)
+	if err != nil {
+		return err
+	}
+	for i, result := range b.results {
+		if outcomes[i].Err != nil {
+			b.reject(b.indexes[i], result.ID, outcomes[i].Err)
+			continue
+		}
+		b.resp.Stored++
+		b.s.analyzeResult(ctx, result, outcomes[i].Duplicate)
+	}
+	b.results = b.results[:0]
+	b.indexes = b.indexes[:0]
+	return nil
+}
+
+// reject records a result of the stream that was not stored
+func (b *resultBatch) reject(index int64, resultID string, err error) {
+	b.resp.Rejected = append(b.resp.Rejected, &api.RejectedResult{
+		Index:     index,
+		ResultId:  resultID,
+		Error:     err.Error(),
+		ErrorCode: errorCode(err),
+	})
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamTasksPollInterval is how often a task stream re-checks for due tasks
+// it was not notified of, e.g. ones scheduled before it connected
+const streamTasksPollInterval = 5 * time.Second
+
+// StreamTasks pushes an agent's tasks to it as they become due, marking each
+// running as it is sent. Under load it waits out the backoff a LeaseTask
+// call would be asked for before sending the next task.
+func (s *Server) StreamTasks(req *api.StreamTasksRequest, stream api.DBOS_StreamTasksServer) error {
+	ctx := stream.Context()
+	// Subscribe before the first check so no task scheduled in between is missed
+	notifications, err := s.taskStore.SubscribeTasks(ctx, req.AgentId)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "subscribing to tasks: %v", err)
+	}
+	defer notifications.Close()
+
+	poll := time.NewTicker(streamTasksPollInterval)
+	defer poll.Stop()
+
+	// wake fires when the earliest known future task becomes due
+	var wake *time.Timer
+	var wakeC <-chan time.Time
+	var wakeAt time.Time
+	defer func() {
+		if wake != nil {
+			wake.Stop()
+		}
+	}()
+
+	for {
+		for {
+			task, err := s.leaseTask(ctx, req.AgentId)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return status.Errorf(codes.Unavailable, "leasing task: %v", err)
+			}
+			if task == nil {
+				break
+			}
+			if err := stream.Send(taskToAPI(task)); err != nil {
+				// The agent never got the task, e.g. because the server
+				// is shutting down, so it is handed back rather than
+				// left in flight
+				ctx := context.WithoutCancel(ctx)
+				taskIDs := []string{task.ID}
+				errs, nackErr := s.taskStore.NackTasks(ctx, req.AgentId, taskIDs, time.Now(), false)
+				if nackErr != nil {
+					log.Printf("Task %s: requeueing undelivered task: %v", task.ID, nackErr)
+				} else {
+					s.recordSettledTasks(ctx, models.EventTaskRequeued, models.EventSeverityWarning, models.TaskLeaseUndelivered, taskIDs, errs)
+				}
+				return err
+			}
+			waitBackoff(ctx, s.backoff(backoffLow, 0))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case scheduledAt, ok := <-notifications.Scheduled():
+			if !ok {
+				return status.Error(codes.Unavailable, "task notifications closed")
+			}
+			if d := time.Until(scheduledAt); d > 0 && (wakeC == nil || scheduledAt.Before(wakeAt)) {
+				if wake != nil {
+					wake.Stop()
+				}
+				wake = time.NewTimer(d)
+				wakeC = wake.C
+				wakeAt = scheduledAt
+			}
+		case <-wakeC:
+			wakeC = nil
+		case <-poll.C:
+		}
+	}
+}
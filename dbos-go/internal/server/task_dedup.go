@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+	"log"
+)
+
+// taskDedupKey returns the key deduplicating a scheduled task: the dedup
+// key given, else the task ID given, or empty if neither was
+func taskDedupKey(dedupKey, taskID string) string {
+	switch {
+	case dedupKey != "":
+		return "key:" + dedupKey
+	case taskID != "":
+		return "id:" + taskID
+	default:
+		return ""
+	}
+}
+
+// releaseTaskDedupKey releases the dedup key claimed for a task that could
+// not be scheduled, logging a failure: the claim then expires on its own
+func (s *Server) releaseTaskDedupKey(ctx context.Context, dedupKey, taskID string) {
+	if dedupKey == "" {
+		return
+	}
+	if err := s.taskStore.ReleaseDedupKey(ctx, dedupKey, taskID); err != nil {
+		log.Printf("Task %s: releasing dedup key: %v", taskID, err)
+	}
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/internal/store"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// ReportTaskProgress records how far the agent got with a task it holds a
+// lease on, such as the share of a long traceroute or scan done and its
+// partial results, for GetTask to show. Each report replaces the last.
+func (s *Server) ReportTaskProgress(ctx context.Context, req *api.ReportTaskProgressRequest) (*api.ReportTaskProgressResponse, error) {
+	if len(req.Data) > 0 && !json.Valid(req.Data) {
+		return &api.ReportTaskProgressResponse{
+			Success:   false,
+			Error:     "progress data must be JSON",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+
+	task, err := s.taskStore.GetTask(ctx, req.TaskId)
+	if err != nil {
+		return &api.ReportTaskProgressResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+	if task.AgentID != req.AgentId || task.Status != string(models.TaskStatusRunning) {
+		return &api.ReportTaskProgressResponse{
+			Success:   false,
+			Error:     store.ErrTaskNotLeased.Error(),
+			ErrorCode: errorCode(store.ErrTaskNotLeased),
+		}, nil
+	}
+
+	progress := &models.TaskProgress{
+		TaskID:     task.ID,
+		AgentID:    task.AgentID,
+		Percent:    req.Percent,
+		Message:    req.Message,
+		Data:       req.Data,
+		ReportedAt: time.Now(),
+	}
+	if err := s.taskProgressStore.SaveProgress(ctx, progress); err != nil {
+		return &api.ReportTaskProgressResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	// Partial results can be large, so the event carries the rest
+	s.recordTaskChange(ctx, models.EventTaskProgress, models.EventSeverityInfo, task, &models.TaskProgress{
+		TaskID:     progress.TaskID,
+		AgentID:    progress.AgentID,
+		Percent:    progress.Percent,
+		Message:    progress.Message,
+		ReportedAt: progress.ReportedAt,
+	})
+
+	return &api.ReportTaskProgressResponse{
+		Success: true,
+	}, nil
+}
+
+// taskProgressToAPI converts a model task progress into an API task
+// progress
+func taskProgressToAPI(p *models.TaskProgress) *api.TaskProgress {
+	return &api.TaskProgress{
+		TaskId:     p.TaskID,
+		AgentId:    p.AgentID,
+		Percent:    p.Percent,
+		Message:    p.Message,
+		Data:       p.Data,
+		ReportedAt: p.ReportedAt.Unix(),
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+const (
+	// taskLeaseExpirationsMetric is the metric sample counting, per agent
+	// and module, the task leases that expired in one requeue pass
+	taskLeaseExpirationsMetric = "dbos_task_lease_expirations"
+
+	// taskHungMetric is the metric sample counting, per agent and module,
+	// the hung tasks requeued in one requeue pass
+	taskHungMetric = "dbos_hung_tasks"
+)
+
+// runTaskRequeuer periodically ends the expired leases of in-flight tasks,
+// and those of hung tasks, until ctx is done
+func (s *Server) runTaskRequeuer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.requeueExpiredTasks(ctx, now); err != nil {
+				log.Printf("Task requeuer: %v", err)
+			}
+		}
+	}
+}
+
+// requeueExpiredTasks ends the leases agents took more than the lease
+// timeout ago without settling them, then those of hung tasks
+func (s *Server) requeueExpiredTasks(ctx context.Context, now time.Time) error {
+	tasks, err := s.taskStore.ListExpiredTasks(ctx, now.Add(-s.config.TaskLeaseTimeout))
+	if err != nil {
+		return err
+	}
+	if err := s.endLeases(ctx, tasks, now, models.TaskLeaseExpired, taskLeaseExpirationsMetric); err != nil {
+		return err
+	}
+	if s.hungTaskDetection() {
+		return s.requeueHungTasks(ctx, now)
+	}
+	return nil
+}
+
+// hungTaskDetection reports whether the leases of hung tasks are ended
+// before the lease timeout. The streams task queue hands leases older than
+// the cutoff it is asked for to the expired consumer at once, so it ends
+// leases at the lease timeout only.
+func (s *Server) hungTaskDetection() bool {
+	return s.config.TaskHungFactor > 0 && s.config.TaskQueue != TaskQueueStreams &&
+		s.config.TaskHungMinAge < s.config.TaskLeaseTimeout
+}
+
+// requeueHungTasks ends the leases of tasks that have run for longer than
+// their module's hung task threshold, but not yet for the lease timeout
+func (s *Server) requeueHungTasks(ctx context.Context, now time.Time) error {
+	tasks, err := s.taskStore.ListExpiredTasks(ctx, now.Add(-s.config.TaskHungMinAge))
+	if err != nil || len(tasks) == 0 {
+		return err
+	}
+
+	// thresholds holds each module's threshold, zero if it has none
+	thresholds := make(map[string]time.Duration)
+	var hung []*models.Task
+	for _, task := range tasks {
+		// Tasks with leases of their own length, or whose agent extends its
+		// lease, are known to run long
+		leasedAt := models.TimeOf(task.LeasedAt)
+		if leasedAt.IsZero() || task.LeaseTimeoutSeconds > 0 || !models.TimeOf(task.LeaseExtendedAt).IsZero() {
+			continue
+		}
+		threshold, ok := thresholds[task.ModuleName]
+		if !ok {
+			stats, err := s.executionStore.ModuleStats(ctx, task.ModuleName)
+			if err != nil {
+				return err
+			}
+			threshold, _ = s.hungTaskThreshold(stats)
+			thresholds[task.ModuleName] = threshold
+		}
+		if threshold > 0 && now.Sub(leasedAt) > threshold {
+			hung = append(hung, task)
+		}
+	}
+	return s.endLeases(ctx, hung, now, models.TaskLeaseHung, taskHungMetric)
+}
+
+// endLeases ends the leases of tasks whose agents did not settle them, for
+// the reason given. Agents streaming tasks do not acknowledge them, so a
+// task with a stored result or a completed module state is completed and
+// one whose module state reports an error is failed; the others are
+// retried, due at once unless their retry policy backs off. Each lease
+// ended is recorded in the event log, and the requeued tasks are counted,
+// per agent and module, in samples of metric.
+func (s *Server) endLeases(ctx context.Context, tasks []*models.Task, now time.Time, reason models.TaskLeaseEndReasonEnum, metric string) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	for _, task := range tasks {
+		stored, err := s.resultStore.HasResult(ctx, task.AgentID, task.ID)
+		if err != nil {
+			return err
+		}
+		if stored {
+			task.Status = string(models.TaskStatusCompleted)
+			continue
+		}
+		state, err := s.moduleStateStore.GetModuleState(ctx, task.ID)
+		if err != nil {
+			continue
+		}
+		switch models.ModuleStateEnum(state.State) {
+		case models.ModuleStateCompleted:
+			task.Status = string(models.TaskStatusCompleted)
+		case models.ModuleStateError, models.ModuleStateFailed:
+			task.Status = string(models.TaskStatusFailed)
+		}
+	}
+
+	released, err := s.taskStore.RequeueExpiredTasks(ctx, tasks, now)
+	if err != nil {
+		return err
+	}
+
+	expirations := make(map[[2]string]int)
+	for i, task := range tasks {
+		if !released[i] {
+			continue
+		}
+		eventType, severity, leaseEnd := leaseEndEvent(task, reason)
+		s.recordEvent(ctx, eventType, severity, &models.Event{
+			Actor:         models.EventActorSystem,
+			Subject:       taskName(task.ID),
+			AgentID:       task.AgentID,
+			CorrelationID: task.CorrelationID,
+		}, leaseEnd)
+		if task.Status == string(models.TaskStatusPending) {
+			expirations[[2]string{task.AgentID, task.ModuleName}]++
+		}
+	}
+
+	samples := make([]metricSample, 0, len(expirations))
+	for key, n := range expirations {
+		samples = append(samples, metricSample{
+			Name:      metric,
+			AgentID:   key[0],
+			Module:    key[1],
+			Value:     float64(n),
+			Timestamp: now,
+		})
+	}
+	s.exportSamples(samples)
+	return nil
+}
+
+// leaseEndEvent describes the event of a task's lease ended for reason: a
+// requeue if the task is pending again, and its completion or failure
+// otherwise
+func leaseEndEvent(task *models.Task, reason models.TaskLeaseEndReasonEnum) (models.EventTypeEnum, models.EventSeverityEnum, *models.TaskTransition) {
+	eventType, severity := models.EventTaskRequeued, models.EventSeverityWarning
+	switch models.TaskStatusEnum(task.Status) {
+	case models.TaskStatusCompleted:
+		eventType, severity = models.EventTaskCompleted, models.EventSeverityInfo
+	case models.TaskStatusFailed:
+		eventType, severity = models.EventTaskFailed, models.EventSeverityWarning
+	}
+	return eventType, severity, models.TaskTransitionOf(task, reason)
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// TestLeaseEndEvent checks the event recorded for a task whose lease the
+// requeuer ended, by the status the task was left in
+func TestLeaseEndEvent(t *testing.T) {
+	due := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		status       models.TaskStatusEnum
+		reason       models.TaskLeaseEndReasonEnum
+		wantType     models.EventTypeEnum
+		wantSeverity models.EventSeverityEnum
+	}{
+		{models.TaskStatusPending, models.TaskLeaseExpired, models.EventTaskRequeued, models.EventSeverityWarning},
+		{models.TaskStatusPending, models.TaskLeaseHung, models.EventTaskRequeued, models.EventSeverityWarning},
+		{models.TaskStatusCompleted, models.TaskLeaseExpired, models.EventTaskCompleted, models.EventSeverityInfo},
+		{models.TaskStatusFailed, models.TaskLeaseHung, models.EventTaskFailed, models.EventSeverityWarning},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status)+"/"+string(tt.reason), func(t *testing.T) {
+			task := &models.Task{
+				ID:          "task-1",
+				AgentID:     "a1",
+				ModuleName:  "ping_module",
+				Status:      string(tt.status),
+				RetryCount:  2,
+				ScheduledAt: due,
+			}
+			eventType, severity, payload := leaseEndEvent(task, tt.reason)
+			if eventType != tt.wantType || severity != tt.wantSeverity {
+				t.Errorf("leaseEndEvent() = %s, %s, want %s, %s", eventType, severity, tt.wantType, tt.wantSeverity)
+			}
+			want := models.TaskTransition{
+				TaskID:      "task-1",
+				ModuleName:  "ping_module",
+				AgentID:     "a1",
+				Reason:      tt.reason,
+				Status:      string(tt.status),
+				RetryCount:  2,
+				ScheduledAt: due,
+			}
+			if !reflect.DeepEqual(*payload, want) {
+				t.Errorf("leaseEndEvent() payload = %+v, want %+v", *payload, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/internet-measurement-network/dbos/api"
+	apiv2 "github.com/internet-measurement-network/dbos/api/v2"
+	"github.com/internet-measurement-network/dbos/pkg/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// clientIdentityKey is the context key of the verified client certificate CN
+type clientIdentityKey struct{}
+
+// tlsConfig returns the TLS configuration of the gRPC, HTTP ingest and GraphQL
+// listeners, or nil if TLS is not configured
+func (s *Server) tlsConfig() (*tls.Config, error) {
+	if s.config.TLSCertFile == "" {
+		if s.config.TLSClientCAFile != "" {
+			return nil, fmt.Errorf("client CA requires a server certificate")
+		}
+		return nil, nil
+	}
+	return tlsconfig.Server(s.config.TLSCertFile, s.config.TLSKeyFile, s.config.TLSClientCAFile)
+}
+
+// grpcServerOptions returns the transport credentials and, with client
+// certificates required, the interceptors verifying agent identity
+func (s *Server) grpcServerOptions() ([]grpc.ServerOption, error) {
+	cfg, err := s.tlsConfig()
+	if err != nil || cfg == nil {
+		return nil, err
+	}
+
+	opts := []grpc.ServerOption{grpc.Creds(credentials.NewTLS(cfg))}
+	if s.config.TLSClientCAFile != "" {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(s.unaryIdentityInterceptor),
+			grpc.ChainStreamInterceptor(s.streamIdentityInterceptor),
+		)
+	}
+	return opts, nil
+}
+
+// unaryIdentityInterceptor rejects agent-scoped requests for an agent other
+// than the one named by the client certificate
+func (s *Server) unaryIdentityInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx = withClientIdentity(ctx, peerIdentity(ctx))
+	if err := s.authorizeRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamIdentityInterceptor is unaryIdentityInterceptor for streaming RPCs,
+// checking every message received on the stream
+func (s *Server) streamIdentityInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := withClientIdentity(ss.Context(), peerIdentity(ss.Context()))
+	return handler(srv, &identityStream{ServerStream: ss, ctx: ctx, server: s})
+}
+
+// identityStream authorizes the messages received on a server stream
+type identityStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	server *Server
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *identityStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.server.authorizeRequest(s.ctx, m)
+}
+
+// authorizeRequest checks the agent a request acts for, if any, against the
+// client identity
+func (s *Server) authorizeRequest(ctx context.Context, req any) error {
+	agentIDs, _ := requestAgentIDs(req)
+	for _, agentID := range agentIDs {
+		if err := s.authorizeAgent(ctx, agentID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authorizeAgent checks that the client may act for agentID: its certificate
+// CN must be the agent ID or one of the operator names. It allows everything
+// when client certificates are not required.
+func (s *Server) authorizeAgent(ctx context.Context, agentID string) error {
+	if s.config.TLSClientCAFile == "" {
+		return nil
+	}
+
+	identity, _ := ctx.Value(clientIdentityKey{}).(string)
+	if identity == "" {
+		return status.Errorf(codes.Unauthenticated, "no verified client certificate")
+	}
+	if identity == agentID || slices.Contains(s.config.TLSOperatorNames, identity) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "client %s may not act for agent %s", identity, agentID)
+}
+
+// requestAgentIDs returns the agents an agent-scoped write request acts for:
+// the one requestAgentID returns, or the agents of the results of a batch
+func requestAgentIDs(req any) ([]string, bool) {
+	if r, ok := req.(*api.StoreResultsRequest); ok {
+		agentIDs := make([]string, len(r.Results))
+		for i, result := range r.Results {
+			agentIDs[i] = result.GetAgentId()
+		}
+		return agentIDs, true
+	}
+	agentID, ok := requestAgentID(req)
+	if !ok {
+		return nil, false
+	}
+	return []string{agentID}, true
+}
+
+// requestAgentID returns the agent an agent-scoped write request acts for
+func requestAgentID(req any) (string, bool) {
+	switch r := req.(type) {
+	case *api.RegisterAgentRequest:
+		return r.GetAgent().GetId(), true
+	case *api.HeartbeatRequest:
+		return r.AgentId, true
+	case *api.SetModuleStateRequest:
+		return r.GetState().GetAgentId(), true
+	case *api.StoreResultRequest:
+		return r.GetResult().GetAgentId(), true
+	case *api.LeaseTaskRequest:
+		return r.AgentId, true
+	case *api.AckTasksRequest:
+		return r.AgentId, true
+	case *api.NackTasksRequest:
+		return r.AgentId, true
+	case *api.ExtendTaskVisibilityRequest:
+		return r.AgentId, true
+	case *api.ReportTaskProgressRequest:
+		return r.AgentId, true
+	case *api.StreamTasksRequest:
+		return r.AgentId, true
+	case *apiv2.CreateAgentRequest:
+		return r.AgentId, true
+	case *apiv2.UpdateAgentRequest:
+		return agentNameID(r.GetAgent().GetName()), true
+	case *apiv2.HeartbeatRequest:
+		return agentNameID(r.Name), true
+	case *apiv2.CreateResultRequest:
+		return agentNameID(r.Parent), true
+	}
+	return "", false
+}
+
+// agentNameID returns the agent ID of an "agents/{agent}" name, or the name
+// itself if it is malformed, which no client identity matches
+func agentNameID(name string) string {
+	if agentID, err := parseAgentName(name); err == nil {
+		return agentID
+	}
+	return name
+}
+
+// peerIdentity returns the CN of the verified certificate of a gRPC peer
+func peerIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ""
+	}
+	return verifiedCommonName(info.State)
+}
+
+// verifiedCommonName returns the CN of the leaf of the first verified chain
+func verifiedCommonName(state tls.ConnectionState) string {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return state.VerifiedChains[0][0].Subject.CommonName
+}
+
+// withClientIdentity records the verified client certificate CN in ctx
+func withClientIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, clientIdentityKey{}, identity)
+}
+
+// withHTTPClientIdentity records the verified client certificate CN of HTTP
+// requests in their context
+func withHTTPClientIdentity(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil {
+			r = r.WithContext(withClientIdentity(r.Context(), verifiedCommonName(*r.TLS)))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorizeHTTPRequest is authorizeRequest for the HTTP ingest endpoint,
+// answering 403 if the client may not act for the request's agent
+func (s *Server) authorizeHTTPRequest(w http.ResponseWriter, r *http.Request, req any) bool {
+	if err := s.authorizeRequest(r.Context(), req); err != nil {
+		writeHTTPError(w, err)
+		return false
+	}
+	if err := authorizeHTTPToken(r, req); err != nil {
+		writeHTTPError(w, err)
+		return false
+	}
+	return true
+}
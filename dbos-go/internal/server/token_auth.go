@@ -0,0 +1,380 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/internet-measurement-network/dbos/api"
+	apiv2 "github.com/internet-measurement-network/dbos/api/v2"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/apitoken"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tokenIdentityKey is the context key of the identity an API token proves
+type tokenIdentityKey struct{}
+
+// tokenIdentity is the client an API token proves and the role it holds;
+// agentID is set for agent tokens, name for tokens issued by CreateAPIToken
+type tokenIdentity struct {
+	role    models.RoleEnum
+	agentID string
+	name    string
+}
+
+func (t *tokenIdentity) String() string {
+	switch {
+	case t.role == models.RoleAgent:
+		return "agent " + t.agentID
+	case t.name != "":
+		return string(t.role) + " token " + t.name
+	}
+	return string(t.role) + " token"
+}
+
+// tokenExemptMethods are the RPCs served without a token; an enrolling
+// agent proves itself with its bootstrap token instead
+var tokenExemptMethods = map[string]bool{
+	api.DBOS_EnrollAgent_FullMethodName: true,
+}
+
+// agentTokenMethods are the RPCs an agent token may call, for its own agent
+var agentTokenMethods = map[string]bool{
+	api.DBOS_RegisterAgent_FullMethodName:        true,
+	api.DBOS_Heartbeat_FullMethodName:            true,
+	api.DBOS_GetAgentConfig_FullMethodName:       true,
+	api.DBOS_GetManifest_FullMethodName:          true,
+	api.DBOS_SetModuleState_FullMethodName:       true,
+	api.DBOS_StoreResult_FullMethodName:          true,
+	api.DBOS_StoreResults_FullMethodName:         true,
+	api.DBOS_StreamResults_FullMethodName:        true,
+	api.DBOS_LeaseTask_FullMethodName:            true,
+	api.DBOS_AckTasks_FullMethodName:             true,
+	api.DBOS_NackTasks_FullMethodName:            true,
+	api.DBOS_ExtendTaskVisibility_FullMethodName: true,
+	api.DBOS_ReportTaskProgress_FullMethodName:   true,
+	api.DBOS_StreamTasks_FullMethodName:          true,
+	apiv2.DBOS_CreateAgent_FullMethodName:        true,
+	apiv2.DBOS_UpdateAgent_FullMethodName:        true,
+	apiv2.DBOS_Heartbeat_FullMethodName:          true,
+	apiv2.DBOS_CreateResult_FullMethodName:       true,
+}
+
+// readOnlyMethods are the RPCs that only query, which every role but agents
+// may call
+var readOnlyMethods = map[string]bool{
+	api.DBOS_GetAgent_FullMethodName:               true,
+	api.DBOS_ListAgents_FullMethodName:             true,
+	api.DBOS_WatchAgents_FullMethodName:            true,
+	api.DBOS_GetAgentGeoJSON_FullMethodName:        true,
+	api.DBOS_GetConfigRollout_FullMethodName:       true,
+	api.DBOS_GetAgentConfig_FullMethodName:         true,
+	api.DBOS_ListConfigSchemas_FullMethodName:      true,
+	api.DBOS_ValidateConfig_FullMethodName:         true,
+	api.DBOS_GetManifest_FullMethodName:            true,
+	api.DBOS_GetModuleState_FullMethodName:         true,
+	api.DBOS_WatchModuleStates_FullMethodName:      true,
+	api.DBOS_ListModuleStates_FullMethodName:       true,
+	api.DBOS_GetResult_FullMethodName:              true,
+	api.DBOS_ListResults_FullMethodName:            true,
+	api.DBOS_GetCorrelatedResults_FullMethodName:   true,
+	api.DBOS_CountResults_FullMethodName:           true,
+	api.DBOS_HasResult_FullMethodName:              true,
+	api.DBOS_ExportResults_FullMethodName:          true,
+	api.DBOS_ProfileResults_FullMethodName:         true,
+	api.DBOS_GetIngestGaps_FullMethodName:          true,
+	api.DBOS_GetClockSkew_FullMethodName:           true,
+	api.DBOS_ListAlerts_FullMethodName:             true,
+	api.DBOS_GetIncident_FullMethodName:            true,
+	api.DBOS_ListIncidents_FullMethodName:          true,
+	api.DBOS_WatchIncidents_FullMethodName:         true,
+	api.DBOS_ListRoutingEvents_FullMethodName:      true,
+	api.DBOS_GetTask_FullMethodName:                true,
+	api.DBOS_ListTasks_FullMethodName:              true,
+	api.DBOS_ListDueTasks_FullMethodName:           true,
+	api.DBOS_ListPendingTasks_FullMethodName:       true,
+	api.DBOS_GetVerification_FullMethodName:        true,
+	api.DBOS_GetBroadcastStatus_FullMethodName:     true,
+	api.DBOS_ListViews_FullMethodName:              true,
+	api.DBOS_QueryView_FullMethodName:              true,
+	api.DBOS_ListExtractionRules_FullMethodName:    true,
+	api.DBOS_QueryResults_FullMethodName:           true,
+	api.DBOS_GetSavedQuery_FullMethodName:          true,
+	api.DBOS_ListSavedQueries_FullMethodName:       true,
+	api.DBOS_ExecuteSavedQuery_FullMethodName:      true,
+	api.DBOS_ListAlertRules_FullMethodName:         true,
+	api.DBOS_GetMaintenanceWindow_FullMethodName:   true,
+	api.DBOS_ListMaintenanceWindows_FullMethodName: true,
+	api.DBOS_GetTrends_FullMethodName:              true,
+	api.DBOS_GetCampaign_FullMethodName:            true,
+	api.DBOS_ListCampaigns_FullMethodName:          true,
+	api.DBOS_ListCampaignResults_FullMethodName:    true,
+	api.DBOS_GetDrift_FullMethodName:               true,
+	api.DBOS_PlanCapacity_FullMethodName:           true,
+	api.DBOS_GetExecutionStats_FullMethodName:      true,
+	api.DBOS_GetUsage_FullMethodName:               true,
+	api.DBOS_GetEvents_FullMethodName:              true,
+	api.DBOS_StreamEvents_FullMethodName:           true,
+	api.DBOS_ListStateEvents_FullMethodName:        true,
+	api.DBOS_ExportSnapshot_FullMethodName:         true,
+	apiv2.DBOS_GetAgent_FullMethodName:             true,
+	apiv2.DBOS_ListAgents_FullMethodName:           true,
+	apiv2.DBOS_GetTask_FullMethodName:              true,
+	apiv2.DBOS_GetResult_FullMethodName:            true,
+	apiv2.DBOS_ListResults_FullMethodName:          true,
+	apiv2.DBOS_CountResults_FullMethodName:         true,
+}
+
+// operatorMethods are the RPCs that change what is measured and how results
+// are analyzed, which operators may call besides the read-only ones. The
+// RPCs in neither set, such as those issuing tokens or rebuilding state, are
+// for admins only.
+var operatorMethods = map[string]bool{
+	api.DBOS_DeleteAgent_FullMethodName:             true,
+	api.DBOS_SetConfigSchema_FullMethodName:         true,
+	api.DBOS_DeleteConfigSchema_FullMethodName:      true,
+	api.DBOS_StartConfigRollout_FullMethodName:      true,
+	api.DBOS_RollbackConfigRollout_FullMethodName:   true,
+	api.DBOS_CreateIncident_FullMethodName:          true,
+	api.DBOS_UpdateIncident_FullMethodName:          true,
+	api.DBOS_AcknowledgeIncident_FullMethodName:     true,
+	api.DBOS_ResolveIncident_FullMethodName:         true,
+	api.DBOS_AddIncidentComment_FullMethodName:      true,
+	api.DBOS_DeleteIncident_FullMethodName:          true,
+	api.DBOS_ScheduleTask_FullMethodName:            true,
+	api.DBOS_CancelTask_FullMethodName:              true,
+	api.DBOS_ScheduleVerifiedTask_FullMethodName:    true,
+	api.DBOS_BroadcastTask_FullMethodName:           true,
+	api.DBOS_ApplySpec_FullMethodName:               true,
+	api.DBOS_CreateView_FullMethodName:              true,
+	api.DBOS_DeleteView_FullMethodName:              true,
+	api.DBOS_CreateExtractionRule_FullMethodName:    true,
+	api.DBOS_DeleteExtractionRule_FullMethodName:    true,
+	api.DBOS_CreateSavedQuery_FullMethodName:        true,
+	api.DBOS_UpdateSavedQuery_FullMethodName:        true,
+	api.DBOS_DeleteSavedQuery_FullMethodName:        true,
+	api.DBOS_CreateAlertRule_FullMethodName:         true,
+	api.DBOS_DeleteAlertRule_FullMethodName:         true,
+	api.DBOS_CreateMaintenanceWindow_FullMethodName: true,
+	api.DBOS_UpdateMaintenanceWindow_FullMethodName: true,
+	api.DBOS_DeleteMaintenanceWindow_FullMethodName: true,
+	api.DBOS_CreateCampaign_FullMethodName:          true,
+	api.DBOS_StopCampaign_FullMethodName:            true,
+	apiv2.DBOS_DeleteAgent_FullMethodName:           true,
+	apiv2.DBOS_CreateTask_FullMethodName:            true,
+	apiv2.DBOS_CancelTask_FullMethodName:            true,
+}
+
+// agentTokenPaths are the HTTP ingest endpoints an agent token may call,
+// for its own agent
+var agentTokenPaths = map[string]bool{
+	"/v1/results":     true,
+	"/v1/heartbeat":   true,
+	"/v1/tasks/lease": true,
+}
+
+// unaryTokenInterceptor rejects calls without a valid API token, and calls
+// to RPCs the token's role does not allow
+func (s *Server) unaryTokenInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if tokenExemptMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	identity, err := s.authenticateToken(ctx, apitoken.FromIncomingContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if err := identity.authorize(info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, tokenIdentityKey{}, identity), req)
+}
+
+// streamTokenInterceptor is unaryTokenInterceptor for streaming RPCs,
+// checking every message received on the stream
+func (s *Server) streamTokenInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if tokenExemptMethods[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	identity, err := s.authenticateToken(ss.Context(), apitoken.FromIncomingContext(ss.Context()))
+	if err != nil {
+		return err
+	}
+	if !identity.allowed(info.FullMethod) {
+		return status.Errorf(codes.PermissionDenied, "%s may not call %s", identity, info.FullMethod)
+	}
+	return handler(srv, &tokenStream{
+		ServerStream: ss,
+		ctx:          context.WithValue(ss.Context(), tokenIdentityKey{}, identity),
+		identity:     identity,
+		method:       info.FullMethod,
+	})
+}
+
+// tokenStream authorizes the messages received on a server stream
+type tokenStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	identity *tokenIdentity
+	method   string
+}
+
+func (s *tokenStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tokenStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.identity.authorize(s.method, m)
+}
+
+// authenticateToken returns the identity an API token proves: an admin
+// token from the configuration, an agent token issued by EnrollAgent or
+// CreateAgentToken, or a token issued with a role by CreateAPIToken
+func (s *Server) authenticateToken(ctx context.Context, token string) (*tokenIdentity, error) {
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing API token")
+	}
+	for _, admin := range s.config.AdminTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(admin)) == 1 {
+			return &tokenIdentity{role: models.RoleAdmin}, nil
+		}
+	}
+
+	claims, err := s.credentialStore.LookupToken(ctx, token)
+	if err != nil {
+		return nil, dberrors.Status(dberrors.New(dberrors.StorageUnavailable, "looking up API token: %v", err))
+	}
+	if claims == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid API token")
+	}
+	return &tokenIdentity{
+		role:    models.RoleEnum(claims.Role),
+		agentID: claims.AgentID,
+		name:    claims.Name,
+	}, nil
+}
+
+// allowed reports whether the identity's role may call an RPC: admins may
+// call anything, operators the operator and read-only RPCs, read-only
+// tokens the read-only ones and agents the agent RPCs
+func (t *tokenIdentity) allowed(method string) bool {
+	switch t.role {
+	case models.RoleAdmin:
+		return true
+	case models.RoleOperator:
+		return operatorMethods[method] || readOnlyMethods[method]
+	case models.RoleReadOnly:
+		return readOnlyMethods[method]
+	case models.RoleAgent:
+		return agentTokenMethods[method]
+	}
+	return false
+}
+
+// authorize checks that the identity may call an RPC with a request: its
+// role must allow the RPC, and agents may only act for themselves
+func (t *tokenIdentity) authorize(method string, req any) error {
+	if !t.allowed(method) {
+		return status.Errorf(codes.PermissionDenied, "%s may not call %s", t, method)
+	}
+	return t.authorizeAgent(req)
+}
+
+// authorizeAgent checks that an agent request acts for the identity's agent;
+// other roles act for no agent and are not checked, nor are agent requests
+// for what every agent may read, such as the manifest
+func (t *tokenIdentity) authorizeAgent(req any) error {
+	if t.role != models.RoleAgent {
+		return nil
+	}
+	agentIDs, ok := requestAgentIDs(req)
+	switch r := req.(type) {
+	case *api.GetAgentConfigRequest:
+		agentIDs, ok = []string{r.AgentId}, true
+	case *api.GetManifestRequest:
+		return nil
+	}
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "agent %s may only act for itself", t.agentID)
+	}
+	for _, agentID := range agentIDs {
+		if agentID != t.agentID {
+			return status.Errorf(codes.PermissionDenied, "agent %s may not act for agent %s", t.agentID, agentID)
+		}
+	}
+	return nil
+}
+
+// withHTTPTokenAuth rejects HTTP ingest requests without a valid API token,
+// and requests to endpoints the token's role does not allow: agents may
+// call the agent endpoints, operators and read-only tokens the GET ones,
+// which only query
+func (s *Server) withHTTPTokenAuth(next http.Handler) http.Handler {
+	return s.withHTTPToken(next, (*tokenIdentity).allowedPath)
+}
+
+// withHTTPReadTokenAuth rejects requests without a valid API token allowed
+// to read, for endpoints that only query whatever their method, such as
+// GraphQL's POST /graphql
+func (s *Server) withHTTPReadTokenAuth(next http.Handler) http.Handler {
+	return s.withHTTPToken(next, func(t *tokenIdentity, r *http.Request) bool {
+		return t.mayRead()
+	})
+}
+
+// withHTTPToken rejects HTTP requests without a valid API token, and those
+// allowed rejects for the token's role
+func (s *Server) withHTTPToken(next http.Handler, allowed func(*tokenIdentity, *http.Request) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, err := s.authenticateToken(r.Context(), apitoken.FromRequest(r))
+		if err != nil {
+			writeHTTPError(w, err)
+			return
+		}
+		if !allowed(identity, r) {
+			writeHTTPError(w, dberrors.New(dberrors.PermissionDenied, "%s may not call %s", identity, r.URL.Path))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tokenIdentityKey{}, identity)))
+	})
+}
+
+// allowedPath reports whether the identity's role may make an HTTP ingest
+// request
+func (t *tokenIdentity) allowedPath(r *http.Request) bool {
+	switch t.role {
+	case models.RoleAdmin:
+		return true
+	case models.RoleOperator, models.RoleReadOnly:
+		return r.Method == http.MethodGet
+	case models.RoleAgent:
+		return agentTokenPaths[r.URL.Path]
+	}
+	return false
+}
+
+// mayRead reports whether the identity's role may query: every role but
+// agent's
+func (t *tokenIdentity) mayRead() bool {
+	switch t.role {
+	case models.RoleAdmin, models.RoleOperator, models.RoleReadOnly:
+		return true
+	}
+	return false
+}
+
+// authorizeHTTPToken checks that the API token of an HTTP ingest request, if
+// token authentication is enabled, may act for the request's agent
+func authorizeHTTPToken(r *http.Request, req any) error {
+	identity, ok := r.Context().Value(tokenIdentityKey{}).(*tokenIdentity)
+	if !ok {
+		return nil
+	}
+	return identity.authorizeAgent(req)
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestAgentTokenAuthorize checks which agent RPCs an agent token may call
+// for its own agent, for other agents, and for no agent
+func TestAgentTokenAuthorize(t *testing.T) {
+	agent := &tokenIdentity{role: models.RoleAgent, agentID: "a1"}
+	tests := []struct {
+		name   string
+		method string
+		req    any
+		want   codes.Code
+	}{
+		{"manifest", api.DBOS_GetManifest_FullMethodName, &api.GetManifestRequest{}, codes.OK},
+		{"own config", api.DBOS_GetAgentConfig_FullMethodName, &api.GetAgentConfigRequest{AgentId: "a1"}, codes.OK},
+		{"other config", api.DBOS_GetAgentConfig_FullMethodName, &api.GetAgentConfigRequest{AgentId: "a2"}, codes.PermissionDenied},
+		{"own lease", api.DBOS_LeaseTask_FullMethodName, &api.LeaseTaskRequest{AgentId: "a1"}, codes.OK},
+		{"other lease", api.DBOS_LeaseTask_FullMethodName, &api.LeaseTaskRequest{AgentId: "a2"}, codes.PermissionDenied},
+		{"not an agent RPC", api.DBOS_ListAgents_FullMethodName, &api.ListAgentsRequest{}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(agent.authorize(tt.method, tt.req)); got != tt.want {
+				t.Errorf("authorize(%s) = %v, want %v", tt.method, got, tt.want)
+			}
+		})
+	}
+}
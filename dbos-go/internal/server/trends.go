@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/tdigest"
+)
+
+const (
+	// defaultTrendRange is how many days GetTrends covers when no start day is given
+	defaultTrendRange = 30
+
+	// maxTrendRange bounds the number of days one GetTrends call may cover
+	maxTrendRange = 3660
+)
+
+// recordTrends appends a result's metric fields to their trend series
+func (s *Server) recordTrends(ctx context.Context, result *models.MeasurementResult) {
+	if !s.config.TrendsEnabled {
+		return
+	}
+
+	for _, sample := range extractMetrics(s.config.MetricFields, result) {
+		series := models.TrendSeries{Metric: sample.Name, AgentID: sample.AgentID, Target: sample.Target}
+		if err := s.trendStore.RecordValue(ctx, series, sample.Timestamp, sample.Value); err != nil {
+			log.Printf("Trends: recording %s for %s: %v", sample.Name, sample.AgentID, err)
+		}
+	}
+}
+
+// runTrendRollup periodically compacts finished days into digests until ctx is done
+func (s *Server) runTrendRollup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := s.trendStore.Rollup(ctx, now)
+			if err != nil {
+				log.Printf("Trend rollup: %v", err)
+			}
+			if n > 0 {
+				log.Printf("Trend rollup: compacted %d series-days", n)
+			}
+		}
+	}
+}
+
+// GetTrends reports per-day distributions of a metric for an agent and target
+func (s *Server) GetTrends(ctx context.Context, req *api.GetTrendsRequest) (*api.GetTrendsResponse, error) {
+	to := time.Now().UTC()
+	if req.ToDay != "" {
+		t, err := time.Parse(models.ViewDayFormat, req.ToDay)
+		if err != nil {
+			return &api.GetTrendsResponse{
+				Error:     fmt.Sprintf("invalid to_day: %v", err),
+				ErrorCode: string(dberrors.InvalidArgument),
+			}, nil
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -defaultTrendRange)
+	if req.FromDay != "" {
+		t, err := time.Parse(models.ViewDayFormat, req.FromDay)
+		if err != nil {
+			return &api.GetTrendsResponse{
+				Error:     fmt.Sprintf("invalid from_day: %v", err),
+				ErrorCode: string(dberrors.InvalidArgument),
+			}, nil
+		}
+		from = t
+	}
+	if to.Sub(from) > maxTrendRange*24*time.Hour {
+		return &api.GetTrendsResponse{
+			Error:     fmt.Sprintf("range exceeds %d days", maxTrendRange),
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+
+	quantiles := req.Quantiles
+	if len(quantiles) == 0 {
+		quantiles = models.DefaultTrendQuantiles
+	}
+	for _, q := range quantiles {
+		if q < 0 || q > 1 {
+			return &api.GetTrendsResponse{
+				Error:     fmt.Sprintf("quantile %v out of range [0, 1]", q),
+				ErrorCode: string(dberrors.InvalidArgument),
+			}, nil
+		}
+	}
+
+	series := models.TrendSeries{Metric: req.Metric, AgentID: req.AgentId, Target: req.Target}
+	days, err := s.trendStore.GetTrends(ctx, series, from, to)
+	if err != nil {
+		return &api.GetTrendsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	summary := tdigest.New(tdigest.DefaultCompression)
+	points := make([]*api.TrendPoint, len(days))
+	for i, day := range days {
+		points[i] = trendPoint(day.Digest, quantiles)
+		points[i].Day = day.Day
+		summary.Merge(day.Digest)
+	}
+
+	return &api.GetTrendsResponse{
+		Points:    points,
+		Summary:   trendPoint(summary, quantiles),
+		Quantiles: quantiles,
+	}, nil
+}
+
+// trendPoint summarizes a digest at the given quantiles
+func trendPoint(digest *tdigest.TDigest, quantiles []float64) *api.TrendPoint {
+	point := &api.TrendPoint{
+		Count: int64(digest.Count()),
+	}
+	if digest.Count() == 0 {
+		return point
+	}
+
+	point.Min = digest.Min()
+	point.Max = digest.Max()
+	point.Mean = digest.Mean()
+	for _, q := range quantiles {
+		v := digest.Quantile(q)
+		if math.IsNaN(v) {
+			v = 0
+		}
+		point.Quantiles = append(point.Quantiles, v)
+	}
+	return point
+}
@@ -0,0 +1,448 @@
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// defaultUsageWindow is the window GetUsage counts if none is asked for
+	defaultUsageWindow = 5 * time.Minute
+
+	// defaultUsageLimit and maxUsageLimit bound the entries of GetUsage
+	defaultUsageLimit = 100
+	maxUsageLimit     = 1000
+
+	// usageFlushTimeout bounds the final flush of usage counts on shutdown
+	usageFlushTimeout = 10 * time.Second
+
+	// usageMetricModule is the module label of exported usage metrics, and
+	// their InfluxDB measurement
+	usageMetricModule = "dbos_api"
+)
+
+// usageMeter counts the RPCs of each agent and API key in memory, by the
+// minute they ended in, until they are flushed to the usage store
+type usageMeter struct {
+	mu     sync.Mutex
+	counts map[int64]map[models.UsageKey]models.Usage
+}
+
+// record counts the usage of an RPC that ended at
+func (m *usageMeter) record(at time.Time, key models.UsageKey, usage models.Usage) {
+	minute := at.Unix() / 60
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.counts == nil {
+		m.counts = make(map[int64]map[models.UsageKey]models.Usage)
+	}
+	if m.counts[minute] == nil {
+		m.counts[minute] = make(map[models.UsageKey]models.Usage)
+	}
+	counted := m.counts[minute][key]
+	counted.Add(usage)
+	m.counts[minute][key] = counted
+}
+
+// drain returns and clears the counts, by minute
+func (m *usageMeter) drain() map[int64]map[models.UsageKey]models.Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := m.counts
+	m.counts = nil
+	return counts
+}
+
+// usageEnabled reports whether API usage is accounted
+func (s *Server) usageEnabled() bool {
+	return s.config.UsageFlushInterval > 0
+}
+
+// unaryUsageInterceptor counts a unary RPC, its message bytes and whether
+// it failed against the principal making it, rejecting it if the principal
+// is over a quota counting it
+func (s *Server) unaryUsageInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	kind, principal := s.usagePrincipal(ctx, req)
+	key := models.UsageKey{Kind: kind, Principal: principal, Method: info.FullMethod}
+	if err := s.checkQuotas(ctx, key, time.Now()); err != nil {
+		s.usage.record(time.Now(), key, models.Usage{Calls: 1, Errors: 1, BytesIn: messageSize(req)})
+		if resp := errorResponse(info.FullMethod, err); resp != nil {
+			return resp, nil
+		}
+		return nil, err
+	}
+
+	resp, err := handler(ctx, req)
+
+	usage := models.Usage{Calls: 1, BytesIn: messageSize(req), BytesOut: messageSize(resp)}
+	if err != nil || responseFailed(resp) {
+		usage.Errors = 1
+	}
+	now := time.Now()
+	s.usage.record(now, key, usage)
+	s.countQuotas(ctx, key, usage, now)
+	return resp, err
+}
+
+// streamUsageInterceptor is unaryUsageInterceptor for streaming RPCs,
+// counting a stream as one call with the bytes of all its messages. A
+// stream the client ends is not an error. Quotas are checked and counted
+// message by message, failing the stream once its principal is over one.
+func (s *Server) streamUsageInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	stream := &usageStream{ServerStream: ss, s: s, method: info.FullMethod}
+	err := handler(srv, stream)
+
+	usage := models.Usage{Calls: 1, BytesIn: stream.bytesIn, BytesOut: stream.bytesOut}
+	if err != nil && ss.Context().Err() == nil {
+		usage.Errors = 1
+	}
+	if stream.key == nil {
+		kind, principal := s.usagePrincipal(ss.Context(), nil)
+		stream.key = &models.UsageKey{Kind: kind, Principal: principal, Method: info.FullMethod}
+	}
+	s.usage.record(time.Now(), *stream.key, usage)
+	return err
+}
+
+// meterHTTP serves an HTTP request standing for the gRPC method with req
+// as unaryUsageInterceptor serves the RPC: serve is only called if the
+// principal making the request is within the quotas counting method, and
+// the request is counted against it with the bytes served, failing if it
+// is answered with an error status
+func (s *Server) meterHTTP(w http.ResponseWriter, r *http.Request, method string, req proto.Message, serve func(w http.ResponseWriter)) {
+	if !s.usageEnabled() || s.config.UpstreamAddr != "" {
+		serve(w)
+		return
+	}
+
+	ctx := r.Context()
+	kind, principal := s.usagePrincipal(ctx, req)
+	key := models.UsageKey{Kind: kind, Principal: principal, Method: method}
+	if err := s.checkQuotas(ctx, key, time.Now()); err != nil {
+		s.usage.record(time.Now(), key, models.Usage{Calls: 1, Errors: 1, BytesIn: messageSize(req)})
+		writeHTTPError(w, err)
+		return
+	}
+
+	mw := &meteredResponseWriter{ResponseWriter: w, status: http.StatusOK}
+	serve(mw)
+
+	usage := models.Usage{Calls: 1, BytesIn: messageSize(req), BytesOut: mw.bytes}
+	if mw.status >= http.StatusBadRequest {
+		usage.Errors = 1
+	}
+	now := time.Now()
+	s.usage.record(now, key, usage)
+	s.countQuotas(ctx, key, usage, now)
+}
+
+// meteredResponseWriter records the status and counts the body bytes of an
+// HTTP response
+type meteredResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (m *meteredResponseWriter) WriteHeader(status int) {
+	m.status = status
+	m.ResponseWriter.WriteHeader(status)
+}
+
+func (m *meteredResponseWriter) Write(p []byte) (int, error) {
+	n, err := m.ResponseWriter.Write(p)
+	m.bytes += int64(n)
+	return n, err
+}
+
+func (m *meteredResponseWriter) Unwrap() http.ResponseWriter {
+	return m.ResponseWriter
+}
+
+// usageStream counts the bytes of the messages of a stream against the
+// principal making the call, told by the first message received
+type usageStream struct {
+	grpc.ServerStream
+	s        *Server
+	method   string
+	key      *models.UsageKey
+	bytesIn  int64
+	bytesOut int64
+}
+
+func (u *usageStream) RecvMsg(m any) error {
+	if err := u.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	now := time.Now()
+	if u.key == nil {
+		kind, principal := u.s.usagePrincipal(u.Context(), m)
+		u.key = &models.UsageKey{Kind: kind, Principal: principal, Method: u.method}
+	}
+	n := messageSize(m)
+	u.bytesIn += n
+	if err := u.s.checkQuotas(u.Context(), *u.key, now); err != nil {
+		return err
+	}
+	u.s.countQuotas(u.Context(), *u.key, models.Usage{BytesIn: n}, now)
+	return nil
+}
+
+func (u *usageStream) SendMsg(m any) error {
+	if err := u.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	n := messageSize(m)
+	u.bytesOut += n
+	if u.key != nil {
+		u.s.countQuotas(u.Context(), *u.key, models.Usage{BytesOut: n}, time.Now())
+	}
+	return nil
+}
+
+// usagePrincipal returns whom an RPC is accounted to: the agent or API key
+// of its token, else the client certificate, which is an API key if it is
+// an operator's, else the agent the request acts for. Other calls are
+// anonymous.
+func (s *Server) usagePrincipal(ctx context.Context, req any) (string, string) {
+	if identity, ok := ctx.Value(tokenIdentityKey{}).(*tokenIdentity); ok {
+		switch {
+		case identity.role == models.RoleAgent:
+			return models.UsagePrincipalAgent, identity.agentID
+		case identity.name != "":
+			return models.UsagePrincipalKey, identity.name
+		}
+		return models.UsagePrincipalKey, string(identity.role)
+	}
+	identity := peerIdentity(ctx)
+	if identity == "" {
+		// HTTP requests carry the client certificate in their context
+		identity, _ = ctx.Value(clientIdentityKey{}).(string)
+	}
+	if identity != "" {
+		if slices.Contains(s.config.TLSOperatorNames, identity) {
+			return models.UsagePrincipalKey, identity
+		}
+		return models.UsagePrincipalAgent, identity
+	}
+	if agentID, ok := requestAgentID(req); ok && agentID != "" {
+		return models.UsagePrincipalAgent, agentID
+	}
+	return models.UsagePrincipalAnonymous, ""
+}
+
+// messageSize returns the encoded size of a message, zero if it is none
+func messageSize(m any) int64 {
+	msg, ok := m.(proto.Message)
+	if !ok || !msg.ProtoReflect().IsValid() {
+		return 0
+	}
+	return int64(proto.Size(msg))
+}
+
+// responseFailed reports whether a v1 response reports an error in its
+// error_code or error field
+func responseFailed(resp any) bool {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return false
+	}
+	m := msg.ProtoReflect()
+	if !m.IsValid() {
+		return false
+	}
+	for _, name := range []protoreflect.Name{"error_code", "error"} {
+		field := m.Descriptor().Fields().ByName(name)
+		if field != nil && field.Kind() == protoreflect.StringKind && m.Get(field).String() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// runUsageFlusher periodically flushes the usage counted in memory and
+// checks it against quotas until ctx is done, then flushes what is left
+func (s *Server) runUsageFlusher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), usageFlushTimeout)
+			defer cancel()
+			s.flushUsage(flushCtx, time.Now())
+			s.flushQuotas(flushCtx, time.Now())
+			return
+		case <-ticker.C:
+			s.flushUsage(ctx, time.Now())
+			s.flushQuotas(ctx, time.Now())
+		}
+	}
+}
+
+// flushUsage adds the usage counted since the last flush to the usage
+// store, and exports it as metrics. Counts that cannot be stored are
+// dropped.
+func (s *Server) flushUsage(ctx context.Context, now time.Time) {
+	counts := s.usage.drain()
+	if len(counts) == 0 {
+		return
+	}
+
+	total := make(map[models.UsageKey]models.Usage)
+	for minute, usage := range counts {
+		if err := s.usageStore.RecordUsage(ctx, time.Unix(minute*60, 0), usage); err != nil {
+			log.Printf("Dropping API usage of %d principals and methods: %v", len(usage), err)
+		}
+		for key, u := range usage {
+			counted := total[key]
+			counted.Add(u)
+			total[key] = counted
+		}
+	}
+	s.exportSamples(usageSamples(total, now))
+}
+
+// usageSamples returns the metric samples of usage counted since the last
+// flush, labelled by principal and method
+func usageSamples(usage map[models.UsageKey]models.Usage, now time.Time) []metricSample {
+	var samples []metricSample
+	for key, u := range usage {
+		agentID := ""
+		if key.Kind == models.UsagePrincipalAgent {
+			agentID = key.Principal
+		}
+		labels := map[string]string{
+			"principal_kind": key.Kind,
+			"principal":      key.Principal,
+			"method":         key.Method,
+		}
+		for name, value := range map[string]int64{
+			"dbos_api_calls":     u.Calls,
+			"dbos_api_errors":    u.Errors,
+			"dbos_api_bytes_in":  u.BytesIn,
+			"dbos_api_bytes_out": u.BytesOut,
+		} {
+			samples = append(samples, metricSample{
+				Name:      name,
+				AgentID:   agentID,
+				Module:    usageMetricModule,
+				Labels:    labels,
+				Value:     float64(value),
+				Timestamp: now,
+			})
+		}
+	}
+	return samples
+}
+
+// GetUsage returns the RPCs made by each agent and API key over a trailing
+// window of whole minutes, ending with the current one. Usage is stored
+// every flush interval, so the latest calls may not be counted yet.
+func (s *Server) GetUsage(ctx context.Context, req *api.GetUsageRequest) (*api.GetUsageResponse, error) {
+	window := time.Duration(req.WindowSeconds) * time.Second
+	if window <= 0 {
+		window = defaultUsageWindow
+	}
+	minutes := (window + time.Minute - 1) / time.Minute
+	window = minutes * time.Minute
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultUsageLimit
+	}
+	limit = min(limit, maxUsageLimit)
+
+	now := time.Now()
+	usage, err := s.usageStore.GetUsage(ctx, now.Add(-window+time.Minute), now)
+	if err != nil {
+		return &api.GetUsageResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	totals := make(map[models.UsageKey]*models.Usage)
+	for key, u := range usage {
+		if req.PrincipalKind != "" && key.Kind != req.PrincipalKind {
+			continue
+		}
+		if req.Principal != "" && key.Principal != req.Principal {
+			continue
+		}
+		if !req.ByMethod {
+			key.Method = ""
+		}
+		if totals[key] == nil {
+			totals[key] = &models.Usage{}
+		}
+		totals[key].Add(*u)
+	}
+
+	entries := make([]*api.UsageEntry, 0, len(totals))
+	for key, u := range totals {
+		entries = append(entries, &api.UsageEntry{
+			PrincipalKind: key.Kind,
+			Principal:     key.Principal,
+			Method:        key.Method,
+			Calls:         u.Calls,
+			Errors:        u.Errors,
+			ErrorRate:     u.ErrorRate(),
+			BytesIn:       u.BytesIn,
+			BytesOut:      u.BytesOut,
+		})
+	}
+	sortUsageEntries(entries, req.OrderBy)
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return &api.GetUsageResponse{
+		Entries:       entries,
+		WindowSeconds: int64(window / time.Second),
+	}, nil
+}
+
+// sortUsageEntries orders usage entries by a counter, highest first, then
+// by principal and method
+func sortUsageEntries(entries []*api.UsageEntry, orderBy string) {
+	value := func(e *api.UsageEntry) float64 {
+		switch orderBy {
+		case "errors":
+			return float64(e.Errors)
+		case "error_rate":
+			return e.ErrorRate
+		case "bytes_in":
+			return float64(e.BytesIn)
+		case "bytes_out":
+			return float64(e.BytesOut)
+		}
+		return float64(e.Calls)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if va, vb := value(a), value(b); va != vb {
+			return va > vb
+		}
+		if a.PrincipalKind != b.PrincipalKind {
+			return a.PrincipalKind < b.PrincipalKind
+		}
+		if a.Principal != b.Principal {
+			return a.Principal < b.Principal
+		}
+		return a.Method < b.Method
+	})
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/internet-measurement-network/dbos/api/validate"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// unaryValidationInterceptor checks requests against the validation rules
+// of the API protos before they reach their handler. v1 methods report a
+// rejected request in the error fields of their response, like their
+// handlers do; v2 methods fail with INVALID_ARGUMENT.
+func unaryValidationInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return handler(ctx, req)
+	}
+	if err := validate.Message(msg); err != nil {
+		if resp := errorResponse(info.FullMethod, err); resp != nil {
+			return resp, nil
+		}
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamValidationInterceptor checks the request of server-streaming RPCs.
+// Messages of client streams are left to their handlers, which reject
+// them one by one without ending the stream.
+func streamValidationInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if info.IsClientStream {
+		return handler(srv, ss)
+	}
+	return handler(srv, &validatedStream{ServerStream: ss})
+}
+
+// validatedStream validates the messages received on a stream
+type validatedStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		return validate.Message(msg)
+	}
+	return nil
+}
+
+// errorResponse returns the response of a v1 method reporting err in its
+// error and error_code fields, or nil if the method's response has none
+func errorResponse(fullMethod string, err error) proto.Message {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(fullMethod, "/"), "/", "."))
+	desc, findErr := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if findErr != nil {
+		return nil
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil
+	}
+	output := method.Output()
+	errorField := output.Fields().ByName("error")
+	codeField := output.Fields().ByName("error_code")
+	if errorField == nil || codeField == nil || errorField.Kind() != protoreflect.StringKind || codeField.Kind() != protoreflect.StringKind {
+		return nil
+	}
+
+	respType, findErr := protoregistry.GlobalTypes.FindMessageByName(output.FullName())
+	if findErr != nil {
+		return nil
+	}
+	resp := respType.New()
+	resp.Set(errorField, protoreflect.ValueOfString(err.Error()))
+	resp.Set(codeField, protoreflect.ValueOfString(errorCode(err)))
+	return resp.Interface()
+}
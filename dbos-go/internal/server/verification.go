@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+)
+
+// ScheduleVerifiedTask schedules the same measurement on K distinct live
+// agents; the verification is marked verified once all their results agree
+func (s *Server) ScheduleVerifiedTask(ctx context.Context, req *api.ScheduleVerifiedTaskRequest) (*api.ScheduleVerifiedTaskResponse, error) {
+	v := req.Verification
+	if v == nil || v.ModuleName == "" || v.CompareField == "" {
+		return &api.ScheduleVerifiedTaskResponse{
+			Success:   false,
+			Error:     "verification requires a module name and a compare field",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+	if v.Replicas < 2 {
+		return &api.ScheduleVerifiedTaskResponse{
+			Success:   false,
+			Error:     "verification requires at least 2 replicas",
+			ErrorCode: string(dberrors.InvalidArgument),
+		}, nil
+	}
+
+	id := v.Id
+	if id == "" {
+		id = ids.New("verify-")
+	}
+	if _, err := s.verificationStore.GetVerification(ctx, id); err == nil {
+		return &api.ScheduleVerifiedTaskResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("verification %s already exists", id),
+			ErrorCode: string(dberrors.AlreadyExists),
+		}, nil
+	}
+
+	now := time.Now()
+	agentIDs, err := s.pickReplicaAgents(ctx, req.Selector, int(v.Replicas), now)
+	if err != nil {
+		return &api.ScheduleVerifiedTaskResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	verification := models.NewVerification(id, v.ModuleName, v.Payload, v.Replicas, v.CompareField, v.Tolerance)
+	verification.AgentIDs = agentIDs
+	for i := range agentIDs {
+		verification.TaskIDs = append(verification.TaskIDs, verification.ReplicaTaskID(i))
+	}
+	if err := s.verificationStore.SaveVerification(ctx, verification); err != nil {
+		return &api.ScheduleVerifiedTaskResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	for i, agentID := range agentIDs {
+		task := models.NewTask(verification.TaskIDs[i], agentID, v.ModuleName, v.Payload, now)
+		task.VerificationID = id
+		if err := s.taskStore.ScheduleTask(ctx, task); err != nil {
+			return &api.ScheduleVerifiedTaskResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+		s.recordTaskEvent(ctx, models.EventTaskScheduled, task)
+	}
+
+	return &api.ScheduleVerifiedTaskResponse{
+		Success:      true,
+		Verification: verificationToAPI(verification),
+	}, nil
+}
+
+// GetVerification retrieves a verification and its agreement status
+func (s *Server) GetVerification(ctx context.Context, req *api.GetVerificationRequest) (*api.GetVerificationResponse, error) {
+	verification, err := s.verificationStore.GetVerification(ctx, req.VerificationId)
+	if err != nil {
+		return &api.GetVerificationResponse{
+			Found:     false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.GetVerificationResponse{
+		Found:        true,
+		Verification: verificationToAPI(verification),
+	}, nil
+}
+
+// pickReplicaAgents selects n distinct live agents matching selector, most
+// recently seen first
+func (s *Server) pickReplicaAgents(ctx context.Context, selector map[string]string, n int, now time.Time) ([]string, error) {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var live []*models.Agent
+	for _, agent := range agents {
+		if !agent.Alive || now.Sub(agent.LastSeen) > s.config.AgentLivenessWindow {
+			continue
+		}
+		if !models.MatchesLabels(selector, agent.Labels) {
+			continue
+		}
+		live = append(live, agent)
+	}
+	if len(live) < n {
+		return nil, dberrors.New(dberrors.FailedPrecondition, "need %d independent live agents, only %d available", n, len(live))
+	}
+
+	sort.Slice(live, func(i, j int) bool {
+		return live[i].LastSeen.After(live[j].LastSeen)
+	})
+
+	agentIDs := make([]string, n)
+	for i := range agentIDs {
+		agentIDs[i] = live[i].ID
+	}
+	return agentIDs, nil
+}
+
+// recordVerificationResult feeds a result into the verification its task is
+// a replica of, if any
+func (s *Server) recordVerificationResult(ctx context.Context, result *models.MeasurementResult) {
+	if result.Origin != string(models.ResultOriginScheduled) {
+		return
+	}
+	task, err := s.taskStore.GetTask(ctx, result.ID)
+	if err != nil || task.VerificationID == "" {
+		return
+	}
+
+	verification, err := s.verificationStore.GetVerification(ctx, task.VerificationID)
+	if err != nil {
+		log.Printf("Verification %s: %v", task.VerificationID, err)
+		return
+	}
+
+	// A missing field is recorded as null so the replica still counts and
+	// cannot agree with replicas that did report a value
+	value := "null"
+	if raw, ok := jsonpath.Lookup(result.Data, verification.CompareField); ok {
+		if data, err := json.Marshal(raw); err == nil {
+			value = string(data)
+		}
+	}
+
+	verification, err = s.verificationStore.RecordValue(ctx, verification.ID, task.AgentID, value)
+	if err != nil {
+		log.Printf("Verification %s: %v", task.VerificationID, err)
+		return
+	}
+	if verification.Status == string(models.VerificationStatusDisagreed) {
+		log.Printf("Verification %s: replicas disagree on %s: %v", verification.ID, verification.CompareField, verification.Values)
+	}
+}
+
+// verificationToAPI converts a model verification into an API verification
+func verificationToAPI(v *models.Verification) *api.Verification {
+	return &api.Verification{
+		Id:           v.ID,
+		ModuleName:   v.ModuleName,
+		Payload:      v.Payload,
+		Replicas:     v.Replicas,
+		CompareField: v.CompareField,
+		Tolerance:    v.Tolerance,
+		AgentIds:     v.AgentIDs,
+		TaskIds:      v.TaskIDs,
+		Values:       v.Values,
+		Status:       v.Status,
+		CreatedAt:    v.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+)
+
+// CreateView defines a materialized view, optionally backfilling it from
+// already stored results
+func (s *Server) CreateView(ctx context.Context, req *api.CreateViewRequest) (*api.CreateViewResponse, error) {
+	view := models.NewView(req.View.Name, req.View.Kind, req.View.ModuleName, req.View.KeyField, req.View.ValueField)
+	if err := s.viewStore.CreateView(ctx, view); err != nil {
+		return &api.CreateViewResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	if req.Backfill {
+		if err := s.backfillView(ctx, view); err != nil {
+			return &api.CreateViewResponse{
+				Success:   false,
+				Error:     err.Error(),
+				ErrorCode: errorCode(err),
+			}, nil
+		}
+	}
+
+	return &api.CreateViewResponse{
+		Success: true,
+	}, nil
+}
+
+// ListViews retrieves all view definitions
+func (s *Server) ListViews(ctx context.Context, req *api.ListViewsRequest) (*api.ListViewsResponse, error) {
+	views, err := s.viewStore.ListViews(ctx)
+	if err != nil {
+		return &api.ListViewsResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiViews := make([]*api.View, len(views))
+	for i, view := range views {
+		apiViews[i] = &api.View{
+			Name:       view.Name,
+			Kind:       view.Kind,
+			ModuleName: view.ModuleName,
+			KeyField:   view.KeyField,
+			ValueField: view.ValueField,
+			CreatedAt:  view.CreatedAt.Unix(),
+		}
+	}
+
+	return &api.ListViewsResponse{
+		Views: apiViews,
+	}, nil
+}
+
+// DeleteView removes a view and its rows
+func (s *Server) DeleteView(ctx context.Context, req *api.DeleteViewRequest) (*api.DeleteViewResponse, error) {
+	err := s.viewStore.DeleteView(ctx, req.Name)
+	if err != nil {
+		return &api.DeleteViewResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	return &api.DeleteViewResponse{
+		Success: true,
+	}, nil
+}
+
+// QueryView retrieves the rows of a view
+func (s *Server) QueryView(ctx context.Context, req *api.QueryViewRequest) (*api.QueryViewResponse, error) {
+	day := req.Day
+	if day == "" {
+		day = time.Now().UTC().Format(models.ViewDayFormat)
+	}
+
+	rows, err := s.viewStore.QueryView(ctx, req.Name, req.AgentId, req.Key, day)
+	if err != nil {
+		return &api.QueryViewResponse{
+			Error:     err.Error(),
+			ErrorCode: errorCode(err),
+		}, nil
+	}
+
+	apiRows := make([]*api.ViewRow, len(rows))
+	for i, row := range rows {
+		apiRows[i] = &api.ViewRow{
+			AgentId:  row.AgentID,
+			Key:      row.Key,
+			Day:      row.Day,
+			ResultId: row.ResultID,
+			Data:     row.Data,
+			Count:    row.Count,
+			Sum:      row.Sum,
+			Min:      row.Min,
+			Max:      row.Max,
+			Avg:      row.Avg(),
+		}
+		if !row.Timestamp.IsZero() {
+			apiRows[i].Timestamp = row.Timestamp.Unix()
+		}
+	}
+
+	return &api.QueryViewResponse{
+		Rows: apiRows,
+	}, nil
+}
+
+// backfillView folds the stored results of every known agent into a new view
+func (s *Server) backfillView(ctx context.Context, view *models.View) error {
+	agents, err := s.agentStore.ListAgents(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, agent := range agents {
+		results, err := s.resultStore.ListResults(ctx, agent.ID)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			if err := s.viewStore.ApplyView(ctx, view, result); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
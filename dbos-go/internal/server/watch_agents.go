@@ -0,0 +1,94 @@
+package server
+
+import (
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// agentDeltaSnapshot and agentDeltaSnapshotEnd frame the initial inventory snapshot
+	agentDeltaSnapshot    = "snapshot"
+	agentDeltaSnapshotEnd = "snapshot_end"
+
+	// watchAgentsBlock is how long a single change-log read waits for new changes
+	watchAgentsBlock = 5 * time.Second
+)
+
+// WatchAgents streams the agent inventory: a full snapshot (unless resuming
+// from a revision) followed by incremental add/update/remove deltas
+func (s *Server) WatchAgents(req *api.WatchAgentsRequest, stream api.DBOS_WatchAgentsServer) error {
+	ctx := stream.Context()
+	revision := req.Revision
+
+	if revision == "" {
+		// Take the revision before listing so no change is missed; changes
+		// racing with the listing are re-delivered and must be applied idempotently
+		latest, err := s.events.LatestRevision(ctx)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "reading agent revision: %v", err)
+		}
+
+		agents, err := s.agentStore.ListAgents(ctx)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "listing agents: %v", err)
+		}
+		for _, agent := range agents {
+			if err := stream.Send(&api.AgentDelta{Type: agentDeltaSnapshot, Agent: agentToAPI(agent), Revision: latest}); err != nil {
+				return err
+			}
+		}
+		if err := stream.Send(&api.AgentDelta{Type: agentDeltaSnapshotEnd, Revision: latest}); err != nil {
+			return err
+		}
+		revision = latest
+	} else {
+		available, err := s.events.RevisionAvailable(ctx, revision)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "checking agent revision: %v", err)
+		}
+		if !available {
+			return status.Errorf(codes.OutOfRange, "revision %s has been compacted; watch again without a revision", revision)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		changes, err := s.events.ListChanges(ctx, revision, watchAgentsBlock)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return status.Errorf(codes.Unavailable, "reading agent changes: %v", err)
+		}
+
+		for _, change := range changes {
+			if err := stream.Send(agentChangeToAPI(change)); err != nil {
+				return err
+			}
+			revision = change.Revision
+		}
+	}
+}
+
+// agentChangeToAPI converts a model agent change into an API delta
+func agentChangeToAPI(change *models.AgentChange) *api.AgentDelta {
+	delta := &api.AgentDelta{
+		Type:     change.Type,
+		Revision: change.Revision,
+	}
+	if change.Agent != nil {
+		delta.Agent = agentToAPI(change.Agent)
+	} else {
+		delta.Agent = &api.Agent{Id: change.AgentID}
+	}
+	return delta
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchIncidents streams incident events: opened, updated, acknowledged,
+// commented, resolved and deleted incidents, from a revision or from now on
+func (s *Server) WatchIncidents(req *api.WatchIncidentsRequest, stream api.DBOS_WatchIncidentsServer) error {
+	ctx := stream.Context()
+	revision := req.Revision
+
+	if revision == "" {
+		latest, err := s.incidentStore.LatestRevision(ctx)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "reading incident revision: %v", err)
+		}
+		revision = latest
+	} else {
+		available, err := s.incidentStore.RevisionAvailable(ctx, revision)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "checking incident revision: %v", err)
+		}
+		if !available {
+			return status.Errorf(codes.OutOfRange, "revision %s has been compacted; list incidents and watch again without a revision", revision)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		events, err := s.incidentStore.ListEvents(ctx, revision, watchAgentsBlock)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return status.Errorf(codes.Unavailable, "reading incident events: %v", err)
+		}
+
+		for _, event := range events {
+			if err := stream.Send(incidentEventToAPI(event)); err != nil {
+				return err
+			}
+			revision = event.Revision
+		}
+	}
+}
+
+// incidentEventToAPI converts a model incident event into its API representation
+func incidentEventToAPI(event *models.IncidentEvent) *api.IncidentEvent {
+	apiEvent := &api.IncidentEvent{
+		Type:     event.Type,
+		Revision: event.Revision,
+	}
+	if event.Incident != nil {
+		apiEvent.Incident = incidentToAPI(event.Incident)
+	} else {
+		apiEvent.Incident = &api.Incident{Id: event.IncidentID}
+	}
+	return apiEvent
+}
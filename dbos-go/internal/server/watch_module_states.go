@@ -0,0 +1,57 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/internet-measurement-network/dbos/api"
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchModuleStates streams module states as they are written, from now
+// on, for an agent, a module or both. A watcher that falls behind is ended
+// with ResourceExhausted, to list the states it missed and watch again.
+func (s *Server) WatchModuleStates(req *api.WatchModuleStatesRequest, stream api.DBOS_WatchModuleStatesServer) error {
+	ctx := stream.Context()
+	sub, err := s.moduleStateStore.SubscribeModuleStates(ctx, req.AgentId, req.ModuleName)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "subscribing to module states: %v", err)
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case state, ok := <-sub.States():
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return status.Error(codes.ResourceExhausted, "module state notifications fell behind or failed; list module states and watch again")
+			}
+			if len(req.States) > 0 && !slices.Contains(req.States, state.State) {
+				continue
+			}
+			if err := stream.Send(moduleStateToAPI(state)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// moduleStateToAPI converts a model module state into its API representation
+func moduleStateToAPI(state *models.ModuleState) *api.ModuleState {
+	return &api.ModuleState{
+		AgentId:      state.AgentID,
+		ModuleName:   state.ModuleName,
+		State:        state.State,
+		ErrorMessage: state.ErrorMessage,
+		ErrorCode:    state.ErrorCode,
+		Details:      state.Details,
+		Timestamp:    state.Timestamp.Unix(),
+		RequestId:    state.RequestID,
+		Version:      state.Version,
+	}
+}
@@ -0,0 +1,280 @@
+package server
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/internet-measurement-network/dbos/api"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// webUIEventLimit is how many recent events the event tail starts with
+	webUIEventLimit = 50
+
+	// webUIWriteTimeout bounds sending one event to a UI client, so a stalled
+	// browser does not hold its event stream open forever
+	webUIWriteTimeout = 10 * time.Second
+)
+
+//go:embed webui
+var webUIFiles embed.FS
+
+// webUIQueue is the document of GET /api/queue: the tasks due and not yet
+// handed to their agent, by agent and by module
+type webUIQueue struct {
+	GeneratedAt      string            `json:"generated_at"`
+	Due              int               `json:"due"`
+	OldestDueSeconds int64             `json:"oldest_due_seconds"`
+	Agents           []webUIQueueEntry `json:"agents"`
+	Modules          []webUIQueueEntry `json:"modules"`
+}
+
+// webUIQueueEntry counts the due tasks of one agent or module
+type webUIQueueEntry struct {
+	Name             string `json:"name"`
+	Due              int    `json:"due"`
+	OldestDueSeconds int64  `json:"oldest_due_seconds"`
+}
+
+// newWebUIHandler returns the operator web UI: the embedded dashboard, and
+// the read-only JSON and WebSocket endpoints under /api/ it is built on,
+// which are served by the gRPC handlers. The dashboard's files are public;
+// with token authentication the endpoints require a token allowed to read,
+// which the dashboard asks for.
+func (s *Server) newWebUIHandler() http.Handler {
+	files, err := fs.Sub(webUIFiles, "webui")
+	if err != nil {
+		panic(err)
+	}
+
+	apiMux := http.NewServeMux()
+	apiMux.HandleFunc("GET /api/agents", s.handleWebUIAgents)
+	apiMux.HandleFunc("GET /api/agents/geojson", s.handleWebUIAgentGeoJSON)
+	apiMux.HandleFunc("GET /api/queue", s.handleWebUIQueue)
+	apiMux.HandleFunc("GET /api/campaigns", s.handleWebUICampaigns)
+	apiMux.HandleFunc("GET /api/events", s.handleWebUIEvents)
+	apiMux.HandleFunc("GET /api/events/tail", s.handleWebUIEventTail)
+	var apiHandler http.Handler = apiMux
+	if s.config.TokenAuth {
+		apiHandler = withWebSocketToken(s.withHTTPTokenAuth(apiMux))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /api/", withHTTPClientIdentity(apiHandler))
+	mux.Handle("GET /", http.FileServerFS(files))
+	return mux
+}
+
+// startWebUI serves the operator web UI on port, over TLS if the gRPC
+// server uses TLS
+func (s *Server) startWebUI(ctx context.Context, port string) {
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		log.Printf("Operator web UI not started: %v", err)
+		return
+	}
+	httpServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           s.newWebUIHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         tlsConfig,
+		// Shutdown leaves hijacked connections be, so event tails end with ctx
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	log.Printf("Starting operator web UI on port %s", port)
+	serve := httpServer.ListenAndServe
+	if tlsConfig != nil {
+		serve = func() error {
+			return httpServer.ListenAndServeTLS("", "")
+		}
+	}
+	if err := s.serveHTTP(ctx, httpServer, serve); err != nil {
+		log.Printf("Operator web UI stopped: %v", err)
+	}
+}
+
+// withWebSocketToken takes the API token of a WebSocket handshake from its
+// access_token query parameter, as browsers cannot set its authorization
+// header
+func withWebSocketToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.URL.Query().Get("access_token"); token != "" && websocket.IsWebSocketUpgrade(r) && r.Header.Get("Authorization") == "" {
+			r = r.Clone(r.Context())
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleWebUIAgents serves GET /api/agents, every agent ordered by ID
+func (s *Server) handleWebUIAgents(w http.ResponseWriter, r *http.Request) {
+	resp, _ := s.ListAgents(r.Context(), &api.ListAgentsRequest{OrderBy: "id"})
+	writeProtoJSON(w, resp)
+}
+
+// handleWebUIAgentGeoJSON serves GET /api/agents/geojson, the GeoJSON of
+// GetAgentGeoJSON of the agents with every ?label=key=value, and with
+// ?incidents=true the active incidents
+func (s *Server) handleWebUIAgentGeoJSON(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &api.GetAgentGeoJSONRequest{IncludeIncidents: query.Get("incidents") == "true"}
+	for _, label := range query["label"] {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			writeHTTPError(w, dberrors.New(dberrors.InvalidArgument, "invalid label %q, expected key=value", label))
+			return
+		}
+		if req.Selector == nil {
+			req.Selector = make(map[string]string)
+		}
+		req.Selector[key] = value
+	}
+
+	resp, _ := s.GetAgentGeoJSON(r.Context(), req)
+	if resp.Error != "" {
+		writeProtoJSON(w, resp)
+		return
+	}
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.Header().Set("X-Unlocated-Agents", strconv.Itoa(int(resp.UnlocatedAgents)))
+	w.Write(resp.Geojson)
+}
+
+// handleWebUICampaigns serves GET /api/campaigns, optionally of one ?status
+func (s *Server) handleWebUICampaigns(w http.ResponseWriter, r *http.Request) {
+	resp, _ := s.ListCampaigns(r.Context(), &api.ListCampaignsRequest{Status: r.URL.Query().Get("status")})
+	writeProtoJSON(w, resp)
+}
+
+// handleWebUIEvents serves GET /api/events, the most recent events
+func (s *Server) handleWebUIEvents(w http.ResponseWriter, r *http.Request) {
+	resp, _ := s.GetEvents(r.Context(), &api.GetEventsRequest{Limit: webUIEventLimit})
+	writeProtoJSON(w, resp)
+}
+
+// handleWebUIQueue serves GET /api/queue
+func (s *Server) handleWebUIQueue(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	tasks, err := s.taskStore.ListDueTasks(r.Context(), "", now, 0)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	queue := webUIQueue{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Due:         len(tasks),
+	}
+	agents := make(map[string]*webUIQueueEntry)
+	modules := make(map[string]*webUIQueueEntry)
+	count := func(entries map[string]*webUIQueueEntry, name string, age int64) {
+		entry, ok := entries[name]
+		if !ok {
+			entry = &webUIQueueEntry{Name: name}
+			entries[name] = entry
+		}
+		entry.Due++
+		entry.OldestDueSeconds = max(entry.OldestDueSeconds, age)
+	}
+	for _, task := range tasks {
+		age := int64(now.Sub(task.ScheduledAt) / time.Second)
+		queue.OldestDueSeconds = max(queue.OldestDueSeconds, age)
+		count(agents, task.AgentID, age)
+		count(modules, task.ModuleName, age)
+	}
+	queue.Agents = sortedQueueEntries(agents)
+	queue.Modules = sortedQueueEntries(modules)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(queue)
+}
+
+// sortedQueueEntries lists queue entries with the most due tasks first
+func sortedQueueEntries(entries map[string]*webUIQueueEntry) []webUIQueueEntry {
+	list := make([]webUIQueueEntry, 0, len(entries))
+	for _, entry := range entries {
+		list = append(list, *entry)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Due != list[j].Due {
+			return list[i].Due > list[j].Due
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// webUIUpgrader accepts WebSocket handshakes from the UI's own origin only
+var webUIUpgrader = websocket.Upgrader{}
+
+// handleWebUIEventTail serves GET /api/events/tail, a WebSocket sending the
+// events appended to the event log from now on, one JSON event per message,
+// as StreamEvents streams them. Events of one ?type and ?agent_id only may
+// be asked for.
+func (s *Server) handleWebUIEventTail(w http.ResponseWriter, r *http.Request) {
+	req := &api.StreamEventsRequest{AgentId: r.URL.Query().Get("agent_id")}
+	if eventType := r.URL.Query().Get("type"); eventType != "" {
+		req.Types = []string{eventType}
+	}
+
+	conn, err := webUIUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader answered the handshake with the error
+		return
+	}
+	defer conn.Close()
+
+	// The client sends nothing; reading notices when it goes away
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = s.StreamEvents(req, &webSocketEventStream{ctx: ctx, conn: conn})
+	if err != nil && ctx.Err() == nil {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error()), time.Now().Add(webUIWriteTimeout))
+	}
+}
+
+// webSocketEventStream is the server stream of StreamEvents over a
+// WebSocket; StreamEvents only uses its context and Send
+type webSocketEventStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	conn *websocket.Conn
+}
+
+func (s *webSocketEventStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *webSocketEventStream) Send(event *api.Event) error {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(webUIWriteTimeout))
+	return s.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+var _ api.DBOS_StreamEventsServer = (*webSocketEventStream)(nil)
@@ -0,0 +1,222 @@
+// Operator dashboard of DBOS: polls the /api/ endpoints of the web UI server
+// and tails the event log over a WebSocket.
+"use strict";
+
+const refreshMs = 10000;
+const maxEvents = 200;
+const tokenKey = "dbos-token";
+
+let token = sessionStorage.getItem(tokenKey) || "";
+
+// askToken prompts for an API token, for servers with token authentication
+function askToken() {
+  const entered = prompt("API token (operator or read-only)", token);
+  if (entered === null) {
+    return false;
+  }
+  token = entered.trim();
+  sessionStorage.setItem(tokenKey, token);
+  document.getElementById("token").hidden = token === "";
+  return true;
+}
+
+// get fetches a JSON endpoint, asking for a token when one is required
+async function get(path) {
+  for (;;) {
+    const headers = token ? { Authorization: "Bearer " + token } : {};
+    const resp = await fetch(path, { headers });
+    if ((resp.status === 401 || resp.status === 403) && askToken()) {
+      continue;
+    }
+    const body = await resp.json();
+    if (!resp.ok || body.error) {
+      throw new Error(body.error || resp.statusText);
+    }
+    return body;
+  }
+}
+
+// el creates an element with text content and a class
+function el(tag, text, className) {
+  const node = document.createElement(tag);
+  if (text !== undefined) {
+    node.textContent = text;
+  }
+  if (className) {
+    node.className = className;
+  }
+  return node;
+}
+
+// fillTable replaces the rows of a table's body
+function fillTable(id, rows, empty) {
+  const body = document.querySelector("#" + id + " tbody");
+  body.replaceChildren();
+  if (rows.length === 0) {
+    const tr = el("tr");
+    const td = el("td", empty, "muted");
+    td.colSpan = document.querySelectorAll("#" + id + " th").length;
+    tr.append(td);
+    body.append(tr);
+    return;
+  }
+  for (const cells of rows) {
+    const tr = el("tr");
+    for (const cell of cells) {
+      tr.append(cell instanceof Node ? cell : el("td", cell));
+    }
+    body.append(tr);
+  }
+}
+
+// ago renders how long ago a Unix time in seconds was
+function ago(unix) {
+  const seconds = Math.max(0, Math.round(Date.now() / 1000 - Number(unix)));
+  return duration(seconds) + " ago";
+}
+
+// duration renders a number of seconds
+function duration(seconds) {
+  if (seconds < 60) return seconds + "s";
+  if (seconds < 3600) return Math.floor(seconds / 60) + "m";
+  if (seconds < 86400) return Math.floor(seconds / 3600) + "h";
+  return Math.floor(seconds / 86400) + "d";
+}
+
+function renderAgents(agents) {
+  const regions = new Map();
+  for (const agent of agents) {
+    const region = (agent.labels && agent.labels.region) || "unlabeled";
+    const counts = regions.get(region) || { alive: 0, total: 0 };
+    counts.total++;
+    if (agent.alive) counts.alive++;
+    regions.set(region, counts);
+  }
+
+  const map = document.getElementById("map");
+  map.replaceChildren();
+  for (const [region, counts] of [...regions].sort()) {
+    let state = "";
+    if (counts.alive === 0) state = " down";
+    else if (counts.alive < counts.total) state = " degraded";
+    const tile = el("div", undefined, "tile" + state);
+    tile.append(el("strong", region), el("span", counts.alive + " / " + counts.total + " alive"));
+    map.append(tile);
+  }
+  if (regions.size === 0) {
+    map.append(el("p", "No agents registered", "muted"));
+  }
+
+  fillTable("agents", agents.map((agent) => {
+    const labels = Object.entries(agent.labels || {}).map(([k, v]) => k + "=" + v).join(", ");
+    return [
+      agent.id,
+      agent.hostname || "",
+      (agent.labels && agent.labels.region) || "",
+      el("td", agent.alive ? "alive" : "dead", agent.alive ? "alive" : "dead"),
+      agent.last_seen ? ago(agent.last_seen) : "never",
+      el("td", labels, "wrap"),
+    ];
+  }), "No agents registered");
+}
+
+function renderQueue(queue) {
+  document.getElementById("queue-summary").textContent = queue.due === 0
+    ? "No tasks due."
+    : queue.due + " tasks due, the oldest for " + duration(queue.oldest_due_seconds) + ".";
+  const rows = (entries) => entries.slice(0, 10).map((e) => [e.name || "(any)", String(e.due), duration(e.oldest_due_seconds)]);
+  fillTable("queue-agents", rows(queue.agents), "Nothing queued");
+  fillTable("queue-modules", rows(queue.modules), "Nothing queued");
+}
+
+function renderCampaigns(campaigns) {
+  campaigns.sort((a, b) => Number(b.created_at || 0) - Number(a.created_at || 0));
+  fillTable("campaigns", campaigns.map((c) => {
+    const issued = Number(c.tasks_issued || 0);
+    const received = Number(c.results_received || 0);
+    const progress = el("td");
+    const bar = el("div", undefined, "progress");
+    const fill = el("div");
+    fill.style.width = (issued ? Math.min(100, (100 * received) / issued) : 0) + "%";
+    bar.append(fill);
+    progress.append(bar, document.createTextNode(received + " / " + issued));
+    return [c.name || c.id, c.module_name, c.status, String(c.rounds || 0), progress];
+  }), "No campaigns");
+}
+
+// eventRow renders an event as a table row
+function eventRow(event) {
+  const tr = el("tr");
+  tr.append(
+    el("td", new Date(Number(event.timestamp) * 1000).toLocaleTimeString()),
+    el("td", event.type),
+    el("td", event.severity || "info", event.severity),
+    el("td", event.subject || "", "wrap"),
+    el("td", event.actor || ""),
+  );
+  return tr;
+}
+
+// tailEvents streams new events into the event table, reconnecting when the
+// stream drops
+function tailEvents() {
+  const state = document.getElementById("tail-state");
+  const url = new URL("api/events/tail", location.href);
+  url.protocol = location.protocol === "https:" ? "wss:" : "ws:";
+  if (token) {
+    url.searchParams.set("access_token", token);
+  }
+
+  const socket = new WebSocket(url);
+  socket.onopen = () => { state.textContent = "live"; };
+  socket.onmessage = (message) => {
+    const body = document.querySelector("#events tbody");
+    body.querySelector(".muted")?.parentElement.remove();
+    body.prepend(eventRow(JSON.parse(message.data)));
+    while (body.children.length > maxEvents) {
+      body.lastElementChild.remove();
+    }
+  };
+  socket.onclose = () => {
+    state.textContent = "reconnecting";
+    setTimeout(tailEvents, refreshMs);
+  };
+}
+
+async function refresh() {
+  const updated = document.getElementById("updated");
+  try {
+    const [agents, queue, campaigns] = await Promise.all([
+      get("api/agents"),
+      get("api/queue"),
+      get("api/campaigns"),
+    ]);
+    renderAgents(agents.agents || []);
+    renderQueue(queue);
+    renderCampaigns(campaigns.campaigns || []);
+    updated.textContent = "Updated " + new Date().toLocaleTimeString();
+  } catch (err) {
+    updated.textContent = "Update failed: " + err.message;
+  }
+}
+
+async function start() {
+  document.getElementById("token").hidden = token === "";
+  document.getElementById("token").onclick = () => { askToken() && refresh(); };
+
+  await refresh();
+  try {
+    const events = await get("api/events");
+    fillTable("events", [], "No events yet");
+    const body = document.querySelector("#events tbody");
+    if ((events.events || []).length > 0) {
+      body.replaceChildren(...events.events.map(eventRow));
+    }
+  } catch (err) {
+    fillTable("events", [], "Events unavailable: " + err.message);
+  }
+  tailEvents();
+  setInterval(refresh, refreshMs);
+}
+
+start();
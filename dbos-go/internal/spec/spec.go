@@ -0,0 +1,144 @@
+// Package spec parses the declarative specs ApplySpec and dbosctl apply
+// take: named target lists, campaigns and recurring tasks, in YAML or JSON.
+// Names identify what a spec defines, so applying an edited spec again
+// updates what an earlier one created rather than adding to it.
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is a set of target lists, campaigns and recurring tasks
+type Spec struct {
+	TargetLists []TargetList `yaml:"target_lists"`
+	Campaigns   []Campaign   `yaml:"campaigns"`
+	Tasks       []Task       `yaml:"tasks"`
+}
+
+// TargetList is a named list of targets campaigns can measure
+type TargetList struct {
+	Name    string   `yaml:"name"`
+	Targets []string `yaml:"targets"`
+}
+
+// Campaign defines a campaign, whose ID is its name. Its targets are those
+// it lists and those of its target list.
+type Campaign struct {
+	Name            string            `yaml:"name"`
+	Description     string            `yaml:"description"`
+	Module          string            `yaml:"module"`
+	Payload         map[string]any    `yaml:"payload"`
+	Targets         []string          `yaml:"targets"`
+	TargetList      string            `yaml:"target_list"`
+	TargetField     string            `yaml:"target_field"`
+	AgentIDs        []string          `yaml:"agent_ids"`
+	Selector        map[string]string `yaml:"selector"`
+	IntervalSeconds int64             `yaml:"interval_seconds"`
+	StartsAt        time.Time         `yaml:"starts_at"`
+	EndsAt          time.Time         `yaml:"ends_at"`
+}
+
+// Task defines a recurring task, a continuous task whose ID is its name,
+// run every interval by one agent or by each agent matching a selector
+type Task struct {
+	Name            string            `yaml:"name"`
+	Module          string            `yaml:"module"`
+	Payload         map[string]any    `yaml:"payload"`
+	AgentID         string            `yaml:"agent_id"`
+	Selector        map[string]string `yaml:"selector"`
+	IntervalSeconds int64             `yaml:"interval_seconds"`
+	StartsAt        time.Time         `yaml:"starts_at"`
+}
+
+// Parse decodes and validates a spec. Unknown fields are rejected, so a
+// misspelled one is not silently ignored.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// Validate checks that everything in the spec has a unique valid name and
+// the fields it needs. Whether the target lists campaigns name exist is
+// left to applying the spec, as they may have been applied before.
+func (s *Spec) Validate() error {
+	if len(s.TargetLists) == 0 && len(s.Campaigns) == 0 && len(s.Tasks) == 0 {
+		return fmt.Errorf("spec defines nothing")
+	}
+	if err := uniqueNames("target list", len(s.TargetLists), func(i int) string { return s.TargetLists[i].Name }); err != nil {
+		return err
+	}
+	if err := uniqueNames("campaign", len(s.Campaigns), func(i int) string { return s.Campaigns[i].Name }); err != nil {
+		return err
+	}
+	if err := uniqueNames("task", len(s.Tasks), func(i int) string { return s.Tasks[i].Name }); err != nil {
+		return err
+	}
+
+	for _, list := range s.TargetLists {
+		if len(list.Targets) == 0 {
+			return fmt.Errorf("target list %s has no targets", list.Name)
+		}
+	}
+	for _, campaign := range s.Campaigns {
+		if campaign.Module == "" {
+			return fmt.Errorf("campaign %s needs a module", campaign.Name)
+		}
+		if len(campaign.Targets) == 0 && campaign.TargetList == "" {
+			return fmt.Errorf("campaign %s needs targets or a target list", campaign.Name)
+		}
+	}
+	for _, task := range s.Tasks {
+		if task.Module == "" {
+			return fmt.Errorf("task %s needs a module", task.Name)
+		}
+		if (task.AgentID == "") == (len(task.Selector) == 0) {
+			return fmt.Errorf("task %s needs either an agent_id or a selector", task.Name)
+		}
+		if task.AgentID != "" {
+			if err := ids.Validate(task.AgentID); err != nil {
+				return fmt.Errorf("task %s: invalid agent_id: %w", task.Name, err)
+			}
+		}
+		if task.IntervalSeconds <= 0 {
+			return fmt.Errorf("task %s needs a positive interval_seconds", task.Name)
+		}
+	}
+	return nil
+}
+
+// uniqueNames checks that n things of a kind have valid names, none twice
+func uniqueNames(kind string, n int, name func(int) string) error {
+	seen := make(map[string]bool, n)
+	for i := 0; i < n; i++ {
+		if err := ids.Validate(name(i)); err != nil {
+			return fmt.Errorf("%s name %q: %w", kind, name(i), err)
+		}
+		if seen[name(i)] {
+			return fmt.Errorf("%s %s is defined twice", kind, name(i))
+		}
+		seen[name(i)] = true
+	}
+	return nil
+}
+
+// EncodePayload returns a payload as JSON, or nil if there is none
+func EncodePayload(payload map[string]any) ([]byte, error) {
+	if len(payload) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(payload)
+}
@@ -2,9 +2,15 @@ package store
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
 	"github.com/internet-measurement-network/dbos/pkg/redis"
 )
 
@@ -20,9 +26,120 @@ func NewAgentStore(redis *redis.Client) *AgentStore {
 	}
 }
 
-// RegisterAgent stores an agent in the database
+// RegisterAgent stores an agent in the database and records the change
 func (s *AgentStore) RegisterAgent(ctx context.Context, agent *models.Agent) error {
-	return s.redis.SetAgent(ctx, agent.ID, agent)
+	changeType := models.AgentChangeAdd
+	if _, err := s.redis.GetAgent(ctx, agent.ID); err == nil {
+		changeType = models.AgentChangeUpdate
+	}
+
+	if err := s.redis.SetAgent(ctx, agent.ID, agent); err != nil {
+		return err
+	}
+	if err := s.redis.IndexAgentOrder(ctx, agent.ID, agentOrderValues(agent)); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(agent)
+	if err != nil {
+		return err
+	}
+	_, err = s.redis.AppendAgentChange(ctx, string(changeType), agent.ID, data)
+	return err
+}
+
+// RecordHeartbeat marks an agent alive and seen now, registering it if
+// unknown. The agent is updated atomically, so concurrent heartbeats all
+// count; only registering it, reviving it or changing its hostname is
+// recorded as a change, not every heartbeat.
+func (s *AgentStore) RecordHeartbeat(ctx context.Context, agentID, hostname string, now time.Time) (*models.Agent, error) {
+	var agent *models.Agent
+	var changeType models.AgentChangeEnum
+	var data []byte
+	_, err := s.redis.UpdateAgent(ctx, agentID, func(stored []byte) ([]byte, map[string]string, error) {
+		changeType = ""
+		if stored == nil {
+			agent = models.NewAgent(agentID, hostname)
+			agent.FirstSeen = now
+			changeType = models.AgentChangeAdd
+		} else {
+			agent = &models.Agent{}
+			if err := json.Unmarshal(stored, agent); err != nil {
+				return nil, nil, err
+			}
+			if !agent.Alive || (hostname != "" && hostname != agent.Hostname) {
+				changeType = models.AgentChangeUpdate
+			}
+		}
+		if hostname != "" {
+			agent.Hostname = hostname
+		}
+		agent.Alive = true
+		agent.LastSeen = now
+		agent.TotalHeartbeats++
+
+		var err error
+		data, err = json.Marshal(agent)
+		return data, agentOrderValues(agent), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if changeType != "" {
+		if _, err := s.redis.AppendAgentChange(ctx, string(changeType), agentID, data); err != nil {
+			return nil, err
+		}
+	}
+	return agent, nil
+}
+
+// MarkDead marks an agent dead if it is still alive and was last seen
+// before cutoff, reporting whether it did. The check and the update are
+// atomic, so a heartbeat racing the sweep wins.
+func (s *AgentStore) MarkDead(ctx context.Context, agentID string, cutoff time.Time) (bool, error) {
+	var data []byte
+	marked, err := s.redis.UpdateAgent(ctx, agentID, func(stored []byte) ([]byte, map[string]string, error) {
+		data = nil
+		if stored == nil {
+			return nil, nil, dberrors.New(dberrors.NotFound, "agent %s not found", agentID)
+		}
+		var agent models.Agent
+		if err := json.Unmarshal(stored, &agent); err != nil {
+			return nil, nil, err
+		}
+		if !agent.Alive || !agent.LastSeen.Before(cutoff) {
+			return nil, nil, nil
+		}
+
+		agent.Alive = false
+		var err error
+		data, err = json.Marshal(&agent)
+		return data, agentOrderValues(&agent), err
+	})
+	if err != nil || !marked {
+		return false, err
+	}
+	if _, err := s.redis.AppendAgentChange(ctx, string(models.AgentChangeUpdate), agentID, data); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// DeleteAgent removes an agent from the database and records the change
+func (s *AgentStore) DeleteAgent(ctx context.Context, agentID string) error {
+	if _, err := s.redis.GetAgent(ctx, agentID); err != nil {
+		return err
+	}
+
+	if err := s.redis.DeleteAgent(ctx, agentID); err != nil {
+		return err
+	}
+	if err := s.redis.UnindexAgentOrder(ctx, agentID, models.AgentOrderFields); err != nil {
+		return err
+	}
+
+	_, err := s.redis.AppendAgentChange(ctx, string(models.AgentChangeRemove), agentID, nil)
+	return err
 }
 
 // GetAgent retrieves an agent from the database
@@ -47,6 +164,70 @@ func (s *AgentStore) ListAgents(ctx context.Context) ([]*models.Agent, error) {
 		return nil, err
 	}
 
+	return decodeAgents(agentsData), nil
+}
+
+// ListAgentsPage retrieves a page of about pageSize agents starting at
+// cursor, an empty cursor starting from the beginning. It returns the cursor
+// of the next page, or an empty cursor after the last page.
+func (s *AgentStore) ListAgentsPage(ctx context.Context, cursor string, pageSize int) ([]*models.Agent, string, error) {
+	var position uint64
+	if cursor != "" {
+		var err error
+		position, err = strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, "", dberrors.New(dberrors.InvalidArgument, "invalid cursor %q", cursor)
+		}
+	}
+
+	agentsData, next, err := s.redis.ScanAgents(ctx, position, int64(pageSize))
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if next != 0 {
+		nextCursor = strconv.FormatUint(next, 10)
+	}
+	return decodeAgents(agentsData), nextCursor, nil
+}
+
+// ListAgentsOrdered retrieves up to pageSize agents in an order starting
+// after cursor, or all of them if pageSize is 0. It returns the cursor of
+// the next page, or an empty cursor after the last page.
+func (s *AgentStore) ListAgentsOrdered(ctx context.Context, order models.Order, cursor string, pageSize int) ([]*models.Agent, string, error) {
+	if !slices.Contains(models.AgentOrderFields, order.Field) {
+		return nil, "", dberrors.New(dberrors.InvalidArgument, "agents cannot be ordered by %q", order.Field)
+	}
+	after, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, "", dberrors.New(dberrors.InvalidArgument, "invalid cursor %q", cursor)
+	}
+
+	// Reading one more than a page tells whether another page follows
+	count := int64(0)
+	if pageSize > 0 {
+		count = int64(pageSize) + 1
+	}
+	members, err := s.redis.RangeAgentOrder(ctx, order.Field, string(after), order.Desc, count)
+	if err != nil {
+		return nil, "", err
+	}
+	nextCursor := ""
+	if pageSize > 0 && len(members) > pageSize {
+		members = members[:pageSize]
+		nextCursor = base64.RawURLEncoding.EncodeToString([]byte(members[pageSize-1]))
+	}
+
+	agentIDs := make([]string, len(members))
+	for i, member := range members {
+		agentIDs[i] = redis.AgentOrderID(member)
+	}
+	agentsData, err := s.redis.GetAgents(ctx, agentIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
 	agents := make([]*models.Agent, 0, len(agentsData))
 	for _, data := range agentsData {
 		var agent models.Agent
@@ -55,6 +236,29 @@ func (s *AgentStore) ListAgents(ctx context.Context) ([]*models.Agent, error) {
 		}
 		agents = append(agents, &agent)
 	}
+	return agents, nextCursor, nil
+}
+
+// agentOrderValues returns an agent's sortable value of each field agents
+// can be ordered by
+func agentOrderValues(agent *models.Agent) map[string]string {
+	return map[string]string{
+		models.AgentOrderID:       agent.ID,
+		models.AgentOrderHostname: agent.Hostname,
+		// Zero padded, so that times sort as strings
+		models.AgentOrderLastSeen: fmt.Sprintf("%020d", max(agent.LastSeen.Unix(), 0)),
+	}
+}
 
-	return agents, nil
+// decodeAgents decodes agents keyed by their Redis key, skipping malformed ones
+func decodeAgents(agentsData map[string][]byte) []*models.Agent {
+	agents := make([]*models.Agent, 0, len(agentsData))
+	for _, data := range agentsData {
+		var agent models.Agent
+		if err := json.Unmarshal(data, &agent); err != nil {
+			continue
+		}
+		agents = append(agents, &agent)
+	}
+	return agents
 }
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// AlertRuleStore manages alert rule definitions and the states of their
+// active series
+type AlertRuleStore struct {
+	redis *redis.Client
+}
+
+// NewAlertRuleStore creates a new alert rule store
+func NewAlertRuleStore(redis *redis.Client) *AlertRuleStore {
+	return &AlertRuleStore{
+		redis: redis,
+	}
+}
+
+// CreateRule stores a new alert rule. The view or saved query its
+// expression refers to is checked by the caller.
+func (s *AlertRuleStore) CreateRule(ctx context.Context, rule *models.AlertRule) error {
+	if rule.Name == "" {
+		return dberrors.New(dberrors.InvalidArgument, "alert rule name is required")
+	}
+	if _, err := models.ParseAlertExpr(rule.Expr); err != nil {
+		return err
+	}
+	if rule.ForSeconds < 0 {
+		return dberrors.New(dberrors.InvalidArgument, "for duration must not be negative")
+	}
+	switch models.AlertSeverityEnum(rule.Severity) {
+	case models.AlertSeverityInfo, models.AlertSeverityWarning, models.AlertSeverityCritical:
+	default:
+		return dberrors.New(dberrors.InvalidArgument, "unknown severity %q", rule.Severity)
+	}
+
+	if _, err := s.GetRule(ctx, rule.Name); err == nil {
+		return dberrors.New(dberrors.AlreadyExists, "alert rule %s already exists", rule.Name)
+	}
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetAlertRule(ctx, rule.Name, data)
+}
+
+// GetRule retrieves an alert rule by name
+func (s *AlertRuleStore) GetRule(ctx context.Context, name string) (*models.AlertRule, error) {
+	rules, err := s.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.Name == name {
+			return rule, nil
+		}
+	}
+	return nil, dberrors.New(dberrors.NotFound, "alert rule %s not found", name)
+}
+
+// ListRules retrieves all alert rules, ordered by name
+func (s *AlertRuleStore) ListRules(ctx context.Context) ([]*models.AlertRule, error) {
+	data, err := s.redis.GetAlertRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*models.AlertRule, 0, len(data))
+	for _, raw := range data {
+		var rule models.AlertRule
+		if err := json.Unmarshal([]byte(raw), &rule); err != nil {
+			continue
+		}
+		rules = append(rules, &rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+
+	return rules, nil
+}
+
+// DeleteRule removes an alert rule and the states of its series
+func (s *AlertRuleStore) DeleteRule(ctx context.Context, name string) error {
+	found, err := s.redis.DeleteAlertRule(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return dberrors.New(dberrors.NotFound, "alert rule %s not found", name)
+	}
+	return nil
+}
+
+// ListSeries retrieves the states of the active series of an alert rule,
+// ordered by series
+func (s *AlertRuleStore) ListSeries(ctx context.Context, name string) ([]*models.AlertSeriesState, error) {
+	data, err := s.redis.GetAlertSeriesStates(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*models.AlertSeriesState, 0, len(data))
+	for _, raw := range data {
+		var state models.AlertSeriesState
+		if err := json.Unmarshal([]byte(raw), &state); err != nil {
+			continue
+		}
+		states = append(states, &state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Series < states[j].Series
+	})
+
+	return states, nil
+}
+
+// SaveSeries stores the state of an active series of an alert rule
+func (s *AlertRuleStore) SaveSeries(ctx context.Context, name string, state *models.AlertSeriesState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetAlertSeriesState(ctx, name, state.Series, data)
+}
+
+// DeleteSeries removes the state of a series of an alert rule
+func (s *AlertRuleStore) DeleteSeries(ctx context.Context, name, series string) error {
+	return s.redis.DeleteAlertSeriesState(ctx, name, series)
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// maxAlerts bounds how many of the newest alerts are kept
+const maxAlerts = 10000
+
+// AlertStore manages alert persistence
+type AlertStore struct {
+	redis *redis.Client
+}
+
+// NewAlertStore creates a new alert store
+func NewAlertStore(redis *redis.Client) *AlertStore {
+	return &AlertStore{
+		redis: redis,
+	}
+}
+
+// RecordAlert stores an alert
+func (s *AlertStore) RecordAlert(ctx context.Context, alert *models.Alert) error {
+	return s.redis.AppendAlert(ctx, alert, maxAlerts)
+}
+
+// ListAlerts retrieves up to limit alerts, newest first, optionally
+// restricted to an agent and an alert type
+func (s *AlertStore) ListAlerts(ctx context.Context, agentID, alertType string, limit int) ([]*models.Alert, error) {
+	alertsData, err := s.redis.GetAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]*models.Alert, 0)
+	for _, data := range alertsData {
+		var alert models.Alert
+		if err := json.Unmarshal([]byte(data), &alert); err != nil {
+			continue
+		}
+		if agentID != "" && alert.AgentID != agentID {
+			continue
+		}
+		if alertType != "" && alert.Type != alertType {
+			continue
+		}
+		alerts = append(alerts, &alert)
+		if limit > 0 && len(alerts) == limit {
+			break
+		}
+	}
+
+	return alerts, nil
+}
+
+// CTVerdict retrieves whether a certificate fingerprint was last found in CT logs
+func (s *AlertStore) CTVerdict(ctx context.Context, fingerprint string) (logged bool, found bool, err error) {
+	return s.redis.GetCTVerdict(ctx, fingerprint)
+}
+
+// SaveCTVerdict caches a CT lookup for ttl
+func (s *AlertStore) SaveCTVerdict(ctx context.Context, fingerprint string, logged bool, ttl time.Duration) error {
+	return s.redis.SetCTVerdict(ctx, fingerprint, logged, ttl)
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// ArchiveStore manages where archived results are held in the result
+// archive
+type ArchiveStore struct {
+	redis *redis.Client
+}
+
+// NewArchiveStore creates a new archive store
+func NewArchiveStore(redis *redis.Client) *ArchiveStore {
+	return &ArchiveStore{
+		redis: redis,
+	}
+}
+
+// RecordArchived records that results of an agent are held by an archived
+// object
+func (s *ArchiveStore) RecordArchived(ctx context.Context, agentID, object string, requestIDs []string) error {
+	return s.redis.SetArchivedResults(ctx, agentID, object, requestIDs)
+}
+
+// GetLocation retrieves the archived object holding a result of an agent,
+// returning a NotFound error if the result was not archived
+func (s *ArchiveStore) GetLocation(ctx context.Context, agentID, requestID string) (string, error) {
+	return s.redis.GetArchivedResult(ctx, agentID, requestID)
+}
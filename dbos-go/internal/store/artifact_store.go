@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// ArtifactStore manages the agent and module binaries listed in the
+// software manifest
+type ArtifactStore struct {
+	redis *redis.Client
+}
+
+// NewArtifactStore creates a new artifact store
+func NewArtifactStore(redis *redis.Client) *ArtifactStore {
+	return &ArtifactStore{
+		redis: redis,
+	}
+}
+
+// PublishArtifact stores an artifact version, replacing any published
+// under the same name and version
+func (s *ArtifactStore) PublishArtifact(ctx context.Context, artifact *models.Artifact) error {
+	if err := artifact.Check(); err != nil {
+		return err
+	}
+	artifact.SHA256 = strings.ToLower(artifact.SHA256)
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetArtifact(ctx, artifact.Name, artifact.Version, data)
+}
+
+// ListArtifacts retrieves all published artifact versions, ordered by
+// name and then by publication time
+func (s *ArtifactStore) ListArtifacts(ctx context.Context) ([]*models.Artifact, error) {
+	data, err := s.redis.GetArtifacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := make([]*models.Artifact, 0, len(data))
+	for _, raw := range data {
+		var artifact models.Artifact
+		if err := json.Unmarshal([]byte(raw), &artifact); err != nil {
+			continue
+		}
+		artifacts = append(artifacts, &artifact)
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		if artifacts[i].Name != artifacts[j].Name {
+			return artifacts[i].Name < artifacts[j].Name
+		}
+		return artifacts[i].PublishedAt.Before(artifacts[j].PublishedAt)
+	})
+	return artifacts, nil
+}
+
+// UnpublishArtifact removes an artifact version
+func (s *ArtifactStore) UnpublishArtifact(ctx context.Context, name, version string) error {
+	deleted, err := s.redis.DeleteArtifact(ctx, name, version)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return dberrors.New(dberrors.NotFound, "artifact %s version %s not found", name, version)
+	}
+	return nil
+}
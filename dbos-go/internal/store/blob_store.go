@@ -0,0 +1,188 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// blobRefKey is the JSON key of a placeholder object pointing at a stored
+// fragment. Keys of the document starting with "$" are escaped with another
+// "$" in deduplicated documents, so no document object reads as a placeholder.
+const blobRefKey = "$blob"
+
+// BlobStore deduplicates large, repeated fragments of JSON payloads (e.g.
+// certificate chains) by storing them once under their content hash
+type BlobStore struct {
+	redis    *redis.Client
+	minBytes int
+}
+
+// NewBlobStore creates a new blob store; fragments smaller than minBytes stay inline
+func NewBlobStore(redis *redis.Client, minBytes int) *BlobStore {
+	return &BlobStore{
+		redis:    redis,
+		minBytes: minBytes,
+	}
+}
+
+// Deduplicate replaces every fragment of a JSON document at least minBytes
+// long with a reference to a content-addressed blob. It returns the rewritten
+// document and the hashes referenced. Non-JSON data, and documents with no
+// fragment that long, are returned unchanged.
+func (s *BlobStore) Deduplicate(ctx context.Context, data []byte) ([]byte, []string, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return data, nil, nil
+	}
+
+	var refs []string
+	doc, err := s.extract(ctx, doc, true, &refs)
+	if err != nil {
+		s.Release(ctx, refs)
+		return nil, nil, err
+	}
+	if len(refs) == 0 {
+		return data, nil, nil
+	}
+
+	out, err := encodeJSON(doc)
+	if err != nil {
+		s.Release(ctx, refs)
+		return nil, nil, err
+	}
+	return out, refs, nil
+}
+
+// extract walks the document bottom-up so the largest repeated leaves become blobs
+func (s *BlobStore) extract(ctx context.Context, node interface{}, root bool, refs *[]string) (interface{}, error) {
+	var err error
+	switch v := node.(type) {
+	case map[string]interface{}:
+		escaped := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if strings.HasPrefix(key, "$") {
+				key = "$" + key
+			}
+			if escaped[key], err = s.extract(ctx, child, false, refs); err != nil {
+				return nil, err
+			}
+		}
+		node = escaped
+	case []interface{}:
+		for i, child := range v {
+			if v[i], err = s.extract(ctx, child, false, refs); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if root {
+		return node, nil
+	}
+
+	encoded, err := encodeJSON(node)
+	if err != nil {
+		return nil, err
+	}
+	if len(encoded) < s.minBytes {
+		return node, nil
+	}
+
+	sum := sha256.Sum256(encoded)
+	hash := hex.EncodeToString(sum[:])
+	if err := s.redis.PutBlob(ctx, hash, encoded); err != nil {
+		return nil, err
+	}
+	*refs = append(*refs, hash)
+
+	return map[string]interface{}{blobRefKey: hash}, nil
+}
+
+// Expand resolves blob references in a deduplicated document
+func (s *BlobStore) Expand(ctx context.Context, data []byte) ([]byte, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return data, nil
+	}
+
+	doc, err := s.resolve(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	return encodeJSON(doc)
+}
+
+// resolve replaces blob placeholders with their stored content and unescapes
+// the keys around them
+func (s *BlobStore) resolve(ctx context.Context, node interface{}) (interface{}, error) {
+	var err error
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if hash, ok := v[blobRefKey].(string); ok && len(v) == 1 {
+			blob, err := s.redis.GetBlob(ctx, hash)
+			if err != nil {
+				return nil, err
+			}
+			var fragment interface{}
+			decoder := json.NewDecoder(bytes.NewReader(blob))
+			decoder.UseNumber()
+			if err := decoder.Decode(&fragment); err != nil {
+				return nil, err
+			}
+			return s.resolve(ctx, fragment)
+		}
+		unescaped := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if strings.HasPrefix(key, "$$") {
+				key = key[1:]
+			}
+			if unescaped[key], err = s.resolve(ctx, child); err != nil {
+				return nil, err
+			}
+		}
+		node = unescaped
+	case []interface{}:
+		for i, child := range v {
+			if v[i], err = s.resolve(ctx, child); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return node, nil
+}
+
+// Release drops one reference to each blob
+func (s *BlobStore) Release(ctx context.Context, hashes []string) error {
+	for _, hash := range hashes {
+		if err := s.redis.ReleaseBlob(ctx, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeJSON encodes a document without escaping HTML characters, which
+// json.Marshal would rewrite in payloads such as HTTP bodies
+func encodeJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// GC deletes blobs no longer referenced by any result
+func (s *BlobStore) GC(ctx context.Context) (int, error) {
+	return s.redis.DeleteUnreferencedBlobs(ctx)
+}
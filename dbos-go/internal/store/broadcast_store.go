@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// BroadcastStore manages broadcasts and the outcomes their agents report
+type BroadcastStore struct {
+	redis *redis.Client
+}
+
+// NewBroadcastStore creates a new broadcast store
+func NewBroadcastStore(redis *redis.Client) *BroadcastStore {
+	return &BroadcastStore{
+		redis: redis,
+	}
+}
+
+// SaveBroadcast stores a broadcast
+func (s *BroadcastStore) SaveBroadcast(ctx context.Context, broadcast *models.Broadcast) error {
+	return s.redis.SetBroadcast(ctx, broadcast.ID, broadcast)
+}
+
+// GetBroadcast retrieves a broadcast
+func (s *BroadcastStore) GetBroadcast(ctx context.Context, broadcastID string) (*models.Broadcast, error) {
+	data, err := s.redis.GetBroadcast(ctx, broadcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	var broadcast models.Broadcast
+	if err := json.Unmarshal(data, &broadcast); err != nil {
+		return nil, err
+	}
+	return &broadcast, nil
+}
+
+// RecordAgentStatus records the outcome an agent reported for its task of
+// a broadcast through a result or a module state
+func (s *BroadcastStore) RecordAgentStatus(ctx context.Context, broadcastID, agentID string, status models.TaskStatusEnum) error {
+	return s.redis.SetBroadcastAgentStatus(ctx, broadcastID, agentID, string(status))
+}
+
+// AgentStatuses retrieves the outcomes agents reported for their tasks of
+// a broadcast, by agent ID
+func (s *BroadcastStore) AgentStatuses(ctx context.Context, broadcastID string) (map[string]string, error) {
+	return s.redis.GetBroadcastAgentStatuses(ctx, broadcastID)
+}
@@ -0,0 +1,280 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// Counters kept per campaign
+const (
+	campaignStatRounds          = "rounds"
+	campaignStatTasksIssued     = "tasks_issued"
+	campaignStatResultsReceived = "results_received"
+)
+
+// CampaignStore manages measurement campaigns, their rounds and the results
+// correlated with them
+type CampaignStore struct {
+	redis *redis.Client
+}
+
+// NewCampaignStore creates a new campaign store
+func NewCampaignStore(redis *redis.Client) *CampaignStore {
+	return &CampaignStore{
+		redis: redis,
+	}
+}
+
+// CampaignResultRef names a result correlated with a campaign
+type CampaignResultRef struct {
+	AgentID   string
+	RequestID string
+}
+
+// CreateCampaign validates and stores a new campaign, scheduling its first
+// round at its start
+func (s *CampaignStore) CreateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	if err := campaign.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.saveCampaign(ctx, campaign); err != nil {
+		return err
+	}
+	return s.redis.ScheduleCampaignRound(ctx, campaign.ID, campaign.StartsAt)
+}
+
+// UpdateCampaign validates and stores a changed campaign definition, which
+// the rounds claimed from then on run; its schedule and progress stay
+func (s *CampaignStore) UpdateCampaign(ctx context.Context, campaign *models.Campaign) error {
+	if err := campaign.Validate(); err != nil {
+		return err
+	}
+	return s.saveCampaign(ctx, campaign)
+}
+
+// saveCampaign stores a campaign's definition
+func (s *CampaignStore) saveCampaign(ctx context.Context, campaign *models.Campaign) error {
+	data, err := json.Marshal(campaign)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetCampaign(ctx, campaign.ID, data)
+}
+
+// GetCampaign retrieves a campaign by ID with its progress
+func (s *CampaignStore) GetCampaign(ctx context.Context, id string) (*models.Campaign, error) {
+	data, err := s.redis.GetCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, dberrors.New(dberrors.NotFound, "campaign %s not found", id)
+	}
+
+	var campaign models.Campaign
+	if err := json.Unmarshal(data, &campaign); err != nil {
+		return nil, err
+	}
+	if err := s.loadProgress(ctx, &campaign); err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// ListCampaigns retrieves all campaigns with their progress, newest first
+func (s *CampaignStore) ListCampaigns(ctx context.Context) ([]*models.Campaign, error) {
+	data, err := s.redis.GetCampaigns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns := make([]*models.Campaign, 0, len(data))
+	for _, raw := range data {
+		var campaign models.Campaign
+		if err := json.Unmarshal([]byte(raw), &campaign); err != nil {
+			continue
+		}
+		if err := s.loadProgress(ctx, &campaign); err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, &campaign)
+	}
+	sort.Slice(campaigns, func(i, j int) bool {
+		if !campaigns[i].CreatedAt.Equal(campaigns[j].CreatedAt) {
+			return campaigns[i].CreatedAt.After(campaigns[j].CreatedAt)
+		}
+		return campaigns[i].ID < campaigns[j].ID
+	})
+
+	return campaigns, nil
+}
+
+// loadProgress fills in a campaign's status, next round and counters
+func (s *CampaignStore) loadProgress(ctx context.Context, campaign *models.Campaign) error {
+	next, scheduled, err := s.redis.GetCampaignNextRun(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+	stats, err := s.redis.GetCampaignStats(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+
+	campaign.NextRunAt = time.Time{}
+	switch {
+	case !campaign.StoppedAt.IsZero():
+		campaign.Status = string(models.CampaignStatusStopped)
+	case scheduled:
+		campaign.Status = string(models.CampaignStatusRunning)
+		campaign.NextRunAt = time.Unix(next, 0)
+	default:
+		campaign.Status = string(models.CampaignStatusCompleted)
+	}
+	campaign.Rounds, _ = strconv.ParseInt(stats[campaignStatRounds], 10, 64)
+	campaign.TasksIssued, _ = strconv.ParseInt(stats[campaignStatTasksIssued], 10, 64)
+	campaign.ResultsReceived, _ = strconv.ParseInt(stats[campaignStatResultsReceived], 10, 64)
+	return nil
+}
+
+// StopCampaign stops a campaign from running further rounds; tasks already
+// issued still run and their results are still correlated
+func (s *CampaignStore) StopCampaign(ctx context.Context, id string, now time.Time) (*models.Campaign, error) {
+	campaign, err := s.GetCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if campaign.Status != string(models.CampaignStatusRunning) {
+		return nil, dberrors.New(dberrors.InvalidTransition, "campaign %s is already %s", id, campaign.Status)
+	}
+
+	campaign.StoppedAt = now
+	if err := s.saveCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+	if err := s.redis.UnscheduleCampaign(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.loadProgress(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// ResumeCampaign runs a campaign that was stopped or ran out of rounds
+// again, its next round at a time
+func (s *CampaignStore) ResumeCampaign(ctx context.Context, id string, at time.Time) error {
+	campaign, err := s.GetCampaign(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !campaign.StoppedAt.IsZero() {
+		campaign.StoppedAt = time.Time{}
+		if err := s.saveCampaign(ctx, campaign); err != nil {
+			return err
+		}
+	}
+	return s.redis.ScheduleCampaignRound(ctx, id, at)
+}
+
+// ListDueCampaigns retrieves the campaigns with a round due by now; each
+// one's NextRunAt is the time its round is due at
+func (s *CampaignStore) ListDueCampaigns(ctx context.Context, now time.Time) ([]*models.Campaign, error) {
+	ids, err := s.redis.GetDueCampaigns(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns := make([]*models.Campaign, 0, len(ids))
+	for _, id := range ids {
+		campaign, err := s.GetCampaign(ctx, id)
+		if err != nil {
+			// A round of a campaign that no longer exists never runs
+			s.redis.UnscheduleCampaign(ctx, id)
+			continue
+		}
+		if campaign.Status == string(models.CampaignStatusRunning) {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+	return campaigns, nil
+}
+
+// ClaimRound claims a due campaign's round, scheduling the one after it. It
+// returns the round's number, or 0 if another server claimed it.
+func (s *CampaignStore) ClaimRound(ctx context.Context, campaign *models.Campaign, now time.Time) (int64, error) {
+	next, hasNext := campaign.NextRunAfter(campaign.NextRunAt, now)
+	return s.redis.ClaimCampaignRound(ctx, campaign.ID, campaign.NextRunAt.Unix(), next, hasNext)
+}
+
+// AddTasksIssued counts tasks issued for a campaign
+func (s *CampaignStore) AddTasksIssued(ctx context.Context, id string, n int) error {
+	return s.redis.IncrCampaignStat(ctx, id, campaignStatTasksIssued, int64(n))
+}
+
+// RecordResult correlates a result with a campaign, counting it once
+func (s *CampaignStore) RecordResult(ctx context.Context, id string, result *models.MeasurementResult) error {
+	added, err := s.redis.AddCampaignResult(ctx, id, result.AgentID, result.ID, result.Timestamp)
+	if err != nil || !added {
+		return err
+	}
+	return s.redis.IncrCampaignStat(ctx, id, campaignStatResultsReceived, 1)
+}
+
+// ListResults pages through the results correlated with a campaign in
+// measurement time order, or reversed if desc, with an opaque cursor that
+// is empty for the first page and returned empty after the last one
+func (s *CampaignStore) ListResults(ctx context.Context, id string, desc bool, cursor string, limit int) ([]CampaignResultRef, string, error) {
+	after := scoreCursor{}
+	if cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", dberrors.New(dberrors.InvalidArgument, "invalid cursor %q", cursor)
+		}
+		if after, err = parseScoreCursor(string(raw)); err != nil {
+			return nil, "", dberrors.New(dberrors.InvalidArgument, "invalid cursor %q", cursor)
+		}
+	}
+
+	min, max := "-inf", "+inf"
+	if after.key != "" {
+		if desc {
+			max = strconv.FormatInt(after.score, 10)
+		} else {
+			min = strconv.FormatInt(after.score, 10)
+		}
+	}
+
+	refs := make([]CampaignResultRef, 0, limit)
+	var offset int64
+	for {
+		entries, err := s.redis.GetCampaignResults(ctx, id, min, max, desc, offset, int64(limit)+1)
+		if err != nil {
+			return nil, "", err
+		}
+		offset += int64(len(entries))
+
+		for _, entry := range entries {
+			if after.passed(entry.Score, entry.Member, desc) {
+				continue
+			}
+			if len(refs) == limit {
+				return refs, base64.RawURLEncoding.EncodeToString([]byte(after.String())), nil
+			}
+			after = scoreCursor{score: entry.Score, key: entry.Member}
+			refs = append(refs, CampaignResultRef{AgentID: entry.AgentID, RequestID: entry.RequestID})
+		}
+
+		if len(entries) < limit+1 {
+			return refs, "", nil
+		}
+	}
+}
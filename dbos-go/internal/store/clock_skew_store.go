@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// ClockSkewStore manages the per-agent clock skew models
+type ClockSkewStore struct {
+	redis *redis.Client
+}
+
+// NewClockSkewStore creates a new clock skew store
+func NewClockSkewStore(redis *redis.Client) *ClockSkewStore {
+	return &ClockSkewStore{
+		redis: redis,
+	}
+}
+
+// GetClockSkew retrieves an agent's clock skew model
+func (s *ClockSkewStore) GetClockSkew(ctx context.Context, agentID string) (*models.ClockSkew, error) {
+	data, err := s.redis.GetClockSkew(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var skew models.ClockSkew
+	if err := json.Unmarshal(data, &skew); err != nil {
+		return nil, err
+	}
+
+	return &skew, nil
+}
+
+// RecordOffset folds an offset measurement into an agent's clock skew model
+func (s *ClockSkewStore) RecordOffset(ctx context.Context, agentID string, offsetMs, delayMs float64, at time.Time) (*models.ClockSkew, error) {
+	skew, err := s.GetClockSkew(ctx, agentID)
+	if err != nil {
+		skew = models.NewClockSkew(agentID)
+	}
+
+	skew.Observe(offsetMs, delayMs, at)
+	if err := s.redis.SetClockSkew(ctx, agentID, skew); err != nil {
+		return nil, err
+	}
+	return skew, nil
+}
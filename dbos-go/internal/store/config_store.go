@@ -0,0 +1,202 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// ConfigStore manages versioned desired agent configs and config rollouts
+type ConfigStore struct {
+	redis *redis.Client
+}
+
+// NewConfigStore creates a new config store
+func NewConfigStore(redis *redis.Client) *ConfigStore {
+	return &ConfigStore{
+		redis: redis,
+	}
+}
+
+// GetAgentConfig retrieves the current desired config version of an agent
+func (s *ConfigStore) GetAgentConfig(ctx context.Context, agentID string) (*models.AgentConfigVersion, error) {
+	data, err := s.redis.GetAgentConfig(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var version models.AgentConfigVersion
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// MergedConfig returns the config an agent would have with changes merged
+// into its desired config, along with the desired config's version. An agent
+// without a desired config starts from the config it registered with.
+func (s *ConfigStore) MergedConfig(ctx context.Context, agentID string, changes map[string]string) (map[string]string, int64, error) {
+	base := make(map[string]string)
+	var version int64
+	if current, err := s.GetAgentConfig(ctx, agentID); err == nil {
+		base = current.Config
+		version = current.Version
+	} else if data, err := s.redis.GetAgent(ctx, agentID); err == nil {
+		var agent models.Agent
+		if err := json.Unmarshal(data, &agent); err == nil && agent.Config != nil {
+			base = agent.Config
+		}
+	}
+
+	config := make(map[string]string, len(base)+len(changes))
+	for k, v := range base {
+		config[k] = v
+	}
+	for k, v := range changes {
+		config[k] = v
+	}
+	return config, version, nil
+}
+
+// ApplyConfig merges changes into an agent's desired config as a new version
+func (s *ConfigStore) ApplyConfig(ctx context.Context, agentID string, changes map[string]string, rolloutID string, now time.Time) (*models.AgentConfigVersion, error) {
+	config, version, err := s.MergedConfig(ctx, agentID, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	next := &models.AgentConfigVersion{
+		AgentID:   agentID,
+		Version:   version + 1,
+		Config:    config,
+		RolloutID: rolloutID,
+		AppliedAt: now,
+	}
+	if err := s.redis.PushAgentConfig(ctx, agentID, next); err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+// RevertConfig restores an agent's previous desired config if its current one
+// came from the given rollout, reporting whether it did
+func (s *ConfigStore) RevertConfig(ctx context.Context, agentID, rolloutID string) (bool, error) {
+	current, err := s.GetAgentConfig(ctx, agentID)
+	if err != nil || current.RolloutID != rolloutID {
+		return false, nil
+	}
+
+	if err := s.redis.PopAgentConfig(ctx, agentID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SaveRollout stores a config rollout
+func (s *ConfigStore) SaveRollout(ctx context.Context, rollout *models.ConfigRollout) error {
+	active := rollout.Status == string(models.RolloutStatusInProgress)
+	return s.redis.SetConfigRollout(ctx, rollout.ID, rollout, active)
+}
+
+// GetRollout retrieves a config rollout
+func (s *ConfigStore) GetRollout(ctx context.Context, rolloutID string) (*models.ConfigRollout, error) {
+	data, err := s.redis.GetConfigRollout(ctx, rolloutID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rollout models.ConfigRollout
+	if err := json.Unmarshal(data, &rollout); err != nil {
+		return nil, err
+	}
+
+	return &rollout, nil
+}
+
+// ListActiveRollouts retrieves all in-progress config rollouts
+func (s *ConfigStore) ListActiveRollouts(ctx context.Context) ([]*models.ConfigRollout, error) {
+	ids, err := s.redis.GetActiveConfigRolloutIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rollouts := make([]*models.ConfigRollout, 0, len(ids))
+	for _, id := range ids {
+		rollout, err := s.GetRollout(ctx, id)
+		if err != nil {
+			continue
+		}
+		rollouts = append(rollouts, rollout)
+	}
+
+	return rollouts, nil
+}
+
+// ErrorRate returns the fraction of module states reported as errors by the
+// given agents since a time, along with the number of states reported
+func (s *ConfigStore) ErrorRate(ctx context.Context, agentIDs []string, since time.Time) (float64, int64, error) {
+	var total, errors int64
+	for _, agentID := range agentIDs {
+		t, e, err := s.redis.CountModuleOutcomes(ctx, agentID, since)
+		if err != nil {
+			return 0, 0, err
+		}
+		total += t
+		errors += e
+	}
+	if total == 0 {
+		return 0, 0, nil
+	}
+	return float64(errors) / float64(total), total, nil
+}
+
+// SetSchema stores the config schema of an agent version, replacing any
+func (s *ConfigStore) SetSchema(ctx context.Context, schema *models.ConfigSchema) error {
+	if err := schema.Check(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetConfigSchema(ctx, schema.AgentVersion, data)
+}
+
+// ListSchemas retrieves the config schemas of all agent versions, ordered
+// by version with the default schema first
+func (s *ConfigStore) ListSchemas(ctx context.Context) ([]*models.ConfigSchema, error) {
+	data, err := s.redis.GetConfigSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make([]*models.ConfigSchema, 0, len(data))
+	for _, raw := range data {
+		var schema models.ConfigSchema
+		if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+			continue
+		}
+		schemas = append(schemas, &schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].AgentVersion < schemas[j].AgentVersion })
+	return schemas, nil
+}
+
+// DeleteSchema removes the config schema of an agent version
+func (s *ConfigStore) DeleteSchema(ctx context.Context, agentVersion string) error {
+	deleted, err := s.redis.DeleteConfigSchema(ctx, agentVersion)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return dberrors.New(dberrors.NotFound, "config schema of agent version %q not found", agentVersion)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// CredentialStore manages bootstrap tokens and agent credentials. Only the
+// SHA-256 hash of a secret is ever stored.
+type CredentialStore struct {
+	redis *redis.Client
+}
+
+// NewCredentialStore creates a new credential store
+func NewCredentialStore(redis *redis.Client) *CredentialStore {
+	return &CredentialStore{
+		redis: redis,
+	}
+}
+
+// CreateBootstrapToken stores a new one-time bootstrap token and returns its secret
+func (s *CredentialStore) CreateBootstrapToken(ctx context.Context, token *models.BootstrapToken) (string, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return "", err
+	}
+
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		return "", dberrors.New(dberrors.InvalidArgument, "bootstrap token already expired")
+	}
+	if err := s.redis.SetBootstrapToken(ctx, hashSecret(secret), token, ttl); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+// ConsumeBootstrapToken redeems a bootstrap token; it cannot be used again
+func (s *CredentialStore) ConsumeBootstrapToken(ctx context.Context, secret string) (*models.BootstrapToken, error) {
+	data, err := s.redis.ConsumeBootstrapToken(ctx, hashSecret(secret))
+	if err != nil {
+		return nil, dberrors.New(dberrors.Unauthenticated, "invalid or expired bootstrap token")
+	}
+
+	var token models.BootstrapToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return nil, dberrors.New(dberrors.Unauthenticated, "invalid or expired bootstrap token")
+	}
+
+	return &token, nil
+}
+
+// IssueAgentToken mints a permanent credential for an agent and returns its secret
+func (s *CredentialStore) IssueAgentToken(ctx context.Context, agentID string) (string, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.redis.SetAgentToken(ctx, hashSecret(secret), agentID); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+// IssueAPIToken mints an API token carrying the given claims and returns
+// its secret
+func (s *CredentialStore) IssueAPIToken(ctx context.Context, token *models.APIToken) (string, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.redis.SetAPIToken(ctx, hashSecret(secret), token); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+// LookupToken returns the claims of an API token or agent credential, or
+// nil if the secret is neither
+func (s *CredentialStore) LookupToken(ctx context.Context, secret string) (*models.APIToken, error) {
+	hash := hashSecret(secret)
+	data, err := s.redis.GetAPIToken(ctx, hash)
+	if err == nil {
+		var token models.APIToken
+		if err := json.Unmarshal(data, &token); err != nil {
+			return nil, err
+		}
+		return &token, nil
+	}
+	if !redis.IsNotFound(err) {
+		return nil, err
+	}
+
+	agentID, err := s.redis.GetAgentToken(ctx, hash)
+	if redis.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.APIToken{
+		Role:    string(models.RoleAgent),
+		AgentID: agentID,
+	}, nil
+}
+
+// newSecret returns 32 random bytes, hex-encoded
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashSecret returns the hex SHA-256 of a secret
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
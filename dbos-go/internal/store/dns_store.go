@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// dnsObservationTTL is how long a query's observations are kept after the
+// last region reported on it
+const dnsObservationTTL = 7 * 24 * time.Hour
+
+// DNSStore manages DNS answers observed per region and their expectations.
+// Queries are identified as "<type>:<name>", e.g. "A:example.com".
+type DNSStore struct {
+	redis *redis.Client
+}
+
+// NewDNSStore creates a new DNS store
+func NewDNSStore(redis *redis.Client) *DNSStore {
+	return &DNSStore{
+		redis: redis,
+	}
+}
+
+// RecordObservation stores a region's latest answer to a query
+func (s *DNSStore) RecordObservation(ctx context.Context, query string, observation *models.DNSObservation) error {
+	return s.redis.SetDNSObservation(ctx, query, observation.Region, observation, dnsObservationTTL)
+}
+
+// ListObservations retrieves the latest answer to a query of every region
+func (s *DNSStore) ListObservations(ctx context.Context, query string) ([]*models.DNSObservation, error) {
+	observationsData, err := s.redis.GetDNSObservations(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	observations := make([]*models.DNSObservation, 0, len(observationsData))
+	for _, data := range observationsData {
+		var observation models.DNSObservation
+		if err := json.Unmarshal([]byte(data), &observation); err != nil {
+			continue
+		}
+		observations = append(observations, &observation)
+	}
+
+	return observations, nil
+}
+
+// GetExpectation retrieves the expected answers to a query
+func (s *DNSStore) GetExpectation(ctx context.Context, query string) (*models.DNSExpectation, error) {
+	data, err := s.redis.GetDNSExpectation(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var expectation models.DNSExpectation
+	if err := json.Unmarshal(data, &expectation); err != nil {
+		return nil, err
+	}
+
+	return &expectation, nil
+}
+
+// SetExpectation stores the expected answers to a query
+func (s *DNSStore) SetExpectation(ctx context.Context, query string, expectation *models.DNSExpectation) error {
+	return s.redis.SetDNSExpectation(ctx, query, expectation)
+}
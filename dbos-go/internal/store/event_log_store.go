@@ -0,0 +1,144 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// eventLogPageSize is how many events are read at a time while filtering
+const eventLogPageSize = 1000
+
+// EventLogStore manages the log of typed mutation events
+type EventLogStore struct {
+	redis *redis.Client
+}
+
+// NewEventLogStore creates a new event log store
+func NewEventLogStore(redis *redis.Client) *EventLogStore {
+	return &EventLogStore{
+		redis: redis,
+	}
+}
+
+// AppendEvent adds an event to the log and sets its sequence
+func (s *EventLogStore) AppendEvent(ctx context.Context, event *models.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	sequence, err := s.redis.AppendEvent(ctx, data)
+	if err != nil {
+		return err
+	}
+	event.Sequence = sequence
+	return nil
+}
+
+// ListEvents retrieves up to limit events matching filter, newest first.
+// The log is read newest first until limit events matched or the events
+// are older than filter.Since.
+func (s *EventLogStore) ListEvents(ctx context.Context, filter models.EventFilter, limit int) ([]*models.Event, error) {
+	events := make([]*models.Event, 0)
+	before := ""
+	for {
+		page, err := s.redis.GetEvents(ctx, before, eventLogPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range page {
+			before = entry.Sequence
+			event, ok := decodeEvent(entry)
+			if !ok {
+				continue
+			}
+			if !filter.Since.IsZero() && event.Timestamp.Before(filter.Since) {
+				return events, nil
+			}
+			if !filter.Matches(event) {
+				continue
+			}
+			events = append(events, event)
+			if limit > 0 && len(events) == limit {
+				return events, nil
+			}
+		}
+		if len(page) < eventLogPageSize {
+			return events, nil
+		}
+	}
+}
+
+// ReadEvents returns events after sequence in log order, blocking up to
+// block for new ones
+func (s *EventLogStore) ReadEvents(ctx context.Context, sequence string, block time.Duration) ([]*models.Event, string, error) {
+	entries, err := s.redis.ReadEvents(ctx, sequence, block)
+	if err != nil {
+		return nil, "", err
+	}
+
+	events := make([]*models.Event, 0, len(entries))
+	for _, entry := range entries {
+		sequence = entry.Sequence
+		if event, ok := decodeEvent(entry); ok {
+			events = append(events, event)
+		}
+	}
+	return events, sequence, nil
+}
+
+// LatestSequence returns the sequence of the newest event, or "0-0"
+func (s *EventLogStore) LatestSequence(ctx context.Context) (string, error) {
+	return s.redis.LatestEventSequence(ctx)
+}
+
+// SequenceAvailable reports whether every event after sequence is still
+// in the log
+func (s *EventLogStore) SequenceAvailable(ctx context.Context, sequence string) (bool, error) {
+	return s.redis.EventSequenceAvailable(ctx, sequence)
+}
+
+// Length returns the number of events in the log
+func (s *EventLogStore) Length(ctx context.Context) (int64, error) {
+	return s.redis.EventLogLength(ctx)
+}
+
+// OldestEvents retrieves up to count events of the log oldest first. An
+// entry that cannot be decoded is returned with only its sequence set.
+func (s *EventLogStore) OldestEvents(ctx context.Context, count int) ([]*models.Event, error) {
+	entries, err := s.redis.GetOldestEvents(ctx, int64(count))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*models.Event, 0, len(entries))
+	for _, entry := range entries {
+		event, ok := decodeEvent(entry)
+		if !ok {
+			event = &models.Event{Sequence: entry.Sequence}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// TrimEvents removes the events of the log up to and including the one
+// with sequence through
+func (s *EventLogStore) TrimEvents(ctx context.Context, through string) error {
+	_, err := s.redis.TrimEvents(ctx, through)
+	return err
+}
+
+// decodeEvent decodes an entry of the log, reporting false for one that
+// cannot be decoded
+func decodeEvent(entry redis.EventEntry) (*models.Event, bool) {
+	var event models.Event
+	if err := json.Unmarshal(entry.Data, &event); err != nil {
+		return nil, false
+	}
+	event.Sequence = entry.Sequence
+	return &event, true
+}
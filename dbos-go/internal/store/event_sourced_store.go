@@ -0,0 +1,446 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// stateEventPageSize is how many events a rebuild reads at a time
+const stateEventPageSize = 1000
+
+// EventSourcedStore is a storage backend recording every agent and task
+// mutation made through another backend as an event in an append-only log,
+// from which the state of agents and tasks can be rebuilt
+type EventSourcedStore struct {
+	backend Store
+	redis   *redis.Client
+	agents  *eventSourcedAgents
+	tasks   *eventSourcedTasks
+	outbox  *OutboxStore
+}
+
+var _ Store = (*EventSourcedStore)(nil)
+
+// NewEventSourcedStore creates a storage backend recording the agent and
+// task mutations of backend in a log on a Redis client
+func NewEventSourcedStore(backend Store, redis *redis.Client) *EventSourcedStore {
+	s := &EventSourcedStore{
+		backend: backend,
+		redis:   redis,
+	}
+	s.agents = &eventSourcedAgents{Agents: backend.Agents(), log: s}
+	s.tasks = &eventSourcedTasks{Tasks: backend.Tasks(), log: s}
+	return s
+}
+
+// SetOutbox enables appending logged events to the outbox
+func (s *EventSourcedStore) SetOutbox(outbox *OutboxStore) {
+	s.outbox = outbox
+}
+
+// Agents returns the agent store, recording its mutations
+func (s *EventSourcedStore) Agents() Agents { return s.agents }
+
+// ModuleStates returns the backend's module state store
+func (s *EventSourcedStore) ModuleStates() ModuleStates { return s.backend.ModuleStates() }
+
+// Results returns the backend's result store
+func (s *EventSourcedStore) Results() Results { return s.backend.Results() }
+
+// Tasks returns the task store, recording its mutations
+func (s *EventSourcedStore) Tasks() Tasks { return s.tasks }
+
+// Events returns the backend's agent change log
+func (s *EventSourcedStore) Events() Events { return s.backend.Events() }
+
+// Ping checks that the backend answers
+func (s *EventSourcedStore) Ping(ctx context.Context) error { return s.backend.Ping(ctx) }
+
+// Close closes the backend
+func (s *EventSourcedStore) Close() error { return s.backend.Close() }
+
+// record appends an event to the log, and to the outbox if enabled
+func (s *EventSourcedStore) record(ctx context.Context, eventType models.StateEventEnum, event *models.StateEvent) error {
+	event.Type = string(eventType)
+	event.Timestamp = time.Now()
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	event.ID, err = s.redis.AppendStateEvent(ctx, event.EntityType, event.EntityID, data)
+	if err != nil || s.outbox == nil {
+		return err
+	}
+	return s.outbox.AppendEvent(ctx, event)
+}
+
+// recordAgent appends an event holding an agent's state, nil if deleted
+func (s *EventSourcedStore) recordAgent(ctx context.Context, eventType models.StateEventEnum, agentID string, agent *models.Agent) error {
+	return s.record(ctx, eventType, &models.StateEvent{
+		EntityType: string(models.StateEntityAgent),
+		EntityID:   agentID,
+		Agent:      agent,
+	})
+}
+
+// recordTask appends an event holding a task's state
+func (s *EventSourcedStore) recordTask(ctx context.Context, eventType models.StateEventEnum, task *models.Task, nextRun time.Time) error {
+	return s.record(ctx, eventType, &models.StateEvent{
+		EntityType: string(models.StateEntityTask),
+		EntityID:   task.ID,
+		Task:       task,
+		NextRunAt:  nextRun,
+	})
+}
+
+// recordSettledTasks appends an event holding the state of each task of a
+// batch that was settled without error
+func (s *EventSourcedStore) recordSettledTasks(ctx context.Context, eventType models.StateEventEnum, taskIDs []string, errs []error) error {
+	for i, taskID := range taskIDs {
+		if errs[i] != nil {
+			continue
+		}
+		task, err := s.backend.Tasks().GetTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := s.recordTask(ctx, eventType, task, time.Time{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListEvents retrieves up to limit events after cursor, of the whole log or
+// of one agent or task's history if entityType and entityID are set. It
+// returns the cursor of the next page, or an empty cursor after the last
+// page.
+func (s *EventSourcedStore) ListEvents(ctx context.Context, entityType, entityID, cursor string, limit int) ([]*models.StateEvent, string, error) {
+	var entries []redis.StateEventEntry
+	var err error
+	if entityType != "" {
+		entries, err = s.redis.GetEntityStateEvents(ctx, entityType, entityID, cursor, int64(limit))
+	} else {
+		entries, err = s.redis.GetStateEvents(ctx, cursor, int64(limit))
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	events := decodeStateEvents(entries)
+	nextCursor := ""
+	if len(entries) == limit {
+		nextCursor = entries[len(entries)-1].ID
+	}
+	return events, nextCursor, nil
+}
+
+// Rebuild replays the log to rebuild agents and tasks: each gets the state
+// its last event recorded, and each task is scheduled, in flight or re-issued
+// as that state implies. Agents deleted in the log are deleted; agents and
+// tasks without events are left alone. With dryRun nothing is written, and
+// only the agents and tasks whose stored state differs from the replayed
+// one are reported.
+func (s *EventSourcedStore) Rebuild(ctx context.Context, dryRun bool) (*models.StateRebuild, error) {
+	rebuild := &models.StateRebuild{}
+	agents := make(map[string]*models.StateEvent)
+	tasks := make(map[string]*models.StateEvent)
+	nextRuns := make(map[string]time.Time)
+
+	after := ""
+	for {
+		entries, err := s.redis.GetStateEvents(ctx, after, stateEventPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range decodeStateEvents(entries) {
+			switch models.StateEntityEnum(event.EntityType) {
+			case models.StateEntityAgent:
+				agents[event.EntityID] = event
+			case models.StateEntityTask:
+				if event.Task == nil {
+					continue
+				}
+				tasks[event.EntityID] = event
+				if !event.NextRunAt.IsZero() {
+					nextRuns[event.EntityID] = event.NextRunAt
+				}
+			}
+		}
+		rebuild.EventsReplayed += int64(len(entries))
+		if len(entries) < stateEventPageSize {
+			break
+		}
+		after = entries[len(entries)-1].ID
+	}
+
+	for agentID, event := range agents {
+		current, err := s.backend.Agents().GetAgent(ctx, agentID)
+		if err != nil && !redis.IsNotFound(err) {
+			return nil, err
+		}
+		if event.Agent == nil {
+			if current == nil {
+				continue
+			}
+			rebuild.DivergedAgentIDs = append(rebuild.DivergedAgentIDs, agentID)
+			if !dryRun {
+				if err := s.backend.Agents().DeleteAgent(ctx, agentID); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		rebuild.AgentsRebuilt++
+		if sameState(current, event.Agent) {
+			continue
+		}
+		rebuild.DivergedAgentIDs = append(rebuild.DivergedAgentIDs, agentID)
+		if !dryRun {
+			if err := s.backend.Agents().RegisterAgent(ctx, event.Agent); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for taskID, event := range tasks {
+		current, err := s.backend.Tasks().GetTask(ctx, taskID)
+		if err != nil && !redis.IsNotFound(err) {
+			return nil, err
+		}
+		rebuild.TasksRebuilt++
+		if !sameState(current, event.Task) {
+			rebuild.DivergedTaskIDs = append(rebuild.DivergedTaskIDs, taskID)
+		}
+		// The schedules are restored even for tasks whose state matches,
+		// as they are not part of the stored task
+		if !dryRun {
+			if err := s.backend.Tasks().RestoreTask(ctx, event.Task, nextRuns[taskID], event.Timestamp); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Strings(rebuild.DivergedAgentIDs)
+	sort.Strings(rebuild.DivergedTaskIDs)
+	return rebuild, nil
+}
+
+// Marker captures a snapshot marker: the end of the log and the last result
+// sequence of each of agentIDs at one point in time
+func (s *EventSourcedStore) Marker(ctx context.Context, agentIDs []string) (*models.SnapshotMarker, error) {
+	takenAt := time.Now()
+	eventID, sequences, err := s.redis.GetSnapshotMarker(ctx, agentIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &models.SnapshotMarker{
+		TakenAt:         takenAt,
+		StateEventID:    eventID,
+		ResultSequences: sequences,
+	}, nil
+}
+
+// StatesAt retrieves for each of the agents or tasks with IDs entityIDs the
+// last event of its history at or before the event with ID eventID, nil if
+// there is none, and whether it has any history at all
+func (s *EventSourcedStore) StatesAt(ctx context.Context, entityType string, entityIDs []string, eventID string) ([]*models.StateEvent, []bool, error) {
+	entries, histories, err := s.redis.GetEntityStateEventsAt(ctx, entityType, entityIDs, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make([]*models.StateEvent, len(entries))
+	for i, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		if decoded := decodeStateEvents([]redis.StateEventEntry{*entry}); len(decoded) > 0 {
+			events[i] = decoded[0]
+		}
+	}
+	return events, histories, nil
+}
+
+// decodeStateEvents decodes log entries, skipping undecodable ones
+func decodeStateEvents(entries []redis.StateEventEntry) []*models.StateEvent {
+	events := make([]*models.StateEvent, 0, len(entries))
+	for _, entry := range entries {
+		var event models.StateEvent
+		if err := json.Unmarshal(entry.Data, &event); err != nil {
+			continue
+		}
+		event.ID = entry.ID
+		events = append(events, &event)
+	}
+	return events
+}
+
+// sameState reports whether a stored agent or task has the replayed state
+func sameState[T any](current *T, replayed *T) bool {
+	if current == nil {
+		return false
+	}
+	a, errA := json.Marshal(current)
+	b, errB := json.Marshal(replayed)
+	return errA == nil && errB == nil && string(a) == string(b)
+}
+
+// eventSourcedAgents records the mutations of an agent store
+type eventSourcedAgents struct {
+	Agents
+	log *EventSourcedStore
+}
+
+func (a *eventSourcedAgents) RegisterAgent(ctx context.Context, agent *models.Agent) error {
+	if err := a.Agents.RegisterAgent(ctx, agent); err != nil {
+		return err
+	}
+	return a.log.recordAgent(ctx, models.StateEventAgentRegistered, agent.ID, agent)
+}
+
+func (a *eventSourcedAgents) RecordHeartbeat(ctx context.Context, agentID, hostname string, now time.Time) (*models.Agent, error) {
+	agent, err := a.Agents.RecordHeartbeat(ctx, agentID, hostname, now)
+	if err != nil {
+		return nil, err
+	}
+	return agent, a.log.recordAgent(ctx, models.StateEventAgentHeartbeat, agentID, agent)
+}
+
+func (a *eventSourcedAgents) MarkDead(ctx context.Context, agentID string, cutoff time.Time) (bool, error) {
+	dead, err := a.Agents.MarkDead(ctx, agentID, cutoff)
+	if err != nil || !dead {
+		return dead, err
+	}
+	agent, err := a.Agents.GetAgent(ctx, agentID)
+	if err != nil {
+		return true, err
+	}
+	return true, a.log.recordAgent(ctx, models.StateEventAgentMarkedDead, agentID, agent)
+}
+
+func (a *eventSourcedAgents) DeleteAgent(ctx context.Context, agentID string) error {
+	if err := a.Agents.DeleteAgent(ctx, agentID); err != nil {
+		return err
+	}
+	return a.log.recordAgent(ctx, models.StateEventAgentDeleted, agentID, nil)
+}
+
+// eventSourcedTasks records the mutations of a task store
+type eventSourcedTasks struct {
+	Tasks
+	log *EventSourcedStore
+}
+
+func (t *eventSourcedTasks) ScheduleTask(ctx context.Context, task *models.Task) error {
+	if err := t.Tasks.ScheduleTask(ctx, task); err != nil {
+		return err
+	}
+	var nextRun time.Time
+	if task.IsContinuous() || task.IsGroup() {
+		nextRun = task.ScheduledAt
+	}
+	return t.log.recordTask(ctx, models.StateEventTaskScheduled, task, nextRun)
+}
+
+func (t *eventSourcedTasks) UpdateTask(ctx context.Context, task *models.Task) error {
+	if err := t.Tasks.UpdateTask(ctx, task); err != nil {
+		return err
+	}
+	return t.log.recordTask(ctx, models.StateEventTaskUpdated, task, time.Time{})
+}
+
+func (t *eventSourcedTasks) CancelTask(ctx context.Context, taskID string) error {
+	// Cancelling a one-shot group task cancels its unfinished instances
+	var instanceIDs []string
+	if task, err := t.Tasks.GetTask(ctx, taskID); err == nil && task.IsGroup() && !task.IsContinuous() {
+		for _, agentID := range task.AgentIDs {
+			instance, err := t.Tasks.GetTask(ctx, task.GroupInstanceID(agentID, time.Time{}))
+			if err == nil && !instance.IsFinished() {
+				instanceIDs = append(instanceIDs, instance.ID)
+			}
+		}
+	}
+
+	if err := t.Tasks.CancelTask(ctx, taskID); err != nil {
+		return err
+	}
+	for _, id := range append(instanceIDs, taskID) {
+		task, err := t.Tasks.GetTask(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := t.log.recordTask(ctx, models.StateEventTaskCancelled, task, time.Time{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *eventSourcedTasks) RescheduleContinuousTask(ctx context.Context, task *models.Task, nextRun time.Time) error {
+	if err := t.Tasks.RescheduleContinuousTask(ctx, task, nextRun); err != nil {
+		return err
+	}
+	return t.log.recordTask(ctx, models.StateEventTaskRescheduled, task, nextRun)
+}
+
+func (t *eventSourcedTasks) FinishGroupTask(ctx context.Context, task *models.Task) error {
+	if err := t.Tasks.FinishGroupTask(ctx, task); err != nil {
+		return err
+	}
+	return t.log.recordTask(ctx, models.StateEventTaskFinished, task, time.Time{})
+}
+
+func (t *eventSourcedTasks) LeaseTask(ctx context.Context, agentID string, now time.Time) (*models.Task, error) {
+	task, err := t.Tasks.LeaseTask(ctx, agentID, now)
+	if err != nil || task == nil {
+		return task, err
+	}
+	return task, t.log.recordTask(ctx, models.StateEventTaskLeased, task, time.Time{})
+}
+
+func (t *eventSourcedTasks) AckTasks(ctx context.Context, agentID string, taskIDs []string) ([]error, error) {
+	errs, err := t.Tasks.AckTasks(ctx, agentID, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	return errs, t.log.recordSettledTasks(ctx, models.StateEventTaskAcked, taskIDs, errs)
+}
+
+func (t *eventSourcedTasks) NackTasks(ctx context.Context, agentID string, taskIDs []string, requeueAt time.Time, retry bool) ([]error, error) {
+	errs, err := t.Tasks.NackTasks(ctx, agentID, taskIDs, requeueAt, retry)
+	if err != nil {
+		return nil, err
+	}
+	return errs, t.log.recordSettledTasks(ctx, models.StateEventTaskNacked, taskIDs, errs)
+}
+
+func (t *eventSourcedTasks) ExtendLease(ctx context.Context, agentID, taskID string, expiresAt, now time.Time) (*models.Task, error) {
+	task, err := t.Tasks.ExtendLease(ctx, agentID, taskID, expiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+	return task, t.log.recordTask(ctx, models.StateEventTaskExtended, task, time.Time{})
+}
+
+func (t *eventSourcedTasks) RequeueExpiredTasks(ctx context.Context, tasks []*models.Task, requeueAt time.Time) ([]bool, error) {
+	released, err := t.Tasks.RequeueExpiredTasks(ctx, tasks, requeueAt)
+	if err != nil {
+		return nil, err
+	}
+	for i, task := range tasks {
+		if !released[i] {
+			continue
+		}
+		if err := t.log.recordTask(ctx, models.StateEventTaskExpired, task, time.Time{}); err != nil {
+			return nil, err
+		}
+	}
+	return released, nil
+}
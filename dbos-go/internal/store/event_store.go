@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// EventStore reads the log of agent inventory changes
+type EventStore struct {
+	redis *redis.Client
+}
+
+// NewEventStore creates a new event store
+func NewEventStore(redis *redis.Client) *EventStore {
+	return &EventStore{
+		redis: redis,
+	}
+}
+
+// LatestRevision returns the revision of the newest agent change
+func (s *EventStore) LatestRevision(ctx context.Context) (string, error) {
+	return s.redis.LatestAgentRevision(ctx)
+}
+
+// RevisionAvailable reports whether changes after revision can still be replayed
+func (s *EventStore) RevisionAvailable(ctx context.Context, revision string) (bool, error) {
+	return s.redis.AgentRevisionAvailable(ctx, revision)
+}
+
+// ListChanges returns agent changes after revision, waiting up to block for new ones
+func (s *EventStore) ListChanges(ctx context.Context, revision string, block time.Duration) ([]*models.AgentChange, error) {
+	changesData, err := s.redis.ReadAgentChanges(ctx, revision, block)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*models.AgentChange, 0, len(changesData))
+	for _, data := range changesData {
+		change := &models.AgentChange{
+			Revision: data.Revision,
+			Type:     data.Type,
+			AgentID:  data.AgentID,
+		}
+		if len(data.Data) > 0 {
+			var agent models.Agent
+			if err := json.Unmarshal(data.Data, &agent); err == nil {
+				change.Agent = &agent
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
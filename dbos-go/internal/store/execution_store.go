@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// ExecutionStore manages the recent task executions of each agent and
+// module and their duration histograms, kept for 30 days after the latest
+type ExecutionStore struct {
+	redis *redis.Client
+}
+
+// NewExecutionStore creates a new execution store
+func NewExecutionStore(redis *redis.Client) *ExecutionStore {
+	return &ExecutionStore{
+		redis: redis,
+	}
+}
+
+// RecordExecution records an agent's execution of a task of a module under
+// a lease, identified by the task and when it was leased, unless it was
+// recorded already. It reports whether the execution was recorded.
+func (s *ExecutionStore) RecordExecution(ctx context.Context, agentID, moduleName, taskID string, leasedAt time.Time, execution *models.Execution) (bool, error) {
+	lease := fmt.Sprintf("%s:%d", taskID, leasedAt.UnixNano())
+	return s.redis.RecordExecution(ctx, agentID, moduleName, lease, execution,
+		models.ExecutionBucket(execution.DurationMs), execution.DurationMs,
+		models.MaxAgentExecutions, models.MaxModuleExecutions)
+}
+
+// AgentStats returns the statistics of each agent's recent executions of a
+// module, keyed by agent ID; agents without executions are left out
+func (s *ExecutionStore) AgentStats(ctx context.Context, agentIDs []string, moduleName string) (map[string]models.ExecutionStats, error) {
+	data, err := s.redis.GetAgentExecutions(ctx, agentIDs, moduleName)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]models.ExecutionStats, len(data))
+	for agentID, entries := range data {
+		stats[agentID] = models.SummarizeExecutions(decodeExecutions(entries))
+	}
+	return stats, nil
+}
+
+// ModuleStats returns the statistics of the recent executions of a module
+// across all agents
+func (s *ExecutionStore) ModuleStats(ctx context.Context, moduleName string) (models.ExecutionStats, error) {
+	data, err := s.redis.GetModuleExecutions(ctx, moduleName)
+	if err != nil {
+		return models.ExecutionStats{}, err
+	}
+	return models.SummarizeExecutions(decodeExecutions(data)), nil
+}
+
+// Histograms returns the duration histogram of a module across all agents
+// and of each of several agents, keyed by agent ID
+func (s *ExecutionStore) Histograms(ctx context.Context, moduleName string, agentIDs []string) (models.ExecutionHistogram, map[string]models.ExecutionHistogram, error) {
+	moduleFields, agentFields, err := s.redis.GetExecutionHistograms(ctx, moduleName, agentIDs)
+	if err != nil {
+		return models.ExecutionHistogram{}, nil, err
+	}
+
+	agents := make(map[string]models.ExecutionHistogram, len(agentFields))
+	for agentID, fields := range agentFields {
+		agents[agentID] = models.ParseExecutionHistogram(fields)
+	}
+	return models.ParseExecutionHistogram(moduleFields), agents, nil
+}
+
+// ListAgents returns the agents that ran a module in the last 30 days,
+// sorted by ID
+func (s *ExecutionStore) ListAgents(ctx context.Context, moduleName string) ([]string, error) {
+	agentIDs, err := s.redis.GetExecutionAgents(ctx, moduleName)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(agentIDs)
+	return agentIDs, nil
+}
+
+// decodeExecutions decodes stored executions, skipping malformed ones
+func decodeExecutions(data [][]byte) []*models.Execution {
+	executions := make([]*models.Execution, 0, len(data))
+	for _, entry := range data {
+		var execution models.Execution
+		if err := json.Unmarshal(entry, &execution); err != nil {
+			continue
+		}
+		executions = append(executions, &execution)
+	}
+	return executions
+}
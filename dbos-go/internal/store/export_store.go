@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// ExportStore manages how far sinks tailing stored results have exported
+// each agent's results
+type ExportStore struct {
+	redis *redis.Client
+}
+
+// NewExportStore creates a new export store
+func NewExportStore(redis *redis.Client) *ExportStore {
+	return &ExportStore{
+		redis: redis,
+	}
+}
+
+// GetCursors retrieves the sequence number of every agent's last result a
+// sink exported
+func (s *ExportStore) GetCursors(ctx context.Context, sink string) (map[string]int64, error) {
+	return s.redis.GetExportCursors(ctx, sink)
+}
+
+// SetCursor records the sequence number of an agent's last result a sink
+// exported
+func (s *ExportStore) SetCursor(ctx context.Context, sink, agentID string, seq int64) error {
+	return s.redis.SetExportCursor(ctx, sink, agentID, seq)
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// httpObservationTTL is how long a URL's observations are kept after the
+// last region reported on it
+const httpObservationTTL = 7 * 24 * time.Hour
+
+// HTTPContentStore manages the HTTP responses to URLs observed per region
+type HTTPContentStore struct {
+	redis *redis.Client
+}
+
+// NewHTTPContentStore creates a new HTTP content store
+func NewHTTPContentStore(redis *redis.Client) *HTTPContentStore {
+	return &HTTPContentStore{
+		redis: redis,
+	}
+}
+
+// RecordObservation stores a region's latest response to a URL
+func (s *HTTPContentStore) RecordObservation(ctx context.Context, url string, observation *models.HTTPObservation) error {
+	return s.redis.SetHTTPObservation(ctx, url, observation.Region, observation, httpObservationTTL)
+}
+
+// ListObservations retrieves the latest response to a URL of every region
+func (s *HTTPContentStore) ListObservations(ctx context.Context, url string) ([]*models.HTTPObservation, error) {
+	observationsData, err := s.redis.GetHTTPObservations(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	observations := make([]*models.HTTPObservation, 0, len(observationsData))
+	for _, data := range observationsData {
+		var observation models.HTTPObservation
+		if err := json.Unmarshal([]byte(data), &observation); err != nil {
+			continue
+		}
+		observations = append(observations, &observation)
+	}
+
+	return observations, nil
+}
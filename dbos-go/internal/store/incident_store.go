@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// IncidentStore manages incident persistence
+type IncidentStore struct {
+	redis *redis.Client
+}
+
+// NewIncidentStore creates a new incident store
+func NewIncidentStore(redis *redis.Client) *IncidentStore {
+	return &IncidentStore{
+		redis: redis,
+	}
+}
+
+// GetIncident retrieves an incident by ID
+func (s *IncidentStore) GetIncident(ctx context.Context, id string) (*models.Incident, error) {
+	data, err := s.redis.GetIncident(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var incident models.Incident
+	if err := json.Unmarshal(data, &incident); err != nil {
+		return nil, err
+	}
+
+	return &incident, nil
+}
+
+// GetOpenIncident retrieves the open incident for a key, or nil if there is none
+func (s *IncidentStore) GetOpenIncident(ctx context.Context, key string) (*models.Incident, error) {
+	id, err := s.redis.GetOpenIncidentID(ctx, key)
+	if err != nil || id == "" {
+		return nil, err
+	}
+	return s.GetIncident(ctx, id)
+}
+
+// SaveIncident stores an incident
+func (s *IncidentStore) SaveIncident(ctx context.Context, incident *models.Incident) error {
+	return s.redis.SetIncident(ctx, incident.ID, incident.Key, incident.Active(), incident)
+}
+
+// DeleteIncident removes an incident
+func (s *IncidentStore) DeleteIncident(ctx context.Context, incident *models.Incident) error {
+	return s.redis.DeleteIncident(ctx, incident.ID, incident.Key)
+}
+
+// ResolveIncident resolves the open incident for a key, returning it, or nil
+// if there was none
+func (s *IncidentStore) ResolveIncident(ctx context.Context, key string, at time.Time) (*models.Incident, error) {
+	incident, err := s.GetOpenIncident(ctx, key)
+	if err != nil || incident == nil {
+		return nil, err
+	}
+
+	incident.Resolve(at)
+	if err := s.SaveIncident(ctx, incident); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// ListIncidents retrieves up to limit incidents, most recently seen first,
+// optionally restricted to a type, status (or "active" for open and
+// acknowledged ones) and region
+func (s *IncidentStore) ListIncidents(ctx context.Context, incidentType, status, region string, limit int) ([]*models.Incident, error) {
+	incidentsData, err := s.redis.GetIncidents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents := make([]*models.Incident, 0)
+	for _, data := range incidentsData {
+		var incident models.Incident
+		if err := json.Unmarshal([]byte(data), &incident); err != nil {
+			continue
+		}
+		if incidentType != "" && incident.Type != incidentType {
+			continue
+		}
+		if status == models.IncidentStatusActive {
+			if !incident.Active() {
+				continue
+			}
+		} else if status != "" && string(incident.Status) != status {
+			continue
+		}
+		if region != "" && incident.Region != region {
+			continue
+		}
+		incidents = append(incidents, &incident)
+	}
+
+	sort.Slice(incidents, func(i, j int) bool {
+		return incidents[i].LastSeen.After(incidents[j].LastSeen)
+	})
+	if limit > 0 && len(incidents) > limit {
+		incidents = incidents[:limit]
+	}
+
+	return incidents, nil
+}
+
+// AppendEvent records a change to an incident in the incident event log
+func (s *IncidentStore) AppendEvent(ctx context.Context, eventType models.IncidentEventTypeEnum, incident *models.Incident) (string, error) {
+	var data []byte
+	if eventType != models.IncidentEventDeleted {
+		var err error
+		if data, err = json.Marshal(incident); err != nil {
+			return "", err
+		}
+	}
+	return s.redis.AppendIncidentEvent(ctx, string(eventType), incident.ID, data)
+}
+
+// LatestRevision returns the revision of the newest incident event
+func (s *IncidentStore) LatestRevision(ctx context.Context) (string, error) {
+	return s.redis.LatestIncidentRevision(ctx)
+}
+
+// RevisionAvailable reports whether events after revision can still be replayed
+func (s *IncidentStore) RevisionAvailable(ctx context.Context, revision string) (bool, error) {
+	return s.redis.IncidentRevisionAvailable(ctx, revision)
+}
+
+// ListEvents returns incident events after revision, waiting up to block for new ones
+func (s *IncidentStore) ListEvents(ctx context.Context, revision string, block time.Duration) ([]*models.IncidentEvent, error) {
+	eventsData, err := s.redis.ReadIncidentEvents(ctx, revision, block)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*models.IncidentEvent, 0, len(eventsData))
+	for _, data := range eventsData {
+		event := &models.IncidentEvent{
+			Revision:   data.Revision,
+			Type:       data.Type,
+			IncidentID: data.IncidentID,
+		}
+		if len(data.Data) > 0 {
+			var incident models.Incident
+			if err := json.Unmarshal(data.Data, &incident); err == nil {
+				event.Incident = &incident
+			}
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,337 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// indexScanPageSize is how many entries of the scanned column are read at a time
+const indexScanPageSize = 1000
+
+// columnNamePattern restricts column names to identifiers
+var columnNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IndexStore manages extraction rules and the column indexes they maintain
+type IndexStore struct {
+	redis *redis.Client
+}
+
+// NewIndexStore creates a new index store
+func NewIndexStore(redis *redis.Client) *IndexStore {
+	return &IndexStore{
+		redis: redis,
+	}
+}
+
+// CreateRule stores an extraction rule
+func (s *IndexStore) CreateRule(ctx context.Context, rule *models.ExtractionRule) error {
+	if rule.ModuleName == "" {
+		return dberrors.New(dberrors.InvalidArgument, "extraction rule requires a module name")
+	}
+	if !columnNamePattern.MatchString(rule.Column) {
+		return dberrors.New(dberrors.InvalidArgument, "invalid column name %q", rule.Column)
+	}
+	if err := jsonpath.Validate(rule.Path); err != nil {
+		return dberrors.New(dberrors.InvalidArgument, "invalid path %q: %v", rule.Path, err)
+	}
+	switch models.ColumnTypeEnum(rule.Type) {
+	case models.ColumnTypeNumber, models.ColumnTypeString:
+	default:
+		return dberrors.New(dberrors.InvalidArgument, "unknown column type %q", rule.Type)
+	}
+
+	if _, err := s.GetRule(ctx, rule.ModuleName, rule.Column); err == nil {
+		return dberrors.New(dberrors.AlreadyExists, "column %s of module %s already exists", rule.Column, rule.ModuleName)
+	}
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetExtractionRule(ctx, rule.ModuleName, rule.Column, data)
+}
+
+// GetRule retrieves the extraction rule of a module's column
+func (s *IndexStore) GetRule(ctx context.Context, moduleName, column string) (*models.ExtractionRule, error) {
+	rules, err := s.ListRules(ctx, moduleName)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.Column == column {
+			return rule, nil
+		}
+	}
+	return nil, dberrors.New(dberrors.NotFound, "column %s of module %s not found", column, moduleName)
+}
+
+// ListRules retrieves the extraction rules, optionally of one module only,
+// ordered by module and column
+func (s *IndexStore) ListRules(ctx context.Context, moduleName string) ([]*models.ExtractionRule, error) {
+	data, err := s.redis.GetExtractionRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*models.ExtractionRule, 0, len(data))
+	for _, raw := range data {
+		var rule models.ExtractionRule
+		if err := json.Unmarshal([]byte(raw), &rule); err != nil {
+			continue
+		}
+		if moduleName != "" && rule.ModuleName != moduleName {
+			continue
+		}
+		rules = append(rules, &rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].ModuleName != rules[j].ModuleName {
+			return rules[i].ModuleName < rules[j].ModuleName
+		}
+		return rules[i].Column < rules[j].Column
+	})
+
+	return rules, nil
+}
+
+// DeleteRule removes an extraction rule and its index
+func (s *IndexStore) DeleteRule(ctx context.Context, moduleName, column string) error {
+	return s.redis.DeleteExtractionRule(ctx, moduleName, column)
+}
+
+// Apply indexes a newly stored result under every column of its module.
+// A re-delivered result replaces the values it was indexed under.
+func (s *IndexStore) Apply(ctx context.Context, result *models.MeasurementResult) error {
+	rules, err := s.ListRules(ctx, result.ModuleName)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if err := s.ApplyRule(ctx, rule, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyRule indexes a result under one column; results without a value of
+// the column's type at its path are removed from the column
+func (s *IndexStore) ApplyRule(ctx context.Context, rule *models.ExtractionRule, result *models.MeasurementResult) error {
+	if rule.ModuleName != result.ModuleName {
+		return nil
+	}
+
+	ref := redis.ResultRef{AgentID: result.AgentID, RequestID: result.ID}
+	value, found := jsonpath.Lookup(result.Data, rule.Path)
+
+	switch models.ColumnTypeEnum(rule.Type) {
+	case models.ColumnTypeNumber:
+		if number, ok := jsonpath.ToFloat(value); found && ok {
+			return s.redis.IndexNumber(ctx, rule.ModuleName, rule.Column, ref, number)
+		}
+		return s.redis.UnindexNumber(ctx, rule.ModuleName, rule.Column, ref)
+
+	case models.ColumnTypeString:
+		if found && value != nil {
+			return s.redis.IndexString(ctx, rule.ModuleName, rule.Column, ref, viewKey(value))
+		}
+		return s.redis.UnindexString(ctx, rule.ModuleName, rule.Column, ref)
+	}
+
+	return nil
+}
+
+// Remove removes a deleted result from every column of its module
+func (s *IndexStore) Remove(ctx context.Context, result *models.MeasurementResult) error {
+	rules, err := s.ListRules(ctx, result.ModuleName)
+	if err != nil {
+		return err
+	}
+
+	ref := redis.ResultRef{AgentID: result.AgentID, RequestID: result.ID}
+	for _, rule := range rules {
+		switch models.ColumnTypeEnum(rule.Type) {
+		case models.ColumnTypeNumber:
+			err = s.redis.UnindexNumber(ctx, rule.ModuleName, rule.Column, ref)
+		case models.ColumnTypeString:
+			err = s.redis.UnindexString(ctx, rule.ModuleName, rule.Column, ref)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexFilter is a column filter validated against its column's rule
+type indexFilter struct {
+	rule   *models.ExtractionRule
+	op     string
+	number float64
+	text   string
+}
+
+// matches reports whether an indexed value passes the filter
+func (f *indexFilter) matches(number float64, text string) bool {
+	if models.ColumnTypeEnum(f.rule.Type) == models.ColumnTypeString {
+		return text == f.text
+	}
+	switch f.op {
+	case "=":
+		return number == f.number
+	case "<":
+		return number < f.number
+	case "<=":
+		return number <= f.number
+	case ">":
+		return number > f.number
+	case ">=":
+		return number >= f.number
+	}
+	return false
+}
+
+// bounds returns the ZRANGEBYSCORE bounds of a number filter
+func (f *indexFilter) bounds() (string, string) {
+	value := strconv.FormatFloat(f.number, 'g', -1, 64)
+	switch f.op {
+	case "<":
+		return "-inf", "(" + value
+	case "<=":
+		return "-inf", value
+	case ">":
+		return "(" + value, "+inf"
+	case ">=":
+		return value, "+inf"
+	}
+	return value, value
+}
+
+// QueryResults finds up to limit results of a module matching every filter,
+// optionally of one agent. The first filter's column is scanned in order and
+// the other filters are checked against their indexes, so payloads are never
+// read.
+func (s *IndexStore) QueryResults(ctx context.Context, moduleName string, filters []models.ColumnFilter, agentID string, limit int) ([]redis.ResultRef, error) {
+	if len(filters) == 0 {
+		return nil, dberrors.New(dberrors.InvalidArgument, "at least one filter is required")
+	}
+
+	parsed := make([]*indexFilter, len(filters))
+	for i, filter := range filters {
+		rule, err := s.GetRule(ctx, moduleName, filter.Column)
+		if err != nil {
+			return nil, err
+		}
+		f := &indexFilter{rule: rule, op: filter.Op, text: filter.Value}
+		switch models.ColumnTypeEnum(rule.Type) {
+		case models.ColumnTypeNumber:
+			switch filter.Op {
+			case "=", "<", "<=", ">", ">=":
+			default:
+				return nil, dberrors.New(dberrors.InvalidArgument, "unsupported operator %q for number column %s", filter.Op, filter.Column)
+			}
+			number, err := strconv.ParseFloat(filter.Value, 64)
+			if err != nil {
+				return nil, dberrors.New(dberrors.InvalidArgument, "invalid number %q for column %s", filter.Value, filter.Column)
+			}
+			f.number = number
+		case models.ColumnTypeString:
+			if filter.Op != "=" {
+				return nil, dberrors.New(dberrors.InvalidArgument, "unsupported operator %q for string column %s", filter.Op, filter.Column)
+			}
+		}
+		parsed[i] = f
+	}
+
+	scanned := parsed[0]
+	var matches []redis.ResultRef
+	for offset := int64(0); len(matches) < limit; offset += indexScanPageSize {
+		var page []redis.ResultRef
+		var err error
+		if models.ColumnTypeEnum(scanned.rule.Type) == models.ColumnTypeNumber {
+			min, max := scanned.bounds()
+			page, err = s.redis.ScanNumberIndex(ctx, moduleName, scanned.rule.Column, min, max, offset, indexScanPageSize)
+		} else {
+			page, err = s.redis.ScanStringIndex(ctx, moduleName, scanned.rule.Column, scanned.text, offset, indexScanPageSize)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]redis.ResultRef, 0, len(page))
+		for _, ref := range page {
+			if agentID == "" || ref.AgentID == agentID {
+				candidates = append(candidates, ref)
+			}
+		}
+		for _, filter := range parsed[1:] {
+			if candidates, err = s.filterIndexed(ctx, filter, candidates); err != nil {
+				return nil, err
+			}
+		}
+		matches = append(matches, candidates...)
+
+		if len(page) < indexScanPageSize {
+			break
+		}
+	}
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+// filterIndexed keeps the refs whose indexed value passes a filter
+func (s *IndexStore) filterIndexed(ctx context.Context, filter *indexFilter, refs []redis.ResultRef) ([]redis.ResultRef, error) {
+	if len(refs) == 0 {
+		return refs, nil
+	}
+
+	kept := refs[:0]
+	if models.ColumnTypeEnum(filter.rule.Type) == models.ColumnTypeNumber {
+		values, err := s.redis.GetIndexedNumbers(ctx, filter.rule.ModuleName, filter.rule.Column, refs)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range refs {
+			if value, ok := values[ref]; ok && filter.matches(value, "") {
+				kept = append(kept, ref)
+			}
+		}
+		return kept, nil
+	}
+
+	values, err := s.redis.GetIndexedStrings(ctx, filter.rule.ModuleName, filter.rule.Column, refs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		if value, ok := values[ref]; ok && filter.matches(0, value) {
+			kept = append(kept, ref)
+		}
+	}
+	return kept, nil
+}
+
+// NumberValues returns the values results are indexed under in a number
+// column; results not in the column are left out
+func (s *IndexStore) NumberValues(ctx context.Context, moduleName, column string, refs []redis.ResultRef) (map[redis.ResultRef]float64, error) {
+	rule, err := s.GetRule(ctx, moduleName, column)
+	if err != nil {
+		return nil, err
+	}
+	if models.ColumnTypeEnum(rule.Type) != models.ColumnTypeNumber {
+		return nil, dberrors.New(dberrors.InvalidArgument, "column %s of module %s is not a number column", column, moduleName)
+	}
+	return s.redis.GetIndexedNumbers(ctx, moduleName, column, refs)
+}
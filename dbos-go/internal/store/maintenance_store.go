@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// MaintenanceStore manages scheduled maintenance windows
+type MaintenanceStore struct {
+	redis *redis.Client
+}
+
+// NewMaintenanceStore creates a new maintenance store
+func NewMaintenanceStore(redis *redis.Client) *MaintenanceStore {
+	return &MaintenanceStore{
+		redis: redis,
+	}
+}
+
+// SaveWindow validates and stores a maintenance window, replacing any with
+// the same ID
+func (s *MaintenanceStore) SaveWindow(ctx context.Context, window *models.MaintenanceWindow) error {
+	if err := window.Validate(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(window)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetMaintenanceWindow(ctx, window.ID, data)
+}
+
+// GetWindow retrieves a maintenance window by ID
+func (s *MaintenanceStore) GetWindow(ctx context.Context, id string) (*models.MaintenanceWindow, error) {
+	data, err := s.redis.GetMaintenanceWindow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, dberrors.New(dberrors.NotFound, "maintenance window %s not found", id)
+	}
+
+	var window models.MaintenanceWindow
+	if err := json.Unmarshal(data, &window); err != nil {
+		return nil, err
+	}
+	return &window, nil
+}
+
+// ListWindows retrieves all maintenance windows, ordered by start
+func (s *MaintenanceStore) ListWindows(ctx context.Context) ([]*models.MaintenanceWindow, error) {
+	data, err := s.redis.GetMaintenanceWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := make([]*models.MaintenanceWindow, 0, len(data))
+	for _, raw := range data {
+		var window models.MaintenanceWindow
+		if err := json.Unmarshal([]byte(raw), &window); err != nil {
+			continue
+		}
+		windows = append(windows, &window)
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		if !windows[i].StartsAt.Equal(windows[j].StartsAt) {
+			return windows[i].StartsAt.Before(windows[j].StartsAt)
+		}
+		return windows[i].ID < windows[j].ID
+	})
+
+	return windows, nil
+}
+
+// ListActiveWindows retrieves the maintenance windows in progress at t
+func (s *MaintenanceStore) ListActiveWindows(ctx context.Context, t time.Time) ([]*models.MaintenanceWindow, error) {
+	windows, err := s.ListWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active := windows[:0]
+	for _, window := range windows {
+		if window.ActiveAt(t) {
+			active = append(active, window)
+		}
+	}
+	return active, nil
+}
+
+// DeleteWindow removes a maintenance window
+func (s *MaintenanceStore) DeleteWindow(ctx context.Context, id string) error {
+	found, err := s.redis.DeleteMaintenanceWindow(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return dberrors.New(dberrors.NotFound, "maintenance window %s not found", id)
+	}
+	return nil
+}
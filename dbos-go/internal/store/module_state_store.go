@@ -3,8 +3,11 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"sync"
+	"time"
 
 	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
 	"github.com/internet-measurement-network/dbos/pkg/redis"
 )
 
@@ -20,9 +23,47 @@ func NewModuleStateStore(redis *redis.Client) *ModuleStateStore {
 	}
 }
 
-// SetModuleState stores a module state in the database
+// ErrVersionConflict is returned by SetModuleStateWithVersion when the
+// stored state is not at the expected version
+var ErrVersionConflict = dberrors.New(dberrors.Conflict, "module state version conflict")
+
+// SetModuleState stores a module state in the database as its next version,
+// records whether it reports an error, which health-gates config rollouts,
+// and notifies subscribers
 func (s *ModuleStateStore) SetModuleState(ctx context.Context, state *models.ModuleState) error {
-	return s.redis.SetModuleState(ctx, state.RequestID, state)
+	return s.setModuleState(ctx, state, -1)
+}
+
+// SetModuleStateWithVersion stores a module state only if the stored state
+// is at expectedVersion, 0 meaning none is stored yet. The check and write
+// are atomic, so of concurrent writers expecting the same version exactly
+// one succeeds; the others get ErrVersionConflict and state.Version set to
+// the stored version.
+func (s *ModuleStateStore) SetModuleStateWithVersion(ctx context.Context, state *models.ModuleState, expectedVersion int64) error {
+	return s.setModuleState(ctx, state, expectedVersion)
+}
+
+// setModuleState stores a module state, unconditionally if expectedVersion
+// is negative, and sets its version
+func (s *ModuleStateStore) setModuleState(ctx context.Context, state *models.ModuleState, expectedVersion int64) error {
+	written, version, err := s.redis.SetModuleState(ctx, state.RequestID, state.AgentID, state.ModuleName, state, expectedVersion)
+	if err != nil {
+		return err
+	}
+	state.Version = version
+	if !written {
+		return ErrVersionConflict
+	}
+
+	isError := state.State == string(models.ModuleStateError) || state.State == string(models.ModuleStateFailed)
+	if err := s.redis.RecordModuleOutcome(ctx, state.AgentID, state.RequestID, isError, time.Now()); err != nil {
+		return err
+	}
+
+	// Watchers resynchronize from ListModuleStates, so a lost notification
+	// does not fail the write
+	s.redis.PublishModuleState(ctx, state.AgentID, state)
+	return nil
 }
 
 // GetModuleState retrieves a module state from the database
@@ -58,3 +99,53 @@ func (s *ModuleStateStore) ListModuleStates(ctx context.Context, agentID, module
 
 	return states, nil
 }
+
+// SubscribeModuleStates subscribes to the states written for an agent's
+// module; an empty agent ID or module name matches any
+func (s *ModuleStateStore) SubscribeModuleStates(ctx context.Context, agentID, moduleName string) (ModuleStateSubscription, error) {
+	notifications, err := s.redis.SubscribeModuleStates(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &moduleStateSubscription{
+		notifications: notifications,
+		states:        make(chan *models.ModuleState),
+		done:          make(chan struct{}),
+	}
+	go func() {
+		defer close(sub.states)
+		for data := range notifications.States() {
+			var state models.ModuleState
+			if err := json.Unmarshal(data, &state); err != nil {
+				continue
+			}
+			if moduleName != "" && state.ModuleName != moduleName {
+				continue
+			}
+			select {
+			case sub.states <- &state:
+			case <-sub.done:
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// moduleStateSubscription decodes and filters module state notifications
+type moduleStateSubscription struct {
+	notifications *redis.ModuleStateNotifications
+	states        chan *models.ModuleState
+	done          chan struct{}
+	closeOnce     sync.Once
+}
+
+func (s *moduleStateSubscription) States() <-chan *models.ModuleState {
+	return s.states
+}
+
+func (s *moduleStateSubscription) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	return s.notifications.Close()
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// scoreCursor is the position of an entry in a sorted set index. Entries
+// with the same score are ordered by key, so the score and key of the last
+// entry returned pin a page's end even as entries are added before or
+// after it. The zero scoreCursor is the start of the index.
+type scoreCursor struct {
+	score int64
+	key   string
+}
+
+// parseScoreCursor parses the cursor returned with a previous page, an
+// empty cursor being the start
+func parseScoreCursor(cursor string) (scoreCursor, error) {
+	if cursor == "" {
+		return scoreCursor{}, nil
+	}
+	score, key, ok := strings.Cut(cursor, ":")
+	n, err := strconv.ParseInt(score, 10, 64)
+	if !ok || err != nil || key == "" {
+		return scoreCursor{}, dberrors.New(dberrors.InvalidArgument, "invalid cursor %q", cursor)
+	}
+	return scoreCursor{score: n, key: key}, nil
+}
+
+// String encodes the cursor
+func (c scoreCursor) String() string {
+	return fmt.Sprintf("%d:%s", c.score, c.key)
+}
+
+// passed reports whether an entry read from the cursor's score on is at or
+// before the cursor, in descending order if desc
+func (c scoreCursor) passed(score int64, key string, desc bool) bool {
+	if c.key == "" || score != c.score {
+		return false
+	}
+	if desc {
+		return key >= c.key
+	}
+	return key <= c.key
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// OutboxStore manages the messages waiting to be published to a message
+// bus, appended as results are stored and events logged so that each is
+// published at least once
+type OutboxStore struct {
+	redis  *redis.Client
+	maxLen int64
+}
+
+// NewOutboxStore creates a new outbox store holding about maxLen messages,
+// beyond which the oldest are dropped
+func NewOutboxStore(redis *redis.Client, maxLen int64) *OutboxStore {
+	return &OutboxStore{
+		redis:  redis,
+		maxLen: maxLen,
+	}
+}
+
+// outboxResult is a result as published, its data embedded as JSON
+type outboxResult struct {
+	*models.MeasurementResult
+	Data json.RawMessage `json:"data"`
+}
+
+// outboxEvent is a state event as published, with its ID
+type outboxEvent struct {
+	ID string `json:"id"`
+	*models.StateEvent
+}
+
+// AppendResults appends stored results to the outbox, keyed by agent
+func (s *OutboxStore) AppendResults(ctx context.Context, results []*models.MeasurementResult) error {
+	entries := make([]redis.OutboxEntry, len(results))
+	for i, result := range results {
+		// Data that is not JSON is published as a string
+		data := json.RawMessage(result.Data)
+		if !json.Valid(data) {
+			quoted, err := json.Marshal(string(result.Data))
+			if err != nil {
+				return err
+			}
+			data = quoted
+		}
+		encoded, err := json.Marshal(outboxResult{MeasurementResult: result, Data: data})
+		if err != nil {
+			return err
+		}
+		entries[i] = redis.OutboxEntry{
+			Kind: string(models.OutboxKindResult),
+			Key:  result.AgentID,
+			Tag:  result.ModuleName,
+			Data: encoded,
+		}
+	}
+	return s.redis.AppendOutbox(ctx, entries, s.maxLen)
+}
+
+// AppendEvent appends a logged state event to the outbox, keyed by entity
+func (s *OutboxStore) AppendEvent(ctx context.Context, event *models.StateEvent) error {
+	encoded, err := json.Marshal(outboxEvent{ID: event.ID, StateEvent: event})
+	if err != nil {
+		return err
+	}
+	return s.redis.AppendOutbox(ctx, []redis.OutboxEntry{{
+		Kind: string(models.OutboxKindEvent),
+		Key:  event.EntityID,
+		Tag:  event.EntityType,
+		Data: encoded,
+	}}, s.maxLen)
+}
+
+// CreateGroup creates the consumer group publishers read the outbox in,
+// unless it exists
+func (s *OutboxStore) CreateGroup(ctx context.Context) error {
+	return s.redis.CreateOutboxGroup(ctx)
+}
+
+// Read reads up to count messages for a publisher: those it read but did
+// not acknowledge if pending is set, otherwise new ones, waiting up to
+// block for some to arrive
+func (s *OutboxStore) Read(ctx context.Context, consumer string, pending bool, count int64, block time.Duration) ([]*models.OutboxEntry, error) {
+	data, err := s.redis.ReadOutbox(ctx, consumer, pending, count, block)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*models.OutboxEntry, len(data))
+	for i, entry := range data {
+		entries[i] = &models.OutboxEntry{
+			ID:   entry.ID,
+			Kind: entry.Kind,
+			Key:  entry.Key,
+			Tag:  entry.Tag,
+			Data: entry.Data,
+		}
+	}
+	return entries, nil
+}
+
+// Claim takes over up to count messages other publishers have held without
+// acknowledging them for at least minIdle, to be read as pending
+func (s *OutboxStore) Claim(ctx context.Context, consumer string, minIdle time.Duration, count int64) error {
+	return s.redis.ClaimOutbox(ctx, consumer, minIdle, count)
+}
+
+// Ack acknowledges published messages, removing them from the outbox
+func (s *OutboxStore) Ack(ctx context.Context, ids []string) error {
+	return s.redis.AckOutbox(ctx, ids)
+}
+
+// Length returns how many messages wait in the outbox
+func (s *OutboxStore) Length(ctx context.Context) (int64, error) {
+	return s.redis.OutboxLength(ctx)
+}
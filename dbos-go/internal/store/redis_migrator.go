@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// Migrations of the Redis backend, named in the events reporting them
+const (
+	// MigrationScheduledTasks moves the tasks earlier versions scheduled in
+	// one queue shared by all agents into their agents' queues
+	MigrationScheduledTasks = "scheduled_tasks"
+	// MigrationTaskIndex indexes the tasks last written before the indexes
+	// of modules, statuses and all tasks existed, once
+	MigrationTaskIndex = "task_index"
+	// MigrationResultIndex moves each agent's results out of the single
+	// time index earlier versions kept into its day shards
+	MigrationResultIndex = "result_index"
+)
+
+// migrateTaskIndexPageSize is how many task keys one step of the task
+// index migration scans
+const migrateTaskIndexPageSize = 1000
+
+// Migrator moves the data earlier versions of a backend stored into its
+// current layout. The server runs it at startup, for backends that
+// implement it.
+type Migrator interface {
+	// Migrate runs the backend's migrations, reporting each that moved any
+	// data; a failed migration does not stop the others
+	Migrate(ctx context.Context, report func(*models.DataMigration)) error
+}
+
+// RedisMigrator migrates the data of the Redis backend
+type RedisMigrator struct {
+	redis  *redis.Client
+	agents *AgentStore
+}
+
+var _ Migrator = (*RedisMigrator)(nil)
+
+// NewRedisMigrator creates a migrator of the Redis backend on a Redis client
+func NewRedisMigrator(redis *redis.Client) *RedisMigrator {
+	return &RedisMigrator{
+		redis:  redis,
+		agents: NewAgentStore(redis),
+	}
+}
+
+// Migrate moves scheduled tasks into their agents' queues, indexes the
+// tasks earlier versions did not, and moves the results of registered
+// agents into day shards
+func (m *RedisMigrator) Migrate(ctx context.Context, report func(*models.DataMigration)) error {
+	var errs []error
+	moved, err := m.redis.MigrateScheduledTasks(ctx)
+	if moved > 0 {
+		report(&models.DataMigration{Migration: MigrationScheduledTasks, Moved: moved})
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("moving scheduled tasks into agent queues: %w", err))
+	}
+
+	indexed, err := m.migrateTaskIndex(ctx)
+	if indexed > 0 {
+		report(&models.DataMigration{Migration: MigrationTaskIndex, Moved: indexed})
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("indexing tasks: %w", err))
+	}
+
+	agents, err := m.agents.ListAgents(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("moving results into day shards of their time indexes: %w", err))
+	}
+	for _, agent := range agents {
+		moved, err := m.redis.MigrateResultIndex(ctx, agent.ID)
+		if moved > 0 {
+			report(&models.DataMigration{Migration: MigrationResultIndex, AgentID: agent.ID, Moved: moved})
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("moving results of agent %s into day shards of its time index: %w", agent.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// migrateTaskIndex adds every stored task to the indexes of its current
+// values, unless that was done before, returning how many tasks it
+// indexed. Tasks are only added, so one written meanwhile is at worst left
+// in an index it no longer belongs to, which listing prunes.
+func (m *RedisMigrator) migrateTaskIndex(ctx context.Context) (int64, error) {
+	done, err := m.redis.MigrationDone(ctx, MigrationTaskIndex)
+	if err != nil || done {
+		return 0, err
+	}
+
+	var indexed int64
+	var cursor uint64
+	for {
+		tasksData, next, err := m.redis.ScanTasks(ctx, cursor, migrateTaskIndexPageSize)
+		if err != nil {
+			return indexed, err
+		}
+		tasks := make([]redis.IndexedTask, 0, len(tasksData))
+		for _, data := range tasksData {
+			var task models.Task
+			if err := json.Unmarshal(data, &task); err != nil {
+				continue
+			}
+			tasks = append(tasks, indexedTask(&task))
+		}
+		if err := m.redis.IndexTasks(ctx, tasks, nil); err != nil {
+			return indexed, err
+		}
+		indexed += int64(len(tasks))
+		if next == 0 {
+			return indexed, m.redis.SetMigrationDone(ctx, MigrationTaskIndex)
+		}
+		cursor = next
+	}
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// ErrRelayBufferFull is returned when a relay buffers more requests than its
+// limit allows
+var ErrRelayBufferFull = dberrors.New(dberrors.QuotaExceeded, "relay buffer is full")
+
+// RelayStore buffers the agent requests of a relay whose upstream server is
+// unreachable, in the order they were received
+type RelayStore struct {
+	redis *redis.Client
+	limit int
+}
+
+// NewRelayStore creates a new relay store holding at most limit requests
+func NewRelayStore(redis *redis.Client, limit int) *RelayStore {
+	return &RelayStore{
+		redis: redis,
+		limit: limit,
+	}
+}
+
+// Buffer appends a request to the buffer
+func (s *RelayStore) Buffer(ctx context.Context, req *models.RelayedRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	added, err := s.redis.BufferRelayRequest(ctx, data, int64(s.limit))
+	if err != nil {
+		return err
+	}
+	if !added {
+		return ErrRelayBufferFull
+	}
+	return nil
+}
+
+// Next retrieves the oldest buffered request without removing it, or nil if
+// the buffer is empty. Undecodable requests are dropped.
+func (s *RelayStore) Next(ctx context.Context) (*models.RelayedRequest, error) {
+	for {
+		data, err := s.redis.PeekRelayRequest(ctx)
+		if err != nil || data == nil {
+			return nil, err
+		}
+
+		var req models.RelayedRequest
+		if err := json.Unmarshal(data, &req); err == nil {
+			return &req, nil
+		}
+		if err := s.redis.PopRelayRequest(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Done removes the oldest buffered request once it was forwarded
+func (s *RelayStore) Done(ctx context.Context) error {
+	return s.redis.PopRelayRequest(ctx)
+}
+
+// Pending returns how many requests are buffered
+func (s *RelayStore) Pending(ctx context.Context) (int64, error) {
+	return s.redis.GetRelayBufferLength(ctx)
+}
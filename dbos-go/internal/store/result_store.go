@@ -3,14 +3,21 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"strconv"
+	"time"
 
 	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
 	"github.com/internet-measurement-network/dbos/pkg/redis"
 )
 
 // ResultStore manages measurement result persistence
 type ResultStore struct {
-	redis *redis.Client
+	redis   *redis.Client
+	blobs   *BlobStore
+	views   *ViewStore
+	indexes *IndexStore
+	outbox  *OutboxStore
 }
 
 // NewResultStore creates a new result store
@@ -20,9 +27,367 @@ func NewResultStore(redis *redis.Client) *ResultStore {
 	}
 }
 
-// StoreResult stores a measurement result in the database
-func (s *ResultStore) StoreResult(ctx context.Context, result *models.MeasurementResult) error {
-	return s.redis.StoreResult(ctx, result.AgentID, result.ID, result)
+// SetBlobStore enables deduplication of large repeated payload fragments
+func (s *ResultStore) SetBlobStore(blobs *BlobStore) {
+	s.blobs = blobs
+}
+
+// SetViewStore enables incremental maintenance of materialized views at ingest
+func (s *ResultStore) SetViewStore(views *ViewStore) {
+	s.views = views
+}
+
+// SetIndexStore enables indexing of extracted columns at ingest
+func (s *ResultStore) SetIndexStore(indexes *IndexStore) {
+	s.indexes = indexes
+}
+
+// SetOutbox enables appending stored results to the outbox, failing a store
+// whose result could not be appended so that it is delivered again
+func (s *ResultStore) SetOutbox(outbox *OutboxStore) {
+	s.outbox = outbox
+}
+
+// StoreResult stores a measurement result in the database, assigning it the
+// agent's next sequence number unless it is a re-delivery of a stored
+// result. It reports whether the result was a re-delivery.
+func (s *ResultStore) StoreResult(ctx context.Context, result *models.MeasurementResult) (bool, error) {
+	existing, err := s.getStoredResult(ctx, result.AgentID, result.ID)
+	if err == nil && existing.Sequence > 0 {
+		result.Sequence = existing.Sequence
+	} else {
+		seq, err := s.redis.NextResultSequence(ctx, result.AgentID)
+		if err != nil {
+			return false, err
+		}
+		result.Sequence = seq
+	}
+
+	stored := *result
+	if s.blobs != nil {
+		data, refs, err := s.blobs.Deduplicate(ctx, result.Data)
+		if err != nil {
+			return false, err
+		}
+		stored.Data = data
+		stored.BlobRefs = refs
+	}
+
+	if err := s.redis.StoreResult(ctx, result.AgentID, result.ID, result.Timestamp, &stored); err != nil {
+		if s.blobs != nil {
+			s.blobs.Release(ctx, stored.BlobRefs)
+		}
+		return false, err
+	}
+
+	// The overwritten version no longer holds its fragments
+	if s.blobs != nil && existing != nil {
+		if err := s.blobs.Release(ctx, existing.BlobRefs); err != nil {
+			return false, err
+		}
+	}
+	// nor its place in the time index, if measured on another day
+	if existing != nil && !existing.Timestamp.IsZero() && !sameResultDay(existing.Timestamp, result.Timestamp) {
+		if err := s.redis.UnindexResultTime(ctx, result.AgentID, result.ID, existing.Timestamp); err != nil {
+			return false, err
+		}
+	}
+
+	if err := s.redis.IndexResultSequence(ctx, result.AgentID, result.ID, result.Sequence); err != nil {
+		return false, err
+	}
+	if err := s.correlate(ctx, result, existing); err != nil {
+		return false, err
+	}
+	if s.outbox != nil {
+		if err := s.outbox.AppendResults(ctx, []*models.MeasurementResult{result}); err != nil {
+			return false, err
+		}
+	}
+
+	if s.indexes != nil {
+		if err := s.indexes.Apply(ctx, result); err != nil {
+			return false, err
+		}
+	}
+	if s.views != nil {
+		if err := s.views.Apply(ctx, result, existing != nil); err != nil {
+			return false, err
+		}
+	}
+	return existing != nil, nil
+}
+
+// StoreResults stores a batch of results as StoreResult does, reading and
+// writing them in a few pipelined round trips instead of several per
+// result. It reports the outcome per result. A result repeated in the batch
+// is stored after the results before it, as a re-delivery of the earlier
+// one.
+func (s *ResultStore) StoreResults(ctx context.Context, results []*models.MeasurementResult) ([]ResultOutcome, error) {
+	seen := make(map[redis.ResultRef]bool, len(results))
+	for i, result := range results {
+		ref := redis.ResultRef{AgentID: result.AgentID, RequestID: result.ID}
+		if !seen[ref] {
+			seen[ref] = true
+			continue
+		}
+		outcomes, err := s.storeResultBatch(ctx, results[:i])
+		if err != nil {
+			return nil, err
+		}
+		rest, err := s.StoreResults(ctx, results[i:])
+		if err != nil {
+			return nil, err
+		}
+		return append(outcomes, rest...), nil
+	}
+	return s.storeResultBatch(ctx, results)
+}
+
+// storeResultBatch stores a batch of distinct results
+func (s *ResultStore) storeResultBatch(ctx context.Context, results []*models.MeasurementResult) ([]ResultOutcome, error) {
+	refs := make([]redis.ResultRef, len(results))
+	for i, result := range results {
+		refs[i] = redis.ResultRef{AgentID: result.AgentID, RequestID: result.ID}
+	}
+	existingData, err := s.redis.GetResults(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-deliveries keep their sequence numbers; the others are assigned
+	// the agent's next ones
+	existing := make([]*models.MeasurementResult, len(results))
+	var unsequenced []int
+	var agentIDs []string
+	for i, data := range existingData {
+		var stored models.MeasurementResult
+		if data != nil && json.Unmarshal(data, &stored) == nil {
+			existing[i] = &stored
+		}
+		if existing[i] != nil && existing[i].Sequence > 0 {
+			results[i].Sequence = existing[i].Sequence
+			continue
+		}
+		unsequenced = append(unsequenced, i)
+		agentIDs = append(agentIDs, results[i].AgentID)
+	}
+	seqs, err := s.redis.NextResultSequences(ctx, agentIDs)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range unsequenced {
+		results[i].Sequence = seqs[j]
+	}
+
+	outcomes := make([]ResultOutcome, len(results))
+	writes := make([]redis.ResultWrite, 0, len(results))
+	written := make([]int, 0, len(results))
+	var blobRefs [][]string
+	for i, result := range results {
+		stored := *result
+		if s.blobs != nil {
+			data, refs, err := s.blobs.Deduplicate(ctx, result.Data)
+			if err != nil {
+				outcomes[i].Err = err
+				continue
+			}
+			stored.Data = data
+			stored.BlobRefs = refs
+			blobRefs = append(blobRefs, refs)
+		}
+		write := redis.ResultWrite{
+			AgentID:       result.AgentID,
+			RequestID:     result.ID,
+			Timestamp:     result.Timestamp,
+			Sequence:      result.Sequence,
+			CorrelationID: result.CorrelationID,
+			Result:        &stored,
+		}
+		if existing[i] != nil {
+			write.Previous = existing[i].Timestamp
+		}
+		writes = append(writes, write)
+		written = append(written, i)
+	}
+
+	if err := s.redis.StoreResults(ctx, writes); err != nil {
+		for _, refs := range blobRefs {
+			s.blobs.Release(ctx, refs)
+		}
+		return nil, err
+	}
+	if s.outbox != nil {
+		stored := make([]*models.MeasurementResult, len(written))
+		for j, i := range written {
+			stored[j] = results[i]
+		}
+		if err := s.outbox.AppendResults(ctx, stored); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, i := range written {
+		outcomes[i].Duplicate = existing[i] != nil
+		if existing[i] != nil && existing[i].CorrelationID != "" && existing[i].CorrelationID != results[i].CorrelationID {
+			if err := s.redis.UncorrelateResult(ctx, existing[i].CorrelationID, results[i].AgentID, results[i].ID); err != nil {
+				outcomes[i].Err = err
+				continue
+			}
+		}
+		// The overwritten version no longer holds its fragments
+		if s.blobs != nil && existing[i] != nil {
+			if err := s.blobs.Release(ctx, existing[i].BlobRefs); err != nil {
+				outcomes[i].Err = err
+				continue
+			}
+		}
+		if s.indexes != nil {
+			if err := s.indexes.Apply(ctx, results[i]); err != nil {
+				outcomes[i].Err = err
+				continue
+			}
+		}
+		// Views feed alert rules, which backfilled history would set off
+		if s.views != nil && !results[i].Backfilled {
+			outcomes[i].Err = s.views.Apply(ctx, results[i], existing[i] != nil)
+		}
+	}
+	return outcomes, nil
+}
+
+// DeleteResults deletes results, removing them from the time, sequence and
+// column indexes and releasing the fragments they hold
+func (s *ResultStore) DeleteResults(ctx context.Context, results []*models.MeasurementResult) error {
+	refs := make([]redis.ResultRef, len(results))
+	for i, result := range results {
+		refs[i] = redis.ResultRef{AgentID: result.AgentID, RequestID: result.ID}
+	}
+	storedData, err := s.redis.GetResults(ctx, refs)
+	if err != nil {
+		return err
+	}
+	timestamps := make([]time.Time, len(results))
+	correlationIDs := make([]string, len(results))
+	for i, result := range results {
+		timestamps[i] = result.Timestamp
+		correlationIDs[i] = result.CorrelationID
+	}
+	if err := s.redis.DeleteResults(ctx, refs, timestamps, correlationIDs); err != nil {
+		return err
+	}
+
+	for i, data := range storedData {
+		if s.indexes != nil {
+			if err := s.indexes.Remove(ctx, results[i]); err != nil {
+				return err
+			}
+		}
+		var stored models.MeasurementResult
+		if s.blobs != nil && data != nil && json.Unmarshal(data, &stored) == nil {
+			if err := s.blobs.Release(ctx, stored.BlobRefs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sameResultDay reports whether two measurement times are indexed under
+// the same day
+func sameResultDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// correlate indexes a stored result under its correlation ID, moving a
+// re-delivered one out of the index of the ID it had before
+func (s *ResultStore) correlate(ctx context.Context, result, existing *models.MeasurementResult) error {
+	if existing != nil && existing.CorrelationID != "" && existing.CorrelationID != result.CorrelationID {
+		if err := s.redis.UncorrelateResult(ctx, existing.CorrelationID, result.AgentID, result.ID); err != nil {
+			return err
+		}
+	}
+	if result.CorrelationID == "" {
+		return nil
+	}
+	return s.redis.CorrelateResult(ctx, result.CorrelationID, result.AgentID, result.ID, result.Timestamp)
+}
+
+// getStoredResult retrieves a result as stored, without expanding deduplicated fragments
+func (s *ResultStore) getStoredResult(ctx context.Context, agentID, requestID string) (*models.MeasurementResult, error) {
+	data, err := s.redis.GetResult(ctx, agentID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.MeasurementResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// expand restores deduplicated fragments into a result's payload
+func (s *ResultStore) expand(ctx context.Context, result *models.MeasurementResult) error {
+	if s.blobs == nil || len(result.BlobRefs) == 0 {
+		return nil
+	}
+
+	data, err := s.blobs.Expand(ctx, result.Data)
+	if err != nil {
+		return err
+	}
+	result.Data = data
+	result.BlobRefs = nil
+	return nil
+}
+
+// GetIngestGaps reports ranges of sequence numbers in [from, to] that have no
+// stored result. A zero to means up to the last assigned sequence.
+func (s *ResultStore) GetIngestGaps(ctx context.Context, agentID string, from, to int64) ([]models.SequenceGap, int64, error) {
+	last, err := s.redis.LastResultSequence(ctx, agentID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if from < 1 {
+		from = 1
+	}
+	if to <= 0 || to > last {
+		to = last
+	}
+	if from > to {
+		return nil, last, nil
+	}
+
+	seqs, err := s.redis.GetResultSequences(ctx, agentID, from, to)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var gaps []models.SequenceGap
+	next := from
+	for _, seq := range seqs {
+		if seq > next {
+			gaps = append(gaps, models.SequenceGap{From: next, To: seq - 1})
+		}
+		if seq >= next {
+			next = seq + 1
+		}
+	}
+	if next <= to {
+		gaps = append(gaps, models.SequenceGap{From: next, To: to})
+	}
+
+	return gaps, last, nil
+}
+
+// LastResultSequence returns the last sequence number assigned to an
+// agent's results, zero if none was
+func (s *ResultStore) LastResultSequence(ctx context.Context, agentID string) (int64, error) {
+	return s.redis.LastResultSequence(ctx, agentID)
 }
 
 // GetResult retrieves a measurement result from the database
@@ -36,10 +401,64 @@ func (s *ResultStore) GetResult(ctx context.Context, agentID, requestID string)
 	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, err
 	}
+	if err := s.expand(ctx, &result); err != nil {
+		return nil, err
+	}
 
 	return &result, nil
 }
 
+// HasResult reports whether a measurement result is stored, without
+// reading it
+func (s *ResultStore) HasResult(ctx context.Context, agentID, requestID string) (bool, error) {
+	return s.redis.ResultExists(ctx, agentID, requestID)
+}
+
+// resultCountScanBatch is the number of index entries read at once when
+// counting results of one module
+const resultCountScanBatch = 1000
+
+// CountResults counts an agent's results within rng. The time index counts
+// a range by itself; results of one module are counted by reading the
+// range's results, without expanding their payloads.
+func (s *ResultStore) CountResults(ctx context.Context, agentID string, rng models.ResultRange) (int64, error) {
+	min, max := "-inf", "+inf"
+	if !rng.From.IsZero() {
+		min = strconv.FormatInt(rng.From.Unix(), 10)
+	}
+	if !rng.To.IsZero() {
+		max = "(" + strconv.FormatInt(rng.To.Unix(), 10)
+	}
+	if rng.ModuleName == "" {
+		return s.redis.CountResultsByTime(ctx, agentID, min, max)
+	}
+
+	var count, offset int64
+	for {
+		entries, read, err := s.redis.GetResultsByTime(ctx, agentID, min, max, false, offset, resultCountScanBatch)
+		if err != nil {
+			return 0, err
+		}
+		offset += int64(read)
+
+		for _, entry := range entries {
+			var result struct {
+				ModuleName string `json:"module_name"`
+			}
+			if err := json.Unmarshal(entry.Data, &result); err != nil {
+				continue
+			}
+			if result.ModuleName == rng.ModuleName {
+				count++
+			}
+		}
+
+		if read < resultCountScanBatch {
+			return count, nil
+		}
+	}
+}
+
 // ListResults retrieves all results for an agent from the database
 func (s *ResultStore) ListResults(ctx context.Context, agentID string) ([]*models.MeasurementResult, error) {
 	resultsData, err := s.redis.GetResultsByAgent(ctx, agentID)
@@ -53,8 +472,184 @@ func (s *ResultStore) ListResults(ctx context.Context, agentID string) ([]*model
 		if err := json.Unmarshal(data, &result); err != nil {
 			continue
 		}
+		if err := s.expand(ctx, &result); err != nil {
+			continue
+		}
 		results = append(results, &result)
 	}
 
 	return results, nil
 }
+
+// ListResultsAfter retrieves up to limit of an agent's results with a
+// sequence above afterSequence, in sequence order
+func (s *ResultStore) ListResultsAfter(ctx context.Context, agentID string, afterSequence int64, limit int) ([]*models.MeasurementResult, error) {
+	resultsData, err := s.redis.GetResultsAfterSequence(ctx, agentID, afterSequence, int64(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.MeasurementResult, 0, len(resultsData))
+	for _, data := range resultsData {
+		var result models.MeasurementResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			continue
+		}
+		if err := s.expand(ctx, &result); err != nil {
+			continue
+		}
+		results = append(results, &result)
+	}
+
+	return results, nil
+}
+
+// resultPageScanLimit bounds the index entries one ListResultsPage call reads
+// when a module filter skips most of them; the page then ends early with a
+// cursor to continue from
+const resultPageScanLimit = 10000
+
+// resultPageScanBatch is the minimum number of index entries read at once
+const resultPageScanBatch = 100
+
+// ListResultsPage retrieves up to limit of an agent's results within rng in
+// an order, by default measurement time, starting after cursor. A page may
+// hold fewer than limit results while the returned cursor is non-empty.
+func (s *ResultStore) ListResultsPage(ctx context.Context, agentID string, rng models.ResultRange, order models.Order, cursor string, limit int) ([]*models.MeasurementResult, string, error) {
+	byTime := true
+	switch order.Field {
+	case "", models.ResultOrderTimestamp:
+	case models.ResultOrderSequence:
+		byTime = false
+	default:
+		return nil, "", dberrors.New(dberrors.InvalidArgument, "results cannot be ordered by %q", order.Field)
+	}
+
+	// The time index bounds the range itself; in sequence order results
+	// outside it are skipped as they are read
+	min, max := "-inf", "+inf"
+	if byTime && !rng.From.IsZero() {
+		min = strconv.FormatInt(rng.From.Unix(), 10)
+	}
+	if byTime && !rng.To.IsZero() {
+		max = "(" + strconv.FormatInt(rng.To.Unix(), 10)
+	}
+
+	after, err := parseScoreCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	if after.key != "" {
+		score := strconv.FormatInt(after.score, 10)
+		switch {
+		case order.Desc && (!byTime || rng.To.IsZero() || after.score < rng.To.Unix()):
+			max = score
+		case !order.Desc && (!byTime || rng.From.IsZero() || after.score > rng.From.Unix()):
+			min = score
+		}
+	}
+
+	batch := limit
+	if batch < resultPageScanBatch {
+		batch = resultPageScanBatch
+	}
+
+	results := make([]*models.MeasurementResult, 0, limit)
+	var offset int64
+	for offset < resultPageScanLimit {
+		var entries []redis.IndexedValue
+		var read int
+		if byTime {
+			entries, read, err = s.redis.GetResultsByTime(ctx, agentID, min, max, order.Desc, offset, int64(batch))
+		} else {
+			entries, read, err = s.redis.GetResultsBySequence(ctx, agentID, min, max, order.Desc, offset, int64(batch))
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		offset += int64(read)
+
+		for _, entry := range entries {
+			if after.passed(entry.Score, entry.Key, order.Desc) {
+				continue
+			}
+			after = scoreCursor{score: entry.Score, key: entry.Key}
+
+			var result models.MeasurementResult
+			if err := json.Unmarshal(entry.Data, &result); err != nil {
+				continue
+			}
+			if rng.ModuleName != "" && result.ModuleName != rng.ModuleName {
+				continue
+			}
+			if !byTime && !rng.Contains(result.Timestamp) {
+				continue
+			}
+			if err := s.expand(ctx, &result); err != nil {
+				continue
+			}
+			results = append(results, &result)
+			if len(results) == limit {
+				return results, after.String(), nil
+			}
+		}
+
+		if read < batch {
+			return results, "", nil
+		}
+	}
+
+	return results, after.String(), nil
+}
+
+// ListCorrelatedResults retrieves up to limit of the results of every agent
+// sharing a correlation ID, in measurement time order, starting after
+// cursor
+func (s *ResultStore) ListCorrelatedResults(ctx context.Context, correlationID, cursor string, limit int) ([]*models.MeasurementResult, string, error) {
+	after, err := parseScoreCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	min := "-inf"
+	if after.key != "" {
+		min = strconv.FormatInt(after.score, 10)
+	}
+
+	batch := limit
+	if batch < resultPageScanBatch {
+		batch = resultPageScanBatch
+	}
+
+	results := make([]*models.MeasurementResult, 0, limit)
+	var offset int64
+	for {
+		entries, read, err := s.redis.GetCorrelatedResults(ctx, correlationID, min, "+inf", offset, int64(batch))
+		if err != nil {
+			return nil, "", err
+		}
+		offset += int64(read)
+
+		for _, entry := range entries {
+			if after.passed(entry.Score, entry.Key, false) {
+				continue
+			}
+			after = scoreCursor{score: entry.Score, key: entry.Key}
+
+			var result models.MeasurementResult
+			if err := json.Unmarshal(entry.Data, &result); err != nil {
+				continue
+			}
+			if err := s.expand(ctx, &result); err != nil {
+				continue
+			}
+			results = append(results, &result)
+			if len(results) == limit {
+				return results, after.String(), nil
+			}
+		}
+
+		if read < batch {
+			return results, "", nil
+		}
+	}
+}
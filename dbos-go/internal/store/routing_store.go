@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// probeAnomalyRetention is how long probe anomalies are kept for correlation
+const probeAnomalyRetention = 24 * time.Hour
+
+// RoutingStore manages routing events, probe anomalies and the RTT
+// baselines anomalies are detected against
+type RoutingStore struct {
+	redis *redis.Client
+}
+
+// NewRoutingStore creates a new routing store
+func NewRoutingStore(redis *redis.Client) *RoutingStore {
+	return &RoutingStore{
+		redis: redis,
+	}
+}
+
+// RecordEvent stores a routing event, assigning its ID
+func (s *RoutingStore) RecordEvent(ctx context.Context, event *models.RoutingEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	id, err := s.redis.AppendRoutingEvent(ctx, data)
+	if err != nil {
+		return err
+	}
+	event.ID = id
+	return nil
+}
+
+// ListEvents retrieves up to limit routing events recorded since a time,
+// newest first, optionally restricted to a watched prefix
+func (s *RoutingStore) ListEvents(ctx context.Context, since time.Time, watchedPrefix string, limit int) ([]*models.RoutingEvent, error) {
+	entries, err := s.redis.GetRoutingEvents(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*models.RoutingEvent, 0)
+	for i := len(entries) - 1; i >= 0; i-- {
+		var event models.RoutingEvent
+		if err := json.Unmarshal(entries[i].Data, &event); err != nil {
+			continue
+		}
+		if watchedPrefix != "" && event.WatchedPrefix != watchedPrefix {
+			continue
+		}
+		event.ID = entries[i].ID
+		events = append(events, &event)
+		if limit > 0 && len(events) == limit {
+			break
+		}
+	}
+
+	return events, nil
+}
+
+// RecordAnomaly stores a probe anomaly towards a watched prefix
+func (s *RoutingStore) RecordAnomaly(ctx context.Context, watchedPrefix string, anomaly *models.ProbeAnomaly) error {
+	return s.redis.AddProbeAnomaly(ctx, watchedPrefix, anomaly.ObservedAt, anomaly, probeAnomalyRetention)
+}
+
+// ListAnomalies retrieves the probe anomalies towards a watched prefix seen
+// between from and to, oldest first
+func (s *RoutingStore) ListAnomalies(ctx context.Context, watchedPrefix string, from, to time.Time) ([]*models.ProbeAnomaly, error) {
+	anomaliesData, err := s.redis.GetProbeAnomalies(ctx, watchedPrefix, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	anomalies := make([]*models.ProbeAnomaly, 0, len(anomaliesData))
+	for _, data := range anomaliesData {
+		var anomaly models.ProbeAnomaly
+		if err := json.Unmarshal([]byte(data), &anomaly); err != nil {
+			continue
+		}
+		anomalies = append(anomalies, &anomaly)
+	}
+
+	return anomalies, nil
+}
+
+// GetRTTBaseline retrieves the RTT baseline from an agent to an address,
+// empty if none was recorded yet
+func (s *RoutingStore) GetRTTBaseline(ctx context.Context, agentID, address string) (*models.RTTBaseline, error) {
+	var baseline models.RTTBaseline
+	data, err := s.redis.GetRTTBaseline(ctx, agentID, address)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return &baseline, nil
+	}
+
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, err
+	}
+	return &baseline, nil
+}
+
+// SaveRTTBaseline stores the RTT baseline from an agent to an address
+func (s *RoutingStore) SaveRTTBaseline(ctx context.Context, agentID, address string, baseline *models.RTTBaseline) error {
+	return s.redis.SetRTTBaseline(ctx, agentID, address, baseline)
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// SavedQueryStore manages saved query definitions
+type SavedQueryStore struct {
+	redis *redis.Client
+}
+
+// NewSavedQueryStore creates a new saved query store
+func NewSavedQueryStore(redis *redis.Client) *SavedQueryStore {
+	return &SavedQueryStore{
+		redis: redis,
+	}
+}
+
+// CreateSavedQuery stores a new saved query
+func (s *SavedQueryStore) CreateSavedQuery(ctx context.Context, query *models.SavedQuery) error {
+	if err := validateSavedQuery(query); err != nil {
+		return err
+	}
+	if _, err := s.GetSavedQuery(ctx, query.Name); err == nil {
+		return dberrors.New(dberrors.AlreadyExists, "saved query %s already exists", query.Name)
+	}
+
+	now := time.Now()
+	query.CreatedAt, query.UpdatedAt = now, now
+	return s.setSavedQuery(ctx, query)
+}
+
+// UpdateSavedQuery replaces an existing saved query, keeping its creation time
+func (s *SavedQueryStore) UpdateSavedQuery(ctx context.Context, query *models.SavedQuery) error {
+	if err := validateSavedQuery(query); err != nil {
+		return err
+	}
+	existing, err := s.GetSavedQuery(ctx, query.Name)
+	if err != nil {
+		return err
+	}
+
+	query.CreatedAt, query.UpdatedAt = existing.CreatedAt, time.Now()
+	return s.setSavedQuery(ctx, query)
+}
+
+func (s *SavedQueryStore) setSavedQuery(ctx context.Context, query *models.SavedQuery) error {
+	data, err := json.Marshal(query)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetSavedQuery(ctx, query.Name, data)
+}
+
+// GetSavedQuery retrieves a saved query by name
+func (s *SavedQueryStore) GetSavedQuery(ctx context.Context, name string) (*models.SavedQuery, error) {
+	queries, err := s.ListSavedQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, query := range queries {
+		if query.Name == name {
+			return query, nil
+		}
+	}
+	return nil, dberrors.New(dberrors.NotFound, "saved query %s not found", name)
+}
+
+// ListSavedQueries retrieves all saved queries, ordered by name
+func (s *SavedQueryStore) ListSavedQueries(ctx context.Context) ([]*models.SavedQuery, error) {
+	data, err := s.redis.GetSavedQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]*models.SavedQuery, 0, len(data))
+	for _, raw := range data {
+		var query models.SavedQuery
+		if err := json.Unmarshal([]byte(raw), &query); err != nil {
+			continue
+		}
+		queries = append(queries, &query)
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].Name < queries[j].Name
+	})
+
+	return queries, nil
+}
+
+// DeleteSavedQuery removes a saved query
+func (s *SavedQueryStore) DeleteSavedQuery(ctx context.Context, name string) error {
+	found, err := s.redis.DeleteSavedQuery(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return dberrors.New(dberrors.NotFound, "saved query %s not found", name)
+	}
+	return nil
+}
+
+// validateSavedQuery checks that a saved query is well formed. Columns are
+// checked when it is executed, as extraction rules may change in between.
+func validateSavedQuery(query *models.SavedQuery) error {
+	if query.Name == "" {
+		return dberrors.New(dberrors.InvalidArgument, "saved query name is required")
+	}
+
+	switch models.SavedQueryTargetEnum(query.Target) {
+	case models.SavedQueryTargetResults:
+		if query.ModuleName == "" {
+			return dberrors.New(dberrors.InvalidArgument, "results query requires a module name")
+		}
+		if len(query.Filters) == 0 {
+			return dberrors.New(dberrors.InvalidArgument, "results query requires at least one filter")
+		}
+		if len(query.Labels) > 0 || query.AliveOnly {
+			return dberrors.New(dberrors.InvalidArgument, "labels and alive_only apply to agents queries only")
+		}
+	case models.SavedQueryTargetAgents:
+		if query.ModuleName != "" || len(query.Filters) > 0 || query.AgentID != "" {
+			return dberrors.New(dberrors.InvalidArgument, "module name, filters and agent ID apply to results queries only")
+		}
+	default:
+		return dberrors.New(dberrors.InvalidArgument, "unknown saved query target %q", query.Target)
+	}
+
+	if agg := query.Aggregation; agg != nil {
+		switch models.AggregationFunctionEnum(agg.Function) {
+		case models.AggregationCount:
+		case models.AggregationSum, models.AggregationAvg, models.AggregationMin, models.AggregationMax:
+			if query.Target != string(models.SavedQueryTargetResults) {
+				return dberrors.New(dberrors.InvalidArgument, "agents queries support the count aggregation only")
+			}
+			if agg.Column == "" {
+				return dberrors.New(dberrors.InvalidArgument, "%s aggregation requires a column", agg.Function)
+			}
+		default:
+			return dberrors.New(dberrors.InvalidArgument, "unknown aggregation function %q", agg.Function)
+		}
+	}
+
+	if query.Limit < 0 {
+		return dberrors.New(dberrors.InvalidArgument, "limit must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// Kinds of the definitions specs manage
+const (
+	managedCampaign = "campaign"
+	managedTask     = "task"
+)
+
+// SpecStore keeps the campaign and recurring task definitions ApplySpec
+// last applied, which drift detection compares them with, and the drift it
+// found
+type SpecStore struct {
+	redis *redis.Client
+}
+
+// NewSpecStore creates a new spec store
+func NewSpecStore(redis *redis.Client) *SpecStore {
+	return &SpecStore{
+		redis: redis,
+	}
+}
+
+// SaveManagedCampaign stores the definition a spec applied for a campaign
+func (s *SpecStore) SaveManagedCampaign(ctx context.Context, campaign *models.Campaign) error {
+	data, err := json.Marshal(campaign)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetManagedDefinition(ctx, managedCampaign, campaign.ID, data)
+}
+
+// ListManagedCampaigns retrieves the definitions specs applied for
+// campaigns, by ID
+func (s *SpecStore) ListManagedCampaigns(ctx context.Context) ([]*models.Campaign, error) {
+	data, err := s.redis.GetManagedDefinitions(ctx, managedCampaign)
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns := make([]*models.Campaign, 0, len(data))
+	for _, raw := range data {
+		var campaign models.Campaign
+		if err := json.Unmarshal([]byte(raw), &campaign); err != nil {
+			continue
+		}
+		campaigns = append(campaigns, &campaign)
+	}
+	sort.Slice(campaigns, func(i, j int) bool { return campaigns[i].ID < campaigns[j].ID })
+	return campaigns, nil
+}
+
+// SaveManagedTask stores the definition a spec applied for a recurring task
+func (s *SpecStore) SaveManagedTask(ctx context.Context, task *models.Task) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetManagedDefinition(ctx, managedTask, task.ID, data)
+}
+
+// ListManagedTasks retrieves the definitions specs applied for recurring
+// tasks, by ID
+func (s *SpecStore) ListManagedTasks(ctx context.Context) ([]*models.Task, error) {
+	data, err := s.redis.GetManagedDefinitions(ctx, managedTask)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, 0, len(data))
+	for _, raw := range data {
+		var task models.Task
+		if err := json.Unmarshal([]byte(raw), &task); err != nil {
+			continue
+		}
+		tasks = append(tasks, &task)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+// SaveDrift replaces the drift found by drift detection
+func (s *SpecStore) SaveDrift(ctx context.Context, drift []*models.Drift) error {
+	data := make(map[string][]byte, len(drift))
+	for _, d := range drift {
+		encoded, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		data[d.Key()] = encoded
+	}
+	return s.redis.SetDrift(ctx, data)
+}
+
+// GetDrift retrieves the drift found by drift detection, by drift key
+func (s *SpecStore) GetDrift(ctx context.Context) (map[string]*models.Drift, error) {
+	data, err := s.redis.GetDrift(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := make(map[string]*models.Drift, len(data))
+	for key, raw := range data {
+		var d models.Drift
+		if err := json.Unmarshal([]byte(raw), &d); err != nil {
+			continue
+		}
+		drift[key] = &d
+	}
+	return drift, nil
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// reachabilityRetention is how long hourly reachability counts are kept
+const reachabilityRetention = 48 * time.Hour
+
+// Reachability counts the probes from a region and how many reached their target
+type Reachability struct {
+	Probes    int64
+	Reachable int64
+}
+
+// StatusStore keeps the aggregate per-region counts behind the public status page
+type StatusStore struct {
+	redis *redis.Client
+}
+
+// NewStatusStore creates a new status store
+func NewStatusStore(redis *redis.Client) *StatusStore {
+	return &StatusStore{
+		redis: redis,
+	}
+}
+
+// RecordProbe counts a probe from a region
+func (s *StatusStore) RecordProbe(ctx context.Context, region string, reachable bool, at time.Time) error {
+	return s.redis.IncrReachability(ctx, region, reachable, at, reachabilityRetention)
+}
+
+// GetReachability sums the probe counts per region over the hours from
+// through to, which must lie within the last 48 hours
+func (s *StatusStore) GetReachability(ctx context.Context, from, to time.Time) (map[string]*Reachability, error) {
+	buckets, err := s.redis.GetReachability(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make(map[string]*Reachability)
+	for _, bucket := range buckets {
+		for field, value := range bucket {
+			i := strings.LastIndex(field, "|")
+			if i < 0 {
+				continue
+			}
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			region := field[:i]
+			if regions[region] == nil {
+				regions[region] = &Reachability{}
+			}
+			switch field[i+1:] {
+			case "probes":
+				regions[region].Probes += n
+			case "reachable":
+				regions[region].Reachable += n
+			}
+		}
+	}
+	return regions, nil
+}
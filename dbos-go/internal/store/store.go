@@ -0,0 +1,265 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// Store is a storage backend for the core DBOS data
+type Store interface {
+	Agents() Agents
+	ModuleStates() ModuleStates
+	Results() Results
+	Tasks() Tasks
+	Events() Events
+	// Ping checks that the backend answers, for measuring its load
+	Ping(ctx context.Context) error
+	// Close releases the backend's connections once the server stops
+	Close() error
+}
+
+// Agents persists the agent inventory; every change is recorded in the
+// backend's Events log
+type Agents interface {
+	RegisterAgent(ctx context.Context, agent *models.Agent) error
+	RecordHeartbeat(ctx context.Context, agentID, hostname string, now time.Time) (*models.Agent, error)
+	MarkDead(ctx context.Context, agentID string, cutoff time.Time) (bool, error)
+	DeleteAgent(ctx context.Context, agentID string) error
+	GetAgent(ctx context.Context, agentID string) (*models.Agent, error)
+	ListAgents(ctx context.Context) ([]*models.Agent, error)
+	// ListAgentsPage pages through agents with an opaque cursor, empty for
+	// the first page and returned empty after the last one
+	ListAgentsPage(ctx context.Context, cursor string, pageSize int) ([]*models.Agent, string, error)
+	// ListAgentsOrdered pages through agents in an order of one of
+	// models.AgentOrderFields, all at once if pageSize is 0
+	ListAgentsOrdered(ctx context.Context, order models.Order, cursor string, pageSize int) ([]*models.Agent, string, error)
+}
+
+// ModuleStates persists the states modules report per request
+type ModuleStates interface {
+	SetModuleState(ctx context.Context, state *models.ModuleState) error
+	// SetModuleStateWithVersion atomically stores a state only if the stored
+	// one is at expectedVersion, returning ErrVersionConflict otherwise
+	SetModuleStateWithVersion(ctx context.Context, state *models.ModuleState, expectedVersion int64) error
+	GetModuleState(ctx context.Context, requestID string) (*models.ModuleState, error)
+	ListModuleStates(ctx context.Context, agentID, moduleName string) ([]*models.ModuleState, error)
+	// SubscribeModuleStates notifies of the states written for an agent's
+	// module; an empty agent ID or module name matches any
+	SubscribeModuleStates(ctx context.Context, agentID, moduleName string) (ModuleStateSubscription, error)
+}
+
+// Results persists measurement results, assigning per-agent sequence numbers
+type Results interface {
+	// StoreResult stores a result, reporting whether it re-delivered a
+	// stored result
+	StoreResult(ctx context.Context, result *models.MeasurementResult) (bool, error)
+	// StoreResults stores a batch of results in a few round trips, reporting
+	// the outcome per result
+	StoreResults(ctx context.Context, results []*models.MeasurementResult) ([]ResultOutcome, error)
+	GetResult(ctx context.Context, agentID, requestID string) (*models.MeasurementResult, error)
+	HasResult(ctx context.Context, agentID, requestID string) (bool, error)
+	// CountResults counts an agent's results within a range
+	CountResults(ctx context.Context, agentID string, rng models.ResultRange) (int64, error)
+	ListResults(ctx context.Context, agentID string) ([]*models.MeasurementResult, error)
+	// ListResultsAfter pages through an agent's results in sequence order,
+	// returning up to limit results with a sequence above afterSequence
+	ListResultsAfter(ctx context.Context, agentID string, afterSequence int64, limit int) ([]*models.MeasurementResult, error)
+	// ListResultsPage pages through an agent's results within a range in
+	// measurement time order, or sequence order, with an opaque cursor that
+	// is empty for the first page and returned empty after the last one
+	ListResultsPage(ctx context.Context, agentID string, rng models.ResultRange, order models.Order, cursor string, limit int) ([]*models.MeasurementResult, string, error)
+	// ListCorrelatedResults pages through the results of every agent
+	// sharing a correlation ID in measurement time order, with a cursor as
+	// ListResultsPage does
+	ListCorrelatedResults(ctx context.Context, correlationID, cursor string, limit int) ([]*models.MeasurementResult, string, error)
+	GetIngestGaps(ctx context.Context, agentID string, from, to int64) ([]models.SequenceGap, int64, error)
+	// LastResultSequence returns the last sequence number assigned to an
+	// agent's results, zero if none was
+	LastResultSequence(ctx context.Context, agentID string) (int64, error)
+	// DeleteResults deletes results, removing them from every index
+	DeleteResults(ctx context.Context, results []*models.MeasurementResult) error
+}
+
+// ResultOutcome is the outcome of storing one result of a batch: Err if it
+// was not stored, and whether it re-delivered a stored result
+type ResultOutcome struct {
+	Duplicate bool
+	Err       error
+}
+
+// Tasks persists scheduled tasks and hands them to agents
+type Tasks interface {
+	ScheduleTask(ctx context.Context, task *models.Task) error
+	SubscribeTasks(ctx context.Context, agentID string) (TaskSubscription, error)
+	UpdateTask(ctx context.Context, task *models.Task) error
+	CancelTask(ctx context.Context, taskID string) error
+	RescheduleContinuousTask(ctx context.Context, task *models.Task, nextRun time.Time) error
+	ContinuousTaskScheduled(ctx context.Context, taskID string) (bool, error)
+	// ClaimDedupKey claims a deduplication key for a task being scheduled,
+	// returning the ID of the task that claimed it first and false if any
+	ClaimDedupKey(ctx context.Context, key, taskID string, ttl time.Duration) (string, bool, error)
+	ReleaseDedupKey(ctx context.Context, key, taskID string) error
+	// FinishGroupTask stores a group task whose instances have all finished
+	// and stops checking it
+	FinishGroupTask(ctx context.Context, task *models.Task) error
+	ListDueContinuousTasks(ctx context.Context, timestamp time.Time) ([]*models.Task, error)
+	GetTask(ctx context.Context, taskID string) (*models.Task, error)
+	// GetTasks retrieves tasks in one round trip, nil for those not found
+	GetTasks(ctx context.Context, taskIDs []string) ([]*models.Task, error)
+	// ListDueTasks retrieves up to limit due tasks of an agent, or of all
+	// agents taking turns if agentID is empty; zero lists them all
+	ListDueTasks(ctx context.Context, agentID string, timestamp time.Time, limit int) ([]*models.Task, error)
+	LeaseTask(ctx context.Context, agentID string, now time.Time) (*models.Task, error)
+	// AckTasks completes tasks an agent leased and NackTasks hands them back,
+	// requeued at requeueAt, counted as a retry with retry, or failed if
+	// requeueAt is zero; both report an error per task that was not settled
+	AckTasks(ctx context.Context, agentID string, taskIDs []string) ([]error, error)
+	NackTasks(ctx context.Context, agentID string, taskIDs []string, requeueAt time.Time, retry bool) ([]error, error)
+	// ExtendLease moves the end of the lease an agent holds on a running
+	// task to expiresAt
+	ExtendLease(ctx context.Context, agentID, taskID string, expiresAt, now time.Time) (*models.Task, error)
+	// ListExpiredTasks retrieves the tasks leased before leasedBefore that
+	// are still in flight, and RequeueExpiredTasks ends their leases,
+	// retrying those still running at requeueAt or as their retry policy
+	// backs off; it reports per task
+	// whether the lease was ended rather than settled by the agent first
+	ListExpiredTasks(ctx context.Context, leasedBefore time.Time) ([]*models.Task, error)
+	RequeueExpiredTasks(ctx context.Context, tasks []*models.Task, requeueAt time.Time) ([]bool, error)
+	// RestoreTask stores a task as rebuilt from the state event log, in the
+	// schedule its state implies
+	RestoreTask(ctx context.Context, task *models.Task, nextRun, leasedAt time.Time) error
+	// ListPendingTasks retrieves the tasks delivered to an agent and not yet
+	// settled, for queues that track deliveries
+	ListPendingTasks(ctx context.Context, agentID string) ([]*models.PendingTask, error)
+	// ListTasksPage pages through the tasks passing a filter in an order of
+	// one of models.TaskOrderFields, by default scheduled time; in creation
+	// time order, each task stored throughout is returned exactly once
+	ListTasksPage(ctx context.Context, filter models.TaskFilter, order models.Order, cursor string, limit int) ([]*models.Task, string, error)
+}
+
+// TaskSubscription notifies of tasks scheduled for one agent
+type TaskSubscription interface {
+	// Scheduled delivers the scheduled time of each new task; it is closed
+	// if the subscription fails
+	Scheduled() <-chan time.Time
+	Close() error
+}
+
+// ModuleStateSubscription notifies of module state writes
+type ModuleStateSubscription interface {
+	// States delivers each written state; it is closed if the subscription
+	// fails or its reader falls behind
+	States() <-chan *models.ModuleState
+	Close() error
+}
+
+// Events reads the log of agent inventory changes
+type Events interface {
+	LatestRevision(ctx context.Context) (string, error)
+	RevisionAvailable(ctx context.Context, revision string) (bool, error)
+	ListChanges(ctx context.Context, revision string, block time.Duration) ([]*models.AgentChange, error)
+}
+
+// RedisStore is the Redis storage backend
+type RedisStore struct {
+	redis        *redis.Client
+	agents       *AgentStore
+	moduleStates *ModuleStateStore
+	results      *ResultStore
+	tasks        *TaskStore
+	events       *EventStore
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore creates a storage backend on a Redis client
+func NewRedisStore(redis *redis.Client) *RedisStore {
+	return &RedisStore{
+		redis:        redis,
+		agents:       NewAgentStore(redis),
+		moduleStates: NewModuleStateStore(redis),
+		results:      NewResultStore(redis),
+		tasks:        NewTaskStore(redis),
+		events:       NewEventStore(redis),
+	}
+}
+
+// SetBlobStore enables deduplication of result payload fragments
+func (s *RedisStore) SetBlobStore(blobs *BlobStore) {
+	s.results.SetBlobStore(blobs)
+}
+
+// SetTaskStreams delivers leased tasks through Redis Streams, tracking
+// their deliveries
+func (s *RedisStore) SetTaskStreams(enabled bool) {
+	s.tasks.SetStreams(enabled)
+}
+
+// SetTaskLeaseTimeout sets how long task leases last by default
+func (s *RedisStore) SetTaskLeaseTimeout(timeout time.Duration) {
+	s.tasks.SetLeaseTimeout(timeout)
+}
+
+// SetViewStore enables incremental maintenance of materialized views at ingest
+func (s *RedisStore) SetViewStore(views *ViewStore) {
+	s.results.SetViewStore(views)
+}
+
+// SetIndexStore enables indexing of extracted columns at ingest
+func (s *RedisStore) SetIndexStore(indexes *IndexStore) {
+	s.results.SetIndexStore(indexes)
+}
+
+// SetOutbox enables appending stored results to the outbox
+func (s *RedisStore) SetOutbox(outbox *OutboxStore) {
+	s.results.SetOutbox(outbox)
+}
+
+// Agents returns the agent inventory
+func (s *RedisStore) Agents() Agents {
+	return s.agents
+}
+
+// ModuleStates returns the module states
+func (s *RedisStore) ModuleStates() ModuleStates {
+	return s.moduleStates
+}
+
+// Results returns the measurement results
+func (s *RedisStore) Results() Results {
+	return s.results
+}
+
+// Tasks returns the scheduled tasks
+func (s *RedisStore) Tasks() Tasks {
+	return s.tasks
+}
+
+// Events returns the agent change log
+func (s *RedisStore) Events() Events {
+	return s.events
+}
+
+// Ping checks that Redis answers
+func (s *RedisStore) Ping(ctx context.Context) error {
+	return s.redis.Ping(ctx)
+}
+
+// Close closes the Redis client
+func (s *RedisStore) Close() error {
+	return s.redis.Close()
+}
+
+// ClusterEnabled reports whether Redis runs in cluster mode
+func (s *RedisStore) ClusterEnabled(ctx context.Context) (bool, error) {
+	return s.redis.ClusterEnabled(ctx)
+}
+
+// CrossSlotOperations describes the operations Redis in cluster mode would
+// refuse for spanning hash slots
+func (s *RedisStore) CrossSlotOperations() []string {
+	return s.redis.CrossSlotOperations()
+}
@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// TargetHistoryStore manages which agents measured each target, kept for 30
+// days after a target's last measurement
+type TargetHistoryStore struct {
+	redis *redis.Client
+}
+
+// NewTargetHistoryStore creates a new target history store
+func NewTargetHistoryStore(redis *redis.Client) *TargetHistoryStore {
+	return &TargetHistoryStore{
+		redis: redis,
+	}
+}
+
+// RecordMeasured records that an agent measured a target with a module
+func (s *TargetHistoryStore) RecordMeasured(ctx context.Context, moduleName, target, agentID string, at time.Time) error {
+	return s.redis.RecordTargetMeasured(ctx, moduleName, target, agentID, at)
+}
+
+// ListAgents returns the agents that measured a target with a module, most
+// recent first
+func (s *TargetHistoryStore) ListAgents(ctx context.Context, moduleName, target string) ([]string, error) {
+	return s.redis.GetTargetAgents(ctx, moduleName, target)
+}
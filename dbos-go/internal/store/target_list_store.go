@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// TargetListStore manages the target lists specs define
+type TargetListStore struct {
+	redis *redis.Client
+}
+
+// NewTargetListStore creates a new target list store
+func NewTargetListStore(redis *redis.Client) *TargetListStore {
+	return &TargetListStore{
+		redis: redis,
+	}
+}
+
+// SaveTargetList stores a target list, replacing one of the same name
+func (s *TargetListStore) SaveTargetList(ctx context.Context, list *models.TargetList) error {
+	return s.redis.SetTargetList(ctx, list.Name, list)
+}
+
+// GetTargetList retrieves a target list by name, or nil if there is none
+func (s *TargetListStore) GetTargetList(ctx context.Context, name string) (*models.TargetList, error) {
+	data, err := s.redis.GetTargetList(ctx, name)
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var list models.TargetList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// TaskProgressStore manages the progress agents report for their tasks
+type TaskProgressStore struct {
+	redis *redis.Client
+}
+
+// NewTaskProgressStore creates a new task progress store
+func NewTaskProgressStore(redis *redis.Client) *TaskProgressStore {
+	return &TaskProgressStore{
+		redis: redis,
+	}
+}
+
+// SaveProgress stores the progress reported for a task, replacing the
+// progress reported before
+func (s *TaskProgressStore) SaveProgress(ctx context.Context, progress *models.TaskProgress) error {
+	return s.redis.SetTaskProgress(ctx, progress.TaskID, progress)
+}
+
+// GetProgress retrieves the progress last reported for a task
+func (s *TaskProgressStore) GetProgress(ctx context.Context, taskID string) (*models.TaskProgress, error) {
+	data, err := s.redis.GetTaskProgress(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var progress models.TaskProgress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
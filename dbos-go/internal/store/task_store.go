@@ -3,15 +3,24 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
 	"github.com/internet-measurement-network/dbos/pkg/redis"
 )
 
-// TaskStore manages task persistence
+// TaskStore manages task persistence. Leased tasks are tracked in an
+// in-flight set, or delivered through Redis Streams once streams are set.
 type TaskStore struct {
-	redis *redis.Client
+	redis   *redis.Client
+	streams bool
+	// leaseTimeout is how long after their start the requeuer ends leases,
+	// against which leases of other lengths are placed
+	leaseTimeout time.Duration
 }
 
 // NewTaskStore creates a new task store
@@ -21,9 +30,168 @@ func NewTaskStore(redis *redis.Client) *TaskStore {
 	}
 }
 
-// ScheduleTask schedules a task in the database
+// SetStreams delivers leased tasks through a Redis stream per agent instead
+// of the in-flight set, tracking their deliveries
+func (s *TaskStore) SetStreams(enabled bool) {
+	s.streams = enabled
+}
+
+// SetLeaseTimeout sets how long leases last unless their task sets its own
+// lease timeout or its agent extends them. Leases last until settled if it
+// is zero.
+func (s *TaskStore) SetLeaseTimeout(timeout time.Duration) {
+	s.leaseTimeout = timeout
+}
+
+// ScheduleTask schedules a task in the database. Continuous tasks are not
+// delivered themselves; they are registered for periodic re-issue instead.
 func (s *TaskStore) ScheduleTask(ctx context.Context, task *models.Task) error {
-	return s.redis.ScheduleTask(ctx, task.ID, task, task.ScheduledAt)
+	if err := s.reindexTask(ctx, task); err != nil {
+		return err
+	}
+
+	// Continuous tasks are re-issued from their own set, as are the instances
+	// of group tasks, which are only issued to their agents
+	if task.IsContinuous() || task.IsGroup() {
+		if err := s.redis.SetTask(ctx, task.ID, task); err != nil {
+			return err
+		}
+		return s.redis.AddContinuousTask(ctx, task.ID, task.ScheduledAt)
+	}
+	if err := s.redis.ScheduleTask(ctx, task.AgentID, task.ID, task, task.ScheduledAt); err != nil {
+		return err
+	}
+
+	// Streaming agents also poll, so a lost notification only delays delivery
+	s.redis.PublishTaskScheduled(ctx, task.AgentID, task.ScheduledAt)
+	return nil
+}
+
+// SubscribeTasks notifies of tasks scheduled for an agent from now on
+func (s *TaskStore) SubscribeTasks(ctx context.Context, agentID string) (TaskSubscription, error) {
+	notifications, err := s.redis.SubscribeTaskScheduled(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// UpdateTask overwrites a stored task without rescheduling it, releasing it
+// from the in-flight set once it has finished
+func (s *TaskStore) UpdateTask(ctx context.Context, task *models.Task) error {
+	if err := s.reindexTask(ctx, task); err != nil {
+		return err
+	}
+	if err := s.redis.SetTask(ctx, task.ID, task); err != nil {
+		return err
+	}
+	switch models.TaskStatusEnum(task.Status) {
+	case models.TaskStatusCompleted, models.TaskStatusFailed, models.TaskStatusCancelled:
+		return s.removeInflightTask(ctx, task)
+	}
+	return nil
+}
+
+// removeInflightTask ends whatever lease a task is under
+func (s *TaskStore) removeInflightTask(ctx context.Context, task *models.Task) error {
+	if s.streams {
+		_, err := s.redis.ReleaseStreamTasks(ctx, []redis.StreamRelease{{AgentID: task.AgentID, TaskID: task.ID}})
+		return err
+	}
+	return s.redis.RemoveInflightTask(ctx, task.ID)
+}
+
+// CancelTask marks a task as cancelled and removes it from all schedules
+func (s *TaskStore) CancelTask(ctx context.Context, taskID string) error {
+	task, err := s.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	task.Status = string(models.TaskStatusCancelled)
+	if err := s.indexTasks(ctx, task); err != nil {
+		return err
+	}
+	if err := s.redis.SetTask(ctx, task.ID, task); err != nil {
+		return err
+	}
+	if err := s.redis.RemoveScheduledTask(ctx, task.AgentID, task.ID); err != nil {
+		return err
+	}
+	if err := s.removeInflightTask(ctx, task); err != nil {
+		return err
+	}
+	if err := s.redis.RemoveContinuousTask(ctx, task.ID); err != nil {
+		return err
+	}
+
+	// The unfinished instances of a one-shot group task go with it
+	if task.IsGroup() && !task.IsContinuous() {
+		for _, agentID := range task.AgentIDs {
+			instance, err := s.GetTask(ctx, task.GroupInstanceID(agentID, time.Time{}))
+			if err != nil || instance.IsFinished() {
+				continue
+			}
+			if err := s.CancelTask(ctx, instance.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// FinishGroupTask stores a group task whose instances have all finished,
+// no longer checking it for agents joining or leaving its group
+func (s *TaskStore) FinishGroupTask(ctx context.Context, task *models.Task) error {
+	if err := s.indexTasks(ctx, task); err != nil {
+		return err
+	}
+	if err := s.redis.SetTask(ctx, task.ID, task); err != nil {
+		return err
+	}
+	return s.redis.RemoveContinuousTask(ctx, task.ID)
+}
+
+// ClaimDedupKey claims a deduplication key for a task being scheduled for
+// ttl, or returns the ID of the task that claimed it and false
+func (s *TaskStore) ClaimDedupKey(ctx context.Context, key, taskID string, ttl time.Duration) (string, bool, error) {
+	return s.redis.ClaimTaskDedupKey(ctx, key, taskID, ttl)
+}
+
+// ReleaseDedupKey releases a deduplication key claimed for a task that
+// could not be scheduled
+func (s *TaskStore) ReleaseDedupKey(ctx context.Context, key, taskID string) error {
+	return s.redis.ReleaseTaskDedupKey(ctx, key, taskID)
+}
+
+// RescheduleContinuousTask sets the next time a continuous task is re-issued
+func (s *TaskStore) RescheduleContinuousTask(ctx context.Context, task *models.Task, nextRun time.Time) error {
+	return s.redis.AddContinuousTask(ctx, task.ID, nextRun)
+}
+
+// ContinuousTaskScheduled reports whether a continuous task has a next run
+func (s *TaskStore) ContinuousTaskScheduled(ctx context.Context, taskID string) (bool, error) {
+	return s.redis.ContinuousTaskScheduled(ctx, taskID)
+}
+
+// ListDueContinuousTasks retrieves all continuous tasks that are due for
+// re-issue, and the group tasks due to issue instances
+func (s *TaskStore) ListDueContinuousTasks(ctx context.Context, timestamp time.Time) ([]*models.Task, error) {
+	tasksData, err := s.redis.GetDueContinuousTasks(ctx, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, 0, len(tasksData))
+	for _, data := range tasksData {
+		var task models.Task
+		if err := json.Unmarshal(data, &task); err != nil {
+			continue
+		}
+		tasks = append(tasks, &task)
+	}
+
+	return tasks, nil
 }
 
 // GetTask retrieves a task from the database
@@ -41,21 +209,649 @@ func (s *TaskStore) GetTask(ctx context.Context, taskID string) (*models.Task, e
 	return &task, nil
 }
 
-// ListDueTasks retrieves all due tasks from the database
-func (s *TaskStore) ListDueTasks(ctx context.Context, timestamp time.Time) ([]*models.Task, error) {
-	tasksData, err := s.redis.GetDueTasks(ctx, timestamp)
+// GetTasks retrieves tasks in one round trip, in the order of taskIDs, with
+// nil for those not found
+func (s *TaskStore) GetTasks(ctx context.Context, taskIDs []string) ([]*models.Task, error) {
+	data, err := s.redis.GetTasks(ctx, taskIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	tasks := make([]*models.Task, 0, len(tasksData))
-	for _, data := range tasksData {
+	tasks := make([]*models.Task, len(taskIDs))
+	for i, raw := range data {
+		if raw == nil {
+			continue
+		}
 		var task models.Task
-		if err := json.Unmarshal(data, &task); err != nil {
+		if err := json.Unmarshal(raw, &task); err != nil {
 			continue
 		}
-		tasks = append(tasks, &task)
+		tasks[i] = &task
+	}
+	return tasks, nil
+}
+
+// ListDueTasks retrieves up to limit tasks due at timestamp, or all of
+// them if limit is zero, from the queue of an agent or, if agentID is
+// empty, of every agent. Agents take turns: the list holds each agent's
+// earliest due task, then each one's second, and so on, so an agent with
+// a long backlog does not crowd out the others.
+func (s *TaskStore) ListDueTasks(ctx context.Context, agentID string, timestamp time.Time, limit int) ([]*models.Task, error) {
+	agentIDs := []string{agentID}
+	if agentID == "" {
+		var err error
+		agentIDs, err = s.redis.GetTaskQueueAgents(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(agentIDs)
+	}
+
+	queuesData, err := s.redis.GetDueTasks(ctx, agentIDs, timestamp, int64(limit))
+	if err != nil {
+		return nil, err
+	}
+	queues := make([][]*models.Task, len(queuesData))
+	for i, queueData := range queuesData {
+		for _, data := range queueData {
+			var task models.Task
+			if err := json.Unmarshal(data, &task); err != nil {
+				continue
+			}
+			queues[i] = append(queues[i], &task)
+		}
+	}
+
+	var tasks []*models.Task
+	for turn := 0; ; turn++ {
+		var round []*models.Task
+		for _, queue := range queues {
+			if turn < len(queue) {
+				round = append(round, queue[turn])
+			}
+		}
+		if len(round) == 0 {
+			break
+		}
+		// Within a turn the tasks due first come first
+		sort.SliceStable(round, func(i, j int) bool {
+			return round[i].ScheduledAt.Before(round[j].ScheduledAt)
+		})
+		tasks = append(tasks, round...)
+		if limit > 0 && len(tasks) >= limit {
+			return tasks[:limit], nil
+		}
+	}
+	return tasks, nil
+}
+
+// ListTasksPage retrieves up to limit of the tasks passing a filter in an
+// order, by default scheduled time, starting after cursor. It returns the
+// cursor of the next page, or an empty cursor after the last page. Tasks
+// are read from the index of the filter's agent, else its status, else its
+// module, else all tasks, within its range of the order's field; the rest
+// of the filter is checked against each task.
+func (s *TaskStore) ListTasksPage(ctx context.Context, filter models.TaskFilter, order models.Order, cursor string, limit int) ([]*models.Task, string, error) {
+	field := order.Field
+	if field == "" {
+		field = models.TaskOrderScheduledAt
+	}
+	if !slices.Contains(models.TaskOrderFields, field) {
+		return nil, "", dberrors.New(dberrors.InvalidArgument, "tasks cannot be ordered by %q", order.Field)
+	}
+
+	after, err := parseScoreCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	// Index scores are whole seconds; the filter checks the exact times
+	since, until := filter.TimeRange(field)
+	min, max := "-inf", "+inf"
+	if !since.IsZero() {
+		min = strconv.FormatInt(since.Unix(), 10)
+	}
+	if !until.IsZero() {
+		max = strconv.FormatInt(until.Unix(), 10)
+	}
+	if after.key != "" {
+		if order.Desc {
+			max = strconv.FormatInt(after.score, 10)
+		} else {
+			min = strconv.FormatInt(after.score, 10)
+		}
+	}
+
+	index := taskIndexOf(filter)
+	var stale []string
+	defer func() {
+		// Entries a failed write left behind are pruned once read
+		s.redis.UnindexTasks(ctx, index, models.TaskOrderFields, stale)
+	}()
+
+	tasks := make([]*models.Task, 0, limit)
+	var offset int64
+	for {
+		entries, read, err := s.redis.GetIndexedTasksByTime(ctx, index, field, min, max, order.Desc, offset, int64(limit))
+		if err != nil {
+			return nil, "", err
+		}
+		offset += int64(read)
+
+		for _, entry := range entries {
+			if after.passed(entry.Score, entry.Key, order.Desc) {
+				continue
+			}
+			after = scoreCursor{score: entry.Score, key: entry.Key}
+
+			var task models.Task
+			if err := json.Unmarshal(entry.Data, &task); err != nil {
+				continue
+			}
+			if !inTaskIndex(index, &task) {
+				stale = append(stale, entry.Key)
+				continue
+			}
+			if !filter.Matches(&task) {
+				continue
+			}
+			tasks = append(tasks, &task)
+			if len(tasks) == limit {
+				return tasks, after.String(), nil
+			}
+		}
+
+		if read < limit {
+			return tasks, "", nil
+		}
+	}
+}
+
+// taskIndexOf returns the index ListTasksPage reads the tasks passing a
+// filter from
+func taskIndexOf(filter models.TaskFilter) redis.TaskIndex {
+	switch {
+	case filter.AgentID != "":
+		return redis.TaskIndex{Dimension: redis.TaskIndexAgent, Value: filter.AgentID}
+	case filter.Status != "":
+		return redis.TaskIndex{Dimension: redis.TaskIndexStatus, Value: filter.Status}
+	case filter.ModuleName != "":
+		return redis.TaskIndex{Dimension: redis.TaskIndexModule, Value: filter.ModuleName}
+	default:
+		return redis.TaskIndex{Dimension: redis.TaskIndexAll}
+	}
+}
+
+// inTaskIndex reports whether a task still has the value of an index it was
+// indexed under
+func inTaskIndex(index redis.TaskIndex, task *models.Task) bool {
+	switch index.Dimension {
+	case redis.TaskIndexAgent:
+		return task.AgentID == index.Value
+	case redis.TaskIndexStatus:
+		return task.Status == index.Value
+	case redis.TaskIndexModule:
+		return task.ModuleName == index.Value
+	default:
+		return true
+	}
+}
+
+// indexTasks indexes tasks for ListTasksPage under their agent, module,
+// status and times as about to be written, removing them from the indexes
+// of their other statuses. It precedes the write, so a failed write leaves
+// at most entries ListTasksPage skips. Tasks whose agent or module may
+// have changed are indexed with reindexTask.
+func (s *TaskStore) indexTasks(ctx context.Context, tasks ...*models.Task) error {
+	indexed := make([]redis.IndexedTask, len(tasks))
+	for i, task := range tasks {
+		indexed[i] = indexedTask(task)
+	}
+	return s.redis.IndexTasks(ctx, indexed, taskStatuses())
+}
+
+// reindexTask is indexTasks for a task that may replace a stored one of
+// another agent or module, removing it from their indexes
+func (s *TaskStore) reindexTask(ctx context.Context, task *models.Task) error {
+	indexed := indexedTask(task)
+	previous, err := s.GetTask(ctx, task.ID)
+	switch {
+	case err == nil:
+		indexed.PreviousAgentID = previous.AgentID
+		indexed.PreviousModuleName = previous.ModuleName
+	case !redis.IsNotFound(err):
+		return err
+	}
+	return s.redis.IndexTasks(ctx, []redis.IndexedTask{indexed}, taskStatuses())
+}
+
+// indexedTask returns what a task is indexed under
+func indexedTask(task *models.Task) redis.IndexedTask {
+	return redis.IndexedTask{
+		ID:         task.ID,
+		AgentID:    task.AgentID,
+		ModuleName: task.ModuleName,
+		Status:     task.Status,
+		Times: map[string]int64{
+			models.TaskOrderScheduledAt: task.ScheduledAt.Unix(),
+			models.TaskOrderCreatedAt:   task.CreatedAt.Unix(),
+		},
+	}
+}
+
+// taskStatuses returns the statuses tasks are indexed by
+func taskStatuses() []string {
+	statuses := make([]string, len(models.TaskStatuses))
+	for i, status := range models.TaskStatuses {
+		statuses[i] = string(status)
+	}
+	return statuses
+}
+
+// LeaseTask atomically moves the earliest due task of an agent's queue in
+// flight and marks it running. It returns nil if the agent has no due task.
+func (s *TaskStore) LeaseTask(ctx context.Context, agentID string, now time.Time) (*models.Task, error) {
+	var data []byte
+	var deliveries int64
+	if s.streams {
+		lease, err := s.redis.LeaseStreamTask(ctx, agentID, now)
+		if err != nil || lease == nil {
+			return nil, err
+		}
+		data, deliveries = lease.Data, lease.Deliveries
+	} else {
+		var err error
+		// Leases are placed by their own length once the task is known
+		var leasedAt time.Time
+		if s.leaseTimeout > 0 {
+			leasedAt = now
+		}
+		data, err = s.redis.LeaseScheduledTask(ctx, agentID, now, leasedAt)
+		if err != nil || data == nil {
+			return nil, err
+		}
+	}
+
+	var task models.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, err
+	}
+	if s.streams {
+		task.Deliveries = deliveries
+	}
+
+	pending := task
+	if err := s.startLease(ctx, &task, now); err != nil {
+		// Left in flight while pending, the task would not be leased again
+		s.returnLease(ctx, &pending)
+		return nil, err
+	}
+	return &task, nil
+}
+
+// startLease marks a task just moved in flight as running under a lease
+// started at now. The task is already owned by the lease, so updating its
+// status separately cannot race another server.
+func (s *TaskStore) startLease(ctx context.Context, task *models.Task, now time.Time) error {
+	task.Status = string(models.TaskStatusRunning)
+	task.LeasedAt = &now
+	task.LeaseExtendedAt = nil
+	task.LeaseExpiresAt = nil
+	if err := s.indexTasks(ctx, task); err != nil {
+		return err
+	}
+	switch {
+	case task.LeaseTimeoutSeconds > 0:
+		task.LeaseExpiresAt = models.OptionalTime(now.Add(time.Duration(task.LeaseTimeoutSeconds) * time.Second))
+		_, err := s.renewLease(ctx, task, now)
+		return err
+	case s.leaseTimeout > 0:
+		task.LeaseExpiresAt = models.OptionalTime(now.Add(s.leaseTimeout))
+	}
+	return s.redis.SetTask(ctx, task.ID, task)
+}
+
+// returnLease hands a pending task whose lease could not be started back
+// to its agent's queue. Whatever fails here is left to the requeuer, which
+// reschedules the pending tasks it finds in flight.
+func (s *TaskStore) returnLease(ctx context.Context, task *models.Task) {
+	if s.streams {
+		if _, err := s.redis.ReleaseStreamTasks(ctx, []redis.StreamRelease{{AgentID: task.AgentID, TaskID: task.ID}}); err != nil {
+			return
+		}
+	}
+	if err := s.indexTasks(ctx, task); err != nil {
+		return
+	}
+	s.redis.ReturnLeasedTask(ctx, task.AgentID, task.ID, task.ScheduledAt)
+}
+
+// ExtendLease moves the end of the lease an agent holds on a running task
+// to expiresAt, which may also bring it forward, and returns the task
+func (s *TaskStore) ExtendLease(ctx context.Context, agentID, taskID string, expiresAt, now time.Time) (*models.Task, error) {
+	task, err := s.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.AgentID != agentID || task.Status != string(models.TaskStatusRunning) {
+		return nil, ErrTaskNotLeased
+	}
+
+	task.LeaseExpiresAt = &expiresAt
+	task.LeaseExtendedAt = &now
+	renewed, err := s.renewLease(ctx, task, now)
+	if err != nil {
+		return nil, err
+	}
+	if !renewed {
+		return nil, ErrTaskNotLeased
+	}
+	return task, nil
+}
+
+// renewLease places the lease of a task to end at its LeaseExpiresAt rather
+// than a lease timeout after it started, storing the task in the same step
+// so that a lease ended meanwhile is not revived. It reports false if the
+// lease has ended.
+func (s *TaskStore) renewLease(ctx context.Context, task *models.Task, now time.Time) (bool, error) {
+	if s.streams {
+		// Entries expire once idle for the lease timeout; a lease ending
+		// later than that is handed back to its agent whenever it is
+		// claimed, until it ends
+		idle := max(s.leaseTimeout-models.TimeOf(task.LeaseExpiresAt).Sub(now), 0)
+		return s.redis.RenewStreamTask(ctx, task.AgentID, task.ID, idle, task)
+	}
+	// The in-flight set holds when leases started, which the requeuer ends
+	// a lease timeout later
+	return s.redis.RenewInflightTask(ctx, task.ID, models.TimeOf(task.LeaseExpiresAt).Add(-s.leaseTimeout), task)
+}
+
+// ErrTaskNotLeased is reported for a task acknowledged by an agent that does
+// not hold a lease on it
+var ErrTaskNotLeased = dberrors.New(dberrors.FailedPrecondition, "task is not leased by the agent")
+
+// AckTasks marks tasks an agent leased as completed, recording how long each
+// ran since its lease, and reports an error per task that was not
+// acknowledged. The batch takes three Redis round trips however many tasks
+// it holds.
+func (s *TaskStore) AckTasks(ctx context.Context, agentID string, taskIDs []string) ([]error, error) {
+	now := time.Now()
+	return s.settleTasks(ctx, agentID, taskIDs, s.releaser(false), func(task *models.Task) time.Time {
+		task.Status = string(models.TaskStatusCompleted)
+		if leasedAt := models.TimeOf(task.LeasedAt); !leasedAt.IsZero() && now.After(leasedAt) {
+			task.DurationMs = float64(now.Sub(leasedAt)) / float64(time.Millisecond)
+		}
+		return time.Time{}
+	})
+}
+
+// NackTasks hands back tasks an agent leased but could not complete: they
+// are rescheduled at requeueAt, or failed if requeueAt is zero. With retry,
+// rescheduling counts as a retry of each task, whose retry policy may push
+// it back further. It reports an error per task that was not handed back.
+func (s *TaskStore) NackTasks(ctx context.Context, agentID string, taskIDs []string, requeueAt time.Time, retry bool) ([]error, error) {
+	now := time.Now()
+	return s.settleTasks(ctx, agentID, taskIDs, s.releaser(!requeueAt.IsZero()), func(task *models.Task) time.Time {
+		if requeueAt.IsZero() {
+			task.Status = string(models.TaskStatusFailed)
+			return time.Time{}
+		}
+		task.Status = string(models.TaskStatusPending)
+		task.ScheduledAt = requeueAt
+		if retry {
+			task.ScheduledAt = task.Retry(requeueAt, now)
+		}
+		return task.ScheduledAt
+	})
+}
+
+// releaseFunc ends the leases of tasks, reporting for each whether it was
+// still held
+type releaseFunc func(ctx context.Context, tasks []*models.Task) ([]bool, error)
+
+// releaser returns how an agent's leases are ended, keeping the deliveries
+// of tasks that are requeued
+func (s *TaskStore) releaser(requeue bool) releaseFunc {
+	if !s.streams {
+		return s.releaseInflight
+	}
+	return func(ctx context.Context, tasks []*models.Task) ([]bool, error) {
+		return s.releaseDeliveries(ctx, tasks, "", func(*models.Task) bool { return requeue })
+	}
+}
+
+// releaseInflight ends the leases of tasks by removing them from the
+// in-flight set
+func (s *TaskStore) releaseInflight(ctx context.Context, tasks []*models.Task) ([]bool, error) {
+	taskIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+	return s.redis.ReleaseInflightTasks(ctx, taskIDs)
+}
+
+// settleTasks ends the leases an agent holds on tasks with release,
+// updating each task with settle, which returns when to reschedule it or
+// the zero time
+func (s *TaskStore) settleTasks(ctx context.Context, agentID string, taskIDs []string, release releaseFunc, settle func(*models.Task) time.Time) ([]error, error) {
+	errs := make([]error, len(taskIDs))
+	data, err := s.redis.GetTasks(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, len(taskIDs))
+	var leased []*models.Task
+	for i, raw := range data {
+		if raw == nil {
+			errs[i] = dberrors.New(dberrors.NotFound, "task %s not found", taskIDs[i])
+			continue
+		}
+		var task models.Task
+		if err := json.Unmarshal(raw, &task); err != nil {
+			errs[i] = err
+			continue
+		}
+		if task.AgentID != agentID || task.Status != string(models.TaskStatusRunning) {
+			errs[i] = ErrTaskNotLeased
+			continue
+		}
+		tasks[i] = &task
+		leased = append(leased, &task)
+	}
+	if len(leased) == 0 {
+		return errs, nil
+	}
+
+	released, err := release(ctx, leased)
+	if err != nil {
+		return nil, err
 	}
 
+	writes := make([]redis.TaskWrite, 0, len(leased))
+	settled := make([]*models.Task, 0, len(leased))
+	var requeued time.Time
+	j := 0
+	for i, task := range tasks {
+		if task == nil {
+			continue
+		}
+		// Another acknowledgement of the same lease got there first
+		if !released[j] {
+			errs[i] = ErrTaskNotLeased
+			j++
+			continue
+		}
+		j++
+
+		scheduleAt := settle(task)
+		writes = append(writes, redis.TaskWrite{ID: task.ID, AgentID: task.AgentID, Task: task, ScheduleAt: scheduleAt})
+		settled = append(settled, task)
+		if !scheduleAt.IsZero() {
+			requeued = scheduleAt
+		}
+	}
+	if err := s.indexTasks(ctx, settled...); err != nil {
+		return nil, err
+	}
+	if err := s.redis.SetTasks(ctx, writes); err != nil {
+		return nil, err
+	}
+
+	if !requeued.IsZero() {
+		s.redis.PublishTaskScheduled(ctx, agentID, requeued)
+	}
+	return errs, nil
+}
+
+// ListExpiredTasks retrieves the in-flight tasks leased before leasedBefore,
+// including pending ones whose lease failed to start. Tasks deleted while
+// in flight are released on the way.
+func (s *TaskStore) ListExpiredTasks(ctx context.Context, leasedBefore time.Time) ([]*models.Task, error) {
+	if s.streams {
+		return s.listExpiredDeliveries(ctx, leasedBefore)
+	}
+	taskIDs, err := s.redis.GetExpiredInflightTasks(ctx, leasedBefore)
+	if err != nil || len(taskIDs) == 0 {
+		return nil, err
+	}
+	data, err := s.redis.GetTasks(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, 0, len(data))
+	for i, raw := range data {
+		if raw == nil {
+			if err := s.redis.RemoveInflightTask(ctx, taskIDs[i]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var task models.Task
+		if err := json.Unmarshal(raw, &task); err != nil {
+			continue
+		}
+		tasks = append(tasks, &task)
+	}
 	return tasks, nil
 }
+
+// RequeueExpiredTasks ends the expired leases of tasks from ListExpiredTasks:
+// those still running are retried, rescheduled as pending at requeueAt or
+// as their retry policy backs off from it, those pending are rescheduled
+// when they were due, and the others are stored with the status the caller
+// gave them. It reports for
+// each task whether its lease was ended here rather than settled first by
+// its agent.
+func (s *TaskStore) RequeueExpiredTasks(ctx context.Context, tasks []*models.Task, requeueAt time.Time) ([]bool, error) {
+	if s.streams {
+		return s.requeueExpiredTasks(ctx, tasks, requeueAt, func(ctx context.Context, tasks []*models.Task) ([]bool, error) {
+			return s.releaseDeliveries(ctx, tasks, redis.TaskStreamExpired, func(task *models.Task) bool {
+				return task.Status == string(models.TaskStatusRunning)
+			})
+		})
+	}
+	return s.requeueExpiredTasks(ctx, tasks, requeueAt, s.releaseInflight)
+}
+
+// requeueExpiredTasks ends the expired leases of tasks with release as
+// RequeueExpiredTasks describes
+func (s *TaskStore) requeueExpiredTasks(ctx context.Context, tasks []*models.Task, requeueAt time.Time, release releaseFunc) ([]bool, error) {
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	released, err := release(ctx, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	writes := make([]redis.TaskWrite, 0, len(tasks))
+	written := make([]*models.Task, 0, len(tasks))
+	// requeued holds the earliest time a task of each agent is due again
+	requeued := make(map[string]time.Time)
+	for i, task := range tasks {
+		if !released[i] {
+			continue
+		}
+		write := redis.TaskWrite{ID: task.ID, AgentID: task.AgentID, Task: task}
+		switch models.TaskStatusEnum(task.Status) {
+		case models.TaskStatusRunning:
+			task.Status = string(models.TaskStatusPending)
+			task.ScheduledAt = task.Retry(requeueAt, requeueAt)
+			fallthrough
+		case models.TaskStatusPending:
+			// A pending task in flight is one whose lease failed to start,
+			// which its agent never ran
+			write.ScheduleAt = task.ScheduledAt
+			if due, ok := requeued[task.AgentID]; !ok || task.ScheduledAt.Before(due) {
+				requeued[task.AgentID] = task.ScheduledAt
+			}
+		}
+		writes = append(writes, write)
+		written = append(written, task)
+	}
+	if err := s.indexTasks(ctx, written...); err != nil {
+		return nil, err
+	}
+	if err := s.redis.SetTasks(ctx, writes); err != nil {
+		return nil, err
+	}
+
+	for agentID, due := range requeued {
+		s.redis.PublishTaskScheduled(ctx, agentID, due)
+	}
+	return released, nil
+}
+
+// RestoreTask stores a task as rebuilt from the state event log, putting it
+// in the schedule its state implies: an open continuous or group task is
+// re-issued at nextRun, or at its scheduled time if nextRun is zero; any
+// other task is scheduled if pending and in flight since leasedAt if
+// running. Tasks delivered through streams are delivered anew, their lease
+// starting now.
+func (s *TaskStore) RestoreTask(ctx context.Context, task *models.Task, nextRun, leasedAt time.Time) error {
+	if err := s.redis.RemoveScheduledTask(ctx, task.AgentID, task.ID); err != nil {
+		return err
+	}
+	if err := s.removeInflightTask(ctx, task); err != nil {
+		return err
+	}
+	if err := s.redis.RemoveContinuousTask(ctx, task.ID); err != nil {
+		return err
+	}
+	if err := s.reindexTask(ctx, task); err != nil {
+		return err
+	}
+
+	if task.IsContinuous() || task.IsGroup() {
+		if err := s.redis.SetTask(ctx, task.ID, task); err != nil || task.IsFinished() {
+			return err
+		}
+		if nextRun.IsZero() {
+			nextRun = task.ScheduledAt
+		}
+		return s.redis.AddContinuousTask(ctx, task.ID, nextRun)
+	}
+
+	switch models.TaskStatusEnum(task.Status) {
+	case models.TaskStatusPending:
+		return s.redis.ScheduleTask(ctx, task.AgentID, task.ID, task, task.ScheduledAt)
+	case models.TaskStatusRunning:
+		if err := s.redis.SetTask(ctx, task.ID, task); err != nil {
+			return err
+		}
+		if s.streams {
+			return s.redis.DeliverStreamTask(ctx, task.AgentID, task.ID)
+		}
+		// A lease of its own length, or extended, ends when it said, and
+		// one without a lease timeout never does
+		if expiresAt := models.TimeOf(task.LeaseExpiresAt); !expiresAt.IsZero() {
+			leasedAt = expiresAt.Add(-s.leaseTimeout)
+		} else if s.leaseTimeout == 0 {
+			leasedAt = time.Time{}
+		}
+		return s.redis.AddInflightTask(ctx, task.ID, leasedAt)
+	}
+	return s.redis.SetTask(ctx, task.ID, task)
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// maxPendingTasks caps the pending tasks ListPendingTasks returns
+const maxPendingTasks = 1000
+
+// releaseDeliveries ends the deliveries of tasks pending with consumer, or
+// with the agent or the expired consumer if it is empty. The deliveries of
+// tasks keep reports true for are kept pending for redelivery.
+func (s *TaskStore) releaseDeliveries(ctx context.Context, tasks []*models.Task, consumer string, keep func(*models.Task) bool) ([]bool, error) {
+	releases := make([]redis.StreamRelease, len(tasks))
+	for i, task := range tasks {
+		releases[i] = redis.StreamRelease{
+			AgentID:       task.AgentID,
+			TaskID:        task.ID,
+			Consumer:      consumer,
+			ExceptWaiting: consumer == "",
+			Keep:          keep(task),
+		}
+	}
+	return s.redis.ReleaseStreamTasks(ctx, releases)
+}
+
+// listExpiredDeliveries claims the deliveries idle since before
+// leasedBefore for the requeuer and retrieves their running and pending
+// tasks. Tasks
+// deleted while delivered are released on the way; tasks waiting to be
+// redelivered are left waiting, and those whose lease ends later, being
+// of their own length or extended, are handed back to their agent.
+func (s *TaskStore) listExpiredDeliveries(ctx context.Context, leasedBefore time.Time) ([]*models.Task, error) {
+	now := time.Now()
+	expired, err := s.redis.ExpireStreamTasks(ctx, time.Since(leasedBefore))
+	if err != nil || len(expired) == 0 {
+		return nil, err
+	}
+	taskIDs := make([]string, len(expired))
+	for i, entry := range expired {
+		taskIDs[i] = entry.TaskID
+	}
+	data, err := s.redis.GetTasks(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, 0, len(data))
+	var deleted []redis.StreamRelease
+	for i, raw := range data {
+		var task models.Task
+		if raw == nil || json.Unmarshal(raw, &task) != nil {
+			if raw == nil {
+				deleted = append(deleted, redis.StreamRelease{AgentID: expired[i].AgentID, TaskID: expired[i].TaskID})
+			}
+			continue
+		}
+		// A pending task delivered is one whose lease failed to start
+		if task.Status != string(models.TaskStatusRunning) && task.Status != string(models.TaskStatusPending) {
+			continue
+		}
+		// Without a lease timeout, leases of no length of their own never end
+		if expiresAt := models.TimeOf(task.LeaseExpiresAt); expiresAt.After(now) || expiresAt.IsZero() && s.leaseTimeout == 0 {
+			idle := max(s.leaseTimeout-expiresAt.Sub(now), 0)
+			if _, err := s.redis.RenewStreamTask(ctx, task.AgentID, task.ID, idle, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		tasks = append(tasks, &task)
+	}
+	if _, err := s.redis.ReleaseStreamTasks(ctx, deleted); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ListPendingTasks retrieves the tasks delivered to an agent that were not
+// settled yet, oldest delivery first. Only the streams task queue tracks
+// deliveries.
+func (s *TaskStore) ListPendingTasks(ctx context.Context, agentID string) ([]*models.PendingTask, error) {
+	if !s.streams {
+		return nil, dberrors.New(dberrors.FailedPrecondition, "pending tasks are only tracked by the streams task queue")
+	}
+
+	entries, err := s.redis.GetPendingStreamTasks(ctx, agentID, maxPendingTasks)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*models.PendingTask, len(entries))
+	for i, entry := range entries {
+		tasks[i] = &models.PendingTask{
+			TaskID:     entry.TaskID,
+			EntryID:    entry.EntryID,
+			Consumer:   entry.Consumer,
+			Idle:       entry.Idle,
+			Deliveries: entry.Deliveries,
+		}
+	}
+	return tasks, nil
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+	"github.com/internet-measurement-network/dbos/pkg/tdigest"
+)
+
+// TrendStore manages long-term per-day metric digests. Values are appended
+// raw at ingest and compacted into t-digests by Rollup once their day is over.
+type TrendStore struct {
+	redis *redis.Client
+}
+
+// NewTrendStore creates a new trend store
+func NewTrendStore(redis *redis.Client) *TrendStore {
+	return &TrendStore{
+		redis: redis,
+	}
+}
+
+// seriesKey identifies a trend series in Redis
+func seriesKey(series models.TrendSeries) string {
+	return strings.Join([]string{series.Metric, series.AgentID, series.Target}, "|")
+}
+
+// RecordValue records a metric value measured at ts
+func (s *TrendStore) RecordValue(ctx context.Context, series models.TrendSeries, ts time.Time, value float64) error {
+	day := ts.UTC().Format(models.ViewDayFormat)
+	return s.redis.AppendTrendValue(ctx, seriesKey(series), day, value)
+}
+
+// Rollup compacts the raw values of every day before today into that day's
+// digest, merging with values compacted earlier. It returns the number of
+// series-days compacted.
+func (s *TrendStore) Rollup(ctx context.Context, now time.Time) (int, error) {
+	today := now.UTC().Format(models.ViewDayFormat)
+	pending, err := s.redis.PendingTrendDays(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	compacted := 0
+	for _, p := range pending {
+		series, day := p[0], p[1]
+		if day >= today {
+			continue
+		}
+
+		values, err := s.redis.GetTrendValues(ctx, series, day)
+		if err != nil {
+			return compacted, err
+		}
+
+		digest := tdigest.New(tdigest.DefaultCompression)
+		existing, err := s.redis.GetTrendDigest(ctx, series, day)
+		if err != nil {
+			return compacted, err
+		}
+		if existing != nil {
+			if err := digest.UnmarshalBinary(existing); err != nil {
+				return compacted, err
+			}
+		}
+		for _, v := range values {
+			digest.Add(v, 1)
+		}
+
+		data, err := digest.MarshalBinary()
+		if err != nil {
+			return compacted, err
+		}
+		if err := s.redis.CompactTrendDay(ctx, series, day, data, len(values)); err != nil {
+			return compacted, err
+		}
+		compacted++
+	}
+
+	return compacted, nil
+}
+
+// GetTrends retrieves the digest of every day in [from, to] that has values,
+// including values not yet compacted
+func (s *TrendStore) GetTrends(ctx context.Context, series models.TrendSeries, from, to time.Time) ([]*models.TrendDay, error) {
+	key := seriesKey(series)
+	digests, err := s.redis.GetTrendDigests(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.redis.PendingTrendDays(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pendingDays := make(map[string]bool)
+	for _, p := range pending {
+		if p[0] == key {
+			pendingDays[p[1]] = true
+		}
+	}
+
+	var days []*models.TrendDay
+	for d := from.UTC(); !d.After(to.UTC()); d = d.AddDate(0, 0, 1) {
+		day := d.Format(models.ViewDayFormat)
+		existing, compacted := digests[day]
+		if !compacted && !pendingDays[day] {
+			continue
+		}
+
+		digest := tdigest.New(tdigest.DefaultCompression)
+		if compacted {
+			if err := digest.UnmarshalBinary([]byte(existing)); err != nil {
+				return nil, err
+			}
+		}
+		if pendingDays[day] {
+			values, err := s.redis.GetTrendValues(ctx, key, day)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range values {
+				digest.Add(v, 1)
+			}
+		}
+
+		days = append(days, &models.TrendDay{Day: day, Digest: digest})
+	}
+
+	return days, nil
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// Counters of a usage bucket field, which is named
+// kind|method|counter|principal; the principal comes last as key names may
+// hold any character
+const (
+	usageCalls    = "calls"
+	usageErrors   = "errors"
+	usageBytesIn  = "bytes_in"
+	usageBytesOut = "bytes_out"
+)
+
+// UsageStore keeps per-minute counts of the RPCs made by each agent and API
+// key
+type UsageStore struct {
+	redis *redis.Client
+}
+
+// NewUsageStore creates a new usage store
+func NewUsageStore(redis *redis.Client) *UsageStore {
+	return &UsageStore{
+		redis: redis,
+	}
+}
+
+// RecordUsage adds usage counted in the minute containing at
+func (s *UsageStore) RecordUsage(ctx context.Context, at time.Time, usage map[models.UsageKey]models.Usage) error {
+	counts := make(map[string]int64, 4*len(usage))
+	for key, u := range usage {
+		for counter, n := range map[string]int64{
+			usageCalls:    u.Calls,
+			usageErrors:   u.Errors,
+			usageBytesIn:  u.BytesIn,
+			usageBytesOut: u.BytesOut,
+		} {
+			if n != 0 {
+				counts[key.Kind+"|"+key.Method+"|"+counter+"|"+key.Principal] += n
+			}
+		}
+	}
+	return s.redis.IncrUsage(ctx, at, counts, models.UsageRetention)
+}
+
+// GetUsage sums the usage counted over the minutes from through to, which
+// must lie within the usage retention
+func (s *UsageStore) GetUsage(ctx context.Context, from, to time.Time) (map[models.UsageKey]*models.Usage, error) {
+	buckets, err := s.redis.GetUsage(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[models.UsageKey]*models.Usage)
+	for _, bucket := range buckets {
+		for field, value := range bucket {
+			parts := strings.SplitN(field, "|", 4)
+			if len(parts) != 4 {
+				continue
+			}
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			key := models.UsageKey{Kind: parts[0], Method: parts[1], Principal: parts[3]}
+			if usage[key] == nil {
+				usage[key] = &models.Usage{}
+			}
+			switch parts[2] {
+			case usageCalls:
+				usage[key].Calls += n
+			case usageErrors:
+				usage[key].Errors += n
+			case usageBytesIn:
+				usage[key].BytesIn += n
+			case usageBytesOut:
+				usage[key].BytesOut += n
+			}
+		}
+	}
+	return usage, nil
+}
+
+// RecordQuotaUsage adds usage of a quota in the window starting at
+// windowStart, by principal; the counts are kept for as long as the window
+// lasts
+func (s *UsageStore) RecordQuotaUsage(ctx context.Context, quota string, windowStart time.Time, window time.Duration, usage map[models.UsageKey]int64) error {
+	counts := make(map[string]int64, len(usage))
+	for key, n := range usage {
+		if n != 0 {
+			counts[key.Kind+"|"+key.Principal] += n
+		}
+	}
+	return s.redis.IncrQuotaUsage(ctx, quota, windowStart, counts, window)
+}
+
+// GetQuotaUsage retrieves the usage of a quota in the window starting at
+// windowStart, by principal; the keys have no method
+func (s *UsageStore) GetQuotaUsage(ctx context.Context, quota string, windowStart time.Time) (map[models.UsageKey]int64, error) {
+	fields, err := s.redis.GetQuotaUsage(ctx, quota, windowStart)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[models.UsageKey]int64, len(fields))
+	for field, value := range fields {
+		kind, principal, ok := strings.Cut(field, "|")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		usage[models.UsageKey{Kind: kind, Principal: principal}] = n
+	}
+	return usage, nil
+}
+
+// MarkQuotaNotified records that a principal was notified of reaching a
+// level of a quota in the window starting at windowStart, reporting
+// whether it is the first to, so that servers sharing the store notify
+// once per window
+func (s *UsageStore) MarkQuotaNotified(ctx context.Context, quota string, windowStart time.Time, window time.Duration, level string, key models.UsageKey) (bool, error) {
+	return s.redis.MarkQuotaNotified(ctx, quota, windowStart, level+"|"+key.Kind+"|"+key.Principal, window)
+}
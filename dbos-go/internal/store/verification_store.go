@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// VerificationStore manages verification persistence
+type VerificationStore struct {
+	redis *redis.Client
+}
+
+// NewVerificationStore creates a new verification store
+func NewVerificationStore(redis *redis.Client) *VerificationStore {
+	return &VerificationStore{
+		redis: redis,
+	}
+}
+
+// SaveVerification stores a verification in the database
+func (s *VerificationStore) SaveVerification(ctx context.Context, verification *models.Verification) error {
+	return s.redis.SetVerification(ctx, verification.ID, verification)
+}
+
+// GetVerification retrieves a verification with the values reported so far
+func (s *VerificationStore) GetVerification(ctx context.Context, verificationID string) (*models.Verification, error) {
+	data, err := s.redis.GetVerification(ctx, verificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var verification models.Verification
+	if err := json.Unmarshal(data, &verification); err != nil {
+		return nil, err
+	}
+
+	values, err := s.redis.GetVerificationValues(ctx, verificationID)
+	if err != nil {
+		return nil, err
+	}
+	verification.Values = values
+
+	return &verification, nil
+}
+
+// RecordValue records the compared value an agent reported and re-evaluates agreement
+func (s *VerificationStore) RecordValue(ctx context.Context, verificationID, agentID, value string) (*models.Verification, error) {
+	if err := s.redis.SetVerificationValue(ctx, verificationID, agentID, value); err != nil {
+		return nil, err
+	}
+
+	verification, err := s.GetVerification(ctx, verificationID)
+	if err != nil {
+		return nil, err
+	}
+
+	verification.Evaluate()
+	if err := s.SaveVerification(ctx, verification); err != nil {
+		return nil, err
+	}
+
+	return verification, nil
+}
@@ -0,0 +1,226 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/internet-measurement-network/dbos/internal/models"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/jsonpath"
+	"github.com/internet-measurement-network/dbos/pkg/redis"
+)
+
+// ViewStore manages materialized view definitions and their rows
+type ViewStore struct {
+	redis *redis.Client
+}
+
+// NewViewStore creates a new view store
+func NewViewStore(redis *redis.Client) *ViewStore {
+	return &ViewStore{
+		redis: redis,
+	}
+}
+
+// CreateView stores a view definition
+func (s *ViewStore) CreateView(ctx context.Context, view *models.View) error {
+	switch models.ViewKindEnum(view.Kind) {
+	case models.ViewKindLatest:
+	case models.ViewKindDaily:
+		if view.ValueField == "" {
+			return dberrors.New(dberrors.InvalidArgument, "daily view requires a value field")
+		}
+	default:
+		return dberrors.New(dberrors.InvalidArgument, "unknown view kind %q", view.Kind)
+	}
+
+	views, err := s.ListViews(ctx)
+	if err != nil {
+		return err
+	}
+	for _, existing := range views {
+		if existing.Name == view.Name {
+			return dberrors.New(dberrors.AlreadyExists, "view %s already exists", view.Name)
+		}
+	}
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		return err
+	}
+	return s.redis.SetView(ctx, view.Name, data)
+}
+
+// GetView retrieves a view definition by name
+func (s *ViewStore) GetView(ctx context.Context, name string) (*models.View, error) {
+	views, err := s.ListViews(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, view := range views {
+		if view.Name == name {
+			return view, nil
+		}
+	}
+	return nil, dberrors.New(dberrors.NotFound, "view %s not found", name)
+}
+
+// ListViews retrieves all view definitions, ordered by name
+func (s *ViewStore) ListViews(ctx context.Context) ([]*models.View, error) {
+	data, err := s.redis.GetViews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*models.View, 0, len(data))
+	for _, raw := range data {
+		var view models.View
+		if err := json.Unmarshal([]byte(raw), &view); err != nil {
+			continue
+		}
+		views = append(views, &view)
+	}
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+
+	return views, nil
+}
+
+// DeleteView removes a view and its rows
+func (s *ViewStore) DeleteView(ctx context.Context, name string) error {
+	return s.redis.DeleteView(ctx, name)
+}
+
+// Apply folds a newly stored result into every view it feeds. Re-deliveries
+// of a stored result are not aggregated again.
+func (s *ViewStore) Apply(ctx context.Context, result *models.MeasurementResult, redelivered bool) error {
+	views, err := s.ListViews(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, view := range views {
+		if redelivered && view.Kind == string(models.ViewKindDaily) {
+			continue
+		}
+		if err := s.ApplyView(ctx, view, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyView folds a result into one view; results missing the view's key or
+// value field are skipped
+func (s *ViewStore) ApplyView(ctx context.Context, view *models.View, result *models.MeasurementResult) error {
+	if !view.Matches(result) {
+		return nil
+	}
+
+	key := ""
+	if view.KeyField != "" {
+		value, ok := jsonpath.Lookup(result.Data, view.KeyField)
+		if !ok {
+			return nil
+		}
+		key = viewKey(value)
+	}
+
+	switch models.ViewKindEnum(view.Kind) {
+	case models.ViewKindLatest:
+		row := &models.ViewRow{
+			AgentID:   result.AgentID,
+			Key:       key,
+			ResultID:  result.ID,
+			Timestamp: result.Timestamp,
+			Data:      result.Data,
+		}
+		data, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		return s.redis.SetLatestViewRow(ctx, view.Name, result.AgentID, key, result.Timestamp.Unix(), data)
+
+	case models.ViewKindDaily:
+		value, ok := jsonpath.LookupFloat(result.Data, view.ValueField)
+		if !ok {
+			return nil
+		}
+		day := result.Timestamp.UTC().Format(models.ViewDayFormat)
+		return s.redis.AddDailyViewValue(ctx, view.Name, day, result.AgentID, key, value)
+	}
+
+	return nil
+}
+
+// QueryView retrieves the rows of a view, optionally filtered by agent and
+// key. Daily views return the rows of the given day.
+func (s *ViewStore) QueryView(ctx context.Context, name, agentID, key, day string) ([]*models.ViewRow, error) {
+	view, err := s.GetView(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*models.ViewRow
+	switch models.ViewKindEnum(view.Kind) {
+	case models.ViewKindLatest:
+		data, err := s.redis.GetLatestViewRows(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, raw := range data {
+			var row models.ViewRow
+			if err := json.Unmarshal(raw, &row); err != nil {
+				continue
+			}
+			rows = append(rows, &row)
+		}
+
+	case models.ViewKindDaily:
+		stats, err := s.redis.GetDailyViewRows(ctx, name, day)
+		if err != nil {
+			return nil, err
+		}
+		for id, st := range stats {
+			rows = append(rows, &models.ViewRow{
+				AgentID: id[0],
+				Key:     id[1],
+				Day:     day,
+				Count:   st.Count,
+				Sum:     st.Sum,
+				Min:     st.Min,
+				Max:     st.Max,
+			})
+		}
+	}
+
+	filtered := rows[:0]
+	for _, row := range rows {
+		if (agentID == "" || row.AgentID == agentID) && (key == "" || row.Key == key) {
+			filtered = append(filtered, row)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].AgentID != filtered[j].AgentID {
+			return filtered[i].AgentID < filtered[j].AgentID
+		}
+		return filtered[i].Key < filtered[j].Key
+	})
+
+	return filtered, nil
+}
+
+// viewKey renders a key field value as a row key
+func viewKey(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
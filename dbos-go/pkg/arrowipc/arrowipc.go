@@ -0,0 +1,334 @@
+// Package arrowipc writes Apache Arrow IPC streams of flat, nullable columns,
+// readable with e.g. pyarrow.ipc.open_stream, pandas or polars.read_ipc_stream.
+package arrowipc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// ContentType is the media type of Arrow IPC streams
+const ContentType = "application/vnd.apache.arrow.stream"
+
+// Type is the type of a column
+type Type int
+
+const (
+	Int64 Type = iota
+	Float64
+	String
+	// Timestamp holds milliseconds since the epoch, in UTC
+	Timestamp
+)
+
+// Field describes a column; every column is nullable
+type Field struct {
+	Name string
+	Type Type
+}
+
+// Flatbuffer enum and union values of the Arrow format (format/Schema.fbs, format/Message.fbs)
+const (
+	metadataV5 = 4
+
+	headerSchema      = 1
+	headerRecordBatch = 3
+
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeUtf8          = 5
+	typeTimestamp     = 10
+
+	precisionDouble = 2
+	unitMillisecond = 1
+)
+
+// continuation precedes every encapsulated message
+const continuation = 0xFFFFFFFF
+
+type column struct {
+	validity []byte
+	nulls    int
+	ints     []int64
+	floats   []float64
+	offsets  []int32
+	data     []byte
+}
+
+// Writer buffers rows and writes them to an Arrow IPC stream as record
+// batches. The schema is written before the first batch.
+type Writer struct {
+	w       io.Writer
+	fields  []Field
+	columns []*column
+	rows    int
+	started bool
+}
+
+// NewWriter creates a writer of a stream with the given columns
+func NewWriter(w io.Writer, fields []Field) *Writer {
+	writer := &Writer{
+		w:      w,
+		fields: fields,
+	}
+	writer.reset()
+	return writer
+}
+
+func (w *Writer) reset() {
+	w.columns = make([]*column, len(w.fields))
+	for i, field := range w.fields {
+		w.columns[i] = &column{}
+		if field.Type == String {
+			w.columns[i].offsets = []int32{0}
+		}
+	}
+	w.rows = 0
+}
+
+// Len returns the number of buffered rows
+func (w *Writer) Len() int {
+	return w.rows
+}
+
+// Append buffers a row with one value per column: int64, float64, string or
+// time.Time according to the column type, or nil for null
+func (w *Writer) Append(values ...interface{}) error {
+	if len(values) != len(w.fields) {
+		return fmt.Errorf("got %d values for %d columns", len(values), len(w.fields))
+	}
+	for i, value := range values {
+		if err := w.columns[i].append(w.fields[i], value, w.rows); err != nil {
+			return err
+		}
+	}
+	w.rows++
+	return nil
+}
+
+func (c *column) append(field Field, value interface{}, row int) error {
+	if row%8 == 0 {
+		c.validity = append(c.validity, 0)
+	}
+	valid := value != nil
+	if valid {
+		c.validity[row/8] |= 1 << (row % 8)
+	} else {
+		c.nulls++
+	}
+
+	switch field.Type {
+	case Int64:
+		v, ok := value.(int64)
+		if valid && !ok {
+			return fmt.Errorf("column %s: %T is not int64", field.Name, value)
+		}
+		c.ints = append(c.ints, v)
+	case Timestamp:
+		v, ok := value.(time.Time)
+		if valid && !ok {
+			return fmt.Errorf("column %s: %T is not time.Time", field.Name, value)
+		}
+		var ms int64
+		if valid {
+			ms = v.UnixMilli()
+		}
+		c.ints = append(c.ints, ms)
+	case Float64:
+		v, ok := value.(float64)
+		if valid && !ok {
+			return fmt.Errorf("column %s: %T is not float64", field.Name, value)
+		}
+		c.floats = append(c.floats, v)
+	case String:
+		v, ok := value.(string)
+		if valid && !ok {
+			return fmt.Errorf("column %s: %T is not string", field.Name, value)
+		}
+		c.data = append(c.data, v...)
+		c.offsets = append(c.offsets, int32(len(c.data)))
+	}
+	return nil
+}
+
+// Flush writes the buffered rows as a record batch
+func (w *Writer) Flush() error {
+	if err := w.writeSchema(); err != nil {
+		return err
+	}
+	if w.rows == 0 {
+		return nil
+	}
+
+	var body []byte
+	var nodes, buffers [][2]int64
+	addBuffer := func(buf []byte) {
+		buffers = append(buffers, [2]int64{int64(len(body)), int64(len(buf))})
+		body = append(body, buf...)
+		body = append(body, make([]byte, padding(len(body)))...)
+	}
+	for i, col := range w.columns {
+		nodes = append(nodes, [2]int64{int64(w.rows), int64(col.nulls)})
+		// A column without nulls may omit its validity bitmap
+		if col.nulls > 0 {
+			addBuffer(col.validity)
+		} else {
+			addBuffer(nil)
+		}
+
+		switch w.fields[i].Type {
+		case Int64, Timestamp:
+			buf := make([]byte, 0, 8*len(col.ints))
+			for _, v := range col.ints {
+				buf = binary.LittleEndian.AppendUint64(buf, uint64(v))
+			}
+			addBuffer(buf)
+		case Float64:
+			buf := make([]byte, 0, 8*len(col.floats))
+			for _, v := range col.floats {
+				buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+			}
+			addBuffer(buf)
+		case String:
+			buf := make([]byte, 0, 4*len(col.offsets))
+			for _, v := range col.offsets {
+				buf = binary.LittleEndian.AppendUint32(buf, uint32(v))
+			}
+			addBuffer(buf)
+			addBuffer(col.data)
+		}
+	}
+
+	b := flatbuffers.NewBuilder(1024)
+	nodesVector := structVector(b, nodes)
+	buffersVector := structVector(b, buffers)
+	b.StartObject(5)
+	b.PrependInt64Slot(0, int64(w.rows), 0)
+	b.PrependUOffsetTSlot(1, nodesVector, 0)
+	b.PrependUOffsetTSlot(2, buffersVector, 0)
+	batch := b.EndObject()
+
+	if err := w.writeMessage(b, headerRecordBatch, batch, body); err != nil {
+		return err
+	}
+	w.reset()
+	return nil
+}
+
+// Close flushes the buffered rows and ends the stream
+func (w *Writer) Close() error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	eos := make([]byte, 8)
+	binary.LittleEndian.PutUint32(eos, continuation)
+	_, err := w.w.Write(eos)
+	return err
+}
+
+func (w *Writer) writeSchema() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	b := flatbuffers.NewBuilder(1024)
+	fields := make([]flatbuffers.UOffsetT, len(w.fields))
+	for i, field := range w.fields {
+		name := b.CreateString(field.Name)
+
+		var typeType byte
+		var typeTable flatbuffers.UOffsetT
+		switch field.Type {
+		case Int64:
+			typeType = typeInt
+			b.StartObject(2)
+			b.PrependInt32Slot(0, 64, 0)
+			b.PrependBoolSlot(1, true, false)
+			typeTable = b.EndObject()
+		case Float64:
+			typeType = typeFloatingPoint
+			b.StartObject(1)
+			b.PrependInt16Slot(0, precisionDouble, 0)
+			typeTable = b.EndObject()
+		case String:
+			typeType = typeUtf8
+			b.StartObject(0)
+			typeTable = b.EndObject()
+		case Timestamp:
+			typeType = typeTimestamp
+			timezone := b.CreateString("UTC")
+			b.StartObject(2)
+			b.PrependInt16Slot(0, unitMillisecond, 0)
+			b.PrependUOffsetTSlot(1, timezone, 0)
+			typeTable = b.EndObject()
+		default:
+			return fmt.Errorf("column %s: unknown type %d", field.Name, field.Type)
+		}
+
+		// Readers require the children vector even for flat types
+		b.StartVector(4, 0, 4)
+		children := b.EndVector(0)
+
+		b.StartObject(7)
+		b.PrependUOffsetTSlot(0, name, 0)
+		b.PrependBoolSlot(1, true, false)
+		b.PrependByteSlot(2, typeType, 0)
+		b.PrependUOffsetTSlot(3, typeTable, 0)
+		b.PrependUOffsetTSlot(5, children, 0)
+		fields[i] = b.EndObject()
+	}
+	fieldsVector := b.CreateVectorOfTables(fields)
+
+	b.StartObject(4)
+	b.PrependUOffsetTSlot(1, fieldsVector, 0)
+	schema := b.EndObject()
+
+	return w.writeMessage(b, headerSchema, schema, nil)
+}
+
+// writeMessage finishes a Message flatbuffer around header and writes it
+// encapsulated, followed by body
+func (w *Writer) writeMessage(b *flatbuffers.Builder, headerType byte, header flatbuffers.UOffsetT, body []byte) error {
+	b.StartObject(5)
+	b.PrependInt16Slot(0, metadataV5, 0)
+	b.PrependByteSlot(1, headerType, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependInt64Slot(3, int64(len(body)), 0)
+	b.Finish(b.EndObject())
+	metadata := b.FinishedBytes()
+
+	// The metadata is padded so the body starts 8-byte aligned
+	size := len(metadata) + padding(8+len(metadata))
+	prefix := make([]byte, 8)
+	binary.LittleEndian.PutUint32(prefix, continuation)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(size))
+
+	for _, buf := range [][]byte{prefix, metadata, make([]byte, size-len(metadata)), body} {
+		if _, err := w.w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// structVector creates a vector of FieldNode or Buffer structs, both pairs of longs
+func structVector(b *flatbuffers.Builder, pairs [][2]int64) flatbuffers.UOffsetT {
+	b.StartVector(16, len(pairs), 8)
+	for i := len(pairs) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(pairs[i][1])
+		b.PrependInt64(pairs[i][0])
+	}
+	return b.EndVector(len(pairs))
+}
+
+// padding returns how many bytes align n to 8
+func padding(n int) int {
+	return (8 - n%8) % 8
+}
@@ -0,0 +1,138 @@
+// Package client is the Go SDK of the DBOS gRPC API. A Client holds one
+// connection serving both the v1 and v2 APIs, and wraps every unary call
+// with a default deadline, client-minted IDs for the tasks and results it
+// creates, and retries with exponential backoff of calls failing for a
+// transient reason. Because IDs are minted before the first attempt, a
+// retried call overwrites what an earlier attempt may have stored rather
+// than storing it twice.
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	apiv2 "github.com/internet-measurement-network/dbos/api/v2"
+	"github.com/internet-measurement-network/dbos/pkg/apitoken"
+	"github.com/internet-measurement-network/dbos/pkg/tlsconfig"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DefaultAddr is the address DBOS serves gRPC on by default
+const DefaultAddr = "localhost:50051"
+
+// Config configures a client
+type Config struct {
+	// Addr is the host:port of the DBOS server
+	Addr string
+	// Token is the API token sent with every call, if set
+	Token string
+	// TLS secures the connection; nil connects in plaintext
+	TLS *tls.Config
+
+	// Timeout is the deadline of calls made with a context without one
+	Timeout time.Duration
+	// MaxRetries is how many times a failed call is retried; negative
+	// disables retries
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry, doubled for each
+	// following one up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// DialOptions are passed on to grpc.NewClient after the client's own
+	DialOptions []grpc.DialOption
+}
+
+// DefaultConfig returns the configuration of a client of a local server
+func DefaultConfig() Config {
+	return Config{
+		Addr:           DefaultAddr,
+		Timeout:        10 * time.Second,
+		MaxRetries:     4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+	}
+}
+
+// ConfigFromEnv returns the configuration DBOS tools share: the server at
+// DBOS_ADDR, the token in DBOS_TOKEN and TLS from DBOS_TLS_CA, DBOS_TLS_CERT
+// and DBOS_TLS_KEY
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+	if addr := os.Getenv("DBOS_ADDR"); addr != "" {
+		cfg.Addr = addr
+	}
+	cfg.Token = os.Getenv("DBOS_TOKEN")
+	tlsConfig, err := tlsconfig.ClientFromEnv()
+	if err != nil {
+		return cfg, fmt.Errorf("invalid DBOS TLS configuration: %w", err)
+	}
+	cfg.TLS = tlsConfig
+	return cfg, nil
+}
+
+// Client is a connection to a DBOS server. Its embedded DBOSClient calls the
+// v1 API; V2 returns a client of the v2 API over the same connection.
+type Client struct {
+	api.DBOSClient
+
+	conn *grpc.ClientConn
+	v2   apiv2.DBOSClient
+}
+
+// New connects to the server of a configuration. Like grpc.NewClient it
+// does not wait for the connection: an unreachable server fails, and is
+// retried, at the first call.
+func New(cfg Config) (*Client, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLS != nil {
+		creds = credentials.NewTLS(cfg.TLS)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(newInterceptor(cfg).unary),
+	}
+	if cfg.Token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(apitoken.New(cfg.Token)))
+	}
+	opts = append(opts, cfg.DialOptions...)
+
+	conn, err := grpc.NewClient(cfg.Addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		DBOSClient: api.NewDBOSClient(conn),
+		conn:       conn,
+		v2:         apiv2.NewDBOSClient(conn),
+	}, nil
+}
+
+// FromEnv connects to the server configured by ConfigFromEnv
+func FromEnv() (*Client, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg)
+}
+
+// V2 returns the v2 API client
+func (c *Client) V2() apiv2.DBOSClient {
+	return c.v2
+}
+
+// Conn returns the underlying connection, for stubs of other services
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
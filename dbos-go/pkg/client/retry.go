@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/internet-measurement-network/dbos/api"
+	apiv2 "github.com/internet-measurement-network/dbos/api/v2"
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+	"github.com/internet-measurement-network/dbos/pkg/ids"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// localResultPrefix prefixes the IDs of results not answering a task, as
+// agents mint them
+const localResultPrefix = "local-"
+
+// interceptor applies a client's deadline, ID and retry policy to unary
+// calls
+type interceptor struct {
+	timeout        time.Duration
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func newInterceptor(cfg Config) *interceptor {
+	return &interceptor{
+		timeout:        cfg.Timeout,
+		maxRetries:     max(cfg.MaxRetries, 0),
+		initialBackoff: max(cfg.InitialBackoff, time.Millisecond),
+		maxBackoff:     max(cfg.MaxBackoff, cfg.InitialBackoff),
+	}
+}
+
+func (i *interceptor) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && i.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+	}
+
+	// Every attempt sends the same IDs
+	mintIDs(req)
+
+	backoff := i.initialBackoff
+	for attempt := 0; ; attempt++ {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if attempt == i.maxRetries || !retryable(err, reply) {
+			return err
+		}
+
+		// Full jitter keeps clients failing together from retrying together
+		wait := rand.N(backoff) + 1
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		backoff = min(backoff*2, i.maxBackoff)
+	}
+}
+
+// mintIDs fills the empty IDs of the tasks and results a request creates
+func mintIDs(req interface{}) {
+	switch req := req.(type) {
+	case *api.ScheduleTaskRequest:
+		if req.Task != nil && req.Task.Id == "" {
+			req.Task.Id = ids.New("task-")
+		}
+	case *api.StoreResultRequest:
+		if req.Result != nil && req.Result.Id == "" {
+			req.Result.Id = ids.New(localResultPrefix)
+		}
+	case *api.StoreResultsRequest:
+		for _, result := range req.Results {
+			if result != nil && result.Id == "" {
+				result.Id = ids.New(localResultPrefix)
+			}
+		}
+	case *apiv2.CreateTaskRequest:
+		if req.TaskId == "" {
+			req.TaskId = ids.New("task-")
+		}
+	case *apiv2.CreateResultRequest:
+		if req.ResultId == "" {
+			req.ResultId = ids.New(localResultPrefix)
+		}
+	}
+}
+
+// retryable tells whether a call failed for a reason that may pass: the
+// server or its storage was unreachable. v1 calls report errors in the
+// response, so its error_code is checked too.
+func retryable(err error, reply interface{}) bool {
+	if err != nil {
+		return status.Code(err) == codes.Unavailable
+	}
+	msg, ok := reply.(proto.Message)
+	if !ok {
+		return false
+	}
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName("error_code")
+	if field == nil || field.Kind() != protoreflect.StringKind {
+		return false
+	}
+	switch dberrors.Code(m.Get(field).String()) {
+	case dberrors.StorageUnavailable, dberrors.Unavailable:
+		return true
+	}
+	return false
+}
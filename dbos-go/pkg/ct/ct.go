@@ -0,0 +1,109 @@
+// Package ct checks whether certificates appear in Certificate Transparency logs.
+package ct
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// oidSCTList is the X.509 extension carrying embedded signed certificate timestamps
+var oidSCTList = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// Client looks certificates up in a CT log search service with the crt.sh
+// query interface
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient creates a new client for a search URL such as https://crt.sh/
+func NewClient(searchURL string) *Client {
+	return &Client{
+		url:        searchURL,
+		httpClient: &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+// Logged reports whether a certificate with the given SHA-256 fingerprint has
+// been seen in any CT log
+func (c *Client) Logged(ctx context.Context, fingerprint [sha256.Size]byte) (bool, error) {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return false, err
+	}
+	q := u.Query()
+	q.Set("sha256", hex.EncodeToString(fingerprint[:]))
+	q.Set("output", "json")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return false, fmt.Errorf("CT search returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	var entries []json.RawMessage
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&entries); err != nil {
+		return false, fmt.Errorf("decoding CT search response: %w", err)
+	}
+	return len(entries) > 0, nil
+}
+
+// HasEmbeddedSCTs reports whether a certificate carries signed certificate
+// timestamps, i.e. its precertificate was submitted to CT logs before issuance
+func HasEmbeddedSCTs(cert *x509.Certificate) bool {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidSCTList) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fingerprint returns the SHA-256 fingerprint of a certificate
+func Fingerprint(cert *x509.Certificate) [sha256.Size]byte {
+	return sha256.Sum256(cert.Raw)
+}
+
+// ParseCertificate parses a certificate given as PEM or base64-encoded DER
+func ParseCertificate(encoded string) (*x509.Certificate, error) {
+	encoded = strings.TrimSpace(encoded)
+	if block, _ := pem.Decode([]byte(encoded)); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+		}
+		return x509.ParseCertificate(block.Bytes)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("certificate is neither PEM nor base64 DER")
+	}
+	return x509.ParseCertificate(der)
+}
@@ -0,0 +1,253 @@
+// Package errors defines the error taxonomy of DBOS. Every error a store or
+// handler returns has a Code, a stable machine-readable string that clients
+// receive alongside the human-readable message, and that maps to one gRPC
+// code and one HTTP status wherever the error surfaces.
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the ErrorInfo domain of gRPC errors carrying a Code
+const Domain = "dbos"
+
+// Code classifies an error
+type Code string
+
+const (
+	// InvalidArgument rejects a malformed request
+	InvalidArgument Code = "invalid_argument"
+
+	// NotFound reports that a resource does not exist
+	NotFound Code = "not_found"
+
+	// AlreadyExists rejects creating a resource that exists
+	AlreadyExists Code = "already_exists"
+
+	// Conflict rejects a write made against a stale version of a resource
+	Conflict Code = "conflict"
+
+	// InvalidTransition rejects a change the resource's current status does
+	// not allow, such as resolving a resolved incident
+	InvalidTransition Code = "invalid_transition"
+
+	// FailedPrecondition rejects a request the server is not set up for or
+	// the caller does not hold, such as acknowledging a task it did not lease
+	FailedPrecondition Code = "failed_precondition"
+
+	// QuotaExceeded rejects a request that would exceed a limit
+	QuotaExceeded Code = "quota_exceeded"
+
+	// Unauthenticated rejects a request without valid credentials
+	Unauthenticated Code = "unauthenticated"
+
+	// PermissionDenied rejects a request the caller may not make
+	PermissionDenied Code = "permission_denied"
+
+	// StorageUnavailable reports that Redis could not be reached
+	StorageUnavailable Code = "storage_unavailable"
+
+	// Unavailable reports that another service, such as a relay's upstream,
+	// could not be reached
+	Unavailable Code = "unavailable"
+
+	// DeadlineExceeded reports that a request ran out of time
+	DeadlineExceeded Code = "deadline_exceeded"
+
+	// Canceled reports that a request was cancelled by its caller
+	Canceled Code = "canceled"
+
+	// Unimplemented rejects a request the server does not serve
+	Unimplemented Code = "unimplemented"
+
+	// Internal reports any other failure
+	Internal Code = "internal"
+)
+
+// grpcCodes maps each Code to its gRPC code
+var grpcCodes = map[Code]codes.Code{
+	InvalidArgument:    codes.InvalidArgument,
+	NotFound:           codes.NotFound,
+	AlreadyExists:      codes.AlreadyExists,
+	Conflict:           codes.Aborted,
+	InvalidTransition:  codes.FailedPrecondition,
+	FailedPrecondition: codes.FailedPrecondition,
+	QuotaExceeded:      codes.ResourceExhausted,
+	Unauthenticated:    codes.Unauthenticated,
+	PermissionDenied:   codes.PermissionDenied,
+	StorageUnavailable: codes.Unavailable,
+	Unavailable:        codes.Unavailable,
+	DeadlineExceeded:   codes.DeadlineExceeded,
+	Canceled:           codes.Canceled,
+	Unimplemented:      codes.Unimplemented,
+	Internal:           codes.Internal,
+}
+
+// httpStatuses maps each Code to its HTTP status
+var httpStatuses = map[Code]int{
+	InvalidArgument:    http.StatusBadRequest,
+	NotFound:           http.StatusNotFound,
+	AlreadyExists:      http.StatusConflict,
+	Conflict:           http.StatusConflict,
+	InvalidTransition:  http.StatusConflict,
+	FailedPrecondition: http.StatusPreconditionFailed,
+	QuotaExceeded:      http.StatusTooManyRequests,
+	Unauthenticated:    http.StatusUnauthorized,
+	PermissionDenied:   http.StatusForbidden,
+	StorageUnavailable: http.StatusServiceUnavailable,
+	Unavailable:        http.StatusServiceUnavailable,
+	DeadlineExceeded:   http.StatusGatewayTimeout,
+	Canceled:           499,
+	Unimplemented:      http.StatusNotImplemented,
+	Internal:           http.StatusInternalServerError,
+}
+
+// fromGRPC maps gRPC codes to Codes, for errors raised as gRPC statuses
+var fromGRPC = map[codes.Code]Code{
+	codes.InvalidArgument:    InvalidArgument,
+	codes.OutOfRange:         InvalidArgument,
+	codes.NotFound:           NotFound,
+	codes.AlreadyExists:      AlreadyExists,
+	codes.Aborted:            Conflict,
+	codes.FailedPrecondition: FailedPrecondition,
+	codes.ResourceExhausted:  QuotaExceeded,
+	codes.Unauthenticated:    Unauthenticated,
+	codes.PermissionDenied:   PermissionDenied,
+	codes.Unavailable:        Unavailable,
+	codes.DeadlineExceeded:   DeadlineExceeded,
+	codes.Canceled:           Canceled,
+	codes.Unimplemented:      Unimplemented,
+}
+
+// Error is an error classified by a Code
+type Error struct {
+	Code Code
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Extensions returns the code as the extensions of a GraphQL error
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": string(e.Code)}
+}
+
+// New returns an error with a code and a message formatted as fmt.Errorf
+// does, so it may wrap another error with %w
+func New(code Code, format string, args ...any) error {
+	return &Error{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// Wrap classifies err with a code, returning nil if err is nil
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// CodeOf returns the code of an error: the code it was classified with,
+// NotFound for a missing Redis key, StorageUnavailable for a failed
+// connection, the Code of a gRPC status, and Internal for anything else.
+// The code of a nil error is empty.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	if s, ok := status.FromError(err); ok {
+		return codeOfStatus(s)
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, redis.Nil):
+		return NotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	case errors.As(err, &netErr), errors.Is(err, redis.ErrClosed):
+		return StorageUnavailable
+	}
+	return Internal
+}
+
+// codeOfStatus returns the Code a gRPC status carries in its ErrorInfo, or
+// the one its gRPC code maps to
+func codeOfStatus(s *status.Status) Code {
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == Domain {
+			return Code(info.Reason)
+		}
+	}
+	if code, ok := fromGRPC[s.Code()]; ok {
+		return code
+	}
+	return Internal
+}
+
+// GRPCCode returns the gRPC code of a Code
+func GRPCCode(code Code) codes.Code {
+	if c, ok := grpcCodes[code]; ok {
+		return c
+	}
+	return codes.Internal
+}
+
+// HTTPStatus returns the HTTP status of a Code
+func HTTPStatus(code Code) int {
+	if s, ok := httpStatuses[code]; ok {
+		return s
+	}
+	return http.StatusInternalServerError
+}
+
+// Status converts an error into a gRPC status error with the gRPC code of
+// its Code, carrying the Code as the reason of an ErrorInfo detail. A gRPC
+// status error keeps its code and message.
+func Status(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	code := CodeOf(err)
+	s, ok := status.FromError(err)
+	if ok {
+		for _, detail := range s.Details() {
+			if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == Domain {
+				return err
+			}
+		}
+	} else {
+		s = status.New(GRPCCode(code), err.Error())
+	}
+
+	withInfo, detailErr := s.WithDetails(&errdetails.ErrorInfo{
+		Reason: string(code),
+		Domain: Domain,
+	})
+	if detailErr != nil {
+		return s.Err()
+	}
+	return withInfo.Err()
+}
@@ -0,0 +1,146 @@
+// Package ids mints sortable, collision-resistant IDs for DBOS resources and
+// validates IDs supplied by clients.
+//
+// Minted IDs are ULIDs: a 48-bit millisecond timestamp followed by 80 random
+// bits, in lowercase Crockford base32, so they sort by creation time. IDs
+// minted in the same millisecond by one process are strictly increasing.
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MaxLength is the longest ID a client may supply, in bytes
+const MaxLength = 128
+
+// MaxDerivedLength is the longest ID derived from client IDs, such as that
+// of a group task instance, which joins a task and an agent ID
+const MaxDerivedLength = 3 * MaxLength
+
+// encoding is the Crockford base32 alphabet, in lowercase
+const encoding = "0123456789abcdefghjkmnpqrstvwxyz"
+
+// generator keeps minted ULIDs increasing within a millisecond
+var generator struct {
+	sync.Mutex
+	ms      uint64
+	entropy [10]byte
+}
+
+// New mints an ID: prefix followed by a ULID
+func New(prefix string) string {
+	return prefix + ULID()
+}
+
+// ULID mints a 26-character ULID. Within a millisecond, or if the clock
+// goes back, the random part of the last ULID is incremented instead of
+// drawn anew.
+func ULID() string {
+	var id [16]byte
+	ms := uint64(time.Now().UnixMilli())
+
+	generator.Lock()
+	if ms <= generator.ms {
+		ms = generator.ms
+		if increment(generator.entropy[:]) {
+			// The random part overflowed; borrow the next millisecond
+			ms++
+			rand.Read(generator.entropy[:])
+		}
+	} else {
+		rand.Read(generator.entropy[:])
+	}
+	generator.ms = ms
+	copy(id[6:], generator.entropy[:])
+	generator.Unlock()
+
+	id[0], id[1], id[2] = byte(ms>>40), byte(ms>>32), byte(ms>>24)
+	id[3], id[4], id[5] = byte(ms>>16), byte(ms>>8), byte(ms)
+	return encodeULID(id)
+}
+
+// UUIDv7 mints an RFC 9562 version 7 UUID, for systems expecting UUIDs;
+// like ULIDs they sort by creation time
+func UUIDv7() string {
+	var id [16]byte
+	rand.Read(id[:])
+	ms := uint64(time.Now().UnixMilli())
+	binary.BigEndian.PutUint16(id[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+	id[6] = 0x70 | id[6]&0x0f
+	id[8] = 0x80 | id[8]&0x3f
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
+
+// Validate checks an ID supplied by a client: it must be 1 to MaxLength
+// bytes of ASCII letters, digits and "-_.:@[]". This keeps IDs safe to embed
+// in Redis keys, resource names and logs.
+func Validate(id string) error {
+	return validate(id, MaxLength)
+}
+
+// ValidateDerived checks an ID a client echoes back which the server may
+// have derived from client IDs, such as the task ID of a result: like
+// Validate, but up to MaxDerivedLength bytes long
+func ValidateDerived(id string) error {
+	return validate(id, MaxDerivedLength)
+}
+
+// validate checks an ID is 1 to max bytes of valid characters
+func validate(id string, max int) error {
+	if id == "" {
+		return fmt.Errorf("ID is empty")
+	}
+	if len(id) > max {
+		return fmt.Errorf("ID is %d bytes long, longer than %d", len(id), max)
+	}
+	for _, c := range []byte(id) {
+		if !validChar(c) {
+			return fmt.Errorf("ID %q contains invalid character %q", id, c)
+		}
+	}
+	return nil
+}
+
+// validChar reports whether c may appear in a client-supplied ID
+func validChar(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	switch c {
+	case '-', '_', '.', ':', '@', '[', ']':
+		return true
+	}
+	return false
+}
+
+// increment adds one to a big-endian number, reporting whether it overflowed
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeULID encodes 128 bits as 26 base32 characters, the first holding
+// only the top 3 bits
+func encodeULID(id [16]byte) string {
+	hi := binary.BigEndian.Uint64(id[0:8])
+	lo := binary.BigEndian.Uint64(id[8:16])
+
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = encoding[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
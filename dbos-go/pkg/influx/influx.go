@@ -0,0 +1,129 @@
+// Package influx writes points to InfluxDB using the line protocol.
+package influx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Point is one line-protocol point
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	Fields      map[string]float64
+	Time        time.Time
+}
+
+// Client writes points to an InfluxDB write endpoint
+type Client struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a new client for a write URL such as
+// http://influxdb:8086/api/v2/write?org=o&bucket=b (v2) or
+// http://influxdb:8086/write?db=d (v1). A non-empty token is sent as an
+// InfluxDB API token.
+func NewClient(url, token string) *Client {
+	return &Client{
+		url:        url,
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Write sends points in a single request with nanosecond precision
+func (c *Client) Write(ctx context.Context, points []Point) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(Encode(points)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Token "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("influx write returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// Encode renders points as line protocol, one point per line. Points
+// without representable fields are skipped.
+func Encode(points []Point) []byte {
+	var buf bytes.Buffer
+	for _, point := range points {
+		fields := make([]string, 0, len(point.Fields))
+		for key, value := range point.Fields {
+			// Line protocol has no representation for NaN or infinities
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			fields = append(fields, key)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		buf.WriteString(measurementEscaper.Replace(point.Measurement))
+		for _, key := range sortedKeys(point.Tags) {
+			if point.Tags[key] == "" {
+				continue
+			}
+			buf.WriteByte(',')
+			buf.WriteString(keyEscaper.Replace(key))
+			buf.WriteByte('=')
+			buf.WriteString(keyEscaper.Replace(point.Tags[key]))
+		}
+
+		buf.WriteByte(' ')
+		sort.Strings(fields)
+		for i, key := range fields {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(keyEscaper.Replace(key))
+			buf.WriteByte('=')
+			buf.WriteString(strconv.FormatFloat(point.Fields[key], 'g', -1, 64))
+		}
+
+		buf.WriteByte(' ')
+		buf.WriteString(strconv.FormatInt(point.Time.UnixNano(), 10))
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+var (
+	// measurementEscaper escapes measurement names
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "\n", `\n`)
+	// keyEscaper escapes tag keys, tag values and field keys
+	keyEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`)
+)
+
+// sortedKeys returns the keys of m in order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
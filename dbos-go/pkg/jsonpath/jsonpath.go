@@ -0,0 +1,138 @@
+// Package jsonpath looks up values in JSON documents by simple dotted paths
+// such as "rtt.avg" or "hops[2].ip".
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Lookup returns the value at path in a JSON document. Numbers are returned
+// as json.Number. A leading "$." is accepted and ignored.
+func Lookup(data []byte, path string) (interface{}, bool) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, false
+	}
+	return LookupValue(doc, path)
+}
+
+// LookupValue returns the value at path in an already decoded JSON document
+func LookupValue(doc interface{}, path string) (interface{}, bool) {
+	segments, err := parse(path)
+	if err != nil {
+		return nil, false
+	}
+
+	node := doc
+	for _, seg := range segments {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			if seg.index >= 0 {
+				return nil, false
+			}
+			child, ok := v[seg.key]
+			if !ok {
+				return nil, false
+			}
+			node = child
+		case []interface{}:
+			if seg.index < 0 || seg.index >= len(v) {
+				return nil, false
+			}
+			node = v[seg.index]
+		default:
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+// LookupFloat returns the numeric value at path
+func LookupFloat(data []byte, path string) (float64, bool) {
+	value, ok := Lookup(data, path)
+	if !ok {
+		return 0, false
+	}
+	return ToFloat(value)
+}
+
+// ToFloat converts a decoded JSON number (or numeric string) to a float64
+func ToFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// Validate reports whether path is a well-formed, non-empty path
+func Validate(path string) error {
+	segments, err := parse(path)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return fmt.Errorf("empty path")
+	}
+	return nil
+}
+
+// segment is one step of a path: a map key or an array index
+type segment struct {
+	key   string
+	index int
+}
+
+// parse splits a path into map-key and array-index segments
+func parse(path string) ([]segment, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return nil, nil
+	}
+
+	var segments []segment
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		var indexes []int
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			key = part[:open]
+			rest := part[open:]
+			for rest != "" {
+				if rest[0] != '[' {
+					return nil, fmt.Errorf("invalid path segment %q", part)
+				}
+				end := strings.IndexByte(rest, ']')
+				if end < 0 {
+					return nil, fmt.Errorf("invalid path segment %q", part)
+				}
+				n, err := strconv.Atoi(rest[1:end])
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("invalid index in %q", part)
+				}
+				indexes = append(indexes, n)
+				rest = rest[end+1:]
+			}
+		}
+		if key != "" {
+			segments = append(segments, segment{key: key, index: -1})
+		}
+		for _, n := range indexes {
+			segments = append(segments, segment{index: n})
+		}
+	}
+
+	return segments, nil
+}
@@ -0,0 +1,253 @@
+// Package jsonprofile profiles the fields of a set of JSON documents: the
+// paths that occur in them, in how many documents, with which types, and
+// the ranges of their values.
+package jsonprofile
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"sort"
+	"unicode/utf8"
+)
+
+// Types of JSON values
+const (
+	Null    = "null"
+	Boolean = "boolean"
+	Number  = "number"
+	String  = "string"
+	Object  = "object"
+	Array   = "array"
+)
+
+const (
+	// RootPath is the path of a document that is not an object
+	RootPath = "$"
+
+	// maxDepth bounds how deep documents are profiled; values nested deeper
+	// are only counted as the object or array holding them
+	maxDepth = 16
+
+	// maxDistinct is how many distinct strings are counted per field
+	maxDistinct = 1000
+
+	// maxExamples is how many example values are kept per field
+	maxExamples = 3
+)
+
+// Field is the profile of the values at one path. Paths are dotted, with
+// "[]" standing for every element of an array, e.g. "hops[].rtt".
+type Field struct {
+	Path string
+
+	// Documents is the number of documents the path occurs in, and Values
+	// the number of values at it, which is larger under arrays
+	Documents int64
+	Values    int64
+
+	// Types counts the values per type
+	Types map[string]int64
+
+	// Min, Max and Sum are over the numbers
+	Min, Max, Sum float64
+
+	// MinLength and MaxLength are over the strings, in characters
+	MinLength, MaxLength int
+
+	// Distinct counts the distinct strings up to maxDistinct; DistinctCapped
+	// is set once there were more
+	Distinct       int
+	DistinctCapped bool
+
+	// Examples are the first distinct non-null scalar values, JSON-encoded
+	Examples []string
+
+	distinct map[string]bool
+	lastDoc  int64
+}
+
+// Mean returns the mean of the numbers at the path
+func (f *Field) Mean() float64 {
+	if f.Types[Number] == 0 {
+		return 0
+	}
+	return f.Sum / float64(f.Types[Number])
+}
+
+// NullRate returns the fraction of the values at the path that are null
+func (f *Field) NullRate() float64 {
+	if f.Values == 0 {
+		return 0
+	}
+	return float64(f.Types[Null]) / float64(f.Values)
+}
+
+// Profiler accumulates the profile of documents
+type Profiler struct {
+	maxFields int
+	documents int64
+	invalid   int64
+	fields    map[string]*Field
+	truncated bool
+}
+
+// New creates a profiler keeping at most maxFields paths; paths first seen
+// after that are left out
+func New(maxFields int) *Profiler {
+	return &Profiler{
+		maxFields: maxFields,
+		fields:    make(map[string]*Field),
+	}
+}
+
+// Add profiles a document, reporting false and leaving it out if it is not
+// valid JSON
+func (p *Profiler) Add(data []byte) bool {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		p.invalid++
+		return false
+	}
+
+	p.documents++
+	if _, isObject := doc.(map[string]interface{}); isObject {
+		p.walk("", doc, 0)
+	} else {
+		p.record(RootPath, doc)
+		p.walk(RootPath, doc, 0)
+	}
+	return true
+}
+
+// walk records the values nested in a value at path
+func (p *Profiler) walk(path string, value interface{}, depth int) {
+	if depth >= maxDepth {
+		return
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			p.record(childPath, child)
+			p.walk(childPath, child, depth+1)
+		}
+	case []interface{}:
+		for _, element := range v {
+			p.record(path+"[]", element)
+			p.walk(path+"[]", element, depth+1)
+		}
+	}
+}
+
+// record adds a value at path to its field
+func (p *Profiler) record(path string, value interface{}) {
+	f, ok := p.fields[path]
+	if !ok {
+		if len(p.fields) >= p.maxFields {
+			p.truncated = true
+			return
+		}
+		f = &Field{
+			Path:     path,
+			Types:    make(map[string]int64),
+			distinct: make(map[string]bool),
+		}
+		p.fields[path] = f
+	}
+
+	if f.lastDoc != p.documents {
+		f.lastDoc = p.documents
+		f.Documents++
+	}
+	f.Values++
+
+	typ := typeOf(value)
+	f.Types[typ]++
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Float64()
+		if err != nil {
+			break
+		}
+		if f.Types[Number] == 1 || n < f.Min {
+			f.Min = n
+		}
+		if f.Types[Number] == 1 || n > f.Max {
+			f.Max = n
+		}
+		f.Sum += n
+	case string:
+		length := utf8.RuneCountInString(v)
+		if f.Types[String] == 1 || length < f.MinLength {
+			f.MinLength = length
+		}
+		if f.Types[String] == 1 || length > f.MaxLength {
+			f.MaxLength = length
+		}
+		if !f.distinct[v] {
+			if len(f.distinct) < maxDistinct {
+				f.distinct[v] = true
+				f.Distinct++
+			} else {
+				f.DistinctCapped = true
+			}
+		}
+	}
+
+	if typ != Null && typ != Object && typ != Array && len(f.Examples) < maxExamples {
+		example, err := json.Marshal(value)
+		if err == nil && !slices.Contains(f.Examples, string(example)) {
+			f.Examples = append(f.Examples, string(example))
+		}
+	}
+}
+
+// Documents returns the number of documents profiled
+func (p *Profiler) Documents() int64 {
+	return p.documents
+}
+
+// Invalid returns the number of documents left out as invalid JSON
+func (p *Profiler) Invalid() int64 {
+	return p.invalid
+}
+
+// Truncated reports whether paths were left out for exceeding maxFields
+func (p *Profiler) Truncated() bool {
+	return p.truncated
+}
+
+// Fields returns the profiled fields in path order
+func (p *Profiler) Fields() []*Field {
+	fields := make([]*Field, 0, len(p.fields))
+	for _, f := range p.fields {
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Path < fields[j].Path
+	})
+	return fields
+}
+
+// typeOf returns the type of a decoded JSON value
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return Null
+	case bool:
+		return Boolean
+	case json.Number:
+		return Number
+	case string:
+		return String
+	case map[string]interface{}:
+		return Object
+	}
+	return Array
+}
@@ -0,0 +1,431 @@
+// Package kafka produces messages to Apache Kafka topics over the Kafka
+// wire protocol. Messages are only reported sent once every in-sync replica
+// of their partition has them.
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// clientID identifies the producer to brokers
+	clientID = "dbos"
+
+	// requestTimeout bounds a request without a context deadline
+	requestTimeout = 30 * time.Second
+
+	// produceTimeout is how long a broker waits for replicas to acknowledge
+	produceTimeout = 10 * time.Second
+
+	// maxRequestBytes caps the keys and values of one produce request,
+	// below the default broker limit on a record batch
+	maxRequestBytes = 900 * 1024
+
+	// metadataRetries is how often topic metadata is re-fetched while a
+	// topic being created has no leader yet
+	metadataRetries = 5
+
+	// maxResponseBytes caps the size of a response read
+	maxResponseBytes = 64 << 20
+)
+
+// Message is a message to produce. Messages with a key go to the partition
+// the key hashes to; the others are spread over the partitions.
+type Message struct {
+	Topic string
+	Key   []byte
+	Value []byte
+	// Time stamps the message; zero stamps it with the time it is sent
+	Time time.Time
+}
+
+// Producer produces messages to a Kafka cluster. It is safe for concurrent
+// use; requests are sent one at a time.
+type Producer struct {
+	brokers  []string
+	user     string
+	password string
+
+	mu          sync.Mutex
+	conns       map[string]*conn
+	nodes       map[int32]string   // broker addresses by node ID
+	leaders     map[string][]int32 // partition leaders by topic, indexed by partition
+	correlation int32
+	next        int // round-robin counter of unkeyed messages
+}
+
+// NewProducer creates a producer bootstrapping from broker addresses such
+// as kafka:9092. With a user, connections authenticate with SASL/PLAIN.
+func NewProducer(brokers []string, user, password string) *Producer {
+	return &Producer{
+		brokers:  brokers,
+		user:     user,
+		password: password,
+		conns:    make(map[string]*conn),
+		nodes:    make(map[int32]string),
+		leaders:  make(map[string][]int32),
+	}
+}
+
+// Produce sends messages and waits for every in-sync replica to have them.
+// On an error some of the messages may have been written; the connections
+// and metadata are dropped so the next call starts afresh.
+func (p *Producer) Produce(ctx context.Context, messages []Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err := p.produce(ctx, messages)
+	if err != nil {
+		p.reset()
+	}
+	return err
+}
+
+// Close closes the producer's connections
+func (p *Producer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.reset()
+	return nil
+}
+
+// reset closes every connection and forgets the cluster's metadata
+func (p *Producer) reset() {
+	for addr, c := range p.conns {
+		c.Close()
+		delete(p.conns, addr)
+	}
+	clear(p.nodes)
+	clear(p.leaders)
+}
+
+// produce sends messages in requests of at most maxRequestBytes
+func (p *Producer) produce(ctx context.Context, messages []Message) error {
+	for len(messages) > 0 {
+		n, size := 0, 0
+		for n < len(messages) {
+			size += len(messages[n].Key) + len(messages[n].Value)
+			if n > 0 && size > maxRequestBytes {
+				break
+			}
+			n++
+		}
+		if err := p.produceChunk(ctx, messages[:n]); err != nil {
+			return err
+		}
+		messages = messages[n:]
+	}
+	return nil
+}
+
+// produceChunk sends messages with one produce request per partition leader
+func (p *Producer) produceChunk(ctx context.Context, messages []Message) error {
+	var missing []string
+	for _, m := range messages {
+		if _, ok := p.leaders[m.Topic]; !ok && !slices.Contains(missing, m.Topic) {
+			missing = append(missing, m.Topic)
+		}
+	}
+	if len(missing) > 0 {
+		if err := p.refreshMetadata(ctx, missing); err != nil {
+			return err
+		}
+	}
+
+	// Messages by leader, topic and partition
+	batches := make(map[int32]map[string]map[int32][]Message)
+	for _, m := range messages {
+		leaders := p.leaders[m.Topic]
+		var partition int32
+		if m.Key != nil {
+			partition = partitionFor(m.Key, len(leaders))
+		} else {
+			partition = int32(p.next % len(leaders))
+			p.next++
+		}
+		leader := leaders[partition]
+		if batches[leader] == nil {
+			batches[leader] = make(map[string]map[int32][]Message)
+		}
+		if batches[leader][m.Topic] == nil {
+			batches[leader][m.Topic] = make(map[int32][]Message)
+		}
+		m.Time = messageTime(m)
+		batches[leader][m.Topic][partition] = append(batches[leader][m.Topic][partition], m)
+	}
+
+	for leader, topics := range batches {
+		addr, ok := p.nodes[leader]
+		if !ok {
+			return fmt.Errorf("kafka: no address for broker %d", leader)
+		}
+		if err := p.sendProduce(ctx, addr, topics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendProduce sends a produce request to a broker and checks the outcome
+// of every partition
+func (p *Producer) sendProduce(ctx context.Context, addr string, topics map[string]map[int32][]Message) error {
+	req := &encoder{}
+	req.nullString("") // transactional ID
+	req.int16(-1)      // acks: all in-sync replicas
+	req.int32(int32(produceTimeout.Milliseconds()))
+	req.int32(int32(len(topics)))
+	for topic, partitions := range topics {
+		req.string(topic)
+		req.int32(int32(len(partitions)))
+		for partition, messages := range partitions {
+			req.int32(partition)
+			req.bytes(encodeRecordBatch(messages))
+		}
+	}
+
+	resp, err := p.request(ctx, addr, apiProduce, produceVersion, req.b)
+	if err != nil {
+		return err
+	}
+	d := &decoder{b: resp}
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		topic := d.string()
+		for j, m := 0, d.arrayLen(); j < m; j++ {
+			partition := d.int32()
+			code := Error(d.int16())
+			d.int64() // base offset
+			d.int64() // log append time
+			if code != 0 && d.err == nil {
+				return fmt.Errorf("producing to %s/%d: %w", topic, partition, code)
+			}
+		}
+	}
+	return d.err
+}
+
+// refreshMetadata fetches the partition leaders of topics, creating topics
+// that do not exist if the cluster allows it. A topic just created has no
+// leaders for a moment, so metadata is fetched again until it does.
+func (p *Producer) refreshMetadata(ctx context.Context, topics []string) error {
+	var lastErr error
+	for attempt := 0; attempt < metadataRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * 200 * time.Millisecond):
+			}
+		}
+		lastErr = p.fetchMetadata(ctx, topics)
+		if lastErr == nil {
+			return nil
+		}
+		if code, ok := lastErr.(Error); !ok || (code != 3 && code != 5) {
+			return lastErr
+		}
+	}
+	return lastErr
+}
+
+// fetchMetadata fetches the metadata of topics from any bootstrap broker
+func (p *Producer) fetchMetadata(ctx context.Context, topics []string) error {
+	req := &encoder{}
+	req.int32(int32(len(topics)))
+	for _, topic := range topics {
+		req.string(topic)
+	}
+	req.bool(true) // allow auto topic creation
+
+	var resp []byte
+	var err error
+	for _, addr := range p.brokers {
+		resp, err = p.request(ctx, addr, apiMetadata, metadataVersion, req.b)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	d := &decoder{b: resp}
+	d.int32() // throttle time
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		node := d.int32()
+		host := d.string()
+		port := d.int32()
+		d.string() // rack
+		p.nodes[node] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	d.string() // cluster ID
+	d.int32()  // controller ID
+	for i, n := 0, d.arrayLen(); i < n; i++ {
+		code := Error(d.int16())
+		topic := d.string()
+		d.bool() // internal
+		var leaders []int32
+		for j, m := 0, d.arrayLen(); j < m; j++ {
+			d.int16() // partition error
+			partition := d.int32()
+			leader := d.int32()
+			for k, r := 0, d.arrayLen(); k < r; k++ {
+				d.int32() // replica
+			}
+			for k, r := 0, d.arrayLen(); k < r; k++ {
+				d.int32() // in-sync replica
+			}
+			if d.err != nil || partition < 0 || int(partition) >= m {
+				continue
+			}
+			if leaders == nil {
+				leaders = make([]int32, m)
+				for k := range leaders {
+					leaders[k] = -1
+				}
+			}
+			leaders[partition] = leader
+		}
+		if d.err != nil {
+			return d.err
+		}
+		if code != 0 {
+			return code
+		}
+		if len(leaders) == 0 || slices.Contains(leaders, -1) {
+			return Error(5)
+		}
+		p.leaders[topic] = leaders
+	}
+	if d.err != nil {
+		return d.err
+	}
+	for _, topic := range topics {
+		if _, ok := p.leaders[topic]; !ok {
+			return fmt.Errorf("kafka: no metadata for topic %s", topic)
+		}
+	}
+	return nil
+}
+
+// request sends a request to a broker, connecting if needed, and returns
+// the response body
+func (p *Producer) request(ctx context.Context, addr string, apiKey, version int16, body []byte) ([]byte, error) {
+	c, err := p.conn(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	p.correlation++
+	resp, err := c.roundTrip(ctx, apiKey, version, p.correlation, body)
+	if err != nil {
+		c.Close()
+		delete(p.conns, addr)
+		return nil, fmt.Errorf("kafka %s: %w", addr, err)
+	}
+	return resp, nil
+}
+
+// conn returns the connection to a broker, dialing and authenticating a
+// new one if there is none
+func (p *Producer) conn(ctx context.Context, addr string) (*conn, error) {
+	if c, ok := p.conns[addr]; ok {
+		return c, nil
+	}
+
+	var dialer net.Dialer
+	nc, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("kafka %s: %w", addr, err)
+	}
+	c := &conn{Conn: nc, r: bufio.NewReader(nc)}
+	if p.user != "" {
+		if err := p.authenticate(ctx, c); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("kafka %s: %w", addr, err)
+		}
+	}
+	p.conns[addr] = c
+	return c, nil
+}
+
+// authenticate authenticates a new connection with SASL/PLAIN
+func (p *Producer) authenticate(ctx context.Context, c *conn) error {
+	req := &encoder{}
+	req.string("PLAIN")
+	p.correlation++
+	resp, err := c.roundTrip(ctx, apiSaslHandshake, saslHandshakeVersion, p.correlation, req.b)
+	if err != nil {
+		return err
+	}
+	d := &decoder{b: resp}
+	if code := Error(d.int16()); code != 0 {
+		return code
+	}
+
+	req = &encoder{}
+	req.bytes([]byte("\x00" + p.user + "\x00" + p.password))
+	p.correlation++
+	resp, err = c.roundTrip(ctx, apiSaslAuthenticate, saslAuthenticateVersion, p.correlation, req.b)
+	if err != nil {
+		return err
+	}
+	d = &decoder{b: resp}
+	code := Error(d.int16())
+	message := d.string()
+	if code != 0 {
+		return fmt.Errorf("%w: %s", code, message)
+	}
+	return d.err
+}
+
+// conn is a connection to a broker
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// roundTrip sends a request and reads its response, returning the response
+// body after its header
+func (c *conn) roundTrip(ctx context.Context, apiKey, version int16, correlation int32, body []byte) ([]byte, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(requestTimeout)
+	}
+	c.SetDeadline(deadline)
+
+	header := &encoder{}
+	header.int32(0) // size, set below
+	header.int16(apiKey)
+	header.int16(version)
+	header.int32(correlation)
+	header.string(clientID)
+	binary.BigEndian.PutUint32(header.b, uint32(len(header.b)-4+len(body)))
+	if _, err := c.Write(append(header.b, body...)); err != nil {
+		return nil, err
+	}
+
+	var size [4]byte
+	if _, err := io.ReadFull(c.r, size[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n < 4 || n > maxResponseBytes {
+		return nil, fmt.Errorf("response of %d bytes", n)
+	}
+	resp := make([]byte, n)
+	if _, err := io.ReadFull(c.r, resp); err != nil {
+		return nil, err
+	}
+	if got := int32(binary.BigEndian.Uint32(resp)); got != correlation {
+		return nil, fmt.Errorf("response to request %d, expected %d", got, correlation)
+	}
+	return resp[4:], nil
+}
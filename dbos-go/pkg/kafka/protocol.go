@@ -0,0 +1,288 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"time"
+)
+
+// API keys and the versions of them the producer speaks. Produce v3 is the
+// oldest version current brokers accept and the first taking record batches.
+const (
+	apiProduce          int16 = 0
+	apiMetadata         int16 = 3
+	apiSaslHandshake    int16 = 17
+	apiSaslAuthenticate int16 = 36
+
+	produceVersion          int16 = 3
+	metadataVersion         int16 = 4
+	saslHandshakeVersion    int16 = 1
+	saslAuthenticateVersion int16 = 0
+)
+
+// crc32c is the checksum of record batches
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// errShortResponse reports a response that ended before all its fields
+var errShortResponse = errors.New("kafka: response is truncated")
+
+// Error is an error code returned by a broker
+type Error int16
+
+// errorNames name the error codes a producer is likely to see
+var errorNames = map[Error]string{
+	1:  "OFFSET_OUT_OF_RANGE",
+	2:  "CORRUPT_MESSAGE",
+	3:  "UNKNOWN_TOPIC_OR_PARTITION",
+	5:  "LEADER_NOT_AVAILABLE",
+	6:  "NOT_LEADER_OR_FOLLOWER",
+	7:  "REQUEST_TIMED_OUT",
+	10: "MESSAGE_TOO_LARGE",
+	17: "INVALID_TOPIC_EXCEPTION",
+	18: "RECORD_LIST_TOO_LARGE",
+	19: "NOT_ENOUGH_REPLICAS",
+	20: "NOT_ENOUGH_REPLICAS_AFTER_APPEND",
+	29: "TOPIC_AUTHORIZATION_FAILED",
+	31: "CLUSTER_AUTHORIZATION_FAILED",
+	33: "UNSUPPORTED_SASL_MECHANISM",
+	34: "ILLEGAL_SASL_STATE",
+	35: "UNSUPPORTED_VERSION",
+	58: "SASL_AUTHENTICATION_FAILED",
+}
+
+func (e Error) Error() string {
+	if name, ok := errorNames[e]; ok {
+		return fmt.Sprintf("kafka: %s (%d)", name, int16(e))
+	}
+	return fmt.Sprintf("kafka: error code %d", int16(e))
+}
+
+// encoder appends Kafka protocol primitives to a buffer
+type encoder struct {
+	b []byte
+}
+
+func (e *encoder) int8(v int8) { e.b = append(e.b, byte(v)) }
+
+func (e *encoder) int16(v int16) { e.b = binary.BigEndian.AppendUint16(e.b, uint16(v)) }
+
+func (e *encoder) int32(v int32) { e.b = binary.BigEndian.AppendUint32(e.b, uint32(v)) }
+
+func (e *encoder) int64(v int64) { e.b = binary.BigEndian.AppendUint64(e.b, uint64(v)) }
+
+func (e *encoder) bool(v bool) {
+	if v {
+		e.int8(1)
+	} else {
+		e.int8(0)
+	}
+}
+
+func (e *encoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.b = append(e.b, s...)
+}
+
+// nullString encodes a nullable string, null if empty
+func (e *encoder) nullString(s string) {
+	if s == "" {
+		e.int16(-1)
+		return
+	}
+	e.string(s)
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.int32(int32(len(b)))
+	e.b = append(e.b, b...)
+}
+
+// varint encodes a zigzag variable-length integer, as record fields are
+func (e *encoder) varint(v int64) {
+	e.b = binary.AppendVarint(e.b, v)
+}
+
+// varbytes encodes bytes prefixed with their varint length, -1 for nil
+func (e *encoder) varbytes(b []byte) {
+	if b == nil {
+		e.varint(-1)
+		return
+	}
+	e.varint(int64(len(b)))
+	e.b = append(e.b, b...)
+}
+
+// decoder reads Kafka protocol primitives from a response, remembering the
+// first read past its end
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.b) {
+		d.err = errShortResponse
+		return nil
+	}
+	v := d.b[:n]
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) int8() int8 {
+	if b := d.take(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (d *decoder) int16() int16 {
+	if b := d.take(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *decoder) int32() int32 {
+	if b := d.take(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (d *decoder) int64() int64 {
+	if b := d.take(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (d *decoder) bool() bool { return d.int8() != 0 }
+
+// string decodes a string, nullable or not; null reads as empty
+func (d *decoder) string() string {
+	n := d.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(d.take(int(n)))
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.int32()
+	if n < 0 {
+		return nil
+	}
+	return d.take(int(n))
+}
+
+// arrayLen decodes the length of an array, 0 for a null one
+func (d *decoder) arrayLen() int {
+	n := d.int32()
+	if n < 0 || int(n) > len(d.b) {
+		if n > 0 {
+			d.err = errShortResponse
+		}
+		return 0
+	}
+	return int(n)
+}
+
+// encodeRecordBatch encodes messages as an uncompressed record batch, the
+// v2 message format
+func encodeRecordBatch(messages []Message) []byte {
+	first, last := messages[0].Time, messages[0].Time
+	for _, m := range messages[1:] {
+		if m.Time.Before(first) {
+			first = m.Time
+		}
+		if m.Time.After(last) {
+			last = m.Time
+		}
+	}
+
+	// The part of the batch covered by its checksum
+	body := &encoder{}
+	body.int16(0) // attributes: no compression, create time
+	body.int32(int32(len(messages) - 1))
+	body.int64(first.UnixMilli())
+	body.int64(last.UnixMilli())
+	body.int64(-1) // producer ID: not idempotent
+	body.int16(-1) // producer epoch
+	body.int32(-1) // base sequence
+	body.int32(int32(len(messages)))
+	for i, m := range messages {
+		record := &encoder{}
+		record.int8(0) // attributes
+		record.varint(m.Time.UnixMilli() - first.UnixMilli())
+		record.varint(int64(i))
+		record.varbytes(m.Key)
+		record.varbytes(m.Value)
+		record.varint(0) // headers
+		body.varint(int64(len(record.b)))
+		body.b = append(body.b, record.b...)
+	}
+
+	batch := &encoder{}
+	batch.int64(0) // base offset, assigned by the broker
+	batch.int32(int32(4 + 1 + 4 + len(body.b)))
+	batch.int32(-1) // partition leader epoch
+	batch.int8(2)   // magic
+	batch.int32(int32(crc32.Checksum(body.b, crc32c)))
+	batch.b = append(batch.b, body.b...)
+	return batch.b
+}
+
+// murmur2 is the hash the Java client partitions keyed messages by, so
+// messages with a key land in the same partition whichever client sent them
+func murmur2(data []byte) uint32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+	length := len(data)
+	h := seed ^ uint32(length)
+	for i := 0; i+4 <= length; i += 4 {
+		k := binary.LittleEndian.Uint32(data[i:])
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+	tail := data[length&^3:]
+	switch len(tail) {
+	case 3:
+		h ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(tail[0])
+		h *= m
+	}
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return h
+}
+
+// partitionFor returns the partition of a keyed message among n
+func partitionFor(key []byte, n int) int32 {
+	return int32((murmur2(key) & 0x7fffffff) % uint32(n))
+}
+
+// messageTime returns the time a message is stamped with
+func messageTime(m Message) time.Time {
+	if m.Time.IsZero() {
+		return time.Now()
+	}
+	return m.Time
+}
@@ -0,0 +1,180 @@
+// Package manifest signs and verifies manifests of the agent and module
+// software DBOS distributes. The server signs the manifest with an Ed25519
+// key; agents verify it with the public key they were provisioned with and
+// check each download against the SHA-256 it lists before running it.
+package manifest
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrBadSignature is returned for a manifest whose signature does not
+	// verify with the key
+	ErrBadSignature = errors.New("manifest signature does not verify")
+
+	// ErrDigestMismatch is returned for a download whose SHA-256 differs
+	// from its manifest entry
+	ErrDigestMismatch = errors.New("artifact digest does not match the manifest")
+
+	// ErrStale is returned for a manifest issued too long ago, or before
+	// one already accepted, which may list artifacts since withdrawn
+	ErrStale = errors.New("manifest is stale")
+)
+
+// DefaultMaxAge is how long after it was issued a manifest is accepted by
+// default. The server signs a fresh manifest for every request, so this
+// only needs to cover clock skew between server and agent.
+const DefaultMaxAge = time.Hour
+
+// Artifact is an agent or module binary listed in a manifest
+type Artifact struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Kind is "agent" or "module"
+	Kind string `json:"kind"`
+	// URL is where the binary is downloaded from
+	URL string `json:"url"`
+	// SHA256 is the hex-encoded digest of the binary
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Manifest lists the artifacts an agent may download
+type Manifest struct {
+	Artifacts []Artifact `json:"artifacts"`
+	IssuedAt  time.Time  `json:"issued_at"`
+}
+
+// Signed is an encoded manifest with its signature. The signature covers
+// the encoded bytes exactly, so they are verified before being decoded.
+type Signed struct {
+	Manifest  []byte
+	Signature []byte
+	// KeyID identifies the signing key, see KeyID
+	KeyID string
+}
+
+// Sign encodes and signs a manifest
+func Sign(key ed25519.PrivateKey, m *Manifest) (*Signed, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return &Signed{
+		Manifest:  data,
+		Signature: ed25519.Sign(key, data),
+		KeyID:     KeyID(key.Public().(ed25519.PublicKey)),
+	}, nil
+}
+
+// Verify checks a signed manifest with a public key and decodes it,
+// rejecting it as stale if it was issued more than maxAge from now, so that
+// an old manifest listing vulnerable artifacts cannot be replayed, or
+// before notBefore, the issue time of the last manifest accepted
+func Verify(key ed25519.PublicKey, s *Signed, now time.Time, maxAge time.Duration, notBefore time.Time) (*Manifest, error) {
+	if s.KeyID != "" && s.KeyID != KeyID(key) {
+		return nil, fmt.Errorf("%w: signed with key %s, expected %s", ErrBadSignature, s.KeyID, KeyID(key))
+	}
+	if !ed25519.Verify(key, s.Manifest, s.Signature) {
+		return nil, ErrBadSignature
+	}
+	var m Manifest
+	if err := json.Unmarshal(s.Manifest, &m); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %w", err)
+	}
+	if age := now.Sub(m.IssuedAt); m.IssuedAt.IsZero() || age > maxAge || age < -maxAge {
+		return nil, fmt.Errorf("%w: issued at %s", ErrStale, m.IssuedAt.Format(time.RFC3339))
+	}
+	if m.IssuedAt.Before(notBefore) {
+		return nil, fmt.Errorf("%w: issued at %s, before the manifest accepted last, issued at %s", ErrStale, m.IssuedAt.Format(time.RFC3339), notBefore.Format(time.RFC3339))
+	}
+	return &m, nil
+}
+
+// Find returns the artifact of a name and version, or with an empty
+// version the last one listed under the name
+func (m *Manifest) Find(name, version string) (*Artifact, bool) {
+	var found *Artifact
+	for i := range m.Artifacts {
+		artifact := &m.Artifacts[i]
+		if artifact.Name != name {
+			continue
+		}
+		if artifact.Version == version {
+			return artifact, true
+		}
+		if version == "" {
+			found = artifact
+		}
+	}
+	return found, found != nil
+}
+
+// VerifyArtifact checks downloaded data against an artifact's digest
+func VerifyArtifact(a *Artifact, data []byte) error {
+	sum := sha256.Sum256(data)
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), a.SHA256) {
+		return fmt.Errorf("%w: %s %s", ErrDigestMismatch, a.Name, a.Version)
+	}
+	return nil
+}
+
+// KeyID is the first 8 bytes of the SHA-256 of a public key, hex-encoded
+func KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// ParsePrivateKey parses a PEM-encoded PKCS #8 Ed25519 private key, as
+// written by "openssl genpkey -algorithm ed25519"
+func ParsePrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%T is not an Ed25519 key", key)
+	}
+	return edKey, nil
+}
+
+// ParsePublicKey parses a PEM-encoded PKIX Ed25519 public key, as written
+// by "openssl pkey -pubout", or a base64-encoded raw one
+func ParsePublicKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, errors.New("neither a PEM block nor base64")
+		}
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("raw public key is %d bytes, expected %d", len(raw), ed25519.PublicKeySize)
+		}
+		return ed25519.PublicKey(raw), nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%T is not an Ed25519 key", key)
+	}
+	return edKey, nil
+}
@@ -0,0 +1,318 @@
+// Package nats publishes messages to NATS subjects over the NATS client
+// protocol, optionally waiting for JetStream to acknowledge storing each.
+package nats
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// requestTimeout bounds a publish without a context deadline
+	requestTimeout = 30 * time.Second
+
+	// maxControlLine caps the length of a protocol line read
+	maxControlLine = 64 * 1024
+
+	// maxPayload caps the size of a message payload read
+	maxPayload = 8 << 20
+)
+
+// Message is a message to publish on a subject
+type Message struct {
+	Subject string
+	Data    []byte
+}
+
+// Options configure a publisher
+type Options struct {
+	// User and Password, or Token, authenticate the connection
+	User     string
+	Password string
+	Token    string
+	// JetStream waits for the stream bound to each subject to acknowledge
+	// storing the message, instead of only for the server to receive it
+	JetStream bool
+}
+
+// Publisher publishes messages to a NATS server. It is safe for concurrent
+// use; publishes are sent one at a time.
+type Publisher struct {
+	servers []string
+	opts    Options
+
+	mu    sync.Mutex
+	conn  net.Conn
+	r     *bufio.Reader
+	inbox string // prefix of the subjects JetStream acknowledgements come to
+}
+
+// NewPublisher creates a publisher connecting to the first reachable server
+// address such as nats:4222 or nats://nats:4222
+func NewPublisher(servers []string, opts Options) *Publisher {
+	return &Publisher{
+		servers: servers,
+		opts:    opts,
+	}
+}
+
+// Publish sends messages and waits until the server received them all or,
+// with JetStream, until their streams stored them. On an error some of the
+// messages may have been published; the connection is dropped so the next
+// call reconnects.
+func (p *Publisher) Publish(ctx context.Context, messages []Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err := p.publish(ctx, messages)
+	if err != nil && p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+	}
+	return err
+}
+
+// Close closes the publisher's connection
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+// publish sends messages over the connection, connecting if needed
+func (p *Publisher) publish(ctx context.Context, messages []Message) error {
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(requestTimeout)
+	}
+	p.conn.SetDeadline(deadline)
+
+	var buf []byte
+	for i, m := range messages {
+		if p.opts.JetStream {
+			buf = fmt.Appendf(buf, "PUB %s %s%d %d\r\n", m.Subject, p.inbox, i, len(m.Data))
+		} else {
+			buf = fmt.Appendf(buf, "PUB %s %d\r\n", m.Subject, len(m.Data))
+		}
+		buf = append(buf, m.Data...)
+		buf = append(buf, "\r\n"...)
+	}
+	if !p.opts.JetStream {
+		// The server handles a connection's messages in order, so its PONG
+		// follows the handling of every message before the PING
+		buf = append(buf, "PING\r\n"...)
+	}
+	if _, err := p.conn.Write(buf); err != nil {
+		return err
+	}
+
+	if !p.opts.JetStream {
+		_, _, err := p.read(false)
+		return err
+	}
+	acked := make([]bool, len(messages))
+	for pending := len(messages); pending > 0; {
+		subject, data, err := p.read(true)
+		if err != nil {
+			return err
+		}
+		i, err := strconv.Atoi(strings.TrimPrefix(subject, p.inbox))
+		if err != nil || i < 0 || i >= len(messages) || acked[i] {
+			continue
+		}
+		if err := checkPubAck(data); err != nil {
+			return fmt.Errorf("publishing to %s: %w", messages[i].Subject, err)
+		}
+		acked[i] = true
+		pending--
+	}
+	return nil
+}
+
+// checkPubAck checks a JetStream publish acknowledgement
+func checkPubAck(data []byte) error {
+	var ack struct {
+		Stream string `json:"stream"`
+		Error  *struct {
+			Code        int    `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &ack); err != nil {
+		return fmt.Errorf("nats: malformed JetStream acknowledgement: %w", err)
+	}
+	if ack.Error != nil {
+		return fmt.Errorf("nats: JetStream error %d: %s", ack.Error.Code, ack.Error.Description)
+	}
+	if ack.Stream == "" {
+		return errors.New("nats: JetStream acknowledgement names no stream")
+	}
+	return nil
+}
+
+// connect dials the first reachable server and sends CONNECT, subscribing
+// to the inbox of JetStream acknowledgements if they are awaited
+func (p *Publisher) connect(ctx context.Context) error {
+	var dialer net.Dialer
+	var lastErr error
+	for _, server := range p.servers {
+		addr := strings.TrimPrefix(server, "nats://")
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			addr = net.JoinHostPort(addr, "4222")
+		}
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		p.conn, p.r = conn, bufio.NewReaderSize(conn, 32*1024)
+		if err := p.handshake(ctx); err != nil {
+			conn.Close()
+			p.conn = nil
+			lastErr = fmt.Errorf("nats %s: %w", addr, err)
+			continue
+		}
+		return nil
+	}
+	if lastErr == nil {
+		lastErr = errors.New("nats: no servers")
+	}
+	return lastErr
+}
+
+// handshake reads the server's INFO, sends CONNECT and waits for the PONG
+// confirming the connection was accepted
+func (p *Publisher) handshake(ctx context.Context) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(requestTimeout)
+	}
+	p.conn.SetDeadline(deadline)
+
+	line, err := p.readLine()
+	if err != nil {
+		return err
+	}
+	op, args, _ := strings.Cut(line, " ")
+	if op != "INFO" {
+		return fmt.Errorf("expected INFO, got %q", line)
+	}
+	var info struct {
+		TLSRequired bool `json:"tls_required"`
+	}
+	if err := json.Unmarshal([]byte(args), &info); err != nil {
+		return fmt.Errorf("malformed INFO: %w", err)
+	}
+	if info.TLSRequired {
+		return errors.New("server requires TLS, which is not supported")
+	}
+
+	connect, err := json.Marshal(map[string]interface{}{
+		"verbose":    false,
+		"pedantic":   false,
+		"name":       "dbos",
+		"lang":       "go",
+		"version":    "1.0",
+		"protocol":   1,
+		"user":       p.opts.User,
+		"pass":       p.opts.Password,
+		"auth_token": p.opts.Token,
+	})
+	if err != nil {
+		return err
+	}
+	buf := fmt.Appendf(nil, "CONNECT %s\r\n", connect)
+	if p.opts.JetStream {
+		var id [8]byte
+		rand.Read(id[:])
+		p.inbox = "_INBOX." + hex.EncodeToString(id[:]) + "."
+		buf = fmt.Appendf(buf, "SUB %s* 1\r\n", p.inbox)
+	}
+	buf = append(buf, "PING\r\n"...)
+	if _, err := p.conn.Write(buf); err != nil {
+		return err
+	}
+	_, _, err = p.read(false)
+	return err
+}
+
+// read reads protocol messages until a PONG or, if msg is set, a MSG, whose
+// subject and payload it returns. It answers the server's PINGs and fails
+// on -ERR.
+func (p *Publisher) read(msg bool) (string, []byte, error) {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return "", nil, err
+		}
+		op, args, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(op) {
+		case "PONG":
+			if !msg {
+				return "", nil, nil
+			}
+		case "PING":
+			if _, err := io.WriteString(p.conn, "PONG\r\n"); err != nil {
+				return "", nil, err
+			}
+		case "-ERR":
+			return "", nil, fmt.Errorf("nats: %s", strings.Trim(args, "'"))
+		case "MSG":
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(args)
+			if len(fields) < 3 {
+				return "", nil, fmt.Errorf("nats: malformed MSG %q", line)
+			}
+			n, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil || n < 0 || n > maxPayload {
+				return "", nil, fmt.Errorf("nats: malformed MSG %q", line)
+			}
+			data := make([]byte, n+2)
+			if _, err := io.ReadFull(p.r, data); err != nil {
+				return "", nil, err
+			}
+			if msg {
+				return fields[0], data[:n], nil
+			}
+		}
+	}
+}
+
+// readLine reads a protocol line without its CRLF
+func (p *Publisher) readLine() (string, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := p.r.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		line = append(line, chunk...)
+		if len(line) > maxControlLine {
+			return "", errors.New("nats: protocol line too long")
+		}
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
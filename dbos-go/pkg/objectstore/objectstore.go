@@ -0,0 +1,193 @@
+// Package objectstore stores objects in an HTTP object store, such as an
+// S3-compatible bucket (AWS S3, MinIO, Ceph, ...) accepting PUT and GET
+// requests.
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	dberrors "github.com/internet-measurement-network/dbos/pkg/errors"
+)
+
+// maxObjectSize caps the size of an object Get reads
+const maxObjectSize = 256 << 20
+
+// Credentials sign requests to an S3-compatible store with AWS Signature
+// Version 4
+type Credentials struct {
+	AccessKey string
+	SecretKey string
+	// Region is the region of the bucket, us-east-1 if empty; MinIO
+	// accepts us-east-1 unless configured otherwise
+	Region string
+}
+
+// Client stores objects under a base URL
+type Client struct {
+	baseURL    string
+	token      string
+	creds      *Credentials
+	httpClient *http.Client
+}
+
+// NewClient creates a new client storing objects under baseURL, e.g.
+// https://storage.example.com/dbos-archive. A non-empty token is sent as a
+// bearer token.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: time.Minute},
+	}
+}
+
+// NewS3Client creates a new client storing objects in an S3-compatible
+// bucket, signing requests with creds. baseURL addresses the bucket either
+// path-style, e.g. http://minio:9000/dbos-archive, or virtual-hosted-style,
+// e.g. https://dbos-archive.s3.eu-west-1.amazonaws.com.
+func NewS3Client(baseURL string, creds Credentials) *Client {
+	if creds.Region == "" {
+		creds.Region = "us-east-1"
+	}
+	c := NewClient(baseURL, "")
+	c.creds = &creds
+	return c
+}
+
+// Put uploads an object, replacing any object of the same name. name is a
+// slash-separated path below the base URL; it is escaped as needed.
+func (c *Client) Put(ctx context.Context, name, contentType string, body []byte) error {
+	resp, err := c.do(ctx, http.MethodPut, name, contentType, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get downloads an object, returning a NotFound error if it does not exist
+func (c *Client) Get(ctx context.Context, name string) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, name, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxObjectSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxObjectSize {
+		return nil, fmt.Errorf("object %s exceeds %d bytes", name, maxObjectSize)
+	}
+	return data, nil
+}
+
+// do sends a request for an object, returning the response if it succeeded
+func (c *Client) do(ctx context.Context, method, name, contentType string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+"/"+escapePath(name), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.creds != nil {
+		c.sign(req, body, time.Now())
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, dberrors.Wrap(dberrors.Unavailable, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, dberrors.New(dberrors.NotFound, "object %s not found", name)
+		}
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("object store returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return resp, nil
+}
+
+// sign adds an AWS Signature Version 4 to a request
+// (https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html)
+func (c *Client) sign(req *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + c.creds.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.creds.SecretKey), date)
+	key = hmacSHA256(key, c.creds.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.creds.AccessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapePath escapes every segment of an object name as S3 signatures
+// expect, leaving only unreserved characters unescaped. Go sends the path
+// as escaped here, as it is a valid encoding of itself.
+func escapePath(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		switch ch := name[i]; {
+		case ch == '/' || ch == '-' || ch == '_' || ch == '.' || ch == '~',
+			'a' <= ch && ch <= 'z', 'A' <= ch && ch <= 'Z', '0' <= ch && ch <= '9':
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,415 @@
+// Package parquet writes Apache Parquet files of flat, required columns,
+// readable with e.g. pyarrow.parquet, pandas, DuckDB or Spark, and reads
+// such files back.
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/golang/snappy"
+)
+
+// ContentType is the media type of Parquet files
+const ContentType = "application/vnd.apache.parquet"
+
+// Type is the type of a column
+type Type int
+
+const (
+	Int64 Type = iota
+	Float64
+	String
+	// JSON holds JSON documents as strings
+	JSON
+	// Timestamp holds milliseconds since the epoch, in UTC
+	Timestamp
+)
+
+// Field describes a column; every column is required
+type Field struct {
+	Name string
+	Type Type
+}
+
+// Enum values of the Parquet format (src/main/thrift/parquet.thrift)
+const (
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	repetitionRequired = 0
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+	convertedJSON            = 19
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecUncompressed = 0
+	codecSnappy       = 1
+
+	pageData = 0
+)
+
+// magic starts and ends every Parquet file
+const magic = "PAR1"
+
+// physicalType returns the physical type and converted type of a column
+// type, -1 for none
+func (t Type) physicalType() (int32, int32) {
+	switch t {
+	case Int64:
+		return physicalInt64, -1
+	case Float64:
+		return physicalDouble, -1
+	case String:
+		return physicalByteArray, convertedUTF8
+	case JSON:
+		return physicalByteArray, convertedJSON
+	case Timestamp:
+		return physicalInt64, convertedTimestampMillis
+	}
+	return -1, -1
+}
+
+// Writer buffers rows and writes them as a Parquet file with one row group
+// holding each column in one Snappy-compressed, PLAIN-encoded page
+type Writer struct {
+	w       io.Writer
+	fields  []Field
+	columns [][]byte // PLAIN-encoded values
+	rows    int
+}
+
+// NewWriter creates a writer of a file with the given columns
+func NewWriter(w io.Writer, fields []Field) *Writer {
+	return &Writer{
+		w:       w,
+		fields:  fields,
+		columns: make([][]byte, len(fields)),
+	}
+}
+
+// Len returns the number of buffered rows
+func (w *Writer) Len() int {
+	return w.rows
+}
+
+// Append buffers a row with one value per column: int64, float64, string or
+// time.Time according to the column type
+func (w *Writer) Append(values ...interface{}) error {
+	if len(values) != len(w.fields) {
+		return fmt.Errorf("parquet: row has %d values, want %d", len(values), len(w.fields))
+	}
+	for i, field := range w.fields {
+		encoded, err := appendPlain(w.columns[i], field, values[i])
+		if err != nil {
+			return err
+		}
+		w.columns[i] = encoded
+	}
+	w.rows++
+	return nil
+}
+
+// appendPlain appends a value to a column in PLAIN encoding
+func appendPlain(buf []byte, field Field, value interface{}) ([]byte, error) {
+	switch field.Type {
+	case Int64:
+		if v, ok := value.(int64); ok {
+			return binary.LittleEndian.AppendUint64(buf, uint64(v)), nil
+		}
+	case Float64:
+		if v, ok := value.(float64); ok {
+			return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v)), nil
+		}
+	case String, JSON:
+		if v, ok := value.(string); ok {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(v)))
+			return append(buf, v...), nil
+		}
+	case Timestamp:
+		if v, ok := value.(time.Time); ok {
+			return binary.LittleEndian.AppendUint64(buf, uint64(v.UnixMilli())), nil
+		}
+	}
+	return nil, fmt.Errorf("parquet: column %s: unexpected value %T", field.Name, value)
+}
+
+// Close writes the file
+func (w *Writer) Close() error {
+	file := []byte(magic)
+	chunks := make([]columnChunk, len(w.fields))
+	for i, plain := range w.columns {
+		compressed := snappy.Encode(nil, plain)
+
+		var header compactWriter
+		header.beginStruct()
+		header.i32(1, pageData)
+		header.i32(2, int32(len(plain)))
+		header.i32(3, int32(len(compressed)))
+		header.structField(5)
+		header.i32(1, int32(w.rows))
+		header.i32(2, encodingPlain)
+		header.i32(3, encodingRLE)
+		header.i32(4, encodingRLE)
+		header.endStruct()
+		header.endStruct()
+
+		chunks[i] = columnChunk{
+			offset:       int64(len(file)),
+			uncompressed: int64(len(header.buf) + len(plain)),
+			compressed:   int64(len(header.buf) + len(compressed)),
+		}
+		file = append(file, header.buf...)
+		file = append(file, compressed...)
+	}
+
+	footer := w.footer(chunks)
+	file = append(file, footer...)
+	file = binary.LittleEndian.AppendUint32(file, uint32(len(footer)))
+	file = append(file, magic...)
+	_, err := w.w.Write(file)
+	return err
+}
+
+// columnChunk locates a written column
+type columnChunk struct {
+	offset       int64
+	uncompressed int64
+	compressed   int64
+}
+
+// footer encodes the FileMetaData of the file
+func (w *Writer) footer(chunks []columnChunk) []byte {
+	var m compactWriter
+	m.beginStruct()
+	m.i32(1, 1)
+
+	m.listField(2, ctStruct, len(w.fields)+1)
+	m.beginStruct()
+	m.string(4, "schema")
+	m.i32(5, int32(len(w.fields)))
+	m.endStruct()
+	for _, field := range w.fields {
+		physical, converted := field.Type.physicalType()
+		m.beginStruct()
+		m.i32(1, physical)
+		m.i32(3, repetitionRequired)
+		m.string(4, field.Name)
+		if converted >= 0 {
+			m.i32(6, converted)
+		}
+		m.endStruct()
+	}
+
+	m.i64(3, int64(w.rows))
+
+	var total int64
+	for _, chunk := range chunks {
+		total += chunk.uncompressed
+	}
+	m.listField(4, ctStruct, 1)
+	m.beginStruct()
+	m.listField(1, ctStruct, len(chunks))
+	for i, chunk := range chunks {
+		physical, _ := w.fields[i].Type.physicalType()
+		m.beginStruct()
+		m.i64(2, chunk.offset)
+		m.structField(3)
+		m.i32(1, physical)
+		m.listField(2, ctI32, 1)
+		m.listI32(encodingPlain)
+		m.listField(3, ctBinary, 1)
+		m.listString(w.fields[i].Name)
+		m.i32(4, codecSnappy)
+		m.i64(5, int64(w.rows))
+		m.i64(6, chunk.uncompressed)
+		m.i64(7, chunk.compressed)
+		m.i64(9, chunk.offset)
+		m.endStruct()
+		m.endStruct()
+	}
+	m.i64(2, total)
+	m.i64(3, int64(w.rows))
+	m.endStruct()
+
+	m.string(6, "dbos")
+	m.endStruct()
+	return m.buf
+}
+
+// Read reads a Parquet file of flat, required, PLAIN-encoded columns, as
+// Writer writes, returning its fields and rows. Values are int64, float64,
+// string or time.Time according to the column type.
+func Read(data []byte) ([]Field, [][]interface{}, error) {
+	if len(data) < 12 || string(data[:4]) != magic || string(data[len(data)-4:]) != magic {
+		return nil, nil, fmt.Errorf("parquet: not a Parquet file")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	if footerLen > len(data)-12 {
+		return nil, nil, fmt.Errorf("parquet: invalid footer length")
+	}
+	footer := &compactReader{data: data[len(data)-8-footerLen : len(data)-8]}
+	meta, err := footer.readStruct()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema := meta.list(2)
+	if len(schema) == 0 {
+		return nil, nil, fmt.Errorf("parquet: empty schema")
+	}
+	fields := make([]Field, 0, len(schema)-1)
+	for _, elem := range schema[1:] {
+		element, _ := elem.(thriftStruct)
+		field, err := schemaField(element)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields = append(fields, field)
+	}
+
+	var rows [][]interface{}
+	for _, rg := range meta.list(4) {
+		rowGroup, _ := rg.(thriftStruct)
+		chunks := rowGroup.list(1)
+		if len(chunks) != len(fields) {
+			return nil, nil, fmt.Errorf("parquet: row group has %d columns, want %d", len(chunks), len(fields))
+		}
+		numRows := int(rowGroup.int(3))
+		if numRows < 0 || numRows > len(data) {
+			return nil, nil, fmt.Errorf("parquet: invalid row count %d", numRows)
+		}
+		groupRows := make([][]interface{}, numRows)
+		for i := range groupRows {
+			groupRows[i] = make([]interface{}, len(fields))
+		}
+		for i, c := range chunks {
+			chunk, _ := c.(thriftStruct)
+			values, err := readColumn(data, fields[i], chunk.structure(3))
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(values) != numRows {
+				return nil, nil, fmt.Errorf("parquet: column %s has %d values, want %d", fields[i].Name, len(values), numRows)
+			}
+			for row, value := range values {
+				groupRows[row][i] = value
+			}
+		}
+		rows = append(rows, groupRows...)
+	}
+	return fields, rows, nil
+}
+
+// schemaField converts a leaf SchemaElement to a field
+func schemaField(element thriftStruct) (Field, error) {
+	field := Field{Name: element.string(4)}
+	if element.int(3) != repetitionRequired {
+		return field, fmt.Errorf("parquet: column %s is not required", field.Name)
+	}
+
+	converted := int64(-1)
+	if _, ok := element[6]; ok {
+		converted = element.int(6)
+	}
+	switch {
+	case element.int(1) == physicalInt64 && converted == convertedTimestampMillis:
+		field.Type = Timestamp
+	case element.int(1) == physicalInt64 && converted < 0:
+		field.Type = Int64
+	case element.int(1) == physicalDouble && converted < 0:
+		field.Type = Float64
+	case element.int(1) == physicalByteArray && converted == convertedJSON:
+		field.Type = JSON
+	case element.int(1) == physicalByteArray:
+		field.Type = String
+	default:
+		return field, fmt.Errorf("parquet: column %s has an unsupported type", field.Name)
+	}
+	return field, nil
+}
+
+// readColumn decodes the values of a column chunk from its data pages
+func readColumn(data []byte, field Field, meta thriftStruct) ([]interface{}, error) {
+	numValues := int(meta.int(5))
+	codec := meta.int(4)
+	if codec != codecUncompressed && codec != codecSnappy {
+		return nil, fmt.Errorf("parquet: column %s has unsupported codec %d", field.Name, codec)
+	}
+
+	values := make([]interface{}, 0, min(numValues, len(data)))
+	pos := meta.int(9)
+	for len(values) < numValues {
+		if pos < 0 || pos >= int64(len(data)) {
+			return nil, fmt.Errorf("parquet: column %s: page offset out of range", field.Name)
+		}
+		r := &compactReader{data: data[pos:]}
+		header, err := r.readStruct()
+		if err != nil {
+			return nil, err
+		}
+		if header.int(1) != pageData {
+			return nil, fmt.Errorf("parquet: column %s has unsupported page type %d", field.Name, header.int(1))
+		}
+		pageHeader := header.structure(5)
+		if pageHeader.int(2) != encodingPlain {
+			return nil, fmt.Errorf("parquet: column %s has unsupported encoding %d", field.Name, pageHeader.int(2))
+		}
+
+		page, err := r.bytes(uint64(header.int(3)))
+		if err != nil {
+			return nil, err
+		}
+		if codec == codecSnappy {
+			if page, err = snappy.Decode(nil, page); err != nil {
+				return nil, fmt.Errorf("parquet: column %s: %v", field.Name, err)
+			}
+		}
+		if values, err = decodePlain(values, field, page, int(pageHeader.int(1))); err != nil {
+			return nil, err
+		}
+		pos += int64(r.pos)
+	}
+	return values, nil
+}
+
+// decodePlain appends n PLAIN-encoded values of a column
+func decodePlain(values []interface{}, field Field, page []byte, n int) ([]interface{}, error) {
+	truncated := fmt.Errorf("parquet: column %s: truncated page", field.Name)
+	for i := 0; i < n; i++ {
+		switch field.Type {
+		case String, JSON:
+			if len(page) < 4 {
+				return nil, truncated
+			}
+			length := binary.LittleEndian.Uint32(page)
+			if uint64(length) > uint64(len(page)-4) {
+				return nil, truncated
+			}
+			values = append(values, string(page[4:4+length]))
+			page = page[4+length:]
+		default:
+			if len(page) < 8 {
+				return nil, truncated
+			}
+			bits := binary.LittleEndian.Uint64(page)
+			page = page[8:]
+			switch field.Type {
+			case Int64:
+				values = append(values, int64(bits))
+			case Float64:
+				values = append(values, math.Float64frombits(bits))
+			case Timestamp:
+				values = append(values, time.UnixMilli(int64(bits)).UTC())
+			}
+		}
+	}
+	return values, nil
+}
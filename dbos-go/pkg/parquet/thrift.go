@@ -0,0 +1,274 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Types of the Thrift compact protocol, which Parquet metadata is encoded in
+const (
+	ctStop   = 0
+	ctTrue   = 1
+	ctFalse  = 2
+	ctByte   = 3
+	ctI16    = 4
+	ctI32    = 5
+	ctI64    = 6
+	ctDouble = 7
+	ctBinary = 8
+	ctList   = 9
+	ctSet    = 10
+	ctMap    = 11
+	ctStruct = 12
+)
+
+// compactWriter encodes Thrift structs in the compact protocol
+type compactWriter struct {
+	buf []byte
+	// lastIDs holds the last field ID written of each struct being written,
+	// as field IDs are encoded relative to the previous one
+	lastIDs []int16
+}
+
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
+
+func (w *compactWriter) varint(v uint64) {
+	w.buf = binary.AppendUvarint(w.buf, v)
+}
+
+func (w *compactWriter) fieldHeader(id int16, typ byte) {
+	last := &w.lastIDs[len(w.lastIDs)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.varint(zigzag(int64(id)))
+	}
+	*last = id
+}
+
+// beginStruct starts a struct, top-level or a list element
+func (w *compactWriter) beginStruct() {
+	w.lastIDs = append(w.lastIDs, 0)
+}
+
+// endStruct ends the struct being written
+func (w *compactWriter) endStruct() {
+	w.buf = append(w.buf, ctStop)
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *compactWriter) i32(id int16, v int32) {
+	w.fieldHeader(id, ctI32)
+	w.varint(zigzag(int64(v)))
+}
+
+func (w *compactWriter) i64(id int16, v int64) {
+	w.fieldHeader(id, ctI64)
+	w.varint(zigzag(v))
+}
+
+func (w *compactWriter) string(id int16, s string) {
+	w.fieldHeader(id, ctBinary)
+	w.varint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// structField starts a struct field, ended with endStruct
+func (w *compactWriter) structField(id int16) {
+	w.fieldHeader(id, ctStruct)
+	w.beginStruct()
+}
+
+// listField starts a list field of size elements, each written with
+// listI32, listString or beginStruct
+func (w *compactWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, ctList)
+	if size < 15 {
+		w.buf = append(w.buf, byte(size)<<4|elemType)
+	} else {
+		w.buf = append(w.buf, 0xf0|elemType)
+		w.varint(uint64(size))
+	}
+}
+
+func (w *compactWriter) listI32(v int32) {
+	w.varint(zigzag(int64(v)))
+}
+
+func (w *compactWriter) listString(s string) {
+	w.varint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// thriftStruct is a decoded struct, its fields by ID. Integers decode to
+// int64, doubles to float64, binaries to []byte, lists and sets to []any and
+// structs to thriftStruct; maps are skipped.
+type thriftStruct map[int16]any
+
+func (s thriftStruct) int(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s thriftStruct) string(id int16) string {
+	v, _ := s[id].([]byte)
+	return string(v)
+}
+
+func (s thriftStruct) list(id int16) []any {
+	v, _ := s[id].([]any)
+	return v
+}
+
+func (s thriftStruct) structure(id int16) thriftStruct {
+	v, _ := s[id].(thriftStruct)
+	return v
+}
+
+// compactReader decodes Thrift structs in the compact protocol
+type compactReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *compactReader) byte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, fmt.Errorf("thrift: unexpected end of data")
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *compactReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("thrift: invalid varint")
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *compactReader) zigzag() (int64, error) {
+	v, err := r.varint()
+	return int64(v>>1) ^ -int64(v&1), err
+}
+
+func (r *compactReader) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, fmt.Errorf("thrift: length %d exceeds data", n)
+	}
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b, nil
+}
+
+// readStruct decodes a struct up to its stop field
+func (r *compactReader) readStruct() (thriftStruct, error) {
+	s := make(thriftStruct)
+	var last int16
+	for {
+		header, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		if header == ctStop {
+			return s, nil
+		}
+
+		typ := header & 0x0f
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			v, err := r.zigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		last = id
+
+		switch typ {
+		case ctTrue:
+			s[id] = true
+		case ctFalse:
+			s[id] = false
+		default:
+			if s[id], err = r.readValue(typ); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// readValue decodes a value of a type other than a struct field's boolean
+func (r *compactReader) readValue(typ byte) (any, error) {
+	switch typ {
+	case ctTrue, ctFalse:
+		// Booleans in collections take a byte
+		b, err := r.byte()
+		return b == ctTrue, err
+	case ctByte:
+		b, err := r.byte()
+		return int64(int8(b)), err
+	case ctI16, ctI32, ctI64:
+		return r.zigzag()
+	case ctDouble:
+		b, err := r.bytes(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case ctBinary:
+		n, err := r.varint()
+		if err != nil {
+			return nil, err
+		}
+		return r.bytes(n)
+	case ctList, ctSet:
+		header, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		size := uint64(header >> 4)
+		if size == 15 {
+			if size, err = r.varint(); err != nil {
+				return nil, err
+			}
+		}
+		if size > uint64(len(r.data)-r.pos) {
+			return nil, fmt.Errorf("thrift: list of %d elements exceeds data", size)
+		}
+		elems := make([]any, size)
+		for i := range elems {
+			if elems[i], err = r.readValue(header & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return elems, nil
+	case ctMap:
+		size, err := r.varint()
+		if err != nil || size == 0 {
+			return nil, err
+		}
+		types, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < size; i++ {
+			if _, err := r.readValue(types >> 4); err != nil {
+				return nil, err
+			}
+			if _, err := r.readValue(types & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case ctStruct:
+		return r.readStruct()
+	}
+	return nil, fmt.Errorf("thrift: unknown type %d", typ)
+}
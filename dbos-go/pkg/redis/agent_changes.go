@@ -0,0 +1,144 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// agentChangesMaxLen bounds the agent change log; older revisions are compacted away
+const agentChangesMaxLen = 10000
+
+// AgentChange is one entry of the agent change log
+type AgentChange struct {
+	Revision string
+	Type     string
+	AgentID  string
+	Data     []byte
+}
+
+// DeleteAgent removes an agent from Redis
+func (c *Client) DeleteAgent(ctx context.Context, agentID string) error {
+	key := c.key("agent:%s", agentID)
+	return c.client.Del(ctx, key).Err()
+}
+
+// AppendAgentChange records an agent change and returns its revision
+func (c *Client) AppendAgentChange(ctx context.Context, changeType, agentID string, data []byte) (string, error) {
+	return c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: c.key("agents:changes"),
+		MaxLen: agentChangesMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type":     changeType,
+			"agent_id": agentID,
+			"data":     data,
+		},
+	}).Result()
+}
+
+// LatestAgentRevision returns the revision of the newest agent change, or "0-0"
+func (c *Client) LatestAgentRevision(ctx context.Context) (string, error) {
+	return c.latestRevision(ctx, c.key("agents:changes"))
+}
+
+// AgentRevisionAvailable reports whether every change after revision is still in the log
+func (c *Client) AgentRevisionAvailable(ctx context.Context, revision string) (bool, error) {
+	return c.revisionAvailable(ctx, c.key("agents:changes"), revision)
+}
+
+// ReadAgentChanges returns agent changes after revision, blocking up to block for new ones
+func (c *Client) ReadAgentChanges(ctx context.Context, revision string, block time.Duration) ([]AgentChange, error) {
+	msgs, err := c.readStream(ctx, c.key("agents:changes"), revision, block)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]AgentChange, 0, len(msgs))
+	for _, msg := range msgs {
+		change := AgentChange{Revision: msg.ID}
+		change.Type, _ = msg.Values["type"].(string)
+		change.AgentID, _ = msg.Values["agent_id"].(string)
+		if data, ok := msg.Values["data"].(string); ok {
+			change.Data = []byte(data)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// latestRevision returns the ID of the newest entry of a stream, or "0-0"
+func (c *Client) latestRevision(ctx context.Context, stream string) (string, error) {
+	msgs, err := c.client.XRevRangeN(ctx, stream, "+", "-", 1).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(msgs) == 0 {
+		return "0-0", nil
+	}
+	return msgs[0].ID, nil
+}
+
+// revisionAvailable reports whether every entry of a stream after revision
+// is still in it
+func (c *Client) revisionAvailable(ctx context.Context, stream, revision string) (bool, error) {
+	msgs, err := c.client.XRangeN(ctx, stream, "-", "+", 1).Result()
+	if err != nil {
+		return false, err
+	}
+	if len(msgs) == 0 {
+		return true, nil
+	}
+	return compareStreamIDs(revision, msgs[0].ID) >= 0, nil
+}
+
+// readStream returns up to 100 entries of a stream after revision, blocking
+// up to block for new ones
+func (c *Client) readStream(ctx context.Context, stream, revision string, block time.Duration) ([]redis.XMessage, error) {
+	streams, err := c.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{stream, revision},
+		Count:   100,
+		Block:   block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []redis.XMessage
+	for _, s := range streams {
+		msgs = append(msgs, s.Messages...)
+	}
+	return msgs, nil
+}
+
+// compareStreamIDs orders two Redis stream IDs of the form "<ms>-<seq>"
+func compareStreamIDs(a, b string) int {
+	aMs, aSeq := parseStreamID(a)
+	bMs, bSeq := parseStreamID(b)
+	switch {
+	case aMs < bMs:
+		return -1
+	case aMs > bMs:
+		return 1
+	case aSeq < bSeq:
+		return -1
+	case aSeq > bSeq:
+		return 1
+	}
+	return 0
+}
+
+// parseStreamID splits a Redis stream ID into its millisecond and sequence parts
+func parseStreamID(id string) (uint64, uint64) {
+	msPart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(msPart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
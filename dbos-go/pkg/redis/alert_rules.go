@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"context"
+)
+
+// SetAlertRule stores an alert rule definition in Redis
+func (c *Client) SetAlertRule(ctx context.Context, name string, rule []byte) error {
+	return c.client.HSet(ctx, c.key("alert_rules"), name, rule).Err()
+}
+
+// GetAlertRules retrieves all alert rule definitions from Redis
+func (c *Client) GetAlertRules(ctx context.Context) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("alert_rules")).Result()
+}
+
+// deleteAlertRuleTx deletes an alert rule and its series states, for its
+// name
+var deleteAlertRuleTx = newTransaction("DeleteAlertRule", 1, func(c *Client, ids ...string) []string {
+	return []string{c.key("alert_rules"), c.key("alert_rule:%s:series", ids[0])}
+})
+
+// DeleteAlertRule removes an alert rule definition and its series states
+// from Redis, reporting whether it existed
+func (c *Client) DeleteAlertRule(ctx context.Context, name string) (bool, error) {
+	keys := deleteAlertRuleTx.keys(c, name)
+	pipe := c.client.TxPipeline()
+	deleted := pipe.HDel(ctx, keys[0], name)
+	pipe.Del(ctx, keys[1])
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return deleted.Val() > 0, nil
+}
+
+// SetAlertSeriesState stores the state of one series of an alert rule
+func (c *Client) SetAlertSeriesState(ctx context.Context, name, series string, state []byte) error {
+	return c.client.HSet(ctx, c.key("alert_rule:%s:series", name), series, state).Err()
+}
+
+// GetAlertSeriesStates retrieves the states of the active series of an alert rule
+func (c *Client) GetAlertSeriesStates(ctx context.Context, name string) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("alert_rule:%s:series", name)).Result()
+}
+
+// DeleteAlertSeriesState removes the state of one series of an alert rule
+func (c *Client) DeleteAlertSeriesState(ctx context.Context, name, series string) error {
+	return c.client.HDel(ctx, c.key("alert_rule:%s:series", name), series).Err()
+}
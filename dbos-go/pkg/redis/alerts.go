@@ -0,0 +1,47 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// AppendAlert adds an alert to the newest-first alert list, keeping at most maxLen
+func (c *Client) AppendAlert(ctx context.Context, alert interface{}, maxLen int64) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.LPush(ctx, c.key("alerts"), data)
+	pipe.LTrim(ctx, c.key("alerts"), 0, maxLen-1)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetAlerts retrieves all alerts, newest first
+func (c *Client) GetAlerts(ctx context.Context) ([]string, error) {
+	return c.client.LRange(ctx, c.key("alerts"), 0, -1).Result()
+}
+
+// SetCTVerdict caches whether a certificate fingerprint was found in CT logs
+func (c *Client) SetCTVerdict(ctx context.Context, fingerprint string, logged bool, ttl time.Duration) error {
+	key := c.key("ct:%s", fingerprint)
+	return c.client.Set(ctx, key, logged, ttl).Err()
+}
+
+// GetCTVerdict retrieves a cached CT verdict, reporting whether one was cached
+func (c *Client) GetCTVerdict(ctx context.Context, fingerprint string) (logged bool, found bool, err error) {
+	key := c.key("ct:%s", fingerprint)
+	logged, err = c.client.Get(ctx, key).Bool()
+	if err == redis.Nil {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return logged, true, nil
+}
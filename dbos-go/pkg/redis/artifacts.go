@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"context"
+)
+
+// artifactField is the field of an artifact version in the artifacts hash
+func artifactField(name, version string) string {
+	return name + "@" + version
+}
+
+// SetArtifact stores a published artifact version in Redis
+func (c *Client) SetArtifact(ctx context.Context, name, version string, data []byte) error {
+	return c.client.HSet(ctx, c.key("artifacts"), artifactField(name, version), data).Err()
+}
+
+// GetArtifacts retrieves all published artifact versions
+func (c *Client) GetArtifacts(ctx context.Context) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("artifacts")).Result()
+}
+
+// DeleteArtifact removes a published artifact version, reporting whether
+// it existed
+func (c *Client) DeleteArtifact(ctx context.Context, name, version string) (bool, error) {
+	deleted, err := c.client.HDel(ctx, c.key("artifacts"), artifactField(name, version)).Result()
+	return deleted > 0, err
+}
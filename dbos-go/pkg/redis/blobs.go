@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+)
+
+// deleteUnreferencedBlobScript deletes a blob only if nothing references it,
+// so a concurrent store re-referencing the blob cannot lose it
+var deleteUnreferencedBlobScript = newScript("deleteUnreferencedBlob", `
+local refs = tonumber(redis.call("HGET", KEYS[1], ARGV[1]) or "0")
+if refs > 0 then
+	return 0
+end
+redis.call("HDEL", KEYS[1], ARGV[1])
+redis.call("DEL", KEYS[2])
+return 1
+`)
+
+// deleteUnreferencedBlobOp runs deleteUnreferencedBlobScript for a blob hash
+var deleteUnreferencedBlobOp = newScriptOperation("DeleteUnreferencedBlobs", deleteUnreferencedBlobScript, 1, false, func(c *Client, ids ...string) []string {
+	return []string{c.key("blobs:refs"), c.key("blob:%s", ids[0])}
+})
+
+// putBlobTx stores a blob and takes a reference to it, for a blob hash
+var putBlobTx = newTransaction("PutBlob", 1, func(c *Client, ids ...string) []string {
+	return []string{c.key("blob:%s", ids[0]), c.key("blobs:refs")}
+})
+
+// PutBlob stores a content-addressed blob and takes a reference to it
+func (c *Client) PutBlob(ctx context.Context, hash string, data []byte) error {
+	keys := putBlobTx.keys(c, hash)
+	pipe := c.client.TxPipeline()
+	pipe.SetNX(ctx, keys[0], data, 0)
+	pipe.HIncrBy(ctx, keys[1], hash, 1)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetBlob retrieves a content-addressed blob
+func (c *Client) GetBlob(ctx context.Context, hash string) ([]byte, error) {
+	key := c.key("blob:%s", hash)
+	return c.client.Get(ctx, key).Bytes()
+}
+
+// ReleaseBlob drops one reference to a blob; unreferenced blobs are removed by DeleteUnreferencedBlobs
+func (c *Client) ReleaseBlob(ctx context.Context, hash string) error {
+	return c.client.HIncrBy(ctx, c.key("blobs:refs"), hash, -1).Err()
+}
+
+// DeleteUnreferencedBlobs removes every blob whose reference count has dropped to zero
+func (c *Client) DeleteUnreferencedBlobs(ctx context.Context) (int, error) {
+	refs, err := c.client.HGetAll(ctx, c.key("blobs:refs")).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for hash, count := range refs {
+		if n, err := strconv.ParseInt(count, 10, 64); err == nil && n > 0 {
+			continue
+		}
+		ok, err := deleteUnreferencedBlobOp.run(ctx, c, []string{hash}, hash).Int()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += ok
+	}
+
+	return deleted, nil
+}
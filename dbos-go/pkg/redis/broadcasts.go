@@ -0,0 +1,32 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SetBroadcast stores a broadcast in Redis
+func (c *Client) SetBroadcast(ctx context.Context, broadcastID string, broadcast interface{}) error {
+	data, err := json.Marshal(broadcast)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.key("broadcast:%s", broadcastID), data, 0).Err()
+}
+
+// GetBroadcast retrieves a broadcast from Redis
+func (c *Client) GetBroadcast(ctx context.Context, broadcastID string) ([]byte, error) {
+	return c.client.Get(ctx, c.key("broadcast:%s", broadcastID)).Bytes()
+}
+
+// SetBroadcastAgentStatus records the outcome an agent reported for its
+// task of a broadcast
+func (c *Client) SetBroadcastAgentStatus(ctx context.Context, broadcastID, agentID, status string) error {
+	return c.client.HSet(ctx, c.key("broadcast_agents:%s", broadcastID), agentID, status).Err()
+}
+
+// GetBroadcastAgentStatuses retrieves the outcomes agents reported for
+// their tasks of a broadcast, by agent ID
+func (c *Client) GetBroadcastAgentStatuses(ctx context.Context, broadcastID string) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("broadcast_agents:%s", broadcastID)).Result()
+}
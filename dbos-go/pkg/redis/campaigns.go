@@ -0,0 +1,181 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Campaign definitions are kept in the "campaigns" hash. The sorted set
+// "campaigns:due" scores each campaign with a round still to run by the unix
+// time of that round; a campaign leaves it once its last round ran or it was
+// stopped.
+
+// claimCampaignRoundScript claims the round of a campaign due at ARGV[2]:
+// if the campaign is still due then, it is rescheduled at ARGV[3], or
+// unscheduled if ARGV[3] is empty, and the campaign's round count is
+// incremented and returned. It returns 0 if another server claimed the
+// round first.
+var claimCampaignRoundScript = newScript("claimCampaignRound", `
+local due = redis.call("ZSCORE", KEYS[1], ARGV[1])
+if not due or tonumber(due) ~= tonumber(ARGV[2]) then
+	return 0
+end
+if ARGV[3] == "" then
+	redis.call("ZREM", KEYS[1], ARGV[1])
+else
+	redis.call("ZADD", KEYS[1], ARGV[3], ARGV[1])
+end
+return redis.call("HINCRBY", KEYS[2], "rounds", 1)
+`)
+
+// claimCampaignRoundOp runs claimCampaignRoundScript for a campaign ID
+var claimCampaignRoundOp = newScriptOperation("ClaimCampaignRound", claimCampaignRoundScript, 1, false, func(c *Client, ids ...string) []string {
+	return []string{c.key("campaigns:due"), c.campaignStatsKey(ids[0])}
+})
+
+func (c *Client) campaignStatsKey(id string) string {
+	return c.key("campaign_stats:%s", id)
+}
+
+func (c *Client) campaignResultsKey(id string) string {
+	return c.key("campaign_results:%s", id)
+}
+
+// SetCampaign stores a campaign in Redis
+func (c *Client) SetCampaign(ctx context.Context, id string, campaign []byte) error {
+	return c.client.HSet(ctx, c.key("campaigns"), id, campaign).Err()
+}
+
+// GetCampaign retrieves a campaign from Redis, or nil if there is none
+func (c *Client) GetCampaign(ctx context.Context, id string) ([]byte, error) {
+	data, err := c.client.HGet(ctx, c.key("campaigns"), id).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return data, err
+}
+
+// GetCampaigns retrieves all campaigns from Redis
+func (c *Client) GetCampaigns(ctx context.Context) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("campaigns")).Result()
+}
+
+// ScheduleCampaignRound schedules the next round of a campaign at a time
+func (c *Client) ScheduleCampaignRound(ctx context.Context, id string, at time.Time) error {
+	return c.client.ZAdd(ctx, c.key("campaigns:due"), &redis.Z{
+		Score:  float64(at.Unix()),
+		Member: id,
+	}).Err()
+}
+
+// UnscheduleCampaign removes a campaign's next round
+func (c *Client) UnscheduleCampaign(ctx context.Context, id string) error {
+	return c.client.ZRem(ctx, c.key("campaigns:due"), id).Err()
+}
+
+// GetCampaignNextRun retrieves the unix time of a campaign's next round,
+// reporting false if it has none
+func (c *Client) GetCampaignNextRun(ctx context.Context, id string) (int64, bool, error) {
+	score, err := c.client.ZScore(ctx, c.key("campaigns:due"), id).Result()
+	if err == redis.Nil {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return int64(score), true, nil
+}
+
+// GetDueCampaigns retrieves the IDs of the campaigns with a round due by
+// timestamp
+func (c *Client) GetDueCampaigns(ctx context.Context, timestamp time.Time) ([]string, error) {
+	return c.client.ZRangeByScore(ctx, c.key("campaigns:due"), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(timestamp.Unix(), 10),
+	}).Result()
+}
+
+// ClaimCampaignRound atomically claims a campaign's round due at due,
+// scheduling the next one at next if hasNext. It returns the claimed
+// round's number, counting from 1, or 0 if the round was already claimed.
+func (c *Client) ClaimCampaignRound(ctx context.Context, id string, due int64, next time.Time, hasNext bool) (int64, error) {
+	nextScore := ""
+	if hasNext {
+		nextScore = strconv.FormatInt(next.Unix(), 10)
+	}
+	return claimCampaignRoundOp.run(ctx, c, []string{id}, id, due, nextScore).Int64()
+}
+
+// IncrCampaignStat adds n to one of a campaign's counters
+func (c *Client) IncrCampaignStat(ctx context.Context, id, field string, n int64) error {
+	return c.client.HIncrBy(ctx, c.campaignStatsKey(id), field, n).Err()
+}
+
+// GetCampaignStats retrieves a campaign's counters
+func (c *Client) GetCampaignStats(ctx context.Context, id string) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.campaignStatsKey(id)).Result()
+}
+
+// CampaignResultEntry is the index entry of a result correlated with a
+// campaign
+type CampaignResultEntry struct {
+	AgentID   string
+	RequestID string
+	Member    string
+	Score     int64
+}
+
+// AddCampaignResult indexes a result under a campaign by its unix time,
+// reporting whether it was not indexed before
+func (c *Client) AddCampaignResult(ctx context.Context, id, agentID, requestID string, timestamp time.Time) (bool, error) {
+	added, err := c.client.ZAdd(ctx, c.campaignResultsKey(id), &redis.Z{
+		Score:  float64(timestamp.Unix()),
+		Member: agentID + indexSeparator + requestID,
+	}).Result()
+	if err != nil {
+		return false, err
+	}
+	return added > 0, nil
+}
+
+// GetCampaignResults retrieves up to count of a campaign's result index
+// entries with a time in [min, max], skipping the first offset, in time
+// order or reversed if desc. min and max take the ZRANGEBYSCORE forms.
+func (c *Client) GetCampaignResults(ctx context.Context, id, min, max string, desc bool, offset, count int64) ([]CampaignResultEntry, error) {
+	by := &redis.ZRangeBy{
+		Min:    min,
+		Max:    max,
+		Offset: offset,
+		Count:  count,
+	}
+	var entries []redis.Z
+	var err error
+	if desc {
+		entries, err = c.client.ZRevRangeByScoreWithScores(ctx, c.campaignResultsKey(id), by).Result()
+	} else {
+		entries, err = c.client.ZRangeByScoreWithScores(ctx, c.campaignResultsKey(id), by).Result()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CampaignResultEntry, 0, len(entries))
+	for _, entry := range entries {
+		member := entry.Member.(string)
+		agentID, requestID, ok := strings.Cut(member, indexSeparator)
+		if !ok {
+			continue
+		}
+		results = append(results, CampaignResultEntry{
+			AgentID:   agentID,
+			RequestID: requestID,
+			Member:    member,
+			Score:     int64(entry.Score),
+		})
+	}
+	return results, nil
+}
@@ -3,7 +3,10 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -12,24 +15,53 @@ import (
 // Client wraps the Redis client with convenience methods
 type Client struct {
 	client *redis.Client
+	prefix string
 }
 
 // NewClient creates a new Redis client
 func NewClient(addr string) *Client {
+	return NewNamespacedClient(addr, "")
+}
+
+// NewNamespacedClient creates a Redis client whose keys and channels all
+// live under a namespace, "<namespace>:agent:a1" rather than "agent:a1", so
+// several DBOS instances can share one Redis. An empty namespace leaves
+// keys as they are.
+func NewNamespacedClient(addr, namespace string) *Client {
 	rdb := redis.NewClient(&redis.Options{
 		Addr: addr,
 	})
 
+	prefix := ""
+	if namespace != "" {
+		prefix = namespace + ":"
+	}
 	return &Client{
 		client: rdb,
+		prefix: prefix,
 	}
 }
 
+// key returns the namespaced name of a key or channel, formatted like
+// fmt.Sprintf
+func (c *Client) key(format string, args ...interface{}) string {
+	if len(args) == 0 {
+		return c.prefix + format
+	}
+	return c.prefix + fmt.Sprintf(format, args...)
+}
+
 // Close closes the Redis connection
 func (c *Client) Close() error {
 	return c.client.Close()
 }
 
+// IsNotFound reports whether err is the error a lookup of a missing key
+// returns
+func IsNotFound(err error) bool {
+	return errors.Is(err, redis.Nil)
+}
+
 // Ping checks if the Redis connection is alive
 func (c *Client) Ping(ctx context.Context) error {
 	return c.client.Ping(ctx).Err()
@@ -37,7 +69,7 @@ func (c *Client) Ping(ctx context.Context) error {
 
 // SetAgent stores an agent in Redis
 func (c *Client) SetAgent(ctx context.Context, agentID string, agent interface{}) error {
-	key := fmt.Sprintf("agent:%s", agentID)
+	key := c.key("agent:%s", agentID)
 	data, err := json.Marshal(agent)
 	if err != nil {
 		return err
@@ -46,66 +78,204 @@ func (c *Client) SetAgent(ctx context.Context, agentID string, agent interface{}
 	return c.client.Set(ctx, key, data, 0).Err()
 }
 
+// agentUpdateAttempts bounds how often UpdateAgent retries an update the
+// agent changed under
+const agentUpdateAttempts = 16
+
+// updateAgentTx replaces an agent and indexes it by its order values, for
+// an agent ID and the fields it is indexed under
+var updateAgentTx = newTransaction("UpdateAgent", 2, func(c *Client, ids ...string) []string {
+	keys := []string{c.key("agent:%s", ids[0])}
+	for _, field := range ids[1:] {
+		keys = append(keys, indexAgentOrderOp.keys(c, field)...)
+	}
+	return keys
+})
+
+// UpdateAgent atomically replaces an agent with what update makes of it,
+// data being nil if the agent is not stored, and indexes it under the
+// order values update returns. Nil data from update leaves the agent as it
+// is. update is run again if the agent changes before it is replaced; it
+// reports whether the agent was replaced.
+func (c *Client) UpdateAgent(ctx context.Context, agentID string, update func(data []byte) ([]byte, map[string]string, error)) (bool, error) {
+	key := updateAgentTx.keys(c, agentID)[0]
+	for attempt := 0; attempt < agentUpdateAttempts; attempt++ {
+		replaced := false
+		err := c.client.Watch(ctx, func(tx *redis.Tx) error {
+			data, err := tx.Get(ctx, key).Bytes()
+			if err != nil && !IsNotFound(err) {
+				return err
+			}
+			updated, orderValues, err := update(data)
+			if err != nil || updated == nil {
+				return err
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, key, updated, 0)
+				c.indexAgentOrder(ctx, pipe, agentID, orderValues)
+				return nil
+			})
+			replaced = err == nil
+			return err
+		}, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		return replaced, err
+	}
+	return false, fmt.Errorf("agent %s changed by %d concurrent updates", agentID, agentUpdateAttempts)
+}
+
 // GetAgent retrieves an agent from Redis
 func (c *Client) GetAgent(ctx context.Context, agentID string) ([]byte, error) {
-	key := fmt.Sprintf("agent:%s", agentID)
+	key := c.key("agent:%s", agentID)
 	return c.client.Get(ctx, key).Bytes()
 }
 
-// GetAllAgents retrieves all agents from Redis
+// agentScanCount is the number of keys each SCAN step over agents examines
+const agentScanCount = 500
+
+// GetAllAgents retrieves all agents from Redis, walking the keyspace with
+// SCAN so large inventories do not block Redis the way KEYS would
 func (c *Client) GetAllAgents(ctx context.Context) (map[string][]byte, error) {
-	keys, err := c.client.Keys(ctx, "agent:*").Result()
-	if err != nil {
-		return nil, err
+	agents := make(map[string][]byte)
+	var cursor uint64
+	for {
+		page, next, err := c.ScanAgents(ctx, cursor, agentScanCount)
+		if err != nil {
+			return nil, err
+		}
+		// SCAN may return a key more than once; the map keeps one copy
+		for key, data := range page {
+			agents[key] = data
+		}
+		if next == 0 {
+			return agents, nil
+		}
+		cursor = next
 	}
+}
 
-	agents := make(map[string][]byte)
-	for _, key := range keys {
-		data, err := c.client.Get(ctx, key).Bytes()
+// ScanAgents retrieves a page of at least count agents, unless the scan ends
+// first, starting at cursor. It returns the cursor of the next page, which
+// is 0 once all agents were returned. Agents present for the whole scan are
+// returned at least once; ones added or deleted meanwhile may be missed.
+func (c *Client) ScanAgents(ctx context.Context, cursor uint64, count int64) (map[string][]byte, uint64, error) {
+	return c.scanValues(ctx, c.keyPattern("agent:"), cursor, count)
+}
+
+// ScanTasks retrieves a page of at least count tasks like ScanAgents does
+// agents
+func (c *Client) ScanTasks(ctx context.Context, cursor uint64, count int64) (map[string][]byte, uint64, error) {
+	return c.scanValues(ctx, c.keyPattern("task:"), cursor, count)
+}
+
+// keyPattern returns a SCAN pattern matching the namespaced keys starting
+// with prefix, escaping the namespace so it matches only itself
+func (c *Client) keyPattern(prefix string) string {
+	return globEscaper.Replace(c.prefix+prefix) + "*"
+}
+
+// scanValues retrieves a page of at least count string values of the keys
+// matching pattern, unless the scan ends first, starting at cursor
+func (c *Client) scanValues(ctx context.Context, pattern string, cursor uint64, count int64) (map[string][]byte, uint64, error) {
+	var batches [][]string
+	found := int64(0)
+	for {
+		keys, next, err := c.client.Scan(ctx, cursor, pattern, count).Result()
 		if err != nil {
-			continue
+			return nil, 0, err
+		}
+		if len(keys) > 0 {
+			batches = append(batches, keys)
+			found += int64(len(keys))
+		}
+		cursor = next
+		if cursor == 0 || found >= count {
+			break
 		}
-		agents[key] = data
 	}
 
-	return agents, nil
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.SliceCmd, len(batches))
+	for i, keys := range batches {
+		cmds[i] = pipe.MGet(ctx, keys...)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, err
+	}
+
+	values := make(map[string][]byte, found)
+	for i, keys := range batches {
+		for j, value := range cmds[i].Val() {
+			// Deleted keys, and keys of other types, read as nil
+			if data, ok := value.(string); ok {
+				values[keys[j]] = []byte(data)
+			}
+		}
+	}
+
+	return values, cursor, nil
 }
 
-// SetModuleState stores a module state in Redis
-func (c *Client) SetModuleState(ctx context.Context, requestID string, state interface{}) error {
-	key := fmt.Sprintf("module_state:%s", requestID)
+// setModuleStateScript writes a module state as the next version of the
+// stored one, and indexes it by agent and module, in one step. With an
+// expected version, it writes nothing unless the stored version matches.
+// It returns whether the state was written and the version it is at.
+var setModuleStateScript = newScript("setModuleState", `
+local version = 0
+local current = redis.call("GET", KEYS[1])
+if current then
+	version = tonumber(cjson.decode(current).version) or 0
+end
+if ARGV[2] ~= "" and tonumber(ARGV[2]) ~= version then
+	return {0, version}
+end
+local state = cjson.decode(ARGV[1])
+state.version = version + 1
+redis.call("SET", KEYS[1], cjson.encode(state))
+redis.call("ZADD", KEYS[2], ARGV[3], KEYS[1])
+return {1, version + 1}
+`)
+
+// setModuleStateOp runs setModuleStateScript for a request ID and the
+// agent and module of its state
+var setModuleStateOp = newScriptOperation("SetModuleState", setModuleStateScript, 3, false, func(c *Client, ids ...string) []string {
+	return []string{c.key("module_state:%s", ids[0]), c.key("module_states:%s:%s", ids[1], ids[2])}
+})
+
+// SetModuleState stores a module state in Redis as the next version of the
+// stored one. If expectedVersion is non-negative, the state is only stored
+// if the stored version equals it, 0 meaning no state is stored yet. It
+// returns whether the state was stored and the version now stored.
+func (c *Client) SetModuleState(ctx context.Context, requestID, agentID, moduleName string, state interface{}, expectedVersion int64) (bool, int64, error) {
 	data, err := json.Marshal(state)
 	if err != nil {
-		return err
+		return false, 0, err
 	}
 
-	// Also store in a sorted set for efficient querying by agent and module
-	stateMap, ok := state.(map[string]interface{})
-	if ok {
-		if agentID, ok := stateMap["agent_id"].(string); ok {
-			if moduleName, ok := stateMap["module_name"].(string); ok {
-				score := float64(time.Now().Unix())
-				setKey := fmt.Sprintf("module_states:%s:%s", agentID, moduleName)
-				c.client.ZAdd(ctx, setKey, &redis.Z{
-					Score:  score,
-					Member: key,
-				})
-			}
-		}
+	expected := ""
+	if expectedVersion >= 0 {
+		expected = strconv.FormatInt(expectedVersion, 10)
 	}
 
-	return c.client.Set(ctx, key, data, 0).Err()
+	// The agent and module index lets states be listed without a scan
+	res, err := setModuleStateOp.run(ctx, c, []string{requestID, agentID, moduleName}, data, expected, time.Now().Unix()).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return res[0] == 1, res[1], nil
 }
 
 // GetModuleState retrieves a module state from Redis
 func (c *Client) GetModuleState(ctx context.Context, requestID string) ([]byte, error) {
-	key := fmt.Sprintf("module_state:%s", requestID)
+	key := c.key("module_state:%s", requestID)
 	return c.client.Get(ctx, key).Bytes()
 }
 
 // GetModuleStatesByAgent retrieves all module states for an agent from Redis
 func (c *Client) GetModuleStatesByAgent(ctx context.Context, agentID, moduleName string) (map[string][]byte, error) {
-	setKey := fmt.Sprintf("module_states:%s:%s", agentID, moduleName)
+	setKey := c.key("module_states:%s:%s", agentID, moduleName)
 	keys, err := c.client.ZRange(ctx, setKey, 0, -1).Result()
 	if err != nil {
 		return nil, err
@@ -124,80 +294,122 @@ func (c *Client) GetModuleStatesByAgent(ctx context.Context, agentID, moduleName
 }
 
 // StoreResult stores a measurement result in Redis
-func (c *Client) StoreResult(ctx context.Context, agentID, requestID string, result interface{}) error {
-	key := fmt.Sprintf("result:%s:%s", agentID, requestID)
+func (c *Client) StoreResult(ctx context.Context, agentID, requestID string, timestamp time.Time, result interface{}) error {
+	key := c.key("result:%s:%s", agentID, requestID)
 	data, err := json.Marshal(result)
 	if err != nil {
 		return err
 	}
 
-	// Also store in a sorted set for efficient querying by agent
-	score := float64(time.Now().Unix())
-	setKey := fmt.Sprintf("results:%s", agentID)
-	c.client.ZAdd(ctx, setKey, &redis.Z{
-		Score:  score,
-		Member: key,
-	})
-
-	return c.client.Set(ctx, key, data, 0).Err()
+	// Also index it by agent and measurement time for efficient querying
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	pipe := c.client.Pipeline()
+	c.indexResultTime(ctx, pipe, agentID, key, timestamp)
+	pipe.Set(ctx, key, data, 0)
+	_, err = pipe.Exec(ctx)
+	return err
 }
 
 // GetResult retrieves a measurement result from Redis
 func (c *Client) GetResult(ctx context.Context, agentID, requestID string) ([]byte, error) {
-	key := fmt.Sprintf("result:%s:%s", agentID, requestID)
+	key := c.key("result:%s:%s", agentID, requestID)
 	return c.client.Get(ctx, key).Bytes()
 }
 
-// GetResultsByAgent retrieves all results for an agent from Redis
-func (c *Client) GetResultsByAgent(ctx context.Context, agentID string) (map[string][]byte, error) {
-	setKey := fmt.Sprintf("results:%s", agentID)
-	keys, err := c.client.ZRange(ctx, setKey, 0, -1).Result()
+// ResultExists reports whether a measurement result is stored in Redis
+func (c *Client) ResultExists(ctx context.Context, agentID, requestID string) (bool, error) {
+	key := c.key("result:%s:%s", agentID, requestID)
+	n, err := c.client.Exists(ctx, key).Result()
 	if err != nil {
-		return nil, err
-	}
-
-	results := make(map[string][]byte)
-	for _, key := range keys {
-		data, err := c.client.Get(ctx, key).Bytes()
-		if err != nil {
-			continue
-		}
-		results[key] = data
+		return false, err
 	}
-
-	return results, nil
+	return n > 0, nil
 }
 
-// ScheduleTask schedules a task in Redis
-func (c *Client) ScheduleTask(ctx context.Context, taskID string, task interface{}, scheduledAt time.Time) error {
-	key := fmt.Sprintf("task:%s", taskID)
+// scheduleTaskTx stores a task and queues it, for its agent and task IDs
+var scheduleTaskTx = newTransaction("ScheduleTask", 2, func(c *Client, ids ...string) []string {
+	return []string{c.key("task:%s", ids[1]), c.taskQueueKey(ids[0]), c.key("tasks:queues")}
+})
+
+// ScheduleTask schedules a task in Redis in its agent's queue
+func (c *Client) ScheduleTask(ctx context.Context, agentID, taskID string, task interface{}, scheduledAt time.Time) error {
+	keys := scheduleTaskTx.keys(c, agentID, taskID)
+	key := keys[0]
 	data, err := json.Marshal(task)
 	if err != nil {
 		return err
 	}
 
-	// Store in a sorted set for efficient querying of due tasks
+	// Store in the agent's sorted set for efficient querying of due tasks.
+	// Both are written in one transaction, since a lease that finds a member
+	// without its task drops it as deleted.
 	score := float64(scheduledAt.Unix())
-	c.client.ZAdd(ctx, "tasks:scheduled", &redis.Z{
+	pipe := c.client.TxPipeline()
+	pipe.Set(ctx, key, data, 0)
+	pipe.ZAdd(ctx, keys[1], &redis.Z{
 		Score:  score,
 		Member: key,
 	})
-
-	return c.client.Set(ctx, key, data, 0).Err()
+	pipe.SAdd(ctx, keys[2], agentID)
+	_, err = pipe.Exec(ctx)
+	return err
 }
 
 // GetTask retrieves a task from Redis
 func (c *Client) GetTask(ctx context.Context, taskID string) ([]byte, error) {
-	key := fmt.Sprintf("task:%s", taskID)
+	key := c.key("task:%s", taskID)
 	return c.client.Get(ctx, key).Bytes()
 }
 
-// GetDueTasks retrieves all due tasks from Redis
-func (c *Client) GetDueTasks(ctx context.Context, timestamp time.Time) (map[string][]byte, error) {
-	score := float64(timestamp.Unix())
-	keys, err := c.client.ZRangeByScore(ctx, "tasks:scheduled", &redis.ZRangeBy{
+// SetTask stores a task in Redis without touching its agent's queue
+func (c *Client) SetTask(ctx context.Context, taskID string, task interface{}) error {
+	key := c.key("task:%s", taskID)
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, key, data, 0).Err()
+}
+
+// RemoveScheduledTask removes a task from its agent's queue
+func (c *Client) RemoveScheduledTask(ctx context.Context, agentID, taskID string) error {
+	key := c.key("task:%s", taskID)
+	return c.client.ZRem(ctx, c.taskQueueKey(agentID), key).Err()
+}
+
+// AddContinuousTask registers a continuous task to be re-issued at nextRun
+func (c *Client) AddContinuousTask(ctx context.Context, taskID string, nextRun time.Time) error {
+	key := c.key("task:%s", taskID)
+	return c.client.ZAdd(ctx, c.key("tasks:continuous"), &redis.Z{
+		Score:  float64(nextRun.Unix()),
+		Member: key,
+	}).Err()
+}
+
+// RemoveContinuousTask stops a continuous task from being re-issued
+func (c *Client) RemoveContinuousTask(ctx context.Context, taskID string) error {
+	key := c.key("task:%s", taskID)
+	return c.client.ZRem(ctx, c.key("tasks:continuous"), key).Err()
+}
+
+// ContinuousTaskScheduled reports whether a continuous task has a next run
+func (c *Client) ContinuousTaskScheduled(ctx context.Context, taskID string) (bool, error) {
+	key := c.key("task:%s", taskID)
+	err := c.client.ZScore(ctx, c.key("tasks:continuous"), key).Err()
+	if err == redis.Nil {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetDueContinuousTasks retrieves all continuous tasks whose next run is due
+func (c *Client) GetDueContinuousTasks(ctx context.Context, timestamp time.Time) (map[string][]byte, error) {
+	keys, err := c.client.ZRangeByScore(ctx, c.key("tasks:continuous"), &redis.ZRangeBy{
 		Min: "0",
-		Max: fmt.Sprintf("%f", score),
+		Max: fmt.Sprintf("%d", timestamp.Unix()),
 	}).Result()
 	if err != nil {
 		return nil, err
@@ -205,18 +417,99 @@ func (c *Client) GetDueTasks(ctx context.Context, timestamp time.Time) (map[stri
 
 	tasks := make(map[string][]byte)
 	for _, key := range keys {
-		// Remove the prefix to get the actual key
-		actualKey := key
-		if len(key) > 5 {
-			actualKey = key[5:] // Remove "task:" prefix
-		}
-
-		data, err := c.client.Get(ctx, actualKey).Bytes()
+		data, err := c.client.Get(ctx, key).Bytes()
 		if err != nil {
 			continue
 		}
-		tasks[actualKey] = data
+		tasks[key] = data
 	}
 
 	return tasks, nil
 }
+
+// leaseScheduledTaskScript moves the earliest due task of an agent's queue
+// to the in-flight set in one step, so concurrent servers never hand out
+// the same task twice and a lease is never half done. Members whose task
+// was deleted are dropped on the way, and an emptied queue is taken off
+// the set of queues.
+var leaseScheduledTaskScript = newScript("leaseScheduledTask", `
+while true do
+	local key = redis.call("ZRANGEBYSCORE", KEYS[1], "0", ARGV[1], "LIMIT", 0, 1)[1]
+	if not key then
+		if redis.call("ZCARD", KEYS[1]) == 0 then
+			redis.call("SREM", KEYS[3], ARGV[2])
+		end
+		return false
+	end
+	redis.call("ZREM", KEYS[1], key)
+	local data = redis.call("GET", key)
+	if data then
+		redis.call("ZADD", KEYS[2], ARGV[3], key)
+		return data
+	end
+end
+`)
+
+// leaseScheduledTaskOp runs leaseScheduledTaskScript for an agent ID
+var leaseScheduledTaskOp = newScriptOperation("LeaseScheduledTask", leaseScheduledTaskScript, 1, true, func(c *Client, ids ...string) []string {
+	return []string{c.taskQueueKey(ids[0]), c.key("tasks:inflight"), c.key("tasks:queues")}
+})
+
+// LeaseScheduledTask atomically dequeues an agent's earliest task due at
+// timestamp into the in-flight set as leased at leasedAt, returning its
+// data, or nil if the agent has no due task
+func (c *Client) LeaseScheduledTask(ctx context.Context, agentID string, timestamp, leasedAt time.Time) ([]byte, error) {
+	data, err := leaseScheduledTaskOp.run(ctx, c, []string{agentID}, timestamp.Unix(), agentID, inflightScore(leasedAt)).Text()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(data), nil
+}
+
+// AddInflightTask puts a task in the in-flight set as leased at leasedAt
+func (c *Client) AddInflightTask(ctx context.Context, taskID string, leasedAt time.Time) error {
+	return c.client.ZAdd(ctx, c.key("tasks:inflight"), &redis.Z{
+		Score:  inflightScore(leasedAt),
+		Member: c.key("task:%s", taskID),
+	}).Err()
+}
+
+// inflightScore returns the in-flight set score of a lease started at
+// leasedAt, or of a lease that never ends if leasedAt is zero
+func inflightScore(leasedAt time.Time) float64 {
+	if leasedAt.IsZero() {
+		return math.Inf(1)
+	}
+	return float64(leasedAt.Unix())
+}
+
+// returnLeasedTaskTx takes a task out of flight into its agent's queue,
+// for an agent ID
+var returnLeasedTaskTx = newTransaction("ReturnLeasedTask", 1, func(c *Client, ids ...string) []string {
+	return []string{c.key("tasks:inflight"), c.taskQueueKey(ids[0]), c.key("tasks:queues")}
+})
+
+// ReturnLeasedTask takes a task out of flight and back into its agent's
+// queue, due at scheduledAt, in one transaction
+func (c *Client) ReturnLeasedTask(ctx context.Context, agentID, taskID string, scheduledAt time.Time) error {
+	key := c.key("task:%s", taskID)
+	keys := returnLeasedTaskTx.keys(c, agentID)
+	pipe := c.client.TxPipeline()
+	pipe.ZRem(ctx, keys[0], key)
+	pipe.ZAdd(ctx, keys[1], &redis.Z{
+		Score:  float64(scheduledAt.Unix()),
+		Member: key,
+	})
+	pipe.SAdd(ctx, keys[2], agentID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RemoveInflightTask removes a task from the in-flight set once it finished
+func (c *Client) RemoveInflightTask(ctx context.Context, taskID string) error {
+	key := c.key("task:%s", taskID)
+	return c.client.ZRem(ctx, c.key("tasks:inflight"), key).Err()
+}
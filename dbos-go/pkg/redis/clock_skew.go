@@ -0,0 +1,21 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SetClockSkew stores an agent's clock skew model in Redis
+func (c *Client) SetClockSkew(ctx context.Context, agentID string, skew interface{}) error {
+	data, err := json.Marshal(skew)
+	if err != nil {
+		return err
+	}
+
+	return c.client.HSet(ctx, c.key("clock_skew"), agentID, data).Err()
+}
+
+// GetClockSkew retrieves an agent's clock skew model from Redis
+func (c *Client) GetClockSkew(ctx context.Context, agentID string) ([]byte, error) {
+	return c.client.HGet(ctx, c.key("clock_skew"), agentID).Bytes()
+}
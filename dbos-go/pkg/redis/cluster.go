@@ -0,0 +1,196 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// clusterSlots is the number of hash slots Redis Cluster shards keys over
+const clusterSlots = 16384
+
+// KeySlot returns the Redis Cluster hash slot of a key: the CRC16 of the
+// key, or of its hash tag, the part between the first { and the next }
+// when not empty, modulo 16384. Keys sharing a hash tag share a slot.
+func KeySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key)) % clusterSlots
+}
+
+// crc16 is the CRC16/XMODEM checksum Redis Cluster hashes keys with
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// ClusterEnabled reports whether the Redis server runs in cluster mode
+func (c *Client) ClusterEnabled(ctx context.Context) (bool, error) {
+	info, err := c.client.Info(ctx, "cluster").Result()
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(info, "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "cluster_enabled:"); ok {
+			return value == "1", nil
+		}
+	}
+	return false, nil
+}
+
+// scripts are the client's Lua scripts by name, as newScript registers
+// them, so that the cluster check finds scripts no operation runs
+var scripts = make(map[string]*redis.Script)
+
+// newScript returns a script of the client, registered under name
+func newScript(name, src string) *redis.Script {
+	script := redis.NewScript(src)
+	scripts[name] = script
+	return script
+}
+
+// keyBuilder builds the keys of an atomic operation from the IDs it is run
+// for, such as an agent ID and a day
+type keyBuilder func(c *Client, ids ...string) []string
+
+// operation is an atomic operation of the client: a run of one of its
+// scripts, or a transaction touching more than one key. The client builds
+// the keys of each run with the operation's builder, from which the
+// cluster check derives them too.
+type operation struct {
+	name   string
+	script *redis.Script
+	// ids is how many IDs the builder takes, the least for builders taking
+	// a list
+	ids  int
+	keys keyBuilder
+	// undeclared operations reach keys they are not passed, such as the
+	// task keys read from a queue
+	undeclared bool
+}
+
+// operations are the client's atomic operations by name
+var operations = make(map[string]*operation)
+
+// newScriptOperation registers an operation running script on the keys
+// built from ids IDs
+func newScriptOperation(name string, script *redis.Script, ids int, undeclared bool, keys keyBuilder) *operation {
+	return registerOperation(&operation{name: name, script: script, ids: ids, keys: keys, undeclared: undeclared})
+}
+
+// newTransaction registers a transaction on the keys built from ids IDs
+func newTransaction(name string, ids int, keys keyBuilder) *operation {
+	return registerOperation(&operation{name: name, ids: ids, keys: keys})
+}
+
+// registerOperation adds op to the operations, which name each only once
+func registerOperation(op *operation) *operation {
+	if _, ok := operations[op.name]; ok {
+		panic("redis: operation " + op.name + " registered twice")
+	}
+	operations[op.name] = op
+	return op
+}
+
+// run runs the operation's script on the keys built from ids
+func (op *operation) run(ctx context.Context, c *Client, ids []string, args ...interface{}) *redis.Cmd {
+	return op.script.Run(ctx, c.client, op.keys(c, ids...), args...)
+}
+
+// eval queues the operation's script on pipe, on the keys built from ids,
+// sending it in full as a pipeline cannot load a script it misses
+func (op *operation) eval(ctx context.Context, pipe redis.Pipeliner, c *Client, ids []string, args ...interface{}) *redis.Cmd {
+	return op.script.Eval(ctx, pipe, op.keys(c, ids...), args...)
+}
+
+// AtomicOperation is a script run or transaction of the client, with the
+// keys its builder gives for sample IDs and the registered name of the
+// script it runs, if any. Redis Cluster runs it only if all of its keys
+// share a slot, and only if it names them all: Undeclared scripts reach
+// keys they are not passed, such as the task keys read from a queue.
+type AtomicOperation struct {
+	Name       string
+	Script     string
+	Keys       []string
+	Undeclared bool
+}
+
+// AtomicOperations lists the client's registered scripts runs and
+// transactions touching more than one key, in name order, with their keys
+// built for distinct sample IDs. Pipelines that are not transactions, such
+// as StoreResult and StoreResults, are left out: a cluster client splits
+// them by slot.
+func (c *Client) AtomicOperations() []AtomicOperation {
+	names := make(map[*redis.Script]string, len(scripts))
+	for name, script := range scripts {
+		names[script] = name
+	}
+
+	ops := make([]AtomicOperation, 0, len(operations))
+	for _, name := range slices.Sorted(maps.Keys(operations)) {
+		op := operations[name]
+		ids := make([]string, op.ids)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("id%d", i+1)
+		}
+		ops = append(ops, AtomicOperation{
+			Name:       op.name,
+			Script:     names[op.script],
+			Keys:       op.keys(c, ids...),
+			Undeclared: op.undeclared,
+		})
+	}
+	return ops
+}
+
+// CrossSlotOperations returns a description of each of the client's atomic
+// operations that Redis Cluster would refuse, as their keys span slots or
+// they reach keys they are not passed, and of each script no registered
+// operation runs, whose keys are unchecked
+func (c *Client) CrossSlotOperations() []string {
+	var problems []string
+	operations := c.AtomicOperations()
+	listed := make(map[string]bool, len(operations))
+	for _, op := range operations {
+		listed[op.Script] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(scripts)) {
+		if !listed[name] {
+			problems = append(problems, "script "+name+" is run by no registered operation, so its keys are unchecked")
+		}
+	}
+	for _, op := range operations {
+		slots := make(map[int]bool)
+		for _, key := range op.Keys {
+			slots[KeySlot(key)] = true
+		}
+		var reasons []string
+		if len(slots) > 1 {
+			reasons = append(reasons, fmt.Sprintf("spans %d slots over keys %s", len(slots), strings.Join(op.Keys, ", ")))
+		}
+		if op.Undeclared {
+			reasons = append(reasons, "reaches keys it is not passed")
+		}
+		if len(reasons) > 0 {
+			problems = append(problems, op.Name+" "+strings.Join(reasons, " and "))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// PushAgentConfig makes a config version the current desired config of an agent
+func (c *Client) PushAgentConfig(ctx context.Context, agentID string, version interface{}) error {
+	key := c.key("agent_config:%s", agentID)
+	data, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	return c.client.LPush(ctx, key, data).Err()
+}
+
+// GetAgentConfig retrieves the current desired config version of an agent
+func (c *Client) GetAgentConfig(ctx context.Context, agentID string) ([]byte, error) {
+	key := c.key("agent_config:%s", agentID)
+	return c.client.LIndex(ctx, key, 0).Bytes()
+}
+
+// PopAgentConfig discards the current desired config version of an agent,
+// restoring the previous one
+func (c *Client) PopAgentConfig(ctx context.Context, agentID string) error {
+	key := c.key("agent_config:%s", agentID)
+	return c.client.LPop(ctx, key).Err()
+}
+
+// setConfigRolloutTx stores a config rollout and lists it while active,
+// for its ID
+var setConfigRolloutTx = newTransaction("SetConfigRollout", 1, func(c *Client, ids ...string) []string {
+	return []string{c.key("config_rollout:%s", ids[0]), c.key("config_rollouts:active")}
+})
+
+// SetConfigRollout stores a config rollout in Redis
+func (c *Client) SetConfigRollout(ctx context.Context, rolloutID string, rollout interface{}, active bool) error {
+	keys := setConfigRolloutTx.keys(c, rolloutID)
+	data, err := json.Marshal(rollout)
+	if err != nil {
+		return err
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.Set(ctx, keys[0], data, 0)
+	if active {
+		pipe.SAdd(ctx, keys[1], rolloutID)
+	} else {
+		pipe.SRem(ctx, keys[1], rolloutID)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetConfigRollout retrieves a config rollout from Redis
+func (c *Client) GetConfigRollout(ctx context.Context, rolloutID string) ([]byte, error) {
+	key := c.key("config_rollout:%s", rolloutID)
+	return c.client.Get(ctx, key).Bytes()
+}
+
+// GetActiveConfigRolloutIDs retrieves the IDs of all in-progress config rollouts
+func (c *Client) GetActiveConfigRolloutIDs(ctx context.Context) ([]string, error) {
+	return c.client.SMembers(ctx, c.key("config_rollouts:active")).Result()
+}
+
+// RecordModuleOutcome records that an agent reported a module state, and
+// whether it was an error, for health-gating config rollouts
+func (c *Client) RecordModuleOutcome(ctx context.Context, agentID, requestID string, isError bool, at time.Time) error {
+	z := &redis.Z{Score: float64(at.Unix()), Member: requestID}
+	pipe := c.client.Pipeline()
+	pipe.ZAdd(ctx, c.key("module_outcomes:%s", agentID), z)
+	if isError {
+		pipe.ZAdd(ctx, c.key("module_errors:%s", agentID), z)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// CountModuleOutcomes counts an agent's reported module states and errors since a time
+func (c *Client) CountModuleOutcomes(ctx context.Context, agentID string, since time.Time) (int64, int64, error) {
+	min := fmt.Sprintf("%d", since.Unix())
+	pipe := c.client.Pipeline()
+	total := pipe.ZCount(ctx, c.key("module_outcomes:%s", agentID), min, "+inf")
+	errors := pipe.ZCount(ctx, c.key("module_errors:%s", agentID), min, "+inf")
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+	return total.Val(), errors.Val(), nil
+}
@@ -0,0 +1,22 @@
+package redis
+
+import (
+	"context"
+)
+
+// SetConfigSchema stores the config schema of an agent version in Redis
+func (c *Client) SetConfigSchema(ctx context.Context, agentVersion string, schema []byte) error {
+	return c.client.HSet(ctx, c.key("config_schemas"), agentVersion, schema).Err()
+}
+
+// GetConfigSchemas retrieves the config schemas of all agent versions
+func (c *Client) GetConfigSchemas(ctx context.Context) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("config_schemas")).Result()
+}
+
+// DeleteConfigSchema removes the config schema of an agent version,
+// reporting whether it existed
+func (c *Client) DeleteConfigSchema(ctx context.Context, agentVersion string) (bool, error) {
+	deleted, err := c.client.HDel(ctx, c.key("config_schemas"), agentVersion).Result()
+	return deleted > 0, err
+}
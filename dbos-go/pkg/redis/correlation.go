@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// correlationKey returns the key of the sorted set indexing the results of
+// a correlation ID by measurement time
+func (c *Client) correlationKey(correlationID string) string {
+	return c.key("results:correlation:%s", correlationID)
+}
+
+// CorrelateResult indexes a result under a correlation ID by measurement
+// time
+func (c *Client) CorrelateResult(ctx context.Context, correlationID, agentID, requestID string, timestamp time.Time) error {
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	return c.client.ZAdd(ctx, c.correlationKey(correlationID), &redis.Z{
+		Score:  float64(timestamp.Unix()),
+		Member: c.key("result:%s:%s", agentID, requestID),
+	}).Err()
+}
+
+// UncorrelateResult removes a result from the index of a correlation ID
+func (c *Client) UncorrelateResult(ctx context.Context, correlationID, agentID, requestID string) error {
+	return c.client.ZRem(ctx, c.correlationKey(correlationID), c.key("result:%s:%s", agentID, requestID)).Err()
+}
+
+// GetCorrelatedResults retrieves up to count of the results of a
+// correlation ID with a unix measurement time in [min, max], skipping the
+// first offset, in time order, like GetResultsByTime
+func (c *Client) GetCorrelatedResults(ctx context.Context, correlationID, min, max string, offset, count int64) ([]IndexedValue, int, error) {
+	return c.getByScore(ctx, c.correlationKey(correlationID), min, max, false, offset, count)
+}
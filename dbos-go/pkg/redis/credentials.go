@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// SetBootstrapToken stores a bootstrap token under the hash of its secret until it expires
+func (c *Client) SetBootstrapToken(ctx context.Context, tokenHash string, token interface{}, ttl time.Duration) error {
+	key := c.key("bootstrap:%s", tokenHash)
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, key, data, ttl).Err()
+}
+
+// ConsumeBootstrapToken atomically retrieves and deletes a bootstrap token
+func (c *Client) ConsumeBootstrapToken(ctx context.Context, tokenHash string) ([]byte, error) {
+	key := c.key("bootstrap:%s", tokenHash)
+	return c.client.GetDel(ctx, key).Bytes()
+}
+
+// SetAgentToken maps the hash of an agent's credential to its agent ID
+func (c *Client) SetAgentToken(ctx context.Context, tokenHash, agentID string) error {
+	key := c.key("agent_token:%s", tokenHash)
+	return c.client.Set(ctx, key, agentID, 0).Err()
+}
+
+// GetAgentToken retrieves the agent ID a credential hash belongs to
+func (c *Client) GetAgentToken(ctx context.Context, tokenHash string) (string, error) {
+	key := c.key("agent_token:%s", tokenHash)
+	return c.client.Get(ctx, key).Result()
+}
+
+// SetAPIToken stores the claims of an API token under the hash of its secret
+func (c *Client) SetAPIToken(ctx context.Context, tokenHash string, token interface{}) error {
+	key := c.key("api_token:%s", tokenHash)
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, key, data, 0).Err()
+}
+
+// GetAPIToken retrieves the claims of the API token a hash belongs to
+func (c *Client) GetAPIToken(ctx context.Context, tokenHash string) ([]byte, error) {
+	key := c.key("api_token:%s", tokenHash)
+	return c.client.Get(ctx, key).Bytes()
+}
@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// SetDNSObservation stores a region's latest answer to a DNS query; the
+// query's observations expire once no region has reported for ttl
+func (c *Client) SetDNSObservation(ctx context.Context, query, region string, observation interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(observation)
+	if err != nil {
+		return err
+	}
+
+	key := c.key("dns:observations:%s", query)
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, key, region, data)
+	pipe.Expire(ctx, key, ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetDNSObservations retrieves the latest answers to a DNS query by region
+func (c *Client) GetDNSObservations(ctx context.Context, query string) (map[string]string, error) {
+	key := c.key("dns:observations:%s", query)
+	return c.client.HGetAll(ctx, key).Result()
+}
+
+// SetDNSExpectation stores the expected answers to a DNS query
+func (c *Client) SetDNSExpectation(ctx context.Context, query string, expectation interface{}) error {
+	data, err := json.Marshal(expectation)
+	if err != nil {
+		return err
+	}
+
+	return c.client.HSet(ctx, c.key("dns:expectations"), query, data).Err()
+}
+
+// GetDNSExpectation retrieves the expected answers to a DNS query
+func (c *Client) GetDNSExpectation(ctx context.Context, query string) ([]byte, error) {
+	return c.client.HGet(ctx, c.key("dns:expectations"), query).Bytes()
+}
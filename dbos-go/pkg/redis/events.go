@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// The event log is the "events:log" stream; an event's stream ID is its
+// sequence, which consumers resume from. It is trimmed from its oldest end
+// by the server's event log retention.
+
+// EventEntry is an entry of the event log
+type EventEntry struct {
+	Sequence string
+	Data     []byte
+}
+
+// AppendEvent adds an event to the event log and returns its sequence
+func (c *Client) AppendEvent(ctx context.Context, data []byte) (string, error) {
+	return c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: c.key("events:log"),
+		Values: map[string]interface{}{"data": data},
+	}).Result()
+}
+
+// GetEvents retrieves up to count entries of the event log newest first,
+// starting before the one with sequence before, or with the newest if
+// before is empty
+func (c *Client) GetEvents(ctx context.Context, before string, count int64) ([]EventEntry, error) {
+	end := "+"
+	if before != "" {
+		ms, seq := parseStreamID(before)
+		switch {
+		case seq > 0:
+			end = fmt.Sprintf("%d-%d", ms, seq-1)
+		case ms > 0:
+			end = fmt.Sprintf("%d-%d", ms-1, ^uint64(0))
+		default:
+			return nil, nil
+		}
+	}
+	msgs, err := c.client.XRevRangeN(ctx, c.key("events:log"), end, "-", count).Result()
+	if err != nil {
+		return nil, err
+	}
+	return eventEntries(msgs), nil
+}
+
+// ReadEvents returns entries of the event log after sequence, blocking up
+// to block for new ones
+func (c *Client) ReadEvents(ctx context.Context, sequence string, block time.Duration) ([]EventEntry, error) {
+	msgs, err := c.readStream(ctx, c.key("events:log"), sequence, block)
+	if err != nil {
+		return nil, err
+	}
+	return eventEntries(msgs), nil
+}
+
+// LatestEventSequence returns the sequence of the newest event, or "0-0"
+func (c *Client) LatestEventSequence(ctx context.Context) (string, error) {
+	return c.latestRevision(ctx, c.key("events:log"))
+}
+
+// EventSequenceAvailable reports whether every event after sequence is
+// still in the log
+func (c *Client) EventSequenceAvailable(ctx context.Context, sequence string) (bool, error) {
+	return c.revisionAvailable(ctx, c.key("events:log"), sequence)
+}
+
+// EventLogLength returns the number of events in the log
+func (c *Client) EventLogLength(ctx context.Context) (int64, error) {
+	return c.client.XLen(ctx, c.key("events:log")).Result()
+}
+
+// GetOldestEvents retrieves up to count entries of the event log oldest
+// first
+func (c *Client) GetOldestEvents(ctx context.Context, count int64) ([]EventEntry, error) {
+	msgs, err := c.client.XRangeN(ctx, c.key("events:log"), "-", "+", count).Result()
+	if err != nil {
+		return nil, err
+	}
+	return eventEntries(msgs), nil
+}
+
+// TrimEvents removes the events of the log up to and including the one
+// with sequence through, returning how many were removed
+func (c *Client) TrimEvents(ctx context.Context, through string) (int64, error) {
+	ms, seq := parseStreamID(through)
+	return c.client.XTrimMinID(ctx, c.key("events:log"), fmt.Sprintf("%d-%d", ms, seq+1)).Result()
+}
+
+// eventEntries converts event log stream entries
+func eventEntries(msgs []redis.XMessage) []EventEntry {
+	entries := make([]EventEntry, 0, len(msgs))
+	for _, msg := range msgs {
+		data, _ := msg.Values["data"].(string)
+		entries = append(entries, EventEntry{Sequence: msg.ID, Data: []byte(data)})
+	}
+	return entries
+}
@@ -0,0 +1,144 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// executionsTTL is how long execution samples are kept after an agent or
+// module last ran a task
+const executionsTTL = 30 * 24 * time.Hour
+
+// agentExecutionsKey returns the key of the list of an agent's latest
+// executions of a module, newest first
+func (c *Client) agentExecutionsKey(agentID, moduleName string) string {
+	return c.key("executions:agent:%s:%s", agentID, moduleName)
+}
+
+// moduleExecutionsKey returns the key of the list of the latest executions
+// of a module across all agents, newest first
+func (c *Client) moduleExecutionsKey(moduleName string) string {
+	return c.key("executions:module:%s", moduleName)
+}
+
+// executionLeaseTTL is how long a recorded lease is remembered, so that
+// its execution is not recorded again
+const executionLeaseTTL = 24 * time.Hour
+
+// agentHistogramKey returns the key of the hash counting an agent's
+// executions of a module by duration bucket
+func (c *Client) agentHistogramKey(agentID, moduleName string) string {
+	return c.key("executions:histogram:agent:%s:%s", agentID, moduleName)
+}
+
+// moduleHistogramKey returns the key of the hash counting the executions of
+// a module across all agents by duration bucket
+func (c *Client) moduleHistogramKey(moduleName string) string {
+	return c.key("executions:histogram:module:%s", moduleName)
+}
+
+// executionAgentsKey returns the key of the set of agents that ran a module
+func (c *Client) executionAgentsKey(moduleName string) string {
+	return c.key("executions:agents:%s", moduleName)
+}
+
+// RecordExecution records the execution of a task under a lease, unless it
+// was recorded before: it is prepended to an agent's list for a module and
+// to the module's, which are trimmed to their most recent agentMax and
+// moduleMax entries, and counted in the bucket field of their histograms.
+// It reports whether the execution was recorded.
+func (c *Client) RecordExecution(ctx context.Context, agentID, moduleName, lease string, execution interface{}, bucket string, durationMs float64, agentMax, moduleMax int64) (bool, error) {
+	data, err := json.Marshal(execution)
+	if err != nil {
+		return false, err
+	}
+	first, err := c.client.SetNX(ctx, c.key("executions:lease:%s", lease), 1, executionLeaseTTL).Result()
+	if err != nil || !first {
+		return false, err
+	}
+
+	agentKey, moduleKey := c.agentExecutionsKey(agentID, moduleName), c.moduleExecutionsKey(moduleName)
+	agentsKey := c.executionAgentsKey(moduleName)
+	pipe := c.client.Pipeline()
+	pipe.LPush(ctx, agentKey, data)
+	pipe.LTrim(ctx, agentKey, 0, agentMax-1)
+	pipe.LPush(ctx, moduleKey, data)
+	pipe.LTrim(ctx, moduleKey, 0, moduleMax-1)
+	for _, key := range []string{c.agentHistogramKey(agentID, moduleName), c.moduleHistogramKey(moduleName)} {
+		pipe.HIncrBy(ctx, key, bucket, 1)
+		pipe.HIncrBy(ctx, key, "count", 1)
+		pipe.HIncrByFloat(ctx, key, "sum_ms", durationMs)
+		pipe.Expire(ctx, key, executionsTTL)
+	}
+	pipe.SAdd(ctx, agentsKey, agentID)
+	for _, key := range []string{agentKey, moduleKey, agentsKey} {
+		pipe.Expire(ctx, key, executionsTTL)
+	}
+	_, err = pipe.Exec(ctx)
+	return err == nil, err
+}
+
+// GetAgentExecutions retrieves the recorded executions of a module by each
+// of several agents in one round trip, keyed by agent ID
+func (c *Client) GetAgentExecutions(ctx context.Context, agentIDs []string, moduleName string) (map[string][][]byte, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringSliceCmd, len(agentIDs))
+	for i, agentID := range agentIDs {
+		cmds[i] = pipe.LRange(ctx, c.agentExecutionsKey(agentID, moduleName), 0, -1)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	executions := make(map[string][][]byte, len(agentIDs))
+	for i, agentID := range agentIDs {
+		for _, data := range cmds[i].Val() {
+			executions[agentID] = append(executions[agentID], []byte(data))
+		}
+	}
+	return executions, nil
+}
+
+// GetModuleExecutions retrieves the recorded executions of a module across
+// all agents
+func (c *Client) GetModuleExecutions(ctx context.Context, moduleName string) ([][]byte, error) {
+	values, err := c.client.LRange(ctx, c.moduleExecutionsKey(moduleName), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	executions := make([][]byte, len(values))
+	for i, data := range values {
+		executions[i] = []byte(data)
+	}
+	return executions, nil
+}
+
+// GetExecutionHistograms retrieves the duration histogram fields of a
+// module across all agents and of each of several agents, keyed by agent ID
+func (c *Client) GetExecutionHistograms(ctx context.Context, moduleName string, agentIDs []string) (map[string]string, map[string]map[string]string, error) {
+	pipe := c.client.Pipeline()
+	moduleCmd := pipe.HGetAll(ctx, c.moduleHistogramKey(moduleName))
+	cmds := make([]*redis.StringStringMapCmd, len(agentIDs))
+	for i, agentID := range agentIDs {
+		cmds[i] = pipe.HGetAll(ctx, c.agentHistogramKey(agentID, moduleName))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, nil, err
+	}
+
+	agents := make(map[string]map[string]string, len(agentIDs))
+	for i, agentID := range agentIDs {
+		agents[agentID] = cmds[i].Val()
+	}
+	return moduleCmd.Val(), agents, nil
+}
+
+// GetExecutionAgents retrieves the agents that ran a module in the last 30
+// days
+func (c *Client) GetExecutionAgents(ctx context.Context, moduleName string) ([]string, error) {
+	return c.client.SMembers(ctx, c.executionAgentsKey(moduleName)).Result()
+}
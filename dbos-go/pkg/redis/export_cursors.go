@@ -0,0 +1,36 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+)
+
+// exportCursorsKey returns the key of the hash holding, per agent, the
+// sequence number of the last result a sink exported
+func (c *Client) exportCursorsKey(sink string) string {
+	return c.key("export:%s:cursors", sink)
+}
+
+// GetExportCursors retrieves the sequence number of every agent's last
+// result a sink exported
+func (c *Client) GetExportCursors(ctx context.Context, sink string) (map[string]int64, error) {
+	values, err := c.client.HGetAll(ctx, c.exportCursorsKey(sink)).Result()
+	if err != nil {
+		return nil, err
+	}
+	cursors := make(map[string]int64, len(values))
+	for agentID, value := range values {
+		seq, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		cursors[agentID] = seq
+	}
+	return cursors, nil
+}
+
+// SetExportCursor records the sequence number of an agent's last result a
+// sink exported
+func (c *Client) SetExportCursor(ctx context.Context, sink, agentID string, seq int64) error {
+	return c.client.HSet(ctx, c.exportCursorsKey(sink), agentID, seq).Err()
+}
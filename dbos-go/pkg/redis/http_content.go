@@ -0,0 +1,29 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// SetHTTPObservation stores a region's latest response to a URL; the URL's
+// observations expire once no region has reported for ttl
+func (c *Client) SetHTTPObservation(ctx context.Context, url, region string, observation interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(observation)
+	if err != nil {
+		return err
+	}
+
+	key := c.key("http:observations:%s", url)
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, key, region, data)
+	pipe.Expire(ctx, key, ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetHTTPObservations retrieves the latest responses to a URL by region
+func (c *Client) GetHTTPObservations(ctx context.Context, url string) (map[string]string, error) {
+	key := c.key("http:observations:%s", url)
+	return c.client.HGetAll(ctx, key).Result()
+}
@@ -0,0 +1,130 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// incidentEventsMaxLen bounds the incident event log; older revisions are compacted away
+const incidentEventsMaxLen = 10000
+
+// deleteIncidentScript removes an incident and, if it is the open incident
+// of its key, the key's index entry
+var deleteIncidentScript = newScript("deleteIncident", `
+redis.call("HDEL", KEYS[1], ARGV[1])
+if redis.call("HGET", KEYS[2], ARGV[2]) == ARGV[1] then
+	redis.call("HDEL", KEYS[2], ARGV[2])
+end
+return 1
+`)
+
+// deleteIncidentOp runs deleteIncidentScript
+var deleteIncidentOp = newScriptOperation("DeleteIncident", deleteIncidentScript, 0, false, incidentKeys)
+
+// setIncidentTx stores an incident and indexes it while it is open
+var setIncidentTx = newTransaction("SetIncident", 0, incidentKeys)
+
+// incidentKeys returns the keys of the incidents and of their open index
+func incidentKeys(c *Client, _ ...string) []string {
+	return []string{c.key("incidents"), c.key("incidents:open")}
+}
+
+// IncidentEvent is one entry of the incident event log
+type IncidentEvent struct {
+	Revision   string
+	Type       string
+	IncidentID string
+	Data       []byte
+}
+
+// SetIncident stores an incident, indexing it by key while it is open
+func (c *Client) SetIncident(ctx context.Context, id, key string, open bool, incident interface{}) error {
+	data, err := json.Marshal(incident)
+	if err != nil {
+		return err
+	}
+
+	keys := setIncidentTx.keys(c)
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, keys[0], id, data)
+	if open {
+		pipe.HSet(ctx, keys[1], key, id)
+	} else {
+		pipe.HDel(ctx, keys[1], key)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetIncident retrieves an incident by ID
+func (c *Client) GetIncident(ctx context.Context, id string) ([]byte, error) {
+	return c.client.HGet(ctx, c.key("incidents"), id).Bytes()
+}
+
+// GetOpenIncidentID retrieves the ID of the open incident for a key, or ""
+func (c *Client) GetOpenIncidentID(ctx context.Context, key string) (string, error) {
+	id, err := c.client.HGet(ctx, c.key("incidents:open"), key).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return id, err
+}
+
+// GetIncidents retrieves all incidents
+func (c *Client) GetIncidents(ctx context.Context) ([]string, error) {
+	return c.client.HVals(ctx, c.key("incidents")).Result()
+}
+
+// DeleteIncident removes an incident, and its open index entry if the
+// index still points at it
+func (c *Client) DeleteIncident(ctx context.Context, id, key string) error {
+	return deleteIncidentOp.run(ctx, c, nil, id, key).Err()
+}
+
+// AppendIncidentEvent records an incident event and returns its revision
+func (c *Client) AppendIncidentEvent(ctx context.Context, eventType, incidentID string, data []byte) (string, error) {
+	return c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: c.key("incidents:events"),
+		MaxLen: incidentEventsMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"type":        eventType,
+			"incident_id": incidentID,
+			"data":        data,
+		},
+	}).Result()
+}
+
+// LatestIncidentRevision returns the revision of the newest incident event, or "0-0"
+func (c *Client) LatestIncidentRevision(ctx context.Context) (string, error) {
+	return c.latestRevision(ctx, c.key("incidents:events"))
+}
+
+// IncidentRevisionAvailable reports whether every event after revision is still in the log
+func (c *Client) IncidentRevisionAvailable(ctx context.Context, revision string) (bool, error) {
+	return c.revisionAvailable(ctx, c.key("incidents:events"), revision)
+}
+
+// ReadIncidentEvents returns incident events after revision, blocking up to block for new ones
+func (c *Client) ReadIncidentEvents(ctx context.Context, revision string, block time.Duration) ([]IncidentEvent, error) {
+	msgs, err := c.readStream(ctx, c.key("incidents:events"), revision, block)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]IncidentEvent, 0, len(msgs))
+	for _, msg := range msgs {
+		event := IncidentEvent{Revision: msg.ID}
+		event.Type, _ = msg.Values["type"].(string)
+		event.IncidentID, _ = msg.Values["incident_id"].(string)
+		if data, ok := msg.Values["data"].(string); ok {
+			event.Data = []byte(data)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,197 @@
+package redis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// indexSeparator joins the agent ID and request ID of an indexed result, and
+// a string value to them, into index members
+const indexSeparator = "\x00"
+
+// setStringIndexScript replaces the value a result is indexed under in a
+// string column, or removes it from the column if no value is given
+var setStringIndexScript = newScript("setStringIndex", `
+local old = redis.call("HGET", KEYS[2], ARGV[1])
+if old then
+	redis.call("ZREM", KEYS[1], old .. ARGV[2] .. ARGV[1])
+end
+if ARGV[3] then
+	redis.call("ZADD", KEYS[1], 0, ARGV[3] .. ARGV[2] .. ARGV[1])
+	redis.call("HSET", KEYS[2], ARGV[1], ARGV[3])
+else
+	redis.call("HDEL", KEYS[2], ARGV[1])
+end
+return 1
+`)
+
+// indexStringOp runs setStringIndexScript on the index of a string column,
+// for a module name and column
+var indexStringOp = newScriptOperation("IndexString", setStringIndexScript, 2, false, func(c *Client, ids ...string) []string {
+	return []string{c.indexKey(ids[0], ids[1]), c.indexValuesKey(ids[0], ids[1])}
+})
+
+// deleteExtractionRuleTx deletes an extraction rule and its index, for a
+// module name and column
+var deleteExtractionRuleTx = newTransaction("DeleteExtractionRule", 2, func(c *Client, ids ...string) []string {
+	return append([]string{c.key("extraction_rules")}, indexStringOp.keys(c, ids...)...)
+})
+
+// ResultRef identifies an indexed result
+type ResultRef struct {
+	AgentID   string
+	RequestID string
+}
+
+func (r ResultRef) member() string {
+	return r.AgentID + indexSeparator + r.RequestID
+}
+
+// parseResultRef parses the trailing agent and request IDs of an index member
+func parseResultRef(member string) (ResultRef, bool) {
+	parts := strings.Split(member, indexSeparator)
+	if len(parts) < 2 {
+		return ResultRef{}, false
+	}
+	return ResultRef{AgentID: parts[len(parts)-2], RequestID: parts[len(parts)-1]}, true
+}
+
+func (c *Client) indexKey(moduleName, column string) string {
+	return c.key("index:%s:%s", moduleName, column)
+}
+
+func (c *Client) indexValuesKey(moduleName, column string) string {
+	return c.key("index:%s:%s:values", moduleName, column)
+}
+
+// SetExtractionRule stores an extraction rule definition in Redis
+func (c *Client) SetExtractionRule(ctx context.Context, moduleName, column string, rule []byte) error {
+	return c.client.HSet(ctx, c.key("extraction_rules"), moduleName+viewRowSeparator+column, rule).Err()
+}
+
+// GetExtractionRules retrieves all extraction rule definitions from Redis
+func (c *Client) GetExtractionRules(ctx context.Context) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("extraction_rules")).Result()
+}
+
+// DeleteExtractionRule removes an extraction rule definition and its index from Redis
+func (c *Client) DeleteExtractionRule(ctx context.Context, moduleName, column string) error {
+	keys := deleteExtractionRuleTx.keys(c, moduleName, column)
+	pipe := c.client.TxPipeline()
+	pipe.HDel(ctx, keys[0], moduleName+viewRowSeparator+column)
+	pipe.Del(ctx, keys[1:]...)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// IndexNumber indexes a result under a value of a number column
+func (c *Client) IndexNumber(ctx context.Context, moduleName, column string, ref ResultRef, value float64) error {
+	return c.client.ZAdd(ctx, c.indexKey(moduleName, column), &redis.Z{
+		Score:  value,
+		Member: ref.member(),
+	}).Err()
+}
+
+// UnindexNumber removes a result from a number column
+func (c *Client) UnindexNumber(ctx context.Context, moduleName, column string, ref ResultRef) error {
+	return c.client.ZRem(ctx, c.indexKey(moduleName, column), ref.member()).Err()
+}
+
+// IndexString indexes a result under a value of a string column, replacing
+// the value it was indexed under before
+func (c *Client) IndexString(ctx context.Context, moduleName, column string, ref ResultRef, value string) error {
+	return indexStringOp.run(ctx, c, []string{moduleName, column}, ref.member(), indexSeparator, value).Err()
+}
+
+// UnindexString removes a result from a string column
+func (c *Client) UnindexString(ctx context.Context, moduleName, column string, ref ResultRef) error {
+	return indexStringOp.run(ctx, c, []string{moduleName, column}, ref.member(), indexSeparator).Err()
+}
+
+// ScanNumberIndex returns up to count results of a number column with a
+// value between min and max, in value order, skipping the first offset.
+// Bounds use the ZRANGEBYSCORE syntax, e.g. "(100" or "+inf".
+func (c *Client) ScanNumberIndex(ctx context.Context, moduleName, column, min, max string, offset, count int64) ([]ResultRef, error) {
+	members, err := c.client.ZRangeByScore(ctx, c.indexKey(moduleName, column), &redis.ZRangeBy{
+		Min:    min,
+		Max:    max,
+		Offset: offset,
+		Count:  count,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseResultRefs(members), nil
+}
+
+// ScanStringIndex returns up to count results indexed under a value of a
+// string column, skipping the first offset
+func (c *Client) ScanStringIndex(ctx context.Context, moduleName, column, value string, offset, count int64) ([]ResultRef, error) {
+	members, err := c.client.ZRangeByLex(ctx, c.indexKey(moduleName, column), &redis.ZRangeBy{
+		Min:    "[" + value + indexSeparator,
+		Max:    "(" + value + "\x01",
+		Offset: offset,
+		Count:  count,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseResultRefs(members), nil
+}
+
+// GetIndexedNumbers returns the values refs are indexed under in a number
+// column; refs not in the column are left out
+func (c *Client) GetIndexedNumbers(ctx context.Context, moduleName, column string, refs []ResultRef) (map[ResultRef]float64, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.FloatCmd, len(refs))
+	for i, ref := range refs {
+		cmds[i] = pipe.ZScore(ctx, c.indexKey(moduleName, column), ref.member())
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	values := make(map[ResultRef]float64, len(refs))
+	for i, cmd := range cmds {
+		if value, err := cmd.Result(); err == nil {
+			values[refs[i]] = value
+		}
+	}
+	return values, nil
+}
+
+// GetIndexedStrings returns the values refs are indexed under in a string
+// column; refs not in the column are left out
+func (c *Client) GetIndexedStrings(ctx context.Context, moduleName, column string, refs []ResultRef) (map[ResultRef]string, error) {
+	if len(refs) == 0 {
+		return map[ResultRef]string{}, nil
+	}
+	members := make([]string, len(refs))
+	for i, ref := range refs {
+		members[i] = ref.member()
+	}
+	found, err := c.client.HMGet(ctx, c.indexValuesKey(moduleName, column), members...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[ResultRef]string, len(refs))
+	for i, value := range found {
+		if str, ok := value.(string); ok {
+			values[refs[i]] = str
+		}
+	}
+	return values, nil
+}
+
+func parseResultRefs(members []string) []ResultRef {
+	refs := make([]ResultRef, 0, len(members))
+	for _, member := range members {
+		if ref, ok := parseResultRef(member); ok {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// SetMaintenanceWindow stores a maintenance window in Redis
+func (c *Client) SetMaintenanceWindow(ctx context.Context, id string, window []byte) error {
+	return c.client.HSet(ctx, c.key("maintenance_windows"), id, window).Err()
+}
+
+// GetMaintenanceWindow retrieves a maintenance window from Redis, or nil if
+// there is none
+func (c *Client) GetMaintenanceWindow(ctx context.Context, id string) ([]byte, error) {
+	data, err := c.client.HGet(ctx, c.key("maintenance_windows"), id).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return data, err
+}
+
+// GetMaintenanceWindows retrieves all maintenance windows from Redis
+func (c *Client) GetMaintenanceWindows(ctx context.Context) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("maintenance_windows")).Result()
+}
+
+// DeleteMaintenanceWindow removes a maintenance window from Redis,
+// reporting whether it existed
+func (c *Client) DeleteMaintenanceWindow(ctx context.Context, id string) (bool, error) {
+	deleted, err := c.client.HDel(ctx, c.key("maintenance_windows"), id).Result()
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
@@ -0,0 +1,15 @@
+package redis
+
+import "context"
+
+// MigrationDone reports whether a one-off migration of the data earlier
+// versions stored has completed, as recorded in the set migrations
+func (c *Client) MigrationDone(ctx context.Context, name string) (bool, error) {
+	return c.client.SIsMember(ctx, c.key("migrations"), name).Result()
+}
+
+// SetMigrationDone records that a one-off migration has completed, so it
+// is not run again
+func (c *Client) SetMigrationDone(ctx context.Context, name string) error {
+	return c.client.SAdd(ctx, c.key("migrations"), name).Err()
+}
@@ -0,0 +1,84 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// moduleStateBuffer is how many notifications a slow subscriber may fall
+// behind before its subscription fails
+const moduleStateBuffer = 256
+
+// ModuleStateNotifications delivers the module states written for one
+// agent, or for all agents
+type ModuleStateNotifications struct {
+	pubsub *redis.PubSub
+	states <-chan []byte
+}
+
+// States returns the written states as JSON. The channel is closed if the
+// subscription fails or the subscriber falls behind, so that it does not
+// silently miss states.
+func (n *ModuleStateNotifications) States() <-chan []byte {
+	return n.states
+}
+
+// Close stops the notifications
+func (n *ModuleStateNotifications) Close() error {
+	return n.pubsub.Close()
+}
+
+// moduleStateChannel returns the channel module states written for an
+// agent are published on
+func (c *Client) moduleStateChannel(agentID string) string {
+	return c.key("module_states:notify:%s", agentID)
+}
+
+// PublishModuleState notifies subscribers that an agent's module state was
+// written
+func (c *Client) PublishModuleState(ctx context.Context, agentID string, state interface{}) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return c.client.Publish(ctx, c.moduleStateChannel(agentID), data).Err()
+}
+
+// SubscribeModuleStates subscribes to the module states written for an
+// agent, or for all agents if agentID is empty; it returns once the
+// subscription is active so no later state is missed
+func (c *Client) SubscribeModuleStates(ctx context.Context, agentID string) (*ModuleStateNotifications, error) {
+	var pubsub *redis.PubSub
+	if agentID == "" {
+		pubsub = c.client.PSubscribe(ctx, globEscaper.Replace(c.moduleStateChannel(""))+"*")
+	} else {
+		pubsub = c.client.Subscribe(ctx, c.moduleStateChannel(agentID))
+	}
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	states := make(chan []byte, moduleStateBuffer)
+	go func() {
+		defer close(states)
+		for msg := range pubsub.Channel() {
+			select {
+			case states <- []byte(msg.Payload):
+			default:
+				// Unsubscribe rather than have Redis buffer states for a
+				// subscriber that has to resynchronize anyway
+				pubsub.Close()
+				return
+			}
+		}
+	}()
+
+	return &ModuleStateNotifications{pubsub: pubsub, states: states}, nil
+}
+
+// globEscaper escapes the characters PSUBSCRIBE patterns treat specially
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
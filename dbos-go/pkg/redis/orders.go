@@ -0,0 +1,164 @@
+package redis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Agents are ordered by a field in a sorted set per field whose members are
+// the agent's sortable value of the field and its ID joined by
+// indexSeparator, all scored 0, so the set is ordered by value, then ID. A
+// hash per field holds the value each agent is indexed under, so that
+// setStringIndexScript can replace it.
+
+func (c *Client) agentOrderKey(field string) string {
+	return c.key("agents:order:%s", field)
+}
+
+func (c *Client) agentOrderValuesKey(field string) string {
+	return c.key("agents:order:%s:values", field)
+}
+
+// IndexAgentOrder indexes an agent under its sortable value of each field,
+// replacing the values it was indexed under before
+func (c *Client) IndexAgentOrder(ctx context.Context, agentID string, values map[string]string) error {
+	pipe := c.client.Pipeline()
+	c.indexAgentOrder(ctx, pipe, agentID, values)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// indexAgentOrderOp runs setStringIndexScript on the order index of a field
+var indexAgentOrderOp = newScriptOperation("IndexAgentOrder", setStringIndexScript, 1, false, func(c *Client, ids ...string) []string {
+	return []string{c.agentOrderKey(ids[0]), c.agentOrderValuesKey(ids[0])}
+})
+
+// indexAgentOrder queues indexing an agent under its order values on pipe
+func (c *Client) indexAgentOrder(ctx context.Context, pipe redis.Pipeliner, agentID string, values map[string]string) {
+	for field, value := range values {
+		indexAgentOrderOp.eval(ctx, pipe, c, []string{field}, agentID, indexSeparator, value)
+	}
+}
+
+// UnindexAgentOrder removes an agent from the order indexes of fields
+func (c *Client) UnindexAgentOrder(ctx context.Context, agentID string, fields []string) error {
+	pipe := c.client.Pipeline()
+	for _, field := range fields {
+		indexAgentOrderOp.eval(ctx, pipe, c, []string{field}, agentID, indexSeparator)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RangeAgentOrder returns the index members of up to count agents in the
+// order of a field, descending if desc, following the member after (from
+// the start if empty). A count of 0 returns all of them.
+func (c *Client) RangeAgentOrder(ctx context.Context, field, after string, desc bool, count int64) ([]string, error) {
+	key := c.agentOrderKey(field)
+	if desc {
+		max := "+"
+		if after != "" {
+			max = "(" + after
+		}
+		return c.client.ZRevRangeByLex(ctx, key, &redis.ZRangeBy{Min: "-", Max: max, Count: count}).Result()
+	}
+	min := "-"
+	if after != "" {
+		min = "(" + after
+	}
+	return c.client.ZRangeByLex(ctx, key, &redis.ZRangeBy{Min: min, Max: "+", Count: count}).Result()
+}
+
+// AgentOrderID returns the agent ID of an agent order index member
+func AgentOrderID(member string) string {
+	return member[strings.LastIndex(member, indexSeparator)+1:]
+}
+
+// GetAgents retrieves agents by ID, skipping ones that do not exist
+func (c *Client) GetAgents(ctx context.Context, agentIDs []string) ([][]byte, error) {
+	if len(agentIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(agentIDs))
+	for i, agentID := range agentIDs {
+		keys[i] = c.key("agent:%s", agentID)
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	agents := make([][]byte, 0, len(values))
+	for _, value := range values {
+		if data, ok := value.(string); ok {
+			agents = append(agents, []byte(data))
+		}
+	}
+	return agents, nil
+}
+
+// IndexedValue is a stored value with its key and its score in the sorted
+// set indexing it
+type IndexedValue struct {
+	Key   string
+	Score int64
+	Data  []byte
+}
+
+// getByScore retrieves up to count values of the keys in a sorted set with a
+// score in [min, max], skipping the first offset, in score order or
+// reversed if desc. min and max take the ZRANGEBYSCORE forms, e.g. "-inf"
+// or "(1700000000". It also returns the number of index entries read, which
+// exceeds the values returned when keys were removed.
+func (c *Client) getByScore(ctx context.Context, setKey, min, max string, desc bool, offset, count int64) ([]IndexedValue, int, error) {
+	by := &redis.ZRangeBy{
+		Min:    min,
+		Max:    max,
+		Offset: offset,
+		Count:  count,
+	}
+	var entries []redis.Z
+	var err error
+	if desc {
+		entries, err = c.client.ZRevRangeByScoreWithScores(ctx, setKey, by).Result()
+	} else {
+		entries, err = c.client.ZRangeByScoreWithScores(ctx, setKey, by).Result()
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return c.indexedValues(ctx, entries)
+}
+
+// indexedValues retrieves the values of the keys of sorted set entries,
+// skipping removed keys, and the number of entries
+func (c *Client) indexedValues(ctx context.Context, entries []redis.Z) ([]IndexedValue, int, error) {
+	if len(entries) == 0 {
+		return nil, 0, nil
+	}
+
+	keys := make([]string, len(entries))
+	for i, entry := range entries {
+		keys[i] = entry.Member.(string)
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	indexed := make([]IndexedValue, 0, len(values))
+	for i, value := range values {
+		if data, ok := value.(string); ok {
+			indexed = append(indexed, IndexedValue{
+				Key:   keys[i],
+				Score: int64(entries[i].Score),
+				Data:  []byte(data),
+			})
+		}
+	}
+
+	return indexed, len(entries), nil
+}
@@ -0,0 +1,156 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Messages waiting to be published to a message bus are entries of the
+// "outbox" stream, read by one consumer group so that servers sharing a
+// Redis each publish a share of them. An entry stays in the group's pending
+// entries list until it is published, then is acknowledged and deleted;
+// entries a consumer failed to publish, or held when its server stopped,
+// are claimed again once idle.
+
+// OutboxGroup is the consumer group publishers read the outbox in
+const OutboxGroup = "publishers"
+
+// OutboxEntry is a message waiting in the outbox
+type OutboxEntry struct {
+	ID   string
+	Kind string
+	Key  string
+	// Tag fills in the topic of the message, such as a result's module
+	Tag  string
+	Data []byte
+}
+
+// AppendOutbox appends entries to the outbox, trimming it to about maxLen
+// entries, oldest first
+func (c *Client) AppendOutbox(ctx context.Context, entries []OutboxEntry, maxLen int64) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	pipe := c.client.Pipeline()
+	for _, entry := range entries {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream:       c.key("outbox"),
+			MaxLenApprox: maxLen,
+			Values: map[string]interface{}{
+				"kind": entry.Kind,
+				"key":  entry.Key,
+				"tag":  entry.Tag,
+				"data": entry.Data,
+			},
+		})
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// CreateOutboxGroup creates the outbox and its consumer group, reading from
+// the start, unless they exist
+func (c *Client) CreateOutboxGroup(ctx context.Context) error {
+	err := c.client.XGroupCreateMkStream(ctx, c.key("outbox"), OutboxGroup, "0").Err()
+	if err != nil && strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil
+	}
+	return err
+}
+
+// ReadOutbox reads up to count entries for a consumer: those it holds but
+// did not acknowledge if pending is set, otherwise new ones, waiting up to
+// block for some to arrive
+func (c *Client) ReadOutbox(ctx context.Context, consumer string, pending bool, count int64, block time.Duration) ([]OutboxEntry, error) {
+	id := ">"
+	if pending {
+		id, block = "0", -1
+	}
+	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    OutboxGroup,
+		Consumer: consumer,
+		Streams:  []string{c.key("outbox"), id},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []OutboxEntry
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			entries = append(entries, outboxEntry(msg))
+		}
+	}
+	return entries, nil
+}
+
+// ClaimOutbox claims up to count entries other consumers have held for at
+// least minIdle for a consumer, which reads them as its pending entries.
+// XAUTOCLAIM would do this in one command, but its reply changed in Redis 7.
+func (c *Client) ClaimOutbox(ctx context.Context, consumer string, minIdle time.Duration, count int64) error {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: c.key("outbox"),
+		Group:  OutboxGroup,
+		Idle:   minIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  count,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, entry := range pending {
+		if entry.Consumer != consumer {
+			ids = append(ids, entry.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return c.client.XClaimJustID(ctx, &redis.XClaimArgs{
+		Stream:   c.key("outbox"),
+		Group:    OutboxGroup,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Err()
+}
+
+// AckOutbox acknowledges and deletes published entries
+func (c *Client) AckOutbox(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	pipe := c.client.Pipeline()
+	pipe.XAck(ctx, c.key("outbox"), OutboxGroup, ids...)
+	pipe.XDel(ctx, c.key("outbox"), ids...)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// OutboxLength returns how many entries wait in the outbox
+func (c *Client) OutboxLength(ctx context.Context) (int64, error) {
+	return c.client.XLen(ctx, c.key("outbox")).Result()
+}
+
+// outboxEntry decodes an outbox stream entry
+func outboxEntry(msg redis.XMessage) OutboxEntry {
+	entry := OutboxEntry{ID: msg.ID}
+	entry.Kind, _ = msg.Values["kind"].(string)
+	entry.Key, _ = msg.Values["key"].(string)
+	entry.Tag, _ = msg.Values["tag"].(string)
+	if data, ok := msg.Values["data"].(string); ok {
+		entry.Data = []byte(data)
+	}
+	return entry
+}
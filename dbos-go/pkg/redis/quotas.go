@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// IncrQuotaUsage adds counts to the usage of a quota in the window
+// starting at windowStart, by principal field
+func (c *Client) IncrQuotaUsage(ctx context.Context, quota string, windowStart time.Time, counts map[string]int64, retention time.Duration) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	key := c.key("quota:%s:%d", quota, windowStart.Unix())
+	pipe := c.client.TxPipeline()
+	for field, n := range counts {
+		pipe.HIncrBy(ctx, key, field, n)
+	}
+	pipe.Expire(ctx, key, retention)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetQuotaUsage retrieves the usage of a quota in the window starting at
+// windowStart, by principal field
+func (c *Client) GetQuotaUsage(ctx context.Context, quota string, windowStart time.Time) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("quota:%s:%d", quota, windowStart.Unix())).Result()
+}
+
+// MarkQuotaNotified records that a principal was notified of reaching a
+// level of a quota in the window starting at windowStart, reporting
+// whether it had not been before
+func (c *Client) MarkQuotaNotified(ctx context.Context, quota string, windowStart time.Time, field string, retention time.Duration) (bool, error) {
+	key := c.key("quota:%s:%d:notified", quota, windowStart.Unix())
+	pipe := c.client.TxPipeline()
+	set := pipe.HSetNX(ctx, key, field, 1)
+	pipe.Expire(ctx, key, retention)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return set.Val(), nil
+}
@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Requests a relay buffers while its upstream is unreachable are kept in the
+// "relay:buffer" list, oldest first.
+
+// bufferRelayRequestScript appends ARGV[1] to the buffer unless it already
+// holds ARGV[2] requests, returning whether it was appended
+var bufferRelayRequestScript = newScript("bufferRelayRequest", `
+if redis.call("LLEN", KEYS[1]) >= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("RPUSH", KEYS[1], ARGV[1])
+return 1
+`)
+
+// bufferRelayRequestOp runs bufferRelayRequestScript
+var bufferRelayRequestOp = newScriptOperation("BufferRelayRequest", bufferRelayRequestScript, 0, false, func(c *Client, _ ...string) []string {
+	return []string{c.key("relay:buffer")}
+})
+
+// BufferRelayRequest appends a request to the relay buffer, reporting false
+// if the buffer already holds limit requests
+func (c *Client) BufferRelayRequest(ctx context.Context, request []byte, limit int64) (bool, error) {
+	added, err := bufferRelayRequestOp.run(ctx, c, nil, request, limit).Int64()
+	if err != nil {
+		return false, err
+	}
+	return added == 1, nil
+}
+
+// PeekRelayRequest retrieves the oldest buffered request, or nil if the
+// buffer is empty
+func (c *Client) PeekRelayRequest(ctx context.Context) ([]byte, error) {
+	data, err := c.client.LIndex(ctx, c.key("relay:buffer"), 0).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return data, err
+}
+
+// PopRelayRequest removes the oldest buffered request
+func (c *Client) PopRelayRequest(ctx context.Context) error {
+	err := c.client.LPop(ctx, c.key("relay:buffer")).Err()
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
+// GetRelayBufferLength returns how many requests are buffered
+func (c *Client) GetRelayBufferLength(ctx context.Context) (int64, error) {
+	return c.client.LLen(ctx, c.key("relay:buffer")).Result()
+}
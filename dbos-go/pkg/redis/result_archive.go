@@ -0,0 +1,28 @@
+package redis
+
+import "context"
+
+// archivedResultsKey returns the key of the hash mapping the request IDs of
+// an agent's archived results to the objects holding them
+func (c *Client) archivedResultsKey(agentID string) string {
+	return c.key("results:archived:%s", agentID)
+}
+
+// SetArchivedResults records that results of an agent are held by an
+// archived object
+func (c *Client) SetArchivedResults(ctx context.Context, agentID, object string, requestIDs []string) error {
+	if len(requestIDs) == 0 {
+		return nil
+	}
+	values := make([]interface{}, 0, 2*len(requestIDs))
+	for _, requestID := range requestIDs {
+		values = append(values, requestID, object)
+	}
+	return c.client.HSet(ctx, c.archivedResultsKey(agentID), values...).Err()
+}
+
+// GetArchivedResult retrieves the archived object holding a result of an
+// agent, returning redis.Nil if it was not archived
+func (c *Client) GetArchivedResult(ctx context.Context, agentID, requestID string) (string, error) {
+	return c.client.HGet(ctx, c.archivedResultsKey(agentID), requestID).Result()
+}
@@ -0,0 +1,123 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetResults retrieves results by reference in one round trip, with nil for
+// results that do not exist
+func (c *Client) GetResults(ctx context.Context, refs []ResultRef) ([][]byte, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(refs))
+	for i, ref := range refs {
+		keys[i] = c.key("result:%s:%s", ref.AgentID, ref.RequestID)
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, len(values))
+	for i, value := range values {
+		if data, ok := value.(string); ok {
+			results[i] = []byte(data)
+		}
+	}
+	return results, nil
+}
+
+// NextResultSequences assigns the next sequence number for each of a list
+// of agents' results in one round trip; an agent listed twice is assigned
+// two consecutive numbers
+func (c *Client) NextResultSequences(ctx context.Context, agentIDs []string) ([]int64, error) {
+	if len(agentIDs) == 0 {
+		return nil, nil
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.IntCmd, len(agentIDs))
+	for i, agentID := range agentIDs {
+		cmds[i] = pipe.Incr(ctx, c.key("results:seq:%s", agentID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	seqs := make([]int64, len(cmds))
+	for i, cmd := range cmds {
+		seqs[i] = cmd.Val()
+	}
+	return seqs, nil
+}
+
+// ResultWrite is a result stored by StoreResults
+type ResultWrite struct {
+	AgentID   string
+	RequestID string
+	Timestamp time.Time
+	Sequence  int64
+	// CorrelationID indexes the result under its experiment, if not empty
+	CorrelationID string
+	// Previous is the measurement time of the stored result a re-delivery
+	// overwrites, whose day's time index it leaves if it moved to another
+	Previous time.Time
+	Result   interface{}
+}
+
+// StoreResults stores results in one round trip, indexing each by
+// measurement time, sequence number and correlation ID as StoreResult,
+// IndexResultSequence and CorrelateResult do. Re-deliveries measured on
+// another day than before take another round trip per day they left.
+func (c *Client) StoreResults(ctx context.Context, writes []ResultWrite) error {
+	if len(writes) == 0 {
+		return nil
+	}
+
+	// left holds the days of each agent re-deliveries moved out of
+	left := make(map[string][]string)
+	pipe := c.client.Pipeline()
+	for _, write := range writes {
+		key := c.key("result:%s:%s", write.AgentID, write.RequestID)
+		data, err := json.Marshal(write.Result)
+		if err != nil {
+			return err
+		}
+		timestamp := write.Timestamp
+		if timestamp.IsZero() {
+			timestamp = time.Now()
+		}
+		if day := resultDay(write.Previous); !write.Previous.IsZero() && day != resultDay(timestamp) {
+			pipe.ZRem(ctx, c.resultDayKey(write.AgentID, day), key)
+			left[write.AgentID] = append(left[write.AgentID], day)
+		}
+		c.indexResultTime(ctx, pipe, write.AgentID, key, timestamp)
+		pipe.Set(ctx, key, data, 0)
+		pipe.ZAdd(ctx, c.key("results:byseq:%s", write.AgentID), &redis.Z{
+			Score:  float64(write.Sequence),
+			Member: key,
+		})
+		if write.CorrelationID != "" {
+			pipe.ZAdd(ctx, c.correlationKey(write.CorrelationID), &redis.Z{
+				Score:  float64(timestamp.Unix()),
+				Member: key,
+			})
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	for agentID, days := range left {
+		if err := c.pruneResultDays(ctx, agentID, days); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package redis
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// resultDayLayout names the day of an agent's time index shard
+	resultDayLayout = "20060102"
+
+	// migrateResultBatch is the number of entries moved at once from an
+	// agent's unsharded time index
+	migrateResultBatch = 1000
+)
+
+// An agent's results are indexed by measurement time in one sorted set per
+// UTC day, results:<agent id>:<yyyymmdd>, so no set grows with all of a
+// very active agent's results. The sorted set results:days:<agent id> lists
+// the days holding results, scored by the start of the day, which queries
+// merge in order.
+
+// resultDay returns the UTC day a measurement time is indexed under
+func resultDay(timestamp time.Time) string {
+	return timestamp.UTC().Format(resultDayLayout)
+}
+
+// resultDayKey returns the key of an agent's time index shard of a day
+func (c *Client) resultDayKey(agentID, day string) string {
+	return c.key("results:%s:%s", agentID, day)
+}
+
+// resultDaysKey returns the key of the set listing the days an agent has
+// results indexed under
+func (c *Client) resultDaysKey(agentID string) string {
+	return c.key("results:days:%s", agentID)
+}
+
+// indexResultTime adds a result key to its agent's time index on pipe,
+// with its day before the day's listing, so that a pruned day is listed
+// again
+func (c *Client) indexResultTime(ctx context.Context, pipe redis.Pipeliner, agentID, key string, timestamp time.Time) {
+	day := resultDay(timestamp)
+	pipe.ZAdd(ctx, c.resultDayKey(agentID, day), &redis.Z{
+		Score:  float64(timestamp.Unix()),
+		Member: key,
+	})
+	dayStart := timestamp.UTC().Truncate(24 * time.Hour)
+	pipe.ZAdd(ctx, c.resultDaysKey(agentID), &redis.Z{
+		Score:  float64(dayStart.Unix()),
+		Member: day,
+	})
+}
+
+// UnindexResultTime removes a result from the time index shard of the day
+// of a measurement time it no longer has, as when a re-delivered result
+// moved to another day
+func (c *Client) UnindexResultTime(ctx context.Context, agentID, requestID string, timestamp time.Time) error {
+	day := resultDay(timestamp)
+	if err := c.client.ZRem(ctx, c.resultDayKey(agentID, day), c.key("result:%s:%s", agentID, requestID)).Err(); err != nil {
+		return err
+	}
+	return c.pruneResultDays(ctx, agentID, []string{day})
+}
+
+// pruneResultDaysScript unlists a day of an agent's time index once its
+// shard is empty. KEYS[1] is the shard and KEYS[2] the agent's listing of
+// days; ARGV[1] is the day.
+var pruneResultDaysScript = newScript("pruneResultDays", `
+if redis.call('ZCARD', KEYS[1]) == 0 then
+	redis.call('ZREM', KEYS[2], ARGV[1])
+end
+return 0
+`)
+
+// pruneResultDaysOp runs pruneResultDaysScript for an agent ID and a day
+var pruneResultDaysOp = newScriptOperation("PruneResultDays", pruneResultDaysScript, 2, false, func(c *Client, ids ...string) []string {
+	return []string{c.resultDayKey(ids[0], ids[1]), c.resultDaysKey(ids[0])}
+})
+
+// pruneResultDays unlists, in one round trip, the days of an agent's time
+// index whose shards results were removed from and are now empty
+func (c *Client) pruneResultDays(ctx context.Context, agentID string, days []string) error {
+	if len(days) == 0 {
+		return nil
+	}
+	// Sent in full, as a pipeline cannot load a script it misses
+	pipe := c.client.Pipeline()
+	for _, day := range days {
+		pruneResultDaysOp.eval(ctx, pipe, c, []string{agentID, day}, day)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// resultDaysIn returns the days of an agent's time index that may hold
+// results with a measurement time in [min, max], which take the
+// ZRANGEBYSCORE forms, in time order or reversed if desc
+func (c *Client) resultDaysIn(ctx context.Context, agentID, min, max string, desc bool) ([]string, error) {
+	// A day is listed by its start, which may precede min
+	dayMin := "-inf"
+	if from, err := strconv.ParseFloat(strings.TrimPrefix(min, "("), 64); err == nil && !math.IsInf(from, 0) {
+		dayMin = strconv.FormatInt(int64(math.Floor(from/86400))*86400, 10)
+	}
+	by := &redis.ZRangeBy{Min: dayMin, Max: max}
+	if desc {
+		return c.client.ZRevRangeByScore(ctx, c.resultDaysKey(agentID), by).Result()
+	}
+	return c.client.ZRangeByScore(ctx, c.resultDaysKey(agentID), by).Result()
+}
+
+// GetResultsByTime retrieves up to count of an agent's results with a unix
+// measurement time in [min, max], skipping the first offset, in time order
+// or reversed if desc. min and max take the ZRANGEBYSCORE forms, e.g.
+// "-inf" or "(1700000000". The days of the range are read in turn until
+// count entries are found. It also returns the number of index entries
+// read, which exceeds the results returned when results were removed.
+func (c *Client) GetResultsByTime(ctx context.Context, agentID, min, max string, desc bool, offset, count int64) ([]IndexedValue, int, error) {
+	days, err := c.resultDaysIn(ctx, agentID, min, max, desc)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var entries []redis.Z
+	for _, day := range days {
+		if int64(len(entries)) >= count {
+			break
+		}
+		setKey := c.resultDayKey(agentID, day)
+		by := &redis.ZRangeBy{
+			Min:    min,
+			Max:    max,
+			Offset: offset,
+			Count:  count - int64(len(entries)),
+		}
+		var found []redis.Z
+		if desc {
+			found, err = c.client.ZRevRangeByScoreWithScores(ctx, setKey, by).Result()
+		} else {
+			found, err = c.client.ZRangeByScoreWithScores(ctx, setKey, by).Result()
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(found) > 0 {
+			offset = 0
+			entries = append(entries, found...)
+			continue
+		}
+		// The offset skips this day's entries altogether
+		if offset > 0 {
+			n, err := c.client.ZCount(ctx, setKey, min, max).Result()
+			if err != nil {
+				return nil, 0, err
+			}
+			offset -= n
+		}
+	}
+	return c.indexedValues(ctx, entries)
+}
+
+// CountResultsByTime counts an agent's results with a unix measurement time
+// in [min, max], which take the ZCOUNT forms, counting each day of the
+// range in one round trip
+func (c *Client) CountResultsByTime(ctx context.Context, agentID, min, max string) (int64, error) {
+	days, err := c.resultDaysIn(ctx, agentID, min, max, false)
+	if err != nil || len(days) == 0 {
+		return 0, err
+	}
+
+	pipe := c.client.Pipeline()
+	counts := make([]*redis.IntCmd, len(days))
+	for i, day := range days {
+		counts[i] = pipe.ZCount(ctx, c.resultDayKey(agentID, day), min, max)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, count := range counts {
+		total += count.Val()
+	}
+	return total, nil
+}
+
+// GetResultsByAgent retrieves all results for an agent from Redis
+func (c *Client) GetResultsByAgent(ctx context.Context, agentID string) (map[string][]byte, error) {
+	days, err := c.resultDaysIn(ctx, agentID, "-inf", "+inf", false)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]byte)
+	for _, day := range days {
+		keys, err := c.client.ZRange(ctx, c.resultDayKey(agentID, day), 0, -1).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			data, err := c.client.Get(ctx, key).Bytes()
+			if err != nil {
+				continue
+			}
+			results[key] = data
+		}
+	}
+	return results, nil
+}
+
+// MigrateResultIndex moves the entries of the single time index earlier
+// versions kept per agent, results:<agent id>, into the agent's day
+// shards, returning how many were moved
+func (c *Client) MigrateResultIndex(ctx context.Context, agentID string) (int64, error) {
+	legacyKey := c.key("results:%s", agentID)
+	var moved int64
+	for {
+		entries, err := c.client.ZRangeWithScores(ctx, legacyKey, 0, migrateResultBatch-1).Result()
+		if err != nil || len(entries) == 0 {
+			return moved, err
+		}
+
+		pipe := c.client.Pipeline()
+		members := make([]interface{}, len(entries))
+		for i, entry := range entries {
+			c.indexResultTime(ctx, pipe, agentID, entry.Member.(string), time.Unix(int64(entry.Score), 0))
+			members[i] = entry.Member
+		}
+		pipe.ZRem(ctx, legacyKey, members...)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return moved, err
+		}
+		moved += int64(len(entries))
+	}
+}
@@ -0,0 +1,49 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// DeleteResults deletes results in one round trip, removing them from their
+// agents' sequence indexes, from the time index shards of the days of their
+// measurement times at the same position of timestamps, and from the index
+// of the correlation ID at the same position of correlationIDs, if not
+// empty. Each day left empty is then unlisted in another round trip.
+func (c *Client) DeleteResults(ctx context.Context, refs []ResultRef, timestamps []time.Time, correlationIDs []string) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	// days holds the days of each agent results were removed from
+	days := make(map[string]map[string]bool)
+	pipe := c.client.Pipeline()
+	for i, ref := range refs {
+		key := c.key("result:%s:%s", ref.AgentID, ref.RequestID)
+		day := resultDay(timestamps[i])
+		pipe.Del(ctx, key)
+		pipe.ZRem(ctx, c.resultDayKey(ref.AgentID, day), key)
+		pipe.ZRem(ctx, c.key("results:byseq:%s", ref.AgentID), key)
+		if correlationIDs[i] != "" {
+			pipe.ZRem(ctx, c.correlationKey(correlationIDs[i]), key)
+		}
+		if days[ref.AgentID] == nil {
+			days[ref.AgentID] = make(map[string]bool)
+		}
+		days[ref.AgentID][day] = true
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	for agentID, agentDays := range days {
+		list := make([]string, 0, len(agentDays))
+		for day := range agentDays {
+			list = append(list, day)
+		}
+		if err := c.pruneResultDays(ctx, agentID, list); err != nil {
+			return err
+		}
+	}
+	return nil
+}
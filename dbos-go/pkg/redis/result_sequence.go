@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// NextResultSequence assigns the next sequence number for an agent's results
+func (c *Client) NextResultSequence(ctx context.Context, agentID string) (int64, error) {
+	key := c.key("results:seq:%s", agentID)
+	return c.client.Incr(ctx, key).Result()
+}
+
+// LastResultSequence returns the last sequence number assigned to an agent's results
+func (c *Client) LastResultSequence(ctx context.Context, agentID string) (int64, error) {
+	key := c.key("results:seq:%s", agentID)
+	seq, err := c.client.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return seq, err
+}
+
+// IndexResultSequence records which result holds a sequence number
+func (c *Client) IndexResultSequence(ctx context.Context, agentID, requestID string, seq int64) error {
+	setKey := c.key("results:byseq:%s", agentID)
+	return c.client.ZAdd(ctx, setKey, &redis.Z{
+		Score:  float64(seq),
+		Member: c.key("result:%s:%s", agentID, requestID),
+	}).Err()
+}
+
+// GetResultSequences returns the stored sequence numbers of an agent's results in [from, to], ascending
+func (c *Client) GetResultSequences(ctx context.Context, agentID string, from, to int64) ([]int64, error) {
+	setKey := c.key("results:byseq:%s", agentID)
+	entries, err := c.client.ZRangeByScoreWithScores(ctx, setKey, &redis.ZRangeBy{
+		Min: fmt.Sprintf("%d", from),
+		Max: fmt.Sprintf("%d", to),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	seqs := make([]int64, len(entries))
+	for i, entry := range entries {
+		seqs[i] = int64(entry.Score)
+	}
+
+	return seqs, nil
+}
+
+// GetResultsBySequence retrieves up to count of an agent's results with a
+// sequence number in [min, max], skipping the first offset, in sequence
+// order or reversed if desc, like GetResultsByTime
+func (c *Client) GetResultsBySequence(ctx context.Context, agentID, min, max string, desc bool, offset, count int64) ([]IndexedValue, int, error) {
+	return c.getByScore(ctx, c.key("results:byseq:%s", agentID), min, max, desc, offset, count)
+}
+
+// GetResultsAfterSequence returns up to count of an agent's results with a
+// sequence number above after, in sequence order
+func (c *Client) GetResultsAfterSequence(ctx context.Context, agentID string, after, count int64) ([][]byte, error) {
+	setKey := c.key("results:byseq:%s", agentID)
+	keys, err := c.client.ZRangeByScore(ctx, setKey, &redis.ZRangeBy{
+		Min:   fmt.Sprintf("(%d", after),
+		Max:   "+inf",
+		Count: count,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, 0, len(values))
+	for _, value := range values {
+		if data, ok := value.(string); ok {
+			results = append(results, []byte(data))
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,96 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// routingEventsMaxLen bounds the routing event log
+const routingEventsMaxLen = 10000
+
+// RoutingEventEntry is one entry of the routing event log
+type RoutingEventEntry struct {
+	ID   string
+	Data []byte
+}
+
+// AppendRoutingEvent records a routing event and returns its ID
+func (c *Client) AppendRoutingEvent(ctx context.Context, data []byte) (string, error) {
+	return c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: c.key("routing:events"),
+		MaxLen: routingEventsMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"data": data,
+		},
+	}).Result()
+}
+
+// GetRoutingEvents retrieves the routing events recorded since a time, oldest first
+func (c *Client) GetRoutingEvents(ctx context.Context, since time.Time) ([]RoutingEventEntry, error) {
+	start := "-"
+	if !since.IsZero() {
+		start = fmt.Sprintf("%d-0", since.UnixMilli())
+	}
+	msgs, err := c.client.XRange(ctx, c.key("routing:events"), start, "+").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]RoutingEventEntry, 0, len(msgs))
+	for _, msg := range msgs {
+		data, _ := msg.Values["data"].(string)
+		entries = append(entries, RoutingEventEntry{ID: msg.ID, Data: []byte(data)})
+	}
+	return entries, nil
+}
+
+// AddProbeAnomaly records a probe anomaly for a watched prefix, dropping
+// anomalies older than retention
+func (c *Client) AddProbeAnomaly(ctx context.Context, prefix string, at time.Time, anomaly interface{}, retention time.Duration) error {
+	data, err := json.Marshal(anomaly)
+	if err != nil {
+		return err
+	}
+
+	key := c.key("routing:anomalies:%s", prefix)
+	pipe := c.client.TxPipeline()
+	pipe.ZAdd(ctx, key, &redis.Z{Score: float64(at.UnixMilli()), Member: data})
+	pipe.ZRemRangeByScore(ctx, key, "-inf", fmt.Sprintf("(%d", at.Add(-retention).UnixMilli()))
+	pipe.Expire(ctx, key, retention)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetProbeAnomalies retrieves the probe anomalies of a watched prefix seen between from and to
+func (c *Client) GetProbeAnomalies(ctx context.Context, prefix string, from, to time.Time) ([]string, error) {
+	key := c.key("routing:anomalies:%s", prefix)
+	return c.client.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min: fmt.Sprintf("%d", from.UnixMilli()),
+		Max: fmt.Sprintf("%d", to.UnixMilli()),
+	}).Result()
+}
+
+// SetRTTBaseline stores the RTT baseline from an agent to an address
+func (c *Client) SetRTTBaseline(ctx context.Context, agentID, address string, baseline interface{}) error {
+	data, err := json.Marshal(baseline)
+	if err != nil {
+		return err
+	}
+
+	return c.client.HSet(ctx, c.key("routing:rtt_baselines"), agentID+"|"+address, data).Err()
+}
+
+// GetRTTBaseline retrieves the RTT baseline from an agent to an address, or
+// nil if there is none
+func (c *Client) GetRTTBaseline(ctx context.Context, agentID, address string) ([]byte, error) {
+	data, err := c.client.HGet(ctx, c.key("routing:rtt_baselines"), agentID+"|"+address).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return data, err
+}
@@ -0,0 +1,22 @@
+package redis
+
+import (
+	"context"
+)
+
+// SetSavedQuery stores a saved query definition in Redis
+func (c *Client) SetSavedQuery(ctx context.Context, name string, query []byte) error {
+	return c.client.HSet(ctx, c.key("saved_queries"), name, query).Err()
+}
+
+// GetSavedQueries retrieves all saved query definitions from Redis
+func (c *Client) GetSavedQueries(ctx context.Context) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("saved_queries")).Result()
+}
+
+// DeleteSavedQuery removes a saved query definition from Redis, reporting
+// whether it existed
+func (c *Client) DeleteSavedQuery(ctx context.Context, name string) (bool, error) {
+	n, err := c.client.HDel(ctx, c.key("saved_queries"), name).Result()
+	return n > 0, err
+}
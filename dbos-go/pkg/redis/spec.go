@@ -0,0 +1,39 @@
+package redis
+
+import "context"
+
+// The definitions ApplySpec last applied are kept per kind in the hashes
+// spec:campaigns and spec:tasks, by ID, and the drift found in them by the
+// last drift detection in the hash spec:drift, by drift key.
+
+// SetManagedDefinition stores the definition a spec applied for a campaign
+// or task, replacing the one applied before
+func (c *Client) SetManagedDefinition(ctx context.Context, kind, id string, definition []byte) error {
+	return c.client.HSet(ctx, c.key("spec:%ss", kind), id, definition).Err()
+}
+
+// GetManagedDefinitions retrieves the definitions specs applied for a kind,
+// by ID
+func (c *Client) GetManagedDefinitions(ctx context.Context, kind string) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("spec:%ss", kind)).Result()
+}
+
+// SetDrift replaces the drift found by drift detection
+func (c *Client) SetDrift(ctx context.Context, drift map[string][]byte) error {
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, c.key("spec:drift"))
+	if len(drift) > 0 {
+		values := make([]interface{}, 0, 2*len(drift))
+		for key, data := range drift {
+			values = append(values, key, data)
+		}
+		pipe.HSet(ctx, c.key("spec:drift"), values...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetDrift retrieves the drift found by drift detection, by drift key
+func (c *Client) GetDrift(ctx context.Context) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("spec:drift")).Result()
+}
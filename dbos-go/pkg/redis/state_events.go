@@ -0,0 +1,148 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// In event-sourced persistence mode every agent and task mutation is
+// appended to the "state_events" stream, and under the same ID to the
+// "state_events:{entity type}:{id}" stream holding the history of that one
+// agent or task. Neither is trimmed, as state is rebuilt by replaying the
+// log from its start.
+
+// appendStateEventScript appends ARGV[1] to the log in KEYS[1] and, under
+// the same ID, to the entity history in KEYS[2], returning the ID
+var appendStateEventScript = newScript("appendStateEvent", `
+local id = redis.call("XADD", KEYS[1], "*", "data", ARGV[1])
+redis.call("XADD", KEYS[2], id, "data", ARGV[1])
+return id
+`)
+
+// appendStateEventOp runs appendStateEventScript for an entity type and ID
+var appendStateEventOp = newScriptOperation("AppendStateEvent", appendStateEventScript, 2, false, func(c *Client, ids ...string) []string {
+	return []string{c.key("state_events"), c.entityStateEventsKey(ids[0], ids[1])}
+})
+
+// getSnapshotMarkerTx reads the last state event and the last result
+// sequences of agents, for their IDs
+var getSnapshotMarkerTx = newTransaction("GetSnapshotMarker", 1, func(c *Client, ids ...string) []string {
+	keys := []string{c.key("state_events")}
+	for _, agentID := range ids {
+		keys = append(keys, c.key("results:seq:%s", agentID))
+	}
+	return keys
+})
+
+// StateEventEntry is an entry of the state event log
+type StateEventEntry struct {
+	ID   string
+	Data []byte
+}
+
+func (c *Client) entityStateEventsKey(entityType, entityID string) string {
+	return c.key("state_events:%s:%s", entityType, entityID)
+}
+
+// AppendStateEvent appends an event to the state event log and to the
+// history of its entity in one step, returning the event's ID
+func (c *Client) AppendStateEvent(ctx context.Context, entityType, entityID string, data []byte) (string, error) {
+	return appendStateEventOp.run(ctx, c, []string{entityType, entityID}, data).Text()
+}
+
+// GetStateEvents retrieves up to count events of the state event log after
+// the one with ID after, or from the start if after is empty
+func (c *Client) GetStateEvents(ctx context.Context, after string, count int64) ([]StateEventEntry, error) {
+	return c.rangeStateEvents(ctx, c.key("state_events"), after, count)
+}
+
+// GetEntityStateEvents retrieves up to count events of an entity's history
+// after the one with ID after, or from the start if after is empty
+func (c *Client) GetEntityStateEvents(ctx context.Context, entityType, entityID, after string, count int64) ([]StateEventEntry, error) {
+	return c.rangeStateEvents(ctx, c.entityStateEventsKey(entityType, entityID), after, count)
+}
+
+// rangeStateEvents retrieves up to count entries of a state event stream
+// after the one with ID after
+func (c *Client) rangeStateEvents(ctx context.Context, stream, after string, count int64) ([]StateEventEntry, error) {
+	start := "-"
+	if after != "" {
+		ms, seq := parseStreamID(after)
+		start = fmt.Sprintf("%d-%d", ms, seq+1)
+	}
+	msgs, err := c.client.XRangeN(ctx, stream, start, "+", count).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]StateEventEntry, 0, len(msgs))
+	for _, msg := range msgs {
+		data, _ := msg.Values["data"].(string)
+		entries = append(entries, StateEventEntry{ID: msg.ID, Data: []byte(data)})
+	}
+	return entries, nil
+}
+
+// GetSnapshotMarker reads, in one transaction, the ID of the last event of
+// the state event log, empty if there is none, and the last result sequence
+// assigned to each agent
+func (c *Client) GetSnapshotMarker(ctx context.Context, agentIDs []string) (string, map[string]int64, error) {
+	keys := getSnapshotMarkerTx.keys(c, agentIDs...)
+	pipe := c.client.TxPipeline()
+	last := pipe.XRevRangeN(ctx, keys[0], "+", "-", 1)
+	seqs := make([]*redis.StringCmd, len(agentIDs))
+	for i := range agentIDs {
+		seqs[i] = pipe.Get(ctx, keys[1+i])
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return "", nil, err
+	}
+
+	eventID := ""
+	if msgs := last.Val(); len(msgs) > 0 {
+		eventID = msgs[0].ID
+	}
+	sequences := make(map[string]int64, len(agentIDs))
+	for i, agentID := range agentIDs {
+		seq, err := seqs[i].Int64()
+		if err != nil && err != redis.Nil {
+			return "", nil, err
+		}
+		sequences[agentID] = seq
+	}
+	return eventID, sequences, nil
+}
+
+// GetEntityStateEventsAt retrieves for each of the entities of a type the
+// last event of its history at or before the one with ID at, nil if there
+// is none, and whether it has any history at all
+func (c *Client) GetEntityStateEventsAt(ctx context.Context, entityType string, entityIDs []string, at string) ([]*StateEventEntry, []bool, error) {
+	pipe := c.client.Pipeline()
+	lasts := make([]*redis.XMessageSliceCmd, len(entityIDs))
+	exists := make([]*redis.IntCmd, len(entityIDs))
+	for i, entityID := range entityIDs {
+		key := c.entityStateEventsKey(entityType, entityID)
+		if at != "" {
+			lasts[i] = pipe.XRevRangeN(ctx, key, at, "-", 1)
+		}
+		exists[i] = pipe.Exists(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	entries := make([]*StateEventEntry, len(entityIDs))
+	histories := make([]bool, len(entityIDs))
+	for i := range entityIDs {
+		histories[i] = exists[i].Val() > 0
+		if lasts[i] == nil || len(lasts[i].Val()) == 0 {
+			continue
+		}
+		msg := lasts[i].Val()[0]
+		data, _ := msg.Values["data"].(string)
+		entries[i] = &StateEventEntry{ID: msg.ID, Data: []byte(data)}
+	}
+	return entries, histories, nil
+}
@@ -0,0 +1,41 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// IncrReachability counts a probe from a region in the hourly bucket
+// containing at, and whether it reached its target
+func (c *Client) IncrReachability(ctx context.Context, region string, reachable bool, at time.Time, retention time.Duration) error {
+	key := c.key("status:reachability:%d", at.Unix()/3600)
+	pipe := c.client.TxPipeline()
+	pipe.HIncrBy(ctx, key, region+"|probes", 1)
+	if reachable {
+		pipe.HIncrBy(ctx, key, region+"|reachable", 1)
+	}
+	pipe.Expire(ctx, key, retention)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetReachability retrieves the probe counts of the hourly buckets from
+// from through to, as region|probes and region|reachable fields per bucket
+func (c *Client) GetReachability(ctx context.Context, from, to time.Time) ([]map[string]string, error) {
+	pipe := c.client.Pipeline()
+	var cmds []*redis.StringStringMapCmd
+	for hour := from.Unix() / 3600; hour <= to.Unix()/3600; hour++ {
+		cmds = append(cmds, pipe.HGetAll(ctx, c.key("status:reachability:%d", hour)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	buckets := make([]map[string]string, len(cmds))
+	for i, cmd := range cmds {
+		buckets[i] = cmd.Val()
+	}
+	return buckets, nil
+}
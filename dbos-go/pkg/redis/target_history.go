@@ -0,0 +1,35 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// targetHistoryTTL is how long a target's measurement history is kept
+// after its last measurement
+const targetHistoryTTL = 30 * 24 * time.Hour
+
+// targetHistoryKey returns the key of the sorted set of agents that
+// measured a target with a module, scored by their last measurement time
+func (c *Client) targetHistoryKey(moduleName, target string) string {
+	return c.key("targets:measured:%s:%s", moduleName, target)
+}
+
+// RecordTargetMeasured records that an agent measured a target with a
+// module at a time
+func (c *Client) RecordTargetMeasured(ctx context.Context, moduleName, target, agentID string, at time.Time) error {
+	key := c.targetHistoryKey(moduleName, target)
+	pipe := c.client.Pipeline()
+	pipe.ZAdd(ctx, key, &redis.Z{Score: float64(at.Unix()), Member: agentID})
+	pipe.Expire(ctx, key, targetHistoryTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetTargetAgents returns the agents that measured a target with a module,
+// most recent first
+func (c *Client) GetTargetAgents(ctx context.Context, moduleName, target string) ([]string, error) {
+	return c.client.ZRevRange(ctx, c.targetHistoryKey(moduleName, target), 0, -1).Result()
+}
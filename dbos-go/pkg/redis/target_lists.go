@@ -0,0 +1,26 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// SetTargetList stores a target list in Redis
+func (c *Client) SetTargetList(ctx context.Context, name string, list interface{}) error {
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return c.client.HSet(ctx, c.key("target_lists"), name, data).Err()
+}
+
+// GetTargetList retrieves a target list from Redis, or nil if there is none
+func (c *Client) GetTargetList(ctx context.Context, name string) ([]byte, error) {
+	data, err := c.client.HGet(ctx, c.key("target_lists"), name).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return data, err
+}
@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetTasks retrieves tasks by ID in one round trip, with nil for tasks that
+// do not exist
+func (c *Client) GetTasks(ctx context.Context, taskIDs []string) ([][]byte, error) {
+	if len(taskIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(taskIDs))
+	for i, taskID := range taskIDs {
+		keys[i] = c.key("task:%s", taskID)
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([][]byte, len(values))
+	for i, value := range values {
+		if data, ok := value.(string); ok {
+			tasks[i] = []byte(data)
+		}
+	}
+	return tasks, nil
+}
+
+// ReleaseInflightTasks removes tasks from the in-flight set in one round
+// trip, reporting for each whether it was in flight. Only one caller sees a
+// task released, so concurrent acknowledgements of a lease cannot both win.
+func (c *Client) ReleaseInflightTasks(ctx context.Context, taskIDs []string) ([]bool, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.IntCmd, len(taskIDs))
+	for i, taskID := range taskIDs {
+		cmds[i] = pipe.ZRem(ctx, c.key("tasks:inflight"), c.key("task:%s", taskID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	released := make([]bool, len(cmds))
+	for i, cmd := range cmds {
+		released[i] = cmd.Val() > 0
+	}
+	return released, nil
+}
+
+// GetExpiredInflightTasks retrieves the IDs of the in-flight tasks leased
+// before leasedBefore
+func (c *Client) GetExpiredInflightTasks(ctx context.Context, leasedBefore time.Time) ([]string, error) {
+	keys, err := c.client.ZRangeByScore(ctx, c.key("tasks:inflight"), &redis.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("(%d", leasedBefore.Unix()),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	taskIDs := make([]string, len(keys))
+	for i, key := range keys {
+		taskIDs[i] = strings.TrimPrefix(key, c.key("task:"))
+	}
+	return taskIDs, nil
+}
+
+// TaskWrite is a task stored by SetTasks, rescheduled at ScheduleAt in the
+// queue of AgentID unless it is zero
+type TaskWrite struct {
+	ID         string
+	AgentID    string
+	Task       interface{}
+	ScheduleAt time.Time
+}
+
+// SetTasks stores tasks in one round trip, adding those with a ScheduleAt
+// back to their agent's queue
+func (c *Client) SetTasks(ctx context.Context, writes []TaskWrite) error {
+	if len(writes) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for _, write := range writes {
+		key := c.key("task:%s", write.ID)
+		data, err := json.Marshal(write.Task)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, key, data, 0)
+		if !write.ScheduleAt.IsZero() {
+			pipe.ZAdd(ctx, c.taskQueueKey(write.AgentID), &redis.Z{
+				Score:  float64(write.ScheduleAt.Unix()),
+				Member: key,
+			})
+			pipe.SAdd(ctx, c.key("tasks:queues"), write.AgentID)
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ClaimTaskDedupKey claims a deduplication key for a task being scheduled,
+// holding it for ttl. If the key is already claimed it returns the ID of
+// the task that claimed it and false.
+func (c *Client) ClaimTaskDedupKey(ctx context.Context, key, taskID string, ttl time.Duration) (string, bool, error) {
+	redisKey := c.key("tasks:dedup:%s", key)
+	for {
+		claimed, err := c.client.SetNX(ctx, redisKey, taskID, ttl).Result()
+		if err != nil || claimed {
+			return taskID, claimed, err
+		}
+		existing, err := c.client.Get(ctx, redisKey).Result()
+		if err == redis.Nil {
+			// The claim expired in between
+			continue
+		}
+		return existing, false, err
+	}
+}
+
+// releaseTaskDedupKeyScript deletes a deduplication key if the task that
+// claimed it is ARGV[1]
+var releaseTaskDedupKeyScript = newScript("releaseTaskDedupKey", `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// releaseTaskDedupKeyOp runs releaseTaskDedupKeyScript for a
+// deduplication key
+var releaseTaskDedupKeyOp = newScriptOperation("ReleaseTaskDedupKey", releaseTaskDedupKeyScript, 1, false, func(c *Client, ids ...string) []string {
+	return []string{c.key("tasks:dedup:%s", ids[0])}
+})
+
+// ReleaseTaskDedupKey releases a deduplication key a task claimed but could
+// not be scheduled under, so a retry can claim it
+func (c *Client) ReleaseTaskDedupKey(ctx context.Context, key, taskID string) error {
+	return releaseTaskDedupKeyOp.run(ctx, c, []string{key}, taskID).Err()
+}
@@ -0,0 +1,134 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Tasks are indexed for listing in sorted sets per time field, scored by
+// the task's unix time of the field: one of all tasks, tasks:all:<field>,
+// and one per agent, module and status, e.g.
+// tasks:status:pending:scheduled_at. Whenever a task is written it is added
+// to the sets of its current values and removed, in the same round trip,
+// from those of the other statuses and of the agent and module it had
+// before. Readers skip and prune what a failed write leaves behind.
+
+// Dimensions tasks are indexed by
+const (
+	TaskIndexAll    = "all"
+	TaskIndexAgent  = "agent"
+	TaskIndexModule = "module"
+	TaskIndexStatus = "status"
+)
+
+// TaskIndex names the tasks indexed together: all of them, or those of one
+// agent, module or status
+type TaskIndex struct {
+	Dimension string
+	Value     string
+}
+
+// IndexedTask is what a task is indexed under
+type IndexedTask struct {
+	ID         string
+	AgentID    string
+	ModuleName string
+	Status     string
+	// Times are the unix times of the task's fields
+	Times map[string]int64
+	// PreviousAgentID and PreviousModuleName are the agent and module the
+	// task was indexed under before, if it was and they may have changed
+	PreviousAgentID    string
+	PreviousModuleName string
+}
+
+// taskIndexKey returns the key of the sorted set of an index ordered by a
+// field
+func (c *Client) taskIndexKey(index TaskIndex, field string) string {
+	switch index.Dimension {
+	case TaskIndexAgent:
+		return c.key("tasks:agent:%s:%s", index.Value, field)
+	case TaskIndexModule:
+		return c.key("tasks:module:%s:%s", index.Value, field)
+	case TaskIndexStatus:
+		return c.key("tasks:status:%s:%s", index.Value, field)
+	default:
+		return c.key("tasks:all:%s", field)
+	}
+}
+
+// IndexTasks indexes tasks under their current values and times in one
+// round trip, removing them from the indexes of the other statuses and of
+// their previous agent and module. Group tasks, which have no agent, are
+// not indexed by agent.
+func (c *Client) IndexTasks(ctx context.Context, tasks []IndexedTask, statuses []string) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for _, task := range tasks {
+		indexes := []TaskIndex{
+			{Dimension: TaskIndexAll},
+			{Dimension: TaskIndexModule, Value: task.ModuleName},
+			{Dimension: TaskIndexStatus, Value: task.Status},
+		}
+		if task.AgentID != "" {
+			indexes = append(indexes, TaskIndex{Dimension: TaskIndexAgent, Value: task.AgentID})
+		}
+		var left []TaskIndex
+		for _, status := range statuses {
+			if status != task.Status {
+				left = append(left, TaskIndex{Dimension: TaskIndexStatus, Value: status})
+			}
+		}
+		if task.PreviousAgentID != "" && task.PreviousAgentID != task.AgentID {
+			left = append(left, TaskIndex{Dimension: TaskIndexAgent, Value: task.PreviousAgentID})
+		}
+		if task.PreviousModuleName != "" && task.PreviousModuleName != task.ModuleName {
+			left = append(left, TaskIndex{Dimension: TaskIndexModule, Value: task.PreviousModuleName})
+		}
+
+		key := c.key("task:%s", task.ID)
+		for field, t := range task.Times {
+			for _, index := range indexes {
+				pipe.ZAdd(ctx, c.taskIndexKey(index, field), &redis.Z{
+					Score:  float64(t),
+					Member: key,
+				})
+			}
+			for _, index := range left {
+				pipe.ZRem(ctx, c.taskIndexKey(index, field), key)
+			}
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// UnindexTasks removes the tasks stored at keys from an index, for each
+// field it is ordered by
+func (c *Client) UnindexTasks(ctx context.Context, index TaskIndex, fields []string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, len(keys))
+	for i, key := range keys {
+		members[i] = key
+	}
+	pipe := c.client.Pipeline()
+	for _, field := range fields {
+		pipe.ZRem(ctx, c.taskIndexKey(index, field), members...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetIndexedTasksByTime retrieves up to count of the tasks of an index with
+// a time of field in [min, max], skipping the first offset, in time order
+// or reversed if desc. It also returns the number of index entries read.
+func (c *Client) GetIndexedTasksByTime(ctx context.Context, index TaskIndex, field, min, max string, desc bool, offset, count int64) ([]IndexedValue, int, error) {
+	return c.getByScore(ctx, c.taskIndexKey(index, field), min, max, desc, offset, count)
+}
@@ -0,0 +1,86 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// renewInflightTaskScript moves a task's lease start in the in-flight set
+// and stores the task, if the task is still in flight
+var renewInflightTaskScript = newScript("renewInflightTask", `
+if not redis.call("ZSCORE", KEYS[1], ARGV[1]) then
+	return 0
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[1])
+redis.call("SET", ARGV[1], ARGV[3])
+return 1
+`)
+
+// renewInflightTaskOp runs renewInflightTaskScript
+var renewInflightTaskOp = newScriptOperation("RenewInflightTask", renewInflightTaskScript, 0, true, func(c *Client, _ ...string) []string {
+	return []string{c.key("tasks:inflight")}
+})
+
+// RenewInflightTask scores an in-flight task as leased at leasedAt, or as
+// never ending if it is zero, and stores it in one step, reporting false without storing it if its lease
+// has ended
+func (c *Client) RenewInflightTask(ctx context.Context, taskID string, leasedAt time.Time, task interface{}) (bool, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return false, err
+	}
+	n, err := renewInflightTaskOp.run(ctx, c, nil, c.key("task:%s", taskID), inflightScore(leasedAt), data).Int64()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// renewStreamTaskScript claims the entry of a task for its agent again with
+// the idle time given, if it is pending with the agent or the expired
+// consumer, and stores the task unless no task data is given
+var renewStreamTaskScript = newScript("renewStreamTask", `
+local id = redis.call("HGET", KEYS[2], ARGV[1])
+if not id then
+	return 0
+end
+local pending = redis.call("XPENDING", KEYS[1], ARGV[2], id, id, 1)
+if not pending[1] then
+	return 0
+end
+local consumer = pending[1][2]
+if consumer ~= ARGV[3] and consumer ~= ARGV[4] then
+	return 0
+end
+redis.call("XCLAIM", KEYS[1], ARGV[2], ARGV[3], 0, id, "IDLE", ARGV[5], "JUSTID")
+if ARGV[6] ~= "" then
+	redis.call("SET", ARGV[1], ARGV[6])
+end
+return 1
+`)
+
+// renewStreamTaskOp runs renewStreamTaskScript for an agent ID
+var renewStreamTaskOp = newScriptOperation("RenewStreamTask", renewStreamTaskScript, 1, true, func(c *Client, ids ...string) []string {
+	return []string{c.taskStreamKey(ids[0]), c.key("tasks:stream:entries")}
+})
+
+// RenewStreamTask gives the delivery of a task back to its agent as if
+// delivered idle ago, so that it expires once idle for the lease timeout,
+// and stores the task in the same step unless it is nil. It reports false
+// if the delivery has ended.
+func (c *Client) RenewStreamTask(ctx context.Context, agentID, taskID string, idle time.Duration, task interface{}) (bool, error) {
+	var data []byte
+	if task != nil {
+		var err error
+		if data, err = json.Marshal(task); err != nil {
+			return false, err
+		}
+	}
+	n, err := renewStreamTaskOp.run(ctx, c, []string{agentID},
+		c.key("task:%s", taskID), TaskStreamGroup, agentID, TaskStreamExpired, idle.Milliseconds(), data).Int64()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
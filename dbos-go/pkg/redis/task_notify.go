@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// TaskNotifications delivers the scheduled times of tasks newly scheduled
+// for one agent
+type TaskNotifications struct {
+	pubsub *redis.PubSub
+	times  <-chan time.Time
+}
+
+// Scheduled returns the scheduled times of newly scheduled tasks
+func (n *TaskNotifications) Scheduled() <-chan time.Time {
+	return n.times
+}
+
+// Close stops the notifications
+func (n *TaskNotifications) Close() error {
+	return n.pubsub.Close()
+}
+
+// PublishTaskScheduled notifies subscribers that a task was scheduled for an agent
+func (c *Client) PublishTaskScheduled(ctx context.Context, agentID string, scheduledAt time.Time) error {
+	channel := c.key("tasks:notify:%s", agentID)
+	return c.client.Publish(ctx, channel, scheduledAt.Unix()).Err()
+}
+
+// SubscribeTaskScheduled subscribes to the tasks scheduled for an agent; it
+// returns once the subscription is active so no later task is missed
+func (c *Client) SubscribeTaskScheduled(ctx context.Context, agentID string) (*TaskNotifications, error) {
+	channel := c.key("tasks:notify:%s", agentID)
+	pubsub := c.client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	times := make(chan time.Time, 16)
+	go func() {
+		defer close(times)
+		for msg := range pubsub.Channel() {
+			unix, err := strconv.ParseInt(msg.Payload, 10, 64)
+			if err != nil {
+				continue
+			}
+			// A slow reader re-checks due tasks anyway, so drop rather than block
+			select {
+			case times <- time.Unix(unix, 0):
+			default:
+			}
+		}
+	}()
+
+	return &TaskNotifications{pubsub: pubsub, times: times}, nil
+}
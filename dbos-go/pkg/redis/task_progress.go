@@ -0,0 +1,23 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SetTaskProgress stores the progress reported for a task in Redis
+func (c *Client) SetTaskProgress(ctx context.Context, taskID string, progress interface{}) error {
+	key := c.key("task_progress:%s", taskID)
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, key, data, 0).Err()
+}
+
+// GetTaskProgress retrieves the progress reported for a task from Redis
+func (c *Client) GetTaskProgress(ctx context.Context, taskID string) ([]byte, error) {
+	key := c.key("task_progress:%s", taskID)
+	return c.client.Get(ctx, key).Bytes()
+}
@@ -0,0 +1,115 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// migrateTaskBatch is how many tasks of the global scheduled set one step
+// of MigrateScheduledTasks moves
+const migrateTaskBatch = 1000
+
+// taskQueueKey returns the key of an agent's queue of scheduled tasks.
+// Scheduled tasks wait in a queue per agent, a sorted set of task keys by
+// the time they are due, so leasing only ever looks at the agent's own
+// tasks. The agents whose queues may hold tasks are kept in a set, for
+// listing the due tasks of all agents.
+func (c *Client) taskQueueKey(agentID string) string {
+	return c.key("tasks:queue:%s", agentID)
+}
+
+// GetTaskQueueAgents lists the agents whose queues may hold tasks
+func (c *Client) GetTaskQueueAgents(ctx context.Context) ([]string, error) {
+	return c.client.SMembers(ctx, c.key("tasks:queues")).Result()
+}
+
+// GetDueTasks retrieves the tasks due at timestamp in the queue of each
+// agent, without dequeuing them: per agent, up to limit tasks in the order
+// they are due, or all of them if limit is zero
+func (c *Client) GetDueTasks(ctx context.Context, agentIDs []string, timestamp time.Time, limit int64) ([][][]byte, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringSliceCmd, len(agentIDs))
+	for i, agentID := range agentIDs {
+		cmds[i] = pipe.ZRangeByScore(ctx, c.taskQueueKey(agentID), &redis.ZRangeBy{
+			Min:   "0",
+			Max:   fmt.Sprintf("%d", timestamp.Unix()),
+			Count: limit,
+		})
+	}
+	if len(cmds) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var keys []string
+	for _, cmd := range cmds {
+		keys = append(keys, cmd.Val()...)
+	}
+	queues := make([][][]byte, len(agentIDs))
+	if len(keys) == 0 {
+		return queues, nil
+	}
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// Members whose task was deleted are left for leases to drop
+	i := 0
+	for q, cmd := range cmds {
+		for range cmd.Val() {
+			if data, ok := values[i].(string); ok {
+				queues[q] = append(queues[q], []byte(data))
+			}
+			i++
+		}
+	}
+	return queues, nil
+}
+
+// migrateScheduledTasksScript moves up to ARGV[2] tasks from the global
+// scheduled set of earlier versions, KEYS[1], into the queues of their
+// agents, keyed by the prefix ARGV[1]. Members whose task was deleted or
+// cannot be decoded are dropped. It returns how many members it took.
+var migrateScheduledTasksScript = newScript("migrateScheduledTasks", `
+local members = redis.call("ZRANGE", KEYS[1], 0, tonumber(ARGV[2]) - 1, "WITHSCORES")
+for i = 1, #members, 2 do
+	local key, score = members[i], members[i + 1]
+	redis.call("ZREM", KEYS[1], key)
+	local data = redis.call("GET", key)
+	if data then
+		local ok, task = pcall(cjson.decode, data)
+		if ok and type(task.agent_id) == "string" then
+			redis.call("ZADD", ARGV[1] .. task.agent_id, score, key)
+			redis.call("SADD", KEYS[2], task.agent_id)
+		end
+	end
+end
+return #members / 2
+`)
+
+// migrateScheduledTasksOp runs migrateScheduledTasksScript
+var migrateScheduledTasksOp = newScriptOperation("MigrateScheduledTasks", migrateScheduledTasksScript, 0, true, func(c *Client, _ ...string) []string {
+	return []string{c.key("tasks:scheduled"), c.key("tasks:queues")}
+})
+
+// MigrateScheduledTasks moves the tasks scheduled in the global
+// tasks:scheduled set of earlier versions into their agents' queues,
+// returning how many members it took from the set
+func (c *Client) MigrateScheduledTasks(ctx context.Context) (int64, error) {
+	var moved int64
+	for {
+		n, err := migrateScheduledTasksOp.run(ctx, c, nil, c.taskQueueKey(""), migrateTaskBatch).Int64()
+		if err != nil {
+			return moved, err
+		}
+		moved += n
+		if n < migrateTaskBatch {
+			return moved, nil
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Tasks delivered through Redis Streams are entries of a stream per agent,
+// read by one consumer group. An entry stays in the group's pending entries
+// list from its delivery until the task is settled, so the list holds the
+// tasks in flight, and Redis counts how often each entry was delivered. A
+// task handed back for redelivery keeps its entry, claimed by the waiting
+// consumer until the agent leases it again.
+const (
+	// TaskStreamGroup is the consumer group agents read their streams in
+	TaskStreamGroup = "dbos"
+	// TaskStreamExpired is the consumer expired entries are claimed by
+	// until the requeuer ends their lease
+	TaskStreamExpired = "dbos:expired"
+	// TaskStreamWaiting is the consumer entries of tasks waiting to be
+	// delivered again are claimed by
+	TaskStreamWaiting = "dbos:waiting"
+)
+
+// taskStreamKey returns the key of an agent's task stream
+func (c *Client) taskStreamKey(agentID string) string {
+	return c.key("tasks:stream:%s", agentID)
+}
+
+// leaseStreamTaskScript moves the earliest due task of an agent's queue
+// into its stream and delivers it to the agent in one step. A task that
+// was delivered before is redelivered from its pending entry, counting
+// another delivery; any other task is appended to the stream and read by
+// the agent. Members whose task was deleted are dropped on the way, and an
+// emptied queue is taken off the set of queues.
+var leaseStreamTaskScript = newScript("leaseStreamTask", `
+while true do
+	local key = redis.call("ZRANGEBYSCORE", KEYS[1], "0", ARGV[1], "LIMIT", 0, 1)[1]
+	if not key then
+		if redis.call("ZCARD", KEYS[1]) == 0 then
+			redis.call("SREM", KEYS[5], ARGV[2])
+		end
+		return false
+	end
+	redis.call("ZREM", KEYS[1], key)
+	local data = redis.call("GET", key)
+	if data then
+		local id = redis.call("HGET", KEYS[3], key)
+		if id then
+			local claimed = redis.call("XCLAIM", KEYS[2], ARGV[3], ARGV[2], 0, id)
+			if not claimed[1] then
+				id = false
+			end
+		end
+		if not id then
+			redis.pcall("XGROUP", "CREATE", KEYS[2], ARGV[3], "$", "MKSTREAM")
+			redis.call("SADD", KEYS[4], KEYS[2])
+			redis.call("XADD", KEYS[2], "*", "task", key)
+			local read = redis.call("XREADGROUP", "GROUP", ARGV[3], ARGV[2], "COUNT", 1, "STREAMS", KEYS[2], ">")
+			id = read[1][2][1][1]
+			redis.call("HSET", KEYS[3], key, id)
+		end
+		local pending = redis.call("XPENDING", KEYS[2], ARGV[3], id, id, 1)
+		return {data, id, pending[1][4]}
+	end
+end
+`)
+
+// leaseStreamTaskOp runs leaseStreamTaskScript for an agent ID
+var leaseStreamTaskOp = newScriptOperation("LeaseStreamTask", leaseStreamTaskScript, 1, true, func(c *Client, ids ...string) []string {
+	return []string{c.taskQueueKey(ids[0]), c.taskStreamKey(ids[0]), c.key("tasks:stream:entries"), c.key("tasks:streams"), c.key("tasks:queues")}
+})
+
+// StreamLease is a task delivered from an agent's stream
+type StreamLease struct {
+	Data       []byte
+	EntryID    string
+	Deliveries int64
+}
+
+// LeaseStreamTask atomically dequeues an agent's earliest task due at
+// timestamp and delivers it through the agent's stream, or returns nil if
+// the agent has no due task
+func (c *Client) LeaseStreamTask(ctx context.Context, agentID string, timestamp time.Time) (*StreamLease, error) {
+	reply, err := leaseStreamTaskOp.run(ctx, c, []string{agentID}, timestamp.Unix(), agentID, TaskStreamGroup).Slice()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != 3 {
+		return nil, fmt.Errorf("unexpected lease reply %v", reply)
+	}
+	data, _ := reply[0].(string)
+	entryID, _ := reply[1].(string)
+	deliveries, _ := reply[2].(int64)
+	return &StreamLease{Data: []byte(data), EntryID: entryID, Deliveries: deliveries}, nil
+}
+
+// deliverStreamTaskScript appends a task to an agent's stream and delivers
+// it to the agent, replacing any entry the task had
+var deliverStreamTaskScript = newScript("deliverStreamTask", `
+local id = redis.call("HGET", KEYS[2], ARGV[1])
+if id then
+	redis.call("XACK", KEYS[1], ARGV[3], id)
+	redis.call("XDEL", KEYS[1], id)
+end
+redis.pcall("XGROUP", "CREATE", KEYS[1], ARGV[3], "$", "MKSTREAM")
+redis.call("SADD", KEYS[3], KEYS[1])
+redis.call("XADD", KEYS[1], "*", "task", ARGV[1])
+local read = redis.call("XREADGROUP", "GROUP", ARGV[3], ARGV[2], "COUNT", 1, "STREAMS", KEYS[1], ">")
+redis.call("HSET", KEYS[2], ARGV[1], read[1][2][1][1])
+return 1
+`)
+
+// deliverStreamTaskOp runs deliverStreamTaskScript for an agent ID
+var deliverStreamTaskOp = newScriptOperation("DeliverStreamTask", deliverStreamTaskScript, 1, false, func(c *Client, ids ...string) []string {
+	return []string{c.taskStreamKey(ids[0]), c.key("tasks:stream:entries"), c.key("tasks:streams")}
+})
+
+// DeliverStreamTask delivers a task to an agent through its stream without
+// taking it from the agent's queue
+func (c *Client) DeliverStreamTask(ctx context.Context, agentID, taskID string) error {
+	return deliverStreamTaskOp.run(ctx, c, []string{agentID}, c.key("task:%s", taskID), agentID, TaskStreamGroup).Err()
+}
+
+// releaseStreamTaskScript ends the delivery of a task if its entry is
+// pending with the expected consumer, an empty one matching any consumer
+// but optionally the waiting one. The entry is either kept pending for
+// redelivery, claimed by the waiting consumer, or acknowledged and deleted.
+var releaseStreamTaskScript = newScript("releaseStreamTask", `
+local id = redis.call("HGET", KEYS[2], ARGV[1])
+if not id then
+	return 0
+end
+local pending = redis.call("XPENDING", KEYS[1], ARGV[2], id, id, 1)
+if not pending[1] then
+	redis.call("HDEL", KEYS[2], ARGV[1])
+	return 0
+end
+local consumer = pending[1][2]
+if ARGV[3] ~= "" and consumer ~= ARGV[3] then
+	return 0
+end
+if ARGV[4] == "1" and consumer == ARGV[6] then
+	return 0
+end
+if ARGV[5] == "1" then
+	redis.call("XCLAIM", KEYS[1], ARGV[2], ARGV[6], 0, id, "JUSTID")
+else
+	redis.call("XACK", KEYS[1], ARGV[2], id)
+	redis.call("XDEL", KEYS[1], id)
+	redis.call("HDEL", KEYS[2], ARGV[1])
+end
+return 1
+`)
+
+// releaseStreamTaskOp runs releaseStreamTaskScript for an agent ID
+var releaseStreamTaskOp = newScriptOperation("ReleaseStreamTasks", releaseStreamTaskScript, 1, false, func(c *Client, ids ...string) []string {
+	return []string{c.taskStreamKey(ids[0]), c.key("tasks:stream:entries")}
+})
+
+// StreamRelease ends the delivery of a task of an agent's stream. Consumer
+// is the consumer its entry must be pending with, any if empty, in which
+// case ExceptWaiting excludes tasks already handed back. Keep keeps the
+// entry for redelivery instead of deleting it.
+type StreamRelease struct {
+	AgentID       string
+	TaskID        string
+	Consumer      string
+	ExceptWaiting bool
+	Keep          bool
+}
+
+// ReleaseStreamTasks ends the deliveries of tasks in one round trip,
+// reporting for each whether it was pending as expected. Only one caller
+// sees a delivery released, so concurrent acknowledgements cannot both win.
+func (c *Client) ReleaseStreamTasks(ctx context.Context, releases []StreamRelease) ([]bool, error) {
+	if len(releases) == 0 {
+		return nil, nil
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.Cmd, len(releases))
+	for i, release := range releases {
+		cmds[i] = releaseStreamTaskOp.eval(ctx, pipe, c, []string{release.AgentID},
+			c.key("task:%s", release.TaskID), TaskStreamGroup,
+			release.Consumer, scriptFlag(release.ExceptWaiting), scriptFlag(release.Keep), TaskStreamWaiting)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	released := make([]bool, len(cmds))
+	for i, cmd := range cmds {
+		n, _ := cmd.Int64()
+		released[i] = n > 0
+	}
+	return released, nil
+}
+
+// scriptFlag encodes a boolean script argument
+func scriptFlag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// expireStreamTasksScript claims the entries of a stream pending for at
+// least a minimum idle time for the expired consumer, without counting a
+// delivery, and returns the keys of their tasks
+var expireStreamTasksScript = newScript("expireStreamTasks", `
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	redis.call("SREM", KEYS[2], KEYS[1])
+	return {}
+end
+local keys = {}
+local cursor = "0-0"
+repeat
+	local reply = redis.call("XAUTOCLAIM", KEYS[1], ARGV[1], ARGV[2], ARGV[3], cursor, "COUNT", 100, "JUSTID")
+	cursor = reply[1]
+	for _, id in ipairs(reply[2]) do
+		local entry = redis.call("XRANGE", KEYS[1], id, id)
+		if entry[1] then
+			table.insert(keys, entry[1][2][2])
+		else
+			redis.call("XACK", KEYS[1], ARGV[1], id)
+		end
+	end
+until cursor == "0-0" or #keys >= tonumber(ARGV[4])
+return keys
+`)
+
+// expireStreamTasksOp runs expireStreamTasksScript for an agent ID
+var expireStreamTasksOp = newScriptOperation("ExpireStreamTasks", expireStreamTasksScript, 1, false, func(c *Client, ids ...string) []string {
+	return []string{c.taskStreamKey(ids[0]), c.key("tasks:streams")}
+})
+
+// maxExpiredStreamTasks caps the entries claimed per stream in one call
+const maxExpiredStreamTasks = 1000
+
+// ExpiredStreamTask is a task whose entry ExpireStreamTasks claimed
+type ExpiredStreamTask struct {
+	AgentID string
+	TaskID  string
+}
+
+// ExpireStreamTasks claims the entries of every agent's stream that have
+// been pending for at least minIdle for the expired consumer, returning
+// their tasks. Claimed entries are not claimed again until they have been
+// idle for minIdle once more, so concurrent callers see each once.
+func (c *Client) ExpireStreamTasks(ctx context.Context, minIdle time.Duration) ([]ExpiredStreamTask, error) {
+	streams, err := c.client.SMembers(ctx, c.key("tasks:streams")).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []ExpiredStreamTask
+	for _, stream := range streams {
+		agentID := strings.TrimPrefix(stream, c.taskStreamKey(""))
+		keys, err := expireStreamTasksOp.run(ctx, c, []string{agentID},
+			TaskStreamGroup, TaskStreamExpired, minIdle.Milliseconds(), maxExpiredStreamTasks).StringSlice()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			expired = append(expired, ExpiredStreamTask{
+				AgentID: agentID,
+				TaskID:  strings.TrimPrefix(key, c.key("task:")),
+			})
+		}
+	}
+	return expired, nil
+}
+
+// PendingStreamTask is a pending entry of an agent's stream
+type PendingStreamTask struct {
+	TaskID     string
+	EntryID    string
+	Consumer   string
+	Idle       time.Duration
+	Deliveries int64
+}
+
+// GetPendingStreamTasks retrieves up to count pending entries of an agent's
+// stream, oldest first
+func (c *Client) GetPendingStreamTasks(ctx context.Context, agentID string, count int64) ([]PendingStreamTask, error) {
+	stream := c.taskStreamKey(agentID)
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  TaskStreamGroup,
+		Start:  "-",
+		End:    "+",
+		Count:  count,
+	}).Result()
+	if err != nil {
+		// An agent that was never delivered a task has no stream
+		if strings.HasPrefix(err.Error(), "NOGROUP") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.XMessageSliceCmd, len(pending))
+	for i, entry := range pending {
+		cmds[i] = pipe.XRange(ctx, stream, entry.ID, entry.ID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]PendingStreamTask, 0, len(pending))
+	for i, entry := range pending {
+		messages := cmds[i].Val()
+		if len(messages) == 0 {
+			continue
+		}
+		key, _ := messages[0].Values["task"].(string)
+		tasks = append(tasks, PendingStreamTask{
+			TaskID:     strings.TrimPrefix(key, c.key("task:")),
+			EntryID:    entry.ID,
+			Consumer:   entry.Consumer,
+			Idle:       entry.Idle,
+			Deliveries: entry.RetryCount,
+		})
+	}
+	return tasks, nil
+}
@@ -0,0 +1,105 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// trendPendingKey is the set of raw trend value lists awaiting rollup
+const trendPendingKey = "trends:pending"
+
+// compactTrendDayScript stores a day's digest and drops the raw values it
+// summarizes; values appended meanwhile stay pending for the next rollup
+var compactTrendDayScript = newScript("compactTrendDay", `
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+redis.call("LTRIM", KEYS[2], ARGV[3], -1)
+if redis.call("EXISTS", KEYS[2]) == 0 then
+	redis.call("SREM", KEYS[3], KEYS[2])
+end
+return 1
+`)
+
+// compactTrendDayOp runs compactTrendDayScript for a series and a day
+var compactTrendDayOp = newScriptOperation("CompactTrendDay", compactTrendDayScript, 2, false, func(c *Client, ids ...string) []string {
+	return []string{c.key("trend:%s", ids[0]), c.trendRawKey(ids[0], ids[1]), c.key(trendPendingKey)}
+})
+
+// appendTrendValueTx appends a raw value of a series and marks its day
+// pending, for the series and the day
+var appendTrendValueTx = newTransaction("AppendTrendValue", 2, func(c *Client, ids ...string) []string {
+	return []string{c.trendRawKey(ids[0], ids[1]), c.key(trendPendingKey)}
+})
+
+// trendRawKey returns the key of the raw values of a series on day
+func (c *Client) trendRawKey(series, day string) string {
+	return c.key("trend_raw:%s:%s", series, day)
+}
+
+// AppendTrendValue appends a raw value to a series' list for day
+func (c *Client) AppendTrendValue(ctx context.Context, series, day string, value float64) error {
+	keys := appendTrendValueTx.keys(c, series, day)
+	pipe := c.client.TxPipeline()
+	pipe.RPush(ctx, keys[0], strconv.FormatFloat(value, 'g', -1, 64))
+	pipe.SAdd(ctx, keys[1], keys[0])
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// PendingTrendDays lists the series and days that have raw values awaiting rollup
+func (c *Client) PendingTrendDays(ctx context.Context) ([][2]string, error) {
+	keys, err := c.client.SMembers(ctx, c.key(trendPendingKey)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([][2]string, 0, len(keys))
+	for _, key := range keys {
+		rest := strings.TrimPrefix(key, c.key("trend_raw:"))
+		sep := strings.LastIndex(rest, ":")
+		if sep < 0 {
+			continue
+		}
+		pending = append(pending, [2]string{rest[:sep], rest[sep+1:]})
+	}
+	return pending, nil
+}
+
+// GetTrendValues retrieves the raw values of a series on day
+func (c *Client) GetTrendValues(ctx context.Context, series, day string) ([]float64, error) {
+	raw, err := c.client.LRange(ctx, c.trendRawKey(series, day), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]float64, 0, len(raw))
+	for _, r := range raw {
+		if v, err := strconv.ParseFloat(r, 64); err == nil {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// CompactTrendDay stores the digest of a series on day and drops the first
+// n raw values it summarizes
+func (c *Client) CompactTrendDay(ctx context.Context, series, day string, digest []byte, n int) error {
+	return compactTrendDayOp.run(ctx, c, []string{series, day}, day, digest, n).Err()
+}
+
+// GetTrendDigests retrieves the compacted digests of a series, keyed by day
+func (c *Client) GetTrendDigests(ctx context.Context, series string) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("trend:%s", series)).Result()
+}
+
+// GetTrendDigest retrieves the compacted digest of a series on day, or nil if
+// the day has not been compacted
+func (c *Client) GetTrendDigest(ctx context.Context, series, day string) ([]byte, error) {
+	data, err := c.client.HGet(ctx, c.key("trend:%s", series), day).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return data, err
+}
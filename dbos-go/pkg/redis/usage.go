@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// IncrUsage adds counts to the fields of the per-minute API usage bucket
+// containing at
+func (c *Client) IncrUsage(ctx context.Context, at time.Time, counts map[string]int64, retention time.Duration) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	key := c.key("usage:%d", at.Unix()/60)
+	pipe := c.client.TxPipeline()
+	for field, n := range counts {
+		pipe.HIncrBy(ctx, key, field, n)
+	}
+	pipe.Expire(ctx, key, retention)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetUsage retrieves the API usage buckets of the minutes from through to
+func (c *Client) GetUsage(ctx context.Context, from, to time.Time) ([]map[string]string, error) {
+	pipe := c.client.Pipeline()
+	var cmds []*redis.StringStringMapCmd
+	for minute := from.Unix() / 60; minute <= to.Unix()/60; minute++ {
+		cmds = append(cmds, pipe.HGetAll(ctx, c.key("usage:%d", minute)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	buckets := make([]map[string]string, len(cmds))
+	for i, cmd := range cmds {
+		buckets[i] = cmd.Val()
+	}
+	return buckets, nil
+}
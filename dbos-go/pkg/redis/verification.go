@@ -0,0 +1,35 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// SetVerification stores a verification in Redis
+func (c *Client) SetVerification(ctx context.Context, verificationID string, verification interface{}) error {
+	key := c.key("verification:%s", verificationID)
+	data, err := json.Marshal(verification)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, key, data, 0).Err()
+}
+
+// GetVerification retrieves a verification from Redis
+func (c *Client) GetVerification(ctx context.Context, verificationID string) ([]byte, error) {
+	key := c.key("verification:%s", verificationID)
+	return c.client.Get(ctx, key).Bytes()
+}
+
+// SetVerificationValue records the compared value one agent reported for a verification
+func (c *Client) SetVerificationValue(ctx context.Context, verificationID, agentID, value string) error {
+	key := c.key("verification_values:%s", verificationID)
+	return c.client.HSet(ctx, key, agentID, value).Err()
+}
+
+// GetVerificationValues retrieves the compared values reported for a verification
+func (c *Client) GetVerificationValues(ctx context.Context, verificationID string) (map[string]string, error) {
+	key := c.key("verification_values:%s", verificationID)
+	return c.client.HGetAll(ctx, key).Result()
+}
@@ -0,0 +1,164 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// viewRowSeparator joins the agent ID and key of a view row into a hash field
+const viewRowSeparator = "|"
+
+// setLatestViewRowScript replaces a latest-view row only with a result that
+// is at least as recent, so out-of-order ingest cannot regress it
+var setLatestViewRowScript = newScript("setLatestViewRow", `
+local current = tonumber(redis.call("HGET", KEYS[2], ARGV[1]) or "-1")
+if current > tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[3])
+redis.call("HSET", KEYS[2], ARGV[1], ARGV[2])
+return 1
+`)
+
+// setLatestViewRowOp runs setLatestViewRowScript for a view name
+var setLatestViewRowOp = newScriptOperation("SetLatestViewRow", setLatestViewRowScript, 1, false, func(c *Client, ids ...string) []string {
+	return []string{c.key("view:%s", ids[0]), c.key("view:%s:ts", ids[0])}
+})
+
+// addDailyViewValueScript folds one value into a daily-view row's count, sum, min and max
+var addDailyViewValueScript = newScript("addDailyViewValue", `
+local value = tonumber(ARGV[2])
+redis.call("HINCRBY", KEYS[1], ARGV[1] .. "|count", 1)
+redis.call("HINCRBYFLOAT", KEYS[1], ARGV[1] .. "|sum", ARGV[2])
+local min = redis.call("HGET", KEYS[1], ARGV[1] .. "|min")
+if not min or value < tonumber(min) then
+	redis.call("HSET", KEYS[1], ARGV[1] .. "|min", ARGV[2])
+end
+local max = redis.call("HGET", KEYS[1], ARGV[1] .. "|max")
+if not max or value > tonumber(max) then
+	redis.call("HSET", KEYS[1], ARGV[1] .. "|max", ARGV[2])
+end
+return 1
+`)
+
+// addDailyViewValueOp runs addDailyViewValueScript for a view name and a day
+var addDailyViewValueOp = newScriptOperation("AddDailyViewValue", addDailyViewValueScript, 2, false, func(c *Client, ids ...string) []string {
+	return []string{c.key("view:%s:%s", ids[0], ids[1])}
+})
+
+// deleteViewTx deletes a view and its rows, for its name and the days it
+// has rows of
+var deleteViewTx = newTransaction("DeleteView", 2, func(c *Client, ids ...string) []string {
+	keys := append([]string{c.key("views")}, setLatestViewRowOp.keys(c, ids[0])...)
+	keys = append(keys, c.key("view:%s:days", ids[0]))
+	for _, day := range ids[1:] {
+		keys = append(keys, addDailyViewValueOp.keys(c, ids[0], day)...)
+	}
+	return keys
+})
+
+// DailyViewStats holds the aggregates of one daily-view row
+type DailyViewStats struct {
+	Count int64
+	Sum   float64
+	Min   float64
+	Max   float64
+}
+
+// SetView stores a view definition in Redis
+func (c *Client) SetView(ctx context.Context, name string, view []byte) error {
+	return c.client.HSet(ctx, c.key("views"), name, view).Err()
+}
+
+// GetViews retrieves all view definitions from Redis
+func (c *Client) GetViews(ctx context.Context) (map[string]string, error) {
+	return c.client.HGetAll(ctx, c.key("views")).Result()
+}
+
+// DeleteView removes a view definition and all of its rows from Redis
+func (c *Client) DeleteView(ctx context.Context, name string) error {
+	daysKey := c.key("view:%s:days", name)
+	days, err := c.client.SMembers(ctx, daysKey).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := deleteViewTx.keys(c, append([]string{name}, days...)...)
+	pipe := c.client.TxPipeline()
+	pipe.HDel(ctx, keys[0], name)
+	pipe.Del(ctx, keys[1:]...)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// SetLatestViewRow stores a latest-view row unless a more recent one is already stored
+func (c *Client) SetLatestViewRow(ctx context.Context, name, agentID, key string, timestamp int64, row []byte) error {
+	field := agentID + viewRowSeparator + key
+	return setLatestViewRowOp.run(ctx, c, []string{name}, field, timestamp, row).Err()
+}
+
+// GetLatestViewRows retrieves all rows of a latest view
+func (c *Client) GetLatestViewRows(ctx context.Context, name string) ([][]byte, error) {
+	rows, err := c.client.HVals(ctx, c.key("view:%s", name)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([][]byte, len(rows))
+	for i, row := range rows {
+		data[i] = []byte(row)
+	}
+	return data, nil
+}
+
+// AddDailyViewValue folds a value into the daily-view row for agent and key on day
+func (c *Client) AddDailyViewValue(ctx context.Context, name, day, agentID, key string, value float64) error {
+	if err := c.client.SAdd(ctx, c.key("view:%s:days", name), day).Err(); err != nil {
+		return err
+	}
+
+	field := agentID + viewRowSeparator + key
+	return addDailyViewValueOp.run(ctx, c, []string{name, day}, field, strconv.FormatFloat(value, 'f', -1, 64)).Err()
+}
+
+// GetDailyViewRows retrieves the aggregates of every row of a daily view on
+// day, keyed by agent ID and key
+func (c *Client) GetDailyViewRows(ctx context.Context, name, day string) (map[[2]string]*DailyViewStats, error) {
+	fields, err := c.client.HGetAll(ctx, c.key("view:%s:%s", name, day)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make(map[[2]string]*DailyViewStats)
+	for field, raw := range fields {
+		sep := strings.LastIndex(field, viewRowSeparator)
+		if sep < 0 {
+			continue
+		}
+		agentID, key, ok := strings.Cut(field[:sep], viewRowSeparator)
+		if !ok {
+			continue
+		}
+
+		id := [2]string{agentID, key}
+		stats, ok := rows[id]
+		if !ok {
+			stats = &DailyViewStats{}
+			rows[id] = stats
+		}
+
+		switch field[sep+1:] {
+		case "count":
+			stats.Count, _ = strconv.ParseInt(raw, 10, 64)
+		case "sum":
+			stats.Sum, _ = strconv.ParseFloat(raw, 64)
+		case "min":
+			stats.Min, _ = strconv.ParseFloat(raw, 64)
+		case "max":
+			stats.Max, _ = strconv.ParseFloat(raw, 64)
+		}
+	}
+
+	return rows, nil
+}
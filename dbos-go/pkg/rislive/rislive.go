@@ -0,0 +1,150 @@
+// Package rislive streams BGP updates from the RIPE RIS Live websocket feed.
+package rislive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultURL is the public RIS Live endpoint
+const DefaultURL = "wss://ris-live.ripe.net/v1/ws/?client=dbos"
+
+// readTimeout is how long the feed may stay silent before the connection is
+// considered dead; RIS Live sends frequent updates for busy subscriptions
+// and answers pings otherwise
+const readTimeout = 2 * time.Minute
+
+// Update is one BGP UPDATE message seen by a RIS route collector peer
+type Update struct {
+	Timestamp     float64           `json:"timestamp"`
+	Peer          string            `json:"peer"`
+	PeerASN       string            `json:"peer_asn"`
+	Host          string            `json:"host"` // route collector, e.g. rrc00
+	Path          []json.RawMessage `json:"path"` // ASNs, or arrays of ASNs for AS sets
+	Announcements []Announcement    `json:"announcements"`
+	Withdrawals   []string          `json:"withdrawals"`
+}
+
+// Announcement is a set of prefixes announced with the same next hop
+type Announcement struct {
+	NextHop  string   `json:"next_hop"`
+	Prefixes []string `json:"prefixes"`
+}
+
+// Time returns when the collector received the update
+func (u *Update) Time() time.Time {
+	return time.UnixMilli(int64(u.Timestamp * 1000))
+}
+
+// OriginASN returns the last ASN of the AS path, or 0 if the path is empty
+// or ends in an AS set, whose origin is ambiguous
+func (u *Update) OriginASN() int64 {
+	if len(u.Path) == 0 {
+		return 0
+	}
+	var asn int64
+	if err := json.Unmarshal(u.Path[len(u.Path)-1], &asn); err != nil {
+		return 0
+	}
+	return asn
+}
+
+// PathString renders the AS path space-separated, AS sets in braces
+func (u *Update) PathString() string {
+	hops := make([]string, len(u.Path))
+	for i, hop := range u.Path {
+		var set []int64
+		if json.Unmarshal(hop, &set) != nil {
+			hops[i] = string(hop)
+			continue
+		}
+		members := make([]string, len(set))
+		for j, asn := range set {
+			members[j] = strconv.FormatInt(asn, 10)
+		}
+		hops[i] = "{" + strings.Join(members, ",") + "}"
+	}
+	return strings.Join(hops, " ")
+}
+
+type message struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+type subscription struct {
+	Prefix       string `json:"prefix"`
+	MoreSpecific bool   `json:"moreSpecific"`
+	Type         string `json:"type"`
+}
+
+// Client subscribes to RIS Live
+type Client struct {
+	url string
+}
+
+// NewClient creates a new client for a RIS Live websocket URL
+func NewClient(url string) *Client {
+	return &Client{
+		url: url,
+	}
+}
+
+// Stream subscribes to updates for prefixes and their more-specifics and
+// calls handle with each one until ctx is done or the connection fails
+func (c *Client) Stream(ctx context.Context, prefixes []string, handle func(*Update)) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.url, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Unblock the read below once ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for _, prefix := range prefixes {
+		data, err := json.Marshal(subscription{Prefix: prefix, MoreSpecific: true, Type: "UPDATE"})
+		if err != nil {
+			return err
+		}
+		if err := conn.WriteJSON(message{Type: "ris_subscribe", Data: data}); err != nil {
+			return err
+		}
+	}
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(readTimeout))
+		var msg message
+		if err := conn.ReadJSON(&msg); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		switch msg.Type {
+		case "ris_message":
+			var update Update
+			if err := json.Unmarshal(msg.Data, &update); err != nil {
+				continue
+			}
+			handle(&update)
+		case "ris_error":
+			return fmt.Errorf("RIS Live error: %s", msg.Data)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Package tdigest implements the merging t-digest, a compact sketch of a
+// distribution that answers quantile queries with high accuracy at the tails.
+package tdigest
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+)
+
+// DefaultCompression bounds a digest to roughly 100 centroids
+const DefaultCompression = 100
+
+// encodingVersion is the first byte of a marshalled digest
+const encodingVersion = 1
+
+// Centroid is the mean of Count values
+type Centroid struct {
+	Mean  float64
+	Count float64
+}
+
+// TDigest is a mergeable sketch of a distribution
+type TDigest struct {
+	compression float64
+	centroids   []Centroid
+	unmerged    []Centroid
+	count       float64
+	min         float64
+	max         float64
+}
+
+// New creates an empty digest with the given compression
+func New(compression float64) *TDigest {
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Add adds a value with weight w
+func (t *TDigest) Add(x, w float64) {
+	if math.IsNaN(x) || w <= 0 {
+		return
+	}
+	t.unmerged = append(t.unmerged, Centroid{Mean: x, Count: w})
+	t.count += w
+	t.min = math.Min(t.min, x)
+	t.max = math.Max(t.max, x)
+
+	if len(t.unmerged) > int(8*t.compression) {
+		t.compress()
+	}
+}
+
+// Merge adds every value summarized by o
+func (t *TDigest) Merge(o *TDigest) {
+	if o.count == 0 {
+		return
+	}
+	t.unmerged = append(t.unmerged, o.centroids...)
+	t.unmerged = append(t.unmerged, o.unmerged...)
+	t.count += o.count
+	t.min = math.Min(t.min, o.min)
+	t.max = math.Max(t.max, o.max)
+	t.compress()
+}
+
+// Count returns the total weight added
+func (t *TDigest) Count() float64 {
+	return t.count
+}
+
+// Min returns the smallest value added
+func (t *TDigest) Min() float64 {
+	return t.min
+}
+
+// Max returns the largest value added
+func (t *TDigest) Max() float64 {
+	return t.max
+}
+
+// Mean returns the mean of the values added
+func (t *TDigest) Mean() float64 {
+	t.compress()
+	if t.count == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, c := range t.centroids {
+		sum += c.Mean * c.Count
+	}
+	return sum / t.count
+}
+
+// Quantile returns the estimated value at quantile q in [0, 1]
+func (t *TDigest) Quantile(q float64) float64 {
+	t.compress()
+	if t.count == 0 || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	if len(t.centroids) == 1 {
+		return t.centroids[0].Mean
+	}
+
+	target := q * t.count
+	if target <= 0 {
+		return t.min
+	}
+	if target >= t.count {
+		return t.max
+	}
+
+	// Interpolate between centroid centers; the outer halves of the first
+	// and last centroids interpolate towards the exact min and max
+	first := t.centroids[0]
+	if target < first.Count/2 {
+		return t.min + (first.Mean-t.min)*target/(first.Count/2)
+	}
+
+	cumulative := first.Count / 2
+	for i := 1; i < len(t.centroids); i++ {
+		prev, cur := t.centroids[i-1], t.centroids[i]
+		step := (prev.Count + cur.Count) / 2
+		if target < cumulative+step {
+			return prev.Mean + (cur.Mean-prev.Mean)*(target-cumulative)/step
+		}
+		cumulative += step
+	}
+
+	last := t.centroids[len(t.centroids)-1]
+	return last.Mean + (t.max-last.Mean)*(target-cumulative)/(last.Count/2)
+}
+
+// compress merges buffered values into the centroid list, bounding centroid
+// sizes with the k1 scale function so the tails stay precise
+func (t *TDigest) compress() {
+	if len(t.unmerged) == 0 {
+		return
+	}
+
+	all := append(t.centroids, t.unmerged...)
+	t.unmerged = nil
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Mean < all[j].Mean
+	})
+
+	merged := []Centroid{all[0]}
+	before := 0.0
+	qLimit := t.kInverse(t.k(0) + 1)
+	for _, c := range all[1:] {
+		cur := &merged[len(merged)-1]
+		if (before+cur.Count+c.Count)/t.count <= qLimit {
+			cur.Mean += (c.Mean - cur.Mean) * c.Count / (cur.Count + c.Count)
+			cur.Count += c.Count
+			continue
+		}
+		before += cur.Count
+		qLimit = t.kInverse(t.k(before/t.count) + 1)
+		merged = append(merged, c)
+	}
+	t.centroids = merged
+}
+
+// k maps a quantile onto the k1 scale
+func (t *TDigest) k(q float64) float64 {
+	return t.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// kInverse maps a k1 scale value back onto a quantile
+func (t *TDigest) kInverse(k float64) float64 {
+	if k >= t.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/t.compression) + 1) / 2
+}
+
+// MarshalBinary encodes the digest compactly: centroid means are stored as
+// float32 deltas and counts as varints
+func (t *TDigest) MarshalBinary() ([]byte, error) {
+	t.compress()
+
+	buf := make([]byte, 0, 21+len(t.centroids)*6)
+	buf = append(buf, encodingVersion)
+	buf = binary.BigEndian.AppendUint32(buf, math.Float32bits(float32(t.compression)))
+	buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(t.min))
+	buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(t.max))
+	buf = binary.AppendUvarint(buf, uint64(len(t.centroids)))
+
+	prev := 0.0
+	for _, c := range t.centroids {
+		delta := float32(c.Mean - prev)
+		buf = binary.BigEndian.AppendUint32(buf, math.Float32bits(delta))
+		buf = binary.AppendUvarint(buf, uint64(math.Round(c.Count)))
+		prev += float64(delta)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a digest encoded by MarshalBinary
+func (t *TDigest) UnmarshalBinary(data []byte) error {
+	errCorrupt := errors.New("tdigest: corrupt encoding")
+	if len(data) < 21 || data[0] != encodingVersion {
+		return errCorrupt
+	}
+
+	decoded := &TDigest{
+		compression: float64(math.Float32frombits(binary.BigEndian.Uint32(data[1:5]))),
+		min:         math.Float64frombits(binary.BigEndian.Uint64(data[5:13])),
+		max:         math.Float64frombits(binary.BigEndian.Uint64(data[13:21])),
+	}
+	data = data[21:]
+
+	n, size := binary.Uvarint(data)
+	if size <= 0 {
+		return errCorrupt
+	}
+	data = data[size:]
+
+	mean := 0.0
+	for i := uint64(0); i < n; i++ {
+		if len(data) < 4 {
+			return errCorrupt
+		}
+		mean += float64(math.Float32frombits(binary.BigEndian.Uint32(data[:4])))
+		data = data[4:]
+
+		count, size := binary.Uvarint(data)
+		if size <= 0 {
+			return errCorrupt
+		}
+		data = data[size:]
+
+		decoded.centroids = append(decoded.centroids, Centroid{Mean: mean, Count: float64(count)})
+		decoded.count += float64(count)
+	}
+
+	*t = *decoded
+	return nil
+}
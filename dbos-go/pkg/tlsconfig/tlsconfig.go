@@ -0,0 +1,82 @@
+// Package tlsconfig builds TLS configurations for DBOS servers and clients
+// from PEM certificate, key and CA files.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// Server returns a server TLS configuration presenting the certificate in
+// certFile. If clientCAFile is set, clients must present a certificate
+// signed by one of its CAs.
+func Server(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load server certificate: %v", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pool, err := loadPool(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// Client returns a client TLS configuration verifying the server against
+// the CAs in caFile, or the system roots if caFile is empty, and presenting
+// the certificate in certFile if set
+func Client(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if caFile != "" {
+		pool, err := loadPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// loadPool reads the PEM certificates in file into a certificate pool
+func loadPool(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, nil
+}
+
+// ClientFromEnv returns the client TLS configuration of DBOS tools from the
+// DBOS_TLS_CA, DBOS_TLS_CERT and DBOS_TLS_KEY environment variables, or nil
+// if none is set and connections are plaintext
+func ClientFromEnv() (*tls.Config, error) {
+	caFile, certFile, keyFile := os.Getenv("DBOS_TLS_CA"), os.Getenv("DBOS_TLS_CERT"), os.Getenv("DBOS_TLS_KEY")
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	return Client(certFile, keyFile, caFile)
+}
@@ -6,19 +6,17 @@ import (
 	"time"
 
 	"github.com/internet-measurement-network/dbos/api"
-	"google.golang.org/grpc"
+	"github.com/internet-measurement-network/dbos/pkg/client"
 )
 
 func main() {
-	// Connect to the DBOS gRPC server
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	// Connect to the DBOS gRPC server at DBOS_ADDR, over TLS if DBOS_TLS_*
+	// is set; calls are retried while the server is unavailable
+	dbos, err := client.FromEnv()
 	if err != nil {
 		log.Fatalf("Failed to connect to DBOS server: %v", err)
 	}
-	defer conn.Close()
-
-	// Create a client
-	client := api.NewDBOSClient(conn)
+	defer dbos.Close()
 
 	// Test registering an agent
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -38,7 +36,7 @@ func main() {
 		Agent: agent,
 	}
 
-	registerResp, err := client.RegisterAgent(ctx, registerReq)
+	registerResp, err := dbos.RegisterAgent(ctx, registerReq)
 	if err != nil {
 		log.Printf("Error registering agent: %v", err)
 	} else {
@@ -50,7 +48,7 @@ func main() {
 		AgentId: "test-agent-1",
 	}
 
-	getResp, err := client.GetAgent(ctx, getReq)
+	getResp, err := dbos.GetAgent(ctx, getReq)
 	if err != nil {
 		log.Printf("Error getting agent: %v", err)
 	} else {
@@ -72,7 +70,7 @@ func main() {
 		State: state,
 	}
 
-	setStateResp, err := client.SetModuleState(ctx, setStateReq)
+	setStateResp, err := dbos.SetModuleState(ctx, setStateReq)
 	if err != nil {
 		log.Printf("Error setting module state: %v", err)
 	} else {
@@ -84,7 +82,7 @@ func main() {
 		RequestId: "req-12345",
 	}
 
-	getStateResp, err := client.GetModuleState(ctx, getStateReq)
+	getStateResp, err := dbos.GetModuleState(ctx, getStateReq)
 	if err != nil {
 		log.Printf("Error getting module state: %v", err)
 	} else {
@@ -105,7 +103,7 @@ func main() {
 		Result: result,
 	}
 
-	storeResultResp, err := client.StoreResult(ctx, storeResultReq)
+	storeResultResp, err := dbos.StoreResult(ctx, storeResultReq)
 	if err != nil {
 		log.Printf("Error storing result: %v", err)
 	} else {
@@ -118,7 +116,7 @@ func main() {
 		RequestId: "result-123",
 	}
 
-	getResultResp, err := client.GetResult(ctx, getResultReq)
+	getResultResp, err := dbos.GetResult(ctx, getResultReq)
 	if err != nil {
 		log.Printf("Error getting result: %v", err)
 	} else {
@@ -127,7 +125,7 @@ func main() {
 
 	// Test listing all agents
 	listAgentsReq := &api.ListAgentsRequest{}
-	listAgentsResp, err := client.ListAgents(ctx, listAgentsReq)
+	listAgentsResp, err := dbos.ListAgents(ctx, listAgentsReq)
 	if err != nil {
 		log.Printf("Error listing agents: %v", err)
 	} else {
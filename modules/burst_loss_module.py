@@ -0,0 +1,203 @@
+import json
+import time
+import socket
+import struct
+import asyncio
+from collections import Counter
+from typing import Any, Type
+from ipaddress import IPv4Address, IPv6Address
+
+from aiori_agent.base import BaseWorker
+from aiori_agent.budget import governor
+from aiori_agent.model import Hostname, Domain, MeasurementQuery
+from nats.aio.msg import Msg
+from pydantic import Field
+
+
+ICMP_ECHO_REQUEST = {socket.AF_INET: 8, socket.AF_INET6: 128}
+ICMP_ECHO_REPLY = {socket.AF_INET: 0, socket.AF_INET6: 129}
+ICMP_PROTO = {socket.AF_INET: socket.IPPROTO_ICMP, socket.AF_INET6: socket.IPPROTO_ICMPV6}
+# IP + ICMP header bytes of a probe, counted against the bandwidth budget
+HEADER_LEN = {socket.AF_INET: 28, socket.AF_INET6: 48}
+
+
+class BurstLossQuery(MeasurementQuery):
+    host: IPv4Address | IPv6Address | Hostname | Domain = Field(title="Host", description="Target of the probe burst", examples=["8.8.8.8", "1.1.1.1"])
+    rate: float = Field(default=100, gt=0, le=1000, title="Rate", description="Probes sent per second; must not exceed the agent's probe rate cap")
+    duration: float = Field(default=10, gt=0, le=60, title="Duration", description="Seconds the burst lasts")
+    size: int = Field(default=64, ge=8, le=1400, title="Size", description="ICMP payload bytes of each probe")
+    timeout: float = Field(default=1.0, gt=0, le=10, title="Timeout", description="Seconds to wait for replies after the last probe")
+
+
+def _echo_request(family: int, seq: int, size: int) -> bytes:
+    """Build an ICMP echo request; ping sockets fill in the identifier and checksum"""
+    header = struct.pack("!BBHHH", ICMP_ECHO_REQUEST[family], 0, 0, 0, seq)
+    # The send time lets replies be matched to an RTT without a lookup table
+    payload = struct.pack("!d", time.perf_counter()).ljust(size, b"\0")
+    return header + payload
+
+
+def run_lengths(received: list[bool]) -> tuple[list[int], list[int]]:
+    """Split a delivery sequence into the lengths of its loss and delivery runs"""
+    losses: list[int] = []
+    deliveries: list[int] = []
+    run, current = 0, None
+    for ok in received:
+        if ok == current:
+            run += 1
+            continue
+        if current is not None:
+            (deliveries if current else losses).append(run)
+        run, current = 1, ok
+    if current is not None:
+        (deliveries if current else losses).append(run)
+    return losses, deliveries
+
+
+def summarize(received: list[bool], rtts: list[float]) -> dict[str, Any]:
+    """Loss rate and run-length distributions of a burst"""
+    losses, deliveries = run_lengths(received)
+    sent = len(received)
+    lost = sent - sum(received)
+    return {
+        "packets_sent": sent,
+        "packets_received": sent - lost,
+        "loss_rate": lost / sent if sent else 0.0,
+        # Keys are run lengths in probes, values how often such a run occurred
+        "loss_runs": {str(k): v for k, v in sorted(Counter(losses).items())},
+        "delivery_runs": {str(k): v for k, v in sorted(Counter(deliveries).items())},
+        "loss_run_count": len(losses),
+        "max_loss_run": max(losses, default=0),
+        "mean_loss_run": sum(losses) / len(losses) if losses else 0.0,
+        "min_rtt": min(rtts, default=0.0),
+        "avg_rtt": sum(rtts) / len(rtts) if rtts else 0.0,
+        "max_rtt": max(rtts, default=0.0),
+    }
+
+
+async def burst(address: str, family: int, rate: float, duration: float, size: int, timeout: float) -> dict[str, Any]:
+    """
+    Send `rate` ICMP echo requests per second for `duration` seconds over an
+    unprivileged ping socket and record which were answered.
+    """
+    count = max(1, int(rate * duration))
+    received = [False] * count
+    rtts: list[float] = []
+
+    sock = socket.socket(family, socket.SOCK_DGRAM, ICMP_PROTO[family])
+    sock.setblocking(False)
+    loop = asyncio.get_running_loop()
+
+    async def receive():
+        while True:
+            data = await loop.sock_recv(sock, 65535)
+            if len(data) < 16 or data[0] != ICMP_ECHO_REPLY[family]:
+                continue
+            seq = struct.unpack("!H", data[6:8])[0]
+            if seq < count and not received[seq]:
+                received[seq] = True
+                sent_at = struct.unpack("!d", data[8:16])[0]
+                rtts.append((time.perf_counter() - sent_at) * 1000)
+
+    receiver = asyncio.create_task(receive())
+    try:
+        # Connecting a datagram socket only sets its peer, so it never blocks
+        sock.connect((address, 0))
+        start = time.perf_counter()
+        for seq in range(count):
+            # Pace against the burst's own schedule so a late probe does not shift the rest
+            delay = start + seq / rate - time.perf_counter()
+            if delay > 0:
+                await asyncio.sleep(delay)
+            await governor.acquire()
+            try:
+                await loop.sock_sendall(sock, _echo_request(family, seq, size))
+            except OSError:
+                # A full send buffer or transient route error counts as a loss
+                pass
+        await asyncio.sleep(timeout)
+    finally:
+        receiver.cancel()
+        sock.close()
+
+    return summarize(received, rtts)
+
+
+class BurstLossModule(BaseWorker):
+    """
+    Probes a target with short high-rate bursts and reports the run-length
+    distribution of losses, telling isolated drops from loss bursts. Bursts
+    are gated by the agent's probe rate cap and bandwidth budget.
+    """
+
+    def __init__(self, name: str, agent, nc, logger, shared):
+        super().__init__(name, agent, nc, logger, shared)
+
+        self.sub_in = f"agent.{self.agent.agent_id}.{self.name}.in"
+        self.sub_out = f"agent.{self.agent.agent_id}.{self.name}.out"
+        self.sub_err = f"agent.{self.agent.agent_id}.{self.name}.error"
+
+        # One burst at a time so concurrent requests cannot stack up traffic
+        self.lock = asyncio.Lock()
+
+    def serializer(self) -> Type[MeasurementQuery]:
+        return BurstLossQuery
+
+    async def setup(self):
+        # Unprivileged ping sockets must be allowed by net.ipv4.ping_group_range
+        try:
+            socket.socket(socket.AF_INET, socket.SOCK_DGRAM, socket.IPPROTO_ICMP).close()
+        except OSError as e:
+            self.logger.warning(f"{self.name}: ICMP ping sockets unavailable: {e}")
+            return False
+        return True
+
+    async def run(self):
+        """
+        Subscribes to the input subject and starts handling burst requests.
+        """
+        await self.nc.subscribe(self.sub_in, cb=self.handle)
+        self.logger.info(f"{self.name}: Listening on {self.sub_in}")
+
+    async def handle(self, msg: Msg):
+        """
+        Processes an incoming burst request and sends the result.
+        """
+        request_id = None
+        try:
+            data = json.loads(msg.data.decode())
+            request_id = data.get("id")
+
+            query = BurstLossQuery(**data)
+
+            family, _, _, _, sockaddr = (await asyncio.get_running_loop().getaddrinfo(str(query.host), None, type=socket.SOCK_DGRAM))[0]
+            count = max(1, int(query.rate * query.duration))
+            # Raises BudgetExceeded, refusing the burst before any probe is sent
+            governor.reserve(query.rate, count * (query.size + HEADER_LEN[family]))
+
+            if request_id:
+                await self._report_state("running", details={"action": "processing_request"}, request_id=request_id)
+
+            async with self.lock:
+                report = await burst(sockaddr[0], family, query.rate, query.duration, query.size, query.timeout)
+            result = {
+                "id": str(query.id),
+                "module": self.name,
+                "target": str(query.host),
+                "address": sockaddr[0],
+                "rate": query.rate,
+                "duration": query.duration,
+                **report,
+            }
+
+            self.logger.info(f"{self.name}: {result['target']} lost {result['loss_rate']:.1%} in {result['loss_run_count']} runs")
+            await self.nc.publish(self.sub_out, json.dumps(result).encode("utf-8"))
+
+            if request_id:
+                await self._report_state("completed", details={"action": "request_completed"}, request_id=request_id)
+        except Exception as e:
+            self.logger.exception(f"{self.name}: Error during handle")
+            await self.nc.publish(self.sub_err, str(e).encode("utf-8"))
+
+            if request_id:
+                await self._report_state("error", str(e), details={"action": "request_failed"}, request_id=request_id)
@@ -0,0 +1,200 @@
+import json
+import time
+import socket
+import struct
+import asyncio
+import statistics
+from typing import Any, Optional, Type
+
+from aiori_agent.base import BaseWorker
+from aiori_agent.config import settings
+from aiori_agent.model import MeasurementQuery
+from nats.aio.msg import Msg
+from pydantic import Field
+
+
+NTP_PORT = 123
+NTP_PACKET_LEN = 48
+# Seconds between the NTP era (1900) and the Unix epoch (1970)
+NTP_EPOCH_OFFSET = 2208988800
+# LI = 0 (no warning), VN = 4, Mode = 3 (client)
+NTP_CLIENT_HEADER = (0 << 6) | (4 << 3) | 3
+
+DEFAULT_SERVERS = ["pool.ntp.org", "time.google.com", "time.cloudflare.com"]
+
+
+class NtpQuery(MeasurementQuery):
+    servers: list[str] = Field(default=DEFAULT_SERVERS, title="Servers", description="NTP servers to measure the clock offset against", examples=[["pool.ntp.org", "time.google.com"]])
+    samples: int = Field(default=4, ge=1, le=16, title="Samples", description="How many queries are sent to each server; the one with the lowest delay is kept")
+    timeout: float = Field(default=2.0, gt=0, title="Timeout", description="Seconds to wait for each server response")
+
+
+def _to_ntp(t: float) -> bytes:
+    """Encode a Unix time as a 64-bit NTP timestamp"""
+    t += NTP_EPOCH_OFFSET
+    seconds = int(t)
+    fraction = int((t - seconds) * 2**32) & 0xFFFFFFFF
+    return struct.pack("!II", seconds, fraction)
+
+
+def _from_ntp(data: bytes) -> float:
+    """Decode a 64-bit NTP timestamp as a Unix time"""
+    seconds, fraction = struct.unpack("!II", data)
+    return seconds - NTP_EPOCH_OFFSET + fraction / 2**32
+
+
+class _NtpProtocol(asyncio.DatagramProtocol):
+    def __init__(self):
+        self.response: asyncio.Future = asyncio.get_running_loop().create_future()
+
+    def datagram_received(self, data, addr):
+        if not self.response.done():
+            self.response.set_result((data, time.time()))
+
+    def error_received(self, exc):
+        if not self.response.done():
+            self.response.set_exception(exc)
+
+
+async def sntp_query(server: str, timeout: float) -> dict[str, Any]:
+    """
+    Send one SNTP request and compute the clock offset and round-trip delay
+    from the four timestamps (RFC 4330).
+    """
+    loop = asyncio.get_running_loop()
+    transport, protocol = await loop.create_datagram_endpoint(
+        _NtpProtocol, remote_addr=(server, NTP_PORT), family=socket.AF_INET
+    )
+    try:
+        t1 = time.time()
+        origin = _to_ntp(t1)
+        request = bytes([NTP_CLIENT_HEADER]) + bytes(39) + origin
+        transport.sendto(request)
+
+        data, t4 = await asyncio.wait_for(protocol.response, timeout)
+    finally:
+        transport.close()
+
+    if len(data) < NTP_PACKET_LEN:
+        raise ValueError("short NTP response")
+    if data[24:32] != origin:
+        raise ValueError("NTP response does not answer our request")
+
+    mode = data[0] & 0x7
+    stratum = data[1]
+    if mode != 4 or stratum == 0 or stratum > 15:
+        # Stratum 0 is a kiss-o'-death packet; its reference ID says why
+        raise ValueError(f"unsynchronized server (stratum {stratum}, {data[12:16]!r})")
+
+    t2 = _from_ntp(data[32:40])
+    t3 = _from_ntp(data[40:48])
+    return {
+        "offset_ms": ((t2 - t1) + (t3 - t4)) / 2 * 1000,
+        "delay_ms": ((t4 - t1) - (t3 - t2)) * 1000,
+        "stratum": stratum,
+    }
+
+
+async def measure_server(server: str, samples: int, timeout: float) -> dict[str, Any]:
+    """Query a server several times and keep the lowest-delay sample"""
+    best: Optional[dict[str, Any]] = None
+    error = None
+    for _ in range(samples):
+        try:
+            sample = await sntp_query(server, timeout)
+        except Exception as e:
+            error = str(e) or type(e).__name__
+            continue
+        if best is None or sample["delay_ms"] < best["delay_ms"]:
+            best = sample
+
+    if best is None:
+        return {"server": server, "error": error}
+    return {"server": server, **best}
+
+
+async def measure_clock(servers: list[str], samples: int, timeout: float) -> dict[str, Any]:
+    """
+    Measure the clock offset against every server. The reported offset is the
+    median of the per-server offsets, so a single bad server cannot skew it.
+    """
+    results = await asyncio.gather(*(measure_server(s, samples, timeout) for s in servers))
+    answered = [r for r in results if "error" not in r]
+
+    report: dict[str, Any] = {
+        "servers": list(results),
+        "servers_answered": len(answered),
+        "measured_at": time.time(),
+    }
+    if answered:
+        report["offset_ms"] = statistics.median(r["offset_ms"] for r in answered)
+        report["delay_ms"] = min(r["delay_ms"] for r in answered)
+    return report
+
+
+class NtpModule(BaseWorker):
+    """
+    Measures the agent's clock offset against NTP servers. DBOS feeds the
+    offset into its clock skew model of the agent.
+    """
+
+    def __init__(self, name: str, agent, nc, logger, shared):
+        super().__init__(name, agent, nc, logger, shared)
+
+        self.sub_in = f"agent.{self.agent.agent_id}.{self.name}.in"
+        self.sub_out = f"agent.{self.agent.agent_id}.{self.name}.out"
+        self.sub_err = f"agent.{self.agent.agent_id}.{self.name}.error"
+
+        # Seconds between locally scheduled measurements; 0 disables them
+        self.interval = settings.ntp_interval
+
+    def serializer(self) -> Type[MeasurementQuery]:
+        return NtpQuery
+
+    async def setup(self):
+        return True
+
+    async def run(self):
+        """
+        Subscribes to the input subject and, if configured, keeps measuring
+        the clock offset on the agent's own schedule.
+        """
+        await self.nc.subscribe(self.sub_in, cb=self.handle)
+        self.logger.info(f"{self.name}: Listening on {self.sub_in}")
+
+        if self.interval:
+            self.schedule_local(self.interval, self._measure_default)
+            self.logger.info(f"{self.name}: Measuring clock offset every {self.interval}s")
+
+    async def _measure_default(self) -> dict[str, Any]:
+        query = NtpQuery()
+        return await measure_clock(query.servers, query.samples, query.timeout)
+
+    async def handle(self, msg: Msg):
+        """
+        Processes an incoming clock offset request and sends the result.
+        """
+        request_id = None
+        try:
+            data = json.loads(msg.data.decode())
+            request_id = data.get("id")
+
+            query = NtpQuery(**data)
+
+            if request_id:
+                await self._report_state("running", details={"action": "processing_request"}, request_id=request_id)
+
+            report = await measure_clock(query.servers, query.samples, query.timeout)
+            result = {"id": str(query.id), "module": self.name, **report}
+
+            self.logger.info(f"{self.name}: Clock offset {result.get('offset_ms')} ms from {result['servers_answered']} servers")
+            await self.nc.publish(self.sub_out, json.dumps(result).encode("utf-8"))
+
+            if request_id:
+                await self._report_state("completed", details={"action": "request_completed"}, request_id=request_id)
+        except Exception as e:
+            self.logger.exception(f"{self.name}: Error during handle")
+            await self.nc.publish(self.sub_err, str(e).encode("utf-8"))
+
+            if request_id:
+                await self._report_state("error", str(e), details={"action": "request_failed"}, request_id=request_id)
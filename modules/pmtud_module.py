@@ -0,0 +1,342 @@
+import json
+import sys
+import time
+import errno
+import select
+import socket
+import struct
+import asyncio
+from typing import Any, Optional, Type
+from ipaddress import IPv4Address, IPv6Address
+
+from aiori_agent.base import BaseWorker
+from aiori_agent.model import Hostname, Domain, MeasurementQuery
+from nats.aio.msg import Msg
+from pydantic import Field
+
+
+# Linux socket options not exported by the socket module on every Python
+IP_MTU_DISCOVER = 10
+IP_RECVERR = 11
+IP_MTU = 14
+IPV6_MTU_DISCOVER = 23
+IPV6_RECVERR = 25
+IPV6_MTU = 24
+# Set DF on every probe but ignore the kernel's cached path MTU
+PMTUDISC_PROBE = 3
+
+# sock_extended_err origins
+SO_EE_ORIGIN_LOCAL = 1
+SO_EE_ORIGIN_ICMP = 2
+SO_EE_ORIGIN_ICMP6 = 3
+
+# IP + UDP header bytes of a probe
+HEADER_LEN = {socket.AF_INET: 28, socket.AF_INET6: 48}
+# Smallest packet every link must carry
+MIN_MTU = {socket.AF_INET: 576, socket.AF_INET6: 1280}
+
+# Common MTU plateaus (RFC 1191) used when a router omits the next-hop MTU
+MTU_PLATEAUS = [65535, 32000, 17914, 8166, 4352, 2002, 1492, 1280, 1006, 576, 508, 296, 68]
+
+BASE_PORT = 33434
+
+
+class PmtudQuery(MeasurementQuery):
+    host: IPv4Address | IPv6Address | Hostname | Domain = Field(title="Host", description="Target whose path MTU is discovered", examples=["8.8.8.8", "example.com"])
+    max_hops: int = Field(default=30, ge=1, le=64, title="Max Hops", description="Largest TTL probed")
+    max_mtu: int = Field(default=1500, ge=576, le=65535, title="Max MTU", description="Packet size the discovery starts from; capped at the local interface MTU")
+    attempts: int = Field(default=2, ge=1, le=5, title="Attempts", description="Probes sent per hop and size before giving up on a response")
+    timeout: float = Field(default=1.0, gt=0, title="Timeout", description="Seconds to wait for each probe response")
+
+
+class Probe:
+    """Outcome of one probe: the kind of response, who sent it and when"""
+
+    def __init__(self, kind: str, address: Optional[str] = None, rtt: Optional[float] = None, mtu: Optional[int] = None):
+        self.kind = kind  # time_exceeded, frag_needed, local_mtu, destination, unreachable or timeout
+        self.address = address
+        self.rtt = rtt
+        self.mtu = mtu
+
+
+def _offender(family: int, data: bytes) -> Optional[str]:
+    """Decode the sockaddr of the host that sent an ICMP error"""
+    if len(data) < 8:
+        return None
+    if family == socket.AF_INET:
+        return socket.inet_ntop(socket.AF_INET, data[4:8])
+    if len(data) >= 24:
+        return socket.inet_ntop(socket.AF_INET6, data[8:24])
+    return None
+
+
+def _classify(family: int, cmsg_data: bytes) -> Probe:
+    """Classify an extended socket error (struct sock_extended_err)"""
+    ee_errno, origin, icmp_type, icmp_code, _, info, _ = struct.unpack("=IBBBBII", cmsg_data[:16])
+    address = _offender(family, cmsg_data[16:])
+
+    if origin == SO_EE_ORIGIN_LOCAL:
+        if ee_errno == errno.EMSGSIZE:
+            return Probe("local_mtu", mtu=info)
+        return Probe("unreachable")
+
+    if origin == SO_EE_ORIGIN_ICMP:
+        if icmp_type == 11:
+            return Probe("time_exceeded", address)
+        if icmp_type == 3 and icmp_code == 4:
+            return Probe("frag_needed", address, mtu=info)
+        if icmp_type == 3 and icmp_code == 3:
+            return Probe("destination", address)
+        return Probe("unreachable", address)
+
+    if origin == SO_EE_ORIGIN_ICMP6:
+        if icmp_type == 3:
+            return Probe("time_exceeded", address)
+        if icmp_type == 2:
+            return Probe("frag_needed", address, mtu=info)
+        if icmp_type == 1 and icmp_code == 4:
+            return Probe("destination", address)
+        return Probe("unreachable", address)
+
+    return Probe("unreachable", address)
+
+
+class PathProber:
+    """
+    Sends DF-marked UDP probes of a given size and TTL and reads the ICMP
+    errors they trigger from the socket error queue, like tracepath. Needs no
+    raw socket privileges but is Linux-only.
+    """
+
+    def __init__(self, address: str, family: int, timeout: float):
+        self.family = family
+        self.timeout = timeout
+        self.seq = 0
+
+        self.sock = socket.socket(family, socket.SOCK_DGRAM)
+        if family == socket.AF_INET:
+            self.sock.setsockopt(socket.IPPROTO_IP, IP_MTU_DISCOVER, PMTUDISC_PROBE)
+            self.sock.setsockopt(socket.IPPROTO_IP, IP_RECVERR, 1)
+        else:
+            self.sock.setsockopt(socket.IPPROTO_IPV6, IPV6_MTU_DISCOVER, PMTUDISC_PROBE)
+            self.sock.setsockopt(socket.IPPROTO_IPV6, IPV6_RECVERR, 1)
+        self.sock.connect((address, BASE_PORT))
+
+    def close(self):
+        self.sock.close()
+
+    def interface_mtu(self) -> int:
+        if self.family == socket.AF_INET:
+            return self.sock.getsockopt(socket.IPPROTO_IP, IP_MTU)
+        return self.sock.getsockopt(socket.IPPROTO_IPV6, IPV6_MTU)
+
+    def probe(self, ttl: int, size: int) -> Probe:
+        """Send one probe of size bytes (IP packet) with the given TTL"""
+        if self.family == socket.AF_INET:
+            self.sock.setsockopt(socket.IPPROTO_IP, socket.IP_TTL, ttl)
+        else:
+            self.sock.setsockopt(socket.IPPROTO_IPV6, socket.IPV6_UNICAST_HOPS, ttl)
+
+        self.seq += 1
+        marker = struct.pack("!I", self.seq)
+        payload = marker + bytes(max(0, size - HEADER_LEN[self.family] - len(marker)))
+
+        start = time.perf_counter()
+        try:
+            self.sock.send(payload)
+        except OSError as e:
+            if e.errno == errno.EMSGSIZE:
+                return Probe("local_mtu", mtu=self.interface_mtu())
+            raise
+
+        poller = select.poll()
+        poller.register(self.sock, select.POLLIN | select.POLLERR)
+        deadline = start + self.timeout
+        while (remaining := deadline - time.perf_counter()) > 0:
+            if not poller.poll(remaining * 1000):
+                break
+            try:
+                data, ancdata, _, _ = self.sock.recvmsg(len(marker), 512, socket.MSG_ERRQUEUE)
+            except BlockingIOError:
+                # Not an error: the target answered the UDP probe itself
+                self.sock.recv(65535)
+                return Probe("destination", rtt=(time.perf_counter() - start) * 1000)
+
+            # Errors of earlier, timed out probes may still be queued
+            if data[:len(marker)] != marker:
+                continue
+            for level, kind, cmsg_data in ancdata:
+                if (level, kind) in ((socket.IPPROTO_IP, IP_RECVERR), (socket.IPPROTO_IPV6, IPV6_RECVERR)):
+                    result = _classify(self.family, cmsg_data)
+                    result.rtt = (time.perf_counter() - start) * 1000
+                    return result
+
+        return Probe("timeout")
+
+    def probe_retry(self, ttl: int, size: int, attempts: int) -> Probe:
+        """Probe until something answers, at most attempts times"""
+        result = Probe("timeout")
+        for _ in range(attempts):
+            result = self.probe(ttl, size)
+            if result.kind != "timeout":
+                break
+        return result
+
+
+def _next_plateau(mtu: int) -> int:
+    """Largest MTU plateau below mtu"""
+    for plateau in MTU_PLATEAUS:
+        if plateau < mtu:
+            return plateau
+    return MTU_PLATEAUS[-1]
+
+
+def _hop(distance: int, probes: list[Probe], mtu: int) -> dict[str, Any]:
+    """Hop entry using the fields of traceroute hops, plus the probed size"""
+    answered = [p for p in probes if p.kind != "timeout"]
+    rtts = [p.rtt for p in answered if p.rtt is not None]
+    return {
+        "distance": distance,
+        "address": next((p.address for p in answered if p.address), None),
+        "rtts": rtts,
+        "avg_rtt": sum(rtts) / len(rtts) if rtts else 0.0,
+        "packets_sent": len(probes),
+        "packets_received": len(answered),
+        "mtu": mtu,
+    }
+
+
+def discover(host: str, max_hops: int, max_mtu: int, attempts: int, timeout: float) -> dict[str, Any]:
+    """
+    Walk the path hop by hop with probes as large as the current path MTU.
+    A router reporting "fragmentation needed" lowers the path MTU; a hop that
+    answers small probes but silently drops large ones is a PMTU blackhole,
+    whose MTU is then found by binary search.
+    """
+    family, _, _, _, sockaddr = socket.getaddrinfo(host, None, type=socket.SOCK_DGRAM)[0]
+    address = sockaddr[0]
+
+    prober = PathProber(address, family, timeout)
+    try:
+        mtu = min(max_mtu, prober.interface_mtu())
+        hops: list[dict[str, Any]] = []
+        fragmentation: list[dict[str, Any]] = []
+        blackhole: Optional[dict[str, Any]] = None
+        reached = False
+
+        for ttl in range(1, max_hops + 1):
+            probes: list[Probe] = []
+            while True:
+                result = prober.probe_retry(ttl, mtu, attempts)
+                probes.append(result)
+                if result.kind not in ("frag_needed", "local_mtu"):
+                    break
+
+                # The link after the reporting hop carries at most the new MTU
+                new_mtu = result.mtu if result.mtu and result.mtu < mtu else _next_plateau(mtu)
+                fragmentation.append({
+                    "distance": ttl - 1 if result.kind == "frag_needed" else 0,
+                    "address": result.address,
+                    "mtu": new_mtu,
+                })
+                mtu = new_mtu
+                if mtu <= MTU_PLATEAUS[-1]:
+                    break
+
+            if result.kind == "timeout" and mtu > MIN_MTU[family]:
+                small = prober.probe_retry(ttl, MIN_MTU[family], attempts)
+                probes.append(small)
+                if small.kind != "timeout":
+                    # Small packets pass where large ones vanish: search the
+                    # largest size that still gets through
+                    lo, hi = MIN_MTU[family], mtu
+                    while hi - lo > 1:
+                        mid = (lo + hi) // 2
+                        answer = prober.probe_retry(ttl, mid, attempts)
+                        probes.append(answer)
+                        if answer.kind == "timeout":
+                            hi = mid
+                        else:
+                            lo = mid
+                    mtu = lo
+                    if blackhole is None:
+                        blackhole = {"distance": ttl, "address": small.address, "mtu": mtu}
+                    result = small
+
+            hops.append(_hop(ttl, probes, mtu))
+            if result.kind == "destination":
+                reached = True
+                break
+            if result.kind == "unreachable":
+                break
+
+        return {
+            "address": address,
+            "path_mtu": mtu,
+            "reached": reached,
+            "hops": hops,
+            "fragmentation": fragmentation,
+            "blackhole": blackhole,
+        }
+    finally:
+        prober.close()
+
+
+class PmtudModule(BaseWorker):
+    """
+    Discovers the path MTU toward a target and the hops where packets must
+    shrink or are silently dropped.
+    """
+
+    def __init__(self, name: str, agent, nc, logger, shared):
+        super().__init__(name, agent, nc, logger, shared)
+
+        self.sub_in = f"agent.{self.agent.agent_id}.{self.name}.in"
+        self.sub_out = f"agent.{self.agent.agent_id}.{self.name}.out"
+        self.sub_err = f"agent.{self.agent.agent_id}.{self.name}.error"
+
+    def serializer(self) -> Type[MeasurementQuery]:
+        return PmtudQuery
+
+    async def setup(self):
+        # Reading ICMP errors from the socket error queue is Linux-specific
+        return sys.platform.startswith("linux")
+
+    async def run(self):
+        """
+        Subscribes to the input subject and starts handling discovery requests.
+        """
+        await self.nc.subscribe(self.sub_in, cb=self.handle)
+        self.logger.info(f"{self.name}: Listening on {self.sub_in}")
+
+    async def handle(self, msg: Msg):
+        """
+        Processes an incoming discovery request and sends the result.
+        """
+        request_id = None
+        try:
+            data = json.loads(msg.data.decode())
+            request_id = data.get("id")
+
+            query = PmtudQuery(**data)
+
+            if request_id:
+                await self._report_state("running", details={"action": "processing_request"}, request_id=request_id)
+
+            # Probing blocks on socket polls, so keep it off the event loop
+            report = await asyncio.to_thread(
+                discover, str(query.host), query.max_hops, query.max_mtu, query.attempts, query.timeout
+            )
+            result = {"id": str(query.id), "module": self.name, "target": str(query.host), **report}
+
+            self.logger.info(f"{self.name}: Path MTU to {result['target']} is {result['path_mtu']}")
+            await self.nc.publish(self.sub_out, json.dumps(result).encode("utf-8"))
+
+            if request_id:
+                await self._report_state("completed", details={"action": "request_completed"}, request_id=request_id)
+        except Exception as e:
+            self.logger.exception(f"{self.name}: Error during handle")
+            await self.nc.publish(self.sub_err, str(e).encode("utf-8"))
+
+            if request_id:
+                await self._report_state("error", str(e), details={"action": "request_failed"}, request_id=request_id)
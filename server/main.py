@@ -186,7 +186,9 @@ async def subscribe_to_agent_results(agent_id: str):
                         if dbos_client:
                             # Convert data to JSON bytes for DBOS storage
                             result_data = json.dumps(data).encode('utf-8')
-                            success = await dbos_client.store_result(agent_id, request_id, "unknown", result_data)
+                            # Locally scheduled measurements name their module explicitly
+                            module_name = data.get("module", "unknown")
+                            success = await dbos_client.store_result(agent_id, request_id, module_name, result_data)
                             if success:
                                 print(f"[DBOS] Stored result for agent {agent_id}, request {request_id}")
                             else:
@@ -3,7 +3,8 @@ import time
 import asyncio
 import logging
 import json
-from typing import Annotated, Optional, Type
+import uuid
+from typing import Annotated, Any, Awaitable, Callable, Optional, Type
 from enum import Enum
 
 # Configure logging
@@ -14,6 +15,10 @@ logging.basicConfig(
 )
 logger = logging.getLogger("agent")
 
+# Prefix for synthetic task IDs of measurements a module schedules on its own.
+# DBOS recognises it and attributes such results as locally generated.
+LOCAL_TASK_ID_PREFIX = "local-"
+
 
 class ModuleStateEnum(str, Enum):
     STARTED = "started"
@@ -33,6 +38,7 @@ class BaseWorker:
         self.logger = logger.getChild(name)
         self.shared = shared
         self.task = None
+        self.local_tasks: list[asyncio.Task] = []
 
         self.sub_in = None
         self.sub_out = None
@@ -53,6 +59,39 @@ class BaseWorker:
         except Exception as e:
             self.logger.error(f"Failed to report state: {e}")
 
+    def local_task_id(self) -> str:
+        """Mint a synthetic task ID for a locally generated measurement"""
+        return f"{LOCAL_TASK_ID_PREFIX}{self.agent.agent_id}-{self.name}-{uuid.uuid4().hex}"
+
+    async def publish_local_result(self, result: dict[str, Any]):
+        """Publish a locally generated measurement so the server stores it under this module"""
+        request_id = self.local_task_id()
+        payload = {**result, "id": request_id, "module": self.name, "origin": "local"}
+        subject = self.sub_out or f"agent.{self.agent.agent_id}.out"
+        await self.nc.publish(subject, json.dumps(payload).encode("utf-8"))
+        return request_id
+
+    def schedule_local(self, interval: float, measure: Callable[[], Awaitable[dict[str, Any]]]):
+        """
+        Run `measure` every `interval` seconds on the agent (agent-local cron)
+        and report each result with a synthetic task ID.
+        """
+        async def _loop():
+            while True:
+                try:
+                    result = await measure()
+                    request_id = await self.publish_local_result(result)
+                    self.logger.debug(f"{self.name}: Published local measurement {request_id}")
+                except asyncio.CancelledError:
+                    raise
+                except Exception as e:
+                    self.logger.error(f"{self.name}: Local measurement failed: {e}")
+                await asyncio.sleep(interval)
+
+        task = asyncio.create_task(_loop())
+        self.local_tasks.append(task)
+        return task
+
     def serializer(self, ) -> Optional[Type["MeasurementQuery"]]:
         # raise NotImplementedError("Worker must implement serializer()")
         return None
@@ -80,6 +119,9 @@ class BaseWorker:
         asyncio.create_task(self._report_state("running"))
 
     async def stop(self, msg="Exclusive stop", timeout=20):
+        for local_task in self.local_tasks:
+            local_task.cancel(msg=msg)
+        self.local_tasks = []
         self.task.cancel(msg=msg)
         ct = time.perf_counter() + timeout
         while self.task and not self.task.done():
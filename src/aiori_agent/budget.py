@@ -0,0 +1,63 @@
+import time
+import asyncio
+from collections import deque
+
+from aiori_agent.config import settings
+
+
+class BudgetExceeded(Exception):
+    """Raised when a measurement would exceed the agent's probing limits"""
+
+
+class ProbeGovernor:
+    """
+    Gates active probing of all modules on an agent: a token bucket caps the
+    probe rate and a rolling window caps the bytes sent. High-rate modules
+    reserve their traffic up front and pace every probe through `acquire`.
+    """
+
+    def __init__(self, rate_cap: float, budget_bytes: int, budget_window: float):
+        self.rate_cap = rate_cap
+        self.budget_bytes = budget_bytes
+        self.budget_window = budget_window
+
+        self._tokens = rate_cap
+        self._refilled = time.monotonic()
+        self._reservations: deque[tuple[float, int]] = deque()
+        self._lock = asyncio.Lock()
+
+    def _expire(self, now: float):
+        while self._reservations and now - self._reservations[0][0] > self.budget_window:
+            self._reservations.popleft()
+
+    def remaining_bytes(self) -> int:
+        """Bytes still available in the current budget window"""
+        self._expire(time.monotonic())
+        return self.budget_bytes - sum(n for _, n in self._reservations)
+
+    def reserve(self, rate: float, total_bytes: int):
+        """
+        Reserve a measurement's traffic, refusing it if its rate exceeds the
+        cap or its bytes exceed what is left of the budget.
+        """
+        if rate > self.rate_cap:
+            raise BudgetExceeded(f"rate {rate:g}/s exceeds the probe rate cap of {self.rate_cap:g}/s")
+        remaining = self.remaining_bytes()
+        if total_bytes > remaining:
+            raise BudgetExceeded(f"{total_bytes} bytes exceed the remaining probe budget of {remaining} bytes")
+        self._reservations.append((time.monotonic(), total_bytes))
+
+    async def acquire(self):
+        """Wait until the rate cap allows one more probe"""
+        async with self._lock:
+            while True:
+                now = time.monotonic()
+                self._tokens = min(self.rate_cap, self._tokens + (now - self._refilled) * self.rate_cap)
+                self._refilled = now
+                if self._tokens >= 1:
+                    self._tokens -= 1
+                    return
+                await asyncio.sleep((1 - self._tokens) / self.rate_cap)
+
+
+governor = ProbeGovernor(settings.probe_rate_cap, settings.probe_budget_bytes, settings.probe_budget_window)
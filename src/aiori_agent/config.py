@@ -14,6 +14,14 @@ class Settings(BaseSettings, cli_parse_args=True, cli_exit_on_error=False):
     message_log_dir: Path = Field(default=Path(".messages"))
     crash_state_file: Path = Field(default=Path(".errors/crash_state.json"))
     max_crash_retries: int = 3
+
+    # Seconds between locally scheduled NTP clock offset measurements; 0 disables them
+    ntp_interval: float = Field(default=0, env="NTP_INTERVAL")
+
+    # Agent-wide caps on active probing shared by all modules
+    probe_rate_cap: float = Field(default=200, env="PROBE_RATE_CAP")  # probes per second
+    probe_budget_bytes: int = Field(default=50_000_000, env="PROBE_BUDGET_BYTES")  # bytes per window
+    probe_budget_window: float = Field(default=3600, env="PROBE_BUDGET_WINDOW")  # seconds
     
     # OTel configuration
     otlp_trace_endpoint: str = Field(default="otel-collector:4317", env="OTLP_TRACE_ENDPOINT")